@@ -11,6 +11,7 @@ import (
 	"github.com/ix-pay/ixpay-pro/internal/app"
 	"github.com/ix-pay/ixpay-pro/internal/config"
 	"github.com/ix-pay/ixpay-pro/internal/infrastructure/observability/logger"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/observability/redact"
 
 	// 导入PostgreSQL驱动，即使我们不直接使用它
 	_ "github.com/lib/pq"
@@ -38,8 +39,14 @@ func main() {
 		log.Fatalf("加载配置失败: %v", err)
 	}
 
+	// 初始化日志脱敏引擎
+	redactor, err := redact.SetupRedactor(cfg)
+	if err != nil {
+		log.Fatalf("日志脱敏配置无效: %v", err)
+	}
+
 	// 初始化日志记录器
-	appLoggerManager := logger.SetupMultiLogger(cfg)
+	appLoggerManager := logger.SetupMultiLogger(cfg, redactor)
 	logger.SetGlobalMultiLogger(appLoggerManager)
 	appLogger := appLoggerManager.GetLogger(logger.DefaultLogger)
 
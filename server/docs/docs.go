@@ -48,12 +48,7 @@ const docTemplate = `{
         },
         "/api//payment": {
             "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "获取当前登录用户的所有支付记录",
+                "description": "分页查询支付记录，按当前用户的数据权限过滤，仅本人权限只返回自己的支付记录",
                 "consumes": [
                     "application/json"
                 ],
@@ -63,13 +58,43 @@ const docTemplate = `{
                 "tags": [
                     "支付管理"
                 ],
-                "summary": "获取用户支付列表",
+                "summary": "获取支付列表",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每页数量",
+                        "name": "pageSize",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "支付状态",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "支付列表及分页信息",
+                        "schema": {
+                            "$ref": "#/definitions/baseRes.PageResult"
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
                         "schema": {
                             "type": "object",
-                            "additionalProperties": true
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "401": {
@@ -80,17 +105,26 @@ const docTemplate = `{
                                 "type": "string"
                             }
                         }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
                     }
-                }
-            }
-        },
-        "/api//payment/{id}": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
+                ]
+            }
+        },
+        "/api//payment/{id}": {
+            "get": {
                 "description": "根据 ID 查询支付详情",
                 "consumes": [
                     "application/json"
@@ -157,16 +191,16 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
-            }
-        },
-        "/api//payment/{id}/cancel": {
-            "put": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
+                ]
+            }
+        },
+        "/api//payment/{id}/cancel": {
+            "put": {
                 "description": "根据 ID 取消一笔支付",
                 "consumes": [
                     "application/json"
@@ -233,16 +267,16 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
-            }
-        },
-        "/api/admin/apis": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
+                ]
+            }
+        },
+        "/api/admin/apis": {
+            "get": {
                 "description": "获取系统中注册的所有 API 路由信息（管理员权限），支持分页和搜索",
                 "consumes": [
                     "application/json"
@@ -278,6 +312,12 @@ const docTemplate = `{
                         "description": "路由分组",
                         "name": "group",
                         "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "是否已失效",
+                        "name": "orphaned",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -353,14 +393,14 @@ const docTemplate = `{
                             ]
                         }
                     }
-                }
-            },
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
+                ]
+            },
+            "post": {
                 "description": "创建新的 API 路由信息（管理员权限）",
                 "consumes": [
                     "application/json"
@@ -474,16 +514,16 @@ const docTemplate = `{
                             ]
                         }
                     }
-                }
-            }
-        },
-        "/api/admin/apis/:id": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
+                ]
+            }
+        },
+        "/api/admin/apis/:id": {
+            "get": {
                 "description": "根据 ID 获取指定的 API 路由信息（管理员权限）",
                 "consumes": [
                     "application/json"
@@ -595,14 +635,14 @@ const docTemplate = `{
                             ]
                         }
                     }
-                }
-            },
-            "put": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
+                ]
+            },
+            "put": {
                 "description": "更新指定的 API 路由信息（管理员权限）",
                 "consumes": [
                     "application/json"
@@ -741,14 +781,14 @@ const docTemplate = `{
                             ]
                         }
                     }
-                }
-            },
-            "delete": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
+                ]
+            },
+            "delete": {
                 "description": "删除指定的 API 路由信息（管理员权限）",
                 "consumes": [
                     "application/json"
@@ -878,16 +918,16 @@ const docTemplate = `{
                             ]
                         }
                     }
-                }
-            }
-        },
-        "/api/admin/apis/list": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
+                ]
+            }
+        },
+        "/api/admin/apis/list": {
+            "get": {
                 "description": "分页获取 API 路由列表，支持过滤（管理员权限）",
                 "consumes": [
                     "application/json"
@@ -923,6 +963,12 @@ const docTemplate = `{
                         "description": "是否需要认证",
                         "name": "authRequired",
                         "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "是否已失效",
+                        "name": "orphaned",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -1001,12 +1047,114 @@ const docTemplate = `{
                             ]
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/admin/apis/sync-report": {
+            "get": {
+                "description": "获取启动时路由同步的结果（管理员权限）：新登记的路由、重新出现的路由、已失效的路由，以及需要授权但尚未分配给任何角色的路由\n失效路由只做标记不删除，确认不再使用后由管理员删除",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "API 路由管理"
+                ],
+                "summary": "获取 API 路由同步报告",
+                "responses": {
+                    "200": {
+                        "description": "同步报告",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/baseRes.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.APISyncReportResponse"
+                                        },
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/baseRes.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "无权限",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/baseRes.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/baseRes.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
         "/api/admin/auth/captcha": {
             "post": {
-                "description": "获取登录验证码",
+                "description": "按场景策略获取验证码，当前不需要验证码时 openCaptcha 为 false 且不生成验证码",
                 "consumes": [
                     "application/json"
                 ],
@@ -1017,6 +1165,16 @@ const docTemplate = `{
                     "认证服务"
                 ],
                 "summary": "获取验证码",
+                "parameters": [
+                    {
+                        "description": "验证码场景，默认 login",
+                        "name": "captcha",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/request.CaptchaRequest"
+                        }
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "验证码信息",
@@ -1118,14 +1276,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/admin/auth/logout": {
+        "/api/admin/auth/login/verify": {
             "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "用户登出系统",
+                "description": "登录命中新设备、新地点或异地登录且需要二次验证时，提交收到的验证码完成登录；须在发起登录的设备上提交",
                 "consumes": [
                     "application/json"
                 ],
@@ -1135,10 +1288,21 @@ const docTemplate = `{
                 "tags": [
                     "认证服务"
                 ],
-                "summary": "用户登出",
+                "summary": "登录二次验证",
+                "parameters": [
+                    {
+                        "description": "二次验证参数",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.LoginStepUpRequest"
+                        }
+                    }
+                ],
                 "responses": {
                     "200": {
-                        "description": "登出成功",
+                        "description": "登录成功",
                         "schema": {
                             "allOf": [
                                 {
@@ -1147,6 +1311,9 @@ const docTemplate = `{
                                 {
                                     "type": "object",
                                     "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.LoginResponse"
+                                        },
                                         "msg": {
                                             "type": "string"
                                         }
@@ -1155,17 +1322,8 @@ const docTemplate = `{
                             ]
                         }
                     },
-                    "401": {
-                        "description": "未授权",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
-                    "500": {
-                        "description": "服务器内部错误",
+                    "400": {
+                        "description": "验证码错误或已过期",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1176,14 +1334,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/admin/auth/refresh-token": {
+        "/api/admin/auth/logout": {
             "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "使用刷新令牌获取新的访问令牌",
+                "description": "退出当前设备的登录，其他设备不受影响",
                 "consumes": [
                     "application/json"
                 ],
@@ -1193,10 +1346,10 @@ const docTemplate = `{
                 "tags": [
                     "认证服务"
                 ],
-                "summary": "刷新令牌",
+                "summary": "用户登出",
                 "responses": {
                     "200": {
-                        "description": "刷新成功",
+                        "description": "登出成功",
                         "schema": {
                             "allOf": [
                                 {
@@ -1205,9 +1358,6 @@ const docTemplate = `{
                                 {
                                     "type": "object",
                                     "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/response.LoginResponse"
-                                        },
                                         "msg": {
                                             "type": "string"
                                         }
@@ -1216,8 +1366,8 @@ const docTemplate = `{
                             ]
                         }
                     },
-                    "400": {
-                        "description": "请求参数错误",
+                    "401": {
+                        "description": "未授权",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1225,8 +1375,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "401": {
-                        "description": "刷新令牌无效",
+                    "500": {
+                        "description": "服务器内部错误",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1234,12 +1384,58 @@ const docTemplate = `{
                             }
                         }
                     }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/admin/auth/oidc/providers": {
+            "get": {
+                "description": "获取已启用的 OIDC 身份提供方，用于登录页展示",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "认证服务"
+                ],
+                "summary": "获取单点登录方式",
+                "responses": {
+                    "200": {
+                        "description": "身份提供方列表",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/baseRes.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/response.OIDCLoginProviderResponse"
+                                            }
+                                        },
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
                 }
             }
         },
-        "/api/admin/auth/register": {
-            "post": {
-                "description": "创建新用户账户",
+        "/api/admin/auth/oidc/{code}/authorize": {
+            "get": {
+                "description": "生成 state、nonce 和 PKCE 参数，返回身份提供方授权地址，前端跳转后在回调页调用回调接口",
                 "consumes": [
                     "application/json"
                 ],
@@ -1247,23 +1443,21 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "基础服务"
+                    "认证服务"
                 ],
-                "summary": "用户注册",
+                "summary": "发起单点登录",
                 "parameters": [
                     {
-                        "description": "注册请求参数",
-                        "name": "register",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.RegisterRequest"
-                        }
+                        "type": "string",
+                        "description": "身份提供方编码",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "注册成功",
+                    "200": {
+                        "description": "授权地址",
                         "schema": {
                             "allOf": [
                                 {
@@ -1273,7 +1467,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/entity.User"
+                                            "$ref": "#/definitions/response.OIDCAuthorizeResponse"
                                         },
                                         "msg": {
                                             "type": "string"
@@ -1295,14 +1489,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/admin/btn-perms": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "获取按钮权限列表",
+        "/api/admin/auth/oidc/{code}/callback": {
+            "post": {
+                "description": "使用授权码换取并校验 ID Token，关联本地用户后签发与账号密码登录相同的令牌",
                 "consumes": [
                     "application/json"
                 ],
@@ -1310,53 +1499,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "按钮权限管理"
+                    "认证服务"
                 ],
-                "summary": "获取按钮权限列表",
+                "summary": "单点登录回调",
                 "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "页码",
-                        "name": "page",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "每页数量",
-                        "name": "pageSize",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "format": "int64",
-                        "description": "菜单 ID",
-                        "name": "menu_id",
-                        "in": "query"
-                    },
                     {
                         "type": "string",
-                        "description": "按钮编码",
+                        "description": "身份提供方编码",
                         "name": "code",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "按钮名称",
-                        "name": "name",
-                        "in": "query"
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "状态(-1:全部,0:禁用,1:启用)",
-                        "name": "status",
-                        "in": "query"
+                        "description": "回调参数",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.OIDCCallbackRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "登录成功",
                         "schema": {
                             "allOf": [
                                 {
@@ -1366,47 +1532,31 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "allOf": [
-                                                {
-                                                    "$ref": "#/definitions/baseRes.PageResult"
-                                                },
-                                                {
-                                                    "type": "object",
-                                                    "properties": {
-                                                        "list": {
-                                                            "type": "array",
-                                                            "items": {
-                                                                "$ref": "#/definitions/entity.BtnPerm"
-                                                            }
-                                                        },
-                                                        "page": {
-                                                            "type": "integer"
-                                                        },
-                                                        "pageSize": {
-                                                            "type": "integer"
-                                                        },
-                                                        "total": {
-                                                            "type": "integer",
-                                                            "format": "int64"
-                                                        }
-                                                    }
-                                                }
-                                            ]
+                                            "$ref": "#/definitions/response.LoginResponse"
+                                        },
+                                        "msg": {
+                                            "type": "string"
                                         }
                                     }
                                 }
                             ]
                         }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
                     }
                 }
-            },
-            "put": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "更新按钮权限信息",
+            }
+        },
+        "/api/admin/auth/password/forgot": {
+            "post": {
+                "description": "根据用户名、邮箱或手机号向绑定的邮箱/手机发送找回密码验证码",
                 "consumes": [
                     "application/json"
                 ],
@@ -1414,36 +1564,57 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "按钮权限管理"
+                    "认证服务"
                 ],
-                "summary": "更新按钮权限",
+                "summary": "发送找回密码验证码",
                 "parameters": [
                     {
-                        "description": "按钮权限信息",
-                        "name": "data",
+                        "description": "找回密码请求参数",
+                        "name": "forgot",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/request.UpdateBtnPermRequest"
+                            "$ref": "#/definitions/request.ForgotPasswordRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "发送成功",
                         "schema": {
-                            "$ref": "#/definitions/baseRes.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/baseRes.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.ForgotPasswordResponse"
+                                        },
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
-            },
+            }
+        },
+        "/api/admin/auth/password/reset": {
             "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "创建新的按钮权限",
+                "description": "校验找回密码验证码并设置新密码，成功后已登录的会话需要重新登录",
                 "consumes": [
                     "application/json"
                 ],
@@ -1451,36 +1622,115 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "按钮权限管理"
+                    "认证服务"
                 ],
-                "summary": "创建按钮权限",
+                "summary": "找回密码",
                 "parameters": [
                     {
-                        "description": "按钮权限信息",
-                        "name": "data",
+                        "description": "设置新密码请求参数",
+                        "name": "reset",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/request.CreateBtnPermRequest"
+                            "$ref": "#/definitions/request.ForgotPasswordResetRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "密码重置成功",
                         "schema": {
-                            "$ref": "#/definitions/baseRes.Response"
-                        }
-                    }
-                }
-            },
-            "delete": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/baseRes.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/auth/refresh-token": {
+            "post": {
+                "description": "使用刷新令牌获取新的访问令牌",
+                "consumes": [
+                    "application/json"
                 ],
-                "description": "删除按钮权限",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "认证服务"
+                ],
+                "summary": "刷新令牌",
+                "responses": {
+                    "200": {
+                        "description": "刷新成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/baseRes.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.LoginResponse"
+                                        },
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "刷新令牌无效",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/admin/auth/register": {
+            "post": {
+                "description": "创建新用户账户",
                 "consumes": [
                     "application/json"
                 ],
@@ -1488,38 +1738,57 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "按钮权限管理"
+                    "基础服务"
                 ],
-                "summary": "删除按钮权限",
+                "summary": "用户注册",
                 "parameters": [
                     {
-                        "description": "按钮权限ID",
-                        "name": "data",
+                        "description": "注册请求参数",
+                        "name": "register",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/request.DeleteBtnPermRequest"
+                            "$ref": "#/definitions/request.RegisterRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "注册成功",
                         "schema": {
-                            "$ref": "#/definitions/baseRes.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/baseRes.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/entity.User"
+                                        },
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
             }
         },
-        "/api/admin/btn-perms/api-routes": {
+        "/api/admin/btn-perms": {
             "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "获取按钮权限关联的 API 路由",
+                "description": "获取按钮权限列表",
                 "consumes": [
                     "application/json"
                 ],
@@ -1529,15 +1798,46 @@ const docTemplate = `{
                 "tags": [
                     "按钮权限管理"
                 ],
-                "summary": "获取按钮权限关联的 API 路由",
+                "summary": "获取按钮权限列表",
                 "parameters": [
                     {
                         "type": "integer",
-                        "format": "int64",
-                        "description": "按钮权限 ID",
-                        "name": "btnPermId",
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每页数量",
+                        "name": "pageSize",
                         "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "菜单 ID",
+                        "name": "menu_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "按钮编码",
+                        "name": "code",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "按钮名称",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "状态(-1:全部,0:禁用,1:启用)",
+                        "name": "status",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -1552,27 +1852,47 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/entity.API"
-                                            }
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/baseRes.PageResult"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "list": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/entity.BtnPerm"
+                                                            }
+                                                        },
+                                                        "page": {
+                                                            "type": "integer"
+                                                        },
+                                                        "pageSize": {
+                                                            "type": "integer"
+                                                        },
+                                                        "total": {
+                                                            "type": "integer",
+                                                            "format": "int64"
+                                                        }
+                                                    }
+                                                }
+                                            ]
                                         }
                                     }
                                 }
                             ]
                         }
                     }
-                }
-            }
-        },
-        "/api/admin/btn-perms/assign-api-routes": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "为按钮权限分配 API 路由",
+                ]
+            },
+            "put": {
+                "description": "更新按钮权限信息",
                 "consumes": [
                     "application/json"
                 ],
@@ -1582,15 +1902,15 @@ const docTemplate = `{
                 "tags": [
                     "按钮权限管理"
                 ],
-                "summary": "为按钮权限分配 API 路由",
+                "summary": "更新按钮权限",
                 "parameters": [
                     {
-                        "description": "按钮 ID 和 API 路由 ID 列表",
+                        "description": "按钮权限信息",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/request.AssignToBtnPermRequest"
+                            "$ref": "#/definitions/request.UpdateBtnPermRequest"
                         }
                     }
                 ],
@@ -1601,17 +1921,15 @@ const docTemplate = `{
                             "$ref": "#/definitions/baseRes.Response"
                         }
                     }
-                }
-            }
-        },
-        "/api/admin/btn-perms/assign-to-role": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "为角色分配按钮权限",
+                ]
+            },
+            "post": {
+                "description": "创建新的按钮权限",
                 "consumes": [
                     "application/json"
                 ],
@@ -1621,15 +1939,15 @@ const docTemplate = `{
                 "tags": [
                     "按钮权限管理"
                 ],
-                "summary": "为角色分配按钮权限",
+                "summary": "创建按钮权限",
                 "parameters": [
                     {
-                        "description": "角色 ID 和按钮权限 ID 列表",
+                        "description": "按钮权限信息",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/request.AssignBtnPermToRoleRequest"
+                            "$ref": "#/definitions/request.CreateBtnPermRequest"
                         }
                     }
                 ],
@@ -1640,17 +1958,15 @@ const docTemplate = `{
                             "$ref": "#/definitions/baseRes.Response"
                         }
                     }
-                }
-            }
-        },
-        "/api/admin/btn-perms/by-menu": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "获取菜单下的所有按钮权限",
+                ]
+            },
+            "delete": {
+                "description": "删除按钮权限",
                 "consumes": [
                     "application/json"
                 ],
@@ -1660,13 +1976,52 @@ const docTemplate = `{
                 "tags": [
                     "按钮权限管理"
                 ],
-                "summary": "获取菜单下的按钮权限",
+                "summary": "删除按钮权限",
                 "parameters": [
                     {
-                        "type": "integer",
+                        "description": "按钮权限ID",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.DeleteBtnPermRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/baseRes.Response"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/admin/btn-perms/api-routes": {
+            "get": {
+                "description": "获取按钮权限关联的 API 路由",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "按钮权限管理"
+                ],
+                "summary": "获取按钮权限关联的 API 路由",
+                "parameters": [
+                    {
+                        "type": "integer",
                         "format": "int64",
-                        "description": "菜单 ID",
-                        "name": "menuId",
+                        "description": "按钮权限 ID",
+                        "name": "btnPermId",
                         "in": "query",
                         "required": true
                     }
@@ -1685,7 +2040,7 @@ const docTemplate = `{
                                         "data": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/entity.BtnPerm"
+                                                "$ref": "#/definitions/entity.API"
                                             }
                                         }
                                     }
@@ -1693,16 +2048,147 @@ const docTemplate = `{
                             ]
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
-        "/api/admin/btn-perms/by-role": {
-            "get": {
+        "/api/admin/btn-perms/assign-api-routes": {
+            "post": {
+                "description": "为按钮权限分配 API 路由",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "按钮权限管理"
+                ],
+                "summary": "为按钮权限分配 API 路由",
+                "parameters": [
+                    {
+                        "description": "按钮 ID 和 API 路由 ID 列表",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.AssignToBtnPermRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/baseRes.Response"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/admin/btn-perms/assign-to-role": {
+            "post": {
+                "description": "为角色分配按钮权限",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "按钮权限管理"
+                ],
+                "summary": "为角色分配按钮权限",
+                "parameters": [
+                    {
+                        "description": "角色 ID 和按钮权限 ID 列表",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.AssignBtnPermToRoleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/baseRes.Response"
+                        }
+                    }
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
+                ]
+            }
+        },
+        "/api/admin/btn-perms/by-menu": {
+            "get": {
+                "description": "获取菜单下的所有按钮权限",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "按钮权限管理"
+                ],
+                "summary": "获取菜单下的按钮权限",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "菜单 ID",
+                        "name": "menuId",
+                        "in": "query",
+                        "required": true
+                    }
                 ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/baseRes.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/entity.BtnPerm"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/admin/btn-perms/by-role": {
+            "get": {
                 "description": "获取角色拥有的按钮权限",
                 "consumes": [
                     "application/json"
@@ -1746,16 +2232,16 @@ const docTemplate = `{
                             ]
                         }
                     }
-                }
-            }
-        },
-        "/api/admin/btn-perms/detail": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
+                ]
+            }
+        },
+        "/api/admin/btn-perms/detail": {
+            "get": {
                 "description": "根据ID获取按钮权限详情",
                 "consumes": [
                     "application/json"
@@ -1795,16 +2281,16 @@ const docTemplate = `{
                             ]
                         }
                     }
-                }
-            }
-        },
-        "/api/admin/btn-perms/for-route": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
+                ]
+            }
+        },
+        "/api/admin/btn-perms/for-route": {
+            "get": {
                 "description": "获取 API 路由关联的按钮权限",
                 "consumes": [
                     "application/json"
@@ -1848,16 +2334,16 @@ const docTemplate = `{
                             ]
                         }
                     }
-                }
-            }
-        },
-        "/api/admin/btn-perms/revoke-api-route": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
+                ]
+            }
+        },
+        "/api/admin/btn-perms/revoke-api-route": {
+            "post": {
                 "description": "从按钮权限撤销API路由",
                 "consumes": [
                     "application/json"
@@ -1887,16 +2373,16 @@ const docTemplate = `{
                             "$ref": "#/definitions/baseRes.Response"
                         }
                     }
-                }
-            }
-        },
-        "/api/admin/btn-perms/revoke-from-role": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
+                ]
+            }
+        },
+        "/api/admin/btn-perms/revoke-from-role": {
+            "post": {
                 "description": "从角色撤销按钮权限",
                 "consumes": [
                     "application/json"
@@ -1926,16 +2412,16 @@ const docTemplate = `{
                             "$ref": "#/definitions/baseRes.Response"
                         }
                     }
-                }
-            }
-        },
-        "/api/admin/config": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
+                ]
+            }
+        },
+        "/api/admin/config": {
+            "get": {
                 "description": "获取系统配置列表",
                 "consumes": [
                     "application/json"
@@ -2013,14 +2499,14 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
-            },
-            "put": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
+                ]
+            },
+            "put": {
                 "description": "更新系统配置",
                 "consumes": [
                     "application/json"
@@ -2089,14 +2575,14 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
-            },
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
+                ]
+            },
+            "post": {
                 "description": "创建新的系统配置",
                 "consumes": [
                     "application/json"
@@ -2168,18 +2654,18 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
-            }
-        },
-        "/api/admin/config/:id": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "根据ID获取配置详情",
-                "consumes": [
+                ]
+            }
+        },
+        "/api/admin/config/:id": {
+            "get": {
+                "description": "根据ID获取配置详情",
+                "consumes": [
                     "application/json"
                 ],
                 "produces": [
@@ -2247,14 +2733,14 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
-            },
-            "delete": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
+                ]
+            },
+            "delete": {
                 "description": "删除系统配置",
                 "consumes": [
                     "application/json"
@@ -2321,7 +2807,12 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
         "/api/admin/config/active": {
@@ -2376,11 +2867,6 @@ const docTemplate = `{
         },
         "/api/admin/config/key": {
             "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
                 "description": "根据配置键获取配置详情",
                 "consumes": [
                     "application/json"
@@ -2450,16 +2936,16 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
-            }
-        },
-        "/api/admin/dept": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
+                ]
+            }
+        },
+        "/api/admin/dept": {
+            "get": {
                 "description": "获取部门列表（支持分页和筛选）",
                 "consumes": [
                     "application/json"
@@ -2539,14 +3025,14 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
-            },
-            "put": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
+                ]
+            },
+            "put": {
                 "description": "更新部门信息",
                 "consumes": [
                     "application/json"
@@ -2618,14 +3104,14 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
-            },
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
+                ]
+            },
+            "post": {
                 "description": "创建新的部门",
                 "consumes": [
                     "application/json"
@@ -2697,16 +3183,16 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
-            }
-        },
-        "/api/admin/dept/:id": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
+                ]
+            }
+        },
+        "/api/admin/dept/:id": {
+            "get": {
                 "description": "根据 ID 获取部门详细信息",
                 "consumes": [
                     "application/json"
@@ -2776,14 +3262,14 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
-            },
-            "delete": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
+                ]
+            },
+            "delete": {
                 "description": "删除部门（管理员权限）",
                 "consumes": [
                     "application/json"
@@ -2850,16 +3336,16 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
-            }
-        },
-        "/api/admin/dept/:id/leader": {
-            "put": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
+                ]
+            }
+        },
+        "/api/admin/dept/:id/leader": {
+            "put": {
                 "description": "更新部门负责人",
                 "consumes": [
                     "application/json"
@@ -2935,16 +3421,16 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
-            }
-        },
-        "/api/admin/dept/tree": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
+                ]
+            }
+        },
+        "/api/admin/dept/tree": {
+            "get": {
                 "description": "获取完整的部门树形结构数据",
                 "consumes": [
                     "application/json"
@@ -2999,16 +3485,16 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
-            }
-        },
-        "/api/admin/dict": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
+                ]
+            }
+        },
+        "/api/admin/dict": {
+            "get": {
                 "description": "获取字典列表",
                 "consumes": [
                     "application/json"
@@ -3092,14 +3578,14 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
-            },
-            "put": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
+                ]
+            },
+            "put": {
                 "description": "更新字典信息",
                 "consumes": [
                     "application/json"
@@ -3168,14 +3654,14 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
-            },
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
+                ]
+            },
+            "post": {
                 "description": "创建新的字典",
                 "consumes": [
                     "application/json"
@@ -3247,16 +3733,16 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
-            }
-        },
-        "/api/admin/dict/:id": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
+                ]
+            }
+        },
+        "/api/admin/dict/:id": {
+            "get": {
                 "description": "根据ID获取字典详情",
                 "consumes": [
                     "application/json"
@@ -3326,14 +3812,14 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
-            },
-            "delete": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
+                ]
+            },
+            "delete": {
                 "description": "删除字典（会同时删除字典下的所有字典项）",
                 "consumes": [
                     "application/json"
@@ -3400,16 +3886,16 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
-            }
-        },
-        "/api/admin/dict/code": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
+                ]
+            }
+        },
+        "/api/admin/dict/code": {
+            "get": {
                 "description": "根据编码获取字典详情",
                 "consumes": [
                     "application/json"
@@ -3479,16 +3965,16 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
-            }
-        },
-        "/api/admin/dict/item": {
-            "put": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
+                ]
+            }
+        },
+        "/api/admin/dict/item": {
+            "put": {
                 "description": "更新字典项信息",
                 "consumes": [
                     "application/json"
@@ -3557,14 +4043,14 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
-            },
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
+                ]
+            },
+            "post": {
                 "description": "创建新的字典项",
                 "consumes": [
                     "application/json"
@@ -3636,16 +4122,16 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
-            }
-        },
-        "/api/admin/dict/item/:id": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
+                ]
+            }
+        },
+        "/api/admin/dict/item/:id": {
+            "get": {
                 "description": "根据ID获取字典项详情",
                 "consumes": [
                     "application/json"
@@ -3715,14 +4201,14 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
-            },
-            "delete": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
+                ]
+            },
+            "delete": {
                 "description": "删除字典项",
                 "consumes": [
                     "application/json"
@@ -3789,16 +4275,16 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
-            }
-        },
-        "/api/admin/dict/items": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
+                ]
+            }
+        },
+        "/api/admin/dict/items": {
+            "get": {
                 "description": "根据字典ID获取该字典下的所有字典项",
                 "consumes": [
                     "application/json"
@@ -3868,17 +4354,17 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
-            }
-        },
-        "/api/admin/jwt/jsonInBlacklist": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "将 JWT token 加入黑名单，使其立即失效",
+                ]
+            }
+        },
+        "/api/admin/ip-policies": {
+            "get": {
+                "description": "分页获取 IP 访问策略列表",
                 "consumes": [
                     "application/json"
                 ],
@@ -3886,23 +4372,46 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "认证服务"
+                    "IP 访问策略"
                 ],
-                "summary": "将 JWT token 加入黑名单",
+                "summary": "获取 IP 访问策略列表",
                 "parameters": [
                     {
-                        "description": "JWT token",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.JsonInBlacklistRequest"
-                        }
+                        "type": "integer",
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每页数量",
+                        "name": "pageSize",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "作用范围 (global、role、user)",
+                        "name": "scope",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "动作 (allow、deny)",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "状态 (0:禁用，1:启用)",
+                        "name": "status",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "加入黑名单成功",
+                        "description": "策略列表",
                         "schema": {
                             "allOf": [
                                 {
@@ -3911,6 +4420,9 @@ const docTemplate = `{
                                 {
                                     "type": "object",
                                     "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.IPPolicyListResponse"
+                                        },
                                         "msg": {
                                             "type": "string"
                                         }
@@ -3936,27 +4448,16 @@ const docTemplate = `{
                                 "type": "string"
                             }
                         }
-                    },
-                    "500": {
-                        "description": "服务器内部错误",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
                     }
-                }
-            }
-        },
-        "/api/admin/login-log": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "获取登录日志列表（支持分页和筛选）",
+                ]
+            },
+            "post": {
+                "description": "创建全局、角色或用户范围的 IP 白名单或黑名单策略，单个 IP 会转换为 /32 或 /128",
                 "consumes": [
                     "application/json"
                 ],
@@ -3964,65 +4465,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "登录日志管理"
+                    "IP 访问策略"
                 ],
-                "summary": "获取登录日志列表",
+                "summary": "创建 IP 访问策略",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "页码",
-                        "name": "page",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "每页数量",
-                        "name": "page_size",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "format": "int64",
-                        "description": "用户 ID",
-                        "name": "user_id",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "用户名",
-                        "name": "userName",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "登录 IP",
-                        "name": "login_ip",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "登录结果：0-失败，1-成功",
-                        "name": "result",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "开始日期（YYYY-MM-DD）",
-                        "name": "start_date",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "结束日期（YYYY-MM-DD）",
-                        "name": "end_date",
-                        "in": "query"
+                        "description": "策略信息",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CreateIPPolicyRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "登录日志列表",
+                        "description": "创建成功",
                         "schema": {
                             "allOf": [
                                 {
@@ -4032,22 +4491,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "allOf": [
-                                                {
-                                                    "$ref": "#/definitions/baseRes.PageResult"
-                                                },
-                                                {
-                                                    "type": "object",
-                                                    "properties": {
-                                                        "list": {
-                                                            "type": "array",
-                                                            "items": {
-                                                                "$ref": "#/definitions/response.LoginLogListDTO"
-                                                            }
-                                                        }
-                                                    }
-                                                }
-                                            ]
+                                            "$ref": "#/definitions/response.IPPolicyResponse"
                                         },
                                         "msg": {
                                             "type": "string"
@@ -4074,25 +4518,18 @@ const docTemplate = `{
                                 "type": "string"
                             }
                         }
-                    },
-                    "500": {
-                        "description": "服务器内部错误",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
                     }
-                }
-            },
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "记录用户登录日志（仅供内部调用）",
+                ]
+            }
+        },
+        "/api/admin/ip-policies/block-abnormal": {
+            "post": {
+                "description": "将最近 1 小时失败登录次数达到阈值的 IP 加入全局黑名单策略「异常登录 IP 黑名单」",
                 "consumes": [
                     "application/json"
                 ],
@@ -4100,23 +4537,22 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "登录日志管理"
+                    "IP 访问策略"
                 ],
-                "summary": "记录登录日志（内部）",
+                "summary": "封禁异常登录 IP",
                 "parameters": [
                     {
-                        "description": "登录日志信息",
+                        "description": "封禁参数",
                         "name": "data",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/request.RecordLoginRequest"
+                            "$ref": "#/definitions/request.BlockAbnormalIPsRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "记录成功",
+                        "description": "封禁结果",
                         "schema": {
                             "allOf": [
                                 {
@@ -4125,6 +4561,9 @@ const docTemplate = `{
                                 {
                                     "type": "object",
                                     "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.BlockAbnormalIPsResponse"
+                                        },
                                         "msg": {
                                             "type": "string"
                                         }
@@ -4150,27 +4589,18 @@ const docTemplate = `{
                                 "type": "string"
                             }
                         }
-                    },
-                    "500": {
-                        "description": "服务器内部错误",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
                     }
-                }
-            }
-        },
-        "/api/admin/login-log/:id": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "根据 ID 获取登录日志详细信息",
+                ]
+            }
+        },
+        "/api/admin/ip-policies/{id}": {
+            "get": {
+                "description": "根据 ID 获取 IP 访问策略详情",
                 "consumes": [
                     "application/json"
                 ],
@@ -4178,13 +4608,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "登录日志管理"
+                    "IP 访问策略"
                 ],
-                "summary": "获取登录日志详情",
+                "summary": "获取 IP 访问策略详情",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "登录日志 ID",
+                        "description": "策略 ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -4192,7 +4622,7 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "登录日志详情",
+                        "description": "策略详情",
                         "schema": {
                             "allOf": [
                                 {
@@ -4202,7 +4632,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/response.LoginLogDetailDTO"
+                                            "$ref": "#/definitions/response.IPPolicyResponse"
                                         },
                                         "msg": {
                                             "type": "string"
@@ -4229,27 +4659,16 @@ const docTemplate = `{
                                 "type": "string"
                             }
                         }
-                    },
-                    "500": {
-                        "description": "服务器内部错误",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
                     }
-                }
-            }
-        },
-        "/api/admin/login-log/abnormal": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "获取异常登录记录（同一 IP 多次失败、异地登录等）",
+                ]
+            },
+            "put": {
+                "description": "更新 IP 访问策略，保存后立即生效",
                 "consumes": [
                     "application/json"
                 ],
@@ -4257,28 +4676,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "登录日志管理"
+                    "IP 访问策略"
                 ],
-                "summary": "获取异常登录查询",
+                "summary": "更新 IP 访问策略",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "页码",
-                        "name": "page",
-                        "in": "query",
+                        "type": "string",
+                        "description": "策略 ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "每页数量",
-                        "name": "page_size",
-                        "in": "query",
-                        "required": true
+                        "description": "策略信息",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.UpdateIPPolicyRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "异常登录列表",
+                        "description": "更新成功",
                         "schema": {
                             "allOf": [
                                 {
@@ -4287,24 +4708,6 @@ const docTemplate = `{
                                 {
                                     "type": "object",
                                     "properties": {
-                                        "data": {
-                                            "allOf": [
-                                                {
-                                                    "$ref": "#/definitions/baseRes.PageResult"
-                                                },
-                                                {
-                                                    "type": "object",
-                                                    "properties": {
-                                                        "list": {
-                                                            "type": "array",
-                                                            "items": {
-                                                                "$ref": "#/definitions/response.AbnormalLoginInfoDTO"
-                                                            }
-                                                        }
-                                                    }
-                                                }
-                                            ]
-                                        },
                                         "msg": {
                                             "type": "string"
                                         }
@@ -4330,27 +4733,16 @@ const docTemplate = `{
                                 "type": "string"
                             }
                         }
-                    },
-                    "500": {
-                        "description": "服务器内部错误",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
                     }
-                }
-            }
-        },
-        "/api/admin/login-log/batch-delete": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "批量删除指定的登录日志记录",
+                ]
+            },
+            "delete": {
+                "description": "删除 IP 访问策略，删除后立即生效",
                 "consumes": [
                     "application/json"
                 ],
@@ -4358,18 +4750,16 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "登录日志管理"
+                    "IP 访问策略"
                 ],
-                "summary": "批量删除登录日志",
+                "summary": "删除 IP 访问策略",
                 "parameters": [
                     {
-                        "description": "批量删除请求参数",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.BatchDeleteLoginLogsRequest"
-                        }
+                        "type": "string",
+                        "description": "策略 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -4408,27 +4798,18 @@ const docTemplate = `{
                                 "type": "string"
                             }
                         }
-                    },
-                    "500": {
-                        "description": "服务器内部错误",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
                     }
-                }
-            }
-        },
-        "/api/admin/login-log/clear": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "根据时间范围清空登录日志",
+                ]
+            }
+        },
+        "/api/admin/jwt/jsonInBlacklist": {
+            "post": {
+                "description": "将 JWT token 加入黑名单，使其立即失效",
                 "consumes": [
                     "application/json"
                 ],
@@ -4436,23 +4817,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "登录日志管理"
+                    "认证服务"
                 ],
-                "summary": "清空登录日志",
+                "summary": "将 JWT token 加入黑名单",
                 "parameters": [
                     {
-                        "description": "清空请求参数",
+                        "description": "JWT token",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/request.ClearLoginLogsRequest"
+                            "$ref": "#/definitions/request.JsonInBlacklistRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "清空成功",
+                        "description": "加入黑名单成功",
                         "schema": {
                             "allOf": [
                                 {
@@ -4496,17 +4877,17 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
-            }
-        },
-        "/api/admin/login-log/statistics": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "获取登录统计信息（按日期、用户、状态等）",
+                ]
+            }
+        },
+        "/api/admin/ldap/group-mappings": {
+            "get": {
+                "description": "分页获取 LDAP 组与角色、部门的映射",
                 "consumes": [
                     "application/json"
                 ],
@@ -4514,28 +4895,34 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "登录日志管理"
+                    "LDAP 管理"
                 ],
-                "summary": "获取登录统计",
+                "summary": "获取 LDAP 组映射列表",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "开始日期（YYYY-MM-DD）",
-                        "name": "start_date",
+                        "type": "integer",
+                        "description": "页码",
+                        "name": "page",
                         "in": "query",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "结束日期（YYYY-MM-DD）",
-                        "name": "end_date",
+                        "type": "integer",
+                        "description": "每页数量",
+                        "name": "pageSize",
                         "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "LDAP 组（模糊匹配）",
+                        "name": "groupDn",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "统计信息",
+                        "description": "映射列表",
                         "schema": {
                             "allOf": [
                                 {
@@ -4545,7 +4932,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/entity.LoginStatistics"
+                                            "$ref": "#/definitions/response.LDAPGroupMappingListResponse"
                                         },
                                         "msg": {
                                             "type": "string"
@@ -4572,27 +4959,16 @@ const docTemplate = `{
                                 "type": "string"
                             }
                         }
-                    },
-                    "500": {
-                        "description": "服务器内部错误",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
                     }
-                }
-            }
-        },
-        "/api/admin/logs": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "获取系统操作日志列表，支持分页和多条件过滤",
+                ]
+            },
+            "post": {
+                "description": "将 LDAP 组映射为本地角色和/或部门，LDAP 用户登录时自动同步",
                 "consumes": [
                     "application/json"
                 ],
@@ -4600,64 +4976,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "系统管理"
+                    "LDAP 管理"
                 ],
-                "summary": "获取操作日志列表",
+                "summary": "创建 LDAP 组映射",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "页码",
-                        "name": "page",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "每页数量",
-                        "name": "pageSize",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "开始时间 (格式:2006-01-02)",
-                        "name": "startTime",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "结束时间 (格式:2006-01-02)",
-                        "name": "endTime",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "用户名",
-                        "name": "userName",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "操作模块",
-                        "name": "module",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "操作类型",
-                        "name": "operationType",
-                        "in": "query"
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "操作结果",
-                        "name": "isSuccess",
-                        "in": "query"
+                        "description": "映射信息",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CreateLDAPGroupMappingRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "操作日志列表",
+                        "description": "创建成功",
                         "schema": {
                             "allOf": [
                                 {
@@ -4667,7 +5002,10 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/response.OperationLogListResponse"
+                                            "$ref": "#/definitions/response.LDAPGroupMappingResponse"
+                                        },
+                                        "msg": {
+                                            "type": "string"
                                         }
                                     }
                                 }
@@ -4683,8 +5021,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "500": {
-                        "description": "服务器内部错误",
+                    "401": {
+                        "description": "未授权",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -4692,17 +5030,17 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
-            }
-        },
-        "/api/admin/logs/:id": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "根据日志 ID 获取详细的操作日志信息",
+                ]
+            }
+        },
+        "/api/admin/ldap/group-mappings/{id}": {
+            "put": {
+                "description": "更新 LDAP 组映射，下次登录时生效",
                 "consumes": [
                     "application/json"
                 ],
@@ -4710,21 +5048,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "系统管理"
+                    "LDAP 管理"
                 ],
-                "summary": "根据 ID 获取操作日志",
+                "summary": "更新 LDAP 组映射",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "日志 ID",
+                        "description": "映射 ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "映射信息",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.UpdateLDAPGroupMappingRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "操作日志详情",
+                        "description": "更新成功",
                         "schema": {
                             "allOf": [
                                 {
@@ -4733,8 +5080,8 @@ const docTemplate = `{
                                 {
                                     "type": "object",
                                     "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/response.OperationLogResponse"
+                                        "msg": {
+                                            "type": "string"
                                         }
                                     }
                                 }
@@ -4750,17 +5097,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "404": {
-                        "description": "日志不存在",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
-                    "500": {
-                        "description": "服务器内部错误",
+                    "401": {
+                        "description": "未授权",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -4768,15 +5106,15 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
-            },
-            "delete": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "根据日志ID删除指定的操作日志",
+                ]
+            },
+            "delete": {
+                "description": "删除 LDAP 组映射，已同步的用户角色不会被回收",
                 "consumes": [
                     "application/json"
                 ],
@@ -4784,13 +5122,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "系统管理"
+                    "LDAP 管理"
                 ],
-                "summary": "根据ID删除操作日志",
+                "summary": "删除 LDAP 组映射",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "日志ID",
+                        "type": "string",
+                        "description": "映射 ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -4800,10 +5138,19 @@ const docTemplate = `{
                     "200": {
                         "description": "删除成功",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/baseRes.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -4815,8 +5162,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "500": {
-                        "description": "服务器内部错误",
+                    "401": {
+                        "description": "未授权",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -4824,17 +5171,17 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
-            }
-        },
-        "/api/admin/logs/batch-delete": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "批量删除指定 ID 列表的操作日志",
+                ]
+            }
+        },
+        "/api/admin/login-log": {
+            "get": {
+                "description": "获取登录日志列表（支持分页和筛选）",
                 "consumes": [
                     "application/json"
                 ],
@@ -4842,28 +5189,97 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "系统管理"
+                    "登录日志管理"
                 ],
-                "summary": "批量删除操作日志",
+                "summary": "获取登录日志列表",
                 "parameters": [
                     {
-                        "description": "批量删除请求参数",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.BatchDeleteOperationLogRequest"
-                        }
+                        "type": "integer",
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每页数量",
+                        "name": "page_size",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "用户 ID",
+                        "name": "user_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "用户名",
+                        "name": "userName",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "登录 IP",
+                        "name": "login_ip",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "登录结果：0-失败，1-成功",
+                        "name": "result",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "开始日期（YYYY-MM-DD）",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "结束日期（YYYY-MM-DD）",
+                        "name": "end_date",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "批量删除成功",
+                        "description": "登录日志列表",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/baseRes.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/baseRes.PageResult"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "list": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/response.LoginLogListDTO"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        },
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -4875,6 +5291,15 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "500": {
                         "description": "服务器内部错误",
                         "schema": {
@@ -4884,17 +5309,15 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
-            }
-        },
-        "/api/admin/logs/clear": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "清空指定时间范围的操作日志",
+                ]
+            },
+            "post": {
+                "description": "记录用户登录日志（仅供内部调用）",
                 "consumes": [
                     "application/json"
                 ],
@@ -4902,23 +5325,41 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "系统管理"
+                    "登录日志管理"
                 ],
-                "summary": "根据时间范围清空操作日志",
+                "summary": "记录登录日志（内部）",
                 "parameters": [
                     {
-                        "description": "清空日志请求参数",
-                        "name": "request",
+                        "description": "登录日志信息",
+                        "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/request.ClearOperationLogByTimeRangeRequest"
+                            "$ref": "#/definitions/request.RecordLoginRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "清空成功",
+                        "description": "记录成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/baseRes.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -4926,8 +5367,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "400": {
-                        "description": "请求参数错误",
+                    "401": {
+                        "description": "未授权",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -4944,17 +5385,17 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
-            }
-        },
-        "/api/admin/logs/statistics": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "获取操作日志的统计信息，包括操作类型分布等",
+                ]
+            }
+        },
+        "/api/admin/login-log/:id": {
+            "get": {
+                "description": "根据 ID 获取登录日志详细信息，数据权限范围外的日志按不存在处理",
                 "consumes": [
                     "application/json"
                 ],
@@ -4962,26 +5403,21 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "系统管理"
+                    "登录日志管理"
                 ],
-                "summary": "获取操作日志统计信息",
+                "summary": "获取登录日志详情",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "开始时间 (格式:2006-01-02)",
-                        "name": "startTime",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "结束时间 (格式:2006-01-02)",
-                        "name": "endTime",
-                        "in": "query"
+                        "description": "登录日志 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "操作日志统计信息",
+                        "description": "登录日志详情",
                         "schema": {
                             "allOf": [
                                 {
@@ -4991,7 +5427,10 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/response.OperationLogStatisticsResponse"
+                                            "$ref": "#/definitions/response.LoginLogDetailDTO"
+                                        },
+                                        "msg": {
+                                            "type": "string"
                                         }
                                     }
                                 }
@@ -5007,6 +5446,15 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "500": {
                         "description": "服务器内部错误",
                         "schema": {
@@ -5016,17 +5464,17 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
-            }
-        },
-        "/api/admin/menu": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "获取系统菜单列表",
+                ]
+            }
+        },
+        "/api/admin/login-log/abnormal": {
+            "get": {
+                "description": "获取异常登录记录（同一 IP 多次失败、异地登录等）",
                 "consumes": [
                     "application/json"
                 ],
@@ -5034,12 +5482,28 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "菜单管理"
+                    "登录日志管理"
+                ],
+                "summary": "获取异常登录查询",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每页数量",
+                        "name": "page_size",
+                        "in": "query",
+                        "required": true
+                    }
                 ],
-                "summary": "获取菜单列表",
                 "responses": {
                     "200": {
-                        "description": "菜单列表",
+                        "description": "异常登录列表",
                         "schema": {
                             "allOf": [
                                 {
@@ -5049,10 +5513,22 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/entity.Menu"
-                                            }
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/baseRes.PageResult"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "list": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/response.AbnormalLoginInfoDTO"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
                                         },
                                         "msg": {
                                             "type": "string"
@@ -5062,6 +5538,15 @@ const docTemplate = `{
                             ]
                         }
                     },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "401": {
                         "description": "未授权",
                         "schema": {
@@ -5080,17 +5565,17 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
-            }
-        },
-        "/api/admin/menu/:id": {
-            "delete": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "删除菜单（管理员权限）",
+                ]
+            }
+        },
+        "/api/admin/login-log/batch-delete": {
+            "post": {
+                "description": "批量删除指定的登录日志记录",
                 "consumes": [
                     "application/json"
                 ],
@@ -5098,16 +5583,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "菜单管理"
+                    "登录日志管理"
                 ],
-                "summary": "删除菜单",
+                "summary": "批量删除登录日志",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "菜单ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "批量删除请求参数",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.BatchDeleteLoginLogsRequest"
+                        }
                     }
                 ],
                 "responses": {
@@ -5156,17 +5643,17 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
-            }
-        },
-        "/api/admin/menu/:id/delete-impact": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "获取删除菜单前的影响范围评估（管理员权限）",
+                ]
+            }
+        },
+        "/api/admin/login-log/clear": {
+            "post": {
+                "description": "根据时间范围清空登录日志",
                 "consumes": [
                     "application/json"
                 ],
@@ -5174,21 +5661,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "菜单管理"
+                    "登录日志管理"
                 ],
-                "summary": "获取菜单删除影响评估",
+                "summary": "清空登录日志",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "菜单 ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "清空请求参数",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.ClearLoginLogsRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "影响评估结果",
+                        "description": "清空成功",
                         "schema": {
                             "allOf": [
                                 {
@@ -5197,9 +5686,6 @@ const docTemplate = `{
                                 {
                                     "type": "object",
                                     "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/entity.DeleteImpact"
-                                        },
                                         "msg": {
                                             "type": "string"
                                         }
@@ -5226,15 +5712,6 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "404": {
-                        "description": "菜单不存在",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
                     "500": {
                         "description": "服务器内部错误",
                         "schema": {
@@ -5244,17 +5721,17 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
-            }
-        },
-        "/api/admin/menu/page": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "获取菜单分页列表（支持过滤）",
+                ]
+            }
+        },
+        "/api/admin/login-log/security-events": {
+            "get": {
+                "description": "分页获取新设备、新国家/城市和异地登录等登录安全事件",
                 "consumes": [
                     "application/json"
                 ],
@@ -5262,9 +5739,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "菜单管理"
+                    "登录日志管理"
                 ],
-                "summary": "获取菜单分页列表",
+                "summary": "获取登录安全事件",
                 "parameters": [
                     {
                         "type": "integer",
@@ -5276,26 +5753,51 @@ const docTemplate = `{
                     {
                         "type": "integer",
                         "description": "每页数量",
-                        "name": "page_size",
+                        "name": "pageSize",
                         "in": "query",
                         "required": true
                     },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "用户 ID",
+                        "name": "userId",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
-                        "description": "菜单标题",
-                        "name": "title",
+                        "description": "用户名",
+                        "name": "userName",
                         "in": "query"
                     },
                     {
-                        "type": "integer",
-                        "description": "状态",
-                        "name": "status",
+                        "type": "string",
+                        "description": "事件类型：new_device、new_country、new_city、impossible_travel",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "风险等级：low、medium、high",
+                        "name": "riskLevel",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "开始日期（YYYY-MM-DD）",
+                        "name": "startDate",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "结束日期（YYYY-MM-DD）",
+                        "name": "endDate",
                         "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "菜单分页列表",
+                        "description": "登录安全事件列表",
                         "schema": {
                             "allOf": [
                                 {
@@ -5315,7 +5817,7 @@ const docTemplate = `{
                                                         "list": {
                                                             "type": "array",
                                                             "items": {
-                                                                "$ref": "#/definitions/response.MenuResponse"
+                                                                "$ref": "#/definitions/response.LoginSecurityEventDTO"
                                                             }
                                                         }
                                                     }
@@ -5357,17 +5859,17 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
-            }
-        },
-        "/api/admin/menu/tree": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "获取完整的菜单树结构（管理员权限）",
+                ]
+            }
+        },
+        "/api/admin/login-log/statistics": {
+            "get": {
+                "description": "获取登录统计信息（按日期、用户、状态等）",
                 "consumes": [
                     "application/json"
                 ],
@@ -5375,12 +5877,28 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "菜单管理"
+                    "登录日志管理"
+                ],
+                "summary": "获取登录统计",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "开始日期（YYYY-MM-DD）",
+                        "name": "start_date",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "结束日期（YYYY-MM-DD）",
+                        "name": "end_date",
+                        "in": "query",
+                        "required": true
+                    }
                 ],
-                "summary": "获取菜单树结构",
                 "responses": {
                     "200": {
-                        "description": "菜单树结构",
+                        "description": "统计信息",
                         "schema": {
                             "allOf": [
                                 {
@@ -5390,10 +5908,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/response.MenuResponse"
-                                            }
+                                            "$ref": "#/definitions/entity.LoginStatistics"
                                         },
                                         "msg": {
                                             "type": "string"
@@ -5403,6 +5918,15 @@ const docTemplate = `{
                             ]
                         }
                     },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "401": {
                         "description": "未授权",
                         "schema": {
@@ -5421,12 +5945,17 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
-        "/api/admin/monitor/cache": {
+        "/api/admin/logs": {
             "get": {
-                "description": "获取缓存监控信息",
+                "description": "获取系统操作日志列表，支持分页和多条件过滤，按当前角色的数据权限过滤",
                 "consumes": [
                     "application/json"
                 ],
@@ -5434,12 +5963,64 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "系统监控"
+                    "系统管理"
+                ],
+                "summary": "获取操作日志列表",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每页数量",
+                        "name": "pageSize",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "开始时间 (格式:2006-01-02)",
+                        "name": "startTime",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "结束时间 (格式:2006-01-02)",
+                        "name": "endTime",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "用户名",
+                        "name": "userName",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "操作模块",
+                        "name": "module",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "操作类型",
+                        "name": "operationType",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "操作结果",
+                        "name": "isSuccess",
+                        "in": "query"
+                    }
                 ],
-                "summary": "获取缓存监控信息",
                 "responses": {
                     "200": {
-                        "description": "成功返回缓存监控信息",
+                        "description": "操作日志列表",
                         "schema": {
                             "allOf": [
                                 {
@@ -5449,25 +6030,42 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "object"
+                                            "$ref": "#/definitions/response.OperationLogListResponse"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "500": {
-                        "description": "服务器错误",
+                        "description": "服务器内部错误",
                         "schema": {
-                            "$ref": "#/definitions/baseRes.Response"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
-        "/api/admin/monitor/database": {
+        "/api/admin/logs/:id": {
             "get": {
-                "description": "获取数据库监控信息",
+                "description": "根据日志 ID 获取详细的操作日志信息，数据权限范围外的日志按不存在处理",
                 "consumes": [
                     "application/json"
                 ],
@@ -5475,12 +6073,21 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "系统监控"
+                    "系统管理"
+                ],
+                "summary": "根据 ID 获取操作日志",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "日志 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "获取数据库监控信息",
                 "responses": {
                     "200": {
-                        "description": "成功返回数据库监控信息",
+                        "description": "操作日志详情",
                         "schema": {
                             "allOf": [
                                 {
@@ -5490,80 +6097,107 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "object"
+                                            "$ref": "#/definitions/response.OperationLogResponse"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "日志不存在",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "500": {
-                        "description": "服务器错误",
+                        "description": "服务器内部错误",
                         "schema": {
-                            "$ref": "#/definitions/baseRes.Response"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
-                }
-            }
-        },
-        "/api/admin/monitor/redis-keys": {
-            "get": {
-                "description": "查询 Redis 键列表",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "delete": {
+                "description": "根据日志ID删除指定的操作日志",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "系统监控"
+                    "系统管理"
                 ],
-                "summary": "查询 Redis 键",
+                "summary": "根据ID删除操作日志",
                 "parameters": [
-                    {
-                        "type": "string",
-                        "description": "键模式 (默认：*)",
-                        "name": "pattern",
-                        "in": "query"
-                    },
                     {
                         "type": "integer",
-                        "description": "限制数量 (默认：100)",
-                        "name": "limit",
-                        "in": "query"
+                        "description": "日志ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "成功返回 Redis 键列表",
+                        "description": "删除成功",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/baseRes.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "object"
-                                        }
-                                    }
-                                }
-                            ]
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "500": {
-                        "description": "服务器错误",
+                        "description": "服务器内部错误",
                         "schema": {
-                            "$ref": "#/definitions/baseRes.Response"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
-        "/api/admin/monitor/slow-queries": {
-            "get": {
-                "description": "查询数据库慢查询日志",
+        "/api/admin/logs/batch-delete": {
+            "post": {
+                "description": "批量删除指定 ID 列表的操作日志",
                 "consumes": [
                     "application/json"
                 ],
@@ -5571,40 +6205,59 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "系统监控"
+                    "系统管理"
+                ],
+                "summary": "批量删除操作日志",
+                "parameters": [
+                    {
+                        "description": "批量删除请求参数",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.BatchDeleteOperationLogRequest"
+                        }
+                    }
                 ],
-                "summary": "查询慢查询日志",
                 "responses": {
                     "200": {
-                        "description": "成功返回慢查询日志",
+                        "description": "批量删除成功",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/baseRes.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "object"
-                                        }
-                                    }
-                                }
-                            ]
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "500": {
-                        "description": "服务器错误",
+                        "description": "服务器内部错误",
                         "schema": {
-                            "$ref": "#/definitions/baseRes.Response"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
-        "/api/admin/monitor/system": {
-            "get": {
-                "description": "获取系统资源监控信息，包括 CPU、内存、磁盘等",
+        "/api/admin/logs/clear": {
+            "post": {
+                "description": "清空指定时间范围的操作日志",
                 "consumes": [
                     "application/json"
                 ],
@@ -5612,45 +6265,59 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "系统监控"
+                    "系统管理"
+                ],
+                "summary": "根据时间范围清空操作日志",
+                "parameters": [
+                    {
+                        "description": "清空日志请求参数",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.ClearOperationLogByTimeRangeRequest"
+                        }
+                    }
                 ],
-                "summary": "获取系统监控信息",
                 "responses": {
                     "200": {
-                        "description": "成功返回系统监控信息",
+                        "description": "清空成功",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/baseRes.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/monitor.SystemStatus"
-                                        }
-                                    }
-                                }
-                            ]
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "500": {
-                        "description": "服务器错误",
+                        "description": "服务器内部错误",
                         "schema": {
-                            "$ref": "#/definitions/baseRes.Response"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
-                }
-            }
-        },
-        "/api/admin/notices": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "获取公告列表（支持分页和筛选）",
+                ]
+            }
+        },
+        "/api/admin/logs/statistics": {
+            "get": {
+                "description": "获取操作日志的统计信息，包括操作类型分布等",
                 "consumes": [
                     "application/json"
                 ],
@@ -5658,46 +6325,26 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "公告管理"
+                    "系统管理"
                 ],
-                "summary": "获取公告列表",
+                "summary": "获取操作日志统计信息",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "页码",
-                        "name": "page",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "每页数量",
-                        "name": "page_size",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "公告类型 (1:系统公告，2:活动公告，3:普通通知，4:紧急通知)",
-                        "name": "type",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "公告状态 (0:草稿，1:已发布，2:已归档)",
-                        "name": "status",
+                        "type": "string",
+                        "description": "开始时间 (格式:2006-01-02)",
+                        "name": "startTime",
                         "in": "query"
                     },
                     {
-                        "type": "boolean",
-                        "description": "是否置顶",
-                        "name": "is_top",
+                        "type": "string",
+                        "description": "结束时间 (格式:2006-01-02)",
+                        "name": "endTime",
                         "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "公告列表",
+                        "description": "操作日志统计信息",
                         "schema": {
                             "allOf": [
                                 {
@@ -5707,18 +6354,15 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/response.NoticeListResponse"
-                                        },
-                                        "msg": {
-                                            "type": "string"
+                                            "$ref": "#/definitions/response.OperationLogStatisticsResponse"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "401": {
-                        "description": "未授权",
+                    "400": {
+                        "description": "请求参数错误",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -5735,15 +6379,17 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
-            },
-            "put": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "更新公告信息（仅限草稿状态）",
+                ]
+            }
+        },
+        "/api/admin/menu": {
+            "get": {
+                "description": "获取系统菜单列表",
                 "consumes": [
                     "application/json"
                 ],
@@ -5751,23 +6397,12 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "公告管理"
-                ],
-                "summary": "更新公告",
-                "parameters": [
-                    {
-                        "description": "公告信息",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.UpdateNoticeRequest"
-                        }
-                    }
+                    "菜单管理"
                 ],
+                "summary": "获取菜单列表",
                 "responses": {
                     "200": {
-                        "description": "更新成功",
+                        "description": "菜单列表",
                         "schema": {
                             "allOf": [
                                 {
@@ -5777,7 +6412,10 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/response.NoticeResponse"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/entity.Menu"
+                                            }
                                         },
                                         "msg": {
                                             "type": "string"
@@ -5787,15 +6425,6 @@ const docTemplate = `{
                             ]
                         }
                     },
-                    "400": {
-                        "description": "请求参数错误",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
                     "401": {
                         "description": "未授权",
                         "schema": {
@@ -5814,15 +6443,17 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
-            },
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "创建新的公告（草稿状态）",
+                ]
+            }
+        },
+        "/api/admin/menu/:id": {
+            "delete": {
+                "description": "删除菜单（管理员权限）",
                 "consumes": [
                     "application/json"
                 ],
@@ -5830,23 +6461,21 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "公告管理"
+                    "菜单管理"
                 ],
-                "summary": "创建公告",
+                "summary": "删除菜单",
                 "parameters": [
                     {
-                        "description": "公告信息",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.CreateNoticeRequest"
-                        }
+                        "type": "integer",
+                        "description": "菜单ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "创建成功",
+                        "description": "删除成功",
                         "schema": {
                             "allOf": [
                                 {
@@ -5855,9 +6484,6 @@ const docTemplate = `{
                                 {
                                     "type": "object",
                                     "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/response.NoticeResponse"
-                                        },
                                         "msg": {
                                             "type": "string"
                                         }
@@ -5893,17 +6519,17 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
-            }
-        },
-        "/api/admin/notices/:id": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "根据 ID 获取公告详细信息",
+                ]
+            }
+        },
+        "/api/admin/menu/:id/delete-impact": {
+            "get": {
+                "description": "获取删除菜单前的影响范围评估（管理员权限）",
                 "consumes": [
                     "application/json"
                 ],
@@ -5911,13 +6537,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "公告管理"
+                    "菜单管理"
                 ],
-                "summary": "获取公告详情",
+                "summary": "获取菜单删除影响评估",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "公告 ID",
+                        "type": "integer",
+                        "description": "菜单 ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -5925,7 +6551,7 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "公告详情",
+                        "description": "影响评估结果",
                         "schema": {
                             "allOf": [
                                 {
@@ -5935,7 +6561,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/response.NoticeResponse"
+                                            "$ref": "#/definitions/entity.DeleteImpact"
                                         },
                                         "msg": {
                                             "type": "string"
@@ -5963,6 +6589,15 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "404": {
+                        "description": "菜单不存在",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "500": {
                         "description": "服务器内部错误",
                         "schema": {
@@ -5972,15 +6607,17 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
-            },
-            "delete": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "删除公告（管理员权限）",
+                ]
+            }
+        },
+        "/api/admin/menu/page": {
+            "get": {
+                "description": "获取菜单分页列表（支持过滤）",
                 "consumes": [
                     "application/json"
                 ],
@@ -5988,21 +6625,40 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "公告管理"
+                    "菜单管理"
                 ],
-                "summary": "删除公告",
+                "summary": "获取菜单分页列表",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "公告 ID",
-                        "name": "id",
-                        "in": "path",
+                        "type": "integer",
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每页数量",
+                        "name": "page_size",
+                        "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "菜单标题",
+                        "name": "title",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "状态",
+                        "name": "status",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "删除成功",
+                        "description": "菜单分页列表",
                         "schema": {
                             "allOf": [
                                 {
@@ -6011,6 +6667,24 @@ const docTemplate = `{
                                 {
                                     "type": "object",
                                     "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/baseRes.PageResult"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "list": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/response.MenuResponse"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        },
                                         "msg": {
                                             "type": "string"
                                         }
@@ -6046,17 +6720,17 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
-            }
-        },
-        "/api/admin/notices/:id/is-read": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "检查当前用户是否已阅读指定公告",
+                ]
+            }
+        },
+        "/api/admin/menu/tree": {
+            "get": {
+                "description": "获取完整的菜单树结构（管理员权限）",
                 "consumes": [
                     "application/json"
                 ],
@@ -6064,21 +6738,12 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "公告管理"
-                ],
-                "summary": "检查用户是否已读公告",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "公告 ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "菜单管理"
                 ],
+                "summary": "获取菜单树结构",
                 "responses": {
                     "200": {
-                        "description": "是否已读",
+                        "description": "菜单树结构",
                         "schema": {
                             "allOf": [
                                 {
@@ -6088,7 +6753,10 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "boolean"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/response.MenuResponse"
+                                            }
                                         },
                                         "msg": {
                                             "type": "string"
@@ -6098,15 +6766,6 @@ const docTemplate = `{
                             ]
                         }
                     },
-                    "400": {
-                        "description": "请求参数错误",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
                     "401": {
                         "description": "未授权",
                         "schema": {
@@ -6125,17 +6784,17 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
-            }
-        },
-        "/api/admin/notices/:id/publish": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "将草稿状态的公告发布",
+                ]
+            }
+        },
+        "/api/admin/monitor/cache": {
+            "get": {
+                "description": "获取缓存监控信息",
                 "consumes": [
                     "application/json"
                 ],
@@ -6143,21 +6802,12 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "公告管理"
-                ],
-                "summary": "发布公告",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "公告 ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "系统监控"
                 ],
+                "summary": "获取缓存监控信息",
                 "responses": {
                     "200": {
-                        "description": "发布成功",
+                        "description": "成功返回缓存监控信息",
                         "schema": {
                             "allOf": [
                                 {
@@ -6166,52 +6816,67 @@ const docTemplate = `{
                                 {
                                     "type": "object",
                                     "properties": {
-                                        "msg": {
-                                            "type": "string"
+                                        "data": {
+                                            "type": "object"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "400": {
-                        "description": "请求参数错误",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
-                    "401": {
-                        "description": "未授权",
+                    "500": {
+                        "description": "服务器错误",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/baseRes.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/monitor/database": {
+            "get": {
+                "description": "获取数据库监控信息",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "系统监控"
+                ],
+                "summary": "获取数据库监控信息",
+                "responses": {
+                    "200": {
+                        "description": "成功返回数据库监控信息",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/baseRes.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "500": {
-                        "description": "服务器内部错误",
+                        "description": "服务器错误",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/baseRes.Response"
                         }
                     }
                 }
             }
         },
-        "/api/admin/notices/:id/read": {
-            "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "标记公告为已读状态，并增加浏览次数",
+        "/api/admin/monitor/redis-keys": {
+            "get": {
+                "description": "查询 Redis 键列表",
                 "consumes": [
                     "application/json"
                 ],
@@ -6219,21 +6884,26 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "公告管理"
+                    "系统监控"
                 ],
-                "summary": "标记公告已读",
+                "summary": "查询 Redis 键",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "公告 ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "键模式 (默认：*)",
+                        "name": "pattern",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "限制数量 (默认：100)",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "标记成功",
+                        "description": "成功返回 Redis 键列表",
                         "schema": {
                             "allOf": [
                                 {
@@ -6242,52 +6912,67 @@ const docTemplate = `{
                                 {
                                     "type": "object",
                                     "properties": {
-                                        "msg": {
-                                            "type": "string"
+                                        "data": {
+                                            "type": "object"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "400": {
-                        "description": "请求参数错误",
+                    "500": {
+                        "description": "服务器错误",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/baseRes.Response"
                         }
-                    },
-                    "401": {
-                        "description": "未授权",
+                    }
+                }
+            }
+        },
+        "/api/admin/monitor/slow-queries": {
+            "get": {
+                "description": "查询数据库慢查询日志",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "系统监控"
+                ],
+                "summary": "查询慢查询日志",
+                "responses": {
+                    "200": {
+                        "description": "成功返回慢查询日志",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/baseRes.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "500": {
-                        "description": "服务器内部错误",
+                        "description": "服务器错误",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/baseRes.Response"
                         }
                     }
                 }
             }
         },
-        "/api/admin/notices/statistics": {
+        "/api/admin/monitor/system": {
             "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "获取公告的统计信息（总数、已发布、草稿、已归档）",
+                "description": "获取系统资源监控信息，包括 CPU、内存、磁盘等",
                 "consumes": [
                     "application/json"
                 ],
@@ -6295,12 +6980,12 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "公告管理"
+                    "系统监控"
                 ],
-                "summary": "获取公告统计",
+                "summary": "获取系统监控信息",
                 "responses": {
                     "200": {
-                        "description": "统计信息",
+                        "description": "成功返回系统监控信息",
                         "schema": {
                             "allOf": [
                                 {
@@ -6310,45 +6995,25 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/response.NoticeStatisticsResponse"
-                                        },
-                                        "msg": {
-                                            "type": "string"
+                                            "$ref": "#/definitions/monitor.SystemStatus"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "401": {
-                        "description": "未授权",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
                     "500": {
-                        "description": "服务器内部错误",
+                        "description": "服务器错误",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/baseRes.Response"
                         }
                     }
                 }
             }
         },
-        "/api/admin/online-user": {
+        "/api/admin/notices": {
             "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "获取当前所有在线用户信息",
+                "description": "获取公告列表（支持分页和筛选）",
                 "consumes": [
                     "application/json"
                 ],
@@ -6356,9 +7021,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "在线用户管理"
+                    "公告管理"
                 ],
-                "summary": "获取在线用户列表",
+                "summary": "获取公告列表",
                 "parameters": [
                     {
                         "type": "integer",
@@ -6373,11 +7038,29 @@ const docTemplate = `{
                         "name": "page_size",
                         "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "公告类型 (1:系统公告，2:活动公告，3:普通通知，4:紧急通知)",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "公告状态 (0:草稿，1:已发布，2:已归档)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "是否置顶",
+                        "name": "is_top",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "在线用户列表",
+                        "description": "公告列表",
                         "schema": {
                             "allOf": [
                                 {
@@ -6387,7 +7070,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/response.OnlineUserListResponse"
+                                            "$ref": "#/definitions/response.NoticeListResponse"
                                         },
                                         "msg": {
                                             "type": "string"
@@ -6397,15 +7080,6 @@ const docTemplate = `{
                             ]
                         }
                     },
-                    "400": {
-                        "description": "请求参数错误",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
                     "401": {
                         "description": "未授权",
                         "schema": {
@@ -6424,17 +7098,15 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
-            }
-        },
-        "/api/admin/online-user/:user_id": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "根据用户 ID 获取在线用户详细信息",
+                ]
+            },
+            "put": {
+                "description": "更新公告信息（仅限草稿状态）",
                 "consumes": [
                     "application/json"
                 ],
@@ -6442,21 +7114,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "在线用户管理"
+                    "公告管理"
                 ],
-                "summary": "获取在线用户详情",
+                "summary": "更新公告",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "用户 ID",
-                        "name": "user_id",
-                        "in": "path",
-                        "required": true
+                        "description": "公告信息",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.UpdateNoticeRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "在线用户详情",
+                        "description": "更新成功",
                         "schema": {
                             "allOf": [
                                 {
@@ -6466,7 +7140,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/response.OnlineUserResponse"
+                                            "$ref": "#/definitions/response.NoticeResponse"
                                         },
                                         "msg": {
                                             "type": "string"
@@ -6494,15 +7168,6 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "404": {
-                        "description": "用户不在线",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
                     "500": {
                         "description": "服务器内部错误",
                         "schema": {
@@ -6512,47 +7177,39 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
-            },
-            "delete": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "强制指定用户下线（管理员权限）",
-                "consumes": [
+                ]
+            },
+            "post": {
+                "description": "创建新的公告（草稿状态）",
+                "consumes": [
                     "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "在线用户管理"
+                    "公告管理"
                 ],
-                "summary": "强制用户下线",
+                "summary": "创建公告",
                 "parameters": [
                     {
-                        "description": "用户 ID",
-                        "name": "user_id",
+                        "description": "公告信息",
+                        "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "type": "integer"
-                        }
-                    },
-                    {
-                        "description": "下线原因",
-                        "name": "reason",
-                        "in": "body",
-                        "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/request.CreateNoticeRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "强制下线成功",
+                        "description": "创建成功",
                         "schema": {
                             "allOf": [
                                 {
@@ -6561,6 +7218,9 @@ const docTemplate = `{
                                 {
                                     "type": "object",
                                     "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.NoticeResponse"
+                                        },
                                         "msg": {
                                             "type": "string"
                                         }
@@ -6587,15 +7247,6 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "404": {
-                        "description": "用户不在线",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
                     "500": {
                         "description": "服务器内部错误",
                         "schema": {
@@ -6605,17 +7256,17 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
-            }
-        },
-        "/api/admin/online-user/batch": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "批量强制指定用户下线（管理员权限）",
+                ]
+            }
+        },
+        "/api/admin/notices/:id": {
+            "get": {
+                "description": "根据 ID 获取公告详细信息",
                 "consumes": [
                     "application/json"
                 ],
@@ -6623,23 +7274,21 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "在线用户管理"
+                    "公告管理"
                 ],
-                "summary": "批量强制用户下线",
+                "summary": "获取公告详情",
                 "parameters": [
                     {
-                        "description": "批量下线请求",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.BatchForceOfflineRequest"
-                        }
+                        "type": "string",
+                        "description": "公告 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "批量强制下线成功",
+                        "description": "公告详情",
                         "schema": {
                             "allOf": [
                                 {
@@ -6648,6 +7297,9 @@ const docTemplate = `{
                                 {
                                     "type": "object",
                                     "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.NoticeResponse"
+                                        },
                                         "msg": {
                                             "type": "string"
                                         }
@@ -6683,17 +7335,15 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
-            }
-        },
-        "/api/admin/online-user/count": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "获取当前在线用户总数",
+                ]
+            },
+            "delete": {
+                "description": "删除公告（管理员权限）",
                 "consumes": [
                     "application/json"
                 ],
@@ -6701,12 +7351,21 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "在线用户管理"
+                    "公告管理"
+                ],
+                "summary": "删除公告",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "公告 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "获取在线用户数量",
                 "responses": {
                     "200": {
-                        "description": "在线用户数量",
+                        "description": "删除成功",
                         "schema": {
                             "allOf": [
                                 {
@@ -6715,10 +7374,6 @@ const docTemplate = `{
                                 {
                                     "type": "object",
                                     "properties": {
-                                        "data": {
-                                            "type": "integer",
-                                            "format": "int64"
-                                        },
                                         "msg": {
                                             "type": "string"
                                         }
@@ -6727,6 +7382,15 @@ const docTemplate = `{
                             ]
                         }
                     },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "401": {
                         "description": "未授权",
                         "schema": {
@@ -6745,17 +7409,17 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
-            }
-        },
-        "/api/admin/online-user/online": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "根据用户 ID 检查用户是否在线",
+                ]
+            }
+        },
+        "/api/admin/notices/:id/is-read": {
+            "get": {
+                "description": "检查当前用户是否已阅读指定公告",
                 "consumes": [
                     "application/json"
                 ],
@@ -6763,22 +7427,21 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "在线用户管理"
+                    "公告管理"
                 ],
-                "summary": "检查用户是否在线",
+                "summary": "检查用户是否已读公告",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "format": "int64",
-                        "description": "用户 ID",
-                        "name": "user_id",
-                        "in": "query",
+                        "type": "string",
+                        "description": "公告 ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "是否在线",
+                        "description": "是否已读",
                         "schema": {
                             "allOf": [
                                 {
@@ -6825,17 +7488,17 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
-            }
-        },
-        "/api/admin/permission-logs": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "获取系统权限变更日志（支持分页和筛选）",
+                ]
+            }
+        },
+        "/api/admin/notices/:id/publish": {
+            "post": {
+                "description": "将草稿状态的公告发布",
                 "consumes": [
                     "application/json"
                 ],
@@ -6843,65 +7506,21 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "权限日志管理"
+                    "公告管理"
                 ],
-                "summary": "获取权限日志列表",
+                "summary": "发布公告",
                 "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "页码",
-                        "name": "page",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "每页数量",
-                        "name": "page_size",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "format": "int64",
-                        "description": "用户 ID",
-                        "name": "user_id",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "用户名",
-                        "name": "userName",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "操作类型",
-                        "name": "operation",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "模块",
-                        "name": "module",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "开始日期（YYYY-MM-DD）",
-                        "name": "start_date",
-                        "in": "query"
-                    },
                     {
                         "type": "string",
-                        "description": "结束日期（YYYY-MM-DD）",
-                        "name": "end_date",
-                        "in": "query"
+                        "description": "公告 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "权限日志列表",
+                        "description": "发布成功",
                         "schema": {
                             "allOf": [
                                 {
@@ -6910,22 +7529,6 @@ const docTemplate = `{
                                 {
                                     "type": "object",
                                     "properties": {
-                                        "data": {
-                                            "allOf": [
-                                                {
-                                                    "$ref": "#/definitions/baseRes.PageResult"
-                                                },
-                                                {
-                                                    "type": "object",
-                                                    "properties": {
-                                                        "list": {
-                                                            "type": "array",
-                                                            "items": {}
-                                                        }
-                                                    }
-                                                }
-                                            ]
-                                        },
                                         "msg": {
                                             "type": "string"
                                         }
@@ -6961,17 +7564,17 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
-            }
-        },
-        "/api/admin/position": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "获取岗位列表（支持分页和筛选）",
+                ]
+            }
+        },
+        "/api/admin/notices/:id/read": {
+            "post": {
+                "description": "标记公告为已读状态，并增加浏览次数",
                 "consumes": [
                     "application/json"
                 ],
@@ -6979,34 +7582,21 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "岗位管理"
+                    "公告管理"
                 ],
-                "summary": "获取岗位列表",
+                "summary": "标记公告已读",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "页码",
-                        "name": "page",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "每页数量",
-                        "name": "page_size",
-                        "in": "query",
+                        "type": "string",
+                        "description": "公告 ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "状态 (0:禁用，1:启用)",
-                        "name": "status",
-                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "岗位列表",
+                        "description": "标记成功",
                         "schema": {
                             "allOf": [
                                 {
@@ -7015,9 +7605,6 @@ const docTemplate = `{
                                 {
                                     "type": "object",
                                     "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/response.PositionListResponse"
-                                        },
                                         "msg": {
                                             "type": "string"
                                         }
@@ -7026,6 +7613,15 @@ const docTemplate = `{
                             ]
                         }
                     },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "401": {
                         "description": "未授权",
                         "schema": {
@@ -7044,15 +7640,17 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
-            },
-            "put": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "更新岗位信息",
+                ]
+            }
+        },
+        "/api/admin/notices/statistics": {
+            "get": {
+                "description": "获取公告的统计信息（总数、已发布、草稿、已归档）",
                 "consumes": [
                     "application/json"
                 ],
@@ -7060,23 +7658,12 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "岗位管理"
-                ],
-                "summary": "更新岗位",
-                "parameters": [
-                    {
-                        "description": "岗位信息",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.UpdatePositionRequest"
-                        }
-                    }
+                    "公告管理"
                 ],
+                "summary": "获取公告统计",
                 "responses": {
                     "200": {
-                        "description": "更新成功",
+                        "description": "统计信息",
                         "schema": {
                             "allOf": [
                                 {
@@ -7086,7 +7673,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/response.PositionResponse"
+                                            "$ref": "#/definitions/response.NoticeStatisticsResponse"
                                         },
                                         "msg": {
                                             "type": "string"
@@ -7096,15 +7683,6 @@ const docTemplate = `{
                             ]
                         }
                     },
-                    "400": {
-                        "description": "请求参数错误",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
                     "401": {
                         "description": "未授权",
                         "schema": {
@@ -7123,15 +7701,17 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
-            },
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "创建新的岗位",
+                ]
+            }
+        },
+        "/api/admin/oidc/clients": {
+            "get": {
+                "description": "分页获取接入本系统身份提供方的客户端，不返回密钥",
                 "consumes": [
                     "application/json"
                 ],
@@ -7139,23 +7719,40 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "岗位管理"
+                    "身份提供方"
                 ],
-                "summary": "创建岗位",
+                "summary": "获取 OIDC 客户端列表",
                 "parameters": [
                     {
-                        "description": "岗位信息",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.CreatePositionRequest"
-                        }
+                        "type": "integer",
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每页数量",
+                        "name": "pageSize",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "名称（模糊匹配）",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "状态",
+                        "name": "status",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "创建成功",
+                        "description": "客户端列表",
                         "schema": {
                             "allOf": [
                                 {
@@ -7165,7 +7762,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/response.PositionResponse"
+                                            "$ref": "#/definitions/response.OIDCClientListResponse"
                                         },
                                         "msg": {
                                             "type": "string"
@@ -7192,27 +7789,16 @@ const docTemplate = `{
                                 "type": "string"
                             }
                         }
-                    },
-                    "500": {
-                        "description": "服务器内部错误",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
                     }
-                }
-            }
-        },
-        "/api/admin/position/:id": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "根据 ID 获取岗位详细信息",
+                ]
+            },
+            "post": {
+                "description": "登记接入本系统身份提供方的应用，机密客户端的密钥明文仅在响应中返回一次",
                 "consumes": [
                     "application/json"
                 ],
@@ -7220,21 +7806,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "岗位管理"
+                    "身份提供方"
                 ],
-                "summary": "获取岗位详情",
+                "summary": "登记 OIDC 客户端",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "岗位 ID",
-                        "name": "id",
-                        "in": "query",
-                        "required": true
+                        "description": "客户端信息",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CreateOIDCClientRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "岗位详情",
+                        "description": "登记成功",
                         "schema": {
                             "allOf": [
                                 {
@@ -7244,7 +7832,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/response.PositionResponse"
+                                            "$ref": "#/definitions/response.OIDCClientSecretResponse"
                                         },
                                         "msg": {
                                             "type": "string"
@@ -7271,25 +7859,18 @@ const docTemplate = `{
                                 "type": "string"
                             }
                         }
-                    },
-                    "500": {
-                        "description": "服务器内部错误",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
                     }
-                }
-            },
-            "delete": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "删除岗位（管理员权限）",
+                ]
+            }
+        },
+        "/api/admin/oidc/clients/{id}": {
+            "get": {
+                "description": "根据 ID 获取客户端，不返回密钥",
                 "consumes": [
                     "application/json"
                 ],
@@ -7297,13 +7878,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "岗位管理"
+                    "身份提供方"
                 ],
-                "summary": "删除岗位",
+                "summary": "获取 OIDC 客户端详情",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "岗位 ID",
+                        "description": "客户端记录 ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -7311,7 +7892,7 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "删除成功",
+                        "description": "客户端详情",
                         "schema": {
                             "allOf": [
                                 {
@@ -7320,6 +7901,9 @@ const docTemplate = `{
                                 {
                                     "type": "object",
                                     "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.OIDCClientResponse"
+                                        },
                                         "msg": {
                                             "type": "string"
                                         }
@@ -7345,27 +7929,16 @@ const docTemplate = `{
                                 "type": "string"
                             }
                         }
-                    },
-                    "500": {
-                        "description": "服务器内部错误",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
                     }
-                }
-            }
-        },
-        "/api/admin/position/all": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "获取所有岗位，用于前端选择岗位选项",
+                ]
+            },
+            "put": {
+                "description": "更新客户端配置，客户端标识和类型不可修改",
                 "consumes": [
                     "application/json"
                 ],
@@ -7373,12 +7946,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "岗位管理"
+                    "身份提供方"
+                ],
+                "summary": "更新 OIDC 客户端",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "客户端记录 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "客户端信息",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.UpdateOIDCClientRequest"
+                        }
+                    }
                 ],
-                "summary": "获取所有岗位",
                 "responses": {
                     "200": {
-                        "description": "岗位列表",
+                        "description": "更新成功",
                         "schema": {
                             "allOf": [
                                 {
@@ -7387,12 +7978,6 @@ const docTemplate = `{
                                 {
                                     "type": "object",
                                     "properties": {
-                                        "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/response.PositionResponse"
-                                            }
-                                        },
                                         "msg": {
                                             "type": "string"
                                         }
@@ -7401,8 +7986,8 @@ const docTemplate = `{
                             ]
                         }
                     },
-                    "401": {
-                        "description": "未授权",
+                    "400": {
+                        "description": "请求参数错误",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -7410,8 +7995,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "500": {
-                        "description": "服务器内部错误",
+                    "401": {
+                        "description": "未授权",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -7419,17 +8004,15 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
-            }
-        },
-        "/api/admin/role": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "获取角色列表",
+                ]
+            },
+            "delete": {
+                "description": "删除客户端及用户授权记录，已签发的访问令牌在过期前仍然有效",
                 "consumes": [
                     "application/json"
                 ],
@@ -7437,40 +8020,21 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "角色管理"
+                    "身份提供方"
                 ],
-                "summary": "获取角色列表",
+                "summary": "删除 OIDC 客户端",
                 "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "页码",
-                        "name": "page",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "每页数量",
-                        "name": "pageSize",
-                        "in": "query",
-                        "required": true
-                    },
                     {
                         "type": "string",
-                        "description": "角色名称",
-                        "name": "name",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "状态(-1:全部,0:禁用,1:启用)",
-                        "name": "status",
-                        "in": "query"
+                        "description": "客户端记录 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "删除成功",
                         "schema": {
                             "allOf": [
                                 {
@@ -7479,23 +8043,43 @@ const docTemplate = `{
                                 {
                                     "type": "object",
                                     "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/response.RoleListResponse"
+                                        "msg": {
+                                            "type": "string"
                                         }
                                     }
                                 }
                             ]
                         }
-                    }
-                }
-            },
-            "put": {
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "更新角色信息",
+                ]
+            }
+        },
+        "/api/admin/oidc/clients/{id}/secret": {
+            "post": {
+                "description": "为机密客户端生成新密钥，旧密钥立即失效，明文仅返回一次",
                 "consumes": [
                     "application/json"
                 ],
@@ -7503,36 +8087,69 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "角色管理"
+                    "身份提供方"
                 ],
-                "summary": "更新角色",
+                "summary": "重置 OIDC 客户端密钥",
                 "parameters": [
                     {
-                        "description": "角色信息",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.UpdateRoleRequest"
-                        }
+                        "type": "string",
+                        "description": "客户端记录 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "重置成功",
                         "schema": {
-                            "$ref": "#/definitions/baseRes.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/baseRes.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.OIDCClientSecretResponse"
+                                        },
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
-                }
-            },
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "创建新的角色",
+                ]
+            }
+        },
+        "/api/admin/oidc/providers": {
+            "get": {
+                "description": "分页获取 OIDC 身份提供方配置，不返回客户端密钥",
                 "consumes": [
                     "application/json"
                 ],
@@ -7540,23 +8157,40 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "角色管理"
+                    "单点登录"
                 ],
-                "summary": "创建角色",
+                "summary": "获取身份提供方列表",
                 "parameters": [
                     {
-                        "description": "角色信息",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.CreateRoleRequest"
-                        }
+                        "type": "integer",
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每页数量",
+                        "name": "pageSize",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "名称（模糊匹配）",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "状态",
+                        "name": "status",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "身份提供方列表",
                         "schema": {
                             "allOf": [
                                 {
@@ -7566,22 +8200,43 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/response.RoleResponse"
+                                            "$ref": "#/definitions/response.OIDCProviderListResponse"
+                                        },
+                                        "msg": {
+                                            "type": "string"
                                         }
                                     }
                                 }
                             ]
                         }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
                     }
-                }
-            },
-            "delete": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "删除角色",
+                ]
+            },
+            "post": {
+                "description": "创建 OIDC 身份提供方，授权、令牌和 JWKS 端点为空时通过签发方的发现文档获取",
                 "consumes": [
                     "application/json"
                 ],
@@ -7589,38 +8244,71 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "角色管理"
+                    "单点登录"
                 ],
-                "summary": "删除角色",
+                "summary": "创建身份提供方",
                 "parameters": [
                     {
-                        "description": "角色ID",
+                        "description": "身份提供方信息",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/request.DeleteRoleRequest"
+                            "$ref": "#/definitions/request.CreateOIDCProviderRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "创建成功",
                         "schema": {
-                            "$ref": "#/definitions/baseRes.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/baseRes.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.OIDCProviderResponse"
+                                        },
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
-                }
-            }
-        },
-        "/api/admin/role/:id/available-apis": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "获取尚未分配给该角色的 API 路由列表",
+                ]
+            }
+        },
+        "/api/admin/oidc/providers/{id}": {
+            "get": {
+                "description": "根据 ID 获取 OIDC 身份提供方配置，不返回客户端密钥",
                 "consumes": [
                     "application/json"
                 ],
@@ -7628,13 +8316,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "角色管理"
+                    "单点登录"
                 ],
-                "summary": "获取角色可用的 API 列表",
+                "summary": "获取身份提供方详情",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "角色 ID",
+                        "description": "身份提供方 ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -7642,7 +8330,7 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "身份提供方详情",
                         "schema": {
                             "allOf": [
                                 {
@@ -7652,27 +8340,43 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/response.APIResponse"
-                                            }
+                                            "$ref": "#/definitions/response.OIDCProviderResponse"
+                                        },
+                                        "msg": {
+                                            "type": "string"
                                         }
                                     }
                                 }
                             ]
                         }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
                     }
-                }
-            }
-        },
-        "/api/admin/role/:id/detail": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "根据路径参数 ID 获取角色详情，支持 RESTful 风格",
+                ]
+            },
+            "put": {
+                "description": "更新 OIDC 身份提供方配置，客户端密钥为空时保留原值，编码不可修改",
                 "consumes": [
                     "application/json"
                 ],
@@ -7680,21 +8384,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "角色管理"
+                    "单点登录"
                 ],
-                "summary": "根据路径参数 ID 获取角色详情",
+                "summary": "更新身份提供方",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "角色 ID",
+                        "description": "身份提供方 ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "身份提供方信息",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.UpdateOIDCProviderRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "更新成功",
                         "schema": {
                             "allOf": [
                                 {
@@ -7703,25 +8416,41 @@ const docTemplate = `{
                                 {
                                     "type": "object",
                                     "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/response.RoleDetailResponse"
+                                        "msg": {
+                                            "type": "string"
                                         }
                                     }
                                 }
                             ]
                         }
-                    }
-                }
-            }
-        },
-        "/api/admin/role/:id/permissions": {
-            "post": {
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "保存角色的菜单、按钮和 API 权限",
+                ]
+            },
+            "delete": {
+                "description": "删除 OIDC 身份提供方，同时解除该身份提供方下的用户绑定",
                 "consumes": [
                     "application/json"
                 ],
@@ -7729,45 +8458,66 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "角色管理"
+                    "单点登录"
                 ],
-                "summary": "保存角色权限",
+                "summary": "删除身份提供方",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "角色 ID",
+                        "description": "身份提供方 ID",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "权限信息",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.SaveRolePermissionsRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "删除成功",
                         "schema": {
-                            "$ref": "#/definitions/baseRes.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/baseRes.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
-                }
-            }
-        },
-        "/api/admin/role/all": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "获取所有角色，用于前端选择角色选项",
+                ]
+            }
+        },
+        "/api/admin/online-user": {
+            "get": {
+                "description": "获取当前所有在线用户信息",
                 "consumes": [
                     "application/json"
                 ],
@@ -7775,12 +8525,28 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "角色管理"
+                    "在线用户管理"
+                ],
+                "summary": "获取在线用户列表",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每页数量",
+                        "name": "page_size",
+                        "in": "query",
+                        "required": true
+                    }
                 ],
-                "summary": "获取所有角色",
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "在线用户列表",
                         "schema": {
                             "allOf": [
                                 {
@@ -7790,27 +8556,54 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/response.RoleResponse"
-                                            }
+                                            "$ref": "#/definitions/response.OnlineUserListResponse"
+                                        },
+                                        "msg": {
+                                            "type": "string"
                                         }
                                     }
                                 }
                             ]
                         }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
                     }
-                }
-            }
-        },
-        "/api/admin/role/assign-api-routes": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "分配API路由到角色",
+                ]
+            }
+        },
+        "/api/admin/online-user/:user_id": {
+            "get": {
+                "description": "根据用户 ID 获取在线用户详细信息，返回该用户全部登录设备的会话",
                 "consumes": [
                     "application/json"
                 ],
@@ -7818,38 +8611,88 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "角色管理"
+                    "在线用户管理"
                 ],
-                "summary": "分配API路由到角色",
+                "summary": "获取在线用户详情",
                 "parameters": [
                     {
-                        "description": "角色ID和API路由ID列表",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.AssignToRoleRequest"
-                        }
+                        "type": "string",
+                        "description": "用户 ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "在线用户详情",
                         "schema": {
-                            "$ref": "#/definitions/baseRes.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/baseRes.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/response.OnlineUserResponse"
+                                            }
+                                        },
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "用户不在线",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
-                }
-            }
-        },
-        "/api/admin/role/assign-menus": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "分配菜单到角色",
+                ]
+            },
+            "delete": {
+                "description": "强制指定用户下线（管理员权限），用户全部会话的令牌立即失效，并记录审计",
                 "consumes": [
                     "application/json"
                 ],
@@ -7857,99 +8700,27 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "角色管理"
+                    "在线用户管理"
                 ],
-                "summary": "分配菜单到角色",
-                "parameters": [
-                    {
-                        "description": "角色ID和菜单ID列表",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.AssignMenuToRoleRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/baseRes.Response"
-                        }
-                    }
-                }
-            }
-        },
-        "/api/admin/role/assign-users": {
-            "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "分配用户到角色",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "角色管理"
-                ],
-                "summary": "分配用户到角色",
-                "parameters": [
-                    {
-                        "description": "角色ID和用户ID列表",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.AssignUserToRoleRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/baseRes.Response"
-                        }
-                    }
-                }
-            }
-        },
-        "/api/admin/role/detail": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "根据 ID 获取角色详情",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "角色管理"
-                ],
-                "summary": "根据 ID 获取角色",
+                "summary": "强制用户下线",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "角色 ID",
-                        "name": "id",
-                        "in": "query",
+                        "description": "用户 ID",
+                        "name": "user_id",
+                        "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "下线原因",
+                        "name": "reason",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "强制下线成功",
                         "schema": {
                             "allOf": [
                                 {
@@ -7958,25 +8729,61 @@ const docTemplate = `{
                                 {
                                     "type": "object",
                                     "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/response.RoleDetailResponse"
+                                        "msg": {
+                                            "type": "string"
                                         }
                                     }
                                 }
                             ]
                         }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "用户不在线",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
                     }
-                }
-            }
-        },
-        "/api/admin/roles/:roleId/permission-logs": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "获取指定角色的权限变更历史",
+                ]
+            }
+        },
+        "/api/admin/online-user/audit-logs": {
+            "get": {
+                "description": "分页获取强制下线、下线登录设备等会话终止记录，包含操作人、原因和时间",
                 "consumes": [
                     "application/json"
                 ],
@@ -7984,17 +8791,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "权限日志管理"
+                    "在线用户管理"
                 ],
-                "summary": "获取角色权限日志",
+                "summary": "获取会话终止审计记录",
                 "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "角色 ID",
-                        "name": "role_id",
-                        "in": "path",
-                        "required": true
-                    },
                     {
                         "type": "integer",
                         "description": "页码",
@@ -8005,14 +8805,33 @@ const docTemplate = `{
                     {
                         "type": "integer",
                         "description": "每页数量",
-                        "name": "page_size",
+                        "name": "pageSize",
                         "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "用户 ID",
+                        "name": "userId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "用户名",
+                        "name": "userName",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "终止方式：kickout、revoke、kick_oldest",
+                        "name": "action",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "角色权限日志列表",
+                        "description": "审计记录列表",
                         "schema": {
                             "allOf": [
                                 {
@@ -8022,20 +8841,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "allOf": [
-                                                {
-                                                    "$ref": "#/definitions/baseRes.PageResult"
-                                                },
-                                                {
-                                                    "type": "object",
-                                                    "properties": {
-                                                        "list": {
-                                                            "type": "array",
-                                                            "items": {}
-                                                        }
-                                                    }
-                                                }
-                                            ]
+                                            "$ref": "#/definitions/baseRes.PageResult"
                                         },
                                         "msg": {
                                             "type": "string"
@@ -8072,17 +8878,17 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
-            }
-        },
-        "/api/admin/task": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "获取所有任务列表（管理员权限）",
+                ]
+            }
+        },
+        "/api/admin/online-user/batch": {
+            "post": {
+                "description": "批量强制指定用户下线（管理员权限），任一用户下线失败时全部不下线",
                 "consumes": [
                     "application/json"
                 ],
@@ -8090,19 +8896,45 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "任务管理"
+                    "在线用户管理"
+                ],
+                "summary": "批量强制用户下线",
+                "parameters": [
+                    {
+                        "description": "批量下线请求",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.BatchForceOfflineRequest"
+                        }
+                    }
                 ],
-                "summary": "获取所有任务",
                 "responses": {
                     "200": {
-                        "description": "任务列表",
+                        "description": "批量强制下线成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/baseRes.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
-                                "type": "array",
-                                "items": {
-                                    "$ref": "#/definitions/response.TaskResponse"
-                                }
+                                "type": "string"
                             }
                         }
                     },
@@ -8115,8 +8947,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "403": {
-                        "description": "无权限",
+                    "500": {
+                        "description": "服务器内部错误",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -8124,15 +8956,17 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
-            },
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "创建一个新的任务（管理员权限）",
+                ]
+            }
+        },
+        "/api/admin/online-user/count": {
+            "get": {
+                "description": "获取当前在线用户总数",
                 "consumes": [
                     "application/json"
                 ],
@@ -8140,37 +8974,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "任务管理"
-                ],
-                "summary": "创建任务",
-                "parameters": [
-                    {
-                        "description": "创建任务请求参数",
-                        "name": "task",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.AddTaskRequest"
-                        }
-                    }
+                    "在线用户管理"
                 ],
+                "summary": "获取在线用户数量",
                 "responses": {
-                    "201": {
-                        "description": "任务创建成功",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "$ref": "#/definitions/response.TaskResponse"
-                            }
-                        }
-                    },
-                    "400": {
-                        "description": "请求参数错误",
+                    "200": {
+                        "description": "在线用户数量",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/baseRes.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "integer",
+                                            "format": "int64"
+                                        },
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "401": {
@@ -8182,15 +9009,6 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "403": {
-                        "description": "无权限",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
                     "500": {
                         "description": "服务器内部错误",
                         "schema": {
@@ -8200,17 +9018,17 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
-            }
-        },
-        "/api/admin/task/:id": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "根据 ID 获取单个任务详情（管理员权限）",
+                ]
+            }
+        },
+        "/api/admin/online-user/online": {
+            "get": {
+                "description": "根据用户 ID 检查用户是否在线",
                 "consumes": [
                     "application/json"
                 ],
@@ -8218,30 +9036,43 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "任务管理"
+                    "在线用户管理"
                 ],
-                "summary": "获取单个任务",
+                "summary": "检查用户是否在线",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "任务 ID",
-                        "name": "id",
-                        "in": "path",
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "用户 ID",
+                        "name": "user_id",
+                        "in": "query",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "任务详情",
+                        "description": "是否在线",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "$ref": "#/definitions/response.TaskResponse"
-                            }
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/baseRes.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "boolean"
+                                        },
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
-                    "401": {
-                        "description": "未授权",
+                    "400": {
+                        "description": "请求参数错误",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -8249,8 +9080,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "403": {
-                        "description": "无权限",
+                    "401": {
+                        "description": "未授权",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -8258,8 +9089,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "404": {
-                        "description": "任务不存在",
+                    "500": {
+                        "description": "服务器内部错误",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -8267,15 +9098,17 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
-            },
-            "delete": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "根据ID移除一个任务（管理员权限）",
+                ]
+            }
+        },
+        "/api/admin/permission-changes": {
+            "get": {
+                "description": "分页获取需要审批的角色产生的权限变更申请，可按角色、变更类型和状态筛选",
                 "consumes": [
                     "application/json"
                 ],
@@ -8283,39 +9116,67 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "任务管理"
+                    "权限变更审批"
                 ],
-                "summary": "移除任务",
+                "summary": "获取权限变更申请列表",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "任务ID",
-                        "name": "id",
-                        "in": "path",
+                        "type": "integer",
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每页数量",
+                        "name": "pageSize",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "角色 ID",
+                        "name": "roleId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "变更类型 (role_users、role_menus、role_apis、role_btn_perms、approval_required、policy_bundle)",
+                        "name": "changeType",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "状态 (pending、approved、rejected)",
+                        "name": "status",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "任务移除成功",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
-                    "401": {
-                        "description": "未授权",
+                        "description": "申请列表",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/baseRes.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.PermissionChangeListResponse"
+                                        },
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
-                    "403": {
-                        "description": "无权限",
+                    "400": {
+                        "description": "请求参数错误",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -8323,8 +9184,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "500": {
-                        "description": "服务器内部错误",
+                    "401": {
+                        "description": "未授权",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -8332,17 +9193,17 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
-            }
-        },
-        "/api/admin/task/:id/execution-logs": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "根据任务 ID 查询任务执行历史记录（管理员权限）",
+                ]
+            }
+        },
+        "/api/admin/permission-changes/{id}": {
+            "get": {
+                "description": "获取变更申请的完整差异：提交时的关联、新增、移除以及生效后的关联",
                 "consumes": [
                     "application/json"
                 ],
@@ -8350,42 +9211,42 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "任务管理"
+                    "权限变更审批"
                 ],
-                "summary": "查询任务执行历史",
+                "summary": "获取权限变更申请详情",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "任务 ID",
+                        "description": "申请 ID",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "页码",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "每页数量",
-                        "name": "pageSize",
-                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "执行日志列表",
+                        "description": "申请详情",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "$ref": "#/definitions/response.TaskExecutionLogsResponse"
-                            }
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/baseRes.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.PermissionChangeResponse"
+                                        },
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
-                    "401": {
-                        "description": "未授权",
+                    "400": {
+                        "description": "请求参数错误",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -8393,8 +9254,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "403": {
-                        "description": "无权限",
+                    "401": {
+                        "description": "未授权",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -8402,17 +9263,17 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
-            }
-        },
-        "/api/admin/task/:id/group": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "设置指定任务的分组（管理员权限）",
+                ]
+            }
+        },
+        "/api/admin/permission-changes/{id}/approve": {
+            "post": {
+                "description": "审批通过后变更立即生效并写入权限审计日志；申请人不能审批自己提交的申请，审批人须有该接口的权限；变更和申请状态在同一事务中写入",
                 "consumes": [
                     "application/json"
                 ],
@@ -8420,35 +9281,46 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "任务管理"
+                    "权限变更审批"
                 ],
-                "summary": "设置任务分组",
+                "summary": "通过权限变更申请",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "任务 ID",
+                        "description": "申请 ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "分组信息",
-                        "name": "task",
+                        "description": "审批意见",
+                        "name": "data",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/request.SetTaskGroupRequest"
+                            "$ref": "#/definitions/request.ReviewPermissionChangeRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "设置成功",
+                        "description": "审批结果",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/baseRes.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.PermissionChangeResponse"
+                                        },
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -8468,36 +9340,18 @@ const docTemplate = `{
                                 "type": "string"
                             }
                         }
-                    },
-                    "403": {
-                        "description": "无权限",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
-                    "404": {
-                        "description": "任务不存在",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
                     }
-                }
-            }
-        },
-        "/api/admin/task/:id/retry": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "根据ID重试一个失败的任务（管理员权限）",
+                ]
+            }
+        },
+        "/api/admin/permission-changes/{id}/reject": {
+            "post": {
+                "description": "拒绝后变更不会生效；申请人不能审批自己提交的申请，审批人须有该接口的权限",
                 "consumes": [
                     "application/json"
                 ],
@@ -8505,39 +9359,50 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "任务管理"
+                    "权限变更审批"
                 ],
-                "summary": "重试任务",
+                "summary": "拒绝权限变更申请",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "任务ID",
+                        "description": "申请 ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "审批意见",
+                        "name": "data",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/request.ReviewPermissionChangeRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "任务重试触发成功",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
-                    "401": {
-                        "description": "未授权",
+                        "description": "审批结果",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/baseRes.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.PermissionChangeResponse"
+                                        },
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
-                    "403": {
-                        "description": "无权限",
+                    "400": {
+                        "description": "请求参数错误",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -8545,8 +9410,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "500": {
-                        "description": "服务器内部错误",
+                    "401": {
+                        "description": "未授权",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -8554,17 +9419,17 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
-            }
-        },
-        "/api/admin/task/:id/start": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "根据ID启动一个任务（管理员权限）",
+                ]
+            }
+        },
+        "/api/admin/permission-logs": {
+            "get": {
+                "description": "获取系统权限变更日志（支持分页和筛选）",
                 "consumes": [
                     "application/json"
                 ],
@@ -8572,30 +9437,99 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "任务管理"
+                    "权限日志管理"
                 ],
-                "summary": "启动任务",
+                "summary": "获取权限日志列表",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "任务ID",
-                        "name": "id",
-                        "in": "path",
+                        "type": "integer",
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每页数量",
+                        "name": "page_size",
+                        "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "用户 ID",
+                        "name": "user_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "用户名",
+                        "name": "userName",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "操作类型",
+                        "name": "operation",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "模块",
+                        "name": "module",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "开始日期（YYYY-MM-DD）",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "结束日期（YYYY-MM-DD）",
+                        "name": "end_date",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "任务启动成功",
+                        "description": "权限日志列表",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/baseRes.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/baseRes.PageResult"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "list": {
+                                                            "type": "array",
+                                                            "items": {}
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        },
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
-                    "401": {
-                        "description": "未授权",
+                    "400": {
+                        "description": "请求参数错误",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -8603,8 +9537,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "403": {
-                        "description": "无权限",
+                    "401": {
+                        "description": "未授权",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -8621,17 +9555,17 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
-            }
-        },
-        "/api/admin/task/:id/stop": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "根据ID停止一个任务（管理员权限）",
+                ]
+            }
+        },
+        "/api/admin/permission-rules": {
+            "get": {
+                "description": "分页获取 ABAC 权限规则列表，按排序升序",
                 "consumes": [
                     "application/json"
                 ],
@@ -8639,88 +9573,67 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "任务管理"
+                    "权限规则"
                 ],
-                "summary": "停止任务",
+                "summary": "获取权限规则列表",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "任务ID",
-                        "name": "id",
-                        "in": "path",
+                        "type": "integer",
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每页数量",
+                        "name": "pageSize",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "效果 (allow、deny)",
+                        "name": "effect",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "状态 (0:禁用，1:启用)",
+                        "name": "status",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "任务停止成功",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
-                    "401": {
-                        "description": "未授权",
+                        "description": "规则列表",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
-                    "403": {
-                        "description": "无权限",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/baseRes.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.PermissionRuleListResponse"
+                                        },
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
-                    "500": {
-                        "description": "服务器内部错误",
+                    "400": {
+                        "description": "请求参数错误",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
                                 "type": "string"
                             }
                         }
-                    }
-                }
-            }
-        },
-        "/api/admin/task/statistics": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "获取所有任务的执行统计信息（管理员权限）",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "任务管理"
-                ],
-                "summary": "获取任务统计",
-                "responses": {
-                    "200": {
-                        "description": "任务统计列表",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "array",
-                                "items": {
-                                    "$ref": "#/definitions/response.TaskStatisticsResponse"
-                                }
-                            }
-                        }
                     },
                     "401": {
                         "description": "未授权",
@@ -8730,27 +9643,16 @@ const docTemplate = `{
                                 "type": "string"
                             }
                         }
-                    },
-                    "403": {
-                        "description": "无权限",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
                     }
-                }
-            }
-        },
-        "/api/admin/user": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "获取用户列表（支持分页和筛选）",
+                ]
+            },
+            "post": {
+                "description": "创建 ABAC 权限规则，保存前校验路径模式、方法和条件表达式\n条件支持 all、any、not 组合，操作符 eq、ne、in、not_in、contains、lt、lte、gt、gte、regex、cidr、time_window、exists\n属性必须带 user.、resource.、env. 前缀，如 {\"all\":[{\"attr\":\"user.roles\",\"op\":\"contains\",\"value\":\"finance\"},{\"attr\":\"env.ip\",\"op\":\"cidr\",\"value\":[\"10.0.0.0/8\"]}]}",
                 "consumes": [
                     "application/json"
                 ],
@@ -8758,52 +9660,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "用户管理"
+                    "权限规则"
                 ],
-                "summary": "获取用户列表",
+                "summary": "创建权限规则",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "页码",
-                        "name": "page",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "每页数量",
-                        "name": "page_size",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "用户名",
-                        "name": "userName",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "邮箱",
-                        "name": "email",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "角色",
-                        "name": "role",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "状态",
-                        "name": "status",
-                        "in": "query"
+                        "description": "规则信息",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CreatePermissionRuleRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "用户列表",
+                        "description": "创建成功",
                         "schema": {
                             "allOf": [
                                 {
@@ -8813,22 +9686,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "allOf": [
-                                                {
-                                                    "$ref": "#/definitions/baseRes.PageResult"
-                                                },
-                                                {
-                                                    "type": "object",
-                                                    "properties": {
-                                                        "list": {
-                                                            "type": "array",
-                                                            "items": {
-                                                                "$ref": "#/definitions/entity.User"
-                                                            }
-                                                        }
-                                                    }
-                                                }
-                                            ]
+                                            "$ref": "#/definitions/response.PermissionRuleResponse"
                                         },
                                         "msg": {
                                             "type": "string"
@@ -8838,8 +9696,8 @@ const docTemplate = `{
                             ]
                         }
                     },
-                    "401": {
-                        "description": "未授权",
+                    "400": {
+                        "description": "请求参数错误",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -8847,8 +9705,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "500": {
-                        "description": "服务器内部错误",
+                    "401": {
+                        "description": "未授权",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -8856,15 +9714,17 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
-            },
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "创建新用户账户",
+                ]
+            }
+        },
+        "/api/admin/permission-rules/validate": {
+            "post": {
+                "description": "编译条件表达式但不保存，返回第一个错误及其位置，供编辑规则时实时校验",
                 "consumes": [
                     "application/json"
                 ],
@@ -8872,23 +9732,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "用户管理"
+                    "权限规则"
                 ],
-                "summary": "创建用户",
+                "summary": "校验权限规则条件",
                 "parameters": [
                     {
-                        "description": "创建用户请求参数",
-                        "name": "create",
+                        "description": "条件表达式",
+                        "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/request.AddUserRequest"
+                            "$ref": "#/definitions/request.ValidatePermissionRuleConditionsRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "创建成功",
+                    "200": {
+                        "description": "条件有效",
                         "schema": {
                             "allOf": [
                                 {
@@ -8897,8 +9757,8 @@ const docTemplate = `{
                                 {
                                     "type": "object",
                                     "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/response.UserResponse"
+                                        "msg": {
+                                            "type": "string"
                                         }
                                     }
                                 }
@@ -8922,27 +9782,18 @@ const docTemplate = `{
                                 "type": "string"
                             }
                         }
-                    },
-                    "500": {
-                        "description": "服务器内部错误",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
                     }
-                }
-            }
-        },
-        "/api/admin/user/get-user-settings": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "获取当前登录用户的系统设置",
+                ]
+            }
+        },
+        "/api/admin/permission-rules/{id}": {
+            "get": {
+                "description": "根据 ID 获取 ABAC 权限规则详情",
                 "consumes": [
                     "application/json"
                 ],
@@ -8950,12 +9801,21 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "用户管理"
+                    "权限规则"
+                ],
+                "summary": "获取权限规则详情",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "规则 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "获取用户设置",
                 "responses": {
                     "200": {
-                        "description": "用户设置信息",
+                        "description": "规则详情",
                         "schema": {
                             "allOf": [
                                 {
@@ -8965,10 +9825,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "object",
-                                            "additionalProperties": {
-                                                "$ref": "#/definitions/entity.UserSetting"
-                                            }
+                                            "$ref": "#/definitions/response.PermissionRuleResponse"
                                         },
                                         "msg": {
                                             "type": "string"
@@ -8978,8 +9835,8 @@ const docTemplate = `{
                             ]
                         }
                     },
-                    "401": {
-                        "description": "未授权",
+                    "400": {
+                        "description": "请求参数错误",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -8987,8 +9844,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "500": {
-                        "description": "服务器内部错误",
+                    "401": {
+                        "description": "未授权",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -8996,17 +9853,15 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
-            }
-        },
-        "/api/admin/user/info": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "获取当前登录用户的详细信息",
+                ]
+            },
+            "put": {
+                "description": "更新 ABAC 权限规则，保存前校验条件表达式，保存后立即生效",
                 "consumes": [
                     "application/json"
                 ],
@@ -9014,12 +9869,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "用户管理"
+                    "权限规则"
+                ],
+                "summary": "更新权限规则",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "规则 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "规则信息",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.UpdatePermissionRuleRequest"
+                        }
+                    }
                 ],
-                "summary": "获取用户信息",
                 "responses": {
                     "200": {
-                        "description": "用户信息",
+                        "description": "更新成功",
                         "schema": {
                             "allOf": [
                                 {
@@ -9028,9 +9901,6 @@ const docTemplate = `{
                                 {
                                     "type": "object",
                                     "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/response.UserInfoResponse"
-                                        },
                                         "msg": {
                                             "type": "string"
                                         }
@@ -9039,8 +9909,8 @@ const docTemplate = `{
                             ]
                         }
                     },
-                    "401": {
-                        "description": "未授权",
+                    "400": {
+                        "description": "请求参数错误",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -9048,8 +9918,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "500": {
-                        "description": "服务器内部错误",
+                    "401": {
+                        "description": "未授权",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -9057,15 +9927,15 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
-            },
-            "put": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "更新用户的基本信息",
+                ]
+            },
+            "delete": {
+                "description": "删除 ABAC 权限规则，系统规则不能删除",
                 "consumes": [
                     "application/json"
                 ],
@@ -9073,23 +9943,21 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "用户管理"
+                    "权限规则"
                 ],
-                "summary": "更新用户信息",
+                "summary": "删除权限规则",
                 "parameters": [
                     {
-                        "description": "更新用户请求参数",
-                        "name": "update_user",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.UpdateUserRequest"
-                        }
+                        "type": "string",
+                        "description": "规则 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "更新成功",
+                        "description": "删除成功",
                         "schema": {
                             "allOf": [
                                 {
@@ -9098,9 +9966,6 @@ const docTemplate = `{
                                 {
                                     "type": "object",
                                     "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/entity.User"
-                                        },
                                         "msg": {
                                             "type": "string"
                                         }
@@ -9126,27 +9991,18 @@ const docTemplate = `{
                                 "type": "string"
                             }
                         }
-                    },
-                    "500": {
-                        "description": "服务器内部错误",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
                     }
-                }
-            }
-        },
-        "/api/admin/user/password": {
-            "put": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "修改当前用户的密码",
+                ]
+            }
+        },
+        "/api/admin/permissions/explain": {
+            "post": {
+                "description": "按当前数据库中的权限配置重放访问判定，返回参与判定的角色（含继承角色）、角色和权限组的授权情况、用户特殊权限、ABAC 规则的条件求值过程以及最终结果\n填写 buttonCode 时解释按钮权限，否则解释 method + path 的 API 访问权限；返回的 cached 为当前缓存的判定结果，与 allowed 不一致说明缓存尚未失效",
                 "consumes": [
                     "application/json"
                 ],
@@ -9154,23 +10010,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "用户管理"
+                    "权限解释"
                 ],
-                "summary": "修改密码",
+                "summary": "解释权限判定过程",
                 "parameters": [
                     {
-                        "description": "修改密码请求参数",
-                        "name": "change_password",
+                        "description": "解释参数",
+                        "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/request.ChangePasswordRequest"
+                            "$ref": "#/definitions/request.ExplainPermissionRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "修改成功",
+                        "description": "判定过程",
                         "schema": {
                             "allOf": [
                                 {
@@ -9179,6 +10035,9 @@ const docTemplate = `{
                                 {
                                     "type": "object",
                                     "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.PermissionExplanationResponse"
+                                        },
                                         "msg": {
                                             "type": "string"
                                         }
@@ -9204,27 +10063,18 @@ const docTemplate = `{
                                 "type": "string"
                             }
                         }
-                    },
-                    "500": {
-                        "description": "服务器内部错误",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
                     }
-                }
-            }
-        },
-        "/api/admin/user/reset-password": {
-            "put": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "重置用户密码为默认密码123456（管理员权限）",
+                ]
+            }
+        },
+        "/api/admin/permissions/simulate": {
+            "post": {
+                "description": "按拟议的权限变更解释访问判定，变更不会保存：userRoleIds 替换用户的角色分配，roles 替换指定角色的父角色、状态、API、按钮权限或权限组，未填写的字段使用当前配置",
                 "consumes": [
                     "application/json"
                 ],
@@ -9232,23 +10082,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "用户管理"
+                    "权限解释"
                 ],
-                "summary": "重置密码",
+                "summary": "模拟权限变更",
                 "parameters": [
                     {
-                        "description": "重置密码请求参数",
-                        "name": "reset_password",
+                        "description": "模拟参数",
+                        "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/request.ResetPasswordRequest"
+                            "$ref": "#/definitions/request.SimulatePermissionRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "重置成功",
+                        "description": "模拟判定过程",
                         "schema": {
                             "allOf": [
                                 {
@@ -9257,6 +10107,9 @@ const docTemplate = `{
                                 {
                                     "type": "object",
                                     "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.PermissionExplanationResponse"
+                                        },
                                         "msg": {
                                             "type": "string"
                                         }
@@ -9282,27 +10135,18 @@ const docTemplate = `{
                                 "type": "string"
                             }
                         }
-                    },
-                    "500": {
-                        "description": "服务器内部错误",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
                     }
-                }
-            }
-        },
-        "/api/admin/user/setUserAuthorities": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "为用户设置多个角色权限",
+                ]
+            }
+        },
+        "/api/admin/policy-bundle/export": {
+            "get": {
+                "description": "将角色及其继承关系、菜单、API、按钮权限、权限组和 ABAC 规则导出为以编码引用的策略包，便于纳入 git 评审",
                 "consumes": [
                     "application/json"
                 ],
@@ -9310,23 +10154,20 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "用户管理"
+                    "权限策略包"
                 ],
-                "summary": "设置用户权限（多角色）",
+                "summary": "导出权限策略包",
                 "parameters": [
                     {
-                        "description": "权限设置信息",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.SetUserAuthoritiesRequest"
-                        }
+                        "type": "string",
+                        "description": "文件格式 (yaml、json)，默认 yaml",
+                        "name": "format",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "设置成功",
+                        "description": "策略包内容",
                         "schema": {
                             "allOf": [
                                 {
@@ -9335,6 +10176,9 @@ const docTemplate = `{
                                 {
                                     "type": "object",
                                     "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.PolicyBundleExportResponse"
+                                        },
                                         "msg": {
                                             "type": "string"
                                         }
@@ -9360,27 +10204,18 @@ const docTemplate = `{
                                 "type": "string"
                             }
                         }
-                    },
-                    "500": {
-                        "description": "服务器内部错误",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
                     }
-                }
-            }
-        },
-        "/api/admin/user/setUserAuthority": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "为用户设置单个角色权限",
+                ]
+            }
+        },
+        "/api/admin/policy-bundle/import": {
+            "post": {
+                "description": "按编码比较策略包与当前环境的差异；dryRun 为 true 时只返回差异，否则在单个事务中写入。策略包外的对象保持不变；差异影响开启权限变更审批的角色（包括通过其引用的菜单、按钮权限和 API）时不直接写入，而是提交权限变更申请并返回申请内容",
                 "consumes": [
                     "application/json"
                 ],
@@ -9388,23 +10223,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "用户管理"
+                    "权限策略包"
                 ],
-                "summary": "设置用户权限（单角色）",
+                "summary": "导入权限策略包",
                 "parameters": [
                     {
-                        "description": "权限设置信息",
+                        "description": "策略包内容",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/request.SetUserAuthorityRequest"
+                            "$ref": "#/definitions/request.ImportPolicyBundleRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "设置成功",
+                        "description": "导入结果",
                         "schema": {
                             "allOf": [
                                 {
@@ -9413,6 +10248,9 @@ const docTemplate = `{
                                 {
                                     "type": "object",
                                     "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.PolicyImportResultResponse"
+                                        },
                                         "msg": {
                                             "type": "string"
                                         }
@@ -9438,27 +10276,18 @@ const docTemplate = `{
                                 "type": "string"
                             }
                         }
-                    },
-                    "500": {
-                        "description": "服务器内部错误",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
                     }
-                }
-            }
-        },
-        "/api/admin/user/switch-role": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "切换当前用户的活动角色（不修改用户角色关联关系）",
+                ]
+            }
+        },
+        "/api/admin/position": {
+            "get": {
+                "description": "获取岗位列表（支持分页和筛选）",
                 "consumes": [
                     "application/json"
                 ],
@@ -9466,23 +10295,34 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "用户管理"
+                    "岗位管理"
                 ],
-                "summary": "切换用户角色",
+                "summary": "获取岗位列表",
                 "parameters": [
                     {
-                        "description": "切换角色请求参数",
-                        "name": "switch_role",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.SwitchRoleRequest"
-                        }
+                        "type": "integer",
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每页数量",
+                        "name": "page_size",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "状态 (0:禁用，1:启用)",
+                        "name": "status",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "切换成功",
+                        "description": "岗位列表",
                         "schema": {
                             "allOf": [
                                 {
@@ -9491,6 +10331,9 @@ const docTemplate = `{
                                 {
                                     "type": "object",
                                     "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.PositionListResponse"
+                                        },
                                         "msg": {
                                             "type": "string"
                                         }
@@ -9499,15 +10342,6 @@ const docTemplate = `{
                             ]
                         }
                     },
-                    "400": {
-                        "description": "请求参数错误",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
                     "401": {
                         "description": "未授权",
                         "schema": {
@@ -9526,17 +10360,15 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
-            }
-        },
-        "/api/admin/user/update-user-settings": {
-            "put": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "更新当前登录用户的系统设置",
+                ]
+            },
+            "put": {
+                "description": "更新岗位信息",
                 "consumes": [
                     "application/json"
                 ],
@@ -9544,17 +10376,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "用户管理"
+                    "岗位管理"
                 ],
-                "summary": "更新用户设置",
+                "summary": "更新岗位",
                 "parameters": [
                     {
-                        "description": "用户设置参数",
-                        "name": "user_setting",
+                        "description": "岗位信息",
+                        "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/entity.UserSetting"
+                            "$ref": "#/definitions/request.UpdatePositionRequest"
                         }
                     }
                 ],
@@ -9570,10 +10402,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "object",
-                                            "additionalProperties": {
-                                                "$ref": "#/definitions/entity.UserSetting"
-                                            }
+                                            "$ref": "#/definitions/response.PositionResponse"
                                         },
                                         "msg": {
                                             "type": "string"
@@ -9610,17 +10439,15 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
-            }
-        },
-        "/api/admin/user/{id}": {
-            "delete": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "删除用户（管理员权限）",
+                ]
+            },
+            "post": {
+                "description": "创建新的岗位",
                 "consumes": [
                     "application/json"
                 ],
@@ -9628,21 +10455,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "用户管理"
+                    "岗位管理"
                 ],
-                "summary": "删除用户",
+                "summary": "创建岗位",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "用户ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "删除成功",
+                        "description": "岗位信息",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CreatePositionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "创建成功",
                         "schema": {
                             "allOf": [
                                 {
@@ -9651,6 +10480,9 @@ const docTemplate = `{
                                 {
                                     "type": "object",
                                     "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.PositionResponse"
+                                        },
                                         "msg": {
                                             "type": "string"
                                         }
@@ -9686,17 +10518,17 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
-            }
-        },
-        "/api/payment": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "创建一笔新的支付订单",
+                ]
+            }
+        },
+        "/api/admin/position/:id": {
+            "get": {
+                "description": "根据 ID 获取岗位详细信息",
                 "consumes": [
                     "application/json"
                 ],
@@ -9704,28 +10536,38 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "支付管理"
+                    "岗位管理"
                 ],
-                "summary": "创建支付",
+                "summary": "获取岗位详情",
                 "parameters": [
                     {
-                        "description": "创建支付请求参数",
-                        "name": "payment",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.CreatePaymentRequest"
-                        }
+                        "type": "integer",
+                        "description": "岗位 ID",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "支付创建成功",
+                    "200": {
+                        "description": "岗位详情",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "$ref": "#/definitions/response.PaymentResponse"
-                            }
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/baseRes.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.PositionResponse"
+                                        },
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -9755,12 +10597,15 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
-            }
-        },
-        "/api/wx/auth/login": {
-            "post": {
-                "description": "使用微信授权码登录获取用户信息和令牌",
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "delete": {
+                "description": "删除岗位（管理员权限）",
                 "consumes": [
                     "application/json"
                 ],
@@ -9768,23 +10613,21 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "微信认证"
+                    "岗位管理"
                 ],
-                "summary": "微信授权码登录",
+                "summary": "删除岗位",
                 "parameters": [
                     {
-                        "description": "微信授权码登录请求参数",
-                        "name": "code",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.WechatLoginRequest"
-                        }
+                        "type": "string",
+                        "description": "岗位 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "登录成功，包含用户信息和令牌",
+                        "description": "删除成功",
                         "schema": {
                             "allOf": [
                                 {
@@ -9793,9 +10636,6 @@ const docTemplate = `{
                                 {
                                     "type": "object",
                                     "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/response.WXLoginResponse"
-                                        },
                                         "msg": {
                                             "type": "string"
                                         }
@@ -9814,7 +10654,16 @@ const docTemplate = `{
                         }
                     },
                     "401": {
-                        "description": "认证失败",
+                        "description": "未授权",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -9822,12 +10671,17 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
-        "/api/wx/auth/refresh-token": {
-            "post": {
-                "description": "使用 refresh token 获取新的 access token 和 refresh token",
+        "/api/admin/position/all": {
+            "get": {
+                "description": "获取所有岗位，用于前端选择岗位选项",
                 "consumes": [
                     "application/json"
                 ],
@@ -9835,23 +10689,12 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "微信认证"
-                ],
-                "summary": "刷新令牌",
-                "parameters": [
-                    {
-                        "description": "刷新令牌请求参数",
-                        "name": "refresh",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.WechatRefreshTokenRequest"
-                        }
-                    }
+                    "岗位管理"
                 ],
+                "summary": "获取所有岗位",
                 "responses": {
                     "200": {
-                        "description": "刷新成功，包含新的令牌",
+                        "description": "岗位列表",
                         "schema": {
                             "allOf": [
                                 {
@@ -9861,7 +10704,10 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/response.WXLoginResponse"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/response.PositionResponse"
+                                            }
                                         },
                                         "msg": {
                                             "type": "string"
@@ -9871,8 +10717,8 @@ const docTemplate = `{
                             ]
                         }
                     },
-                    "400": {
-                        "description": "请求参数错误",
+                    "401": {
+                        "description": "未授权",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -9880,8 +10726,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "401": {
-                        "description": "认证失败",
+                    "500": {
+                        "description": "服务器内部错误",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -9889,803 +10735,8644 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
-            }
-        }
-    },
-    "definitions": {
-        "baseRes.PageResult": {
-            "type": "object",
-            "properties": {
-                "list": {},
-                "page": {
-                    "type": "integer"
-                },
-                "pageSize": {
-                    "type": "integer"
-                },
-                "total": {
-                    "type": "integer"
-                }
-            }
-        },
-        "baseRes.Response": {
-            "type": "object",
-            "properties": {
-                "code": {
-                    "type": "integer"
                 },
-                "data": {},
-                "msg": {
-                    "type": "string"
-                }
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
-        "entity.API": {
-            "type": "object",
-            "properties": {
-                "authRequired": {
-                    "description": "是否需要认证",
-                    "type": "boolean"
-                },
-                "authType": {
-                    "description": "授权类型：0-不需要授权（只要登录），1-需要授权（需要角色权限）",
-                    "type": "integer"
-                },
-                "btnPermIds": {
-                    "description": "关联的按钮权限 ID 列表",
-                    "type": "array",
-                    "items": {
+        "/api/admin/role": {
+            "get": {
+                "description": "获取角色列表",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "角色管理"
+                ],
+                "summary": "获取角色列表",
+                "parameters": [
+                    {
                         "type": "integer",
-                        "format": "int64"
-                    }
-                },
-                "createdAt": {
-                    "description": "创建时间",
-                    "type": "string"
-                },
-                "createdBy": {
-                    "description": "创建人 ID",
-                    "type": "integer",
-                    "format": "int64"
-                },
-                "description": {
-                    "description": "描述",
-                    "type": "string"
-                },
-                "group": {
-                    "description": "路由分组",
-                    "type": "string"
-                },
-                "id": {
-                    "description": "API ID",
-                    "type": "integer",
-                    "format": "int64"
-                },
-                "menuIds": {
-                    "description": "关联的菜单 ID 列表",
-                    "type": "array",
-                    "items": {
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
                         "type": "integer",
-                        "format": "int64"
+                        "description": "每页数量",
+                        "name": "pageSize",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "角色名称",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "状态(-1:全部,0:禁用,1:启用)",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/baseRes.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.RoleListResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "put": {
+                "description": "更新角色信息",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "角色管理"
+                ],
+                "summary": "更新角色",
+                "parameters": [
+                    {
+                        "description": "角色信息",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.UpdateRoleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/baseRes.Response"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "post": {
+                "description": "创建新的角色",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "角色管理"
+                ],
+                "summary": "创建角色",
+                "parameters": [
+                    {
+                        "description": "角色信息",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CreateRoleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/baseRes.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.RoleResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "delete": {
+                "description": "删除角色",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "角色管理"
+                ],
+                "summary": "删除角色",
+                "parameters": [
+                    {
+                        "description": "角色ID",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.DeleteRoleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/baseRes.Response"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/admin/role-grants": {
+            "get": {
+                "description": "分页获取限时角色授予和提权申请，可按用户、角色和状态筛选",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "限时角色授予"
+                ],
+                "summary": "获取限时授予列表",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每页数量",
+                        "name": "pageSize",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "用户 ID",
+                        "name": "userId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "角色 ID",
+                        "name": "roleId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "状态 (pending、scheduled、active、rejected、expired、revoked)",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "授予列表",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/baseRes.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.RoleGrantListResponse"
+                                        },
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "post": {
+                "description": "在有效期内将用户加入角色，到期后由定时任务自动收回；生效时间为空时立即生效\n用户已长期拥有该角色或已有未结束的限时授予时不能授予",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "限时角色授予"
+                ],
+                "summary": "授予限时角色",
+                "parameters": [
+                    {
+                        "description": "授予参数",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.GrantRoleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "授予结果",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/baseRes.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.RoleGrantResponse"
+                                        },
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/admin/role-grants/elevation": {
+            "post": {
+                "description": "当前用户申请在指定时长内拥有某个角色，审批通过后立即生效，到期自动收回",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "限时角色授予"
+                ],
+                "summary": "申请临时提权",
+                "parameters": [
+                    {
+                        "description": "申请参数",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.RequestElevationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "申请结果",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/baseRes.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.RoleGrantResponse"
+                                        },
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/admin/role-grants/mine": {
+            "get": {
+                "description": "分页获取当前登录用户的限时角色授予和提权申请",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "限时角色授予"
+                ],
+                "summary": "获取当前用户的限时授予",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每页数量",
+                        "name": "pageSize",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "状态 (pending、scheduled、active、rejected、expired、revoked)",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "授予列表",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/baseRes.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.RoleGrantListResponse"
+                                        },
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/admin/role-grants/{id}/approve": {
+            "post": {
+                "description": "批准待审批的提权申请，从审批时起按申请时长生效；申请人不能审批自己的申请",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "限时角色授予"
+                ],
+                "summary": "批准提权申请",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "申请 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "审批意见",
+                        "name": "data",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/request.ReviewRoleGrantRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "审批结果",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/baseRes.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.RoleGrantResponse"
+                                        },
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/admin/role-grants/{id}/reject": {
+            "post": {
+                "description": "拒绝待审批的提权申请；申请人不能审批自己的申请",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "限时角色授予"
+                ],
+                "summary": "拒绝提权申请",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "申请 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "审批意见",
+                        "name": "data",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/request.ReviewRoleGrantRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "审批结果",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/baseRes.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.RoleGrantResponse"
+                                        },
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/admin/role-grants/{id}/revoke": {
+            "post": {
+                "description": "在到期前收回待生效或生效中的限时授予，生效中的授予立即将用户移出角色",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "限时角色授予"
+                ],
+                "summary": "收回限时授予",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "授予 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "收回原因",
+                        "name": "data",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/request.ReviewRoleGrantRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "收回结果",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/baseRes.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.RoleGrantResponse"
+                                        },
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/admin/role/:id/available-apis": {
+            "get": {
+                "description": "获取尚未分配给该角色的 API 路由列表",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "角色管理"
+                ],
+                "summary": "获取角色可用的 API 列表",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "角色 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/baseRes.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/response.APIResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/admin/role/:id/detail": {
+            "get": {
+                "description": "根据路径参数 ID 获取角色详情，支持 RESTful 风格",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "角色管理"
+                ],
+                "summary": "根据路径参数 ID 获取角色详情",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "角色 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/baseRes.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.RoleDetailResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/admin/role/:id/permissions": {
+            "post": {
+                "description": "保存角色的菜单、按钮和 API 权限；角色开启变更审批时差异提交为变更申请，审批通过后生效",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "角色管理"
+                ],
+                "summary": "保存角色权限",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "角色 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "权限信息",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.SaveRolePermissionsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/baseRes.Response"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/admin/role/all": {
+            "get": {
+                "description": "获取所有角色，用于前端选择角色选项",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "角色管理"
+                ],
+                "summary": "获取所有角色",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/baseRes.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/response.RoleResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/admin/role/assign-api-routes": {
+            "post": {
+                "description": "分配API路由到角色",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "角色管理"
+                ],
+                "summary": "分配API路由到角色",
+                "parameters": [
+                    {
+                        "description": "角色ID和API路由ID列表",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.AssignToRoleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/baseRes.Response"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/admin/role/assign-menus": {
+            "post": {
+                "description": "分配菜单到角色",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "角色管理"
+                ],
+                "summary": "分配菜单到角色",
+                "parameters": [
+                    {
+                        "description": "角色ID和菜单ID列表",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.AssignMenuToRoleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/baseRes.Response"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/admin/role/assign-users": {
+            "post": {
+                "description": "分配用户到角色",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "角色管理"
+                ],
+                "summary": "分配用户到角色",
+                "parameters": [
+                    {
+                        "description": "角色ID和用户ID列表",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.AssignUserToRoleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/baseRes.Response"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/admin/role/detail": {
+            "get": {
+                "description": "根据 ID 获取角色详情",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "角色管理"
+                ],
+                "summary": "根据 ID 获取角色",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "角色 ID",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/baseRes.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.RoleDetailResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/admin/role/{id}/approval-required": {
+            "put": {
+                "description": "开启后角色的接口、菜单、按钮权限和用户分配变更先生成变更申请，由申请人以外的审批人通过后才生效\n开启立即生效，关闭本身需要审批",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "角色管理"
+                ],
+                "summary": "设置角色权限变更是否需要审批",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "角色 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "是否需要审批",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.SetRoleApprovalRequiredRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "设置成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/baseRes.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/admin/role/{id}/data-scope": {
+            "put": {
+                "description": "设置角色在用户、操作日志、登录日志等列表中可见的数据范围：1-全部，2-本部门，3-本部门及下级，4-自定义部门，5-仅本人\n部门范围下本人的数据始终可见，保存后立即生效",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "角色管理"
+                ],
+                "summary": "设置角色数据权限",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "角色 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "数据权限",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.SetRoleDataScopeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "设置成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/baseRes.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/admin/roles/:roleId/permission-logs": {
+            "get": {
+                "description": "获取指定角色的权限变更历史",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "权限日志管理"
+                ],
+                "summary": "获取角色权限日志",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "角色 ID",
+                        "name": "role_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每页数量",
+                        "name": "page_size",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "角色权限日志列表",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/baseRes.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/baseRes.PageResult"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "list": {
+                                                            "type": "array",
+                                                            "items": {}
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        },
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/admin/service-accounts": {
+            "get": {
+                "description": "分页获取服务账号列表",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "服务账号管理"
+                ],
+                "summary": "获取服务账号列表",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每页数量",
+                        "name": "pageSize",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "状态 (0:禁用，1:启用)",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "服务账号列表",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/baseRes.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.ServiceAccountListResponse"
+                                        },
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "post": {
+                "description": "创建服务账号并生成首个 API Key，API Key 明文仅返回一次",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "服务账号管理"
+                ],
+                "summary": "创建服务账号",
+                "parameters": [
+                    {
+                        "description": "服务账号信息",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CreateServiceAccountRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "创建成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/baseRes.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.ServiceAccountKeyIssuedResponse"
+                                        },
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/admin/service-accounts/{id}": {
+            "get": {
+                "description": "获取服务账号详情，包含绑定角色和 API Key 列表（不含明文）",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "服务账号管理"
+                ],
+                "summary": "获取服务账号详情",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "服务账号 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "服务账号详情",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/baseRes.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.ServiceAccountResponse"
+                                        },
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "put": {
+                "description": "更新服务账号描述、状态、IP 白名单和绑定角色",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "服务账号管理"
+                ],
+                "summary": "更新服务账号",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "服务账号 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "服务账号信息",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.UpdateServiceAccountRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "更新成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/baseRes.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "delete": {
+                "description": "删除服务账号，其下所有 API Key 立即失效",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "服务账号管理"
+                ],
+                "summary": "删除服务账号",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "服务账号 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "删除成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/baseRes.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/admin/service-accounts/{id}/keys/{keyId}": {
+            "delete": {
+                "description": "立即吊销服务账号下的指定 API Key",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "服务账号管理"
+                ],
+                "summary": "吊销 API Key",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "服务账号 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Key ID",
+                        "name": "keyId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "吊销成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/baseRes.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/admin/service-accounts/{id}/rotate-key": {
+            "post": {
+                "description": "生成新的 API Key，旧 Key 在宽限期后失效，新 Key 明文仅返回一次",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "服务账号管理"
+                ],
+                "summary": "轮换 API Key",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "服务账号 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "轮换参数",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.RotateServiceAccountKeyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "轮换成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/baseRes.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.ServiceAccountKeyIssuedResponse"
+                                        },
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/admin/sod-constraints": {
+            "get": {
+                "description": "分页获取职责分离约束列表",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "职责分离"
+                ],
+                "summary": "获取职责分离约束列表",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每页数量",
+                        "name": "pageSize",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "约束名称",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "约束类型 (static、dynamic)",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "状态 (0:禁用，1:启用)",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "约束列表",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/baseRes.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.SoDConstraintListResponse"
+                                        },
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "post": {
+                "description": "静态约束限制用户最多同时拥有角色集合中的 maxRoles 个，动态约束限制同一会话中最多激活其中 maxRoles 个；maxRoles 为 1 即角色互斥",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "职责分离"
+                ],
+                "summary": "创建职责分离约束",
+                "parameters": [
+                    {
+                        "description": "约束信息",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CreateSoDConstraintRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "创建成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/baseRes.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.SoDConstraintResponse"
+                                        },
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/admin/sod-constraints/violations": {
+            "get": {
+                "description": "列出现有角色分配中违反启用的静态约束的用户，通常是约束创建前已存在的分配；动态约束只限制会话内激活，不产生违规",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "职责分离"
+                ],
+                "summary": "获取职责分离违规报告",
+                "responses": {
+                    "200": {
+                        "description": "违规列表",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/baseRes.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/response.SoDViolationResponse"
+                                            }
+                                        },
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/admin/sod-constraints/{id}": {
+            "get": {
+                "description": "根据 ID 获取职责分离约束详情",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "职责分离"
+                ],
+                "summary": "获取职责分离约束详情",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "约束 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "约束详情",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/baseRes.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.SoDConstraintResponse"
+                                        },
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "put": {
+                "description": "更新职责分离约束，只影响之后的角色分配和切换，已有的违规可通过违规报告查询",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "职责分离"
+                ],
+                "summary": "更新职责分离约束",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "约束 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "约束信息",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.UpdateSoDConstraintRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "更新成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/baseRes.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "delete": {
+                "description": "删除职责分离约束，删除后立即生效",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "职责分离"
+                ],
+                "summary": "删除职责分离约束",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "约束 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "删除成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/baseRes.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/admin/task": {
+            "get": {
+                "description": "获取所有任务列表（管理员权限）",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "任务管理"
+                ],
+                "summary": "获取所有任务",
+                "responses": {
+                    "200": {
+                        "description": "任务列表",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "array",
+                                "items": {
+                                    "$ref": "#/definitions/response.TaskResponse"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "无权限",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "post": {
+                "description": "创建一个新的任务（管理员权限）",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "任务管理"
+                ],
+                "summary": "创建任务",
+                "parameters": [
+                    {
+                        "description": "创建任务请求参数",
+                        "name": "task",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.AddTaskRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "任务创建成功",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "$ref": "#/definitions/response.TaskResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "无权限",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/admin/task/:id": {
+            "get": {
+                "description": "根据 ID 获取单个任务详情（管理员权限）",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "任务管理"
+                ],
+                "summary": "获取单个任务",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "任务 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "任务详情",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "$ref": "#/definitions/response.TaskResponse"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "无权限",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "任务不存在",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "delete": {
+                "description": "根据ID移除一个任务（管理员权限）",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "任务管理"
+                ],
+                "summary": "移除任务",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "任务ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "任务移除成功",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "无权限",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/admin/task/:id/execution-logs": {
+            "get": {
+                "description": "根据任务 ID 查询任务执行历史记录（管理员权限）",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "任务管理"
+                ],
+                "summary": "查询任务执行历史",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "任务 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每页数量",
+                        "name": "pageSize",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "执行日志列表",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "$ref": "#/definitions/response.TaskExecutionLogsResponse"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "无权限",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/admin/task/:id/group": {
+            "post": {
+                "description": "设置指定任务的分组（管理员权限）",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "任务管理"
+                ],
+                "summary": "设置任务分组",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "任务 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "分组信息",
+                        "name": "task",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.SetTaskGroupRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "设置成功",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "无权限",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "任务不存在",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/admin/task/:id/retry": {
+            "post": {
+                "description": "根据ID重试一个失败的任务（管理员权限）",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "任务管理"
+                ],
+                "summary": "重试任务",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "任务ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "任务重试触发成功",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "无权限",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/admin/task/:id/start": {
+            "post": {
+                "description": "根据ID启动一个任务（管理员权限）",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "任务管理"
+                ],
+                "summary": "启动任务",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "任务ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "任务启动成功",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "无权限",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/admin/task/:id/stop": {
+            "post": {
+                "description": "根据ID停止一个任务（管理员权限）",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "任务管理"
+                ],
+                "summary": "停止任务",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "任务ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "任务停止成功",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "无权限",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/admin/task/statistics": {
+            "get": {
+                "description": "获取所有任务的执行统计信息（管理员权限）",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "任务管理"
+                ],
+                "summary": "获取任务统计",
+                "responses": {
+                    "200": {
+                        "description": "任务统计列表",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "array",
+                                "items": {
+                                    "$ref": "#/definitions/response.TaskStatisticsResponse"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "无权限",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/admin/user": {
+            "get": {
+                "description": "获取用户列表（支持分页和筛选），按当前角色的数据权限过滤",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "获取用户列表",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每页数量",
+                        "name": "page_size",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "用户名",
+                        "name": "userName",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "邮箱",
+                        "name": "email",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "角色",
+                        "name": "role",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "状态",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "用户列表",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/baseRes.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/baseRes.PageResult"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "list": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/entity.User"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        },
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "post": {
+                "description": "创建新用户账户",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "创建用户",
+                "parameters": [
+                    {
+                        "description": "创建用户请求参数",
+                        "name": "create",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.AddUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "创建成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/baseRes.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.UserResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/admin/user/get-user-settings": {
+            "get": {
+                "description": "获取当前登录用户的系统设置",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "获取用户设置",
+                "responses": {
+                    "200": {
+                        "description": "用户设置信息",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/baseRes.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object",
+                                            "additionalProperties": {
+                                                "$ref": "#/definitions/entity.UserSetting"
+                                            }
+                                        },
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/admin/user/info": {
+            "get": {
+                "description": "获取当前登录用户的详细信息",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "获取用户信息",
+                "responses": {
+                    "200": {
+                        "description": "用户信息",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/baseRes.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.UserInfoResponse"
+                                        },
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "put": {
+                "description": "更新用户的基本信息",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "更新用户信息",
+                "parameters": [
+                    {
+                        "description": "更新用户请求参数",
+                        "name": "update_user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.UpdateUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "更新成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/baseRes.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/entity.User"
+                                        },
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/admin/user/password": {
+            "put": {
+                "description": "修改当前用户的密码",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "修改密码",
+                "parameters": [
+                    {
+                        "description": "修改密码请求参数",
+                        "name": "change_password",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.ChangePasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "修改成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/baseRes.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/admin/user/reset-password": {
+            "put": {
+                "description": "重置用户密码（管理员权限），未指定新密码时自动生成符合密码策略的临时密码，用户首次登录需修改密码",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "重置密码",
+                "parameters": [
+                    {
+                        "description": "重置密码请求参数",
+                        "name": "reset_password",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.ResetPasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "重置成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/baseRes.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.ResetPasswordResponse"
+                                        },
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/admin/user/security-events": {
+            "get": {
+                "description": "分页获取当前用户账号的新设备、新地点和异地登录记录",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "获取当前用户的登录安全事件",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每页数量",
+                        "name": "pageSize",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "事件类型",
+                        "name": "type",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "登录安全事件列表",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/baseRes.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/baseRes.PageResult"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "list": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/response.LoginSecurityEventDTO"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        },
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/admin/user/session-events": {
+            "get": {
+                "description": "以 Server-Sent Events 推送当前登录会话的事件；会话被下线时推送 session_revoked 事件后关闭连接，客户端应清除令牌并跳转登录页",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "在线用户管理"
+                ],
+                "summary": "订阅当前会话的实时事件",
+                "responses": {
+                    "200": {
+                        "description": "会话事件",
+                        "schema": {
+                            "$ref": "#/definitions/push.Event"
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/admin/user/sessions": {
+            "get": {
+                "description": "获取当前用户所有未过期的登录会话，current 标记当前请求使用的会话",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "在线用户管理"
+                ],
+                "summary": "获取当前用户的登录设备",
+                "responses": {
+                    "200": {
+                        "description": "登录设备列表",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/baseRes.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/response.OnlineUserResponse"
+                                            }
+                                        },
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/admin/user/sessions/revoke": {
+            "post": {
+                "description": "下线当前用户的指定登录会话，该设备的令牌立即失效；下线当前会话等同于退出登录",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "在线用户管理"
+                ],
+                "summary": "下线当前用户的登录设备",
+                "parameters": [
+                    {
+                        "description": "下线设备请求",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.RevokeSessionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "下线成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/baseRes.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/admin/user/setUserAuthorities": {
+            "post": {
+                "description": "为用户设置多个角色权限",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "设置用户权限（多角色）",
+                "parameters": [
+                    {
+                        "description": "权限设置信息",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.SetUserAuthoritiesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "设置成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/baseRes.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/admin/user/setUserAuthority": {
+            "post": {
+                "description": "为用户设置单个角色权限",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "设置用户权限（单角色）",
+                "parameters": [
+                    {
+                        "description": "权限设置信息",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.SetUserAuthorityRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "设置成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/baseRes.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/admin/user/switch-role": {
+            "post": {
+                "description": "切换当前用户的活动角色（不修改用户角色关联关系）",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "切换用户角色",
+                "parameters": [
+                    {
+                        "description": "切换角色请求参数",
+                        "name": "switch_role",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.SwitchRoleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "切换成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/baseRes.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/admin/user/update-user-settings": {
+            "put": {
+                "description": "更新当前登录用户的系统设置",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "更新用户设置",
+                "parameters": [
+                    {
+                        "description": "用户设置参数",
+                        "name": "user_setting",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.UserSetting"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "更新成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/baseRes.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object",
+                                            "additionalProperties": {
+                                                "$ref": "#/definitions/entity.UserSetting"
+                                            }
+                                        },
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/admin/user/{id}": {
+            "delete": {
+                "description": "删除用户（管理员权限）",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "删除用户",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "用户ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "删除成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/baseRes.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/oidc/.well-known/openid-configuration": {
+            "get": {
+                "description": "返回身份提供方的端点、支持的授权范围、授权类型和签名算法",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "身份提供方"
+                ],
+                "summary": "OIDC 发现文档",
+                "responses": {
+                    "200": {
+                        "description": "发现文档",
+                        "schema": {
+                            "$ref": "#/definitions/response.OIDCDiscoveryResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "身份提供方未开启",
+                        "schema": {
+                            "$ref": "#/definitions/response.OAuthErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/oidc/authorize": {
+            "get": {
+                "description": "校验授权请求后跳转前端授权确认页（未登录时由前端先跳转登录页）；客户端或回调地址无效时直接返回错误",
+                "tags": [
+                    "身份提供方"
+                ],
+                "summary": "OIDC 授权端点",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "响应类型，仅支持 code",
+                        "name": "response_type",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "客户端标识",
+                        "name": "client_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "回调地址",
+                        "name": "redirect_uri",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "授权范围，必须包含 openid",
+                        "name": "scope",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "客户端状态",
+                        "name": "state",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID Token nonce",
+                        "name": "nonce",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "PKCE 校验值，公开客户端必填",
+                        "name": "code_challenge",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "PKCE 方法，仅支持 S256",
+                        "name": "code_challenge_method",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "consent 表示强制重新确认",
+                        "name": "prompt",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "302": {
+                        "description": "Found"
+                    },
+                    "400": {
+                        "description": "客户端或回调地址无效",
+                        "schema": {
+                            "$ref": "#/definitions/response.OAuthErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/oidc/consent": {
+            "get": {
+                "description": "授权确认页使用，参数与授权端点相同；consentRequired 为 false 时前端可直接提交同意",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "身份提供方"
+                ],
+                "summary": "获取授权确认信息",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "客户端标识",
+                        "name": "client_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "回调地址",
+                        "name": "redirect_uri",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "授权范围",
+                        "name": "scope",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "授权确认信息",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/baseRes.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.OIDCConsentInfoResponse"
+                                        },
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "post": {
+                "description": "用户同意后签发一次性授权码，返回携带 code 的回调地址；拒绝时回调地址携带 access_denied",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "身份提供方"
+                ],
+                "summary": "提交授权决定",
+                "parameters": [
+                    {
+                        "description": "授权请求参数和决定",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.OIDCConsentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "回调地址",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/baseRes.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.OIDCConsentResponse"
+                                        },
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/oidc/jwks": {
+            "get": {
+                "description": "返回校验 ID Token 和访问令牌签名的公钥（JWKS）",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "身份提供方"
+                ],
+                "summary": "OIDC 签名公钥",
+                "responses": {
+                    "200": {
+                        "description": "JWKS",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "身份提供方未开启",
+                        "schema": {
+                            "$ref": "#/definitions/response.OAuthErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/oidc/token": {
+            "post": {
+                "description": "支持 authorization_code、refresh_token 和 client_credentials；客户端认证支持 client_secret_basic、client_secret_post，公开客户端只传 client_id",
+                "consumes": [
+                    "application/x-www-form-urlencoded"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "身份提供方"
+                ],
+                "summary": "OIDC 令牌端点",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "授权类型",
+                        "name": "grant_type",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "授权码",
+                        "name": "code",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "回调地址",
+                        "name": "redirect_uri",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "PKCE 原始值",
+                        "name": "code_verifier",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "刷新令牌",
+                        "name": "refresh_token",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "授权范围",
+                        "name": "scope",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "客户端标识",
+                        "name": "client_id",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "客户端密钥",
+                        "name": "client_secret",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "令牌",
+                        "schema": {
+                            "$ref": "#/definitions/response.OIDCTokenResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "请求错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.OAuthErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "客户端认证失败",
+                        "schema": {
+                            "$ref": "#/definitions/response.OAuthErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/oidc/userinfo": {
+            "get": {
+                "description": "使用访问令牌获取用户声明，按授权范围返回资料、角色编码和所属部门",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "身份提供方"
+                ],
+                "summary": "OIDC 用户信息端点",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer 访问令牌",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "用户声明",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "访问令牌无效",
+                        "schema": {
+                            "$ref": "#/definitions/response.OAuthErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/open/v1/public-key": {
+            "get": {
+                "description": "RSA-SHA256 签名方式的应用使用该公钥校验平台响应签名",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "开放接口"
+                ],
+                "summary": "获取平台响应签名公钥",
+                "responses": {
+                    "200": {
+                        "description": "平台公钥",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/baseRes.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.OpenAPIPublicKeyResponse"
+                                        },
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/payment": {
+            "post": {
+                "description": "创建一笔新的支付订单",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "支付管理"
+                ],
+                "summary": "创建支付",
+                "parameters": [
+                    {
+                        "description": "创建支付请求参数",
+                        "name": "payment",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CreatePaymentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "支付创建成功",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "$ref": "#/definitions/response.PaymentResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/wx/auth/login": {
+            "post": {
+                "description": "使用微信授权码登录获取用户信息和令牌",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "微信认证"
+                ],
+                "summary": "微信授权码登录",
+                "parameters": [
+                    {
+                        "description": "微信授权码登录请求参数",
+                        "name": "code",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.WechatLoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "登录成功，包含用户信息和令牌",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/baseRes.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.WXLoginResponse"
+                                        },
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "认证失败",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/wx/auth/refresh-token": {
+            "post": {
+                "description": "使用 refresh token 获取新的 access token 和 refresh token",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "微信认证"
+                ],
+                "summary": "刷新令牌",
+                "parameters": [
+                    {
+                        "description": "刷新令牌请求参数",
+                        "name": "refresh",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.WechatRefreshTokenRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "刷新成功，包含新的令牌",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/baseRes.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.WXLoginResponse"
+                                        },
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "认证失败",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/wx/open-apps": {
+            "get": {
+                "description": "分页获取开放接口应用列表，不返回应用密钥",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "开放接口"
+                ],
+                "summary": "获取开放接口应用列表",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每页数量",
+                        "name": "pageSize",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "状态 (0:禁用，1:启用)",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "应用列表",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/baseRes.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.OpenAppListResponse"
+                                        },
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "post": {
+                "description": "创建开放接口应用，返回应用标识和应用密钥，密钥只返回一次，请妥善保存",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "开放接口"
+                ],
+                "summary": "创建开放接口应用",
+                "parameters": [
+                    {
+                        "description": "应用信息",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CreateOpenAppRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "创建成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/baseRes.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.OpenAppSecretResponse"
+                                        },
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/wx/open-apps/{id}": {
+            "get": {
+                "description": "根据 ID 获取开放接口应用详情，不返回应用密钥",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "开放接口"
+                ],
+                "summary": "获取开放接口应用详情",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "应用 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "应用详情",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/baseRes.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.OpenAppResponse"
+                                        },
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "put": {
+                "description": "更新开放接口应用的名称、签名方式、公钥、绑定用户、描述和状态",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "开放接口"
+                ],
+                "summary": "更新开放接口应用",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "应用 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "应用信息",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.UpdateOpenAppRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "更新成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/baseRes.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "delete": {
+                "description": "删除开放接口应用，删除后使用该应用标识的请求立即被拒绝",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "开放接口"
+                ],
+                "summary": "删除开放接口应用",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "应用 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "删除成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/baseRes.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/wx/open-apps/{id}/reset-secret": {
+            "post": {
+                "description": "生成新的应用密钥，旧密钥立即失效，新密钥只返回一次",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "开放接口"
+                ],
+                "summary": "重置开放接口应用密钥",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "应用 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "重置成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/baseRes.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.OpenAppSecretResponse"
+                                        },
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        }
+    },
+    "definitions": {
+        "baseRes.PageResult": {
+            "type": "object",
+            "properties": {
+                "list": {},
+                "page": {
+                    "type": "integer"
+                },
+                "pageSize": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "baseRes.Response": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer"
+                },
+                "data": {},
+                "msg": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.API": {
+            "type": "object",
+            "properties": {
+                "authRequired": {
+                    "description": "是否需要认证",
+                    "type": "boolean"
+                },
+                "authType": {
+                    "description": "授权类型：0-不需要授权（只要登录），1-需要授权（需要角色权限）",
+                    "type": "integer"
+                },
+                "btnPermIds": {
+                    "description": "关联的按钮权限 ID 列表",
+                    "type": "array",
+                    "items": {
+                        "type": "integer",
+                        "format": "int64"
+                    }
+                },
+                "createdAt": {
+                    "description": "创建时间",
+                    "type": "string"
+                },
+                "createdBy": {
+                    "description": "创建人 ID",
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "description": {
+                    "description": "描述",
+                    "type": "string"
+                },
+                "group": {
+                    "description": "路由分组",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "API ID",
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "menuIds": {
+                    "description": "关联的菜单 ID 列表",
+                    "type": "array",
+                    "items": {
+                        "type": "integer",
+                        "format": "int64"
+                    }
+                },
+                "method": {
+                    "description": "HTTP 方法",
+                    "type": "string"
+                },
+                "orphaned": {
+                    "description": "是否已失效：启动同步时路由已不存在，保留记录和角色分配等待管理员处理",
+                    "type": "boolean"
+                },
+                "path": {
+                    "description": "路由路径",
+                    "type": "string"
+                },
+                "roleIds": {
+                    "description": "关联的角色 ID 列表",
+                    "type": "array",
+                    "items": {
+                        "type": "integer",
+                        "format": "int64"
+                    }
+                },
+                "status": {
+                    "description": "状态：1-启用，0-禁用",
+                    "type": "integer"
+                },
+                "updatedAt": {
+                    "description": "更新时间",
+                    "type": "string"
+                },
+                "updatedBy": {
+                    "description": "更新人 ID",
+                    "type": "integer",
+                    "format": "int64"
+                }
+            }
+        },
+        "entity.BtnPerm": {
+            "type": "object",
+            "properties": {
+                "apirouteIds": {
+                    "description": "关联的 API 路由 ID 列表",
+                    "type": "array",
+                    "items": {
+                        "type": "integer",
+                        "format": "int64"
+                    }
+                },
+                "apiroutes": {
+                    "description": "关联的 API 路由对象列表",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.API"
+                    }
+                },
+                "code": {
+                    "description": "权限编码，如：user:create, user:edit",
+                    "type": "string"
+                },
+                "createdAt": {
+                    "description": "创建时间",
+                    "type": "string"
+                },
+                "createdBy": {
+                    "description": "创建人 ID",
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "description": {
+                    "description": "权限描述",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "按钮权限 ID",
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "menu": {
+                    "description": "所属菜单（新增）",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entity.Menu"
+                        }
+                    ]
+                },
+                "menuID": {
+                    "description": "所属菜单 ID",
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "name": {
+                    "description": "权限名称，如：创建用户，编辑用户",
+                    "type": "string"
+                },
+                "roleIds": {
+                    "description": "关联的角色 ID 列表",
+                    "type": "array",
+                    "items": {
+                        "type": "integer",
+                        "format": "int64"
+                    }
+                },
+                "roles": {
+                    "description": "关联的角色对象列表关联的角色列表（新增）",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.Role"
+                    }
+                },
+                "status": {
+                    "description": "状态：1-启用，0-禁用",
+                    "type": "integer"
+                },
+                "updatedAt": {
+                    "description": "更新时间",
+                    "type": "string"
+                },
+                "updatedBy": {
+                    "description": "更新人 ID",
+                    "type": "integer",
+                    "format": "int64"
+                }
+            }
+        },
+        "entity.DailyStat": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "description": "日期",
+                    "type": "string"
+                },
+                "failedCount": {
+                    "description": "失败次数",
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "successCount": {
+                    "description": "成功次数",
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "totalCount": {
+                    "description": "总次数",
+                    "type": "integer",
+                    "format": "int64"
+                }
+            }
+        },
+        "entity.DeleteImpact": {
+            "type": "object",
+            "properties": {
+                "affectedApisCount": {
+                    "description": "影响的 API 数量",
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "affectedRolesCount": {
+                    "description": "受影响的角色数量",
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "btnPermsCount": {
+                    "description": "按钮权限数量",
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "childMenusCount": {
+                    "description": "子菜单数量",
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "level": {
+                    "description": "影响等级：LOW, MEDIUM, HIGH",
+                    "type": "string"
+                },
+                "warning": {
+                    "description": "警告信息",
+                    "type": "string"
+                }
+            }
+        },
+        "entity.Department": {
+            "type": "object",
+            "properties": {
+                "children": {
+                    "description": "子部门",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.Department"
+                    }
+                },
+                "createdAt": {
+                    "description": "创建时间",
+                    "type": "string"
+                },
+                "createdBy": {
+                    "description": "创建人 ID",
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "description": {
+                    "description": "部门描述",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "部门 ID",
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "leader": {
+                    "description": "部门负责人（新增）",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entity.User"
+                        }
+                    ]
+                },
+                "leaderID": {
+                    "description": "部门负责人 ID",
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "name": {
+                    "description": "部门名称",
+                    "type": "string"
+                },
+                "parent": {
+                    "description": "父部门（新增）",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entity.Department"
+                        }
+                    ]
+                },
+                "parentID": {
+                    "description": "父部门 ID",
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "sort": {
+                    "description": "排序",
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "状态：1-正常，0-禁用",
+                    "type": "integer"
+                },
+                "updatedAt": {
+                    "description": "更新时间",
+                    "type": "string"
+                },
+                "updatedBy": {
+                    "description": "更新人 ID",
+                    "type": "integer",
+                    "format": "int64"
+                }
+            }
+        },
+        "entity.FailedIPStat": {
+            "type": "object",
+            "properties": {
+                "failedCount": {
+                    "description": "失败次数",
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "ip": {
+                    "description": "IP 地址",
+                    "type": "string"
+                }
+            }
+        },
+        "entity.HourlyStat": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "description": "统计数量",
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "hour": {
+                    "description": "小时 (0-23)",
+                    "type": "integer"
+                }
+            }
+        },
+        "entity.LoginStatistics": {
+            "type": "object",
+            "properties": {
+                "dailyStats": {
+                    "description": "每日统计",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.DailyStat"
+                    }
+                },
+                "endTime": {
+                    "description": "统计结束时间",
+                    "type": "string"
+                },
+                "failedCount": {
+                    "description": "失败登录次数",
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "failedIPs": {
+                    "description": "失败最多的 IP",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.FailedIPStat"
+                    }
+                },
+                "hourlyStats": {
+                    "description": "每小时统计",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.HourlyStat"
+                    }
+                },
+                "startTime": {
+                    "description": "统计开始时间",
+                    "type": "string"
+                },
+                "successCount": {
+                    "description": "成功登录次数",
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "topIPs": {
+                    "description": "登录最频繁 IP",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.TopIPStat"
+                    }
+                },
+                "topUsers": {
+                    "description": "登录最频繁用户",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.TopUserStat"
+                    }
+                },
+                "totalCount": {
+                    "description": "登录总次数",
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "uniqueIPs": {
+                    "description": "独立 IP 数",
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "uniqueUsers": {
+                    "description": "独立用户数",
+                    "type": "integer",
+                    "format": "int64"
+                }
+            }
+        },
+        "entity.Menu": {
+            "type": "object",
+            "properties": {
+                "activeMenu": {
+                    "description": "当前激活的菜单",
+                    "type": "string"
+                },
+                "affix": {
+                    "description": "是否固定在标签栏",
+                    "type": "boolean"
+                },
+                "apirouteIds": {
+                    "description": "关联的 API 路由 ID 列表",
+                    "type": "array",
+                    "items": {
+                        "type": "integer",
+                        "format": "int64"
+                    }
+                },
+                "apiroutes": {
+                    "description": "关联的 API 路由列表（新增）",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.API"
+                    }
+                },
+                "breadcrumb": {
+                    "description": "是否在面包屑显示",
+                    "type": "boolean"
+                },
+                "btnPermIds": {
+                    "description": "菜单下的按钮权限 ID 列表",
+                    "type": "array",
+                    "items": {
+                        "type": "integer",
+                        "format": "int64"
+                    }
+                },
+                "btnPerms": {
+                    "description": "菜单下的按钮权限列表（新增）",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.BtnPerm"
+                    }
+                },
+                "children": {
+                    "description": "子菜单",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.Menu"
+                    }
+                },
+                "component": {
+                    "description": "组件路径",
+                    "type": "string"
+                },
+                "createdAt": {
+                    "description": "创建时间",
+                    "type": "string"
+                },
+                "createdBy": {
+                    "description": "创建人 ID",
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "defaultMenu": {
+                    "description": "是否默认菜单",
+                    "type": "boolean"
+                },
+                "frameLoading": {
+                    "description": "iframe 加载动画",
+                    "type": "boolean"
+                },
+                "frameSrc": {
+                    "description": "iframe 地址（如果是 iframe 类型）",
+                    "type": "string"
+                },
+                "hidden": {
+                    "description": "是否隐藏",
+                    "type": "boolean"
+                },
+                "icon": {
+                    "description": "菜单图标",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "菜单 ID",
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "isExt": {
+                    "description": "是否外部链接",
+                    "type": "boolean"
+                },
+                "keepAlive": {
+                    "description": "是否缓存",
+                    "type": "boolean"
+                },
+                "meta": {
+                    "description": "菜单元数据",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entity.MenuMeta"
+                        }
+                    ]
+                },
+                "name": {
+                    "description": "路由名称，前端组件名",
+                    "type": "string"
+                },
+                "parent": {
+                    "description": "父菜单（新增）",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entity.Menu"
+                        }
+                    ]
+                },
+                "parentID": {
+                    "description": "父菜单 ID，0 表示顶级菜单",
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "path": {
+                    "description": "路由路径",
+                    "type": "string"
+                },
+                "permission": {
+                    "description": "权限标识",
+                    "type": "string"
+                },
+                "redirect": {
+                    "description": "重定向地址",
+                    "type": "string"
+                },
+                "roleIds": {
+                    "description": "关联的角色 ID 列表",
+                    "type": "array",
+                    "items": {
+                        "type": "integer",
+                        "format": "int64"
+                    }
+                },
+                "roles": {
+                    "description": "关联的角色列表（新增）",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.Role"
+                    }
+                },
+                "sort": {
+                    "description": "排序号",
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "状态：0 禁用，1 启用",
+                    "type": "integer"
+                },
+                "title": {
+                    "description": "菜单标题",
+                    "type": "string"
+                },
+                "type": {
+                    "description": "菜单类型：1 目录，2 菜单，3 按钮，4iframe",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entity.MenuType"
+                        }
+                    ]
+                },
+                "updatedAt": {
+                    "description": "更新时间",
+                    "type": "string"
+                },
+                "updatedBy": {
+                    "description": "更新人 ID",
+                    "type": "integer",
+                    "format": "int64"
+                }
+            }
+        },
+        "entity.MenuMeta": {
+            "type": "object",
+            "properties": {
+                "activeMenu": {
+                    "description": "当前激活的菜单",
+                    "type": "string"
+                },
+                "affix": {
+                    "description": "是否固定在标签栏",
+                    "type": "boolean"
+                },
+                "breadcrumb": {
+                    "description": "是否在面包屑显示",
+                    "type": "boolean"
+                },
+                "defaultMenu": {
+                    "description": "是否默认菜单",
+                    "type": "boolean"
+                },
+                "frameLoading": {
+                    "description": "iframe 加载动画",
+                    "type": "boolean"
+                },
+                "frameSrc": {
+                    "description": "iframe 地址（如果是 iframe 类型）",
+                    "type": "string"
+                },
+                "icon": {
+                    "description": "菜单图标",
+                    "type": "string"
+                },
+                "keepAlive": {
+                    "description": "是否缓存",
+                    "type": "boolean"
+                },
+                "title": {
+                    "description": "菜单标题",
+                    "type": "string"
+                }
+            }
+        },
+        "entity.MenuType": {
+            "type": "integer",
+            "enum": [
+                1,
+                2,
+                3,
+                4
+            ],
+            "x-enum-comments": {
+                "MenuTypeButton": "按钮",
+                "MenuTypeDirectory": "目录",
+                "MenuTypeIframe": "内嵌 iframe",
+                "MenuTypeMenu": "菜单"
+            },
+            "x-enum-descriptions": [
+                "目录",
+                "菜单",
+                "按钮",
+                "内嵌 iframe"
+            ],
+            "x-enum-varnames": [
+                "MenuTypeDirectory",
+                "MenuTypeMenu",
+                "MenuTypeButton",
+                "MenuTypeIframe"
+            ]
+        },
+        "entity.PermissionGroup": {
+            "type": "object",
+            "properties": {
+                "apirouteIds": {
+                    "description": "权限组关联的接口路由 ID 列表",
+                    "type": "array",
+                    "items": {
+                        "type": "integer",
+                        "format": "int64"
+                    }
+                },
+                "apiroutes": {
+                    "description": "权限组关联的接口路由对象列表",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.API"
+                    }
+                },
+                "btnPermIds": {
+                    "description": "权限组关联的按钮权限 ID 列表",
+                    "type": "array",
+                    "items": {
+                        "type": "integer",
+                        "format": "int64"
+                    }
+                },
+                "btnPerms": {
+                    "description": "权限组关联的按钮权限对象列表",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.BtnPerm"
+                    }
+                },
+                "createdAt": {
+                    "description": "创建时间",
+                    "type": "string"
+                },
+                "createdBy": {
+                    "description": "创建人 ID",
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "description": {
+                    "description": "权限组描述",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "权限组 ID",
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "name": {
+                    "description": "权限组名称",
+                    "type": "string"
+                },
+                "roleIds": {
+                    "description": "权限组关联的角色 ID 列表",
+                    "type": "array",
+                    "items": {
+                        "type": "integer",
+                        "format": "int64"
+                    }
+                },
+                "roles": {
+                    "description": "权限组关联的角色对象列表",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.PermissionGroup"
+                    }
+                },
+                "sort": {
+                    "description": "排序",
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "状态：1-启用，0-禁用",
+                    "type": "integer"
+                },
+                "updatedAt": {
+                    "description": "更新时间",
+                    "type": "string"
+                },
+                "updatedBy": {
+                    "description": "更新人 ID",
+                    "type": "integer",
+                    "format": "int64"
+                }
+            }
+        },
+        "entity.Position": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "description": "创建时间",
+                    "type": "string"
+                },
+                "createdBy": {
+                    "description": "创建人 ID",
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "description": {
+                    "description": "岗位描述",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "岗位 ID",
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "name": {
+                    "description": "岗位名称",
+                    "type": "string"
+                },
+                "sort": {
+                    "description": "排序",
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "状态：1-正常，0-禁用",
+                    "type": "integer"
+                },
+                "updatedAt": {
+                    "description": "更新时间",
+                    "type": "string"
+                },
+                "updatedBy": {
+                    "description": "更新人 ID",
+                    "type": "integer",
+                    "format": "int64"
+                }
+            }
+        },
+        "entity.Role": {
+            "type": "object",
+            "properties": {
+                "apirouteIds": {
+                    "description": "角色关联的接口路由 ID 列表",
+                    "type": "array",
+                    "items": {
+                        "type": "integer",
+                        "format": "int64"
+                    }
+                },
+                "apiroutes": {
+                    "description": "角色关联的接口路由对象列表",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.API"
+                    }
+                },
+                "approvalRequired": {
+                    "description": "权限变更是否需要审批，开启后变更先生成待审批申请",
+                    "type": "boolean"
+                },
+                "btnPermIds": {
+                    "description": "角色关联的按钮权限 ID 列表",
+                    "type": "array",
+                    "items": {
+                        "type": "integer",
+                        "format": "int64"
+                    }
+                },
+                "btnPerms": {
+                    "description": "角色关联的按钮权限对象列表",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.BtnPerm"
+                    }
+                },
+                "children": {
+                    "description": "子角色",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.Role"
+                    }
+                },
+                "code": {
+                    "description": "角色编码",
+                    "type": "string"
+                },
+                "createdAt": {
+                    "description": "创建时间",
+                    "type": "string"
+                },
+                "createdBy": {
+                    "description": "创建人 ID",
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "dataScope": {
+                    "description": "数据权限范围，见 DataScopeAll 等常量",
+                    "type": "integer"
+                },
+                "dataScopeDeptIds": {
+                    "description": "自定义数据权限的部门 ID 列表，DataScope 为 DataScopeCustom 时有效",
+                    "type": "array",
+                    "items": {
+                        "type": "integer",
+                        "format": "int64"
+                    }
+                },
+                "description": {
+                    "description": "角色描述",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "角色 ID",
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "isSystem": {
+                    "description": "是否系统角色",
+                    "type": "boolean"
+                },
+                "menuIds": {
+                    "description": "角色关联的菜单 ID 列表",
+                    "type": "array",
+                    "items": {
+                        "type": "integer",
+                        "format": "int64"
+                    }
+                },
+                "menus": {
+                    "description": "角色关联的菜单对象列表",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.Menu"
+                    }
+                },
+                "name": {
+                    "description": "角色名称",
+                    "type": "string"
+                },
+                "parent": {
+                    "description": "父角色",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entity.Role"
+                        }
+                    ]
+                },
+                "parentID": {
+                    "description": "父角色 ID，支持角色继承",
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "permissionGroupIds": {
+                    "description": "角色关联的权限组 ID 列表",
+                    "type": "array",
+                    "items": {
+                        "type": "integer",
+                        "format": "int64"
+                    }
+                },
+                "permissionGroups": {
+                    "description": "角色关联的权限组对象列表",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.PermissionGroup"
+                    }
+                },
+                "sort": {
+                    "description": "排序",
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "状态：1-启用，0-禁用",
+                    "type": "integer"
+                },
+                "type": {
+                    "description": "角色类型：1-系统角色，2-业务角色，3-数据角色",
+                    "type": "integer"
+                },
+                "updatedAt": {
+                    "description": "更新时间",
+                    "type": "string"
+                },
+                "updatedBy": {
+                    "description": "更新人 ID",
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "userIds": {
+                    "description": "角色关联的用户 ID 列表",
+                    "type": "array",
+                    "items": {
+                        "type": "integer",
+                        "format": "int64"
+                    }
+                },
+                "users": {
+                    "description": "角色关联的用户对象列表",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.User"
+                    }
+                }
+            }
+        },
+        "entity.TopIPStat": {
+            "type": "object",
+            "properties": {
+                "ip": {
+                    "description": "IP 地址",
+                    "type": "string"
+                },
+                "loginCount": {
+                    "description": "登录次数",
+                    "type": "integer",
+                    "format": "int64"
+                }
+            }
+        },
+        "entity.TopUserStat": {
+            "type": "object",
+            "properties": {
+                "loginCount": {
+                    "description": "登录次数",
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "userID": {
+                    "description": "用户 ID",
+                    "type": "string"
+                },
+                "username": {
+                    "description": "用户名",
+                    "type": "string"
+                }
+            }
+        },
+        "entity.User": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "description": "地址",
+                    "type": "string"
+                },
+                "avatar": {
+                    "description": "用户头像 URL",
+                    "type": "string"
+                },
+                "birthday": {
+                    "description": "生日",
+                    "type": "string"
+                },
+                "createdAt": {
+                    "description": "创建时间",
+                    "type": "string"
+                },
+                "createdBy": {
+                    "description": "创建人 ID",
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "department": {
+                    "description": "所属部门",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entity.Department"
+                        }
+                    ]
+                },
+                "departmentID": {
+                    "description": "部门 ID",
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "email": {
+                    "description": "电子邮箱",
+                    "type": "string"
+                },
+                "entryDate": {
+                    "description": "入职日期",
+                    "type": "string"
+                },
+                "gender": {
+                    "description": "性别：0-未知，1-男，2-女",
+                    "type": "integer"
+                },
+                "id": {
+                    "description": "用户 ID",
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "lastLoginIP": {
+                    "description": "最后登录 IP",
+                    "type": "string"
+                },
+                "lastLoginTime": {
+                    "description": "最后登录时间",
+                    "type": "string"
+                },
+                "mustChangePassword": {
+                    "description": "是否必须修改密码（管理员重置后首次登录）",
+                    "type": "boolean"
+                },
+                "nickname": {
+                    "description": "用户昵称",
+                    "type": "string"
+                },
+                "passwordChangedAt": {
+                    "description": "最近一次修改密码时间，零值表示未知",
+                    "type": "string"
+                },
+                "passwordHash": {
+                    "description": "密码哈希值",
+                    "type": "string"
+                },
+                "phone": {
+                    "description": "手机号码",
+                    "type": "string"
+                },
+                "position": {
+                    "description": "所属岗位",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entity.Position"
+                        }
+                    ]
+                },
+                "positionID": {
+                    "description": "岗位 ID",
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "roleIds": {
+                    "description": "用户关联的角色 ID 列表",
+                    "type": "array",
+                    "items": {
+                        "type": "integer",
+                        "format": "int64"
+                    }
+                },
+                "roles": {
+                    "description": "角色列表",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.Role"
+                    }
+                },
+                "specialBtnPermissionIds": {
+                    "description": "用户特殊按钮权限 ID 列表",
+                    "type": "array",
+                    "items": {
+                        "type": "integer",
+                        "format": "int64"
+                    }
+                },
+                "specialPermissionIds": {
+                    "description": "用户特殊权限 ID 列表",
+                    "type": "array",
+                    "items": {
+                        "type": "integer",
+                        "format": "int64"
+                    }
+                },
+                "status": {
+                    "description": "用户状态，1-正常，0-禁用",
+                    "type": "integer"
+                },
+                "updatedAt": {
+                    "description": "更新时间",
+                    "type": "string"
+                },
+                "updatedBy": {
+                    "description": "更新人 ID",
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "username": {
+                    "description": "用户名，唯一标识",
+                    "type": "string"
+                },
+                "wechatOpenID": {
+                    "description": "微信 OpenID，唯一",
+                    "type": "string"
+                }
+            }
+        },
+        "entity.UserSetting": {
+            "type": "object",
+            "properties": {
+                "autoLogin": {
+                    "description": "自动登录",
+                    "type": "boolean"
+                },
+                "createdAt": {
+                    "description": "创建时间",
+                    "type": "string"
+                },
+                "createdBy": {
+                    "description": "创建人 ID",
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "fontSize": {
+                    "description": "字体大小",
+                    "type": "integer"
+                },
+                "id": {
+                    "description": "用户设置 ID",
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "language": {
+                    "description": "语言",
+                    "type": "string"
+                },
+                "navbarColor": {
+                    "description": "导航栏颜色",
+                    "type": "string"
+                },
+                "rememberPassword": {
+                    "description": "记住密码",
+                    "type": "boolean"
+                },
+                "sidebarColor": {
+                    "description": "侧边栏颜色",
+                    "type": "string"
+                },
+                "themeColor": {
+                    "description": "主题颜色",
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "description": "更新时间",
+                    "type": "string"
+                },
+                "updatedBy": {
+                    "description": "更新人 ID",
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "userID": {
+                    "description": "用户 ID",
+                    "type": "integer",
+                    "format": "int64"
+                }
+            }
+        },
+        "monitor.CPUStatus": {
+            "type": "object",
+            "properties": {
+                "cores": {
+                    "description": "CPU 核心数",
+                    "type": "integer"
+                },
+                "per_cpu_usage": {
+                    "description": "每个 CPU 核心的使用率",
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    }
+                },
+                "usage_percent": {
+                    "description": "CPU 使用率百分比",
+                    "type": "number"
+                }
+            }
+        },
+        "monitor.DiskStatus": {
+            "type": "object",
+            "properties": {
+                "free_gb": {
+                    "description": "空闲磁盘空间 (GB)",
+                    "type": "integer"
+                },
+                "io_read_bytes": {
+                    "description": "IO 读取字节数",
+                    "type": "integer"
+                },
+                "io_write_bytes": {
+                    "description": "IO 写入字节数",
+                    "type": "integer"
+                },
+                "read_bytes": {
+                    "description": "读取字节数",
+                    "type": "integer"
+                },
+                "read_count": {
+                    "description": "读取次数",
+                    "type": "integer"
+                },
+                "total_gb": {
+                    "description": "总磁盘空间 (GB)",
+                    "type": "integer"
+                },
+                "usage_percent": {
+                    "description": "磁盘使用率百分比",
+                    "type": "number"
+                },
+                "used_gb": {
+                    "description": "已使用磁盘空间 (GB)",
+                    "type": "integer"
+                },
+                "write_bytes": {
+                    "description": "写入字节数",
+                    "type": "integer"
+                },
+                "write_count": {
+                    "description": "写入次数",
+                    "type": "integer"
+                }
+            }
+        },
+        "monitor.LoadStatus": {
+            "type": "object",
+            "properties": {
+                "load1": {
+                    "description": "1 分钟平均负载",
+                    "type": "number"
+                },
+                "load15": {
+                    "description": "15 分钟平均负载",
+                    "type": "number"
+                },
+                "load5": {
+                    "description": "5 分钟平均负载",
+                    "type": "number"
+                }
+            }
+        },
+        "monitor.MemoryStatus": {
+            "type": "object",
+            "properties": {
+                "available_mb": {
+                    "description": "可用内存 (MB)",
+                    "type": "integer"
+                },
+                "buffers_mb": {
+                    "description": "缓冲区内存 (MB)",
+                    "type": "integer"
+                },
+                "cached_mb": {
+                    "description": "缓存内存 (MB)",
+                    "type": "integer"
+                },
+                "free_mb": {
+                    "description": "空闲内存 (MB)",
+                    "type": "integer"
+                },
+                "total_mb": {
+                    "description": "总内存 (MB)",
+                    "type": "integer"
+                },
+                "usage_percent": {
+                    "description": "内存使用率百分比",
+                    "type": "number"
+                },
+                "used_mb": {
+                    "description": "已使用内存 (MB)",
+                    "type": "integer"
+                },
+                "used_percent": {
+                    "description": "已使用百分比（与 usage_percent 相同）",
+                    "type": "number"
+                }
+            }
+        },
+        "monitor.SystemStatus": {
+            "type": "object",
+            "properties": {
+                "cpu": {
+                    "description": "CPU 使用状态",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/monitor.CPUStatus"
+                        }
+                    ]
+                },
+                "disk": {
+                    "description": "磁盘使用状态",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/monitor.DiskStatus"
+                        }
+                    ]
+                },
+                "load": {
+                    "description": "系统负载状态",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/monitor.LoadStatus"
+                        }
+                    ]
+                },
+                "memory": {
+                    "description": "内存使用状态",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/monitor.MemoryStatus"
+                        }
+                    ]
+                },
+                "timestamp": {
+                    "description": "采集时间",
+                    "type": "string"
+                }
+            }
+        },
+        "push.Event": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "description": "展示给用户的提示",
+                    "type": "string"
+                },
+                "reason": {
+                    "description": "原因",
+                    "type": "string"
+                },
+                "sessionId": {
+                    "description": "目标会话 ID",
+                    "type": "string"
+                },
+                "time": {
+                    "description": "事件时间",
+                    "type": "string"
+                },
+                "type": {
+                    "description": "事件类型",
+                    "type": "string"
+                }
+            }
+        },
+        "request.AddTaskRequest": {
+            "type": "object",
+            "required": [
+                "expression",
+                "task_id",
+                "type"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "expression": {
+                    "type": "string"
+                },
+                "retry_count": {
+                    "type": "integer",
+                    "maximum": 10,
+                    "minimum": 0
+                },
+                "task_id": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "cron",
+                        "one_time"
+                    ]
+                }
+            }
+        },
+        "request.AddUserRequest": {
+            "type": "object",
+            "required": [
+                "password",
+                "userName"
+            ],
+            "properties": {
+                "avatar": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "departmentId": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "email": {
+                    "type": "string"
+                },
+                "nickname": {
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "password": {
+                    "type": "string",
+                    "maxLength": 64,
+                    "minLength": 6
+                },
+                "phone": {
+                    "type": "string",
+                    "maxLength": 20
+                },
+                "positionId": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "roles": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "status": {
+                    "type": "integer",
+                    "maximum": 1,
+                    "minimum": 0
+                },
+                "userName": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "minLength": 3
+                }
+            }
+        },
+        "request.AssignBtnPermToRoleRequest": {
+            "type": "object",
+            "required": [
+                "btnPermIds",
+                "roleId"
+            ],
+            "properties": {
+                "btnPermIds": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "roleId": {
+                    "type": "integer",
+                    "minimum": 1
+                }
+            }
+        },
+        "request.AssignMenuToRoleRequest": {
+            "type": "object",
+            "required": [
+                "menuIds",
+                "roleId"
+            ],
+            "properties": {
+                "menuIds": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "roleId": {
+                    "type": "integer",
+                    "minimum": 1
+                }
+            }
+        },
+        "request.AssignToBtnPermRequest": {
+            "type": "object",
+            "required": [
+                "btnPermId",
+                "ids"
+            ],
+            "properties": {
+                "btnPermId": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "ids": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "request.AssignToRoleRequest": {
+            "type": "object",
+            "required": [
+                "ids",
+                "roleId"
+            ],
+            "properties": {
+                "ids": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "roleId": {
+                    "type": "integer",
+                    "minimum": 1
+                }
+            }
+        },
+        "request.AssignUserToRoleRequest": {
+            "type": "object",
+            "required": [
+                "roleId",
+                "userIds"
+            ],
+            "properties": {
+                "roleId": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "userIds": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "request.BatchDeleteLoginLogsRequest": {
+            "type": "object",
+            "required": [
+                "ids"
+            ],
+            "properties": {
+                "ids": {
+                    "description": "登录日志 ID 列表",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "request.BatchDeleteOperationLogRequest": {
+            "type": "object",
+            "required": [
+                "ids"
+            ],
+            "properties": {
+                "ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "request.BatchForceOfflineRequest": {
+            "type": "object",
+            "required": [
+                "userIds"
+            ],
+            "properties": {
+                "reason": {
+                    "description": "下线原因",
+                    "type": "string"
+                },
+                "userIds": {
+                    "description": "用户 ID 列表",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "request.BlockAbnormalIPsRequest": {
+            "type": "object",
+            "properties": {
+                "minFailedCount": {
+                    "description": "最近 1 小时失败登录次数阈值，默认为 5",
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
+        "request.CaptchaRequest": {
+            "type": "object",
+            "properties": {
+                "account": {
+                    "description": "账号，登录场景用于判断是否已达到失败阈值",
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "scenario": {
+                    "description": "验证码场景，默认 login",
+                    "type": "string",
+                    "enum": [
+                        "login",
+                        "register",
+                        "password_reset"
+                    ]
+                }
+            }
+        },
+        "request.ChangePasswordRequest": {
+            "type": "object",
+            "required": [
+                "newPassword",
+                "oldPassword"
+            ],
+            "properties": {
+                "newPassword": {
+                    "type": "string",
+                    "maxLength": 64,
+                    "minLength": 6
+                },
+                "oldPassword": {
+                    "type": "string",
+                    "maxLength": 64,
+                    "minLength": 6
+                }
+            }
+        },
+        "request.ClearLoginLogsRequest": {
+            "type": "object",
+            "required": [
+                "endTime",
+                "startTime"
+            ],
+            "properties": {
+                "endTime": {
+                    "description": "结束时间（YYYY-MM-DD HH:mm:ss）",
+                    "type": "string"
+                },
+                "startTime": {
+                    "description": "开始时间（YYYY-MM-DD HH:mm:ss）",
+                    "type": "string"
+                }
+            }
+        },
+        "request.ClearOperationLogByTimeRangeRequest": {
+            "type": "object",
+            "required": [
+                "endTime",
+                "startTime"
+            ],
+            "properties": {
+                "endTime": {
+                    "type": "string"
+                },
+                "startTime": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.CreateAPIRequest": {
+            "type": "object",
+            "required": [
+                "method",
+                "path"
+            ],
+            "properties": {
+                "authRequired": {
+                    "type": "boolean"
+                },
+                "authType": {
+                    "type": "integer",
+                    "enum": [
+                        0,
+                        1
+                    ]
+                },
+                "btnPermIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "description": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "group": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "menuIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "method": {
+                    "type": "string",
+                    "enum": [
+                        "GET",
+                        "POST",
+                        "PUT",
+                        "DELETE",
+                        "PATCH",
+                        "HEAD",
+                        "OPTIONS"
+                    ]
+                },
+                "path": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "roleIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "status": {
+                    "type": "integer",
+                    "enum": [
+                        0,
+                        1
+                    ]
+                }
+            }
+        },
+        "request.CreateBtnPermRequest": {
+            "type": "object",
+            "required": [
+                "code",
+                "menuId",
+                "name"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 2
+                },
+                "description": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "menuId": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "minLength": 2
+                },
+                "status": {
+                    "type": "integer",
+                    "enum": [
+                        0,
+                        1
+                    ]
+                }
+            }
+        },
+        "request.CreateConfigRequest": {
+            "type": "object",
+            "required": [
+                "configKey"
+            ],
+            "properties": {
+                "configKey": {
+                    "description": "配置键",
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "configType": {
+                    "description": "配置类型",
+                    "type": "string",
+                    "maxLength": 20
+                },
+                "configValue": {
+                    "description": "配置值",
+                    "type": "string"
+                },
+                "description": {
+                    "description": "描述",
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "status": {
+                    "description": "状态：1-启用 0-禁用",
+                    "type": "integer",
+                    "enum": [
+                        0,
+                        1
+                    ]
+                }
+            }
+        },
+        "request.CreateDepartmentRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "description": {
+                    "description": "部门描述",
+                    "type": "string"
+                },
+                "leaderId": {
+                    "description": "部门负责人 ID",
+                    "type": "integer"
+                },
+                "name": {
+                    "description": "部门名称",
+                    "type": "string"
+                },
+                "parentId": {
+                    "description": "父部门 ID，默认为 0（顶级部门）",
+                    "type": "integer"
+                },
+                "sort": {
+                    "description": "排序，默认为 0",
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "状态：1-正常，0-禁用，默认为 1",
+                    "type": "integer"
+                }
+            }
+        },
+        "request.CreateDictItemRequest": {
+            "type": "object",
+            "required": [
+                "dictId",
+                "itemKey",
+                "itemValue"
+            ],
+            "properties": {
+                "description": {
+                    "description": "描述",
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "dictId": {
+                    "description": "字典 ID",
+                    "type": "integer"
+                },
+                "itemKey": {
+                    "description": "字典项键",
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "itemValue": {
+                    "description": "字典项值",
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "sort": {
+                    "description": "排序",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "status": {
+                    "description": "状态：1-启用 0-禁用",
+                    "type": "integer",
+                    "enum": [
+                        0,
+                        1
+                    ]
+                }
+            }
+        },
+        "request.CreateDictRequest": {
+            "type": "object",
+            "required": [
+                "dictCode",
+                "dictName"
+            ],
+            "properties": {
+                "description": {
+                    "description": "描述",
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "dictCode": {
+                    "description": "字典编码",
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "dictName": {
+                    "description": "字典名称",
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "status": {
+                    "description": "状态：1-启用 0-禁用",
+                    "type": "integer",
+                    "enum": [
+                        0,
+                        1
+                    ]
+                }
+            }
+        },
+        "request.CreateIPPolicyRequest": {
+            "type": "object",
+            "required": [
+                "action",
+                "cidrs",
+                "name",
+                "scope"
+            ],
+            "properties": {
+                "action": {
+                    "description": "动作：allow、deny",
+                    "type": "string"
+                },
+                "cidrs": {
+                    "description": "CIDR 规则列表，也可以填写单个 IP",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "description": {
+                    "description": "描述",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "策略名称",
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "scope": {
+                    "description": "作用范围：global、role、user",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "状态：1-启用，0-禁用，默认为 1",
+                    "type": "integer"
+                },
+                "targetId": {
+                    "description": "作用对象 ID：角色 ID 或用户 ID，全局策略不填",
+                    "type": "string"
+                }
+            }
+        },
+        "request.CreateLDAPGroupMappingRequest": {
+            "type": "object",
+            "required": [
+                "groupDn"
+            ],
+            "properties": {
+                "departmentId": {
+                    "description": "映射的部门 ID",
+                    "type": "string",
+                    "example": "0"
+                },
+                "description": {
+                    "description": "描述",
+                    "type": "string"
+                },
+                "groupDn": {
+                    "description": "LDAP 组 DN 或组名（CN）",
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "priority": {
+                    "description": "优先级，值越大越优先",
+                    "type": "integer"
+                },
+                "roleId": {
+                    "description": "映射的角色 ID",
+                    "type": "string",
+                    "example": "0"
+                }
+            }
+        },
+        "request.CreateNoticeRequest": {
+            "type": "object",
+            "required": [
+                "content",
+                "title",
+                "type"
+            ],
+            "properties": {
+                "content": {
+                    "description": "公告内容",
+                    "type": "string"
+                },
+                "description": {
+                    "description": "公告描述/摘要",
+                    "type": "string"
+                },
+                "isTop": {
+                    "description": "是否置顶",
+                    "type": "boolean"
+                },
+                "sort": {
+                    "description": "排序",
+                    "type": "integer"
+                },
+                "title": {
+                    "description": "公告标题",
+                    "type": "string"
+                },
+                "type": {
+                    "description": "公告类型：1-系统公告，2-活动公告，3-普通通知，4-紧急通知",
+                    "type": "integer"
+                }
+            }
+        },
+        "request.CreateOIDCClientRequest": {
+            "type": "object",
+            "required": [
+                "clientId",
+                "name"
+            ],
+            "properties": {
+                "clientId": {
+                    "description": "客户端标识，例如 web、h5app",
+                    "type": "string"
+                },
+                "description": {
+                    "description": "描述",
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "grantTypes": {
+                    "description": "授权类型，默认 authorization_code、refresh_token",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "description": "应用名称",
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "public": {
+                    "description": "是否为公开客户端（SPA、移动端），无密钥，必须使用 PKCE",
+                    "type": "boolean"
+                },
+                "redirectUris": {
+                    "description": "允许的回调地址，精确匹配",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "scopes": {
+                    "description": "允许申请的授权范围，openid 始终包含",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "skipConsent": {
+                    "description": "是否跳过授权确认",
+                    "type": "boolean"
+                },
+                "status": {
+                    "description": "状态：1-启用，0-禁用",
+                    "type": "integer",
+                    "enum": [
+                        0,
+                        1
+                    ]
+                }
+            }
+        },
+        "request.CreateOIDCProviderRequest": {
+            "type": "object",
+            "required": [
+                "clientId",
+                "clientSecret",
+                "code",
+                "issuer",
+                "name",
+                "redirectUri"
+            ],
+            "properties": {
+                "authorizationEndpoint": {
+                    "description": "授权端点，为空时使用发现文档",
+                    "type": "string"
+                },
+                "autoCreate": {
+                    "description": "无关联账号时是否自动开通本地用户",
+                    "type": "boolean"
+                },
+                "clientId": {
+                    "description": "客户端 ID",
+                    "type": "string"
+                },
+                "clientSecret": {
+                    "description": "客户端密钥",
+                    "type": "string"
+                },
+                "code": {
+                    "description": "编码，用于登录地址",
+                    "type": "string"
+                },
+                "issuer": {
+                    "description": "签发方",
+                    "type": "string"
+                },
+                "jwksUri": {
+                    "description": "JWKS 地址，为空时使用发现文档",
+                    "type": "string"
+                },
+                "linkBy": {
+                    "description": "账号关联方式：subject / email",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "显示名称",
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "redirectUri": {
+                    "description": "回调地址",
+                    "type": "string"
+                },
+                "roleMappings": {
+                    "description": "声明到角色的映射规则",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/request.OIDCRoleMappingRequest"
+                    }
+                },
+                "scopes": {
+                    "description": "授权范围，默认 openid profile email",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "sort": {
+                    "description": "排序",
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "状态：1-启用，0-禁用",
+                    "type": "integer",
+                    "enum": [
+                        0,
+                        1
+                    ]
+                },
+                "tokenAuthMethod": {
+                    "description": "客户端认证方式：client_secret_basic / client_secret_post",
+                    "type": "string"
+                },
+                "tokenEndpoint": {
+                    "description": "令牌端点，为空时使用发现文档",
+                    "type": "string"
+                },
+                "trustEmail": {
+                    "description": "是否信任未声明 email_verified 的邮箱",
+                    "type": "boolean"
+                },
+                "userinfoEndpoint": {
+                    "description": "用户信息端点，为空时使用发现文档",
+                    "type": "string"
+                },
+                "usernameClaim": {
+                    "description": "自动开通时作为用户名的声明",
+                    "type": "string"
+                }
+            }
+        },
+        "request.CreateOpenAppRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "signType",
+                "userId"
+            ],
+            "properties": {
+                "description": {
+                    "description": "描述",
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "name": {
+                    "description": "应用名称",
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "publicKey": {
+                    "description": "合作方 RSA 公钥 PEM，RSA-SHA256 时必填",
+                    "type": "string"
+                },
+                "signType": {
+                    "description": "签名方式：HMAC-SHA256、RSA-SHA256",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "状态：1-启用，0-禁用，默认为 1",
+                    "type": "integer"
+                },
+                "userId": {
+                    "description": "绑定的平台用户 ID",
+                    "type": "string"
+                }
+            }
+        },
+        "request.CreatePaymentRequest": {
+            "type": "object",
+            "required": [
+                "amount",
+                "currency",
+                "order_id",
+                "payment_method"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "currency": {
+                    "type": "string",
+                    "enum": [
+                        "CNY",
+                        "USD"
+                    ]
+                },
+                "description": {
+                    "type": "string"
+                },
+                "order_id": {
+                    "type": "string"
+                },
+                "payment_method": {
+                    "type": "string",
+                    "enum": [
+                        "wechat_alipay"
+                    ]
+                }
+            }
+        },
+        "request.CreatePermissionRuleRequest": {
+            "type": "object",
+            "required": [
+                "apiPath",
+                "effect",
+                "name"
+            ],
+            "properties": {
+                "apiPath": {
+                    "description": "API 路径模式，支持 :param 和 *wildcard",
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "conditions": {
+                    "description": "条件表达式，JSON 格式，为空表示无条件",
+                    "type": "string"
+                },
+                "description": {
+                    "description": "规则描述",
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "effect": {
+                    "description": "效果：allow、deny",
+                    "type": "string"
+                },
+                "method": {
+                    "description": "HTTP 方法，多个用逗号分隔，为空或 * 表示全部",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "规则名称",
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "sort": {
+                    "description": "排序",
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "状态：1-启用，0-禁用，默认为 1",
+                    "type": "integer"
+                }
+            }
+        },
+        "request.CreatePositionRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "description": {
+                    "description": "岗位描述",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "岗位名称",
+                    "type": "string"
+                },
+                "sort": {
+                    "description": "排序，默认为 0",
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "状态：1-正常，0-禁用，默认为 1",
+                    "type": "integer"
+                }
+            }
+        },
+        "request.CreateRoleRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "minLength": 2
+                },
+                "status": {
+                    "type": "integer",
+                    "enum": [
+                        0,
+                        1
+                    ]
+                }
+            }
+        },
+        "request.CreateServiceAccountRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "roleIds"
+            ],
+            "properties": {
+                "description": {
+                    "description": "描述",
+                    "type": "string"
+                },
+                "expiresAt": {
+                    "description": "首个 API Key 过期时间，为空表示永不过期",
+                    "type": "string"
+                },
+                "ipAllowlist": {
+                    "description": "IP 白名单（IP 或 CIDR），为空表示不限制",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "description": "服务账号名称",
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "roleIds": {
+                    "description": "绑定的角色 ID 列表",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "status": {
+                    "description": "状态：1-启用，0-禁用，默认为 1",
+                    "type": "integer"
+                }
+            }
+        },
+        "request.CreateSoDConstraintRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "roleIds",
+                "type"
+            ],
+            "properties": {
+                "description": {
+                    "description": "描述",
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "maxRoles": {
+                    "description": "最多可持有或激活的角色数量，默认为 1 即互斥",
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "name": {
+                    "description": "约束名称",
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "roleIds": {
+                    "description": "约束涉及的角色 ID 列表",
+                    "type": "array",
+                    "minItems": 2,
+                    "items": {
+                        "type": "string"
                     }
                 },
+                "status": {
+                    "description": "状态：1-启用，0-禁用，默认为 1",
+                    "type": "integer"
+                },
+                "type": {
+                    "description": "约束类型：static-静态，dynamic-动态",
+                    "type": "string",
+                    "enum": [
+                        "static",
+                        "dynamic"
+                    ]
+                }
+            }
+        },
+        "request.DeleteBtnPermRequest": {
+            "type": "object",
+            "required": [
+                "id"
+            ],
+            "properties": {
+                "id": {
+                    "type": "integer",
+                    "minimum": 1
+                }
+            }
+        },
+        "request.DeleteRoleRequest": {
+            "type": "object",
+            "required": [
+                "id"
+            ],
+            "properties": {
+                "id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "request.ExplainPermissionRequest": {
+            "type": "object",
+            "required": [
+                "userId"
+            ],
+            "properties": {
+                "attributes": {
+                    "description": "补充的资源和环境属性，如 {\"env.ip\": \"10.0.0.1\"}",
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "buttonCode": {
+                    "description": "按钮权限编码",
+                    "type": "string"
+                },
                 "method": {
-                    "description": "HTTP 方法",
+                    "description": "请求方法",
                     "type": "string"
                 },
                 "path": {
-                    "description": "路由路径",
+                    "description": "请求路径，如 /api/admin/user/123",
                     "type": "string"
                 },
-                "roleIds": {
-                    "description": "关联的角色 ID 列表",
-                    "type": "array",
-                    "items": {
-                        "type": "integer",
-                        "format": "int64"
-                    }
+                "roleCode": {
+                    "description": "当前角色编码，为空时按用户的全部角色解释，不是用户已分配的角色时按无角色解释",
+                    "type": "string"
                 },
-                "status": {
-                    "description": "状态：1-启用，0-禁用",
-                    "type": "integer"
+                "userId": {
+                    "description": "用户 ID",
+                    "type": "string"
+                }
+            }
+        },
+        "request.ForgotPasswordRequest": {
+            "type": "object",
+            "required": [
+                "account"
+            ],
+            "properties": {
+                "account": {
+                    "description": "用户名、邮箱或手机号",
+                    "type": "string",
+                    "maxLength": 100
                 },
-                "updatedAt": {
-                    "description": "更新时间",
+                "captcha": {
+                    "description": "图形验证码，滑块验证码为拼图块横坐标",
                     "type": "string"
                 },
-                "updatedBy": {
-                    "description": "更新人 ID",
-                    "type": "integer",
-                    "format": "int64"
+                "captchaId": {
+                    "description": "图形验证码 ID",
+                    "type": "string"
+                },
+                "channel": {
+                    "description": "发送渠道，为空时自动选择",
+                    "type": "string",
+                    "enum": [
+                        "email",
+                        "sms"
+                    ]
                 }
             }
         },
-        "entity.BtnPerm": {
+        "request.ForgotPasswordResetRequest": {
             "type": "object",
+            "required": [
+                "account",
+                "code",
+                "newPassword"
+            ],
             "properties": {
-                "apirouteIds": {
-                    "description": "关联的 API 路由 ID 列表",
-                    "type": "array",
-                    "items": {
-                        "type": "integer",
-                        "format": "int64"
-                    }
-                },
-                "apiroutes": {
-                    "description": "关联的 API 路由对象列表",
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/entity.API"
-                    }
+                "account": {
+                    "type": "string",
+                    "maxLength": 100
                 },
                 "code": {
-                    "description": "权限编码，如：user:create, user:edit",
+                    "type": "string",
+                    "maxLength": 10
+                },
+                "newPassword": {
+                    "type": "string",
+                    "maxLength": 64,
+                    "minLength": 6
+                }
+            }
+        },
+        "request.GrantRoleRequest": {
+            "type": "object",
+            "required": [
+                "roleId",
+                "userId",
+                "validUntil"
+            ],
+            "properties": {
+                "justification": {
+                    "description": "授予理由",
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "roleId": {
+                    "description": "授予的角色 ID",
                     "type": "string"
                 },
-                "createdAt": {
-                    "description": "创建时间",
+                "userId": {
+                    "description": "被授予用户 ID",
                     "type": "string"
                 },
-                "createdBy": {
-                    "description": "创建人 ID",
-                    "type": "integer",
-                    "format": "int64"
+                "validFrom": {
+                    "description": "生效时间，为空表示立即生效",
+                    "type": "string"
                 },
-                "description": {
-                    "description": "权限描述",
+                "validUntil": {
+                    "description": "到期时间",
+                    "type": "string"
+                }
+            }
+        },
+        "request.ImportPolicyBundleRequest": {
+            "type": "object",
+            "required": [
+                "content"
+            ],
+            "properties": {
+                "content": {
+                    "description": "策略包文件内容",
                     "type": "string"
                 },
-                "id": {
-                    "description": "按钮权限 ID",
-                    "type": "integer",
-                    "format": "int64"
+                "dryRun": {
+                    "description": "是否只比较差异不写入",
+                    "type": "boolean"
                 },
-                "menu": {
-                    "description": "所属菜单（新增）",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/entity.Menu"
-                        }
+                "format": {
+                    "description": "文件格式：yaml（默认）、json",
+                    "type": "string",
+                    "enum": [
+                        "yaml",
+                        "json"
                     ]
-                },
-                "menuID": {
-                    "description": "所属菜单 ID",
-                    "type": "integer",
-                    "format": "int64"
-                },
-                "name": {
-                    "description": "权限名称，如：创建用户，编辑用户",
+                }
+            }
+        },
+        "request.JsonInBlacklistRequest": {
+            "type": "object",
+            "required": [
+                "token"
+            ],
+            "properties": {
+                "token": {
+                    "description": "JWT token",
+                    "type": "string"
+                }
+            }
+        },
+        "request.LoginRequest": {
+            "type": "object",
+            "required": [
+                "password",
+                "userName"
+            ],
+            "properties": {
+                "captcha": {
+                    "description": "验证码，滑块验证码为拼图块横坐标",
                     "type": "string"
                 },
-                "roleIds": {
-                    "description": "关联的角色 ID 列表",
-                    "type": "array",
-                    "items": {
-                        "type": "integer",
-                        "format": "int64"
-                    }
-                },
-                "roles": {
-                    "description": "关联的角色对象列表关联的角色列表（新增）",
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/entity.Role"
-                    }
-                },
-                "status": {
-                    "description": "状态：1-启用，0-禁用",
-                    "type": "integer"
+                "captchaId": {
+                    "description": "验证码 ID，按登录场景策略需要时必填",
+                    "type": "string"
                 },
-                "updatedAt": {
-                    "description": "更新时间",
+                "password": {
                     "type": "string"
                 },
-                "updatedBy": {
-                    "description": "更新人 ID",
-                    "type": "integer",
-                    "format": "int64"
+                "userName": {
+                    "type": "string"
                 }
             }
         },
-        "entity.DailyStat": {
+        "request.LoginStepUpRequest": {
             "type": "object",
+            "required": [
+                "challengeId",
+                "code"
+            ],
             "properties": {
-                "date": {
-                    "description": "日期",
+                "challengeId": {
+                    "description": "登录时返回的二次验证挑战 ID",
                     "type": "string"
                 },
-                "failedCount": {
-                    "description": "失败次数",
-                    "type": "integer",
-                    "format": "int64"
-                },
-                "successCount": {
-                    "description": "成功次数",
-                    "type": "integer",
-                    "format": "int64"
+                "code": {
+                    "description": "验证码",
+                    "type": "string"
+                }
+            }
+        },
+        "request.OIDCCallbackRequest": {
+            "type": "object",
+            "required": [
+                "code",
+                "state"
+            ],
+            "properties": {
+                "code": {
+                    "description": "授权码",
+                    "type": "string"
                 },
-                "totalCount": {
-                    "description": "总次数",
-                    "type": "integer",
-                    "format": "int64"
+                "state": {
+                    "description": "发起登录时返回的 state",
+                    "type": "string"
                 }
             }
         },
-        "entity.DeleteImpact": {
+        "request.OIDCConsentRequest": {
             "type": "object",
             "properties": {
-                "affectedApisCount": {
-                    "description": "影响的 API 数量",
-                    "type": "integer",
-                    "format": "int64"
+                "approved": {
+                    "description": "是否同意授权",
+                    "type": "boolean"
                 },
-                "affectedRolesCount": {
-                    "description": "受影响的角色数量",
-                    "type": "integer",
-                    "format": "int64"
+                "clientId": {
+                    "description": "客户端标识",
+                    "type": "string"
                 },
-                "btnPermsCount": {
-                    "description": "按钮权限数量",
-                    "type": "integer",
-                    "format": "int64"
+                "codeChallenge": {
+                    "description": "PKCE 校验值",
+                    "type": "string"
+                },
+                "codeChallengeMethod": {
+                    "description": "PKCE 方法",
+                    "type": "string"
+                },
+                "nonce": {
+                    "description": "ID Token nonce",
+                    "type": "string"
+                },
+                "prompt": {
+                    "description": "consent 表示强制重新确认",
+                    "type": "string"
+                },
+                "redirectUri": {
+                    "description": "回调地址",
+                    "type": "string"
                 },
-                "childMenusCount": {
-                    "description": "子菜单数量",
-                    "type": "integer",
-                    "format": "int64"
+                "responseType": {
+                    "description": "响应类型，仅支持 code",
+                    "type": "string"
                 },
-                "level": {
-                    "description": "影响等级：LOW, MEDIUM, HIGH",
+                "scope": {
+                    "description": "授权范围，空格分隔",
                     "type": "string"
                 },
-                "warning": {
-                    "description": "警告信息",
+                "state": {
+                    "description": "客户端状态",
                     "type": "string"
                 }
             }
         },
-        "entity.Department": {
+        "request.OIDCRoleMappingRequest": {
             "type": "object",
+            "required": [
+                "claim",
+                "roleId",
+                "value"
+            ],
             "properties": {
-                "children": {
-                    "description": "子部门",
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/entity.Department"
-                    }
-                },
-                "createdAt": {
-                    "description": "创建时间",
+                "claim": {
+                    "description": "声明路径，例如 groups、realm_access.roles",
                     "type": "string"
                 },
-                "createdBy": {
-                    "description": "创建人 ID",
-                    "type": "integer",
-                    "format": "int64"
+                "roleId": {
+                    "description": "授予的角色 ID",
+                    "type": "string",
+                    "example": "0"
                 },
-                "description": {
-                    "description": "部门描述",
+                "value": {
+                    "description": "期望的声明值",
+                    "type": "string"
+                }
+            }
+        },
+        "request.RecordLoginRequest": {
+            "type": "object",
+            "required": [
+                "ip",
+                "userId",
+                "userName"
+            ],
+            "properties": {
+                "browser": {
+                    "description": "浏览器信息",
                     "type": "string"
                 },
-                "id": {
-                    "description": "部门 ID",
-                    "type": "integer",
-                    "format": "int64"
+                "device": {
+                    "description": "设备信息",
+                    "type": "string"
                 },
-                "leader": {
-                    "description": "部门负责人（新增）",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/entity.User"
-                        }
-                    ]
+                "errorMsg": {
+                    "description": "错误信息",
+                    "type": "string"
                 },
-                "leaderID": {
-                    "description": "部门负责人 ID",
-                    "type": "integer",
-                    "format": "int64"
+                "ip": {
+                    "description": "登录 IP",
+                    "type": "string"
                 },
-                "name": {
-                    "description": "部门名称",
+                "os": {
+                    "description": "操作系统",
                     "type": "string"
                 },
-                "parent": {
-                    "description": "父部门（新增）",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/entity.Department"
-                        }
-                    ]
+                "place": {
+                    "description": "登录地点",
+                    "type": "string"
                 },
-                "parentID": {
-                    "description": "父部门 ID",
-                    "type": "integer",
-                    "format": "int64"
+                "success": {
+                    "description": "是否成功",
+                    "type": "boolean"
                 },
-                "sort": {
-                    "description": "排序",
-                    "type": "integer"
+                "userAgent": {
+                    "description": "原始 User-Agent",
+                    "type": "string"
                 },
-                "status": {
-                    "description": "状态：1-正常，0-禁用",
+                "userId": {
+                    "description": "用户 ID",
                     "type": "integer"
                 },
-                "updatedAt": {
-                    "description": "更新时间",
+                "userName": {
+                    "description": "用户名",
+                    "type": "string"
+                }
+            }
+        },
+        "request.RegisterRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "password",
+                "userName"
+            ],
+            "properties": {
+                "captcha": {
+                    "description": "验证码，滑块验证码为拼图块横坐标",
                     "type": "string"
                 },
-                "updatedBy": {
-                    "description": "更新人 ID",
-                    "type": "integer",
-                    "format": "int64"
+                "captchaId": {
+                    "description": "验证码 ID",
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string",
+                    "maxLength": 64,
+                    "minLength": 6
+                },
+                "userName": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "minLength": 3
                 }
             }
         },
-        "entity.FailedIPStat": {
+        "request.RequestElevationRequest": {
             "type": "object",
+            "required": [
+                "hours",
+                "justification",
+                "roleId"
+            ],
             "properties": {
-                "failedCount": {
-                    "description": "失败次数",
+                "hours": {
+                    "description": "申请时长（小时）",
                     "type": "integer",
-                    "format": "int64"
+                    "minimum": 1
                 },
-                "ip": {
-                    "description": "IP 地址",
+                "justification": {
+                    "description": "申请理由",
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "roleId": {
+                    "description": "申请的角色 ID",
                     "type": "string"
                 }
             }
         },
-        "entity.HourlyStat": {
+        "request.ResetPasswordRequest": {
             "type": "object",
+            "required": [
+                "userId"
+            ],
             "properties": {
-                "count": {
-                    "description": "统计数量",
-                    "type": "integer",
-                    "format": "int64"
+                "newPassword": {
+                    "description": "新密码，为空时自动生成符合策略的临时密码",
+                    "type": "string",
+                    "maxLength": 64
                 },
-                "hour": {
-                    "description": "小时 (0-23)",
+                "userId": {
                     "type": "integer"
                 }
             }
         },
-        "entity.LoginStatistics": {
+        "request.ReviewPermissionChangeRequest": {
             "type": "object",
             "properties": {
-                "dailyStats": {
-                    "description": "每日统计",
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/entity.DailyStat"
-                    }
-                },
-                "endTime": {
-                    "description": "统计结束时间",
-                    "type": "string"
-                },
-                "failedCount": {
-                    "description": "失败登录次数",
-                    "type": "integer",
-                    "format": "int64"
-                },
-                "failedIPs": {
-                    "description": "失败最多的 IP",
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/entity.FailedIPStat"
-                    }
-                },
-                "hourlyStats": {
-                    "description": "每小时统计",
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/entity.HourlyStat"
-                    }
-                },
-                "startTime": {
-                    "description": "统计开始时间",
-                    "type": "string"
-                },
-                "successCount": {
-                    "description": "成功登录次数",
+                "comment": {
+                    "description": "审批意见",
+                    "type": "string",
+                    "maxLength": 500
+                }
+            }
+        },
+        "request.ReviewRoleGrantRequest": {
+            "type": "object",
+            "properties": {
+                "comment": {
+                    "description": "审批意见或收回原因",
+                    "type": "string",
+                    "maxLength": 500
+                }
+            }
+        },
+        "request.RevokeBtnPermFromRoleRequest": {
+            "type": "object",
+            "required": [
+                "btnPermId",
+                "roleId"
+            ],
+            "properties": {
+                "btnPermId": {
                     "type": "integer",
-                    "format": "int64"
-                },
-                "topIPs": {
-                    "description": "登录最频繁 IP",
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/entity.TopIPStat"
-                    }
-                },
-                "topUsers": {
-                    "description": "登录最频繁用户",
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/entity.TopUserStat"
-                    }
+                    "minimum": 1
                 },
-                "totalCount": {
-                    "description": "登录总次数",
+                "roleId": {
                     "type": "integer",
-                    "format": "int64"
-                },
-                "uniqueIPs": {
-                    "description": "独立 IP 数",
+                    "minimum": 1
+                }
+            }
+        },
+        "request.RevokeFromBtnPermRequest": {
+            "type": "object",
+            "required": [
+                "btnPermId",
+                "id"
+            ],
+            "properties": {
+                "btnPermId": {
                     "type": "integer",
-                    "format": "int64"
+                    "minimum": 1
                 },
-                "uniqueUsers": {
-                    "description": "独立用户数",
+                "id": {
                     "type": "integer",
-                    "format": "int64"
+                    "minimum": 1
                 }
             }
         },
-        "entity.Menu": {
+        "request.RevokeSessionRequest": {
             "type": "object",
+            "required": [
+                "sessionId"
+            ],
             "properties": {
-                "activeMenu": {
-                    "description": "当前激活的菜单",
+                "sessionId": {
+                    "description": "会话 ID",
                     "type": "string"
-                },
-                "affix": {
-                    "description": "是否固定在标签栏",
-                    "type": "boolean"
-                },
-                "apirouteIds": {
-                    "description": "关联的 API 路由 ID 列表",
-                    "type": "array",
-                    "items": {
-                        "type": "integer",
-                        "format": "int64"
-                    }
-                },
-                "apiroutes": {
-                    "description": "关联的 API 路由列表（新增）",
+                }
+            }
+        },
+        "request.RoleChangeRequest": {
+            "type": "object",
+            "required": [
+                "roleId"
+            ],
+            "properties": {
+                "apiIds": {
+                    "description": "直接授予的 API ID 列表",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/entity.API"
+                        "type": "string"
                     }
                 },
-                "breadcrumb": {
-                    "description": "是否在面包屑显示",
-                    "type": "boolean"
-                },
                 "btnPermIds": {
-                    "description": "菜单下的按钮权限 ID 列表",
+                    "description": "直接授予的按钮权限 ID 列表",
                     "type": "array",
                     "items": {
-                        "type": "integer",
-                        "format": "int64"
+                        "type": "string"
                     }
                 },
-                "btnPerms": {
-                    "description": "菜单下的按钮权限列表（新增）",
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/entity.BtnPerm"
-                    }
+                "parentId": {
+                    "description": "父角色 ID，\"0\" 表示取消继承",
+                    "type": "string"
                 },
-                "children": {
-                    "description": "子菜单",
+                "permissionGroupIds": {
+                    "description": "关联的权限组 ID 列表",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/entity.Menu"
+                        "type": "string"
                     }
                 },
-                "component": {
-                    "description": "组件路径",
-                    "type": "string"
-                },
-                "createdAt": {
-                    "description": "创建时间",
-                    "type": "string"
-                },
-                "createdBy": {
-                    "description": "创建人 ID",
-                    "type": "integer",
-                    "format": "int64"
-                },
-                "defaultMenu": {
-                    "description": "是否默认菜单",
-                    "type": "boolean"
-                },
-                "frameLoading": {
-                    "description": "iframe 加载动画",
-                    "type": "boolean"
-                },
-                "frameSrc": {
-                    "description": "iframe 地址（如果是 iframe 类型）",
+                "roleId": {
+                    "description": "角色 ID",
                     "type": "string"
                 },
-                "hidden": {
-                    "description": "是否隐藏",
-                    "type": "boolean"
-                },
-                "icon": {
-                    "description": "菜单图标",
+                "status": {
+                    "description": "状态：1-启用，0-禁用",
+                    "type": "integer"
+                }
+            }
+        },
+        "request.RotateServiceAccountKeyRequest": {
+            "type": "object",
+            "properties": {
+                "expiresAt": {
+                    "description": "新 Key 过期时间，为空表示永不过期",
                     "type": "string"
                 },
-                "id": {
-                    "description": "菜单 ID",
+                "graceSeconds": {
+                    "description": "旧 Key 宽限期（秒），0 表示立即失效",
                     "type": "integer",
-                    "format": "int64"
+                    "minimum": 0
+                }
+            }
+        },
+        "request.SaveRolePermissionsRequest": {
+            "type": "object",
+            "required": [
+                "apiRouteIds",
+                "btnPermIds",
+                "menuIds"
+            ],
+            "properties": {
+                "apiRouteIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "isExt": {
-                    "description": "是否外部链接",
-                    "type": "boolean"
+                "btnPermIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "keepAlive": {
-                    "description": "是否缓存",
+                "menuIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "request.SetRoleApprovalRequiredRequest": {
+            "type": "object",
+            "required": [
+                "approvalRequired"
+            ],
+            "properties": {
+                "approvalRequired": {
+                    "description": "是否需要审批",
                     "type": "boolean"
-                },
-                "meta": {
-                    "description": "菜单元数据",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/entity.MenuMeta"
-                        }
-                    ]
-                },
-                "name": {
-                    "description": "路由名称，前端组件名",
-                    "type": "string"
-                },
-                "parent": {
-                    "description": "父菜单（新增）",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/entity.Menu"
-                        }
-                    ]
-                },
-                "parentID": {
-                    "description": "父菜单 ID，0 表示顶级菜单",
+                }
+            }
+        },
+        "request.SetRoleDataScopeRequest": {
+            "type": "object",
+            "required": [
+                "dataScope"
+            ],
+            "properties": {
+                "dataScope": {
+                    "description": "数据权限范围：1-全部，2-本部门，3-本部门及下级，4-自定义部门，5-仅本人",
                     "type": "integer",
-                    "format": "int64"
-                },
-                "path": {
-                    "description": "路由路径",
-                    "type": "string"
-                },
-                "permission": {
-                    "description": "权限标识",
-                    "type": "string"
-                },
-                "redirect": {
-                    "description": "重定向地址",
-                    "type": "string"
+                    "maximum": 5,
+                    "minimum": 1
                 },
-                "roleIds": {
-                    "description": "关联的角色 ID 列表",
+                "deptIds": {
+                    "description": "自定义部门 ID 列表，数据权限范围为 4 时必填",
                     "type": "array",
                     "items": {
-                        "type": "integer",
-                        "format": "int64"
+                        "type": "string"
                     }
-                },
-                "roles": {
-                    "description": "关联的角色列表（新增）",
+                }
+            }
+        },
+        "request.SetTaskGroupRequest": {
+            "type": "object",
+            "required": [
+                "group"
+            ],
+            "properties": {
+                "group": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.SetUserAuthoritiesRequest": {
+            "type": "object",
+            "required": [
+                "roleIds",
+                "userId"
+            ],
+            "properties": {
+                "roleIds": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/entity.Role"
+                        "type": "string"
                     }
                 },
-                "sort": {
-                    "description": "排序号",
-                    "type": "integer"
-                },
-                "status": {
-                    "description": "状态：0 禁用，1 启用",
-                    "type": "integer"
-                },
-                "title": {
-                    "description": "菜单标题",
-                    "type": "string"
-                },
-                "type": {
-                    "description": "菜单类型：1 目录，2 菜单，3 按钮，4iframe",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/entity.MenuType"
-                        }
-                    ]
-                },
-                "updatedAt": {
-                    "description": "更新时间",
+                "userId": {
+                    "description": "支持 string 和 int64"
+                }
+            }
+        },
+        "request.SetUserAuthorityRequest": {
+            "type": "object",
+            "required": [
+                "roleId",
+                "userId"
+            ],
+            "properties": {
+                "roleId": {
                     "type": "string"
                 },
-                "updatedBy": {
-                    "description": "更新人 ID",
-                    "type": "integer",
-                    "format": "int64"
+                "userId": {
+                    "description": "支持 string 和 int64"
                 }
             }
         },
-        "entity.MenuMeta": {
+        "request.SimulatePermissionRequest": {
             "type": "object",
+            "required": [
+                "userId"
+            ],
             "properties": {
-                "activeMenu": {
-                    "description": "当前激活的菜单",
-                    "type": "string"
-                },
-                "affix": {
-                    "description": "是否固定在标签栏",
-                    "type": "boolean"
-                },
-                "breadcrumb": {
-                    "description": "是否在面包屑显示",
-                    "type": "boolean"
+                "attributes": {
+                    "description": "补充的资源和环境属性，如 {\"env.ip\": \"10.0.0.1\"}",
+                    "type": "object",
+                    "additionalProperties": true
                 },
-                "defaultMenu": {
-                    "description": "是否默认菜单",
-                    "type": "boolean"
+                "buttonCode": {
+                    "description": "按钮权限编码",
+                    "type": "string"
                 },
-                "frameLoading": {
-                    "description": "iframe 加载动画",
-                    "type": "boolean"
+                "method": {
+                    "description": "请求方法",
+                    "type": "string"
                 },
-                "frameSrc": {
-                    "description": "iframe 地址（如果是 iframe 类型）",
+                "path": {
+                    "description": "请求路径，如 /api/admin/user/123",
                     "type": "string"
                 },
-                "icon": {
-                    "description": "菜单图标",
+                "roleCode": {
+                    "description": "当前角色编码，为空时按用户的全部角色解释，不是用户已分配的角色时按无角色解释",
                     "type": "string"
                 },
-                "keepAlive": {
-                    "description": "是否缓存",
-                    "type": "boolean"
+                "roles": {
+                    "description": "角色拟修改的配置",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/request.RoleChangeRequest"
+                    }
                 },
-                "title": {
-                    "description": "菜单标题",
+                "userId": {
+                    "description": "用户 ID",
                     "type": "string"
+                },
+                "userRoleIds": {
+                    "description": "用户拟分配的角色 ID 列表，不填写时使用当前分配",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 }
             }
         },
-        "entity.MenuType": {
-            "type": "integer",
-            "enum": [
-                1,
-                2,
-                3,
-                4
-            ],
-            "x-enum-comments": {
-                "MenuTypeButton": "按钮",
-                "MenuTypeDirectory": "目录",
-                "MenuTypeIframe": "内嵌 iframe",
-                "MenuTypeMenu": "菜单"
-            },
-            "x-enum-descriptions": [
-                "目录",
-                "菜单",
-                "按钮",
-                "内嵌 iframe"
+        "request.SwitchRoleRequest": {
+            "type": "object",
+            "required": [
+                "roleId"
             ],
-            "x-enum-varnames": [
-                "MenuTypeDirectory",
-                "MenuTypeMenu",
-                "MenuTypeButton",
-                "MenuTypeIframe"
-            ]
+            "properties": {
+                "roleId": {
+                    "type": "string"
+                }
+            }
         },
-        "entity.PermissionGroup": {
+        "request.UpdateAPIRequest": {
             "type": "object",
+            "required": [
+                "id",
+                "method",
+                "path"
+            ],
             "properties": {
-                "apirouteIds": {
-                    "description": "权限组关联的接口路由 ID 列表",
-                    "type": "array",
-                    "items": {
-                        "type": "integer",
-                        "format": "int64"
-                    }
+                "authRequired": {
+                    "type": "boolean"
                 },
-                "apiroutes": {
-                    "description": "权限组关联的接口路由对象列表",
+                "authType": {
+                    "type": "integer",
+                    "enum": [
+                        0,
+                        1
+                    ]
+                },
+                "btnPermIds": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/entity.API"
+                        "type": "string"
                     }
                 },
-                "btnPermIds": {
-                    "description": "权限组关联的按钮权限 ID 列表",
+                "description": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "group": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "menuIds": {
                     "type": "array",
                     "items": {
-                        "type": "integer",
-                        "format": "int64"
+                        "type": "string"
                     }
                 },
-                "btnPerms": {
-                    "description": "权限组关联的按钮权限对象列表",
+                "method": {
+                    "type": "string",
+                    "enum": [
+                        "GET",
+                        "POST",
+                        "PUT",
+                        "DELETE",
+                        "PATCH",
+                        "HEAD",
+                        "OPTIONS"
+                    ]
+                },
+                "path": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "roleIds": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/entity.BtnPerm"
+                        "type": "string"
                     }
                 },
-                "createdAt": {
-                    "description": "创建时间",
-                    "type": "string"
-                },
-                "createdBy": {
-                    "description": "创建人 ID",
+                "status": {
                     "type": "integer",
-                    "format": "int64"
+                    "enum": [
+                        0,
+                        1
+                    ]
+                }
+            }
+        },
+        "request.UpdateBtnPermRequest": {
+            "type": "object",
+            "required": [
+                "code",
+                "id",
+                "menuId",
+                "name"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 2
                 },
                 "description": {
-                    "description": "权限组描述",
-                    "type": "string"
+                    "type": "string",
+                    "maxLength": 255
                 },
                 "id": {
-                    "description": "权限组 ID",
                     "type": "integer",
-                    "format": "int64"
+                    "minimum": 1
+                },
+                "menuId": {
+                    "type": "integer",
+                    "minimum": 1
                 },
                 "name": {
-                    "description": "权限组名称",
-                    "type": "string"
+                    "type": "string",
+                    "maxLength": 50,
+                    "minLength": 2
                 },
-                "roleIds": {
-                    "description": "权限组关联的角色 ID 列表",
-                    "type": "array",
-                    "items": {
-                        "type": "integer",
-                        "format": "int64"
-                    }
+                "status": {
+                    "type": "integer",
+                    "enum": [
+                        0,
+                        1
+                    ]
+                }
+            }
+        },
+        "request.UpdateConfigRequest": {
+            "type": "object",
+            "required": [
+                "configKey",
+                "id"
+            ],
+            "properties": {
+                "configKey": {
+                    "description": "配置键",
+                    "type": "string",
+                    "maxLength": 100
                 },
-                "roles": {
-                    "description": "权限组关联的角色对象列表",
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/entity.PermissionGroup"
-                    }
+                "configType": {
+                    "description": "配置类型",
+                    "type": "string",
+                    "maxLength": 20
                 },
-                "sort": {
-                    "description": "排序",
+                "configValue": {
+                    "description": "配置值",
+                    "type": "string"
+                },
+                "description": {
+                    "description": "描述",
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "id": {
+                    "description": "配置 ID",
                     "type": "integer"
                 },
                 "status": {
-                    "description": "状态：1-启用，0-禁用",
+                    "description": "状态：1-启用 0-禁用",
+                    "type": "integer",
+                    "enum": [
+                        0,
+                        1
+                    ]
+                }
+            }
+        },
+        "request.UpdateDepartmentLeaderRequest": {
+            "type": "object",
+            "required": [
+                "leaderId"
+            ],
+            "properties": {
+                "leaderId": {
+                    "description": "部门负责人 ID",
                     "type": "integer"
-                },
-                "updatedAt": {
-                    "description": "更新时间",
-                    "type": "string"
-                },
-                "updatedBy": {
-                    "description": "更新人 ID",
-                    "type": "integer",
-                    "format": "int64"
                 }
             }
         },
-        "entity.Position": {
+        "request.UpdateDepartmentRequest": {
             "type": "object",
+            "required": [
+                "id",
+                "name"
+            ],
             "properties": {
-                "createdAt": {
-                    "description": "创建时间",
-                    "type": "string"
-                },
-                "createdBy": {
-                    "description": "创建人 ID",
-                    "type": "integer",
-                    "format": "int64"
-                },
                 "description": {
-                    "description": "岗位描述",
+                    "description": "部门描述",
                     "type": "string"
                 },
                 "id": {
-                    "description": "岗位 ID",
-                    "type": "integer",
-                    "format": "int64"
+                    "description": "部门 ID",
+                    "type": "integer"
+                },
+                "leaderId": {
+                    "description": "部门负责人 ID",
+                    "type": "integer"
                 },
                 "name": {
-                    "description": "岗位名称",
+                    "description": "部门名称",
                     "type": "string"
                 },
+                "parentId": {
+                    "description": "父部门 ID",
+                    "type": "integer"
+                },
                 "sort": {
                     "description": "排序",
                     "type": "integer"
@@ -10693,2084 +19380,2223 @@ const docTemplate = `{
                 "status": {
                     "description": "状态：1-正常，0-禁用",
                     "type": "integer"
-                },
-                "updatedAt": {
-                    "description": "更新时间",
-                    "type": "string"
-                },
-                "updatedBy": {
-                    "description": "更新人 ID",
-                    "type": "integer",
-                    "format": "int64"
                 }
             }
         },
-        "entity.Role": {
+        "request.UpdateDictItemRequest": {
             "type": "object",
+            "required": [
+                "dictId",
+                "id",
+                "itemKey",
+                "itemValue"
+            ],
             "properties": {
-                "apirouteIds": {
-                    "description": "角色关联的接口路由 ID 列表",
-                    "type": "array",
-                    "items": {
-                        "type": "integer",
-                        "format": "int64"
-                    }
-                },
-                "apiroutes": {
-                    "description": "角色关联的接口路由对象列表",
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/entity.API"
-                    }
-                },
-                "btnPermIds": {
-                    "description": "角色关联的按钮权限 ID 列表",
-                    "type": "array",
-                    "items": {
-                        "type": "integer",
-                        "format": "int64"
-                    }
-                },
-                "btnPerms": {
-                    "description": "角色关联的按钮权限对象列表",
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/entity.BtnPerm"
-                    }
-                },
-                "children": {
-                    "description": "子角色",
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/entity.Role"
-                    }
-                },
-                "code": {
-                    "description": "角色编码",
-                    "type": "string"
-                },
-                "createdAt": {
-                    "description": "创建时间",
-                    "type": "string"
-                },
-                "createdBy": {
-                    "description": "创建人 ID",
-                    "type": "integer",
-                    "format": "int64"
-                },
                 "description": {
-                    "description": "角色描述",
-                    "type": "string"
-                },
-                "id": {
-                    "description": "角色 ID",
-                    "type": "integer",
-                    "format": "int64"
-                },
-                "isSystem": {
-                    "description": "是否系统角色",
-                    "type": "boolean"
-                },
-                "menuIds": {
-                    "description": "角色关联的菜单 ID 列表",
-                    "type": "array",
-                    "items": {
-                        "type": "integer",
-                        "format": "int64"
-                    }
-                },
-                "menus": {
-                    "description": "角色关联的菜单对象列表",
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/entity.Menu"
-                    }
-                },
-                "name": {
-                    "description": "角色名称",
-                    "type": "string"
+                    "description": "描述",
+                    "type": "string",
+                    "maxLength": 255
                 },
-                "parent": {
-                    "description": "父角色",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/entity.Role"
-                        }
-                    ]
+                "dictId": {
+                    "description": "字典 ID",
+                    "type": "integer"
                 },
-                "parentID": {
-                    "description": "父角色 ID，支持角色继承",
-                    "type": "integer",
-                    "format": "int64"
+                "id": {
+                    "description": "字典项 ID",
+                    "type": "integer"
                 },
-                "permissionGroupIds": {
-                    "description": "角色关联的权限组 ID 列表",
-                    "type": "array",
-                    "items": {
-                        "type": "integer",
-                        "format": "int64"
-                    }
+                "itemKey": {
+                    "description": "字典项键",
+                    "type": "string",
+                    "maxLength": 50
                 },
-                "permissionGroups": {
-                    "description": "角色关联的权限组对象列表",
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/entity.PermissionGroup"
-                    }
+                "itemValue": {
+                    "description": "字典项值",
+                    "type": "string",
+                    "maxLength": 255
                 },
                 "sort": {
                     "description": "排序",
-                    "type": "integer"
+                    "type": "integer",
+                    "minimum": 0
                 },
                 "status": {
-                    "description": "状态：1-启用，0-禁用",
-                    "type": "integer"
-                },
-                "type": {
-                    "description": "角色类型：1-系统角色，2-业务角色，3-数据角色",
-                    "type": "integer"
-                },
-                "updatedAt": {
-                    "description": "更新时间",
-                    "type": "string"
-                },
-                "updatedBy": {
-                    "description": "更新人 ID",
+                    "description": "状态：1-启用 0-禁用",
                     "type": "integer",
-                    "format": "int64"
-                },
-                "userIds": {
-                    "description": "角色关联的用户 ID 列表",
-                    "type": "array",
-                    "items": {
-                        "type": "integer",
-                        "format": "int64"
-                    }
-                },
-                "users": {
-                    "description": "角色关联的用户对象列表",
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/entity.User"
-                    }
+                    "enum": [
+                        0,
+                        1
+                    ]
                 }
             }
         },
-        "entity.TopIPStat": {
+        "request.UpdateDictRequest": {
             "type": "object",
+            "required": [
+                "dictCode",
+                "dictName",
+                "id"
+            ],
             "properties": {
-                "ip": {
-                    "description": "IP 地址",
-                    "type": "string"
+                "description": {
+                    "description": "描述",
+                    "type": "string",
+                    "maxLength": 255
                 },
-                "loginCount": {
-                    "description": "登录次数",
+                "dictCode": {
+                    "description": "字典编码",
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "dictName": {
+                    "description": "字典名称",
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "id": {
+                    "description": "字典 ID",
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "状态：1-启用 0-禁用",
                     "type": "integer",
-                    "format": "int64"
+                    "enum": [
+                        0,
+                        1
+                    ]
                 }
             }
         },
-        "entity.TopUserStat": {
+        "request.UpdateIPPolicyRequest": {
             "type": "object",
+            "required": [
+                "action",
+                "cidrs",
+                "name",
+                "scope"
+            ],
             "properties": {
-                "loginCount": {
-                    "description": "登录次数",
-                    "type": "integer",
-                    "format": "int64"
+                "action": {
+                    "description": "动作：allow、deny",
+                    "type": "string"
                 },
-                "userID": {
-                    "description": "用户 ID",
+                "cidrs": {
+                    "description": "CIDR 规则列表，也可以填写单个 IP",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "description": {
+                    "description": "描述",
                     "type": "string"
                 },
-                "username": {
-                    "description": "用户名",
+                "name": {
+                    "description": "策略名称",
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "scope": {
+                    "description": "作用范围：global、role、user",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "状态：1-启用，0-禁用",
+                    "type": "integer"
+                },
+                "targetId": {
+                    "description": "作用对象 ID：角色 ID 或用户 ID，全局策略不填",
                     "type": "string"
                 }
             }
         },
-        "entity.User": {
+        "request.UpdateLDAPGroupMappingRequest": {
             "type": "object",
+            "required": [
+                "groupDn"
+            ],
             "properties": {
-                "address": {
-                    "description": "地址",
-                    "type": "string"
-                },
-                "avatar": {
-                    "description": "用户头像 URL",
-                    "type": "string"
-                },
-                "birthday": {
-                    "description": "生日",
-                    "type": "string"
+                "departmentId": {
+                    "description": "映射的部门 ID",
+                    "type": "string",
+                    "example": "0"
                 },
-                "createdAt": {
-                    "description": "创建时间",
+                "description": {
+                    "description": "描述",
                     "type": "string"
                 },
-                "createdBy": {
-                    "description": "创建人 ID",
-                    "type": "integer",
-                    "format": "int64"
-                },
-                "department": {
-                    "description": "所属部门",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/entity.Department"
-                        }
-                    ]
+                "groupDn": {
+                    "description": "LDAP 组 DN 或组名（CN）",
+                    "type": "string",
+                    "maxLength": 255
                 },
-                "departmentID": {
-                    "description": "部门 ID",
-                    "type": "integer",
-                    "format": "int64"
+                "priority": {
+                    "description": "优先级，值越大越优先",
+                    "type": "integer"
                 },
-                "email": {
-                    "description": "电子邮箱",
+                "roleId": {
+                    "description": "映射的角色 ID",
+                    "type": "string",
+                    "example": "0"
+                }
+            }
+        },
+        "request.UpdateNoticeRequest": {
+            "type": "object",
+            "required": [
+                "content",
+                "id",
+                "title",
+                "type"
+            ],
+            "properties": {
+                "content": {
+                    "description": "公告内容",
                     "type": "string"
                 },
-                "entryDate": {
-                    "description": "入职日期",
+                "description": {
+                    "description": "公告描述/摘要",
                     "type": "string"
                 },
-                "gender": {
-                    "description": "性别：0-未知，1-男，2-女",
-                    "type": "integer"
-                },
                 "id": {
-                    "description": "用户 ID",
-                    "type": "integer",
-                    "format": "int64"
-                },
-                "lastLoginIP": {
-                    "description": "最后登录 IP",
-                    "type": "string"
-                },
-                "lastLoginTime": {
-                    "description": "最后登录时间",
-                    "type": "string"
+                    "description": "公告 ID",
+                    "type": "integer"
                 },
-                "nickname": {
-                    "description": "用户昵称",
-                    "type": "string"
+                "isTop": {
+                    "description": "是否置顶",
+                    "type": "boolean"
                 },
-                "passwordHash": {
-                    "description": "密码哈希值",
-                    "type": "string"
+                "sort": {
+                    "description": "排序",
+                    "type": "integer"
                 },
-                "phone": {
-                    "description": "手机号码",
+                "title": {
+                    "description": "公告标题",
                     "type": "string"
                 },
-                "position": {
-                    "description": "所属岗位",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/entity.Position"
-                        }
-                    ]
-                },
-                "positionID": {
-                    "description": "岗位 ID",
-                    "type": "integer",
-                    "format": "int64"
+                "type": {
+                    "description": "公告类型：1-系统公告，2-活动公告，3-普通通知，4-紧急通知",
+                    "type": "integer"
+                }
+            }
+        },
+        "request.UpdateOIDCClientRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "description": {
+                    "description": "描述",
+                    "type": "string",
+                    "maxLength": 500
                 },
-                "roleIds": {
-                    "description": "用户关联的角色 ID 列表",
+                "grantTypes": {
+                    "description": "授权类型",
                     "type": "array",
                     "items": {
-                        "type": "integer",
-                        "format": "int64"
+                        "type": "string"
                     }
                 },
-                "roles": {
-                    "description": "角色列表",
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/entity.Role"
-                    }
+                "name": {
+                    "description": "应用名称",
+                    "type": "string",
+                    "maxLength": 100
                 },
-                "specialBtnPermissionIds": {
-                    "description": "用户特殊按钮权限 ID 列表",
+                "redirectUris": {
+                    "description": "允许的回调地址",
                     "type": "array",
                     "items": {
-                        "type": "integer",
-                        "format": "int64"
+                        "type": "string"
                     }
                 },
-                "specialPermissionIds": {
-                    "description": "用户特殊权限 ID 列表",
+                "scopes": {
+                    "description": "允许申请的授权范围",
                     "type": "array",
                     "items": {
-                        "type": "integer",
-                        "format": "int64"
+                        "type": "string"
                     }
                 },
-                "status": {
-                    "description": "用户状态，1-正常，0-禁用",
-                    "type": "integer"
-                },
-                "updatedAt": {
-                    "description": "更新时间",
-                    "type": "string"
+                "skipConsent": {
+                    "description": "是否跳过授权确认",
+                    "type": "boolean"
                 },
-                "updatedBy": {
-                    "description": "更新人 ID",
+                "status": {
+                    "description": "状态：1-启用，0-禁用",
                     "type": "integer",
-                    "format": "int64"
-                },
-                "username": {
-                    "description": "用户名，唯一标识",
-                    "type": "string"
-                },
-                "wechatOpenID": {
-                    "description": "微信 OpenID，唯一",
-                    "type": "string"
+                    "enum": [
+                        0,
+                        1
+                    ]
                 }
             }
         },
-        "entity.UserSetting": {
+        "request.UpdateOIDCProviderRequest": {
             "type": "object",
+            "required": [
+                "clientId",
+                "issuer",
+                "name",
+                "redirectUri"
+            ],
             "properties": {
-                "autoLogin": {
-                    "description": "自动登录",
+                "authorizationEndpoint": {
+                    "description": "授权端点",
+                    "type": "string"
+                },
+                "autoCreate": {
+                    "description": "是否自动开通本地用户",
                     "type": "boolean"
                 },
-                "createdAt": {
-                    "description": "创建时间",
+                "clientId": {
+                    "description": "客户端 ID",
                     "type": "string"
                 },
-                "createdBy": {
-                    "description": "创建人 ID",
-                    "type": "integer",
-                    "format": "int64"
-                },
-                "fontSize": {
-                    "description": "字体大小",
-                    "type": "integer"
+                "clientSecret": {
+                    "description": "客户端密钥，为空时保留原值",
+                    "type": "string"
                 },
-                "id": {
-                    "description": "用户设置 ID",
-                    "type": "integer",
-                    "format": "int64"
+                "issuer": {
+                    "description": "签发方",
+                    "type": "string"
                 },
-                "language": {
-                    "description": "语言",
+                "jwksUri": {
+                    "description": "JWKS 地址",
                     "type": "string"
                 },
-                "navbarColor": {
-                    "description": "导航栏颜色",
+                "linkBy": {
+                    "description": "账号关联方式",
                     "type": "string"
                 },
-                "rememberPassword": {
-                    "description": "记住密码",
-                    "type": "boolean"
+                "name": {
+                    "description": "显示名称",
+                    "type": "string",
+                    "maxLength": 100
                 },
-                "sidebarColor": {
-                    "description": "侧边栏颜色",
+                "redirectUri": {
+                    "description": "回调地址",
                     "type": "string"
                 },
-                "themeColor": {
-                    "description": "主题颜色",
+                "roleMappings": {
+                    "description": "声明到角色的映射规则",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/request.OIDCRoleMappingRequest"
+                    }
+                },
+                "scopes": {
+                    "description": "授权范围",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "sort": {
+                    "description": "排序",
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "状态：1-启用，0-禁用",
+                    "type": "integer",
+                    "enum": [
+                        0,
+                        1
+                    ]
+                },
+                "tokenAuthMethod": {
+                    "description": "客户端认证方式",
                     "type": "string"
                 },
-                "updatedAt": {
-                    "description": "更新时间",
+                "tokenEndpoint": {
+                    "description": "令牌端点",
                     "type": "string"
                 },
-                "updatedBy": {
-                    "description": "更新人 ID",
-                    "type": "integer",
-                    "format": "int64"
+                "trustEmail": {
+                    "description": "是否信任未声明 email_verified 的邮箱",
+                    "type": "boolean"
                 },
-                "userID": {
-                    "description": "用户 ID",
-                    "type": "integer",
-                    "format": "int64"
+                "userinfoEndpoint": {
+                    "description": "用户信息端点",
+                    "type": "string"
+                },
+                "usernameClaim": {
+                    "description": "自动开通时作为用户名的声明",
+                    "type": "string"
                 }
             }
         },
-        "monitor.CPUStatus": {
+        "request.UpdateOpenAppRequest": {
             "type": "object",
+            "required": [
+                "name",
+                "signType",
+                "userId"
+            ],
             "properties": {
-                "cores": {
-                    "description": "CPU 核心数",
-                    "type": "integer"
+                "description": {
+                    "description": "描述",
+                    "type": "string",
+                    "maxLength": 255
                 },
-                "per_cpu_usage": {
-                    "description": "每个 CPU 核心的使用率",
-                    "type": "array",
-                    "items": {
-                        "type": "number"
-                    }
+                "name": {
+                    "description": "应用名称",
+                    "type": "string",
+                    "maxLength": 100
                 },
-                "usage_percent": {
-                    "description": "CPU 使用率百分比",
-                    "type": "number"
+                "publicKey": {
+                    "description": "合作方 RSA 公钥 PEM，RSA-SHA256 时必填",
+                    "type": "string"
+                },
+                "signType": {
+                    "description": "签名方式：HMAC-SHA256、RSA-SHA256",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "状态：1-启用，0-禁用",
+                    "type": "integer"
+                },
+                "userId": {
+                    "description": "绑定的平台用户 ID",
+                    "type": "string"
                 }
             }
         },
-        "monitor.DiskStatus": {
+        "request.UpdatePermissionRuleRequest": {
             "type": "object",
+            "required": [
+                "apiPath",
+                "effect",
+                "name"
+            ],
             "properties": {
-                "free_gb": {
-                    "description": "空闲磁盘空间 (GB)",
-                    "type": "integer"
-                },
-                "io_read_bytes": {
-                    "description": "IO 读取字节数",
-                    "type": "integer"
-                },
-                "io_write_bytes": {
-                    "description": "IO 写入字节数",
-                    "type": "integer"
+                "apiPath": {
+                    "description": "API 路径模式，支持 :param 和 *wildcard",
+                    "type": "string",
+                    "maxLength": 255
                 },
-                "read_bytes": {
-                    "description": "读取字节数",
-                    "type": "integer"
+                "conditions": {
+                    "description": "条件表达式，JSON 格式，为空表示无条件",
+                    "type": "string"
                 },
-                "read_count": {
-                    "description": "读取次数",
-                    "type": "integer"
+                "description": {
+                    "description": "规则描述",
+                    "type": "string",
+                    "maxLength": 500
                 },
-                "total_gb": {
-                    "description": "总磁盘空间 (GB)",
-                    "type": "integer"
+                "effect": {
+                    "description": "效果：allow、deny",
+                    "type": "string"
                 },
-                "usage_percent": {
-                    "description": "磁盘使用率百分比",
-                    "type": "number"
+                "method": {
+                    "description": "HTTP 方法，多个用逗号分隔，为空或 * 表示全部",
+                    "type": "string"
                 },
-                "used_gb": {
-                    "description": "已使用磁盘空间 (GB)",
-                    "type": "integer"
+                "name": {
+                    "description": "规则名称",
+                    "type": "string",
+                    "maxLength": 100
                 },
-                "write_bytes": {
-                    "description": "写入字节数",
+                "sort": {
+                    "description": "排序",
                     "type": "integer"
                 },
-                "write_count": {
-                    "description": "写入次数",
+                "status": {
+                    "description": "状态：1-启用，0-禁用",
                     "type": "integer"
                 }
             }
         },
-        "monitor.LoadStatus": {
+        "request.UpdatePositionRequest": {
             "type": "object",
+            "required": [
+                "id",
+                "name"
+            ],
             "properties": {
-                "load1": {
-                    "description": "1 分钟平均负载",
-                    "type": "number"
-                },
-                "load15": {
-                    "description": "15 分钟平均负载",
-                    "type": "number"
+                "description": {
+                    "description": "岗位描述",
+                    "type": "string"
                 },
-                "load5": {
-                    "description": "5 分钟平均负载",
-                    "type": "number"
-                }
-            }
-        },
-        "monitor.MemoryStatus": {
-            "type": "object",
-            "properties": {
-                "available_mb": {
-                    "description": "可用内存 (MB)",
+                "id": {
+                    "description": "岗位 ID",
                     "type": "integer"
                 },
-                "buffers_mb": {
-                    "description": "缓冲区内存 (MB)",
-                    "type": "integer"
+                "name": {
+                    "description": "岗位名称",
+                    "type": "string"
                 },
-                "cached_mb": {
-                    "description": "缓存内存 (MB)",
+                "sort": {
+                    "description": "排序",
                     "type": "integer"
                 },
-                "free_mb": {
-                    "description": "空闲内存 (MB)",
+                "status": {
+                    "description": "状态：1-正常，0-禁用",
                     "type": "integer"
+                }
+            }
+        },
+        "request.UpdateRoleRequest": {
+            "type": "object",
+            "required": [
+                "id",
+                "name"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "maxLength": 255
                 },
-                "total_mb": {
-                    "description": "总内存 (MB)",
+                "id": {
                     "type": "integer"
                 },
-                "usage_percent": {
-                    "description": "内存使用率百分比",
-                    "type": "number"
-                },
-                "used_mb": {
-                    "description": "已使用内存 (MB)",
-                    "type": "integer"
+                "name": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "minLength": 2
                 },
-                "used_percent": {
-                    "description": "已使用百分比（与 usage_percent 相同）",
-                    "type": "number"
+                "status": {
+                    "type": "integer",
+                    "enum": [
+                        0,
+                        1
+                    ]
                 }
             }
         },
-        "monitor.SystemStatus": {
+        "request.UpdateServiceAccountRequest": {
             "type": "object",
+            "required": [
+                "roleIds"
+            ],
             "properties": {
-                "cpu": {
-                    "description": "CPU 使用状态",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/monitor.CPUStatus"
-                        }
-                    ]
-                },
-                "disk": {
-                    "description": "磁盘使用状态",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/monitor.DiskStatus"
-                        }
-                    ]
+                "description": {
+                    "description": "描述",
+                    "type": "string"
                 },
-                "load": {
-                    "description": "系统负载状态",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/monitor.LoadStatus"
-                        }
-                    ]
+                "ipAllowlist": {
+                    "description": "IP 白名单（IP 或 CIDR）",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "memory": {
-                    "description": "内存使用状态",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/monitor.MemoryStatus"
-                        }
-                    ]
+                "roleIds": {
+                    "description": "绑定的角色 ID 列表",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "timestamp": {
-                    "description": "采集时间",
-                    "type": "string"
+                "status": {
+                    "description": "状态：1-启用，0-禁用",
+                    "type": "integer"
                 }
             }
         },
-        "request.AddTaskRequest": {
+        "request.UpdateSoDConstraintRequest": {
             "type": "object",
             "required": [
-                "expression",
-                "task_id",
+                "maxRoles",
+                "name",
+                "roleIds",
                 "type"
             ],
             "properties": {
                 "description": {
-                    "type": "string"
-                },
-                "expression": {
-                    "type": "string"
+                    "description": "描述",
+                    "type": "string",
+                    "maxLength": 500
                 },
-                "retry_count": {
+                "maxRoles": {
+                    "description": "最多可持有或激活的角色数量",
                     "type": "integer",
-                    "maximum": 10,
-                    "minimum": 0
+                    "minimum": 1
                 },
-                "task_id": {
-                    "type": "string"
+                "name": {
+                    "description": "约束名称",
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "roleIds": {
+                    "description": "约束涉及的角色 ID 列表",
+                    "type": "array",
+                    "minItems": 2,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "status": {
+                    "description": "状态：1-启用，0-禁用",
+                    "type": "integer"
                 },
                 "type": {
+                    "description": "约束类型：static-静态，dynamic-动态",
                     "type": "string",
                     "enum": [
-                        "cron",
-                        "one_time"
+                        "static",
+                        "dynamic"
                     ]
                 }
             }
         },
-        "request.AddUserRequest": {
+        "request.UpdateUserRequest": {
             "type": "object",
             "required": [
-                "password",
-                "userName"
+                "id"
             ],
             "properties": {
                 "avatar": {
                     "type": "string",
                     "maxLength": 255
                 },
-                "departmentId": {
-                    "type": "integer",
-                    "minimum": 1
-                },
                 "email": {
                     "type": "string"
                 },
+                "id": {
+                    "type": "string"
+                },
                 "nickname": {
                     "type": "string",
                     "maxLength": 50
                 },
-                "password": {
-                    "type": "string",
-                    "maxLength": 30,
-                    "minLength": 6
-                },
                 "phone": {
                     "type": "string",
                     "maxLength": 20
                 },
-                "positionId": {
-                    "type": "integer",
-                    "minimum": 1
-                },
                 "roles": {
+                    "description": "角色列表，支持多选",
                     "type": "array",
                     "items": {
                         "type": "string"
                     }
                 },
                 "status": {
+                    "description": "1: active, 0: inactive",
                     "type": "integer",
                     "maximum": 1,
                     "minimum": 0
-                },
-                "userName": {
-                    "type": "string",
-                    "maxLength": 50,
-                    "minLength": 3
                 }
             }
         },
-        "request.AssignBtnPermToRoleRequest": {
+        "request.ValidatePermissionRuleConditionsRequest": {
             "type": "object",
-            "required": [
-                "btnPermIds",
-                "roleId"
-            ],
             "properties": {
-                "btnPermIds": {
-                    "type": "array",
-                    "minItems": 1,
-                    "items": {
-                        "type": "string"
-                    }
-                },
-                "roleId": {
-                    "type": "integer",
-                    "minimum": 1
+                "conditions": {
+                    "description": "条件表达式，JSON 格式",
+                    "type": "string"
                 }
             }
         },
-        "request.AssignMenuToRoleRequest": {
+        "request.WechatLoginRequest": {
             "type": "object",
             "required": [
-                "menuIds",
-                "roleId"
+                "code"
             ],
             "properties": {
-                "menuIds": {
-                    "type": "array",
-                    "minItems": 1,
-                    "items": {
-                        "type": "string"
-                    }
-                },
-                "roleId": {
-                    "type": "integer",
-                    "minimum": 1
+                "code": {
+                    "type": "string"
                 }
             }
         },
-        "request.AssignToBtnPermRequest": {
+        "request.WechatRefreshTokenRequest": {
             "type": "object",
             "required": [
-                "btnPermId",
-                "ids"
+                "refresh_token"
             ],
             "properties": {
-                "btnPermId": {
-                    "type": "integer",
-                    "minimum": 1
-                },
-                "ids": {
-                    "type": "array",
-                    "minItems": 1,
-                    "items": {
-                        "type": "string"
-                    }
+                "refresh_token": {
+                    "type": "string"
                 }
             }
         },
-        "request.AssignToRoleRequest": {
+        "response.APIResponse": {
             "type": "object",
-            "required": [
-                "ids",
-                "roleId"
-            ],
             "properties": {
-                "ids": {
+                "authRequired": {
+                    "type": "boolean"
+                },
+                "authType": {
+                    "type": "integer"
+                },
+                "btnPermIds": {
                     "type": "array",
-                    "minItems": 1,
                     "items": {
-                        "type": "string"
+                        "type": "integer"
                     }
                 },
-                "roleId": {
-                    "type": "integer",
-                    "minimum": 1
+                "createdAt": {
+                    "type": "string"
+                },
+                "createdBy": {
+                    "type": "string",
+                    "example": "0"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "group": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "0"
+                },
+                "menuIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "method": {
+                    "type": "string"
+                },
+                "orphaned": {
+                    "type": "boolean"
+                },
+                "path": {
+                    "type": "string"
+                },
+                "roleIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "status": {
+                    "type": "integer"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "updatedBy": {
+                    "type": "string",
+                    "example": "0"
                 }
             }
         },
-        "request.AssignUserToRoleRequest": {
+        "response.APISyncReportResponse": {
             "type": "object",
-            "required": [
-                "roleId",
-                "userIds"
-            ],
             "properties": {
-                "roleId": {
-                    "type": "integer",
-                    "minimum": 1
+                "added": {
+                    "description": "新登记的路由",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.APIResponse"
+                    }
                 },
-                "userIds": {
+                "orphaned": {
+                    "description": "已失效的路由",
                     "type": "array",
-                    "minItems": 1,
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/response.APIResponse"
+                    }
+                },
+                "restored": {
+                    "description": "重新出现的路由",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.APIResponse"
+                    }
+                },
+                "syncedAt": {
+                    "description": "最近一次同步时间，本实例未同步时为空",
+                    "type": "string"
+                },
+                "total": {
+                    "description": "已注册的路由数量",
+                    "type": "integer"
+                },
+                "unassigned": {
+                    "description": "需要授权但尚未分配给任何角色的路由",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.APIResponse"
                     }
                 }
             }
         },
-        "request.BatchDeleteLoginLogsRequest": {
+        "response.AbnormalLoginInfoDTO": {
             "type": "object",
-            "required": [
-                "ids"
-            ],
             "properties": {
-                "ids": {
-                    "description": "登录日志 ID 列表",
+                "failedCount": {
+                    "type": "integer"
+                },
+                "ip": {
+                    "type": "string"
+                },
+                "lastFailedTime": {
+                    "type": "string"
+                },
+                "riskDescription": {
+                    "type": "string"
+                },
+                "riskLevel": {
+                    "type": "string"
+                },
+                "usernames": {
                     "type": "array",
                     "items": {
-                        "type": "integer"
+                        "type": "string"
                     }
                 }
             }
         },
-        "request.BatchDeleteOperationLogRequest": {
+        "response.AuthorityInfo": {
             "type": "object",
-            "required": [
-                "ids"
-            ],
             "properties": {
-                "ids": {
-                    "type": "array",
-                    "items": {
-                        "type": "integer"
-                    }
+                "defaultRouter": {
+                    "type": "string"
                 }
             }
         },
-        "request.BatchForceOfflineRequest": {
+        "response.BlockAbnormalIPsResponse": {
             "type": "object",
-            "required": [
-                "userIds"
-            ],
             "properties": {
-                "reason": {
-                    "description": "下线原因",
-                    "type": "string"
+                "added": {
+                    "description": "本次新增封禁的 IP 数量",
+                    "type": "integer"
                 },
-                "userIds": {
-                    "description": "用户 ID 列表",
-                    "type": "array",
-                    "items": {
-                        "type": "integer"
-                    }
+                "policy": {
+                    "description": "异常登录 IP 黑名单策略，没有需要封禁的 IP 时为空",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/response.IPPolicyResponse"
+                        }
+                    ]
                 }
             }
         },
-        "request.ChangePasswordRequest": {
+        "response.BtnPermForRole": {
             "type": "object",
-            "required": [
-                "newPassword",
-                "oldPassword"
-            ],
             "properties": {
-                "newPassword": {
+                "code": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
                     "type": "string",
-                    "maxLength": 30,
-                    "minLength": 6
+                    "example": "0"
                 },
-                "oldPassword": {
+                "isAssigned": {
+                    "type": "boolean"
+                },
+                "menuId": {
                     "type": "string",
-                    "maxLength": 30,
-                    "minLength": 6
+                    "example": "0"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "integer"
                 }
             }
         },
-        "request.ClearLoginLogsRequest": {
+        "response.BtnPermResponse": {
             "type": "object",
-            "required": [
-                "endTime",
-                "startTime"
-            ],
             "properties": {
-                "endTime": {
-                    "description": "结束时间（YYYY-MM-DD HH:mm:ss）",
+                "code": {
                     "type": "string"
                 },
-                "startTime": {
-                    "description": "开始时间（YYYY-MM-DD HH:mm:ss）",
+                "createdAt": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "0"
+                },
+                "menuId": {
+                    "type": "string",
+                    "example": "0"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "integer"
+                },
+                "updatedAt": {
                     "type": "string"
                 }
             }
         },
-        "request.ClearOperationLogByTimeRangeRequest": {
+        "response.CaptchaResponse": {
             "type": "object",
-            "required": [
-                "endTime",
-                "startTime"
-            ],
             "properties": {
-                "endTime": {
+                "captchaId": {
                     "type": "string"
                 },
-                "startTime": {
+                "captchaLength": {
+                    "type": "integer"
+                },
+                "captchaType": {
+                    "description": "验证码类型：digit | math | string | audio | slider",
+                    "type": "string"
+                },
+                "height": {
+                    "description": "背景图高度",
+                    "type": "integer"
+                },
+                "openCaptcha": {
+                    "description": "当前场景是否需要验证码",
+                    "type": "boolean"
+                },
+                "picPath": {
+                    "description": "base64 编码的图片或语音，滑块验证码为背景图",
+                    "type": "string"
+                },
+                "pieceImage": {
+                    "description": "滑块验证码专用",
                     "type": "string"
+                },
+                "pieceY": {
+                    "description": "拼图块纵坐标",
+                    "type": "integer"
+                },
+                "width": {
+                    "description": "背景图宽度",
+                    "type": "integer"
                 }
             }
         },
-        "request.CreateAPIRequest": {
+        "response.ConditionTraceResponse": {
             "type": "object",
-            "required": [
-                "method",
-                "path"
-            ],
             "properties": {
-                "authRequired": {
-                    "type": "boolean"
+                "actual": {
+                    "description": "请求中的属性值"
                 },
-                "authType": {
-                    "type": "integer",
-                    "enum": [
-                        0,
-                        1
-                    ]
+                "attr": {
+                    "type": "string"
                 },
-                "btnPermIds": {
+                "children": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/response.ConditionTraceResponse"
                     }
                 },
-                "description": {
-                    "type": "string",
-                    "maxLength": 500
+                "kind": {
+                    "description": "节点类型：all、any、not、compare、empty",
+                    "type": "string"
                 },
-                "group": {
-                    "type": "string",
-                    "maxLength": 100
+                "op": {
+                    "type": "string"
                 },
-                "menuIds": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "path": {
+                    "description": "节点位置，如 $.all[0]",
+                    "type": "string"
                 },
-                "method": {
-                    "type": "string",
-                    "enum": [
-                        "GET",
-                        "POST",
-                        "PUT",
-                        "DELETE",
-                        "PATCH",
-                        "HEAD",
-                        "OPTIONS"
-                    ]
+                "present": {
+                    "description": "请求中是否存在该属性",
+                    "type": "boolean"
                 },
-                "path": {
-                    "type": "string",
-                    "maxLength": 255
+                "ref": {
+                    "description": "引用的属性名",
+                    "type": "string"
                 },
-                "roleIds": {
+                "result": {
+                    "type": "boolean"
+                },
+                "value": {
+                    "description": "条件中的比较值"
+                }
+            }
+        },
+        "response.ConfigListResponse": {
+            "type": "object",
+            "properties": {
+                "list": {
+                    "description": "配置列表",
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/response.ConfigResponse"
                     }
                 },
-                "status": {
-                    "type": "integer",
-                    "enum": [
-                        0,
-                        1
-                    ]
+                "page": {
+                    "type": "integer"
+                },
+                "pageSize": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
                 }
             }
         },
-        "request.CreateBtnPermRequest": {
+        "response.ConfigResponse": {
             "type": "object",
-            "required": [
-                "code",
-                "menuId",
-                "name"
-            ],
             "properties": {
-                "code": {
-                    "type": "string",
-                    "maxLength": 100,
-                    "minLength": 2
+                "configKey": {
+                    "description": "配置键",
+                    "type": "string"
                 },
-                "description": {
-                    "type": "string",
-                    "maxLength": 255
+                "configType": {
+                    "description": "配置类型",
+                    "type": "string"
                 },
-                "menuId": {
-                    "type": "integer",
-                    "minimum": 1
+                "configValue": {
+                    "description": "配置值",
+                    "type": "string"
                 },
-                "name": {
+                "createdAt": {
+                    "description": "创建时间",
+                    "type": "string"
+                },
+                "description": {
+                    "description": "描述",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "配置 ID",
                     "type": "string",
-                    "maxLength": 50,
-                    "minLength": 2
+                    "example": "0"
                 },
                 "status": {
-                    "type": "integer",
-                    "enum": [
-                        0,
-                        1
-                    ]
+                    "description": "状态：1-启用 0-禁用",
+                    "type": "integer"
+                },
+                "updatedAt": {
+                    "description": "更新时间",
+                    "type": "string"
                 }
             }
         },
-        "request.CreateConfigRequest": {
+        "response.DepartmentListResponse": {
             "type": "object",
-            "required": [
-                "configKey"
-            ],
             "properties": {
-                "configKey": {
-                    "description": "配置键",
+                "list": {
+                    "description": "部门列表",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.DepartmentResponse"
+                    }
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "pageSize": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "response.DepartmentResponse": {
+            "type": "object",
+            "properties": {
+                "children": {
+                    "description": "可选字段：子部门列表",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.DepartmentResponse"
+                    }
+                },
+                "code": {
+                    "description": "部门编码",
+                    "type": "string"
+                },
+                "createdAt": {
+                    "description": "创建时间",
+                    "type": "string"
+                },
+                "email": {
+                    "description": "邮箱",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "部门 ID",
                     "type": "string",
-                    "maxLength": 100
+                    "example": "0"
                 },
-                "configType": {
-                    "description": "配置类型",
+                "leader": {
+                    "description": "负责人",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "部门名称",
+                    "type": "string"
+                },
+                "parentId": {
+                    "description": "父部门 ID",
                     "type": "string",
-                    "maxLength": 20
+                    "example": "0"
                 },
-                "configValue": {
-                    "description": "配置值",
+                "phone": {
+                    "description": "联系电话",
                     "type": "string"
                 },
-                "description": {
-                    "description": "描述",
-                    "type": "string",
-                    "maxLength": 255
+                "sort": {
+                    "description": "排序",
+                    "type": "integer"
                 },
                 "status": {
                     "description": "状态：1-启用 0-禁用",
-                    "type": "integer",
-                    "enum": [
-                        0,
-                        1
-                    ]
+                    "type": "integer"
+                },
+                "updatedAt": {
+                    "description": "更新时间",
+                    "type": "string"
                 }
             }
         },
-        "request.CreateDepartmentRequest": {
+        "response.DictItemListResponse": {
             "type": "object",
-            "required": [
-                "name"
-            ],
             "properties": {
-                "description": {
-                    "description": "部门描述",
-                    "type": "string"
-                },
-                "leaderId": {
-                    "description": "部门负责人 ID",
-                    "type": "integer"
-                },
-                "name": {
-                    "description": "部门名称",
-                    "type": "string"
+                "list": {
+                    "description": "字典项列表",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.DictItemResponse"
+                    }
                 },
-                "parentId": {
-                    "description": "父部门 ID，默认为 0（顶级部门）",
+                "page": {
                     "type": "integer"
                 },
-                "sort": {
-                    "description": "排序，默认为 0",
+                "pageSize": {
                     "type": "integer"
                 },
-                "status": {
-                    "description": "状态：1-正常，0-禁用，默认为 1",
+                "total": {
                     "type": "integer"
                 }
             }
         },
-        "request.CreateDictItemRequest": {
+        "response.DictItemResponse": {
             "type": "object",
-            "required": [
-                "dictId",
-                "itemKey",
-                "itemValue"
-            ],
             "properties": {
+                "createdAt": {
+                    "description": "创建时间",
+                    "type": "string"
+                },
                 "description": {
                     "description": "描述",
-                    "type": "string",
-                    "maxLength": 255
+                    "type": "string"
                 },
                 "dictId": {
                     "description": "字典 ID",
-                    "type": "integer"
+                    "type": "string",
+                    "example": "0"
+                },
+                "id": {
+                    "description": "字典项 ID",
+                    "type": "string",
+                    "example": "0"
                 },
                 "itemKey": {
                     "description": "字典项键",
-                    "type": "string",
-                    "maxLength": 50
+                    "type": "string"
                 },
                 "itemValue": {
                     "description": "字典项值",
-                    "type": "string",
-                    "maxLength": 255
+                    "type": "string"
                 },
                 "sort": {
                     "description": "排序",
-                    "type": "integer",
-                    "minimum": 0
+                    "type": "integer"
                 },
                 "status": {
                     "description": "状态：1-启用 0-禁用",
-                    "type": "integer",
-                    "enum": [
-                        0,
-                        1
-                    ]
+                    "type": "integer"
+                },
+                "updatedAt": {
+                    "description": "更新时间",
+                    "type": "string"
                 }
             }
         },
-        "request.CreateDictRequest": {
+        "response.DictListResponse": {
             "type": "object",
-            "required": [
-                "dictCode",
-                "dictName"
-            ],
             "properties": {
-                "description": {
-                    "description": "描述",
-                    "type": "string",
-                    "maxLength": 255
+                "list": {
+                    "description": "字典列表",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.DictResponse"
+                    }
                 },
-                "dictCode": {
-                    "description": "字典编码",
-                    "type": "string",
-                    "maxLength": 50
+                "page": {
+                    "type": "integer"
                 },
-                "dictName": {
-                    "description": "字典名称",
-                    "type": "string",
-                    "maxLength": 100
+                "pageSize": {
+                    "type": "integer"
                 },
-                "status": {
-                    "description": "状态：1-启用 0-禁用",
-                    "type": "integer",
-                    "enum": [
-                        0,
-                        1
-                    ]
+                "total": {
+                    "type": "integer"
                 }
             }
         },
-        "request.CreateNoticeRequest": {
+        "response.DictResponse": {
             "type": "object",
-            "required": [
-                "content",
-                "title",
-                "type"
-            ],
             "properties": {
-                "content": {
-                    "description": "公告内容",
+                "createdAt": {
+                    "description": "创建时间",
                     "type": "string"
                 },
                 "description": {
-                    "description": "公告描述/摘要",
+                    "description": "描述",
                     "type": "string"
                 },
-                "isTop": {
-                    "description": "是否置顶",
-                    "type": "boolean"
+                "dictCode": {
+                    "description": "字典编码",
+                    "type": "string"
                 },
-                "sort": {
-                    "description": "排序",
-                    "type": "integer"
+                "dictItems": {
+                    "description": "字典项列表",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.DictItemResponse"
+                    }
                 },
-                "title": {
-                    "description": "公告标题",
+                "dictName": {
+                    "description": "字典名称",
                     "type": "string"
                 },
-                "type": {
-                    "description": "公告类型：1-系统公告，2-活动公告，3-普通通知，4-紧急通知",
-                    "type": "integer"
-                }
-            }
-        },
-        "request.CreatePaymentRequest": {
-            "type": "object",
-            "required": [
-                "amount",
-                "currency",
-                "order_id",
-                "payment_method"
-            ],
-            "properties": {
-                "amount": {
-                    "type": "number"
-                },
-                "currency": {
+                "id": {
+                    "description": "字典 ID",
                     "type": "string",
-                    "enum": [
-                        "CNY",
-                        "USD"
-                    ]
+                    "example": "0"
                 },
-                "description": {
-                    "type": "string"
+                "status": {
+                    "description": "状态：1-启用 0-禁用",
+                    "type": "integer"
                 },
-                "order_id": {
+                "updatedAt": {
+                    "description": "更新时间",
                     "type": "string"
-                },
-                "payment_method": {
-                    "type": "string",
-                    "enum": [
-                        "wechat_alipay"
-                    ]
                 }
             }
         },
-        "request.CreatePositionRequest": {
+        "response.ForgotPasswordResponse": {
             "type": "object",
-            "required": [
-                "name"
-            ],
             "properties": {
-                "description": {
-                    "description": "岗位描述",
-                    "type": "string"
-                },
-                "name": {
-                    "description": "岗位名称",
+                "target": {
+                    "description": "脱敏后的接收方",
                     "type": "string"
-                },
-                "sort": {
-                    "description": "排序，默认为 0",
-                    "type": "integer"
-                },
-                "status": {
-                    "description": "状态：1-正常，0-禁用，默认为 1",
-                    "type": "integer"
                 }
             }
         },
-        "request.CreateRoleRequest": {
+        "response.GroupTraceResponse": {
             "type": "object",
-            "required": [
-                "name"
-            ],
             "properties": {
-                "description": {
-                    "type": "string",
-                    "maxLength": 255
+                "granted": {
+                    "type": "boolean"
                 },
-                "name": {
+                "groupId": {
                     "type": "string",
-                    "maxLength": 50,
-                    "minLength": 2
+                    "example": "0"
                 },
-                "status": {
-                    "type": "integer",
-                    "enum": [
-                        0,
-                        1
-                    ]
+                "name": {
+                    "type": "string"
                 }
             }
         },
-        "request.DeleteBtnPermRequest": {
+        "response.IPPolicyListResponse": {
             "type": "object",
-            "required": [
-                "id"
-            ],
             "properties": {
-                "id": {
-                    "type": "integer",
-                    "minimum": 1
+                "list": {
+                    "description": "策略列表",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.IPPolicyResponse"
+                    }
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "pageSize": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
                 }
             }
         },
-        "request.DeleteRoleRequest": {
+        "response.IPPolicyResponse": {
             "type": "object",
-            "required": [
-                "id"
-            ],
             "properties": {
+                "action": {
+                    "description": "动作：allow、deny",
+                    "type": "string"
+                },
+                "cidrs": {
+                    "description": "CIDR 规则列表",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "createdAt": {
+                    "description": "创建时间",
+                    "type": "string"
+                },
+                "description": {
+                    "description": "描述",
+                    "type": "string"
+                },
                 "id": {
+                    "description": "策略 ID",
+                    "type": "string",
+                    "example": "0"
+                },
+                "name": {
+                    "description": "名称",
+                    "type": "string"
+                },
+                "scope": {
+                    "description": "作用范围：global、role、user",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "状态：1-启用 0-禁用",
                     "type": "integer"
+                },
+                "targetId": {
+                    "description": "作用对象 ID",
+                    "type": "string",
+                    "example": "0"
+                },
+                "updatedAt": {
+                    "description": "更新时间",
+                    "type": "string"
                 }
             }
         },
-        "request.JsonInBlacklistRequest": {
+        "response.LDAPGroupMappingListResponse": {
             "type": "object",
-            "required": [
-                "token"
-            ],
             "properties": {
-                "token": {
-                    "description": "JWT token",
-                    "type": "string"
+                "list": {
+                    "description": "映射列表",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.LDAPGroupMappingResponse"
+                    }
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "pageSize": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
                 }
             }
         },
-        "request.LoginRequest": {
+        "response.LDAPGroupMappingResponse": {
             "type": "object",
-            "required": [
-                "password",
-                "userName"
-            ],
             "properties": {
-                "captcha": {
+                "createdAt": {
+                    "description": "创建时间",
                     "type": "string"
                 },
-                "captchaId": {
+                "departmentId": {
+                    "description": "部门 ID",
+                    "type": "string",
+                    "example": "0"
+                },
+                "description": {
+                    "description": "描述",
                     "type": "string"
                 },
-                "password": {
+                "groupDn": {
+                    "description": "LDAP 组 DN 或组名",
                     "type": "string"
                 },
-                "userName": {
+                "id": {
+                    "description": "映射 ID",
+                    "type": "string",
+                    "example": "0"
+                },
+                "priority": {
+                    "description": "优先级",
+                    "type": "integer"
+                },
+                "roleId": {
+                    "description": "角色 ID",
+                    "type": "string",
+                    "example": "0"
+                },
+                "updatedAt": {
+                    "description": "更新时间",
                     "type": "string"
                 }
             }
         },
-        "request.RecordLoginRequest": {
+        "response.LoginLogDetailDTO": {
             "type": "object",
-            "required": [
-                "ip",
-                "userId",
-                "userName"
-            ],
             "properties": {
                 "browser": {
-                    "description": "浏览器信息",
                     "type": "string"
                 },
-                "device": {
-                    "description": "设备信息",
+                "createdAt": {
                     "type": "string"
                 },
-                "errorMsg": {
-                    "description": "错误信息",
+                "device": {
                     "type": "string"
                 },
+                "id": {
+                    "type": "string",
+                    "example": "0"
+                },
                 "ip": {
-                    "description": "登录 IP",
                     "type": "string"
                 },
                 "os": {
-                    "description": "操作系统",
                     "type": "string"
                 },
                 "place": {
-                    "description": "登录地点",
                     "type": "string"
                 },
-                "success": {
-                    "description": "是否成功",
-                    "type": "boolean"
-                },
-                "userAgent": {
-                    "description": "原始 User-Agent",
-                    "type": "string"
+                "result": {
+                    "type": "integer"
                 },
                 "userId": {
-                    "description": "用户 ID",
-                    "type": "integer"
+                    "type": "string",
+                    "example": "0"
                 },
                 "userName": {
-                    "description": "用户名",
                     "type": "string"
                 }
             }
         },
-        "request.RegisterRequest": {
+        "response.LoginLogListDTO": {
             "type": "object",
-            "required": [
-                "email",
-                "password",
-                "userName"
-            ],
             "properties": {
-                "email": {
+                "createdAt": {
                     "type": "string"
                 },
-                "password": {
+                "id": {
                     "type": "string",
-                    "maxLength": 30,
-                    "minLength": 6
+                    "example": "0"
                 },
-                "userName": {
-                    "type": "string",
-                    "maxLength": 50,
-                    "minLength": 3
-                }
-            }
-        },
-        "request.ResetPasswordRequest": {
-            "type": "object",
-            "required": [
-                "userId"
-            ],
-            "properties": {
-                "userId": {
+                "ip": {
+                    "type": "string"
+                },
+                "place": {
+                    "type": "string"
+                },
+                "result": {
                     "type": "integer"
+                },
+                "userName": {
+                    "type": "string"
                 }
             }
         },
-        "request.RevokeBtnPermFromRoleRequest": {
+        "response.LoginResponse": {
             "type": "object",
-            "required": [
-                "btnPermId",
-                "roleId"
-            ],
             "properties": {
-                "btnPermId": {
-                    "type": "integer",
-                    "minimum": 1
+                "accessToken": {
+                    "type": "string"
                 },
-                "roleId": {
-                    "type": "integer",
-                    "minimum": 1
+                "passwordChangeReason": {
+                    "description": "强制修改原因：reset-管理员重置，expired-密码过期",
+                    "type": "string"
+                },
+                "passwordChangeRequired": {
+                    "description": "是否需要强制修改密码",
+                    "type": "boolean"
+                },
+                "refreshToken": {
+                    "type": "string"
+                },
+                "user": {
+                    "$ref": "#/definitions/response.UserInfoResponse"
                 }
             }
         },
-        "request.RevokeFromBtnPermRequest": {
+        "response.LoginSecurityEventDTO": {
             "type": "object",
-            "required": [
-                "btnPermId",
-                "id"
-            ],
             "properties": {
-                "btnPermId": {
-                    "type": "integer",
-                    "minimum": 1
+                "createdAt": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "device": {
+                    "type": "string"
+                },
+                "distanceKm": {
+                    "type": "number"
                 },
                 "id": {
-                    "type": "integer",
-                    "minimum": 1
-                }
-            }
-        },
-        "request.SaveRolePermissionsRequest": {
-            "type": "object",
-            "required": [
-                "apiRouteIds",
-                "btnPermIds",
-                "menuIds"
-            ],
-            "properties": {
-                "apiRouteIds": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                    "type": "string"
                 },
-                "btnPermIds": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "ip": {
+                    "type": "string"
                 },
-                "menuIds": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
-                }
-            }
-        },
-        "request.SetTaskGroupRequest": {
-            "type": "object",
-            "required": [
-                "group"
-            ],
-            "properties": {
-                "group": {
+                "place": {
+                    "type": "string"
+                },
+                "previousIp": {
+                    "type": "string"
+                },
+                "previousPlace": {
+                    "type": "string"
+                },
+                "previousTime": {
+                    "type": "string"
+                },
+                "riskLevel": {
+                    "description": "风险等级：low、medium、high",
+                    "type": "string"
+                },
+                "stepUpStatus": {
+                    "description": "二次验证状态：空-未要求，pending、passed、failed",
+                    "type": "string"
+                },
+                "type": {
+                    "description": "事件类型：new_device、new_country、new_city、impossible_travel",
                     "type": "string"
-                }
-            }
-        },
-        "request.SetUserAuthoritiesRequest": {
-            "type": "object",
-            "required": [
-                "roleIds",
-                "userId"
-            ],
-            "properties": {
-                "roleIds": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
                 },
                 "userId": {
-                    "description": "支持 string 和 int64"
+                    "type": "string"
+                },
+                "userName": {
+                    "type": "string"
                 }
             }
         },
-        "request.SetUserAuthorityRequest": {
+        "response.MenuInfo": {
             "type": "object",
-            "required": [
-                "roleId",
-                "userId"
-            ],
             "properties": {
-                "roleId": {
+                "id": {
+                    "type": "string",
+                    "example": "0"
+                },
+                "name": {
                     "type": "string"
                 },
-                "userId": {
-                    "description": "支持 string 和 int64"
+                "parentId": {
+                    "type": "string",
+                    "example": "0"
+                },
+                "path": {
+                    "type": "string"
                 }
             }
         },
-        "request.SwitchRoleRequest": {
+        "response.MenuMetaResp": {
             "type": "object",
-            "required": [
-                "roleId"
-            ],
             "properties": {
-                "roleId": {
+                "activeMenu": {
+                    "description": "当前激活的菜单（驼峰命名）",
+                    "type": "string"
+                },
+                "affix": {
+                    "description": "是否固定在标签栏（驼峰命名）",
+                    "type": "boolean"
+                },
+                "breadcrumb": {
+                    "description": "是否在面包屑显示（驼峰命名）",
+                    "type": "boolean"
+                },
+                "defaultMenu": {
+                    "description": "是否默认菜单（驼峰命名）",
+                    "type": "boolean"
+                },
+                "frameLoading": {
+                    "description": "iframe 加载动画（驼峰命名）",
+                    "type": "boolean"
+                },
+                "icon": {
+                    "description": "菜单图标",
+                    "type": "string"
+                },
+                "keepAlive": {
+                    "description": "是否缓存（驼峰命名）",
+                    "type": "boolean"
+                },
+                "title": {
+                    "description": "菜单标题",
                     "type": "string"
                 }
             }
         },
-        "request.UpdateAPIRequest": {
+        "response.MenuResponse": {
             "type": "object",
-            "required": [
-                "id",
-                "method",
-                "path"
-            ],
             "properties": {
-                "authRequired": {
+                "activeMenu": {
+                    "description": "当前激活的菜单",
+                    "type": "string"
+                },
+                "affix": {
+                    "description": "是否固定在标签栏",
                     "type": "boolean"
                 },
-                "authType": {
-                    "type": "integer",
-                    "enum": [
-                        0,
-                        1
-                    ]
+                "breadcrumb": {
+                    "description": "是否在面包屑显示",
+                    "type": "boolean"
                 },
-                "btnPermIds": {
+                "children": {
+                    "description": "子菜单",
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/response.MenuResponse"
                     }
                 },
-                "description": {
-                    "type": "string",
-                    "maxLength": 500
-                },
-                "group": {
-                    "type": "string",
-                    "maxLength": 100
-                },
-                "id": {
-                    "type": "integer"
+                "component": {
+                    "description": "组件路径",
+                    "type": "string"
                 },
-                "menuIds": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "defaultMenu": {
+                    "description": "是否默认菜单",
+                    "type": "boolean"
                 },
-                "method": {
-                    "type": "string",
-                    "enum": [
-                        "GET",
-                        "POST",
-                        "PUT",
-                        "DELETE",
-                        "PATCH",
-                        "HEAD",
-                        "OPTIONS"
-                    ]
+                "frameLoading": {
+                    "description": "iframe 加载动画",
+                    "type": "boolean"
                 },
-                "path": {
-                    "type": "string",
-                    "maxLength": 255
+                "hidden": {
+                    "description": "是否隐藏",
+                    "type": "boolean"
                 },
-                "roleIds": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "icon": {
+                    "description": "菜单图标",
+                    "type": "string"
                 },
-                "status": {
-                    "type": "integer",
-                    "enum": [
-                        0,
-                        1
-                    ]
-                }
-            }
-        },
-        "request.UpdateBtnPermRequest": {
-            "type": "object",
-            "required": [
-                "code",
-                "id",
-                "menuId",
-                "name"
-            ],
-            "properties": {
-                "code": {
+                "id": {
+                    "description": "菜单 ID",
                     "type": "string",
-                    "maxLength": 100,
-                    "minLength": 2
+                    "example": "0"
                 },
-                "description": {
-                    "type": "string",
-                    "maxLength": 255
+                "isExt": {
+                    "description": "是否外部链接",
+                    "type": "boolean"
                 },
-                "id": {
-                    "type": "integer",
-                    "minimum": 1
+                "keepAlive": {
+                    "description": "是否缓存",
+                    "type": "boolean"
                 },
-                "menuId": {
-                    "type": "integer",
-                    "minimum": 1
+                "meta": {
+                    "description": "菜单元数据",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/response.MenuMetaResp"
+                        }
+                    ]
                 },
                 "name": {
-                    "type": "string",
-                    "maxLength": 50,
-                    "minLength": 2
+                    "description": "路由名称",
+                    "type": "string"
                 },
-                "status": {
-                    "type": "integer",
-                    "enum": [
-                        0,
-                        1
-                    ]
-                }
-            }
-        },
-        "request.UpdateConfigRequest": {
-            "type": "object",
-            "required": [
-                "configKey",
-                "id"
-            ],
-            "properties": {
-                "configKey": {
-                    "description": "配置键",
+                "parentId": {
+                    "description": "父菜单 ID",
                     "type": "string",
-                    "maxLength": 100
+                    "example": "0"
                 },
-                "configType": {
-                    "description": "配置类型",
-                    "type": "string",
-                    "maxLength": 20
+                "path": {
+                    "description": "路由路径",
+                    "type": "string"
                 },
-                "configValue": {
-                    "description": "配置值",
+                "permission": {
+                    "description": "权限标识",
                     "type": "string"
                 },
-                "description": {
-                    "description": "描述",
-                    "type": "string",
-                    "maxLength": 255
+                "redirect": {
+                    "description": "重定向地址",
+                    "type": "string"
                 },
-                "id": {
-                    "description": "配置 ID",
+                "sort": {
+                    "description": "排序号",
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "状态：0 禁用，1 启用",
                     "type": "integer"
                 },
-                "status": {
-                    "description": "状态：1-启用 0-禁用",
-                    "type": "integer",
-                    "enum": [
-                        0,
-                        1
-                    ]
+                "title": {
+                    "description": "菜单标题",
+                    "type": "string"
+                },
+                "type": {
+                    "description": "菜单类型：1 目录，2 菜单，3 按钮，4iframe",
+                    "type": "integer"
                 }
             }
         },
-        "request.UpdateDepartmentLeaderRequest": {
+        "response.NoticeListResponse": {
             "type": "object",
-            "required": [
-                "leaderId"
-            ],
             "properties": {
-                "leaderId": {
-                    "description": "部门负责人 ID",
+                "list": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.NoticeResponse"
+                    }
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "pageSize": {
+                    "type": "integer"
+                },
+                "total": {
                     "type": "integer"
                 }
             }
         },
-        "request.UpdateDepartmentRequest": {
+        "response.NoticeResponse": {
             "type": "object",
-            "required": [
-                "id",
-                "name"
-            ],
             "properties": {
+                "content": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
                 "description": {
-                    "description": "部门描述",
                     "type": "string"
                 },
                 "id": {
-                    "description": "部门 ID",
-                    "type": "integer"
+                    "type": "string",
+                    "example": "0"
                 },
-                "leaderId": {
-                    "description": "部门负责人 ID",
-                    "type": "integer"
+                "isTop": {
+                    "type": "boolean"
                 },
-                "name": {
-                    "description": "部门名称",
+                "publishTime": {
                     "type": "string"
                 },
-                "parentId": {
-                    "description": "父部门 ID",
-                    "type": "integer"
+                "publisherId": {
+                    "type": "string",
+                    "example": "0"
                 },
                 "sort": {
-                    "description": "排序",
                     "type": "integer"
                 },
                 "status": {
-                    "description": "状态：1-正常，0-禁用",
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "integer"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "viewCount": {
                     "type": "integer"
                 }
             }
         },
-        "request.UpdateDictItemRequest": {
+        "response.NoticeStatisticsResponse": {
             "type": "object",
-            "required": [
-                "dictId",
-                "id",
-                "itemKey",
-                "itemValue"
-            ],
             "properties": {
-                "description": {
-                    "description": "描述",
-                    "type": "string",
-                    "maxLength": 255
-                },
-                "dictId": {
-                    "description": "字典 ID",
+                "archivedCount": {
                     "type": "integer"
                 },
-                "id": {
-                    "description": "字典项 ID",
+                "draftCount": {
                     "type": "integer"
                 },
-                "itemKey": {
-                    "description": "字典项键",
-                    "type": "string",
-                    "maxLength": 50
-                },
-                "itemValue": {
-                    "description": "字典项值",
-                    "type": "string",
-                    "maxLength": 255
-                },
-                "sort": {
-                    "description": "排序",
-                    "type": "integer",
-                    "minimum": 0
+                "publishedCount": {
+                    "type": "integer"
                 },
-                "status": {
-                    "description": "状态：1-启用 0-禁用",
-                    "type": "integer",
-                    "enum": [
-                        0,
-                        1
-                    ]
+                "totalCount": {
+                    "type": "integer"
                 }
             }
         },
-        "request.UpdateDictRequest": {
+        "response.OAuthErrorResponse": {
             "type": "object",
-            "required": [
-                "dictCode",
-                "dictName",
-                "id"
-            ],
             "properties": {
-                "description": {
-                    "description": "描述",
-                    "type": "string",
-                    "maxLength": 255
+                "error": {
+                    "type": "string"
                 },
-                "dictCode": {
-                    "description": "字典编码",
-                    "type": "string",
-                    "maxLength": 50
+                "error_description": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.OIDCAuthorizeResponse": {
+            "type": "object",
+            "properties": {
+                "authorizationUrl": {
+                    "description": "身份提供方授权地址，前端直接跳转",
+                    "type": "string"
+                }
+            }
+        },
+        "response.OIDCClientListResponse": {
+            "type": "object",
+            "properties": {
+                "list": {
+                    "description": "客户端列表",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.OIDCClientResponse"
+                    }
                 },
-                "dictName": {
-                    "description": "字典名称",
-                    "type": "string",
-                    "maxLength": 100
+                "page": {
+                    "type": "integer"
                 },
-                "id": {
-                    "description": "字典 ID",
+                "pageSize": {
                     "type": "integer"
                 },
-                "status": {
-                    "description": "状态：1-启用 0-禁用",
-                    "type": "integer",
-                    "enum": [
-                        0,
-                        1
-                    ]
+                "total": {
+                    "type": "integer"
                 }
             }
         },
-        "request.UpdateNoticeRequest": {
+        "response.OIDCClientResponse": {
             "type": "object",
-            "required": [
-                "content",
-                "id",
-                "title",
-                "type"
-            ],
             "properties": {
-                "content": {
-                    "description": "公告内容",
+                "clientId": {
+                    "description": "客户端标识",
+                    "type": "string"
+                },
+                "createdAt": {
+                    "description": "创建时间",
                     "type": "string"
                 },
                 "description": {
-                    "description": "公告描述/摘要",
+                    "description": "描述",
                     "type": "string"
                 },
+                "grantTypes": {
+                    "description": "授权类型",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "id": {
-                    "description": "公告 ID",
-                    "type": "integer"
+                    "description": "客户端记录 ID",
+                    "type": "string",
+                    "example": "0"
                 },
-                "isTop": {
-                    "description": "是否置顶",
+                "name": {
+                    "description": "应用名称",
+                    "type": "string"
+                },
+                "public": {
+                    "description": "是否为公开客户端",
                     "type": "boolean"
                 },
-                "sort": {
-                    "description": "排序",
+                "redirectUris": {
+                    "description": "允许的回调地址",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "scopes": {
+                    "description": "允许申请的授权范围",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "skipConsent": {
+                    "description": "是否跳过授权确认",
+                    "type": "boolean"
+                },
+                "status": {
+                    "description": "状态",
                     "type": "integer"
                 },
-                "title": {
-                    "description": "公告标题",
+                "updatedAt": {
+                    "description": "更新时间",
                     "type": "string"
+                }
+            }
+        },
+        "response.OIDCClientSecretResponse": {
+            "type": "object",
+            "properties": {
+                "client": {
+                    "description": "客户端信息（重置密钥时为空）",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/response.OIDCClientResponse"
+                        }
+                    ]
                 },
-                "type": {
-                    "description": "公告类型：1-系统公告，2-活动公告，3-普通通知，4-紧急通知",
-                    "type": "integer"
+                "clientSecret": {
+                    "description": "客户端密钥明文，公开客户端为空",
+                    "type": "string"
                 }
             }
         },
-        "request.UpdatePositionRequest": {
+        "response.OIDCConsentInfoResponse": {
             "type": "object",
-            "required": [
-                "id",
-                "name"
-            ],
             "properties": {
-                "description": {
-                    "description": "岗位描述",
+                "clientId": {
+                    "description": "客户端标识",
                     "type": "string"
                 },
-                "id": {
-                    "description": "岗位 ID",
-                    "type": "integer"
-                },
-                "name": {
-                    "description": "岗位名称",
+                "clientName": {
+                    "description": "应用名称",
                     "type": "string"
                 },
-                "sort": {
-                    "description": "排序",
-                    "type": "integer"
+                "consentRequired": {
+                    "description": "是否需要用户确认，为 false 时前端可直接提交同意",
+                    "type": "boolean"
                 },
-                "status": {
-                    "description": "状态：1-正常，0-禁用",
-                    "type": "integer"
+                "description": {
+                    "description": "应用描述",
+                    "type": "string"
+                },
+                "scopes": {
+                    "description": "申请的授权范围",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 }
             }
         },
-        "request.UpdateRoleRequest": {
+        "response.OIDCConsentResponse": {
             "type": "object",
-            "required": [
-                "id",
-                "name"
-            ],
             "properties": {
-                "description": {
-                    "type": "string",
-                    "maxLength": 255
-                },
-                "id": {
-                    "type": "integer"
-                },
-                "name": {
-                    "type": "string",
-                    "maxLength": 50,
-                    "minLength": 2
-                },
-                "status": {
-                    "type": "integer",
-                    "enum": [
-                        0,
-                        1
-                    ]
+                "redirectUrl": {
+                    "description": "跳转回客户端的地址，携带 code 或 error",
+                    "type": "string"
                 }
             }
         },
-        "request.UpdateUserRequest": {
+        "response.OIDCDiscoveryResponse": {
             "type": "object",
-            "required": [
-                "id"
-            ],
             "properties": {
-                "avatar": {
-                    "type": "string",
-                    "maxLength": 255
+                "authorization_endpoint": {
+                    "type": "string"
                 },
-                "email": {
+                "authorization_response_iss_parameter_supported": {
+                    "type": "boolean"
+                },
+                "claims_supported": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "code_challenge_methods_supported": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "grant_types_supported": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id_token_signing_alg_values_supported": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "issuer": {
                     "type": "string"
                 },
-                "id": {
+                "jwks_uri": {
                     "type": "string"
                 },
-                "nickname": {
-                    "type": "string",
-                    "maxLength": 50
+                "response_types_supported": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "phone": {
-                    "type": "string",
-                    "maxLength": 20
+                "scopes_supported": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "roles": {
-                    "description": "角色列表，支持多选",
+                "subject_types_supported": {
                     "type": "array",
                     "items": {
                         "type": "string"
                     }
                 },
-                "status": {
-                    "description": "1: active, 0: inactive",
-                    "type": "integer",
-                    "maximum": 1,
-                    "minimum": 0
+                "token_endpoint": {
+                    "type": "string"
+                },
+                "token_endpoint_auth_methods_supported": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "userinfo_endpoint": {
+                    "type": "string"
                 }
             }
         },
-        "request.WechatLoginRequest": {
+        "response.OIDCLoginProviderResponse": {
             "type": "object",
-            "required": [
-                "code"
-            ],
             "properties": {
                 "code": {
+                    "description": "编码",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "显示名称",
                     "type": "string"
                 }
             }
         },
-        "request.WechatRefreshTokenRequest": {
+        "response.OIDCProviderListResponse": {
             "type": "object",
-            "required": [
-                "refresh_token"
-            ],
             "properties": {
-                "refresh_token": {
-                    "type": "string"
+                "list": {
+                    "description": "身份提供方列表",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.OIDCProviderResponse"
+                    }
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "pageSize": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
                 }
             }
         },
-        "response.APIResponse": {
+        "response.OIDCProviderResponse": {
             "type": "object",
             "properties": {
-                "authRequired": {
+                "authorizationEndpoint": {
+                    "description": "授权端点",
+                    "type": "string"
+                },
+                "autoCreate": {
+                    "description": "是否自动开通本地用户",
                     "type": "boolean"
                 },
-                "authType": {
-                    "type": "integer"
+                "clientId": {
+                    "description": "客户端 ID",
+                    "type": "string"
                 },
-                "btnPermIds": {
-                    "type": "array",
-                    "items": {
-                        "type": "integer"
-                    }
+                "code": {
+                    "description": "编码",
+                    "type": "string"
                 },
                 "createdAt": {
+                    "description": "创建时间",
                     "type": "string"
                 },
-                "createdBy": {
+                "hasClientSecret": {
+                    "description": "是否已配置客户端密钥",
+                    "type": "boolean"
+                },
+                "id": {
+                    "description": "身份提供方 ID",
                     "type": "string",
                     "example": "0"
                 },
-                "description": {
+                "issuer": {
+                    "description": "签发方",
                     "type": "string"
                 },
-                "group": {
+                "jwksUri": {
+                    "description": "JWKS 地址",
                     "type": "string"
                 },
-                "id": {
-                    "type": "string",
-                    "example": "0"
-                },
-                "menuIds": {
-                    "type": "array",
-                    "items": {
-                        "type": "integer"
-                    }
+                "linkBy": {
+                    "description": "账号关联方式",
+                    "type": "string"
                 },
-                "method": {
+                "name": {
+                    "description": "显示名称",
                     "type": "string"
                 },
-                "path": {
+                "redirectUri": {
+                    "description": "回调地址",
                     "type": "string"
                 },
-                "roleIds": {
+                "roleMappings": {
+                    "description": "声明到角色的映射规则",
                     "type": "array",
                     "items": {
-                        "type": "integer"
+                        "$ref": "#/definitions/response.OIDCRoleMappingResponse"
+                    }
+                },
+                "scopes": {
+                    "description": "授权范围",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
                     }
                 },
+                "sort": {
+                    "description": "排序",
+                    "type": "integer"
+                },
                 "status": {
+                    "description": "状态",
                     "type": "integer"
                 },
+                "tokenAuthMethod": {
+                    "description": "客户端认证方式",
+                    "type": "string"
+                },
+                "tokenEndpoint": {
+                    "description": "令牌端点",
+                    "type": "string"
+                },
+                "trustEmail": {
+                    "description": "是否信任未声明 email_verified 的邮箱",
+                    "type": "boolean"
+                },
                 "updatedAt": {
+                    "description": "更新时间",
                     "type": "string"
                 },
-                "updatedBy": {
+                "userinfoEndpoint": {
+                    "description": "用户信息端点",
+                    "type": "string"
+                },
+                "usernameClaim": {
+                    "description": "自动开通时作为用户名的声明",
+                    "type": "string"
+                }
+            }
+        },
+        "response.OIDCRoleMappingResponse": {
+            "type": "object",
+            "properties": {
+                "claim": {
+                    "description": "声明路径",
+                    "type": "string"
+                },
+                "roleId": {
+                    "description": "授予的角色 ID",
                     "type": "string",
                     "example": "0"
+                },
+                "value": {
+                    "description": "期望的声明值",
+                    "type": "string"
                 }
             }
         },
-        "response.AbnormalLoginInfoDTO": {
+        "response.OIDCTokenResponse": {
             "type": "object",
             "properties": {
-                "failedCount": {
+                "access_token": {
+                    "type": "string"
+                },
+                "expires_in": {
                     "type": "integer"
                 },
-                "ip": {
+                "id_token": {
                     "type": "string"
                 },
-                "lastFailedTime": {
+                "refresh_token": {
                     "type": "string"
                 },
-                "riskDescription": {
+                "scope": {
                     "type": "string"
                 },
-                "riskLevel": {
+                "token_type": {
                     "type": "string"
-                },
-                "usernames": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
                 }
             }
         },
-        "response.AuthorityInfo": {
+        "response.OnlineUserListResponse": {
             "type": "object",
             "properties": {
-                "defaultRouter": {
-                    "type": "string"
+                "list": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.OnlineUserResponse"
+                    }
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "pageSize": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
                 }
             }
         },
-        "response.BtnPermForRole": {
+        "response.OnlineUserResponse": {
             "type": "object",
             "properties": {
-                "code": {
+                "browser": {
                     "type": "string"
                 },
-                "description": {
-                    "type": "string"
+                "current": {
+                    "description": "是否为当前请求使用的会话",
+                    "type": "boolean"
                 },
-                "id": {
-                    "type": "string",
-                    "example": "0"
+                "device": {
+                    "type": "string"
                 },
-                "isAssigned": {
-                    "type": "boolean"
+                "expiresAt": {
+                    "type": "string"
                 },
-                "menuId": {
-                    "type": "string",
-                    "example": "0"
+                "lastActiveAt": {
+                    "type": "string"
                 },
-                "name": {
+                "loginIp": {
                     "type": "string"
                 },
-                "status": {
-                    "type": "integer"
-                }
-            }
-        },
-        "response.BtnPermResponse": {
-            "type": "object",
-            "properties": {
-                "code": {
+                "loginPlace": {
                     "type": "string"
                 },
-                "createdAt": {
+                "loginTime": {
                     "type": "string"
                 },
-                "description": {
+                "loginType": {
                     "type": "string"
                 },
-                "id": {
-                    "type": "string",
-                    "example": "0"
+                "nickname": {
+                    "type": "string"
                 },
-                "menuId": {
-                    "type": "string",
-                    "example": "0"
+                "os": {
+                    "type": "string"
                 },
-                "name": {
+                "sessionId": {
                     "type": "string"
                 },
-                "status": {
-                    "type": "integer"
+                "userAgent": {
+                    "type": "string"
                 },
-                "updatedAt": {
+                "userId": {
+                    "type": "string",
+                    "example": ""
+                },
+                "userName": {
                     "type": "string"
                 }
             }
         },
-        "response.CaptchaResponse": {
+        "response.OpenAPIPublicKeyResponse": {
             "type": "object",
             "properties": {
-                "captchaId": {
+                "publicKey": {
                     "type": "string"
                 },
-                "captchaLength": {
-                    "type": "integer"
-                },
-                "openCaptcha": {
-                    "type": "boolean"
-                },
-                "picPath": {
+                "signType": {
                     "type": "string"
                 }
             }
         },
-        "response.ConfigListResponse": {
+        "response.OpenAppListResponse": {
             "type": "object",
             "properties": {
                 "list": {
-                    "description": "配置列表",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/response.ConfigResponse"
+                        "$ref": "#/definitions/response.OpenAppResponse"
                     }
                 },
                 "page": {
@@ -12784,131 +21610,86 @@ const docTemplate = `{
                 }
             }
         },
-        "response.ConfigResponse": {
+        "response.OpenAppResponse": {
             "type": "object",
             "properties": {
-                "configKey": {
-                    "description": "配置键",
+                "appKey": {
                     "type": "string"
                 },
-                "configType": {
-                    "description": "配置类型",
+                "createdAt": {
                     "type": "string"
                 },
-                "configValue": {
-                    "description": "配置值",
+                "description": {
                     "type": "string"
                 },
-                "createdAt": {
-                    "description": "创建时间",
+                "id": {
                     "type": "string"
                 },
-                "description": {
-                    "description": "描述",
+                "name": {
                     "type": "string"
                 },
-                "id": {
-                    "description": "配置 ID",
-                    "type": "string",
-                    "example": "0"
+                "publicKey": {
+                    "type": "string"
+                },
+                "signType": {
+                    "type": "string"
                 },
                 "status": {
-                    "description": "状态：1-启用 0-禁用",
                     "type": "integer"
                 },
                 "updatedAt": {
-                    "description": "更新时间",
                     "type": "string"
-                }
-            }
-        },
-        "response.DepartmentListResponse": {
-            "type": "object",
-            "properties": {
-                "list": {
-                    "description": "部门列表",
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/response.DepartmentResponse"
-                    }
-                },
-                "page": {
-                    "type": "integer"
-                },
-                "pageSize": {
-                    "type": "integer"
                 },
-                "total": {
-                    "type": "integer"
+                "userId": {
+                    "type": "string"
                 }
             }
         },
-        "response.DepartmentResponse": {
+        "response.OpenAppSecretResponse": {
             "type": "object",
             "properties": {
-                "children": {
-                    "description": "可选字段：子部门列表",
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/response.DepartmentResponse"
-                    }
+                "appKey": {
+                    "type": "string"
                 },
-                "code": {
-                    "description": "部门编码",
+                "appSecret": {
                     "type": "string"
                 },
                 "createdAt": {
-                    "description": "创建时间",
                     "type": "string"
                 },
-                "email": {
-                    "description": "邮箱",
+                "description": {
                     "type": "string"
                 },
                 "id": {
-                    "description": "部门 ID",
-                    "type": "string",
-                    "example": "0"
-                },
-                "leader": {
-                    "description": "负责人",
                     "type": "string"
                 },
                 "name": {
-                    "description": "部门名称",
                     "type": "string"
                 },
-                "parentId": {
-                    "description": "父部门 ID",
-                    "type": "string",
-                    "example": "0"
-                },
-                "phone": {
-                    "description": "联系电话",
+                "publicKey": {
                     "type": "string"
                 },
-                "sort": {
-                    "description": "排序",
-                    "type": "integer"
+                "signType": {
+                    "type": "string"
                 },
                 "status": {
-                    "description": "状态：1-启用 0-禁用",
                     "type": "integer"
                 },
                 "updatedAt": {
-                    "description": "更新时间",
+                    "type": "string"
+                },
+                "userId": {
                     "type": "string"
                 }
             }
         },
-        "response.DictItemListResponse": {
+        "response.OperationLogListResponse": {
             "type": "object",
             "properties": {
                 "list": {
-                    "description": "字典项列表",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/response.DictItemResponse"
+                        "$ref": "#/definitions/response.OperationLogResponse"
                     }
                 },
                 "page": {
@@ -12922,351 +21703,487 @@ const docTemplate = `{
                 }
             }
         },
-        "response.DictItemResponse": {
+        "response.OperationLogResponse": {
             "type": "object",
             "properties": {
+                "clientIp": {
+                    "type": "string"
+                },
                 "createdAt": {
-                    "description": "创建时间",
                     "type": "string"
                 },
                 "description": {
-                    "description": "描述",
                     "type": "string"
                 },
-                "dictId": {
-                    "description": "字典 ID",
-                    "type": "string",
-                    "example": "0"
+                "duration": {
+                    "type": "integer"
+                },
+                "errorMessage": {
+                    "type": "string"
                 },
                 "id": {
-                    "description": "字典项 ID",
                     "type": "string",
                     "example": "0"
                 },
-                "itemKey": {
-                    "description": "字典项键",
+                "isSuccess": {
+                    "type": "boolean"
+                },
+                "method": {
                     "type": "string"
                 },
-                "itemValue": {
-                    "description": "字典项值",
+                "module": {
                     "type": "string"
                 },
-                "sort": {
-                    "description": "排序",
+                "nickname": {
+                    "type": "string"
+                },
+                "operationType": {
                     "type": "integer"
                 },
-                "status": {
-                    "description": "状态：1-启用 0-禁用",
+                "params": {
+                    "type": "string"
+                },
+                "path": {
+                    "type": "string"
+                },
+                "result": {
+                    "type": "string"
+                },
+                "statusCode": {
                     "type": "integer"
                 },
                 "updatedAt": {
-                    "description": "更新时间",
+                    "type": "string"
+                },
+                "userAgent": {
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "string",
+                    "example": "0"
+                },
+                "userName": {
                     "type": "string"
                 }
             }
         },
-        "response.DictListResponse": {
+        "response.OperationLogStatisticsResponse": {
             "type": "object",
             "properties": {
-                "list": {
-                    "description": "字典列表",
+                "avgDuration": {
+                    "type": "integer"
+                },
+                "failedCount": {
+                    "type": "integer"
+                },
+                "operationTypes": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/response.DictResponse"
+                        "type": "object",
+                        "properties": {
+                            "count": {
+                                "type": "integer"
+                            },
+                            "type": {
+                                "type": "integer"
+                            }
+                        }
                     }
                 },
-                "page": {
+                "successCount": {
                     "type": "integer"
                 },
-                "pageSize": {
+                "successRate": {
                     "type": "integer"
                 },
-                "total": {
+                "totalCount": {
                     "type": "integer"
                 }
             }
         },
-        "response.DictResponse": {
+        "response.PaymentResponse": {
             "type": "object",
             "properties": {
-                "createdAt": {
-                    "description": "创建时间",
+                "amount": {
+                    "type": "number"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "currency": {
                     "type": "string"
                 },
                 "description": {
-                    "description": "描述",
                     "type": "string"
                 },
-                "dictCode": {
-                    "description": "字典编码",
+                "id": {
                     "type": "string"
                 },
-                "dictItems": {
-                    "description": "字典项列表",
+                "order_id": {
+                    "type": "string"
+                },
+                "payment_method": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "transaction_id": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                },
+                "wechat_pay_params": {
+                    "description": "微信支付相关参数",
+                    "type": "object",
+                    "additionalProperties": true
+                }
+            }
+        },
+        "response.PermissionChangeListResponse": {
+            "type": "object",
+            "properties": {
+                "list": {
+                    "description": "申请列表",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/response.DictItemResponse"
+                        "$ref": "#/definitions/response.PermissionChangeResponse"
                     }
                 },
-                "dictName": {
-                    "description": "字典名称",
-                    "type": "string"
-                },
-                "id": {
-                    "description": "字典 ID",
-                    "type": "string",
-                    "example": "0"
+                "page": {
+                    "type": "integer"
                 },
-                "status": {
-                    "description": "状态：1-启用 0-禁用",
+                "pageSize": {
                     "type": "integer"
                 },
-                "updatedAt": {
-                    "description": "更新时间",
-                    "type": "string"
+                "total": {
+                    "type": "integer"
                 }
             }
         },
-        "response.LoginLogDetailDTO": {
+        "response.PermissionChangeResponse": {
             "type": "object",
             "properties": {
-                "browser": {
-                    "type": "string"
+                "addedIds": {
+                    "description": "新增关联的对象 ID",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "createdAt": {
+                "afterIds": {
+                    "description": "生效后角色关联的对象 ID",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "beforeIds": {
+                    "description": "提交时角色已关联的对象 ID",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "changeType": {
+                    "description": "变更类型：role_users、role_menus、role_apis、role_btn_perms、approval_required、policy_bundle",
                     "type": "string"
                 },
-                "device": {
+                "createdAt": {
+                    "description": "提交时间",
                     "type": "string"
                 },
                 "id": {
+                    "description": "申请 ID",
                     "type": "string",
                     "example": "0"
                 },
-                "ip": {
-                    "type": "string"
-                },
-                "os": {
-                    "type": "string"
-                },
-                "place": {
+                "payload": {
+                    "description": "策略包导入申请的策略包内容（JSON）",
                     "type": "string"
                 },
-                "result": {
-                    "type": "integer"
+                "removedIds": {
+                    "description": "移除关联的对象 ID",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "userId": {
+                "requestedBy": {
+                    "description": "申请人 ID",
                     "type": "string",
                     "example": "0"
                 },
-                "userName": {
+                "reviewComment": {
+                    "description": "审批意见",
                     "type": "string"
-                }
-            }
-        },
-        "response.LoginLogListDTO": {
-            "type": "object",
-            "properties": {
-                "createdAt": {
+                },
+                "reviewedAt": {
+                    "description": "审批时间",
                     "type": "string"
                 },
-                "id": {
+                "reviewedBy": {
+                    "description": "审批人 ID",
                     "type": "string",
                     "example": "0"
                 },
-                "ip": {
-                    "type": "string"
-                },
-                "place": {
-                    "type": "string"
-                },
-                "result": {
-                    "type": "integer"
+                "roleId": {
+                    "description": "角色 ID",
+                    "type": "string",
+                    "example": "0"
                 },
-                "userName": {
+                "status": {
+                    "description": "状态：pending、approved、rejected",
                     "type": "string"
                 }
             }
         },
-        "response.LoginResponse": {
+        "response.PermissionDecisionResponse": {
             "type": "object",
             "properties": {
-                "accessToken": {
-                    "type": "string"
+                "allowed": {
+                    "type": "boolean"
                 },
-                "refreshToken": {
+                "reason": {
                     "type": "string"
                 },
-                "user": {
-                    "$ref": "#/definitions/response.UserInfoResponse"
+                "route": {
+                    "type": "string"
                 }
             }
         },
-        "response.MenuInfo": {
+        "response.PermissionExplanationResponse": {
             "type": "object",
             "properties": {
-                "id": {
-                    "type": "string",
-                    "example": "0"
+                "allowed": {
+                    "description": "最终结果",
+                    "type": "boolean"
                 },
-                "name": {
+                "authType": {
+                    "description": "API 授权类型：0-不需要授权，1-需要授权",
+                    "type": "integer"
+                },
+                "buttonCode": {
+                    "description": "解释的按钮权限编码",
                     "type": "string"
                 },
-                "parentId": {
-                    "type": "string",
-                    "example": "0"
+                "cached": {
+                    "description": "当前缓存的判定结果，与 allowed 不一致说明缓存尚未失效",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/response.PermissionDecisionResponse"
+                        }
+                    ]
+                },
+                "method": {
+                    "type": "string"
                 },
                 "path": {
                     "type": "string"
-                }
-            }
-        },
-        "response.MenuMetaResp": {
-            "type": "object",
-            "properties": {
-                "activeMenu": {
-                    "description": "当前激活的菜单（驼峰命名）",
+                },
+                "reason": {
+                    "description": "判定依据：admin、public、rbac、rule_allow、rule_deny、no_permission",
                     "type": "string"
                 },
-                "affix": {
-                    "description": "是否固定在标签栏（驼峰命名）",
+                "registered": {
+                    "description": "路由是否已在 API 表登记",
                     "type": "boolean"
                 },
-                "breadcrumb": {
-                    "description": "是否在面包屑显示（驼峰命名）",
-                    "type": "boolean"
+                "roles": {
+                    "description": "参与判定的角色，包含继承角色",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.RoleTraceResponse"
+                    }
                 },
-                "defaultMenu": {
-                    "description": "是否默认菜单（驼峰命名）",
-                    "type": "boolean"
+                "route": {
+                    "description": "匹配到的路由模板",
+                    "type": "string"
                 },
-                "frameLoading": {
-                    "description": "iframe 加载动画（驼峰命名）",
-                    "type": "boolean"
+                "ruleId": {
+                    "description": "决定结果的 ABAC 规则 ID",
+                    "type": "string",
+                    "example": "0"
                 },
-                "icon": {
-                    "description": "菜单图标",
-                    "type": "string"
+                "rules": {
+                    "description": "路径和方法匹配的 ABAC 规则",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.RuleTraceResponse"
+                    }
                 },
-                "keepAlive": {
-                    "description": "是否缓存（驼峰命名）",
+                "simulated": {
+                    "description": "是否按拟议变更解释",
                     "type": "boolean"
                 },
-                "title": {
-                    "description": "菜单标题",
+                "specialGranted": {
+                    "description": "用户特殊权限是否授予该 API",
+                    "type": "boolean"
+                },
+                "userId": {
+                    "type": "string",
+                    "example": "0"
+                },
+                "username": {
                     "type": "string"
                 }
             }
         },
-        "response.MenuResponse": {
+        "response.PermissionRuleListResponse": {
             "type": "object",
             "properties": {
-                "activeMenu": {
-                    "description": "当前激活的菜单",
-                    "type": "string"
-                },
-                "affix": {
-                    "description": "是否固定在标签栏",
-                    "type": "boolean"
-                },
-                "breadcrumb": {
-                    "description": "是否在面包屑显示",
-                    "type": "boolean"
-                },
-                "children": {
-                    "description": "子菜单",
+                "list": {
+                    "description": "规则列表",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/response.MenuResponse"
+                        "$ref": "#/definitions/response.PermissionRuleResponse"
                     }
                 },
-                "component": {
-                    "description": "组件路径",
+                "page": {
+                    "type": "integer"
+                },
+                "pageSize": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "response.PermissionRuleResponse": {
+            "type": "object",
+            "properties": {
+                "apiPath": {
+                    "description": "API 路径模式",
                     "type": "string"
                 },
-                "defaultMenu": {
-                    "description": "是否默认菜单",
-                    "type": "boolean"
+                "conditions": {
+                    "description": "条件表达式，JSON 格式",
+                    "type": "string"
                 },
-                "frameLoading": {
-                    "description": "iframe 加载动画",
-                    "type": "boolean"
+                "createdAt": {
+                    "description": "创建时间",
+                    "type": "string"
                 },
-                "hidden": {
-                    "description": "是否隐藏",
-                    "type": "boolean"
+                "description": {
+                    "description": "规则描述",
+                    "type": "string"
                 },
-                "icon": {
-                    "description": "菜单图标",
+                "effect": {
+                    "description": "效果：allow、deny",
                     "type": "string"
                 },
                 "id": {
-                    "description": "菜单 ID",
+                    "description": "规则 ID",
                     "type": "string",
                     "example": "0"
                 },
-                "isExt": {
-                    "description": "是否外部链接",
-                    "type": "boolean"
-                },
-                "keepAlive": {
-                    "description": "是否缓存",
+                "isSystem": {
+                    "description": "是否系统规则",
                     "type": "boolean"
                 },
-                "meta": {
-                    "description": "菜单元数据",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/response.MenuMetaResp"
-                        }
-                    ]
+                "method": {
+                    "description": "HTTP 方法",
+                    "type": "string"
                 },
                 "name": {
-                    "description": "路由名称",
+                    "description": "规则名称",
                     "type": "string"
                 },
-                "parentId": {
-                    "description": "父菜单 ID",
-                    "type": "string",
-                    "example": "0"
+                "sort": {
+                    "description": "排序",
+                    "type": "integer"
                 },
-                "path": {
-                    "description": "路由路径",
-                    "type": "string"
+                "status": {
+                    "description": "状态：1-启用 0-禁用",
+                    "type": "integer"
                 },
-                "permission": {
-                    "description": "权限标识",
+                "updatedAt": {
+                    "description": "更新时间",
+                    "type": "string"
+                }
+            }
+        },
+        "response.PolicyBundleExportResponse": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "description": "策略包文件内容",
                     "type": "string"
                 },
-                "redirect": {
-                    "description": "重定向地址",
+                "format": {
+                    "description": "文件格式：yaml、json",
                     "type": "string"
                 },
-                "sort": {
-                    "description": "排序号",
+                "version": {
+                    "description": "策略包格式版本",
                     "type": "integer"
+                }
+            }
+        },
+        "response.PolicyChangeResponse": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "description": "变更动作：create、update",
+                    "type": "string"
                 },
-                "status": {
-                    "description": "状态：0 禁用，1 启用",
-                    "type": "integer"
+                "fields": {
+                    "description": "有差异的字段",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "title": {
-                    "description": "菜单标题",
+                "key": {
+                    "description": "对象引用键",
                     "type": "string"
                 },
-                "type": {
-                    "description": "菜单类型：1 目录，2 菜单，3 按钮，4iframe",
-                    "type": "integer"
+                "kind": {
+                    "description": "对象类型：api、menu、btn_perm、role、permission_group、rule",
+                    "type": "string"
+                }
+            }
+        },
+        "response.PolicyImportResultResponse": {
+            "type": "object",
+            "properties": {
+                "applied": {
+                    "description": "是否已写入",
+                    "type": "boolean"
+                },
+                "approvalRoles": {
+                    "description": "受差异影响且开启了变更审批的角色编码，不为空时导入会提交为权限变更申请",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "changes": {
+                    "description": "差异列表",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.PolicyChangeResponse"
+                    }
+                },
+                "dryRun": {
+                    "description": "是否只比较差异",
+                    "type": "boolean"
                 }
             }
         },
-        "response.NoticeListResponse": {
+        "response.PositionListResponse": {
             "type": "object",
             "properties": {
                 "list": {
+                    "description": "岗位列表",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/response.NoticeResponse"
+                        "$ref": "#/definitions/response.PositionResponse"
                     }
                 },
                 "page": {
@@ -13280,138 +22197,149 @@ const docTemplate = `{
                 }
             }
         },
-        "response.NoticeResponse": {
+        "response.PositionResponse": {
             "type": "object",
             "properties": {
-                "content": {
+                "code": {
+                    "description": "岗位编码",
                     "type": "string"
                 },
                 "createdAt": {
+                    "description": "创建时间",
                     "type": "string"
                 },
                 "description": {
+                    "description": "描述",
                     "type": "string"
                 },
                 "id": {
+                    "description": "岗位 ID",
                     "type": "string",
                     "example": "0"
                 },
-                "isTop": {
-                    "type": "boolean"
-                },
-                "publishTime": {
+                "name": {
+                    "description": "岗位名称",
                     "type": "string"
                 },
-                "publisherId": {
-                    "type": "string",
-                    "example": "0"
-                },
                 "sort": {
+                    "description": "排序",
                     "type": "integer"
                 },
                 "status": {
-                    "type": "integer"
-                },
-                "title": {
-                    "type": "string"
-                },
-                "type": {
+                    "description": "状态：1-启用 0-禁用",
                     "type": "integer"
                 },
                 "updatedAt": {
+                    "description": "更新时间",
                     "type": "string"
-                },
-                "viewCount": {
-                    "type": "integer"
                 }
             }
         },
-        "response.NoticeStatisticsResponse": {
+        "response.ResetPasswordResponse": {
             "type": "object",
             "properties": {
-                "archivedCount": {
-                    "type": "integer"
-                },
-                "draftCount": {
-                    "type": "integer"
-                },
-                "publishedCount": {
-                    "type": "integer"
-                },
-                "totalCount": {
-                    "type": "integer"
+                "password": {
+                    "description": "重置后的密码（未指定时为系统生成的临时密码）",
+                    "type": "string"
                 }
             }
         },
-        "response.OnlineUserListResponse": {
+        "response.RoleDTO": {
             "type": "object",
             "properties": {
-                "list": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/response.OnlineUserResponse"
-                    }
-                },
-                "page": {
-                    "type": "integer"
+                "code": {
+                    "type": "string"
                 },
-                "pageSize": {
-                    "type": "integer"
+                "id": {
+                    "type": "string",
+                    "example": "0"
                 },
-                "total": {
-                    "type": "integer"
+                "name": {
+                    "type": "string"
                 }
             }
         },
-        "response.OnlineUserResponse": {
+        "response.RoleDetailResponse": {
             "type": "object",
             "properties": {
-                "browser": {
-                    "type": "string"
+                "approvalRequired": {
+                    "description": "权限变更是否需要审批",
+                    "type": "boolean"
                 },
-                "device": {
+                "code": {
                     "type": "string"
                 },
-                "lastActiveAt": {
+                "createdAt": {
                     "type": "string"
                 },
-                "loginIp": {
-                    "type": "string"
+                "dataScope": {
+                    "description": "数据权限范围：1-全部，2-本部门，3-本部门及下级，4-自定义部门，5-仅本人",
+                    "type": "integer"
                 },
-                "loginPlace": {
-                    "type": "string"
+                "dataScopeDeptIds": {
+                    "description": "自定义数据权限的部门 ID 列表",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "loginTime": {
+                "description": {
                     "type": "string"
                 },
-                "nickname": {
-                    "type": "string"
+                "id": {
+                    "type": "string",
+                    "example": "0"
                 },
-                "os": {
-                    "type": "string"
+                "isSystem": {
+                    "type": "boolean"
                 },
-                "sessionId": {
-                    "type": "string"
+                "menus": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.MenuInfo"
+                    }
                 },
-                "userAgent": {
+                "name": {
                     "type": "string"
                 },
-                "userId": {
+                "parentId": {
                     "type": "string",
-                    "example": ""
+                    "example": "0"
                 },
-                "userName": {
+                "routes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.RouteInfo"
+                    }
+                },
+                "sort": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "integer"
+                },
+                "type": {
+                    "type": "integer"
+                },
+                "updatedAt": {
                     "type": "string"
+                },
+                "users": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.UserInfo"
+                    }
                 }
             }
         },
-        "response.OperationLogListResponse": {
+        "response.RoleGrantListResponse": {
             "type": "object",
             "properties": {
                 "list": {
+                    "description": "授予列表",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/response.OperationLogResponse"
+                        "$ref": "#/definitions/response.RoleGrantResponse"
                     }
                 },
                 "page": {
@@ -13425,155 +22353,122 @@ const docTemplate = `{
                 }
             }
         },
-        "response.OperationLogResponse": {
+        "response.RoleGrantResponse": {
             "type": "object",
             "properties": {
-                "clientIp": {
-                    "type": "string"
-                },
-                "createdAt": {
-                    "type": "string"
-                },
-                "description": {
+                "approvedAt": {
+                    "description": "审批时间",
                     "type": "string"
                 },
-                "duration": {
-                    "type": "integer"
+                "approvedBy": {
+                    "description": "审批人或授予人 ID",
+                    "type": "string",
+                    "example": "0"
                 },
-                "errorMessage": {
+                "createdAt": {
+                    "description": "创建时间",
                     "type": "string"
                 },
-                "id": {
+                "createdBy": {
+                    "description": "创建人 ID，提权申请时为申请人",
                     "type": "string",
                     "example": "0"
                 },
-                "isSuccess": {
+                "elevation": {
+                    "description": "是否由用户申请提权产生",
                     "type": "boolean"
                 },
-                "method": {
-                    "type": "string"
-                },
-                "module": {
-                    "type": "string"
+                "id": {
+                    "description": "授予 ID",
+                    "type": "string",
+                    "example": "0"
                 },
-                "nickname": {
+                "justification": {
+                    "description": "授予或申请理由",
                     "type": "string"
                 },
-                "operationType": {
+                "requestedHours": {
+                    "description": "提权申请的时长（小时）",
                     "type": "integer"
                 },
-                "params": {
-                    "type": "string"
-                },
-                "path": {
+                "reviewComment": {
+                    "description": "审批意见或收回原因",
                     "type": "string"
                 },
-                "result": {
+                "revokedAt": {
+                    "description": "收回时间",
                     "type": "string"
                 },
-                "statusCode": {
-                    "type": "integer"
+                "revokedBy": {
+                    "description": "收回人 ID，到期自动收回时为 0",
+                    "type": "string",
+                    "example": "0"
                 },
-                "updatedAt": {
-                    "type": "string"
+                "roleId": {
+                    "description": "角色 ID",
+                    "type": "string",
+                    "example": "0"
                 },
-                "userAgent": {
+                "status": {
+                    "description": "状态：pending、scheduled、active、rejected、expired、revoked",
                     "type": "string"
                 },
                 "userId": {
+                    "description": "被授予用户 ID",
                     "type": "string",
                     "example": "0"
                 },
-                "userName": {
+                "validFrom": {
+                    "description": "生效时间，待审批的提权申请为空",
                     "type": "string"
-                }
-            }
-        },
-        "response.OperationLogStatisticsResponse": {
-            "type": "object",
-            "properties": {
-                "avgDuration": {
-                    "type": "integer"
-                },
-                "failedCount": {
-                    "type": "integer"
-                },
-                "operationTypes": {
-                    "type": "array",
-                    "items": {
-                        "type": "object",
-                        "properties": {
-                            "count": {
-                                "type": "integer"
-                            },
-                            "type": {
-                                "type": "integer"
-                            }
-                        }
-                    }
-                },
-                "successCount": {
-                    "type": "integer"
                 },
-                "successRate": {
-                    "type": "integer"
-                },
-                "totalCount": {
-                    "type": "integer"
+                "validUntil": {
+                    "description": "到期时间，待审批的提权申请为空",
+                    "type": "string"
                 }
             }
         },
-        "response.PaymentResponse": {
+        "response.RoleInfo": {
             "type": "object",
             "properties": {
-                "amount": {
-                    "type": "number"
-                },
-                "created_at": {
-                    "type": "string"
-                },
-                "currency": {
+                "code": {
                     "type": "string"
                 },
                 "description": {
                     "type": "string"
                 },
                 "id": {
-                    "type": "string"
-                },
-                "order_id": {
-                    "type": "string"
-                },
-                "payment_method": {
-                    "type": "string"
+                    "type": "string",
+                    "example": "0"
                 },
-                "status": {
-                    "type": "string"
+                "isSystem": {
+                    "type": "boolean"
                 },
-                "transaction_id": {
+                "name": {
                     "type": "string"
                 },
-                "updated_at": {
-                    "type": "string"
+                "parentId": {
+                    "type": "string",
+                    "example": "0"
                 },
-                "user_id": {
-                    "type": "string"
+                "sort": {
+                    "type": "integer"
                 },
-                "wechat_pay_params": {
-                    "description": "微信支付相关参数",
-                    "type": "object",
-                    "additionalProperties": true
+                "status": {
+                    "type": "integer"
+                },
+                "type": {
+                    "type": "integer"
                 }
             }
         },
-        "response.PositionListResponse": {
+        "response.RoleListResponse": {
             "type": "object",
             "properties": {
                 "list": {
-                    "description": "岗位列表",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/response.PositionResponse"
+                        "$ref": "#/definitions/response.RoleResponse"
                     }
                 },
                 "page": {
@@ -13587,158 +22482,324 @@ const docTemplate = `{
                 }
             }
         },
-        "response.PositionResponse": {
+        "response.RoleResponse": {
             "type": "object",
             "properties": {
+                "approvalRequired": {
+                    "description": "权限变更是否需要审批",
+                    "type": "boolean"
+                },
                 "code": {
-                    "description": "岗位编码",
                     "type": "string"
                 },
                 "createdAt": {
-                    "description": "创建时间",
                     "type": "string"
                 },
+                "dataScope": {
+                    "description": "数据权限范围：1-全部，2-本部门，3-本部门及下级，4-自定义部门，5-仅本人",
+                    "type": "integer"
+                },
+                "dataScopeDeptIds": {
+                    "description": "自定义数据权限的部门 ID 列表",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "description": {
-                    "description": "描述",
                     "type": "string"
                 },
                 "id": {
-                    "description": "岗位 ID",
                     "type": "string",
                     "example": "0"
                 },
+                "isSystem": {
+                    "type": "boolean"
+                },
                 "name": {
-                    "description": "岗位名称",
                     "type": "string"
                 },
+                "parentId": {
+                    "type": "string",
+                    "example": "0"
+                },
                 "sort": {
-                    "description": "排序",
                     "type": "integer"
                 },
                 "status": {
-                    "description": "状态：1-启用 0-禁用",
+                    "type": "integer"
+                },
+                "type": {
                     "type": "integer"
                 },
                 "updatedAt": {
-                    "description": "更新时间",
                     "type": "string"
                 }
             }
         },
-        "response.RoleDTO": {
+        "response.RoleTraceResponse": {
             "type": "object",
             "properties": {
                 "code": {
                     "type": "string"
                 },
+                "effective": {
+                    "description": "角色授权是否生效",
+                    "type": "boolean"
+                },
+                "granted": {
+                    "description": "角色是否直接授予",
+                    "type": "boolean"
+                },
+                "groups": {
+                    "description": "角色关联的权限组",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.GroupTraceResponse"
+                    }
+                },
+                "inheritedFrom": {
+                    "description": "继承该角色的子角色编码",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "roleId": {
+                    "type": "string",
+                    "example": "0"
+                },
+                "simulated": {
+                    "description": "角色配置是否来自拟议变更",
+                    "type": "boolean"
+                },
+                "source": {
+                    "description": "来源：assigned、current、inherited",
+                    "type": "string"
+                },
+                "status": {
+                    "type": "integer"
+                }
+            }
+        },
+        "response.RouteInfo": {
+            "type": "object",
+            "properties": {
+                "group": {
+                    "type": "string"
+                },
                 "id": {
                     "type": "string",
                     "example": "0"
                 },
+                "method": {
+                    "type": "string"
+                },
+                "path": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.RuleTraceResponse": {
+            "type": "object",
+            "properties": {
+                "apiPath": {
+                    "type": "string"
+                },
+                "condition": {
+                    "description": "条件求值过程",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/response.ConditionTraceResponse"
+                        }
+                    ]
+                },
+                "effect": {
+                    "type": "string"
+                },
+                "error": {
+                    "description": "条件无法编译时的错误",
+                    "type": "string"
+                },
+                "matched": {
+                    "description": "条件是否满足",
+                    "type": "boolean"
+                },
+                "method": {
+                    "type": "string"
+                },
                 "name": {
                     "type": "string"
+                },
+                "ruleId": {
+                    "type": "string",
+                    "example": "0"
+                },
+                "sort": {
+                    "type": "integer"
                 }
             }
         },
-        "response.RoleDetailResponse": {
+        "response.ServiceAccountKeyIssuedResponse": {
             "type": "object",
             "properties": {
-                "code": {
+                "apiKey": {
+                    "description": "API Key 明文",
+                    "type": "string"
+                },
+                "expiresAt": {
+                    "description": "过期时间",
+                    "type": "string"
+                },
+                "keyId": {
+                    "description": "Key ID",
+                    "type": "string",
+                    "example": "0"
+                },
+                "prefix": {
+                    "description": "Key 前缀",
                     "type": "string"
                 },
+                "serviceAccountId": {
+                    "description": "服务账号 ID",
+                    "type": "string",
+                    "example": "0"
+                }
+            }
+        },
+        "response.ServiceAccountKeyResponse": {
+            "type": "object",
+            "properties": {
                 "createdAt": {
+                    "description": "创建时间",
                     "type": "string"
                 },
-                "description": {
+                "expiresAt": {
+                    "description": "过期时间",
                     "type": "string"
                 },
                 "id": {
+                    "description": "Key ID",
                     "type": "string",
                     "example": "0"
                 },
-                "isSystem": {
-                    "type": "boolean"
+                "lastUsedAt": {
+                    "description": "最近使用时间",
+                    "type": "string"
                 },
-                "menus": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/response.MenuInfo"
-                    }
+                "lastUsedIp": {
+                    "description": "最近使用 IP",
+                    "type": "string"
                 },
-                "name": {
+                "prefix": {
+                    "description": "Key 前缀",
                     "type": "string"
                 },
-                "parentId": {
-                    "type": "string",
-                    "example": "0"
+                "revokedAt": {
+                    "description": "吊销时间",
+                    "type": "string"
                 },
-                "routes": {
+                "valid": {
+                    "description": "当前是否有效",
+                    "type": "boolean"
+                }
+            }
+        },
+        "response.ServiceAccountListResponse": {
+            "type": "object",
+            "properties": {
+                "list": {
+                    "description": "服务账号列表",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/response.RouteInfo"
+                        "$ref": "#/definitions/response.ServiceAccountResponse"
                     }
                 },
-                "sort": {
+                "page": {
                     "type": "integer"
                 },
-                "status": {
+                "pageSize": {
                     "type": "integer"
                 },
-                "type": {
+                "total": {
                     "type": "integer"
-                },
-                "updatedAt": {
-                    "type": "string"
-                },
-                "users": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/response.UserInfo"
-                    }
                 }
             }
         },
-        "response.RoleInfo": {
+        "response.ServiceAccountResponse": {
             "type": "object",
             "properties": {
-                "code": {
+                "createdAt": {
+                    "description": "创建时间",
                     "type": "string"
                 },
                 "description": {
+                    "description": "描述",
                     "type": "string"
                 },
                 "id": {
+                    "description": "服务账号 ID",
                     "type": "string",
                     "example": "0"
                 },
-                "isSystem": {
-                    "type": "boolean"
+                "ipAllowlist": {
+                    "description": "IP 白名单",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "keys": {
+                    "description": "API Key 列表",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.ServiceAccountKeyResponse"
+                    }
+                },
+                "lastUsedAt": {
+                    "description": "最近使用时间",
+                    "type": "string"
+                },
+                "lastUsedIp": {
+                    "description": "最近使用 IP",
+                    "type": "string"
                 },
                 "name": {
+                    "description": "名称",
                     "type": "string"
                 },
-                "parentId": {
-                    "type": "string",
-                    "example": "0"
+                "roleIds": {
+                    "description": "绑定的角色 ID 列表",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "sort": {
-                    "type": "integer"
+                "roles": {
+                    "description": "绑定的角色列表",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.RoleDTO"
+                    }
                 },
                 "status": {
+                    "description": "状态：1-启用 0-禁用",
                     "type": "integer"
                 },
-                "type": {
-                    "type": "integer"
+                "updatedAt": {
+                    "description": "更新时间",
+                    "type": "string"
                 }
             }
         },
-        "response.RoleListResponse": {
+        "response.SoDConstraintListResponse": {
             "type": "object",
             "properties": {
                 "list": {
+                    "description": "约束列表",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/response.RoleResponse"
+                        "$ref": "#/definitions/response.SoDConstraintResponse"
                     }
                 },
                 "page": {
@@ -13752,60 +22813,81 @@ const docTemplate = `{
                 }
             }
         },
-        "response.RoleResponse": {
+        "response.SoDConstraintResponse": {
             "type": "object",
             "properties": {
-                "code": {
-                    "type": "string"
-                },
                 "createdAt": {
+                    "description": "创建时间",
                     "type": "string"
                 },
                 "description": {
+                    "description": "描述",
                     "type": "string"
                 },
                 "id": {
+                    "description": "约束 ID",
                     "type": "string",
                     "example": "0"
                 },
-                "isSystem": {
-                    "type": "boolean"
+                "maxRoles": {
+                    "description": "最多可持有或激活的角色数量",
+                    "type": "integer"
                 },
                 "name": {
+                    "description": "约束名称",
                     "type": "string"
                 },
-                "parentId": {
-                    "type": "string",
-                    "example": "0"
-                },
-                "sort": {
-                    "type": "integer"
+                "roleIds": {
+                    "description": "约束涉及的角色 ID 列表",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
                 "status": {
+                    "description": "状态：1-启用 0-禁用",
                     "type": "integer"
                 },
                 "type": {
-                    "type": "integer"
+                    "description": "约束类型：static、dynamic",
+                    "type": "string"
                 },
                 "updatedAt": {
+                    "description": "更新时间",
                     "type": "string"
                 }
             }
         },
-        "response.RouteInfo": {
+        "response.SoDViolationResponse": {
             "type": "object",
             "properties": {
-                "group": {
-                    "type": "string"
-                },
-                "id": {
+                "constraintId": {
+                    "description": "违反的约束 ID",
                     "type": "string",
                     "example": "0"
                 },
-                "method": {
+                "constraintName": {
+                    "description": "违反的约束名称",
                     "type": "string"
                 },
-                "path": {
+                "maxRoles": {
+                    "description": "约束允许的最多角色数量",
+                    "type": "integer"
+                },
+                "roleIds": {
+                    "description": "用户持有的约束内角色 ID",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "userId": {
+                    "description": "用户 ID",
+                    "type": "string",
+                    "example": "0"
+                },
+                "username": {
+                    "description": "用户名",
                     "type": "string"
                 }
             }
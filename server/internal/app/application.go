@@ -9,11 +9,16 @@ import (
 	"github.com/ix-pay/ixpay-pro/internal/app/wx"
 	"github.com/ix-pay/ixpay-pro/internal/config"
 	"github.com/ix-pay/ixpay-pro/internal/infrastructure/observability/logger"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/observability/redact"
 	"github.com/ix-pay/ixpay-pro/internal/infrastructure/persistence/cache"
 	"github.com/ix-pay/ixpay-pro/internal/infrastructure/persistence/database"
 	"github.com/ix-pay/ixpay-pro/internal/infrastructure/security/auth"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/security/fieldcrypt"
 	"github.com/ix-pay/ixpay-pro/internal/infrastructure/support/snowflake"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/support/task"
 	"github.com/ix-pay/ixpay-pro/internal/infrastructure/transport/middleware"
+	basePersistence "github.com/ix-pay/ixpay-pro/internal/persistence/base"
+	wxPersistence "github.com/ix-pay/ixpay-pro/internal/persistence/wx"
 
 	_ "github.com/ix-pay/ixpay-pro/docs"
 
@@ -34,6 +39,7 @@ type Application struct {
 	cache            cache.Cache
 	server           *http.Server
 	middlewareConfig *middleware.MiddlewareConfig
+	taskManager      *task.TaskManager
 	appBase          *base.AppBase
 	appWX            *wx.AppWX
 }
@@ -43,11 +49,14 @@ func SetupApplication(
 	cfg *config.Config,
 	logManager *logger.MultiLogger,
 	log logger.Logger,
+	redactor *redact.Redactor,
 	db *database.PostgresDB,
 	snowflake *snowflake.Snowflake,
 	auth *auth.JWTAuth,
 	permissions *auth.PermissionManager,
 	cache cache.Cache,
+	fieldCipher *fieldcrypt.Cipher,
+	taskManager *task.TaskManager,
 	appBase *base.AppBase,
 	appWX *wx.AppWX,
 ) (*Application, error) {
@@ -55,13 +64,19 @@ func SetupApplication(
 	// 创建路由引擎
 	router := gin.New()
 
+	// 配置受信任代理，ClientIP 只采信受信任代理转发的客户端地址
+	if err := router.SetTrustedProxies(cfg.Server.TrustedProxies); err != nil {
+		log.Error("受信任代理配置无效", "error", err)
+		return nil, err
+	}
+
 	// 配置 Swagger
 	if cfg.Swagger.Enabled {
 		router.GET(cfg.Swagger.Path+"/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 	}
 
 	// 创建中间件配置中心
-	middlewareConfig := middleware.SetupMiddlewareConfig(auth, log, cache)
+	middlewareConfig := middleware.SetupMiddlewareConfig(auth, log, redactor, cache)
 
 	// 创建应用实例
 	app := &Application{
@@ -74,6 +89,7 @@ func SetupApplication(
 		loggerManager:    logManager,
 		cache:            cache,
 		middlewareConfig: middlewareConfig,
+		taskManager:      taskManager,
 		appBase:          appBase,
 		appWX:            appWX,
 	}
@@ -87,10 +103,22 @@ func SetupApplication(
 	// 设置雪花算法实例到数据库模块
 	database.SetSnowflakeInstance(app.snowflake)
 
+	// 设置字段加密器到数据库模块，必须在初始化种子数据之前
+	database.SetFieldCipher(fieldCipher)
+
 	// 初始化模块应用
 	app.appBase.Init(router)
 	app.appWX.Init(router)
 
+	// 注册字段重新加密任务，将历史明文和旧主密钥加密的字段改用当前主密钥加密
+	if cfg.Encryption.RotationCron != "" {
+		encryptedColumns := append(append([]database.EncryptedColumn{}, basePersistence.EncryptedColumns...), wxPersistence.EncryptedColumns...)
+		reEncryptTask := database.NewReEncryptTask(db, encryptedColumns, log)
+		if err := taskManager.AddScheduledTask(&task.ScheduledTask{Task: reEncryptTask, CronExpr: cfg.Encryption.RotationCron, Group: reEncryptTask.GetGroup()}); err != nil {
+			return nil, err
+		}
+	}
+
 	// 创建HTTP服务器
 	app.server = &http.Server{
 		Addr:         ":" + cfg.Server.Port,
@@ -119,12 +147,15 @@ func (a *Application) setupMiddleware() {
 // Start 启动HTTP服务器
 func (a *Application) Start() error {
 	a.logger.Info("启动HTTP服务器", "address", a.server.Addr)
+	a.taskManager.Start()
 	return a.server.ListenAndServe()
 }
 
 // Shutdown 优雅关闭HTTP服务器
 func (a *Application) Shutdown(ctx context.Context) error {
 	a.logger.Info("正在关闭HTTP服务器")
+	// 停止定时任务
+	a.taskManager.Stop()
 	// 关闭缓存连接
 	if a.cache != nil {
 		a.cache.Close()
@@ -75,6 +75,7 @@ func convertToAPIResponse(route *entity.API) *response.APIResponse {
 		AuthType:     route.AuthType,
 		Description:  route.Description,
 		Status:       route.Status,
+		Orphaned:     route.Orphaned,
 		RoleIds:      route.RoleIds,
 		MenuIds:      route.MenuIds,
 		BtnPermIds:   route.BtnPermIds,
@@ -97,6 +98,7 @@ func convertToAPIResponse(route *entity.API) *response.APIResponse {
 //	@Param			pageSize	query		int										false	"每页数量，默认为 100"
 //	@Param			keyword		query		string									false	"搜索关键词（支持路径、描述、分组）"
 //	@Param			group		query		string									false	"路由分组"
+//	@Param			orphaned	query		bool									false	"是否已失效"
 //	@Success		200			{object}	baseRes.Response{data=baseRes.PageResult}	"路由列表"
 //	@Failure		401			{object}	baseRes.Response{msg=string}				"未授权"
 //	@Failure		403			{object}	baseRes.Response{msg=string}				"无权限"
@@ -130,6 +132,9 @@ func (c *APIController) GetAPIs(ctx *gin.Context) {
 	if req.Group != "" {
 		filters["group"] = req.Group
 	}
+	if req.Orphaned != nil {
+		filters["orphaned"] = *req.Orphaned
+	}
 
 	routes, total, err := c.apisService.GetAPIRouteList(req.Page, req.PageSize, filters)
 	if err != nil {
@@ -426,6 +431,7 @@ func (c *APIController) DeleteAPI(ctx *gin.Context) {
 //	@Param			pageSize		query		int														false	"每页数量，默认为 10"
 //	@Param			group			query		string													false	"路由分组"
 //	@Param			authRequired	query		bool													false	"是否需要认证"
+//	@Param			orphaned		query		bool													false	"是否已失效"
 //	@Success		200				{object}	baseRes.Response{data=baseRes.PageResult,msg=string}	"路由列表"
 //	@Failure		401				{object}	baseRes.Response{msg=string}							"未授权"
 //	@Failure		403				{object}	baseRes.Response{msg=string}							"无权限"
@@ -455,6 +461,9 @@ func (c *APIController) GetAPIList(ctx *gin.Context) {
 			filters["auth_required"] = false
 		}
 	}
+	if req.Orphaned != nil {
+		filters["orphaned"] = *req.Orphaned
+	}
 
 	routes, total, err := c.apisService.GetAPIRouteList(req.Page, req.PageSize, filters)
 	if err != nil {
@@ -480,3 +489,55 @@ func (c *APIController) GetAPIList(ctx *gin.Context) {
 		PageSize: req.PageSize,
 	}, "获取成功", ctx)
 }
+
+// GetSyncReport 获取 API 路由同步报告
+//
+//	@Summary		获取 API 路由同步报告
+//	@Description	获取启动时路由同步的结果（管理员权限）：新登记的路由、重新出现的路由、已失效的路由，以及需要授权但尚未分配给任何角色的路由
+//	@Description	失效路由只做标记不删除，确认不再使用后由管理员删除
+//	@Tags			API 路由管理
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	baseRes.Response{data=response.APISyncReportResponse,msg=string}	"同步报告"
+//	@Failure		401	{object}	baseRes.Response{msg=string}									"未授权"
+//	@Failure		403	{object}	baseRes.Response{msg=string}									"无权限"
+//	@Failure		500	{object}	baseRes.Response{msg=string}									"服务器内部错误"
+//	@Router			/api/admin/apis/sync-report [get]
+func (c *APIController) GetSyncReport(ctx *gin.Context) {
+	_, ok := c.checkAdminPermission(ctx)
+	if !ok {
+		return
+	}
+
+	report, err := c.apisService.GetSyncReport()
+	if err != nil {
+		c.log.Error("获取 API 路由同步报告失败", "error", err)
+		baseRes.FailWithMessage("获取同步报告失败", ctx)
+		return
+	}
+
+	resp := response.APISyncReportResponse{
+		Total:      report.Total,
+		Added:      convertToAPIResponses(report.Added),
+		Restored:   convertToAPIResponses(report.Restored),
+		Orphaned:   convertToAPIResponses(report.Orphaned),
+		Unassigned: convertToAPIResponses(report.Unassigned),
+	}
+	if !report.SyncedAt.IsZero() {
+		resp.SyncedAt = report.SyncedAt.Format("2006-01-02 15:04:05")
+	}
+
+	baseRes.OkWithDetailed(resp, "获取成功", ctx)
+}
+
+// convertToAPIResponses 批量转换 API 路由响应
+func convertToAPIResponses(routes []*entity.API) []response.APIResponse {
+	responses := make([]response.APIResponse, 0, len(routes))
+	for _, route := range routes {
+		if resp := convertToAPIResponse(route); resp != nil {
+			responses = append(responses, *resp)
+		}
+	}
+	return responses
+}
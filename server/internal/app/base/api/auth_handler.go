@@ -1,12 +1,16 @@
 package baseapi
 
 import (
+	"errors"
+
 	"github.com/gin-gonic/gin"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/entity"
 	"github.com/ix-pay/ixpay-pro/internal/domain/base/service"
 	"github.com/ix-pay/ixpay-pro/internal/dto/base/request"
 	"github.com/ix-pay/ixpay-pro/internal/dto/base/response"
 	"github.com/ix-pay/ixpay-pro/internal/infrastructure/observability/logger"
 	"github.com/ix-pay/ixpay-pro/internal/infrastructure/security/auth"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/security/captcha"
 	"github.com/ix-pay/ixpay-pro/internal/utils/common/baseRes"
 )
 
@@ -62,17 +66,71 @@ func (c *AuthController) Login(ctx *gin.Context) {
 
 	// 调用服务层进行登录
 	user, accessToken, refreshToken, _, _, err := c.service.Login(req.Username, req.Password, req.CaptchaId, req.Captcha, ip, userAgent)
+	if err != nil {
+		failLogin(err, ctx)
+		return
+	}
+
+	baseRes.OkWithDetailed(buildLoginResponse(c.service, c.log, user, accessToken, refreshToken), "登录成功", ctx)
+}
+
+// VerifyLoginStepUp 登录二次验证
+//
+//	@Summary		登录二次验证
+//	@Description	登录命中新设备、新地点或异地登录且需要二次验证时，提交收到的验证码完成登录；须在发起登录的设备上提交
+//	@Tags			认证服务
+//	@Accept			json
+//	@Produce		json
+//	@Param			data	body		request.LoginStepUpRequest									true	"二次验证参数"
+//	@Success		200		{object}	baseRes.Response{data=response.LoginResponse,msg=string}	"登录成功"
+//	@Failure		400		{object}	map[string]string											"验证码错误或已过期"
+//	@Router			/api/admin/auth/login/verify [post]
+func (c *AuthController) VerifyLoginStepUp(ctx *gin.Context) {
+	var req request.LoginStepUpRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		c.log.Error("请求参数错误", "error", err)
+		baseRes.FailWithMessage("请求参数错误", ctx)
+		return
+	}
+
+	user, accessToken, refreshToken, _, _, err := c.service.VerifyLoginStepUp(req.ChallengeID, req.Code, ctx.ClientIP(), ctx.Request.UserAgent())
 	if err != nil {
 		baseRes.FailWithMessage(err.Error(), ctx)
 		return
 	}
+	if user == nil {
+		baseRes.FailWithMessage("用户未分配角色，请联系管理员", ctx)
+		return
+	}
 
+	baseRes.OkWithDetailed(buildLoginResponse(c.service, c.log, user, accessToken, refreshToken), "登录成功", ctx)
+}
+
+// failLogin 返回登录失败响应，需要二次验证时返回验证码发送信息
+func failLogin(err error, ctx *gin.Context) {
+	var stepUpErr *service.StepUpRequiredError
+	if errors.As(err, &stepUpErr) {
+		baseRes.FailWithDetailed(response.LoginStepUpResponse{
+			StepUpRequired: true,
+			ChallengeID:    stepUpErr.ChallengeID,
+			Channel:        stepUpErr.Channel,
+			Target:         stepUpErr.Target,
+			ExpiresIn:      stepUpErr.ExpiresIn,
+			Reasons:        stepUpErr.Reasons,
+		}, err.Error(), ctx)
+		return
+	}
+	baseRes.FailWithMessage(err.Error(), ctx)
+}
+
+// buildLoginResponse 构建登录响应，账号密码登录和单点登录共用
+func buildLoginResponse(userService *service.UserService, log logger.Logger, user *entity.User, accessToken, refreshToken string) response.LoginResponse {
 	// 通过用户角色 ID 列表获取完整的角色信息
 	roleInfos := make([]*response.RoleInfo, 0, len(user.RoleIds))
 	for _, roleID := range user.RoleIds {
-		role, err := c.service.GetRoleByID(roleID)
+		role, err := userService.GetRoleByID(roleID)
 		if err != nil {
-			c.log.Warn("获取角色信息失败", "roleID", roleID, "error", err)
+			log.Warn("获取角色信息失败", "roleID", roleID, "error", err)
 			continue
 		}
 		roleInfo := &response.RoleInfo{
@@ -111,38 +169,64 @@ func (c *AuthController) Login(ctx *gin.Context) {
 		},
 	}
 
+	// 检查是否需要强制修改密码（管理员重置后首次登录或密码过期）
+	changeRequired, changeReason := userService.CheckPasswordChangeRequired(user)
+
 	// 构建响应数据
-	loginResponse := response.LoginResponse{
-		User:         userInfo,
-		AccessToken:  accessToken,
-		RefreshToken: refreshToken,
+	return response.LoginResponse{
+		User:                   userInfo,
+		AccessToken:            accessToken,
+		RefreshToken:           refreshToken,
+		PasswordChangeRequired: changeRequired,
+		PasswordChangeReason:   changeReason,
 	}
-
-	baseRes.OkWithDetailed(loginResponse, "登录成功", ctx)
 }
 
 // Captcha 获取验证码
 //
 //	@Summary		获取验证码
-//	@Description	获取登录验证码
+//	@Description	按场景策略获取验证码，当前不需要验证码时 openCaptcha 为 false 且不生成验证码
 //	@Tags			认证服务
 //	@Accept			json
 //	@Produce		json
-//	@Success		200	{object}	baseRes.Response{data=response.CaptchaResponse,msg=string}	"验证码信息"
-//	@Failure		500	{object}	map[string]string									"服务器内部错误"
+//	@Param			captcha	body		request.CaptchaRequest										false	"验证码场景，默认 login"
+//	@Success		200		{object}	baseRes.Response{data=response.CaptchaResponse,msg=string}	"验证码信息"
+//	@Failure		500		{object}	map[string]string											"服务器内部错误"
 //	@Router			/api/admin/auth/captcha [post]
 func (c *AuthController) Captcha(ctx *gin.Context) {
-	captchaId, captchaImage, captchaLen, openCaptcha, err := c.service.Captcha()
+	// 兼容不带请求体的旧调用方式
+	var req request.CaptchaRequest
+	var err error
+	if ctx.Request.ContentLength > 0 {
+		err = ctx.ShouldBindJSON(&req)
+	} else {
+		err = ctx.ShouldBindQuery(&req)
+	}
+	if err != nil {
+		c.log.Error("请求参数错误", "error", err)
+		baseRes.FailWithMessage("请求参数错误", ctx)
+		return
+	}
+	if req.Scenario == "" {
+		req.Scenario = captcha.ScenarioLogin
+	}
+
+	challenge, required, err := c.service.Captcha(req.Scenario, req.Account, ctx.ClientIP())
 	if err != nil {
 		baseRes.FailWithMessage("获取验证码失败", ctx)
 		return
 	}
 
-	responseData := response.CaptchaResponse{
-		CaptchaId:     captchaId,
-		PicPath:       captchaImage,
-		CaptchaLength: captchaLen,
-		OpenCaptcha:   openCaptcha,
+	responseData := response.CaptchaResponse{OpenCaptcha: required}
+	if challenge != nil {
+		responseData.CaptchaId = challenge.ID
+		responseData.CaptchaType = challenge.Type
+		responseData.PicPath = challenge.Data
+		responseData.CaptchaLength = challenge.Length
+		responseData.PieceImage = challenge.PieceImage
+		responseData.PieceY = challenge.PieceY
+		responseData.Width = challenge.Width
+		responseData.Height = challenge.Height
 	}
 
 	baseRes.OkWithDetailed(responseData, "获取验证码成功", ctx)
@@ -187,7 +271,7 @@ func (c *AuthController) RefreshToken(ctx *gin.Context) {
 // Logout 用户登出
 //
 //	@Summary		用户登出
-//	@Description	用户登出系统
+//	@Description	退出当前设备的登录，其他设备不受影响
 //	@Tags			认证服务
 //	@Accept			json
 //	@Produce		json
@@ -205,7 +289,14 @@ func (c *AuthController) Logout(ctx *gin.Context) {
 		return
 	}
 
-	if err := c.service.Logout(userID.(string)); err != nil {
+	// 携带会话 ID 的令牌只退出当前设备，旧令牌按用户加入黑名单
+	var err error
+	if sessionID := ctx.GetString("sessionID"); sessionID != "" {
+		err = c.service.LogoutSession(userID.(string), sessionID)
+	} else {
+		err = c.service.Logout(userID.(string))
+	}
+	if err != nil {
 		baseRes.FailWithMessage("登出失败", ctx)
 		return
 	}
@@ -349,8 +349,17 @@ func (c *BtnPermController) AssignBtnPermToRole(ctx *gin.Context) {
 		return
 	}
 
+	operatorID, err := getCurrentUserID(ctx)
+	if err != nil {
+		baseRes.NoAuth(err.Error(), ctx)
+		return
+	}
+
 	// 使用角色服务批量分配按钮权限
-	err = c.roleService.BatchAssignBtnPermsToRole(req.RoleID, btnPermIDs)
+	err = c.roleService.BatchAssignBtnPermsToRole(req.RoleID, btnPermIDs, operatorID)
+	if respondPendingApproval(err, ctx) {
+		return
+	}
 	if err != nil {
 		baseRes.FailWithMessage("分配按钮权限到角色失败", ctx)
 		return
@@ -379,9 +388,17 @@ func (c *BtnPermController) RevokeBtnPermFromRole(ctx *gin.Context) {
 		return
 	}
 
-	userID, _ := ctx.Get("userID")
+	userID, err := getCurrentUserID(ctx)
+	if err != nil {
+		baseRes.NoAuth(err.Error(), ctx)
+		return
+	}
+
 	// 使用角色服务撤销按钮权限
-	err := c.roleService.RevokeBtnPermFromRole(req.RoleID, req.BtnPermID)
+	err = c.roleService.RevokeBtnPermFromRole(req.RoleID, req.BtnPermID, userID)
+	if respondPendingApproval(err, ctx) {
+		return
+	}
 	if err != nil {
 		baseRes.FailWithMessage("从角色撤销按钮权限失败", ctx)
 		return
@@ -141,7 +141,7 @@ func (c *ConfigController) CreateConfig(ctx *gin.Context) {
 	}
 
 	// 从上下文中获取用户 ID
-	createdBy, exists := ctx.Get("userID")
+	createdBy, exists := getOperator(ctx)
 	if !exists {
 		c.log.Error("未授权")
 		baseRes.NoAuth("未授权", ctx)
@@ -219,7 +219,7 @@ func (c *ConfigController) UpdateConfig(ctx *gin.Context) {
 	}
 
 	// 从上下文中获取用户 ID
-	updatedBy, exists := ctx.Get("userID")
+	updatedBy, exists := getOperator(ctx)
 	if !exists {
 		c.log.Error("未授权")
 		baseRes.NoAuth("未授权", ctx)
@@ -75,7 +75,7 @@ func convertToDepartmentResponse(dept *entity.Department) response.DepartmentRes
 //	@Router			/api/admin/dept [get]
 func (c *DepartmentController) GetDepartmentList(ctx *gin.Context) {
 	// 检查用户是否已登录
-	_, exists := ctx.Get("userID")
+	exists := isAuthenticated(ctx)
 	if !exists {
 		c.log.Error("未登录")
 		baseRes.NoAuth("未登录", ctx)
@@ -138,7 +138,7 @@ func (c *DepartmentController) GetDepartmentList(ctx *gin.Context) {
 //	@Router			/api/admin/dept/tree [get]
 func (c *DepartmentController) GetDepartmentTree(ctx *gin.Context) {
 	// 检查用户是否已登录
-	_, exists := ctx.Get("userID")
+	exists := isAuthenticated(ctx)
 	if !exists {
 		c.log.Error("未登录")
 		baseRes.NoAuth("未登录", ctx)
@@ -170,7 +170,7 @@ func (c *DepartmentController) GetDepartmentTree(ctx *gin.Context) {
 //	@Router			/api/admin/dept/:id [get]
 func (c *DepartmentController) GetDepartmentByID(ctx *gin.Context) {
 	// 检查用户是否已登录
-	_, exists := ctx.Get("userID")
+	exists := isAuthenticated(ctx)
 	if !exists {
 		c.log.Error("未登录")
 		baseRes.NoAuth("未登录", ctx)
@@ -217,7 +217,7 @@ func (c *DepartmentController) CreateDepartment(ctx *gin.Context) {
 	}
 
 	// 获取当前登录用户 ID 作为创建者
-	createdBy, exists := ctx.Get("userID")
+	createdBy, exists := getOperator(ctx)
 	if !exists {
 		c.log.Error("未登录")
 		baseRes.NoAuth("未登录", ctx)
@@ -297,7 +297,7 @@ func (c *DepartmentController) UpdateDepartment(ctx *gin.Context) {
 	}
 
 	// 获取当前登录用户 ID 作为更新者
-	updatedBy, exists := ctx.Get("userID")
+	updatedBy, exists := getOperator(ctx)
 	if !exists {
 		c.log.Error("未登录")
 		baseRes.NoAuth("未登录", ctx)
@@ -426,7 +426,7 @@ func (c *DepartmentController) UpdateDepartmentLeader(ctx *gin.Context) {
 	}
 
 	// 获取当前登录用户 ID 作为更新者
-	updatedBy, exists := ctx.Get("userID")
+	updatedBy, exists := getOperator(ctx)
 	if !exists {
 		c.log.Error("未登录")
 		baseRes.NoAuth("未登录", ctx)
@@ -190,7 +190,7 @@ func (c *DictController) CreateDict(ctx *gin.Context) {
 	}
 
 	// 从上下文中获取用户 ID
-	createdBy, exists := ctx.Get("userID")
+	createdBy, exists := getOperator(ctx)
 	if !exists {
 		c.log.Error("未授权")
 		baseRes.NoAuth("未授权", ctx)
@@ -268,7 +268,7 @@ func (c *DictController) UpdateDict(ctx *gin.Context) {
 	}
 
 	// 从上下文中获取用户 ID
-	updatedBy, exists := ctx.Get("userID")
+	updatedBy, exists := getOperator(ctx)
 	if !exists {
 		c.log.Error("未授权")
 		baseRes.NoAuth("未授权", ctx)
@@ -547,7 +547,7 @@ func (c *DictController) CreateDictItem(ctx *gin.Context) {
 	}
 
 	// 从上下文中获取用户 ID
-	createdBy, exists := ctx.Get("userID")
+	createdBy, exists := getOperator(ctx)
 	if !exists {
 		c.log.Error("未授权")
 		baseRes.NoAuth("未授权", ctx)
@@ -618,7 +618,7 @@ func (c *DictController) UpdateDictItem(ctx *gin.Context) {
 	}
 
 	// 从上下文中获取用户 ID
-	updatedBy, exists := ctx.Get("userID")
+	updatedBy, exists := getOperator(ctx)
 	if !exists {
 		c.log.Error("未授权")
 		baseRes.NoAuth("未授权", ctx)
@@ -3,6 +3,10 @@ package baseapi
 import (
 	"fmt"
 	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/entity"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/service"
 )
 
 // convertStringSliceToInt64Slice 将字符串切片转换为 int64 切片
@@ -20,3 +24,97 @@ func convertStringSliceToInt64Slice(stringSlice []string) ([]int64, error) {
 	}
 	return int64Slice, nil
 }
+
+// convertInt64SliceToStringSlice 将 int64 切片转换为字符串切片，nil 转换为空切片
+func convertInt64SliceToStringSlice(int64Slice []int64) []string {
+	stringSlice := make([]string, 0, len(int64Slice))
+	for _, id := range int64Slice {
+		stringSlice = append(stringSlice, strconv.FormatInt(id, 10))
+	}
+	return stringSlice
+}
+
+// isAuthenticated 检查请求是否已认证，用户和服务账号均可
+func isAuthenticated(ctx *gin.Context) bool {
+	if _, exists := ctx.Get("userID"); exists {
+		return true
+	}
+	_, exists := ctx.Get("serviceAccountID")
+	return exists
+}
+
+// getOperator 获取记录创建人、更新人等使用的操作人 ID，返回值与 ctx.Get("userID") 相同
+// 服务账号没有用户 ID，其操作按系统操作记为 "0"，具体的服务账号由操作日志记录
+func getOperator(ctx *gin.Context) (interface{}, bool) {
+	if ctx.GetString("loginType") == service.LoginTypeAPIKey {
+		_, exists := ctx.Get("serviceAccountID")
+		return "0", exists
+	}
+	return ctx.Get("userID")
+}
+
+// getCurrentUserID 从上下文获取当前登录用户 ID 并转换为 int64
+// 服务账号没有用户身份，不能调用需要用户身份的接口，如审批、授权和个人数据
+func getCurrentUserID(ctx *gin.Context) (int64, error) {
+	if ctx.GetString("loginType") == service.LoginTypeAPIKey {
+		return 0, fmt.Errorf("服务账号不能执行该操作")
+	}
+	value, exists := ctx.Get("userID")
+	if !exists {
+		return 0, fmt.Errorf("未登录")
+	}
+	switch v := value.(type) {
+	case string:
+		id, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("用户 ID 格式错误")
+		}
+		return id, nil
+	case int64:
+		return v, nil
+	case int:
+		return int64(v), nil
+	default:
+		return 0, fmt.Errorf("用户 ID 类型错误")
+	}
+}
+
+// currentDataScope 获取当前请求的数据权限，由数据权限中间件保存的解析函数按需解析
+// 上下文中没有解析函数时按仅本人处理
+func currentDataScope(ctx *gin.Context) *entity.DataScope {
+	if value, exists := ctx.Get("dataScopeResolver"); exists {
+		if resolve, ok := value.(func() *entity.DataScope); ok {
+			if scope := resolve(); scope != nil {
+				return scope
+			}
+		}
+	}
+	var userID int64
+	if value := ctx.GetString("userID"); value != "" {
+		userID, _ = strconv.ParseInt(value, 10, 64)
+	}
+	return &entity.DataScope{Scope: entity.DataScopeSelf, UserID: userID}
+}
+
+// canAccessUserData 检查按 ID 查询的记录所属用户是否在当前数据权限范围内
+// 全部数据和本人数据无需查询用户，所属用户不存在时只有全部数据权限可见
+func canAccessUserData(ctx *gin.Context, users *service.UserService, userID int64) bool {
+	scope := currentDataScope(ctx)
+	if scope.IsAll() || userID == scope.UserID {
+		return true
+	}
+	if scope.IsSelf() {
+		return false
+	}
+	user, err := users.GetUserInfo(userID)
+	if err != nil {
+		return false
+	}
+	return scope.CanAccess(user.ID, user.DepartmentID)
+}
+
+// withDataScope 将当前数据权限放入列表查询的过滤条件
+func withDataScope(ctx *gin.Context, filters map[string]interface{}) map[string]interface{} {
+	filters[entity.DataScopeFilterKey] = currentDataScope(ctx)
+	return filters
+}
@@ -0,0 +1,600 @@
+package baseapi
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/entity"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/service"
+	"github.com/ix-pay/ixpay-pro/internal/dto/base/request"
+	"github.com/ix-pay/ixpay-pro/internal/dto/base/response"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/observability/logger"
+	"github.com/ix-pay/ixpay-pro/internal/utils/common/baseRes"
+)
+
+// IdentityProviderController OIDC 身份提供方控制器
+// 提供协议端点（发现文档、JWKS、授权、令牌、用户信息）、授权确认接口和客户端管理接口；
+// 协议端点按 OAuth 2.0 / OIDC 规范直接返回，不使用统一响应包装
+type IdentityProviderController struct {
+	service *service.IdentityProviderService // 身份提供方服务
+	log     logger.Logger                    // 日志记录器
+}
+
+// NewIdentityProviderController 创建 OIDC 身份提供方控制器实例
+func NewIdentityProviderController(service *service.IdentityProviderService, log logger.Logger) *IdentityProviderController {
+	return &IdentityProviderController{
+		service: service,
+		log:     log,
+	}
+}
+
+// convertToOIDCClientResponse 将 entity.OIDCClient 转换为 response.OIDCClientResponse
+func convertToOIDCClientResponse(client *entity.OIDCClient) response.OIDCClientResponse {
+	return response.OIDCClientResponse{
+		ID:           client.ID,
+		ClientID:     client.ClientID,
+		Name:         client.Name,
+		Description:  client.Description,
+		Public:       client.Public,
+		RedirectURIs: client.RedirectURIs,
+		GrantTypes:   client.GrantTypes,
+		Scopes:       client.Scopes,
+		SkipConsent:  client.SkipConsent,
+		Status:       client.Status,
+		CreatedAt:    client.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:    client.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+// toAuthorizeRequest 将请求参数转换为服务层授权请求
+func toAuthorizeRequest(req request.OIDCAuthorizeRequest) *service.AuthorizeRequest {
+	return &service.AuthorizeRequest{
+		ResponseType:        req.ResponseType,
+		ClientID:            req.ClientID,
+		RedirectURI:         req.RedirectURI,
+		Scope:               req.Scope,
+		State:               req.State,
+		Nonce:               req.Nonce,
+		CodeChallenge:       req.CodeChallenge,
+		CodeChallengeMethod: req.CodeChallengeMethod,
+		Prompt:              req.Prompt,
+	}
+}
+
+// oauthError 按协议格式输出错误
+func oauthError(ctx *gin.Context, err error) {
+	var oauthErr *service.OAuthError
+	if !errors.As(err, &oauthErr) {
+		oauthErr = &service.OAuthError{Code: "server_error", Description: err.Error(), Status: http.StatusInternalServerError}
+	}
+	switch oauthErr.Code {
+	case "invalid_client":
+		ctx.Header("WWW-Authenticate", `Basic realm="oidc"`)
+	case "invalid_token", "insufficient_scope":
+		ctx.Header("WWW-Authenticate", fmt.Sprintf(`Bearer error="%s"`, oauthErr.Code))
+	}
+	ctx.JSON(oauthErr.Status, response.OAuthErrorResponse{
+		Error:            oauthErr.Code,
+		ErrorDescription: oauthErr.Description,
+	})
+}
+
+// Discovery OIDC 发现文档
+//
+//	@Summary		OIDC 发现文档
+//	@Description	返回身份提供方的端点、支持的授权范围、授权类型和签名算法
+//	@Tags			身份提供方
+//	@Produce		json
+//	@Success		200	{object}	response.OIDCDiscoveryResponse	"发现文档"
+//	@Failure		404	{object}	response.OAuthErrorResponse		"身份提供方未开启"
+//	@Router			/api/oidc/.well-known/openid-configuration [get]
+func (c *IdentityProviderController) Discovery(ctx *gin.Context) {
+	if !c.service.Enabled() {
+		ctx.JSON(http.StatusNotFound, response.OAuthErrorResponse{Error: "not_found", ErrorDescription: "身份提供方未开启"})
+		return
+	}
+
+	issuer := c.service.Issuer()
+	ctx.JSON(http.StatusOK, response.OIDCDiscoveryResponse{
+		Issuer:                            issuer,
+		AuthorizationEndpoint:             issuer + "/authorize",
+		TokenEndpoint:                     issuer + "/token",
+		UserinfoEndpoint:                  issuer + "/userinfo",
+		JWKSURI:                           issuer + "/jwks",
+		ScopesSupported:                   service.SupportedOIDCScopes,
+		ResponseTypesSupported:            []string{"code"},
+		GrantTypesSupported:               service.SupportedOIDCGrantTypes,
+		SubjectTypesSupported:             []string{"public"},
+		IDTokenSigningAlgValuesSupported:  []string{"RS256"},
+		TokenEndpointAuthMethodsSupported: []string{"client_secret_basic", "client_secret_post", "none"},
+		CodeChallengeMethodsSupported:     []string{"S256"},
+		ClaimsSupported:                   service.SupportedOIDCClaims,
+		AuthorizationResponseIssParameter: true,
+	})
+}
+
+// JWKS 签名公钥集合
+//
+//	@Summary		OIDC 签名公钥
+//	@Description	返回校验 ID Token 和访问令牌签名的公钥（JWKS）
+//	@Tags			身份提供方
+//	@Produce		json
+//	@Success		200	{object}	map[string]interface{}		"JWKS"
+//	@Failure		404	{object}	response.OAuthErrorResponse	"身份提供方未开启"
+//	@Router			/api/oidc/jwks [get]
+func (c *IdentityProviderController) JWKS(ctx *gin.Context) {
+	if !c.service.Enabled() {
+		ctx.JSON(http.StatusNotFound, response.OAuthErrorResponse{Error: "not_found", ErrorDescription: "身份提供方未开启"})
+		return
+	}
+
+	ctx.Header("Cache-Control", "public, max-age=3600")
+	ctx.JSON(http.StatusOK, c.service.JWKS())
+}
+
+// Authorize 授权端点
+//
+//	@Summary		OIDC 授权端点
+//	@Description	校验授权请求后跳转前端授权确认页（未登录时由前端先跳转登录页）；客户端或回调地址无效时直接返回错误
+//	@Tags			身份提供方
+//	@Param			response_type			query	string	true	"响应类型，仅支持 code"
+//	@Param			client_id				query	string	true	"客户端标识"
+//	@Param			redirect_uri			query	string	true	"回调地址"
+//	@Param			scope					query	string	true	"授权范围，必须包含 openid"
+//	@Param			state					query	string	false	"客户端状态"
+//	@Param			nonce					query	string	false	"ID Token nonce"
+//	@Param			code_challenge			query	string	false	"PKCE 校验值，公开客户端必填"
+//	@Param			code_challenge_method	query	string	false	"PKCE 方法，仅支持 S256"
+//	@Param			prompt					query	string	false	"consent 表示强制重新确认"
+//	@Success		302
+//	@Failure		400	{object}	response.OAuthErrorResponse	"客户端或回调地址无效"
+//	@Router			/api/oidc/authorize [get]
+func (c *IdentityProviderController) Authorize(ctx *gin.Context) {
+	var req request.OIDCAuthorizeRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.OAuthErrorResponse{Error: "invalid_request", ErrorDescription: "请求参数错误"})
+		return
+	}
+
+	target, err := c.service.Authorize(toAuthorizeRequest(req))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.OAuthErrorResponse{Error: "invalid_request", ErrorDescription: err.Error()})
+		return
+	}
+
+	ctx.Redirect(http.StatusFound, target)
+}
+
+// GetConsentInfo 获取授权确认信息
+//
+//	@Summary		获取授权确认信息
+//	@Description	授权确认页使用，参数与授权端点相同；consentRequired 为 false 时前端可直接提交同意
+//	@Tags			身份提供方
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			client_id		query		string																true	"客户端标识"
+//	@Param			redirect_uri	query		string																true	"回调地址"
+//	@Param			scope			query		string																true	"授权范围"
+//	@Success		200				{object}	baseRes.Response{data=response.OIDCConsentInfoResponse,msg=string}	"授权确认信息"
+//	@Failure		401				{object}	map[string]string													"未授权"
+//	@Router			/api/oidc/consent [get]
+func (c *IdentityProviderController) GetConsentInfo(ctx *gin.Context) {
+	var req request.OIDCAuthorizeRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		baseRes.FailWithMessage("请求参数错误", ctx)
+		return
+	}
+
+	userID, err := getCurrentUserID(ctx)
+	if err != nil {
+		baseRes.NoAuth(err.Error(), ctx)
+		return
+	}
+
+	info, err := c.service.GetConsentInfo(userID, toAuthorizeRequest(req))
+	if err != nil {
+		baseRes.FailWithMessage(err.Error(), ctx)
+		return
+	}
+
+	baseRes.OkWithDetailed(response.OIDCConsentInfoResponse{
+		ClientID:        info.Client.ClientID,
+		ClientName:      info.Client.Name,
+		Description:     info.Client.Description,
+		Scopes:          info.Scopes,
+		ConsentRequired: info.ConsentRequired,
+	}, "获取授权确认信息成功", ctx)
+}
+
+// Consent 提交授权决定
+//
+//	@Summary		提交授权决定
+//	@Description	用户同意后签发一次性授权码，返回携带 code 的回调地址；拒绝时回调地址携带 access_denied
+//	@Tags			身份提供方
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			data	body		request.OIDCConsentRequest										true	"授权请求参数和决定"
+//	@Success		200		{object}	baseRes.Response{data=response.OIDCConsentResponse,msg=string}	"回调地址"
+//	@Failure		401		{object}	map[string]string												"未授权"
+//	@Router			/api/oidc/consent [post]
+func (c *IdentityProviderController) Consent(ctx *gin.Context) {
+	var req request.OIDCConsentRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		baseRes.FailWithMessage("请求参数错误", ctx)
+		return
+	}
+
+	userID, err := getCurrentUserID(ctx)
+	if err != nil {
+		baseRes.NoAuth(err.Error(), ctx)
+		return
+	}
+
+	redirectURL, err := c.service.Consent(userID, toAuthorizeRequest(req.OIDCAuthorizeRequest), req.Approved)
+	if err != nil {
+		baseRes.FailWithMessage(err.Error(), ctx)
+		return
+	}
+
+	baseRes.OkWithDetailed(response.OIDCConsentResponse{RedirectURL: redirectURL}, "授权成功", ctx)
+}
+
+// Token 令牌端点
+//
+//	@Summary		OIDC 令牌端点
+//	@Description	支持 authorization_code、refresh_token 和 client_credentials；客户端认证支持 client_secret_basic、client_secret_post，公开客户端只传 client_id
+//	@Tags			身份提供方
+//	@Accept			x-www-form-urlencoded
+//	@Produce		json
+//	@Param			grant_type		formData	string						true	"授权类型"
+//	@Param			code			formData	string						false	"授权码"
+//	@Param			redirect_uri	formData	string						false	"回调地址"
+//	@Param			code_verifier	formData	string						false	"PKCE 原始值"
+//	@Param			refresh_token	formData	string						false	"刷新令牌"
+//	@Param			scope			formData	string						false	"授权范围"
+//	@Param			client_id		formData	string						false	"客户端标识"
+//	@Param			client_secret	formData	string						false	"客户端密钥"
+//	@Success		200				{object}	response.OIDCTokenResponse	"令牌"
+//	@Failure		400				{object}	response.OAuthErrorResponse	"请求错误"
+//	@Failure		401				{object}	response.OAuthErrorResponse	"客户端认证失败"
+//	@Router			/api/oidc/token [post]
+func (c *IdentityProviderController) Token(ctx *gin.Context) {
+	ctx.Header("Cache-Control", "no-store")
+	ctx.Header("Pragma", "no-cache")
+
+	var req request.OIDCTokenRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		oauthError(ctx, &service.OAuthError{Code: "invalid_request", Description: "请求参数错误", Status: http.StatusBadRequest})
+		return
+	}
+
+	// client_secret_basic：客户端标识和密钥按 application/x-www-form-urlencoded 编码后放入 Basic 认证
+	if username, password, ok := ctx.Request.BasicAuth(); ok {
+		if req.ClientSecret != "" {
+			oauthError(ctx, &service.OAuthError{Code: "invalid_request", Description: "不能同时使用多种客户端认证方式", Status: http.StatusBadRequest})
+			return
+		}
+		clientID, idErr := url.QueryUnescape(username)
+		secret, secretErr := url.QueryUnescape(password)
+		if idErr != nil || secretErr != nil || (req.ClientID != "" && req.ClientID != clientID) {
+			oauthError(ctx, &service.OAuthError{Code: "invalid_client", Description: "客户端认证失败", Status: http.StatusUnauthorized})
+			return
+		}
+		req.ClientID = clientID
+		req.ClientSecret = secret
+	}
+
+	token, err := c.service.Token(&service.TokenRequest{
+		GrantType:    req.GrantType,
+		Code:         req.Code,
+		RedirectURI:  req.RedirectURI,
+		CodeVerifier: req.CodeVerifier,
+		RefreshToken: req.RefreshToken,
+		Scope:        req.Scope,
+		ClientID:     req.ClientID,
+		ClientSecret: req.ClientSecret,
+	})
+	if err != nil {
+		oauthError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.OIDCTokenResponse{
+		AccessToken:  token.AccessToken,
+		TokenType:    token.TokenType,
+		ExpiresIn:    token.ExpiresIn,
+		RefreshToken: token.RefreshToken,
+		IDToken:      token.IDToken,
+		Scope:        token.Scope,
+	})
+}
+
+// UserInfo 用户信息端点
+//
+//	@Summary		OIDC 用户信息端点
+//	@Description	使用访问令牌获取用户声明，按授权范围返回资料、角色编码和所属部门
+//	@Tags			身份提供方
+//	@Produce		json
+//	@Param			Authorization	header		string						true	"Bearer 访问令牌"
+//	@Success		200				{object}	map[string]interface{}		"用户声明"
+//	@Failure		401				{object}	response.OAuthErrorResponse	"访问令牌无效"
+//	@Router			/api/oidc/userinfo [get]
+func (c *IdentityProviderController) UserInfo(ctx *gin.Context) {
+	ctx.Header("Cache-Control", "no-store")
+
+	accessToken := ""
+	if header := ctx.GetHeader("Authorization"); len(header) > 7 && strings.EqualFold(header[:7], "Bearer ") {
+		accessToken = strings.TrimSpace(header[7:])
+	} else if ctx.Request.Method == http.MethodPost {
+		accessToken = ctx.PostForm("access_token")
+	}
+	if accessToken == "" {
+		oauthError(ctx, &service.OAuthError{Code: "invalid_token", Description: "缺少访问令牌", Status: http.StatusUnauthorized})
+		return
+	}
+
+	claims, err := c.service.UserInfo(accessToken)
+	if err != nil {
+		oauthError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, claims)
+}
+
+// GetClientList 获取客户端列表
+//
+//	@Summary		获取 OIDC 客户端列表
+//	@Description	分页获取接入本系统身份提供方的客户端，不返回密钥
+//	@Tags			身份提供方
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			page		query		int																	true	"页码"
+//	@Param			pageSize	query		int																	true	"每页数量"
+//	@Param			name		query		string																false	"名称（模糊匹配）"
+//	@Param			status		query		int																	false	"状态"
+//	@Success		200			{object}	baseRes.Response{data=response.OIDCClientListResponse,msg=string}	"客户端列表"
+//	@Failure		400			{object}	map[string]string													"请求参数错误"
+//	@Failure		401			{object}	map[string]string													"未授权"
+//	@Router			/api/admin/oidc/clients [get]
+func (c *IdentityProviderController) GetClientList(ctx *gin.Context) {
+	var req request.GetOIDCClientListRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		c.log.Error("请求参数错误", "error", err)
+		baseRes.FailWithMessage("请求参数错误", ctx)
+		return
+	}
+
+	filters := make(map[string]interface{})
+	if req.Name != "" {
+		filters["name"] = req.Name
+	}
+	if req.Status != nil {
+		filters["status"] = *req.Status
+	}
+
+	clients, total, err := c.service.GetClientList(req.Page, req.PageSize, filters)
+	if err != nil {
+		c.log.Error("获取客户端列表失败", "error", err)
+		baseRes.FailWithMessage("获取客户端列表失败", ctx)
+		return
+	}
+
+	responses := make([]response.OIDCClientResponse, 0, len(clients))
+	for _, client := range clients {
+		responses = append(responses, convertToOIDCClientResponse(client))
+	}
+
+	baseRes.OkWithDetailed(response.OIDCClientListResponse{
+		PageResult: baseRes.PageResult{
+			List:     responses,
+			Total:    total,
+			Page:     req.Page,
+			PageSize: req.PageSize,
+		},
+		List: responses,
+	}, "获取客户端列表成功", ctx)
+}
+
+// GetClientByID 获取客户端详情
+//
+//	@Summary		获取 OIDC 客户端详情
+//	@Description	根据 ID 获取客户端，不返回密钥
+//	@Tags			身份提供方
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string																true	"客户端记录 ID"
+//	@Success		200	{object}	baseRes.Response{data=response.OIDCClientResponse,msg=string}	"客户端详情"
+//	@Failure		400	{object}	map[string]string												"请求参数错误"
+//	@Failure		401	{object}	map[string]string												"未授权"
+//	@Router			/api/admin/oidc/clients/{id} [get]
+func (c *IdentityProviderController) GetClientByID(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		baseRes.FailWithMessage("无效的 ID 格式", ctx)
+		return
+	}
+
+	client, err := c.service.GetClientByID(id)
+	if err != nil {
+		baseRes.FailWithMessage(err.Error(), ctx)
+		return
+	}
+
+	baseRes.OkWithDetailed(convertToOIDCClientResponse(client), "获取客户端成功", ctx)
+}
+
+// CreateClient 登记客户端
+//
+//	@Summary		登记 OIDC 客户端
+//	@Description	登记接入本系统身份提供方的应用，机密客户端的密钥明文仅在响应中返回一次
+//	@Tags			身份提供方
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			data	body		request.CreateOIDCClientRequest										true	"客户端信息"
+//	@Success		200		{object}	baseRes.Response{data=response.OIDCClientSecretResponse,msg=string}	"登记成功"
+//	@Failure		400		{object}	map[string]string													"请求参数错误"
+//	@Failure		401		{object}	map[string]string													"未授权"
+//	@Router			/api/admin/oidc/clients [post]
+func (c *IdentityProviderController) CreateClient(ctx *gin.Context) {
+	var req request.CreateOIDCClientRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		baseRes.FailWithMessage("请求参数错误", ctx)
+		return
+	}
+
+	operatorID, err := getCurrentUserID(ctx)
+	if err != nil {
+		baseRes.NoAuth(err.Error(), ctx)
+		return
+	}
+
+	client := &entity.OIDCClient{
+		ClientID:     req.ClientID,
+		Name:         req.Name,
+		Description:  req.Description,
+		Public:       req.Public,
+		RedirectURIs: req.RedirectURIs,
+		GrantTypes:   req.GrantTypes,
+		Scopes:       req.Scopes,
+		SkipConsent:  req.SkipConsent,
+		Status:       req.Status,
+		CreatedBy:    operatorID,
+		UpdatedBy:    operatorID,
+	}
+	secret, err := c.service.CreateClient(client)
+	if err != nil {
+		baseRes.FailWithMessage(err.Error(), ctx)
+		return
+	}
+
+	clientResponse := convertToOIDCClientResponse(client)
+	baseRes.OkWithDetailed(response.OIDCClientSecretResponse{
+		Client:       &clientResponse,
+		ClientSecret: secret,
+	}, "登记客户端成功", ctx)
+}
+
+// UpdateClient 更新客户端
+//
+//	@Summary		更新 OIDC 客户端
+//	@Description	更新客户端配置，客户端标识和类型不可修改
+//	@Tags			身份提供方
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		string							true	"客户端记录 ID"
+//	@Param			data	body		request.UpdateOIDCClientRequest	true	"客户端信息"
+//	@Success		200		{object}	baseRes.Response{msg=string}	"更新成功"
+//	@Failure		400		{object}	map[string]string				"请求参数错误"
+//	@Failure		401		{object}	map[string]string				"未授权"
+//	@Router			/api/admin/oidc/clients/{id} [put]
+func (c *IdentityProviderController) UpdateClient(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		baseRes.FailWithMessage("无效的 ID 格式", ctx)
+		return
+	}
+
+	var req request.UpdateOIDCClientRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		baseRes.FailWithMessage("请求参数错误", ctx)
+		return
+	}
+
+	operatorID, err := getCurrentUserID(ctx)
+	if err != nil {
+		baseRes.NoAuth(err.Error(), ctx)
+		return
+	}
+
+	if err := c.service.UpdateClient(&entity.OIDCClient{
+		ID:           id,
+		Name:         req.Name,
+		Description:  req.Description,
+		RedirectURIs: req.RedirectURIs,
+		GrantTypes:   req.GrantTypes,
+		Scopes:       req.Scopes,
+		SkipConsent:  req.SkipConsent,
+		Status:       req.Status,
+		UpdatedBy:    operatorID,
+	}); err != nil {
+		baseRes.FailWithMessage(err.Error(), ctx)
+		return
+	}
+
+	baseRes.OkWithMessage("更新客户端成功", ctx)
+}
+
+// ResetClientSecret 重置客户端密钥
+//
+//	@Summary		重置 OIDC 客户端密钥
+//	@Description	为机密客户端生成新密钥，旧密钥立即失效，明文仅返回一次
+//	@Tags			身份提供方
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string																	true	"客户端记录 ID"
+//	@Success		200	{object}	baseRes.Response{data=response.OIDCClientSecretResponse,msg=string}	"重置成功"
+//	@Failure		400	{object}	map[string]string														"请求参数错误"
+//	@Failure		401	{object}	map[string]string														"未授权"
+//	@Router			/api/admin/oidc/clients/{id}/secret [post]
+func (c *IdentityProviderController) ResetClientSecret(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		baseRes.FailWithMessage("无效的 ID 格式", ctx)
+		return
+	}
+
+	operatorID, err := getCurrentUserID(ctx)
+	if err != nil {
+		baseRes.NoAuth(err.Error(), ctx)
+		return
+	}
+
+	secret, err := c.service.ResetClientSecret(id, operatorID)
+	if err != nil {
+		baseRes.FailWithMessage(err.Error(), ctx)
+		return
+	}
+
+	baseRes.OkWithDetailed(response.OIDCClientSecretResponse{ClientSecret: secret}, "重置客户端密钥成功", ctx)
+}
+
+// DeleteClient 删除客户端
+//
+//	@Summary		删除 OIDC 客户端
+//	@Description	删除客户端及用户授权记录，已签发的访问令牌在过期前仍然有效
+//	@Tags			身份提供方
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string							true	"客户端记录 ID"
+//	@Success		200	{object}	baseRes.Response{msg=string}	"删除成功"
+//	@Failure		400	{object}	map[string]string				"请求参数错误"
+//	@Failure		401	{object}	map[string]string				"未授权"
+//	@Router			/api/admin/oidc/clients/{id} [delete]
+func (c *IdentityProviderController) DeleteClient(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		baseRes.FailWithMessage("无效的 ID 格式", ctx)
+		return
+	}
+
+	if err := c.service.DeleteClient(id); err != nil {
+		baseRes.FailWithMessage(err.Error(), ctx)
+		return
+	}
+
+	baseRes.OkWithMessage("删除客户端成功", ctx)
+}
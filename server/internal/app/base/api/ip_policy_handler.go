@@ -0,0 +1,335 @@
+package baseapi
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/entity"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/service"
+	"github.com/ix-pay/ixpay-pro/internal/dto/base/request"
+	"github.com/ix-pay/ixpay-pro/internal/dto/base/response"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/observability/logger"
+	"github.com/ix-pay/ixpay-pro/internal/utils/common/baseRes"
+)
+
+// defaultAbnormalFailedCount 封禁异常登录 IP 的默认失败次数阈值，与异常登录的高风险等级一致
+const defaultAbnormalFailedCount = 5
+
+// IPPolicyController IP 访问策略控制器
+// 处理 IP 白名单、黑名单策略的管理请求
+type IPPolicyController struct {
+	service *service.IPPolicyService // IP 访问策略服务
+	log     logger.Logger            // 日志记录器
+}
+
+// NewIPPolicyController 创建 IP 访问策略控制器实例
+func NewIPPolicyController(service *service.IPPolicyService, log logger.Logger) *IPPolicyController {
+	return &IPPolicyController{
+		service: service,
+		log:     log,
+	}
+}
+
+// convertToIPPolicyResponse 将 entity.IPPolicy 转换为 response.IPPolicyResponse
+func convertToIPPolicyResponse(policy *entity.IPPolicy) response.IPPolicyResponse {
+	resp := response.IPPolicyResponse{
+		ID:          policy.ID,
+		Name:        policy.Name,
+		Scope:       policy.Scope,
+		TargetID:    policy.TargetID,
+		Action:      policy.Action,
+		CIDRs:       policy.CIDRs,
+		Description: policy.Description,
+		Status:      policy.Status,
+		CreatedAt:   policy.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:   policy.UpdatedAt.Format(time.RFC3339),
+	}
+	if resp.CIDRs == nil {
+		resp.CIDRs = []string{}
+	}
+	return resp
+}
+
+// parseIPPolicyTargetID 解析策略作用对象 ID，为空时返回 0
+func parseIPPolicyTargetID(value string) (int64, error) {
+	if value == "" {
+		return 0, nil
+	}
+	return strconv.ParseInt(value, 10, 64)
+}
+
+// GetIPPolicyList 获取 IP 访问策略列表
+//
+//	@Summary		获取 IP 访问策略列表
+//	@Description	分页获取 IP 访问策略列表
+//	@Tags			IP 访问策略
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			page		query		int																	true	"页码"
+//	@Param			pageSize	query		int																	true	"每页数量"
+//	@Param			scope		query		string																false	"作用范围 (global、role、user)"
+//	@Param			action		query		string																false	"动作 (allow、deny)"
+//	@Param			status		query		int																	false	"状态 (0:禁用，1:启用)"
+//	@Success		200			{object}	baseRes.Response{data=response.IPPolicyListResponse,msg=string}		"策略列表"
+//	@Failure		400			{object}	map[string]string													"请求参数错误"
+//	@Failure		401			{object}	map[string]string													"未授权"
+//	@Router			/api/admin/ip-policies [get]
+func (c *IPPolicyController) GetIPPolicyList(ctx *gin.Context) {
+	var req request.GetIPPolicyListRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		c.log.Error("请求参数错误", "error", err)
+		baseRes.FailWithMessage("请求参数错误", ctx)
+		return
+	}
+
+	filters := make(map[string]interface{})
+	if req.Scope != "" {
+		filters["scope"] = req.Scope
+	}
+	if req.Action != "" {
+		filters["action"] = req.Action
+	}
+	if req.Status != nil {
+		filters["status"] = *req.Status
+	}
+
+	policies, total, err := c.service.GetIPPolicyList(req.Page, req.PageSize, filters)
+	if err != nil {
+		c.log.Error("获取 IP 访问策略列表失败", "error", err)
+		baseRes.FailWithMessage("获取 IP 访问策略列表失败", ctx)
+		return
+	}
+
+	responses := make([]response.IPPolicyResponse, 0, len(policies))
+	for _, policy := range policies {
+		responses = append(responses, convertToIPPolicyResponse(policy))
+	}
+
+	baseRes.OkWithDetailed(response.IPPolicyListResponse{
+		PageResult: baseRes.PageResult{
+			List:     responses,
+			Total:    total,
+			Page:     req.Page,
+			PageSize: req.PageSize,
+		},
+		List: responses,
+	}, "获取 IP 访问策略列表成功", ctx)
+}
+
+// GetIPPolicyByID 获取 IP 访问策略详情
+//
+//	@Summary		获取 IP 访问策略详情
+//	@Description	根据 ID 获取 IP 访问策略详情
+//	@Tags			IP 访问策略
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string															true	"策略 ID"
+//	@Success		200	{object}	baseRes.Response{data=response.IPPolicyResponse,msg=string}	"策略详情"
+//	@Failure		400	{object}	map[string]string												"请求参数错误"
+//	@Failure		401	{object}	map[string]string												"未授权"
+//	@Router			/api/admin/ip-policies/{id} [get]
+func (c *IPPolicyController) GetIPPolicyByID(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		baseRes.FailWithMessage("无效的 ID 格式", ctx)
+		return
+	}
+
+	policy, err := c.service.GetIPPolicyByID(id)
+	if err != nil {
+		baseRes.FailWithMessage(err.Error(), ctx)
+		return
+	}
+
+	baseRes.OkWithDetailed(convertToIPPolicyResponse(policy), "获取 IP 访问策略详情成功", ctx)
+}
+
+// CreateIPPolicy 创建 IP 访问策略
+//
+//	@Summary		创建 IP 访问策略
+//	@Description	创建全局、角色或用户范围的 IP 白名单或黑名单策略，单个 IP 会转换为 /32 或 /128
+//	@Tags			IP 访问策略
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			data	body		request.CreateIPPolicyRequest									true	"策略信息"
+//	@Success		200		{object}	baseRes.Response{data=response.IPPolicyResponse,msg=string}	"创建成功"
+//	@Failure		400		{object}	map[string]string												"请求参数错误"
+//	@Failure		401		{object}	map[string]string												"未授权"
+//	@Router			/api/admin/ip-policies [post]
+func (c *IPPolicyController) CreateIPPolicy(ctx *gin.Context) {
+	var req request.CreateIPPolicyRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		baseRes.FailWithMessage("请求参数错误", ctx)
+		return
+	}
+
+	operatorID, err := getCurrentUserID(ctx)
+	if err != nil {
+		baseRes.NoAuth(err.Error(), ctx)
+		return
+	}
+
+	targetID, err := parseIPPolicyTargetID(req.TargetID)
+	if err != nil {
+		baseRes.FailWithMessage("无效的作用对象 ID", ctx)
+		return
+	}
+
+	// 提供默认值：status=1（启用）
+	status := entity.IPPolicyStatusEnabled
+	if req.Status != nil {
+		status = *req.Status
+	}
+
+	policy := &entity.IPPolicy{
+		Name:        req.Name,
+		Scope:       req.Scope,
+		TargetID:    targetID,
+		Action:      req.Action,
+		CIDRs:       req.CIDRs,
+		Description: req.Description,
+		Status:      status,
+		CreatedBy:   operatorID,
+		UpdatedBy:   operatorID,
+	}
+	if err := c.service.CreateIPPolicy(policy); err != nil {
+		baseRes.FailWithMessage(err.Error(), ctx)
+		return
+	}
+
+	baseRes.OkWithDetailed(convertToIPPolicyResponse(policy), "创建 IP 访问策略成功", ctx)
+}
+
+// UpdateIPPolicy 更新 IP 访问策略
+//
+//	@Summary		更新 IP 访问策略
+//	@Description	更新 IP 访问策略，保存后立即生效
+//	@Tags			IP 访问策略
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		string							true	"策略 ID"
+//	@Param			data	body		request.UpdateIPPolicyRequest	true	"策略信息"
+//	@Success		200		{object}	baseRes.Response{msg=string}	"更新成功"
+//	@Failure		400		{object}	map[string]string				"请求参数错误"
+//	@Failure		401		{object}	map[string]string				"未授权"
+//	@Router			/api/admin/ip-policies/{id} [put]
+func (c *IPPolicyController) UpdateIPPolicy(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		baseRes.FailWithMessage("无效的 ID 格式", ctx)
+		return
+	}
+
+	var req request.UpdateIPPolicyRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		baseRes.FailWithMessage("请求参数错误", ctx)
+		return
+	}
+
+	operatorID, err := getCurrentUserID(ctx)
+	if err != nil {
+		baseRes.NoAuth(err.Error(), ctx)
+		return
+	}
+
+	targetID, err := parseIPPolicyTargetID(req.TargetID)
+	if err != nil {
+		baseRes.FailWithMessage("无效的作用对象 ID", ctx)
+		return
+	}
+
+	if err := c.service.UpdateIPPolicy(&entity.IPPolicy{
+		ID:          id,
+		Name:        req.Name,
+		Scope:       req.Scope,
+		TargetID:    targetID,
+		Action:      req.Action,
+		CIDRs:       req.CIDRs,
+		Description: req.Description,
+		Status:      req.Status,
+		UpdatedBy:   operatorID,
+	}); err != nil {
+		baseRes.FailWithMessage(err.Error(), ctx)
+		return
+	}
+
+	baseRes.OkWithMessage("更新 IP 访问策略成功", ctx)
+}
+
+// DeleteIPPolicy 删除 IP 访问策略
+//
+//	@Summary		删除 IP 访问策略
+//	@Description	删除 IP 访问策略，删除后立即生效
+//	@Tags			IP 访问策略
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string							true	"策略 ID"
+//	@Success		200	{object}	baseRes.Response{msg=string}	"删除成功"
+//	@Failure		400	{object}	map[string]string				"请求参数错误"
+//	@Failure		401	{object}	map[string]string				"未授权"
+//	@Router			/api/admin/ip-policies/{id} [delete]
+func (c *IPPolicyController) DeleteIPPolicy(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		baseRes.FailWithMessage("无效的 ID 格式", ctx)
+		return
+	}
+
+	if err := c.service.DeleteIPPolicy(id); err != nil {
+		baseRes.FailWithMessage(err.Error(), ctx)
+		return
+	}
+
+	baseRes.OkWithMessage("删除 IP 访问策略成功", ctx)
+}
+
+// BlockAbnormalIPs 封禁异常登录 IP
+//
+//	@Summary		封禁异常登录 IP
+//	@Description	将最近 1 小时失败登录次数达到阈值的 IP 加入全局黑名单策略「异常登录 IP 黑名单」
+//	@Tags			IP 访问策略
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			data	body		request.BlockAbnormalIPsRequest										false	"封禁参数"
+//	@Success		200		{object}	baseRes.Response{data=response.BlockAbnormalIPsResponse,msg=string}	"封禁结果"
+//	@Failure		400		{object}	map[string]string													"请求参数错误"
+//	@Failure		401		{object}	map[string]string													"未授权"
+//	@Router			/api/admin/ip-policies/block-abnormal [post]
+func (c *IPPolicyController) BlockAbnormalIPs(ctx *gin.Context) {
+	var req request.BlockAbnormalIPsRequest
+	if ctx.Request.ContentLength > 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			baseRes.FailWithMessage("请求参数错误", ctx)
+			return
+		}
+	}
+	if req.MinFailedCount == 0 {
+		req.MinFailedCount = defaultAbnormalFailedCount
+	}
+
+	operatorID, err := getCurrentUserID(ctx)
+	if err != nil {
+		baseRes.NoAuth(err.Error(), ctx)
+		return
+	}
+
+	policy, added, err := c.service.BlockAbnormalIPs(req.MinFailedCount, operatorID)
+	if err != nil {
+		baseRes.FailWithMessage(err.Error(), ctx)
+		return
+	}
+
+	resp := response.BlockAbnormalIPsResponse{Added: added}
+	if policy != nil {
+		policyResp := convertToIPPolicyResponse(policy)
+		resp.Policy = &policyResp
+	}
+	baseRes.OkWithDetailed(resp, "封禁异常登录 IP 成功", ctx)
+}
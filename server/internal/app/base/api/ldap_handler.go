@@ -0,0 +1,214 @@
+package baseapi
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/entity"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/service"
+	"github.com/ix-pay/ixpay-pro/internal/dto/base/request"
+	"github.com/ix-pay/ixpay-pro/internal/dto/base/response"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/observability/logger"
+	"github.com/ix-pay/ixpay-pro/internal/utils/common/baseRes"
+)
+
+// LDAPController LDAP 控制器
+// 管理 LDAP 组与本地角色、部门的映射关系
+type LDAPController struct {
+	service *service.LDAPService // LDAP 登录服务
+	log     logger.Logger        // 日志记录器
+}
+
+// NewLDAPController 创建 LDAP 控制器实例
+func NewLDAPController(service *service.LDAPService, log logger.Logger) *LDAPController {
+	return &LDAPController{
+		service: service,
+		log:     log,
+	}
+}
+
+// convertToLDAPGroupMappingResponse 将 entity.LDAPGroupMapping 转换为 response.LDAPGroupMappingResponse
+func convertToLDAPGroupMappingResponse(mapping *entity.LDAPGroupMapping) response.LDAPGroupMappingResponse {
+	return response.LDAPGroupMappingResponse{
+		ID:           mapping.ID,
+		GroupDN:      mapping.GroupDN,
+		RoleID:       mapping.RoleID,
+		DepartmentID: mapping.DepartmentID,
+		Priority:     mapping.Priority,
+		Description:  mapping.Description,
+		CreatedAt:    mapping.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:    mapping.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+// GetGroupMappingList 获取 LDAP 组映射列表
+//
+//	@Summary		获取 LDAP 组映射列表
+//	@Description	分页获取 LDAP 组与角色、部门的映射
+//	@Tags			LDAP 管理
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			page		query		int																			true	"页码"
+//	@Param			pageSize	query		int																			true	"每页数量"
+//	@Param			groupDn		query		string																		false	"LDAP 组（模糊匹配）"
+//	@Success		200			{object}	baseRes.Response{data=response.LDAPGroupMappingListResponse,msg=string}	"映射列表"
+//	@Failure		400			{object}	map[string]string															"请求参数错误"
+//	@Failure		401			{object}	map[string]string															"未授权"
+//	@Router			/api/admin/ldap/group-mappings [get]
+func (c *LDAPController) GetGroupMappingList(ctx *gin.Context) {
+	var req request.GetLDAPGroupMappingListRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		c.log.Error("请求参数错误", "error", err)
+		baseRes.FailWithMessage("请求参数错误", ctx)
+		return
+	}
+
+	filters := make(map[string]interface{})
+	if req.GroupDN != "" {
+		filters["group_dn"] = req.GroupDN
+	}
+
+	mappings, total, err := c.service.GetMappingList(req.Page, req.PageSize, filters)
+	if err != nil {
+		c.log.Error("获取 LDAP 组映射列表失败", "error", err)
+		baseRes.FailWithMessage("获取 LDAP 组映射列表失败", ctx)
+		return
+	}
+
+	responses := make([]response.LDAPGroupMappingResponse, 0, len(mappings))
+	for _, mapping := range mappings {
+		responses = append(responses, convertToLDAPGroupMappingResponse(mapping))
+	}
+
+	baseRes.OkWithDetailed(response.LDAPGroupMappingListResponse{
+		PageResult: baseRes.PageResult{
+			List:     responses,
+			Total:    total,
+			Page:     req.Page,
+			PageSize: req.PageSize,
+		},
+		List: responses,
+	}, "获取 LDAP 组映射列表成功", ctx)
+}
+
+// CreateGroupMapping 创建 LDAP 组映射
+//
+//	@Summary		创建 LDAP 组映射
+//	@Description	将 LDAP 组映射为本地角色和/或部门，LDAP 用户登录时自动同步
+//	@Tags			LDAP 管理
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			data	body		request.CreateLDAPGroupMappingRequest								true	"映射信息"
+//	@Success		200		{object}	baseRes.Response{data=response.LDAPGroupMappingResponse,msg=string}	"创建成功"
+//	@Failure		400		{object}	map[string]string													"请求参数错误"
+//	@Failure		401		{object}	map[string]string													"未授权"
+//	@Router			/api/admin/ldap/group-mappings [post]
+func (c *LDAPController) CreateGroupMapping(ctx *gin.Context) {
+	var req request.CreateLDAPGroupMappingRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		baseRes.FailWithMessage("请求参数错误", ctx)
+		return
+	}
+
+	operatorID, err := getCurrentUserID(ctx)
+	if err != nil {
+		baseRes.NoAuth(err.Error(), ctx)
+		return
+	}
+
+	mapping := &entity.LDAPGroupMapping{
+		GroupDN:      req.GroupDN,
+		RoleID:       req.RoleID,
+		DepartmentID: req.DepartmentID,
+		Priority:     req.Priority,
+		Description:  req.Description,
+		CreatedBy:    operatorID,
+		UpdatedBy:    operatorID,
+	}
+	if err := c.service.CreateMapping(mapping); err != nil {
+		baseRes.FailWithMessage(err.Error(), ctx)
+		return
+	}
+
+	baseRes.OkWithDetailed(convertToLDAPGroupMappingResponse(mapping), "创建 LDAP 组映射成功", ctx)
+}
+
+// UpdateGroupMapping 更新 LDAP 组映射
+//
+//	@Summary		更新 LDAP 组映射
+//	@Description	更新 LDAP 组映射，下次登录时生效
+//	@Tags			LDAP 管理
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		string									true	"映射 ID"
+//	@Param			data	body		request.UpdateLDAPGroupMappingRequest	true	"映射信息"
+//	@Success		200		{object}	baseRes.Response{msg=string}			"更新成功"
+//	@Failure		400		{object}	map[string]string						"请求参数错误"
+//	@Failure		401		{object}	map[string]string						"未授权"
+//	@Router			/api/admin/ldap/group-mappings/{id} [put]
+func (c *LDAPController) UpdateGroupMapping(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		baseRes.FailWithMessage("无效的 ID 格式", ctx)
+		return
+	}
+
+	var req request.UpdateLDAPGroupMappingRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		baseRes.FailWithMessage("请求参数错误", ctx)
+		return
+	}
+
+	operatorID, err := getCurrentUserID(ctx)
+	if err != nil {
+		baseRes.NoAuth(err.Error(), ctx)
+		return
+	}
+
+	if err := c.service.UpdateMapping(&entity.LDAPGroupMapping{
+		ID:           id,
+		GroupDN:      req.GroupDN,
+		RoleID:       req.RoleID,
+		DepartmentID: req.DepartmentID,
+		Priority:     req.Priority,
+		Description:  req.Description,
+		UpdatedBy:    operatorID,
+	}); err != nil {
+		baseRes.FailWithMessage(err.Error(), ctx)
+		return
+	}
+
+	baseRes.OkWithMessage("更新 LDAP 组映射成功", ctx)
+}
+
+// DeleteGroupMapping 删除 LDAP 组映射
+//
+//	@Summary		删除 LDAP 组映射
+//	@Description	删除 LDAP 组映射，已同步的用户角色不会被回收
+//	@Tags			LDAP 管理
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string							true	"映射 ID"
+//	@Success		200	{object}	baseRes.Response{msg=string}	"删除成功"
+//	@Failure		400	{object}	map[string]string				"请求参数错误"
+//	@Failure		401	{object}	map[string]string				"未授权"
+//	@Router			/api/admin/ldap/group-mappings/{id} [delete]
+func (c *LDAPController) DeleteGroupMapping(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		baseRes.FailWithMessage("无效的 ID 格式", ctx)
+		return
+	}
+
+	if err := c.service.DeleteMapping(id); err != nil {
+		baseRes.FailWithMessage(err.Error(), ctx)
+		return
+	}
+
+	baseRes.OkWithMessage("删除 LDAP 组映射成功", ctx)
+}
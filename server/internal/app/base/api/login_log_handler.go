@@ -15,15 +15,19 @@ import (
 // LoginLogController 登录日志控制器
 // 处理登录日志相关的 HTTP 请求
 type LoginLogController struct {
-	service *service.LoginLogService
-	log     logger.Logger
+	service     *service.LoginLogService
+	riskService *service.LoginRiskService
+	userService *service.UserService
+	log         logger.Logger
 }
 
 // NewLoginLogController 创建登录日志控制器实例
-func NewLoginLogController(service *service.LoginLogService, log logger.Logger) *LoginLogController {
+func NewLoginLogController(service *service.LoginLogService, riskService *service.LoginRiskService, userService *service.UserService, log logger.Logger) *LoginLogController {
 	return &LoginLogController{
-		service: service,
-		log:     log,
+		service:     service,
+		riskService: riskService,
+		userService: userService,
+		log:         log,
 	}
 }
 
@@ -50,7 +54,7 @@ func NewLoginLogController(service *service.LoginLogService, log logger.Logger)
 //	@Router			/api/admin/login-log [get]
 func (c *LoginLogController) GetLoginLogList(ctx *gin.Context) {
 	// 检查用户是否已登录
-	_, exists := ctx.Get("userID")
+	exists := isAuthenticated(ctx)
 	if !exists {
 		c.log.Error("未登录")
 		baseRes.NoAuth("未登录", ctx)
@@ -89,7 +93,7 @@ func (c *LoginLogController) GetLoginLogList(ctx *gin.Context) {
 		}
 	}
 
-	logs, total, err := c.service.GetLoginLogList(req.Page, req.PageSize, filters)
+	logs, total, err := c.service.GetLoginLogList(req.Page, req.PageSize, withDataScope(ctx, filters))
 	if err != nil {
 		baseRes.FailWithMessage(err.Error(), ctx)
 		return
@@ -125,7 +129,7 @@ func (c *LoginLogController) GetLoginLogList(ctx *gin.Context) {
 //	@Router			/api/admin/login-log/statistics [get]
 func (c *LoginLogController) GetStatistics(ctx *gin.Context) {
 	// 检查用户是否已登录
-	_, exists := ctx.Get("userID")
+	exists := isAuthenticated(ctx)
 	if !exists {
 		c.log.Error("未登录")
 		baseRes.NoAuth("未登录", ctx)
@@ -185,7 +189,7 @@ func (c *LoginLogController) GetStatistics(ctx *gin.Context) {
 //	@Router			/api/admin/login-log/abnormal [get]
 func (c *LoginLogController) GetAbnormalLogins(ctx *gin.Context) {
 	// 检查用户是否已登录
-	_, exists := ctx.Get("userID")
+	exists := isAuthenticated(ctx)
 	if !exists {
 		c.log.Error("未登录")
 		baseRes.NoAuth("未登录", ctx)
@@ -218,6 +222,119 @@ func (c *LoginLogController) GetAbnormalLogins(ctx *gin.Context) {
 	baseRes.OkWithDetailed(pageResult, "获取异常登录记录成功", ctx)
 }
 
+// GetSecurityEvents 获取登录安全事件
+//
+//	@Summary		获取登录安全事件
+//	@Description	分页获取新设备、新国家/城市和异地登录等登录安全事件
+//	@Tags			登录日志管理
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			page		query		int																						true	"页码"
+//	@Param			pageSize	query		int																						true	"每页数量"
+//	@Param			userId		query		int64																					false	"用户 ID"
+//	@Param			userName	query		string																					false	"用户名"
+//	@Param			type		query		string																					false	"事件类型：new_device、new_country、new_city、impossible_travel"
+//	@Param			riskLevel	query		string																					false	"风险等级：low、medium、high"
+//	@Param			startDate	query		string																					false	"开始日期（YYYY-MM-DD）"
+//	@Param			endDate		query		string																					false	"结束日期（YYYY-MM-DD）"
+//	@Success		200			{object}	baseRes.Response{data=baseRes.PageResult{list=[]response.LoginSecurityEventDTO},msg=string}	"登录安全事件列表"
+//	@Failure		400			{object}	map[string]string																		"请求参数错误"
+//	@Failure		401			{object}	map[string]string																		"未授权"
+//	@Failure		500			{object}	map[string]string																		"服务器内部错误"
+//	@Router			/api/admin/login-log/security-events [get]
+func (c *LoginLogController) GetSecurityEvents(ctx *gin.Context) {
+	var req request.GetLoginSecurityEventListRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		c.log.Error("请求参数错误", "error", err)
+		baseRes.FailWithMessage("请求参数错误", ctx)
+		return
+	}
+
+	filters := securityEventFilters(&req)
+	if req.UserID != nil {
+		filters["user_id"] = *req.UserID
+	}
+	if req.Username != "" {
+		filters["userName"] = req.Username
+	}
+	c.respondSecurityEvents(ctx, req.Page, req.PageSize, filters)
+}
+
+// GetMySecurityEvents 获取当前用户的登录安全事件
+//
+//	@Summary		获取当前用户的登录安全事件
+//	@Description	分页获取当前用户账号的新设备、新地点和异地登录记录
+//	@Tags			用户管理
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			page		query		int																						true	"页码"
+//	@Param			pageSize	query		int																						true	"每页数量"
+//	@Param			type		query		string																					false	"事件类型"
+//	@Success		200			{object}	baseRes.Response{data=baseRes.PageResult{list=[]response.LoginSecurityEventDTO},msg=string}	"登录安全事件列表"
+//	@Failure		400			{object}	map[string]string																		"请求参数错误"
+//	@Failure		401			{object}	map[string]string																		"未授权"
+//	@Router			/api/admin/user/security-events [get]
+func (c *LoginLogController) GetMySecurityEvents(ctx *gin.Context) {
+	userID, err := getCurrentUserID(ctx)
+	if err != nil {
+		baseRes.NoAuth(err.Error(), ctx)
+		return
+	}
+
+	var req request.GetLoginSecurityEventListRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		c.log.Error("请求参数错误", "error", err)
+		baseRes.FailWithMessage("请求参数错误", ctx)
+		return
+	}
+
+	filters := securityEventFilters(&req)
+	filters["user_id"] = userID
+	c.respondSecurityEvents(ctx, req.Page, req.PageSize, filters)
+}
+
+// securityEventFilters 构建登录安全事件的公共筛选条件
+func securityEventFilters(req *request.GetLoginSecurityEventListRequest) map[string]interface{} {
+	filters := make(map[string]interface{})
+	if req.Type != "" {
+		filters["type"] = req.Type
+	}
+	if req.RiskLevel != "" {
+		filters["risk_level"] = req.RiskLevel
+	}
+	if req.StartDate != "" {
+		if startDate, err := time.Parse("2006-01-02", req.StartDate); err == nil {
+			filters["start_time"] = startDate
+		}
+	}
+	if req.EndDate != "" {
+		if endDate, err := time.Parse("2006-01-02", req.EndDate); err == nil {
+			filters["end_time"] = endDate.Add(24 * time.Hour)
+		}
+	}
+	return filters
+}
+
+// respondSecurityEvents 查询登录安全事件并返回分页结果
+func (c *LoginLogController) respondSecurityEvents(ctx *gin.Context, page, pageSize int, filters map[string]interface{}) {
+	events, total, err := c.riskService.GetSecurityEvents(page, pageSize, filters)
+	if err != nil {
+		baseRes.FailWithMessage("获取登录安全事件失败", ctx)
+		return
+	}
+
+	pageResult := baseRes.PageResult{
+		List:     converter.ConvertSliceWithFunc(events, converter.LoginSecurityEventToDTO),
+		Total:    total,
+		Page:     page,
+		PageSize: pageSize,
+	}
+
+	baseRes.OkWithDetailed(pageResult, "获取登录安全事件成功", ctx)
+}
+
 // RecordLogin 记录登录日志（内部调用）
 // 该接口仅供内部服务调用，不对外暴露
 //
@@ -300,7 +417,7 @@ func (c *LoginLogController) RecordLogin(ctx *gin.Context) {
 // GetLoginLogByID 获取登录日志详情
 //
 //	@Summary		获取登录日志详情
-//	@Description	根据 ID 获取登录日志详细信息
+//	@Description	根据 ID 获取登录日志详细信息，数据权限范围外的日志按不存在处理
 //	@Tags			登录日志管理
 //	@Accept			json
 //	@Produce		json
@@ -313,7 +430,7 @@ func (c *LoginLogController) RecordLogin(ctx *gin.Context) {
 //	@Router			/api/admin/login-log/:id [get]
 func (c *LoginLogController) GetLoginLogByID(ctx *gin.Context) {
 	// 检查用户是否已登录
-	_, exists := ctx.Get("userID")
+	exists := isAuthenticated(ctx)
 	if !exists {
 		c.log.Error("未登录")
 		baseRes.NoAuth("未登录", ctx)
@@ -341,6 +458,12 @@ func (c *LoginLogController) GetLoginLogByID(ctx *gin.Context) {
 		return
 	}
 
+	// 数据权限范围外的日志按不存在处理，不暴露日志是否存在
+	if !canAccessUserData(ctx, c.userService, log.UserID) {
+		baseRes.FailWithMessage("登录日志不存在", ctx)
+		return
+	}
+
 	// 使用转换器将 Entity 转换为详情 DTO
 	detailDTO := converter.ConvertWithFunc(log, converter.LoginLogToDetailDTO)
 
@@ -363,7 +486,7 @@ func (c *LoginLogController) GetLoginLogByID(ctx *gin.Context) {
 //	@Router			/api/admin/login-log/batch-delete [post]
 func (c *LoginLogController) BatchDeleteLoginLogs(ctx *gin.Context) {
 	// 检查用户是否已登录
-	_, exists := ctx.Get("userID")
+	exists := isAuthenticated(ctx)
 	if !exists {
 		c.log.Error("未登录")
 		baseRes.NoAuth("未登录", ctx)
@@ -408,7 +531,7 @@ func (c *LoginLogController) BatchDeleteLoginLogs(ctx *gin.Context) {
 //	@Router			/api/admin/login-log/clear [post]
 func (c *LoginLogController) ClearLoginLogs(ctx *gin.Context) {
 	// 检查用户是否已登录
-	_, exists := ctx.Get("userID")
+	exists := isAuthenticated(ctx)
 	if !exists {
 		c.log.Error("未登录")
 		baseRes.NoAuth("未登录", ctx)
@@ -101,7 +101,7 @@ func convertToMenuResponse(menu *entity.Menu) *response.MenuResponse {
 //	@Router			/api/admin/menu [get]
 func (c *MenuController) GetMenuList(ctx *gin.Context) {
 	// 从上下文中获取用户ID
-	_, exists := ctx.Get("userID")
+	exists := isAuthenticated(ctx)
 	if !exists {
 		c.log.Error("未登录")
 		baseRes.NoAuth("未登录", ctx)
@@ -146,7 +146,7 @@ func (c *MenuController) CreateMenu(ctx *gin.Context) {
 	}
 
 	// 获取当前登录用户 ID 作为创建者
-	createdBy, exists := ctx.Get("userID")
+	createdBy, exists := getOperator(ctx)
 	if !exists {
 		c.log.Error("未登录")
 		baseRes.NoAuth("未登录", ctx)
@@ -233,7 +233,7 @@ func (c *MenuController) UpdateMenu(ctx *gin.Context) {
 	}
 
 	// 获取当前登录用户 ID 作为修改者
-	updatedBy, exists := ctx.Get("userID")
+	updatedBy, exists := getOperator(ctx)
 	if !exists {
 		c.log.Error("未登录")
 		baseRes.NoAuth("未登录", ctx)
@@ -459,7 +459,7 @@ func (c *MenuController) GetMenuDeleteImpact(ctx *gin.Context) {
 //	@Router			/api/admin/menu/tree [get]
 func (c *MenuController) GetMenuTree(ctx *gin.Context) {
 	// 从上下文中获取用户 ID
-	_, exists := ctx.Get("userID")
+	exists := isAuthenticated(ctx)
 	if !exists {
 		c.log.Error("未登录")
 		baseRes.NoAuth("未登录", ctx)
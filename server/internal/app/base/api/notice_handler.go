@@ -63,7 +63,7 @@ func NewNoticeController(service *service.NoticeService, recordService *service.
 //	@Router			/api/admin/notices [get]
 func (c *NoticeController) GetNoticeList(ctx *gin.Context) {
 	// 检查用户是否已登录
-	_, exists := ctx.Get("userID")
+	exists := isAuthenticated(ctx)
 	if !exists {
 		c.log.Error("未登录")
 		baseRes.NoAuth("未登录", ctx)
@@ -146,7 +146,7 @@ func (c *NoticeController) GetNoticeList(ctx *gin.Context) {
 //	@Router			/api/admin/notices/:id [get]
 func (c *NoticeController) GetNoticeByID(ctx *gin.Context) {
 	// 检查用户是否已登录
-	_, exists := ctx.Get("userID")
+	exists := isAuthenticated(ctx)
 	if !exists {
 		c.log.Error("未登录")
 		baseRes.NoAuth("未登录", ctx)
@@ -218,7 +218,7 @@ func (c *NoticeController) CreateNotice(ctx *gin.Context) {
 	}
 
 	// 获取当前登录用户 ID 作为发布人
-	publisherID, exists := ctx.Get("userID")
+	publisherID, exists := getOperator(ctx)
 	if !exists {
 		c.log.Error("未登录")
 		baseRes.NoAuth("未登录", ctx)
@@ -308,7 +308,7 @@ func (c *NoticeController) UpdateNotice(ctx *gin.Context) {
 	}
 
 	// 获取当前登录用户 ID 作为更新者
-	publisherID, exists := ctx.Get("userID")
+	publisherID, exists := getOperator(ctx)
 	if !exists {
 		c.log.Error("未登录")
 		baseRes.NoAuth("未登录", ctx)
@@ -444,7 +444,7 @@ func (c *NoticeController) PublishNotice(ctx *gin.Context) {
 	}
 
 	// 获取当前登录用户 ID 作为发布人
-	publisherID, exists := ctx.Get("userID")
+	publisherID, exists := getOperator(ctx)
 	if !exists {
 		c.log.Error("未登录")
 		baseRes.NoAuth("未登录", ctx)
@@ -543,7 +543,7 @@ func (c *NoticeController) MarkAsRead(ctx *gin.Context) {
 //	@Router			/api/admin/notices/statistics [get]
 func (c *NoticeController) GetStatistics(ctx *gin.Context) {
 	// 检查用户是否已登录
-	_, exists := ctx.Get("userID")
+	exists := isAuthenticated(ctx)
 	if !exists {
 		c.log.Error("未登录")
 		baseRes.NoAuth("未登录", ctx)
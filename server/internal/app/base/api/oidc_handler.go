@@ -0,0 +1,393 @@
+package baseapi
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/entity"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/service"
+	"github.com/ix-pay/ixpay-pro/internal/dto/base/request"
+	"github.com/ix-pay/ixpay-pro/internal/dto/base/response"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/observability/logger"
+	"github.com/ix-pay/ixpay-pro/internal/utils/common/baseRes"
+)
+
+// OIDCController OIDC 单点登录控制器
+// 提供身份提供方管理接口，以及登录页使用的发起登录和回调接口
+type OIDCController struct {
+	service     *service.OIDCService // OIDC 单点登录服务
+	userService *service.UserService // 用户服务，用于构建登录响应
+	log         logger.Logger        // 日志记录器
+}
+
+// NewOIDCController 创建 OIDC 单点登录控制器实例
+func NewOIDCController(service *service.OIDCService, userService *service.UserService, log logger.Logger) *OIDCController {
+	return &OIDCController{
+		service:     service,
+		userService: userService,
+		log:         log,
+	}
+}
+
+// convertToOIDCProviderResponse 将 entity.OIDCProvider 转换为 response.OIDCProviderResponse
+func convertToOIDCProviderResponse(provider *entity.OIDCProvider) response.OIDCProviderResponse {
+	roleMappings := make([]response.OIDCRoleMappingResponse, 0, len(provider.RoleMappings))
+	for _, mapping := range provider.RoleMappings {
+		roleMappings = append(roleMappings, response.OIDCRoleMappingResponse{
+			Claim:  mapping.Claim,
+			Value:  mapping.Value,
+			RoleID: mapping.RoleID,
+		})
+	}
+
+	return response.OIDCProviderResponse{
+		ID:                    provider.ID,
+		Code:                  provider.Code,
+		Name:                  provider.Name,
+		Issuer:                provider.Issuer,
+		ClientID:              provider.ClientID,
+		HasClientSecret:       provider.ClientSecret != "",
+		TokenAuthMethod:       provider.TokenAuthMethod,
+		Scopes:                provider.Scopes,
+		RedirectURI:           provider.RedirectURI,
+		AuthorizationEndpoint: provider.AuthorizationEndpoint,
+		TokenEndpoint:         provider.TokenEndpoint,
+		UserinfoEndpoint:      provider.UserinfoEndpoint,
+		JWKSURI:               provider.JWKSURI,
+		LinkBy:                provider.LinkBy,
+		TrustEmail:            provider.TrustEmail,
+		AutoCreate:            provider.AutoCreate,
+		UsernameClaim:         provider.UsernameClaim,
+		RoleMappings:          roleMappings,
+		Status:                provider.Status,
+		Sort:                  provider.Sort,
+		CreatedAt:             provider.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:             provider.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+// convertOIDCRoleMappings 将请求中的映射规则转换为领域实体
+func convertOIDCRoleMappings(mappings []request.OIDCRoleMappingRequest) []*entity.OIDCRoleMapping {
+	result := make([]*entity.OIDCRoleMapping, 0, len(mappings))
+	for _, mapping := range mappings {
+		result = append(result, &entity.OIDCRoleMapping{
+			Claim:  mapping.Claim,
+			Value:  mapping.Value,
+			RoleID: mapping.RoleID,
+		})
+	}
+	return result
+}
+
+// GetProviderList 获取身份提供方列表
+//
+//	@Summary		获取身份提供方列表
+//	@Description	分页获取 OIDC 身份提供方配置，不返回客户端密钥
+//	@Tags			单点登录
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			page		query		int																		true	"页码"
+//	@Param			pageSize	query		int																		true	"每页数量"
+//	@Param			name		query		string																	false	"名称（模糊匹配）"
+//	@Param			status		query		int																		false	"状态"
+//	@Success		200			{object}	baseRes.Response{data=response.OIDCProviderListResponse,msg=string}	"身份提供方列表"
+//	@Failure		400			{object}	map[string]string														"请求参数错误"
+//	@Failure		401			{object}	map[string]string														"未授权"
+//	@Router			/api/admin/oidc/providers [get]
+func (c *OIDCController) GetProviderList(ctx *gin.Context) {
+	var req request.GetOIDCProviderListRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		c.log.Error("请求参数错误", "error", err)
+		baseRes.FailWithMessage("请求参数错误", ctx)
+		return
+	}
+
+	filters := make(map[string]interface{})
+	if req.Name != "" {
+		filters["name"] = req.Name
+	}
+	if req.Status != nil {
+		filters["status"] = *req.Status
+	}
+
+	providers, total, err := c.service.GetProviderList(req.Page, req.PageSize, filters)
+	if err != nil {
+		c.log.Error("获取身份提供方列表失败", "error", err)
+		baseRes.FailWithMessage("获取身份提供方列表失败", ctx)
+		return
+	}
+
+	responses := make([]response.OIDCProviderResponse, 0, len(providers))
+	for _, provider := range providers {
+		responses = append(responses, convertToOIDCProviderResponse(provider))
+	}
+
+	baseRes.OkWithDetailed(response.OIDCProviderListResponse{
+		PageResult: baseRes.PageResult{
+			List:     responses,
+			Total:    total,
+			Page:     req.Page,
+			PageSize: req.PageSize,
+		},
+		List: responses,
+	}, "获取身份提供方列表成功", ctx)
+}
+
+// GetProviderByID 获取身份提供方详情
+//
+//	@Summary		获取身份提供方详情
+//	@Description	根据 ID 获取 OIDC 身份提供方配置，不返回客户端密钥
+//	@Tags			单点登录
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string																true	"身份提供方 ID"
+//	@Success		200	{object}	baseRes.Response{data=response.OIDCProviderResponse,msg=string}	"身份提供方详情"
+//	@Failure		400	{object}	map[string]string													"请求参数错误"
+//	@Failure		401	{object}	map[string]string													"未授权"
+//	@Router			/api/admin/oidc/providers/{id} [get]
+func (c *OIDCController) GetProviderByID(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		baseRes.FailWithMessage("无效的 ID 格式", ctx)
+		return
+	}
+
+	provider, err := c.service.GetProviderByID(id)
+	if err != nil {
+		baseRes.FailWithMessage(err.Error(), ctx)
+		return
+	}
+
+	baseRes.OkWithDetailed(convertToOIDCProviderResponse(provider), "获取身份提供方成功", ctx)
+}
+
+// CreateProvider 创建身份提供方
+//
+//	@Summary		创建身份提供方
+//	@Description	创建 OIDC 身份提供方，授权、令牌和 JWKS 端点为空时通过签发方的发现文档获取
+//	@Tags			单点登录
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			data	body		request.CreateOIDCProviderRequest								true	"身份提供方信息"
+//	@Success		200		{object}	baseRes.Response{data=response.OIDCProviderResponse,msg=string}	"创建成功"
+//	@Failure		400		{object}	map[string]string												"请求参数错误"
+//	@Failure		401		{object}	map[string]string												"未授权"
+//	@Router			/api/admin/oidc/providers [post]
+func (c *OIDCController) CreateProvider(ctx *gin.Context) {
+	var req request.CreateOIDCProviderRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		baseRes.FailWithMessage("请求参数错误", ctx)
+		return
+	}
+
+	operatorID, err := getCurrentUserID(ctx)
+	if err != nil {
+		baseRes.NoAuth(err.Error(), ctx)
+		return
+	}
+
+	provider := &entity.OIDCProvider{
+		Code:                  req.Code,
+		Name:                  req.Name,
+		Issuer:                req.Issuer,
+		ClientID:              req.ClientID,
+		ClientSecret:          req.ClientSecret,
+		TokenAuthMethod:       req.TokenAuthMethod,
+		Scopes:                req.Scopes,
+		RedirectURI:           req.RedirectURI,
+		AuthorizationEndpoint: req.AuthorizationEndpoint,
+		TokenEndpoint:         req.TokenEndpoint,
+		UserinfoEndpoint:      req.UserinfoEndpoint,
+		JWKSURI:               req.JWKSURI,
+		LinkBy:                req.LinkBy,
+		TrustEmail:            req.TrustEmail,
+		AutoCreate:            req.AutoCreate,
+		UsernameClaim:         req.UsernameClaim,
+		RoleMappings:          convertOIDCRoleMappings(req.RoleMappings),
+		Status:                req.Status,
+		Sort:                  req.Sort,
+		CreatedBy:             operatorID,
+		UpdatedBy:             operatorID,
+	}
+	if err := c.service.CreateProvider(provider); err != nil {
+		baseRes.FailWithMessage(err.Error(), ctx)
+		return
+	}
+
+	baseRes.OkWithDetailed(convertToOIDCProviderResponse(provider), "创建身份提供方成功", ctx)
+}
+
+// UpdateProvider 更新身份提供方
+//
+//	@Summary		更新身份提供方
+//	@Description	更新 OIDC 身份提供方配置，客户端密钥为空时保留原值，编码不可修改
+//	@Tags			单点登录
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		string								true	"身份提供方 ID"
+//	@Param			data	body		request.UpdateOIDCProviderRequest	true	"身份提供方信息"
+//	@Success		200		{object}	baseRes.Response{msg=string}		"更新成功"
+//	@Failure		400		{object}	map[string]string					"请求参数错误"
+//	@Failure		401		{object}	map[string]string					"未授权"
+//	@Router			/api/admin/oidc/providers/{id} [put]
+func (c *OIDCController) UpdateProvider(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		baseRes.FailWithMessage("无效的 ID 格式", ctx)
+		return
+	}
+
+	var req request.UpdateOIDCProviderRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		baseRes.FailWithMessage("请求参数错误", ctx)
+		return
+	}
+
+	operatorID, err := getCurrentUserID(ctx)
+	if err != nil {
+		baseRes.NoAuth(err.Error(), ctx)
+		return
+	}
+
+	if err := c.service.UpdateProvider(&entity.OIDCProvider{
+		ID:                    id,
+		Name:                  req.Name,
+		Issuer:                req.Issuer,
+		ClientID:              req.ClientID,
+		ClientSecret:          req.ClientSecret,
+		TokenAuthMethod:       req.TokenAuthMethod,
+		Scopes:                req.Scopes,
+		RedirectURI:           req.RedirectURI,
+		AuthorizationEndpoint: req.AuthorizationEndpoint,
+		TokenEndpoint:         req.TokenEndpoint,
+		UserinfoEndpoint:      req.UserinfoEndpoint,
+		JWKSURI:               req.JWKSURI,
+		LinkBy:                req.LinkBy,
+		TrustEmail:            req.TrustEmail,
+		AutoCreate:            req.AutoCreate,
+		UsernameClaim:         req.UsernameClaim,
+		RoleMappings:          convertOIDCRoleMappings(req.RoleMappings),
+		Status:                req.Status,
+		Sort:                  req.Sort,
+		UpdatedBy:             operatorID,
+	}); err != nil {
+		baseRes.FailWithMessage(err.Error(), ctx)
+		return
+	}
+
+	baseRes.OkWithMessage("更新身份提供方成功", ctx)
+}
+
+// DeleteProvider 删除身份提供方
+//
+//	@Summary		删除身份提供方
+//	@Description	删除 OIDC 身份提供方，同时解除该身份提供方下的用户绑定
+//	@Tags			单点登录
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string							true	"身份提供方 ID"
+//	@Success		200	{object}	baseRes.Response{msg=string}	"删除成功"
+//	@Failure		400	{object}	map[string]string				"请求参数错误"
+//	@Failure		401	{object}	map[string]string				"未授权"
+//	@Router			/api/admin/oidc/providers/{id} [delete]
+func (c *OIDCController) DeleteProvider(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		baseRes.FailWithMessage("无效的 ID 格式", ctx)
+		return
+	}
+
+	if err := c.service.DeleteProvider(id); err != nil {
+		baseRes.FailWithMessage(err.Error(), ctx)
+		return
+	}
+
+	baseRes.OkWithMessage("删除身份提供方成功", ctx)
+}
+
+// GetLoginProviders 获取登录页可用的身份提供方
+//
+//	@Summary		获取单点登录方式
+//	@Description	获取已启用的 OIDC 身份提供方，用于登录页展示
+//	@Tags			认证服务
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	baseRes.Response{data=[]response.OIDCLoginProviderResponse,msg=string}	"身份提供方列表"
+//	@Router			/api/admin/auth/oidc/providers [get]
+func (c *OIDCController) GetLoginProviders(ctx *gin.Context) {
+	providers, err := c.service.GetEnabledProviders()
+	if err != nil {
+		c.log.Error("获取单点登录方式失败", "error", err)
+		baseRes.FailWithMessage("获取单点登录方式失败", ctx)
+		return
+	}
+
+	responses := make([]response.OIDCLoginProviderResponse, 0, len(providers))
+	for _, provider := range providers {
+		responses = append(responses, response.OIDCLoginProviderResponse{
+			Code: provider.Code,
+			Name: provider.Name,
+		})
+	}
+
+	baseRes.OkWithDetailed(responses, "获取单点登录方式成功", ctx)
+}
+
+// Authorize 发起单点登录
+//
+//	@Summary		发起单点登录
+//	@Description	生成 state、nonce 和 PKCE 参数，返回身份提供方授权地址，前端跳转后在回调页调用回调接口
+//	@Tags			认证服务
+//	@Accept			json
+//	@Produce		json
+//	@Param			code	path		string																true	"身份提供方编码"
+//	@Success		200		{object}	baseRes.Response{data=response.OIDCAuthorizeResponse,msg=string}	"授权地址"
+//	@Failure		400		{object}	map[string]string													"请求参数错误"
+//	@Router			/api/admin/auth/oidc/{code}/authorize [get]
+func (c *OIDCController) Authorize(ctx *gin.Context) {
+	authorizationURL, err := c.service.BeginLogin(ctx.Param("code"))
+	if err != nil {
+		baseRes.FailWithMessage(err.Error(), ctx)
+		return
+	}
+
+	baseRes.OkWithDetailed(response.OIDCAuthorizeResponse{AuthorizationURL: authorizationURL}, "获取授权地址成功", ctx)
+}
+
+// Callback 单点登录回调
+//
+//	@Summary		单点登录回调
+//	@Description	使用授权码换取并校验 ID Token，关联本地用户后签发与账号密码登录相同的令牌
+//	@Tags			认证服务
+//	@Accept			json
+//	@Produce		json
+//	@Param			code	path		string														true	"身份提供方编码"
+//	@Param			data	body		request.OIDCCallbackRequest									true	"回调参数"
+//	@Success		200		{object}	baseRes.Response{data=response.LoginResponse,msg=string}	"登录成功"
+//	@Failure		400		{object}	map[string]string											"请求参数错误"
+//	@Router			/api/admin/auth/oidc/{code}/callback [post]
+func (c *OIDCController) Callback(ctx *gin.Context) {
+	var req request.OIDCCallbackRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		baseRes.FailWithMessage("请求参数错误", ctx)
+		return
+	}
+
+	user, accessToken, refreshToken, _, _, err := c.service.HandleCallback(ctx.Param("code"), req.Code, req.State, ctx.ClientIP(), ctx.Request.UserAgent())
+	if err != nil {
+		failLogin(err, ctx)
+		return
+	}
+	if user == nil {
+		baseRes.FailWithMessage("用户未分配角色，请联系管理员", ctx)
+		return
+	}
+
+	baseRes.OkWithDetailed(buildLoginResponse(c.userService, c.log, user, accessToken, refreshToken), "登录成功", ctx)
+}
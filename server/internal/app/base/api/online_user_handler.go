@@ -1,7 +1,10 @@
 package baseapi
 
 import (
+	"io"
+	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/ix-pay/ixpay-pro/internal/domain/base/entity"
@@ -9,6 +12,7 @@ import (
 	"github.com/ix-pay/ixpay-pro/internal/dto/base/request"
 	"github.com/ix-pay/ixpay-pro/internal/dto/base/response"
 	"github.com/ix-pay/ixpay-pro/internal/infrastructure/observability/logger"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/transport/push"
 	"github.com/ix-pay/ixpay-pro/internal/utils/common/baseRes"
 )
 
@@ -16,13 +20,18 @@ import (
 // 处理在线用户相关的 HTTP 请求
 type OnlineUserController struct {
 	service *service.OnlineUserService
+	hub     *push.Hub
 	log     logger.Logger
 }
 
+// sessionEventHeartbeat 会话事件流心跳间隔，防止代理因空闲断开连接
+const sessionEventHeartbeat = 30 * time.Second
+
 // NewOnlineUserController 创建在线用户控制器实例
-func NewOnlineUserController(service *service.OnlineUserService, log logger.Logger) *OnlineUserController {
+func NewOnlineUserController(service *service.OnlineUserService, hub *push.Hub, log logger.Logger) *OnlineUserController {
 	return &OnlineUserController{
 		service: service,
+		hub:     hub,
 		log:     log,
 	}
 }
@@ -44,7 +53,7 @@ func NewOnlineUserController(service *service.OnlineUserService, log logger.Logg
 //	@Router			/api/admin/online-user [get]
 func (c *OnlineUserController) GetOnlineUserList(ctx *gin.Context) {
 	// 检查用户是否已登录
-	_, exists := ctx.Get("userID")
+	exists := isAuthenticated(ctx)
 	if !exists {
 		c.log.Error("未登录")
 		baseRes.NoAuth("未登录", ctx)
@@ -85,20 +94,7 @@ func (c *OnlineUserController) GetOnlineUserList(ctx *gin.Context) {
 	// 转换为响应 DTO
 	userResponses := make([]response.OnlineUserResponse, len(pageUsers))
 	for i, user := range pageUsers {
-		userResponses[i] = response.OnlineUserResponse{
-			UserID:       strconv.FormatInt(user.UserID, 10),
-			Username:     user.Username,
-			Nickname:     user.Nickname,
-			SessionID:    user.SessionID,
-			LoginIP:      user.LoginIP,
-			LoginPlace:   user.LoginPlace,
-			LoginTime:    user.LoginTime.Format("2006-01-02 15:04:05"),
-			LastActiveAt: user.LastActiveAt.Format("2006-01-02 15:04:05"),
-			Device:       user.Device,
-			Browser:      user.Browser,
-			OS:           user.OS,
-			UserAgent:    user.UserAgent,
-		}
+		userResponses[i] = toOnlineUserResponse(user, "")
 	}
 
 	userListResponse := response.OnlineUserListResponse{
@@ -117,13 +113,13 @@ func (c *OnlineUserController) GetOnlineUserList(ctx *gin.Context) {
 // GetOnlineUserByID 获取在线用户详情
 //
 //	@Summary		获取在线用户详情
-//	@Description	根据用户 ID 获取在线用户详细信息
+//	@Description	根据用户 ID 获取在线用户详细信息，返回该用户全部登录设备的会话
 //	@Tags			在线用户管理
 //	@Accept			json
 //	@Produce		json
 //	@Security		BearerAuth
 //	@Param			user_id	path		string																true	"用户 ID"
-//	@Success		200		{object}	baseRes.Response{data=response.OnlineUserResponse,msg=string}		"在线用户详情"
+//	@Success		200		{object}	baseRes.Response{data=[]response.OnlineUserResponse,msg=string}	"在线用户详情"
 //	@Failure		400		{object}	map[string]string													"请求参数错误"
 //	@Failure		401		{object}	map[string]string													"未授权"
 //	@Failure		404		{object}	map[string]string													"用户不在线"
@@ -131,7 +127,7 @@ func (c *OnlineUserController) GetOnlineUserList(ctx *gin.Context) {
 //	@Router			/api/admin/online-user/:user_id [get]
 func (c *OnlineUserController) GetOnlineUserByID(ctx *gin.Context) {
 	// 检查用户是否已登录
-	_, exists := ctx.Get("userID")
+	exists := isAuthenticated(ctx)
 	if !exists {
 		c.log.Error("未登录")
 		baseRes.NoAuth("未登录", ctx)
@@ -153,26 +149,16 @@ func (c *OnlineUserController) GetOnlineUserByID(ctx *gin.Context) {
 		return
 	}
 
-	user, err := c.service.GetOnlineUserByID(userID)
+	sessions, err := c.service.GetOnlineUserByID(userID)
 	if err != nil {
 		baseRes.FailWithMessage(err.Error(), ctx)
 		return
 	}
 
-	// 转换为响应 DTO
-	userResponse := response.OnlineUserResponse{
-		UserID:       strconv.FormatInt(user.UserID, 10),
-		Username:     user.Username,
-		Nickname:     user.Nickname,
-		SessionID:    user.SessionID,
-		LoginIP:      user.LoginIP,
-		LoginPlace:   user.LoginPlace,
-		LoginTime:    user.LoginTime.Format("2006-01-02 15:04:05"),
-		LastActiveAt: user.LastActiveAt.Format("2006-01-02 15:04:05"),
-		Device:       user.Device,
-		Browser:      user.Browser,
-		OS:           user.OS,
-		UserAgent:    user.UserAgent,
+	// 转换为响应 DTO，每个登录设备一条
+	userResponse := make([]response.OnlineUserResponse, len(sessions))
+	for i, session := range sessions {
+		userResponse[i] = toOnlineUserResponse(session, "")
 	}
 
 	baseRes.OkWithDetailed(userResponse, "获取在线用户详情成功", ctx)
@@ -181,13 +167,13 @@ func (c *OnlineUserController) GetOnlineUserByID(ctx *gin.Context) {
 // ForceOffline 强制用户下线
 //
 //	@Summary		强制用户下线
-//	@Description	强制指定用户下线（管理员权限）
+//	@Description	强制指定用户下线（管理员权限），用户全部会话的令牌立即失效，并记录审计
 //	@Tags			在线用户管理
 //	@Accept			json
 //	@Produce		json
 //	@Security		BearerAuth
-//	@Param			user_id	body		int64							true	"用户 ID"
-//	@Param			reason	body		string						false	"下线原因"
+//	@Param			user_id	path		string							true	"用户 ID"
+//	@Param			reason	query		string							false	"下线原因"
 //	@Success		200		{object}	baseRes.Response{msg=string}	"强制下线成功"
 //	@Failure		400		{object}	map[string]string			"请求参数错误"
 //	@Failure		401		{object}	map[string]string			"未授权"
@@ -196,7 +182,7 @@ func (c *OnlineUserController) GetOnlineUserByID(ctx *gin.Context) {
 //	@Router			/api/admin/online-user/:user_id [delete]
 func (c *OnlineUserController) ForceOffline(ctx *gin.Context) {
 	// 检查用户是否已登录
-	operatorID, exists := ctx.Get("userID")
+	operatorID, exists := getOperator(ctx)
 	if !exists {
 		c.log.Error("未登录")
 		baseRes.NoAuth("未登录", ctx)
@@ -230,7 +216,7 @@ func (c *OnlineUserController) ForceOffline(ctx *gin.Context) {
 	}
 
 	// 强制用户下线
-	if err := c.service.ForceOffline(userID, strconv.FormatInt(operatorIDInt, 10)); err != nil {
+	if err := c.service.KickoutUser(userID, ctx.Query("reason"), operatorIDInt); err != nil {
 		baseRes.FailWithMessage(err.Error(), ctx)
 		return
 	}
@@ -253,7 +239,7 @@ func (c *OnlineUserController) ForceOffline(ctx *gin.Context) {
 //	@Router			/api/admin/online-user/count [get]
 func (c *OnlineUserController) GetOnlineCount(ctx *gin.Context) {
 	// 检查用户是否已登录
-	_, exists := ctx.Get("userID")
+	exists := isAuthenticated(ctx)
 	if !exists {
 		c.log.Error("未登录")
 		baseRes.NoAuth("未登录", ctx)
@@ -285,7 +271,7 @@ func (c *OnlineUserController) GetOnlineCount(ctx *gin.Context) {
 //	@Router			/api/admin/online-user/online [get]
 func (c *OnlineUserController) IsOnline(ctx *gin.Context) {
 	// 检查用户是否已登录
-	_, exists := ctx.Get("userID")
+	exists := isAuthenticated(ctx)
 	if !exists {
 		c.log.Error("未登录")
 		baseRes.NoAuth("未登录", ctx)
@@ -311,7 +297,7 @@ func (c *OnlineUserController) IsOnline(ctx *gin.Context) {
 // BatchForceOffline 批量强制用户下线
 //
 //	@Summary		批量强制用户下线
-//	@Description	批量强制指定用户下线（管理员权限）
+//	@Description	批量强制指定用户下线（管理员权限），任一用户下线失败时全部不下线
 //	@Tags			在线用户管理
 //	@Accept			json
 //	@Produce		json
@@ -324,7 +310,7 @@ func (c *OnlineUserController) IsOnline(ctx *gin.Context) {
 //	@Router			/api/admin/online-user/batch [post]
 func (c *OnlineUserController) BatchForceOffline(ctx *gin.Context) {
 	// 检查用户是否已登录
-	operatorID, exists := ctx.Get("userID")
+	operatorID, exists := getOperator(ctx)
 	if !exists {
 		c.log.Error("未登录")
 		baseRes.NoAuth("未登录", ctx)
@@ -368,7 +354,7 @@ func (c *OnlineUserController) BatchForceOffline(ctx *gin.Context) {
 	}
 
 	// 批量强制用户下线
-	if err := c.service.BatchKickoutUsers(userIDs, req.Reason, strconv.FormatInt(operatorIDInt, 10)); err != nil {
+	if err := c.service.BatchKickoutUsers(userIDs, req.Reason, operatorIDInt); err != nil {
 		baseRes.FailWithMessage(err.Error(), ctx)
 		return
 	}
@@ -376,3 +362,233 @@ func (c *OnlineUserController) BatchForceOffline(ctx *gin.Context) {
 	c.log.Info("批量强制用户下线成功", "count", len(userIDs), "operator_id", operatorIDInt)
 	baseRes.OkWithMessage("批量强制下线成功", ctx)
 }
+
+// GetMySessions 获取当前用户的登录设备
+//
+//	@Summary		获取当前用户的登录设备
+//	@Description	获取当前用户所有未过期的登录会话，current 标记当前请求使用的会话
+//	@Tags			在线用户管理
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	baseRes.Response{data=[]response.OnlineUserResponse,msg=string}	"登录设备列表"
+//	@Failure		401	{object}	map[string]string												"未授权"
+//	@Failure		500	{object}	map[string]string												"服务器内部错误"
+//	@Router			/api/admin/user/sessions [get]
+func (c *OnlineUserController) GetMySessions(ctx *gin.Context) {
+	userID, err := getCurrentUserID(ctx)
+	if err != nil {
+		baseRes.NoAuth(err.Error(), ctx)
+		return
+	}
+
+	sessions, err := c.service.GetUserSessions(userID)
+	if err != nil {
+		baseRes.FailWithMessage("获取登录设备失败", ctx)
+		return
+	}
+
+	currentSessionID := ctx.GetString("sessionID")
+	sessionResponses := make([]response.OnlineUserResponse, len(sessions))
+	for i, session := range sessions {
+		sessionResponses[i] = toOnlineUserResponse(session, currentSessionID)
+	}
+
+	baseRes.OkWithDetailed(sessionResponses, "获取登录设备成功", ctx)
+}
+
+// RevokeMySession 下线当前用户的某个登录设备
+//
+//	@Summary		下线当前用户的登录设备
+//	@Description	下线当前用户的指定登录会话，该设备的令牌立即失效；下线当前会话等同于退出登录
+//	@Tags			在线用户管理
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			data	body		request.RevokeSessionRequest	true	"下线设备请求"
+//	@Success		200		{object}	baseRes.Response{msg=string}	"下线成功"
+//	@Failure		400		{object}	map[string]string				"请求参数错误"
+//	@Failure		401		{object}	map[string]string				"未授权"
+//	@Failure		500		{object}	map[string]string				"服务器内部错误"
+//	@Router			/api/admin/user/sessions/revoke [post]
+func (c *OnlineUserController) RevokeMySession(ctx *gin.Context) {
+	userID, err := getCurrentUserID(ctx)
+	if err != nil {
+		baseRes.NoAuth(err.Error(), ctx)
+		return
+	}
+
+	var req request.RevokeSessionRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		c.log.Error("请求参数错误", "error", err)
+		baseRes.FailWithMessage("请求参数错误", ctx)
+		return
+	}
+
+	if err := c.service.RevokeUserSession(userID, req.SessionID); err != nil {
+		baseRes.FailWithMessage(err.Error(), ctx)
+		return
+	}
+
+	baseRes.OkWithMessage("下线成功", ctx)
+}
+
+// GetSessionAuditLogs 获取会话终止审计记录
+//
+//	@Summary		获取会话终止审计记录
+//	@Description	分页获取强制下线、下线登录设备等会话终止记录，包含操作人、原因和时间
+//	@Tags			在线用户管理
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			page		query		int																	true	"页码"
+//	@Param			pageSize	query		int																	true	"每页数量"
+//	@Param			userId		query		int64																false	"用户 ID"
+//	@Param			userName	query		string																false	"用户名"
+//	@Param			action		query		string																false	"终止方式：kickout、revoke、kick_oldest"
+//	@Success		200			{object}	baseRes.Response{data=baseRes.PageResult,msg=string}				"审计记录列表"
+//	@Failure		400			{object}	map[string]string													"请求参数错误"
+//	@Failure		401			{object}	map[string]string													"未授权"
+//	@Failure		500			{object}	map[string]string													"服务器内部错误"
+//	@Router			/api/admin/online-user/audit-logs [get]
+func (c *OnlineUserController) GetSessionAuditLogs(ctx *gin.Context) {
+	var req request.GetSessionAuditLogListRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		c.log.Error("请求参数错误", "error", err)
+		baseRes.FailWithMessage("请求参数错误", ctx)
+		return
+	}
+
+	// 构建筛选条件
+	filters := make(map[string]interface{})
+	if req.UserID != nil {
+		filters["user_id"] = *req.UserID
+	}
+	if req.Username != "" {
+		filters["userName"] = req.Username
+	}
+	if req.Action != "" {
+		filters["action"] = req.Action
+	}
+
+	logs, total, err := c.service.GetSessionAuditLogs(req.Page, req.PageSize, filters)
+	if err != nil {
+		baseRes.FailWithMessage(err.Error(), ctx)
+		return
+	}
+
+	list := make([]response.SessionAuditLogResponse, len(logs))
+	for i, item := range logs {
+		list[i] = response.SessionAuditLogResponse{
+			ID:         strconv.FormatInt(item.ID, 10),
+			UserID:     strconv.FormatInt(item.UserID, 10),
+			Username:   item.Username,
+			SessionID:  item.SessionID,
+			LoginIP:    item.LoginIP,
+			Device:     item.Device,
+			Action:     item.Action,
+			Reason:     item.Reason,
+			OperatorID: strconv.FormatInt(item.OperatorID, 10),
+			CreatedAt:  item.CreatedAt.Format("2006-01-02 15:04:05"),
+		}
+	}
+
+	pageResult := baseRes.PageResult{
+		List:     list,
+		Total:    total,
+		Page:     req.Page,
+		PageSize: req.PageSize,
+	}
+
+	baseRes.OkWithDetailed(pageResult, "获取会话审计记录成功", ctx)
+}
+
+// SessionEvents 订阅当前会话的实时事件
+//
+//	@Summary		订阅当前会话的实时事件
+//	@Description	以 Server-Sent Events 推送当前登录会话的事件；会话被下线时推送 session_revoked 事件后关闭连接，客户端应清除令牌并跳转登录页
+//	@Tags			在线用户管理
+//	@Produce		text/event-stream
+//	@Security		BearerAuth
+//	@Success		200	{object}	push.Event			"会话事件"
+//	@Failure		401	{object}	map[string]string	"未授权"
+//	@Router			/api/admin/user/session-events [get]
+func (c *OnlineUserController) SessionEvents(ctx *gin.Context) {
+	sessionID := ctx.GetString("sessionID")
+	if sessionID == "" {
+		// API Key 等不带会话的凭证无法订阅
+		baseRes.FailWithMessage("当前凭证没有登录会话", ctx)
+		return
+	}
+
+	events, unsubscribe := c.hub.Subscribe(sessionID)
+	defer unsubscribe()
+
+	ctx.Header("Content-Type", "text/event-stream")
+	ctx.Header("Cache-Control", "no-cache")
+	ctx.Header("Connection", "keep-alive")
+	ctx.Header("X-Accel-Buffering", "no")
+
+	// 事件流是长连接，取消服务器的写超时
+	if err := http.NewResponseController(ctx.Writer).SetWriteDeadline(time.Time{}); err != nil {
+		c.log.Warn("取消事件流写超时失败", "error", err)
+	}
+
+	// 订阅前会话可能已被下线，此时直接推送下线事件
+	if active, err := c.service.TouchSession(sessionID); err == nil && !active {
+		ctx.SSEvent(push.EventSessionRevoked, push.Event{
+			Type:      push.EventSessionRevoked,
+			SessionID: sessionID,
+			Message:   "登录会话已失效，请重新登录",
+			Time:      time.Now(),
+		})
+		return
+	}
+
+	heartbeat := time.NewTicker(sessionEventHeartbeat)
+	defer heartbeat.Stop()
+
+	ctx.Stream(func(w io.Writer) bool {
+		select {
+		case <-ctx.Request.Context().Done():
+			return false
+		case event := <-events:
+			ctx.SSEvent(event.Type, event)
+			// 会话已终止，推送后关闭连接
+			return event.Type != push.EventSessionRevoked
+		case <-heartbeat.C:
+			_, err := io.WriteString(w, ": heartbeat\n\n")
+			return err == nil
+		}
+	})
+}
+
+// toOnlineUserResponse 将会话转换为响应 DTO
+// currentSessionID 为当前请求使用的会话 ID，用于标记当前设备
+func toOnlineUserResponse(user *entity.OnlineUser, currentSessionID string) response.OnlineUserResponse {
+	return response.OnlineUserResponse{
+		UserID:       strconv.FormatInt(user.UserID, 10),
+		Username:     user.Username,
+		Nickname:     user.Nickname,
+		SessionID:    user.SessionID,
+		LoginType:    user.LoginType,
+		LoginIP:      user.LoginIP,
+		LoginPlace:   user.LoginPlace,
+		LoginTime:    user.LoginTime.Format("2006-01-02 15:04:05"),
+		LastActiveAt: user.LastActiveAt.Format("2006-01-02 15:04:05"),
+		ExpiresAt:    formatSessionExpiresAt(user.ExpiresAt),
+		Device:       user.Device,
+		Browser:      user.Browser,
+		OS:           user.OS,
+		UserAgent:    user.UserAgent,
+		Current:      currentSessionID != "" && user.SessionID == currentSessionID,
+	}
+}
+
+// formatSessionExpiresAt 格式化会话过期时间，未设置时返回空字符串
+func formatSessionExpiresAt(expiresAt time.Time) string {
+	if expiresAt.IsZero() {
+		return ""
+	}
+	return expiresAt.Format("2006-01-02 15:04:05")
+}
@@ -18,20 +18,22 @@ import (
 //	@Tags			系统管理
 //	@Router			/api/admin/logs [get]
 type OperationLogController struct {
-	service *service.OperationLogService
+	service     *service.OperationLogService
+	userService *service.UserService
 }
 
 // NewOperationLogController 创建操作日志控制器实例
-func NewOperationLogController(service *service.OperationLogService) *OperationLogController {
+func NewOperationLogController(service *service.OperationLogService, userService *service.UserService) *OperationLogController {
 	return &OperationLogController{
-		service: service,
+		service:     service,
+		userService: userService,
 	}
 }
 
 // GetLogList 获取操作日志列表
 //
 //	@Summary		获取操作日志列表
-//	@Description	获取系统操作日志列表，支持分页和多条件过滤
+//	@Description	获取系统操作日志列表，支持分页和多条件过滤，按当前角色的数据权限过滤
 //	@Tags			系统管理
 //	@Accept			json
 //	@Produce		json
@@ -77,7 +79,7 @@ func (c *OperationLogController) GetLogList(ctx *gin.Context) {
 	}
 
 	// 获取日志列表
-	logs, total, err := c.service.GetLogList(req.Page, req.PageSize, filters)
+	logs, total, err := c.service.GetLogList(req.Page, req.PageSize, withDataScope(ctx, filters))
 	if err != nil {
 		baseRes.FailWithMessage("获取日志列表失败", ctx)
 		return
@@ -125,7 +127,7 @@ func (c *OperationLogController) GetLogList(ctx *gin.Context) {
 // GetLogByID 根据 ID 获取操作日志
 //
 //	@Summary		根据 ID 获取操作日志
-//	@Description	根据日志 ID 获取详细的操作日志信息
+//	@Description	根据日志 ID 获取详细的操作日志信息，数据权限范围外的日志按不存在处理
 //	@Tags			系统管理
 //	@Accept			json
 //	@Produce		json
@@ -158,7 +160,8 @@ func (c *OperationLogController) GetLogByID(ctx *gin.Context) {
 		return
 	}
 
-	if log == nil {
+	// 数据权限范围外的日志按不存在处理，不暴露日志是否存在
+	if log == nil || !canAccessUserData(ctx, c.userService, log.UserID) {
 		baseRes.FailWithMessage("日志不存在", ctx)
 		return
 	}
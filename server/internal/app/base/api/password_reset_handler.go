@@ -0,0 +1,80 @@
+package baseapi
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/service"
+	"github.com/ix-pay/ixpay-pro/internal/dto/base/request"
+	"github.com/ix-pay/ixpay-pro/internal/dto/base/response"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/observability/logger"
+	"github.com/ix-pay/ixpay-pro/internal/utils/common/baseRes"
+)
+
+// PasswordResetController 自助找回密码控制器
+// 处理发送找回密码验证码和校验验证码设置新密码的请求
+type PasswordResetController struct {
+	service *service.PasswordResetService
+	log     logger.Logger
+}
+
+// NewPasswordResetController 创建找回密码控制器实例
+func NewPasswordResetController(service *service.PasswordResetService, log logger.Logger) *PasswordResetController {
+	return &PasswordResetController{
+		service: service,
+		log:     log,
+	}
+}
+
+// SendCode 发送找回密码验证码
+//
+//	@Summary		发送找回密码验证码
+//	@Description	根据用户名、邮箱或手机号向绑定的邮箱/手机发送找回密码验证码
+//	@Tags			认证服务
+//	@Accept			json
+//	@Produce		json
+//	@Param			forgot	body		request.ForgotPasswordRequest										true	"找回密码请求参数"
+//	@Success		200		{object}	baseRes.Response{data=response.ForgotPasswordResponse,msg=string}	"发送成功"
+//	@Failure		400		{object}	map[string]string													"请求参数错误"
+//	@Router			/api/admin/auth/password/forgot [post]
+func (c *PasswordResetController) SendCode(ctx *gin.Context) {
+	var req request.ForgotPasswordRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		c.log.Error("请求参数错误", "error", err)
+		baseRes.FailWithMessage("请求参数错误", ctx)
+		return
+	}
+
+	target, err := c.service.SendCode(req.Account, req.Channel, req.CaptchaId, req.Captcha, ctx.ClientIP())
+	if err != nil {
+		baseRes.FailWithMessage(err.Error(), ctx)
+		return
+	}
+
+	baseRes.OkWithDetailed(response.ForgotPasswordResponse{Target: target}, "验证码已发送", ctx)
+}
+
+// ResetPassword 校验验证码并设置新密码
+//
+//	@Summary		找回密码
+//	@Description	校验找回密码验证码并设置新密码，成功后已登录的会话需要重新登录
+//	@Tags			认证服务
+//	@Accept			json
+//	@Produce		json
+//	@Param			reset	body		request.ForgotPasswordResetRequest	true	"设置新密码请求参数"
+//	@Success		200		{object}	baseRes.Response{msg=string}		"密码重置成功"
+//	@Failure		400		{object}	map[string]string					"请求参数错误"
+//	@Router			/api/admin/auth/password/reset [post]
+func (c *PasswordResetController) ResetPassword(ctx *gin.Context) {
+	var req request.ForgotPasswordResetRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		c.log.Error("请求参数错误", "error", err)
+		baseRes.FailWithMessage("请求参数错误", ctx)
+		return
+	}
+
+	if err := c.service.ResetPassword(req.Account, req.Code, req.NewPassword); err != nil {
+		baseRes.FailWithMessage(err.Error(), ctx)
+		return
+	}
+
+	baseRes.OkWithMessage("密码重置成功，请使用新密码登录", ctx)
+}
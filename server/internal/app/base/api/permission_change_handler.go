@@ -0,0 +1,223 @@
+package baseapi
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/entity"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/service"
+	"github.com/ix-pay/ixpay-pro/internal/dto/base/request"
+	"github.com/ix-pay/ixpay-pro/internal/dto/base/response"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/observability/logger"
+	"github.com/ix-pay/ixpay-pro/internal/utils/common/baseRes"
+)
+
+// PermissionChangeController 权限变更审批控制器
+// 处理需要审批的角色产生的权限变更申请的查询、通过和拒绝
+type PermissionChangeController struct {
+	service *service.PermissionChangeService // 权限变更审批服务
+	log     logger.Logger                    // 日志记录器
+}
+
+// NewPermissionChangeController 创建权限变更审批控制器实例
+func NewPermissionChangeController(service *service.PermissionChangeService, log logger.Logger) *PermissionChangeController {
+	return &PermissionChangeController{
+		service: service,
+		log:     log,
+	}
+}
+
+// convertToPermissionChangeResponse 将 entity.PermissionChangeRequest 转换为 response.PermissionChangeResponse
+func convertToPermissionChangeResponse(change *entity.PermissionChangeRequest) response.PermissionChangeResponse {
+	resp := response.PermissionChangeResponse{
+		ID:            change.ID,
+		RoleID:        change.RoleID,
+		ChangeType:    change.ChangeType,
+		BeforeIds:     convertInt64SliceToStringSlice(change.BeforeIDs),
+		AddedIds:      convertInt64SliceToStringSlice(change.AddedIDs),
+		RemovedIds:    convertInt64SliceToStringSlice(change.RemovedIDs),
+		AfterIds:      convertInt64SliceToStringSlice(change.AfterIDs()),
+		Payload:       change.Payload,
+		Status:        change.Status,
+		RequestedBy:   change.RequestedBy,
+		ReviewedBy:    change.ReviewedBy,
+		ReviewComment: change.ReviewComment,
+		CreatedAt:     change.CreatedAt.Format(time.RFC3339),
+	}
+	if change.ReviewedAt != nil {
+		resp.ReviewedAt = change.ReviewedAt.Format(time.RFC3339)
+	}
+	return resp
+}
+
+// respondPendingApproval 变更已提交审批时返回申请内容，不是待审批错误时返回 false 由调用方继续处理
+func respondPendingApproval(err error, ctx *gin.Context) bool {
+	var pending *service.PendingApprovalError
+	if !errors.As(err, &pending) {
+		return false
+	}
+	baseRes.OkWithDetailed(convertToPermissionChangeResponse(pending.Request), pending.Error(), ctx)
+	return true
+}
+
+// GetPermissionChangeList 获取权限变更申请列表
+//
+//	@Summary		获取权限变更申请列表
+//	@Description	分页获取需要审批的角色产生的权限变更申请，可按角色、变更类型和状态筛选
+//	@Tags			权限变更审批
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			page		query		int																		true	"页码"
+//	@Param			pageSize	query		int																		true	"每页数量"
+//	@Param			roleId		query		string																	false	"角色 ID"
+//	@Param			changeType	query		string																	false	"变更类型 (role_users、role_menus、role_apis、role_btn_perms、approval_required、policy_bundle)"
+//	@Param			status		query		string																	false	"状态 (pending、approved、rejected)"
+//	@Success		200			{object}	baseRes.Response{data=response.PermissionChangeListResponse,msg=string}	"申请列表"
+//	@Failure		400			{object}	map[string]string														"请求参数错误"
+//	@Failure		401			{object}	map[string]string														"未授权"
+//	@Router			/api/admin/permission-changes [get]
+func (c *PermissionChangeController) GetPermissionChangeList(ctx *gin.Context) {
+	var req request.GetPermissionChangeListRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		c.log.Error("请求参数错误", "error", err)
+		baseRes.FailWithMessage("请求参数错误", ctx)
+		return
+	}
+
+	filters := make(map[string]interface{})
+	if req.RoleID != "" {
+		roleID, err := strconv.ParseInt(req.RoleID, 10, 64)
+		if err != nil {
+			baseRes.FailWithMessage("无效的角色 ID 格式", ctx)
+			return
+		}
+		filters["role_id"] = roleID
+	}
+	if req.ChangeType != "" {
+		filters["change_type"] = req.ChangeType
+	}
+	if req.Status != "" {
+		filters["status"] = req.Status
+	}
+
+	changes, total, err := c.service.GetChangeRequestList(req.Page, req.PageSize, filters)
+	if err != nil {
+		c.log.Error("获取权限变更申请列表失败", "error", err)
+		baseRes.FailWithMessage("获取权限变更申请列表失败", ctx)
+		return
+	}
+
+	responses := make([]response.PermissionChangeResponse, 0, len(changes))
+	for _, change := range changes {
+		responses = append(responses, convertToPermissionChangeResponse(change))
+	}
+
+	baseRes.OkWithDetailed(response.PermissionChangeListResponse{
+		PageResult: baseRes.PageResult{
+			List:     responses,
+			Total:    total,
+			Page:     req.Page,
+			PageSize: req.PageSize,
+		},
+		List: responses,
+	}, "获取权限变更申请列表成功", ctx)
+}
+
+// GetPermissionChange 获取权限变更申请详情
+//
+//	@Summary		获取权限变更申请详情
+//	@Description	获取变更申请的完整差异：提交时的关联、新增、移除以及生效后的关联
+//	@Tags			权限变更审批
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string																	true	"申请 ID"
+//	@Success		200	{object}	baseRes.Response{data=response.PermissionChangeResponse,msg=string}	"申请详情"
+//	@Failure		400	{object}	map[string]string														"请求参数错误"
+//	@Failure		401	{object}	map[string]string														"未授权"
+//	@Router			/api/admin/permission-changes/{id} [get]
+func (c *PermissionChangeController) GetPermissionChange(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		baseRes.FailWithMessage("无效的 ID 格式", ctx)
+		return
+	}
+
+	change, err := c.service.GetChangeRequest(id)
+	if err != nil {
+		baseRes.FailWithMessage(err.Error(), ctx)
+		return
+	}
+
+	baseRes.OkWithDetailed(convertToPermissionChangeResponse(change), "获取权限变更申请成功", ctx)
+}
+
+// ApprovePermissionChange 通过权限变更申请
+//
+//	@Summary		通过权限变更申请
+//	@Description	审批通过后变更立即生效并写入权限审计日志；申请人不能审批自己提交的申请，审批人须有该接口的权限；变更和申请状态在同一事务中写入
+//	@Tags			权限变更审批
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		string																	true	"申请 ID"
+//	@Param			data	body		request.ReviewPermissionChangeRequest									false	"审批意见"
+//	@Success		200		{object}	baseRes.Response{data=response.PermissionChangeResponse,msg=string}	"审批结果"
+//	@Failure		400		{object}	map[string]string														"请求参数错误"
+//	@Failure		401		{object}	map[string]string														"未授权"
+//	@Router			/api/admin/permission-changes/{id}/approve [post]
+func (c *PermissionChangeController) ApprovePermissionChange(ctx *gin.Context) {
+	c.review(ctx, c.service.ApproveChange, "权限变更申请已通过")
+}
+
+// RejectPermissionChange 拒绝权限变更申请
+//
+//	@Summary		拒绝权限变更申请
+//	@Description	拒绝后变更不会生效；申请人不能审批自己提交的申请，审批人须有该接口的权限
+//	@Tags			权限变更审批
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		string																	true	"申请 ID"
+//	@Param			data	body		request.ReviewPermissionChangeRequest									false	"审批意见"
+//	@Success		200		{object}	baseRes.Response{data=response.PermissionChangeResponse,msg=string}	"审批结果"
+//	@Failure		400		{object}	map[string]string														"请求参数错误"
+//	@Failure		401		{object}	map[string]string														"未授权"
+//	@Router			/api/admin/permission-changes/{id}/reject [post]
+func (c *PermissionChangeController) RejectPermissionChange(ctx *gin.Context) {
+	c.review(ctx, c.service.RejectChange, "权限变更申请已拒绝")
+}
+
+// review 处理审批请求，请求体可以为空
+func (c *PermissionChangeController) review(ctx *gin.Context, action func(id, approverID int64, comment string) (*entity.PermissionChangeRequest, error), message string) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		baseRes.FailWithMessage("无效的 ID 格式", ctx)
+		return
+	}
+
+	var req request.ReviewPermissionChangeRequest
+	if ctx.Request.ContentLength > 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			baseRes.FailWithMessage("请求参数错误", ctx)
+			return
+		}
+	}
+
+	approverID, err := getCurrentUserID(ctx)
+	if err != nil {
+		baseRes.NoAuth(err.Error(), ctx)
+		return
+	}
+
+	change, err := action(id, approverID, req.Comment)
+	if err != nil {
+		baseRes.FailWithMessage(err.Error(), ctx)
+		return
+	}
+
+	baseRes.OkWithDetailed(convertToPermissionChangeResponse(change), message, ctx)
+}
@@ -0,0 +1,242 @@
+package baseapi
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/service"
+	"github.com/ix-pay/ixpay-pro/internal/dto/base/request"
+	"github.com/ix-pay/ixpay-pro/internal/dto/base/response"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/observability/logger"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/security/abac"
+	"github.com/ix-pay/ixpay-pro/internal/utils/common/baseRes"
+)
+
+// PermissionExplainController 权限解释控制器
+// 解释用户为何可以或不能访问某个 API 或按钮，并支持在保存前模拟权限变更的效果
+type PermissionExplainController struct {
+	service *service.PermissionExplainService // 权限解释服务
+	log     logger.Logger                     // 日志记录器
+}
+
+// NewPermissionExplainController 创建权限解释控制器实例
+func NewPermissionExplainController(service *service.PermissionExplainService, log logger.Logger) *PermissionExplainController {
+	return &PermissionExplainController{
+		service: service,
+		log:     log,
+	}
+}
+
+// ExplainPermission 解释权限判定过程
+//
+//	@Summary		解释权限判定过程
+//	@Description	按当前数据库中的权限配置重放访问判定，返回参与判定的角色（含继承角色）、角色和权限组的授权情况、用户特殊权限、ABAC 规则的条件求值过程以及最终结果
+//	@Description	填写 buttonCode 时解释按钮权限，否则解释 method + path 的 API 访问权限；返回的 cached 为当前缓存的判定结果，与 allowed 不一致说明缓存尚未失效
+//	@Tags			权限解释
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			data	body		request.ExplainPermissionRequest										true	"解释参数"
+//	@Success		200		{object}	baseRes.Response{data=response.PermissionExplanationResponse,msg=string}	"判定过程"
+//	@Failure		400		{object}	map[string]string														"请求参数错误"
+//	@Failure		401		{object}	map[string]string														"未授权"
+//	@Router			/api/admin/permissions/explain [post]
+func (c *PermissionExplainController) ExplainPermission(ctx *gin.Context) {
+	var req request.ExplainPermissionRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		baseRes.FailWithMessage("请求参数错误", ctx)
+		return
+	}
+
+	explainReq, err := toPermissionExplainRequest(req)
+	if err != nil {
+		baseRes.FailWithMessage(err.Error(), ctx)
+		return
+	}
+
+	explanation, err := c.service.Explain(explainReq)
+	if err != nil {
+		baseRes.FailWithMessage(err.Error(), ctx)
+		return
+	}
+
+	baseRes.OkWithDetailed(convertToPermissionExplanationResponse(explanation), "解释权限判定成功", ctx)
+}
+
+// SimulatePermission 模拟权限变更
+//
+//	@Summary		模拟权限变更
+//	@Description	按拟议的权限变更解释访问判定，变更不会保存：userRoleIds 替换用户的角色分配，roles 替换指定角色的父角色、状态、API、按钮权限或权限组，未填写的字段使用当前配置
+//	@Tags			权限解释
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			data	body		request.SimulatePermissionRequest										true	"模拟参数"
+//	@Success		200		{object}	baseRes.Response{data=response.PermissionExplanationResponse,msg=string}	"模拟判定过程"
+//	@Failure		400		{object}	map[string]string														"请求参数错误"
+//	@Failure		401		{object}	map[string]string														"未授权"
+//	@Router			/api/admin/permissions/simulate [post]
+func (c *PermissionExplainController) SimulatePermission(ctx *gin.Context) {
+	var req request.SimulatePermissionRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		baseRes.FailWithMessage("请求参数错误", ctx)
+		return
+	}
+
+	explainReq, err := toPermissionExplainRequest(req.ExplainPermissionRequest)
+	if err != nil {
+		baseRes.FailWithMessage(err.Error(), ctx)
+		return
+	}
+
+	simulation := &service.PermissionSimulation{Roles: make([]*service.RoleChange, 0, len(req.Roles))}
+	if simulation.UserRoleIDs, err = convertStringSliceToInt64Slice(req.UserRoleIDs); err != nil {
+		baseRes.FailWithMessage(err.Error(), ctx)
+		return
+	}
+	for _, roleReq := range req.Roles {
+		change, err := toRoleChange(roleReq)
+		if err != nil {
+			baseRes.FailWithMessage(err.Error(), ctx)
+			return
+		}
+		simulation.Roles = append(simulation.Roles, change)
+	}
+	explainReq.Simulation = simulation
+
+	explanation, err := c.service.Explain(explainReq)
+	if err != nil {
+		baseRes.FailWithMessage(err.Error(), ctx)
+		return
+	}
+
+	baseRes.OkWithDetailed(convertToPermissionExplanationResponse(explanation), "模拟权限变更成功", ctx)
+}
+
+// toPermissionExplainRequest 将请求参数转换为解释请求
+func toPermissionExplainRequest(req request.ExplainPermissionRequest) (service.PermissionExplainRequest, error) {
+	userID, err := strconv.ParseInt(req.UserID, 10, 64)
+	if err != nil {
+		return service.PermissionExplainRequest{}, fmt.Errorf("无效的用户 ID 格式：%s", req.UserID)
+	}
+	return service.PermissionExplainRequest{
+		UserID:     userID,
+		RoleCode:   req.RoleCode,
+		Method:     req.Method,
+		Path:       req.Path,
+		ButtonCode: req.ButtonCode,
+		Attributes: abac.Attributes(req.Attributes),
+	}, nil
+}
+
+// toRoleChange 将角色拟修改的配置转换为模拟变更
+func toRoleChange(req request.RoleChangeRequest) (*service.RoleChange, error) {
+	roleID, err := strconv.ParseInt(req.RoleID, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("无效的角色 ID 格式：%s", req.RoleID)
+	}
+	change := &service.RoleChange{RoleID: roleID, Status: req.Status}
+	if req.ParentID != nil {
+		parentID, err := strconv.ParseInt(*req.ParentID, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("无效的父角色 ID 格式：%s", *req.ParentID)
+		}
+		change.ParentID = &parentID
+	}
+	if change.APIIDs, err = convertStringSliceToInt64Slice(req.APIIDs); err != nil {
+		return nil, err
+	}
+	if change.BtnPermIDs, err = convertStringSliceToInt64Slice(req.BtnPermIDs); err != nil {
+		return nil, err
+	}
+	if change.PermissionGroupIDs, err = convertStringSliceToInt64Slice(req.PermissionGroupIDs); err != nil {
+		return nil, err
+	}
+	return change, nil
+}
+
+// convertToPermissionExplanationResponse 将 service.PermissionExplanation 转换为 response.PermissionExplanationResponse
+func convertToPermissionExplanationResponse(explanation *service.PermissionExplanation) response.PermissionExplanationResponse {
+	resp := response.PermissionExplanationResponse{
+		UserID:         explanation.UserID,
+		Username:       explanation.Username,
+		Method:         explanation.Method,
+		Path:           explanation.Path,
+		Route:          explanation.Route,
+		Registered:     explanation.Registered,
+		AuthType:       explanation.AuthType,
+		ButtonCode:     explanation.ButtonCode,
+		Simulated:      explanation.Simulated,
+		Roles:          make([]response.RoleTraceResponse, 0, len(explanation.Roles)),
+		SpecialGranted: explanation.SpecialGranted,
+		Rules:          make([]response.RuleTraceResponse, 0, len(explanation.Rules)),
+		Allowed:        explanation.Allowed,
+		Reason:         explanation.Reason,
+		RuleID:         explanation.RuleID,
+	}
+
+	for _, role := range explanation.Roles {
+		roleResp := response.RoleTraceResponse{
+			RoleID:        role.RoleID,
+			Code:          role.Code,
+			Name:          role.Name,
+			Status:        role.Status,
+			Source:        role.Source,
+			InheritedFrom: role.InheritedFrom,
+			Simulated:     role.Simulated,
+			Effective:     role.Effective,
+			Granted:       role.Granted,
+			Groups:        make([]response.GroupTraceResponse, 0, len(role.Groups)),
+		}
+		for _, group := range role.Groups {
+			roleResp.Groups = append(roleResp.Groups, response.GroupTraceResponse{GroupID: group.GroupID, Name: group.Name, Granted: group.Granted})
+		}
+		resp.Roles = append(resp.Roles, roleResp)
+	}
+
+	for _, rule := range explanation.Rules {
+		resp.Rules = append(resp.Rules, response.RuleTraceResponse{
+			RuleID:    rule.RuleID,
+			Name:      rule.Name,
+			Effect:    rule.Effect,
+			APIPath:   rule.APIPath,
+			Method:    rule.Method,
+			Sort:      rule.Sort,
+			Matched:   rule.Matched,
+			Error:     rule.Error,
+			Condition: convertToConditionTraceResponse(rule.Condition),
+		})
+	}
+
+	if explanation.Cached != nil {
+		resp.Cached = &response.PermissionDecisionResponse{
+			Allowed: explanation.Cached.Allowed,
+			Reason:  explanation.Cached.Reason,
+			Route:   explanation.Cached.Route,
+		}
+	}
+	return resp
+}
+
+// convertToConditionTraceResponse 将 abac.Trace 转换为 response.ConditionTraceResponse
+func convertToConditionTraceResponse(trace *abac.Trace) *response.ConditionTraceResponse {
+	if trace == nil {
+		return nil
+	}
+	resp := &response.ConditionTraceResponse{
+		Path:    trace.Path,
+		Kind:    trace.Kind,
+		Attr:    trace.Attr,
+		Op:      trace.Op,
+		Value:   trace.Value,
+		Ref:     trace.Ref,
+		Actual:  trace.Actual,
+		Present: trace.Present,
+		Result:  trace.Result,
+	}
+	for _, child := range trace.Children {
+		resp.Children = append(resp.Children, *convertToConditionTraceResponse(child))
+	}
+	return resp
+}
@@ -48,7 +48,7 @@ func NewPermissionLogController(service *service.PermissionLogService, log logge
 //	@Router			/api/admin/permission-logs [get]
 func (c *PermissionLogController) GetPermissionLogList(ctx *gin.Context) {
 	// 检查用户是否已登录
-	_, exists := ctx.Get("userID")
+	exists := isAuthenticated(ctx)
 	if !exists {
 		c.log.Error("未登录")
 		baseRes.NoAuth("未登录", ctx)
@@ -122,7 +122,7 @@ func (c *PermissionLogController) GetPermissionLogList(ctx *gin.Context) {
 //	@Router			/api/admin/roles/:roleId/permission-logs [get]
 func (c *PermissionLogController) GetRolePermissionLogs(ctx *gin.Context) {
 	// 检查用户是否已登录
-	_, exists := ctx.Get("userID")
+	exists := isAuthenticated(ctx)
 	if !exists {
 		c.log.Error("未登录")
 		baseRes.NoAuth("未登录", ctx)
@@ -0,0 +1,293 @@
+package baseapi
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/entity"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/service"
+	"github.com/ix-pay/ixpay-pro/internal/dto/base/request"
+	"github.com/ix-pay/ixpay-pro/internal/dto/base/response"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/observability/logger"
+	"github.com/ix-pay/ixpay-pro/internal/utils/common/baseRes"
+)
+
+// PermissionRuleController 权限规则控制器
+// 处理 ABAC 权限规则的管理请求
+type PermissionRuleController struct {
+	service *service.PermissionRuleService // 权限规则服务
+	log     logger.Logger                  // 日志记录器
+}
+
+// NewPermissionRuleController 创建权限规则控制器实例
+func NewPermissionRuleController(service *service.PermissionRuleService, log logger.Logger) *PermissionRuleController {
+	return &PermissionRuleController{
+		service: service,
+		log:     log,
+	}
+}
+
+// convertToPermissionRuleResponse 将 entity.PermissionRule 转换为 response.PermissionRuleResponse
+func convertToPermissionRuleResponse(rule *entity.PermissionRule) response.PermissionRuleResponse {
+	return response.PermissionRuleResponse{
+		ID:          rule.ID,
+		Name:        rule.Name,
+		Description: rule.Description,
+		Effect:      rule.Effect,
+		APIPath:     rule.APIPath,
+		Method:      rule.Method,
+		Conditions:  rule.Conditions,
+		Status:      rule.Status,
+		Sort:        rule.Sort,
+		IsSystem:    rule.IsSystem,
+		CreatedAt:   rule.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:   rule.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+// GetPermissionRuleList 获取权限规则列表
+//
+//	@Summary		获取权限规则列表
+//	@Description	分页获取 ABAC 权限规则列表，按排序升序
+//	@Tags			权限规则
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			page		query		int																		true	"页码"
+//	@Param			pageSize	query		int																		true	"每页数量"
+//	@Param			effect		query		string																	false	"效果 (allow、deny)"
+//	@Param			status		query		int																		false	"状态 (0:禁用，1:启用)"
+//	@Success		200			{object}	baseRes.Response{data=response.PermissionRuleListResponse,msg=string}	"规则列表"
+//	@Failure		400			{object}	map[string]string														"请求参数错误"
+//	@Failure		401			{object}	map[string]string														"未授权"
+//	@Router			/api/admin/permission-rules [get]
+func (c *PermissionRuleController) GetPermissionRuleList(ctx *gin.Context) {
+	var req request.GetPermissionRuleListRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		c.log.Error("请求参数错误", "error", err)
+		baseRes.FailWithMessage("请求参数错误", ctx)
+		return
+	}
+
+	filters := make(map[string]interface{})
+	if req.Effect != "" {
+		filters["effect"] = req.Effect
+	}
+	if req.Status != nil {
+		filters["status"] = *req.Status
+	}
+
+	rules, total, err := c.service.GetRuleList(req.Page, req.PageSize, filters)
+	if err != nil {
+		c.log.Error("获取权限规则列表失败", "error", err)
+		baseRes.FailWithMessage("获取权限规则列表失败", ctx)
+		return
+	}
+
+	responses := make([]response.PermissionRuleResponse, 0, len(rules))
+	for _, rule := range rules {
+		responses = append(responses, convertToPermissionRuleResponse(rule))
+	}
+
+	baseRes.OkWithDetailed(response.PermissionRuleListResponse{
+		PageResult: baseRes.PageResult{
+			List:     responses,
+			Total:    total,
+			Page:     req.Page,
+			PageSize: req.PageSize,
+		},
+		List: responses,
+	}, "获取权限规则列表成功", ctx)
+}
+
+// GetPermissionRuleByID 获取权限规则详情
+//
+//	@Summary		获取权限规则详情
+//	@Description	根据 ID 获取 ABAC 权限规则详情
+//	@Tags			权限规则
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string																true	"规则 ID"
+//	@Success		200	{object}	baseRes.Response{data=response.PermissionRuleResponse,msg=string}	"规则详情"
+//	@Failure		400	{object}	map[string]string													"请求参数错误"
+//	@Failure		401	{object}	map[string]string													"未授权"
+//	@Router			/api/admin/permission-rules/{id} [get]
+func (c *PermissionRuleController) GetPermissionRuleByID(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		baseRes.FailWithMessage("无效的 ID 格式", ctx)
+		return
+	}
+
+	rule, err := c.service.GetRuleByID(id)
+	if err != nil {
+		baseRes.FailWithMessage(err.Error(), ctx)
+		return
+	}
+
+	baseRes.OkWithDetailed(convertToPermissionRuleResponse(rule), "获取权限规则详情成功", ctx)
+}
+
+// CreatePermissionRule 创建权限规则
+//
+//	@Summary		创建权限规则
+//	@Description	创建 ABAC 权限规则，保存前校验路径模式、方法和条件表达式
+//	@Description	条件支持 all、any、not 组合，操作符 eq、ne、in、not_in、contains、lt、lte、gt、gte、regex、cidr、time_window、exists
+//	@Description	属性必须带 user.、resource.、env. 前缀，如 {"all":[{"attr":"user.roles","op":"contains","value":"finance"},{"attr":"env.ip","op":"cidr","value":["10.0.0.0/8"]}]}
+//	@Tags			权限规则
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			data	body		request.CreatePermissionRuleRequest									true	"规则信息"
+//	@Success		200		{object}	baseRes.Response{data=response.PermissionRuleResponse,msg=string}	"创建成功"
+//	@Failure		400		{object}	map[string]string													"请求参数错误"
+//	@Failure		401		{object}	map[string]string													"未授权"
+//	@Router			/api/admin/permission-rules [post]
+func (c *PermissionRuleController) CreatePermissionRule(ctx *gin.Context) {
+	var req request.CreatePermissionRuleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		baseRes.FailWithMessage("请求参数错误", ctx)
+		return
+	}
+
+	operatorID, err := getCurrentUserID(ctx)
+	if err != nil {
+		baseRes.NoAuth(err.Error(), ctx)
+		return
+	}
+
+	// 提供默认值：status=1（启用）
+	status := 1
+	if req.Status != nil {
+		status = *req.Status
+	}
+
+	rule := &entity.PermissionRule{
+		Name:        req.Name,
+		Description: req.Description,
+		Effect:      req.Effect,
+		APIPath:     req.APIPath,
+		Method:      req.Method,
+		Conditions:  req.Conditions,
+		Status:      status,
+		Sort:        req.Sort,
+		CreatedBy:   operatorID,
+		UpdatedBy:   operatorID,
+	}
+	if err := c.service.CreateRule(rule); err != nil {
+		baseRes.FailWithMessage(err.Error(), ctx)
+		return
+	}
+
+	baseRes.OkWithDetailed(convertToPermissionRuleResponse(rule), "创建权限规则成功", ctx)
+}
+
+// UpdatePermissionRule 更新权限规则
+//
+//	@Summary		更新权限规则
+//	@Description	更新 ABAC 权限规则，保存前校验条件表达式，保存后立即生效
+//	@Tags			权限规则
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		string									true	"规则 ID"
+//	@Param			data	body		request.UpdatePermissionRuleRequest		true	"规则信息"
+//	@Success		200		{object}	baseRes.Response{msg=string}			"更新成功"
+//	@Failure		400		{object}	map[string]string						"请求参数错误"
+//	@Failure		401		{object}	map[string]string						"未授权"
+//	@Router			/api/admin/permission-rules/{id} [put]
+func (c *PermissionRuleController) UpdatePermissionRule(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		baseRes.FailWithMessage("无效的 ID 格式", ctx)
+		return
+	}
+
+	var req request.UpdatePermissionRuleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		baseRes.FailWithMessage("请求参数错误", ctx)
+		return
+	}
+
+	operatorID, err := getCurrentUserID(ctx)
+	if err != nil {
+		baseRes.NoAuth(err.Error(), ctx)
+		return
+	}
+
+	if err := c.service.UpdateRule(&entity.PermissionRule{
+		ID:          id,
+		Name:        req.Name,
+		Description: req.Description,
+		Effect:      req.Effect,
+		APIPath:     req.APIPath,
+		Method:      req.Method,
+		Conditions:  req.Conditions,
+		Status:      req.Status,
+		Sort:        req.Sort,
+		UpdatedBy:   operatorID,
+	}); err != nil {
+		baseRes.FailWithMessage(err.Error(), ctx)
+		return
+	}
+
+	baseRes.OkWithMessage("更新权限规则成功", ctx)
+}
+
+// DeletePermissionRule 删除权限规则
+//
+//	@Summary		删除权限规则
+//	@Description	删除 ABAC 权限规则，系统规则不能删除
+//	@Tags			权限规则
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string							true	"规则 ID"
+//	@Success		200	{object}	baseRes.Response{msg=string}	"删除成功"
+//	@Failure		400	{object}	map[string]string				"请求参数错误"
+//	@Failure		401	{object}	map[string]string				"未授权"
+//	@Router			/api/admin/permission-rules/{id} [delete]
+func (c *PermissionRuleController) DeletePermissionRule(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		baseRes.FailWithMessage("无效的 ID 格式", ctx)
+		return
+	}
+
+	if err := c.service.DeleteRule(id); err != nil {
+		baseRes.FailWithMessage(err.Error(), ctx)
+		return
+	}
+
+	baseRes.OkWithMessage("删除权限规则成功", ctx)
+}
+
+// ValidatePermissionRuleConditions 校验权限规则条件
+//
+//	@Summary		校验权限规则条件
+//	@Description	编译条件表达式但不保存，返回第一个错误及其位置，供编辑规则时实时校验
+//	@Tags			权限规则
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			data	body		request.ValidatePermissionRuleConditionsRequest	true	"条件表达式"
+//	@Success		200		{object}	baseRes.Response{msg=string}					"条件有效"
+//	@Failure		400		{object}	map[string]string								"请求参数错误"
+//	@Failure		401		{object}	map[string]string								"未授权"
+//	@Router			/api/admin/permission-rules/validate [post]
+func (c *PermissionRuleController) ValidatePermissionRuleConditions(ctx *gin.Context) {
+	var req request.ValidatePermissionRuleConditionsRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		baseRes.FailWithMessage("请求参数错误", ctx)
+		return
+	}
+
+	if err := c.service.ValidateConditions(req.Conditions); err != nil {
+		baseRes.FailWithMessage(err.Error(), ctx)
+		return
+	}
+
+	baseRes.OkWithMessage("条件有效", ctx)
+}
@@ -0,0 +1,133 @@
+package baseapi
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/entity"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/service"
+	"github.com/ix-pay/ixpay-pro/internal/dto/base/request"
+	"github.com/ix-pay/ixpay-pro/internal/dto/base/response"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/observability/logger"
+	"github.com/ix-pay/ixpay-pro/internal/utils/common/baseRes"
+)
+
+// PolicyBundleController 权限策略包控制器
+// 处理权限配置以策略包形式的导出和导入，用于在环境之间迁移权限配置
+type PolicyBundleController struct {
+	service *service.PolicyBundleService // 权限策略包服务
+	log     logger.Logger                // 日志记录器
+}
+
+// NewPolicyBundleController 创建权限策略包控制器实例
+func NewPolicyBundleController(service *service.PolicyBundleService, log logger.Logger) *PolicyBundleController {
+	return &PolicyBundleController{
+		service: service,
+		log:     log,
+	}
+}
+
+// convertToPolicyImportResultResponse 将 entity.PolicyImportResult 转换为 response.PolicyImportResultResponse
+func convertToPolicyImportResultResponse(result *entity.PolicyImportResult) response.PolicyImportResultResponse {
+	changes := make([]response.PolicyChangeResponse, 0, len(result.Changes))
+	for _, change := range result.Changes {
+		fields := change.Fields
+		if fields == nil {
+			fields = []string{}
+		}
+		changes = append(changes, response.PolicyChangeResponse{
+			Kind:   change.Kind,
+			Key:    change.Key,
+			Action: change.Action,
+			Fields: fields,
+		})
+	}
+	approvalRoles := result.ApprovalRoles
+	if approvalRoles == nil {
+		approvalRoles = []string{}
+	}
+	return response.PolicyImportResultResponse{
+		DryRun:        result.DryRun,
+		Applied:       result.Applied,
+		Changes:       changes,
+		ApprovalRoles: approvalRoles,
+	}
+}
+
+// ExportPolicyBundle 导出权限策略包
+//
+//	@Summary		导出权限策略包
+//	@Description	将角色及其继承关系、菜单、API、按钮权限、权限组和 ABAC 规则导出为以编码引用的策略包，便于纳入 git 评审
+//	@Tags			权限策略包
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			format	query		string																	false	"文件格式 (yaml、json)，默认 yaml"
+//	@Success		200		{object}	baseRes.Response{data=response.PolicyBundleExportResponse,msg=string}	"策略包内容"
+//	@Failure		400		{object}	map[string]string														"请求参数错误"
+//	@Failure		401		{object}	map[string]string														"未授权"
+//	@Router			/api/admin/policy-bundle/export [get]
+func (c *PolicyBundleController) ExportPolicyBundle(ctx *gin.Context) {
+	var req request.ExportPolicyBundleRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		c.log.Error("请求参数错误", "error", err)
+		baseRes.FailWithMessage("请求参数错误", ctx)
+		return
+	}
+	if req.Format == "" {
+		req.Format = service.PolicyFormatYAML
+	}
+
+	content, err := c.service.Export(req.Format)
+	if err != nil {
+		baseRes.FailWithMessage(err.Error(), ctx)
+		return
+	}
+
+	baseRes.OkWithDetailed(response.PolicyBundleExportResponse{
+		Format:  req.Format,
+		Version: entity.PolicyBundleVersion,
+		Content: string(content),
+	}, "导出权限策略包成功", ctx)
+}
+
+// ImportPolicyBundle 导入权限策略包
+//
+//	@Summary		导入权限策略包
+//	@Description	按编码比较策略包与当前环境的差异；dryRun 为 true 时只返回差异，否则在单个事务中写入。策略包外的对象保持不变；差异影响开启权限变更审批的角色（包括通过其引用的菜单、按钮权限和 API）时不直接写入，而是提交权限变更申请并返回申请内容
+//	@Tags			权限策略包
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			data	body		request.ImportPolicyBundleRequest										true	"策略包内容"
+//	@Success		200		{object}	baseRes.Response{data=response.PolicyImportResultResponse,msg=string}	"导入结果"
+//	@Failure		400		{object}	map[string]string														"请求参数错误"
+//	@Failure		401		{object}	map[string]string														"未授权"
+//	@Router			/api/admin/policy-bundle/import [post]
+func (c *PolicyBundleController) ImportPolicyBundle(ctx *gin.Context) {
+	var req request.ImportPolicyBundleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		c.log.Error("请求参数错误", "error", err)
+		baseRes.FailWithMessage("请求参数错误", ctx)
+		return
+	}
+
+	operatorID, err := getCurrentUserID(ctx)
+	if err != nil {
+		baseRes.NoAuth(err.Error(), ctx)
+		return
+	}
+
+	result, err := c.service.Import([]byte(req.Content), req.Format, req.DryRun, operatorID)
+	if err != nil {
+		if respondPendingApproval(err, ctx) {
+			return
+		}
+		baseRes.FailWithMessage(err.Error(), ctx)
+		return
+	}
+
+	message := "导入权限策略包成功"
+	if result.DryRun {
+		message = "权限策略包差异比较完成"
+	}
+	baseRes.OkWithDetailed(convertToPolicyImportResultResponse(result), message, ctx)
+}
@@ -72,7 +72,7 @@ func convertToPositionResponse(position *entity.Position) response.PositionRespo
 //	@Router			/api/admin/position [get]
 func (c *PositionController) GetPositionList(ctx *gin.Context) {
 	// 检查用户是否已登录
-	_, exists := ctx.Get("userID")
+	exists := isAuthenticated(ctx)
 	if !exists {
 		c.log.Error("未登录")
 		baseRes.NoAuth("未登录", ctx)
@@ -131,7 +131,7 @@ func (c *PositionController) GetPositionList(ctx *gin.Context) {
 //	@Router			/api/admin/position/all [get]
 func (c *PositionController) GetAllPositions(ctx *gin.Context) {
 	// 检查用户是否已登录
-	_, exists := ctx.Get("userID")
+	exists := isAuthenticated(ctx)
 	if !exists {
 		c.log.Error("未登录")
 		baseRes.NoAuth("未登录", ctx)
@@ -169,7 +169,7 @@ func (c *PositionController) GetAllPositions(ctx *gin.Context) {
 //	@Router			/api/admin/position/:id [get]
 func (c *PositionController) GetPositionByID(ctx *gin.Context) {
 	// 检查用户是否已登录
-	_, exists := ctx.Get("userID")
+	exists := isAuthenticated(ctx)
 	if !exists {
 		c.log.Error("未登录")
 		baseRes.NoAuth("未登录", ctx)
@@ -216,7 +216,7 @@ func (c *PositionController) CreatePosition(ctx *gin.Context) {
 	}
 
 	// 获取当前登录用户 ID 作为创建者
-	createdBy, exists := ctx.Get("userID")
+	createdBy, exists := getOperator(ctx)
 	if !exists {
 		c.log.Error("未登录")
 		baseRes.NoAuth("未登录", ctx)
@@ -290,7 +290,7 @@ func (c *PositionController) UpdatePosition(ctx *gin.Context) {
 	}
 
 	// 获取当前登录用户 ID 作为更新者
-	updatedBy, exists := ctx.Get("userID")
+	updatedBy, exists := getOperator(ctx)
 	if !exists {
 		c.log.Error("未登录")
 		baseRes.NoAuth("未登录", ctx)
@@ -0,0 +1,345 @@
+package baseapi
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/entity"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/service"
+	"github.com/ix-pay/ixpay-pro/internal/dto/base/request"
+	"github.com/ix-pay/ixpay-pro/internal/dto/base/response"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/observability/logger"
+	"github.com/ix-pay/ixpay-pro/internal/utils/common/baseRes"
+)
+
+// RoleGrantController 限时角色授予控制器
+// 处理限时角色的直接授予、收回以及用户临时提权的申请和审批
+type RoleGrantController struct {
+	service *service.RoleGrantService // 限时角色授予服务
+	log     logger.Logger             // 日志记录器
+}
+
+// NewRoleGrantController 创建限时角色授予控制器实例
+func NewRoleGrantController(service *service.RoleGrantService, log logger.Logger) *RoleGrantController {
+	return &RoleGrantController{
+		service: service,
+		log:     log,
+	}
+}
+
+// convertToRoleGrantResponse 将 entity.RoleGrant 转换为 response.RoleGrantResponse
+func convertToRoleGrantResponse(grant *entity.RoleGrant) response.RoleGrantResponse {
+	resp := response.RoleGrantResponse{
+		ID:             grant.ID,
+		UserID:         grant.UserID,
+		RoleID:         grant.RoleID,
+		Justification:  grant.Justification,
+		Status:         grant.Status,
+		Elevation:      grant.Elevation,
+		RequestedHours: grant.RequestedHours,
+		ApprovedBy:     grant.ApprovedBy,
+		ReviewComment:  grant.ReviewComment,
+		RevokedBy:      grant.RevokedBy,
+		CreatedBy:      grant.CreatedBy,
+		CreatedAt:      grant.CreatedAt.Format(time.RFC3339),
+	}
+	if !grant.ValidFrom.IsZero() {
+		resp.ValidFrom = grant.ValidFrom.Format(time.RFC3339)
+		resp.ValidUntil = grant.ValidUntil.Format(time.RFC3339)
+	}
+	if grant.ApprovedAt != nil {
+		resp.ApprovedAt = grant.ApprovedAt.Format(time.RFC3339)
+	}
+	if grant.RevokedAt != nil {
+		resp.RevokedAt = grant.RevokedAt.Format(time.RFC3339)
+	}
+	return resp
+}
+
+// GetRoleGrantList 获取限时授予列表
+//
+//	@Summary		获取限时授予列表
+//	@Description	分页获取限时角色授予和提权申请，可按用户、角色和状态筛选
+//	@Tags			限时角色授予
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			page		query		int																true	"页码"
+//	@Param			pageSize	query		int																true	"每页数量"
+//	@Param			userId		query		string															false	"用户 ID"
+//	@Param			roleId		query		string															false	"角色 ID"
+//	@Param			status		query		string															false	"状态 (pending、scheduled、active、rejected、expired、revoked)"
+//	@Success		200			{object}	baseRes.Response{data=response.RoleGrantListResponse,msg=string}	"授予列表"
+//	@Failure		400			{object}	map[string]string												"请求参数错误"
+//	@Failure		401			{object}	map[string]string												"未授权"
+//	@Router			/api/admin/role-grants [get]
+func (c *RoleGrantController) GetRoleGrantList(ctx *gin.Context) {
+	var req request.GetRoleGrantListRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		c.log.Error("请求参数错误", "error", err)
+		baseRes.FailWithMessage("请求参数错误", ctx)
+		return
+	}
+
+	filters := make(map[string]interface{})
+	if req.UserID != "" {
+		userID, err := strconv.ParseInt(req.UserID, 10, 64)
+		if err != nil {
+			baseRes.FailWithMessage("无效的用户 ID 格式", ctx)
+			return
+		}
+		filters["user_id"] = userID
+	}
+	if req.RoleID != "" {
+		roleID, err := strconv.ParseInt(req.RoleID, 10, 64)
+		if err != nil {
+			baseRes.FailWithMessage("无效的角色 ID 格式", ctx)
+			return
+		}
+		filters["role_id"] = roleID
+	}
+	if req.Status != "" {
+		filters["status"] = req.Status
+	}
+
+	c.respondList(ctx, req.Page, req.PageSize, filters)
+}
+
+// GetMyRoleGrants 获取当前用户的限时授予
+//
+//	@Summary		获取当前用户的限时授予
+//	@Description	分页获取当前登录用户的限时角色授予和提权申请
+//	@Tags			限时角色授予
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			page		query		int																true	"页码"
+//	@Param			pageSize	query		int																true	"每页数量"
+//	@Param			status		query		string															false	"状态 (pending、scheduled、active、rejected、expired、revoked)"
+//	@Success		200			{object}	baseRes.Response{data=response.RoleGrantListResponse,msg=string}	"授予列表"
+//	@Failure		400			{object}	map[string]string												"请求参数错误"
+//	@Failure		401			{object}	map[string]string												"未授权"
+//	@Router			/api/admin/role-grants/mine [get]
+func (c *RoleGrantController) GetMyRoleGrants(ctx *gin.Context) {
+	var req request.GetRoleGrantListRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		c.log.Error("请求参数错误", "error", err)
+		baseRes.FailWithMessage("请求参数错误", ctx)
+		return
+	}
+
+	userID, err := getCurrentUserID(ctx)
+	if err != nil {
+		baseRes.NoAuth(err.Error(), ctx)
+		return
+	}
+
+	filters := map[string]interface{}{"user_id": userID}
+	if req.Status != "" {
+		filters["status"] = req.Status
+	}
+
+	c.respondList(ctx, req.Page, req.PageSize, filters)
+}
+
+// respondList 查询并返回限时授予列表
+func (c *RoleGrantController) respondList(ctx *gin.Context, page, pageSize int, filters map[string]interface{}) {
+	grants, total, err := c.service.GetRoleGrantList(page, pageSize, filters)
+	if err != nil {
+		c.log.Error("获取限时授予列表失败", "error", err)
+		baseRes.FailWithMessage("获取限时授予列表失败", ctx)
+		return
+	}
+
+	responses := make([]response.RoleGrantResponse, 0, len(grants))
+	for _, grant := range grants {
+		responses = append(responses, convertToRoleGrantResponse(grant))
+	}
+
+	baseRes.OkWithDetailed(response.RoleGrantListResponse{
+		PageResult: baseRes.PageResult{
+			List:     responses,
+			Total:    total,
+			Page:     page,
+			PageSize: pageSize,
+		},
+		List: responses,
+	}, "获取限时授予列表成功", ctx)
+}
+
+// GrantRole 授予限时角色
+//
+//	@Summary		授予限时角色
+//	@Description	在有效期内将用户加入角色，到期后由定时任务自动收回；生效时间为空时立即生效
+//	@Description	用户已长期拥有该角色或已有未结束的限时授予时不能授予
+//	@Tags			限时角色授予
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			data	body		request.GrantRoleRequest										true	"授予参数"
+//	@Success		200		{object}	baseRes.Response{data=response.RoleGrantResponse,msg=string}	"授予结果"
+//	@Failure		400		{object}	map[string]string												"请求参数错误"
+//	@Failure		401		{object}	map[string]string												"未授权"
+//	@Router			/api/admin/role-grants [post]
+func (c *RoleGrantController) GrantRole(ctx *gin.Context) {
+	var req request.GrantRoleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		baseRes.FailWithMessage("请求参数错误", ctx)
+		return
+	}
+
+	userID, err := strconv.ParseInt(req.UserID, 10, 64)
+	if err != nil {
+		baseRes.FailWithMessage("无效的用户 ID 格式", ctx)
+		return
+	}
+	roleID, err := strconv.ParseInt(req.RoleID, 10, 64)
+	if err != nil {
+		baseRes.FailWithMessage("无效的角色 ID 格式", ctx)
+		return
+	}
+	operatorID, err := getCurrentUserID(ctx)
+	if err != nil {
+		baseRes.NoAuth(err.Error(), ctx)
+		return
+	}
+
+	var validFrom time.Time
+	if req.ValidFrom != nil {
+		validFrom = *req.ValidFrom
+	}
+
+	grant, err := c.service.GrantRole(userID, roleID, validFrom, req.ValidUntil, req.Justification, operatorID)
+	if err != nil {
+		baseRes.FailWithMessage(err.Error(), ctx)
+		return
+	}
+
+	baseRes.OkWithDetailed(convertToRoleGrantResponse(grant), "授予限时角色成功", ctx)
+}
+
+// RequestElevation 申请临时提权
+//
+//	@Summary		申请临时提权
+//	@Description	当前用户申请在指定时长内拥有某个角色，审批通过后立即生效，到期自动收回
+//	@Tags			限时角色授予
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			data	body		request.RequestElevationRequest									true	"申请参数"
+//	@Success		200		{object}	baseRes.Response{data=response.RoleGrantResponse,msg=string}	"申请结果"
+//	@Failure		400		{object}	map[string]string												"请求参数错误"
+//	@Failure		401		{object}	map[string]string												"未授权"
+//	@Router			/api/admin/role-grants/elevation [post]
+func (c *RoleGrantController) RequestElevation(ctx *gin.Context) {
+	var req request.RequestElevationRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		baseRes.FailWithMessage("请求参数错误", ctx)
+		return
+	}
+
+	roleID, err := strconv.ParseInt(req.RoleID, 10, 64)
+	if err != nil {
+		baseRes.FailWithMessage("无效的角色 ID 格式", ctx)
+		return
+	}
+	userID, err := getCurrentUserID(ctx)
+	if err != nil {
+		baseRes.NoAuth(err.Error(), ctx)
+		return
+	}
+
+	grant, err := c.service.RequestElevation(userID, roleID, req.Hours, req.Justification)
+	if err != nil {
+		baseRes.FailWithMessage(err.Error(), ctx)
+		return
+	}
+
+	baseRes.OkWithDetailed(convertToRoleGrantResponse(grant), "提交提权申请成功", ctx)
+}
+
+// ApproveElevation 批准提权申请
+//
+//	@Summary		批准提权申请
+//	@Description	批准待审批的提权申请，从审批时起按申请时长生效；申请人不能审批自己的申请
+//	@Tags			限时角色授予
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		string															true	"申请 ID"
+//	@Param			data	body		request.ReviewRoleGrantRequest									false	"审批意见"
+//	@Success		200		{object}	baseRes.Response{data=response.RoleGrantResponse,msg=string}	"审批结果"
+//	@Failure		400		{object}	map[string]string												"请求参数错误"
+//	@Failure		401		{object}	map[string]string												"未授权"
+//	@Router			/api/admin/role-grants/{id}/approve [post]
+func (c *RoleGrantController) ApproveElevation(ctx *gin.Context) {
+	c.review(ctx, c.service.ApproveElevation, "批准提权申请成功")
+}
+
+// RejectElevation 拒绝提权申请
+//
+//	@Summary		拒绝提权申请
+//	@Description	拒绝待审批的提权申请；申请人不能审批自己的申请
+//	@Tags			限时角色授予
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		string															true	"申请 ID"
+//	@Param			data	body		request.ReviewRoleGrantRequest									false	"审批意见"
+//	@Success		200		{object}	baseRes.Response{data=response.RoleGrantResponse,msg=string}	"审批结果"
+//	@Failure		400		{object}	map[string]string												"请求参数错误"
+//	@Failure		401		{object}	map[string]string												"未授权"
+//	@Router			/api/admin/role-grants/{id}/reject [post]
+func (c *RoleGrantController) RejectElevation(ctx *gin.Context) {
+	c.review(ctx, c.service.RejectElevation, "拒绝提权申请成功")
+}
+
+// RevokeRoleGrant 收回限时授予
+//
+//	@Summary		收回限时授予
+//	@Description	在到期前收回待生效或生效中的限时授予，生效中的授予立即将用户移出角色
+//	@Tags			限时角色授予
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		string															true	"授予 ID"
+//	@Param			data	body		request.ReviewRoleGrantRequest									false	"收回原因"
+//	@Success		200		{object}	baseRes.Response{data=response.RoleGrantResponse,msg=string}	"收回结果"
+//	@Failure		400		{object}	map[string]string												"请求参数错误"
+//	@Failure		401		{object}	map[string]string												"未授权"
+//	@Router			/api/admin/role-grants/{id}/revoke [post]
+func (c *RoleGrantController) RevokeRoleGrant(ctx *gin.Context) {
+	c.review(ctx, c.service.RevokeGrant, "收回限时授予成功")
+}
+
+// review 处理审批和收回请求，请求体可以为空
+func (c *RoleGrantController) review(ctx *gin.Context, action func(id, operatorID int64, comment string) (*entity.RoleGrant, error), message string) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		baseRes.FailWithMessage("无效的 ID 格式", ctx)
+		return
+	}
+
+	var req request.ReviewRoleGrantRequest
+	if ctx.Request.ContentLength > 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			baseRes.FailWithMessage("请求参数错误", ctx)
+			return
+		}
+	}
+
+	operatorID, err := getCurrentUserID(ctx)
+	if err != nil {
+		baseRes.NoAuth(err.Error(), ctx)
+		return
+	}
+
+	grant, err := action(id, operatorID, req.Comment)
+	if err != nil {
+		baseRes.FailWithMessage(err.Error(), ctx)
+		return
+	}
+
+	baseRes.OkWithDetailed(convertToRoleGrantResponse(grant), message, ctx)
+}
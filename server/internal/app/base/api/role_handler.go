@@ -1,6 +1,7 @@
 package baseapi
 
 import (
+	"errors"
 	"strconv"
 	"strings"
 	"time"
@@ -19,15 +20,17 @@ type RoleController struct {
 	roleService           *service.RoleService
 	rolePermissionService *service.RolePermissionService
 	apiService            *service.APIService
+	dataScopeService      *service.DataScopeService
 	log                   logger.Logger
 }
 
 // NewRoleController 创建角色控制器
-func NewRoleController(roleService *service.RoleService, rolePermissionService *service.RolePermissionService, apiService *service.APIService, log logger.Logger) *RoleController {
+func NewRoleController(roleService *service.RoleService, rolePermissionService *service.RolePermissionService, apiService *service.APIService, dataScopeService *service.DataScopeService, log logger.Logger) *RoleController {
 	return &RoleController{
 		roleService:           roleService,
 		rolePermissionService: rolePermissionService,
 		apiService:            apiService,
+		dataScopeService:      dataScopeService,
 		log:                   log,
 	}
 }
@@ -35,17 +38,20 @@ func NewRoleController(roleService *service.RoleService, rolePermissionService *
 // convertToRoleResponse 将 entity.Role 转换为 response.RoleResponse
 func convertToRoleResponse(role *entity.Role) response.RoleResponse {
 	return response.RoleResponse{
-		ID:          role.ID,
-		Name:        role.Name,
-		Code:        role.Code,
-		Description: role.Description,
-		Type:        role.Type,
-		ParentId:    role.ParentID,
-		Status:      role.Status,
-		IsSystem:    role.IsSystem,
-		Sort:        role.Sort,
-		CreatedAt:   role.CreatedAt.Format(time.RFC3339),
-		UpdatedAt:   role.UpdatedAt.Format(time.RFC3339),
+		ID:               role.ID,
+		Name:             role.Name,
+		Code:             role.Code,
+		Description:      role.Description,
+		Type:             role.Type,
+		ParentId:         role.ParentID,
+		Status:           role.Status,
+		IsSystem:         role.IsSystem,
+		Sort:             role.Sort,
+		CreatedAt:        role.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:        role.UpdatedAt.Format(time.RFC3339),
+		DataScope:        role.DataScope,
+		DataScopeDeptIds: convertInt64SliceToStringSlice(role.DataScopeDeptIds),
+		ApprovalRequired: role.ApprovalRequired,
 	}
 }
 
@@ -178,6 +184,100 @@ func (c *RoleController) UpdateRole(ctx *gin.Context) {
 	baseRes.OkWithMessage("更新角色成功", ctx)
 }
 
+// SetRoleDataScope 设置角色数据权限
+//
+//	@Summary		设置角色数据权限
+//	@Description	设置角色在用户、操作日志、登录日志等列表中可见的数据范围：1-全部，2-本部门，3-本部门及下级，4-自定义部门，5-仅本人
+//	@Description	部门范围下本人的数据始终可见，保存后立即生效
+//	@Tags			角色管理
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		string							true	"角色 ID"
+//	@Param			data	body		request.SetRoleDataScopeRequest	true	"数据权限"
+//	@Success		200		{object}	baseRes.Response{msg=string}	"设置成功"
+//	@Failure		400		{object}	map[string]string				"请求参数错误"
+//	@Failure		401		{object}	map[string]string				"未授权"
+//	@Router			/api/admin/role/{id}/data-scope [put]
+func (c *RoleController) SetRoleDataScope(ctx *gin.Context) {
+	roleID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		baseRes.FailWithMessage("无效的 ID 格式", ctx)
+		return
+	}
+
+	var req request.SetRoleDataScopeRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		baseRes.FailWithMessage("请求参数错误", ctx)
+		return
+	}
+
+	deptIDs, err := convertStringSliceToInt64Slice(req.DeptIds)
+	if err != nil {
+		baseRes.FailWithMessage(err.Error(), ctx)
+		return
+	}
+
+	operatorID, err := getCurrentUserID(ctx)
+	if err != nil {
+		baseRes.NoAuth(err.Error(), ctx)
+		return
+	}
+
+	if err := c.dataScopeService.SetRoleDataScope(roleID, req.DataScope, deptIDs, operatorID); err != nil {
+		baseRes.FailWithMessage(err.Error(), ctx)
+		return
+	}
+
+	baseRes.OkWithMessage("设置角色数据权限成功", ctx)
+}
+
+// SetRoleApprovalRequired 设置角色权限变更是否需要审批
+//
+//	@Summary		设置角色权限变更是否需要审批
+//	@Description	开启后角色的接口、菜单、按钮权限和用户分配变更先生成变更申请，由申请人以外的审批人通过后才生效
+//	@Description	开启立即生效，关闭本身需要审批
+//	@Tags			角色管理
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		string									true	"角色 ID"
+//	@Param			data	body		request.SetRoleApprovalRequiredRequest	true	"是否需要审批"
+//	@Success		200		{object}	baseRes.Response{msg=string}			"设置成功"
+//	@Failure		400		{object}	map[string]string						"请求参数错误"
+//	@Failure		401		{object}	map[string]string						"未授权"
+//	@Router			/api/admin/role/{id}/approval-required [put]
+func (c *RoleController) SetRoleApprovalRequired(ctx *gin.Context) {
+	roleID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		baseRes.FailWithMessage("无效的 ID 格式", ctx)
+		return
+	}
+
+	var req request.SetRoleApprovalRequiredRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		baseRes.FailWithMessage("请求参数错误", ctx)
+		return
+	}
+
+	operatorID, err := getCurrentUserID(ctx)
+	if err != nil {
+		baseRes.NoAuth(err.Error(), ctx)
+		return
+	}
+
+	err = c.roleService.SetRoleApprovalRequired(roleID, *req.ApprovalRequired, operatorID)
+	if respondPendingApproval(err, ctx) {
+		return
+	}
+	if err != nil {
+		baseRes.FailWithMessage(err.Error(), ctx)
+		return
+	}
+
+	baseRes.OkWithMessage("设置角色变更审批成功", ctx)
+}
+
 // DeleteRole 删除角色
 //
 //	@Summary		删除角色
@@ -293,13 +393,20 @@ func (c *RoleController) AssignUserToRole(ctx *gin.Context) {
 		return
 	}
 
-	// 遍历用户 ID 列表，逐个分配
-	for _, userID := range userIDs {
-		err := c.roleService.AssignUserToRole(req.RoleID, userID)
-		if err != nil {
-			baseRes.FailWithDetailed(map[string]interface{}{"error": err.Error()}, "分配用户到角色失败", ctx)
-			return
-		}
+	operatorID, err := getCurrentUserID(ctx)
+	if err != nil {
+		baseRes.NoAuth(err.Error(), ctx)
+		return
+	}
+
+	// 批量分配，角色需要审批时整批生成一条变更申请
+	err = c.roleService.BatchAssignUsersToRole(req.RoleID, userIDs, operatorID)
+	if respondPendingApproval(err, ctx) {
+		return
+	}
+	if err != nil {
+		baseRes.FailWithDetailed(map[string]interface{}{"error": err.Error()}, "分配用户到角色失败", ctx)
+		return
 	}
 
 	c.log.Info("分配用户到角色成功", "roleID", req.RoleID, "userCount", len(userIDs))
@@ -333,15 +440,32 @@ func (c *RoleController) AssignMenuToRole(ctx *gin.Context) {
 		return
 	}
 
-	// 遍历菜单 ID 列表，逐个分配
+	operatorID, err := getCurrentUserID(ctx)
+	if err != nil {
+		baseRes.NoAuth(err.Error(), ctx)
+		return
+	}
+
+	// 遍历菜单 ID 列表，逐个分配；角色需要审批时每个菜单生成一条变更申请
+	var pendingChanges []response.PermissionChangeResponse
 	for _, menuID := range menuIDs {
-		err := c.roleService.AssignMenuToRole(req.RoleID, menuID)
+		err := c.roleService.AssignMenuToRole(req.RoleID, menuID, operatorID)
+		var pending *service.PendingApprovalError
+		if errors.As(err, &pending) {
+			pendingChanges = append(pendingChanges, convertToPermissionChangeResponse(pending.Request))
+			continue
+		}
 		if err != nil {
 			baseRes.FailWithDetailed(map[string]interface{}{"error": err.Error()}, "分配菜单到角色失败", ctx)
 			return
 		}
 	}
 
+	if len(pendingChanges) > 0 {
+		baseRes.OkWithDetailed(pendingChanges, "角色权限变更需要审批，已提交变更申请", ctx)
+		return
+	}
+
 	c.log.Info("分配菜单到角色成功", "roleID", req.RoleID, "menuCount", len(menuIDs))
 	baseRes.OkWithMessage("分配菜单到角色成功", ctx)
 }
@@ -373,8 +497,17 @@ func (c *RoleController) AssignAPIToRole(ctx *gin.Context) {
 		return
 	}
 
+	operatorID, err := getCurrentUserID(ctx)
+	if err != nil {
+		baseRes.NoAuth(err.Error(), ctx)
+		return
+	}
+
 	// 使用批量分配方法，更高效
-	err = c.roleService.BatchAssignAPIsToRole(req.RoleID, apiIDs)
+	err = c.roleService.BatchAssignAPIsToRole(req.RoleID, apiIDs, operatorID)
+	if respondPendingApproval(err, ctx) {
+		return
+	}
 	if err != nil {
 		baseRes.FailWithDetailed(map[string]interface{}{"error": err.Error()}, "分配 API 路由到角色失败", ctx)
 		return
@@ -414,7 +547,7 @@ func (c *RoleController) GetAllRoles(ctx *gin.Context) {
 // SaveRolePermissions 保存角色权限
 //
 //	@Summary		保存角色权限
-//	@Description	保存角色的菜单、按钮和 API 权限
+//	@Description	保存角色的菜单、按钮和 API 权限；角色开启变更审批时差异提交为变更申请，审批通过后生效
 //	@Tags			角色管理
 //	@Accept			json
 //	@Produce		json
@@ -447,7 +580,7 @@ func (c *RoleController) SaveRolePermissions(ctx *gin.Context) {
 	}
 
 	// 获取操作人 ID 并转换为 int64
-	operatorID, _ := ctx.Get("userID")
+	operatorID, _ := getOperator(ctx)
 	var operatorIDInt int64
 	switch v := operatorID.(type) {
 	case string:
@@ -487,6 +620,9 @@ func (c *RoleController) SaveRolePermissions(ctx *gin.Context) {
 		strconv.FormatInt(operatorIDInt, 10),
 	)
 	if err != nil {
+		if respondPendingApproval(err, ctx) {
+			return
+		}
 		baseRes.FailWithMessage(err.Error(), ctx)
 		return
 	}
@@ -0,0 +1,383 @@
+package baseapi
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/entity"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/service"
+	"github.com/ix-pay/ixpay-pro/internal/dto/base/request"
+	"github.com/ix-pay/ixpay-pro/internal/dto/base/response"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/observability/logger"
+	"github.com/ix-pay/ixpay-pro/internal/utils/common/baseRes"
+)
+
+// ServiceAccountController 服务账号控制器
+// 处理服务账号及其 API Key 的管理请求
+type ServiceAccountController struct {
+	service *service.ServiceAccountService // 服务账号服务
+	log     logger.Logger                  // 日志记录器
+}
+
+// NewServiceAccountController 创建服务账号控制器实例
+func NewServiceAccountController(service *service.ServiceAccountService, log logger.Logger) *ServiceAccountController {
+	return &ServiceAccountController{
+		service: service,
+		log:     log,
+	}
+}
+
+// formatOptionalTime 格式化可选时间，为空时返回空字符串
+func formatOptionalTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+// convertToServiceAccountResponse 将 entity.ServiceAccount 转换为 response.ServiceAccountResponse
+func convertToServiceAccountResponse(account *entity.ServiceAccount) response.ServiceAccountResponse {
+	resp := response.ServiceAccountResponse{
+		ID:          account.ID,
+		Name:        account.Name,
+		Description: account.Description,
+		Status:      account.Status,
+		IPAllowlist: account.IPAllowlist,
+		RoleIDs:     make([]string, 0, len(account.RoleIds)),
+		Roles:       make([]response.RoleDTO, 0, len(account.Roles)),
+		LastUsedAt:  formatOptionalTime(account.LastUsedAt),
+		LastUsedIP:  account.LastUsedIP,
+		CreatedAt:   account.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:   account.UpdatedAt.Format(time.RFC3339),
+	}
+	if resp.IPAllowlist == nil {
+		resp.IPAllowlist = []string{}
+	}
+	for _, roleID := range account.RoleIds {
+		resp.RoleIDs = append(resp.RoleIDs, strconv.FormatInt(roleID, 10))
+	}
+	for _, role := range account.Roles {
+		resp.Roles = append(resp.Roles, response.RoleDTO{ID: role.ID, Name: role.Name, Code: role.Code})
+	}
+	now := time.Now()
+	for _, key := range account.Keys {
+		resp.Keys = append(resp.Keys, response.ServiceAccountKeyResponse{
+			ID:         key.ID,
+			Prefix:     key.Prefix,
+			ExpiresAt:  formatOptionalTime(key.ExpiresAt),
+			RevokedAt:  formatOptionalTime(key.RevokedAt),
+			LastUsedAt: formatOptionalTime(key.LastUsedAt),
+			LastUsedIP: key.LastUsedIP,
+			Valid:      key.IsValid(now),
+			CreatedAt:  key.CreatedAt.Format(time.RFC3339),
+		})
+	}
+	return resp
+}
+
+// GetServiceAccountList 获取服务账号列表
+//
+//	@Summary		获取服务账号列表
+//	@Description	分页获取服务账号列表
+//	@Tags			服务账号管理
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			page		query		int																		true	"页码"
+//	@Param			pageSize	query		int																		true	"每页数量"
+//	@Param			status		query		int																		false	"状态 (0:禁用，1:启用)"
+//	@Success		200			{object}	baseRes.Response{data=response.ServiceAccountListResponse,msg=string}	"服务账号列表"
+//	@Failure		400			{object}	map[string]string														"请求参数错误"
+//	@Failure		401			{object}	map[string]string														"未授权"
+//	@Router			/api/admin/service-accounts [get]
+func (c *ServiceAccountController) GetServiceAccountList(ctx *gin.Context) {
+	var req request.GetServiceAccountListRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		c.log.Error("请求参数错误", "error", err)
+		baseRes.FailWithMessage("请求参数错误", ctx)
+		return
+	}
+
+	filters := make(map[string]interface{})
+	if req.Status != nil {
+		filters["status"] = *req.Status
+	}
+
+	accounts, total, err := c.service.GetServiceAccountList(req.Page, req.PageSize, filters)
+	if err != nil {
+		c.log.Error("获取服务账号列表失败", "error", err)
+		baseRes.FailWithMessage("获取服务账号列表失败", ctx)
+		return
+	}
+
+	responses := make([]response.ServiceAccountResponse, 0, len(accounts))
+	for _, account := range accounts {
+		responses = append(responses, convertToServiceAccountResponse(account))
+	}
+
+	baseRes.OkWithDetailed(response.ServiceAccountListResponse{
+		PageResult: baseRes.PageResult{
+			List:     responses,
+			Total:    total,
+			Page:     req.Page,
+			PageSize: req.PageSize,
+		},
+		List: responses,
+	}, "获取服务账号列表成功", ctx)
+}
+
+// GetServiceAccountByID 获取服务账号详情
+//
+//	@Summary		获取服务账号详情
+//	@Description	获取服务账号详情，包含绑定角色和 API Key 列表（不含明文）
+//	@Tags			服务账号管理
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string																true	"服务账号 ID"
+//	@Success		200	{object}	baseRes.Response{data=response.ServiceAccountResponse,msg=string}	"服务账号详情"
+//	@Failure		400	{object}	map[string]string													"请求参数错误"
+//	@Failure		401	{object}	map[string]string													"未授权"
+//	@Router			/api/admin/service-accounts/{id} [get]
+func (c *ServiceAccountController) GetServiceAccountByID(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		baseRes.FailWithMessage("无效的 ID 格式", ctx)
+		return
+	}
+
+	account, err := c.service.GetServiceAccountByID(id)
+	if err != nil {
+		baseRes.FailWithMessage(err.Error(), ctx)
+		return
+	}
+
+	baseRes.OkWithDetailed(convertToServiceAccountResponse(account), "获取服务账号详情成功", ctx)
+}
+
+// CreateServiceAccount 创建服务账号
+//
+//	@Summary		创建服务账号
+//	@Description	创建服务账号并生成首个 API Key，API Key 明文仅返回一次
+//	@Tags			服务账号管理
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			data	body		request.CreateServiceAccountRequest										true	"服务账号信息"
+//	@Success		200		{object}	baseRes.Response{data=response.ServiceAccountKeyIssuedResponse,msg=string}	"创建成功"
+//	@Failure		400		{object}	map[string]string														"请求参数错误"
+//	@Failure		401		{object}	map[string]string														"未授权"
+//	@Router			/api/admin/service-accounts [post]
+func (c *ServiceAccountController) CreateServiceAccount(ctx *gin.Context) {
+	var req request.CreateServiceAccountRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		baseRes.FailWithMessage("请求参数错误", ctx)
+		return
+	}
+
+	operatorID, err := getCurrentUserID(ctx)
+	if err != nil {
+		baseRes.NoAuth(err.Error(), ctx)
+		return
+	}
+
+	roleIDs, err := convertStringSliceToInt64Slice(req.RoleIDs)
+	if err != nil {
+		baseRes.FailWithMessage(err.Error(), ctx)
+		return
+	}
+
+	// 提供默认值：status=1（启用）
+	status := req.Status
+	if status == 0 {
+		status = entity.ServiceAccountStatusEnabled
+	}
+
+	account, rawKey, err := c.service.CreateServiceAccount(&entity.ServiceAccount{
+		Name:        req.Name,
+		Description: req.Description,
+		Status:      status,
+		IPAllowlist: req.IPAllowlist,
+		RoleIds:     roleIDs,
+		CreatedBy:   operatorID,
+		UpdatedBy:   operatorID,
+	}, req.ExpiresAt)
+	if err != nil {
+		baseRes.FailWithMessage(err.Error(), ctx)
+		return
+	}
+
+	key := account.Keys[0]
+	baseRes.OkWithDetailed(response.ServiceAccountKeyIssuedResponse{
+		ServiceAccountID: account.ID,
+		KeyID:            key.ID,
+		Prefix:           key.Prefix,
+		APIKey:           rawKey,
+		ExpiresAt:        formatOptionalTime(key.ExpiresAt),
+	}, "创建服务账号成功，请妥善保存 API Key", ctx)
+}
+
+// UpdateServiceAccount 更新服务账号
+//
+//	@Summary		更新服务账号
+//	@Description	更新服务账号描述、状态、IP 白名单和绑定角色
+//	@Tags			服务账号管理
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		string								true	"服务账号 ID"
+//	@Param			data	body		request.UpdateServiceAccountRequest	true	"服务账号信息"
+//	@Success		200		{object}	baseRes.Response{msg=string}		"更新成功"
+//	@Failure		400		{object}	map[string]string					"请求参数错误"
+//	@Failure		401		{object}	map[string]string					"未授权"
+//	@Router			/api/admin/service-accounts/{id} [put]
+func (c *ServiceAccountController) UpdateServiceAccount(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		baseRes.FailWithMessage("无效的 ID 格式", ctx)
+		return
+	}
+
+	var req request.UpdateServiceAccountRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		baseRes.FailWithMessage("请求参数错误", ctx)
+		return
+	}
+
+	operatorID, err := getCurrentUserID(ctx)
+	if err != nil {
+		baseRes.NoAuth(err.Error(), ctx)
+		return
+	}
+
+	roleIDs, err := convertStringSliceToInt64Slice(req.RoleIDs)
+	if err != nil {
+		baseRes.FailWithMessage(err.Error(), ctx)
+		return
+	}
+
+	if err := c.service.UpdateServiceAccount(&entity.ServiceAccount{
+		ID:          id,
+		Description: req.Description,
+		Status:      req.Status,
+		IPAllowlist: req.IPAllowlist,
+		RoleIds:     roleIDs,
+		UpdatedBy:   operatorID,
+	}); err != nil {
+		baseRes.FailWithMessage(err.Error(), ctx)
+		return
+	}
+
+	baseRes.OkWithMessage("更新服务账号成功", ctx)
+}
+
+// DeleteServiceAccount 删除服务账号
+//
+//	@Summary		删除服务账号
+//	@Description	删除服务账号，其下所有 API Key 立即失效
+//	@Tags			服务账号管理
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string							true	"服务账号 ID"
+//	@Success		200	{object}	baseRes.Response{msg=string}	"删除成功"
+//	@Failure		400	{object}	map[string]string				"请求参数错误"
+//	@Failure		401	{object}	map[string]string				"未授权"
+//	@Router			/api/admin/service-accounts/{id} [delete]
+func (c *ServiceAccountController) DeleteServiceAccount(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		baseRes.FailWithMessage("无效的 ID 格式", ctx)
+		return
+	}
+
+	if err := c.service.DeleteServiceAccount(id); err != nil {
+		baseRes.FailWithMessage(err.Error(), ctx)
+		return
+	}
+
+	baseRes.OkWithMessage("删除服务账号成功", ctx)
+}
+
+// RotateKey 轮换 API Key
+//
+//	@Summary		轮换 API Key
+//	@Description	生成新的 API Key，旧 Key 在宽限期后失效，新 Key 明文仅返回一次
+//	@Tags			服务账号管理
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		string																	true	"服务账号 ID"
+//	@Param			data	body		request.RotateServiceAccountKeyRequest									true	"轮换参数"
+//	@Success		200		{object}	baseRes.Response{data=response.ServiceAccountKeyIssuedResponse,msg=string}	"轮换成功"
+//	@Failure		400		{object}	map[string]string														"请求参数错误"
+//	@Failure		401		{object}	map[string]string														"未授权"
+//	@Router			/api/admin/service-accounts/{id}/rotate-key [post]
+func (c *ServiceAccountController) RotateKey(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		baseRes.FailWithMessage("无效的 ID 格式", ctx)
+		return
+	}
+
+	var req request.RotateServiceAccountKeyRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		baseRes.FailWithMessage("请求参数错误", ctx)
+		return
+	}
+
+	operatorID, err := getCurrentUserID(ctx)
+	if err != nil {
+		baseRes.NoAuth(err.Error(), ctx)
+		return
+	}
+
+	key, rawKey, err := c.service.RotateKey(id, req.ExpiresAt, req.GraceSeconds, operatorID)
+	if err != nil {
+		baseRes.FailWithMessage(err.Error(), ctx)
+		return
+	}
+
+	baseRes.OkWithDetailed(response.ServiceAccountKeyIssuedResponse{
+		ServiceAccountID: id,
+		KeyID:            key.ID,
+		Prefix:           key.Prefix,
+		APIKey:           rawKey,
+		ExpiresAt:        formatOptionalTime(key.ExpiresAt),
+	}, "轮换 API Key 成功，请妥善保存新 Key", ctx)
+}
+
+// RevokeKey 吊销 API Key
+//
+//	@Summary		吊销 API Key
+//	@Description	立即吊销服务账号下的指定 API Key
+//	@Tags			服务账号管理
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		string							true	"服务账号 ID"
+//	@Param			keyId	path		string							true	"Key ID"
+//	@Success		200		{object}	baseRes.Response{msg=string}	"吊销成功"
+//	@Failure		400		{object}	map[string]string				"请求参数错误"
+//	@Failure		401		{object}	map[string]string				"未授权"
+//	@Router			/api/admin/service-accounts/{id}/keys/{keyId} [delete]
+func (c *ServiceAccountController) RevokeKey(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		baseRes.FailWithMessage("无效的 ID 格式", ctx)
+		return
+	}
+	keyID, err := strconv.ParseInt(ctx.Param("keyId"), 10, 64)
+	if err != nil {
+		baseRes.FailWithMessage("无效的 Key ID 格式", ctx)
+		return
+	}
+
+	if err := c.service.RevokeKey(id, keyID); err != nil {
+		baseRes.FailWithMessage(err.Error(), ctx)
+		return
+	}
+
+	baseRes.OkWithMessage("吊销 API Key 成功", ctx)
+}
@@ -0,0 +1,309 @@
+package baseapi
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/entity"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/service"
+	"github.com/ix-pay/ixpay-pro/internal/dto/base/request"
+	"github.com/ix-pay/ixpay-pro/internal/dto/base/response"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/observability/logger"
+	"github.com/ix-pay/ixpay-pro/internal/utils/common/baseRes"
+)
+
+// SoDController 职责分离约束控制器
+// 处理角色互斥和角色数量上限约束的管理，以及现有违规的查询
+type SoDController struct {
+	service *service.SoDService // 职责分离约束服务
+	log     logger.Logger       // 日志记录器
+}
+
+// NewSoDController 创建职责分离约束控制器实例
+func NewSoDController(service *service.SoDService, log logger.Logger) *SoDController {
+	return &SoDController{
+		service: service,
+		log:     log,
+	}
+}
+
+// convertToSoDConstraintResponse 将 entity.SoDConstraint 转换为 response.SoDConstraintResponse
+func convertToSoDConstraintResponse(constraint *entity.SoDConstraint) response.SoDConstraintResponse {
+	return response.SoDConstraintResponse{
+		ID:          constraint.ID,
+		Name:        constraint.Name,
+		Description: constraint.Description,
+		Type:        constraint.Type,
+		RoleIds:     convertInt64SliceToStringSlice(constraint.RoleIDs),
+		MaxRoles:    constraint.MaxRoles,
+		Status:      constraint.Status,
+		CreatedAt:   constraint.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:   constraint.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+// GetSoDConstraintList 获取职责分离约束列表
+//
+//	@Summary		获取职责分离约束列表
+//	@Description	分页获取职责分离约束列表
+//	@Tags			职责分离
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			page		query		int																		true	"页码"
+//	@Param			pageSize	query		int																		true	"每页数量"
+//	@Param			name		query		string																	false	"约束名称"
+//	@Param			type		query		string																	false	"约束类型 (static、dynamic)"
+//	@Param			status		query		int																		false	"状态 (0:禁用，1:启用)"
+//	@Success		200			{object}	baseRes.Response{data=response.SoDConstraintListResponse,msg=string}	"约束列表"
+//	@Failure		400			{object}	map[string]string														"请求参数错误"
+//	@Failure		401			{object}	map[string]string														"未授权"
+//	@Router			/api/admin/sod-constraints [get]
+func (c *SoDController) GetSoDConstraintList(ctx *gin.Context) {
+	var req request.GetSoDConstraintListRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		c.log.Error("请求参数错误", "error", err)
+		baseRes.FailWithMessage("请求参数错误", ctx)
+		return
+	}
+
+	filters := make(map[string]interface{})
+	if req.Name != "" {
+		filters["name"] = req.Name
+	}
+	if req.Type != "" {
+		filters["type"] = req.Type
+	}
+	if req.Status != nil {
+		filters["status"] = *req.Status
+	}
+
+	constraints, total, err := c.service.GetConstraintList(req.Page, req.PageSize, filters)
+	if err != nil {
+		c.log.Error("获取职责分离约束列表失败", "error", err)
+		baseRes.FailWithMessage("获取职责分离约束列表失败", ctx)
+		return
+	}
+
+	responses := make([]response.SoDConstraintResponse, 0, len(constraints))
+	for _, constraint := range constraints {
+		responses = append(responses, convertToSoDConstraintResponse(constraint))
+	}
+
+	baseRes.OkWithDetailed(response.SoDConstraintListResponse{
+		PageResult: baseRes.PageResult{
+			List:     responses,
+			Total:    total,
+			Page:     req.Page,
+			PageSize: req.PageSize,
+		},
+		List: responses,
+	}, "获取职责分离约束列表成功", ctx)
+}
+
+// GetSoDConstraintByID 获取职责分离约束详情
+//
+//	@Summary		获取职责分离约束详情
+//	@Description	根据 ID 获取职责分离约束详情
+//	@Tags			职责分离
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string																true	"约束 ID"
+//	@Success		200	{object}	baseRes.Response{data=response.SoDConstraintResponse,msg=string}	"约束详情"
+//	@Failure		400	{object}	map[string]string													"请求参数错误"
+//	@Failure		401	{object}	map[string]string													"未授权"
+//	@Router			/api/admin/sod-constraints/{id} [get]
+func (c *SoDController) GetSoDConstraintByID(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		baseRes.FailWithMessage("无效的 ID 格式", ctx)
+		return
+	}
+
+	constraint, err := c.service.GetConstraint(id)
+	if err != nil {
+		baseRes.FailWithMessage(err.Error(), ctx)
+		return
+	}
+
+	baseRes.OkWithDetailed(convertToSoDConstraintResponse(constraint), "获取职责分离约束详情成功", ctx)
+}
+
+// CreateSoDConstraint 创建职责分离约束
+//
+//	@Summary		创建职责分离约束
+//	@Description	静态约束限制用户最多同时拥有角色集合中的 maxRoles 个，动态约束限制同一会话中最多激活其中 maxRoles 个；maxRoles 为 1 即角色互斥
+//	@Tags			职责分离
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			data	body		request.CreateSoDConstraintRequest									true	"约束信息"
+//	@Success		200		{object}	baseRes.Response{data=response.SoDConstraintResponse,msg=string}	"创建成功"
+//	@Failure		400		{object}	map[string]string													"请求参数错误"
+//	@Failure		401		{object}	map[string]string													"未授权"
+//	@Router			/api/admin/sod-constraints [post]
+func (c *SoDController) CreateSoDConstraint(ctx *gin.Context) {
+	var req request.CreateSoDConstraintRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		baseRes.FailWithMessage("请求参数错误", ctx)
+		return
+	}
+
+	operatorID, err := getCurrentUserID(ctx)
+	if err != nil {
+		baseRes.NoAuth(err.Error(), ctx)
+		return
+	}
+
+	roleIDs, err := convertStringSliceToInt64Slice(req.RoleIds)
+	if err != nil {
+		baseRes.FailWithMessage("无效的角色 ID 格式", ctx)
+		return
+	}
+
+	// 提供默认值：maxRoles=1（互斥），status=1（启用）
+	maxRoles := 1
+	if req.MaxRoles > 0 {
+		maxRoles = req.MaxRoles
+	}
+	status := 1
+	if req.Status != nil {
+		status = *req.Status
+	}
+
+	constraint := &entity.SoDConstraint{
+		Name:        req.Name,
+		Description: req.Description,
+		Type:        req.Type,
+		RoleIDs:     roleIDs,
+		MaxRoles:    maxRoles,
+		Status:      status,
+		CreatedBy:   operatorID,
+		UpdatedBy:   operatorID,
+	}
+	if err := c.service.CreateConstraint(constraint); err != nil {
+		baseRes.FailWithMessage(err.Error(), ctx)
+		return
+	}
+
+	baseRes.OkWithDetailed(convertToSoDConstraintResponse(constraint), "创建职责分离约束成功", ctx)
+}
+
+// UpdateSoDConstraint 更新职责分离约束
+//
+//	@Summary		更新职责分离约束
+//	@Description	更新职责分离约束，只影响之后的角色分配和切换，已有的违规可通过违规报告查询
+//	@Tags			职责分离
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		string								true	"约束 ID"
+//	@Param			data	body		request.UpdateSoDConstraintRequest	true	"约束信息"
+//	@Success		200		{object}	baseRes.Response{msg=string}		"更新成功"
+//	@Failure		400		{object}	map[string]string					"请求参数错误"
+//	@Failure		401		{object}	map[string]string					"未授权"
+//	@Router			/api/admin/sod-constraints/{id} [put]
+func (c *SoDController) UpdateSoDConstraint(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		baseRes.FailWithMessage("无效的 ID 格式", ctx)
+		return
+	}
+
+	var req request.UpdateSoDConstraintRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		baseRes.FailWithMessage("请求参数错误", ctx)
+		return
+	}
+
+	operatorID, err := getCurrentUserID(ctx)
+	if err != nil {
+		baseRes.NoAuth(err.Error(), ctx)
+		return
+	}
+
+	roleIDs, err := convertStringSliceToInt64Slice(req.RoleIds)
+	if err != nil {
+		baseRes.FailWithMessage("无效的角色 ID 格式", ctx)
+		return
+	}
+
+	if err := c.service.UpdateConstraint(&entity.SoDConstraint{
+		ID:          id,
+		Name:        req.Name,
+		Description: req.Description,
+		Type:        req.Type,
+		RoleIDs:     roleIDs,
+		MaxRoles:    req.MaxRoles,
+		Status:      req.Status,
+		UpdatedBy:   operatorID,
+	}); err != nil {
+		baseRes.FailWithMessage(err.Error(), ctx)
+		return
+	}
+
+	baseRes.OkWithMessage("更新职责分离约束成功", ctx)
+}
+
+// DeleteSoDConstraint 删除职责分离约束
+//
+//	@Summary		删除职责分离约束
+//	@Description	删除职责分离约束，删除后立即生效
+//	@Tags			职责分离
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string							true	"约束 ID"
+//	@Success		200	{object}	baseRes.Response{msg=string}	"删除成功"
+//	@Failure		400	{object}	map[string]string				"请求参数错误"
+//	@Failure		401	{object}	map[string]string				"未授权"
+//	@Router			/api/admin/sod-constraints/{id} [delete]
+func (c *SoDController) DeleteSoDConstraint(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		baseRes.FailWithMessage("无效的 ID 格式", ctx)
+		return
+	}
+
+	if err := c.service.DeleteConstraint(id); err != nil {
+		baseRes.FailWithMessage(err.Error(), ctx)
+		return
+	}
+
+	baseRes.OkWithMessage("删除职责分离约束成功", ctx)
+}
+
+// GetSoDViolations 获取职责分离违规报告
+//
+//	@Summary		获取职责分离违规报告
+//	@Description	列出现有角色分配中违反启用的静态约束的用户，通常是约束创建前已存在的分配；动态约束只限制会话内激活，不产生违规
+//	@Tags			职责分离
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	baseRes.Response{data=[]response.SoDViolationResponse,msg=string}	"违规列表"
+//	@Failure		401	{object}	map[string]string													"未授权"
+//	@Router			/api/admin/sod-constraints/violations [get]
+func (c *SoDController) GetSoDViolations(ctx *gin.Context) {
+	violations, err := c.service.GetViolations()
+	if err != nil {
+		baseRes.FailWithMessage(err.Error(), ctx)
+		return
+	}
+
+	responses := make([]response.SoDViolationResponse, 0, len(violations))
+	for _, violation := range violations {
+		responses = append(responses, response.SoDViolationResponse{
+			ConstraintID:   violation.Constraint.ID,
+			ConstraintName: violation.Constraint.Name,
+			MaxRoles:       violation.Constraint.MaxRoles,
+			UserID:         violation.UserID,
+			Username:       violation.Username,
+			RoleIds:        convertInt64SliceToStringSlice(violation.RoleIDs),
+		})
+	}
+
+	baseRes.OkWithDetailed(responses, "获取职责分离违规报告成功", ctx)
+}
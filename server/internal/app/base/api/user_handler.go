@@ -1,6 +1,7 @@
 package baseapi
 
 import (
+	"errors"
 	"strconv"
 	"time"
 
@@ -10,6 +11,7 @@ import (
 	"github.com/ix-pay/ixpay-pro/internal/dto/base/request"
 	"github.com/ix-pay/ixpay-pro/internal/dto/base/response"
 	"github.com/ix-pay/ixpay-pro/internal/infrastructure/observability/logger"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/security/captcha"
 	"github.com/ix-pay/ixpay-pro/internal/utils/common/baseRes"
 )
 
@@ -109,8 +111,12 @@ func (c *UserController) Register(ctx *gin.Context) {
 		return
 	}
 
-	user, err := c.service.Register(req.Username, req.Password, req.Email)
+	user, err := c.service.Register(req.Username, req.Password, req.Email, req.CaptchaId, req.Captcha, ctx.ClientIP())
 	if err != nil {
+		if captcha.IsCaptchaError(err) {
+			baseRes.FailWithMessage(err.Error(), ctx)
+			return
+		}
 		baseRes.FailWithMessage("用户注册失败", ctx)
 		return
 	}
@@ -243,7 +249,7 @@ func (c *UserController) GetUserInfo(ctx *gin.Context) {
 // GetUserList 获取用户列表
 //
 //	@Summary		获取用户列表
-//	@Description	获取用户列表（支持分页和筛选）
+//	@Description	获取用户列表（支持分页和筛选），按当前角色的数据权限过滤
 //	@Tags			用户管理
 //	@Accept			json
 //	@Produce		json
@@ -260,7 +266,7 @@ func (c *UserController) GetUserInfo(ctx *gin.Context) {
 //	@Router			/api/admin/user [get]
 func (c *UserController) GetUserList(ctx *gin.Context) {
 	// 检查用户是否已登录
-	_, exists := ctx.Get("userID")
+	exists := isAuthenticated(ctx)
 	if !exists {
 		c.log.Error("未登录")
 		baseRes.NoAuth("未登录", ctx)
@@ -289,7 +295,7 @@ func (c *UserController) GetUserList(ctx *gin.Context) {
 		filters["status"] = *req.Status
 	}
 
-	users, total, err := c.service.GetUserList(req.Page, req.PageSize, filters)
+	users, total, err := c.service.GetUserList(req.Page, req.PageSize, withDataScope(ctx, filters))
 	if err != nil {
 		baseRes.FailWithMessage(err.Error(), ctx)
 		return
@@ -335,7 +341,7 @@ func (c *UserController) CreateUser(ctx *gin.Context) {
 	}
 
 	// 获取当前登录用户ID作为创建者
-	createdBy, exists := ctx.Get("userID")
+	createdBy, exists := getOperator(ctx)
 	if !exists {
 		c.log.Error("未登录")
 		baseRes.NoAuth("未登录", ctx)
@@ -363,7 +369,14 @@ func (c *UserController) CreateUser(ctx *gin.Context) {
 			baseRes.FailWithMessage("角色 ID 格式错误", ctx)
 			return
 		}
-		if err := c.service.UpdateUserRoles(user.ID, roleIDs); err != nil {
+		createdByID, _ := strconv.ParseInt(createdBy.(string), 10, 64)
+		err = c.service.UpdateUserRoles(user.ID, roleIDs, createdByID)
+		var pending *service.PendingApprovalError
+		if errors.As(err, &pending) {
+			baseRes.OkWithDetailed(convertToUserResponse(user), "添加用户成功，部分角色分配已提交审批", ctx)
+			return
+		}
+		if err != nil {
 			baseRes.FailWithMessage("分配用户角色失败", ctx)
 			return
 		}
@@ -397,7 +410,7 @@ func (c *UserController) UpdateUserInfo(ctx *gin.Context) {
 	}
 
 	// 检查用户是否已登录
-	updatedBy, exists := ctx.Get("userID")
+	updatedBy, exists := getOperator(ctx)
 	if !exists {
 		c.log.Error("未登录")
 		baseRes.NoAuth("未登录", ctx)
@@ -418,6 +431,10 @@ func (c *UserController) UpdateUserInfo(ctx *gin.Context) {
 		baseRes.FailWithMessage("获取用户信息失败", ctx)
 		return
 	}
+	if !currentDataScope(ctx).CanAccess(user.ID, user.DepartmentID) {
+		baseRes.FailWithMessage(errUserOutOfDataScope.Error(), ctx)
+		return
+	}
 
 	// 更新用户信息
 	if req.Nickname != "" {
@@ -462,7 +479,13 @@ func (c *UserController) UpdateUserInfo(ctx *gin.Context) {
 			baseRes.FailWithMessage("角色 ID 格式错误", ctx)
 			return
 		}
-		if err := c.service.UpdateUserRoles(user.ID, roleIDs); err != nil {
+		err = c.service.UpdateUserRoles(user.ID, roleIDs, updatedByInt)
+		var pending *service.PendingApprovalError
+		if errors.As(err, &pending) {
+			baseRes.OkWithDetailed(convertToUserResponse(user), "更新用户信息成功，部分角色变更已提交审批", ctx)
+			return
+		}
+		if err != nil {
 			baseRes.FailWithMessage("更新用户角色失败", ctx)
 			return
 		}
@@ -503,6 +526,11 @@ func (c *UserController) DeleteUser(ctx *gin.Context) {
 		return
 	}
 
+	if err := c.checkUserDataScope(ctx, userID); err != nil {
+		baseRes.FailWithMessage(err.Error(), ctx)
+		return
+	}
+
 	if err := c.service.DeleteUser(userID); err != nil {
 		baseRes.FailWithMessage(err.Error(), ctx)
 		return
@@ -564,13 +592,13 @@ func (c *UserController) ChangePassword(ctx *gin.Context) {
 // ResetPassword 重置密码
 //
 //	@Summary		重置密码
-//	@Description	重置用户密码为默认密码123456（管理员权限）
+//	@Description	重置用户密码（管理员权限），未指定新密码时自动生成符合密码策略的临时密码，用户首次登录需修改密码
 //	@Tags			用户管理
 //	@Accept			json
 //	@Produce		json
 //	@Security		BearerAuth
 //	@Param			reset_password	body		request.ResetPasswordRequest	true	"重置密码请求参数"
-//	@Success		200				{object}	baseRes.Response{msg=string}	"重置成功"
+//	@Success		200				{object}	baseRes.Response{data=response.ResetPasswordResponse,msg=string}	"重置成功"
 //	@Failure		400				{object}	map[string]string				"请求参数错误"
 //	@Failure		401				{object}	map[string]string				"未授权"
 //	@Failure		500				{object}	map[string]string				"服务器内部错误"
@@ -584,7 +612,7 @@ func (c *UserController) ResetPassword(ctx *gin.Context) {
 	}
 
 	// 获取当前登录用户 ID 作为修改者
-	updatedBy, exists := ctx.Get("userID")
+	updatedBy, exists := getOperator(ctx)
 	if !exists {
 		c.log.Error("未登录")
 		baseRes.NoAuth("未登录", ctx)
@@ -605,12 +633,18 @@ func (c *UserController) ResetPassword(ctx *gin.Context) {
 		updatedByInt = int64(v)
 	}
 
-	if err := c.service.ResetPassword(userIDInt, "123456", updatedByInt); err != nil {
+	if err := c.checkUserDataScope(ctx, userIDInt); err != nil {
 		baseRes.FailWithMessage(err.Error(), ctx)
 		return
 	}
 
-	baseRes.OkWithMessage("重置密码成功", ctx)
+	password, err := c.service.ResetPassword(userIDInt, req.NewPassword, updatedByInt)
+	if err != nil {
+		baseRes.FailWithMessage(err.Error(), ctx)
+		return
+	}
+
+	baseRes.OkWithDetailed(response.ResetPasswordResponse{Password: password}, "重置密码成功", ctx)
 }
 
 // GetUserSettings 获取用户设置
@@ -762,7 +796,7 @@ func (c *UserController) SwitchRole(ctx *gin.Context) {
 	}
 
 	// 调用服务层切换角色
-	if err := c.service.SwitchRole(userIDInt, roleIDInt); err != nil {
+	if err := c.service.SwitchRole(userIDInt, roleIDInt, ctx.GetString("sessionID")); err != nil {
 		baseRes.FailWithMessage(err.Error(), ctx)
 		return
 	}
@@ -811,8 +845,22 @@ func (c *UserController) SetUserAuthority(ctx *gin.Context) {
 		return
 	}
 
-	// 调用服务层设置用户权限
-	if err := c.service.SetUserAuthority(userIDInt, roleIDInt); err != nil {
+	if err := c.checkUserDataScope(ctx, userIDInt); err != nil {
+		baseRes.FailWithMessage(err.Error(), ctx)
+		return
+	}
+
+	operatorID, err := getCurrentUserID(ctx)
+	if err != nil {
+		baseRes.NoAuth(err.Error(), ctx)
+		return
+	}
+
+	// 调用服务层设置用户权限，需要审批的角色返回申请内容
+	if err := c.service.SetUserAuthority(userIDInt, roleIDInt, operatorID); err != nil {
+		if respondPendingApproval(err, ctx) {
+			return
+		}
 		baseRes.FailWithMessage(err.Error(), ctx)
 		return
 	}
@@ -866,8 +914,22 @@ func (c *UserController) SetUserAuthorities(ctx *gin.Context) {
 		roleIDs[i] = roleID
 	}
 
-	// 调用服务层设置用户权限
-	if err := c.service.SetUserAuthorities(userIDInt, roleIDs); err != nil {
+	if err := c.checkUserDataScope(ctx, userIDInt); err != nil {
+		baseRes.FailWithMessage(err.Error(), ctx)
+		return
+	}
+
+	operatorID, err := getCurrentUserID(ctx)
+	if err != nil {
+		baseRes.NoAuth(err.Error(), ctx)
+		return
+	}
+
+	// 调用服务层设置用户权限，需要审批的角色返回申请内容
+	if err := c.service.SetUserAuthorities(userIDInt, roleIDs, operatorID); err != nil {
+		if respondPendingApproval(err, ctx) {
+			return
+		}
 		baseRes.FailWithMessage(err.Error(), ctx)
 		return
 	}
@@ -875,3 +937,18 @@ func (c *UserController) SetUserAuthorities(ctx *gin.Context) {
 	c.log.Info("设置用户权限成功", "user_id", userIDInt, "role_ids", roleIDs)
 	baseRes.OkWithMessage("设置成功", ctx)
 }
+
+// errUserOutOfDataScope 目标用户不在当前数据权限范围内
+var errUserOutOfDataScope = errors.New("无权操作数据权限范围外的用户")
+
+// checkUserDataScope 检查按 ID 操作的目标用户是否在当前数据权限范围内
+func (c *UserController) checkUserDataScope(ctx *gin.Context, userID int64) error {
+	user, err := c.service.GetUserInfo(userID)
+	if err != nil {
+		return errors.New("用户不存在")
+	}
+	if !currentDataScope(ctx).CanAccess(user.ID, user.DepartmentID) {
+		return errUserOutOfDataScope
+	}
+	return nil
+}
@@ -1,58 +1,90 @@
 package base
 
 import (
+	"strings"
+
 	baseapi "github.com/ix-pay/ixpay-pro/internal/app/base/api"
 	"github.com/ix-pay/ixpay-pro/internal/app/base/middleware"
 	"github.com/ix-pay/ixpay-pro/internal/app/base/migrations"
 	"github.com/ix-pay/ixpay-pro/internal/config"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/entity"
 	"github.com/ix-pay/ixpay-pro/internal/domain/base/repo"
 	"github.com/ix-pay/ixpay-pro/internal/domain/base/seed"
 	"github.com/ix-pay/ixpay-pro/internal/domain/base/service"
 	"github.com/ix-pay/ixpay-pro/internal/infrastructure/observability/logger"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/observability/redact"
 	"github.com/ix-pay/ixpay-pro/internal/infrastructure/persistence/cache"
 	"github.com/ix-pay/ixpay-pro/internal/infrastructure/persistence/database"
 	"github.com/ix-pay/ixpay-pro/internal/infrastructure/security/auth"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/support/apidoc"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/support/task"
 	infraMiddleware "github.com/ix-pay/ixpay-pro/internal/infrastructure/transport/middleware"
+	repository "github.com/ix-pay/ixpay-pro/internal/persistence/base"
 
 	"github.com/gin-gonic/gin"
 )
 
+const (
+	apiRoutePrefix       = "/api/admin/" // 同步到 API 表的路由前缀
+	apiRouteDefaultGroup = "未分组"         // swagger 文档中没有标签时使用的分组
+)
+
 // AppBase 应用程序结构
 type AppBase struct {
-	router                  *gin.Engine
-	db                      *database.PostgresDB
-	auth                    *auth.JWTAuth
-	permissions             *auth.PermissionManager
-	logger                  logger.Logger
-	config                  *config.Config
-	authController          *baseapi.AuthController
-	userController          *baseapi.UserController
-	taskController          *baseapi.TaskController
-	apiController           *baseapi.APIController
-	menuController          *baseapi.MenuController
-	roleController          *baseapi.RoleController
-	btnPermController       *baseapi.BtnPermController
-	configController        *baseapi.ConfigController
-	dictController          *baseapi.DictController
-	operationLogController  *baseapi.OperationLogController
-	departmentController    *baseapi.DepartmentController
-	positionController      *baseapi.PositionController
-	noticeController        *baseapi.NoticeController
-	loginLogController      *baseapi.LoginLogController
-	onlineUserController    *baseapi.OnlineUserController
-	monitorController       *baseapi.MonitorController
-	permissionLogController *baseapi.PermissionLogController
-	userRepo                repo.UserRepository
-	apiRepo                 repo.APIRepository
-	roleRepo                repo.RoleRepository
-	menuRepo                repo.MenuRepository
-	configRepo              repo.ConfigRepository
-	dictRepo                repo.DictRepository
-	permissionService       *service.PermissionService
-	operationLogService     *service.OperationLogService
-	onlineUserService       *service.OnlineUserService
-	taskExecutionLogRepo    repo.TaskExecutionLogRepository // 任务执行日志仓库
-	cache                   cache.Cache
+	router                      *gin.Engine
+	db                          *database.PostgresDB
+	auth                        *auth.JWTAuth
+	permissions                 *auth.PermissionManager
+	logger                      logger.Logger
+	config                      *config.Config
+	authController              *baseapi.AuthController
+	userController              *baseapi.UserController
+	taskController              *baseapi.TaskController
+	apiController               *baseapi.APIController
+	menuController              *baseapi.MenuController
+	roleController              *baseapi.RoleController
+	btnPermController           *baseapi.BtnPermController
+	configController            *baseapi.ConfigController
+	dictController              *baseapi.DictController
+	operationLogController      *baseapi.OperationLogController
+	departmentController        *baseapi.DepartmentController
+	positionController          *baseapi.PositionController
+	noticeController            *baseapi.NoticeController
+	loginLogController          *baseapi.LoginLogController
+	onlineUserController        *baseapi.OnlineUserController
+	monitorController           *baseapi.MonitorController
+	permissionLogController     *baseapi.PermissionLogController
+	passwordResetController     *baseapi.PasswordResetController
+	serviceAccountController    *baseapi.ServiceAccountController
+	ldapController              *baseapi.LDAPController
+	oidcController              *baseapi.OIDCController
+	identityProviderController  *baseapi.IdentityProviderController
+	ipPolicyController          *baseapi.IPPolicyController
+	permissionRuleController    *baseapi.PermissionRuleController
+	permissionExplainController *baseapi.PermissionExplainController
+	roleGrantController         *baseapi.RoleGrantController
+	permissionChangeController  *baseapi.PermissionChangeController
+	policyBundleController      *baseapi.PolicyBundleController
+	sodController               *baseapi.SoDController
+	userRepo                    repo.UserRepository
+	apiRepo                     repo.APIRepository
+	roleRepo                    repo.RoleRepository
+	menuRepo                    repo.MenuRepository
+	configRepo                  repo.ConfigRepository
+	dictRepo                    repo.DictRepository
+	permissionService           *service.PermissionService
+	dataScopeService            *service.DataScopeService
+	apiService                  *service.APIService
+	permissionDecisionService   *service.PermissionDecisionService
+	operationLogService         *service.OperationLogService
+	onlineUserService           *service.OnlineUserService
+	serviceAccountService       *service.ServiceAccountService
+	ipPolicyService             *service.IPPolicyService
+	loginLogService             *service.LoginLogService
+	roleGrantService            *service.RoleGrantService
+	taskExecutionLogRepo        repo.TaskExecutionLogRepository // 任务执行日志仓库
+	cache                       cache.Cache
+	redactor                    *redact.Redactor // 操作日志脱敏引擎
 }
 
 // NewAppBase 创建应用程序实例
@@ -79,6 +111,18 @@ func NewAppBase(
 	onlineUserController *baseapi.OnlineUserController,
 	monitorController *baseapi.MonitorController,
 	permissionLogController *baseapi.PermissionLogController,
+	passwordResetController *baseapi.PasswordResetController,
+	serviceAccountController *baseapi.ServiceAccountController,
+	ldapController *baseapi.LDAPController,
+	oidcController *baseapi.OIDCController,
+	identityProviderController *baseapi.IdentityProviderController,
+	ipPolicyController *baseapi.IPPolicyController,
+	permissionRuleController *baseapi.PermissionRuleController,
+	permissionExplainController *baseapi.PermissionExplainController,
+	roleGrantController *baseapi.RoleGrantController,
+	permissionChangeController *baseapi.PermissionChangeController,
+	policyBundleController *baseapi.PolicyBundleController,
+	sodController *baseapi.SoDController,
 	userRepo repo.UserRepository,
 	apiRepo repo.APIRepository,
 	roleRepo repo.RoleRepository,
@@ -87,44 +131,72 @@ func NewAppBase(
 	dictRepo repo.DictRepository,
 	operationLogService *service.OperationLogService,
 	onlineUserService *service.OnlineUserService,
+	serviceAccountService *service.ServiceAccountService,
+	ipPolicyService *service.IPPolicyService,
+	dataScopeService *service.DataScopeService,
+	apiService *service.APIService,
+	permissionDecisionService *service.PermissionDecisionService,
+	loginLogService *service.LoginLogService,
+	roleGrantService *service.RoleGrantService,
 	taskExecutionLogRepo repo.TaskExecutionLogRepository,
 	cache cache.Cache,
+	redactor *redact.Redactor,
 ) (*AppBase, error) {
 	// 创建应用实例
 	app := &AppBase{
-		router:                  nil,
-		db:                      db,
-		auth:                    auth,
-		permissions:             permissions,
-		logger:                  log,
-		config:                  config,
-		authController:          authController,
-		userController:          userController,
-		taskController:          taskController,
-		apiController:           apiController,
-		menuController:          menuController,
-		roleController:          roleController,
-		btnPermController:       btnPermController,
-		configController:        configController,
-		dictController:          dictController,
-		operationLogController:  operationLogController,
-		departmentController:    departmentController,
-		positionController:      positionController,
-		noticeController:        noticeController,
-		loginLogController:      loginLogController,
-		onlineUserController:    onlineUserController,
-		monitorController:       monitorController,
-		permissionLogController: permissionLogController,
-		userRepo:                userRepo,
-		apiRepo:                 apiRepo,
-		roleRepo:                roleRepo,
-		menuRepo:                menuRepo,
-		configRepo:              configRepo,
-		dictRepo:                dictRepo,
-		operationLogService:     operationLogService,
-		onlineUserService:       onlineUserService,
-		taskExecutionLogRepo:    taskExecutionLogRepo,
-		cache:                   cache,
+		router:                      nil,
+		db:                          db,
+		auth:                        auth,
+		permissions:                 permissions,
+		logger:                      log,
+		config:                      config,
+		authController:              authController,
+		userController:              userController,
+		taskController:              taskController,
+		apiController:               apiController,
+		menuController:              menuController,
+		roleController:              roleController,
+		btnPermController:           btnPermController,
+		configController:            configController,
+		dictController:              dictController,
+		operationLogController:      operationLogController,
+		departmentController:        departmentController,
+		positionController:          positionController,
+		noticeController:            noticeController,
+		loginLogController:          loginLogController,
+		onlineUserController:        onlineUserController,
+		monitorController:           monitorController,
+		permissionLogController:     permissionLogController,
+		passwordResetController:     passwordResetController,
+		serviceAccountController:    serviceAccountController,
+		ldapController:              ldapController,
+		oidcController:              oidcController,
+		identityProviderController:  identityProviderController,
+		ipPolicyController:          ipPolicyController,
+		permissionRuleController:    permissionRuleController,
+		permissionExplainController: permissionExplainController,
+		roleGrantController:         roleGrantController,
+		permissionChangeController:  permissionChangeController,
+		policyBundleController:      policyBundleController,
+		sodController:               sodController,
+		userRepo:                    userRepo,
+		apiRepo:                     apiRepo,
+		roleRepo:                    roleRepo,
+		menuRepo:                    menuRepo,
+		configRepo:                  configRepo,
+		dictRepo:                    dictRepo,
+		operationLogService:         operationLogService,
+		onlineUserService:           onlineUserService,
+		serviceAccountService:       serviceAccountService,
+		ipPolicyService:             ipPolicyService,
+		dataScopeService:            dataScopeService,
+		apiService:                  apiService,
+		permissionDecisionService:   permissionDecisionService,
+		loginLogService:             loginLogService,
+		roleGrantService:            roleGrantService,
+		taskExecutionLogRepo:        taskExecutionLogRepo,
+		cache:                       cache,
+		redactor:                    redactor,
 	}
 	return app, nil
 }
@@ -148,7 +220,7 @@ func (a *AppBase) setupMiddleware() {
 	a.router.Use(infraMiddleware.CircuitBreakerMiddleware("BaseService", circuitBreakerSettings))
 
 	// 添加操作日志中间件
-	a.router.Use(middleware.OperationLogMiddleware(a.operationLogService, a.logger))
+	a.router.Use(middleware.OperationLogMiddleware(a.operationLogService, a.redactor, a.logger))
 
 	// 添加Prometheus指标导出路由
 	a.router.GET("/metrics", infraMiddleware.PrometheusHandler())
@@ -180,6 +252,68 @@ func (a *AppBase) initializeSeedData() {
 	a.logger.Info("种子数据初始化完成")
 }
 
+// syncAPIRoutes 将管理后台已注册的 gin 路由同步到 API 表
+// 新路由的描述和分组取 swagger 文档的摘要和第一个标签，文档中没有的路由使用路径作为描述
+func (a *AppBase) syncAPIRoutes() {
+	docs, err := apidoc.Load()
+	if err != nil {
+		a.logger.Warn("读取 swagger 文档失败，新路由不填充描述和分组", "error", err)
+	}
+
+	routes := make([]*entity.API, 0)
+	for _, route := range a.router.Routes() {
+		if !strings.HasPrefix(route.Path, apiRoutePrefix) {
+			continue
+		}
+		api := &entity.API{Path: route.Path, Method: route.Method, Description: route.Path, Group: apiRouteDefaultGroup}
+		if op, ok := docs.Lookup(route.Method, route.Path); ok {
+			if op.Summary != "" {
+				api.Description = truncateRunes(op.Summary, 255)
+			}
+			if len(op.Tags) > 0 {
+				api.Group = truncateRunes(op.Tags[0], 50)
+			}
+		}
+		routes = append(routes, api)
+	}
+
+	report, err := a.apiService.SyncRoutes(apiRoutePrefix, routes)
+	if err != nil {
+		a.logger.Error("同步 API 路由失败", "error", err)
+		return
+	}
+	for _, api := range report.Added {
+		a.logger.Info("登记新 API 路由，等待分配给角色", "path", api.Path, "method", api.Method, "group", api.Group)
+	}
+	for _, api := range report.Orphaned {
+		a.logger.Warn("API 路由已不存在，已标记为失效", "id", api.ID, "path", api.Path, "method", api.Method)
+	}
+}
+
+// truncateRunes 按字符截断字符串，避免超过列宽
+func truncateRunes(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n])
+}
+
+// setupPermissionDecisions 注册权限相关表的写入监听，使权限判定缓存和数据权限缓存随数据变更失效，并预计算所有角色的有效权限集合
+func (a *AppBase) setupPermissionDecisions() {
+	if err := database.WatchTables(a.db, "permission", repository.PermissionTables, a.permissionDecisionService.OnTableChange); err != nil {
+		a.logger.Error("注册权限表变更监听失败", "error", err)
+	}
+	if err := database.WatchTables(a.db, "data_scope", repository.DataScopeTables, a.dataScopeService.OnTableChange); err != nil {
+		a.logger.Error("注册数据权限表变更监听失败", "error", err)
+	}
+
+	a.permissionDecisionService.Invalidate("应用启动")
+	if err := a.permissionDecisionService.Warmup(); err != nil {
+		a.logger.Error("预计算角色权限失败", "error", err)
+	}
+}
+
 // 初始化基础应用
 func (a *AppBase) Init(router *gin.Engine) {
 	a.logger.Info("初始化基础应用")
@@ -202,9 +336,41 @@ func (a *AppBase) Init(router *gin.Engine) {
 		a.logger.Info("配置禁用初始化种子数据")
 	}
 
+	// 同步已注册的路由到 API 表，在种子数据之后执行，种子数据中维护的描述和授权类型优先
+	if a.config.Server.UpdateRoutesOnStart {
+		a.syncAPIRoutes()
+	}
+
+	// 监听权限相关表的写入并预计算角色权限，在种子数据和路由同步之后注册，启动阶段的批量写入只失效一次
+	a.setupPermissionDecisions()
+
 	// 设置任务执行日志仓库到任务管理器
 	if a.taskExecutionLogRepo != nil {
 		a.taskController.GetManager().SetExecutionLogRepository(a.taskExecutionLogRepo)
 		a.logger.Info("任务执行日志仓库已设置到任务管理器")
 	}
+
+	// 注册登录地点回填任务，为接入 IP 地址库前记录的登录日志补充登录地点
+	if a.config.GeoIP.DBPath != "" && a.config.GeoIP.BackfillCron != "" {
+		backfillTask := service.NewLoginPlaceBackfillTask(a.loginLogService, a.logger)
+		if err := a.taskController.GetManager().AddScheduledTask(&task.ScheduledTask{
+			Task:     backfillTask,
+			CronExpr: a.config.GeoIP.BackfillCron,
+			Group:    backfillTask.GetGroup(),
+		}); err != nil {
+			a.logger.Error("注册登录地点回填任务失败", "error", err)
+		}
+	}
+
+	// 注册限时角色生效和到期检查任务
+	if a.config.RoleGrant.ExpiryCron != "" {
+		expiryTask := service.NewRoleGrantExpiryTask(a.roleGrantService, a.logger)
+		if err := a.taskController.GetManager().AddScheduledTask(&task.ScheduledTask{
+			Task:     expiryTask,
+			CronExpr: a.config.RoleGrant.ExpiryCron,
+			Group:    expiryTask.GetGroup(),
+		}); err != nil {
+			a.logger.Error("注册限时角色到期检查任务失败", "error", err)
+		}
+	}
 }
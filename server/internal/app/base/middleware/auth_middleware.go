@@ -8,6 +8,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/ix-pay/ixpay-pro/internal/domain/base/entity"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/service"
 	"github.com/ix-pay/ixpay-pro/internal/infrastructure/observability/logger"
 	"github.com/ix-pay/ixpay-pro/internal/infrastructure/persistence/cache"
 	"github.com/ix-pay/ixpay-pro/internal/infrastructure/security/auth"
@@ -15,7 +16,9 @@ import (
 )
 
 // AuthMiddleware 认证中间件
-func AuthMiddleware(jwtAuth *auth.JWTAuth, cacheClient cache.Cache, log logger.Logger) gin.HandlerFunc {
+// 支持 Bearer {token}（用户 JWT）和 ApiKey {key}（服务账号）两种认证方式
+// 令牌携带会话 ID 时校验会话是否仍然有效，并刷新会话最后活跃时间
+func AuthMiddleware(jwtAuth *auth.JWTAuth, cacheClient cache.Cache, serviceAccountService *service.ServiceAccountService, onlineUserService *service.OnlineUserService, log logger.Logger) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// 从 Authorization 头获取令牌
 		authHeader := c.GetHeader("Authorization")
@@ -27,8 +30,12 @@ func AuthMiddleware(jwtAuth *auth.JWTAuth, cacheClient cache.Cache, log logger.L
 
 		// 检查令牌格式
 		parts := strings.SplitN(authHeader, " ", 2)
+		if len(parts) == 2 && parts[0] == "ApiKey" && serviceAccountService != nil {
+			authenticateAPIKey(c, serviceAccountService, parts[1], log)
+			return
+		}
 		if !(len(parts) == 2 && parts[0] == "Bearer") {
-			httpresponse.UnauthorizedResponse(c, "Authorization 头格式必须为 Bearer {token}")
+			httpresponse.UnauthorizedResponse(c, "Authorization 头格式必须为 Bearer {token} 或 ApiKey {key}")
 			c.Abort()
 			return
 		}
@@ -56,11 +63,27 @@ func AuthMiddleware(jwtAuth *auth.JWTAuth, cacheClient cache.Cache, log logger.L
 			return
 		}
 
+		// 检查会话是否已退出、被下线或过期
+		if claims.SessionID != "" {
+			active, err := onlineUserService.TouchSession(claims.SessionID)
+			if err != nil {
+				httpresponse.InternalServerErrorResponse(c, "检查会话状态失败")
+				c.Abort()
+				return
+			}
+			if !active {
+				httpresponse.UnauthorizedResponse(c, "登录会话已失效，请重新登录")
+				c.Abort()
+				return
+			}
+		}
+
 		// 将用户信息添加到上下文
 		c.Set("userID", claims.UserID)
 		c.Set("userName", claims.Username)
 		c.Set("nickname", claims.Nickname)
 		c.Set("loginType", claims.LoginType)
+		c.Set("sessionID", claims.SessionID)
 		c.Set("claims", claims)
 
 		// 【关键修改】从缓存获取用户的当前角色，实现故障降级策略
@@ -85,6 +108,50 @@ func AuthMiddleware(jwtAuth *auth.JWTAuth, cacheClient cache.Cache, log logger.L
 	}
 }
 
+// authenticateAPIKey 服务账号 API Key 认证
+// 认证通过后按 JWT 登录相同的上下文键写入 claims 和角色信息，服务账号不是用户，ID 保存在 serviceAccountID 而不是 userID，
+// 需要用户身份的处理按 loginType 区分；可通过 X-Role-Id 头指定使用的角色
+func authenticateAPIKey(c *gin.Context, serviceAccountService *service.ServiceAccountService, rawKey string, log logger.Logger) {
+	var roleID int64
+	if roleHeader := c.GetHeader("X-Role-Id"); roleHeader != "" {
+		parsed, err := strconv.ParseInt(roleHeader, 10, 64)
+		if err != nil {
+			httpresponse.UnauthorizedResponse(c, "X-Role-Id 格式无效")
+			c.Abort()
+			return
+		}
+		roleID = parsed
+	}
+
+	principal, err := serviceAccountService.Authenticate(rawKey, c.ClientIP(), roleID)
+	if err != nil {
+		log.Warn("API Key 认证失败", "ip", c.ClientIP(), "error", err)
+		httpresponse.UnauthorizedResponse(c, err.Error())
+		c.Abort()
+		return
+	}
+
+	accountID := strconv.FormatInt(principal.Account.ID, 10)
+	claims := &auth.Claims{
+		Username:  principal.Account.Name,
+		Nickname:  principal.Account.Name,
+		Role:      principal.Role.Code,
+		LoginType: service.LoginTypeAPIKey,
+	}
+
+	c.Set("serviceAccountID", accountID)
+	c.Set("userName", claims.Username)
+	c.Set("nickname", claims.Nickname)
+	c.Set("loginType", claims.LoginType)
+	c.Set("claims", claims)
+	c.Set("role", principal.Role.Code)
+	c.Set("currentRoleId", strconv.FormatInt(principal.Role.ID, 10))
+	c.Set("serviceAccountKeyPrefix", principal.Key.Prefix)
+
+	log.Info("API Key 认证成功", "serviceAccountID", accountID, "keyPrefix", principal.Key.Prefix, "roleCode", principal.Role.Code)
+	c.Next()
+}
+
 // getCurrentRoleFromCache 从缓存获取用户的当前角色，实现故障降级策略
 // 返回：当前角色 ID, 角色来源 (cache/jwt/fallback)
 func getCurrentRoleFromCache(claims *auth.Claims, cacheClient cache.Cache, c *gin.Context, log logger.Logger) (string, string) {
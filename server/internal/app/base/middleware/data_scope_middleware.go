@@ -0,0 +1,50 @@
+package middleware
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/entity"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/service"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/observability/logger"
+)
+
+// DataScopeMiddleware 数据权限中间件
+// 只在上下文中保存数据权限的解析函数，需要数据权限的接口首次使用时才解析，同一请求内只解析一次
+// 不使用数据权限的接口不产生缓存访问
+// 解析失败时按仅本人处理，宁可少返回数据也不越权
+func DataScopeMiddleware(dataScopeService *service.DataScopeService, log logger.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var scope *entity.DataScope
+		c.Set("dataScopeResolver", func() *entity.DataScope {
+			if scope != nil {
+				return scope
+			}
+
+			role := c.GetString("role")
+			if c.GetString("loginType") == service.LoginTypeAPIKey {
+				resolved, err := dataScopeService.ResolveServiceAccount(role)
+				if err != nil {
+					log.Error("解析服务账号数据权限失败，按仅本人处理", "error", err, "role", role)
+					resolved = &entity.DataScope{Scope: entity.DataScopeSelf}
+				}
+				scope = resolved
+				return scope
+			}
+
+			var userID int64
+			if value := c.GetString("userID"); value != "" {
+				userID, _ = strconv.ParseInt(value, 10, 64)
+			}
+			resolved, err := dataScopeService.Resolve(userID, role)
+			if err != nil {
+				log.Error("解析数据权限失败，按仅本人处理", "error", err, "userID", userID, "role", role)
+				resolved = &entity.DataScope{Scope: entity.DataScopeSelf, UserID: userID}
+			}
+			scope = resolved
+			return scope
+		})
+
+		c.Next()
+	}
+}
@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/service"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/observability/logger"
+	httpresponse "github.com/ix-pay/ixpay-pro/internal/infrastructure/transport/http"
+)
+
+// IPPolicyMiddleware IP 访问策略中间件
+// 认证前使用只判定全局策略，认证后使用会同时判定当前角色和用户的策略
+// 客户端 IP 取自 ClientIP，依赖 server.trusted_proxies 正确配置受信任代理
+func IPPolicyMiddleware(ipPolicyService *service.IPPolicyService, log logger.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ip := c.ClientIP()
+
+		var userID int64
+		if value := c.GetString("userID"); value != "" {
+			userID, _ = strconv.ParseInt(value, 10, 64)
+		}
+		role := c.GetString("role")
+
+		decision, err := ipPolicyService.Check(ip, userID, role)
+		if err != nil {
+			log.Error("检查 IP 访问策略失败", "ip", ip, "error", err)
+			httpresponse.InternalServerErrorResponse(c, "检查 IP 访问策略失败")
+			c.Abort()
+			return
+		}
+
+		if !decision.Allowed {
+			userName := c.GetString("userName")
+			userAgent := c.Request.UserAgent()
+			log.Warn("IP 访问策略拒绝请求",
+				"ip", ip,
+				"userID", userID,
+				"role", role,
+				"path", c.Request.URL.Path,
+				"policy", decision.Policy.Name,
+			)
+			go ipPolicyService.RecordDenial(ip, userID, userName, userAgent, decision)
+
+			httpresponse.ForbiddenResponse(c, "当前 IP 不允许访问："+decision.Reason)
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -13,22 +13,36 @@ import (
 	"github.com/ix-pay/ixpay-pro/internal/domain/base/entity"
 	"github.com/ix-pay/ixpay-pro/internal/domain/base/service"
 	"github.com/ix-pay/ixpay-pro/internal/infrastructure/observability/logger"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/observability/redact"
 	auth "github.com/ix-pay/ixpay-pro/internal/infrastructure/security/auth"
 )
 
 // bodyLogWriter 用于捕获响应体
 type bodyLogWriter struct {
 	gin.ResponseWriter
-	body *bytes.Buffer
+	body  *bytes.Buffer
+	limit int // 捕获的最大字节数，超出部分只写出不缓存
 }
 
 func (w bodyLogWriter) Write(b []byte) (int, error) {
-	w.body.Write(b)
+	if remain := w.limit - w.body.Len(); remain > 0 {
+		if len(b) > remain {
+			w.body.Write(b[:remain])
+		} else {
+			w.body.Write(b)
+		}
+	}
 	return w.ResponseWriter.Write(b)
 }
 
+// Unwrap 返回原始 ResponseWriter，供 http.ResponseController 设置写超时等
+func (w bodyLogWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
 // OperationLogMiddleware 操作日志中间件
-func OperationLogMiddleware(operationLogService *service.OperationLogService, log logger.Logger) gin.HandlerFunc {
+// 请求参数和响应结果经脱敏引擎处理并截断后保存，配置为不记录请求体和响应体的接口只保存占位符
+func OperationLogMiddleware(operationLogService *service.OperationLogService, redactor *redact.Redactor, log logger.Logger) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		startTime := time.Now()
 
@@ -39,23 +53,26 @@ func OperationLogMiddleware(operationLogService *service.OperationLogService, lo
 		userAgent := c.Request.UserAgent()
 
 		// 解析请求参数
+		skipBody := redactor.SkipBody(method, path)
 		var params string
-		if method == http.MethodGet || method == http.MethodDelete {
-			params = c.Request.URL.RawQuery
+		if skipBody {
+			params = redact.OmittedBody
+		} else if method == http.MethodGet || method == http.MethodDelete {
+			params = redactor.RedactQuery(c.Request.URL.RawQuery)
 		} else {
 			// 读取请求体
 			bodyBytes, err := io.ReadAll(c.Request.Body)
 			if err != nil {
 				log.Error("读取请求体失败", "error", err)
 			} else {
-				params = string(bodyBytes)
+				params = redactor.RedactBody(bodyBytes)
 				// 重置请求体，以便后续处理
 				c.Request.Body = io.NopCloser(bytes.NewBuffer(bodyBytes))
 			}
 		}
 
 		// 记录响应体
-		blw := &bodyLogWriter{body: bytes.NewBufferString(""), ResponseWriter: c.Writer}
+		blw := &bodyLogWriter{body: bytes.NewBufferString(""), ResponseWriter: c.Writer, limit: redactor.CaptureLimit()}
 		c.Writer = blw
 
 		// 执行请求
@@ -68,6 +85,12 @@ func OperationLogMiddleware(operationLogService *service.OperationLogService, lo
 		statusCode := c.Writer.Status()
 		responseBody := blw.body.String()
 
+		// 响应结果脱敏，错误信息仍从原始响应中解析
+		result := redact.OmittedBody
+		if !skipBody {
+			result = redactor.RedactBody(blw.body.Bytes())
+		}
+
 		// 判断请求是否成功
 		isSuccess := statusCode < 400
 
@@ -138,7 +161,7 @@ func OperationLogMiddleware(operationLogService *service.OperationLogService, lo
 				ClientIP:      clientIP,
 				UserAgent:     userAgent,
 				StatusCode:    statusCode,
-				Result:        responseBody,
+				Result:        result,
 				Duration:      duration.Milliseconds(),
 				ErrorMessage:  errorMessage,
 				IsSuccess:     isSuccess,
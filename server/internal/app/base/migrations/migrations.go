@@ -122,6 +122,8 @@ func MigrateDatabase(db *database.PostgresDB, log logger.Logger) {
 		entry_date VARCHAR(20),
 		last_login_ip VARCHAR(50),
 		last_login_time VARCHAR(50),
+		password_changed_at TIMESTAMP,
+		must_change_password BOOLEAN NOT NULL DEFAULT false,
 		created_by BIGINT NOT NULL DEFAULT 0,
 		updated_by BIGINT NOT NULL DEFAULT 0,
 		deleted_by BIGINT NOT NULL DEFAULT 0,
@@ -750,6 +752,41 @@ func MigrateDatabase(db *database.PostgresDB, log logger.Logger) {
 		log.Info("base_permission_groups 表创建成功")
 	}
 
+	// 创建权限组关联表：权限组-API、权限组-按钮权限、角色-权限组
+	createPermissionGroupRelationsTableSQL := `
+	CREATE TABLE IF NOT EXISTS base_permission_group_apis (
+		group_id BIGINT NOT NULL,
+		route_id BIGINT NOT NULL,
+		PRIMARY KEY (group_id, route_id),
+		FOREIGN KEY (group_id) REFERENCES base_permission_groups(id) ON DELETE CASCADE,
+		FOREIGN KEY (route_id) REFERENCES base_apis(id) ON DELETE CASCADE
+	);
+
+	CREATE TABLE IF NOT EXISTS base_permission_group_btn_perms (
+		group_id BIGINT NOT NULL,
+		btn_perm_id BIGINT NOT NULL,
+		PRIMARY KEY (group_id, btn_perm_id),
+		FOREIGN KEY (group_id) REFERENCES base_permission_groups(id) ON DELETE CASCADE,
+		FOREIGN KEY (btn_perm_id) REFERENCES base_btn_perms(id) ON DELETE CASCADE
+	);
+
+	CREATE TABLE IF NOT EXISTS base_role_permission_groups (
+		role_id BIGINT NOT NULL,
+		group_id BIGINT NOT NULL,
+		PRIMARY KEY (role_id, group_id),
+		FOREIGN KEY (role_id) REFERENCES base_roles(id) ON DELETE CASCADE,
+		FOREIGN KEY (group_id) REFERENCES base_permission_groups(id) ON DELETE CASCADE
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_role_permission_groups_group_id ON base_role_permission_groups(group_id);
+	`
+
+	if err := db.Exec(createPermissionGroupRelationsTableSQL).Error; err != nil {
+		log.Error("创建权限组关联表失败", "error", err)
+	} else {
+		log.Info("权限组关联表创建成功")
+	}
+
 	// 创建公告表
 	createNoticesTableSQL := `
 	CREATE TABLE IF NOT EXISTS base_notices (
@@ -807,6 +844,568 @@ func MigrateDatabase(db *database.PostgresDB, log logger.Logger) {
 		log.Info("base_notice_read_records 表创建成功")
 	}
 
+	// 为已有的 base_users 表补充密码策略字段
+	// 历史用户没有密码修改时间，以迁移时间补齐，开启密码有效期后从迁移时起计算，避免已有用户登录即被要求修改密码
+	alterUsersPasswordPolicySQL := `
+	ALTER TABLE base_users ADD COLUMN IF NOT EXISTS password_changed_at TIMESTAMP;
+	ALTER TABLE base_users ADD COLUMN IF NOT EXISTS must_change_password BOOLEAN NOT NULL DEFAULT false;
+	UPDATE base_users SET password_changed_at = NOW() WHERE password_changed_at IS NULL;
+	`
+
+	if err := db.Exec(alterUsersPasswordPolicySQL).Error; err != nil {
+		log.Error("base_users 表补充密码策略字段失败", "error", err)
+	} else {
+		log.Info("base_users 表密码策略字段补充成功")
+	}
+
+	// 创建密码历史表
+	createPasswordHistoriesTableSQL := `
+	CREATE TABLE IF NOT EXISTS base_password_histories (
+		id BIGINT PRIMARY KEY,
+		user_id BIGINT NOT NULL DEFAULT 0,
+		password_hash VARCHAR(100) NOT NULL,
+		created_by BIGINT NOT NULL DEFAULT 0,
+		updated_by BIGINT NOT NULL DEFAULT 0,
+		deleted_by BIGINT NOT NULL DEFAULT 0,
+		created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+		updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+		deleted_at TIMESTAMP
+	);
+	
+	CREATE INDEX IF NOT EXISTS idx_base_password_histories_user_id ON base_password_histories(user_id);
+	`
+
+	if err := db.Exec(createPasswordHistoriesTableSQL).Error; err != nil {
+		log.Error("创建 base_password_histories 表失败", "error", err)
+	} else {
+		log.Info("base_password_histories 表创建成功")
+	}
+
+	// 创建服务账号表
+	createServiceAccountsTableSQL := `
+	CREATE TABLE IF NOT EXISTS base_service_accounts (
+		id BIGINT PRIMARY KEY,
+		name VARCHAR(100) UNIQUE NOT NULL,
+		description VARCHAR(255),
+		status INTEGER NOT NULL DEFAULT 1,
+		ip_allowlist TEXT,
+		last_used_at TIMESTAMP,
+		last_used_ip VARCHAR(50),
+		created_by BIGINT NOT NULL DEFAULT 0,
+		updated_by BIGINT NOT NULL DEFAULT 0,
+		deleted_by BIGINT NOT NULL DEFAULT 0,
+		created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+		updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+		deleted_at TIMESTAMP
+	);
+	`
+
+	if err := db.Exec(createServiceAccountsTableSQL).Error; err != nil {
+		log.Error("创建 base_service_accounts 表失败", "error", err)
+	} else {
+		log.Info("base_service_accounts 表创建成功")
+	}
+
+	// 创建服务账号角色关联表
+	createServiceAccountRolesTableSQL := `
+	CREATE TABLE IF NOT EXISTS base_service_account_roles (
+		service_account_id BIGINT NOT NULL,
+		role_id BIGINT NOT NULL,
+		PRIMARY KEY (service_account_id, role_id),
+		FOREIGN KEY (service_account_id) REFERENCES base_service_accounts(id) ON DELETE CASCADE,
+		FOREIGN KEY (role_id) REFERENCES base_roles(id) ON DELETE CASCADE
+	);
+	`
+
+	if err := db.Exec(createServiceAccountRolesTableSQL).Error; err != nil {
+		log.Error("创建 base_service_account_roles 表失败", "error", err)
+	} else {
+		log.Info("base_service_account_roles 表创建成功")
+	}
+
+	// 创建服务账号 API Key 表
+	createServiceAccountKeysTableSQL := `
+	CREATE TABLE IF NOT EXISTS base_service_account_keys (
+		id BIGINT PRIMARY KEY,
+		service_account_id BIGINT NOT NULL,
+		prefix VARCHAR(32) UNIQUE NOT NULL,
+		key_hash VARCHAR(128) NOT NULL,
+		expires_at TIMESTAMP,
+		revoked_at TIMESTAMP,
+		last_used_at TIMESTAMP,
+		last_used_ip VARCHAR(50),
+		created_by BIGINT NOT NULL DEFAULT 0,
+		updated_by BIGINT NOT NULL DEFAULT 0,
+		deleted_by BIGINT NOT NULL DEFAULT 0,
+		created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+		updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+		deleted_at TIMESTAMP
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_base_service_account_keys_account_id ON base_service_account_keys(service_account_id);
+	`
+
+	if err := db.Exec(createServiceAccountKeysTableSQL).Error; err != nil {
+		log.Error("创建 base_service_account_keys 表失败", "error", err)
+	} else {
+		log.Info("base_service_account_keys 表创建成功")
+	}
+
+	// 创建 LDAP 组映射表
+	createLDAPGroupMappingsTableSQL := `
+	CREATE TABLE IF NOT EXISTS base_ldap_group_mappings (
+		id BIGINT PRIMARY KEY,
+		group_dn VARCHAR(255) NOT NULL,
+		role_id BIGINT NOT NULL DEFAULT 0,
+		department_id BIGINT NOT NULL DEFAULT 0,
+		priority INTEGER NOT NULL DEFAULT 0,
+		description VARCHAR(255),
+		created_by BIGINT NOT NULL DEFAULT 0,
+		updated_by BIGINT NOT NULL DEFAULT 0,
+		deleted_by BIGINT NOT NULL DEFAULT 0,
+		created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+		updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+		deleted_at TIMESTAMP
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_base_ldap_group_mappings_group_dn ON base_ldap_group_mappings(LOWER(group_dn));
+	`
+
+	if err := db.Exec(createLDAPGroupMappingsTableSQL).Error; err != nil {
+		log.Error("创建 base_ldap_group_mappings 表失败", "error", err)
+	} else {
+		log.Info("base_ldap_group_mappings 表创建成功")
+	}
+
+	// 创建 OIDC 身份提供方表
+	createOIDCProvidersTableSQL := `
+	CREATE TABLE IF NOT EXISTS base_oidc_providers (
+		id BIGINT PRIMARY KEY,
+		code VARCHAR(50) NOT NULL UNIQUE,
+		name VARCHAR(100) NOT NULL,
+		issuer VARCHAR(255) NOT NULL,
+		client_id VARCHAR(255) NOT NULL,
+		client_secret VARCHAR(500),
+		token_auth_method VARCHAR(50),
+		scopes VARCHAR(500),
+		redirect_uri VARCHAR(500) NOT NULL,
+		authorization_endpoint VARCHAR(500),
+		token_endpoint VARCHAR(500),
+		userinfo_endpoint VARCHAR(500),
+		jwks_uri VARCHAR(500),
+		link_by VARCHAR(20),
+		trust_email BOOLEAN NOT NULL DEFAULT FALSE,
+		auto_create BOOLEAN NOT NULL DEFAULT FALSE,
+		username_claim VARCHAR(100),
+		role_mappings TEXT,
+		status INTEGER NOT NULL DEFAULT 1,
+		sort INTEGER NOT NULL DEFAULT 0,
+		created_by BIGINT NOT NULL DEFAULT 0,
+		updated_by BIGINT NOT NULL DEFAULT 0,
+		deleted_by BIGINT NOT NULL DEFAULT 0,
+		created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+		updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+		deleted_at TIMESTAMP
+	);
+	`
+
+	if err := db.Exec(createOIDCProvidersTableSQL).Error; err != nil {
+		log.Error("创建 base_oidc_providers 表失败", "error", err)
+	} else {
+		log.Info("base_oidc_providers 表创建成功")
+	}
+
+	// 创建用户 OIDC 身份绑定表
+	createUserOIDCIdentitiesTableSQL := `
+	CREATE TABLE IF NOT EXISTS base_user_oidc_identities (
+		id BIGINT PRIMARY KEY,
+		provider_id BIGINT NOT NULL,
+		subject VARCHAR(255) NOT NULL,
+		user_id BIGINT NOT NULL,
+		email VARCHAR(255),
+		last_login_at TIMESTAMP,
+		created_by BIGINT NOT NULL DEFAULT 0,
+		updated_by BIGINT NOT NULL DEFAULT 0,
+		created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+		updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+		deleted_at TIMESTAMP
+	);
+
+	CREATE UNIQUE INDEX IF NOT EXISTS uk_base_user_oidc_identities_subject ON base_user_oidc_identities(provider_id, subject);
+	CREATE INDEX IF NOT EXISTS idx_base_user_oidc_identities_user_id ON base_user_oidc_identities(user_id);
+	`
+
+	if err := db.Exec(createUserOIDCIdentitiesTableSQL).Error; err != nil {
+		log.Error("创建 base_user_oidc_identities 表失败", "error", err)
+	} else {
+		log.Info("base_user_oidc_identities 表创建成功")
+	}
+
+	// 创建 OIDC 客户端表（本系统作为身份提供方时接入的应用）
+	createOIDCClientsTableSQL := `
+	CREATE TABLE IF NOT EXISTS base_oidc_clients (
+		id BIGINT PRIMARY KEY,
+		client_id VARCHAR(64) NOT NULL UNIQUE,
+		name VARCHAR(100) NOT NULL,
+		description VARCHAR(500),
+		secret_hash VARCHAR(64),
+		public BOOLEAN NOT NULL DEFAULT FALSE,
+		redirect_uris TEXT,
+		grant_types VARCHAR(255),
+		scopes VARCHAR(255),
+		skip_consent BOOLEAN NOT NULL DEFAULT FALSE,
+		status INTEGER NOT NULL DEFAULT 1,
+		created_by BIGINT NOT NULL DEFAULT 0,
+		updated_by BIGINT NOT NULL DEFAULT 0,
+		created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+		updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+		deleted_at TIMESTAMP
+	);
+	`
+
+	if err := db.Exec(createOIDCClientsTableSQL).Error; err != nil {
+		log.Error("创建 base_oidc_clients 表失败", "error", err)
+	} else {
+		log.Info("base_oidc_clients 表创建成功")
+	}
+
+	// 创建 OIDC 用户授权记录表
+	createOIDCConsentsTableSQL := `
+	CREATE TABLE IF NOT EXISTS base_oidc_consents (
+		id BIGINT PRIMARY KEY,
+		user_id BIGINT NOT NULL,
+		client_id BIGINT NOT NULL,
+		scopes VARCHAR(255),
+		created_by BIGINT NOT NULL DEFAULT 0,
+		updated_by BIGINT NOT NULL DEFAULT 0,
+		created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+		updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+		deleted_at TIMESTAMP
+	);
+
+	CREATE UNIQUE INDEX IF NOT EXISTS uk_base_oidc_consents_user_client ON base_oidc_consents(user_id, client_id);
+	CREATE INDEX IF NOT EXISTS idx_base_oidc_consents_client_id ON base_oidc_consents(client_id);
+	`
+
+	if err := db.Exec(createOIDCConsentsTableSQL).Error; err != nil {
+		log.Error("创建 base_oidc_consents 表失败", "error", err)
+	} else {
+		log.Info("base_oidc_consents 表创建成功")
+	}
+
+	// 创建会话终止审计记录表
+	createSessionAuditLogsTableSQL := `
+	CREATE TABLE IF NOT EXISTS base_session_audit_logs (
+		id BIGINT PRIMARY KEY,
+		user_id BIGINT NOT NULL,
+		username VARCHAR(50),
+		session_id VARCHAR(64) NOT NULL,
+		login_ip VARCHAR(50),
+		device VARCHAR(100),
+		action VARCHAR(20) NOT NULL,
+		reason VARCHAR(500),
+		operator_id BIGINT NOT NULL DEFAULT 0,
+		created_by BIGINT NOT NULL DEFAULT 0,
+		updated_by BIGINT NOT NULL DEFAULT 0,
+		created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+		updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+		deleted_at TIMESTAMP
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_base_session_audit_logs_user_id ON base_session_audit_logs(user_id);
+	CREATE INDEX IF NOT EXISTS idx_base_session_audit_logs_session_id ON base_session_audit_logs(session_id);
+	CREATE INDEX IF NOT EXISTS idx_base_session_audit_logs_operator_id ON base_session_audit_logs(operator_id);
+	CREATE INDEX IF NOT EXISTS idx_base_session_audit_logs_created_at ON base_session_audit_logs(created_at);
+	`
+
+	if err := db.Exec(createSessionAuditLogsTableSQL).Error; err != nil {
+		log.Error("创建 base_session_audit_logs 表失败", "error", err)
+	} else {
+		log.Info("base_session_audit_logs 表创建成功")
+	}
+
+	// 创建 IP 访问策略表
+	createIPPoliciesTableSQL := `
+	CREATE TABLE IF NOT EXISTS base_ip_policies (
+		id BIGINT PRIMARY KEY,
+		name VARCHAR(100) UNIQUE NOT NULL,
+		scope VARCHAR(20) NOT NULL,
+		target_id BIGINT NOT NULL DEFAULT 0,
+		action VARCHAR(20) NOT NULL,
+		cidrs TEXT,
+		description VARCHAR(255),
+		status INTEGER NOT NULL DEFAULT 1,
+		created_by BIGINT NOT NULL DEFAULT 0,
+		updated_by BIGINT NOT NULL DEFAULT 0,
+		created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+		updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+		deleted_at TIMESTAMP
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_base_ip_policies_scope_target ON base_ip_policies(scope, target_id);
+	`
+
+	if err := db.Exec(createIPPoliciesTableSQL).Error; err != nil {
+		log.Error("创建 base_ip_policies 表失败", "error", err)
+	} else {
+		log.Info("base_ip_policies 表创建成功")
+	}
+
+	// 创建用户常用登录设备、登录地点和登录安全事件表
+	createLoginRiskTablesSQL := `
+	CREATE TABLE IF NOT EXISTS base_login_devices (
+		id BIGINT PRIMARY KEY,
+		user_id BIGINT NOT NULL,
+		fingerprint VARCHAR(64) NOT NULL,
+		browser VARCHAR(50),
+		os VARCHAR(50),
+		last_ip VARCHAR(50),
+		last_place VARCHAR(100),
+		login_count INTEGER NOT NULL DEFAULT 0,
+		first_seen_at TIMESTAMP NOT NULL,
+		last_seen_at TIMESTAMP NOT NULL,
+		created_by BIGINT NOT NULL DEFAULT 0,
+		updated_by BIGINT NOT NULL DEFAULT 0,
+		created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+		updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+		deleted_at TIMESTAMP
+	);
+
+	CREATE UNIQUE INDEX IF NOT EXISTS idx_login_device_user_fingerprint ON base_login_devices(user_id, fingerprint);
+
+	CREATE TABLE IF NOT EXISTS base_login_locations (
+		id BIGINT PRIMARY KEY,
+		user_id BIGINT NOT NULL,
+		country VARCHAR(50) NOT NULL,
+		province VARCHAR(50),
+		city VARCHAR(50) NOT NULL,
+		latitude DOUBLE PRECISION NOT NULL DEFAULT 0,
+		longitude DOUBLE PRECISION NOT NULL DEFAULT 0,
+		last_ip VARCHAR(50),
+		last_seen_at TIMESTAMP NOT NULL,
+		created_by BIGINT NOT NULL DEFAULT 0,
+		updated_by BIGINT NOT NULL DEFAULT 0,
+		created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+		updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+		deleted_at TIMESTAMP
+	);
+
+	CREATE UNIQUE INDEX IF NOT EXISTS idx_login_location_user_place ON base_login_locations(user_id, country, city);
+	CREATE INDEX IF NOT EXISTS idx_base_login_locations_last_seen_at ON base_login_locations(last_seen_at);
+
+	CREATE TABLE IF NOT EXISTS base_login_security_events (
+		id BIGINT PRIMARY KEY,
+		user_id BIGINT NOT NULL,
+		username VARCHAR(50),
+		type VARCHAR(30) NOT NULL,
+		risk_level VARCHAR(10) NOT NULL,
+		ip VARCHAR(50),
+		place VARCHAR(100),
+		device VARCHAR(100),
+		fingerprint VARCHAR(64),
+		previous_ip VARCHAR(50),
+		previous_place VARCHAR(100),
+		previous_time TIMESTAMP,
+		distance_km DOUBLE PRECISION NOT NULL DEFAULT 0,
+		description VARCHAR(500),
+		step_up_status VARCHAR(20),
+		created_by BIGINT NOT NULL DEFAULT 0,
+		updated_by BIGINT NOT NULL DEFAULT 0,
+		created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+		updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+		deleted_at TIMESTAMP
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_base_login_security_events_user_id ON base_login_security_events(user_id);
+	CREATE INDEX IF NOT EXISTS idx_base_login_security_events_type ON base_login_security_events(type);
+	CREATE INDEX IF NOT EXISTS idx_base_login_security_events_created_at ON base_login_security_events(created_at);
+	`
+
+	if err := db.Exec(createLoginRiskTablesSQL).Error; err != nil {
+		log.Error("创建登录设备、登录地点和登录安全事件表失败", "error", err)
+	} else {
+		log.Info("登录设备、登录地点和登录安全事件表创建成功")
+	}
+
+	// 为已有的 base_roles 表补充数据权限字段，默认为全部数据
+	alterRolesDataScopeSQL := `
+	ALTER TABLE base_roles ADD COLUMN IF NOT EXISTS data_scope INTEGER NOT NULL DEFAULT 1;
+	ALTER TABLE base_roles ADD COLUMN IF NOT EXISTS data_scope_dept_ids TEXT NOT NULL DEFAULT '';
+	CREATE INDEX IF NOT EXISTS idx_base_users_department_id ON base_users(department_id);
+	`
+
+	if err := db.Exec(alterRolesDataScopeSQL).Error; err != nil {
+		log.Error("base_roles 表补充数据权限字段失败", "error", err)
+	} else {
+		log.Info("base_roles 表数据权限字段补充成功")
+	}
+
+	// 为已有的 base_apis 表补充失效标记，启动同步路由时已不存在的路由只标记不删除，保留角色分配
+	alterAPIsOrphanedSQL := `
+	ALTER TABLE base_apis ADD COLUMN IF NOT EXISTS orphaned BOOLEAN NOT NULL DEFAULT false;
+	`
+
+	if err := db.Exec(alterAPIsOrphanedSQL).Error; err != nil {
+		log.Error("base_apis 表补充失效标记字段失败", "error", err)
+	} else {
+		log.Info("base_apis 表失效标记字段补充成功")
+	}
+
+	// 创建限时角色授予表，记录直接授予和提权申请，到期由定时任务收回
+	createRoleGrantsTableSQL := `
+	CREATE TABLE IF NOT EXISTS base_role_grants (
+		id BIGINT PRIMARY KEY,
+		user_id BIGINT NOT NULL,
+		role_id BIGINT NOT NULL,
+		valid_from TIMESTAMP,
+		valid_until TIMESTAMP,
+		justification VARCHAR(500),
+		status VARCHAR(20) NOT NULL,
+		elevation BOOLEAN NOT NULL DEFAULT false,
+		requested_hours INTEGER NOT NULL DEFAULT 0,
+		approved_by BIGINT NOT NULL DEFAULT 0,
+		approved_at TIMESTAMP,
+		review_comment VARCHAR(500),
+		revoked_by BIGINT NOT NULL DEFAULT 0,
+		revoked_at TIMESTAMP,
+		created_by BIGINT NOT NULL DEFAULT 0,
+		updated_by BIGINT NOT NULL DEFAULT 0,
+		created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+		updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+		deleted_at TIMESTAMP
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_base_role_grants_user_role ON base_role_grants(user_id, role_id);
+	CREATE INDEX IF NOT EXISTS idx_base_role_grants_status_valid_from ON base_role_grants(status, valid_from);
+	CREATE INDEX IF NOT EXISTS idx_base_role_grants_status_valid_until ON base_role_grants(status, valid_until);
+	`
+
+	if err := db.Exec(createRoleGrantsTableSQL).Error; err != nil {
+		log.Error("创建 base_role_grants 表失败", "error", err)
+	} else {
+		log.Info("base_role_grants 表创建成功")
+	}
+
+	// 创建权限日志表，与 entity.PermissionLog 对应，记录限时角色授予等权限变更
+	createPermissionLogsSQL := `
+	CREATE TABLE IF NOT EXISTS permission_logs (
+		id BIGSERIAL PRIMARY KEY,
+		user_id BIGINT NOT NULL DEFAULT 0,
+		user_name VARCHAR(64),
+		operation VARCHAR(128),
+		module VARCHAR(64),
+		target_type VARCHAR(64),
+		target_id BIGINT NOT NULL DEFAULT 0,
+		old_value TEXT,
+		new_value TEXT,
+		ip VARCHAR(64),
+		user_agent VARCHAR(512),
+		created_at TIMESTAMP NOT NULL DEFAULT NOW()
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_permission_logs_user_id ON permission_logs(user_id);
+	CREATE INDEX IF NOT EXISTS idx_permission_logs_target ON permission_logs(target_type, target_id);
+	CREATE INDEX IF NOT EXISTS idx_permission_logs_created_at ON permission_logs(created_at);
+	`
+
+	if err := db.Exec(createPermissionLogsSQL).Error; err != nil {
+		log.Error("创建 permission_logs 表失败", "error", err)
+	} else {
+		log.Info("permission_logs 表创建成功")
+	}
+
+	// 权限变更审批：角色增加审批开关，创建变更申请表，审计日志表补充基础字段
+	createPermissionChangeRequestsSQL := `
+	ALTER TABLE base_roles ADD COLUMN IF NOT EXISTS approval_required BOOLEAN NOT NULL DEFAULT false;
+
+	CREATE TABLE IF NOT EXISTS base_permission_change_requests (
+		id BIGINT PRIMARY KEY,
+		role_id BIGINT NOT NULL,
+		change_type VARCHAR(30) NOT NULL,
+		before_ids TEXT,
+		added_ids TEXT,
+		removed_ids TEXT,
+		status VARCHAR(20) NOT NULL,
+		requested_by BIGINT NOT NULL DEFAULT 0,
+		reviewed_by BIGINT NOT NULL DEFAULT 0,
+		reviewed_at TIMESTAMP,
+		review_comment VARCHAR(500),
+		created_by BIGINT NOT NULL DEFAULT 0,
+		updated_by BIGINT NOT NULL DEFAULT 0,
+		created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+		updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+		deleted_at TIMESTAMP
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_base_permission_change_requests_role_id ON base_permission_change_requests(role_id);
+	CREATE INDEX IF NOT EXISTS idx_base_permission_change_requests_status ON base_permission_change_requests(status);
+
+	ALTER TABLE sys_permission_logs ADD COLUMN IF NOT EXISTS created_by BIGINT NOT NULL DEFAULT 0;
+	ALTER TABLE sys_permission_logs ADD COLUMN IF NOT EXISTS updated_by BIGINT NOT NULL DEFAULT 0;
+	ALTER TABLE sys_permission_logs ADD COLUMN IF NOT EXISTS updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP;
+	`
+
+	if err := db.Exec(createPermissionChangeRequestsSQL).Error; err != nil {
+		log.Error("创建权限变更申请表失败", "error", err)
+	} else {
+		log.Info("权限变更申请表创建成功")
+	}
+
+	// 职责分离约束表
+	createSoDConstraintsSQL := `
+	CREATE TABLE IF NOT EXISTS base_sod_constraints (
+		id BIGINT PRIMARY KEY,
+		name VARCHAR(100) NOT NULL UNIQUE,
+		description VARCHAR(500),
+		type VARCHAR(20) NOT NULL,
+		role_ids TEXT,
+		max_roles INTEGER NOT NULL DEFAULT 1,
+		status INTEGER NOT NULL DEFAULT 1,
+		created_by BIGINT NOT NULL DEFAULT 0,
+		updated_by BIGINT NOT NULL DEFAULT 0,
+		created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+		updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+		deleted_at TIMESTAMP
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_base_sod_constraints_type ON base_sod_constraints(type);
+	`
+
+	if err := db.Exec(createSoDConstraintsSQL).Error; err != nil {
+		log.Error("创建职责分离约束表失败", "error", err)
+	} else {
+		log.Info("职责分离约束表创建成功")
+	}
+
+	// 敏感字段加密：密文长度超过原列宽，改为 TEXT，并增加盲索引列用于等值查询
+	encryptSensitiveColumnsSQL := `
+	ALTER TABLE base_users ALTER COLUMN email TYPE TEXT;
+	ALTER TABLE base_users ALTER COLUMN phone TYPE TEXT;
+	ALTER TABLE base_users ADD COLUMN IF NOT EXISTS email_hash VARCHAR(64) NOT NULL DEFAULT '';
+	ALTER TABLE base_users ADD COLUMN IF NOT EXISTS phone_hash VARCHAR(64) NOT NULL DEFAULT '';
+	ALTER TABLE base_organizations ALTER COLUMN database_password TYPE TEXT;
+
+	CREATE INDEX IF NOT EXISTS idx_base_users_email_hash ON base_users(email_hash);
+	CREATE INDEX IF NOT EXISTS idx_base_users_phone_hash ON base_users(phone_hash);
+	`
+
+	if err := db.Exec(encryptSensitiveColumnsSQL).Error; err != nil {
+		log.Error("调整敏感字段加密列失败", "error", err)
+	} else {
+		log.Info("敏感字段加密列调整成功")
+	}
+
+	// 策略包导入审批：变更申请保存待导入的策略包内容
+	addPermissionChangePayloadSQL := `
+	ALTER TABLE base_permission_change_requests ADD COLUMN IF NOT EXISTS payload TEXT;
+	`
+
+	if err := db.Exec(addPermissionChangePayloadSQL).Error; err != nil {
+		log.Error("权限变更申请表增加策略包内容列失败", "error", err)
+	} else {
+		log.Info("权限变更申请表策略包内容列增加成功")
+	}
+
 	log.Info("base 应用数据库迁移完成")
 }
 
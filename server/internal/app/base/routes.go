@@ -9,6 +9,8 @@ func (a *AppBase) setupRoutes() {
 
 	// 管理后台路由组，添加/api/admin 前缀（保持向后兼容）
 	admin := a.router.Group("/api/admin")
+	// IP 访问策略：认证前先判定全局策略，认证后再判定角色和用户策略
+	admin.Use(middleware.IPPolicyMiddleware(a.ipPolicyService, a.logger))
 	{
 		// 公共路由
 		public := admin
@@ -18,14 +20,24 @@ func (a *AppBase) setupRoutes() {
 			{
 				auth.POST("/register", a.userController.Register)
 				auth.POST("/login", a.authController.Login)
+				auth.POST("/login/verify", a.authController.VerifyLoginStepUp)
 				auth.POST("/captcha", a.authController.Captcha)
+				// 自助找回密码
+				auth.POST("/password/forgot", a.passwordResetController.SendCode)
+				auth.POST("/password/reset", a.passwordResetController.ResetPassword)
+				// OIDC 单点登录
+				auth.GET("/oidc/providers", a.oidcController.GetLoginProviders)
+				auth.GET("/oidc/:code/authorize", a.oidcController.Authorize)
+				auth.POST("/oidc/:code/callback", a.oidcController.Callback)
 			}
 		}
 
 		// 需要认证的路由
 		authenticated := admin
-		authenticated.Use(middleware.AuthMiddleware(a.auth, a.cache, a.logger))
-		authenticated.Use(middleware.PermissionMiddleware(a.permissionService, a.roleRepo, a.logger, a.cache))
+		authenticated.Use(middleware.AuthMiddleware(a.auth, a.cache, a.serviceAccountService, a.onlineUserService, a.logger))
+		authenticated.Use(middleware.IPPolicyMiddleware(a.ipPolicyService, a.logger))
+		authenticated.Use(middleware.PermissionMiddleware(a.permissionDecisionService, a.logger))
+		authenticated.Use(middleware.DataScopeMiddleware(a.dataScopeService, a.logger))
 		{
 			// 认证相关路由（需要认证）
 			auth := authenticated.Group("/auth")
@@ -47,6 +59,11 @@ func (a *AppBase) setupRoutes() {
 				user.GET("/get-user-settings", a.userController.GetUserSettings)
 				user.PUT("/update-user-settings", a.userController.UpdateUserSettings)
 				user.POST("/switch-role", a.userController.SwitchRole)
+				// 当前用户的登录设备
+				user.GET("/sessions", a.onlineUserController.GetMySessions)
+				user.POST("/sessions/revoke", a.onlineUserController.RevokeMySession)
+				user.GET("/session-events", a.onlineUserController.SessionEvents)
+				user.GET("/security-events", a.loginLogController.GetMySecurityEvents)
 				user.POST("/setUserAuthority", a.userController.SetUserAuthority)
 				user.POST("/setUserAuthorities", a.userController.SetUserAuthorities)
 			}
@@ -58,6 +75,8 @@ func (a *AppBase) setupRoutes() {
 				role.GET("/:id", a.roleController.GetRoleByID)
 				role.GET("/:id/detail", a.roleController.GetRoleDetail)
 				role.GET("/:id/available-apis", a.roleController.GetAvailableAPIs)
+				role.PUT("/:id/data-scope", a.roleController.SetRoleDataScope)
+				role.PUT("/:id/approval-required", a.roleController.SetRoleApprovalRequired)
 				role.PUT("", a.roleController.UpdateRole)
 				role.DELETE("", a.roleController.DeleteRole)
 				role.GET("", a.roleController.GetRoleList)
@@ -67,6 +86,116 @@ func (a *AppBase) setupRoutes() {
 				role.POST("/assign-api-routes", a.roleController.AssignAPIToRole)
 			}
 
+			// 服务账号路由
+			serviceAccount := authenticated.Group("/service-accounts")
+			{
+				serviceAccount.GET("", a.serviceAccountController.GetServiceAccountList)
+				serviceAccount.POST("", a.serviceAccountController.CreateServiceAccount)
+				serviceAccount.GET("/:id", a.serviceAccountController.GetServiceAccountByID)
+				serviceAccount.PUT("/:id", a.serviceAccountController.UpdateServiceAccount)
+				serviceAccount.DELETE("/:id", a.serviceAccountController.DeleteServiceAccount)
+				serviceAccount.POST("/:id/rotate-key", a.serviceAccountController.RotateKey)
+				serviceAccount.DELETE("/:id/keys/:keyId", a.serviceAccountController.RevokeKey)
+			}
+
+			// IP 访问策略路由
+			ipPolicy := authenticated.Group("/ip-policies")
+			{
+				ipPolicy.GET("", a.ipPolicyController.GetIPPolicyList)
+				ipPolicy.POST("", a.ipPolicyController.CreateIPPolicy)
+				ipPolicy.POST("/block-abnormal", a.ipPolicyController.BlockAbnormalIPs)
+				ipPolicy.GET("/:id", a.ipPolicyController.GetIPPolicyByID)
+				ipPolicy.PUT("/:id", a.ipPolicyController.UpdateIPPolicy)
+				ipPolicy.DELETE("/:id", a.ipPolicyController.DeleteIPPolicy)
+			}
+
+			// 权限规则（ABAC）路由
+			permissionRule := authenticated.Group("/permission-rules")
+			{
+				permissionRule.GET("", a.permissionRuleController.GetPermissionRuleList)
+				permissionRule.POST("", a.permissionRuleController.CreatePermissionRule)
+				permissionRule.POST("/validate", a.permissionRuleController.ValidatePermissionRuleConditions)
+				permissionRule.GET("/:id", a.permissionRuleController.GetPermissionRuleByID)
+				permissionRule.PUT("/:id", a.permissionRuleController.UpdatePermissionRule)
+				permissionRule.DELETE("/:id", a.permissionRuleController.DeletePermissionRule)
+			}
+
+			// 权限解释路由
+			permissionExplain := authenticated.Group("/permissions")
+			{
+				permissionExplain.POST("/explain", a.permissionExplainController.ExplainPermission)
+				permissionExplain.POST("/simulate", a.permissionExplainController.SimulatePermission)
+			}
+
+			// 限时角色授予路由
+			roleGrant := authenticated.Group("/role-grants")
+			{
+				roleGrant.GET("", a.roleGrantController.GetRoleGrantList)
+				roleGrant.POST("", a.roleGrantController.GrantRole)
+				roleGrant.GET("/mine", a.roleGrantController.GetMyRoleGrants)
+				roleGrant.POST("/elevation", a.roleGrantController.RequestElevation)
+				roleGrant.POST("/:id/approve", a.roleGrantController.ApproveElevation)
+				roleGrant.POST("/:id/reject", a.roleGrantController.RejectElevation)
+				roleGrant.POST("/:id/revoke", a.roleGrantController.RevokeRoleGrant)
+			}
+
+			// 权限变更审批路由
+			permissionChange := authenticated.Group("/permission-changes")
+			{
+				permissionChange.GET("", a.permissionChangeController.GetPermissionChangeList)
+				permissionChange.GET("/:id", a.permissionChangeController.GetPermissionChange)
+				permissionChange.POST("/:id/approve", a.permissionChangeController.ApprovePermissionChange)
+				permissionChange.POST("/:id/reject", a.permissionChangeController.RejectPermissionChange)
+			}
+
+			// 权限策略包路由
+			policyBundle := authenticated.Group("/policy-bundle")
+			{
+				policyBundle.GET("/export", a.policyBundleController.ExportPolicyBundle)
+				policyBundle.POST("/import", a.policyBundleController.ImportPolicyBundle)
+			}
+
+			// 职责分离约束路由
+			sodConstraint := authenticated.Group("/sod-constraints")
+			{
+				sodConstraint.GET("", a.sodController.GetSoDConstraintList)
+				sodConstraint.POST("", a.sodController.CreateSoDConstraint)
+				sodConstraint.GET("/violations", a.sodController.GetSoDViolations)
+				sodConstraint.GET("/:id", a.sodController.GetSoDConstraintByID)
+				sodConstraint.PUT("/:id", a.sodController.UpdateSoDConstraint)
+				sodConstraint.DELETE("/:id", a.sodController.DeleteSoDConstraint)
+			}
+
+			// LDAP 组映射路由
+			ldap := authenticated.Group("/ldap")
+			{
+				ldap.GET("/group-mappings", a.ldapController.GetGroupMappingList)
+				ldap.POST("/group-mappings", a.ldapController.CreateGroupMapping)
+				ldap.PUT("/group-mappings/:id", a.ldapController.UpdateGroupMapping)
+				ldap.DELETE("/group-mappings/:id", a.ldapController.DeleteGroupMapping)
+			}
+
+			// OIDC 身份提供方路由
+			oidcProvider := authenticated.Group("/oidc/providers")
+			{
+				oidcProvider.GET("", a.oidcController.GetProviderList)
+				oidcProvider.POST("", a.oidcController.CreateProvider)
+				oidcProvider.GET("/:id", a.oidcController.GetProviderByID)
+				oidcProvider.PUT("/:id", a.oidcController.UpdateProvider)
+				oidcProvider.DELETE("/:id", a.oidcController.DeleteProvider)
+			}
+
+			// OIDC 客户端路由（本系统作为身份提供方）
+			oidcClient := authenticated.Group("/oidc/clients")
+			{
+				oidcClient.GET("", a.identityProviderController.GetClientList)
+				oidcClient.POST("", a.identityProviderController.CreateClient)
+				oidcClient.GET("/:id", a.identityProviderController.GetClientByID)
+				oidcClient.PUT("/:id", a.identityProviderController.UpdateClient)
+				oidcClient.DELETE("/:id", a.identityProviderController.DeleteClient)
+				oidcClient.POST("/:id/secret", a.identityProviderController.ResetClientSecret)
+			}
+
 			// 任务路由（需要 admin 角色）
 			task := authenticated.Group("/task")
 			{
@@ -88,6 +217,8 @@ func (a *AppBase) setupRoutes() {
 			{
 				// 分页获取API路由列表
 				apis.GET("", a.apiController.GetAPIList)
+				// 路由同步报告
+				apis.GET("/sync-report", a.apiController.GetSyncReport)
 				// 根据ID获取API路由
 				apis.GET("/:id", a.apiController.GetRouteByID)
 				// 创建API路由
@@ -246,6 +377,8 @@ func (a *AppBase) setupRoutes() {
 				loginLog.GET("/statistics", a.loginLogController.GetStatistics)
 				// 获取异常登录查询
 				loginLog.GET("/abnormal", a.loginLogController.GetAbnormalLogins)
+				// 获取登录安全事件（新设备、新地点、异地登录）
+				loginLog.GET("/security-events", a.loginLogController.GetSecurityEvents)
 				// 记录登录日志（内部调用）
 				loginLog.POST("", a.loginLogController.RecordLogin)
 				// 批量删除登录日志
@@ -278,6 +411,8 @@ func (a *AppBase) setupRoutes() {
 				onlineUser.DELETE("/:user_id", a.onlineUserController.ForceOffline)
 				// 批量强制用户下线
 				onlineUser.POST("/batch", a.onlineUserController.BatchForceOffline)
+				// 会话终止审计记录
+				onlineUser.GET("/audit-logs", a.onlineUserController.GetSessionAuditLogs)
 			}
 
 			// 系统监控路由
@@ -296,4 +431,23 @@ func (a *AppBase) setupRoutes() {
 			}
 		}
 	}
+
+	// OIDC 身份提供方协议端点，签发方为 {对外地址}/api/oidc
+	idp := a.router.Group("/api/oidc")
+	{
+		idp.GET("/.well-known/openid-configuration", a.identityProviderController.Discovery)
+		idp.GET("/jwks", a.identityProviderController.JWKS)
+		idp.GET("/authorize", a.identityProviderController.Authorize)
+		idp.POST("/token", a.identityProviderController.Token)
+		idp.GET("/userinfo", a.identityProviderController.UserInfo)
+		idp.POST("/userinfo", a.identityProviderController.UserInfo)
+
+		// 授权确认只要求登录，不做接口权限校验：任何用户都可以授权应用读取自己的资料
+		consent := idp.Group("/consent")
+		consent.Use(middleware.AuthMiddleware(a.auth, a.cache, a.serviceAccountService, a.onlineUserService, a.logger))
+		{
+			consent.GET("", a.identityProviderController.GetConsentInfo)
+			consent.POST("", a.identityProviderController.Consent)
+		}
+	}
 }
@@ -38,7 +38,22 @@ var ProviderSetBaseRepo = wire.NewSet(
 	repository.NewNoticeRepository,
 	repository.NewNoticeReadRecordRepository,
 	repository.NewOnlineUserRepository,
+	repository.NewSessionAuditLogRepository,
+	repository.NewLoginDeviceRepository,
+	repository.NewLoginLocationRepository,
+	repository.NewLoginSecurityEventRepository,
+	repository.NewIPPolicyRepository,
 	repository.NewPermissionLogRepository,
+	repository.NewRoleGrantRepository,
+	repository.NewPermissionChangeRequestRepository,
+	repository.NewPolicyBundleRepository,
+	repository.NewSoDConstraintRepository,
+	repository.NewPermissionAuditLogRepository,
+	repository.NewPasswordHistoryRepository,
+	repository.NewServiceAccountRepository,
+	repository.NewLDAPGroupMappingRepository,
+	repository.NewOIDCProviderRepository,
+	repository.NewOIDCClientRepository,
 )
 
 var ProviderSetBaseService = wire.NewSet(
@@ -54,14 +69,30 @@ var ProviderSetBaseService = wire.NewSet(
 	service.NewDictService,
 	service.NewDictItemService,
 	service.NewOperationLogService,
+	service.NewPermissionDecisionService,
 	service.NewPermissionService,
+	service.NewPermissionExplainService,
+	service.NewRoleGrantService,
+	service.NewPermissionChangeService,
+	service.NewPolicyBundleService,
+	service.NewSoDService,
+	service.NewPermissionRuleService,
+	service.NewDataScopeService,
 	service.NewTaskExecutionLogService,
 	service.NewDepartmentService,
 	service.NewPositionService,
 	service.NewNoticeService,
 	service.NewNoticeReadRecordService,
 	service.NewOnlineUserService,
+	service.NewLoginRiskService,
+	service.NewIPPolicyService,
 	service.NewPermissionLogService,
+	service.NewPasswordPolicyService,
+	service.NewPasswordResetService,
+	service.NewServiceAccountService,
+	service.NewLDAPService,
+	service.NewOIDCService,
+	service.NewIdentityProviderService,
 )
 
 var ProviderSetBaseConverter = wire.NewSet(
@@ -78,6 +109,7 @@ var ProviderSetBaseController = wire.NewSet(
 	baseapi.NewRoleController,
 	baseapi.NewUserController,
 	baseapi.NewAuthController,
+	baseapi.NewPasswordResetController,
 	baseapi.NewConfigController,
 	baseapi.NewDictController,
 	baseapi.NewOperationLogController,
@@ -86,8 +118,19 @@ var ProviderSetBaseController = wire.NewSet(
 	baseapi.NewPositionController,
 	baseapi.NewNoticeController,
 	baseapi.NewOnlineUserController,
+	baseapi.NewIPPolicyController,
+	baseapi.NewPermissionRuleController,
+	baseapi.NewPermissionExplainController,
+	baseapi.NewRoleGrantController,
+	baseapi.NewPermissionChangeController,
+	baseapi.NewPolicyBundleController,
+	baseapi.NewSoDController,
 	baseapi.NewMonitorController,
 	baseapi.NewPermissionLogController,
+	baseapi.NewServiceAccountController,
+	baseapi.NewLDAPController,
+	baseapi.NewOIDCController,
+	baseapi.NewIdentityProviderController,
 )
 var ProviderSetBaseApp = wire.NewSet(
 	// 应用层
@@ -9,13 +9,21 @@ import (
 	"github.com/ix-pay/ixpay-pro/internal/app/wx"
 	"github.com/ix-pay/ixpay-pro/internal/config"
 	"github.com/ix-pay/ixpay-pro/internal/infrastructure/observability/logger"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/observability/redact"
 	"github.com/ix-pay/ixpay-pro/internal/infrastructure/persistence/cache"
 	"github.com/ix-pay/ixpay-pro/internal/infrastructure/persistence/database"
 	redisClient "github.com/ix-pay/ixpay-pro/internal/infrastructure/persistence/redis"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/security/apisign"
 	"github.com/ix-pay/ixpay-pro/internal/infrastructure/security/auth"
 	"github.com/ix-pay/ixpay-pro/internal/infrastructure/security/captcha"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/security/fieldcrypt"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/security/ldap"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/security/oidc"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/support/geoip"
 	"github.com/ix-pay/ixpay-pro/internal/infrastructure/support/snowflake"
 	"github.com/ix-pay/ixpay-pro/internal/infrastructure/support/task"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/transport/notify"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/transport/push"
 
 	"github.com/redis/go-redis/v9"
 )
@@ -24,6 +32,7 @@ import (
 var GlobalServiceSet = wire.NewSet(
 	// 基础设施层
 	config.LoadConfig,
+	redact.SetupRedactor,
 	logger.SetupMultiLogger,
 	logger.SetupLogger,
 	// 数据库
@@ -31,10 +40,22 @@ var GlobalServiceSet = wire.NewSet(
 	// 缓存
 	redisClient.SetupRedisClient,
 	cache.SetupCache,
+	// 缓存失效广播
+	cache.SetupInvalidationBus,
 	// 雪花 ID
 	snowflake.SetupSnowflake,
 	// 验证码
 	captcha.SetupCaptcha,
+	// IP 归属地
+	geoip.SetupLocator,
+	// 消息发送
+	notify.SetupSenders,
+	// 实时推送
+	push.SetupHub,
+	// LDAP 认证
+	ldap.SetupAuthenticator, oidc.SetupSigner, apisign.SetupSigner,
+	// 字段加密
+	fieldcrypt.SetupCipher,
 	// 认证
 	auth.SetupJWTAuth,
 	// 权限管理
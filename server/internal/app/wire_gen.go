@@ -17,13 +17,21 @@ import (
 	service2 "github.com/ix-pay/ixpay-pro/internal/domain/wx/service"
 	"github.com/ix-pay/ixpay-pro/internal/infrastructure/observability/logger"
 	"github.com/ix-pay/ixpay-pro/internal/infrastructure/observability/monitor"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/observability/redact"
 	"github.com/ix-pay/ixpay-pro/internal/infrastructure/persistence/cache"
 	"github.com/ix-pay/ixpay-pro/internal/infrastructure/persistence/database"
 	"github.com/ix-pay/ixpay-pro/internal/infrastructure/persistence/redis"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/security/apisign"
 	"github.com/ix-pay/ixpay-pro/internal/infrastructure/security/auth"
 	"github.com/ix-pay/ixpay-pro/internal/infrastructure/security/captcha"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/security/fieldcrypt"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/security/ldap"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/security/oidc"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/support/geoip"
 	"github.com/ix-pay/ixpay-pro/internal/infrastructure/support/snowflake"
 	"github.com/ix-pay/ixpay-pro/internal/infrastructure/support/task"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/transport/notify"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/transport/push"
 	persistence "github.com/ix-pay/ixpay-pro/internal/persistence/base"
 	persistence2 "github.com/ix-pay/ixpay-pro/internal/persistence/wx"
 	redis2 "github.com/redis/go-redis/v9"
@@ -39,8 +47,12 @@ func InitializeApp() (*Application, error) {
 	if err != nil {
 		return nil, err
 	}
-	multiLogger := logger.SetupMultiLogger(configConfig)
-	loggerLogger := logger.SetupLogger(configConfig)
+	redactor, err := redact.SetupRedactor(configConfig)
+	if err != nil {
+		return nil, err
+	}
+	multiLogger := logger.SetupMultiLogger(configConfig, redactor)
+	loggerLogger := logger.SetupLogger(configConfig, redactor)
 	postgresDB, err := database.SetupPostgresDB(configConfig, loggerLogger)
 	if err != nil {
 		return nil, err
@@ -69,15 +81,41 @@ func InitializeApp() (*Application, error) {
 	apiRepository := persistence.NewAPIRepository(postgresDB)
 	btnPermRepository := persistence.NewBtnPermRepository(postgresDB)
 	permissionGroupRepository := persistence.NewPermissionGroupRepository(postgresDB)
-	roleService := service.NewRoleService(roleRepository, userRepository, menuRepository, apiRepository, btnPermRepository, permissionGroupRepository, loggerLogger)
-	rolePermissionService := service.NewRolePermissionService(postgresDB, roleRepository, menuRepository, btnPermRepository, apiRepository, cacheCache, loggerLogger)
-	captchaCaptcha, err := captcha.SetupCaptcha(configConfig, redisClient)
+	permissionChangeRequestRepository := persistence.NewPermissionChangeRequestRepository(postgresDB)
+	policyBundleRepository := persistence.NewPolicyBundleRepository(postgresDB)
+	soDConstraintRepository := persistence.NewSoDConstraintRepository(postgresDB)
+	soDService := service.NewSoDService(soDConstraintRepository, roleRepository, cacheCache, loggerLogger)
+	roleService := service.NewRoleService(roleRepository, userRepository, menuRepository, apiRepository, btnPermRepository, permissionGroupRepository, permissionChangeRequestRepository, soDService, loggerLogger)
+	rolePermissionService := service.NewRolePermissionService(postgresDB, roleRepository, menuRepository, btnPermRepository, apiRepository, cacheCache, roleService, loggerLogger)
+	captchaCaptcha, err := captcha.SetupCaptcha(configConfig, cacheCache)
 	if err != nil {
 		return nil, err
 	}
 	loginLogRepository := persistence.NewLoginLogRepository(postgresDB)
-	loginLogService := service.NewLoginLogService(loginLogRepository, loggerLogger)
-	userService := service.NewUserService(userRepository, userSettingRepository, roleService, rolePermissionService, jwtAuth, configConfig, loggerLogger, cacheCache, captchaCaptcha, loginLogService)
+	locator, err := geoip.SetupLocator(configConfig, loggerLogger)
+	if err != nil {
+		return nil, err
+	}
+	loginLogService := service.NewLoginLogService(loginLogRepository, locator, loggerLogger)
+	passwordHistoryRepository := persistence.NewPasswordHistoryRepository(postgresDB)
+	passwordPolicyService := service.NewPasswordPolicyService(configConfig, passwordHistoryRepository, loggerLogger)
+	authenticator := ldap.SetupAuthenticator(configConfig, loggerLogger)
+	ldapGroupMappingRepository := persistence.NewLDAPGroupMappingRepository(postgresDB)
+	departmentRepository := persistence.NewDepartmentRepository(postgresDB)
+	ldapService := service.NewLDAPService(authenticator, ldapGroupMappingRepository, roleRepository, departmentRepository, loggerLogger)
+	onlineUserRepository := persistence.NewOnlineUserRepository(cacheCache)
+	sessionAuditLogRepository := persistence.NewSessionAuditLogRepository(postgresDB)
+	hub := push.SetupHub(redisClient, loggerLogger)
+	onlineUserService := service.NewOnlineUserService(onlineUserRepository, sessionAuditLogRepository, hub, locator, configConfig, loggerLogger)
+	loginDeviceRepository := persistence.NewLoginDeviceRepository(postgresDB)
+	loginLocationRepository := persistence.NewLoginLocationRepository(postgresDB)
+	loginSecurityEventRepository := persistence.NewLoginSecurityEventRepository(postgresDB)
+	senders, err := notify.SetupSenders(configConfig, loggerLogger)
+	if err != nil {
+		return nil, err
+	}
+	loginRiskService := service.NewLoginRiskService(loginDeviceRepository, loginLocationRepository, loginSecurityEventRepository, locator, onlineUserService, senders, cacheCache, configConfig, loggerLogger)
+	userService := service.NewUserService(userRepository, userSettingRepository, roleService, rolePermissionService, jwtAuth, configConfig, loggerLogger, cacheCache, captchaCaptcha, loginLogService, passwordPolicyService, ldapService, onlineUserService, loginRiskService, soDService)
 	authController := baseapi.NewAuthController(userService, jwtAuth, loggerLogger)
 	userController := baseapi.NewUserController(userService, loggerLogger)
 	taskManager := task.SetupTaskManager(loggerLogger)
@@ -88,10 +126,13 @@ func InitializeApp() (*Application, error) {
 	apiController := baseapi.NewAPIController(apiService, loggerLogger)
 	menuService := service.NewMenuService(menuRepository, btnPermRepository, roleRepository, apiRepository, loggerLogger)
 	menuController := baseapi.NewMenuController(menuService, loggerLogger)
-	roleController := baseapi.NewRoleController(roleService, rolePermissionService, apiService, loggerLogger)
+	dataScopeService := service.NewDataScopeService(roleRepository, userRepository, departmentRepository, cacheCache, loggerLogger)
+	roleController := baseapi.NewRoleController(roleService, rolePermissionService, apiService, dataScopeService, loggerLogger)
 	btnPermService := service.NewBtnPermService(btnPermRepository, loggerLogger)
 	permissionRuleRepository := persistence.NewPermissionRuleRepository(postgresDB)
-	permissionService := service.NewPermissionService(roleService, userService, roleRepository, btnPermRepository, apiRepository, permissionRuleRepository, permissionGroupRepository, loggerLogger)
+	invalidationBus := cache.SetupInvalidationBus(redisClient, loggerLogger)
+	permissionDecisionService := service.NewPermissionDecisionService(roleRepository, userRepository, apiRepository, permissionGroupRepository, permissionRuleRepository, cacheCache, invalidationBus, loggerLogger)
+	permissionService := service.NewPermissionService(roleService, userService, roleRepository, btnPermRepository, apiRepository, permissionRuleRepository, permissionGroupRepository, permissionDecisionService, loggerLogger)
 	btnPermController := baseapi.NewBtnPermController(btnPermService, permissionService, roleService, loggerLogger)
 	configRepository := persistence.NewConfigRepository(postgresDB)
 	configService := service.NewConfigService(configRepository, loggerLogger)
@@ -103,8 +144,7 @@ func InitializeApp() (*Application, error) {
 	dictController := baseapi.NewDictController(dictService, dictItemService, loggerLogger)
 	operationLogRepository := persistence.NewOperationLogRepository(postgresDB)
 	operationLogService := service.NewOperationLogService(operationLogRepository, loggerLogger)
-	operationLogController := baseapi.NewOperationLogController(operationLogService)
-	departmentRepository := persistence.NewDepartmentRepository(postgresDB)
+	operationLogController := baseapi.NewOperationLogController(operationLogService, userService)
 	departmentService := service.NewDepartmentService(departmentRepository, loggerLogger)
 	departmentController := baseapi.NewDepartmentController(departmentService, loggerLogger)
 	positionRepository := persistence.NewPositionRepository(postgresDB)
@@ -115,10 +155,8 @@ func InitializeApp() (*Application, error) {
 	noticeService := service.NewNoticeService(noticeRepository, noticeReadRecordRepository, loggerLogger)
 	noticeReadRecordService := service.NewNoticeReadRecordService(noticeReadRecordRepository, loggerLogger)
 	noticeController := baseapi.NewNoticeController(noticeService, noticeReadRecordService, loggerLogger)
-	loginLogController := baseapi.NewLoginLogController(loginLogService, loggerLogger)
-	onlineUserRepository := persistence.NewOnlineUserRepository(cacheCache)
-	onlineUserService := service.NewOnlineUserService(onlineUserRepository, loggerLogger)
-	onlineUserController := baseapi.NewOnlineUserController(onlineUserService, loggerLogger)
+	loginLogController := baseapi.NewLoginLogController(loginLogService, loginRiskService, userService, loggerLogger)
+	onlineUserController := baseapi.NewOnlineUserController(onlineUserService, hub, loggerLogger)
 	systemMonitor := monitor.SetupSystemMonitor()
 	client := ProvideRedisClient(redisClient)
 	cacheMonitor := monitor.SetupCacheMonitor(client)
@@ -127,22 +165,65 @@ func InitializeApp() (*Application, error) {
 	permissionLogRepository := persistence.NewPermissionLogRepository(postgresDB, loggerLogger)
 	permissionLogService := service.NewPermissionLogService(permissionLogRepository, loggerLogger)
 	permissionLogController := baseapi.NewPermissionLogController(permissionLogService, loggerLogger)
-	appBase, err := base.NewAppBase(loggerLogger, configConfig, postgresDB, jwtAuth, permissionManager, authController, userController, taskController, apiController, menuController, roleController, btnPermController, configController, dictController, operationLogController, departmentController, positionController, noticeController, loginLogController, onlineUserController, monitorController, permissionLogController, userRepository, apiRepository, roleRepository, menuRepository, configRepository, dictRepository, operationLogService, onlineUserService, taskExecutionLogRepository, cacheCache)
+	passwordResetService := service.NewPasswordResetService(userRepository, userService, cacheCache, captchaCaptcha, senders, configConfig, loggerLogger)
+	passwordResetController := baseapi.NewPasswordResetController(passwordResetService, loggerLogger)
+	serviceAccountRepository := persistence.NewServiceAccountRepository(postgresDB)
+	serviceAccountService := service.NewServiceAccountService(serviceAccountRepository, roleRepository, loggerLogger)
+	serviceAccountController := baseapi.NewServiceAccountController(serviceAccountService, loggerLogger)
+	ldapController := baseapi.NewLDAPController(ldapService, loggerLogger)
+	oidcProviderRepository := persistence.NewOIDCProviderRepository(postgresDB)
+	oidcService := service.NewOIDCService(oidcProviderRepository, userRepository, roleRepository, userService, cacheCache, loggerLogger)
+	oidcController := baseapi.NewOIDCController(oidcService, userService, loggerLogger)
+	oidcClientRepository := persistence.NewOIDCClientRepository(postgresDB)
+	signer, err := oidc.SetupSigner(configConfig, loggerLogger)
+	if err != nil {
+		return nil, err
+	}
+	identityProviderService := service.NewIdentityProviderService(oidcClientRepository, userRepository, roleRepository, departmentRepository, signer, configConfig, cacheCache, loggerLogger)
+	identityProviderController := baseapi.NewIdentityProviderController(identityProviderService, loggerLogger)
+	ipPolicyRepository := persistence.NewIPPolicyRepository(postgresDB)
+	ipPolicyService := service.NewIPPolicyService(ipPolicyRepository, roleRepository, userRepository, loginLogService, cacheCache, loggerLogger)
+	ipPolicyController := baseapi.NewIPPolicyController(ipPolicyService, loggerLogger)
+	permissionRuleService := service.NewPermissionRuleService(permissionRuleRepository, loggerLogger)
+	permissionRuleController := baseapi.NewPermissionRuleController(permissionRuleService, loggerLogger)
+	permissionExplainService := service.NewPermissionExplainService(roleRepository, userRepository, apiRepository, btnPermRepository, permissionGroupRepository, permissionRuleRepository, permissionDecisionService, loggerLogger)
+	permissionExplainController := baseapi.NewPermissionExplainController(permissionExplainService, loggerLogger)
+	roleGrantRepository := persistence.NewRoleGrantRepository(postgresDB)
+	roleGrantService := service.NewRoleGrantService(roleGrantRepository, roleRepository, userRepository, permissionLogRepository, permissionDecisionService, soDService, cacheCache, configConfig, loggerLogger)
+	roleGrantController := baseapi.NewRoleGrantController(roleGrantService, loggerLogger)
+	permissionAuditLogRepository := persistence.NewPermissionAuditLogRepository(postgresDB)
+	policyBundleService := service.NewPolicyBundleService(policyBundleRepository, userRepository, permissionAuditLogRepository, permissionChangeRequestRepository, loggerLogger)
+	permissionChangeService := service.NewPermissionChangeService(permissionChangeRequestRepository, roleService, policyBundleService, userRepository, roleRepository, permissionDecisionService, permissionAuditLogRepository, loggerLogger)
+	permissionChangeController := baseapi.NewPermissionChangeController(permissionChangeService, loggerLogger)
+	policyBundleController := baseapi.NewPolicyBundleController(policyBundleService, loggerLogger)
+	soDController := baseapi.NewSoDController(soDService, loggerLogger)
+	appBase, err := base.NewAppBase(loggerLogger, configConfig, postgresDB, jwtAuth, permissionManager, authController, userController, taskController, apiController, menuController, roleController, btnPermController, configController, dictController, operationLogController, departmentController, positionController, noticeController, loginLogController, onlineUserController, monitorController, permissionLogController, passwordResetController, serviceAccountController, ldapController, oidcController, identityProviderController, ipPolicyController, permissionRuleController, permissionExplainController, roleGrantController, permissionChangeController, policyBundleController, soDController, userRepository, apiRepository, roleRepository, menuRepository, configRepository, dictRepository, operationLogService, onlineUserService, serviceAccountService, ipPolicyService, dataScopeService, apiService, permissionDecisionService, loginLogService, roleGrantService, taskExecutionLogRepository, cacheCache, redactor)
 	if err != nil {
 		return nil, err
 	}
 	wxUserRepository := persistence2.NewWXUserRepository(postgresDB)
 	wxAuthSessionRepository := persistence2.NewWXAuthSessionRepository(postgresDB)
-	wxAuthService := service2.NewWXAuthService(jwtAuth, loggerLogger, wxUserRepository, wxAuthSessionRepository, configRepository)
+	wxAuthService := service2.NewWXAuthService(jwtAuth, loggerLogger, wxUserRepository, wxAuthSessionRepository, configRepository, onlineUserService)
 	wxapiAuthController := wxapi.NewAuthController(wxAuthService, loggerLogger)
 	paymentRepository := persistence2.NewPaymentRepository(postgresDB)
 	paymentService := service2.NewPaymentService(paymentRepository, taskManager, configRepository, loggerLogger)
 	paymentController := wxapi.NewPaymentController(paymentService, loggerLogger)
-	appWX, err := wx.NewAppWX(loggerLogger, postgresDB, jwtAuth, permissionManager, wxapiAuthController, paymentController)
+	openAppRepository := persistence2.NewOpenAppRepository(postgresDB)
+	apisignSigner, err := apisign.SetupSigner(configConfig, loggerLogger)
+	if err != nil {
+		return nil, err
+	}
+	openAPIService := service2.NewOpenAPIService(openAppRepository, userRepository, cacheCache, apisignSigner, configConfig, loggerLogger)
+	openAppController := wxapi.NewOpenAppController(openAPIService, loggerLogger)
+	appWX, err := wx.NewAppWX(loggerLogger, postgresDB, jwtAuth, permissionManager, wxapiAuthController, paymentController, openAppController, onlineUserService, dataScopeService, openAPIService)
+	if err != nil {
+		return nil, err
+	}
+	cipher, err := fieldcrypt.SetupCipher(configConfig, loggerLogger)
 	if err != nil {
 		return nil, err
 	}
-	application, err := SetupApplication(configConfig, multiLogger, loggerLogger, postgresDB, snowflakeSnowflake, jwtAuth, permissionManager, cacheCache, appBase, appWX)
+	application, err := SetupApplication(configConfig, multiLogger, loggerLogger, redactor, postgresDB, snowflakeSnowflake, jwtAuth, permissionManager, cacheCache, cipher, taskManager, appBase, appWX)
 	if err != nil {
 		return nil, err
 	}
@@ -152,7 +233,7 @@ func InitializeApp() (*Application, error) {
 // wire.go:
 
 // 定义全局服务提供者集合
-var GlobalServiceSet = wire.NewSet(config.LoadConfig, logger.SetupMultiLogger, logger.SetupLogger, database.SetupPostgresDB, redis.SetupRedisClient, cache.SetupCache, snowflake.SetupSnowflake, captcha.SetupCaptcha, auth.SetupJWTAuth, auth.SetupPermissionManager, task.SetupTaskManager, ProvideRedisClient,
+var GlobalServiceSet = wire.NewSet(config.LoadConfig, redact.SetupRedactor, logger.SetupMultiLogger, logger.SetupLogger, database.SetupPostgresDB, redis.SetupRedisClient, cache.SetupCache, snowflake.SetupSnowflake, captcha.SetupCaptcha, notify.SetupSenders, push.SetupHub, ldap.SetupAuthenticator, oidc.SetupSigner, apisign.SetupSigner, fieldcrypt.SetupCipher, auth.SetupJWTAuth, auth.SetupPermissionManager, task.SetupTaskManager, ProvideRedisClient,
 
 	SetupApplication,
 )
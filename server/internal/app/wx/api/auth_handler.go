@@ -49,7 +49,7 @@ func (c *AuthController) LoginByCode(ctx *gin.Context) {
 	}
 
 	// 调用服务层登录方法，返回用户信息、访问令牌、刷新令牌和错误
-	wxUser, accessToken, refreshToken, accessExpire, _, err := c.service.LoginByCode(req.Code)
+	wxUser, accessToken, refreshToken, accessExpire, _, err := c.service.LoginByCode(req.Code, ctx.ClientIP(), ctx.GetHeader("User-Agent"))
 	if err != nil {
 		baseRes.FailWithMessage("微信登录失败", ctx)
 		return
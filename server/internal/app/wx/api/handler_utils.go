@@ -0,0 +1,31 @@
+package wxapi
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/entity"
+)
+
+// currentDataScope 获取当前请求的数据权限，由数据权限中间件保存的解析函数按需解析
+// 上下文中没有解析函数时按仅本人处理
+func currentDataScope(ctx *gin.Context) *entity.DataScope {
+	if value, exists := ctx.Get("dataScopeResolver"); exists {
+		if resolve, ok := value.(func() *entity.DataScope); ok {
+			if scope := resolve(); scope != nil {
+				return scope
+			}
+		}
+	}
+	var userID int64
+	if value := ctx.GetString("userID"); value != "" {
+		userID, _ = strconv.ParseInt(value, 10, 64)
+	}
+	return &entity.DataScope{Scope: entity.DataScopeSelf, UserID: userID}
+}
+
+// withDataScope 将当前数据权限放入列表查询的过滤条件
+func withDataScope(ctx *gin.Context, filters map[string]interface{}) map[string]interface{} {
+	filters[entity.DataScopeFilterKey] = currentDataScope(ctx)
+	return filters
+}
@@ -0,0 +1,341 @@
+package wxapi
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/ix-pay/ixpay-pro/internal/domain/wx/entity"
+	wxService "github.com/ix-pay/ixpay-pro/internal/domain/wx/service"
+	"github.com/ix-pay/ixpay-pro/internal/dto/wx/request"
+	"github.com/ix-pay/ixpay-pro/internal/dto/wx/response"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/observability/logger"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/security/apisign"
+	"github.com/ix-pay/ixpay-pro/internal/utils/common/baseRes"
+
+	"github.com/gin-gonic/gin"
+)
+
+// OpenAppController 开放接口应用控制器
+// @Summary 开放接口应用相关 API
+// @Description 提供合作方开放接口应用的创建、查询、更新、删除和密钥重置功能
+// @Tags 开放接口
+// @Router /api/wx/open-apps [get]
+type OpenAppController struct {
+	service *wxService.OpenAPIService
+	log     logger.Logger
+}
+
+// NewOpenAppController 创建开放接口应用控制器
+func NewOpenAppController(service *wxService.OpenAPIService, log logger.Logger) *OpenAppController {
+	return &OpenAppController{
+		service: service,
+		log:     log,
+	}
+}
+
+// convertToOpenAppResponse 将 entity.OpenApp 转换为 response.OpenAppResponse
+func convertToOpenAppResponse(app *entity.OpenApp) response.OpenAppResponse {
+	return response.OpenAppResponse{
+		ID:          strconv.FormatInt(app.ID, 10),
+		Name:        app.Name,
+		AppKey:      app.AppKey,
+		SignType:    app.SignType,
+		PublicKey:   app.PublicKey,
+		UserID:      strconv.FormatInt(app.UserID, 10),
+		Description: app.Description,
+		Status:      app.Status,
+		CreatedAt:   app.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:   app.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+// currentUserID 从上下文获取当前登录用户 ID
+func currentUserID(ctx *gin.Context) (int64, error) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		return 0, errors.New("未授权")
+	}
+	userIDStr, ok := userID.(string)
+	if !ok {
+		return 0, errors.New("用户 ID 格式错误")
+	}
+	id, err := strconv.ParseInt(userIDStr, 10, 64)
+	if err != nil {
+		return 0, errors.New("用户 ID 格式错误")
+	}
+	return id, nil
+}
+
+// GetOpenAppList 获取开放接口应用列表
+// @Summary 获取开放接口应用列表
+// @Description 分页获取开放接口应用列表，不返回应用密钥
+// @Tags 开放接口
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param page query int true "页码"
+// @Param pageSize query int true "每页数量"
+// @Param status query int false "状态 (0:禁用，1:启用)"
+// @Success 200 {object} baseRes.Response{data=response.OpenAppListResponse,msg=string} "应用列表"
+// @Failure 400 {object} map[string]string "请求参数错误"
+// @Failure 401 {object} map[string]string "未授权"
+// @Router /api/wx/open-apps [get]
+func (c *OpenAppController) GetOpenAppList(ctx *gin.Context) {
+	var req request.GetOpenAppListRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		c.log.Error("请求参数错误", "error", err)
+		baseRes.FailWithMessage("请求参数错误", ctx)
+		return
+	}
+
+	filters := make(map[string]interface{})
+	if req.Status != nil {
+		filters["status"] = *req.Status
+	}
+
+	apps, total, err := c.service.ListApps(req.Page, req.PageSize, filters)
+	if err != nil {
+		baseRes.FailWithMessage(err.Error(), ctx)
+		return
+	}
+
+	list := make([]response.OpenAppResponse, 0, len(apps))
+	for _, app := range apps {
+		list = append(list, convertToOpenAppResponse(app))
+	}
+
+	baseRes.OkWithDetailed(response.OpenAppListResponse{
+		List:     list,
+		Total:    total,
+		Page:     int64(req.Page),
+		PageSize: int64(req.PageSize),
+	}, "获取开放接口应用列表成功", ctx)
+}
+
+// GetOpenApp 获取开放接口应用详情
+// @Summary 获取开放接口应用详情
+// @Description 根据 ID 获取开放接口应用详情，不返回应用密钥
+// @Tags 开放接口
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "应用 ID"
+// @Success 200 {object} baseRes.Response{data=response.OpenAppResponse,msg=string} "应用详情"
+// @Failure 400 {object} map[string]string "请求参数错误"
+// @Failure 401 {object} map[string]string "未授权"
+// @Router /api/wx/open-apps/{id} [get]
+func (c *OpenAppController) GetOpenApp(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		baseRes.FailWithMessage("无效的 ID 格式", ctx)
+		return
+	}
+
+	app, err := c.service.GetApp(id)
+	if err != nil {
+		baseRes.FailWithMessage(err.Error(), ctx)
+		return
+	}
+
+	baseRes.OkWithDetailed(convertToOpenAppResponse(app), "获取开放接口应用详情成功", ctx)
+}
+
+// CreateOpenApp 创建开放接口应用
+// @Summary 创建开放接口应用
+// @Description 创建开放接口应用，返回应用标识和应用密钥，密钥只返回一次，请妥善保存
+// @Tags 开放接口
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param data body request.CreateOpenAppRequest true "应用信息"
+// @Success 200 {object} baseRes.Response{data=response.OpenAppSecretResponse,msg=string} "创建成功"
+// @Failure 400 {object} map[string]string "请求参数错误"
+// @Failure 401 {object} map[string]string "未授权"
+// @Router /api/wx/open-apps [post]
+func (c *OpenAppController) CreateOpenApp(ctx *gin.Context) {
+	var req request.CreateOpenAppRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		c.log.Error("请求参数错误", "error", err)
+		baseRes.FailWithMessage("请求参数错误", ctx)
+		return
+	}
+
+	operatorID, err := currentUserID(ctx)
+	if err != nil {
+		baseRes.NoAuth(err.Error(), ctx)
+		return
+	}
+
+	userID, err := strconv.ParseInt(req.UserID, 10, 64)
+	if err != nil {
+		baseRes.FailWithMessage("无效的用户 ID", ctx)
+		return
+	}
+
+	// 提供默认值：status=1（启用）
+	status := entity.OpenAppStatusEnabled
+	if req.Status != nil {
+		status = *req.Status
+	}
+
+	app, err := c.service.CreateApp(&entity.OpenApp{
+		Name:        req.Name,
+		SignType:    req.SignType,
+		PublicKey:   req.PublicKey,
+		UserID:      userID,
+		Description: req.Description,
+		Status:      status,
+		CreatedBy:   operatorID,
+		UpdatedBy:   operatorID,
+	})
+	if err != nil {
+		baseRes.FailWithMessage(err.Error(), ctx)
+		return
+	}
+
+	baseRes.OkWithDetailed(response.OpenAppSecretResponse{
+		OpenAppResponse: convertToOpenAppResponse(app),
+		AppSecret:       app.AppSecret,
+	}, "创建开放接口应用成功", ctx)
+}
+
+// UpdateOpenApp 更新开放接口应用
+// @Summary 更新开放接口应用
+// @Description 更新开放接口应用的名称、签名方式、公钥、绑定用户、描述和状态
+// @Tags 开放接口
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "应用 ID"
+// @Param data body request.UpdateOpenAppRequest true "应用信息"
+// @Success 200 {object} baseRes.Response{msg=string} "更新成功"
+// @Failure 400 {object} map[string]string "请求参数错误"
+// @Failure 401 {object} map[string]string "未授权"
+// @Router /api/wx/open-apps/{id} [put]
+func (c *OpenAppController) UpdateOpenApp(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		baseRes.FailWithMessage("无效的 ID 格式", ctx)
+		return
+	}
+
+	var req request.UpdateOpenAppRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		c.log.Error("请求参数错误", "error", err)
+		baseRes.FailWithMessage("请求参数错误", ctx)
+		return
+	}
+
+	operatorID, err := currentUserID(ctx)
+	if err != nil {
+		baseRes.NoAuth(err.Error(), ctx)
+		return
+	}
+
+	userID, err := strconv.ParseInt(req.UserID, 10, 64)
+	if err != nil {
+		baseRes.FailWithMessage("无效的用户 ID", ctx)
+		return
+	}
+
+	if err := c.service.UpdateApp(&entity.OpenApp{
+		ID:          id,
+		Name:        req.Name,
+		SignType:    req.SignType,
+		PublicKey:   req.PublicKey,
+		UserID:      userID,
+		Description: req.Description,
+		Status:      req.Status,
+		UpdatedBy:   operatorID,
+	}); err != nil {
+		baseRes.FailWithMessage(err.Error(), ctx)
+		return
+	}
+
+	baseRes.OkWithMessage("更新开放接口应用成功", ctx)
+}
+
+// DeleteOpenApp 删除开放接口应用
+// @Summary 删除开放接口应用
+// @Description 删除开放接口应用，删除后使用该应用标识的请求立即被拒绝
+// @Tags 开放接口
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "应用 ID"
+// @Success 200 {object} baseRes.Response{msg=string} "删除成功"
+// @Failure 400 {object} map[string]string "请求参数错误"
+// @Failure 401 {object} map[string]string "未授权"
+// @Router /api/wx/open-apps/{id} [delete]
+func (c *OpenAppController) DeleteOpenApp(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		baseRes.FailWithMessage("无效的 ID 格式", ctx)
+		return
+	}
+
+	if err := c.service.DeleteApp(id); err != nil {
+		baseRes.FailWithMessage(err.Error(), ctx)
+		return
+	}
+
+	baseRes.OkWithMessage("删除开放接口应用成功", ctx)
+}
+
+// ResetOpenAppSecret 重置开放接口应用密钥
+// @Summary 重置开放接口应用密钥
+// @Description 生成新的应用密钥，旧密钥立即失效，新密钥只返回一次
+// @Tags 开放接口
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "应用 ID"
+// @Success 200 {object} baseRes.Response{data=response.OpenAppSecretResponse,msg=string} "重置成功"
+// @Failure 400 {object} map[string]string "请求参数错误"
+// @Failure 401 {object} map[string]string "未授权"
+// @Router /api/wx/open-apps/{id}/reset-secret [post]
+func (c *OpenAppController) ResetOpenAppSecret(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		baseRes.FailWithMessage("无效的 ID 格式", ctx)
+		return
+	}
+
+	operatorID, err := currentUserID(ctx)
+	if err != nil {
+		baseRes.NoAuth(err.Error(), ctx)
+		return
+	}
+
+	secret, err := c.service.ResetSecret(id, operatorID)
+	if err != nil {
+		baseRes.FailWithMessage(err.Error(), ctx)
+		return
+	}
+
+	app, err := c.service.GetApp(id)
+	if err != nil {
+		baseRes.FailWithMessage(err.Error(), ctx)
+		return
+	}
+
+	baseRes.OkWithDetailed(response.OpenAppSecretResponse{
+		OpenAppResponse: convertToOpenAppResponse(app),
+		AppSecret:       secret,
+	}, "重置应用密钥成功", ctx)
+}
+
+// GetPlatformPublicKey 获取平台响应签名公钥
+// @Summary 获取平台响应签名公钥
+// @Description RSA-SHA256 签名方式的应用使用该公钥校验平台响应签名
+// @Tags 开放接口
+// @Produce json
+// @Success 200 {object} baseRes.Response{data=response.OpenAPIPublicKeyResponse,msg=string} "平台公钥"
+// @Router /api/open/v1/public-key [get]
+func (c *OpenAppController) GetPlatformPublicKey(ctx *gin.Context) {
+	baseRes.OkWithDetailed(response.OpenAPIPublicKeyResponse{
+		SignType:  apisign.SignTypeRSA,
+		PublicKey: c.service.PlatformPublicKey(),
+	}, "获取平台公钥成功", ctx)
+}
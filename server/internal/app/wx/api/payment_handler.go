@@ -170,35 +170,69 @@ func (c *PaymentController) GetPayment(ctx *gin.Context) {
 	baseRes.OkWithDetailed(paymentResponse, "查询支付成功", ctx)
 }
 
-// GetUserPayments 获取用户支付列表
-// @Summary 获取用户支付列表
-// @Description 获取当前登录用户的所有支付记录
+// GetPaymentList 获取支付列表
+// @Summary 获取支付列表
+// @Description 分页查询支付记录，按当前用户的数据权限过滤，仅本人权限只返回自己的支付记录
 // @Tags 支付管理
 // @Accept json
 // @Produce json
 // @Security BearerAuth
-// @Success 200 {object} map[string]interface{} "支付列表及分页信息"
+// @Param page query int true "页码"
+// @Param pageSize query int true "每页数量"
+// @Param status query string false "支付状态"
+// @Success 200 {object} baseRes.PageResult "支付列表及分页信息"
+// @Failure 400 {object} map[string]string "请求参数错误"
 // @Failure 401 {object} map[string]string "未授权"
+// @Failure 500 {object} map[string]string "服务器内部错误"
 // @Router /api//payment [get]
-func (c *PaymentController) GetUserPayments(ctx *gin.Context) {
-	// 从上下文中获取用户 ID（实际未使用）
-	_, exists := ctx.Get("userID")
-	if !exists {
+func (c *PaymentController) GetPaymentList(ctx *gin.Context) {
+	if _, exists := ctx.Get("userID"); !exists {
 		c.log.Error("未授权")
 		baseRes.NoAuth("未授权", ctx)
 		return
 	}
 
-	// 由于 service 中没有 GetUserPayments 方法，返回空列表
-	// 实际实现应该在 service 层添加这个方法
-	c.log.Error("GetUserPayments 方法在 service 层未实现")
-	response := baseRes.PageResult{
-		List:     []response.PaymentResponse{},
-		Total:    0,
-		Page:     1,
-		PageSize: 10,
+	var req request.GetPaymentListRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		c.log.Error("支付列表参数错误", "error", err)
+		baseRes.FailWithMessage("请求参数错误: "+err.Error(), ctx)
+		return
 	}
-	baseRes.OkWithDetailed(response, "查询支付成功", ctx)
+
+	filters := make(map[string]interface{})
+	if req.Status != "" {
+		filters["status"] = req.Status
+	}
+
+	payments, total, err := c.service.GetPaymentList(req.Page, req.PageSize, withDataScope(ctx, filters))
+	if err != nil {
+		baseRes.FailWithMessage("查询支付列表失败", ctx)
+		return
+	}
+
+	list := make([]response.PaymentResponse, len(payments))
+	for i, payment := range payments {
+		list[i] = response.PaymentResponse{
+			ID:            fmt.Sprintf("%d", payment.ID),
+			OrderID:       payment.OrderID,
+			UserID:        fmt.Sprintf("%d", payment.UserID),
+			Amount:        float64(payment.Amount) / 100.0,
+			Currency:      payment.Currency,
+			PaymentMethod: payment.Method,
+			Status:        string(payment.Status),
+			TransactionID: payment.TransactionID,
+			Description:   payment.Description,
+			CreatedAt:     payment.CreatedAt.Format("2006-01-02 15:04:05"),
+			UpdatedAt:     payment.UpdatedAt.Format("2006-01-02 15:04:05"),
+		}
+	}
+
+	baseRes.OkWithDetailed(baseRes.PageResult{
+		List:     list,
+		Total:    int64(total),
+		Page:     req.Page,
+		PageSize: req.PageSize,
+	}, "查询支付成功", ctx)
 }
 
 // CancelPayment 取消支付
@@ -3,6 +3,8 @@ package wx
 import (
 	wxapi "github.com/ix-pay/ixpay-pro/internal/app/wx/api"
 	"github.com/ix-pay/ixpay-pro/internal/app/wx/migrations"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/service"
+	wxService "github.com/ix-pay/ixpay-pro/internal/domain/wx/service"
 	"github.com/ix-pay/ixpay-pro/internal/infrastructure/observability/logger"
 	"github.com/ix-pay/ixpay-pro/internal/infrastructure/persistence/database"
 	auth "github.com/ix-pay/ixpay-pro/internal/infrastructure/security/auth"
@@ -19,6 +21,10 @@ type AppWX struct {
 	logger            logger.Logger
 	authController    *wxapi.AuthController
 	paymentController *wxapi.PaymentController
+	openAppController *wxapi.OpenAppController
+	onlineUserService *service.OnlineUserService
+	dataScopeService  *service.DataScopeService
+	openAPIService    *wxService.OpenAPIService
 }
 
 // NewApplication 创建应用程序实例
@@ -29,6 +35,10 @@ func NewAppWX(
 	permissions *auth.PermissionManager,
 	authController *wxapi.AuthController,
 	paymentController *wxapi.PaymentController,
+	openAppController *wxapi.OpenAppController,
+	onlineUserService *service.OnlineUserService,
+	dataScopeService *service.DataScopeService,
+	openAPIService *wxService.OpenAPIService,
 ) (*AppWX, error) {
 	// 执行数据库迁移，创建所有需要的表
 	// if err := db.Migrate(log); err != nil {
@@ -44,6 +54,10 @@ func NewAppWX(
 		logger:            log,
 		authController:    authController,
 		paymentController: paymentController,
+		openAppController: openAppController,
+		onlineUserService: onlineUserService,
+		dataScopeService:  dataScopeService,
+		openAPIService:    openAPIService,
 	}
 
 	return app, nil
@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/service"
 	"github.com/ix-pay/ixpay-pro/internal/infrastructure/observability/logger"
 	"github.com/ix-pay/ixpay-pro/internal/infrastructure/security/auth"
 
@@ -11,7 +12,8 @@ import (
 )
 
 // AuthMiddleware 认证中间件
-func AuthMiddleware(jwtAuth *auth.JWTAuth, log logger.Logger) gin.HandlerFunc {
+// 令牌携带会话 ID 时校验会话是否仍然有效，并刷新会话最后活跃时间
+func AuthMiddleware(jwtAuth *auth.JWTAuth, onlineUserService *service.OnlineUserService, log logger.Logger) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// 从 Authorization 头获取令牌
 		authHeader := c.GetHeader("Authorization")
@@ -37,11 +39,27 @@ func AuthMiddleware(jwtAuth *auth.JWTAuth, log logger.Logger) gin.HandlerFunc {
 			return
 		}
 
+		// 检查会话是否已退出、被下线或过期
+		if claims.SessionID != "" {
+			active, err := onlineUserService.TouchSession(claims.SessionID)
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "检查会话状态失败"})
+				c.Abort()
+				return
+			}
+			if !active {
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "登录会话已失效，请重新登录"})
+				c.Abort()
+				return
+			}
+		}
+
 		// 将用户信息添加到上下文
 		c.Set("userID", claims.UserID)
 		c.Set("userName", claims.Username)
 		c.Set("role", claims.Role)
 		c.Set("loginType", claims.LoginType)
+		c.Set("sessionID", claims.SessionID)
 		c.Set("claims", claims)
 
 		c.Next()
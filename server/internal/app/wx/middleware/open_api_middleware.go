@@ -0,0 +1,138 @@
+package middleware
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/ix-pay/ixpay-pro/internal/domain/wx/service"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/observability/logger"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/security/apisign"
+
+	"github.com/gin-gonic/gin"
+)
+
+// 开放接口请求体大小上限
+const maxOpenAPIBodySize = 1 << 20
+
+// OpenAPIMiddleware 开放接口签名中间件
+// 校验请求签名、时间戳和随机串，通过后以应用绑定的用户身份继续处理，并对响应签名
+// 签名失败的响应不签名，合作方应以缺少签名头判断为鉴权失败
+func OpenAPIMiddleware(openAPIService *service.OpenAPIService, log logger.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxOpenAPIBodySize))
+		if err != nil {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "请求体过大"})
+			c.Abort()
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		app, err := openAPIService.VerifyRequest(&service.OpenAPIRequest{
+			Method:    c.Request.Method,
+			Path:      c.Request.URL.EscapedPath(),
+			RawQuery:  c.Request.URL.RawQuery,
+			AppKey:    c.GetHeader(apisign.HeaderAppKey),
+			Timestamp: c.GetHeader(apisign.HeaderTimestamp),
+			Nonce:     c.GetHeader(apisign.HeaderNonce),
+			Signature: c.GetHeader(apisign.HeaderSignature),
+			Body:      body,
+		})
+		if err != nil {
+			if errors.Is(err, service.ErrOpenAPIUnavailable) {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			} else {
+				log.Warn("开放接口请求校验失败",
+					"appKey", c.GetHeader(apisign.HeaderAppKey),
+					"ip", c.ClientIP(),
+					"path", c.Request.URL.Path,
+					"error", err,
+				)
+				c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			}
+			c.Abort()
+			return
+		}
+
+		// 以应用绑定的用户身份继续处理，复用现有支付接口的归属校验
+		c.Set("userID", strconv.FormatInt(app.UserID, 10))
+		c.Set("openAppID", app.ID)
+		c.Set("openAppKey", app.AppKey)
+
+		// 缓冲响应，签名头必须在写出响应体之前设置
+		writer := &signedResponseWriter{ResponseWriter: c.Writer, status: http.StatusOK}
+		c.Writer = writer
+
+		c.Next()
+
+		c.Writer = writer.ResponseWriter
+		responseBody := writer.body.Bytes()
+		signature, err := openAPIService.SignResponse(app, responseBody)
+		if err != nil {
+			log.Error("开放接口响应签名失败", "appKey", app.AppKey, "error", err)
+			c.Writer.Header().Del("Content-Length")
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "响应签名失败"})
+			return
+		}
+
+		header := c.Writer.Header()
+		header.Set(apisign.HeaderAppKey, app.AppKey)
+		header.Set(apisign.HeaderTimestamp, signature.Timestamp)
+		header.Set(apisign.HeaderNonce, signature.Nonce)
+		header.Set(apisign.HeaderSignType, signature.SignType)
+		header.Set(apisign.HeaderSignature, signature.Signature)
+		c.Writer.WriteHeader(writer.status)
+		if _, err := c.Writer.Write(responseBody); err != nil {
+			log.Error("写出开放接口响应失败", "appKey", app.AppKey, "error", err)
+		}
+	}
+}
+
+// signedResponseWriter 缓冲响应状态码和响应体，由中间件签名后统一写出
+type signedResponseWriter struct {
+	gin.ResponseWriter
+	body   bytes.Buffer
+	status int
+	size   int
+}
+
+// WriteHeader 仅记录状态码
+func (w *signedResponseWriter) WriteHeader(code int) {
+	if code > 0 {
+		w.status = code
+	}
+}
+
+// WriteHeaderNow 缓冲期间不写出响应头
+func (w *signedResponseWriter) WriteHeaderNow() {}
+
+// Write 写入缓冲区
+func (w *signedResponseWriter) Write(data []byte) (int, error) {
+	n, err := w.body.Write(data)
+	w.size += n
+	return n, err
+}
+
+// WriteString 写入缓冲区
+func (w *signedResponseWriter) WriteString(s string) (int, error) {
+	n, err := w.body.WriteString(s)
+	w.size += n
+	return n, err
+}
+
+// Status 返回缓冲的状态码
+func (w *signedResponseWriter) Status() int {
+	return w.status
+}
+
+// Size 返回缓冲的响应体长度
+func (w *signedResponseWriter) Size() int {
+	return w.size
+}
+
+// Written 缓冲期间视为未写出，允许后续处理继续设置响应
+func (w *signedResponseWriter) Written() bool {
+	return false
+}
@@ -48,8 +48,12 @@ func PermissionMiddleware(permissionManager *auth.PermissionManager) gin.Handler
 			ctx = context.WithValue(ctx, auth.ClaimsKey, claims)
 		}
 
-		// 检查权限
-		if !permissionManager.CheckPermission(ctx, method, path) {
+		// 按路由模板检查权限，未匹配路由时使用请求路径
+		route := c.FullPath()
+		if route == "" {
+			route = path
+		}
+		if !permissionManager.CheckPermission(ctx, method, route) {
 			c.JSON(http.StatusForbidden, gin.H{"error": "权限被拒绝"})
 			c.Abort()
 			return
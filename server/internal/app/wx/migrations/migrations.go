@@ -124,12 +124,47 @@ func MigrateDatabase(db *database.PostgresDB, log logger.Logger) {
 		CREATE INDEX IF NOT EXISTS idx_wx_auth_sessions_expires_at ON wx_auth_sessions(expires_at);
 	`
 
+	// 创建 wx_open_apps 表
+	createWxOpenAppsTable := `
+		CREATE TABLE IF NOT EXISTS wx_open_apps (
+			id BIGINT PRIMARY KEY,
+			name VARCHAR(100) NOT NULL,
+			app_key VARCHAR(64) NOT NULL,
+			app_secret VARCHAR(128) NOT NULL,
+			sign_type VARCHAR(20) NOT NULL,
+			public_key TEXT,
+			user_id BIGINT NOT NULL DEFAULT 0,
+			description VARCHAR(255),
+			status INTEGER NOT NULL DEFAULT 1,
+			created_by BIGINT NOT NULL DEFAULT 0,
+			updated_by BIGINT NOT NULL DEFAULT 0,
+			deleted_by BIGINT NOT NULL DEFAULT 0,
+			created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+			updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+			deleted_at TIMESTAMP
+		);
+		
+		CREATE UNIQUE INDEX IF NOT EXISTS idx_wx_open_apps_app_key ON wx_open_apps(app_key);
+		CREATE INDEX IF NOT EXISTS idx_wx_open_apps_user_id ON wx_open_apps(user_id);
+	`
+
+	// 敏感字段加密：OpenID 和应用密钥改为 TEXT 保存密文，OpenID 唯一约束改由盲索引保证
+	encryptSensitiveColumns := `
+		ALTER TABLE wx_users ALTER COLUMN open_id TYPE TEXT;
+		ALTER TABLE wx_users ADD COLUMN IF NOT EXISTS open_id_hash VARCHAR(64) NOT NULL DEFAULT '';
+		ALTER TABLE wx_open_apps ALTER COLUMN app_secret TYPE TEXT;
+		
+		CREATE UNIQUE INDEX IF NOT EXISTS idx_wx_users_open_id_hash ON wx_users(open_id_hash) WHERE open_id_hash <> '';
+	`
+
 	// 执行SQL语句
 	sqlStatements := []string{
 		createPaymentsTable,
 		createWechatPayInfosTable,
 		createWxUsersTable,
 		createWxAuthSessionsTable,
+		createWxOpenAppsTable,
+		encryptSensitiveColumns,
 	}
 
 	for _, sql := range sqlStatements {
@@ -28,17 +28,46 @@ func (a *AppWX) setupRoutes() {
 
 		// 需要认证的路由
 		authenticated := wx
-		authenticated.Use(middleware.AuthMiddleware(a.auth, a.logger))
+		authenticated.Use(middleware.AuthMiddleware(a.auth, a.onlineUserService, a.logger))
 		authenticated.Use(middleware.PermissionMiddleware(a.permissions))
+		authenticated.Use(middleware.DataScopeMiddleware(a.dataScopeService, a.logger))
 		{
 			// 支付路由
 			payment := authenticated.Group("/payment")
 			{
 				payment.POST("", a.paymentController.CreatePayment)
 				payment.GET("/:id", a.paymentController.GetPayment)
-				payment.GET("", a.paymentController.GetUserPayments)
+				payment.GET("", a.paymentController.GetPaymentList)
 				payment.PUT("/:id/cancel", a.paymentController.CancelPayment)
 			}
+			// 开放接口应用管理路由
+			openApps := authenticated.Group("/open-apps")
+			{
+				openApps.GET("", a.openAppController.GetOpenAppList)
+				openApps.POST("", a.openAppController.CreateOpenApp)
+				openApps.GET("/:id", a.openAppController.GetOpenApp)
+				openApps.PUT("/:id", a.openAppController.UpdateOpenApp)
+				openApps.DELETE("/:id", a.openAppController.DeleteOpenApp)
+				openApps.POST("/:id/reset-secret", a.openAppController.ResetOpenAppSecret)
+			}
+		}
+	}
+
+	// 合作方开放接口路由组，使用应用标识和签名认证，不使用登录令牌
+	open := a.router.Group("/api/open/v1")
+	{
+		// 平台响应签名公钥（不需要签名）
+		open.GET("/public-key", a.openAppController.GetPlatformPublicKey)
+
+		// 需要签名的路由
+		signed := open.Group("")
+		signed.Use(middleware.OpenAPIMiddleware(a.openAPIService, a.logger))
+		{
+			payment := signed.Group("/payment")
+			{
+				payment.POST("", a.paymentController.CreatePayment)
+				payment.GET("/:id", a.paymentController.GetPayment)
+			}
 		}
 	}
 }
@@ -14,6 +14,7 @@ var ProviderSetWXRepo = wire.NewSet(
 	repository.NewWechatPayInfoRepository,
 	repository.NewWXUserRepository,
 	repository.NewWXAuthSessionRepository,
+	repository.NewOpenAppRepository,
 )
 
 var ProviderSetWXService = wire.NewSet(
@@ -21,12 +22,14 @@ var ProviderSetWXService = wire.NewSet(
 	service.NewPaymentService,
 	service.NewWechatPayInfoService,
 	service.NewWXAuthService,
+	service.NewOpenAPIService,
 )
 
 var ProviderSetWXController = wire.NewSet(
 	// 控制器层
 	wxapi.NewPaymentController,
 	wxapi.NewAuthController,
+	wxapi.NewOpenAppController,
 )
 
 var ProviderSetWXApp = wire.NewSet(
@@ -14,6 +14,20 @@ type Config struct {
 	Wechat   WechatConfig   `mapstructure:"wechat"`
 	Swagger  SwaggerConfig  `mapstructure:"swagger"`
 	DBPool   DBPoolConfig   `mapstructure:"db_pool"`
+
+	PasswordPolicy PasswordPolicyConfig `mapstructure:"password_policy"`
+	PasswordReset  PasswordResetConfig  `mapstructure:"password_reset"`
+	Notify         NotifyConfig         `mapstructure:"notify"`
+	LDAP           LDAPConfig           `mapstructure:"ldap"`
+
+	IdentityProvider IdentityProviderConfig `mapstructure:"identity_provider"`
+	Session          SessionConfig          `mapstructure:"session"`
+	OpenAPI          OpenAPIConfig          `mapstructure:"open_api"`
+	Encryption       EncryptionConfig       `mapstructure:"encryption"`
+	Captcha          CaptchaConfig          `mapstructure:"captcha"`
+	GeoIP            GeoIPConfig            `mapstructure:"geoip"`
+	LoginRisk        LoginRiskConfig        `mapstructure:"login_risk"`
+	RoleGrant        RoleGrantConfig        `mapstructure:"role_grant"`
 }
 
 // DBPoolConfig 数据库连接池配置
@@ -37,6 +51,9 @@ type ServerConfig struct {
 	UpdateRoutesOnStart bool   `mapstructure:"update_routes_on_start"` // 是否在启动时更新路由信息
 	InitSeedData        bool   `mapstructure:"init_seed_data"`         // 是否初始化种子数据
 	SlowQueryThreshold  int64  `mapstructure:"slow_query_threshold"`   // 慢查询阈值（毫秒）
+	// TrustedProxies 受信任的反向代理（IP 或 CIDR），只有来自这些地址的 X-Forwarded-For 才会被采信
+	// 为空表示不信任任何代理，客户端 IP 取 TCP 连接的对端地址
+	TrustedProxies []string `mapstructure:"trusted_proxies"`
 }
 
 // PostgresConfig PostgreSQL配置
@@ -84,6 +101,23 @@ type LoggingConfig struct {
 	MaxBackups  int    `mapstructure:"max_backups"`
 	MaxAge      int    `mapstructure:"max_age"`
 	SeparateLog bool   `mapstructure:"separate_log"` // 是否分开存储不同级别日志（保留向后兼容）
+
+	Redaction RedactionConfig `mapstructure:"redaction"` // 敏感数据脱敏配置
+}
+
+// RedactionConfig 日志敏感数据脱敏配置
+type RedactionConfig struct {
+	Rules         []RedactionRuleConfig `mapstructure:"rules"`           // 自定义脱敏规则，追加到内置规则之后
+	HashSalt      string                `mapstructure:"hash_salt"`       // 哈希脱敏使用的盐值
+	MaxBodySize   int                   `mapstructure:"max_body_size"`   // 请求体和响应体记录的最大字节数
+	SkipBodyPaths []string              `mapstructure:"skip_body_paths"` // 不记录请求体和响应体的接口，格式为 "[方法] 路径"
+}
+
+// RedactionRuleConfig 脱敏规则配置
+type RedactionRuleConfig struct {
+	Key    string `mapstructure:"key"`    // 字段名，忽略大小写和下划线，包含即命中
+	Path   string `mapstructure:"path"`   // JSON 路径，如 $.data.list[*].phone
+	Action string `mapstructure:"action"` // 脱敏方式：mask 全部遮盖、partial 保留首尾、hash 哈希
 }
 
 // WechatConfig 微信配置
@@ -101,6 +135,163 @@ type SwaggerConfig struct {
 	Path    string `mapstructure:"path"`
 }
 
+// PasswordPolicyConfig 密码策略配置
+type PasswordPolicyConfig struct {
+	MinLength          int    `mapstructure:"min_length"`            // 最小长度
+	MaxLength          int    `mapstructure:"max_length"`            // 最大长度
+	RequireUpper       bool   `mapstructure:"require_upper"`         // 是否要求大写字母
+	RequireLower       bool   `mapstructure:"require_lower"`         // 是否要求小写字母
+	RequireDigit       bool   `mapstructure:"require_digit"`         // 是否要求数字
+	RequireSpecial     bool   `mapstructure:"require_special"`       // 是否要求特殊字符
+	DisallowUsername   bool   `mapstructure:"disallow_username"`     // 是否禁止包含用户名
+	CommonPasswordFile string `mapstructure:"common_password_file"`  // 常用弱密码字典文件路径（每行一个）
+	HistoryCount       int    `mapstructure:"history_count"`         // 禁止重复使用最近N次密码，0表示不限制
+	ExpireDays         int    `mapstructure:"expire_days"`           // 密码有效期（天），0表示永不过期
+	ForceChangeOnReset bool   `mapstructure:"force_change_on_reset"` // 管理员重置后首次登录是否强制修改密码
+}
+
+// PasswordResetConfig 自助找回密码配置
+type PasswordResetConfig struct {
+	Enabled        bool `mapstructure:"enabled"`         // 是否开启自助找回密码
+	CodeLength     int  `mapstructure:"code_length"`     // 验证码长度
+	CodeTTL        int  `mapstructure:"code_ttl"`        // 验证码有效期（秒）
+	MaxAttempts    int  `mapstructure:"max_attempts"`    // 单个验证码最大校验次数，超过后作废
+	ResendInterval int  `mapstructure:"resend_interval"` // 同一账号重新发送间隔（秒）
+	DailyLimit     int  `mapstructure:"daily_limit"`     // 同一账号每日最多发送次数
+	IPHourlyLimit  int  `mapstructure:"ip_hourly_limit"` // 同一 IP 每小时最多请求次数
+}
+
+// NotifyConfig 消息发送配置
+type NotifyConfig struct {
+	EmailDriver string     `mapstructure:"email_driver"` // 邮件发送驱动：smtp | log
+	SMSDriver   string     `mapstructure:"sms_driver"`   // 短信发送驱动：http | log
+	SMTP        SMTPConfig `mapstructure:"smtp"`
+	SMS         SMSConfig  `mapstructure:"sms"`
+}
+
+// SMTPConfig SMTP 邮件服务配置
+type SMTPConfig struct {
+	Host     string `mapstructure:"host"`
+	Port     int    `mapstructure:"port"`
+	Username string `mapstructure:"username"`
+	Password string `mapstructure:"password"`
+	From     string `mapstructure:"from"`
+	UseSSL   bool   `mapstructure:"use_ssl"` // true 使用隐式 TLS（465），false 使用 STARTTLS（587/25）
+}
+
+// SMSConfig 短信网关配置（HTTP 网关，适配主流短信服务商的通用转发接口）
+type SMSConfig struct {
+	GatewayURL string `mapstructure:"gateway_url"` // 短信网关地址
+	AppKey     string `mapstructure:"app_key"`
+	AppSecret  string `mapstructure:"app_secret"`
+	SignName   string `mapstructure:"sign_name"` // 短信签名
+	Timeout    int    `mapstructure:"timeout"`   // 请求超时（秒）
+}
+
+// LDAPConfig LDAP / Active Directory 登录配置
+type LDAPConfig struct {
+	Enabled            bool   `mapstructure:"enabled"`              // 是否开启 LDAP 登录
+	URL                string `mapstructure:"url"`                  // 服务地址，ldap://host:389 或 ldaps://host:636
+	StartTLS           bool   `mapstructure:"start_tls"`            // ldap:// 连接是否升级为 TLS
+	InsecureSkipVerify bool   `mapstructure:"insecure_skip_verify"` // 是否跳过证书校验（仅测试环境使用）
+	Timeout            int    `mapstructure:"timeout"`              // 连接和请求超时（秒）
+	BindDN             string `mapstructure:"bind_dn"`              // 查询用户使用的服务账号 DN，为空时匿名绑定
+	BindPassword       string `mapstructure:"bind_password"`        // 服务账号密码
+	BaseDN             string `mapstructure:"base_dn"`              // 用户搜索根 DN
+	UserFilter         string `mapstructure:"user_filter"`          // 用户搜索过滤器，{username} 替换为转义后的登录名
+	GroupBaseDN        string `mapstructure:"group_base_dn"`        // 组搜索根 DN，为空时只使用用户的 memberOf 属性
+	GroupFilter        string `mapstructure:"group_filter"`         // 组搜索过滤器，{dn} 替换为用户 DN，{username} 替换为登录名
+	UsernameAttribute  string `mapstructure:"username_attribute"`   // 用户名属性，AD 为 sAMAccountName，OpenLDAP 为 uid
+	NicknameAttribute  string `mapstructure:"nickname_attribute"`   // 昵称属性
+	EmailAttribute     string `mapstructure:"email_attribute"`      // 邮箱属性
+	PhoneAttribute     string `mapstructure:"phone_attribute"`      // 手机号属性
+	FallbackToLocal    bool   `mapstructure:"fallback_to_local"`    // LDAP 中不存在该用户或服务不可用时是否回退到本地账号
+}
+
+// IdentityProviderConfig 对内部应用提供的 OIDC 身份提供方配置
+type IdentityProviderConfig struct {
+	Enabled         bool   `mapstructure:"enabled"`           // 是否开启
+	Issuer          string `mapstructure:"issuer"`            // 签发方，即对外访问地址 + /api/oidc，如 https://admin.example.com/api/oidc
+	SigningKeyFile  string `mapstructure:"signing_key_file"`  // RSA 签名私钥 PEM 文件，为空时启动时临时生成（重启后已签发令牌失效，仅用于开发环境）
+	ConsentURL      string `mapstructure:"consent_url"`       // 前端授权确认页地址，未登录时由前端先跳转登录页
+	AuthCodeTTL     int    `mapstructure:"auth_code_ttl"`     // 授权码有效期（秒）
+	AccessTokenTTL  int    `mapstructure:"access_token_ttl"`  // 访问令牌有效期（秒）
+	IDTokenTTL      int    `mapstructure:"id_token_ttl"`      // ID Token 有效期（秒）
+	RefreshTokenTTL int    `mapstructure:"refresh_token_ttl"` // 刷新令牌有效期（秒）
+}
+
+// 同一用户会话数超过上限时的处理策略
+const (
+	SessionPolicyKickOldest = "kick_oldest" // 踢出最早登录的会话
+	SessionPolicyReject     = "reject"      // 拒绝本次登录
+)
+
+// SessionConfig 登录会话配置
+type SessionConfig struct {
+	MaxConcurrent int    `mapstructure:"max_concurrent"` // 同一用户最大同时在线会话数，0 表示不限制
+	Policy        string `mapstructure:"policy"`         // 超过上限时的处理策略：kick_oldest | reject
+}
+
+// OpenAPIConfig 合作方开放接口配置
+type OpenAPIConfig struct {
+	TimestampTolerance int    `mapstructure:"timestamp_tolerance"` // 请求时间戳允许的最大偏差（秒），随机串在两倍偏差时间内不可重复使用
+	SigningKeyFile     string `mapstructure:"signing_key_file"`    // RSA 签名方式下平台响应签名私钥 PEM 文件，为空时启动时临时生成（仅开发环境）
+}
+
+// EncryptionConfig 敏感字段加密配置
+// 主密钥格式为 "<密钥 ID>:<base64 编码的 32 字节密钥>"，多个密钥以换行或逗号分隔，第一个为当前主密钥
+type EncryptionConfig struct {
+	MasterKeyFile string `mapstructure:"master_key_file"` // 主密钥文件，配置后忽略环境变量
+	MasterKeyEnv  string `mapstructure:"master_key_env"`  // 主密钥环境变量名，默认 IXPAY_MASTER_KEYS
+	RotationCron  string `mapstructure:"rotation_cron"`   // 重新加密任务的 cron 表达式（含秒），为空时只能手动执行
+}
+
+// CaptchaConfig 验证码配置
+// 验证码长度、有效期和总开关沿用 server.captcha_len、server.captcha_time_out 和 server.open_captcha
+type CaptchaConfig struct {
+	Driver          string                           `mapstructure:"driver"`           // 默认验证码类型：digit | math | string | audio | slider
+	MaxAttempts     int                              `mapstructure:"max_attempts"`     // 单个验证码最大校验次数，超过后作废
+	SliderTolerance int                              `mapstructure:"slider_tolerance"` // 滑块验证码允许的偏移误差（像素）
+	Scenarios       map[string]CaptchaScenarioConfig `mapstructure:"scenarios"`        // 按场景配置的验证码策略，键为 login、register、password_reset
+}
+
+// CaptchaScenarioConfig 场景验证码策略
+type CaptchaScenarioConfig struct {
+	Mode             string `mapstructure:"mode"`              // always 始终需要、after_failures 失败次数达到阈值后需要、off 不需要
+	Driver           string `mapstructure:"driver"`            // 验证码类型，为空时使用默认类型
+	FailureThreshold int    `mapstructure:"failure_threshold"` // after_failures 模式下需要验证码的失败次数
+	FailureWindow    int    `mapstructure:"failure_window"`    // 失败次数统计窗口（秒）
+}
+
+// GeoIPConfig 离线 IP 归属地配置
+type GeoIPConfig struct {
+	DBPath         string `mapstructure:"db_path"`         // 地址库文件路径，为空时只识别本地和内网地址
+	Format         string `mapstructure:"format"`          // 地址库格式：ip2region | mmdb，为空时按扩展名识别
+	Language       string `mapstructure:"language"`        // MaxMind DB 地名语言，默认 zh-CN
+	CacheSize      int    `mapstructure:"cache_size"`      // 查询缓存条数
+	ReloadInterval int    `mapstructure:"reload_interval"` // 检查地址库文件变更的间隔（秒）
+	BackfillCron   string `mapstructure:"backfill_cron"`   // 登录日志归属地回填任务的 cron 表达式（含秒），为空时不回填
+}
+
+// LoginRiskConfig 异常登录检测配置
+type LoginRiskConfig struct {
+	Enabled           bool     `mapstructure:"enabled"`              // 是否开启新设备、新地点和异地登录检测
+	NotifyChannels    []string `mapstructure:"notify_channels"`      // 提醒渠道：in_app（推送到已登录会话）、email
+	MaxTravelSpeed    float64  `mapstructure:"max_travel_speed"`     // 两次登录之间可能的最大移动速度（km/h），超过视为异地登录
+	MinTravelDistance float64  `mapstructure:"min_travel_distance"`  // 异地登录的最小距离（km），距离较近时忽略 IP 定位误差
+	TravelWindow      int      `mapstructure:"travel_window"`        // 地址库没有经纬度时，在该时间（秒）内更换国家视为异地登录
+	StepUpEvents      []string `mapstructure:"step_up_events"`       // 需要二次验证才能完成登录的事件类型，为空时只提醒
+	StepUpChannel     string   `mapstructure:"step_up_channel"`      // 二次验证码发送渠道：email | sms
+	StepUpCodeTTL     int      `mapstructure:"step_up_code_ttl"`     // 二次验证码有效期（秒）
+	StepUpMaxAttempts int      `mapstructure:"step_up_max_attempts"` // 二次验证码最大校验次数
+}
+
+// RoleGrantConfig 限时角色授予配置
+type RoleGrantConfig struct {
+	ExpiryCron        string `mapstructure:"expiry_cron"`         // 生效和到期检查任务的 cron 表达式（含秒），为空时限时授予不会自动生效和收回
+	MaxElevationHours int    `mapstructure:"max_elevation_hours"` // 单次提权申请的最长时长（小时），默认 24
+}
+
 // LoadConfig 加载配置文件
 func LoadConfig() (*Config, error) {
 	viper.SetConfigName("config")
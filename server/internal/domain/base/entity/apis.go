@@ -13,6 +13,7 @@ type API struct {
 	AuthType     int       // 授权类型：0-不需要授权（只要登录），1-需要授权（需要角色权限）
 	Description  string    // 描述
 	Status       int       // 状态：1-启用，0-禁用
+	Orphaned     bool      // 是否已失效：启动同步时路由已不存在，保留记录和角色分配等待管理员处理
 	RoleIds      []int64   // 关联的角色 ID 列表
 	MenuIds      []int64   // 关联的菜单 ID 列表
 	BtnPermIds   []int64   // 关联的按钮权限 ID 列表
@@ -36,3 +37,14 @@ func (a *API) RequireAuth() bool {
 func (a *API) RequirePermission() bool {
 	return a.AuthType == 1
 }
+
+// APISyncReport API 路由同步报告
+// 启动时将已注册的 gin 路由与 API 表比对生成，供权限管理员查看需要分配的新接口和已失效的接口
+type APISyncReport struct {
+	SyncedAt   time.Time // 同步时间
+	Total      int       // 已注册的路由数量
+	Added      []*API    // 本次新登记的路由
+	Restored   []*API    // 重新出现并清除失效标记的路由
+	Orphaned   []*API    // 已失效的路由
+	Unassigned []*API    // 需要授权但尚未分配给任何角色的路由
+}
@@ -0,0 +1,56 @@
+package entity
+
+// 数据权限范围，配置在角色上，按用户当前角色生效
+const (
+	DataScopeAll             = 1 // 全部数据
+	DataScopeDept            = 2 // 本部门数据
+	DataScopeDeptAndChildren = 3 // 本部门及下级部门数据
+	DataScopeCustom          = 4 // 自定义部门数据
+	DataScopeSelf            = 5 // 仅本人数据
+)
+
+// DataScopeFilterKey 列表查询过滤条件中携带数据权限的键，仓库取出后转换为查询条件
+const DataScopeFilterKey = "__data_scope"
+
+// DataScope 解析后的数据权限，由当前用户和当前角色计算得出
+// 部门范围统一展开为部门 ID 列表，仓库无需再查询部门树
+type DataScope struct {
+	Scope         int     `json:"scope"`         // 数据权限范围
+	UserID        int64   `json:"userId"`        // 当前用户 ID，部门范围下本人的数据始终可见
+	DepartmentIDs []int64 `json:"departmentIds"` // 可见的部门 ID 列表
+}
+
+// IsAll 是否可以查看全部数据，未解析出数据权限时不视为全部
+func (d *DataScope) IsAll() bool {
+	return d != nil && d.Scope == DataScopeAll
+}
+
+// IsSelf 是否只能查看本人数据
+func (d *DataScope) IsSelf() bool {
+	return d != nil && d.Scope == DataScopeSelf
+}
+
+// CanAccess 是否可以访问指定用户的数据，用于按 ID 查询、修改和删除单条记录
+// 本人的数据始终可见，部门范围下按用户所属部门判断
+func (d *DataScope) CanAccess(userID, departmentID int64) bool {
+	if d == nil {
+		return false
+	}
+	if d.Scope == DataScopeAll || userID == d.UserID {
+		return true
+	}
+	if d.Scope == DataScopeSelf {
+		return false
+	}
+	for _, id := range d.DepartmentIDs {
+		if id == departmentID {
+			return true
+		}
+	}
+	return false
+}
+
+// IsValidDataScope 检查数据权限范围是否有效
+func IsValidDataScope(scope int) bool {
+	return scope >= DataScopeAll && scope <= DataScopeSelf
+}
@@ -0,0 +1,45 @@
+package entity
+
+import "time"
+
+// IP 访问策略作用范围
+const (
+	IPPolicyScopeGlobal = "global" // 全局，作用于所有访问 /api/admin 的请求
+	IPPolicyScopeRole   = "role"   // 角色，作用于使用该角色的用户
+	IPPolicyScopeUser   = "user"   // 用户，作用于指定用户
+)
+
+// IP 访问策略动作
+const (
+	IPPolicyActionAllow = "allow" // 白名单，只允许规则内的 IP 访问
+	IPPolicyActionDeny  = "deny"  // 黑名单，拒绝规则内的 IP 访问
+)
+
+// IP 访问策略状态
+const (
+	IPPolicyStatusDisabled = 0 // 禁用
+	IPPolicyStatusEnabled  = 1 // 启用
+)
+
+// IPPolicy IP 访问策略领域实体
+// 同一作用范围内存在白名单时，IP 必须命中其中一条；任一黑名单命中即拒绝
+// 纯业务模型，无 GORM 标签
+type IPPolicy struct {
+	ID          int64     // 策略 ID
+	Name        string    // 名称，唯一
+	Scope       string    // 作用范围：global、role、user
+	TargetID    int64     // 作用对象 ID：角色 ID 或用户 ID，全局策略为 0
+	Action      string    // 动作：allow、deny
+	CIDRs       []string  // CIDR 规则列表，单个 IP 存储为 /32 或 /128
+	Description string    // 描述
+	Status      int       // 状态：1-启用，0-禁用
+	CreatedBy   int64     // 创建人 ID
+	CreatedAt   time.Time // 创建时间
+	UpdatedBy   int64     // 更新人 ID
+	UpdatedAt   time.Time // 更新时间
+}
+
+// IsActive 检查策略是否启用
+func (p *IPPolicy) IsActive() bool {
+	return p.Status == IPPolicyStatusEnabled
+}
@@ -0,0 +1,19 @@
+package entity
+
+import "time"
+
+// LDAPGroupMapping LDAP 组映射领域实体
+// 将 LDAP / AD 中的组映射为本地角色和部门，LDAP 用户登录时据此同步角色和所属部门
+// 纯业务模型，无 GORM 标签
+type LDAPGroupMapping struct {
+	ID           int64     // 映射 ID
+	GroupDN      string    // LDAP 组 DN 或组名（CN），匹配时不区分大小写
+	RoleID       int64     // 映射的角色 ID，0 表示不映射角色
+	DepartmentID int64     // 映射的部门 ID，0 表示不映射部门
+	Priority     int       // 优先级，值越大越优先；用户属于多个组时取优先级最高的部门
+	Description  string    // 描述
+	CreatedBy    int64     // 创建人 ID
+	CreatedAt    time.Time // 创建时间
+	UpdatedBy    int64     // 更新人 ID
+	UpdatedAt    time.Time // 更新时间
+}
@@ -0,0 +1,33 @@
+package entity
+
+import "time"
+
+// LoginDevice 用户常用登录设备
+// 设备指纹由浏览器类型和操作系统计算，不含版本号，浏览器升级不会被识别为新设备
+// 纯业务模型，无 GORM 标签
+type LoginDevice struct {
+	ID          int64     // 记录 ID
+	UserID      int64     // 用户 ID
+	Fingerprint string    // 设备指纹
+	Browser     string    // 浏览器
+	OS          string    // 操作系统
+	LastIP      string    // 最近登录 IP
+	LastPlace   string    // 最近登录地点
+	LoginCount  int       // 登录次数
+	FirstSeenAt time.Time // 首次登录时间
+	LastSeenAt  time.Time // 最近登录时间
+}
+
+// LoginLocation 用户常用登录地点，按国家和城市区分
+// 纯业务模型，无 GORM 标签
+type LoginLocation struct {
+	ID         int64     // 记录 ID
+	UserID     int64     // 用户 ID
+	Country    string    // 国家
+	Province   string    // 省份
+	City       string    // 城市
+	Latitude   float64   // 纬度，地址库不提供时为 0
+	Longitude  float64   // 经度，地址库不提供时为 0
+	LastIP     string    // 最近登录 IP
+	LastSeenAt time.Time // 最近登录时间
+}
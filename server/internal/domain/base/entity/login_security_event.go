@@ -0,0 +1,60 @@
+package entity
+
+import "time"
+
+// 登录安全事件类型
+const (
+	LoginEventNewDevice        = "new_device"        // 新设备登录
+	LoginEventNewCountry       = "new_country"       // 新国家登录
+	LoginEventNewCity          = "new_city"          // 新城市登录
+	LoginEventImpossibleTravel = "impossible_travel" // 短时间内在相距较远的两地登录
+)
+
+// 登录安全事件风险等级
+const (
+	LoginRiskLow    = "low"
+	LoginRiskMedium = "medium"
+	LoginRiskHigh   = "high"
+)
+
+// 二次验证状态
+const (
+	StepUpStatusNone    = ""        // 未要求二次验证
+	StepUpStatusPending = "pending" // 等待二次验证
+	StepUpStatusPassed  = "passed"  // 二次验证通过，登录完成
+	StepUpStatusFailed  = "failed"  // 二次验证失败次数过多
+)
+
+// LoginRiskLevel 返回事件类型对应的风险等级
+func LoginRiskLevel(eventType string) string {
+	switch eventType {
+	case LoginEventImpossibleTravel:
+		return LoginRiskHigh
+	case LoginEventNewDevice, LoginEventNewCountry:
+		return LoginRiskMedium
+	default:
+		return LoginRiskLow
+	}
+}
+
+// LoginSecurityEvent 登录安全事件
+// 登录认证通过后检测到新设备、新地点或异地登录时生成，用于异常登录查询和用户提醒
+// 纯业务模型，无 GORM 标签
+type LoginSecurityEvent struct {
+	ID            int64     // 事件 ID
+	UserID        int64     // 用户 ID
+	Username      string    // 用户名
+	Type          string    // 事件类型：new_device、new_country、new_city、impossible_travel
+	RiskLevel     string    // 风险等级：low、medium、high
+	IP            string    // 本次登录 IP
+	Place         string    // 本次登录地点
+	Device        string    // 本次登录设备
+	Fingerprint   string    // 本次登录设备指纹
+	PreviousIP    string    // 上次登录 IP（异地登录）
+	PreviousPlace string    // 上次登录地点（异地登录）
+	PreviousTime  time.Time // 上次登录时间（异地登录）
+	DistanceKm    float64   // 两次登录地点的距离（km），无经纬度时为 0
+	Description   string    // 事件描述
+	StepUpStatus  string    // 二次验证状态
+	CreatedAt     time.Time // 发生时间
+}
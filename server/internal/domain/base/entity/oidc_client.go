@@ -0,0 +1,83 @@
+package entity
+
+import "time"
+
+// OIDC 客户端状态
+const (
+	OIDCClientStatusDisabled = 0 // 禁用
+	OIDCClientStatusEnabled  = 1 // 启用
+)
+
+// OIDC 客户端支持的授权类型
+const (
+	OIDCGrantAuthorizationCode = "authorization_code" // 授权码
+	OIDCGrantRefreshToken      = "refresh_token"      // 刷新令牌
+	OIDCGrantClientCredentials = "client_credentials" // 客户端凭证（服务间调用）
+)
+
+// OIDC 客户端可申请的授权范围
+const (
+	OIDCScopeOpenID     = "openid"     // 必选，返回 sub
+	OIDCScopeProfile    = "profile"    // 昵称、用户名、头像
+	OIDCScopeEmail      = "email"      // 邮箱
+	OIDCScopePhone      = "phone"      // 手机号
+	OIDCScopeRoles      = "roles"      // 角色编码列表
+	OIDCScopeDepartment = "department" // 所属部门
+)
+
+// OIDCClient 接入本系统身份提供方的客户端应用
+// 机密客户端使用密钥认证，密钥只保存 SHA-256 哈希；公开客户端（SPA、移动端）无密钥，必须使用 PKCE
+// 纯业务模型，无 GORM 标签
+type OIDCClient struct {
+	ID           int64     // 客户端记录 ID
+	ClientID     string    // 客户端标识，唯一，例如 web、h5app
+	Name         string    // 应用名称，授权确认页展示
+	Description  string    // 描述
+	SecretHash   string    // 客户端密钥哈希，公开客户端为空
+	Public       bool      // 是否为公开客户端
+	RedirectURIs []string  // 允许的回调地址，精确匹配
+	GrantTypes   []string  // 允许的授权类型
+	Scopes       []string  // 允许申请的授权范围
+	SkipConsent  bool      // 是否跳过授权确认（受信任的内部应用）
+	Status       int       // 状态：1-启用，0-禁用
+	CreatedBy    int64     // 创建人 ID
+	CreatedAt    time.Time // 创建时间
+	UpdatedBy    int64     // 更新人 ID
+	UpdatedAt    time.Time // 更新时间
+}
+
+// IsActive 检查客户端是否启用
+func (c *OIDCClient) IsActive() bool {
+	return c.Status == OIDCClientStatusEnabled
+}
+
+// AllowsGrant 检查客户端是否允许指定授权类型
+func (c *OIDCClient) AllowsGrant(grantType string) bool {
+	for _, g := range c.GrantTypes {
+		if g == grantType {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowsRedirectURI 检查回调地址是否已登记
+func (c *OIDCClient) AllowsRedirectURI(redirectURI string) bool {
+	for _, uri := range c.RedirectURIs {
+		if uri == redirectURI {
+			return true
+		}
+	}
+	return false
+}
+
+// OIDCConsent 用户对客户端的授权记录
+// 已授权的范围再次申请时不再弹出确认页
+type OIDCConsent struct {
+	ID        int64     // 记录 ID
+	UserID    int64     // 用户 ID
+	ClientID  int64     // 客户端记录 ID
+	Scopes    []string  // 已授权的范围
+	CreatedAt time.Time // 首次授权时间
+	UpdatedAt time.Time // 最近授权时间
+}
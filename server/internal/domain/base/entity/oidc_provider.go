@@ -0,0 +1,70 @@
+package entity
+
+import "time"
+
+// OIDC 身份提供方状态
+const (
+	OIDCProviderStatusDisabled = 0 // 禁用
+	OIDCProviderStatusEnabled  = 1 // 启用
+)
+
+// OIDC 账号关联方式
+const (
+	OIDCLinkBySubject = "subject" // 仅按已绑定的 sub 关联
+	OIDCLinkByEmail   = "email"   // 未绑定时按已验证的邮箱关联本地用户
+)
+
+// OIDCProvider OIDC 身份提供方领域实体
+// 管理后台可通过任意 OIDC / OAuth2 身份提供方（Keycloak、飞书、钉钉、企业 IdP 等）单点登录
+// 端点为空时通过 Issuer 的发现文档获取
+// 纯业务模型，无 GORM 标签
+type OIDCProvider struct {
+	ID                    int64              // 身份提供方 ID
+	Code                  string             // 编码，唯一，用于登录地址
+	Name                  string             // 显示名称
+	Issuer                string             // 签发方
+	ClientID              string             // 客户端 ID
+	ClientSecret          string             // 客户端密钥
+	TokenAuthMethod       string             // 客户端认证方式：client_secret_basic / client_secret_post
+	Scopes                []string           // 授权范围
+	RedirectURI           string             // 回调地址
+	AuthorizationEndpoint string             // 授权端点
+	TokenEndpoint         string             // 令牌端点
+	UserinfoEndpoint      string             // 用户信息端点
+	JWKSURI               string             // JWKS 地址
+	LinkBy                string             // 账号关联方式：subject / email
+	TrustEmail            bool               // 是否信任未声明 email_verified 的邮箱
+	AutoCreate            bool               // 无关联账号时是否自动开通本地用户
+	UsernameClaim         string             // 自动开通时作为用户名的声明，默认 preferred_username
+	RoleMappings          []*OIDCRoleMapping // 声明到角色的映射规则
+	Status                int                // 状态：1-启用，0-禁用
+	Sort                  int                // 排序
+	CreatedBy             int64              // 创建人 ID
+	CreatedAt             time.Time          // 创建时间
+	UpdatedBy             int64              // 更新人 ID
+	UpdatedAt             time.Time          // 更新时间
+}
+
+// IsActive 检查身份提供方是否启用
+func (p *OIDCProvider) IsActive() bool {
+	return p.Status == OIDCProviderStatusEnabled
+}
+
+// OIDCRoleMapping 声明到角色的映射规则
+// 登录时声明（支持 a.b.c 形式的嵌套路径）等于 Value 或为包含 Value 的数组时授予角色
+type OIDCRoleMapping struct {
+	Claim  string // 声明路径，例如 groups、realm_access.roles
+	Value  string // 期望的声明值
+	RoleID int64  // 授予的角色 ID
+}
+
+// UserOIDCIdentity 用户与 OIDC 身份的绑定关系
+type UserOIDCIdentity struct {
+	ID          int64      // 绑定 ID
+	ProviderID  int64      // 身份提供方 ID
+	Subject     string     // 身份提供方中的用户标识（sub）
+	UserID      int64      // 本地用户 ID
+	Email       string     // 绑定时的邮箱
+	LastLoginAt *time.Time // 最近登录时间
+	CreatedAt   time.Time  // 绑定时间
+}
@@ -3,13 +3,14 @@ package entity
 import "time"
 
 // OnlineUser 在线用户领域实体
-// 存储在 Redis 中，用于实时跟踪在线用户状态
+// 每次登录生成一条会话记录，按会话 ID 存储在 Redis 中，同一用户可在多个设备同时在线
 // 纯业务模型，无 GORM 标签
 type OnlineUser struct {
 	UserID       int64     // 用户 ID
 	Username     string    // 用户名
 	Nickname     string    // 用户昵称
-	SessionID    string    // 会话 ID
+	SessionID    string    // 会话 ID，与令牌中的 sid 声明一致
+	LoginType    string    // 登录方式：password、ldap、oidc、wechat
 	LoginIP      string    // 登录 IP
 	LoginPlace   string    // 登录地点
 	LoginTime    time.Time // 登录时间
@@ -18,6 +19,7 @@ type OnlineUser struct {
 	Browser      string    // 浏览器信息
 	OS           string    // 操作系统
 	UserAgent    string    // 原始 User-Agent
+	ExpiresAt    time.Time // 会话过期时间，与刷新令牌有效期一致
 }
 
 // IsActive 检查用户是否活跃（5 分钟内）
@@ -0,0 +1,14 @@
+package entity
+
+import "time"
+
+// PasswordHistory 密码历史领域实体
+// 记录用户历次使用过的密码哈希，用于禁止重复使用最近 N 次密码
+// 纯业务模型，无 GORM 标签
+type PasswordHistory struct {
+	ID           int64     // 记录 ID
+	UserID       int64     // 用户 ID
+	PasswordHash string    // 密码哈希值
+	CreatedBy    int64     // 创建人 ID
+	CreatedAt    time.Time // 创建时间（即密码设置时间）
+}
@@ -0,0 +1,20 @@
+package entity
+
+import "time"
+
+// PermissionAuditLog 权限审计日志领域实体
+// 对应 sys_permission_logs 表，记录经审批生效的权限变更前后数据
+// 纯业务模型，无 GORM 标签
+type PermissionAuditLog struct {
+	ID           int64     // 日志 ID
+	OperatorID   int64     // 操作人 ID，审批生效时为审批人
+	OperatorName string    // 操作人名称
+	ActionType   string    // 操作类型
+	TargetType   string    // 目标类型
+	TargetID     int64     // 目标 ID
+	BeforeData   string    // 变更前数据（JSON）
+	AfterData    string    // 变更后数据（JSON）
+	IPAddress    string    // 操作 IP
+	UserAgent    string    // User-Agent
+	CreatedAt    time.Time // 创建时间
+}
@@ -0,0 +1,68 @@
+package entity
+
+import "time"
+
+// 权限变更类型
+const (
+	PermissionChangeRoleUsers        = "role_users"        // 角色用户分配
+	PermissionChangeRoleMenus        = "role_menus"        // 角色菜单
+	PermissionChangeRoleAPIs         = "role_apis"         // 角色接口
+	PermissionChangeRoleBtnPerms     = "role_btn_perms"    // 角色按钮权限
+	PermissionChangeApprovalRequired = "approval_required" // 关闭角色的变更审批，无增删列表
+	PermissionChangePolicyBundle     = "policy_bundle"     // 导入影响开启审批的角色的策略包，无增删列表
+)
+
+// 权限变更申请状态
+const (
+	PermissionChangeStatusPending  = "pending"  // 待审批
+	PermissionChangeStatusApproved = "approved" // 已通过并生效
+	PermissionChangeStatusRejected = "rejected" // 已拒绝
+)
+
+// PermissionChangeRequest 权限变更申请领域实体
+// 需要审批的角色发生权限变更时，变更不会立即生效，而是记录完整的差异等待其他审批人处理
+// 纯业务模型，无 GORM 标签
+type PermissionChangeRequest struct {
+	ID            int64      // 申请 ID
+	RoleID        int64      // 变更的角色 ID
+	ChangeType    string     // 变更类型，见 PermissionChangeRoleUsers 等常量
+	BeforeIDs     []int64    // 提交时角色已关联的对象 ID
+	AddedIDs      []int64    // 新增关联的对象 ID
+	RemovedIDs    []int64    // 移除关联的对象 ID
+	Payload       string     // 策略包导入申请的策略包内容（JSON），其他变更类型为空
+	Status        string     // 状态，见 PermissionChangeStatusPending 等常量
+	RequestedBy   int64      // 申请人 ID
+	ReviewedBy    int64      // 审批人 ID
+	ReviewedAt    *time.Time // 审批时间
+	ReviewComment string     // 审批意见
+	CreatedBy     int64      // 创建人 ID
+	CreatedAt     time.Time  // 创建时间
+	UpdatedBy     int64      // 更新人 ID
+	UpdatedAt     time.Time  // 更新时间
+}
+
+// IsPending 检查申请是否待审批
+func (r *PermissionChangeRequest) IsPending() bool {
+	return r.Status == PermissionChangeStatusPending
+}
+
+// AfterIDs 计算变更生效后角色关联的对象 ID
+func (r *PermissionChangeRequest) AfterIDs() []int64 {
+	removed := make(map[int64]bool, len(r.RemovedIDs))
+	for _, id := range r.RemovedIDs {
+		removed[id] = true
+	}
+
+	after := make([]int64, 0, len(r.BeforeIDs)+len(r.AddedIDs))
+	seen := make(map[int64]bool, len(r.BeforeIDs)+len(r.AddedIDs))
+	for _, ids := range [][]int64{r.BeforeIDs, r.AddedIDs} {
+		for _, id := range ids {
+			if removed[id] || seen[id] {
+				continue
+			}
+			seen[id] = true
+			after = append(after, id)
+		}
+	}
+	return after
+}
@@ -0,0 +1,21 @@
+package entity
+
+// 访问判定依据
+const (
+	DecisionReasonAdmin        = "admin"         // 管理员角色拥有全部权限
+	DecisionReasonPublic       = "public"        // API 不需要授权，登录即可访问
+	DecisionReasonRBAC         = "rbac"          // 角色、继承角色、权限组或用户特殊权限授予
+	DecisionReasonRuleAllow    = "rule_allow"    // ABAC 允许规则命中
+	DecisionReasonRuleDeny     = "rule_deny"     // ABAC 拒绝规则命中
+	DecisionReasonNoPermission = "no_permission" // 没有任何授权来源
+)
+
+// PermissionDecision 访问判定结果
+type PermissionDecision struct {
+	Allowed    bool            // 是否允许访问
+	Reason     string          // 判定依据
+	Route      string          // 匹配到的路由模板，API 表未登记时为请求路径
+	Rule       *PermissionRule // 命中的 ABAC 规则，依据为规则时有值
+	Buttons    []string        // 当前角色拥有的按钮权限编码
+	Registered bool            // 路由是否已在 API 表登记
+}
@@ -2,6 +2,12 @@ package entity
 
 import "time"
 
+// 权限规则效果
+const (
+	PermissionEffectAllow = "allow" // 允许
+	PermissionEffectDeny  = "deny"  // 拒绝，优先于允许
+)
+
 // PermissionAttribute 权限属性定义
 type PermissionAttribute struct {
 	Key   string // 属性键
@@ -18,7 +24,7 @@ type PermissionRule struct {
 	Effect      string                // 效果：allow, deny
 	APIPath     string                // API 路径
 	Method      string                // HTTP 方法
-	Conditions  string                // 条件表达式，JSON 格式
+	Conditions  string                // 条件表达式，JSON 格式，语法见 abac.Condition
 	Attributes  []PermissionAttribute // 属性列表
 	Status      int                   // 状态：1-启用，0-禁用
 	Sort        int                   // 排序
@@ -40,12 +46,12 @@ func (p *PermissionRule) IsActive() bool {
 
 // IsAllow 检查规则是否允许
 func (p *PermissionRule) IsAllow() bool {
-	return p.Effect == "allow"
+	return p.Effect == PermissionEffectAllow
 }
 
 // IsDeny 检查规则是否拒绝
 func (p *PermissionRule) IsDeny() bool {
-	return p.Effect == "deny"
+	return p.Effect == PermissionEffectDeny
 }
 
 // IsSystemRule 检查是否是系统规则
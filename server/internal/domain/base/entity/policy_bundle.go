@@ -0,0 +1,145 @@
+package entity
+
+import "time"
+
+// PolicyBundleVersion 当前策略包格式版本，格式不兼容变更时递增
+const PolicyBundleVersion = 1
+
+// 策略包对象类型
+const (
+	PolicyKindAPI             = "api"
+	PolicyKindMenu            = "menu"
+	PolicyKindBtnPerm         = "btn_perm"
+	PolicyKindRole            = "role"
+	PolicyKindPermissionGroup = "permission_group"
+	PolicyKindRule            = "rule"
+)
+
+// 策略包变更动作
+const (
+	PolicyActionCreate = "create" // 目标环境不存在，将新建
+	PolicyActionUpdate = "update" // 目标环境已存在，字段或关联有差异
+)
+
+// PolicyBundle 权限策略包
+// 以编码而非雪花 ID 描述角色、菜单、API、按钮权限、权限组和 ABAC 规则，便于纳入 git 评审并在环境间迁移
+// 标签即策略文件的 JSON/YAML 格式，对象之间通过以下键引用：
+// API 为 "METHOD 路径"，菜单为名称，按钮权限和角色为编码，权限组和规则为名称
+type PolicyBundle struct {
+	Version          int                     `json:"version" yaml:"version"`
+	ExportedAt       time.Time               `json:"exportedAt" yaml:"exportedAt"`
+	APIs             []PolicyAPI             `json:"apis" yaml:"apis"`
+	Menus            []PolicyMenu            `json:"menus" yaml:"menus"`
+	BtnPerms         []PolicyBtnPerm         `json:"btnPerms" yaml:"btnPerms"`
+	Roles            []PolicyRole            `json:"roles" yaml:"roles"`
+	PermissionGroups []PolicyPermissionGroup `json:"permissionGroups" yaml:"permissionGroups"`
+	Rules            []PolicyRule            `json:"rules" yaml:"rules"`
+}
+
+// PolicyAPI 策略包中的 API
+type PolicyAPI struct {
+	Method       string `json:"method" yaml:"method"`
+	Path         string `json:"path" yaml:"path"`
+	Group        string `json:"group" yaml:"group"`
+	AuthRequired bool   `json:"authRequired" yaml:"authRequired"`
+	AuthType     int    `json:"authType" yaml:"authType"`
+	Description  string `json:"description" yaml:"description"`
+	Status       int    `json:"status" yaml:"status"`
+}
+
+// Key 返回 API 在策略包中的引用键
+func (a *PolicyAPI) Key() string {
+	return PolicyAPIKey(a.Method, a.Path)
+}
+
+// PolicyAPIKey 由方法和路径组成 API 引用键
+func PolicyAPIKey(method, path string) string {
+	return method + " " + path
+}
+
+// PolicyMenu 策略包中的菜单，Parent 为父菜单名称，APIs 为关联的 API 引用键
+type PolicyMenu struct {
+	Name       string   `json:"name" yaml:"name"`
+	Parent     string   `json:"parent,omitempty" yaml:"parent,omitempty"`
+	Path       string   `json:"path" yaml:"path"`
+	Component  string   `json:"component" yaml:"component"`
+	Title      string   `json:"title" yaml:"title"`
+	Icon       string   `json:"icon" yaml:"icon"`
+	Hidden     bool     `json:"hidden" yaml:"hidden"`
+	Sort       int      `json:"sort" yaml:"sort"`
+	Status     int      `json:"status" yaml:"status"`
+	IsExt      bool     `json:"isExt" yaml:"isExt"`
+	Redirect   string   `json:"redirect" yaml:"redirect"`
+	Permission string   `json:"permission" yaml:"permission"`
+	Type       int      `json:"type" yaml:"type"`
+	FrameSrc   string   `json:"frameSrc" yaml:"frameSrc"`
+	APIs       []string `json:"apis" yaml:"apis"`
+}
+
+// PolicyBtnPerm 策略包中的按钮权限，Menu 为所属菜单名称
+type PolicyBtnPerm struct {
+	Code        string   `json:"code" yaml:"code"`
+	Name        string   `json:"name" yaml:"name"`
+	Menu        string   `json:"menu,omitempty" yaml:"menu,omitempty"`
+	Description string   `json:"description" yaml:"description"`
+	Status      int      `json:"status" yaml:"status"`
+	APIs        []string `json:"apis" yaml:"apis"`
+}
+
+// PolicyRole 策略包中的角色，Parent 为父角色编码，PermissionGroups 为绑定的权限组名称
+// 自定义数据权限的部门依赖各环境的部门 ID，不纳入策略包，导入时只能保留目标环境中已有的自定义数据权限
+type PolicyRole struct {
+	Code             string   `json:"code" yaml:"code"`
+	Name             string   `json:"name" yaml:"name"`
+	Parent           string   `json:"parent,omitempty" yaml:"parent,omitempty"`
+	Description      string   `json:"description" yaml:"description"`
+	Type             int      `json:"type" yaml:"type"`
+	Status           int      `json:"status" yaml:"status"`
+	IsSystem         bool     `json:"isSystem" yaml:"isSystem"`
+	Sort             int      `json:"sort" yaml:"sort"`
+	DataScope        int      `json:"dataScope" yaml:"dataScope"`
+	ApprovalRequired bool     `json:"approvalRequired" yaml:"approvalRequired"`
+	Menus            []string `json:"menus" yaml:"menus"`
+	APIs             []string `json:"apis" yaml:"apis"`
+	BtnPerms         []string `json:"btnPerms" yaml:"btnPerms"`
+	PermissionGroups []string `json:"permissionGroups" yaml:"permissionGroups"`
+}
+
+// PolicyPermissionGroup 策略包中的权限组，APIs 和 BtnPerms 为组内的 API 引用键和按钮权限编码
+type PolicyPermissionGroup struct {
+	Name        string   `json:"name" yaml:"name"`
+	Description string   `json:"description" yaml:"description"`
+	Status      int      `json:"status" yaml:"status"`
+	Sort        int      `json:"sort" yaml:"sort"`
+	APIs        []string `json:"apis" yaml:"apis"`
+	BtnPerms    []string `json:"btnPerms" yaml:"btnPerms"`
+}
+
+// PolicyRule 策略包中的 ABAC 规则
+type PolicyRule struct {
+	Name        string `json:"name" yaml:"name"`
+	Description string `json:"description" yaml:"description"`
+	Effect      string `json:"effect" yaml:"effect"`
+	Method      string `json:"method" yaml:"method"`
+	APIPath     string `json:"apiPath" yaml:"apiPath"`
+	Conditions  string `json:"conditions" yaml:"conditions"`
+	Status      int    `json:"status" yaml:"status"`
+	Sort        int    `json:"sort" yaml:"sort"`
+	IsSystem    bool   `json:"isSystem" yaml:"isSystem"`
+}
+
+// PolicyChange 导入策略包时单个对象的差异
+type PolicyChange struct {
+	Kind   string   // 对象类型，见 PolicyKindAPI 等常量
+	Key    string   // 对象引用键
+	Action string   // 变更动作，见 PolicyActionCreate 等常量
+	Fields []string // 有差异的字段，新建时为空
+}
+
+// PolicyImportResult 策略包导入结果
+type PolicyImportResult struct {
+	DryRun        bool           // 是否仅比较差异
+	Applied       bool           // 是否已写入
+	Changes       []PolicyChange // 差异列表，策略包中与目标环境一致的对象不列出
+	ApprovalRoles []string       // 受差异影响且开启了变更审批的角色编码，不为空时导入需要审批
+}
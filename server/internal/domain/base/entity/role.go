@@ -15,6 +15,9 @@ type Role struct {
 	Status             int                // 状态：1-启用，0-禁用
 	IsSystem           bool               // 是否系统角色
 	Sort               int                // 排序
+	DataScope          int                // 数据权限范围，见 DataScopeAll 等常量
+	DataScopeDeptIds   []int64            // 自定义数据权限的部门 ID 列表，DataScope 为 DataScopeCustom 时有效
+	ApprovalRequired   bool               // 权限变更是否需要审批，开启后变更先生成待审批申请
 	UserIds            []int64            // 角色关联的用户 ID 列表
 	Users              []*User            // 角色关联的用户对象列表
 	MenuIds            []int64            // 角色关联的菜单 ID 列表
@@ -0,0 +1,47 @@
+package entity
+
+import "time"
+
+// 限时角色授予状态
+const (
+	RoleGrantStatusPending   = "pending"   // 提权申请待审批
+	RoleGrantStatusScheduled = "scheduled" // 已授予，尚未到生效时间
+	RoleGrantStatusActive    = "active"    // 生效中，用户已加入角色
+	RoleGrantStatusRejected  = "rejected"  // 提权申请被拒绝
+	RoleGrantStatusExpired   = "expired"   // 到期自动收回
+	RoleGrantStatusRevoked   = "revoked"   // 到期前被手动收回
+)
+
+// RoleGrant 限时角色授予领域实体
+// 管理员直接授予或用户申请提权并经审批后，在 ValidFrom 到 ValidUntil 期间将用户加入角色，到期由定时任务收回
+// 纯业务模型，无 GORM 标签
+type RoleGrant struct {
+	ID             int64      // 授予 ID
+	UserID         int64      // 被授予用户 ID
+	RoleID         int64      // 授予的角色 ID
+	ValidFrom      time.Time  // 生效时间，提权申请审批通过时设置
+	ValidUntil     time.Time  // 到期时间，提权申请审批通过时设置
+	Justification  string     // 授予或申请理由
+	Status         string     // 状态，见 RoleGrantStatusPending 等常量
+	Elevation      bool       // 是否由用户申请提权产生
+	RequestedHours int        // 提权申请的时长（小时）
+	ApprovedBy     int64      // 审批人 ID，直接授予时为授予人
+	ApprovedAt     *time.Time // 审批时间
+	ReviewComment  string     // 审批意见或收回原因
+	RevokedBy      int64      // 收回人 ID，到期自动收回时为 0
+	RevokedAt      *time.Time // 收回时间
+	CreatedBy      int64      // 创建人 ID，提权申请时为申请人
+	CreatedAt      time.Time  // 创建时间
+	UpdatedBy      int64      // 更新人 ID
+	UpdatedAt      time.Time  // 更新时间
+}
+
+// IsPending 检查是否为待审批的提权申请
+func (g *RoleGrant) IsPending() bool {
+	return g.Status == RoleGrantStatusPending
+}
+
+// IsOpen 检查授予是否尚未结束（待生效或生效中）
+func (g *RoleGrant) IsOpen() bool {
+	return g.Status == RoleGrantStatusScheduled || g.Status == RoleGrantStatusActive
+}
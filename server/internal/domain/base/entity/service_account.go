@@ -0,0 +1,71 @@
+package entity
+
+import "time"
+
+// 服务账号状态
+const (
+	ServiceAccountStatusDisabled = 0 // 禁用
+	ServiceAccountStatusEnabled  = 1 // 启用
+)
+
+// ServiceAccount 服务账号领域实体
+// 供批处理任务、后端服务等机器调用 /api/admin 接口使用
+// 通过 API Key 认证，绑定一个或多个角色，可配置 IP 白名单
+// 纯业务模型，无 GORM 标签
+type ServiceAccount struct {
+	ID          int64                // 服务账号 ID
+	Name        string               // 名称，唯一
+	Description string               // 描述
+	Status      int                  // 状态：1-启用，0-禁用
+	IPAllowlist []string             // IP 白名单（IP 或 CIDR），为空表示不限制
+	LastUsedAt  *time.Time           // 最近使用时间
+	LastUsedIP  string               // 最近使用 IP
+	RoleIds     []int64              // 绑定的角色 ID 列表
+	Roles       []*Role              // 绑定的角色列表
+	Keys        []*ServiceAccountKey // API Key 列表（不含明文）
+	CreatedBy   int64                // 创建人 ID
+	CreatedAt   time.Time            // 创建时间
+	UpdatedBy   int64                // 更新人 ID
+	UpdatedAt   time.Time            // 更新时间
+}
+
+// IsActive 检查服务账号是否启用
+func (a *ServiceAccount) IsActive() bool {
+	return a.Status == ServiceAccountStatusEnabled
+}
+
+// HasRole 检查服务账号是否绑定指定角色
+func (a *ServiceAccount) HasRole(roleID int64) bool {
+	for _, rid := range a.RoleIds {
+		if rid == roleID {
+			return true
+		}
+	}
+	return false
+}
+
+// ServiceAccountKey 服务账号 API Key 领域实体
+// 只保存 Key 的前缀（用于查找）和哈希值，明文仅在创建/轮换时返回一次
+type ServiceAccountKey struct {
+	ID               int64      // Key ID
+	ServiceAccountID int64      // 所属服务账号 ID
+	Prefix           string     // Key 前缀，唯一，用于定位记录和展示
+	KeyHash          string     // Key 密文部分的哈希值
+	ExpiresAt        *time.Time // 过期时间，为空表示永不过期
+	RevokedAt        *time.Time // 吊销时间，为空表示未吊销
+	LastUsedAt       *time.Time // 最近使用时间
+	LastUsedIP       string     // 最近使用 IP
+	CreatedBy        int64      // 创建人 ID
+	CreatedAt        time.Time  // 创建时间
+}
+
+// IsValid 检查 Key 在指定时间是否有效（未吊销且未过期）
+func (k *ServiceAccountKey) IsValid(now time.Time) bool {
+	if k.RevokedAt != nil {
+		return false
+	}
+	if k.ExpiresAt != nil && !now.Before(*k.ExpiresAt) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,26 @@
+package entity
+
+import "time"
+
+// 会话终止方式
+const (
+	SessionAuditActionKickout    = "kickout"     // 管理员强制下线
+	SessionAuditActionRevoke     = "revoke"      // 用户下线自己的登录设备
+	SessionAuditActionKickOldest = "kick_oldest" // 超过同时在线会话数上限，踢出最早登录的会话
+)
+
+// SessionAuditLog 会话终止审计记录
+// 记录登录会话被强制结束的操作人、原因和时间，正常退出登录不记录
+// 纯业务模型，无 GORM 标签
+type SessionAuditLog struct {
+	ID         int64     // 记录 ID
+	UserID     int64     // 会话所属用户 ID
+	Username   string    // 会话所属用户名
+	SessionID  string    // 会话 ID
+	LoginIP    string    // 会话登录 IP
+	Device     string    // 会话登录设备
+	Action     string    // 终止方式：kickout、revoke、kick_oldest
+	Reason     string    // 原因
+	OperatorID int64     // 操作人 ID，系统自动处理时为 0
+	CreatedAt  time.Time // 操作时间
+}
@@ -0,0 +1,60 @@
+package entity
+
+import "time"
+
+// 职责分离约束类型
+const (
+	SoDTypeStatic  = "static"  // 静态约束：用户不能同时被分配超过上限的角色
+	SoDTypeDynamic = "dynamic" // 动态约束：用户可以同时拥有，但同一会话中不能激活超过上限的角色
+)
+
+// SoDConstraint 职责分离约束领域实体
+// 限制用户从一组角色中最多持有（静态）或在同一会话中最多激活（动态）MaxRoles 个，MaxRoles 为 1 即角色互斥
+// 只按直接分配的角色计算，不展开角色继承
+// 纯业务模型，无 GORM 标签
+type SoDConstraint struct {
+	ID          int64     // 约束 ID
+	Name        string    // 约束名称
+	Description string    // 约束描述
+	Type        string    // 约束类型，见 SoDTypeStatic 等常量
+	RoleIDs     []int64   // 约束涉及的角色 ID 列表
+	MaxRoles    int       // 最多可持有或激活的角色数量
+	Status      int       // 状态：1-启用，0-禁用
+	CreatedBy   int64     // 创建人 ID
+	CreatedAt   time.Time // 创建时间
+	UpdatedBy   int64     // 更新人 ID
+	UpdatedAt   time.Time // 更新时间
+}
+
+// IsActive 检查约束是否启用
+func (c *SoDConstraint) IsActive() bool {
+	return c.Status == 1
+}
+
+// Conflicts 返回 roleIDs 中属于约束角色集合的角色，数量超过上限时为冲突，否则返回 nil
+func (c *SoDConstraint) Conflicts(roleIDs []int64) []int64 {
+	inSet := make(map[int64]bool, len(c.RoleIDs))
+	for _, id := range c.RoleIDs {
+		inSet[id] = true
+	}
+	var matched []int64
+	seen := make(map[int64]bool, len(roleIDs))
+	for _, id := range roleIDs {
+		if inSet[id] && !seen[id] {
+			seen[id] = true
+			matched = append(matched, id)
+		}
+	}
+	if len(matched) <= c.MaxRoles {
+		return nil
+	}
+	return matched
+}
+
+// SoDViolation 现有角色分配违反静态职责分离约束的记录
+type SoDViolation struct {
+	Constraint *SoDConstraint // 违反的约束
+	UserID     int64          // 用户 ID
+	Username   string         // 用户名
+	RoleIDs    []int64        // 用户持有的约束内角色 ID
+}
@@ -24,6 +24,8 @@ type User struct {
 	LastLoginIP             string      // 最后登录 IP
 	LastLoginTime           string      // 最后登录时间
 	WechatOpenID            string      // 微信 OpenID，唯一
+	PasswordChangedAt       time.Time   // 最近一次修改密码时间，零值表示未知
+	MustChangePassword      bool        // 是否必须修改密码（管理员重置后首次登录）
 	CreatedBy               int64       // 创建人 ID
 	CreatedAt               time.Time   // 创建时间
 	UpdatedBy               int64       // 更新人 ID
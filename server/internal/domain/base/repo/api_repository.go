@@ -13,4 +13,5 @@ type APIRepository interface {
 	Delete(id int64) error
 	List(page, pageSize int, filters map[string]interface{}) ([]*entity.API, int64, error)
 	GetAPIsByRole(roleID int64) ([]*entity.API, error)
+	GetUnassigned() ([]*entity.API, error) // 获取需要授权但尚未分配给任何角色的有效路由
 }
@@ -0,0 +1,15 @@
+package repo
+
+import "github.com/ix-pay/ixpay-pro/internal/domain/base/entity"
+
+// IPPolicyRepository IP 访问策略仓库接口
+type IPPolicyRepository interface {
+	GetByID(id int64) (*entity.IPPolicy, error)
+	GetByName(name string) (*entity.IPPolicy, error)
+	Create(policy *entity.IPPolicy) error
+	Update(policy *entity.IPPolicy) error
+	Delete(id int64) error
+	List(page, pageSize int, filters map[string]interface{}) ([]*entity.IPPolicy, int64, error)
+	// ListEnabled 获取所有启用的策略，用于访问控制
+	ListEnabled() ([]*entity.IPPolicy, error)
+}
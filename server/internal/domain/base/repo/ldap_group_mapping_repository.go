@@ -0,0 +1,14 @@
+package repo
+
+import "github.com/ix-pay/ixpay-pro/internal/domain/base/entity"
+
+// LDAPGroupMappingRepository LDAP 组映射仓库接口
+type LDAPGroupMappingRepository interface {
+	GetByID(id int64) (*entity.LDAPGroupMapping, error)
+	Create(mapping *entity.LDAPGroupMapping) error
+	Update(mapping *entity.LDAPGroupMapping) error
+	Delete(id int64) error
+	List(page, pageSize int, filters map[string]interface{}) ([]*entity.LDAPGroupMapping, int64, error)
+	// GetAll 获取全部映射，按优先级从高到低排序
+	GetAll() ([]*entity.LDAPGroupMapping, error)
+}
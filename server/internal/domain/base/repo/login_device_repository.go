@@ -0,0 +1,13 @@
+package repo
+
+import "github.com/ix-pay/ixpay-pro/internal/domain/base/entity"
+
+// LoginDeviceRepository 用户常用登录设备仓库接口
+type LoginDeviceRepository interface {
+	// GetByFingerprint 查询用户的指定设备，不存在时返回 gorm.ErrRecordNotFound
+	GetByFingerprint(userID int64, fingerprint string) (*entity.LoginDevice, error)
+	CountByUser(userID int64) (int64, error)
+	// Save 保存设备，ID 为 0 时新建
+	Save(device *entity.LoginDevice) error
+	ListByUser(userID int64) ([]*entity.LoginDevice, error)
+}
@@ -0,0 +1,14 @@
+package repo
+
+import "github.com/ix-pay/ixpay-pro/internal/domain/base/entity"
+
+// LoginLocationRepository 用户常用登录地点仓库接口
+type LoginLocationRepository interface {
+	// Get 查询用户的指定地点，不存在时返回 gorm.ErrRecordNotFound
+	Get(userID int64, country, city string) (*entity.LoginLocation, error)
+	HasCountry(userID int64, country string) (bool, error)
+	// GetLatest 查询用户最近一次登录的地点，不存在时返回 gorm.ErrRecordNotFound
+	GetLatest(userID int64) (*entity.LoginLocation, error)
+	// Save 保存地点，ID 为 0 时新建
+	Save(location *entity.LoginLocation) error
+}
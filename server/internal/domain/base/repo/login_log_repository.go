@@ -22,4 +22,7 @@ type LoginLogRepository interface {
 	// 批量操作
 	BatchDelete(ids []int64) error
 	ClearByTimeRange(startTime, endTime time.Time) error
+	// 登录地点回填
+	ListByPlaces(places []string, afterID int64, limit int) ([]*entity.LoginLog, error)
+	UpdatePlace(id int64, oldPlace, place string) (bool, error)
 }
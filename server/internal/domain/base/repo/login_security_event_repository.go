@@ -0,0 +1,11 @@
+package repo
+
+import "github.com/ix-pay/ixpay-pro/internal/domain/base/entity"
+
+// LoginSecurityEventRepository 登录安全事件仓库接口
+type LoginSecurityEventRepository interface {
+	// CreateBatch 在同一事务中写入多条事件，任一失败则全部回滚
+	CreateBatch(events []*entity.LoginSecurityEvent) error
+	UpdateStepUpStatus(ids []int64, status string) error
+	List(page, pageSize int, filters map[string]interface{}) ([]*entity.LoginSecurityEvent, int64, error)
+}
@@ -0,0 +1,23 @@
+package repo
+
+import (
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/entity"
+)
+
+// OIDCClientRepository OIDC 客户端仓库接口
+type OIDCClientRepository interface {
+	GetByID(id int64) (*entity.OIDCClient, error)
+	GetByClientID(clientID string) (*entity.OIDCClient, error)
+	Create(client *entity.OIDCClient) error
+	Update(client *entity.OIDCClient) error
+	// UpdateSecret 更新客户端密钥哈希
+	UpdateSecret(id int64, secretHash string, operatorID int64) error
+	// Delete 删除客户端及用户授权记录
+	Delete(id int64) error
+	List(page, pageSize int, filters map[string]interface{}) ([]*entity.OIDCClient, int64, error)
+
+	// 用户授权记录
+	GetConsent(userID, clientID int64) (*entity.OIDCConsent, error)
+	// SaveConsent 保存授权记录，已存在时更新授权范围
+	SaveConsent(consent *entity.OIDCConsent) error
+}
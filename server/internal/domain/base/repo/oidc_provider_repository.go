@@ -0,0 +1,25 @@
+package repo
+
+import (
+	"time"
+
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/entity"
+)
+
+// OIDCProviderRepository OIDC 身份提供方仓库接口
+type OIDCProviderRepository interface {
+	GetByID(id int64) (*entity.OIDCProvider, error)
+	GetByCode(code string) (*entity.OIDCProvider, error)
+	Create(provider *entity.OIDCProvider) error
+	Update(provider *entity.OIDCProvider) error
+	// Delete 删除身份提供方及其用户绑定
+	Delete(id int64) error
+	List(page, pageSize int, filters map[string]interface{}) ([]*entity.OIDCProvider, int64, error)
+	// GetEnabled 获取启用的身份提供方，按排序返回
+	GetEnabled() ([]*entity.OIDCProvider, error)
+
+	// 用户绑定
+	GetIdentity(providerID int64, subject string) (*entity.UserOIDCIdentity, error)
+	CreateIdentity(identity *entity.UserOIDCIdentity) error
+	TouchIdentity(id int64, at time.Time) error
+}
@@ -3,11 +3,12 @@ package repo
 import "github.com/ix-pay/ixpay-pro/internal/domain/base/entity"
 
 // OnlineUserRepository 在线用户仓库接口（基于 Redis）
+// 记录按会话 ID 存储，同一用户可以有多条会话记录
 type OnlineUserRepository interface {
 	Add(user *entity.OnlineUser) error
-	GetByUserID(userID int64) (*entity.OnlineUser, error)
+	GetByUserID(userID int64) ([]*entity.OnlineUser, error)
 	GetBySessionID(sessionID string) (*entity.OnlineUser, error)
-	UpdateActiveTime(userID int64) error
+	UpdateActiveTime(sessionID string) error
 	Remove(userID int64) error
 	RemoveBySessionID(sessionID string) error
 	GetAll() ([]*entity.OnlineUser, error)
@@ -0,0 +1,12 @@
+package repo
+
+import "github.com/ix-pay/ixpay-pro/internal/domain/base/entity"
+
+// PasswordHistoryRepository 密码历史仓库接口
+type PasswordHistoryRepository interface {
+	Create(history *entity.PasswordHistory) error
+	// GetRecentByUserID 按时间倒序获取用户最近 limit 条密码历史
+	GetRecentByUserID(userID int64, limit int) ([]*entity.PasswordHistory, error)
+	// PruneByUserID 仅保留用户最近 keep 条密码历史，删除更早的记录
+	PruneByUserID(userID int64, keep int) error
+}
@@ -0,0 +1,8 @@
+package repo
+
+import "github.com/ix-pay/ixpay-pro/internal/domain/base/entity"
+
+// PermissionAuditLogRepository 权限审计日志仓库接口
+type PermissionAuditLogRepository interface {
+	Create(log *entity.PermissionAuditLog) error
+}
@@ -0,0 +1,13 @@
+package repo
+
+import "github.com/ix-pay/ixpay-pro/internal/domain/base/entity"
+
+// PermissionChangeRequestRepository 权限变更申请仓库接口
+type PermissionChangeRequestRepository interface {
+	GetByID(id int64) (*entity.PermissionChangeRequest, error)
+	Create(request *entity.PermissionChangeRequest) error
+	Update(request *entity.PermissionChangeRequest) error
+	// Apply 在单个事务中执行审批通过的变更并更新申请的状态和审批信息
+	Apply(request *entity.PermissionChangeRequest) error
+	List(page, pageSize int, filters map[string]interface{}) ([]*entity.PermissionChangeRequest, int64, error)
+}
@@ -24,6 +24,6 @@ type PermissionRuleRepository interface {
 	GetUsersByRule(ruleID int64) ([]*entity.User, error)
 	GetRulesByUser(userID int64) ([]*entity.PermissionRule, error)
 
-	// 规则匹配
-	FindMatchingRules(apiPath, method string, attributes []entity.PermissionAttribute) ([]*entity.PermissionRule, error)
+	// 规则匹配：返回路径和方法匹配的启用规则，条件由调用方求值
+	FindMatchingRules(apiPath, method string) ([]*entity.PermissionRule, error)
 }
@@ -0,0 +1,13 @@
+package repo
+
+import "github.com/ix-pay/ixpay-pro/internal/domain/base/entity"
+
+// PolicyBundleRepository 权限策略包仓库接口
+type PolicyBundleRepository interface {
+	// Export 导出当前环境的权限配置，对象和关联均以编码引用
+	Export() (*entity.PolicyBundle, error)
+	// Apply 在单个事务中按编码新建或更新策略包中的对象，并以策略包为准替换其关联；策略包外的对象保持不变
+	Apply(bundle *entity.PolicyBundle, operatorID int64) error
+	// ApplyApproved 在同一事务中写入审批通过的策略包并更新导入申请的状态和审批信息
+	ApplyApproved(bundle *entity.PolicyBundle, request *entity.PermissionChangeRequest) error
+}
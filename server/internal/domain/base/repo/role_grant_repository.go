@@ -0,0 +1,21 @@
+package repo
+
+import (
+	"time"
+
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/entity"
+)
+
+// RoleGrantRepository 限时角色授予仓库接口
+type RoleGrantRepository interface {
+	GetByID(id int64) (*entity.RoleGrant, error)
+	Create(grant *entity.RoleGrant) error
+	Update(grant *entity.RoleGrant) error
+	List(page, pageSize int, filters map[string]interface{}) ([]*entity.RoleGrant, int64, error)
+	// ListByUserRole 获取用户在指定角色上处于指定状态的授予
+	ListByUserRole(userID, roleID int64, statuses ...string) ([]*entity.RoleGrant, error)
+	// ListDueForActivation 获取生效时间已到、尚未生效的授予
+	ListDueForActivation(now time.Time) ([]*entity.RoleGrant, error)
+	// ListDueForExpiry 获取到期时间已到、仍在生效中的授予
+	ListDueForExpiry(now time.Time) ([]*entity.RoleGrant, error)
+}
@@ -0,0 +1,32 @@
+package repo
+
+import (
+	"time"
+
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/entity"
+)
+
+// ServiceAccountRepository 服务账号仓库接口
+type ServiceAccountRepository interface {
+	GetByID(id int64) (*entity.ServiceAccount, error)
+	GetByName(name string) (*entity.ServiceAccount, error)
+	Create(account *entity.ServiceAccount) error
+	Update(account *entity.ServiceAccount) error
+	Delete(id int64) error
+	List(page, pageSize int, filters map[string]interface{}) ([]*entity.ServiceAccount, int64, error)
+	// SetRoles 覆盖设置服务账号绑定的角色
+	SetRoles(accountID int64, roleIDs []int64) error
+	GetRoles(accountID int64) ([]*entity.Role, error)
+	// TouchAccount 更新服务账号最近使用时间和 IP
+	TouchAccount(accountID int64, ip string, at time.Time) error
+
+	CreateKey(key *entity.ServiceAccountKey) error
+	GetKeyByPrefix(prefix string) (*entity.ServiceAccountKey, error)
+	ListKeys(accountID int64) ([]*entity.ServiceAccountKey, error)
+	// RevokeKey 吊销指定 Key
+	RevokeKey(accountID, keyID int64, at time.Time) error
+	// ExpireActiveKeys 将服务账号下除 exceptKeyID 外仍有效的 Key 的过期时间提前到 expiresAt
+	ExpireActiveKeys(accountID, exceptKeyID int64, expiresAt time.Time) error
+	// TouchKey 更新 Key 最近使用时间和 IP
+	TouchKey(keyID int64, ip string, at time.Time) error
+}
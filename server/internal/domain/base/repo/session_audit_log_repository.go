@@ -0,0 +1,10 @@
+package repo
+
+import "github.com/ix-pay/ixpay-pro/internal/domain/base/entity"
+
+// SessionAuditLogRepository 会话终止审计记录仓库接口
+type SessionAuditLogRepository interface {
+	// CreateBatch 在同一事务中写入多条记录，任一失败则全部回滚
+	CreateBatch(logs []*entity.SessionAuditLog) error
+	List(page, pageSize int, filters map[string]interface{}) ([]*entity.SessionAuditLog, int64, error)
+}
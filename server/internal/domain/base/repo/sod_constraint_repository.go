@@ -0,0 +1,15 @@
+package repo
+
+import "github.com/ix-pay/ixpay-pro/internal/domain/base/entity"
+
+// SoDConstraintRepository 职责分离约束仓库接口
+type SoDConstraintRepository interface {
+	GetByID(id int64) (*entity.SoDConstraint, error)
+	GetByName(name string) (*entity.SoDConstraint, error)
+	// GetActiveByType 获取指定类型的启用约束
+	GetActiveByType(constraintType string) ([]*entity.SoDConstraint, error)
+	Create(constraint *entity.SoDConstraint) error
+	Update(constraint *entity.SoDConstraint) error
+	Delete(id int64) error
+	List(page, pageSize int, filters map[string]interface{}) ([]*entity.SoDConstraint, int64, error)
+}
@@ -95,6 +95,15 @@ func (as *APISeed) getAPIRoutes() []*entity.API {
 			Description:  "用户登录",
 			Status:       1,
 		},
+		{
+			Path:         "/api/admin/auth/login/verify",
+			Method:       "POST",
+			Group:        "认证管理",
+			AuthRequired: false,
+			AuthType:     0,
+			Description:  "登录二次验证",
+			Status:       1,
+		},
 		{
 			Path:         "/api/admin/auth/captcha",
 			Method:       "POST",
@@ -151,6 +160,42 @@ func (as *APISeed) getAPIRoutes() []*entity.API {
 			Description:  "修改当前用户密码",
 			Status:       1,
 		},
+		{
+			Path:         "/api/admin/user/sessions",
+			Method:       "GET",
+			Group:        "用户管理",
+			AuthRequired: true,
+			AuthType:     0,
+			Description:  "获取当前用户的登录设备",
+			Status:       1,
+		},
+		{
+			Path:         "/api/admin/user/sessions/revoke",
+			Method:       "POST",
+			Group:        "用户管理",
+			AuthRequired: true,
+			AuthType:     0,
+			Description:  "下线当前用户的登录设备",
+			Status:       1,
+		},
+		{
+			Path:         "/api/admin/user/session-events",
+			Method:       "GET",
+			Group:        "用户管理",
+			AuthRequired: true,
+			AuthType:     0,
+			Description:  "订阅当前会话的实时事件",
+			Status:       1,
+		},
+		{
+			Path:         "/api/admin/user/security-events",
+			Method:       "GET",
+			Group:        "用户管理",
+			AuthRequired: true,
+			AuthType:     0,
+			Description:  "获取当前用户的登录安全事件",
+			Status:       1,
+		},
 		{
 			Path:         "/api/admin/user",
 			Method:       "GET",
@@ -333,6 +378,24 @@ func (as *APISeed) getAPIRoutes() []*entity.API {
 			Description:  "获取角色可授权的 API 列表",
 			Status:       1,
 		},
+		{
+			Path:         "/api/admin/role/:id/data-scope",
+			Method:       "PUT",
+			Group:        "角色管理",
+			AuthRequired: true,
+			AuthType:     1,
+			Description:  "设置角色数据权限",
+			Status:       1,
+		},
+		{
+			Path:         "/api/admin/role/:id/approval-required",
+			Method:       "PUT",
+			Group:        "角色管理",
+			AuthRequired: true,
+			AuthType:     1,
+			Description:  "设置角色权限变更是否需要审批",
+			Status:       1,
+		},
 		{
 			Path:         "/api/admin/roles/assign-users",
 			Method:       "POST",
@@ -454,6 +517,15 @@ func (as *APISeed) getAPIRoutes() []*entity.API {
 			Description:  "获取 API 详情",
 			Status:       1,
 		},
+		{
+			Path:         "/api/admin/apis/sync-report",
+			Method:       "GET",
+			Group:        "API 管理",
+			AuthRequired: true,
+			AuthType:     1,
+			Description:  "获取 API 路由同步报告",
+			Status:       1,
+		},
 		{
 			Path:         "/api/admin/apis",
 			Method:       "POST",
@@ -1082,6 +1154,15 @@ func (as *APISeed) getAPIRoutes() []*entity.API {
 			Description:  "获取异常登录查询",
 			Status:       1,
 		},
+		{
+			Path:         "/api/admin/login-log/security-events",
+			Method:       "GET",
+			Group:        "登录日志",
+			AuthRequired: true,
+			AuthType:     1,
+			Description:  "获取登录安全事件",
+			Status:       1,
+		},
 		{
 			Path:         "/api/admin/login-log",
 			Method:       "POST",
@@ -1147,6 +1228,15 @@ func (as *APISeed) getAPIRoutes() []*entity.API {
 			Description:  "批量强制用户下线",
 			Status:       1,
 		},
+		{
+			Path:         "/api/admin/online-user/audit-logs",
+			Method:       "GET",
+			Group:        "在线用户",
+			AuthRequired: true,
+			AuthType:     1,
+			Description:  "获取会话终止审计记录",
+			Status:       1,
+		},
 
 		// ==================== 系统监控 ====================
 		{
@@ -1306,6 +1396,259 @@ func (as *APISeed) getAPIRoutes() []*entity.API {
 			Description:  "获取角色权限日志",
 			Status:       1,
 		},
+
+		// ==================== 权限规则 ====================
+		{
+			Path:         "/api/admin/permission-rules",
+			Method:       "GET",
+			Group:        "权限规则",
+			AuthRequired: true,
+			AuthType:     1,
+			Description:  "获取权限规则列表",
+			Status:       1,
+		},
+		{
+			Path:         "/api/admin/permission-rules",
+			Method:       "POST",
+			Group:        "权限规则",
+			AuthRequired: true,
+			AuthType:     1,
+			Description:  "创建权限规则",
+			Status:       1,
+		},
+		{
+			Path:         "/api/admin/permission-rules/validate",
+			Method:       "POST",
+			Group:        "权限规则",
+			AuthRequired: true,
+			AuthType:     1,
+			Description:  "校验权限规则条件",
+			Status:       1,
+		},
+		{
+			Path:         "/api/admin/permission-rules/:id",
+			Method:       "GET",
+			Group:        "权限规则",
+			AuthRequired: true,
+			AuthType:     1,
+			Description:  "获取权限规则详情",
+			Status:       1,
+		},
+		{
+			Path:         "/api/admin/permission-rules/:id",
+			Method:       "PUT",
+			Group:        "权限规则",
+			AuthRequired: true,
+			AuthType:     1,
+			Description:  "更新权限规则",
+			Status:       1,
+		},
+		{
+			Path:         "/api/admin/permission-rules/:id",
+			Method:       "DELETE",
+			Group:        "权限规则",
+			AuthRequired: true,
+			AuthType:     1,
+			Description:  "删除权限规则",
+			Status:       1,
+		},
+
+		// ==================== 权限解释 ====================
+		{
+			Path:         "/api/admin/permissions/explain",
+			Method:       "POST",
+			Group:        "权限解释",
+			AuthRequired: true,
+			AuthType:     1,
+			Description:  "解释权限判定过程",
+			Status:       1,
+		},
+		{
+			Path:         "/api/admin/permissions/simulate",
+			Method:       "POST",
+			Group:        "权限解释",
+			AuthRequired: true,
+			AuthType:     1,
+			Description:  "模拟权限变更",
+			Status:       1,
+		},
+
+		// ==================== 限时角色授予 ====================
+		{
+			Path:         "/api/admin/role-grants",
+			Method:       "GET",
+			Group:        "限时角色授予",
+			AuthRequired: true,
+			AuthType:     1,
+			Description:  "获取限时授予列表",
+			Status:       1,
+		},
+		{
+			Path:         "/api/admin/role-grants",
+			Method:       "POST",
+			Group:        "限时角色授予",
+			AuthRequired: true,
+			AuthType:     1,
+			Description:  "授予限时角色",
+			Status:       1,
+		},
+		{
+			Path:         "/api/admin/role-grants/mine",
+			Method:       "GET",
+			Group:        "限时角色授予",
+			AuthRequired: true,
+			AuthType:     0,
+			Description:  "获取当前用户的限时授予",
+			Status:       1,
+		},
+		{
+			Path:         "/api/admin/role-grants/elevation",
+			Method:       "POST",
+			Group:        "限时角色授予",
+			AuthRequired: true,
+			AuthType:     0,
+			Description:  "申请临时提权",
+			Status:       1,
+		},
+		{
+			Path:         "/api/admin/role-grants/:id/approve",
+			Method:       "POST",
+			Group:        "限时角色授予",
+			AuthRequired: true,
+			AuthType:     1,
+			Description:  "批准提权申请",
+			Status:       1,
+		},
+		{
+			Path:         "/api/admin/role-grants/:id/reject",
+			Method:       "POST",
+			Group:        "限时角色授予",
+			AuthRequired: true,
+			AuthType:     1,
+			Description:  "拒绝提权申请",
+			Status:       1,
+		},
+		{
+			Path:         "/api/admin/role-grants/:id/revoke",
+			Method:       "POST",
+			Group:        "限时角色授予",
+			AuthRequired: true,
+			AuthType:     1,
+			Description:  "收回限时授予",
+			Status:       1,
+		},
+
+		// ==================== 权限变更审批 ====================
+		{
+			Path:         "/api/admin/permission-changes",
+			Method:       "GET",
+			Group:        "权限变更审批",
+			AuthRequired: true,
+			AuthType:     1,
+			Description:  "获取权限变更申请列表",
+			Status:       1,
+		},
+		{
+			Path:         "/api/admin/permission-changes/:id",
+			Method:       "GET",
+			Group:        "权限变更审批",
+			AuthRequired: true,
+			AuthType:     1,
+			Description:  "获取权限变更申请详情",
+			Status:       1,
+		},
+		{
+			Path:         "/api/admin/permission-changes/:id/approve",
+			Method:       "POST",
+			Group:        "权限变更审批",
+			AuthRequired: true,
+			AuthType:     1,
+			Description:  "通过权限变更申请",
+			Status:       1,
+		},
+		{
+			Path:         "/api/admin/permission-changes/:id/reject",
+			Method:       "POST",
+			Group:        "权限变更审批",
+			AuthRequired: true,
+			AuthType:     1,
+			Description:  "拒绝权限变更申请",
+			Status:       1,
+		},
+		// ==================== 权限策略包 ====================
+		{
+			Path:         "/api/admin/policy-bundle/export",
+			Method:       "GET",
+			Group:        "权限策略包",
+			AuthRequired: true,
+			AuthType:     1,
+			Description:  "导出权限策略包",
+			Status:       1,
+		},
+		{
+			Path:         "/api/admin/policy-bundle/import",
+			Method:       "POST",
+			Group:        "权限策略包",
+			AuthRequired: true,
+			AuthType:     1,
+			Description:  "导入权限策略包",
+			Status:       1,
+		},
+		// ==================== 职责分离约束 ====================
+		{
+			Path:         "/api/admin/sod-constraints",
+			Method:       "GET",
+			Group:        "职责分离约束",
+			AuthRequired: true,
+			AuthType:     1,
+			Description:  "获取职责分离约束列表",
+			Status:       1,
+		},
+		{
+			Path:         "/api/admin/sod-constraints",
+			Method:       "POST",
+			Group:        "职责分离约束",
+			AuthRequired: true,
+			AuthType:     1,
+			Description:  "创建职责分离约束",
+			Status:       1,
+		},
+		{
+			Path:         "/api/admin/sod-constraints/violations",
+			Method:       "GET",
+			Group:        "职责分离约束",
+			AuthRequired: true,
+			AuthType:     1,
+			Description:  "获取职责分离违规报告",
+			Status:       1,
+		},
+		{
+			Path:         "/api/admin/sod-constraints/:id",
+			Method:       "GET",
+			Group:        "职责分离约束",
+			AuthRequired: true,
+			AuthType:     1,
+			Description:  "获取职责分离约束详情",
+			Status:       1,
+		},
+		{
+			Path:         "/api/admin/sod-constraints/:id",
+			Method:       "PUT",
+			Group:        "职责分离约束",
+			AuthRequired: true,
+			AuthType:     1,
+			Description:  "更新职责分离约束",
+			Status:       1,
+		},
+		{
+			Path:         "/api/admin/sod-constraints/:id",
+			Method:       "DELETE",
+			Group:        "职责分离约束",
+			AuthRequired: true,
+			AuthType:     1,
+			Description:  "删除职责分离约束",
+			Status:       1,
+		},
 	}
 
 	// 批量替换所有双斜杠为单斜杠
@@ -2,6 +2,10 @@ package service
 
 import (
 	"errors"
+	"sort"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/ix-pay/ixpay-pro/internal/domain/base/entity"
 	"github.com/ix-pay/ixpay-pro/internal/domain/base/repo"
@@ -12,6 +16,9 @@ import (
 type APIService struct {
 	repo repo.APIRepository
 	log  logger.Logger
+
+	mu         sync.RWMutex
+	lastReport *entity.APISyncReport // 最近一次路由同步的结果
 }
 
 // NewAPIService 创建API路由服务实例
@@ -53,6 +60,146 @@ func (s *APIService) InitRoutes() error {
 	return nil
 }
 
+// SyncRoutes 将已注册的路由同步到 API 表
+// 新路由按需要授权登记，等待管理员分配给角色；API 表中已不存在的路由只标记为失效，不删除，保留原有的角色分配
+// 已登记路由的分组和描述由管理员维护，只在为空时用 routes 中的值补齐
+// prefix 为本次同步覆盖的路径前缀，只有该前缀下未注册的路由才标记为失效，其他应用登记的路由不受影响
+func (s *APIService) SyncRoutes(prefix string, routes []*entity.API) (*entity.APISyncReport, error) {
+	existing, err := s.repo.GetAllRoutes()
+	if err != nil {
+		s.log.Error("获取 API 路由失败", "error", err)
+		return nil, err
+	}
+
+	existingByKey := make(map[string]*entity.API, len(existing))
+	for _, api := range existing {
+		existingByKey[api.Method+" "+api.Path] = api
+	}
+
+	report := &entity.APISyncReport{SyncedAt: time.Now()}
+	registered := make(map[string]bool, len(routes))
+	for _, route := range routes {
+		key := route.Method + " " + route.Path
+		if registered[key] {
+			continue
+		}
+		registered[key] = true
+		report.Total++
+
+		api, ok := existingByKey[key]
+		if !ok {
+			api = &entity.API{
+				Path:         route.Path,
+				Method:       route.Method,
+				Group:        route.Group,
+				AuthRequired: true,
+				AuthType:     1,
+				Description:  route.Description,
+				Status:       1,
+			}
+			if err := s.repo.Create(api); err != nil {
+				s.log.Error("登记 API 路由失败", "error", err, "path", route.Path, "method", route.Method)
+				return nil, err
+			}
+			report.Added = append(report.Added, api)
+			continue
+		}
+
+		changed := false
+		if api.Orphaned {
+			api.Orphaned = false
+			changed = true
+			report.Restored = append(report.Restored, api)
+		}
+		if api.Group == "" && route.Group != "" {
+			api.Group = route.Group
+			changed = true
+		}
+		if api.Description == "" && route.Description != "" {
+			api.Description = route.Description
+			changed = true
+		}
+		if changed {
+			if err := s.repo.Update(api); err != nil {
+				s.log.Error("更新 API 路由失败", "error", err, "id", api.ID)
+				return nil, err
+			}
+		}
+	}
+
+	for key, api := range existingByKey {
+		if registered[key] || !strings.HasPrefix(api.Path, prefix) {
+			continue
+		}
+		if !api.Orphaned {
+			api.Orphaned = true
+			if err := s.repo.Update(api); err != nil {
+				s.log.Error("标记失效 API 路由失败", "error", err, "id", api.ID)
+				return nil, err
+			}
+		}
+		report.Orphaned = append(report.Orphaned, api)
+	}
+	sortAPIs(report.Added)
+	sortAPIs(report.Restored)
+	sortAPIs(report.Orphaned)
+
+	if report.Unassigned, err = s.repo.GetUnassigned(); err != nil {
+		s.log.Error("获取未分配的 API 路由失败", "error", err)
+		return nil, err
+	}
+
+	s.mu.Lock()
+	s.lastReport = report
+	s.mu.Unlock()
+
+	s.log.Info("API 路由同步完成", "total", report.Total, "added", len(report.Added), "restored", len(report.Restored),
+		"orphaned", len(report.Orphaned), "unassigned", len(report.Unassigned))
+	return report, nil
+}
+
+// GetSyncReport 获取路由同步报告
+// 新登记和重新出现的路由来自本实例最近一次同步，失效和未分配的路由按当前 API 表实时查询
+func (s *APIService) GetSyncReport() (*entity.APISyncReport, error) {
+	report := &entity.APISyncReport{}
+	s.mu.RLock()
+	if s.lastReport != nil {
+		report.SyncedAt = s.lastReport.SyncedAt
+		report.Total = s.lastReport.Total
+		report.Added = s.lastReport.Added
+		report.Restored = s.lastReport.Restored
+	}
+	s.mu.RUnlock()
+
+	routes, err := s.repo.GetAllRoutes()
+	if err != nil {
+		s.log.Error("获取 API 路由失败", "error", err)
+		return nil, err
+	}
+	for _, api := range routes {
+		if api.Orphaned {
+			report.Orphaned = append(report.Orphaned, api)
+		}
+	}
+	sortAPIs(report.Orphaned)
+
+	if report.Unassigned, err = s.repo.GetUnassigned(); err != nil {
+		s.log.Error("获取未分配的 API 路由失败", "error", err)
+		return nil, err
+	}
+	return report, nil
+}
+
+// sortAPIs 按路径和方法排序，使报告顺序稳定
+func sortAPIs(apis []*entity.API) {
+	sort.Slice(apis, func(i, j int) bool {
+		if apis[i].Path != apis[j].Path {
+			return apis[i].Path < apis[j].Path
+		}
+		return apis[i].Method < apis[j].Method
+	})
+}
+
 // BatchUpdateRoutes 批量更新路由信息
 func (s *APIService) BatchUpdateRoutes(routes []*entity.API) error {
 	s.log.Info("批量更新 API 路由", "count", len(routes))
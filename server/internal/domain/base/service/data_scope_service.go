@@ -0,0 +1,242 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/entity"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/repo"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/observability/logger"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/persistence/cache"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/persistence/database"
+)
+
+const (
+	dataScopeVersionKey = "data_scope:version" // 数据权限缓存版本号，角色数据权限变更时自增，使旧缓存失效
+	dataScopeVersionTTL = 24 * time.Hour       // 版本号有效期，需大于数据权限缓存有效期
+	// dataScopeCacheTTL 解析结果缓存有效期，用户调整部门、部门树变更时最多延迟该时长生效
+	dataScopeCacheTTL = 5 * time.Minute
+
+	// adminRoleCode 管理员角色编码，与权限中间件一致，始终可以查看全部数据
+	adminRoleCode = "admin"
+)
+
+// DataScopeService 数据权限服务
+// 根据用户当前角色的数据权限范围，解析出用户可见的部门集合，由仓库转换为列表查询条件
+type DataScopeService struct {
+	roleRepo       repo.RoleRepository
+	userRepo       repo.UserRepository
+	departmentRepo repo.DepartmentRepository
+	cache          cache.Cache
+	log            logger.Logger
+	settlePending  atomic.Bool // 是否已安排事务写入后的延迟失效
+}
+
+// NewDataScopeService 创建数据权限服务实例
+func NewDataScopeService(
+	roleRepo repo.RoleRepository,
+	userRepo repo.UserRepository,
+	departmentRepo repo.DepartmentRepository,
+	cache cache.Cache,
+	log logger.Logger,
+) *DataScopeService {
+	return &DataScopeService{
+		roleRepo:       roleRepo,
+		userRepo:       userRepo,
+		departmentRepo: departmentRepo,
+		cache:          cache,
+		log:            log,
+	}
+}
+
+// Resolve 解析用户在当前角色下的数据权限
+// 当前角色必须是用户已分配的角色，否则按仅本人处理，缓存中伪造或已失效的当前角色不能扩大数据范围
+// 管理员角色可以查看全部数据；角色不存在时按仅本人处理，避免降级角色看到他人数据
+func (s *DataScopeService) Resolve(userID int64, roleCode string) (*entity.DataScope, error) {
+	cacheKey := s.cacheKey(userID, roleCode)
+	if data, err := s.cache.Get(cacheKey); err == nil && data != "" {
+		var scope entity.DataScope
+		if err := json.Unmarshal([]byte(data), &scope); err == nil {
+			return &scope, nil
+		}
+	}
+
+	scope, err := s.resolve(userID, roleCode)
+	if err != nil {
+		return nil, err
+	}
+
+	if data, err := json.Marshal(scope); err == nil {
+		if err := s.cache.Set(cacheKey, string(data), dataScopeCacheTTL); err != nil {
+			s.log.Warn("缓存数据权限失败", "error", err, "userID", userID, "role", roleCode)
+		}
+	}
+	return scope, nil
+}
+
+// ResolveServiceAccount 解析服务账号在其角色下的数据权限
+// 服务账号不对应用户也没有部门，只有全部数据和自定义部门范围生效，其余范围看不到任何用户的数据
+func (s *DataScopeService) ResolveServiceAccount(roleCode string) (*entity.DataScope, error) {
+	if roleCode == adminRoleCode {
+		return &entity.DataScope{Scope: entity.DataScopeAll}, nil
+	}
+
+	role, err := s.roleRepo.GetByCode(roleCode)
+	if err != nil || role == nil {
+		s.log.Warn("服务账号角色不存在，数据权限按仅本人处理", "role", roleCode)
+		return &entity.DataScope{Scope: entity.DataScopeSelf}, nil
+	}
+	return s.resolveRole(0, role)
+}
+
+// resolve 校验当前角色属于用户后，计算数据权限
+func (s *DataScopeService) resolve(userID int64, roleCode string) (*entity.DataScope, error) {
+	roles, err := s.roleRepo.GetRolesByUser(userID)
+	if err != nil {
+		s.log.Error("获取用户角色失败", "error", err, "userID", userID)
+		return nil, err
+	}
+
+	var role *entity.Role
+	for _, r := range roles {
+		if r.Code == roleCode {
+			role = r
+			break
+		}
+	}
+	if role == nil {
+		s.log.Warn("当前角色不是用户已分配的角色，数据权限按仅本人处理", "userID", userID, "role", roleCode)
+		return &entity.DataScope{Scope: entity.DataScopeSelf, UserID: userID}, nil
+	}
+	if role.Code == adminRoleCode {
+		return &entity.DataScope{Scope: entity.DataScopeAll, UserID: userID}, nil
+	}
+	return s.resolveRole(userID, role)
+}
+
+// resolveRole 按角色的数据权限范围查询用户和部门树，计算数据权限
+func (s *DataScopeService) resolveRole(userID int64, role *entity.Role) (*entity.DataScope, error) {
+	self := &entity.DataScope{Scope: entity.DataScopeSelf, UserID: userID}
+
+	switch role.DataScope {
+	case entity.DataScopeAll, 0:
+		return &entity.DataScope{Scope: entity.DataScopeAll, UserID: userID}, nil
+	case entity.DataScopeCustom:
+		return &entity.DataScope{Scope: entity.DataScopeCustom, UserID: userID, DepartmentIDs: role.DataScopeDeptIds}, nil
+	case entity.DataScopeDept, entity.DataScopeDeptAndChildren:
+	default:
+		return self, nil
+	}
+	if userID == 0 {
+		// 服务账号没有所属部门
+		return self, nil
+	}
+
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		s.log.Error("获取用户失败", "error", err, "userID", userID)
+		return nil, err
+	}
+	if user.DepartmentID == 0 {
+		return self, nil
+	}
+
+	scope := &entity.DataScope{Scope: role.DataScope, UserID: userID, DepartmentIDs: []int64{user.DepartmentID}}
+	if role.DataScope == entity.DataScopeDeptAndChildren {
+		departments, err := s.departmentRepo.GetAll()
+		if err != nil {
+			s.log.Error("获取部门列表失败", "error", err)
+			return nil, err
+		}
+		scope.DepartmentIDs = descendantDepartmentIDs(departments, user.DepartmentID)
+	}
+	return scope, nil
+}
+
+// SetRoleDataScope 设置角色的数据权限，保存后立即失效所有用户的数据权限缓存
+func (s *DataScopeService) SetRoleDataScope(roleID int64, dataScope int, deptIDs []int64, updatedBy int64) error {
+	if !entity.IsValidDataScope(dataScope) {
+		return errors.New("数据权限范围无效")
+	}
+	if dataScope == entity.DataScopeCustom && len(deptIDs) == 0 {
+		return errors.New("自定义数据权限至少需要选择一个部门")
+	}
+
+	role, err := s.roleRepo.GetByID(roleID)
+	if err != nil {
+		return errors.New("角色不存在")
+	}
+
+	if dataScope == entity.DataScopeCustom {
+		for _, deptID := range deptIDs {
+			if _, err := s.departmentRepo.GetByID(deptID); err != nil {
+				return fmt.Errorf("部门 %d 不存在", deptID)
+			}
+		}
+	} else {
+		deptIDs = nil
+	}
+
+	role.DataScope = dataScope
+	role.DataScopeDeptIds = deptIDs
+	role.UpdatedBy = updatedBy
+	if err := s.roleRepo.Update(role); err != nil {
+		s.log.Error("设置角色数据权限失败", "error", err, "roleID", roleID)
+		return errors.New("设置角色数据权限失败")
+	}
+
+	s.Invalidate()
+	s.log.Info("角色数据权限设置成功", "roleID", roleID, "roleCode", role.Code, "dataScope", dataScope, "deptCount", len(deptIDs))
+	return nil
+}
+
+// Invalidate 使所有用户的数据权限缓存失效
+func (s *DataScopeService) Invalidate() {
+	if _, err := s.cache.Incr(dataScopeVersionKey, dataScopeVersionTTL); err != nil {
+		s.log.Warn("更新数据权限缓存版本失败", "error", err)
+	}
+}
+
+// OnTableChange 角色、角色分配或限时授予写入后使数据权限缓存失效，由 database.WatchTables 回调
+// 与权限判定缓存一致，事务内的写入在事务提交后再失效一次
+func (s *DataScopeService) OnTableChange(change database.TableChange) {
+	s.Invalidate()
+	if change.InTx && s.settlePending.CompareAndSwap(false, true) {
+		time.AfterFunc(permissionTxSettleDelay, func() {
+			s.settlePending.Store(false)
+			s.Invalidate()
+		})
+	}
+}
+
+// cacheKey 生成带版本号的缓存键
+func (s *DataScopeService) cacheKey(userID int64, roleCode string) string {
+	version, _ := s.cache.Get(dataScopeVersionKey)
+	if version == "" {
+		version = "0"
+	}
+	return fmt.Sprintf("data_scope:v%s:%d:%s", version, userID, roleCode)
+}
+
+// descendantDepartmentIDs 返回部门及其所有下级部门的 ID
+func descendantDepartmentIDs(departments []*entity.Department, rootID int64) []int64 {
+	children := make(map[int64][]int64, len(departments))
+	for _, dept := range departments {
+		children[dept.ParentID] = append(children[dept.ParentID], dept.ID)
+	}
+
+	ids := []int64{rootID}
+	visited := map[int64]bool{rootID: true}
+	for i := 0; i < len(ids); i++ {
+		for _, child := range children[ids[i]] {
+			if !visited[child] {
+				visited[child] = true
+				ids = append(ids, child)
+			}
+		}
+	}
+	return ids
+}
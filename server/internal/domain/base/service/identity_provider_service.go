@@ -0,0 +1,886 @@
+package service
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/ix-pay/ixpay-pro/internal/config"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/entity"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/repo"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/observability/logger"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/persistence/cache"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/security/oidc"
+	"gorm.io/gorm"
+)
+
+const (
+	// idpCodeKeyPrefix 授权码缓存键前缀，键中使用授权码的哈希
+	idpCodeKeyPrefix = "oidc:idp:code:"
+	// idpRefreshKeyPrefix 刷新令牌缓存键前缀，键中使用刷新令牌的哈希
+	idpRefreshKeyPrefix = "oidc:idp:refresh:"
+	// idpTokenBytes 授权码、刷新令牌和客户端密钥的随机字节数
+	idpTokenBytes = 32
+
+	defaultIDPAuthCodeTTL     = time.Minute
+	defaultIDPAccessTokenTTL  = time.Hour
+	defaultIDPIDTokenTTL      = time.Hour
+	defaultIDPRefreshTokenTTL = 30 * 24 * time.Hour
+)
+
+var (
+	oidcClientIDPattern = regexp.MustCompile(`^[A-Za-z0-9_.-]{2,64}$`)
+
+	// SupportedOIDCScopes 身份提供方支持的授权范围
+	SupportedOIDCScopes = []string{
+		entity.OIDCScopeOpenID,
+		entity.OIDCScopeProfile,
+		entity.OIDCScopeEmail,
+		entity.OIDCScopePhone,
+		entity.OIDCScopeRoles,
+		entity.OIDCScopeDepartment,
+	}
+	// SupportedOIDCGrantTypes 身份提供方支持的授权类型
+	SupportedOIDCGrantTypes = []string{
+		entity.OIDCGrantAuthorizationCode,
+		entity.OIDCGrantRefreshToken,
+		entity.OIDCGrantClientCredentials,
+	}
+	// SupportedOIDCClaims 身份提供方可能返回的声明
+	SupportedOIDCClaims = []string{
+		"sub", "name", "preferred_username", "nickname", "picture", "updated_at",
+		"email", "email_verified", "phone_number", "phone_number_verified",
+		"roles", "department_id", "department_name",
+	}
+)
+
+// OAuthError OAuth 2.0 / OIDC 协议错误
+// 令牌端点和用户信息端点按规范以 error、error_description 返回给客户端
+type OAuthError struct {
+	Code        string // 错误码，例如 invalid_request、invalid_grant
+	Description string // 错误描述
+	Status      int    // HTTP 状态码
+}
+
+// Error 实现 error 接口
+func (e *OAuthError) Error() string {
+	return e.Description
+}
+
+// newOAuthError 创建协议错误，HTTP 状态码按错误码确定
+func newOAuthError(code, description string) *OAuthError {
+	status := http.StatusBadRequest
+	switch code {
+	case "invalid_client", "invalid_token":
+		status = http.StatusUnauthorized
+	case "server_error":
+		status = http.StatusInternalServerError
+	}
+	return &OAuthError{Code: code, Description: description, Status: status}
+}
+
+// AuthorizeRequest 授权请求参数
+type AuthorizeRequest struct {
+	ResponseType        string // 响应类型，仅支持 code
+	ClientID            string // 客户端标识
+	RedirectURI         string // 回调地址
+	Scope               string // 授权范围，空格分隔
+	State               string // 客户端状态，原样返回
+	Nonce               string // 写入 ID Token 的 nonce
+	CodeChallenge       string // PKCE 校验值
+	CodeChallengeMethod string // PKCE 方法，仅支持 S256
+	Prompt              string // consent 表示强制重新确认授权
+}
+
+// Query 将授权请求编码为查询串，用于跳转授权确认页
+func (r *AuthorizeRequest) Query() url.Values {
+	values := url.Values{}
+	set := func(key, value string) {
+		if value != "" {
+			values.Set(key, value)
+		}
+	}
+	set("response_type", r.ResponseType)
+	set("client_id", r.ClientID)
+	set("redirect_uri", r.RedirectURI)
+	set("scope", r.Scope)
+	set("state", r.State)
+	set("nonce", r.Nonce)
+	set("code_challenge", r.CodeChallenge)
+	set("code_challenge_method", r.CodeChallengeMethod)
+	set("prompt", r.Prompt)
+	return values
+}
+
+// ConsentInfo 授权确认页展示的信息
+type ConsentInfo struct {
+	Client          *entity.OIDCClient // 申请授权的客户端
+	Scopes          []string           // 申请的授权范围
+	ConsentRequired bool               // 是否需要用户确认，为 false 时前端可直接提交同意
+}
+
+// TokenRequest 令牌端点请求参数
+type TokenRequest struct {
+	GrantType    string // 授权类型
+	Code         string // 授权码
+	RedirectURI  string // 授权请求中的回调地址
+	CodeVerifier string // PKCE 原始值
+	RefreshToken string // 刷新令牌
+	Scope        string // 申请的授权范围（刷新时只能缩小）
+	ClientID     string // 客户端标识（Basic 认证或表单）
+	ClientSecret string // 客户端密钥（Basic 认证或表单）
+}
+
+// idpAuthorization 授权码和刷新令牌对应的授权上下文
+type idpAuthorization struct {
+	ClientID            int64    `json:"clientId"`
+	UserID              int64    `json:"userId"`
+	Scopes              []string `json:"scopes"`
+	AuthTime            int64    `json:"authTime"`
+	RedirectURI         string   `json:"redirectUri,omitempty"`
+	Nonce               string   `json:"nonce,omitempty"`
+	CodeChallenge       string   `json:"codeChallenge,omitempty"`
+	CodeChallengeMethod string   `json:"codeChallengeMethod,omitempty"`
+}
+
+// IdentityProviderService 对内部应用提供的 OIDC 身份提供方服务
+// 内部应用通过授权码 + PKCE 使用本系统账号登录，令牌以 RS256 签名并通过 JWKS 公开公钥；
+// 授权码和刷新令牌保存在缓存中（只保存哈希），刷新令牌每次使用后轮换；
+// ID Token 和用户信息端点按授权范围返回用户资料、角色编码和所属部门
+type IdentityProviderService struct {
+	repo     repo.OIDCClientRepository
+	userRepo repo.UserRepository
+	roleRepo repo.RoleRepository
+	deptRepo repo.DepartmentRepository
+	signer   *oidc.Signer
+	cache    cache.Cache
+	log      logger.Logger
+	now      func() time.Time
+
+	enabled         bool
+	issuer          string
+	consentURL      string
+	authCodeTTL     time.Duration
+	accessTokenTTL  time.Duration
+	idTokenTTL      time.Duration
+	refreshTokenTTL time.Duration
+}
+
+// NewIdentityProviderService 创建身份提供方服务实例
+func NewIdentityProviderService(repo repo.OIDCClientRepository, userRepo repo.UserRepository, roleRepo repo.RoleRepository, deptRepo repo.DepartmentRepository, signer *oidc.Signer, cfg *config.Config, cache cache.Cache, log logger.Logger) *IdentityProviderService {
+	idpCfg := cfg.IdentityProvider
+	seconds := func(value int, fallback time.Duration) time.Duration {
+		if value <= 0 {
+			return fallback
+		}
+		return time.Duration(value) * time.Second
+	}
+
+	return &IdentityProviderService{
+		repo:            repo,
+		userRepo:        userRepo,
+		roleRepo:        roleRepo,
+		deptRepo:        deptRepo,
+		signer:          signer,
+		cache:           cache,
+		log:             log,
+		now:             time.Now,
+		enabled:         idpCfg.Enabled,
+		issuer:          strings.TrimRight(idpCfg.Issuer, "/"),
+		consentURL:      idpCfg.ConsentURL,
+		authCodeTTL:     seconds(idpCfg.AuthCodeTTL, defaultIDPAuthCodeTTL),
+		accessTokenTTL:  seconds(idpCfg.AccessTokenTTL, defaultIDPAccessTokenTTL),
+		idTokenTTL:      seconds(idpCfg.IDTokenTTL, defaultIDPIDTokenTTL),
+		refreshTokenTTL: seconds(idpCfg.RefreshTokenTTL, defaultIDPRefreshTokenTTL),
+	}
+}
+
+// Enabled 是否开启身份提供方
+func (s *IdentityProviderService) Enabled() bool {
+	return s.enabled && s.issuer != ""
+}
+
+// Issuer 返回签发方
+func (s *IdentityProviderService) Issuer() string {
+	return s.issuer
+}
+
+// JWKS 返回签名公钥集合
+func (s *IdentityProviderService) JWKS() oidc.JWKSet {
+	return s.signer.JWKS()
+}
+
+// CreateClient 登记客户端，机密客户端返回一次性明文密钥
+func (s *IdentityProviderService) CreateClient(client *entity.OIDCClient) (string, error) {
+	client.ClientID = strings.TrimSpace(client.ClientID)
+	if !oidcClientIDPattern.MatchString(client.ClientID) {
+		return "", errors.New("客户端标识只能包含字母、数字、下划线、点和中划线，长度 2-64")
+	}
+	if _, err := s.repo.GetByClientID(client.ClientID); err == nil {
+		return "", errors.New("客户端标识已存在")
+	}
+	if err := s.validateClient(client); err != nil {
+		return "", err
+	}
+
+	secret := ""
+	if !client.Public {
+		var err error
+		secret, err = randomHex(idpTokenBytes)
+		if err != nil {
+			s.log.Error("生成客户端密钥失败", "error", err)
+			return "", errors.New("生成客户端密钥失败")
+		}
+		client.SecretHash = hashAPIKeySecret(secret)
+	}
+
+	if err := s.repo.Create(client); err != nil {
+		s.log.Error("创建 OIDC 客户端失败", "clientID", client.ClientID, "error", err)
+		return "", errors.New("创建客户端失败")
+	}
+
+	s.log.Info("创建 OIDC 客户端成功", "id", client.ID, "clientID", client.ClientID)
+	return secret, nil
+}
+
+// UpdateClient 更新客户端，客户端标识和类型不可修改
+func (s *IdentityProviderService) UpdateClient(client *entity.OIDCClient) error {
+	existing, err := s.repo.GetByID(client.ID)
+	if err != nil {
+		return errors.New("客户端不存在")
+	}
+	client.ClientID = existing.ClientID
+	client.Public = existing.Public
+	if err := s.validateClient(client); err != nil {
+		return err
+	}
+
+	if err := s.repo.Update(client); err != nil {
+		s.log.Error("更新 OIDC 客户端失败", "id", client.ID, "error", err)
+		return errors.New("更新客户端失败")
+	}
+	return nil
+}
+
+// ResetClientSecret 重置机密客户端的密钥，旧密钥立即失效
+func (s *IdentityProviderService) ResetClientSecret(id, operatorID int64) (string, error) {
+	client, err := s.repo.GetByID(id)
+	if err != nil {
+		return "", errors.New("客户端不存在")
+	}
+	if client.Public {
+		return "", errors.New("公开客户端没有密钥")
+	}
+
+	secret, err := randomHex(idpTokenBytes)
+	if err != nil {
+		s.log.Error("生成客户端密钥失败", "error", err)
+		return "", errors.New("生成客户端密钥失败")
+	}
+	if err := s.repo.UpdateSecret(id, hashAPIKeySecret(secret), operatorID); err != nil {
+		s.log.Error("重置 OIDC 客户端密钥失败", "id", id, "error", err)
+		return "", errors.New("重置客户端密钥失败")
+	}
+
+	s.log.Info("重置 OIDC 客户端密钥成功", "id", id, "clientID", client.ClientID, "operatorID", operatorID)
+	return secret, nil
+}
+
+// DeleteClient 删除客户端及用户授权记录
+func (s *IdentityProviderService) DeleteClient(id int64) error {
+	if _, err := s.repo.GetByID(id); err != nil {
+		return errors.New("客户端不存在")
+	}
+	if err := s.repo.Delete(id); err != nil {
+		s.log.Error("删除 OIDC 客户端失败", "id", id, "error", err)
+		return errors.New("删除客户端失败")
+	}
+	return nil
+}
+
+// GetClientByID 获取客户端详情
+func (s *IdentityProviderService) GetClientByID(id int64) (*entity.OIDCClient, error) {
+	client, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, errors.New("客户端不存在")
+	}
+	return client, nil
+}
+
+// GetClientList 分页获取客户端列表
+func (s *IdentityProviderService) GetClientList(page, pageSize int, filters map[string]interface{}) ([]*entity.OIDCClient, int64, error) {
+	return s.repo.List(page, pageSize, filters)
+}
+
+// validateClient 校验客户端配置并补充默认值
+func (s *IdentityProviderService) validateClient(client *entity.OIDCClient) error {
+	client.Name = strings.TrimSpace(client.Name)
+	if client.Name == "" {
+		return errors.New("应用名称不能为空")
+	}
+
+	if len(client.GrantTypes) == 0 {
+		client.GrantTypes = []string{entity.OIDCGrantAuthorizationCode, entity.OIDCGrantRefreshToken}
+	}
+	grantTypes := make([]string, 0, len(client.GrantTypes))
+	for _, grantType := range client.GrantTypes {
+		if !containsString(SupportedOIDCGrantTypes, grantType) {
+			return fmt.Errorf("不支持的授权类型：%s", grantType)
+		}
+		if !containsString(grantTypes, grantType) {
+			grantTypes = append(grantTypes, grantType)
+		}
+	}
+	client.GrantTypes = grantTypes
+	if client.Public && client.AllowsGrant(entity.OIDCGrantClientCredentials) {
+		return errors.New("公开客户端不能使用客户端凭证授权")
+	}
+
+	if client.AllowsGrant(entity.OIDCGrantAuthorizationCode) && len(client.RedirectURIs) == 0 {
+		return errors.New("授权码模式至少需要登记一个回调地址")
+	}
+	for _, redirectURI := range client.RedirectURIs {
+		if err := validateOIDCURL(redirectURI, true); err != nil {
+			return fmt.Errorf("回调地址%s：%s", err.Error(), redirectURI)
+		}
+		if u, _ := url.Parse(redirectURI); u.Fragment != "" {
+			return fmt.Errorf("回调地址不能包含片段：%s", redirectURI)
+		}
+	}
+
+	scopes := []string{entity.OIDCScopeOpenID}
+	for _, scope := range client.Scopes {
+		if !containsString(SupportedOIDCScopes, scope) {
+			return fmt.Errorf("不支持的授权范围：%s", scope)
+		}
+		if !containsString(scopes, scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+	client.Scopes = scopes
+
+	if client.Status != entity.OIDCClientStatusEnabled && client.Status != entity.OIDCClientStatusDisabled {
+		client.Status = entity.OIDCClientStatusEnabled
+	}
+	return nil
+}
+
+// Authorize 处理授权端点请求，返回浏览器需要跳转的地址
+// 客户端或回调地址无效时返回错误，不能跳转回客户端；
+// 其他参数错误按规范携带 error 跳转回客户端；校验通过后跳转前端授权确认页，由前端完成登录和确认
+func (s *IdentityProviderService) Authorize(req *AuthorizeRequest) (string, error) {
+	client, err := s.authorizeClient(req)
+	if err != nil {
+		return "", err
+	}
+	if _, err := s.validateAuthorizeParams(client, req); err != nil {
+		return s.errorRedirect(req, err), nil
+	}
+
+	target, err := url.Parse(s.consentURL)
+	if err != nil || s.consentURL == "" {
+		s.log.Error("身份提供方授权确认页地址配置错误", "consentURL", s.consentURL)
+		return "", errors.New("身份提供方授权确认页地址配置错误")
+	}
+	query := target.Query()
+	for key, values := range req.Query() {
+		query[key] = values
+	}
+	target.RawQuery = query.Encode()
+	return target.String(), nil
+}
+
+// GetConsentInfo 获取授权确认页展示的信息
+func (s *IdentityProviderService) GetConsentInfo(userID int64, req *AuthorizeRequest) (*ConsentInfo, error) {
+	client, err := s.authorizeClient(req)
+	if err != nil {
+		return nil, err
+	}
+	scopes, err := s.validateAuthorizeParams(client, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ConsentInfo{
+		Client:          client,
+		Scopes:          scopes,
+		ConsentRequired: s.consentRequired(userID, client, scopes, req.Prompt),
+	}, nil
+}
+
+// Consent 处理用户的授权决定，返回跳转回客户端的地址
+// 同意时签发一次性授权码并记录授权范围；拒绝时返回 access_denied
+func (s *IdentityProviderService) Consent(userID int64, req *AuthorizeRequest, approved bool) (string, error) {
+	client, err := s.authorizeClient(req)
+	if err != nil {
+		return "", err
+	}
+	scopes, err := s.validateAuthorizeParams(client, req)
+	if err != nil {
+		return s.errorRedirect(req, err), nil
+	}
+	if !approved {
+		return s.errorRedirect(req, newOAuthError("access_denied", "用户拒绝授权")), nil
+	}
+
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil || !user.IsActive() {
+		return "", errors.New("用户不存在或已被禁用")
+	}
+
+	if !client.SkipConsent {
+		if err := s.repo.SaveConsent(&entity.OIDCConsent{UserID: userID, ClientID: client.ID, Scopes: scopes}); err != nil {
+			s.log.Error("保存 OIDC 授权记录失败", "userID", userID, "clientID", client.ClientID, "error", err)
+			return "", errors.New("保存授权记录失败")
+		}
+	}
+
+	code, err := randomHex(idpTokenBytes)
+	if err != nil {
+		s.log.Error("生成授权码失败", "error", err)
+		return "", errors.New("生成授权码失败")
+	}
+	authorization := idpAuthorization{
+		ClientID:            client.ID,
+		UserID:              userID,
+		Scopes:              scopes,
+		AuthTime:            s.now().Unix(),
+		RedirectURI:         req.RedirectURI,
+		Nonce:               req.Nonce,
+		CodeChallenge:       req.CodeChallenge,
+		CodeChallengeMethod: req.CodeChallengeMethod,
+	}
+	data, _ := json.Marshal(authorization)
+	if err := s.cache.Set(idpCodeKeyPrefix+hashAPIKeySecret(code), string(data), s.authCodeTTL); err != nil {
+		s.log.Error("保存授权码失败", "error", err)
+		return "", errors.New("生成授权码失败")
+	}
+
+	s.log.Info("OIDC 授权成功", "userID", userID, "clientID", client.ClientID, "scopes", strings.Join(scopes, " "))
+	return s.redirectWith(req.RedirectURI, url.Values{"code": {code}}, req.State), nil
+}
+
+// authorizeClient 校验客户端和回调地址，两者无效时不能跳转回客户端
+func (s *IdentityProviderService) authorizeClient(req *AuthorizeRequest) (*entity.OIDCClient, error) {
+	if !s.Enabled() {
+		return nil, errors.New("身份提供方未开启")
+	}
+	client, err := s.repo.GetByClientID(req.ClientID)
+	if err != nil || !client.IsActive() {
+		return nil, errors.New("客户端不存在或已被禁用")
+	}
+	if !client.AllowsRedirectURI(req.RedirectURI) {
+		return nil, errors.New("回调地址未登记")
+	}
+	return client, nil
+}
+
+// validateAuthorizeParams 校验授权请求的其余参数，返回规范化后的授权范围
+func (s *IdentityProviderService) validateAuthorizeParams(client *entity.OIDCClient, req *AuthorizeRequest) ([]string, error) {
+	if req.ResponseType != "code" {
+		return nil, newOAuthError("unsupported_response_type", "仅支持授权码模式")
+	}
+	if !client.AllowsGrant(entity.OIDCGrantAuthorizationCode) {
+		return nil, newOAuthError("unauthorized_client", "客户端未开通授权码模式")
+	}
+	scopes, err := parseOIDCScopes(client, req.Scope)
+	if err != nil {
+		return nil, err
+	}
+	if !containsString(scopes, entity.OIDCScopeOpenID) {
+		return nil, newOAuthError("invalid_scope", "授权范围必须包含 openid")
+	}
+	if req.CodeChallenge == "" {
+		if client.Public {
+			return nil, newOAuthError("invalid_request", "公开客户端必须使用 PKCE")
+		}
+	} else if req.CodeChallengeMethod != "S256" {
+		return nil, newOAuthError("invalid_request", "PKCE 仅支持 S256")
+	}
+	return scopes, nil
+}
+
+// consentRequired 判断是否需要用户确认授权
+func (s *IdentityProviderService) consentRequired(userID int64, client *entity.OIDCClient, scopes []string, prompt string) bool {
+	if client.SkipConsent {
+		return false
+	}
+	if containsString(strings.Fields(prompt), "consent") {
+		return true
+	}
+	consent, err := s.repo.GetConsent(userID, client.ID)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			s.log.Error("查询 OIDC 授权记录失败", "userID", userID, "clientID", client.ClientID, "error", err)
+		}
+		return true
+	}
+	for _, scope := range scopes {
+		if !containsString(consent.Scopes, scope) {
+			return true
+		}
+	}
+	return false
+}
+
+// errorRedirect 构造携带协议错误的回调地址
+func (s *IdentityProviderService) errorRedirect(req *AuthorizeRequest, err error) string {
+	oauthErr, ok := err.(*OAuthError)
+	if !ok {
+		oauthErr = newOAuthError("server_error", err.Error())
+	}
+	return s.redirectWith(req.RedirectURI, url.Values{
+		"error":             {oauthErr.Code},
+		"error_description": {oauthErr.Description},
+	}, req.State)
+}
+
+// redirectWith 在回调地址上附加参数、state 和签发方（RFC 9207）
+func (s *IdentityProviderService) redirectWith(redirectURI string, params url.Values, state string) string {
+	target, _ := url.Parse(redirectURI)
+	query := target.Query()
+	for key, values := range params {
+		query[key] = values
+	}
+	if state != "" {
+		query.Set("state", state)
+	}
+	query.Set("iss", s.issuer)
+	target.RawQuery = query.Encode()
+	return target.String()
+}
+
+// Token 处理令牌端点请求
+func (s *IdentityProviderService) Token(req *TokenRequest) (*oidc.TokenResponse, error) {
+	if !s.Enabled() {
+		return nil, newOAuthError("invalid_request", "身份提供方未开启")
+	}
+	client, err := s.authenticateClient(req.ClientID, req.ClientSecret)
+	if err != nil {
+		return nil, err
+	}
+	if !containsString(SupportedOIDCGrantTypes, req.GrantType) {
+		return nil, newOAuthError("unsupported_grant_type", "不支持的授权类型")
+	}
+	if !client.AllowsGrant(req.GrantType) {
+		return nil, newOAuthError("unauthorized_client", "客户端未开通该授权类型")
+	}
+
+	switch req.GrantType {
+	case entity.OIDCGrantAuthorizationCode:
+		return s.exchangeCode(client, req)
+	case entity.OIDCGrantRefreshToken:
+		return s.refresh(client, req)
+	default:
+		return s.clientCredentials(client, req)
+	}
+}
+
+// authenticateClient 认证客户端：机密客户端校验密钥，公开客户端不能携带密钥
+func (s *IdentityProviderService) authenticateClient(clientID, secret string) (*entity.OIDCClient, error) {
+	if clientID == "" {
+		return nil, newOAuthError("invalid_client", "缺少客户端标识")
+	}
+	client, err := s.repo.GetByClientID(clientID)
+	if err != nil || !client.IsActive() {
+		return nil, newOAuthError("invalid_client", "客户端认证失败")
+	}
+	if client.Public {
+		if secret != "" {
+			return nil, newOAuthError("invalid_client", "客户端认证失败")
+		}
+		return client, nil
+	}
+	if secret == "" || subtle.ConstantTimeCompare([]byte(hashAPIKeySecret(secret)), []byte(client.SecretHash)) != 1 {
+		s.log.Warn("OIDC 客户端认证失败", "clientID", clientID)
+		return nil, newOAuthError("invalid_client", "客户端认证失败")
+	}
+	return client, nil
+}
+
+// exchangeCode 使用授权码换取令牌，授权码只能使用一次
+func (s *IdentityProviderService) exchangeCode(client *entity.OIDCClient, req *TokenRequest) (*oidc.TokenResponse, error) {
+	if req.Code == "" {
+		return nil, newOAuthError("invalid_request", "缺少授权码")
+	}
+	authorization, err := s.consumeAuthorization(idpCodeKeyPrefix + hashAPIKeySecret(req.Code))
+	if err != nil {
+		return nil, newOAuthError("invalid_grant", "授权码无效或已过期")
+	}
+	if authorization.ClientID != client.ID || authorization.RedirectURI != req.RedirectURI {
+		return nil, newOAuthError("invalid_grant", "授权码与客户端或回调地址不匹配")
+	}
+	if authorization.CodeChallenge != "" {
+		if req.CodeVerifier == "" || subtle.ConstantTimeCompare([]byte(oidc.CodeChallengeS256(req.CodeVerifier)), []byte(authorization.CodeChallenge)) != 1 {
+			return nil, newOAuthError("invalid_grant", "PKCE 校验失败")
+		}
+	} else if req.CodeVerifier != "" {
+		return nil, newOAuthError("invalid_grant", "PKCE 校验失败")
+	}
+
+	return s.issueUserTokens(client, authorization)
+}
+
+// refresh 使用刷新令牌换取新令牌，旧刷新令牌立即失效
+func (s *IdentityProviderService) refresh(client *entity.OIDCClient, req *TokenRequest) (*oidc.TokenResponse, error) {
+	if req.RefreshToken == "" {
+		return nil, newOAuthError("invalid_request", "缺少刷新令牌")
+	}
+	authorization, err := s.consumeAuthorization(idpRefreshKeyPrefix + hashAPIKeySecret(req.RefreshToken))
+	if err != nil || authorization.ClientID != client.ID {
+		return nil, newOAuthError("invalid_grant", "刷新令牌无效或已过期")
+	}
+
+	// 刷新时只能缩小授权范围
+	if req.Scope != "" {
+		scopes := strings.Fields(req.Scope)
+		for _, scope := range scopes {
+			if !containsString(authorization.Scopes, scope) {
+				return nil, newOAuthError("invalid_scope", "刷新时不能扩大授权范围")
+			}
+		}
+		authorization.Scopes = scopes
+	}
+	// 刷新得到的 ID Token 不包含 nonce
+	authorization.Nonce = ""
+
+	return s.issueUserTokens(client, authorization)
+}
+
+// clientCredentials 客户端凭证模式，签发代表客户端自身的访问令牌
+func (s *IdentityProviderService) clientCredentials(client *entity.OIDCClient, req *TokenRequest) (*oidc.TokenResponse, error) {
+	scopes, err := parseOIDCScopes(client, req.Scope)
+	if err != nil {
+		return nil, err
+	}
+	// 客户端凭证不代表任何用户，不能申请用户相关的授权范围
+	if containsString(scopes, entity.OIDCScopeOpenID) {
+		return nil, newOAuthError("invalid_scope", "客户端凭证模式不能申请 openid")
+	}
+
+	accessToken, err := s.signAccessToken(client, client.ClientID, scopes)
+	if err != nil {
+		return nil, err
+	}
+	return &oidc.TokenResponse{
+		AccessToken: accessToken,
+		TokenType:   "Bearer",
+		ExpiresIn:   int64(s.accessTokenTTL.Seconds()),
+		Scope:       strings.Join(scopes, " "),
+	}, nil
+}
+
+// issueUserTokens 为用户签发访问令牌、ID Token 和（客户端允许时）刷新令牌
+func (s *IdentityProviderService) issueUserTokens(client *entity.OIDCClient, authorization *idpAuthorization) (*oidc.TokenResponse, error) {
+	user, err := s.userRepo.GetByID(authorization.UserID)
+	if err != nil || !user.IsActive() {
+		return nil, newOAuthError("invalid_grant", "用户不存在或已被禁用")
+	}
+
+	subject := strconv.FormatInt(user.ID, 10)
+	accessToken, err := s.signAccessToken(client, subject, authorization.Scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	idClaims := s.userClaims(user, authorization.Scopes)
+	idClaims["iss"] = s.issuer
+	idClaims["aud"] = client.ClientID
+	idClaims["azp"] = client.ClientID
+	idClaims["iat"] = now.Unix()
+	idClaims["exp"] = now.Add(s.idTokenTTL).Unix()
+	idClaims["auth_time"] = authorization.AuthTime
+	idClaims["at_hash"] = oidc.AccessTokenHash(accessToken)
+	if authorization.Nonce != "" {
+		idClaims["nonce"] = authorization.Nonce
+	}
+	idToken, err := s.signer.Sign(idClaims, oidc.TokenTypeID)
+	if err != nil {
+		s.log.Error("签发 ID Token 失败", "error", err)
+		return nil, newOAuthError("server_error", "签发令牌失败")
+	}
+
+	resp := &oidc.TokenResponse{
+		AccessToken: accessToken,
+		TokenType:   "Bearer",
+		ExpiresIn:   int64(s.accessTokenTTL.Seconds()),
+		IDToken:     idToken,
+		Scope:       strings.Join(authorization.Scopes, " "),
+	}
+
+	if client.AllowsGrant(entity.OIDCGrantRefreshToken) {
+		refreshToken, err := randomHex(idpTokenBytes)
+		if err != nil {
+			s.log.Error("生成刷新令牌失败", "error", err)
+			return nil, newOAuthError("server_error", "签发令牌失败")
+		}
+		data, _ := json.Marshal(idpAuthorization{
+			ClientID: authorization.ClientID,
+			UserID:   authorization.UserID,
+			Scopes:   authorization.Scopes,
+			AuthTime: authorization.AuthTime,
+		})
+		if err := s.cache.Set(idpRefreshKeyPrefix+hashAPIKeySecret(refreshToken), string(data), s.refreshTokenTTL); err != nil {
+			s.log.Error("保存刷新令牌失败", "error", err)
+			return nil, newOAuthError("server_error", "签发令牌失败")
+		}
+		resp.RefreshToken = refreshToken
+	}
+
+	s.log.Info("OIDC 签发令牌成功", "userID", user.ID, "clientID", client.ClientID)
+	return resp, nil
+}
+
+// signAccessToken 签发访问令牌（RFC 9068 JWT 格式）
+func (s *IdentityProviderService) signAccessToken(client *entity.OIDCClient, subject string, scopes []string) (string, error) {
+	jti, err := randomHex(16)
+	if err != nil {
+		s.log.Error("生成令牌 ID 失败", "error", err)
+		return "", newOAuthError("server_error", "签发令牌失败")
+	}
+	now := s.now()
+	token, err := s.signer.Sign(jwt.MapClaims{
+		"iss":       s.issuer,
+		"sub":       subject,
+		"aud":       client.ClientID,
+		"client_id": client.ClientID,
+		"scope":     strings.Join(scopes, " "),
+		"iat":       now.Unix(),
+		"exp":       now.Add(s.accessTokenTTL).Unix(),
+		"jti":       jti,
+	}, oidc.TokenTypeAccess)
+	if err != nil {
+		s.log.Error("签发访问令牌失败", "error", err)
+		return "", newOAuthError("server_error", "签发令牌失败")
+	}
+	return token, nil
+}
+
+// consumeAuthorization 取出并删除缓存中的授权上下文，保证只能使用一次
+func (s *IdentityProviderService) consumeAuthorization(key string) (*idpAuthorization, error) {
+	data, err := s.cache.Get(key)
+	if err != nil || data == "" {
+		return nil, errors.New("授权不存在")
+	}
+	if err := s.cache.Delete(key); err != nil {
+		return nil, err
+	}
+
+	var authorization idpAuthorization
+	if err := json.Unmarshal([]byte(data), &authorization); err != nil {
+		return nil, err
+	}
+	return &authorization, nil
+}
+
+// UserInfo 根据访问令牌返回用户声明
+func (s *IdentityProviderService) UserInfo(accessToken string) (map[string]interface{}, error) {
+	if !s.Enabled() {
+		return nil, newOAuthError("invalid_request", "身份提供方未开启")
+	}
+	claims := jwt.MapClaims{}
+	if err := s.signer.Verify(accessToken, s.issuer, oidc.TokenTypeAccess, claims); err != nil {
+		return nil, newOAuthError("invalid_token", "访问令牌无效或已过期")
+	}
+
+	scope, _ := claims["scope"].(string)
+	scopes := strings.Fields(scope)
+	if !containsString(scopes, entity.OIDCScopeOpenID) {
+		return nil, newOAuthError("insufficient_scope", "访问令牌未包含 openid 授权")
+	}
+	subject, _ := claims["sub"].(string)
+	userID, err := strconv.ParseInt(subject, 10, 64)
+	if err != nil {
+		return nil, newOAuthError("invalid_token", "访问令牌无效或已过期")
+	}
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil || !user.IsActive() {
+		return nil, newOAuthError("invalid_token", "用户不存在或已被禁用")
+	}
+
+	return s.userClaims(user, scopes), nil
+}
+
+// userClaims 按授权范围构建用户声明
+func (s *IdentityProviderService) userClaims(user *entity.User, scopes []string) jwt.MapClaims {
+	claims := jwt.MapClaims{"sub": strconv.FormatInt(user.ID, 10)}
+
+	if containsString(scopes, entity.OIDCScopeProfile) {
+		claims["name"] = firstNonEmpty(user.Nickname, user.Username)
+		claims["preferred_username"] = user.Username
+		if user.Nickname != "" {
+			claims["nickname"] = user.Nickname
+		}
+		if user.Avatar != "" {
+			claims["picture"] = user.Avatar
+		}
+		if !user.UpdatedAt.IsZero() {
+			claims["updated_at"] = user.UpdatedAt.Unix()
+		}
+	}
+	// 本系统不校验邮箱和手机号归属，verified 固定为 false
+	if containsString(scopes, entity.OIDCScopeEmail) && user.Email != "" {
+		claims["email"] = user.Email
+		claims["email_verified"] = false
+	}
+	if containsString(scopes, entity.OIDCScopePhone) && user.Phone != "" {
+		claims["phone_number"] = user.Phone
+		claims["phone_number_verified"] = false
+	}
+	if containsString(scopes, entity.OIDCScopeRoles) {
+		roleCodes := make([]string, 0)
+		roles, err := s.roleRepo.GetRolesByUser(user.ID)
+		if err != nil {
+			s.log.Error("获取用户角色失败", "userID", user.ID, "error", err)
+		}
+		for _, role := range roles {
+			if role != nil && role.IsActive() {
+				roleCodes = append(roleCodes, role.Code)
+			}
+		}
+		claims["roles"] = roleCodes
+	}
+	if containsString(scopes, entity.OIDCScopeDepartment) && user.DepartmentID != 0 {
+		claims["department_id"] = strconv.FormatInt(user.DepartmentID, 10)
+		dept, err := s.deptRepo.GetByID(user.DepartmentID)
+		if err != nil {
+			s.log.Error("获取用户部门失败", "userID", user.ID, "departmentID", user.DepartmentID, "error", err)
+		} else {
+			claims["department_name"] = dept.Name
+		}
+	}
+	return claims
+}
+
+// parseOIDCScopes 解析授权范围，必须是客户端已开通的范围
+func parseOIDCScopes(client *entity.OIDCClient, raw string) ([]string, error) {
+	scopes := make([]string, 0)
+	for _, scope := range strings.Fields(raw) {
+		if !containsString(client.Scopes, scope) {
+			return nil, newOAuthError("invalid_scope", fmt.Sprintf("客户端未开通授权范围：%s", scope))
+		}
+		if !containsString(scopes, scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+	return scopes, nil
+}
+
+// containsString 检查字符串切片是否包含指定值
+func containsString(values []string, target string) bool {
+	for _, v := range values {
+		if v == target {
+			return true
+		}
+	}
+	return false
+}
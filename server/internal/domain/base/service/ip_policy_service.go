@@ -0,0 +1,409 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/entity"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/repo"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/observability/logger"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/persistence/cache"
+)
+
+const (
+	ipPolicyCacheKey = "ip_policy:enabled" // 启用策略快照缓存键
+	ipPolicyCacheTTL = 10 * time.Minute    // 快照缓存有效期，策略变更时主动失效
+
+	// AbnormalLoginPolicyName 由异常登录 IP 生成的全局黑名单策略名称
+	AbnormalLoginPolicyName = "异常登录 IP 黑名单"
+	// abnormalLoginScanLimit 生成黑名单时扫描的失败登录记录上限
+	abnormalLoginScanLimit = 1000
+)
+
+// IPPolicyDecision IP 访问策略判定结果
+type IPPolicyDecision struct {
+	Allowed bool             // 是否允许访问
+	Policy  *entity.IPPolicy // 导致拒绝的策略
+	Reason  string           // 拒绝原因
+}
+
+// ipPolicySnapshot 缓存的启用策略快照
+// 角色策略按角色编码匹配（请求上下文中只有角色编码），加载时一并解析角色编码
+type ipPolicySnapshot struct {
+	Policies  []*entity.IPPolicy `json:"policies"`
+	RoleCodes map[int64]string   `json:"roleCodes"`
+}
+
+// IPPolicyService IP 访问策略服务
+// 在全局、角色、用户三个范围配置 CIDR 白名单和黑名单，限制 /api/admin 的访问来源
+// 判定规则：任一适用的黑名单命中即拒绝；每个存在白名单的范围，IP 都必须命中其中一条
+type IPPolicyService struct {
+	repo            repo.IPPolicyRepository
+	roleRepo        repo.RoleRepository
+	userRepo        repo.UserRepository
+	loginLogService *LoginLogService
+	cache           cache.Cache
+	log             logger.Logger
+}
+
+// NewIPPolicyService 创建 IP 访问策略服务实例
+func NewIPPolicyService(
+	repo repo.IPPolicyRepository,
+	roleRepo repo.RoleRepository,
+	userRepo repo.UserRepository,
+	loginLogService *LoginLogService,
+	cache cache.Cache,
+	log logger.Logger,
+) *IPPolicyService {
+	return &IPPolicyService{
+		repo:            repo,
+		roleRepo:        roleRepo,
+		userRepo:        userRepo,
+		loginLogService: loginLogService,
+		cache:           cache,
+		log:             log,
+	}
+}
+
+// CreateIPPolicy 创建 IP 访问策略
+func (s *IPPolicyService) CreateIPPolicy(policy *entity.IPPolicy) error {
+	if err := s.validatePolicy(policy); err != nil {
+		return err
+	}
+	if existing, err := s.repo.GetByName(policy.Name); err == nil && existing != nil {
+		return errors.New("策略名称已存在")
+	}
+
+	if err := s.repo.Create(policy); err != nil {
+		s.log.Error("创建 IP 访问策略失败", "name", policy.Name, "error", err)
+		return errors.New("创建 IP 访问策略失败")
+	}
+
+	s.invalidateCache()
+	s.log.Info("IP 访问策略创建成功", "policyID", policy.ID, "name", policy.Name, "scope", policy.Scope, "action", policy.Action)
+	return nil
+}
+
+// UpdateIPPolicy 更新 IP 访问策略
+func (s *IPPolicyService) UpdateIPPolicy(policy *entity.IPPolicy) error {
+	if _, err := s.repo.GetByID(policy.ID); err != nil {
+		return errors.New("IP 访问策略不存在")
+	}
+	if err := s.validatePolicy(policy); err != nil {
+		return err
+	}
+	if existing, err := s.repo.GetByName(policy.Name); err == nil && existing != nil && existing.ID != policy.ID {
+		return errors.New("策略名称已存在")
+	}
+
+	if err := s.repo.Update(policy); err != nil {
+		s.log.Error("更新 IP 访问策略失败", "policyID", policy.ID, "error", err)
+		return errors.New("更新 IP 访问策略失败")
+	}
+
+	s.invalidateCache()
+	s.log.Info("IP 访问策略更新成功", "policyID", policy.ID, "name", policy.Name)
+	return nil
+}
+
+// DeleteIPPolicy 删除 IP 访问策略
+func (s *IPPolicyService) DeleteIPPolicy(id int64) error {
+	if _, err := s.repo.GetByID(id); err != nil {
+		return errors.New("IP 访问策略不存在")
+	}
+
+	if err := s.repo.Delete(id); err != nil {
+		s.log.Error("删除 IP 访问策略失败", "policyID", id, "error", err)
+		return errors.New("删除 IP 访问策略失败")
+	}
+
+	s.invalidateCache()
+	s.log.Info("IP 访问策略删除成功", "policyID", id)
+	return nil
+}
+
+// GetIPPolicyByID 获取 IP 访问策略详情
+func (s *IPPolicyService) GetIPPolicyByID(id int64) (*entity.IPPolicy, error) {
+	policy, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, errors.New("IP 访问策略不存在")
+	}
+	return policy, nil
+}
+
+// GetIPPolicyList 分页获取 IP 访问策略列表
+func (s *IPPolicyService) GetIPPolicyList(page, pageSize int, filters map[string]interface{}) ([]*entity.IPPolicy, int64, error) {
+	return s.repo.List(page, pageSize, filters)
+}
+
+// BlockAbnormalIPs 将最近 1 小时失败登录次数达到阈值的 IP 加入全局黑名单
+// 黑名单策略不存在时创建，存在时合并新的 IP 并启用
+// 返回:
+// - *entity.IPPolicy: 更新后的黑名单策略，没有需要封禁的 IP 时为 nil
+// - int: 新增封禁的 IP 数量
+// - error: 错误信息
+func (s *IPPolicyService) BlockAbnormalIPs(minFailedCount int64, operatorID int64) (*entity.IPPolicy, int, error) {
+	if minFailedCount <= 0 {
+		return nil, 0, errors.New("失败次数阈值必须大于 0")
+	}
+
+	infos, _, err := s.loginLogService.GetAbnormalLogins(1, abnormalLoginScanLimit)
+	if err != nil {
+		return nil, 0, errors.New("获取异常登录记录失败")
+	}
+
+	var candidates []string
+	for _, info := range infos {
+		if info.FailedCount < minFailedCount {
+			continue
+		}
+		cidr, err := normalizeCIDR(info.IP)
+		if err != nil {
+			s.log.Warn("异常登录 IP 格式无效，跳过", "ip", info.IP)
+			continue
+		}
+		candidates = append(candidates, cidr)
+	}
+	if len(candidates) == 0 {
+		return nil, 0, nil
+	}
+
+	policy, err := s.repo.GetByName(AbnormalLoginPolicyName)
+	if err != nil || policy == nil {
+		policy = &entity.IPPolicy{
+			Name:        AbnormalLoginPolicyName,
+			Scope:       entity.IPPolicyScopeGlobal,
+			Action:      entity.IPPolicyActionDeny,
+			Description: "由异常登录记录自动生成",
+			Status:      entity.IPPolicyStatusEnabled,
+			CreatedBy:   operatorID,
+			UpdatedBy:   operatorID,
+		}
+	}
+
+	added := 0
+	for _, cidr := range candidates {
+		if !containsString(policy.CIDRs, cidr) {
+			policy.CIDRs = append(policy.CIDRs, cidr)
+			added++
+		}
+	}
+	if added == 0 && policy.ID != 0 && policy.IsActive() {
+		return policy, 0, nil
+	}
+
+	policy.Status = entity.IPPolicyStatusEnabled
+	policy.UpdatedBy = operatorID
+	if policy.ID == 0 {
+		err = s.repo.Create(policy)
+	} else {
+		err = s.repo.Update(policy)
+	}
+	if err != nil {
+		s.log.Error("保存异常登录 IP 黑名单失败", "error", err)
+		return nil, 0, errors.New("保存异常登录 IP 黑名单失败")
+	}
+
+	s.invalidateCache()
+	s.log.Info("异常登录 IP 已加入黑名单", "added", added, "total", len(policy.CIDRs), "operatorID", operatorID)
+	return policy, added, nil
+}
+
+// Check 判定 IP 是否允许访问
+// 参数:
+// - ip: 客户端 IP（由受信任代理配置解析）
+// - userID: 当前用户 ID，未认证时为 0，只判定全局策略
+// - roleCode: 当前角色编码，为空时不判定角色策略
+func (s *IPPolicyService) Check(ip string, userID int64, roleCode string) (*IPPolicyDecision, error) {
+	snapshot, err := s.loadSnapshot()
+	if err != nil {
+		return nil, err
+	}
+
+	parsed := net.ParseIP(ip)
+	allowByScope := make(map[string][]*entity.IPPolicy)
+	for _, policy := range snapshot.Policies {
+		if !policyApplies(policy, userID, roleCode, snapshot.RoleCodes) {
+			continue
+		}
+
+		if policy.Action == entity.IPPolicyActionDeny {
+			if parsed != nil && cidrsContain(policy.CIDRs, parsed) {
+				return &IPPolicyDecision{Policy: policy, Reason: fmt.Sprintf("IP 命中黑名单策略「%s」", policy.Name)}, nil
+			}
+			continue
+		}
+		allowByScope[policy.Scope] = append(allowByScope[policy.Scope], policy)
+	}
+
+	for _, scope := range []string{entity.IPPolicyScopeGlobal, entity.IPPolicyScopeRole, entity.IPPolicyScopeUser} {
+		policies := allowByScope[scope]
+		if len(policies) == 0 {
+			continue
+		}
+		matched := false
+		for _, policy := range policies {
+			if parsed != nil && cidrsContain(policy.CIDRs, parsed) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return &IPPolicyDecision{Policy: policies[0], Reason: fmt.Sprintf("IP 不在白名单策略「%s」允许的范围内", policies[0].Name)}, nil
+		}
+	}
+
+	return &IPPolicyDecision{Allowed: true}, nil
+}
+
+// RecordDenial 将 IP 策略拒绝记录到登录日志
+// 操作日志由操作日志中间件根据 403 响应记录
+func (s *IPPolicyService) RecordDenial(ip string, userID int64, userName, userAgent string, decision *IPPolicyDecision) {
+	browser, os := parseUserAgent(userAgent)
+	device := fmt.Sprintf("%s / %s", browser, os)
+	if err := s.loginLogService.RecordLogin(userID, userName, ip, s.loginLogService.LoginPlace(ip), device, browser, os, userAgent,
+		false, "IP 访问策略拒绝："+decision.Reason); err != nil {
+		s.log.Error("记录 IP 访问策略拒绝日志失败", "ip", ip, "error", err)
+	}
+}
+
+// loadSnapshot 加载启用的策略快照，优先读取缓存
+func (s *IPPolicyService) loadSnapshot() (*ipPolicySnapshot, error) {
+	if data, err := s.cache.Get(ipPolicyCacheKey); err == nil && data != "" {
+		var snapshot ipPolicySnapshot
+		if err := json.Unmarshal([]byte(data), &snapshot); err == nil {
+			return &snapshot, nil
+		}
+		s.log.Warn("解析 IP 访问策略缓存失败，重新加载")
+	}
+
+	policies, err := s.repo.ListEnabled()
+	if err != nil {
+		s.log.Error("加载 IP 访问策略失败", "error", err)
+		return nil, err
+	}
+
+	snapshot := &ipPolicySnapshot{Policies: policies, RoleCodes: make(map[int64]string)}
+	for _, policy := range policies {
+		if policy.Scope != entity.IPPolicyScopeRole {
+			continue
+		}
+		if _, ok := snapshot.RoleCodes[policy.TargetID]; ok {
+			continue
+		}
+		role, err := s.roleRepo.GetByID(policy.TargetID)
+		if err != nil || role == nil {
+			s.log.Warn("IP 访问策略关联的角色不存在", "policyID", policy.ID, "roleID", policy.TargetID)
+			continue
+		}
+		snapshot.RoleCodes[policy.TargetID] = role.Code
+	}
+
+	if data, err := json.Marshal(snapshot); err == nil {
+		if err := s.cache.Set(ipPolicyCacheKey, string(data), ipPolicyCacheTTL); err != nil {
+			s.log.Warn("缓存 IP 访问策略失败", "error", err)
+		}
+	}
+	return snapshot, nil
+}
+
+// invalidateCache 策略变更后清除缓存
+func (s *IPPolicyService) invalidateCache() {
+	if err := s.cache.Delete(ipPolicyCacheKey); err != nil {
+		s.log.Warn("清除 IP 访问策略缓存失败", "error", err)
+	}
+}
+
+// validatePolicy 校验策略并规范化 CIDR 规则
+func (s *IPPolicyService) validatePolicy(policy *entity.IPPolicy) error {
+	policy.Name = strings.TrimSpace(policy.Name)
+	if policy.Name == "" {
+		return errors.New("策略名称不能为空")
+	}
+
+	switch policy.Action {
+	case entity.IPPolicyActionAllow, entity.IPPolicyActionDeny:
+	default:
+		return errors.New("策略动作无效，仅支持 allow 或 deny")
+	}
+
+	switch policy.Scope {
+	case entity.IPPolicyScopeGlobal:
+		policy.TargetID = 0
+	case entity.IPPolicyScopeRole:
+		if role, err := s.roleRepo.GetByID(policy.TargetID); err != nil || role == nil {
+			return errors.New("策略关联的角色不存在")
+		}
+	case entity.IPPolicyScopeUser:
+		if user, err := s.userRepo.GetByID(policy.TargetID); err != nil || user == nil {
+			return errors.New("策略关联的用户不存在")
+		}
+	default:
+		return errors.New("策略作用范围无效，仅支持 global、role 或 user")
+	}
+
+	if len(policy.CIDRs) == 0 {
+		return errors.New("IP 规则不能为空")
+	}
+	cidrs := make([]string, 0, len(policy.CIDRs))
+	for _, item := range policy.CIDRs {
+		cidr, err := normalizeCIDR(item)
+		if err != nil {
+			return err
+		}
+		if !containsString(cidrs, cidr) {
+			cidrs = append(cidrs, cidr)
+		}
+	}
+	policy.CIDRs = cidrs
+	return nil
+}
+
+// policyApplies 判断策略是否适用于当前请求
+func policyApplies(policy *entity.IPPolicy, userID int64, roleCode string, roleCodes map[int64]string) bool {
+	switch policy.Scope {
+	case entity.IPPolicyScopeGlobal:
+		return true
+	case entity.IPPolicyScopeRole:
+		code, ok := roleCodes[policy.TargetID]
+		return ok && roleCode != "" && code == roleCode
+	case entity.IPPolicyScopeUser:
+		return userID != 0 && policy.TargetID == userID
+	}
+	return false
+}
+
+// normalizeCIDR 将 IP 或 CIDR 规范化为 CIDR 形式，单个 IP 转为 /32 或 /128
+func normalizeCIDR(item string) (string, error) {
+	item = strings.TrimSpace(item)
+	if strings.Contains(item, "/") {
+		_, ipNet, err := net.ParseCIDR(item)
+		if err != nil {
+			return "", fmt.Errorf("IP 规则格式无效：%s", item)
+		}
+		return ipNet.String(), nil
+	}
+
+	ip := net.ParseIP(item)
+	if ip == nil {
+		return "", fmt.Errorf("IP 规则格式无效：%s", item)
+	}
+	if ip.To4() != nil {
+		return ip.String() + "/32", nil
+	}
+	return ip.String() + "/128", nil
+}
+
+// cidrsContain 判断 IP 是否命中任一 CIDR 规则
+func cidrsContain(cidrs []string, ip net.IP) bool {
+	for _, cidr := range cidrs {
+		if _, ipNet, err := net.ParseCIDR(cidr); err == nil && ipNet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
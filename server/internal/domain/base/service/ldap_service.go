@@ -0,0 +1,188 @@
+package service
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/entity"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/repo"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/observability/logger"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/security/ldap"
+)
+
+// LDAPLoginResult LDAP 认证结果
+// 包含 LDAP 身份信息以及根据组映射计算出的本地角色和部门
+type LDAPLoginResult struct {
+	Identity     *ldap.Identity // LDAP 身份信息
+	RoleIDs      []int64        // 映射得到的角色 ID 列表，为空表示没有匹配的映射
+	DepartmentID int64          // 映射得到的部门 ID，0 表示没有匹配的映射
+}
+
+// LDAPService LDAP 登录服务
+// 负责调用 LDAP 认证器校验账号密码，并按组映射表计算本地角色和部门；
+// 本地用户的即时开通和更新由 UserService 完成
+type LDAPService struct {
+	authenticator  *ldap.Authenticator
+	mappingRepo    repo.LDAPGroupMappingRepository
+	roleRepo       repo.RoleRepository
+	departmentRepo repo.DepartmentRepository
+	log            logger.Logger
+}
+
+// NewLDAPService 创建 LDAP 登录服务实例
+func NewLDAPService(authenticator *ldap.Authenticator, mappingRepo repo.LDAPGroupMappingRepository, roleRepo repo.RoleRepository, departmentRepo repo.DepartmentRepository, log logger.Logger) *LDAPService {
+	return &LDAPService{
+		authenticator:  authenticator,
+		mappingRepo:    mappingRepo,
+		roleRepo:       roleRepo,
+		departmentRepo: departmentRepo,
+		log:            log,
+	}
+}
+
+// Enabled 是否开启 LDAP 登录
+func (s *LDAPService) Enabled() bool {
+	return s != nil && s.authenticator.Enabled()
+}
+
+// FallbackToLocal LDAP 中不存在该用户或服务不可用时是否回退到本地账号
+func (s *LDAPService) FallbackToLocal() bool {
+	return s == nil || s.authenticator.FallbackToLocal()
+}
+
+// Authenticate 使用 LDAP 校验账号密码并计算映射的角色和部门
+func (s *LDAPService) Authenticate(username, password string) (*LDAPLoginResult, error) {
+	identity, err := s.authenticator.Authenticate(username, password)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &LDAPLoginResult{Identity: identity}
+	mappings, err := s.mappingRepo.GetAll()
+	if err != nil {
+		// 映射读取失败时不阻断登录，保留用户现有角色和部门
+		s.log.Error("获取 LDAP 组映射失败", "error", err)
+		return result, nil
+	}
+
+	result.RoleIDs, result.DepartmentID = resolveLDAPMappings(identity.Groups, mappings)
+	s.log.Info("LDAP 认证成功", "username", identity.Username, "dn", identity.DN, "groups", len(identity.Groups), "roleIDs", result.RoleIDs, "departmentID", result.DepartmentID)
+	return result, nil
+}
+
+// resolveLDAPMappings 根据用户所属组匹配映射
+// mappings 需按优先级从高到低排序；角色取全部匹配映射的并集，部门取优先级最高的匹配映射
+func resolveLDAPMappings(groups []string, mappings []*entity.LDAPGroupMapping) ([]int64, int64) {
+	var roleIDs []int64
+	var departmentID int64
+	seen := make(map[int64]bool)
+
+	for _, mapping := range mappings {
+		if !matchLDAPGroup(groups, mapping.GroupDN) {
+			continue
+		}
+		if mapping.RoleID > 0 && !seen[mapping.RoleID] {
+			seen[mapping.RoleID] = true
+			roleIDs = append(roleIDs, mapping.RoleID)
+		}
+		if departmentID == 0 && mapping.DepartmentID > 0 {
+			departmentID = mapping.DepartmentID
+		}
+	}
+	return roleIDs, departmentID
+}
+
+// matchLDAPGroup 判断用户组是否匹配映射配置
+// 映射配置为完整 DN 时按 DN 比较，否则按组的 CN 比较，均不区分大小写
+func matchLDAPGroup(groups []string, pattern string) bool {
+	pattern = strings.TrimSpace(pattern)
+	if pattern == "" {
+		return false
+	}
+	isDN := strings.Contains(pattern, "=")
+	for _, group := range groups {
+		if isDN {
+			if strings.EqualFold(normalizeDN(group), normalizeDN(pattern)) {
+				return true
+			}
+		} else if strings.EqualFold(ldap.GroupCN(group), pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeDN 去掉 DN 各 RDN 两侧的空白，便于比较
+func normalizeDN(dn string) string {
+	parts := strings.Split(dn, ",")
+	for i, part := range parts {
+		parts[i] = strings.TrimSpace(part)
+	}
+	return strings.Join(parts, ",")
+}
+
+// CreateMapping 创建 LDAP 组映射
+func (s *LDAPService) CreateMapping(mapping *entity.LDAPGroupMapping) error {
+	if err := s.validateMapping(mapping); err != nil {
+		return err
+	}
+	if err := s.mappingRepo.Create(mapping); err != nil {
+		s.log.Error("创建 LDAP 组映射失败", "groupDN", mapping.GroupDN, "error", err)
+		return errors.New("创建 LDAP 组映射失败")
+	}
+	return nil
+}
+
+// UpdateMapping 更新 LDAP 组映射
+func (s *LDAPService) UpdateMapping(mapping *entity.LDAPGroupMapping) error {
+	if _, err := s.mappingRepo.GetByID(mapping.ID); err != nil {
+		return errors.New("LDAP 组映射不存在")
+	}
+	if err := s.validateMapping(mapping); err != nil {
+		return err
+	}
+	if err := s.mappingRepo.Update(mapping); err != nil {
+		s.log.Error("更新 LDAP 组映射失败", "id", mapping.ID, "error", err)
+		return errors.New("更新 LDAP 组映射失败")
+	}
+	return nil
+}
+
+// DeleteMapping 删除 LDAP 组映射
+func (s *LDAPService) DeleteMapping(id int64) error {
+	if _, err := s.mappingRepo.GetByID(id); err != nil {
+		return errors.New("LDAP 组映射不存在")
+	}
+	if err := s.mappingRepo.Delete(id); err != nil {
+		s.log.Error("删除 LDAP 组映射失败", "id", id, "error", err)
+		return errors.New("删除 LDAP 组映射失败")
+	}
+	return nil
+}
+
+// GetMappingList 分页获取 LDAP 组映射列表
+func (s *LDAPService) GetMappingList(page, pageSize int, filters map[string]interface{}) ([]*entity.LDAPGroupMapping, int64, error) {
+	return s.mappingRepo.List(page, pageSize, filters)
+}
+
+// validateMapping 校验映射配置
+func (s *LDAPService) validateMapping(mapping *entity.LDAPGroupMapping) error {
+	mapping.GroupDN = strings.TrimSpace(mapping.GroupDN)
+	if mapping.GroupDN == "" {
+		return errors.New("LDAP 组不能为空")
+	}
+	if mapping.RoleID == 0 && mapping.DepartmentID == 0 {
+		return errors.New("角色和部门至少需要映射一项")
+	}
+	if mapping.RoleID > 0 {
+		if _, err := s.roleRepo.GetByID(mapping.RoleID); err != nil {
+			return errors.New("角色不存在")
+		}
+	}
+	if mapping.DepartmentID > 0 {
+		if _, err := s.departmentRepo.GetByID(mapping.DepartmentID); err != nil {
+			return errors.New("部门不存在")
+		}
+	}
+	return nil
+}
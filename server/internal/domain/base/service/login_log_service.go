@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"time"
@@ -8,22 +9,30 @@ import (
 	"github.com/ix-pay/ixpay-pro/internal/domain/base/entity"
 	"github.com/ix-pay/ixpay-pro/internal/domain/base/repo"
 	"github.com/ix-pay/ixpay-pro/internal/infrastructure/observability/logger"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/support/geoip"
 )
 
 // LoginLogService 登录日志服务实现
 type LoginLogService struct {
-	repo repo.LoginLogRepository
-	log  logger.Logger
+	repo    repo.LoginLogRepository
+	locator *geoip.Locator
+	log     logger.Logger
 }
 
 // NewLoginLogService 创建登录日志服务实例
-func NewLoginLogService(repo repo.LoginLogRepository, log logger.Logger) *LoginLogService {
+func NewLoginLogService(repo repo.LoginLogRepository, locator *geoip.Locator, log logger.Logger) *LoginLogService {
 	return &LoginLogService{
-		repo: repo,
-		log:  log,
+		repo:    repo,
+		locator: locator,
+		log:     log,
 	}
 }
 
+// LoginPlace 根据 IP 地址获取登录地点
+func (s *LoginLogService) LoginPlace(ip string) string {
+	return s.locator.Place(ip)
+}
+
 // RecordLogin 记录登录日志
 func (s *LoginLogService) RecordLogin(
 	userID int64,
@@ -274,3 +283,82 @@ func (s *LoginLogService) ClearLoginLogs(startTime, endTime time.Time) error {
 	s.log.Info("清空登录日志成功", "start_time", startTime, "end_time", endTime)
 	return nil
 }
+
+// 登录地点回填每批处理的行数
+const placeBackfillBatchSize = 200
+
+// placeBackfillSources 需要回填的登录地点：未记录、未接入地址库时的默认值和查询失败的结果
+var placeBackfillSources = []string{"", "中国", geoip.PlaceUnknown}
+
+// BackfillPlaces 使用当前地址库为历史登录日志回填登录地点，返回更新的行数
+// 未加载地址库时不处理；查询结果仍为未知或与原值相同的记录保持不变
+func (s *LoginLogService) BackfillPlaces(ctx context.Context) (int, error) {
+	if !s.locator.Enabled() {
+		return 0, nil
+	}
+
+	var lastID int64
+	updated := 0
+	for {
+		if err := ctx.Err(); err != nil {
+			return updated, err
+		}
+		logs, err := s.repo.ListByPlaces(placeBackfillSources, lastID, placeBackfillBatchSize)
+		if err != nil {
+			return updated, err
+		}
+		if len(logs) == 0 {
+			return updated, nil
+		}
+
+		for _, log := range logs {
+			lastID = log.ID
+			place := s.locator.Place(log.LoginIP)
+			if place == log.LoginPlace || place == geoip.PlaceUnknown {
+				continue
+			}
+			ok, err := s.repo.UpdatePlace(log.ID, log.LoginPlace, place)
+			if err != nil {
+				return updated, fmt.Errorf("更新 ID 为 %d 的登录日志失败：%w", log.ID, err)
+			}
+			if ok {
+				updated++
+			}
+		}
+	}
+}
+
+// LoginPlaceBackfillTask 登录地点回填任务
+// 接入或更新 IP 地址库后，为此前记录的登录日志补充登录地点
+type LoginPlaceBackfillTask struct {
+	service *LoginLogService
+	log     logger.Logger
+}
+
+// NewLoginPlaceBackfillTask 创建登录地点回填任务
+func NewLoginPlaceBackfillTask(service *LoginLogService, log logger.Logger) *LoginPlaceBackfillTask {
+	return &LoginPlaceBackfillTask{service: service, log: log}
+}
+
+// GetName 返回任务名称
+func (t *LoginPlaceBackfillTask) GetName() string {
+	return "login_place_backfill"
+}
+
+// GetGroup 返回任务分组
+func (t *LoginPlaceBackfillTask) GetGroup() string {
+	return "system"
+}
+
+// Run 执行登录地点回填
+func (t *LoginPlaceBackfillTask) Run(ctx context.Context) error {
+	count, err := t.service.BackfillPlaces(ctx)
+	if err != nil {
+		t.log.Error("登录地点回填失败", "updated", count, "error", err)
+		return err
+	}
+	if count > 0 {
+		t.log.Info("登录地点回填完成", "count", count)
+	}
+	return nil
+}
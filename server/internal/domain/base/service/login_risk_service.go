@@ -0,0 +1,596 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/ix-pay/ixpay-pro/internal/config"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/entity"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/repo"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/observability/logger"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/persistence/cache"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/support/geoip"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/transport/notify"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/transport/push"
+	"gorm.io/gorm"
+)
+
+// 异常登录提醒渠道
+const (
+	LoginAlertChannelInApp = "in_app" // 推送到用户已登录的会话
+	LoginAlertChannelEmail = "email"  // 发送到用户绑定的邮箱
+)
+
+// 登录二次验证相关缓存键
+const (
+	loginStepUpKey        = "login_step_up:%s"          // 二次验证挑战
+	loginStepUpAttemptKey = "login_step_up:attempts:%s" // 二次验证码校验次数
+)
+
+// 异常登录检测默认配置（配置项为零值时使用）
+const (
+	defaultMaxTravelSpeed    = 900
+	defaultMinTravelDistance = 500
+	defaultTravelWindow      = 3600
+	defaultStepUpCodeTTL     = 300
+	defaultStepUpMaxAttempts = 5
+	loginStepUpCodeLength    = 6
+	earthRadiusKm            = 6371.0
+)
+
+// LoginAssessment 一次登录的风险评估结果
+// 认证通过后、创建会话前生成，登录完成后用于更新用户常用设备和地点
+type LoginAssessment struct {
+	UserID      int64
+	Username    string
+	Email       string
+	IP          string
+	Place       string
+	Browser     string
+	OS          string
+	Device      string
+	Fingerprint string
+	Location    geoip.Location // IP 归属地，Located 为 false 时为空
+	Located     bool
+	At          time.Time
+	Events      []*entity.LoginSecurityEvent
+
+	knownDevice   *entity.LoginDevice   // 已登录过的设备，新设备为空
+	knownLocation *entity.LoginLocation // 已登录过的地点，新地点为空
+}
+
+// StepUpRequiredError 登录需要二次验证
+// 调用方应提示用户输入验证码，再通过 VerifyLoginStepUp 完成登录
+type StepUpRequiredError struct {
+	ChallengeID string   // 二次验证挑战 ID
+	Channel     string   // 验证码发送渠道
+	Target      string   // 脱敏后的接收方
+	ExpiresIn   int      // 有效期（秒）
+	Reasons     []string // 触发二次验证的事件描述
+}
+
+// Error 实现 error 接口
+func (e *StepUpRequiredError) Error() string {
+	return "登录存在安全风险，请输入验证码完成登录"
+}
+
+// LoginStepUpChallenge 缓存中的二次验证挑战，验证码只保存哈希值
+type LoginStepUpChallenge struct {
+	UserID      int64   `json:"userId"`
+	UserName    string  `json:"userName"`
+	LoginType   string  `json:"loginType"`
+	IP          string  `json:"ip"`
+	Fingerprint string  `json:"fingerprint"`
+	CodeHash    string  `json:"codeHash"`
+	EventIDs    []int64 `json:"eventIds"`
+}
+
+// LoginRiskService 异常登录检测服务
+// 记录每个用户登录过的设备（按浏览器和操作系统计算指纹）和地点（按国家和城市），
+// 认证通过后与历史记录比较，新设备、新国家/城市和短时间内相距过远的两次登录生成安全事件，
+// 事件入库后通过站内推送或邮件提醒用户；命中配置的事件类型时要求二次验证后才完成登录
+type LoginRiskService struct {
+	deviceRepo        repo.LoginDeviceRepository
+	locationRepo      repo.LoginLocationRepository
+	eventRepo         repo.LoginSecurityEventRepository
+	locator           *geoip.Locator
+	onlineUserService *OnlineUserService
+	senders           *notify.Senders
+	cache             cache.Cache
+	cfg               config.LoginRiskConfig
+	secret            string
+	log               logger.Logger
+}
+
+// NewLoginRiskService 创建异常登录检测服务实例
+func NewLoginRiskService(
+	deviceRepo repo.LoginDeviceRepository,
+	locationRepo repo.LoginLocationRepository,
+	eventRepo repo.LoginSecurityEventRepository,
+	locator *geoip.Locator,
+	onlineUserService *OnlineUserService,
+	senders *notify.Senders,
+	cache cache.Cache,
+	cfg *config.Config,
+	log logger.Logger,
+) *LoginRiskService {
+	riskCfg := cfg.LoginRisk
+	if riskCfg.MaxTravelSpeed <= 0 {
+		riskCfg.MaxTravelSpeed = defaultMaxTravelSpeed
+	}
+	if riskCfg.MinTravelDistance <= 0 {
+		riskCfg.MinTravelDistance = defaultMinTravelDistance
+	}
+	if riskCfg.TravelWindow <= 0 {
+		riskCfg.TravelWindow = defaultTravelWindow
+	}
+	if riskCfg.StepUpChannel == "" {
+		riskCfg.StepUpChannel = notify.ChannelEmail
+	}
+	if riskCfg.StepUpCodeTTL <= 0 {
+		riskCfg.StepUpCodeTTL = defaultStepUpCodeTTL
+	}
+	if riskCfg.StepUpMaxAttempts <= 0 {
+		riskCfg.StepUpMaxAttempts = defaultStepUpMaxAttempts
+	}
+
+	return &LoginRiskService{
+		deviceRepo:        deviceRepo,
+		locationRepo:      locationRepo,
+		eventRepo:         eventRepo,
+		locator:           locator,
+		onlineUserService: onlineUserService,
+		senders:           senders,
+		cache:             cache,
+		cfg:               riskCfg,
+		secret:            cfg.JWT.SecretKey,
+		log:               log,
+	}
+}
+
+// Assess 评估本次登录的风险
+// 用户首次登录（没有任何设备和地点记录）时只建立基线，不生成事件；
+// 查询历史记录失败时放行登录，不生成对应事件
+// 未开启检测时返回 nil
+func (s *LoginRiskService) Assess(user *entity.User, ip, userAgent string) *LoginAssessment {
+	if s == nil || !s.cfg.Enabled || user == nil {
+		return nil
+	}
+
+	browser, os := parseUserAgent(userAgent)
+	a := &LoginAssessment{
+		UserID:      user.ID,
+		Username:    user.Username,
+		Email:       user.Email,
+		IP:          ip,
+		Place:       s.locator.Place(ip),
+		Browser:     browser,
+		OS:          os,
+		Device:      fmt.Sprintf("%s / %s", browser, os),
+		Fingerprint: deviceFingerprint(browser, os),
+		At:          time.Now(),
+	}
+	a.Location, a.Located = s.locator.Lookup(ip)
+	if a.Located && a.Location.Country == "" {
+		a.Located = false
+	}
+
+	s.assessDevice(a)
+	if a.Located {
+		s.assessLocation(a)
+	}
+	return a
+}
+
+// assessDevice 检查设备指纹是否登录过
+func (s *LoginRiskService) assessDevice(a *LoginAssessment) {
+	device, err := s.deviceRepo.GetByFingerprint(a.UserID, a.Fingerprint)
+	if err == nil {
+		a.knownDevice = device
+		return
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		s.log.Error("查询登录设备失败", "userID", a.UserID, "error", err)
+		return
+	}
+
+	count, err := s.deviceRepo.CountByUser(a.UserID)
+	if err != nil {
+		s.log.Error("统计登录设备失败", "userID", a.UserID, "error", err)
+		return
+	}
+	if count > 0 {
+		s.addEvent(a, entity.LoginEventNewDevice, fmt.Sprintf("首次使用 %s 登录", a.Device))
+	}
+}
+
+// assessLocation 检查登录地点是否登录过，并与最近一次登录地点比较是否为异地登录
+func (s *LoginRiskService) assessLocation(a *LoginAssessment) {
+	latest, err := s.locationRepo.GetLatest(a.UserID)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			s.log.Error("查询最近登录地点失败", "userID", a.UserID, "error", err)
+		}
+		return
+	}
+
+	location, err := s.locationRepo.Get(a.UserID, a.Location.Country, a.Location.City)
+	switch {
+	case err == nil:
+		a.knownLocation = location
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		hasCountry, err := s.locationRepo.HasCountry(a.UserID, a.Location.Country)
+		if err != nil {
+			s.log.Error("查询登录国家失败", "userID", a.UserID, "error", err)
+			break
+		}
+		if !hasCountry {
+			s.addEvent(a, entity.LoginEventNewCountry, fmt.Sprintf("首次在 %s 登录", a.Location.Country))
+		} else if a.Location.City != "" {
+			s.addEvent(a, entity.LoginEventNewCity, fmt.Sprintf("首次在 %s 登录", a.Location.String()))
+		}
+	default:
+		s.log.Error("查询登录地点失败", "userID", a.UserID, "error", err)
+	}
+
+	if latest.Country == a.Location.Country && latest.City == a.Location.City {
+		return
+	}
+	s.assessTravel(a, latest)
+}
+
+// assessTravel 判断与最近一次登录之间是否为不可能的移动
+// 两地都有经纬度时按距离和移动速度判断，否则按是否在时间窗口内更换国家判断
+func (s *LoginRiskService) assessTravel(a *LoginAssessment, latest *entity.LoginLocation) {
+	elapsed := a.At.Sub(latest.LastSeenAt)
+	if elapsed < 0 {
+		elapsed = 0
+	}
+	previousPlace := geoip.Location{Country: latest.Country, Province: latest.Province, City: latest.City}.String()
+
+	var distance float64
+	if a.Location.HasCoordinates() && (latest.Latitude != 0 || latest.Longitude != 0) {
+		distance = haversineKm(latest.Latitude, latest.Longitude, a.Location.Latitude, a.Location.Longitude)
+		if distance < s.cfg.MinTravelDistance {
+			return
+		}
+		if elapsed.Hours() > 0 && distance/elapsed.Hours() <= s.cfg.MaxTravelSpeed {
+			return
+		}
+	} else if latest.Country == a.Location.Country || elapsed > time.Duration(s.cfg.TravelWindow)*time.Second {
+		return
+	}
+
+	description := fmt.Sprintf("%s前在 %s 登录，本次在 %s 登录", formatElapsed(elapsed), previousPlace, a.Location.String())
+	if distance > 0 {
+		description += fmt.Sprintf("，相距约 %.0f 公里", distance)
+	}
+	event := s.addEvent(a, entity.LoginEventImpossibleTravel, description)
+	event.PreviousIP = latest.LastIP
+	event.PreviousPlace = previousPlace
+	event.PreviousTime = latest.LastSeenAt
+	event.DistanceKm = math.Round(distance)
+}
+
+// addEvent 向评估结果追加安全事件
+func (s *LoginRiskService) addEvent(a *LoginAssessment, eventType, description string) *entity.LoginSecurityEvent {
+	event := &entity.LoginSecurityEvent{
+		UserID:      a.UserID,
+		Username:    a.Username,
+		Type:        eventType,
+		RiskLevel:   entity.LoginRiskLevel(eventType),
+		IP:          a.IP,
+		Place:       a.Place,
+		Device:      a.Device,
+		Fingerprint: a.Fingerprint,
+		Description: description,
+		CreatedAt:   a.At,
+	}
+	a.Events = append(a.Events, event)
+	return event
+}
+
+// RequireStepUp 本次登录是否需要二次验证
+func (s *LoginRiskService) RequireStepUp(a *LoginAssessment) bool {
+	if s == nil || a == nil {
+		return false
+	}
+	for _, event := range a.Events {
+		if slices.Contains(s.cfg.StepUpEvents, event.Type) {
+			return true
+		}
+	}
+	return false
+}
+
+// Record 保存安全事件并提醒用户，没有事件时不做处理
+// 保存或提醒失败只记录日志，不影响登录
+func (s *LoginRiskService) Record(a *LoginAssessment, stepUpStatus string) {
+	if s == nil || a == nil || len(a.Events) == 0 {
+		return
+	}
+	for _, event := range a.Events {
+		event.StepUpStatus = stepUpStatus
+	}
+	if err := s.eventRepo.CreateBatch(a.Events); err != nil {
+		s.log.Error("保存登录安全事件失败", "userID", a.UserID, "error", err)
+	}
+	s.notify(a)
+}
+
+// notify 按配置的渠道提醒用户
+func (s *LoginRiskService) notify(a *LoginAssessment) {
+	descriptions := make([]string, 0, len(a.Events))
+	types := make([]string, 0, len(a.Events))
+	for _, event := range a.Events {
+		descriptions = append(descriptions, event.Description)
+		types = append(types, event.Type)
+	}
+	message := fmt.Sprintf("您的账号 %s 于 %s 在 %s（IP：%s）登录：%s。如非本人操作，请立即修改密码。",
+		a.Username, a.At.Format("2006-01-02 15:04:05"), a.Place, a.IP, strings.Join(descriptions, "；"))
+
+	for _, channel := range s.cfg.NotifyChannels {
+		switch channel {
+		case LoginAlertChannelInApp:
+			if s.onlineUserService != nil {
+				s.onlineUserService.NotifyUser(a.UserID, push.EventLoginAlert, message, strings.Join(types, ","))
+			}
+		case LoginAlertChannelEmail:
+			if a.Email == "" || s.senders == nil {
+				continue
+			}
+			sender, err := s.senders.Get(notify.ChannelEmail)
+			if err != nil {
+				s.log.Warn("获取邮件发送器失败", "error", err)
+				continue
+			}
+			msg := &notify.Message{To: a.Email, Subject: "账号登录提醒", Content: message}
+			if err := sender.Send(msg); err != nil {
+				s.log.Error("发送登录提醒邮件失败", "userID", a.UserID, "sender", sender.Name(), "error", err)
+			}
+		default:
+			s.log.Warn("未知的登录提醒渠道", "channel", channel)
+		}
+	}
+}
+
+// Remember 登录成功后记录设备和地点，作为之后登录的比较基线
+func (s *LoginRiskService) Remember(a *LoginAssessment) {
+	if s == nil || a == nil {
+		return
+	}
+
+	device := a.knownDevice
+	if device == nil {
+		device = &entity.LoginDevice{
+			UserID:      a.UserID,
+			Fingerprint: a.Fingerprint,
+			FirstSeenAt: a.At,
+		}
+	}
+	device.Browser = a.Browser
+	device.OS = a.OS
+	device.LastIP = a.IP
+	device.LastPlace = a.Place
+	device.LoginCount++
+	device.LastSeenAt = a.At
+	if err := s.deviceRepo.Save(device); err != nil {
+		s.log.Error("保存登录设备失败", "userID", a.UserID, "error", err)
+	}
+
+	if !a.Located {
+		return
+	}
+	location := a.knownLocation
+	if location == nil {
+		location = &entity.LoginLocation{
+			UserID:  a.UserID,
+			Country: a.Location.Country,
+			City:    a.Location.City,
+		}
+	}
+	location.Province = a.Location.Province
+	location.Latitude = a.Location.Latitude
+	location.Longitude = a.Location.Longitude
+	location.LastIP = a.IP
+	location.LastSeenAt = a.At
+	if err := s.locationRepo.Save(location); err != nil {
+		s.log.Error("保存登录地点失败", "userID", a.UserID, "error", err)
+	}
+}
+
+// StartStepUp 保存待验证的安全事件并向用户发送二次验证码
+// 返回 *StepUpRequiredError，调用方据此提示用户输入验证码；用户未绑定接收方时返回普通错误
+func (s *LoginRiskService) StartStepUp(user *entity.User, userName, loginType string, a *LoginAssessment) error {
+	channel := s.cfg.StepUpChannel
+	target := user.Email
+	if channel == notify.ChannelSMS {
+		target = user.Phone
+	}
+	if target == "" {
+		s.Record(a, entity.StepUpStatusFailed)
+		return errors.New("登录存在安全风险，需要二次验证，但账号未绑定邮箱/手机号，请联系管理员")
+	}
+	if s.senders == nil {
+		return errors.New("未配置消息发送，无法进行二次验证")
+	}
+	sender, err := s.senders.Get(channel)
+	if err != nil {
+		return err
+	}
+
+	code, err := generateNumericCode(loginStepUpCodeLength)
+	if err != nil {
+		s.log.Error("生成登录二次验证码失败", "error", err)
+		return errors.New("发送验证码失败，请稍后重试")
+	}
+	challengeID, err := randomHex(16)
+	if err != nil {
+		s.log.Error("生成登录二次验证挑战失败", "error", err)
+		return errors.New("发送验证码失败，请稍后重试")
+	}
+
+	s.Record(a, entity.StepUpStatusPending)
+	challenge := &LoginStepUpChallenge{
+		UserID:      user.ID,
+		UserName:    userName,
+		LoginType:   loginType,
+		IP:          a.IP,
+		Fingerprint: a.Fingerprint,
+		CodeHash:    s.hashCode(challengeID, code),
+	}
+	reasons := make([]string, 0, len(a.Events))
+	for _, event := range a.Events {
+		challenge.EventIDs = append(challenge.EventIDs, event.ID)
+		reasons = append(reasons, event.Description)
+	}
+
+	record, _ := json.Marshal(challenge)
+	ttl := time.Duration(s.cfg.StepUpCodeTTL) * time.Second
+	if err := s.cache.Set(fmt.Sprintf(loginStepUpKey, challengeID), string(record), ttl); err != nil {
+		s.log.Error("保存登录二次验证挑战失败", "userID", user.ID, "error", err)
+		return errors.New("发送验证码失败，请稍后重试")
+	}
+
+	msg := &notify.Message{
+		To:      target,
+		Subject: "登录验证码",
+		Content: fmt.Sprintf("您的账号 %s 正在 %s 使用 %s 登录（%s），验证码为 %s，%d 分钟内有效。如非本人操作，请立即修改密码。",
+			user.Username, a.Place, a.Device, strings.Join(reasons, "；"), code, max(s.cfg.StepUpCodeTTL/60, 1)),
+	}
+	if err := sender.Send(msg); err != nil {
+		s.log.Error("发送登录二次验证码失败", "userID", user.ID, "channel", channel, "sender", sender.Name(), "error", err)
+		_ = s.cache.Delete(fmt.Sprintf(loginStepUpKey, challengeID))
+		return errors.New("发送验证码失败，请稍后重试")
+	}
+
+	s.log.Info("登录需要二次验证", "userID", user.ID, "channel", channel, "ip", a.IP)
+	return &StepUpRequiredError{
+		ChallengeID: challengeID,
+		Channel:     channel,
+		Target:      maskTarget(channel, target),
+		ExpiresIn:   s.cfg.StepUpCodeTTL,
+		Reasons:     reasons,
+	}
+}
+
+// VerifyStepUp 校验二次验证码
+// 必须在发起登录的 IP 和设备上完成验证；验证码一次性有效，失败次数超过上限后作废并将事件标记为验证失败
+func (s *LoginRiskService) VerifyStepUp(challengeID, code, ip, userAgent string) (*LoginStepUpChallenge, error) {
+	if s == nil || challengeID == "" {
+		return nil, errors.New("验证码错误或已过期")
+	}
+
+	challengeKey := fmt.Sprintf(loginStepUpKey, challengeID)
+	raw, err := s.cache.Get(challengeKey)
+	if err != nil || raw == "" {
+		return nil, errors.New("验证码错误或已过期")
+	}
+	var challenge LoginStepUpChallenge
+	if err := json.Unmarshal([]byte(raw), &challenge); err != nil {
+		_ = s.cache.Delete(challengeKey)
+		return nil, errors.New("验证码错误或已过期")
+	}
+
+	attemptKey := fmt.Sprintf(loginStepUpAttemptKey, challengeID)
+	attempts, err := s.cache.Incr(attemptKey, time.Duration(s.cfg.StepUpCodeTTL)*time.Second)
+	if err != nil {
+		s.log.Error("登录二次验证计数失败", "userID", challenge.UserID, "error", err)
+		return nil, errors.New("验证失败，请稍后重试")
+	}
+	if attempts > int64(s.cfg.StepUpMaxAttempts) {
+		_ = s.cache.Delete(challengeKey)
+		s.updateStepUpStatus(challenge.EventIDs, entity.StepUpStatusFailed)
+		return nil, errors.New("验证码错误次数过多，请重新登录")
+	}
+
+	browser, os := parseUserAgent(userAgent)
+	if ip != challenge.IP || deviceFingerprint(browser, os) != challenge.Fingerprint {
+		s.log.Warn("登录二次验证的设备与发起登录的设备不一致", "userID", challenge.UserID, "ip", ip)
+		return nil, errors.New("请在发起登录的设备上完成验证")
+	}
+
+	expected := s.hashCode(challengeID, strings.TrimSpace(code))
+	if subtle.ConstantTimeCompare([]byte(expected), []byte(challenge.CodeHash)) != 1 {
+		return nil, errors.New("验证码错误或已过期")
+	}
+
+	// 验证码一次性使用
+	_ = s.cache.Delete(challengeKey)
+	_ = s.cache.Delete(attemptKey)
+	s.updateStepUpStatus(challenge.EventIDs, entity.StepUpStatusPassed)
+	return &challenge, nil
+}
+
+// updateStepUpStatus 更新事件的二次验证状态，失败只记录日志
+func (s *LoginRiskService) updateStepUpStatus(eventIDs []int64, status string) {
+	if err := s.eventRepo.UpdateStepUpStatus(eventIDs, status); err != nil {
+		s.log.Error("更新登录安全事件验证状态失败", "error", err, "status", status)
+	}
+}
+
+// GetSecurityEvents 分页获取登录安全事件
+func (s *LoginRiskService) GetSecurityEvents(page, pageSize int, filters map[string]interface{}) ([]*entity.LoginSecurityEvent, int64, error) {
+	events, total, err := s.eventRepo.List(page, pageSize, filters)
+	if err != nil {
+		s.log.Error("获取登录安全事件失败", "error", err)
+		return nil, 0, err
+	}
+	return events, total, nil
+}
+
+// hashCode 计算验证码哈希，绑定挑战 ID 防止跨挑战复用
+func (s *LoginRiskService) hashCode(challengeID, code string) string {
+	mac := hmac.New(sha256.New, []byte(s.secret))
+	mac.Write([]byte(challengeID + ":" + code))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// deviceFingerprint 根据浏览器和操作系统计算设备指纹，去掉版本号，浏览器升级后指纹不变
+func deviceFingerprint(browser, os string) string {
+	sum := sha256.Sum256([]byte(strings.ToLower(stripVersion(browser) + "|" + stripVersion(os))))
+	return hex.EncodeToString(sum[:])
+}
+
+// stripVersion 去掉名称末尾的版本号，如 "Chrome 120.0.0.0" 返回 "Chrome"
+func stripVersion(name string) string {
+	fields := strings.Fields(name)
+	for len(fields) > 1 && unicode.IsDigit(rune(fields[len(fields)-1][0])) {
+		fields = fields[:len(fields)-1]
+	}
+	return strings.Join(fields, " ")
+}
+
+// haversineKm 计算两个经纬度之间的球面距离（km）
+func haversineKm(lat1, lon1, lat2, lon2 float64) float64 {
+	toRad := func(deg float64) float64 { return deg * math.Pi / 180 }
+	dLat := toRad(lat2 - lat1)
+	dLon := toRad(lon2 - lon1)
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(toRad(lat1))*math.Cos(toRad(lat2))*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusKm * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// formatElapsed 将时间间隔格式化为 "5 分钟"、"2 小时" 等，用于 "…前在某地登录"
+func formatElapsed(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return "不到 1 分钟"
+	case d < time.Hour:
+		return fmt.Sprintf("%d 分钟", int(d.Minutes()))
+	case d < 48*time.Hour:
+		return fmt.Sprintf("%d 小时", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%d 天", int(d.Hours()/24))
+	}
+}
@@ -0,0 +1,709 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/entity"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/repo"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/observability/logger"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/persistence/cache"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/security/oidc"
+	"github.com/ix-pay/ixpay-pro/internal/utils/encryption"
+	"gorm.io/gorm"
+)
+
+const (
+	// oidcStateKeyPrefix 登录状态缓存键前缀，值为 oidcLoginState 的 JSON
+	oidcStateKeyPrefix = "oidc:state:"
+	// oidcStateTTL 登录状态有效期，超时需重新发起登录
+	oidcStateTTL = 10 * time.Minute
+	// oidcMetadataTTL 发现文档缓存有效期
+	oidcMetadataTTL = time.Hour
+	// oidcRequestTimeout 与身份提供方交互的总超时时间
+	oidcRequestTimeout = 15 * time.Second
+	// defaultOIDCUsernameClaim 自动开通用户时默认使用的用户名声明
+	defaultOIDCUsernameClaim = "preferred_username"
+)
+
+var (
+	oidcProviderCodePattern = regexp.MustCompile(`^[a-z0-9_-]{2,50}$`)
+	oidcUsernameSanitizer   = regexp.MustCompile(`[^A-Za-z0-9_.@-]`)
+	defaultOIDCScopes       = []string{"openid", "profile", "email"}
+)
+
+// oidcLoginState 发起登录时保存的状态，回调时一次性取出
+type oidcLoginState struct {
+	ProviderID   int64  `json:"providerId"`
+	Nonce        string `json:"nonce"`
+	CodeVerifier string `json:"codeVerifier"`
+}
+
+// oidcEndpoints 身份提供方的实际端点（配置优先，缺失时取发现文档）
+type oidcEndpoints struct {
+	Issuer                string
+	AuthorizationEndpoint string
+	TokenEndpoint         string
+	UserinfoEndpoint      string
+	JWKSURI               string
+	fetchedAt             time.Time
+	version               time.Time
+}
+
+// OIDCService OIDC 单点登录服务
+// 负责身份提供方配置管理，以及授权码 + PKCE 登录流程：
+// 发起登录（state、nonce、PKCE）-> 回调换取令牌 -> 通过 JWKS 校验 ID Token ->
+// 关联或开通本地用户 -> 按声明映射角色 -> 与账号密码登录相同的方式签发本地令牌
+type OIDCService struct {
+	repo        repo.OIDCProviderRepository
+	userRepo    repo.UserRepository
+	roleRepo    repo.RoleRepository
+	userService *UserService
+	cache       cache.Cache
+	client      *oidc.Client
+	log         logger.Logger
+
+	mu        sync.Mutex
+	endpoints map[int64]*oidcEndpoints
+	keySets   map[string]*oidc.KeySet
+}
+
+// NewOIDCService 创建 OIDC 单点登录服务实例
+func NewOIDCService(repo repo.OIDCProviderRepository, userRepo repo.UserRepository, roleRepo repo.RoleRepository, userService *UserService, cache cache.Cache, log logger.Logger) *OIDCService {
+	return &OIDCService{
+		repo:        repo,
+		userRepo:    userRepo,
+		roleRepo:    roleRepo,
+		userService: userService,
+		cache:       cache,
+		client:      oidc.NewClient(nil),
+		log:         log,
+		endpoints:   make(map[int64]*oidcEndpoints),
+		keySets:     make(map[string]*oidc.KeySet),
+	}
+}
+
+// CreateProvider 创建身份提供方
+func (s *OIDCService) CreateProvider(provider *entity.OIDCProvider) error {
+	provider.Code = strings.TrimSpace(provider.Code)
+	if !oidcProviderCodePattern.MatchString(provider.Code) {
+		return errors.New("编码只能包含小写字母、数字、下划线和中划线，长度 2-50")
+	}
+	if _, err := s.repo.GetByCode(provider.Code); err == nil {
+		return errors.New("身份提供方编码已存在")
+	}
+	if provider.ClientSecret == "" {
+		return errors.New("客户端密钥不能为空")
+	}
+	if err := s.validateProvider(provider); err != nil {
+		return err
+	}
+
+	if err := s.repo.Create(provider); err != nil {
+		s.log.Error("创建身份提供方失败", "code", provider.Code, "error", err)
+		return errors.New("创建身份提供方失败")
+	}
+	s.log.Info("创建身份提供方成功", "id", provider.ID, "code", provider.Code)
+	return nil
+}
+
+// UpdateProvider 更新身份提供方，客户端密钥为空时保留原值，编码不可修改
+func (s *OIDCService) UpdateProvider(provider *entity.OIDCProvider) error {
+	existing, err := s.repo.GetByID(provider.ID)
+	if err != nil {
+		return errors.New("身份提供方不存在")
+	}
+	provider.Code = existing.Code
+	if provider.ClientSecret == "" {
+		provider.ClientSecret = existing.ClientSecret
+	}
+	if err := s.validateProvider(provider); err != nil {
+		return err
+	}
+
+	if err := s.repo.Update(provider); err != nil {
+		s.log.Error("更新身份提供方失败", "id", provider.ID, "error", err)
+		return errors.New("更新身份提供方失败")
+	}
+	s.forgetEndpoints(provider.ID)
+	return nil
+}
+
+// DeleteProvider 删除身份提供方及其用户绑定
+func (s *OIDCService) DeleteProvider(id int64) error {
+	if _, err := s.repo.GetByID(id); err != nil {
+		return errors.New("身份提供方不存在")
+	}
+	if err := s.repo.Delete(id); err != nil {
+		s.log.Error("删除身份提供方失败", "id", id, "error", err)
+		return errors.New("删除身份提供方失败")
+	}
+	s.forgetEndpoints(id)
+	return nil
+}
+
+// GetProviderByID 根据 ID 获取身份提供方
+func (s *OIDCService) GetProviderByID(id int64) (*entity.OIDCProvider, error) {
+	provider, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, errors.New("身份提供方不存在")
+	}
+	return provider, nil
+}
+
+// GetProviderList 分页获取身份提供方列表
+func (s *OIDCService) GetProviderList(page, pageSize int, filters map[string]interface{}) ([]*entity.OIDCProvider, int64, error) {
+	return s.repo.List(page, pageSize, filters)
+}
+
+// GetEnabledProviders 获取登录页可用的身份提供方
+func (s *OIDCService) GetEnabledProviders() ([]*entity.OIDCProvider, error) {
+	return s.repo.GetEnabled()
+}
+
+// validateProvider 校验配置并补充默认值
+func (s *OIDCService) validateProvider(provider *entity.OIDCProvider) error {
+	provider.Name = strings.TrimSpace(provider.Name)
+	provider.Issuer = strings.TrimSpace(provider.Issuer)
+	provider.ClientID = strings.TrimSpace(provider.ClientID)
+	if provider.Name == "" {
+		return errors.New("名称不能为空")
+	}
+	if provider.ClientID == "" {
+		return errors.New("客户端 ID 不能为空")
+	}
+	if err := validateOIDCURL(provider.Issuer, true); err != nil {
+		return fmt.Errorf("签发方%s", err.Error())
+	}
+	if err := validateOIDCURL(provider.RedirectURI, true); err != nil {
+		return fmt.Errorf("回调地址%s", err.Error())
+	}
+	for name, endpoint := range map[string]string{
+		"授权端点":    provider.AuthorizationEndpoint,
+		"令牌端点":    provider.TokenEndpoint,
+		"用户信息端点":  provider.UserinfoEndpoint,
+		"JWKS 地址": provider.JWKSURI,
+	} {
+		if err := validateOIDCURL(endpoint, false); err != nil {
+			return fmt.Errorf("%s%s", name, err.Error())
+		}
+	}
+
+	switch provider.TokenAuthMethod {
+	case "":
+		provider.TokenAuthMethod = oidc.AuthMethodClientSecretBasic
+	case oidc.AuthMethodClientSecretBasic, oidc.AuthMethodClientSecretPost:
+	default:
+		return errors.New("不支持的客户端认证方式")
+	}
+
+	switch provider.LinkBy {
+	case "":
+		provider.LinkBy = entity.OIDCLinkBySubject
+	case entity.OIDCLinkBySubject, entity.OIDCLinkByEmail:
+	default:
+		return errors.New("不支持的账号关联方式")
+	}
+
+	scopes := make([]string, 0, len(provider.Scopes)+1)
+	hasOpenID := false
+	for _, scope := range provider.Scopes {
+		scope = strings.TrimSpace(scope)
+		if scope == "" {
+			continue
+		}
+		if scope == "openid" {
+			hasOpenID = true
+		}
+		scopes = append(scopes, scope)
+	}
+	if len(scopes) == 0 {
+		scopes = append(scopes, defaultOIDCScopes...)
+	} else if !hasOpenID {
+		scopes = append([]string{"openid"}, scopes...)
+	}
+	provider.Scopes = scopes
+
+	for _, mapping := range provider.RoleMappings {
+		mapping.Claim = strings.TrimSpace(mapping.Claim)
+		if mapping.Claim == "" || mapping.Value == "" {
+			return errors.New("角色映射的声明和值不能为空")
+		}
+		if _, err := s.roleRepo.GetByID(mapping.RoleID); err != nil {
+			return fmt.Errorf("角色映射中的角色不存在：%d", mapping.RoleID)
+		}
+	}
+	return nil
+}
+
+// validateOIDCURL 校验地址格式，必须为 https；本机地址允许 http，便于开发调试
+func validateOIDCURL(raw string, required bool) error {
+	if raw == "" {
+		if required {
+			return errors.New("不能为空")
+		}
+		return nil
+	}
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return errors.New("格式错误")
+	}
+	switch u.Scheme {
+	case "https":
+		return nil
+	case "http":
+		host := u.Hostname()
+		if host == "localhost" {
+			return nil
+		}
+		if ip := net.ParseIP(host); ip != nil && ip.IsLoopback() {
+			return nil
+		}
+	}
+	return errors.New("必须使用 https")
+}
+
+// BeginLogin 发起单点登录，返回身份提供方的授权地址
+// state、nonce 和 PKCE 校验值保存在缓存中，回调时一次性使用
+func (s *OIDCService) BeginLogin(providerCode string) (string, error) {
+	provider, err := s.getActiveProvider(providerCode)
+	if err != nil {
+		return "", err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), oidcRequestTimeout)
+	defer cancel()
+	endpoints, err := s.resolveEndpoints(ctx, provider)
+	if err != nil {
+		s.log.Error("获取身份提供方端点失败", "code", provider.Code, "error", err)
+		return "", errors.New("身份提供方暂不可用，请稍后重试")
+	}
+
+	state, err := oidc.RandomString(32)
+	if err != nil {
+		return "", err
+	}
+	nonce, err := oidc.RandomString(32)
+	if err != nil {
+		return "", err
+	}
+	verifier, err := oidc.RandomString(48)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := json.Marshal(oidcLoginState{ProviderID: provider.ID, Nonce: nonce, CodeVerifier: verifier})
+	if err != nil {
+		return "", err
+	}
+	if err := s.cache.Set(oidcStateKeyPrefix+state, string(data), oidcStateTTL); err != nil {
+		s.log.Error("保存单点登录状态失败", "error", err)
+		return "", errors.New("发起单点登录失败")
+	}
+
+	return oidc.AuthCodeURL(oidc.AuthCodeRequest{
+		Endpoint:      endpoints.AuthorizationEndpoint,
+		ClientID:      provider.ClientID,
+		RedirectURI:   provider.RedirectURI,
+		Scopes:        provider.Scopes,
+		State:         state,
+		Nonce:         nonce,
+		CodeChallenge: oidc.CodeChallengeS256(verifier),
+	})
+}
+
+// HandleCallback 处理身份提供方回调，校验通过后签发本地令牌
+// 返回值与 UserService.Login 相同
+func (s *OIDCService) HandleCallback(providerCode, code, state, ip, userAgent string) (*entity.User, string, string, time.Time, time.Time, error) {
+	fail := func(userID int64, userName, reason string, err error) (*entity.User, string, string, time.Time, time.Time, error) {
+		s.userService.RecordLoginFailure(userID, userName, ip, userAgent, reason)
+		return nil, "", "", time.Time{}, time.Time{}, err
+	}
+
+	provider, err := s.getActiveProvider(providerCode)
+	if err != nil {
+		return nil, "", "", time.Time{}, time.Time{}, err
+	}
+	loginName := "oidc:" + provider.Code
+
+	loginState, err := s.consumeState(state)
+	if err != nil || loginState.ProviderID != provider.ID {
+		return fail(0, loginName, "单点登录状态无效", errors.New("登录状态已失效，请重新登录"))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), oidcRequestTimeout)
+	defer cancel()
+
+	endpoints, err := s.resolveEndpoints(ctx, provider)
+	if err != nil {
+		s.log.Error("获取身份提供方端点失败", "code", provider.Code, "error", err)
+		return fail(0, loginName, "身份提供方不可用", errors.New("身份提供方暂不可用，请稍后重试"))
+	}
+
+	token, err := s.client.Exchange(ctx, oidc.ExchangeRequest{
+		Endpoint:     endpoints.TokenEndpoint,
+		ClientID:     provider.ClientID,
+		ClientSecret: provider.ClientSecret,
+		AuthMethod:   provider.TokenAuthMethod,
+		Code:         code,
+		RedirectURI:  provider.RedirectURI,
+		CodeVerifier: loginState.CodeVerifier,
+	})
+	if err != nil {
+		s.log.Error("单点登录换取令牌失败", "code", provider.Code, "error", err)
+		return fail(0, loginName, "授权码无效", errors.New("单点登录失败，请重新登录"))
+	}
+
+	claims, err := oidc.VerifyIDToken(ctx, token.IDToken, s.keySet(endpoints.JWKSURI), oidc.IDTokenExpectation{
+		Issuer:   endpoints.Issuer,
+		ClientID: provider.ClientID,
+		Nonce:    loginState.Nonce,
+	})
+	if err != nil {
+		s.log.Error("ID Token 校验失败", "code", provider.Code, "error", err)
+		return fail(0, loginName, "ID Token 校验失败", errors.New("单点登录失败，请重新登录"))
+	}
+	subject, _ := claims["sub"].(string)
+	loginName = "oidc:" + provider.Code + ":" + subject
+
+	if endpoints.UserinfoEndpoint != "" && token.AccessToken != "" {
+		s.mergeUserInfo(ctx, endpoints.UserinfoEndpoint, token.AccessToken, claims)
+	}
+
+	user, identity, created, err := s.linkUser(provider, claims)
+	if err != nil {
+		s.log.Warn("单点登录关联本地用户失败", "code", provider.Code, "sub", subject, "error", err)
+		return fail(0, loginName, err.Error(), err)
+	}
+
+	s.userService.syncExternalRoles(user.ID, resolveOIDCRoles(provider.RoleMappings, claims), created)
+	if err := s.repo.TouchIdentity(identity.ID, time.Now()); err != nil {
+		s.log.Warn("更新 OIDC 绑定登录时间失败", "identityID", identity.ID, "error", err)
+	}
+
+	return s.userService.CompleteLogin(user, user.Username, LoginTypeOIDC, ip, userAgent)
+}
+
+// getActiveProvider 获取启用的身份提供方
+func (s *OIDCService) getActiveProvider(code string) (*entity.OIDCProvider, error) {
+	provider, err := s.repo.GetByCode(code)
+	if err != nil || !provider.IsActive() {
+		return nil, errors.New("身份提供方不存在或已禁用")
+	}
+	return provider, nil
+}
+
+// consumeState 取出并删除登录状态，保证只能使用一次
+func (s *OIDCService) consumeState(state string) (*oidcLoginState, error) {
+	if state == "" {
+		return nil, errors.New("state 为空")
+	}
+	key := oidcStateKeyPrefix + state
+	data, err := s.cache.Get(key)
+	if err != nil || data == "" {
+		return nil, errors.New("state 不存在")
+	}
+	if err := s.cache.Delete(key); err != nil {
+		return nil, err
+	}
+
+	var loginState oidcLoginState
+	if err := json.Unmarshal([]byte(data), &loginState); err != nil {
+		return nil, err
+	}
+	return &loginState, nil
+}
+
+// resolveEndpoints 获取身份提供方端点
+// 授权、令牌和 JWKS 端点均已配置时直接使用，否则通过发现文档补全（缓存一小时）
+func (s *OIDCService) resolveEndpoints(ctx context.Context, provider *entity.OIDCProvider) (*oidcEndpoints, error) {
+	configured := &oidcEndpoints{
+		Issuer:                provider.Issuer,
+		AuthorizationEndpoint: provider.AuthorizationEndpoint,
+		TokenEndpoint:         provider.TokenEndpoint,
+		UserinfoEndpoint:      provider.UserinfoEndpoint,
+		JWKSURI:               provider.JWKSURI,
+	}
+	if configured.AuthorizationEndpoint != "" && configured.TokenEndpoint != "" && configured.JWKSURI != "" {
+		return configured, nil
+	}
+
+	s.mu.Lock()
+	cached, ok := s.endpoints[provider.ID]
+	s.mu.Unlock()
+	if ok && cached.version.Equal(provider.UpdatedAt) && time.Since(cached.fetchedAt) < oidcMetadataTTL {
+		return cached, nil
+	}
+
+	metadata, err := s.client.Discover(ctx, provider.Issuer)
+	if err != nil {
+		return nil, err
+	}
+	resolved := &oidcEndpoints{
+		Issuer:                metadata.Issuer,
+		AuthorizationEndpoint: firstNonEmpty(configured.AuthorizationEndpoint, metadata.AuthorizationEndpoint),
+		TokenEndpoint:         firstNonEmpty(configured.TokenEndpoint, metadata.TokenEndpoint),
+		UserinfoEndpoint:      firstNonEmpty(configured.UserinfoEndpoint, metadata.UserinfoEndpoint),
+		JWKSURI:               firstNonEmpty(configured.JWKSURI, metadata.JWKSURI),
+		fetchedAt:             time.Now(),
+		version:               provider.UpdatedAt,
+	}
+
+	s.mu.Lock()
+	s.endpoints[provider.ID] = resolved
+	s.mu.Unlock()
+	return resolved, nil
+}
+
+// forgetEndpoints 清除身份提供方的端点缓存
+func (s *OIDCService) forgetEndpoints(providerID int64) {
+	s.mu.Lock()
+	delete(s.endpoints, providerID)
+	s.mu.Unlock()
+}
+
+// keySet 获取 JWKS 地址对应的密钥缓存
+func (s *OIDCService) keySet(uri string) *oidc.KeySet {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if keySet, ok := s.keySets[uri]; ok {
+		return keySet
+	}
+	keySet := oidc.NewKeySet(s.client, uri)
+	s.keySets[uri] = keySet
+	return keySet
+}
+
+// mergeUserInfo 使用用户信息端点补充 ID Token 中缺失的声明
+// sub 不一致时丢弃用户信息，获取失败不影响登录
+func (s *OIDCService) mergeUserInfo(ctx context.Context, endpoint, accessToken string, claims map[string]interface{}) {
+	userInfo, err := s.client.UserInfo(ctx, endpoint, accessToken)
+	if err != nil {
+		s.log.Warn("获取 OIDC 用户信息失败", "error", err)
+		return
+	}
+	if userInfo["sub"] != claims["sub"] {
+		s.log.Warn("OIDC 用户信息 sub 与 ID Token 不一致", "sub", claims["sub"])
+		return
+	}
+	for key, value := range userInfo {
+		if _, ok := claims[key]; !ok {
+			claims[key] = value
+		}
+	}
+}
+
+// linkUser 按 sub 查找已绑定的本地用户；未绑定时按配置通过已验证邮箱关联或自动开通
+// 返回:
+// - *entity.User: 本地用户
+// - *entity.UserOIDCIdentity: 绑定关系
+// - bool: 是否为新开通的用户
+// - error: 无法关联时返回错误
+func (s *OIDCService) linkUser(provider *entity.OIDCProvider, claims map[string]interface{}) (*entity.User, *entity.UserOIDCIdentity, bool, error) {
+	subject, _ := claims["sub"].(string)
+	email := claimString(claims, "email")
+
+	identity, err := s.repo.GetIdentity(provider.ID, subject)
+	if err == nil {
+		user, err := s.userRepo.GetByID(identity.UserID)
+		if err != nil {
+			return nil, nil, false, errors.New("绑定的本地用户不存在")
+		}
+		return user, identity, false, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil, false, fmt.Errorf("查询 OIDC 绑定失败：%w", err)
+	}
+
+	var user *entity.User
+	created := false
+	if provider.LinkBy == entity.OIDCLinkByEmail && email != "" && oidcEmailVerified(claims, provider.TrustEmail) {
+		if existing, err := s.userRepo.GetByEmail(email); err == nil {
+			user = existing
+		}
+	}
+
+	if user == nil {
+		if !provider.AutoCreate {
+			return nil, nil, false, errors.New("未找到关联的本地账号，请联系管理员")
+		}
+		user, err = s.createUser(provider, claims)
+		if err != nil {
+			return nil, nil, false, err
+		}
+		created = true
+	}
+
+	identity = &entity.UserOIDCIdentity{
+		ProviderID: provider.ID,
+		Subject:    subject,
+		UserID:     user.ID,
+		Email:      email,
+	}
+	if err := s.repo.CreateIdentity(identity); err != nil {
+		return nil, nil, false, fmt.Errorf("保存 OIDC 绑定失败：%w", err)
+	}
+	s.log.Info("OIDC 身份已绑定本地用户", "provider", provider.Code, "sub", subject, "userID", user.ID, "created", created)
+	return user, identity, created, nil
+}
+
+// createUser 根据声明自动开通本地用户，使用随机密码（无法用于本地登录）
+func (s *OIDCService) createUser(provider *entity.OIDCProvider, claims map[string]interface{}) (*entity.User, error) {
+	username, err := s.allocateUsername(provider, claims)
+	if err != nil {
+		return nil, err
+	}
+
+	randomPassword, err := randomHex(24)
+	if err != nil {
+		return nil, err
+	}
+	passwordHash, err := encryption.GeneratePasswordHash(randomPassword)
+	if err != nil {
+		return nil, err
+	}
+
+	user := &entity.User{
+		Username:          username,
+		PasswordHash:      passwordHash,
+		Nickname:          claimString(claims, "name"),
+		Email:             claimString(claims, "email"),
+		Phone:             claimString(claims, "phone_number"),
+		Status:            1,
+		PasswordChangedAt: time.Now(),
+	}
+	if err := s.userRepo.Create(user); err != nil {
+		return nil, fmt.Errorf("开通本地用户失败：%w", err)
+	}
+	return user, nil
+}
+
+// allocateUsername 生成不重复的用户名
+// 依次尝试配置的用户名声明、邮箱前缀和 "编码_sub"，重名时追加随机后缀
+func (s *OIDCService) allocateUsername(provider *entity.OIDCProvider, claims map[string]interface{}) (string, error) {
+	usernameClaim := provider.UsernameClaim
+	if usernameClaim == "" {
+		usernameClaim = defaultOIDCUsernameClaim
+	}
+
+	base := claimString(claims, usernameClaim)
+	if base == "" {
+		if email := claimString(claims, "email"); email != "" {
+			base = strings.SplitN(email, "@", 2)[0]
+		}
+	}
+	if base == "" {
+		subject, _ := claims["sub"].(string)
+		base = provider.Code + "_" + subject
+	}
+	base = oidcUsernameSanitizer.ReplaceAllString(base, "_")
+	if len(base) > 40 {
+		base = base[:40]
+	}
+
+	candidate := base
+	for i := 0; i < 5; i++ {
+		if _, err := s.userRepo.GetByUsername(candidate); err != nil {
+			return candidate, nil
+		}
+		suffix, err := randomHex(3)
+		if err != nil {
+			return "", err
+		}
+		candidate = base + "_" + suffix
+	}
+	return "", errors.New("无法生成唯一的用户名")
+}
+
+// resolveOIDCRoles 按映射规则计算角色，返回去重后的角色 ID 列表
+func resolveOIDCRoles(mappings []*entity.OIDCRoleMapping, claims map[string]interface{}) []int64 {
+	var roleIDs []int64
+	seen := make(map[int64]bool)
+	for _, mapping := range mappings {
+		if seen[mapping.RoleID] {
+			continue
+		}
+		for _, value := range claimValues(claims, mapping.Claim) {
+			if value == mapping.Value {
+				seen[mapping.RoleID] = true
+				roleIDs = append(roleIDs, mapping.RoleID)
+				break
+			}
+		}
+	}
+	return roleIDs
+}
+
+// claimValues 读取声明值并统一为字符串列表
+// 声明名本身包含 "." 时（如 URL 形式的自定义声明）优先按完整名称读取，否则按嵌套路径读取
+func claimValues(claims map[string]interface{}, path string) []string {
+	value, ok := claims[path]
+	if !ok {
+		var current interface{} = claims
+		for _, part := range strings.Split(path, ".") {
+			object, isObject := current.(map[string]interface{})
+			if !isObject {
+				return nil
+			}
+			if current, ok = object[part]; !ok {
+				return nil
+			}
+		}
+		value = current
+	}
+
+	switch v := value.(type) {
+	case string:
+		return []string{v}
+	case []interface{}:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			values = append(values, fmt.Sprint(item))
+		}
+		return values
+	case []string:
+		return v
+	case nil:
+		return nil
+	default:
+		return []string{fmt.Sprint(v)}
+	}
+}
+
+// claimString 读取字符串声明
+func claimString(claims map[string]interface{}, name string) string {
+	value, _ := claims[name].(string)
+	return strings.TrimSpace(value)
+}
+
+// oidcEmailVerified 判断邮箱是否已验证；未声明 email_verified 时取决于是否信任该身份提供方
+func oidcEmailVerified(claims map[string]interface{}, trustEmail bool) bool {
+	switch v := claims["email_verified"].(type) {
+	case bool:
+		return v
+	case string:
+		return strings.EqualFold(v, "true")
+	case nil:
+		return trustEmail
+	default:
+		return false
+	}
+}
+
+// firstNonEmpty 返回第一个非空字符串
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
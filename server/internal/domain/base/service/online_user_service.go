@@ -3,25 +3,218 @@ package service
 import (
 	"errors"
 	"fmt"
+	"sort"
+	"sync"
 	"time"
 
+	"github.com/ix-pay/ixpay-pro/internal/config"
 	"github.com/ix-pay/ixpay-pro/internal/domain/base/entity"
 	"github.com/ix-pay/ixpay-pro/internal/domain/base/repo"
 	"github.com/ix-pay/ixpay-pro/internal/infrastructure/observability/logger"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/support/geoip"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/transport/push"
 )
 
+// sessionTouchInterval 会话活跃时间的最小刷新间隔，避免每个请求都写缓存
+const sessionTouchInterval = time.Minute
+
 // OnlineUserService 在线用户服务实现
+// 每次登录创建一条会话记录，会话 ID 写入令牌，同一用户可在多个设备同时在线
+// 会话被强制终止时记录审计，并通过推送中心通知对应客户端
 type OnlineUserService struct {
-	repo repo.OnlineUserRepository
-	log  logger.Logger
+	repo      repo.OnlineUserRepository
+	auditRepo repo.SessionAuditLogRepository
+	hub       *push.Hub
+	locator   *geoip.Locator
+	config    *config.Config
+	log       logger.Logger
+	kickoutMu sync.Mutex
 }
 
 // NewOnlineUserService 创建在线用户服务实例
-func NewOnlineUserService(repo repo.OnlineUserRepository, log logger.Logger) *OnlineUserService {
+func NewOnlineUserService(
+	repo repo.OnlineUserRepository,
+	auditRepo repo.SessionAuditLogRepository,
+	hub *push.Hub,
+	locator *geoip.Locator,
+	config *config.Config,
+	log logger.Logger,
+) *OnlineUserService {
 	return &OnlineUserService{
-		repo: repo,
-		log:  log,
+		repo:      repo,
+		auditRepo: auditRepo,
+		hub:       hub,
+		locator:   locator,
+		config:    config,
+		log:       log,
+	}
+}
+
+// CreateSession 登录成功后创建会话
+// 同一用户的会话数达到上限时按配置的策略踢出最早登录的会话或拒绝本次登录
+// 参数:
+// - userID: 用户 ID
+// - userName: 用户名
+// - nickname: 昵称
+// - loginType: 登录方式
+// - ip: 客户端 IP
+// - userAgent: 客户端 User-Agent
+// - ttl: 会话有效期，与刷新令牌有效期一致
+func (s *OnlineUserService) CreateSession(userID int64, userName, nickname, loginType, ip, userAgent string, ttl time.Duration) (*entity.OnlineUser, error) {
+	if err := s.enforceSessionLimit(userID); err != nil {
+		return nil, err
+	}
+
+	sessionID, err := randomHex(16)
+	if err != nil {
+		s.log.Error("生成会话 ID 失败", "error", err)
+		return nil, err
+	}
+
+	browser, os := parseUserAgent(userAgent)
+	now := time.Now()
+	session := &entity.OnlineUser{
+		UserID:       userID,
+		Username:     userName,
+		Nickname:     nickname,
+		SessionID:    sessionID,
+		LoginType:    loginType,
+		LoginIP:      ip,
+		LoginPlace:   s.locator.Place(ip),
+		LoginTime:    now,
+		LastActiveAt: now,
+		Device:       fmt.Sprintf("%s / %s", browser, os),
+		Browser:      browser,
+		OS:           os,
+		UserAgent:    userAgent,
+		ExpiresAt:    now.Add(ttl),
+	}
+
+	if err := s.repo.Add(session); err != nil {
+		s.log.Error("创建会话失败", "error", err, "user_id", userID)
+		return nil, err
+	}
+
+	s.log.Info("创建会话成功", "user_id", userID, "session_id", sessionID, "login_type", loginType)
+	return session, nil
+}
+
+// enforceSessionLimit 检查同时在线会话数上限
+func (s *OnlineUserService) enforceSessionLimit(userID int64) error {
+	maxSessions := s.config.Session.MaxConcurrent
+	if maxSessions <= 0 {
+		return nil
+	}
+
+	sessions, err := s.repo.GetByUserID(userID)
+	if err != nil {
+		s.log.Error("获取用户会话失败", "error", err, "user_id", userID)
+		return err
+	}
+	if len(sessions) < maxSessions {
+		return nil
+	}
+
+	if s.config.Session.Policy == config.SessionPolicyReject {
+		s.log.Warn("会话数已达上限，拒绝登录", "user_id", userID, "sessions", len(sessions), "max", maxSessions)
+		return fmt.Errorf("同时在线设备数已达上限（%d 个），请先在其他设备退出登录", maxSessions)
+	}
+
+	// 默认踢出最早登录的会话，为本次登录腾出位置
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].LoginTime.Before(sessions[j].LoginTime)
+	})
+	for _, session := range sessions[:len(sessions)-maxSessions+1] {
+		if err := s.repo.RemoveBySessionID(session.SessionID); err != nil {
+			s.log.Error("踢出最早登录的会话失败", "error", err, "user_id", userID, "session_id", session.SessionID)
+			return err
+		}
+		s.log.Info("会话数已达上限，踢出最早登录的会话", "user_id", userID, "session_id", session.SessionID, "device", session.Device)
+		s.recordSessionAudit(session, entity.SessionAuditActionKickOldest, "同时在线设备数已达上限", 0)
+		s.notifySessionsRevoked([]*entity.OnlineUser{session}, "您的账号已在其他设备登录", "")
+	}
+	return nil
+}
+
+// TouchSession 校验会话并刷新最后活跃时间
+// 会话已过期或已被下线时返回 false，认证中间件据此拒绝请求
+func (s *OnlineUserService) TouchSession(sessionID string) (bool, error) {
+	session, err := s.repo.GetBySessionID(sessionID)
+	if err != nil {
+		s.log.Error("获取会话失败", "error", err, "session_id", sessionID)
+		return false, err
+	}
+	if session == nil {
+		return false, nil
+	}
+
+	if time.Since(session.LastActiveAt) >= sessionTouchInterval {
+		if err := s.repo.UpdateActiveTime(sessionID); err != nil {
+			// 活跃时间刷新失败不影响本次请求
+			s.log.Warn("刷新会话活跃时间失败", "error", err, "session_id", sessionID)
+		}
+	}
+	return true, nil
+}
+
+// RenewSession 刷新令牌后延长会话有效期
+func (s *OnlineUserService) RenewSession(sessionID string, expiresAt time.Time) error {
+	session, err := s.repo.GetBySessionID(sessionID)
+	if err != nil {
+		s.log.Error("获取会话失败", "error", err, "session_id", sessionID)
+		return err
+	}
+	if session == nil {
+		return errors.New("会话不存在或已过期")
+	}
+
+	session.UpdateActiveTime()
+	if expiresAt.After(session.ExpiresAt) {
+		session.ExpiresAt = expiresAt
+	}
+	if err := s.repo.Add(session); err != nil {
+		s.log.Error("延长会话有效期失败", "error", err, "session_id", sessionID)
+		return err
+	}
+	return nil
+}
+
+// GetUserSessions 获取用户的全部会话（登录设备），最近登录的在前
+func (s *OnlineUserService) GetUserSessions(userID int64) ([]*entity.OnlineUser, error) {
+	sessions, err := s.repo.GetByUserID(userID)
+	if err != nil {
+		s.log.Error("获取用户会话失败", "error", err, "user_id", userID)
+		return nil, err
+	}
+
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].LoginTime.After(sessions[j].LoginTime)
+	})
+	return sessions, nil
+}
+
+// RevokeUserSession 用户下线自己的某个登录设备
+func (s *OnlineUserService) RevokeUserSession(userID int64, sessionID string) error {
+	session, err := s.repo.GetBySessionID(sessionID)
+	if err != nil {
+		s.log.Error("获取会话失败", "error", err, "session_id", sessionID)
+		return err
 	}
+	// 不属于当前用户的会话按不存在处理，避免泄露其他用户的会话
+	if session == nil || session.UserID != userID {
+		return errors.New("会话不存在或已过期")
+	}
+
+	if err := s.repo.RemoveBySessionID(sessionID); err != nil {
+		s.log.Error("下线登录设备失败", "error", err, "user_id", userID, "session_id", sessionID)
+		return err
+	}
+
+	s.recordSessionAudit(session, entity.SessionAuditActionRevoke, "", userID)
+	s.notifySessionsRevoked([]*entity.OnlineUser{session}, "该设备已被下线", "")
+
+	s.log.Info("下线登录设备成功", "user_id", userID, "session_id", sessionID, "device", session.Device)
+	return nil
 }
 
 // AddOnlineUser 添加用户到在线列表
@@ -55,12 +248,20 @@ func (s *OnlineUserService) AddOnlineUser(
 	return nil
 }
 
-// UpdateUserActive 更新用户活跃状态
+// UpdateUserActive 更新用户所有会话的活跃状态
 func (s *OnlineUserService) UpdateUserActive(userID int64) error {
-	if err := s.repo.UpdateActiveTime(userID); err != nil {
+	sessions, err := s.repo.GetByUserID(userID)
+	if err != nil {
 		s.log.Error("更新用户活跃状态失败", "error", err, "user_id", userID)
 		return err
 	}
+
+	for _, session := range sessions {
+		if err := s.repo.UpdateActiveTime(session.SessionID); err != nil {
+			s.log.Error("更新用户活跃状态失败", "error", err, "user_id", userID, "session_id", session.SessionID)
+			return err
+		}
+	}
 	return nil
 }
 
@@ -87,6 +288,7 @@ func (s *OnlineUserService) RemoveOnlineUserBySessionID(sessionID string) error
 }
 
 // GetOnlineUserList 获取在线用户列表
+// 每个会话一条记录，同一用户多个设备登录时有多条，按最后活跃时间倒序
 func (s *OnlineUserService) GetOnlineUserList() ([]*entity.OnlineUser, error) {
 	users, err := s.repo.GetAll()
 	if err != nil {
@@ -94,23 +296,26 @@ func (s *OnlineUserService) GetOnlineUserList() ([]*entity.OnlineUser, error) {
 		return nil, err
 	}
 
+	sort.Slice(users, func(i, j int) bool {
+		return users[i].LastActiveAt.After(users[j].LastActiveAt)
+	})
+
 	s.log.Info("获取在线用户列表成功", "count", len(users))
 	return users, nil
 }
 
-// GetOnlineUserByID 获取在线用户详情
-func (s *OnlineUserService) GetOnlineUserByID(userID int64) (*entity.OnlineUser, error) {
-	user, err := s.repo.GetByUserID(userID)
+// GetOnlineUserByID 获取在线用户详情，返回该用户的全部会话
+func (s *OnlineUserService) GetOnlineUserByID(userID int64) ([]*entity.OnlineUser, error) {
+	sessions, err := s.GetUserSessions(userID)
 	if err != nil {
-		s.log.Error("获取在线用户详情失败", "error", err, "user_id", userID)
 		return nil, err
 	}
 
-	if user == nil {
+	if len(sessions) == 0 {
 		return nil, errors.New("用户不在线")
 	}
 
-	return user, nil
+	return sessions, nil
 }
 
 // GetOnlineUserBySessionID 根据会话 ID 获取在线用户
@@ -129,39 +334,9 @@ func (s *OnlineUserService) GetOnlineUserBySessionID(sessionID string) (*entity.
 }
 
 // ForceOffline 强制用户下线
-// 该方法会移除用户的在线状态，但不会使 JWT token 失效
-// 如果需要完全禁止用户访问，需要配合 JWT 黑名单机制
-func (s *OnlineUserService) ForceOffline(userID int64, operatorID string) error {
-	// 检查用户是否在线
-	online, err := s.repo.Exists(userID)
-	if err != nil {
-		s.log.Error("检查用户在线状态失败", "error", err, "user_id", userID)
-		return err
-	}
-
-	if !online {
-		return errors.New("用户不在线")
-	}
-
-	// 获取用户信息用于日志
-	user, err := s.repo.GetByUserID(userID)
-	if err != nil {
-		s.log.Error("获取用户信息失败", "error", err, "user_id", userID)
-		return err
-	}
-
-	// 移除在线用户
-	if err := s.repo.Remove(userID); err != nil {
-		s.log.Error("强制用户下线失败", "error", err, "user_id", userID)
-		return err
-	}
-
-	s.log.Info("强制用户下线成功",
-		"user_id", userID,
-		"userName", user.Username,
-		"operator_id", operatorID,
-	)
-	return nil
+// 移除用户的全部会话，携带会话 ID 的访问令牌和刷新令牌随之失效
+func (s *OnlineUserService) ForceOffline(userID int64, operatorID int64) error {
+	return s.KickoutUser(userID, "", operatorID)
 }
 
 // GetOnlineCount 获取在线用户数量
@@ -181,39 +356,9 @@ func (s *OnlineUserService) IsOnline(userID int64) (bool, error) {
 }
 
 // KickoutUser 踢出用户（用于管理员强制下线）
-// 与 ForceOffline 类似，但会记录更详细的日志
-func (s *OnlineUserService) KickoutUser(userID int64, reason string, operatorID string) error {
-	// 检查用户是否在线
-	online, err := s.repo.Exists(userID)
-	if err != nil {
-		s.log.Error("检查用户在线状态失败", "error", err, "user_id", userID)
-		return err
-	}
-
-	if !online {
-		return errors.New("用户不在线")
-	}
-
-	// 获取用户信息用于日志
-	user, err := s.repo.GetByUserID(userID)
-	if err != nil {
-		s.log.Error("获取用户信息失败", "error", err, "user_id", userID)
-		return err
-	}
-
-	// 移除在线用户
-	if err := s.repo.Remove(userID); err != nil {
-		s.log.Error("踢出用户失败", "error", err, "user_id", userID)
-		return err
-	}
-
-	s.log.Info("踢出用户成功",
-		"user_id", userID,
-		"userName", user.Username,
-		"reason", reason,
-		"operator_id", operatorID,
-	)
-	return nil
+// 移除用户的全部会话并记录审计，同时推送下线事件通知客户端
+func (s *OnlineUserService) KickoutUser(userID int64, reason string, operatorID int64) error {
+	return s.kickout([]int64{userID}, reason, operatorID)
 }
 
 // GetOnlineUsersByCondition 根据条件获取在线用户（预留方法）
@@ -245,30 +390,148 @@ func (s *OnlineUserService) RefreshUserExpiration(userID int64) error {
 }
 
 // BatchKickoutUsers 批量踢出用户
-func (s *OnlineUserService) BatchKickoutUsers(userIDs []int64, reason string, operatorID string) error {
-	successCount := 0
-	failCount := 0
+// 全部成功或全部失败：任一用户不在线、会话移除失败或审计记录写入失败时，已移除的会话会被恢复
+func (s *OnlineUserService) BatchKickoutUsers(userIDs []int64, reason string, operatorID int64) error {
+	if len(userIDs) == 0 {
+		return errors.New("批量下线用户 ID 不能为空")
+	}
+	return s.kickout(userIDs, reason, operatorID)
+}
+
+// kickout 强制下线一批用户
+// 下线操作串行执行，避免并发踢出同一用户时重复记录审计
+func (s *OnlineUserService) kickout(userIDs []int64, reason string, operatorID int64) error {
+	s.kickoutMu.Lock()
+	defer s.kickoutMu.Unlock()
 
+	// 预检查：所有用户都在线才执行
+	seen := make(map[int64]bool, len(userIDs))
+	var sessions []*entity.OnlineUser
 	for _, userID := range userIDs {
-		if err := s.KickoutUser(userID, reason, operatorID); err != nil {
-			s.log.Error("批量踢出用户失败", "user_id", userID, "error", err)
-			failCount++
-		} else {
-			successCount++
+		if seen[userID] {
+			continue
+		}
+		seen[userID] = true
+
+		userSessions, err := s.repo.GetByUserID(userID)
+		if err != nil {
+			s.log.Error("获取用户会话失败", "error", err, "user_id", userID)
+			return err
 		}
+		if len(userSessions) == 0 {
+			if len(userIDs) == 1 {
+				return errors.New("用户不在线")
+			}
+			return fmt.Errorf("用户 %d 不在线，未执行批量下线", userID)
+		}
+		sessions = append(sessions, userSessions...)
+	}
+
+	// 逐个移除会话，失败时恢复已移除的会话
+	for i, session := range sessions {
+		if err := s.repo.RemoveBySessionID(session.SessionID); err != nil {
+			s.log.Error("移除会话失败", "error", err, "user_id", session.UserID, "session_id", session.SessionID)
+			s.restoreSessions(sessions[:i])
+			return err
+		}
+	}
+
+	now := time.Now()
+	logs := make([]*entity.SessionAuditLog, len(sessions))
+	for i, session := range sessions {
+		logs[i] = newSessionAuditLog(session, entity.SessionAuditActionKickout, reason, operatorID, now)
 	}
+	if err := s.auditRepo.CreateBatch(logs); err != nil {
+		s.log.Error("记录强制下线审计失败", "error", err, "operator_id", operatorID)
+		s.restoreSessions(sessions)
+		return err
+	}
+
+	s.notifySessionsRevoked(sessions, "您已被管理员强制下线", reason)
 
-	s.log.Info("批量踢出用户完成",
-		"total", len(userIDs),
-		"success", successCount,
-		"fail", failCount,
+	s.log.Info("强制下线成功",
+		"users", len(seen),
+		"sessions", len(sessions),
 		"reason", reason,
 		"operator_id", operatorID,
 	)
+	return nil
+}
 
-	if failCount > 0 {
-		return fmt.Errorf("批量踢出用户完成，成功 %d 人，失败 %d 人", successCount, failCount)
+// restoreSessions 恢复已移除的会话，用于批量下线失败时回滚
+func (s *OnlineUserService) restoreSessions(sessions []*entity.OnlineUser) {
+	for _, session := range sessions {
+		if err := s.repo.Add(session); err != nil {
+			s.log.Error("恢复会话失败", "error", err, "user_id", session.UserID, "session_id", session.SessionID)
+		}
 	}
+}
 
-	return nil
+// recordSessionAudit 记录单个会话的终止审计，失败只记录日志，不影响会话终止
+func (s *OnlineUserService) recordSessionAudit(session *entity.OnlineUser, action, reason string, operatorID int64) {
+	log := newSessionAuditLog(session, action, reason, operatorID, time.Now())
+	if err := s.auditRepo.CreateBatch([]*entity.SessionAuditLog{log}); err != nil {
+		s.log.Error("记录会话终止审计失败", "error", err, "session_id", session.SessionID, "action", action)
+	}
+}
+
+// notifySessionsRevoked 向被终止会话的客户端推送下线事件
+func (s *OnlineUserService) notifySessionsRevoked(sessions []*entity.OnlineUser, message, reason string) {
+	for _, session := range sessions {
+		event := push.Event{
+			Type:      push.EventSessionRevoked,
+			SessionID: session.SessionID,
+			Message:   message,
+			Reason:    reason,
+		}
+		if err := s.hub.Publish(event); err != nil {
+			// 推送失败不影响下线结果，客户端下次请求时会收到 401
+			s.log.Warn("推送下线事件失败", "error", err, "session_id", session.SessionID)
+		}
+	}
+}
+
+// NotifyUser 向用户全部在线会话推送事件，推送失败只记录日志
+func (s *OnlineUserService) NotifyUser(userID int64, eventType, message, reason string) {
+	sessions, err := s.repo.GetByUserID(userID)
+	if err != nil {
+		s.log.Warn("获取用户会话失败", "error", err, "user_id", userID)
+		return
+	}
+	for _, session := range sessions {
+		event := push.Event{
+			Type:      eventType,
+			SessionID: session.SessionID,
+			Message:   message,
+			Reason:    reason,
+		}
+		if err := s.hub.Publish(event); err != nil {
+			s.log.Warn("推送会话事件失败", "error", err, "session_id", session.SessionID, "type", eventType)
+		}
+	}
+}
+
+// newSessionAuditLog 根据会话构建审计记录
+func newSessionAuditLog(session *entity.OnlineUser, action, reason string, operatorID int64, at time.Time) *entity.SessionAuditLog {
+	return &entity.SessionAuditLog{
+		UserID:     session.UserID,
+		Username:   session.Username,
+		SessionID:  session.SessionID,
+		LoginIP:    session.LoginIP,
+		Device:     session.Device,
+		Action:     action,
+		Reason:     reason,
+		OperatorID: operatorID,
+		CreatedAt:  at,
+	}
+}
+
+// GetSessionAuditLogs 分页获取会话终止审计记录
+func (s *OnlineUserService) GetSessionAuditLogs(page, pageSize int, filters map[string]interface{}) ([]*entity.SessionAuditLog, int64, error) {
+	logs, total, err := s.auditRepo.List(page, pageSize, filters)
+	if err != nil {
+		s.log.Error("获取会话审计记录失败", "error", err)
+		return nil, 0, err
+	}
+	return logs, total, nil
 }
@@ -0,0 +1,272 @@
+package service
+
+import (
+	"bufio"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/ix-pay/ixpay-pro/internal/config"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/entity"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/repo"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/observability/logger"
+	"github.com/ix-pay/ixpay-pro/internal/utils/encryption"
+)
+
+// 强制修改密码的原因
+const (
+	PasswordChangeReasonReset   = "reset"   // 管理员重置密码后首次登录
+	PasswordChangeReasonExpired = "expired" // 密码已过期
+)
+
+// 密码策略默认值（配置项为零值时使用）
+const (
+	defaultPasswordMinLength = 8
+	defaultPasswordMaxLength = 64
+)
+
+// 生成临时密码使用的字符集
+const (
+	passwordUpperChars   = "ABCDEFGHJKLMNPQRSTUVWXYZ"
+	passwordLowerChars   = "abcdefghijkmnpqrstuvwxyz"
+	passwordDigitChars   = "23456789"
+	passwordSpecialChars = "!@#$%^&*"
+)
+
+// builtinCommonPasswords 内置常用弱密码列表（小写）
+var builtinCommonPasswords = []string{
+	"123456", "12345678", "123456789", "1234567890", "111111", "000000",
+	"password", "password1", "password123", "passw0rd", "p@ssw0rd", "p@ssword",
+	"qwerty", "qwerty123", "qwertyuiop", "abc123", "abc12345", "admin", "admin123",
+	"admin@123", "root", "root123", "welcome", "welcome1", "iloveyou", "letmein",
+	"1qaz2wsx", "1q2w3e4r", "zaq12wsx", "a123456", "aa123456", "test123", "changeme",
+}
+
+// PasswordPolicyService 密码策略服务
+// 统一负责密码复杂度校验、历史密码复用检查、密码过期与强制修改判断
+// Register / ChangePassword / ResetPassword / AddUser 均通过本服务校验密码
+type PasswordPolicyService struct {
+	policy          config.PasswordPolicyConfig
+	historyRepo     repo.PasswordHistoryRepository
+	commonPasswords map[string]struct{}
+	log             logger.Logger
+}
+
+// NewPasswordPolicyService 创建密码策略服务实例
+func NewPasswordPolicyService(cfg *config.Config, historyRepo repo.PasswordHistoryRepository, log logger.Logger) *PasswordPolicyService {
+	var policy config.PasswordPolicyConfig
+	if cfg != nil {
+		policy = cfg.PasswordPolicy
+	}
+	if policy.MinLength <= 0 {
+		policy.MinLength = defaultPasswordMinLength
+	}
+	if policy.MaxLength <= 0 || policy.MaxLength < policy.MinLength {
+		policy.MaxLength = defaultPasswordMaxLength
+	}
+
+	s := &PasswordPolicyService{
+		policy:          policy,
+		historyRepo:     historyRepo,
+		commonPasswords: make(map[string]struct{}, len(builtinCommonPasswords)),
+		log:             log,
+	}
+	for _, p := range builtinCommonPasswords {
+		s.commonPasswords[p] = struct{}{}
+	}
+	if policy.CommonPasswordFile != "" {
+		if err := s.loadCommonPasswords(policy.CommonPasswordFile); err != nil {
+			log.Warn("加载常用弱密码字典失败，仅使用内置列表", "file", policy.CommonPasswordFile, "error", err)
+		}
+	}
+	return s
+}
+
+// loadCommonPasswords 从文件加载常用弱密码，每行一个，忽略空行和 # 开头的注释
+func (s *PasswordPolicyService) loadCommonPasswords(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		s.commonPasswords[strings.ToLower(line)] = struct{}{}
+	}
+	return scanner.Err()
+}
+
+// Validate 校验密码复杂度
+// 检查长度、字符类别、是否包含用户名以及是否为常用弱密码
+func (s *PasswordPolicyService) Validate(password, username string) error {
+	length := len([]rune(password))
+	if length < s.policy.MinLength {
+		return fmt.Errorf("密码长度不能少于%d位", s.policy.MinLength)
+	}
+	if length > s.policy.MaxLength {
+		return fmt.Errorf("密码长度不能超过%d位", s.policy.MaxLength)
+	}
+
+	var hasUpper, hasLower, hasDigit, hasSpecial bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case unicode.IsSpace(r):
+			return errors.New("密码不能包含空白字符")
+		default:
+			hasSpecial = true
+		}
+	}
+	if s.policy.RequireUpper && !hasUpper {
+		return errors.New("密码必须包含大写字母")
+	}
+	if s.policy.RequireLower && !hasLower {
+		return errors.New("密码必须包含小写字母")
+	}
+	if s.policy.RequireDigit && !hasDigit {
+		return errors.New("密码必须包含数字")
+	}
+	if s.policy.RequireSpecial && !hasSpecial {
+		return errors.New("密码必须包含特殊字符")
+	}
+
+	lower := strings.ToLower(password)
+	if s.policy.DisallowUsername && username != "" && strings.Contains(lower, strings.ToLower(username)) {
+		return errors.New("密码不能包含用户名")
+	}
+	if _, ok := s.commonPasswords[lower]; ok {
+		return errors.New("密码过于简单，请勿使用常用密码")
+	}
+	return nil
+}
+
+// CheckHistory 检查新密码是否与最近 N 次使用过的密码重复
+func (s *PasswordPolicyService) CheckHistory(userID int64, password string) error {
+	if s.policy.HistoryCount <= 0 || s.historyRepo == nil || userID == 0 {
+		return nil
+	}
+
+	histories, err := s.historyRepo.GetRecentByUserID(userID, s.policy.HistoryCount)
+	if err != nil {
+		s.log.Error("获取密码历史失败", "userID", userID, "error", err)
+		return errors.New("校验密码历史失败")
+	}
+	for _, h := range histories {
+		if encryption.VerifyPassword(h.PasswordHash, password) == nil {
+			return fmt.Errorf("新密码不能与最近%d次使用过的密码相同", s.policy.HistoryCount)
+		}
+	}
+	return nil
+}
+
+// RecordPassword 记录密码历史，并清理超出保留数量的旧记录
+func (s *PasswordPolicyService) RecordPassword(userID int64, passwordHash string, operatorID int64) {
+	if s.policy.HistoryCount <= 0 || s.historyRepo == nil || userID == 0 {
+		return
+	}
+
+	history := &entity.PasswordHistory{
+		UserID:       userID,
+		PasswordHash: passwordHash,
+		CreatedBy:    operatorID,
+	}
+	if err := s.historyRepo.Create(history); err != nil {
+		s.log.Error("记录密码历史失败", "userID", userID, "error", err)
+		return
+	}
+	if err := s.historyRepo.PruneByUserID(userID, s.policy.HistoryCount); err != nil {
+		s.log.Warn("清理密码历史失败", "userID", userID, "error", err)
+	}
+}
+
+// ForceChangeOnReset 管理员重置密码后是否要求用户首次登录修改密码
+func (s *PasswordPolicyService) ForceChangeOnReset() bool {
+	return s.policy.ForceChangeOnReset
+}
+
+// ChangeRequired 判断用户是否需要修改密码
+// 返回是否需要修改以及原因（reset / expired）
+func (s *PasswordPolicyService) ChangeRequired(user *entity.User) (bool, string) {
+	if user == nil {
+		return false, ""
+	}
+	if user.MustChangePassword {
+		return true, PasswordChangeReasonReset
+	}
+	if s.policy.ExpireDays > 0 {
+		changedAt := user.PasswordChangedAt
+		if changedAt.IsZero() {
+			// 迁移已为历史用户补齐修改时间，仍未记录时以创建时间作为起点
+			changedAt = user.CreatedAt
+		}
+		if !changedAt.IsZero() && time.Since(changedAt) > time.Duration(s.policy.ExpireDays)*24*time.Hour {
+			return true, PasswordChangeReasonExpired
+		}
+	}
+	return false, ""
+}
+
+// GenerateCompliantPassword 生成满足当前策略的随机临时密码
+func (s *PasswordPolicyService) GenerateCompliantPassword() (string, error) {
+	length := s.policy.MinLength
+	if length < 12 {
+		length = 12
+	}
+	if length > s.policy.MaxLength {
+		length = s.policy.MaxLength
+	}
+
+	// 每类字符至少一个，其余从全部字符集中随机
+	classes := []string{passwordUpperChars, passwordLowerChars, passwordDigitChars, passwordSpecialChars}
+	all := strings.Join(classes, "")
+	buf := make([]byte, 0, length)
+	for _, chars := range classes {
+		c, err := randomChar(chars)
+		if err != nil {
+			return "", err
+		}
+		buf = append(buf, c)
+	}
+	for len(buf) < length {
+		c, err := randomChar(all)
+		if err != nil {
+			return "", err
+		}
+		buf = append(buf, c)
+	}
+
+	// 打乱顺序，避免固定的字符类别位置
+	for i := len(buf) - 1; i > 0; i-- {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(i+1)))
+		if err != nil {
+			return "", err
+		}
+		j := int(n.Int64())
+		buf[i], buf[j] = buf[j], buf[i]
+	}
+	return string(buf), nil
+}
+
+// randomChar 从字符集中随机取一个字符
+func randomChar(chars string) (byte, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(int64(len(chars))))
+	if err != nil {
+		return 0, err
+	}
+	return chars[n.Int64()], nil
+}
@@ -0,0 +1,345 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ix-pay/ixpay-pro/internal/config"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/entity"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/repo"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/observability/logger"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/persistence/cache"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/security/captcha"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/transport/notify"
+)
+
+// 找回密码相关缓存键
+const (
+	passwordResetCodeKey     = "pwd_reset:code:%d"     // 验证码记录（哈希）
+	passwordResetAttemptKey  = "pwd_reset:attempts:%d" // 验证码校验次数
+	passwordResetIntervalKey = "pwd_reset:interval:%d" // 重新发送冷却
+	passwordResetDailyKey    = "pwd_reset:daily:%d:%s" // 账号每日发送次数
+	passwordResetIPKey       = "pwd_reset:ip:%s"       // IP 每小时请求次数
+)
+
+// 找回密码默认配置（配置项为零值时使用）
+const (
+	defaultResetCodeLength     = 6
+	defaultResetCodeTTL        = 600
+	defaultResetMaxAttempts    = 5
+	defaultResetResendInterval = 60
+	defaultResetDailyLimit     = 10
+	defaultResetIPHourlyLimit  = 20
+)
+
+// passwordResetCode 缓存中的验证码记录，只保存哈希值
+type passwordResetCode struct {
+	CodeHash string `json:"codeHash"`
+	Channel  string `json:"channel"`
+	Target   string `json:"target"`
+}
+
+// PasswordResetService 自助找回密码服务
+// 用户通过用户名、邮箱或手机号申请验证码，验证码以哈希形式存入 Redis，
+// 限时、一次性有效，并对账号和 IP 做发送频率限制；校验通过后按密码策略设置新密码
+// 发送验证码前按找回密码场景策略校验图形验证码，防止批量触发短信和邮件
+type PasswordResetService struct {
+	userRepo    repo.UserRepository
+	userService *UserService
+	cache       cache.Cache
+	captcha     *captcha.Captcha
+	senders     *notify.Senders
+	cfg         config.PasswordResetConfig
+	secret      string
+	log         logger.Logger
+}
+
+// NewPasswordResetService 创建找回密码服务实例
+func NewPasswordResetService(userRepo repo.UserRepository, userService *UserService, cache cache.Cache, captcha *captcha.Captcha, senders *notify.Senders, cfg *config.Config, log logger.Logger) *PasswordResetService {
+	resetCfg := cfg.PasswordReset
+	if resetCfg.CodeLength <= 0 {
+		resetCfg.CodeLength = defaultResetCodeLength
+	}
+	if resetCfg.CodeTTL <= 0 {
+		resetCfg.CodeTTL = defaultResetCodeTTL
+	}
+	if resetCfg.MaxAttempts <= 0 {
+		resetCfg.MaxAttempts = defaultResetMaxAttempts
+	}
+	if resetCfg.ResendInterval <= 0 {
+		resetCfg.ResendInterval = defaultResetResendInterval
+	}
+	if resetCfg.DailyLimit <= 0 {
+		resetCfg.DailyLimit = defaultResetDailyLimit
+	}
+	if resetCfg.IPHourlyLimit <= 0 {
+		resetCfg.IPHourlyLimit = defaultResetIPHourlyLimit
+	}
+
+	return &PasswordResetService{
+		userRepo:    userRepo,
+		userService: userService,
+		cache:       cache,
+		captcha:     captcha,
+		senders:     senders,
+		cfg:         resetCfg,
+		secret:      cfg.JWT.SecretKey,
+		log:         log,
+	}
+}
+
+// SendCode 发送找回密码验证码
+// 参数:
+// - account: 用户名、邮箱或手机号
+// - channel: 发送渠道 email / sms，为空时根据账号类型自动选择
+// - captchaId: 图形验证码 ID
+// - captchaVal: 用户输入的图形验证码值
+// - ip: 请求方 IP，用于频率限制
+// 返回:
+// - string: 脱敏后的接收方，供前端提示
+// - error: 错误信息
+func (s *PasswordResetService) SendCode(account, channel, captchaId, captchaVal, ip string) (string, error) {
+	if !s.cfg.Enabled {
+		return "", errors.New("未开启自助找回密码")
+	}
+
+	// 按找回密码场景策略校验图形验证码
+	if err := s.captcha.Verify(captcha.ScenarioPasswordReset, account, ip, captchaId, captchaVal); err != nil {
+		return "", err
+	}
+
+	// IP 维度限流，无论账号是否存在都计数，防止枚举账号
+	if ip != "" {
+		count, err := s.cache.Incr(fmt.Sprintf(passwordResetIPKey, ip), time.Hour)
+		if err != nil {
+			s.log.Error("找回密码 IP 计数失败", "ip", ip, "error", err)
+			return "", errors.New("发送验证码失败，请稍后重试")
+		}
+		if count > int64(s.cfg.IPHourlyLimit) {
+			return "", errors.New("请求过于频繁，请稍后再试")
+		}
+	}
+
+	user, matched := s.findUser(account)
+	if user == nil {
+		// 账号不存在时不暴露具体原因
+		s.log.Warn("找回密码账号不存在", "account", account, "ip", ip)
+		return "", errors.New("账号不存在或未绑定邮箱/手机号")
+	}
+	if !user.IsActive() {
+		// 与账号不存在的响应一致，避免通过提示枚举已停用的账号
+		s.log.Warn("找回密码账号未激活", "userID", user.ID, "ip", ip)
+		return "", errors.New("账号不存在或未绑定邮箱/手机号")
+	}
+
+	channel, target, err := s.resolveTarget(user, matched, channel)
+	if err != nil {
+		return "", err
+	}
+
+	// 账号维度限流：重新发送冷却 + 每日上限
+	intervalKey := fmt.Sprintf(passwordResetIntervalKey, user.ID)
+	if exists, _ := s.cache.Exists(intervalKey); exists {
+		return "", fmt.Errorf("验证码发送过于频繁，请%d秒后再试", s.cfg.ResendInterval)
+	}
+	dailyKey := fmt.Sprintf(passwordResetDailyKey, user.ID, time.Now().Format("20060102"))
+	count, err := s.cache.Incr(dailyKey, 24*time.Hour)
+	if err != nil {
+		s.log.Error("找回密码账号计数失败", "userID", user.ID, "error", err)
+		return "", errors.New("发送验证码失败，请稍后重试")
+	}
+	if count > int64(s.cfg.DailyLimit) {
+		return "", errors.New("今日验证码发送次数已达上限")
+	}
+
+	code, err := generateNumericCode(s.cfg.CodeLength)
+	if err != nil {
+		s.log.Error("生成找回密码验证码失败", "error", err)
+		return "", errors.New("发送验证码失败，请稍后重试")
+	}
+
+	record, _ := json.Marshal(&passwordResetCode{
+		CodeHash: s.hashCode(user.ID, code),
+		Channel:  channel,
+		Target:   target,
+	})
+	ttl := time.Duration(s.cfg.CodeTTL) * time.Second
+	if err := s.cache.Set(fmt.Sprintf(passwordResetCodeKey, user.ID), string(record), ttl); err != nil {
+		s.log.Error("保存找回密码验证码失败", "userID", user.ID, "error", err)
+		return "", errors.New("发送验证码失败，请稍后重试")
+	}
+	// 新验证码重新计算校验次数
+	_ = s.cache.Delete(fmt.Sprintf(passwordResetAttemptKey, user.ID))
+
+	sender, err := s.senders.Get(channel)
+	if err != nil {
+		return "", err
+	}
+	msg := &notify.Message{
+		To:      target,
+		Subject: "找回密码验证码",
+		Content: fmt.Sprintf("您正在找回账号 %s 的密码，验证码为 %s，%d 分钟内有效。如非本人操作请忽略。", user.Username, code, s.cfg.CodeTTL/60),
+	}
+	if err := sender.Send(msg); err != nil {
+		s.log.Error("发送找回密码验证码失败", "userID", user.ID, "channel", channel, "sender", sender.Name(), "error", err)
+		_ = s.cache.Delete(fmt.Sprintf(passwordResetCodeKey, user.ID))
+		return "", errors.New("发送验证码失败，请稍后重试")
+	}
+
+	_ = s.cache.Set(intervalKey, "1", time.Duration(s.cfg.ResendInterval)*time.Second)
+	s.log.Info("找回密码验证码已发送", "userID", user.ID, "channel", channel, "ip", ip)
+	return maskTarget(channel, target), nil
+}
+
+// ResetPassword 校验验证码并设置新密码
+// 验证码一次性有效，校验失败次数超过上限后作废
+// 验证码通过比较并删除原子消费，并发提交同一验证码时只有一个请求能设置密码
+func (s *PasswordResetService) ResetPassword(account, code, newPassword string) error {
+	if !s.cfg.Enabled {
+		return errors.New("未开启自助找回密码")
+	}
+
+	user, _ := s.findUser(account)
+	if user == nil {
+		return errors.New("验证码错误或已过期")
+	}
+
+	codeKey := fmt.Sprintf(passwordResetCodeKey, user.ID)
+	raw, err := s.cache.Get(codeKey)
+	if err != nil || raw == "" {
+		return errors.New("验证码错误或已过期")
+	}
+	var record passwordResetCode
+	if err := json.Unmarshal([]byte(raw), &record); err != nil {
+		_ = s.cache.Delete(codeKey)
+		return errors.New("验证码错误或已过期")
+	}
+
+	attemptKey := fmt.Sprintf(passwordResetAttemptKey, user.ID)
+	attempts, err := s.cache.Incr(attemptKey, time.Duration(s.cfg.CodeTTL)*time.Second)
+	if err != nil {
+		s.log.Error("找回密码校验计数失败", "userID", user.ID, "error", err)
+		return errors.New("验证失败，请稍后重试")
+	}
+	if attempts > int64(s.cfg.MaxAttempts) {
+		_ = s.cache.Delete(codeKey)
+		return errors.New("验证码错误次数过多，请重新获取")
+	}
+
+	expected := s.hashCode(user.ID, strings.TrimSpace(code))
+	if subtle.ConstantTimeCompare([]byte(expected), []byte(record.CodeHash)) != 1 {
+		return errors.New("验证码错误或已过期")
+	}
+
+	// 密码不符合策略时保留验证码，允许用户修改密码后重试
+	if err := s.userService.CheckNewPassword(user.ID, newPassword); err != nil {
+		return err
+	}
+
+	// 验证码一次性使用，只有删除成功的请求可以继续设置密码
+	consumed, err := s.cache.CompareAndDelete(codeKey, raw)
+	if err != nil {
+		s.log.Error("消费找回密码验证码失败", "userID", user.ID, "error", err)
+		return errors.New("验证失败，请稍后重试")
+	}
+	if !consumed {
+		return errors.New("验证码错误或已过期")
+	}
+	_ = s.cache.Delete(attemptKey)
+
+	return s.userService.ResetPasswordByVerification(user.ID, newPassword)
+}
+
+// findUser 根据用户名、邮箱或手机号查找用户
+// 返回用户及匹配到的账号类型（username / email / phone）
+func (s *PasswordResetService) findUser(account string) (*entity.User, string) {
+	account = strings.TrimSpace(account)
+	if account == "" {
+		return nil, ""
+	}
+	if user, err := s.userRepo.GetByUsername(account); err == nil {
+		return user, "username"
+	}
+	if strings.Contains(account, "@") {
+		if user, err := s.userRepo.GetByEmail(account); err == nil {
+			return user, "email"
+		}
+	}
+	if user, err := s.userRepo.GetByPhone(account); err == nil {
+		return user, "phone"
+	}
+	return nil, ""
+}
+
+// resolveTarget 确定发送渠道和接收方
+func (s *PasswordResetService) resolveTarget(user *entity.User, matched, channel string) (string, string, error) {
+	if channel == "" {
+		switch {
+		case matched == "phone":
+			channel = notify.ChannelSMS
+		case matched == "email" || user.Email != "":
+			channel = notify.ChannelEmail
+		default:
+			channel = notify.ChannelSMS
+		}
+	}
+
+	switch channel {
+	case notify.ChannelEmail:
+		if user.Email == "" {
+			return "", "", errors.New("账号不存在或未绑定邮箱/手机号")
+		}
+		return channel, user.Email, nil
+	case notify.ChannelSMS:
+		if user.Phone == "" {
+			return "", "", errors.New("账号不存在或未绑定邮箱/手机号")
+		}
+		return channel, user.Phone, nil
+	default:
+		return "", "", errors.New("不支持的发送渠道")
+	}
+}
+
+// hashCode 计算验证码哈希，绑定用户 ID 防止跨账号复用
+func (s *PasswordResetService) hashCode(userID int64, code string) string {
+	mac := hmac.New(sha256.New, []byte(s.secret))
+	mac.Write([]byte(fmt.Sprintf("%d:%s", userID, code)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// generateNumericCode 生成指定长度的数字验证码
+func generateNumericCode(length int) (string, error) {
+	var b strings.Builder
+	for i := 0; i < length; i++ {
+		n, err := rand.Int(rand.Reader, big.NewInt(10))
+		if err != nil {
+			return "", err
+		}
+		b.WriteByte(byte('0' + n.Int64()))
+	}
+	return b.String(), nil
+}
+
+// maskTarget 对接收方脱敏，例如 a***@example.com、138****5678
+func maskTarget(channel, target string) string {
+	if channel == notify.ChannelEmail {
+		at := strings.Index(target, "@")
+		if at <= 1 {
+			return "***" + target[max(at, 0):]
+		}
+		return target[:1] + "***" + target[at:]
+	}
+	if len(target) >= 7 {
+		return target[:3] + "****" + target[len(target)-4:]
+	}
+	return "****"
+}
@@ -0,0 +1,218 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/entity"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/repo"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/observability/logger"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/security/abac"
+)
+
+// 权限审计日志中的操作类型和目标类型
+const (
+	permissionChangeActionApprove = "permission_change_approve"
+	permissionChangeTargetType    = "role"
+)
+
+// 审批和拒绝接口的路由模板，审批人须在某个已分配的角色下有对应接口的权限
+const (
+	permissionChangeApproveRoute = "/api/admin/permission-changes/:id/approve"
+	permissionChangeRejectRoute  = "/api/admin/permission-changes/:id/reject"
+)
+
+// PermissionChangeService 权限变更审批服务
+// 需要审批的角色发生权限变更时由 RoleService 生成申请，影响这些角色的策略包导入由 PolicyBundleService 生成申请，
+// 由申请人以外、有审批权限的审批人通过或拒绝
+// 只有审批通过才会执行变更并写入 sys_permission_logs
+type PermissionChangeService struct {
+	repo          repo.PermissionChangeRequestRepository
+	roleService   *RoleService
+	policyBundles *PolicyBundleService
+	userRepo      repo.UserRepository
+	roleRepo      repo.RoleRepository
+	decisions     *PermissionDecisionService
+	auditRepo     repo.PermissionAuditLogRepository
+	log           logger.Logger
+}
+
+// NewPermissionChangeService 创建权限变更审批服务实例
+func NewPermissionChangeService(
+	repo repo.PermissionChangeRequestRepository,
+	roleService *RoleService,
+	policyBundles *PolicyBundleService,
+	userRepo repo.UserRepository,
+	roleRepo repo.RoleRepository,
+	decisions *PermissionDecisionService,
+	auditRepo repo.PermissionAuditLogRepository,
+	log logger.Logger,
+) *PermissionChangeService {
+	return &PermissionChangeService{
+		repo:          repo,
+		roleService:   roleService,
+		policyBundles: policyBundles,
+		userRepo:      userRepo,
+		roleRepo:      roleRepo,
+		decisions:     decisions,
+		auditRepo:     auditRepo,
+		log:           log,
+	}
+}
+
+// GetChangeRequestList 分页获取变更申请列表
+func (s *PermissionChangeService) GetChangeRequestList(page, pageSize int, filters map[string]interface{}) ([]*entity.PermissionChangeRequest, int64, error) {
+	return s.repo.List(page, pageSize, filters)
+}
+
+// GetChangeRequest 获取变更申请详情
+func (s *PermissionChangeService) GetChangeRequest(id int64) (*entity.PermissionChangeRequest, error) {
+	request, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, errors.New("变更申请不存在")
+	}
+	return request, nil
+}
+
+// ApproveChange 审批通过变更申请，执行变更并写入权限审计日志
+func (s *PermissionChangeService) ApproveChange(id, approverID int64, comment string) (*entity.PermissionChangeRequest, error) {
+	request, err := s.getPending(id, approverID, permissionChangeApproveRoute)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	request.Status = entity.PermissionChangeStatusApproved
+	request.ReviewedBy = approverID
+	request.ReviewedAt = &now
+	request.ReviewComment = comment
+	request.UpdatedBy = approverID
+
+	// 变更和申请状态在同一事务中写入，失败时整体回滚，申请保持待审批，可以再次审批
+	apply := s.roleService.applyPermissionChange
+	if request.ChangeType == entity.PermissionChangePolicyBundle {
+		apply = s.policyBundles.applyApprovedImport
+	}
+	if err := apply(request); err != nil {
+		s.log.Error("执行权限变更申请失败", "error", err, "request_id", id)
+		return nil, fmt.Errorf("执行权限变更失败: %w", err)
+	}
+
+	s.writeAuditLog(request)
+	s.log.Info("权限变更申请审批通过", "request_id", id, "role_id", request.RoleID, "change_type", request.ChangeType, "approver_id", approverID)
+	return request, nil
+}
+
+// RejectChange 拒绝变更申请，变更不会执行
+func (s *PermissionChangeService) RejectChange(id, approverID int64, comment string) (*entity.PermissionChangeRequest, error) {
+	request, err := s.getPending(id, approverID, permissionChangeRejectRoute)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	request.Status = entity.PermissionChangeStatusRejected
+	request.ReviewedBy = approverID
+	request.ReviewedAt = &now
+	request.ReviewComment = comment
+	request.UpdatedBy = approverID
+	if err := s.repo.Update(request); err != nil {
+		s.log.Error("更新权限变更申请状态失败", "error", err, "request_id", id)
+		return nil, err
+	}
+
+	s.log.Info("权限变更申请已拒绝", "request_id", id, "role_id", request.RoleID, "change_type", request.ChangeType, "approver_id", approverID)
+	return request, nil
+}
+
+// getPending 获取待审批的申请，审批人不能是申请人，且须有该申请的审批权限
+func (s *PermissionChangeService) getPending(id, approverID int64, route string) (*entity.PermissionChangeRequest, error) {
+	request, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, errors.New("变更申请不存在")
+	}
+	if !request.IsPending() {
+		return nil, errors.New("只能审批待审批的变更申请")
+	}
+	if request.RequestedBy == approverID {
+		return nil, errors.New("不能审批自己提交的变更申请")
+	}
+	allowed, err := s.canReview(request, approverID, route)
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		return nil, errors.New("没有审批该变更申请的权限")
+	}
+	return request, nil
+}
+
+// canReview 判断审批人是否在某个已分配的角色下有审批接口的权限
+// 目标角色作为 resource.role_id 传入，可用 ABAC 规则限制只能审批指定角色的申请
+func (s *PermissionChangeService) canReview(request *entity.PermissionChangeRequest, approverID int64, route string) (bool, error) {
+	roles, err := s.roleRepo.GetRolesByUser(approverID)
+	if err != nil {
+		s.log.Error("获取审批人角色失败", "error", err, "approver_id", approverID)
+		return false, errors.New("获取审批人角色失败")
+	}
+
+	path := strings.Replace(route, ":id", strconv.FormatInt(request.ID, 10), 1)
+	for _, role := range roles {
+		decision, err := s.decisions.Decide(DecisionRequest{
+			UserID:   approverID,
+			RoleCode: role.Code,
+			Method:   "POST",
+			Path:     path,
+			Route:    route,
+			Attributes: abac.Attributes{
+				"resource.role_id": strconv.FormatInt(request.RoleID, 10),
+			},
+		})
+		if err != nil {
+			s.log.Error("判定审批权限失败", "error", err, "approver_id", approverID, "request_id", request.ID)
+			return false, errors.New("判定审批权限失败")
+		}
+		if decision.Allowed {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// writeAuditLog 将生效的变更写入 sys_permission_logs，失败不影响审批结果
+func (s *PermissionChangeService) writeAuditLog(request *entity.PermissionChangeRequest) {
+	operatorName := ""
+	if user, err := s.userRepo.GetByID(request.ReviewedBy); err == nil {
+		operatorName = user.Username
+	}
+
+	before, _ := json.Marshal(map[string]interface{}{
+		"changeType": request.ChangeType,
+		"ids":        request.BeforeIDs,
+	})
+	after, _ := json.Marshal(map[string]interface{}{
+		"changeType":  request.ChangeType,
+		"ids":         request.AfterIDs(),
+		"added":       request.AddedIDs,
+		"removed":     request.RemovedIDs,
+		"requestId":   request.ID,
+		"requestedBy": request.RequestedBy,
+	})
+
+	record := &entity.PermissionAuditLog{
+		OperatorID:   request.ReviewedBy,
+		OperatorName: operatorName,
+		ActionType:   permissionChangeActionApprove,
+		TargetType:   permissionChangeTargetType,
+		TargetID:     request.RoleID,
+		BeforeData:   string(before),
+		AfterData:    string(after),
+	}
+	if err := s.auditRepo.Create(record); err != nil {
+		s.log.Warn("写入权限审计日志失败", "error", err, "request_id", request.ID)
+	}
+}
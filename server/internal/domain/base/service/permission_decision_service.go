@@ -0,0 +1,687 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/entity"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/repo"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/observability/logger"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/persistence/cache"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/persistence/database"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/security/abac"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/security/auth"
+	"gorm.io/gorm"
+)
+
+const (
+	permissionInvalidationTopic = "permission"        // 权限缓存失效广播主题
+	permissionVersionKey        = "perm:version"      // 权限缓存版本号，权限数据变更时自增，使所有旧缓存失效
+	permissionVersionTTL        = 30 * 24 * time.Hour // 版本号有效期，需远大于缓存有效期，避免版本号过期后重复使用
+	permissionRoleSetTTL        = time.Hour           // 角色有效权限集合在 Redis 中的有效期
+	permissionUserSetTTL        = 5 * time.Minute     // 用户权限信息在 Redis 中的有效期，用户部门等属性变更最多延迟该时长生效
+	permissionLocalTTL          = 30 * time.Second    // 进程内缓存和版本号的有效期，失效广播丢失时最多延迟该时长生效
+	permissionTxSettleDelay     = 2 * time.Second     // 事务内写入的延迟失效时间，等待事务提交后再次失效，避免缓存事务提交前读到的旧数据
+	permissionUnknownAuthType   = 1                   // API 表未登记的路由默认需要授权
+)
+
+// DecisionRequest 访问判定请求
+type DecisionRequest struct {
+	UserID         int64           // 用户 ID
+	RoleCode       string          // 当前角色编码，须是用户已分配的角色，为空或未分配时不具有任何角色的权限
+	AllRoles       bool            // 忽略 RoleCode，按用户的全部角色判定；仅供服务端内部检查使用，不能由请求参数决定
+	ServiceAccount bool            // 调用方为服务账号，不对应用户，只按 RoleCode 判定
+	Method         string          // 请求方法
+	Path           string          // 请求路径，ABAC 规则按该路径匹配
+	Route          string          // 路由模板，如 c.FullPath()，为空时按 API 表解析请求路径
+	Attributes     abac.Attributes // 调用方补充的资源和环境属性，如 env.ip、resource.owner_id
+}
+
+// PermissionDecisionService 权限判定服务
+// 统一 RBAC（角色、继承角色、权限组、用户特殊权限）和 ABAC 规则的访问判定
+// 每个角色的有效权限集合预先计算后缓存：进程内缓存 -> Redis（带版本号的键）-> 数据库
+// 角色、菜单、API、按钮、权限组、规则或用户角色分配变更时版本号自增，并通过 Redis 发布订阅清除所有实例的进程内缓存
+type PermissionDecisionService struct {
+	roleRepo            repo.RoleRepository
+	userRepo            repo.UserRepository
+	apiRepo             repo.APIRepository
+	permissionGroupRepo repo.PermissionGroupRepository
+	permissionRuleRepo  repo.PermissionRuleRepository
+	cache               cache.Cache
+	bus                 *cache.InvalidationBus
+	log                 logger.Logger
+
+	local            sync.Map     // 进程内缓存，键与 Redis 键相同，包含版本号
+	version          atomic.Int64 // 当前实例已知的版本号
+	versionCheckedAt atomic.Int64 // 最近一次读取版本号的时间（UnixNano）
+	settlePending    atomic.Bool  // 是否已安排事务写入后的延迟失效
+	programs         sync.Map     // 规则条件的编译结果，键为条件文本
+}
+
+// permissionLocalEntry 进程内缓存项
+type permissionLocalEntry struct {
+	value     interface{}
+	expiresAt time.Time
+}
+
+// permissionSnapshot API 表和启用的 ABAC 规则，只保存在进程内缓存
+type permissionSnapshot struct {
+	routes    *auth.RouteTrie
+	authTypes map[string]int // METHOD path -> 授权类型
+	rules     []*entity.PermissionRule
+}
+
+// rolePermissionSet 角色的有效权限集合，包含继承角色和权限组授予的权限
+type rolePermissionSet struct {
+	RoleID   int64    `json:"roleId"`
+	RoleCode string   `json:"roleCode"`
+	APIs     []string `json:"apis"`    // METHOD path
+	Buttons  []string `json:"buttons"` // 启用的按钮权限编码
+
+	apiSet map[string]bool
+}
+
+// userPermissionSet 用户的角色分配、特殊权限和 ABAC 用户属性
+type userPermissionSet struct {
+	UserID       int64    `json:"userId"`
+	Username     string   `json:"username"`
+	Status       int      `json:"status"`
+	DepartmentID int64    `json:"departmentId"`
+	PositionID   int64    `json:"positionId"`
+	RoleCodes    []string `json:"roleCodes"`
+	RoleIDs      []string `json:"roleIds"`
+	SpecialAPIs  []string `json:"specialApis"` // 用户特殊 API 权限，METHOD path
+
+	apiSet map[string]bool
+}
+
+// NewPermissionDecisionService 创建权限判定服务实例，并订阅其他实例发布的失效广播
+func NewPermissionDecisionService(
+	roleRepo repo.RoleRepository,
+	userRepo repo.UserRepository,
+	apiRepo repo.APIRepository,
+	permissionGroupRepo repo.PermissionGroupRepository,
+	permissionRuleRepo repo.PermissionRuleRepository,
+	cacheClient cache.Cache,
+	bus *cache.InvalidationBus,
+	log logger.Logger,
+) *PermissionDecisionService {
+	s := &PermissionDecisionService{
+		roleRepo:            roleRepo,
+		userRepo:            userRepo,
+		apiRepo:             apiRepo,
+		permissionGroupRepo: permissionGroupRepo,
+		permissionRuleRepo:  permissionRuleRepo,
+		cache:               cacheClient,
+		bus:                 bus,
+		log:                 log,
+	}
+	bus.Subscribe(permissionInvalidationTopic, func(message cache.InvalidationMessage) {
+		s.resetLocal(message.Version)
+	})
+	return s
+}
+
+// Decide 判定用户是否可以访问 API
+// 判定顺序：命中的 ABAC 拒绝规则优先，对管理员同样生效；其次管理员直接允许；否则无需授权的 API、RBAC 授权或命中的 ABAC 允许规则任一满足即允许
+func (s *PermissionDecisionService) Decide(req DecisionRequest) (*entity.PermissionDecision, error) {
+	version := s.currentVersion()
+	snapshot, err := s.snapshot(version)
+	if err != nil {
+		return nil, err
+	}
+
+	decision := &entity.PermissionDecision{Route: req.Route}
+	if decision.Route == "" {
+		decision.Route = req.Path
+		if route, ok := snapshot.routes.Match(req.Method, req.Path); ok {
+			decision.Route = route
+		}
+	}
+	authType, registered := snapshot.authTypes[req.Method+" "+decision.Route]
+	decision.Registered = registered
+	if !registered {
+		authType = permissionUnknownAuthType
+	}
+
+	// 当前角色先与用户的角色分配核对，再判断是否为管理员
+	var user *userPermissionSet
+	var roleCodes []string
+	if req.ServiceAccount {
+		// 服务账号不在用户表中，认证时已校验 RoleCode 是其绑定的角色
+		user = &userPermissionSet{RoleCodes: []string{}, RoleIDs: []string{}, apiSet: map[string]bool{}}
+		if req.RoleCode != "" {
+			roleCodes = []string{req.RoleCode}
+		}
+	} else {
+		user, err = s.userSet(version, req.UserID)
+		if err != nil {
+			return nil, err
+		}
+		roleCodes = s.activeRoleCodes(user, req)
+	}
+	isAdmin := containsString(roleCodes, adminRoleCode)
+
+	// 管理员拥有全部权限，不需要计算 RBAC 授权和按钮
+	apiKey := req.Method + " " + decision.Route
+	rbacAllowed := user.apiSet[apiKey]
+	if !isAdmin {
+		buttons := make(map[string]bool)
+		for _, code := range roleCodes {
+			set, err := s.roleSet(version, code)
+			if err != nil {
+				return nil, err
+			}
+			if set.apiSet[apiKey] {
+				rbacAllowed = true
+			}
+			for _, button := range set.Buttons {
+				buttons[button] = true
+			}
+		}
+		decision.Buttons = sortedKeys(buttons)
+	}
+
+	attrs := s.buildAttributes(user, roleCodes, req, decision.Route)
+	effect, rule := s.evaluateRules(s.matchRules(snapshot.rules, req), attrs)
+	switch {
+	case effect == entity.PermissionEffectDeny:
+		decision.Reason = entity.DecisionReasonRuleDeny
+		decision.Rule = rule
+	case isAdmin:
+		decision.Allowed = true
+		decision.Reason = entity.DecisionReasonAdmin
+	case authType == 0:
+		decision.Allowed = true
+		decision.Reason = entity.DecisionReasonPublic
+	case rbacAllowed:
+		decision.Allowed = true
+		decision.Reason = entity.DecisionReasonRBAC
+	case effect == entity.PermissionEffectAllow:
+		decision.Allowed = true
+		decision.Reason = entity.DecisionReasonRuleAllow
+		decision.Rule = rule
+	default:
+		decision.Reason = entity.DecisionReasonNoPermission
+	}
+	return decision, nil
+}
+
+// RoleButtons 获取角色的有效按钮权限编码，包含继承角色和权限组授予的按钮
+func (s *PermissionDecisionService) RoleButtons(roleCode string) ([]string, error) {
+	set, err := s.roleSet(s.currentVersion(), roleCode)
+	if err != nil {
+		return nil, err
+	}
+	return set.Buttons, nil
+}
+
+// Warmup 预先计算所有角色的有效权限集合，启动时调用，避免首批请求集中访问数据库
+func (s *PermissionDecisionService) Warmup() error {
+	roles, err := s.roleRepo.GetAllRoles()
+	if err != nil {
+		s.log.Error("获取角色列表失败", "error", err)
+		return err
+	}
+
+	version := s.currentVersion()
+	for _, role := range roles {
+		if _, err := s.roleSet(version, role.Code); err != nil {
+			s.log.Warn("预计算角色权限失败", "error", err, "role", role.Code)
+		}
+	}
+	s.log.Info("角色权限预计算完成", "roles", len(roles), "version", version)
+	return nil
+}
+
+// Invalidate 使所有实例的权限判定缓存失效
+func (s *PermissionDecisionService) Invalidate(reason string) {
+	version, err := s.cache.Incr(permissionVersionKey, permissionVersionTTL)
+	if err != nil {
+		// 版本号更新失败时 Redis 中的旧缓存仍然有效，只能清除各实例的进程内缓存
+		s.log.Warn("更新权限缓存版本失败", "error", err, "reason", reason)
+		version = 0
+	}
+
+	s.resetLocal(version)
+	if err := s.bus.Publish(cache.InvalidationMessage{Topic: permissionInvalidationTopic, Version: version, Reason: reason}); err != nil {
+		s.log.Warn("广播权限缓存失效失败", "error", err, "reason", reason)
+	}
+	s.log.Info("权限缓存已失效", "version", version, "reason", reason)
+}
+
+// OnTableChange 权限相关表写入后使缓存失效，由 database.WatchTables 回调
+// 事务内的写入在提交前其他请求仍会读到旧数据并写入新版本的缓存，因此在事务提交后再失效一次
+func (s *PermissionDecisionService) OnTableChange(change database.TableChange) {
+	s.Invalidate("表 " + change.Table + " 变更")
+	if change.InTx && s.settlePending.CompareAndSwap(false, true) {
+		time.AfterFunc(permissionTxSettleDelay, func() {
+			s.settlePending.Store(false)
+			s.Invalidate("表 " + change.Table + " 事务提交")
+		})
+	}
+}
+
+// currentVersion 获取当前版本号，进程内缓存有效期内直接使用已知版本号
+func (s *PermissionDecisionService) currentVersion() int64 {
+	if time.Since(time.Unix(0, s.versionCheckedAt.Load())) < permissionLocalTTL {
+		return s.version.Load()
+	}
+
+	var version int64
+	if data, err := s.cache.Get(permissionVersionKey); err == nil && data != "" {
+		version, _ = strconv.ParseInt(data, 10, 64)
+	}
+	if s.version.Swap(version) != version {
+		s.clearLocal()
+	}
+	s.versionCheckedAt.Store(time.Now().UnixNano())
+	return version
+}
+
+// resetLocal 清除进程内缓存并记录新版本号，version 为 0 时下次使用前重新读取版本号
+func (s *PermissionDecisionService) resetLocal(version int64) {
+	s.clearLocal()
+	if version > 0 {
+		s.version.Store(version)
+		s.versionCheckedAt.Store(time.Now().UnixNano())
+	} else {
+		s.versionCheckedAt.Store(0)
+	}
+}
+
+// clearLocal 清除进程内缓存
+func (s *PermissionDecisionService) clearLocal() {
+	s.local.Range(func(key, _ interface{}) bool {
+		s.local.Delete(key)
+		return true
+	})
+}
+
+// localGet 读取进程内缓存
+func (s *PermissionDecisionService) localGet(key string) (interface{}, bool) {
+	value, ok := s.local.Load(key)
+	if !ok {
+		return nil, false
+	}
+	entry := value.(*permissionLocalEntry)
+	if time.Now().After(entry.expiresAt) {
+		s.local.Delete(key)
+		return nil, false
+	}
+	return entry.value, true
+}
+
+// localSet 写入进程内缓存
+func (s *PermissionDecisionService) localSet(key string, value interface{}) {
+	s.local.Store(key, &permissionLocalEntry{value: value, expiresAt: time.Now().Add(permissionLocalTTL)})
+}
+
+// sharedGet 读取 Redis 缓存，命中时反序列化到 value
+func (s *PermissionDecisionService) sharedGet(key string, value interface{}) bool {
+	data, err := s.cache.Get(key)
+	if err != nil || data == "" {
+		return false
+	}
+	return json.Unmarshal([]byte(data), value) == nil
+}
+
+// sharedSet 写入 Redis 缓存
+func (s *PermissionDecisionService) sharedSet(key string, value interface{}, ttl time.Duration) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return
+	}
+	if err := s.cache.Set(key, string(data), ttl); err != nil {
+		s.log.Warn("缓存权限数据失败", "error", err, "key", key)
+	}
+}
+
+// snapshot 获取 API 表和启用的 ABAC 规则
+func (s *PermissionDecisionService) snapshot(version int64) (*permissionSnapshot, error) {
+	key := fmt.Sprintf("perm:v%d:snapshot", version)
+	if value, ok := s.localGet(key); ok {
+		return value.(*permissionSnapshot), nil
+	}
+
+	apis, err := s.apiRepo.GetAllRoutes()
+	if err != nil {
+		s.log.Error("获取 API 路由失败", "error", err)
+		return nil, err
+	}
+	rules, err := s.permissionRuleRepo.GetRulesByStatus(1)
+	if err != nil {
+		s.log.Error("获取权限规则失败", "error", err)
+		return nil, err
+	}
+	sort.SliceStable(rules, func(i, j int) bool { return rules[i].Sort < rules[j].Sort })
+
+	snapshot := &permissionSnapshot{
+		routes:    auth.NewRouteTrie(),
+		authTypes: make(map[string]int, len(apis)),
+		rules:     rules,
+	}
+	for _, api := range apis {
+		snapshot.routes.Insert(api.Method, api.Path)
+		snapshot.authTypes[api.Method+" "+api.Path] = api.AuthType
+	}
+	s.localSet(key, snapshot)
+	return snapshot, nil
+}
+
+// matchRoute 使用当前版本缓存的路由前缀树匹配路由模板，返回路由模板、授权类型和是否已登记
+// 前缀树按权限版本构建一次，API 变更使版本号自增后重新构建
+func (s *PermissionDecisionService) matchRoute(method, path string) (string, int, bool, error) {
+	snapshot, err := s.snapshot(s.currentVersion())
+	if err != nil {
+		return "", 0, false, err
+	}
+	route := path
+	if matched, ok := snapshot.routes.Match(method, path); ok {
+		route = matched
+	}
+	authType, registered := snapshot.authTypes[method+" "+route]
+	if !registered {
+		authType = permissionUnknownAuthType
+	}
+	return route, authType, registered, nil
+}
+
+// roleSet 获取角色的有效权限集合
+func (s *PermissionDecisionService) roleSet(version int64, roleCode string) (*rolePermissionSet, error) {
+	key := fmt.Sprintf("perm:v%d:role:%s", version, roleCode)
+	if value, ok := s.localGet(key); ok {
+		return value.(*rolePermissionSet), nil
+	}
+
+	set := &rolePermissionSet{}
+	if !s.sharedGet(key, set) {
+		built, err := s.buildRoleSet(roleCode)
+		if err != nil {
+			return nil, err
+		}
+		set = built
+		s.sharedSet(key, set, permissionRoleSetTTL)
+	}
+
+	set.apiSet = make(map[string]bool, len(set.APIs))
+	for _, api := range set.APIs {
+		set.apiSet[api] = true
+	}
+	s.localSet(key, set)
+	return set, nil
+}
+
+// buildRoleSet 从数据库计算角色的有效权限集合
+// 沿父角色链收集每个角色直接授予和权限组授予的 API 与按钮，角色不存在或已禁用时权限为空
+func (s *PermissionDecisionService) buildRoleSet(roleCode string) (*rolePermissionSet, error) {
+	set := &rolePermissionSet{RoleCode: roleCode, APIs: []string{}, Buttons: []string{}}
+	role, err := s.roleRepo.GetByCode(roleCode)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return set, nil
+		}
+		s.log.Error("获取角色失败", "error", err, "role", roleCode)
+		return nil, err
+	}
+	if role == nil || role.Status != 1 {
+		return set, nil
+	}
+	set.RoleID = role.ID
+
+	apis := make(map[string]bool)
+	buttons := make(map[string]bool)
+	addAPIs := func(list []*entity.API) {
+		for _, api := range list {
+			apis[api.Method+" "+api.Path] = true
+		}
+	}
+	addButtons := func(list []*entity.BtnPerm) {
+		for _, button := range list {
+			if button != nil && button.Status == 1 {
+				buttons[button.Code] = true
+			}
+		}
+	}
+
+	visited := make(map[int64]bool)
+	for current := role; current != nil && !visited[current.ID]; {
+		visited[current.ID] = true
+
+		roleAPIs, err := s.roleRepo.GetsByRole(current.ID)
+		if err != nil {
+			s.log.Error("获取角色 API 权限失败", "error", err, "roleId", current.ID)
+			return nil, err
+		}
+		addAPIs(roleAPIs)
+
+		roleButtons, err := s.roleRepo.GetBtnPermsByRole(current.ID)
+		if err != nil {
+			s.log.Error("获取角色按钮权限失败", "error", err, "roleId", current.ID)
+			return nil, err
+		}
+		addButtons(roleButtons)
+
+		groups, err := s.permissionGroupRepo.GetGroupsByRole(current.ID)
+		if err != nil {
+			s.log.Error("获取角色权限组失败", "error", err, "roleId", current.ID)
+			return nil, err
+		}
+		for _, group := range groups {
+			groupAPIs, err := s.permissionGroupRepo.GetAPIsByGroup(group.ID)
+			if err != nil {
+				s.log.Error("获取权限组 API 失败", "error", err, "groupId", group.ID)
+				return nil, err
+			}
+			addAPIs(groupAPIs)
+
+			groupButtons, err := s.permissionGroupRepo.GetBtnPermsByGroup(group.ID)
+			if err != nil {
+				s.log.Error("获取权限组按钮权限失败", "error", err, "groupId", group.ID)
+				return nil, err
+			}
+			addButtons(groupButtons)
+		}
+
+		if current.ParentID == 0 {
+			break
+		}
+		parent, err := s.roleRepo.GetByID(current.ParentID)
+		if err != nil {
+			// 父角色已删除时只使用已收集的权限
+			s.log.Warn("获取父角色失败", "error", err, "parentId", current.ParentID)
+			break
+		}
+		current = parent
+	}
+
+	set.APIs = sortedKeys(apis)
+	set.Buttons = sortedKeys(buttons)
+	return set, nil
+}
+
+// userSet 获取用户的角色分配、特殊权限和属性
+func (s *PermissionDecisionService) userSet(version int64, userID int64) (*userPermissionSet, error) {
+	key := fmt.Sprintf("perm:v%d:user:%d", version, userID)
+	if value, ok := s.localGet(key); ok {
+		return value.(*userPermissionSet), nil
+	}
+
+	set := &userPermissionSet{}
+	if !s.sharedGet(key, set) {
+		built, err := s.buildUserSet(userID)
+		if err != nil {
+			return nil, err
+		}
+		set = built
+		s.sharedSet(key, set, permissionUserSetTTL)
+	}
+
+	set.apiSet = make(map[string]bool, len(set.SpecialAPIs))
+	for _, api := range set.SpecialAPIs {
+		set.apiSet[api] = true
+	}
+	s.localSet(key, set)
+	return set, nil
+}
+
+// activeRoleCodes 获取参与判定的角色
+// 当前角色来自缓存，可能已过期未清理或被篡改，为空或不是用户已分配的角色时不具有任何角色的权限，
+// 不退回用户的全部角色，避免当前角色失效后反而获得其他角色的权限
+func (s *PermissionDecisionService) activeRoleCodes(user *userPermissionSet, req DecisionRequest) []string {
+	if req.AllRoles {
+		return user.RoleCodes
+	}
+	if req.RoleCode != "" && containsString(user.RoleCodes, req.RoleCode) {
+		return []string{req.RoleCode}
+	}
+	if req.RoleCode != "" {
+		s.log.Warn("当前角色不是用户已分配的角色，按无角色判定", "userId", user.UserID, "role", req.RoleCode)
+	}
+	return []string{}
+}
+
+// buildUserSet 从数据库读取用户的角色分配、特殊权限和属性
+// 用户不存在时返回空的权限信息，只能访问无需授权的 API
+func (s *PermissionDecisionService) buildUserSet(userID int64) (*userPermissionSet, error) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			s.log.Warn("用户不存在，按无角色判定", "userId", userID)
+			return &userPermissionSet{UserID: userID, RoleCodes: []string{}, RoleIDs: []string{}, SpecialAPIs: []string{}}, nil
+		}
+		s.log.Error("获取用户失败", "error", err, "userId", userID)
+		return nil, err
+	}
+
+	roles, err := s.roleRepo.GetRolesByUser(userID)
+	if err != nil {
+		s.log.Error("获取用户角色失败", "error", err, "userId", userID)
+		return nil, err
+	}
+
+	specialAPIs, err := s.userRepo.GetUserSpecialPermissions(userID)
+	if err != nil {
+		s.log.Error("获取用户特殊权限失败", "error", err, "userId", userID)
+		return nil, err
+	}
+
+	set := &userPermissionSet{
+		UserID:       user.ID,
+		Username:     user.Username,
+		Status:       user.Status,
+		DepartmentID: user.DepartmentID,
+		PositionID:   user.PositionID,
+		RoleCodes:    make([]string, 0, len(roles)),
+		RoleIDs:      make([]string, 0, len(roles)),
+		SpecialAPIs:  make([]string, 0, len(specialAPIs)),
+	}
+	for _, role := range roles {
+		set.RoleCodes = append(set.RoleCodes, role.Code)
+		set.RoleIDs = append(set.RoleIDs, strconv.FormatInt(role.ID, 10))
+	}
+	for _, api := range specialAPIs {
+		set.SpecialAPIs = append(set.SpecialAPIs, api.Method+" "+api.Path)
+	}
+	return set, nil
+}
+
+// matchRules 筛选路径和方法匹配的规则，规则按请求路径匹配，未命中时再按路由模板匹配
+func (s *PermissionDecisionService) matchRules(rules []*entity.PermissionRule, req DecisionRequest) []*entity.PermissionRule {
+	matched := make([]*entity.PermissionRule, 0)
+	for _, rule := range rules {
+		if !abac.MatchMethod(rule.Method, req.Method) {
+			continue
+		}
+		if abac.MatchPath(rule.APIPath, req.Path) || (req.Route != "" && abac.MatchPath(rule.APIPath, req.Route)) {
+			matched = append(matched, rule)
+		}
+	}
+	return matched
+}
+
+// buildAttributes 构建 ABAC 条件求值使用的属性，调用方补充的同名属性会覆盖默认值
+func (s *PermissionDecisionService) buildAttributes(user *userPermissionSet, roleCodes []string, req DecisionRequest, route string) abac.Attributes {
+	attrs := abac.Attributes{
+		"user.id":            strconv.FormatInt(user.UserID, 10),
+		"user.username":      user.Username,
+		"user.status":        user.Status,
+		"user.department_id": strconv.FormatInt(user.DepartmentID, 10),
+		"user.position_id":   strconv.FormatInt(user.PositionID, 10),
+		"user.roles":         roleCodes,
+		"user.role_ids":      user.RoleIDs,
+		"resource.path":      req.Path,
+		"resource.route":     route,
+		"resource.method":    req.Method,
+		"env.time":           time.Now(),
+	}
+	for key, value := range req.Attributes {
+		attrs[key] = value
+	}
+	return attrs
+}
+
+// evaluateRules 按排序对规则条件求值，拒绝优先
+// 返回命中的效果和规则：任一拒绝规则命中即返回 deny；否则返回第一条命中的允许规则；都未命中时 effect 为空
+// 条件无法编译时（如历史数据）拒绝规则按命中处理，允许规则按未命中处理
+func (s *PermissionDecisionService) evaluateRules(rules []*entity.PermissionRule, attrs abac.Attributes) (string, *entity.PermissionRule) {
+	var allowed *entity.PermissionRule
+	for _, rule := range rules {
+		if !rule.IsActive() {
+			continue
+		}
+
+		program, err := s.compileConditions(rule.Conditions)
+		if err != nil {
+			s.log.Error("权限规则条件无效", "error", err, "ruleId", rule.ID, "ruleName", rule.Name)
+			if rule.IsDeny() {
+				return entity.PermissionEffectDeny, rule
+			}
+			continue
+		}
+		if !program.Evaluate(attrs) {
+			continue
+		}
+
+		if rule.IsDeny() {
+			return entity.PermissionEffectDeny, rule
+		}
+		if rule.IsAllow() && allowed == nil {
+			allowed = rule
+		}
+	}
+
+	if allowed != nil {
+		return entity.PermissionEffectAllow, allowed
+	}
+	return "", nil
+}
+
+// compileConditions 编译规则条件，按条件文本缓存编译结果
+func (s *PermissionDecisionService) compileConditions(conditions string) (*abac.Program, error) {
+	if cached, ok := s.programs.Load(conditions); ok {
+		return cached.(*abac.Program), nil
+	}
+	program, err := abac.Compile(conditions)
+	if err != nil {
+		return nil, err
+	}
+	s.programs.Store(conditions, program)
+	return program, nil
+}
+
+// sortedKeys 返回集合中的元素，按字典序排序
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
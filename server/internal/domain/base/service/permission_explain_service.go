@@ -0,0 +1,572 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/entity"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/repo"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/observability/logger"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/security/abac"
+)
+
+// 角色参与判定的来源
+const (
+	RoleSourceAssigned  = "assigned"  // 用户已分配的角色
+	RoleSourceCurrent   = "current"   // 请求指定的当前角色
+	RoleSourceInherited = "inherited" // 沿父角色链继承的角色
+)
+
+// PermissionExplainRequest 权限解释请求
+// 填写 ButtonCode 时解释按钮权限，否则解释 Method + Path 的 API 访问权限
+type PermissionExplainRequest struct {
+	UserID     int64                 // 用户 ID
+	RoleCode   string                // 当前角色编码，为空时按用户的全部角色解释，不是用户已分配的角色时按无角色解释
+	Method     string                // 请求方法
+	Path       string                // 请求路径或路由模板
+	ButtonCode string                // 按钮权限编码
+	Attributes abac.Attributes       // 补充的资源和环境属性，如 env.ip、resource.owner_id
+	Simulation *PermissionSimulation // 拟议的权限变更，不为空时按变更后的配置解释
+}
+
+// PermissionSimulation 拟议的权限变更，只用于模拟判定，不会保存
+type PermissionSimulation struct {
+	UserRoleIDs []int64       // 用户拟分配的角色 ID 列表，为 nil 时使用当前分配
+	Roles       []*RoleChange // 角色拟修改的配置
+}
+
+// RoleChange 角色拟修改的配置，字段为 nil 时使用当前配置
+type RoleChange struct {
+	RoleID             int64   // 角色 ID
+	ParentID           *int64  // 父角色 ID
+	Status             *int    // 状态：1-启用，0-禁用
+	APIIDs             []int64 // 直接授予的 API ID 列表
+	BtnPermIDs         []int64 // 直接授予的按钮权限 ID 列表
+	PermissionGroupIDs []int64 // 关联的权限组 ID 列表
+}
+
+// PermissionExplanation 权限判定过程
+type PermissionExplanation struct {
+	UserID         int64                      // 用户 ID
+	Username       string                     // 用户名
+	Method         string                     // 请求方法
+	Path           string                     // 请求路径
+	Route          string                     // 匹配到的路由模板
+	Registered     bool                       // 路由是否已在 API 表登记
+	AuthType       int                        // API 授权类型：0-不需要授权，1-需要授权
+	ButtonCode     string                     // 解释的按钮权限编码
+	Simulated      bool                       // 是否按拟议变更解释
+	Roles          []*RoleTrace               // 参与判定的角色，包含继承角色
+	SpecialGranted bool                       // 用户特殊权限是否授予该 API
+	Rules          []*RuleTrace               // 路径和方法匹配的 ABAC 规则
+	Allowed        bool                       // 最终结果
+	Reason         string                     // 判定依据，见 entity.DecisionReason* 常量
+	RuleID         int64                      // 决定结果的 ABAC 规则 ID
+	Cached         *entity.PermissionDecision // 当前缓存的判定结果，模拟和按钮解释时为空；与 Allowed 不一致说明缓存尚未失效
+}
+
+// RoleTrace 角色在判定中的授权情况
+type RoleTrace struct {
+	RoleID        int64         // 角色 ID
+	Code          string        // 角色编码
+	Name          string        // 角色名称
+	Status        int           // 角色状态
+	Source        string        // 来源，见 RoleSource* 常量
+	InheritedFrom string        // 继承该角色的子角色编码，来源为 inherited 时有值
+	Simulated     bool          // 角色配置是否来自拟议变更
+	Effective     bool          // 角色授权是否生效，起始角色被禁用时整条继承链都不生效
+	Granted       bool          // 角色是否直接授予
+	Groups        []*GroupTrace // 角色关联的权限组
+}
+
+// GroupTrace 权限组在判定中的授权情况
+type GroupTrace struct {
+	GroupID int64  // 权限组 ID
+	Name    string // 权限组名称
+	Granted bool   // 权限组是否授予
+}
+
+// RuleTrace ABAC 规则的求值情况
+type RuleTrace struct {
+	RuleID    int64       // 规则 ID
+	Name      string      // 规则名称
+	Effect    string      // 效果：allow、deny
+	APIPath   string      // 规则的路径模式
+	Method    string      // 规则的 HTTP 方法
+	Sort      int         // 排序
+	Matched   bool        // 条件是否满足
+	Error     string      // 条件无法编译时的错误，拒绝规则按命中处理
+	Condition *abac.Trace // 条件求值过程
+}
+
+// PermissionExplainService 权限解释服务
+// 直接读取数据库重放 PermissionDecisionService 的判定过程，返回每一步的授权来源，并支持在保存前模拟拟议的权限变更
+type PermissionExplainService struct {
+	roleRepo            repo.RoleRepository
+	userRepo            repo.UserRepository
+	apiRepo             repo.APIRepository
+	btnPermRepo         repo.BtnPermRepository
+	permissionGroupRepo repo.PermissionGroupRepository
+	permissionRuleRepo  repo.PermissionRuleRepository
+	decisions           *PermissionDecisionService
+	log                 logger.Logger
+}
+
+// NewPermissionExplainService 创建权限解释服务实例
+func NewPermissionExplainService(
+	roleRepo repo.RoleRepository,
+	userRepo repo.UserRepository,
+	apiRepo repo.APIRepository,
+	btnPermRepo repo.BtnPermRepository,
+	permissionGroupRepo repo.PermissionGroupRepository,
+	permissionRuleRepo repo.PermissionRuleRepository,
+	decisions *PermissionDecisionService,
+	log logger.Logger,
+) *PermissionExplainService {
+	return &PermissionExplainService{
+		roleRepo:            roleRepo,
+		userRepo:            userRepo,
+		apiRepo:             apiRepo,
+		btnPermRepo:         btnPermRepo,
+		permissionGroupRepo: permissionGroupRepo,
+		permissionRuleRepo:  permissionRuleRepo,
+		decisions:           decisions,
+		log:                 log,
+	}
+}
+
+// explainScope 一次解释使用的数据来源，模拟时在数据库数据之上叠加拟议变更
+type explainScope struct {
+	s       *PermissionExplainService
+	changes map[int64]*RoleChange
+}
+
+// Explain 解释用户访问 API 或按钮的判定过程
+func (s *PermissionExplainService) Explain(req PermissionExplainRequest) (*PermissionExplanation, error) {
+	req.Method = strings.ToUpper(strings.TrimSpace(req.Method))
+	if req.ButtonCode == "" && (req.Method == "" || req.Path == "") {
+		return nil, errors.New("请填写请求方法和路径，或填写按钮权限编码")
+	}
+
+	scope := &explainScope{s: s, changes: make(map[int64]*RoleChange)}
+	if req.Simulation != nil {
+		for _, change := range req.Simulation.Roles {
+			if change == nil {
+				continue
+			}
+			if _, err := s.roleRepo.GetByID(change.RoleID); err != nil {
+				return nil, fmt.Errorf("角色 %d 不存在", change.RoleID)
+			}
+			scope.changes[change.RoleID] = change
+		}
+	}
+
+	user, err := s.userRepo.GetByID(req.UserID)
+	if err != nil {
+		s.log.Error("用户不存在", "error", err, "userId", req.UserID)
+		return nil, errors.New("用户不存在")
+	}
+
+	explanation := &PermissionExplanation{
+		UserID:     user.ID,
+		Username:   user.Username,
+		Method:     req.Method,
+		Path:       req.Path,
+		ButtonCode: req.ButtonCode,
+		Simulated:  req.Simulation != nil,
+		Roles:      make([]*RoleTrace, 0),
+		Rules:      make([]*RuleTrace, 0),
+	}
+
+	// 确定参与判定的起始角色
+	startRoles, err := scope.startRoles(req)
+	if err != nil {
+		return nil, err
+	}
+	roleCodes := make([]string, 0, len(startRoles))
+	roleIDs := make([]string, 0, len(startRoles))
+	for _, role := range startRoles {
+		roleCodes = append(roleCodes, role.Code)
+		roleIDs = append(roleIDs, strconv.FormatInt(role.ID, 10))
+	}
+	if req.ButtonCode != "" {
+		if err := scope.explainButton(explanation, req, startRoles, roleCodes); err != nil {
+			return nil, err
+		}
+		return explanation, nil
+	}
+
+	// 解析路由模板和授权类型，复用权限判定按版本缓存的路由前缀树
+	explanation.Route, explanation.AuthType, explanation.Registered, err = s.decisions.matchRoute(req.Method, req.Path)
+	if err != nil {
+		return nil, err
+	}
+	apiKey := req.Method + " " + explanation.Route
+
+	// 用户特殊权限
+	specialAPIs, err := s.userRepo.GetUserSpecialPermissions(user.ID)
+	if err != nil {
+		s.log.Error("获取用户特殊权限失败", "error", err, "userId", user.ID)
+		return nil, err
+	}
+	specialKeys := make([]string, 0, len(specialAPIs))
+	for _, api := range specialAPIs {
+		specialKeys = append(specialKeys, api.Method+" "+api.Path)
+		if api.Method+" "+api.Path == apiKey {
+			explanation.SpecialGranted = true
+		}
+	}
+
+	// 角色及继承角色、权限组
+	rbacAllowed := explanation.SpecialGranted
+	for _, role := range startRoles {
+		traces, err := scope.traceRole(role, req.RoleCode != "", func(apis []*entity.API, _ []*entity.BtnPerm) bool {
+			for _, api := range apis {
+				if api.Method+" "+api.Path == apiKey {
+					return true
+				}
+			}
+			return false
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, trace := range traces {
+			if trace.Effective && (trace.Granted || groupGranted(trace.Groups)) {
+				rbacAllowed = true
+			}
+		}
+		explanation.Roles = append(explanation.Roles, traces...)
+	}
+
+	// ABAC 规则
+	userSet := &userPermissionSet{
+		UserID:       user.ID,
+		Username:     user.Username,
+		Status:       user.Status,
+		DepartmentID: user.DepartmentID,
+		PositionID:   user.PositionID,
+		RoleCodes:    roleCodes,
+		RoleIDs:      roleIDs,
+		SpecialAPIs:  specialKeys,
+	}
+	decisionReq := DecisionRequest{UserID: user.ID, RoleCode: req.RoleCode, AllRoles: req.RoleCode == "", Method: req.Method, Path: req.Path, Attributes: req.Attributes}
+	attrs := s.decisions.buildAttributes(userSet, roleCodes, decisionReq, explanation.Route)
+	effect, rule, err := scope.traceRules(explanation, req, attrs)
+	if err != nil {
+		return nil, err
+	}
+
+	// 与 PermissionDecisionService.Decide 的判定顺序一致
+	switch {
+	case effect == entity.PermissionEffectDeny:
+		explanation.Reason = entity.DecisionReasonRuleDeny
+		explanation.RuleID = rule.ID
+	case containsString(roleCodes, adminRoleCode):
+		explanation.Allowed = true
+		explanation.Reason = entity.DecisionReasonAdmin
+	case explanation.AuthType == 0:
+		explanation.Allowed = true
+		explanation.Reason = entity.DecisionReasonPublic
+	case rbacAllowed:
+		explanation.Allowed = true
+		explanation.Reason = entity.DecisionReasonRBAC
+	case effect == entity.PermissionEffectAllow:
+		explanation.Allowed = true
+		explanation.Reason = entity.DecisionReasonRuleAllow
+		explanation.RuleID = rule.ID
+	default:
+		explanation.Reason = entity.DecisionReasonNoPermission
+	}
+
+	if req.Simulation == nil {
+		cached, err := s.decisions.Decide(decisionReq)
+		if err != nil {
+			s.log.Warn("获取缓存的判定结果失败", "error", err, "userId", user.ID)
+		} else {
+			explanation.Cached = cached
+		}
+	}
+	return explanation, nil
+}
+
+// explainButton 解释按钮权限，按钮权限只来自角色、继承角色和权限组，不经过 ABAC 规则
+func (e *explainScope) explainButton(explanation *PermissionExplanation, req PermissionExplainRequest, startRoles []*entity.Role, roleCodes []string) error {
+	if containsString(roleCodes, adminRoleCode) {
+		explanation.Allowed = true
+		explanation.Reason = entity.DecisionReasonAdmin
+	}
+
+	for _, role := range startRoles {
+		traces, err := e.traceRole(role, req.RoleCode != "", func(_ []*entity.API, buttons []*entity.BtnPerm) bool {
+			for _, button := range buttons {
+				if button != nil && button.Status == 1 && button.Code == req.ButtonCode {
+					return true
+				}
+			}
+			return false
+		})
+		if err != nil {
+			return err
+		}
+		for _, trace := range traces {
+			if !explanation.Allowed && trace.Effective && (trace.Granted || groupGranted(trace.Groups)) {
+				explanation.Allowed = true
+				explanation.Reason = entity.DecisionReasonRBAC
+			}
+		}
+		explanation.Roles = append(explanation.Roles, traces...)
+	}
+
+	if !explanation.Allowed {
+		explanation.Reason = entity.DecisionReasonNoPermission
+	}
+	return nil
+}
+
+// startRoles 获取参与判定的起始角色：请求指定的当前角色，或用户（拟）分配的全部角色
+func (e *explainScope) startRoles(req PermissionExplainRequest) ([]*entity.Role, error) {
+	var roles []*entity.Role
+	if req.Simulation != nil && req.Simulation.UserRoleIDs != nil {
+		roles = make([]*entity.Role, 0, len(req.Simulation.UserRoleIDs))
+		for _, roleID := range req.Simulation.UserRoleIDs {
+			role, err := e.s.roleRepo.GetByID(roleID)
+			if err != nil {
+				return nil, fmt.Errorf("角色 %d 不存在", roleID)
+			}
+			roles = append(roles, role)
+		}
+	} else {
+		var err error
+		roles, err = e.s.roleRepo.GetRolesByUser(req.UserID)
+		if err != nil {
+			e.s.log.Error("获取用户角色失败", "error", err, "userId", req.UserID)
+			return nil, err
+		}
+	}
+
+	// 与 PermissionDecisionService.activeRoleCodes 一致，当前角色不是用户已分配的角色时按无角色解释
+	if req.RoleCode != "" {
+		for _, role := range roles {
+			if role.Code == req.RoleCode {
+				return []*entity.Role{e.apply(role)}, nil
+			}
+		}
+		return []*entity.Role{}, nil
+	}
+
+	for i, role := range roles {
+		roles[i] = e.apply(role)
+	}
+	return roles, nil
+}
+
+// apply 在角色上叠加拟修改的父角色和状态
+func (e *explainScope) apply(role *entity.Role) *entity.Role {
+	change, ok := e.changes[role.ID]
+	if !ok {
+		return role
+	}
+	copied := *role
+	if change.ParentID != nil {
+		copied.ParentID = *change.ParentID
+	}
+	if change.Status != nil {
+		copied.Status = *change.Status
+	}
+	return &copied
+}
+
+// traceRole 沿父角色链记录每个角色及其权限组是否授予，继承顺序与 PermissionDecisionService 计算角色权限集合时一致
+func (e *explainScope) traceRole(role *entity.Role, current bool, grants func(apis []*entity.API, buttons []*entity.BtnPerm) bool) ([]*RoleTrace, error) {
+	traces := make([]*RoleTrace, 0)
+	effective := role.Status == 1
+	source := RoleSourceAssigned
+	if current {
+		source = RoleSourceCurrent
+	}
+
+	visited := make(map[int64]bool)
+	inheritedFrom := ""
+	for node := role; node != nil && !visited[node.ID]; {
+		visited[node.ID] = true
+		_, simulated := e.changes[node.ID]
+
+		apis, buttons, err := e.roleGrants(node.ID)
+		if err != nil {
+			return nil, err
+		}
+		trace := &RoleTrace{
+			RoleID:        node.ID,
+			Code:          node.Code,
+			Name:          node.Name,
+			Status:        node.Status,
+			Source:        source,
+			InheritedFrom: inheritedFrom,
+			Simulated:     simulated,
+			Effective:     effective,
+			Granted:       grants(apis, buttons),
+			Groups:        make([]*GroupTrace, 0),
+		}
+
+		groups, err := e.roleGroups(node.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, group := range groups {
+			groupAPIs, err := e.s.permissionGroupRepo.GetAPIsByGroup(group.ID)
+			if err != nil {
+				e.s.log.Error("获取权限组 API 失败", "error", err, "groupId", group.ID)
+				return nil, err
+			}
+			groupButtons, err := e.s.permissionGroupRepo.GetBtnPermsByGroup(group.ID)
+			if err != nil {
+				e.s.log.Error("获取权限组按钮权限失败", "error", err, "groupId", group.ID)
+				return nil, err
+			}
+			trace.Groups = append(trace.Groups, &GroupTrace{GroupID: group.ID, Name: group.Name, Granted: grants(groupAPIs, groupButtons)})
+		}
+		traces = append(traces, trace)
+
+		if node.ParentID == 0 {
+			break
+		}
+		parent, err := e.s.roleRepo.GetByID(node.ParentID)
+		if err != nil {
+			e.s.log.Warn("获取父角色失败", "error", err, "parentId", node.ParentID)
+			break
+		}
+		source = RoleSourceInherited
+		inheritedFrom = node.Code
+		node = e.apply(parent)
+	}
+	return traces, nil
+}
+
+// roleGrants 获取角色直接授予的 API 和按钮权限，模拟时使用拟议的配置
+func (e *explainScope) roleGrants(roleID int64) ([]*entity.API, []*entity.BtnPerm, error) {
+	change := e.changes[roleID]
+
+	var apis []*entity.API
+	if change != nil && change.APIIDs != nil {
+		apis = make([]*entity.API, 0, len(change.APIIDs))
+		for _, apiID := range change.APIIDs {
+			api, err := e.s.apiRepo.GetByID(apiID)
+			if err != nil {
+				return nil, nil, fmt.Errorf("API %d 不存在", apiID)
+			}
+			apis = append(apis, api)
+		}
+	} else {
+		var err error
+		if apis, err = e.s.roleRepo.GetsByRole(roleID); err != nil {
+			e.s.log.Error("获取角色 API 权限失败", "error", err, "roleId", roleID)
+			return nil, nil, err
+		}
+	}
+
+	var buttons []*entity.BtnPerm
+	if change != nil && change.BtnPermIDs != nil {
+		buttons = make([]*entity.BtnPerm, 0, len(change.BtnPermIDs))
+		for _, btnID := range change.BtnPermIDs {
+			button, err := e.s.btnPermRepo.GetByID(btnID)
+			if err != nil {
+				return nil, nil, fmt.Errorf("按钮权限 %d 不存在", btnID)
+			}
+			buttons = append(buttons, button)
+		}
+	} else {
+		var err error
+		if buttons, err = e.s.roleRepo.GetBtnPermsByRole(roleID); err != nil {
+			e.s.log.Error("获取角色按钮权限失败", "error", err, "roleId", roleID)
+			return nil, nil, err
+		}
+	}
+	return apis, buttons, nil
+}
+
+// roleGroups 获取角色关联的权限组，模拟时使用拟议的配置
+func (e *explainScope) roleGroups(roleID int64) ([]*entity.PermissionGroup, error) {
+	change := e.changes[roleID]
+	if change == nil || change.PermissionGroupIDs == nil {
+		groups, err := e.s.permissionGroupRepo.GetGroupsByRole(roleID)
+		if err != nil {
+			e.s.log.Error("获取角色权限组失败", "error", err, "roleId", roleID)
+			return nil, err
+		}
+		return groups, nil
+	}
+
+	groups := make([]*entity.PermissionGroup, 0, len(change.PermissionGroupIDs))
+	for _, groupID := range change.PermissionGroupIDs {
+		group, err := e.s.permissionGroupRepo.GetByID(groupID)
+		if err != nil {
+			return nil, fmt.Errorf("权限组 %d 不存在", groupID)
+		}
+		groups = append(groups, group)
+	}
+	return groups, nil
+}
+
+// traceRules 记录路径和方法匹配的启用规则的条件求值过程，返回与 PermissionDecisionService 一致的规则判定结果
+func (e *explainScope) traceRules(explanation *PermissionExplanation, req PermissionExplainRequest, attrs abac.Attributes) (string, *entity.PermissionRule, error) {
+	rules, err := e.s.permissionRuleRepo.GetRulesByStatus(1)
+	if err != nil {
+		e.s.log.Error("获取权限规则失败", "error", err)
+		return "", nil, err
+	}
+	sort.SliceStable(rules, func(i, j int) bool { return rules[i].Sort < rules[j].Sort })
+
+	var denied, allowed *entity.PermissionRule
+	matched := e.s.decisions.matchRules(rules, DecisionRequest{Method: req.Method, Path: req.Path, Route: explanation.Route})
+	for _, rule := range matched {
+		trace := &RuleTrace{
+			RuleID:  rule.ID,
+			Name:    rule.Name,
+			Effect:  rule.Effect,
+			APIPath: rule.APIPath,
+			Method:  rule.Method,
+			Sort:    rule.Sort,
+		}
+		program, err := e.s.decisions.compileConditions(rule.Conditions)
+		if err != nil {
+			trace.Error = err.Error()
+			trace.Matched = rule.IsDeny()
+		} else {
+			trace.Condition = program.Explain(attrs)
+			trace.Matched = trace.Condition.Result
+		}
+		explanation.Rules = append(explanation.Rules, trace)
+
+		if !trace.Matched {
+			continue
+		}
+		if rule.IsDeny() && denied == nil {
+			denied = rule
+		}
+		if rule.IsAllow() && allowed == nil {
+			allowed = rule
+		}
+	}
+
+	switch {
+	case denied != nil:
+		return entity.PermissionEffectDeny, denied, nil
+	case allowed != nil:
+		return entity.PermissionEffectAllow, allowed, nil
+	}
+	return "", nil, nil
+}
+
+// groupGranted 检查是否有权限组授予
+func groupGranted(groups []*GroupTrace) bool {
+	for _, group := range groups {
+		if group.Granted {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,130 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/entity"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/repo"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/observability/logger"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/security/abac"
+)
+
+// PermissionRuleService 权限规则（ABAC）服务
+// 规则按 API 路径模式和方法匹配请求，条件满足时生效，拒绝规则优先于允许规则
+// 条件在写入时编译校验，避免无效条件保存后在鉴权时才暴露
+type PermissionRuleService struct {
+	repo repo.PermissionRuleRepository
+	log  logger.Logger
+}
+
+// NewPermissionRuleService 创建权限规则服务实例
+func NewPermissionRuleService(repo repo.PermissionRuleRepository, log logger.Logger) *PermissionRuleService {
+	return &PermissionRuleService{
+		repo: repo,
+		log:  log,
+	}
+}
+
+// CreateRule 创建权限规则
+func (s *PermissionRuleService) CreateRule(rule *entity.PermissionRule) error {
+	if err := s.validateRule(rule); err != nil {
+		return err
+	}
+	if existing, err := s.repo.GetByName(rule.Name); err == nil && existing != nil {
+		return errors.New("规则名称已存在")
+	}
+
+	if err := s.repo.Create(rule); err != nil {
+		s.log.Error("创建权限规则失败", "name", rule.Name, "error", err)
+		return errors.New("创建权限规则失败")
+	}
+
+	s.log.Info("权限规则创建成功", "ruleID", rule.ID, "name", rule.Name, "effect", rule.Effect, "apiPath", rule.APIPath, "method", rule.Method)
+	return nil
+}
+
+// UpdateRule 更新权限规则，系统规则的是否系统规则标记保持不变
+func (s *PermissionRuleService) UpdateRule(rule *entity.PermissionRule) error {
+	existing, err := s.repo.GetByID(rule.ID)
+	if err != nil {
+		return errors.New("权限规则不存在")
+	}
+	if err := s.validateRule(rule); err != nil {
+		return err
+	}
+	if other, err := s.repo.GetByName(rule.Name); err == nil && other != nil && other.ID != rule.ID {
+		return errors.New("规则名称已存在")
+	}
+
+	rule.IsSystem = existing.IsSystem
+	rule.CreatedBy = existing.CreatedBy
+	if err := s.repo.Update(rule); err != nil {
+		s.log.Error("更新权限规则失败", "ruleID", rule.ID, "error", err)
+		return errors.New("更新权限规则失败")
+	}
+
+	s.log.Info("权限规则更新成功", "ruleID", rule.ID, "name", rule.Name)
+	return nil
+}
+
+// DeleteRule 删除权限规则，系统规则不能删除
+func (s *PermissionRuleService) DeleteRule(id int64) error {
+	rule, err := s.repo.GetByID(id)
+	if err != nil {
+		return errors.New("权限规则不存在")
+	}
+	if rule.IsSystemRule() {
+		return errors.New("系统规则不能删除")
+	}
+
+	if err := s.repo.Delete(id); err != nil {
+		s.log.Error("删除权限规则失败", "ruleID", id, "error", err)
+		return errors.New("删除权限规则失败")
+	}
+
+	s.log.Info("权限规则删除成功", "ruleID", id, "name", rule.Name)
+	return nil
+}
+
+// GetRuleByID 获取权限规则详情
+func (s *PermissionRuleService) GetRuleByID(id int64) (*entity.PermissionRule, error) {
+	rule, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, errors.New("权限规则不存在")
+	}
+	return rule, nil
+}
+
+// GetRuleList 分页获取权限规则列表
+func (s *PermissionRuleService) GetRuleList(page, pageSize int, filters map[string]interface{}) ([]*entity.PermissionRule, int64, error) {
+	return s.repo.List(page, pageSize, filters)
+}
+
+// ValidateConditions 校验条件表达式，供前端编辑规则时实时校验
+func (s *PermissionRuleService) ValidateConditions(conditions string) error {
+	if err := abac.Validate(conditions); err != nil {
+		return fmt.Errorf("条件无效: %w", err)
+	}
+	return nil
+}
+
+// validateRule 校验规则的效果、路径模式、方法和条件，并规范化方法
+func (s *PermissionRuleService) validateRule(rule *entity.PermissionRule) error {
+	if strings.TrimSpace(rule.Name) == "" {
+		return errors.New("规则名称不能为空")
+	}
+	if !rule.IsAllow() && !rule.IsDeny() {
+		return errors.New("规则效果只能是 allow 或 deny")
+	}
+	if err := abac.ValidatePathPattern(rule.APIPath); err != nil {
+		return err
+	}
+	method, err := abac.ValidateMethod(rule.Method)
+	if err != nil {
+		return err
+	}
+	rule.Method = method
+	return s.ValidateConditions(rule.Conditions)
+}
@@ -2,11 +2,13 @@ package service
 
 import (
 	"errors"
-	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/ix-pay/ixpay-pro/internal/domain/base/entity"
 	"github.com/ix-pay/ixpay-pro/internal/domain/base/repo"
 	"github.com/ix-pay/ixpay-pro/internal/infrastructure/observability/logger"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/security/abac"
 )
 
 // PermissionService 权限服务实现
@@ -19,9 +21,18 @@ type PermissionService struct {
 	apiRepo             repo.APIRepository
 	permissionRuleRepo  repo.PermissionRuleRepository
 	permissionGroupRepo repo.PermissionGroupRepository
+	decisions           *PermissionDecisionService
 	logger              logger.Logger
 }
 
+// resourceActionMethods 资源动作与 HTTP 方法的对应关系
+var resourceActionMethods = map[string]string{
+	"read":   "GET",
+	"create": "POST",
+	"write":  "PUT",
+	"delete": "DELETE",
+}
+
 // NewPermissionService 创建权限服务实例
 func NewPermissionService(
 	roleService *RoleService,
@@ -31,6 +42,7 @@ func NewPermissionService(
 	apiRepo repo.APIRepository,
 	permissionRuleRepo repo.PermissionRuleRepository,
 	permissionGroupRepo repo.PermissionGroupRepository,
+	decisions *PermissionDecisionService,
 	logger logger.Logger,
 ) *PermissionService {
 	return &PermissionService{
@@ -41,6 +53,7 @@ func NewPermissionService(
 		apiRepo:             apiRepo,
 		permissionRuleRepo:  permissionRuleRepo,
 		permissionGroupRepo: permissionGroupRepo,
+		decisions:           decisions,
 		logger:              logger,
 	}
 }
@@ -153,91 +166,28 @@ func (p *PermissionService) RevokeBtnPermFromRole(roleId int64, btnPermId int64)
 
 // CheckAPIAccess 检查用户是否有 API 访问权限（支持 RBAC+ABAC）
 func (p *PermissionService) CheckAPIAccess(userId int64, apiPath, method string) (bool, error) {
-	user, err := p.userService.GetUserInfo(userId)
-	if err != nil {
-		p.logger.Error("用户不存在", "error", err, "userId", userId)
-		return false, err
-	}
-
-	// 2. 检查用户特殊 API 权限
-	specialPerms, err := p.userService.GetUserSpecialPermissions(userId)
-	if err != nil {
-		p.logger.Error("获取用户特殊权限失败", "error", err, "userId", userId)
-		return false, err
-	}
-
-	// 检查是否有直接匹配的特殊权限
-	for _, api := range specialPerms {
-		if api.Path == apiPath && api.Method == method {
-			return true, nil
-		}
-	}
-
-	// 3. 检查用户角色的 API 权限（包括继承和权限组）
-	roles, err := p.roleService.GetRolesForUser(userId)
-	if err != nil {
-		p.logger.Error("获取用户角色失败", "error", err, "userId", userId)
-		return false, err
-	}
-
-	for _, role := range roles {
-		// 获取角色的所有 API 权限（包括继承）
-		apiPerms, err := p.roleService.GetAllInheritedPermissions(role.ID)
-		if err != nil {
-			p.logger.Error("获取角色继承权限失败", "error", err, "roleId", role.ID)
-			continue
-		}
-
-		// 检查 API 权限
-		for _, api := range apiPerms {
-			if api.Path == apiPath && api.Method == method {
-				return true, nil
-			}
-		}
-
-		// 获取角色的权限组
-		groups, err := p.permissionGroupRepo.GetGroupsByRole(role.ID)
-		if err != nil {
-			p.logger.Error("获取角色权限组失败", "error", err, "roleId", role.ID)
-			continue
-		}
-
-		// 检查权限组的 API 权限
-		for _, group := range groups {
-			groupAPIs, err := p.permissionGroupRepo.GetAPIsByGroup(group.ID)
-			if err != nil {
-				p.logger.Error("获取权限组 API 失败", "error", err, "groupId", group.ID)
-				continue
-			}
-
-			for _, api := range groupAPIs {
-				if api.Path == apiPath && api.Method == method {
-					return true, nil
-				}
-			}
-		}
-	}
+	return p.CheckAPIAccessWithAttributes(userId, apiPath, method, nil)
+}
 
-	// 4. 检查 ABAC 权限规则
-	// 评估规则
-	allow, err := p.permissionRuleRepo.FindMatchingRules(apiPath, method, []entity.PermissionAttribute{
-		{Key: "user_id", Value: fmt.Sprintf("%d", userId), Type: "user"},
-		{Key: "department_id", Value: fmt.Sprintf("%d", user.DepartmentID), Type: "user"},
-		{Key: "position_id", Value: fmt.Sprintf("%d", user.PositionID), Type: "user"},
+// CheckAPIAccessWithAttributes 检查用户是否有 API 访问权限，extra 为调用方补充的资源和环境属性，如 env.ip、resource.owner_id
+// 按用户的全部角色判定，判定规则见 PermissionDecisionService.Decide
+func (p *PermissionService) CheckAPIAccessWithAttributes(userId int64, apiPath, method string, extra abac.Attributes) (bool, error) {
+	decision, err := p.decisions.Decide(DecisionRequest{
+		UserID:     userId,
+		AllRoles:   true,
+		Method:     method,
+		Path:       apiPath,
+		Attributes: extra,
 	})
 	if err != nil {
-		p.logger.Error("评估权限规则失败", "error", err, "userId", userId, "apiPath", apiPath, "method", method)
+		p.logger.Error("权限判定失败", "error", err, "userId", userId, "apiPath", apiPath, "method", method)
 		return false, err
 	}
 
-	// 如果有匹配的规则，检查是否有允许的规则
-	for _, rule := range allow {
-		if rule.Effect == "allow" && rule.Status == 1 {
-			return true, nil
-		}
+	if decision.Rule != nil {
+		p.logger.Info("ABAC 规则命中", "ruleId", decision.Rule.ID, "ruleName", decision.Rule.Name, "reason", decision.Reason, "userId", userId, "apiPath", apiPath, "method", method)
 	}
-
-	return false, nil
+	return decision.Allowed, nil
 }
 
 // CheckBtnPermission 检查用户是否有按钮权限（支持 RBAC+ABAC）
@@ -466,76 +416,32 @@ func (p *PermissionService) GetUserBtnPermissions(userId int64) ([]*entity.BtnPe
 }
 
 // CheckResourceAccess 检查用户对资源的访问权限（ABAC）
+// 资源访问映射为 /api/admin/{resourceType}/{resourceID} 的 API 访问，动作 read、write、delete 分别对应 GET、PUT、DELETE
+// 规则条件中可以通过 resource.type、resource.id、resource.action 引用资源属性
 func (p *PermissionService) CheckResourceAccess(userId int64, resourceType string, resourceID string, action string) (bool, error) {
-	// 1. 检查用户是否存在
-	_, err := p.userService.GetUserInfo(userId)
-	if err != nil {
-		p.logger.Error("用户不存在", "error", err, "userId", userId)
-		return false, err
+	method, ok := resourceActionMethods[action]
+	if !ok {
+		method = strings.ToUpper(action)
 	}
+	apiPath := "/api/admin/" + resourceType + "/" + resourceID
 
-	// 2. 构建 API 路径和方法（资源访问可以映射为 API）
-	apiPath := "/api//" + resourceType + "/" + resourceID
-	method := action
-
-	// 3. 检查 RBAC 权限
-	// 调用 CheckAPIAccess 检查基本访问权限
-	rbacAllowed, err := p.CheckAPIAccess(userId, apiPath, method)
-	if err != nil {
-		p.logger.Error("检查 RBAC 访问失败", "error", err, "userId", userId, "resourceType", resourceType, "resourceID", resourceID, "action", action)
-		return false, err
-	}
-
-	if !rbacAllowed {
-		return false, nil
-	}
-
-	// 4. 检查 ABAC 规则
-	// 构建用户属性 - 暂时注释，等待实现完整的 ABAC 规则匹配逻辑
-	// attributes := []repo.PermissionAttribute{
-	// 	{Key: "user_id", Value: strconv.FormatInt(userId, 10), Type: "user"},
-	// 	{Key: "department_id", Value: strconv.FormatInt(user.DepartmentID, 10), Type: "user"},
-	// 	{Key: "position_id", Value: strconv.FormatInt(user.PositionID, 10), Type: "user"},
-	// 	{Key: "resource_type", Value: resourceType, Type: "resource"},
-	// 	{Key: "resource_id", Value: strconv.FormatInt(resourceID, 10), Type: "resource"},
-	// 	{Key: "action", Value: action, Type: "environment"},
-	// }
-
-	// 评估 ABAC 规则
-	rules, err := p.permissionRuleRepo.GetRulesByRole(userId)
+	allowed, err := p.CheckAPIAccessWithAttributes(userId, apiPath, method, abac.Attributes{
+		"resource.type":   resourceType,
+		"resource.id":     resourceID,
+		"resource.action": action,
+	})
 	if err != nil {
-		p.logger.Error("获取用户权限规则失败", "error", err, "userId", userId)
+		p.logger.Error("检查资源访问权限失败", "error", err, "userId", userId, "resourceType", resourceType, "resourceID", resourceID, "action", action)
 		return false, err
 	}
 
-	// 检查是否有明确的拒绝规则
-	for _, rule := range rules {
-		if rule.Effect == "deny" && rule.Status == 1 {
-			// TODO: 实现规则条件匹配逻辑
-			// 这里简化处理，实际应该解析 rule.Conditions 并匹配 attributes
-			p.logger.Info("ABAC 规则拒绝访问", "ruleId", rule.ID, "ruleName", rule.Name, "userId", userId)
-			return false, nil
-		}
-	}
-
-	// 检查是否有明确的允许规则
-	for _, rule := range rules {
-		if rule.Effect == "allow" && rule.Status == 1 {
-			// TODO: 实现规则条件匹配逻辑
-			// 这里简化处理，实际应该解析 rule.Conditions 并匹配 attributes
-			p.logger.Info("ABAC 规则允许访问", "ruleId", rule.ID, "ruleName", rule.Name, "userId", userId)
-			return true, nil
-		}
-	}
-
-	// 如果没有 ABAC 规则，则默认允许（基于 RBAC 结果）
-	return true, nil
+	return allowed, nil
 }
 
 // RefreshPermissionCache 刷新用户权限缓存
+// 角色权限集合在多个用户之间共享，无法只清除单个用户，因此使所有实例的权限判定缓存失效
 func (p *PermissionService) RefreshPermissionCache(userId int64) error {
-	// TODO: 实现权限缓存刷新逻辑
-	// 例如：清除 Redis 中的用户权限缓存
+	p.decisions.Invalidate("刷新用户 " + strconv.FormatInt(userId, 10) + " 权限缓存")
 	p.logger.Info("用户权限缓存已刷新", "userId", userId)
 	return nil
 }
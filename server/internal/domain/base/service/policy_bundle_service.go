@@ -0,0 +1,631 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/entity"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/repo"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/observability/logger"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/security/abac"
+	"gopkg.in/yaml.v3"
+)
+
+// 策略包文件格式
+const (
+	PolicyFormatJSON = "json"
+	PolicyFormatYAML = "yaml"
+)
+
+// 权限审计日志中策略包导入的操作类型和目标类型
+const (
+	policyBundleActionImport = "policy_bundle_import"
+	policyBundleTargetType   = "policy_bundle"
+)
+
+// PolicyBundleService 权限策略包服务
+// 将角色、菜单、API、按钮权限、权限组和 ABAC 规则导出为以编码引用的 JSON/YAML 策略包，
+// 导入时先与当前环境比较差异，可只预览差异，确认后在单个事务中写入；
+// 差异影响开启变更审批的角色时，导入记录为待审批申请，由 PermissionChangeService 审批通过后写入
+type PolicyBundleService struct {
+	repo       repo.PolicyBundleRepository
+	userRepo   repo.UserRepository
+	auditRepo  repo.PermissionAuditLogRepository
+	changeRepo repo.PermissionChangeRequestRepository
+	log        logger.Logger
+}
+
+// NewPolicyBundleService 创建权限策略包服务实例
+func NewPolicyBundleService(
+	repo repo.PolicyBundleRepository,
+	userRepo repo.UserRepository,
+	auditRepo repo.PermissionAuditLogRepository,
+	changeRepo repo.PermissionChangeRequestRepository,
+	log logger.Logger,
+) *PolicyBundleService {
+	return &PolicyBundleService{
+		repo:       repo,
+		userRepo:   userRepo,
+		auditRepo:  auditRepo,
+		changeRepo: changeRepo,
+		log:        log,
+	}
+}
+
+// Export 导出当前环境的策略包，format 为空时使用 YAML
+func (s *PolicyBundleService) Export(format string) ([]byte, error) {
+	bundle, err := s.repo.Export()
+	if err != nil {
+		s.log.Error("导出权限策略包失败", "error", err)
+		return nil, errors.New("导出权限策略包失败")
+	}
+	return encodePolicyBundle(bundle, format)
+}
+
+// Import 导入策略包，dryRun 为 true 时只返回差异不写入
+// 策略包中不存在的对象保持不变；差异影响开启变更审批的角色时不直接写入，提交审批申请并返回 PendingApprovalError
+func (s *PolicyBundleService) Import(data []byte, format string, dryRun bool, operatorID int64) (*entity.PolicyImportResult, error) {
+	bundle, err := decodePolicyBundle(data, format)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := s.repo.Export()
+	if err != nil {
+		s.log.Error("读取当前权限配置失败", "error", err)
+		return nil, errors.New("读取当前权限配置失败")
+	}
+
+	if err := validatePolicyBundle(bundle, current); err != nil {
+		return nil, err
+	}
+
+	changes := diffPolicyBundle(current, bundle)
+	result := &entity.PolicyImportResult{
+		DryRun:        dryRun,
+		Changes:       changes,
+		ApprovalRoles: affectedApprovalRequiredRoles(current, changes),
+	}
+	if dryRun || len(changes) == 0 {
+		return result, nil
+	}
+
+	if len(result.ApprovalRoles) > 0 {
+		return nil, s.submitImport(bundle, result.ApprovalRoles, operatorID)
+	}
+
+	if err := s.apply(bundle, changes, operatorID); err != nil {
+		return nil, err
+	}
+	result.Applied = true
+	return result, nil
+}
+
+// submitImport 将影响开启变更审批的角色的导入记录为待审批申请，申请中保存规范化后的策略包
+// 策略包导入不针对单个角色，申请的 RoleID 为 0
+func (s *PolicyBundleService) submitImport(bundle *entity.PolicyBundle, roles []string, operatorID int64) error {
+	payload, err := json.Marshal(bundle)
+	if err != nil {
+		return fmt.Errorf("序列化策略包失败: %w", err)
+	}
+
+	request := &entity.PermissionChangeRequest{
+		ChangeType:  entity.PermissionChangePolicyBundle,
+		Payload:     string(payload),
+		Status:      entity.PermissionChangeStatusPending,
+		RequestedBy: operatorID,
+		CreatedBy:   operatorID,
+		UpdatedBy:   operatorID,
+	}
+	if err := s.changeRepo.Create(request); err != nil {
+		s.log.Error("创建策略包导入申请失败", "error", err)
+		return errors.New("创建权限变更申请失败")
+	}
+
+	s.log.Info("权限策略包导入已提交审批", "request_id", request.ID, "roles", roles, "operator_id", operatorID)
+	return &PendingApprovalError{Request: request}
+}
+
+// applyApprovedImport 执行审批通过的策略包导入申请
+// 环境可能在提交后发生变化，按审批时的环境重新校验和比较差异，由审批人写入，策略包和申请状态在同一事务中写入
+func (s *PolicyBundleService) applyApprovedImport(request *entity.PermissionChangeRequest) error {
+	bundle := &entity.PolicyBundle{}
+	if err := json.Unmarshal([]byte(request.Payload), bundle); err != nil {
+		return fmt.Errorf("解析策略包失败: %w", err)
+	}
+
+	current, err := s.repo.Export()
+	if err != nil {
+		s.log.Error("读取当前权限配置失败", "error", err)
+		return errors.New("读取当前权限配置失败")
+	}
+	if err := validatePolicyBundle(bundle, current); err != nil {
+		return err
+	}
+
+	// 没有差异时也在事务中更新申请状态
+	changes := diffPolicyBundle(current, bundle)
+	if err := s.repo.ApplyApproved(bundle, request); err != nil {
+		s.log.Error("写入权限策略包失败", "error", err, "request_id", request.ID)
+		return fmt.Errorf("写入权限策略包失败: %w", err)
+	}
+	if len(changes) > 0 {
+		s.writeAuditLog(request.ReviewedBy, changes)
+	}
+	s.log.Info("权限策略包导入申请已生效", "changes", len(changes), "request_id", request.ID, "operator_id", request.ReviewedBy)
+	return nil
+}
+
+// apply 在单个事务中写入策略包并记录审计日志
+func (s *PolicyBundleService) apply(bundle *entity.PolicyBundle, changes []entity.PolicyChange, operatorID int64) error {
+	if err := s.repo.Apply(bundle, operatorID); err != nil {
+		s.log.Error("写入权限策略包失败", "error", err)
+		return fmt.Errorf("写入权限策略包失败: %w", err)
+	}
+
+	s.writeAuditLog(operatorID, changes)
+	s.log.Info("权限策略包导入成功", "changes", len(changes), "operator_id", operatorID)
+	return nil
+}
+
+// writeAuditLog 将导入的差异写入 sys_permission_logs，失败不影响导入结果
+func (s *PolicyBundleService) writeAuditLog(operatorID int64, changes []entity.PolicyChange) {
+	operatorName := ""
+	if user, err := s.userRepo.GetByID(operatorID); err == nil {
+		operatorName = user.Username
+	}
+
+	after, _ := json.Marshal(changes)
+	record := &entity.PermissionAuditLog{
+		OperatorID:   operatorID,
+		OperatorName: operatorName,
+		ActionType:   policyBundleActionImport,
+		TargetType:   policyBundleTargetType,
+		AfterData:    string(after),
+	}
+	if err := s.auditRepo.Create(record); err != nil {
+		s.log.Warn("写入权限审计日志失败", "error", err)
+	}
+}
+
+// encodePolicyBundle 按格式序列化策略包
+func encodePolicyBundle(bundle *entity.PolicyBundle, format string) ([]byte, error) {
+	switch strings.ToLower(format) {
+	case "", PolicyFormatYAML:
+		return yaml.Marshal(bundle)
+	case PolicyFormatJSON:
+		return json.MarshalIndent(bundle, "", "  ")
+	default:
+		return nil, fmt.Errorf("不支持的策略包格式: %s", format)
+	}
+}
+
+// decodePolicyBundle 按格式解析策略包，并规范化引用列表
+func decodePolicyBundle(data []byte, format string) (*entity.PolicyBundle, error) {
+	bundle := &entity.PolicyBundle{}
+	var err error
+	switch strings.ToLower(format) {
+	case "", PolicyFormatYAML:
+		err = yaml.Unmarshal(data, bundle)
+	case PolicyFormatJSON:
+		err = json.Unmarshal(data, bundle)
+	default:
+		return nil, fmt.Errorf("不支持的策略包格式: %s", format)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("解析策略包失败: %w", err)
+	}
+
+	for i := range bundle.Menus {
+		bundle.Menus[i].APIs = normalizePolicyRefs(bundle.Menus[i].APIs)
+	}
+	for i := range bundle.BtnPerms {
+		bundle.BtnPerms[i].APIs = normalizePolicyRefs(bundle.BtnPerms[i].APIs)
+	}
+	for i := range bundle.Roles {
+		bundle.Roles[i].Menus = normalizePolicyRefs(bundle.Roles[i].Menus)
+		bundle.Roles[i].APIs = normalizePolicyRefs(bundle.Roles[i].APIs)
+		bundle.Roles[i].BtnPerms = normalizePolicyRefs(bundle.Roles[i].BtnPerms)
+		bundle.Roles[i].PermissionGroups = normalizePolicyRefs(bundle.Roles[i].PermissionGroups)
+	}
+	for i := range bundle.PermissionGroups {
+		bundle.PermissionGroups[i].APIs = normalizePolicyRefs(bundle.PermissionGroups[i].APIs)
+		bundle.PermissionGroups[i].BtnPerms = normalizePolicyRefs(bundle.PermissionGroups[i].BtnPerms)
+	}
+	return bundle, nil
+}
+
+// normalizePolicyRefs 去重并排序引用列表，与导出结果保持一致，避免顺序不同产生差异
+func normalizePolicyRefs(refs []string) []string {
+	seen := make(map[string]bool, len(refs))
+	result := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		if !seen[ref] {
+			seen[ref] = true
+			result = append(result, ref)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+// validatePolicyBundle 校验策略包版本、编码唯一性、规则合法性，以及引用的对象在策略包或当前环境中存在
+func validatePolicyBundle(bundle, current *entity.PolicyBundle) error {
+	if bundle.Version != entity.PolicyBundleVersion {
+		return fmt.Errorf("不支持的策略包版本: %d，当前版本为 %d", bundle.Version, entity.PolicyBundleVersion)
+	}
+
+	apis := make(map[string]bool)
+	for _, api := range current.APIs {
+		apis[api.Key()] = true
+	}
+	incomingAPIs := make(map[string]bool)
+	for _, api := range bundle.APIs {
+		if api.Method == "" || api.Path == "" {
+			return errors.New("API 的方法和路径不能为空")
+		}
+		if incomingAPIs[api.Key()] {
+			return fmt.Errorf("API %s 重复", api.Key())
+		}
+		incomingAPIs[api.Key()] = true
+		apis[api.Key()] = true
+	}
+
+	menus := make(map[string]bool)
+	for _, menu := range current.Menus {
+		menus[menu.Name] = true
+	}
+	incomingMenus := make(map[string]bool)
+	for _, menu := range bundle.Menus {
+		if menu.Name == "" {
+			return errors.New("菜单名称不能为空")
+		}
+		if incomingMenus[menu.Name] {
+			return fmt.Errorf("菜单 %s 重复", menu.Name)
+		}
+		incomingMenus[menu.Name] = true
+		menus[menu.Name] = true
+	}
+
+	btnPerms := make(map[string]bool)
+	for _, btnPerm := range current.BtnPerms {
+		btnPerms[btnPerm.Code] = true
+	}
+	incomingBtnPerms := make(map[string]bool)
+	for _, btnPerm := range bundle.BtnPerms {
+		if btnPerm.Code == "" {
+			return errors.New("按钮权限编码不能为空")
+		}
+		if incomingBtnPerms[btnPerm.Code] {
+			return fmt.Errorf("按钮权限 %s 重复", btnPerm.Code)
+		}
+		incomingBtnPerms[btnPerm.Code] = true
+		btnPerms[btnPerm.Code] = true
+	}
+
+	groups := make(map[string]bool)
+	for _, group := range current.PermissionGroups {
+		groups[group.Name] = true
+	}
+	incomingGroups := make(map[string]bool)
+	for _, group := range bundle.PermissionGroups {
+		if group.Name == "" {
+			return errors.New("权限组名称不能为空")
+		}
+		if incomingGroups[group.Name] {
+			return fmt.Errorf("权限组 %s 重复", group.Name)
+		}
+		incomingGroups[group.Name] = true
+		groups[group.Name] = true
+	}
+
+	roles := make(map[string]bool)
+	customScopeRoles := make(map[string]bool)
+	for _, role := range current.Roles {
+		roles[role.Code] = true
+		if role.DataScope == entity.DataScopeCustom {
+			customScopeRoles[role.Code] = true
+		}
+	}
+	incomingRoles := make(map[string]bool)
+	for _, role := range bundle.Roles {
+		if role.Code == "" {
+			return errors.New("角色编码不能为空")
+		}
+		if incomingRoles[role.Code] {
+			return fmt.Errorf("角色 %s 重复", role.Code)
+		}
+		// 自定义数据权限的部门不在策略包中，设置后角色没有任何部门，只允许保留目标环境已有的自定义数据权限
+		if role.DataScope == entity.DataScopeCustom && !customScopeRoles[role.Code] {
+			return fmt.Errorf("角色 %s 的自定义数据权限依赖各环境的部门，不能通过策略包设置，请在目标环境中配置", role.Code)
+		}
+		incomingRoles[role.Code] = true
+		roles[role.Code] = true
+	}
+
+	for _, menu := range bundle.Menus {
+		if menu.Parent != "" && !menus[menu.Parent] {
+			return fmt.Errorf("菜单 %s 的父菜单 %s 不存在", menu.Name, menu.Parent)
+		}
+		if err := checkPolicyRefs("菜单 "+menu.Name, "API", menu.APIs, apis); err != nil {
+			return err
+		}
+	}
+	for _, btnPerm := range bundle.BtnPerms {
+		if btnPerm.Menu != "" && !menus[btnPerm.Menu] {
+			return fmt.Errorf("按钮权限 %s 所属菜单 %s 不存在", btnPerm.Code, btnPerm.Menu)
+		}
+		if err := checkPolicyRefs("按钮权限 "+btnPerm.Code, "API", btnPerm.APIs, apis); err != nil {
+			return err
+		}
+	}
+	for _, role := range bundle.Roles {
+		if role.Parent != "" && !roles[role.Parent] {
+			return fmt.Errorf("角色 %s 的父角色 %s 不存在", role.Code, role.Parent)
+		}
+		if err := checkPolicyRefs("角色 "+role.Code, "菜单", role.Menus, menus); err != nil {
+			return err
+		}
+		if err := checkPolicyRefs("角色 "+role.Code, "API", role.APIs, apis); err != nil {
+			return err
+		}
+		if err := checkPolicyRefs("角色 "+role.Code, "按钮权限", role.BtnPerms, btnPerms); err != nil {
+			return err
+		}
+		if err := checkPolicyRefs("角色 "+role.Code, "权限组", role.PermissionGroups, groups); err != nil {
+			return err
+		}
+	}
+	for _, group := range bundle.PermissionGroups {
+		if err := checkPolicyRefs("权限组 "+group.Name, "API", group.APIs, apis); err != nil {
+			return err
+		}
+		if err := checkPolicyRefs("权限组 "+group.Name, "按钮权限", group.BtnPerms, btnPerms); err != nil {
+			return err
+		}
+	}
+
+	rules := make(map[string]bool)
+	for i := range bundle.Rules {
+		rule := &bundle.Rules[i]
+		if rule.Name == "" {
+			return errors.New("规则名称不能为空")
+		}
+		if rules[rule.Name] {
+			return fmt.Errorf("规则 %s 重复", rule.Name)
+		}
+		rules[rule.Name] = true
+		if rule.Effect != entity.PermissionEffectAllow && rule.Effect != entity.PermissionEffectDeny {
+			return fmt.Errorf("规则 %s 的效果只能是 allow 或 deny", rule.Name)
+		}
+		if err := abac.ValidatePathPattern(rule.APIPath); err != nil {
+			return fmt.Errorf("规则 %s: %w", rule.Name, err)
+		}
+		method, err := abac.ValidateMethod(rule.Method)
+		if err != nil {
+			return fmt.Errorf("规则 %s: %w", rule.Name, err)
+		}
+		rule.Method = method
+		if err := abac.Validate(rule.Conditions); err != nil {
+			return fmt.Errorf("规则 %s 的条件无效: %w", rule.Name, err)
+		}
+	}
+
+	return checkRoleCycles(bundle, current)
+}
+
+// checkPolicyRefs 校验引用列表中的每一项都存在
+func checkPolicyRefs(owner, kind string, refs []string, known map[string]bool) error {
+	for _, ref := range refs {
+		if !known[ref] {
+			return fmt.Errorf("%s 引用的%s %s 不存在", owner, kind, ref)
+		}
+	}
+	return nil
+}
+
+// checkRoleCycles 校验导入后的角色继承关系不存在环
+func checkRoleCycles(bundle, current *entity.PolicyBundle) error {
+	parents := make(map[string]string)
+	for _, role := range current.Roles {
+		parents[role.Code] = role.Parent
+	}
+	for _, role := range bundle.Roles {
+		parents[role.Code] = role.Parent
+	}
+	for _, role := range bundle.Roles {
+		visited := map[string]bool{role.Code: true}
+		for parent := parents[role.Code]; parent != ""; parent = parents[parent] {
+			if visited[parent] {
+				return fmt.Errorf("角色 %s 的继承关系存在循环", role.Code)
+			}
+			visited[parent] = true
+		}
+	}
+	return nil
+}
+
+// affectedApprovalRequiredRoles 返回受差异影响且开启了变更审批的角色编码
+// 除角色自身的变更外，角色引用的菜单、按钮权限、API、权限组发生变更，或菜单、按钮权限、权限组关联的对象发生变更，
+// 都会改变角色的实际权限；子角色继承父角色的权限，父角色受影响时子角色同样受影响
+// ABAC 规则按路径和条件作用于所有用户，允许规则可以授予 RBAC 之外的权限，规则有差异时所有开启审批的角色都受影响
+func affectedApprovalRequiredRoles(current *entity.PolicyBundle, changes []entity.PolicyChange) []string {
+	changed := make(map[string]map[string]bool)
+	for _, change := range changes {
+		if changed[change.Kind] == nil {
+			changed[change.Kind] = make(map[string]bool)
+		}
+		changed[change.Kind][change.Key] = true
+	}
+	changedAPIs := changed[entity.PolicyKindAPI]
+
+	menus := make(map[string]bool)
+	for _, menu := range current.Menus {
+		if changed[entity.PolicyKindMenu][menu.Name] || refsAny(menu.APIs, changedAPIs) {
+			menus[menu.Name] = true
+		}
+	}
+	btnPerms := make(map[string]bool)
+	for _, btnPerm := range current.BtnPerms {
+		if changed[entity.PolicyKindBtnPerm][btnPerm.Code] || refsAny(btnPerm.APIs, changedAPIs) {
+			btnPerms[btnPerm.Code] = true
+		}
+	}
+
+	groups := make(map[string]bool)
+	for _, group := range current.PermissionGroups {
+		if changed[entity.PolicyKindPermissionGroup][group.Name] || refsAny(group.APIs, changedAPIs) || refsAny(group.BtnPerms, btnPerms) {
+			groups[group.Name] = true
+		}
+	}
+	rulesChanged := len(changed[entity.PolicyKindRule]) > 0
+
+	parents := make(map[string]string, len(current.Roles))
+	affected := make(map[string]bool)
+	for _, role := range current.Roles {
+		parents[role.Code] = role.Parent
+		if changed[entity.PolicyKindRole][role.Code] || refsAny(role.Menus, menus) || refsAny(role.BtnPerms, btnPerms) ||
+			refsAny(role.APIs, changedAPIs) || refsAny(role.PermissionGroups, groups) {
+			affected[role.Code] = true
+		}
+	}
+
+	var roles []string
+	for _, role := range current.Roles {
+		if !role.ApprovalRequired {
+			continue
+		}
+		if rulesChanged {
+			roles = append(roles, role.Code)
+			continue
+		}
+		// 当前环境的继承关系已校验无环，visited 只用于防御异常数据
+		visited := make(map[string]bool)
+		for code := role.Code; code != "" && !visited[code]; code = parents[code] {
+			visited[code] = true
+			if affected[code] {
+				roles = append(roles, role.Code)
+				break
+			}
+		}
+	}
+	sort.Strings(roles)
+	return roles
+}
+
+// refsAny 检查引用列表中是否有任一项在集合中
+func refsAny(refs []string, keys map[string]bool) bool {
+	for _, ref := range refs {
+		if keys[ref] {
+			return true
+		}
+	}
+	return false
+}
+
+// diffPolicyBundle 比较策略包与当前环境，返回需要新建或更新的对象，顺序与写入顺序一致
+func diffPolicyBundle(current, bundle *entity.PolicyBundle) []entity.PolicyChange {
+	var changes []entity.PolicyChange
+
+	currentAPIs := make(map[string]interface{}, len(current.APIs))
+	for _, item := range current.APIs {
+		currentAPIs[item.Key()] = item
+	}
+	for _, item := range bundle.APIs {
+		changes = appendPolicyChange(changes, entity.PolicyKindAPI, item.Key(), currentAPIs, item)
+	}
+
+	currentMenus := make(map[string]interface{}, len(current.Menus))
+	for _, item := range current.Menus {
+		currentMenus[item.Name] = item
+	}
+	for _, item := range bundle.Menus {
+		changes = appendPolicyChange(changes, entity.PolicyKindMenu, item.Name, currentMenus, item)
+	}
+
+	currentBtnPerms := make(map[string]interface{}, len(current.BtnPerms))
+	for _, item := range current.BtnPerms {
+		currentBtnPerms[item.Code] = item
+	}
+	for _, item := range bundle.BtnPerms {
+		changes = appendPolicyChange(changes, entity.PolicyKindBtnPerm, item.Code, currentBtnPerms, item)
+	}
+
+	currentGroups := make(map[string]interface{}, len(current.PermissionGroups))
+	for _, item := range current.PermissionGroups {
+		currentGroups[item.Name] = item
+	}
+	for _, item := range bundle.PermissionGroups {
+		changes = appendPolicyChange(changes, entity.PolicyKindPermissionGroup, item.Name, currentGroups, item)
+	}
+
+	currentRules := make(map[string]interface{}, len(current.Rules))
+	for _, item := range current.Rules {
+		currentRules[item.Name] = item
+	}
+	for _, item := range bundle.Rules {
+		changes = appendPolicyChange(changes, entity.PolicyKindRule, item.Name, currentRules, item)
+	}
+
+	currentRoles := make(map[string]interface{}, len(current.Roles))
+	for _, item := range current.Roles {
+		currentRoles[item.Code] = item
+	}
+	for _, item := range bundle.Roles {
+		changes = appendPolicyChange(changes, entity.PolicyKindRole, item.Code, currentRoles, item)
+	}
+
+	return changes
+}
+
+// appendPolicyChange 对象在当前环境不存在时记为新建，存在且字段有差异时记为更新并列出差异字段
+func appendPolicyChange(changes []entity.PolicyChange, kind, key string, current map[string]interface{}, incoming interface{}) []entity.PolicyChange {
+	existing, ok := current[key]
+	if !ok {
+		return append(changes, entity.PolicyChange{Kind: kind, Key: key, Action: entity.PolicyActionCreate})
+	}
+	fields := diffPolicyFields(existing, incoming)
+	if len(fields) == 0 {
+		return changes
+	}
+	return append(changes, entity.PolicyChange{Kind: kind, Key: key, Action: entity.PolicyActionUpdate, Fields: fields})
+}
+
+// diffPolicyFields 按策略包中的字段名比较两个对象，返回有差异的字段
+func diffPolicyFields(a, b interface{}) []string {
+	left := policyFieldMap(a)
+	right := policyFieldMap(b)
+	var fields []string
+	for name, value := range right {
+		if !reflect.DeepEqual(left[name], value) {
+			fields = append(fields, name)
+		}
+	}
+	for name := range left {
+		if _, ok := right[name]; !ok {
+			fields = append(fields, name)
+		}
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// policyFieldMap 将对象按 JSON 字段名展开，空引用列表与缺省等价
+func policyFieldMap(v interface{}) map[string]interface{} {
+	data, _ := json.Marshal(v)
+	fields := make(map[string]interface{})
+	json.Unmarshal(data, &fields)
+	for name, value := range fields {
+		if list, ok := value.([]interface{}); ok && len(list) == 0 {
+			delete(fields, name)
+		}
+		if value == nil {
+			delete(fields, name)
+		}
+	}
+	return fields
+}
@@ -0,0 +1,491 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ix-pay/ixpay-pro/internal/config"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/entity"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/repo"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/observability/logger"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/persistence/cache"
+)
+
+const (
+	// defaultMaxElevationHours 未配置时单次提权申请的最长时长（小时）
+	defaultMaxElevationHours = 24
+
+	roleGrantLogModule     = "role_grant" // 权限日志模块
+	roleGrantLogTargetType = "role"       // 权限日志目标类型，与角色权限日志一同按角色查询
+)
+
+// 限时角色授予的权限日志操作类型
+const (
+	RoleGrantOpGrant    = "grant_role"        // 直接授予限时角色
+	RoleGrantOpRequest  = "request_elevation" // 申请临时提权
+	RoleGrantOpApprove  = "approve_elevation" // 批准提权申请
+	RoleGrantOpReject   = "reject_elevation"  // 拒绝提权申请
+	RoleGrantOpActivate = "activate_grant"    // 到达生效时间，用户加入角色
+	RoleGrantOpExpire   = "expire_grant"      // 到期自动收回
+	RoleGrantOpRevoke   = "revoke_grant"      // 到期前手动收回
+)
+
+// RoleGrantService 限时角色授予服务
+// 授予生效时将用户加入角色，到期或收回时移出角色，判定缓存随之失效，每次状态变化写入权限日志
+// 移出角色时如果该角色是用户的当前角色，同时清除当前角色，用户回到登录时的角色
+// 授予、申请、审批时和生效前都校验静态职责分离约束，计划生效时违反约束的授予直接收回
+// 同一用户在同一角色上同时只能有一个未结束的限时授予；已长期拥有的角色不能再限时授予，
+// 因此到期收回时直接将用户移出角色，限时授予期间也不要再长期分配同一角色
+type RoleGrantService struct {
+	repo              repo.RoleGrantRepository
+	roleRepo          repo.RoleRepository
+	userRepo          repo.UserRepository
+	permissionLogRepo repo.PermissionLogRepository
+	decisions         *PermissionDecisionService
+	sod               *SoDService
+	cache             cache.Cache
+	maxElevationHours int
+	log               logger.Logger
+}
+
+// NewRoleGrantService 创建限时角色授予服务实例
+func NewRoleGrantService(
+	repo repo.RoleGrantRepository,
+	roleRepo repo.RoleRepository,
+	userRepo repo.UserRepository,
+	permissionLogRepo repo.PermissionLogRepository,
+	decisions *PermissionDecisionService,
+	sod *SoDService,
+	cache cache.Cache,
+	cfg *config.Config,
+	log logger.Logger,
+) *RoleGrantService {
+	maxHours := defaultMaxElevationHours
+	if cfg != nil && cfg.RoleGrant.MaxElevationHours > 0 {
+		maxHours = cfg.RoleGrant.MaxElevationHours
+	}
+	return &RoleGrantService{
+		repo:              repo,
+		roleRepo:          roleRepo,
+		userRepo:          userRepo,
+		permissionLogRepo: permissionLogRepo,
+		decisions:         decisions,
+		sod:               sod,
+		cache:             cache,
+		maxElevationHours: maxHours,
+		log:               log,
+	}
+}
+
+// GrantRole 直接授予限时角色
+// validFrom 为空时立即生效；生效时间未到的授予由定时任务在生效时间到达后将用户加入角色
+func (s *RoleGrantService) GrantRole(userID, roleID int64, validFrom, validUntil time.Time, justification string, operatorID int64) (*entity.RoleGrant, error) {
+	now := time.Now()
+	if validFrom.IsZero() {
+		validFrom = now
+	}
+	if !validUntil.After(validFrom) || !validUntil.After(now) {
+		return nil, errors.New("到期时间必须晚于生效时间和当前时间")
+	}
+	if err := s.checkGrantable(userID, roleID); err != nil {
+		return nil, err
+	}
+	// 开启变更审批的角色不能直接授予，只能通过提权申请经他人审批获得
+	if role, err := s.roleRepo.GetByID(roleID); err == nil && role.ApprovalRequired {
+		return nil, errors.New("该角色的变更需要审批，请通过提权申请获取")
+	}
+
+	grant := &entity.RoleGrant{
+		UserID:        userID,
+		RoleID:        roleID,
+		ValidFrom:     validFrom,
+		ValidUntil:    validUntil,
+		Justification: strings.TrimSpace(justification),
+		Status:        entity.RoleGrantStatusScheduled,
+		ApprovedBy:    operatorID,
+		ApprovedAt:    &now,
+		CreatedBy:     operatorID,
+		UpdatedBy:     operatorID,
+	}
+	if err := s.repo.Create(grant); err != nil {
+		s.log.Error("创建限时角色授予失败", "error", err, "user_id", userID, "role_id", roleID)
+		return nil, err
+	}
+	s.writeLog(operatorID, RoleGrantOpGrant, nil, grant)
+
+	if !validFrom.After(now) {
+		if err := s.activate(grant, operatorID); err != nil {
+			return nil, err
+		}
+		s.decisions.Invalidate("授予限时角色")
+	}
+
+	s.log.Info("授予限时角色成功", "grant_id", grant.ID, "user_id", userID, "role_id", roleID, "valid_until", validUntil)
+	return grant, nil
+}
+
+// RequestElevation 申请临时提权，审批通过后在 hours 小时内拥有该角色
+func (s *RoleGrantService) RequestElevation(userID, roleID int64, hours int, justification string) (*entity.RoleGrant, error) {
+	if hours <= 0 || hours > s.maxElevationHours {
+		return nil, fmt.Errorf("提权时长必须在 1 到 %d 小时之间", s.maxElevationHours)
+	}
+	justification = strings.TrimSpace(justification)
+	if justification == "" {
+		return nil, errors.New("请填写提权理由")
+	}
+	if err := s.checkGrantable(userID, roleID); err != nil {
+		return nil, err
+	}
+
+	pending, err := s.repo.ListByUserRole(userID, roleID, entity.RoleGrantStatusPending)
+	if err != nil {
+		return nil, err
+	}
+	if len(pending) > 0 {
+		return nil, errors.New("已有待审批的提权申请")
+	}
+
+	grant := &entity.RoleGrant{
+		UserID:         userID,
+		RoleID:         roleID,
+		Justification:  justification,
+		Status:         entity.RoleGrantStatusPending,
+		Elevation:      true,
+		RequestedHours: hours,
+		CreatedBy:      userID,
+		UpdatedBy:      userID,
+	}
+	if err := s.repo.Create(grant); err != nil {
+		s.log.Error("创建提权申请失败", "error", err, "user_id", userID, "role_id", roleID)
+		return nil, err
+	}
+	s.writeLog(userID, RoleGrantOpRequest, nil, grant)
+
+	s.log.Info("提交提权申请成功", "grant_id", grant.ID, "user_id", userID, "role_id", roleID, "hours", hours)
+	return grant, nil
+}
+
+// ApproveElevation 批准提权申请，从审批时起按申请时长生效
+func (s *RoleGrantService) ApproveElevation(id, approverID int64, comment string) (*entity.RoleGrant, error) {
+	grant, err := s.getPending(id, approverID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkGrantable(grant.UserID, grant.RoleID); err != nil {
+		return nil, err
+	}
+
+	old := *grant
+	now := time.Now()
+	grant.ValidFrom = now
+	grant.ValidUntil = now.Add(time.Duration(grant.RequestedHours) * time.Hour)
+	grant.Status = entity.RoleGrantStatusScheduled
+	grant.ApprovedBy = approverID
+	grant.ApprovedAt = &now
+	grant.ReviewComment = strings.TrimSpace(comment)
+	grant.UpdatedBy = approverID
+	if err := s.repo.Update(grant); err != nil {
+		s.log.Error("批准提权申请失败", "error", err, "grant_id", id)
+		return nil, err
+	}
+	s.writeLog(approverID, RoleGrantOpApprove, &old, grant)
+
+	if err := s.activate(grant, approverID); err != nil {
+		return nil, err
+	}
+	s.decisions.Invalidate("批准提权申请")
+
+	s.log.Info("批准提权申请成功", "grant_id", id, "user_id", grant.UserID, "role_id", grant.RoleID, "approver_id", approverID)
+	return grant, nil
+}
+
+// RejectElevation 拒绝提权申请
+func (s *RoleGrantService) RejectElevation(id, approverID int64, comment string) (*entity.RoleGrant, error) {
+	grant, err := s.getPending(id, approverID)
+	if err != nil {
+		return nil, err
+	}
+
+	old := *grant
+	now := time.Now()
+	grant.Status = entity.RoleGrantStatusRejected
+	grant.ApprovedBy = approverID
+	grant.ApprovedAt = &now
+	grant.ReviewComment = strings.TrimSpace(comment)
+	grant.UpdatedBy = approverID
+	if err := s.repo.Update(grant); err != nil {
+		s.log.Error("拒绝提权申请失败", "error", err, "grant_id", id)
+		return nil, err
+	}
+	s.writeLog(approverID, RoleGrantOpReject, &old, grant)
+
+	s.log.Info("拒绝提权申请成功", "grant_id", id, "user_id", grant.UserID, "role_id", grant.RoleID, "approver_id", approverID)
+	return grant, nil
+}
+
+// RevokeGrant 在到期前收回限时授予
+func (s *RoleGrantService) RevokeGrant(id, operatorID int64, reason string) (*entity.RoleGrant, error) {
+	grant, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, errors.New("限时授予不存在")
+	}
+	if !grant.IsOpen() {
+		return nil, errors.New("只能收回待生效或生效中的授予")
+	}
+
+	if err := s.close(grant, entity.RoleGrantStatusRevoked, operatorID, strings.TrimSpace(reason), time.Now()); err != nil {
+		return nil, err
+	}
+	s.decisions.Invalidate("收回限时角色")
+
+	s.log.Info("收回限时角色成功", "grant_id", id, "user_id", grant.UserID, "role_id", grant.RoleID, "operator_id", operatorID)
+	return grant, nil
+}
+
+// ProcessDueGrants 处理到达生效时间和到期时间的授予，返回生效和收回的数量
+// 先收回到期的授予，生效时间和到期时间都已过的授予直接标记为到期，不再加入角色
+func (s *RoleGrantService) ProcessDueGrants(now time.Time) (activated, expired int, err error) {
+	defer func() {
+		if activated+expired > 0 {
+			s.decisions.Invalidate("限时角色生效或到期")
+		}
+	}()
+
+	due, err := s.repo.ListDueForExpiry(now)
+	if err != nil {
+		return 0, 0, err
+	}
+	for _, grant := range due {
+		if err := s.close(grant, entity.RoleGrantStatusExpired, 0, "", now); err != nil {
+			return activated, expired, fmt.Errorf("收回 ID 为 %d 的限时授予失败：%w", grant.ID, err)
+		}
+		expired++
+	}
+
+	due, err = s.repo.ListDueForActivation(now)
+	if err != nil {
+		return activated, expired, err
+	}
+	for _, grant := range due {
+		err := s.activate(grant, 0)
+		var conflict *SoDConflictError
+		if errors.As(err, &conflict) {
+			// 授予创建后用户获得了冲突的角色或新增了约束，收回该授予，不影响其他授予生效
+			s.log.Warn("限时授予生效时违反职责分离约束，已收回", "grant_id", grant.ID, "user_id", grant.UserID, "role_id", grant.RoleID, "constraint", conflict.Constraint.Name)
+			if err := s.close(grant, entity.RoleGrantStatusRevoked, 0, err.Error(), now); err != nil {
+				return activated, expired, fmt.Errorf("收回 ID 为 %d 的限时授予失败：%w", grant.ID, err)
+			}
+			continue
+		}
+		if err != nil {
+			return activated, expired, fmt.Errorf("生效 ID 为 %d 的限时授予失败：%w", grant.ID, err)
+		}
+		activated++
+	}
+
+	return activated, expired, nil
+}
+
+// GetRoleGrantList 分页查询限时授予
+func (s *RoleGrantService) GetRoleGrantList(page, pageSize int, filters map[string]interface{}) ([]*entity.RoleGrant, int64, error) {
+	return s.repo.List(page, pageSize, filters)
+}
+
+// checkGrantable 检查用户和角色是否存在、角色是否启用、用户是否已拥有该角色，以及加入角色后是否违反静态职责分离约束
+func (s *RoleGrantService) checkGrantable(userID, roleID int64) error {
+	if _, err := s.userRepo.GetByID(userID); err != nil {
+		return errors.New("用户不存在")
+	}
+	role, err := s.roleRepo.GetByID(roleID)
+	if err != nil {
+		return errors.New("角色不存在")
+	}
+	if !role.IsActive() {
+		return errors.New("角色已禁用")
+	}
+
+	open, err := s.repo.ListByUserRole(userID, roleID, entity.RoleGrantStatusScheduled, entity.RoleGrantStatusActive)
+	if err != nil {
+		return err
+	}
+	if len(open) > 0 {
+		return errors.New("用户在该角色上已有未结束的限时授予")
+	}
+	exists, err := s.roleRepo.ExistsUserInRole(roleID, userID)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return errors.New("用户已长期拥有该角色")
+	}
+	return s.sod.CheckAddRole(userID, roleID)
+}
+
+// getPending 获取待审批的提权申请，申请人不能审批自己的申请
+func (s *RoleGrantService) getPending(id, approverID int64) (*entity.RoleGrant, error) {
+	grant, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, errors.New("提权申请不存在")
+	}
+	if !grant.IsPending() {
+		return nil, errors.New("只能审批待审批的提权申请")
+	}
+	if grant.UserID == approverID {
+		return nil, errors.New("不能审批自己的提权申请")
+	}
+	return grant, nil
+}
+
+// activate 将用户加入角色并标记为生效中，operatorID 为 0 表示由定时任务执行
+// 加入前再次校验静态职责分离约束，授予创建到生效之间用户的角色和约束都可能变化
+func (s *RoleGrantService) activate(grant *entity.RoleGrant, operatorID int64) error {
+	if err := s.sod.CheckAddRole(grant.UserID, grant.RoleID); err != nil {
+		return err
+	}
+	if err := s.roleRepo.AddUserToRole(grant.RoleID, grant.UserID); err != nil {
+		s.log.Error("限时授予加入角色失败", "error", err, "grant_id", grant.ID)
+		return err
+	}
+
+	old := *grant
+	grant.Status = entity.RoleGrantStatusActive
+	grant.UpdatedBy = operatorID
+	if err := s.repo.Update(grant); err != nil {
+		s.log.Error("更新限时授予状态失败", "error", err, "grant_id", grant.ID)
+		return err
+	}
+	s.writeLog(operatorID, RoleGrantOpActivate, &old, grant)
+	return nil
+}
+
+// close 结束授予，生效中的授予同时将用户移出角色
+func (s *RoleGrantService) close(grant *entity.RoleGrant, status string, operatorID int64, reason string, now time.Time) error {
+	if grant.Status == entity.RoleGrantStatusActive {
+		if err := s.roleRepo.RemoveUserFromRole(grant.RoleID, grant.UserID); err != nil {
+			s.log.Error("限时授予移出角色失败", "error", err, "grant_id", grant.ID)
+			return err
+		}
+		s.clearCurrentRole(grant)
+	}
+
+	old := *grant
+	grant.Status = status
+	grant.RevokedBy = operatorID
+	grant.RevokedAt = &now
+	if reason != "" {
+		grant.ReviewComment = reason
+	}
+	grant.UpdatedBy = operatorID
+	if err := s.repo.Update(grant); err != nil {
+		s.log.Error("更新限时授予状态失败", "error", err, "grant_id", grant.ID)
+		return err
+	}
+
+	operation := RoleGrantOpRevoke
+	if status == entity.RoleGrantStatusExpired {
+		operation = RoleGrantOpExpire
+	}
+	s.writeLog(operatorID, operation, &old, grant)
+	return nil
+}
+
+// clearCurrentRole 用户的当前角色是被移出的角色时清除当前角色，避免继续以该角色访问
+func (s *RoleGrantService) clearCurrentRole(grant *entity.RoleGrant) {
+	currentRoleKey := fmt.Sprintf("user:current_role:%d", grant.UserID)
+	value, err := s.cache.Get(currentRoleKey)
+	if err != nil || value != fmt.Sprintf("%d", grant.RoleID) {
+		return
+	}
+	if err := s.cache.Delete(currentRoleKey); err != nil {
+		s.log.Warn("清除用户当前角色失败", "error", err, "user_id", grant.UserID, "role_id", grant.RoleID)
+		return
+	}
+	s.log.Info("已清除到期或收回的当前角色", "grant_id", grant.ID, "user_id", grant.UserID, "role_id", grant.RoleID)
+}
+
+// writeLog 写入权限日志，失败只记录警告，不影响授予本身
+func (s *RoleGrantService) writeLog(operatorID int64, operation string, old, grant *entity.RoleGrant) {
+	username := "system"
+	if operatorID != 0 {
+		if user, err := s.userRepo.GetByID(operatorID); err == nil {
+			username = user.Username
+		}
+	}
+
+	record := &entity.PermissionLog{
+		UserID:     operatorID,
+		Username:   username,
+		Operation:  operation,
+		Module:     roleGrantLogModule,
+		TargetType: roleGrantLogTargetType,
+		TargetID:   grant.RoleID,
+		NewValue:   roleGrantSnapshot(grant),
+	}
+	if old != nil {
+		record.OldValue = roleGrantSnapshot(old)
+	}
+	if err := s.permissionLogRepo.Create(record); err != nil {
+		s.log.Warn("写入权限日志失败", "error", err, "operation", operation, "grant_id", grant.ID)
+	}
+}
+
+// roleGrantSnapshot 权限日志中记录的授予内容
+func roleGrantSnapshot(grant *entity.RoleGrant) string {
+	snapshot := map[string]interface{}{
+		"grantId":       grant.ID,
+		"userId":        grant.UserID,
+		"roleId":        grant.RoleID,
+		"status":        grant.Status,
+		"justification": grant.Justification,
+	}
+	if !grant.ValidFrom.IsZero() {
+		snapshot["validFrom"] = grant.ValidFrom
+		snapshot["validUntil"] = grant.ValidUntil
+	}
+	if grant.Elevation {
+		snapshot["requestedHours"] = grant.RequestedHours
+	}
+	if grant.ReviewComment != "" {
+		snapshot["reviewComment"] = grant.ReviewComment
+	}
+	data, _ := json.Marshal(snapshot)
+	return string(data)
+}
+
+// RoleGrantExpiryTask 限时角色生效和到期检查任务
+type RoleGrantExpiryTask struct {
+	service *RoleGrantService
+	log     logger.Logger
+}
+
+// NewRoleGrantExpiryTask 创建限时角色生效和到期检查任务
+func NewRoleGrantExpiryTask(service *RoleGrantService, log logger.Logger) *RoleGrantExpiryTask {
+	return &RoleGrantExpiryTask{service: service, log: log}
+}
+
+// GetName 返回任务名称
+func (t *RoleGrantExpiryTask) GetName() string {
+	return "role_grant_expiry"
+}
+
+// GetGroup 返回任务分组
+func (t *RoleGrantExpiryTask) GetGroup() string {
+	return "system"
+}
+
+// Run 执行限时角色生效和到期检查
+func (t *RoleGrantExpiryTask) Run(ctx context.Context) error {
+	activated, expired, err := t.service.ProcessDueGrants(time.Now())
+	if err != nil {
+		t.log.Error("处理限时角色授予失败", "activated", activated, "expired", expired, "error", err)
+		return err
+	}
+	if activated+expired > 0 {
+		t.log.Info("处理限时角色授予完成", "activated", activated, "expired", expired)
+	}
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/ix-pay/ixpay-pro/internal/domain/base/entity"
@@ -22,6 +23,7 @@ type RolePermissionService struct {
 	btnPermRepo repo.BtnPermRepository
 	apiRepo     repo.APIRepository
 	cache       cache.Cache
+	roleService *RoleService
 	log         logger.Logger
 }
 
@@ -33,6 +35,7 @@ func NewRolePermissionService(
 	btnPermRepo repo.BtnPermRepository,
 	apiRepo repo.APIRepository,
 	cache cache.Cache,
+	roleService *RoleService,
 	log logger.Logger,
 ) *RolePermissionService {
 	return &RolePermissionService{
@@ -42,12 +45,19 @@ func NewRolePermissionService(
 		btnPermRepo: btnPermRepo,
 		apiRepo:     apiRepo,
 		cache:       cache,
+		roleService: roleService,
 		log:         log,
 	}
 }
 
 // SaveRolePermissions 保存角色权限（菜单、按钮、API）
+// 角色开启变更审批时不直接保存，差异提交为变更申请并返回 PendingApprovalError
 func (s *RolePermissionService) SaveRolePermissions(roleID int64, menuIds, btnPermIds, apiIds []int64, operatorID string) error {
+	operator, _ := strconv.ParseInt(operatorID, 10, 64)
+	if submitted, err := s.submitIfApprovalRequired(roleID, menuIds, btnPermIds, apiIds, operator); submitted || err != nil {
+		return err
+	}
+
 	// 1. 尝试获取分布式锁
 	lockKey := fmt.Sprintf("lock:role:%d", roleID)
 	lockAcquired := false
@@ -104,6 +114,54 @@ func (s *RolePermissionService) SaveRolePermissions(roleID int64, menuIds, btnPe
 	})
 }
 
+// submitIfApprovalRequired 角色开启变更审批时，将菜单、按钮权限和接口相对当前关联的差异分别提交为变更申请
+// 返回 true 表示角色需要审批、不应直接保存；有申请生成时返回第一个 PendingApprovalError
+func (s *RolePermissionService) submitIfApprovalRequired(roleID int64, menuIds, btnPermIds, apiIds []int64, operatorID int64) (bool, error) {
+	role, err := s.roleRepo.GetByID(roleID)
+	if err != nil {
+		return false, errors.New("角色不存在")
+	}
+	if !role.ApprovalRequired {
+		return false, nil
+	}
+
+	changes := []struct {
+		changeType string
+		ids        []int64
+	}{
+		{entity.PermissionChangeRoleMenus, menuIds},
+		{entity.PermissionChangeRoleBtnPerms, btnPermIds},
+		{entity.PermissionChangeRoleAPIs, apiIds},
+	}
+
+	var pendingErr error
+	for _, change := range changes {
+		before, err := s.roleService.currentRelationIDs(roleID, change.changeType)
+		if err != nil {
+			s.log.Error("获取角色当前关联失败", "error", err, "role_id", roleID, "change_type", change.changeType)
+			return true, err
+		}
+		target := make(map[int64]bool, len(change.ids))
+		for _, id := range change.ids {
+			target[id] = true
+		}
+		removed := filterIDs(before, func(id int64) bool { return !target[id] })
+
+		err = s.roleService.submitChangeIfRequired(role, change.changeType, change.ids, removed, operatorID)
+		var pending *PendingApprovalError
+		if errors.As(err, &pending) {
+			if pendingErr == nil {
+				pendingErr = err
+			}
+			continue
+		}
+		if err != nil {
+			return true, err
+		}
+	}
+	return true, pendingErr
+}
+
 // GetRolePermissions 获取角色权限详情
 func (s *RolePermissionService) GetRolePermissions(roleID int64) (menuIds []int64, btnPermIds []int64, apiIds []int64, err error) {
 	menus, err := s.roleRepo.GetMenusByRole(roleID)
@@ -18,11 +18,13 @@ type RoleService struct {
 	apiRepo             repo.APIRepository
 	btnPermRepo         repo.BtnPermRepository
 	permissionGroupRepo repo.PermissionGroupRepository
+	changeRepo          repo.PermissionChangeRequestRepository
+	sod                 *SoDService
 	log                 logger.Logger
 }
 
 // NewRoleService 创建角色服务实例
-func NewRoleService(roleRepo repo.RoleRepository, userRepo repo.UserRepository, menuRepo repo.MenuRepository, apiRepo repo.APIRepository, btnPermRepo repo.BtnPermRepository, permissionGroupRepo repo.PermissionGroupRepository, log logger.Logger) *RoleService {
+func NewRoleService(roleRepo repo.RoleRepository, userRepo repo.UserRepository, menuRepo repo.MenuRepository, apiRepo repo.APIRepository, btnPermRepo repo.BtnPermRepository, permissionGroupRepo repo.PermissionGroupRepository, changeRepo repo.PermissionChangeRequestRepository, sod *SoDService, log logger.Logger) *RoleService {
 	return &RoleService{
 		roleRepo:            roleRepo,
 		userRepo:            userRepo,
@@ -30,6 +32,8 @@ func NewRoleService(roleRepo repo.RoleRepository, userRepo repo.UserRepository,
 		apiRepo:             apiRepo,
 		btnPermRepo:         btnPermRepo,
 		permissionGroupRepo: permissionGroupRepo,
+		changeRepo:          changeRepo,
+		sod:                 sod,
 		log:                 log,
 	}
 }
@@ -226,7 +230,7 @@ func (s *RoleService) GetAllRoles() ([]*entity.Role, error) {
 }
 
 // AssignUserToRole 分配用户到角色
-func (s *RoleService) AssignUserToRole(roleID, userID int64) error {
+func (s *RoleService) AssignUserToRole(roleID, userID, operatorID int64) error {
 	// 检查角色是否存在
 	role, err := s.roleRepo.GetByID(roleID)
 	if err != nil {
@@ -234,6 +238,16 @@ func (s *RoleService) AssignUserToRole(roleID, userID int64) error {
 		return errors.New("角色不存在")
 	}
 
+	// 校验静态职责分离约束
+	if err := s.sod.CheckAddRole(userID, roleID); err != nil {
+		return err
+	}
+
+	// 角色开启变更审批时只生成待审批申请
+	if err := s.submitChangeIfRequired(role, entity.PermissionChangeRoleUsers, []int64{userID}, nil, operatorID); err != nil {
+		return err
+	}
+
 	// 检查用户是否已在角色中
 	users, err := s.roleRepo.GetUsersByRole(roleID)
 	if err != nil {
@@ -258,7 +272,7 @@ func (s *RoleService) AssignUserToRole(roleID, userID int64) error {
 }
 
 // RevokeUserFromRole 从角色中撤销用户
-func (s *RoleService) RevokeUserFromRole(roleID int64, userID int64) error {
+func (s *RoleService) RevokeUserFromRole(roleID, userID, operatorID int64) error {
 	// 检查角色是否存在
 	role, err := s.roleRepo.GetByID(roleID)
 	if err != nil {
@@ -266,6 +280,11 @@ func (s *RoleService) RevokeUserFromRole(roleID int64, userID int64) error {
 		return errors.New("角色不存在")
 	}
 
+	// 角色开启变更审批时只生成待审批申请
+	if err := s.submitChangeIfRequired(role, entity.PermissionChangeRoleUsers, nil, []int64{userID}, operatorID); err != nil {
+		return err
+	}
+
 	// 检查用户是否在角色中
 	users, err := s.roleRepo.GetUsersByRole(roleID)
 	if err != nil {
@@ -316,7 +335,7 @@ func (s *RoleService) GetRolesForUser(userID int64) ([]*entity.Role, error) {
 }
 
 // AssignMenuToRole 分配菜单到角色
-func (s *RoleService) AssignMenuToRole(roleID int64, menuID int64) error {
+func (s *RoleService) AssignMenuToRole(roleID, menuID, operatorID int64) error {
 	// 检查角色是否存在
 	role, err := s.roleRepo.GetByID(roleID)
 	if err != nil {
@@ -330,6 +349,11 @@ func (s *RoleService) AssignMenuToRole(roleID int64, menuID int64) error {
 		return errors.New("系统角色不允许修改权限")
 	}
 
+	// 角色开启变更审批时只生成待审批申请
+	if err := s.submitChangeIfRequired(role, entity.PermissionChangeRoleMenus, []int64{menuID}, nil, operatorID); err != nil {
+		return err
+	}
+
 	// 检查菜单是否已在角色中
 	menus, err := s.roleRepo.GetMenusByRole(roleID)
 	if err != nil {
@@ -410,7 +434,7 @@ func (s *RoleService) AssignMenuToRole(roleID int64, menuID int64) error {
 }
 
 // RevokeMenuFromRole 从角色中撤销菜单
-func (s *RoleService) RevokeMenuFromRole(roleID, menuID int64) error {
+func (s *RoleService) RevokeMenuFromRole(roleID, menuID, operatorID int64) error {
 	// 检查角色是否存在
 	role, err := s.roleRepo.GetByID(roleID)
 	if err != nil {
@@ -418,6 +442,11 @@ func (s *RoleService) RevokeMenuFromRole(roleID, menuID int64) error {
 		return errors.New("角色不存在")
 	}
 
+	// 角色开启变更审批时只生成待审批申请
+	if err := s.submitChangeIfRequired(role, entity.PermissionChangeRoleMenus, nil, []int64{menuID}, operatorID); err != nil {
+		return err
+	}
+
 	// 检查菜单是否在角色中
 	menus, err := s.roleRepo.GetMenusByRole(roleID)
 	if err != nil {
@@ -464,7 +493,7 @@ func (s *RoleService) GetMenusForRole(roleID int64) ([]*entity.Menu, error) {
 }
 
 // BatchAssignMenusToRole 批量分配菜单到角色
-func (s *RoleService) BatchAssignMenusToRole(roleID int64, menuIDs []int64) error {
+func (s *RoleService) BatchAssignMenusToRole(roleID int64, menuIDs []int64, operatorID int64) error {
 	// 检查角色是否存在
 	role, err := s.roleRepo.GetByID(roleID)
 	if err != nil {
@@ -481,6 +510,11 @@ func (s *RoleService) BatchAssignMenusToRole(roleID int64, menuIDs []int64) erro
 		}
 	}
 
+	// 角色开启变更审批时只生成待审批申请
+	if err := s.submitChangeIfRequired(role, entity.PermissionChangeRoleMenus, menuIDs, nil, operatorID); err != nil {
+		return err
+	}
+
 	// 批量添加菜单到角色（不清除现有菜单）
 	for _, menuID := range menuIDs {
 		if err := s.roleRepo.AddMenuToRole(roleID, menuID); err != nil {
@@ -494,7 +528,7 @@ func (s *RoleService) BatchAssignMenusToRole(roleID int64, menuIDs []int64) erro
 }
 
 // BatchRevokeMenusFromRole 批量从角色中撤销菜单
-func (s *RoleService) BatchRevokeMenusFromRole(roleID int64, menuIDs []int64) error {
+func (s *RoleService) BatchRevokeMenusFromRole(roleID int64, menuIDs []int64, operatorID int64) error {
 	// 检查角色是否存在
 	role, err := s.roleRepo.GetByID(roleID)
 	if err != nil {
@@ -502,6 +536,11 @@ func (s *RoleService) BatchRevokeMenusFromRole(roleID int64, menuIDs []int64) er
 		return errors.New("角色不存在")
 	}
 
+	// 角色开启变更审批时只生成待审批申请
+	if err := s.submitChangeIfRequired(role, entity.PermissionChangeRoleMenus, nil, menuIDs, operatorID); err != nil {
+		return err
+	}
+
 	// 批量从角色中撤销菜单
 	for _, menuID := range menuIDs {
 		if err := s.roleRepo.RemoveMenuFromRole(roleID, menuID); err != nil {
@@ -515,7 +554,7 @@ func (s *RoleService) BatchRevokeMenusFromRole(roleID int64, menuIDs []int64) er
 }
 
 // AssignAPIToRole 分配 API 到角色
-func (s *RoleService) AssignAPIToRole(roleID, apiID int64) error {
+func (s *RoleService) AssignAPIToRole(roleID, apiID, operatorID int64) error {
 	// 检查角色是否存在
 	role, err := s.roleRepo.GetByID(roleID)
 	if err != nil {
@@ -530,6 +569,11 @@ func (s *RoleService) AssignAPIToRole(roleID, apiID int64) error {
 		return errors.New("API 不存在")
 	}
 
+	// 角色开启变更审批时只生成待审批申请
+	if err := s.submitChangeIfRequired(role, entity.PermissionChangeRoleAPIs, []int64{apiID}, nil, operatorID); err != nil {
+		return err
+	}
+
 	// 检查 API 是否已分配给角色
 	apis, err := s.roleRepo.GetsByRole(roleID)
 	if err != nil {
@@ -554,7 +598,7 @@ func (s *RoleService) AssignAPIToRole(roleID, apiID int64) error {
 }
 
 // RevokeAPIFromRole 从角色中撤销 API
-func (s *RoleService) RevokeAPIFromRole(roleID, apiID int64) error {
+func (s *RoleService) RevokeAPIFromRole(roleID, apiID, operatorID int64) error {
 	// 检查角色是否存在
 	role, err := s.roleRepo.GetByID(roleID)
 	if err != nil {
@@ -569,6 +613,11 @@ func (s *RoleService) RevokeAPIFromRole(roleID, apiID int64) error {
 		return errors.New("API 不存在")
 	}
 
+	// 角色开启变更审批时只生成待审批申请
+	if err := s.submitChangeIfRequired(role, entity.PermissionChangeRoleAPIs, nil, []int64{apiID}, operatorID); err != nil {
+		return err
+	}
+
 	// 检查 API 是否已分配给角色
 	apis, err := s.roleRepo.GetsByRole(roleID)
 	if err != nil {
@@ -619,7 +668,7 @@ func (s *RoleService) GetRolesForAPI(apiID int64) ([]*entity.Role, error) {
 }
 
 // BatchAssignAPIsToRole 批量分配 API 到角色
-func (s *RoleService) BatchAssignAPIsToRole(roleID int64, apiIDs []int64) error {
+func (s *RoleService) BatchAssignAPIsToRole(roleID int64, apiIDs []int64, operatorID int64) error {
 	// 检查角色是否存在
 	role, err := s.roleRepo.GetByID(roleID)
 	if err != nil {
@@ -642,6 +691,11 @@ func (s *RoleService) BatchAssignAPIsToRole(roleID int64, apiIDs []int64) error
 		}
 	}
 
+	// 角色开启变更审批时只生成待审批申请
+	if err := s.submitChangeIfRequired(role, entity.PermissionChangeRoleAPIs, apiIDs, nil, operatorID); err != nil {
+		return err
+	}
+
 	// 批量添加 API 关联（不清除现有 API）
 	for _, apiID := range apiIDs {
 		if err := s.roleRepo.AddToRole(roleID, apiID); err != nil {
@@ -655,7 +709,7 @@ func (s *RoleService) BatchAssignAPIsToRole(roleID int64, apiIDs []int64) error
 }
 
 // BatchRevokeAPIsFromRole 批量从角色中撤销 API
-func (s *RoleService) BatchRevokeAPIsFromRole(roleID int64, apiIDs []int64) error {
+func (s *RoleService) BatchRevokeAPIsFromRole(roleID int64, apiIDs []int64, operatorID int64) error {
 	// 检查角色是否存在
 	role, err := s.roleRepo.GetByID(roleID)
 	if err != nil {
@@ -663,6 +717,11 @@ func (s *RoleService) BatchRevokeAPIsFromRole(roleID int64, apiIDs []int64) erro
 		return errors.New("角色不存在")
 	}
 
+	// 角色开启变更审批时只生成待审批申请
+	if err := s.submitChangeIfRequired(role, entity.PermissionChangeRoleAPIs, nil, apiIDs, operatorID); err != nil {
+		return err
+	}
+
 	// 批量移除 API 关联
 	for _, apiID := range apiIDs {
 		if err := s.roleRepo.RemoveFromRole(roleID, apiID); err != nil {
@@ -696,7 +755,7 @@ func (s *RoleService) GetRolesForBtnPerm(btnPermID int64) ([]*entity.Role, error
 }
 
 // AssignToRole 分配接口路由到角色
-func (s *RoleService) AssignToRole(roleID, routeID int64) error {
+func (s *RoleService) AssignToRole(roleID, routeID, operatorID int64) error {
 	// 检查角色是否存在
 	role, err := s.roleRepo.GetByID(roleID)
 	if err != nil {
@@ -704,6 +763,11 @@ func (s *RoleService) AssignToRole(roleID, routeID int64) error {
 		return errors.New("角色不存在")
 	}
 
+	// 角色开启变更审批时只生成待审批申请
+	if err := s.submitChangeIfRequired(role, entity.PermissionChangeRoleAPIs, []int64{routeID}, nil, operatorID); err != nil {
+		return err
+	}
+
 	// 检查接口路由是否已在角色中
 	routes, err := s.roleRepo.GetsByRole(roleID)
 	if err != nil {
@@ -728,7 +792,7 @@ func (s *RoleService) AssignToRole(roleID, routeID int64) error {
 }
 
 // RevokeFromRole 从角色中撤销接口路由
-func (s *RoleService) RevokeFromRole(roleID, routeID int64) error {
+func (s *RoleService) RevokeFromRole(roleID, routeID, operatorID int64) error {
 	// 检查角色是否存在
 	role, err := s.roleRepo.GetByID(roleID)
 	if err != nil {
@@ -736,6 +800,11 @@ func (s *RoleService) RevokeFromRole(roleID, routeID int64) error {
 		return errors.New("角色不存在")
 	}
 
+	// 角色开启变更审批时只生成待审批申请
+	if err := s.submitChangeIfRequired(role, entity.PermissionChangeRoleAPIs, nil, []int64{routeID}, operatorID); err != nil {
+		return err
+	}
+
 	// 检查接口路由是否在角色中
 	routes, err := s.roleRepo.GetsByRole(roleID)
 	if err != nil {
@@ -766,7 +835,7 @@ func (s *RoleService) RevokeFromRole(roleID, routeID int64) error {
 }
 
 // BatchAssignBtnPermsToRole 批量分配按钮权限给角色
-func (s *RoleService) BatchAssignBtnPermsToRole(roleID int64, btnPermIDs []int64) error {
+func (s *RoleService) BatchAssignBtnPermsToRole(roleID int64, btnPermIDs []int64, operatorID int64) error {
 	// 检查角色是否存在
 	role, err := s.roleRepo.GetByID(roleID)
 	if err != nil {
@@ -774,6 +843,11 @@ func (s *RoleService) BatchAssignBtnPermsToRole(roleID int64, btnPermIDs []int64
 		return errors.New("角色不存在")
 	}
 
+	// 角色开启变更审批时只生成待审批申请
+	if err := s.submitChangeIfRequired(role, entity.PermissionChangeRoleBtnPerms, btnPermIDs, nil, operatorID); err != nil {
+		return err
+	}
+
 	// 获取角色已有的按钮权限
 	existingBtnPerms, err := s.roleRepo.GetBtnPermsByRole(roleID)
 	if err != nil {
@@ -812,7 +886,7 @@ func (s *RoleService) BatchAssignBtnPermsToRole(roleID int64, btnPermIDs []int64
 }
 
 // BatchRevokeBtnPermsFromRole 批量从角色中撤销按钮权限
-func (s *RoleService) BatchRevokeBtnPermsFromRole(roleID int64, btnPermIDs []int64) error {
+func (s *RoleService) BatchRevokeBtnPermsFromRole(roleID int64, btnPermIDs []int64, operatorID int64) error {
 	// 检查角色是否存在
 	role, err := s.roleRepo.GetByID(roleID)
 	if err != nil {
@@ -820,6 +894,11 @@ func (s *RoleService) BatchRevokeBtnPermsFromRole(roleID int64, btnPermIDs []int
 		return errors.New("角色不存在")
 	}
 
+	// 角色开启变更审批时只生成待审批申请
+	if err := s.submitChangeIfRequired(role, entity.PermissionChangeRoleBtnPerms, nil, btnPermIDs, operatorID); err != nil {
+		return err
+	}
+
 	// 批量移除按钮权限关联
 	for _, btnPermID := range btnPermIDs {
 		if err := s.roleRepo.RemoveBtnPermFromRole(roleID, btnPermID); err != nil {
@@ -833,7 +912,7 @@ func (s *RoleService) BatchRevokeBtnPermsFromRole(roleID int64, btnPermIDs []int
 }
 
 // BatchAssignUsersToRole 批量分配用户到角色
-func (s *RoleService) BatchAssignUsersToRole(roleID int64, userIDs []int64) error {
+func (s *RoleService) BatchAssignUsersToRole(roleID int64, userIDs []int64, operatorID int64) error {
 	// 检查角色是否存在
 	role, err := s.roleRepo.GetByID(roleID)
 	if err != nil {
@@ -850,6 +929,18 @@ func (s *RoleService) BatchAssignUsersToRole(roleID int64, userIDs []int64) erro
 		}
 	}
 
+	// 校验静态职责分离约束，任一用户违反时整批不分配
+	for _, userID := range userIDs {
+		if err := s.sod.CheckAddRole(userID, roleID); err != nil {
+			return err
+		}
+	}
+
+	// 角色开启变更审批时只生成待审批申请
+	if err := s.submitChangeIfRequired(role, entity.PermissionChangeRoleUsers, userIDs, nil, operatorID); err != nil {
+		return err
+	}
+
 	// 批量添加用户角色关联
 	for _, userID := range userIDs {
 		// 检查用户是否已在角色中
@@ -890,7 +981,7 @@ func (s *RoleService) GetsForRole(roleID int64) ([]*entity.API, error) {
 }
 
 // AssignBtnPermToRole 分配按钮权限给角色
-func (s *RoleService) AssignBtnPermToRole(roleID, buttonID int64) error {
+func (s *RoleService) AssignBtnPermToRole(roleID, buttonID, operatorID int64) error {
 	// 检查角色是否存在
 	role, err := s.roleRepo.GetByID(roleID)
 	if err != nil {
@@ -905,6 +996,11 @@ func (s *RoleService) AssignBtnPermToRole(roleID, buttonID int64) error {
 		return err
 	}
 
+	// 角色开启变更审批时只生成待审批申请
+	if err := s.submitChangeIfRequired(role, entity.PermissionChangeRoleBtnPerms, []int64{buttonID}, nil, operatorID); err != nil {
+		return err
+	}
+
 	// 检查按钮权限是否已在角色中
 	buttons, err := s.roleRepo.GetBtnPermsByRole(roleID)
 	if err != nil {
@@ -929,7 +1025,7 @@ func (s *RoleService) AssignBtnPermToRole(roleID, buttonID int64) error {
 }
 
 // RevokeBtnPermFromRole 从角色中撤销按钮权限
-func (s *RoleService) RevokeBtnPermFromRole(roleID, buttonID int64) error {
+func (s *RoleService) RevokeBtnPermFromRole(roleID, buttonID, operatorID int64) error {
 	// 检查角色是否存在
 	role, err := s.roleRepo.GetByID(roleID)
 	if err != nil {
@@ -937,6 +1033,11 @@ func (s *RoleService) RevokeBtnPermFromRole(roleID, buttonID int64) error {
 		return errors.New("角色不存在")
 	}
 
+	// 角色开启变更审批时只生成待审批申请
+	if err := s.submitChangeIfRequired(role, entity.PermissionChangeRoleBtnPerms, nil, []int64{buttonID}, operatorID); err != nil {
+		return err
+	}
+
 	// 检查按钮权限是否在角色中
 	buttons, err := s.roleRepo.GetBtnPermsByRole(roleID)
 	if err != nil {
@@ -987,7 +1088,7 @@ func (s *RoleService) GetRolesFor(routeID int64) ([]*entity.Role, error) {
 }
 
 // BatchRevokeUsersFromRole 批量从角色中撤销用户
-func (s *RoleService) BatchRevokeUsersFromRole(roleID int64, userIDs []int64) error {
+func (s *RoleService) BatchRevokeUsersFromRole(roleID int64, userIDs []int64, operatorID int64) error {
 	// 检查角色是否存在
 	role, err := s.roleRepo.GetByID(roleID)
 	if err != nil {
@@ -995,6 +1096,11 @@ func (s *RoleService) BatchRevokeUsersFromRole(roleID int64, userIDs []int64) er
 		return errors.New("角色不存在")
 	}
 
+	// 角色开启变更审批时只生成待审批申请
+	if err := s.submitChangeIfRequired(role, entity.PermissionChangeRoleUsers, nil, userIDs, operatorID); err != nil {
+		return err
+	}
+
 	// 批量移除用户角色关联
 	for _, userID := range userIDs {
 		// 检查用户是否在角色中
@@ -1338,3 +1444,186 @@ func (s *RoleService) GetAllInheritedMenus(roleID int64) ([]*entity.Menu, error)
 
 	return result, nil
 }
+
+// PendingApprovalError 角色开启变更审批时返回，表示变更已记录为待审批申请、尚未生效
+type PendingApprovalError struct {
+	Request *entity.PermissionChangeRequest
+}
+
+// Error 实现 error 接口
+func (e *PendingApprovalError) Error() string {
+	return "角色权限变更需要审批，已提交变更申请"
+}
+
+// SetRoleApprovalRequired 设置角色权限变更是否需要审批
+// 开启立即生效；关闭本身也是一次需要审批的变更，避免绕过审批
+func (s *RoleService) SetRoleApprovalRequired(roleID int64, required bool, operatorID int64) error {
+	role, err := s.roleRepo.GetByID(roleID)
+	if err != nil {
+		s.log.Error("角色不存在", "role_id", roleID)
+		return errors.New("角色不存在")
+	}
+
+	if role.ApprovalRequired == required {
+		return nil
+	}
+
+	if !required {
+		return s.submitChangeIfRequired(role, entity.PermissionChangeApprovalRequired, nil, nil, operatorID)
+	}
+
+	role.ApprovalRequired = true
+	role.UpdatedBy = operatorID
+	if err := s.roleRepo.Update(role); err != nil {
+		s.log.Error("开启角色变更审批失败", "error", err, "role_id", roleID)
+		return err
+	}
+
+	s.log.Info("开启角色变更审批成功", "role_id", roleID, "role_name", role.Name, "operator_id", operatorID)
+	return nil
+}
+
+// submitChangeIfRequired 角色开启变更审批时将变更记录为待审批申请并返回 PendingApprovalError
+// 未开启审批或变更不会改变任何关联时返回 nil，由调用方直接执行
+func (s *RoleService) submitChangeIfRequired(role *entity.Role, changeType string, added, removed []int64, operatorID int64) error {
+	if !role.ApprovalRequired {
+		return nil
+	}
+
+	before, err := s.currentRelationIDs(role.ID, changeType)
+	if err != nil {
+		s.log.Error("获取角色当前关联失败", "error", err, "role_id", role.ID, "change_type", changeType)
+		return err
+	}
+
+	// 只保留确实会改变关联的 ID
+	existing := make(map[int64]bool, len(before))
+	for _, id := range before {
+		existing[id] = true
+	}
+	added = filterIDs(added, func(id int64) bool { return !existing[id] })
+	removed = filterIDs(removed, func(id int64) bool { return existing[id] })
+	if changeType != entity.PermissionChangeApprovalRequired && len(added) == 0 && len(removed) == 0 {
+		return nil
+	}
+
+	request := &entity.PermissionChangeRequest{
+		RoleID:      role.ID,
+		ChangeType:  changeType,
+		BeforeIDs:   before,
+		AddedIDs:    added,
+		RemovedIDs:  removed,
+		Status:      entity.PermissionChangeStatusPending,
+		RequestedBy: operatorID,
+		CreatedBy:   operatorID,
+		UpdatedBy:   operatorID,
+	}
+	if err := s.changeRepo.Create(request); err != nil {
+		s.log.Error("创建权限变更申请失败", "error", err, "role_id", role.ID, "change_type", changeType)
+		return errors.New("创建权限变更申请失败")
+	}
+
+	s.log.Info("角色权限变更已提交审批", "request_id", request.ID, "role_id", role.ID, "role_name", role.Name,
+		"change_type", changeType, "added", len(added), "removed", len(removed), "operator_id", operatorID)
+	return &PendingApprovalError{Request: request}
+}
+
+// currentRelationIDs 获取角色当前在指定变更类型下已关联的对象 ID
+func (s *RoleService) currentRelationIDs(roleID int64, changeType string) ([]int64, error) {
+	var ids []int64
+	switch changeType {
+	case entity.PermissionChangeRoleUsers:
+		users, err := s.roleRepo.GetUsersByRole(roleID)
+		if err != nil {
+			return nil, err
+		}
+		for _, user := range users {
+			ids = append(ids, user.ID)
+		}
+	case entity.PermissionChangeRoleMenus:
+		menus, err := s.roleRepo.GetMenusByRole(roleID)
+		if err != nil {
+			return nil, err
+		}
+		for _, menu := range menus {
+			ids = append(ids, menu.ID)
+		}
+	case entity.PermissionChangeRoleAPIs:
+		apis, err := s.roleRepo.GetsByRole(roleID)
+		if err != nil {
+			return nil, err
+		}
+		for _, api := range apis {
+			ids = append(ids, api.ID)
+		}
+	case entity.PermissionChangeRoleBtnPerms:
+		btnPerms, err := s.roleRepo.GetBtnPermsByRole(roleID)
+		if err != nil {
+			return nil, err
+		}
+		for _, btnPerm := range btnPerms {
+			ids = append(ids, btnPerm.ID)
+		}
+	case entity.PermissionChangeApprovalRequired:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("未知的权限变更类型：%s", changeType)
+	}
+	return ids, nil
+}
+
+// applyPermissionChange 执行审批通过的变更申请
+// 按申请中的差异精确生效：已存在的新增和已不存在的移除直接跳过，也不会自动关联菜单下的接口
+// 校验在写入前完成，变更和申请状态由仓库在同一事务中写入
+func (s *RoleService) applyPermissionChange(request *entity.PermissionChangeRequest) error {
+	role, err := s.roleRepo.GetByID(request.RoleID)
+	if err != nil {
+		return errors.New("角色不存在")
+	}
+
+	switch request.ChangeType {
+	case entity.PermissionChangeApprovalRequired, entity.PermissionChangeRoleMenus, entity.PermissionChangeRoleAPIs, entity.PermissionChangeRoleBtnPerms:
+	case entity.PermissionChangeRoleUsers:
+		// 提交后用户可能已获得冲突的角色，生效前重新校验静态职责分离约束
+		current, err := s.currentRelationIDs(role.ID, request.ChangeType)
+		if err != nil {
+			return err
+		}
+		existing := make(map[int64]bool, len(current))
+		for _, id := range current {
+			existing[id] = true
+		}
+		for _, id := range request.AddedIDs {
+			if existing[id] {
+				continue
+			}
+			if err := s.sod.CheckAddRole(id, role.ID); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("未知的权限变更类型：%s", request.ChangeType)
+	}
+
+	if err := s.changeRepo.Apply(request); err != nil {
+		s.log.Error("执行权限变更失败", "error", err, "request_id", request.ID, "role_id", role.ID)
+		return err
+	}
+
+	s.log.Info("权限变更申请已生效", "request_id", request.ID, "role_id", role.ID, "role_name", role.Name, "change_type", request.ChangeType)
+	return nil
+}
+
+// filterIDs 去重并保留满足条件的 ID
+func filterIDs(ids []int64, keep func(id int64) bool) []int64 {
+	var result []int64
+	seen := make(map[int64]bool, len(ids))
+	for _, id := range ids {
+		if seen[id] || !keep(id) {
+			continue
+		}
+		seen[id] = true
+		result = append(result, id)
+	}
+	return result
+}
@@ -0,0 +1,383 @@
+package service
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/entity"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/repo"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/observability/logger"
+)
+
+// API Key 格式：ixp_{prefix}_{secret}
+const (
+	apiKeyScheme       = "ixp"
+	apiKeyPrefixBytes  = 6
+	apiKeySecretBytes  = 24
+	apiKeyTouchMinimum = time.Minute // 最近使用信息的最小更新间隔
+)
+
+// ServiceAccountPrincipal API Key 认证通过后的调用主体
+type ServiceAccountPrincipal struct {
+	Account *entity.ServiceAccount // 服务账号
+	Key     *entity.ServiceAccountKey
+	Role    *entity.Role // 本次请求使用的角色
+}
+
+// ServiceAccountService 服务账号服务
+// 服务账号通过 API Key 访问管理端接口，Key 只保存 SHA-256 哈希，
+// 明文仅在创建和轮换时返回一次；认证时校验账号状态、Key 有效期、IP 白名单和角色绑定
+type ServiceAccountService struct {
+	repo     repo.ServiceAccountRepository
+	roleRepo repo.RoleRepository
+	log      logger.Logger
+	now      func() time.Time
+}
+
+// NewServiceAccountService 创建服务账号服务实例
+func NewServiceAccountService(repo repo.ServiceAccountRepository, roleRepo repo.RoleRepository, log logger.Logger) *ServiceAccountService {
+	return &ServiceAccountService{
+		repo:     repo,
+		roleRepo: roleRepo,
+		log:      log,
+		now:      time.Now,
+	}
+}
+
+// CreateServiceAccount 创建服务账号并生成首个 API Key
+// 返回:
+// - *entity.ServiceAccount: 创建后的服务账号
+// - string: API Key 明文，仅返回一次
+// - error: 错误信息
+func (s *ServiceAccountService) CreateServiceAccount(account *entity.ServiceAccount, expiresAt *time.Time) (*entity.ServiceAccount, string, error) {
+	account.Name = strings.TrimSpace(account.Name)
+	if account.Name == "" {
+		return nil, "", errors.New("服务账号名称不能为空")
+	}
+	if existing, err := s.repo.GetByName(account.Name); err == nil && existing != nil {
+		return nil, "", errors.New("服务账号名称已存在")
+	}
+	if err := validateIPAllowlist(account.IPAllowlist); err != nil {
+		return nil, "", err
+	}
+	if err := s.validateRoles(account.RoleIds); err != nil {
+		return nil, "", err
+	}
+	if expiresAt != nil && !expiresAt.After(s.now()) {
+		return nil, "", errors.New("过期时间必须晚于当前时间")
+	}
+
+	if err := s.repo.Create(account); err != nil {
+		s.log.Error("创建服务账号失败", "name", account.Name, "error", err)
+		return nil, "", errors.New("创建服务账号失败")
+	}
+	if err := s.repo.SetRoles(account.ID, account.RoleIds); err != nil {
+		s.log.Error("设置服务账号角色失败", "accountID", account.ID, "error", err)
+		return nil, "", errors.New("设置服务账号角色失败")
+	}
+
+	key, rawKey, err := s.issueKey(account.ID, expiresAt, account.CreatedBy)
+	if err != nil {
+		return nil, "", err
+	}
+	account.Keys = []*entity.ServiceAccountKey{key}
+
+	s.log.Info("服务账号创建成功", "accountID", account.ID, "name", account.Name, "keyPrefix", key.Prefix)
+	return account, rawKey, nil
+}
+
+// UpdateServiceAccount 更新服务账号描述、状态、IP 白名单和角色
+func (s *ServiceAccountService) UpdateServiceAccount(account *entity.ServiceAccount) error {
+	existing, err := s.repo.GetByID(account.ID)
+	if err != nil {
+		return errors.New("服务账号不存在")
+	}
+	if err := validateIPAllowlist(account.IPAllowlist); err != nil {
+		return err
+	}
+	if err := s.validateRoles(account.RoleIds); err != nil {
+		return err
+	}
+
+	existing.Description = account.Description
+	existing.Status = account.Status
+	existing.IPAllowlist = account.IPAllowlist
+	existing.UpdatedBy = account.UpdatedBy
+	if err := s.repo.Update(existing); err != nil {
+		s.log.Error("更新服务账号失败", "accountID", account.ID, "error", err)
+		return errors.New("更新服务账号失败")
+	}
+	if err := s.repo.SetRoles(account.ID, account.RoleIds); err != nil {
+		s.log.Error("设置服务账号角色失败", "accountID", account.ID, "error", err)
+		return errors.New("设置服务账号角色失败")
+	}
+	return nil
+}
+
+// DeleteServiceAccount 删除服务账号，其下所有 Key 同时吊销
+func (s *ServiceAccountService) DeleteServiceAccount(id int64) error {
+	if _, err := s.repo.GetByID(id); err != nil {
+		return errors.New("服务账号不存在")
+	}
+	if err := s.repo.Delete(id); err != nil {
+		s.log.Error("删除服务账号失败", "accountID", id, "error", err)
+		return errors.New("删除服务账号失败")
+	}
+	return nil
+}
+
+// GetServiceAccountByID 获取服务账号详情（含角色和 Key 列表）
+func (s *ServiceAccountService) GetServiceAccountByID(id int64) (*entity.ServiceAccount, error) {
+	account, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, errors.New("服务账号不存在")
+	}
+	if account.Roles, err = s.repo.GetRoles(id); err != nil {
+		return nil, err
+	}
+	if account.Keys, err = s.repo.ListKeys(id); err != nil {
+		return nil, err
+	}
+	return account, nil
+}
+
+// GetServiceAccountList 分页获取服务账号列表
+func (s *ServiceAccountService) GetServiceAccountList(page, pageSize int, filters map[string]interface{}) ([]*entity.ServiceAccount, int64, error) {
+	return s.repo.List(page, pageSize, filters)
+}
+
+// RotateKey 轮换 API Key
+// 生成新 Key，旧 Key 在 graceSeconds 秒后过期；graceSeconds 为 0 时旧 Key 立即失效
+// 返回新 Key 记录和明文
+func (s *ServiceAccountService) RotateKey(accountID int64, expiresAt *time.Time, graceSeconds int, operatorID int64) (*entity.ServiceAccountKey, string, error) {
+	if _, err := s.repo.GetByID(accountID); err != nil {
+		return nil, "", errors.New("服务账号不存在")
+	}
+	if graceSeconds < 0 {
+		return nil, "", errors.New("旧 Key 宽限期不能为负数")
+	}
+	if expiresAt != nil && !expiresAt.After(s.now()) {
+		return nil, "", errors.New("过期时间必须晚于当前时间")
+	}
+
+	key, rawKey, err := s.issueKey(accountID, expiresAt, operatorID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	oldExpiresAt := s.now().Add(time.Duration(graceSeconds) * time.Second)
+	if err := s.repo.ExpireActiveKeys(accountID, key.ID, oldExpiresAt); err != nil {
+		s.log.Error("设置旧 API Key 过期时间失败", "accountID", accountID, "error", err)
+		return nil, "", errors.New("轮换 API Key 失败")
+	}
+
+	s.log.Info("API Key 轮换成功", "accountID", accountID, "keyPrefix", key.Prefix, "graceSeconds", graceSeconds, "operatorID", operatorID)
+	return key, rawKey, nil
+}
+
+// RevokeKey 吊销指定 API Key
+func (s *ServiceAccountService) RevokeKey(accountID, keyID int64) error {
+	if err := s.repo.RevokeKey(accountID, keyID, s.now()); err != nil {
+		s.log.Error("吊销 API Key 失败", "accountID", accountID, "keyID", keyID, "error", err)
+		return errors.New("API Key 不存在或已吊销")
+	}
+	s.log.Info("API Key 已吊销", "accountID", accountID, "keyID", keyID)
+	return nil
+}
+
+// Authenticate 校验 API Key 并返回调用主体
+// 参数:
+// - rawKey: API Key 明文
+// - ip: 请求方 IP
+// - roleID: 请求指定的角色 ID，为 0 时使用绑定的第一个角色
+func (s *ServiceAccountService) Authenticate(rawKey, ip string, roleID int64) (*ServiceAccountPrincipal, error) {
+	prefix, secret, ok := parseAPIKey(rawKey)
+	if !ok {
+		return nil, errors.New("API Key 格式无效")
+	}
+
+	key, err := s.repo.GetKeyByPrefix(prefix)
+	if err != nil || key == nil {
+		return nil, errors.New("API Key 无效")
+	}
+	if subtle.ConstantTimeCompare([]byte(hashAPIKeySecret(secret)), []byte(key.KeyHash)) != 1 {
+		return nil, errors.New("API Key 无效")
+	}
+
+	now := s.now()
+	if !key.IsValid(now) {
+		return nil, errors.New("API Key 已过期或已吊销")
+	}
+
+	account, err := s.repo.GetByID(key.ServiceAccountID)
+	if err != nil {
+		return nil, errors.New("服务账号不存在")
+	}
+	if !account.IsActive() {
+		return nil, errors.New("服务账号已禁用")
+	}
+	if !ipAllowed(account.IPAllowlist, ip) {
+		s.log.Warn("服务账号 IP 不在白名单内", "accountID", account.ID, "ip", ip)
+		return nil, errors.New("请求 IP 不在服务账号白名单内")
+	}
+
+	role, err := s.selectRole(account, roleID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.touch(account, key, ip, now)
+
+	return &ServiceAccountPrincipal{Account: account, Key: key, Role: role}, nil
+}
+
+// selectRole 选择本次请求使用的角色
+func (s *ServiceAccountService) selectRole(account *entity.ServiceAccount, roleID int64) (*entity.Role, error) {
+	roles, err := s.repo.GetRoles(account.ID)
+	if err != nil {
+		s.log.Error("获取服务账号角色失败", "accountID", account.ID, "error", err)
+		return nil, errors.New("获取服务账号角色失败")
+	}
+
+	for _, role := range roles {
+		if role.Status != 1 {
+			continue
+		}
+		if roleID == 0 || role.ID == roleID {
+			return role, nil
+		}
+	}
+
+	if roleID != 0 {
+		return nil, errors.New("服务账号未绑定该角色")
+	}
+	return nil, errors.New("服务账号未绑定可用角色")
+}
+
+// touch 更新最近使用时间和 IP，按最小间隔节流以减少写库
+func (s *ServiceAccountService) touch(account *entity.ServiceAccount, key *entity.ServiceAccountKey, ip string, now time.Time) {
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) >= apiKeyTouchMinimum || key.LastUsedIP != ip {
+		if err := s.repo.TouchKey(key.ID, ip, now); err != nil {
+			s.log.Warn("更新 API Key 使用信息失败", "keyID", key.ID, "error", err)
+		}
+	}
+	if account.LastUsedAt == nil || now.Sub(*account.LastUsedAt) >= apiKeyTouchMinimum || account.LastUsedIP != ip {
+		if err := s.repo.TouchAccount(account.ID, ip, now); err != nil {
+			s.log.Warn("更新服务账号使用信息失败", "accountID", account.ID, "error", err)
+		}
+	}
+}
+
+// issueKey 生成并保存新的 API Key
+func (s *ServiceAccountService) issueKey(accountID int64, expiresAt *time.Time, operatorID int64) (*entity.ServiceAccountKey, string, error) {
+	prefix, err := randomHex(apiKeyPrefixBytes)
+	if err != nil {
+		s.log.Error("生成 API Key 失败", "error", err)
+		return nil, "", errors.New("生成 API Key 失败")
+	}
+	secret, err := randomHex(apiKeySecretBytes)
+	if err != nil {
+		s.log.Error("生成 API Key 失败", "error", err)
+		return nil, "", errors.New("生成 API Key 失败")
+	}
+
+	key := &entity.ServiceAccountKey{
+		ServiceAccountID: accountID,
+		Prefix:           prefix,
+		KeyHash:          hashAPIKeySecret(secret),
+		ExpiresAt:        expiresAt,
+		CreatedBy:        operatorID,
+	}
+	if err := s.repo.CreateKey(key); err != nil {
+		s.log.Error("保存 API Key 失败", "accountID", accountID, "error", err)
+		return nil, "", errors.New("生成 API Key 失败")
+	}
+
+	return key, fmt.Sprintf("%s_%s_%s", apiKeyScheme, prefix, secret), nil
+}
+
+// validateRoles 校验角色存在且至少绑定一个
+func (s *ServiceAccountService) validateRoles(roleIDs []int64) error {
+	if len(roleIDs) == 0 {
+		return errors.New("服务账号至少需要绑定一个角色")
+	}
+	for _, roleID := range roleIDs {
+		if _, err := s.roleRepo.GetByID(roleID); err != nil {
+			return fmt.Errorf("角色不存在：%d", roleID)
+		}
+	}
+	return nil
+}
+
+// parseAPIKey 解析 API Key，返回前缀和密文部分
+func parseAPIKey(rawKey string) (string, string, bool) {
+	parts := strings.Split(strings.TrimSpace(rawKey), "_")
+	if len(parts) != 3 || parts[0] != apiKeyScheme || parts[1] == "" || parts[2] == "" {
+		return "", "", false
+	}
+	return parts[1], parts[2], true
+}
+
+// hashAPIKeySecret 计算 API Key 密文部分的哈希
+// Key 为高熵随机值，直接使用 SHA-256 即可，无需慢哈希
+func hashAPIKeySecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// randomHex 生成指定字节数的随机十六进制字符串
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// validateIPAllowlist 校验 IP 白名单格式，支持单个 IP 和 CIDR
+func validateIPAllowlist(allowlist []string) error {
+	for _, item := range allowlist {
+		item = strings.TrimSpace(item)
+		if strings.Contains(item, "/") {
+			if _, _, err := net.ParseCIDR(item); err != nil {
+				return fmt.Errorf("IP 白名单格式无效：%s", item)
+			}
+			continue
+		}
+		if net.ParseIP(item) == nil {
+			return fmt.Errorf("IP 白名单格式无效：%s", item)
+		}
+	}
+	return nil
+}
+
+// ipAllowed 检查 IP 是否在白名单内，白名单为空时不限制
+func ipAllowed(allowlist []string, ip string) bool {
+	if len(allowlist) == 0 {
+		return true
+	}
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return false
+	}
+	for _, item := range allowlist {
+		item = strings.TrimSpace(item)
+		if strings.Contains(item, "/") {
+			if _, ipNet, err := net.ParseCIDR(item); err == nil && ipNet.Contains(parsed) {
+				return true
+			}
+			continue
+		}
+		if allowed := net.ParseIP(item); allowed != nil && allowed.Equal(parsed) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,300 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/entity"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/repo"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/observability/logger"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/persistence/cache"
+)
+
+// sessionRolesExpiration 会话已激活角色的缓存时长，与当前角色缓存一致
+const sessionRolesExpiration = 24 * time.Hour
+
+// SoDService 职责分离约束服务
+// 静态约束在分配角色时校验用户最终持有的角色，动态约束在切换角色时校验同一会话中激活过的角色
+type SoDService struct {
+	repo     repo.SoDConstraintRepository
+	roleRepo repo.RoleRepository
+	cache    cache.Cache
+	log      logger.Logger
+}
+
+// NewSoDService 创建职责分离约束服务实例
+func NewSoDService(
+	repo repo.SoDConstraintRepository,
+	roleRepo repo.RoleRepository,
+	cache cache.Cache,
+	log logger.Logger,
+) *SoDService {
+	return &SoDService{
+		repo:     repo,
+		roleRepo: roleRepo,
+		cache:    cache,
+		log:      log,
+	}
+}
+
+// CreateConstraint 创建职责分离约束
+func (s *SoDService) CreateConstraint(constraint *entity.SoDConstraint) error {
+	if err := s.validateConstraint(constraint); err != nil {
+		return err
+	}
+	if _, err := s.repo.GetByName(constraint.Name); err == nil {
+		return errors.New("约束名称已存在")
+	}
+
+	if err := s.repo.Create(constraint); err != nil {
+		s.log.Error("创建职责分离约束失败", "error", err, "name", constraint.Name)
+		return err
+	}
+	s.log.Info("创建职责分离约束成功", "id", constraint.ID, "name", constraint.Name, "type", constraint.Type)
+	return nil
+}
+
+// UpdateConstraint 更新职责分离约束，只影响之后的角色分配和切换，已有违规可通过报告查询
+func (s *SoDService) UpdateConstraint(constraint *entity.SoDConstraint) error {
+	if _, err := s.repo.GetByID(constraint.ID); err != nil {
+		return errors.New("约束不存在")
+	}
+	if err := s.validateConstraint(constraint); err != nil {
+		return err
+	}
+	if existing, err := s.repo.GetByName(constraint.Name); err == nil && existing.ID != constraint.ID {
+		return errors.New("约束名称已存在")
+	}
+
+	if err := s.repo.Update(constraint); err != nil {
+		s.log.Error("更新职责分离约束失败", "error", err, "id", constraint.ID)
+		return err
+	}
+	s.log.Info("更新职责分离约束成功", "id", constraint.ID, "name", constraint.Name)
+	return nil
+}
+
+// DeleteConstraint 删除职责分离约束
+func (s *SoDService) DeleteConstraint(id int64) error {
+	if _, err := s.repo.GetByID(id); err != nil {
+		return errors.New("约束不存在")
+	}
+	if err := s.repo.Delete(id); err != nil {
+		s.log.Error("删除职责分离约束失败", "error", err, "id", id)
+		return err
+	}
+	s.log.Info("删除职责分离约束成功", "id", id)
+	return nil
+}
+
+// GetConstraint 获取职责分离约束详情
+func (s *SoDService) GetConstraint(id int64) (*entity.SoDConstraint, error) {
+	constraint, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, errors.New("约束不存在")
+	}
+	return constraint, nil
+}
+
+// GetConstraintList 分页获取职责分离约束列表
+func (s *SoDService) GetConstraintList(page, pageSize int, filters map[string]interface{}) ([]*entity.SoDConstraint, int64, error) {
+	return s.repo.List(page, pageSize, filters)
+}
+
+// CheckUserRoles 校验用户持有 roleIDs 这组角色是否违反静态约束，roleIDs 为分配完成后用户的全部直接角色
+func (s *SoDService) CheckUserRoles(userID int64, roleIDs []int64) error {
+	constraints, err := s.repo.GetActiveByType(entity.SoDTypeStatic)
+	if err != nil {
+		s.log.Error("获取静态职责分离约束失败", "error", err)
+		return errors.New("获取职责分离约束失败")
+	}
+	for _, constraint := range constraints {
+		if conflicts := constraint.Conflicts(roleIDs); conflicts != nil {
+			s.log.Warn("角色分配违反静态职责分离约束", "user_id", userID, "constraint", constraint.Name, "role_ids", conflicts)
+			return s.conflictError(constraint, conflicts, "持有")
+		}
+	}
+	return nil
+}
+
+// CheckAddRole 校验用户在现有角色基础上再加入 roleID 是否违反静态约束
+func (s *SoDService) CheckAddRole(userID, roleID int64) error {
+	roles, err := s.roleRepo.GetRolesByUser(userID)
+	if err != nil {
+		s.log.Error("获取用户角色失败", "error", err, "user_id", userID)
+		return err
+	}
+	roleIDs := make([]int64, 0, len(roles)+1)
+	for _, role := range roles {
+		roleIDs = append(roleIDs, role.ID)
+	}
+	return s.CheckUserRoles(userID, append(roleIDs, roleID))
+}
+
+// ActivateRole 在会话中激活角色，激活后与该会话中激活过的角色一起校验动态约束，通过后记录
+// 会话首次切换时，切换前正在使用的角色也视为已激活；没有会话 ID 时按用户记录
+func (s *SoDService) ActivateRole(userID int64, sessionID string, roleID int64, currentRoleID int64) error {
+	key := sessionRolesKey(userID, sessionID)
+	activated, err := s.sessionRoles(key)
+	if err != nil {
+		s.log.Error("读取会话已激活角色失败", "error", err, "user_id", userID, "session_id", sessionID)
+		return errors.New("读取会话已激活角色失败")
+	}
+	if len(activated) == 0 && currentRoleID != 0 {
+		activated = append(activated, currentRoleID)
+	}
+	for _, id := range activated {
+		if id == roleID {
+			return nil
+		}
+	}
+	activated = append(activated, roleID)
+
+	constraints, err := s.repo.GetActiveByType(entity.SoDTypeDynamic)
+	if err != nil {
+		s.log.Error("获取动态职责分离约束失败", "error", err)
+		return errors.New("获取职责分离约束失败")
+	}
+	for _, constraint := range constraints {
+		if conflicts := constraint.Conflicts(activated); conflicts != nil {
+			s.log.Warn("切换角色违反动态职责分离约束", "user_id", userID, "session_id", sessionID, "constraint", constraint.Name, "role_ids", conflicts)
+			return s.conflictError(constraint, conflicts, "在同一会话中激活")
+		}
+	}
+
+	data, _ := json.Marshal(activated)
+	if err := s.cache.Set(key, string(data), sessionRolesExpiration); err != nil {
+		s.log.Error("记录会话已激活角色失败", "error", err, "user_id", userID, "session_id", sessionID)
+		return errors.New("记录会话已激活角色失败")
+	}
+	return nil
+}
+
+// GetViolations 列出现有角色分配中违反静态约束的用户
+// 动态约束只限制会话内的激活，同时持有不算违规
+func (s *SoDService) GetViolations() ([]*entity.SoDViolation, error) {
+	constraints, err := s.repo.GetActiveByType(entity.SoDTypeStatic)
+	if err != nil {
+		s.log.Error("获取静态职责分离约束失败", "error", err)
+		return nil, errors.New("获取职责分离约束失败")
+	}
+
+	var violations []*entity.SoDViolation
+	for _, constraint := range constraints {
+		// 按用户汇总其持有的约束内角色，保持约束中的角色顺序
+		held := make(map[int64][]int64)
+		var userOrder []int64
+		usernames := make(map[int64]string)
+		for _, roleID := range constraint.RoleIDs {
+			users, err := s.roleRepo.GetUsersByRole(roleID)
+			if err != nil {
+				s.log.Error("获取角色关联用户失败", "error", err, "role_id", roleID)
+				return nil, err
+			}
+			for _, user := range users {
+				if _, ok := held[user.ID]; !ok {
+					userOrder = append(userOrder, user.ID)
+					usernames[user.ID] = user.Username
+				}
+				held[user.ID] = append(held[user.ID], roleID)
+			}
+		}
+
+		for _, userID := range userOrder {
+			if conflicts := constraint.Conflicts(held[userID]); conflicts != nil {
+				violations = append(violations, &entity.SoDViolation{
+					Constraint: constraint,
+					UserID:     userID,
+					Username:   usernames[userID],
+					RoleIDs:    conflicts,
+				})
+			}
+		}
+	}
+	return violations, nil
+}
+
+// validateConstraint 校验约束类型、角色集合和上限，并对角色去重
+func (s *SoDService) validateConstraint(constraint *entity.SoDConstraint) error {
+	if strings.TrimSpace(constraint.Name) == "" {
+		return errors.New("约束名称不能为空")
+	}
+	if constraint.Type != entity.SoDTypeStatic && constraint.Type != entity.SoDTypeDynamic {
+		return errors.New("约束类型只能是 static 或 dynamic")
+	}
+
+	roleIDs := filterIDs(constraint.RoleIDs, func(id int64) bool { return id != 0 })
+	if len(roleIDs) < 2 {
+		return errors.New("约束至少包含两个角色")
+	}
+	for _, roleID := range roleIDs {
+		if _, err := s.roleRepo.GetByID(roleID); err != nil {
+			return fmt.Errorf("角色 %d 不存在", roleID)
+		}
+	}
+	if constraint.MaxRoles < 1 || constraint.MaxRoles >= len(roleIDs) {
+		return errors.New("最多角色数必须大于 0 且小于约束的角色数")
+	}
+	constraint.RoleIDs = roleIDs
+	return nil
+}
+
+// SoDConflictError 违反职责分离约束时返回，与读取约束失败等其他错误区分
+type SoDConflictError struct {
+	Constraint *entity.SoDConstraint
+	message    string
+}
+
+// Error 实现 error 接口
+func (e *SoDConflictError) Error() string {
+	return e.message
+}
+
+// conflictError 生成包含角色名称的冲突错误
+func (s *SoDService) conflictError(constraint *entity.SoDConstraint, roleIDs []int64, action string) error {
+	names := make([]string, 0, len(roleIDs))
+	for _, roleID := range roleIDs {
+		if role, err := s.roleRepo.GetByID(roleID); err == nil {
+			names = append(names, role.Name)
+		} else {
+			names = append(names, fmt.Sprintf("%d", roleID))
+		}
+	}
+	return &SoDConflictError{
+		Constraint: constraint,
+		message:    fmt.Sprintf("违反职责分离约束「%s」：不能同时%s角色 %s，最多 %d 个", constraint.Name, action, strings.Join(names, "、"), constraint.MaxRoles),
+	}
+}
+
+// sessionRoles 读取会话已激活的角色，键不存在时返回空列表
+func (s *SoDService) sessionRoles(key string) ([]int64, error) {
+	exists, err := s.cache.Exists(key)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, nil
+	}
+	data, err := s.cache.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	var roleIDs []int64
+	if data != "" {
+		if err := json.Unmarshal([]byte(data), &roleIDs); err != nil {
+			return nil, err
+		}
+	}
+	return roleIDs, nil
+}
+
+// sessionRolesKey 会话已激活角色的缓存键
+func sessionRolesKey(userID int64, sessionID string) string {
+	if sessionID == "" {
+		return fmt.Sprintf("sod:user_roles:%d", userID)
+	}
+	return fmt.Sprintf("sod:session_roles:%s", sessionID)
+}
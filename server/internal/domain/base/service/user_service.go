@@ -16,6 +16,7 @@ import (
 	"github.com/ix-pay/ixpay-pro/internal/infrastructure/persistence/cache"
 	auth "github.com/ix-pay/ixpay-pro/internal/infrastructure/security/auth"
 	"github.com/ix-pay/ixpay-pro/internal/infrastructure/security/captcha"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/security/ldap"
 	"github.com/ix-pay/ixpay-pro/internal/utils/encryption"
 	"gorm.io/gorm"
 )
@@ -36,6 +37,10 @@ import (
 // - cache: 缓存服务，用于缓存和会话管理
 // - captcha: 验证码服务，用于验证码生成和验证
 // - loginLogService: 登录日志服务，用于记录用户登录日志
+// - passwordPolicy: 密码策略服务，用于密码复杂度、历史与过期校验
+// - ldapService: LDAP 登录服务，开启后优先使用 LDAP 认证
+// - onlineUserService: 在线用户服务，用于创建和管理登录会话
+// - loginRisk: 异常登录检测服务，用于新设备、新地点和异地登录提醒及二次验证
 type UserService struct {
 	repo                  repo.UserRepository        // 用户数据仓库
 	settingRepo           repo.UserSettingRepository // 用户设置数据仓库
@@ -47,6 +52,11 @@ type UserService struct {
 	cache                 cache.Cache                // 缓存服务
 	captcha               *captcha.Captcha           // 验证码服务
 	loginLogService       *LoginLogService           // 登录日志服务
+	passwordPolicy        *PasswordPolicyService     // 密码策略服务
+	ldapService           *LDAPService               // LDAP 登录服务
+	onlineUserService     *OnlineUserService         // 在线用户服务
+	loginRisk             *LoginRiskService          // 异常登录检测服务
+	sod                   *SoDService                // 职责分离约束服务
 }
 
 // NewUserService 创建用户服务实例
@@ -61,9 +71,14 @@ type UserService struct {
 // - cache: 缓存服务，用于缓存和会话管理
 // - captcha: 验证码服务，用于验证码生成和验证
 // - loginLogService: 登录日志服务，用于记录用户登录日志
+// - passwordPolicy: 密码策略服务，用于密码复杂度、历史与过期校验
+// - ldapService: LDAP 登录服务，可为空
+// - onlineUserService: 在线用户服务，用于创建和管理登录会话
+// - loginRisk: 异常登录检测服务，可为空
+// - sod: 职责分离约束服务，用于分配和切换角色时校验约束
 // 返回:
 // - *UserService: 用户服务实现
-func NewUserService(repo repo.UserRepository, settingRepo repo.UserSettingRepository, roleService *RoleService, rolePermissionService *RolePermissionService, jwtAuth *auth.JWTAuth, config *config.Config, log logger.Logger, cache cache.Cache, captcha *captcha.Captcha, loginLogService *LoginLogService) *UserService {
+func NewUserService(repo repo.UserRepository, settingRepo repo.UserSettingRepository, roleService *RoleService, rolePermissionService *RolePermissionService, jwtAuth *auth.JWTAuth, config *config.Config, log logger.Logger, cache cache.Cache, captcha *captcha.Captcha, loginLogService *LoginLogService, passwordPolicy *PasswordPolicyService, ldapService *LDAPService, onlineUserService *OnlineUserService, loginRisk *LoginRiskService, sod *SoDService) *UserService {
 	// 创建并返回用户服务实例，注入所有依赖
 	return &UserService{
 		repo:                  repo,
@@ -76,27 +91,36 @@ func NewUserService(repo repo.UserRepository, settingRepo repo.UserSettingReposi
 		cache:                 cache,
 		captcha:               captcha,
 		loginLogService:       loginLogService,
+		passwordPolicy:        passwordPolicy,
+		ldapService:           ldapService,
+		onlineUserService:     onlineUserService,
+		loginRisk:             loginRisk,
+		sod:                   sod,
 	}
 }
 
 // Captcha 生成验证码
-// 调用验证码服务生成新的验证码图片
+// 按场景策略判断当前是否需要验证码，需要时按场景配置的类型生成
+// 参数:
+// - scenario: 验证码场景 login / register / password_reset
+// - account: 账号，登录场景按账号统计失败次数
+// - ip: 客户端 IP，登录场景按 IP 统计失败次数
 // 返回:
-// - string: 验证码ID
-// - string: base64编码的验证码图片
-// - int: 验证码长度
-// - bool: 验证码功能是否开启
+// - *captcha.Challenge: 验证码题目，不需要验证码时为 nil
+// - bool: 当前是否需要验证码
 // - error: 错误信息
-func (s *UserService) Captcha() (string, string, int, bool, error) {
+func (s *UserService) Captcha(scenario, account, ip string) (*captcha.Challenge, bool, error) {
+	if !s.captcha.Required(scenario, account, ip) {
+		return nil, false, nil
+	}
+
 	// 调用验证码服务生成新的验证码
-	id, base64Img, err := s.captcha.NewCaptcha()
+	challenge, err := s.captcha.Generate(scenario)
 	if err != nil {
-		s.log.Error("生成验证码失败", "error", err)
-		return id, "", s.captcha.GetCaptchaLen(), s.captcha.IsOpenCaptcha(), err
+		s.log.Error("生成验证码失败", "scenario", scenario, "error", err)
+		return nil, true, err
 	}
-
-	// 返回验证码ID、图片数据、长度、开关状态
-	return id, base64Img, s.captcha.GetCaptchaLen(), s.captcha.IsOpenCaptcha(), nil
+	return challenge, true, nil
 }
 
 // Register 用户注册
@@ -105,10 +129,18 @@ func (s *UserService) Captcha() (string, string, int, bool, error) {
 // - userName: 用户名
 // - password: 密码（明文）
 // - email: 电子邮箱
+// - captchaId: 验证码 ID
+// - captchaVal: 用户输入的验证码值
+// - ip: 客户端 IP
 // 返回:
 // - *entity.User: 新创建的用户对象
 // - error: 错误信息
-func (s *UserService) Register(userName, password, email string) (*entity.User, error) {
+func (s *UserService) Register(userName, password, email, captchaId, captchaVal, ip string) (*entity.User, error) {
+	// 按注册场景策略校验验证码
+	if err := s.captcha.Verify(captcha.ScenarioRegister, userName, ip, captchaId, captchaVal); err != nil {
+		return nil, err
+	}
+
 	// 检查用户是否已存在
 	_, err := s.repo.GetByUsername(userName)
 	if err == nil {
@@ -121,6 +153,11 @@ func (s *UserService) Register(userName, password, email string) (*entity.User,
 		return nil, errors.New("邮箱已存在")
 	}
 
+	// 校验密码复杂度
+	if err := s.passwordPolicy.Validate(password, userName); err != nil {
+		return nil, err
+	}
+
 	// 生成密码哈希
 	passwordHash, err := encryption.GeneratePasswordHash(password)
 	if err != nil {
@@ -130,10 +167,11 @@ func (s *UserService) Register(userName, password, email string) (*entity.User,
 
 	// 创建新用户
 	user := &entity.User{
-		Username:     userName,
-		PasswordHash: passwordHash,
-		Email:        email,
-		Status:       1,
+		Username:          userName,
+		PasswordHash:      passwordHash,
+		Email:             email,
+		Status:            1,
+		PasswordChangedAt: time.Now(),
 	}
 
 	// 保存用户
@@ -142,6 +180,9 @@ func (s *UserService) Register(userName, password, email string) (*entity.User,
 		return nil, err
 	}
 
+	// 记录密码历史
+	s.passwordPolicy.RecordPassword(user.ID, passwordHash, user.ID)
+
 	// 为用户分配默认角色（user）
 	if err := s.assignDefaultRole(user.ID); err != nil {
 		s.log.Warn("为用户分配默认角色失败", "userID", user.ID, "error", err)
@@ -173,43 +214,76 @@ func (s *UserService) Login(userName, password, captchaId, captchaVal, ip, userA
 	browser, os := parseUserAgent(userAgent)
 	// 组合设备信息
 	device := fmt.Sprintf("%s / %s", browser, os)
-	// 获取登录地点（基于 IP 的简单定位）
-	loginPlace := getLoginPlaceByIP(ip)
+	// 获取登录地点（基于离线 IP 地址库）
+	loginPlace := s.loginLogService.LoginPlace(ip)
 
-	// 检查是否开启验证码，如果开启则验证验证码
-	if s.captcha.IsOpenCaptcha() {
-		// 验证用户输入的验证码是否正确
-		ok, err := s.captcha.VerifyCaptcha(captchaId, captchaVal)
-		if err != nil {
-			s.log.Error("获取验证码失败", "error", err)
-			// 记录失败的登录日志（验证码错误）
-			s.loginLogService.RecordLogin(0, userName, ip, loginPlace, device, browser, os, userAgent, false, "验证码错误")
-			return nil, "", "", time.Time{}, time.Time{}, errors.New("验证码已过期或无效")
-		}
+	// 按登录场景策略校验验证码，默认账号或 IP 登录失败次数达到阈值后才需要
+	if err := s.captcha.Verify(captcha.ScenarioLogin, userName, ip, captchaId, captchaVal); err != nil {
+		// 记录失败的登录日志（验证码错误）
+		s.loginLogService.RecordLogin(0, userName, ip, loginPlace, device, browser, os, userAgent, false, "验证码错误")
+		return nil, "", "", time.Time{}, time.Time{}, err
+	}
 
-		if !ok {
-			// 记录失败的登录日志（验证码错误）
-			s.loginLogService.RecordLogin(0, userName, ip, loginPlace, device, browser, os, userAgent, false, "验证码错误")
-			return nil, "", "", time.Time{}, time.Time{}, errors.New("验证码错误")
+	// 校验账号密码：开启 LDAP 时优先使用 LDAP，按配置回退到本地账号
+	user, loginType, failReason, err := s.authenticate(userName, password)
+	if err != nil {
+		var userID int64
+		if user != nil {
+			userID = user.ID
 		}
+		// 记录失败的登录日志
+		s.loginLogService.RecordLogin(userID, userName, ip, loginPlace, device, browser, os, userAgent, false, failReason)
+		// 累计登录失败次数，达到阈值后需要验证码
+		s.captcha.RecordFailure(captcha.ScenarioLogin, userName, ip)
+		return nil, "", "", time.Time{}, time.Time{}, err
 	}
 
-	// 根据用户名获取用户
-	user, err := s.repo.GetByUsername(userName)
+	s.captcha.ResetFailures(captcha.ScenarioLogin, userName)
+	return s.CompleteLogin(user, userName, loginType, ip, userAgent)
+}
+
+// CompleteLogin 完成登录
+// 账号密码、LDAP、OIDC 等方式认证通过后统一调用：检查用户状态、加载角色、创建会话、签发令牌、
+// 缓存默认角色并记录登录日志，保证各登录方式签发的令牌一致
+// 开启异常登录检测时，命中需要二次验证的事件返回 *StepUpRequiredError，
+// 用户通过 VerifyLoginStepUp 提交验证码后完成登录
+// 参数:
+// - user: 已认证的用户
+// - userName: 登录名，用于登录日志
+// - loginType: 登录方式，写入令牌
+// - ip: 客户端 IP
+// - userAgent: 客户端 User-Agent
+// 返回值与 Login 相同
+func (s *UserService) CompleteLogin(user *entity.User, userName, loginType, ip, userAgent string) (*entity.User, string, string, time.Time, time.Time, error) {
+	return s.completeLogin(user, userName, loginType, ip, userAgent, false)
+}
+
+// VerifyLoginStepUp 校验登录二次验证码并完成登录
+// 参数:
+// - challengeID: 登录时返回的二次验证挑战 ID
+// - code: 用户收到的验证码
+// - ip: 客户端 IP，须与发起登录时一致
+// - userAgent: 客户端 User-Agent，须与发起登录的设备一致
+// 返回值与 Login 相同
+func (s *UserService) VerifyLoginStepUp(challengeID, code, ip, userAgent string) (*entity.User, string, string, time.Time, time.Time, error) {
+	challenge, err := s.loginRisk.VerifyStepUp(challengeID, code, ip, userAgent)
 	if err != nil {
-		s.log.Error("查找用户失败", "error", err)
-		// 记录失败的登录日志（用户不存在）
-		s.loginLogService.RecordLogin(0, userName, ip, loginPlace, device, browser, os, userAgent, false, "用户不存在")
-		return nil, "", "", time.Time{}, time.Time{}, errors.New("用户名或密码错误")
+		return nil, "", "", time.Time{}, time.Time{}, err
 	}
 
-	// 验证密码
-	if verifyErr := encryption.VerifyPassword(user.PasswordHash, password); verifyErr != nil {
-		s.log.Error("密码验证失败", "userName", userName)
-		// 记录失败的登录日志（密码错误）
-		s.loginLogService.RecordLogin(user.ID, userName, ip, loginPlace, device, browser, os, userAgent, false, "密码错误")
-		return nil, "", "", time.Time{}, time.Time{}, errors.New("用户名或密码错误")
+	user, err := s.repo.GetByID(challenge.UserID)
+	if err != nil {
+		s.log.Error("获取用户失败", "userID", challenge.UserID, "error", err)
+		return nil, "", "", time.Time{}, time.Time{}, errors.New("用户不存在")
 	}
+	return s.completeLogin(user, challenge.UserName, challenge.LoginType, ip, userAgent, true)
+}
+
+// completeLogin 完成登录，stepUpPassed 表示已通过二次验证，不再重复生成安全事件
+func (s *UserService) completeLogin(user *entity.User, userName, loginType, ip, userAgent string, stepUpPassed bool) (*entity.User, string, string, time.Time, time.Time, error) {
+	browser, os := parseUserAgent(userAgent)
+	device := fmt.Sprintf("%s / %s", browser, os)
+	loginPlace := s.loginLogService.LoginPlace(ip)
 
 	// 检查用户状态
 	if user.Status != 1 {
@@ -250,15 +324,38 @@ func (s *UserService) Login(userName, password, captchaId, captchaVal, ip, userA
 		return nil, "", "", time.Time{}, time.Time{}, nil
 	}
 
+	// 异常登录检测：新设备、新地点和异地登录生成安全事件并提醒用户，按配置要求二次验证
+	assessment := s.loginRisk.Assess(user, ip, userAgent)
+	if !stepUpPassed {
+		if s.loginRisk.RequireStepUp(assessment) {
+			if err := s.loginRisk.StartStepUp(user, userName, loginType, assessment); err != nil {
+				var stepUpErr *StepUpRequiredError
+				if !errors.As(err, &stepUpErr) {
+					s.loginLogService.RecordLogin(user.ID, userName, ip, loginPlace, device, browser, os, userAgent, false, err.Error())
+				}
+				return nil, "", "", time.Time{}, time.Time{}, err
+			}
+		}
+		s.loginRisk.Record(assessment, entity.StepUpStatusNone)
+	}
+
 	// 传入 nickname（如果有昵称则使用昵称，否则使用用户名）
 	nickname := user.Nickname
 	if nickname == "" {
 		nickname = user.Username
 	}
-	// 【修改 1】生成 JWT 时 role 参数传空字符串
-	accessToken, refreshToken, accessExpire, refreshExpire, err := s.jwtAuth.GenerateToken(fmt.Sprintf("%d", user.ID), user.Username, nickname, "", "password")
+	// 创建登录会话，会话数超过上限时按配置踢出最早的会话或拒绝登录
+	session, err := s.onlineUserService.CreateSession(user.ID, user.Username, nickname, loginType, ip, userAgent, s.jwtAuth.RefreshTokenExpire())
+	if err != nil {
+		s.loginLogService.RecordLogin(user.ID, userName, ip, loginPlace, device, browser, os, userAgent, false, err.Error())
+		return nil, "", "", time.Time{}, time.Time{}, err
+	}
+
+	// 【修改 1】生成 JWT 时 role 参数传空字符串，会话 ID 写入令牌
+	accessToken, refreshToken, accessExpire, refreshExpire, err := s.jwtAuth.GenerateToken(fmt.Sprintf("%d", user.ID), user.Username, nickname, "", loginType, session.SessionID)
 	if err != nil {
 		s.log.Error("生成令牌失败", "error", err)
+		s.onlineUserService.RemoveOnlineUserBySessionID(session.SessionID)
 		// 记录失败的登录日志（令牌生成失败）
 		s.loginLogService.RecordLogin(user.ID, userName, ip, loginPlace, device, browser, os, userAgent, false, err.Error())
 		return nil, "", "", time.Time{}, time.Time{}, err
@@ -301,6 +398,7 @@ func (s *UserService) Login(userName, password, captchaId, captchaVal, ip, userA
 
 	// 记录成功的登录日志
 	s.loginLogService.RecordLogin(user.ID, userName, ip, loginPlace, device, browser, os, userAgent, true, "")
+	s.loginRisk.Remember(assessment)
 
 	// 【新增】登录成功后,清除之前退出登录的黑名单
 	blacklistKey := fmt.Sprintf("blacklist:user:%s", fmt.Sprintf("%d", user.ID))
@@ -314,6 +412,177 @@ func (s *UserService) Login(userName, password, captchaId, captchaVal, ip, userA
 	return user, accessToken, refreshToken, accessExpire, refreshExpire, nil
 }
 
+// 管理端登录方式
+const (
+	LoginTypePassword = "password" // 本地账号密码登录
+	LoginTypeLDAP     = "ldap"     // LDAP / AD 登录
+	LoginTypeOIDC     = "oidc"     // OIDC 单点登录
+	LoginTypeAPIKey   = "api_key"  // 服务账号 API Key 认证，上下文中没有用户 ID，服务账号 ID 保存在 serviceAccountID
+)
+
+// RecordLoginFailure 记录认证阶段失败的登录日志，供账号密码以外的登录方式使用
+func (s *UserService) RecordLoginFailure(userID int64, userName, ip, userAgent, reason string) {
+	browser, os := parseUserAgent(userAgent)
+	device := fmt.Sprintf("%s / %s", browser, os)
+	s.loginLogService.RecordLogin(userID, userName, ip, s.loginLogService.LoginPlace(ip), device, browser, os, userAgent, false, reason)
+}
+
+// authenticate 校验账号密码
+// 开启 LDAP 时优先使用 LDAP 认证并即时开通本地用户；LDAP 中不存在该用户或服务不可用时，
+// 按配置回退到本地账号。LDAP 明确返回密码错误时不回退
+// 返回:
+// - *entity.User: 认证通过的用户（密码错误时也返回用户，用于记录登录日志）
+// - string: 登录方式
+// - string: 失败原因，用于登录日志
+// - error: 返回给调用方的错误信息
+func (s *UserService) authenticate(userName, password string) (*entity.User, string, string, error) {
+	if s.ldapService.Enabled() {
+		result, err := s.ldapService.Authenticate(userName, password)
+		switch {
+		case err == nil:
+			user, provisionErr := s.provisionLDAPUser(result)
+			if provisionErr != nil {
+				s.log.Error("LDAP 用户同步失败", "userName", userName, "error", provisionErr)
+				return nil, "", "LDAP 用户同步失败", errors.New("登录失败，请稍后重试")
+			}
+			return user, LoginTypeLDAP, "", nil
+		case errors.Is(err, ldap.ErrInvalidCredentials):
+			s.log.Error("LDAP 密码验证失败", "userName", userName)
+			return nil, "", "LDAP 密码错误", errors.New("用户名或密码错误")
+		case !s.ldapService.FallbackToLocal():
+			if errors.Is(err, ldap.ErrUserNotFound) {
+				return nil, "", "LDAP 用户不存在", errors.New("用户名或密码错误")
+			}
+			s.log.Error("LDAP 认证失败", "userName", userName, "error", err)
+			return nil, "", "LDAP 服务不可用", errors.New("LDAP 服务不可用，请稍后重试")
+		default:
+			s.log.Warn("LDAP 认证未通过，回退到本地账号", "userName", userName, "error", err)
+		}
+	}
+
+	// 根据用户名获取用户
+	user, err := s.repo.GetByUsername(userName)
+	if err != nil {
+		s.log.Error("查找用户失败", "error", err)
+		return nil, "", "用户不存在", errors.New("用户名或密码错误")
+	}
+
+	// 验证密码
+	if verifyErr := encryption.VerifyPassword(user.PasswordHash, password); verifyErr != nil {
+		s.log.Error("密码验证失败", "userName", userName)
+		return user, "", "密码错误", errors.New("用户名或密码错误")
+	}
+
+	return user, LoginTypePassword, "", nil
+}
+
+// provisionLDAPUser 根据 LDAP 认证结果即时开通或更新本地用户
+// 新用户使用随机密码（无法用于本地登录）；已有用户同步昵称、邮箱、手机号和映射的部门；
+// 匹配到组映射时按映射覆盖用户角色，未匹配且为新用户时分配默认角色
+func (s *UserService) provisionLDAPUser(result *LDAPLoginResult) (*entity.User, error) {
+	identity := result.Identity
+
+	user, err := s.repo.GetByUsername(identity.Username)
+	created := false
+	if err != nil {
+		randomPassword, err := randomHex(24)
+		if err != nil {
+			return nil, err
+		}
+		passwordHash, err := encryption.GeneratePasswordHash(randomPassword)
+		if err != nil {
+			return nil, err
+		}
+
+		user = &entity.User{
+			Username:          identity.Username,
+			PasswordHash:      passwordHash,
+			Nickname:          identity.Nickname,
+			Email:             identity.Email,
+			Phone:             identity.Phone,
+			Status:            1,
+			DepartmentID:      result.DepartmentID,
+			PasswordChangedAt: time.Now(),
+		}
+		if err := s.repo.Create(user); err != nil {
+			return nil, fmt.Errorf("创建 LDAP 用户失败：%w", err)
+		}
+		created = true
+		s.log.Info("LDAP 用户已自动开通", "userID", user.ID, "userName", user.Username, "dn", identity.DN)
+	} else {
+		updates := make(map[string]interface{})
+		if identity.Nickname != "" && identity.Nickname != user.Nickname {
+			updates["nickname"] = identity.Nickname
+			user.Nickname = identity.Nickname
+		}
+		if identity.Email != "" && identity.Email != user.Email {
+			updates["email"] = identity.Email
+			user.Email = identity.Email
+		}
+		if identity.Phone != "" && identity.Phone != user.Phone {
+			updates["phone"] = identity.Phone
+			user.Phone = identity.Phone
+		}
+		if result.DepartmentID > 0 && result.DepartmentID != user.DepartmentID {
+			updates["department_id"] = result.DepartmentID
+			user.DepartmentID = result.DepartmentID
+		}
+		// 密码由目录服务管理，不要求 LDAP 用户修改本地密码
+		if s.passwordPolicy != nil {
+			if required, _ := s.passwordPolicy.ChangeRequired(user); required {
+				now := time.Now()
+				updates["must_change_password"] = false
+				updates["password_changed_at"] = now
+				user.MustChangePassword = false
+				user.PasswordChangedAt = now
+			}
+		}
+		if len(updates) > 0 {
+			updates["updated_at"] = time.Now()
+			if err := s.repo.UpdateFields(user.ID, updates); err != nil {
+				return nil, fmt.Errorf("更新 LDAP 用户失败：%w", err)
+			}
+		}
+	}
+
+	s.syncExternalRoles(user.ID, result.RoleIDs, created)
+	return user, nil
+}
+
+// syncExternalRoles 按外部身份源（LDAP、OIDC）映射结果同步用户角色，失败不阻断登录
+// 映射到角色时覆盖用户角色；未映射到角色且为新开通用户时分配默认角色
+func (s *UserService) syncExternalRoles(userID int64, roleIDs []int64, created bool) {
+	if len(roleIDs) > 0 {
+		if !s.hasExactRoles(userID, roleIDs) {
+			if err := s.UpdateUserRoles(userID, roleIDs, 0); err != nil {
+				s.log.Warn("同步外部身份用户角色失败", "userID", userID, "roleIDs", roleIDs, "error", err)
+			}
+		}
+	} else if created {
+		if err := s.assignDefaultRole(userID); err != nil {
+			s.log.Warn("为外部身份用户分配默认角色失败", "userID", userID, "error", err)
+		}
+	}
+}
+
+// hasExactRoles 判断用户当前角色是否与给定角色集合完全一致
+func (s *UserService) hasExactRoles(userID int64, roleIDs []int64) bool {
+	currentRoles, err := s.roleService.GetRolesForUser(userID)
+	if err != nil || len(currentRoles) != len(roleIDs) {
+		return false
+	}
+	current := make(map[int64]bool, len(currentRoles))
+	for _, role := range currentRoles {
+		current[role.ID] = true
+	}
+	for _, roleID := range roleIDs {
+		if !current[roleID] {
+			return false
+		}
+	}
+	return true
+}
+
 // GetUserInfo 获取用户信息
 func (s *UserService) GetUserInfo(userID int64) (*entity.User, error) {
 	user, err := s.repo.GetByID(userID, repo.DEPARTMENT, repo.POSITION, repo.ROLES)
@@ -340,8 +609,9 @@ func (s *UserService) UpdateUserInfo(user *entity.User, updatedBy int64) error {
 	return nil
 }
 
-// SetUserAuthority 设置用户权限（单角色）
-func (s *UserService) SetUserAuthority(userID, roleID int64) error {
+// SetUserAuthority 设置用户权限（单角色），为用户分配角色
+// 角色开启变更审批时只提交申请并返回 PendingApprovalError
+func (s *UserService) SetUserAuthority(userID, roleID, operatorID int64) error {
 	s.log.Info("设置用户权限（单角色）", "user_id", userID, "role_id", roleID)
 
 	// 检查用户是否存在
@@ -364,10 +634,12 @@ func (s *UserService) SetUserAuthority(userID, roleID int64) error {
 		return errors.New("角色已禁用")
 	}
 
-	// 调用 SaveRolePermissions 方法为用户分配角色（空权限列表）
-	operatorID := fmt.Sprintf("%d", userID)
-	if err := s.rolePermissionService.SaveRolePermissions(roleID, []int64{}, []int64{}, []int64{}, operatorID); err != nil {
-		s.log.Error("保存角色权限失败", "error", err, "user_id", userID, "role_id", roleID)
+	// 分配角色，校验职责分离约束和变更审批
+	if err := s.roleService.AssignUserToRole(roleID, userID, operatorID); err != nil {
+		var pending *PendingApprovalError
+		if !errors.As(err, &pending) {
+			s.log.Error("分配用户角色失败", "error", err, "user_id", userID, "role_id", roleID)
+		}
 		return err
 	}
 
@@ -375,8 +647,9 @@ func (s *UserService) SetUserAuthority(userID, roleID int64) error {
 	return nil
 }
 
-// SetUserAuthorities 设置用户权限（多角色）
-func (s *UserService) SetUserAuthorities(userID int64, roleIDs []int64) error {
+// SetUserAuthorities 设置用户权限（多角色），为用户分配多个角色
+// 开启变更审批的角色只提交申请，其余角色照常生效，最后返回第一个 PendingApprovalError
+func (s *UserService) SetUserAuthorities(userID int64, roleIDs []int64, operatorID int64) error {
 	s.log.Info("设置用户权限（多角色）", "user_id", userID, "role_ids", roleIDs)
 
 	// 检查用户是否存在
@@ -399,14 +672,39 @@ func (s *UserService) SetUserAuthorities(userID int64, roleIDs []int64) error {
 		}
 	}
 
-	// 为每个角色分配权限
-	operatorID := fmt.Sprintf("%d", userID)
+	// 校验分配后用户持有的全部角色是否违反静态职责分离约束
+	currentRoles, err := s.roleService.GetRolesForUser(userID)
+	if err != nil {
+		s.log.Error("获取用户当前角色失败", "error", err, "user_id", userID)
+		return err
+	}
+	finalRoleIDs := append([]int64{}, roleIDs...)
+	for _, role := range currentRoles {
+		finalRoleIDs = append(finalRoleIDs, role.ID)
+	}
+	if err := s.sod.CheckUserRoles(userID, finalRoleIDs); err != nil {
+		return err
+	}
+
+	// 逐个分配角色，需要审批的角色只提交申请
+	var pendingErr error
 	for _, roleID := range roleIDs {
-		if err := s.rolePermissionService.SaveRolePermissions(roleID, []int64{}, []int64{}, []int64{}, operatorID); err != nil {
-			s.log.Error("保存角色权限失败", "error", err, "user_id", userID, "role_id", roleID)
+		err := s.roleService.AssignUserToRole(roleID, userID, operatorID)
+		var pending *PendingApprovalError
+		if errors.As(err, &pending) {
+			if pendingErr == nil {
+				pendingErr = err
+			}
+			continue
+		}
+		if err != nil {
+			s.log.Error("分配用户角色失败", "error", err, "user_id", userID, "role_id", roleID)
 			return err
 		}
 	}
+	if pendingErr != nil {
+		return pendingErr
+	}
 
 	s.log.Info("设置用户权限成功", "user_id", userID, "user_name", user.Username, "role_ids", roleIDs)
 	return nil
@@ -501,18 +799,12 @@ func (s *UserService) ChangePassword(userID int64, oldPassword, newPassword stri
 		return errors.New("旧密码错误")
 	}
 
-	// 生成新密码哈希
-	passwordHash, err := encryption.GeneratePasswordHash(newPassword)
-	if err != nil {
-		s.log.Error("生成密码哈希失败", "error", err)
-		return err
+	if encryption.VerifyPassword(user.PasswordHash, newPassword) == nil {
+		return errors.New("新密码不能与当前密码相同")
 	}
 
-	// 更新密码
-	user.PasswordHash = passwordHash
-	user.UpdatedBy = userID
-	if err := s.repo.Update(user); err != nil {
-		s.log.Error("更新密码失败", "error", err)
+	// 更新密码并清除强制修改标记
+	if err := s.applyNewPassword(user, newPassword, userID, false); err != nil {
 		return err
 	}
 
@@ -530,8 +822,36 @@ func (s *UserService) ChangePassword(userID int64, oldPassword, newPassword stri
 // - time.Time: 刷新令牌过期时间
 // - error: 错误信息
 func (s *UserService) RefreshToken(refreshToken string) (string, string, time.Time, time.Time, error) {
+	claims, err := s.jwtAuth.ParseToken(refreshToken)
+	if err != nil {
+		return "", "", time.Time{}, time.Time{}, err
+	}
+
+	// 会话已被下线或已过期时不再签发新令牌
+	if claims.SessionID != "" {
+		active, err := s.onlineUserService.TouchSession(claims.SessionID)
+		if err != nil {
+			return "", "", time.Time{}, time.Time{}, err
+		}
+		if !active {
+			return "", "", time.Time{}, time.Time{}, errors.New("会话已失效，请重新登录")
+		}
+	}
+
 	// 调用JWT认证服务刷新令牌
-	return s.jwtAuth.RefreshToken(refreshToken)
+	accessToken, newRefreshToken, accessExpire, refreshExpire, err := s.jwtAuth.RefreshToken(refreshToken)
+	if err != nil {
+		return "", "", time.Time{}, time.Time{}, err
+	}
+
+	// 签发了新的刷新令牌时同步延长会话有效期
+	if claims.SessionID != "" && newRefreshToken != refreshToken {
+		if err := s.onlineUserService.RenewSession(claims.SessionID, refreshExpire); err != nil {
+			s.log.Warn("延长会话有效期失败", "error", err, "session_id", claims.SessionID)
+		}
+	}
+
+	return accessToken, newRefreshToken, accessExpire, refreshExpire, nil
 }
 
 // Logout 退出登录
@@ -553,9 +873,20 @@ func (s *UserService) Logout(userID string) error {
 	return nil
 }
 
+// LogoutSession 退出当前设备的登录
+// 只移除令牌对应的会话，用户在其他设备的登录不受影响
+func (s *UserService) LogoutSession(userID, sessionID string) error {
+	if err := s.onlineUserService.RemoveOnlineUserBySessionID(sessionID); err != nil {
+		return err
+	}
+
+	s.log.Info("用户退出登录成功", "userID", userID, "sessionID", sessionID)
+	return nil
+}
+
 // GenerateToken 生成访问令牌和刷新令牌
-func (s *UserService) GenerateToken(userID string, userName string, nickname string, role string, loginType string) (string, string, time.Time, time.Time, error) {
-	return s.jwtAuth.GenerateToken(userID, userName, nickname, role, loginType)
+func (s *UserService) GenerateToken(userID string, userName string, nickname string, role string, loginType string, sessionID string) (string, string, time.Time, time.Time, error) {
+	return s.jwtAuth.GenerateToken(userID, userName, nickname, role, loginType, sessionID)
 }
 
 // GetUserList 获取用户列表
@@ -579,6 +910,11 @@ func (s *UserService) AddUser(userName, password, email, nickname, phone, avatar
 
 	// 不再检查邮箱和手机号的唯一性，因为已经移除了唯一约束
 
+	// 校验密码复杂度
+	if err := s.passwordPolicy.Validate(password, userName); err != nil {
+		return nil, err
+	}
+
 	// 生成密码哈希
 	passwordHash, err := encryption.GeneratePasswordHash(password)
 	if err != nil {
@@ -597,6 +933,9 @@ func (s *UserService) AddUser(userName, password, email, nickname, phone, avatar
 		DepartmentID: departmentID,
 		PositionID:   positionID,
 		Status:       status,
+		// 管理员代设的初始密码与重置密码同等对待
+		PasswordChangedAt:  time.Now(),
+		MustChangePassword: s.passwordPolicy.ForceChangeOnReset(),
 	}
 
 	// 保存用户
@@ -605,6 +944,10 @@ func (s *UserService) AddUser(userName, password, email, nickname, phone, avatar
 		return nil, err
 	}
 
+	// 记录密码历史
+	createdByID, _ := strconv.ParseInt(createdBy, 10, 64)
+	s.passwordPolicy.RecordPassword(user.ID, passwordHash, createdByID)
+
 	// 为用户分配默认角色（user）
 	if err := s.assignDefaultRole(user.ID); err != nil {
 		s.log.Warn("为用户分配默认角色失败", "userID", user.ID, "error", err)
@@ -634,7 +977,12 @@ func (s *UserService) assignDefaultRole(userID int64) error {
 
 	// 如果存在 user 角色，为用户分配
 	if userRole != nil {
-		if err := s.roleService.AssignUserToRole(userRole.ID, userID); err != nil {
+		// 默认角色开启变更审批时，分配等待审批人处理
+		var pending *PendingApprovalError
+		if err := s.roleService.AssignUserToRole(userRole.ID, userID, 0); errors.As(err, &pending) {
+			s.log.Info("默认角色分配已提交审批", "userID", userID, "roleID", userRole.ID, "requestID", pending.Request.ID)
+			return nil
+		} else if err != nil {
 			return err
 		}
 		s.log.Info("为用户分配默认角色成功", "userID", userID, "roleID", userRole.ID, "roleName", userRole.Name)
@@ -668,37 +1016,121 @@ func (s *UserService) DeleteUser(userID int64) error {
 }
 
 // ResetPassword 重置密码（管理员功能）
-func (s *UserService) ResetPassword(userID int64, newPassword string, updatedBy int64) error {
+// newPassword 为空时自动生成符合密码策略的临时密码
+// 根据密码策略设置首次登录强制修改密码标记
+// 返回:
+// - string: 实际设置的密码（供管理员告知用户）
+// - error: 错误信息
+func (s *UserService) ResetPassword(userID int64, newPassword string, updatedBy int64) (string, error) {
 	// 检查用户是否存在
-	_, err := s.repo.GetByID(userID)
+	user, err := s.repo.GetByID(userID)
+	if err != nil {
+		s.log.Error("查找用户失败", "error", err)
+		return "", errors.New("用户不存在")
+	}
+
+	if newPassword == "" {
+		// 生成临时密码，极小概率不满足策略（如包含用户名）时重试
+		for i := 0; i < 5; i++ {
+			newPassword, err = s.passwordPolicy.GenerateCompliantPassword()
+			if err != nil {
+				s.log.Error("生成临时密码失败", "error", err)
+				return "", err
+			}
+			if s.passwordPolicy.Validate(newPassword, user.Username) == nil {
+				break
+			}
+		}
+	}
+
+	// 更新密码，按策略设置首次登录强制修改标记
+	if err := s.applyNewPassword(user, newPassword, updatedBy, s.passwordPolicy.ForceChangeOnReset()); err != nil {
+		return "", err
+	}
+
+	s.log.Info("管理员重置密码成功", "userID", userID, "updatedBy", updatedBy)
+	return newPassword, nil
+}
+
+// ResetPasswordByVerification 通过验证码校验后由用户自助设置新密码
+// 调用方需已完成验证码校验，设置成功后清除强制修改标记并使已签发的令牌失效
+func (s *UserService) ResetPasswordByVerification(userID int64, newPassword string) error {
+	user, err := s.repo.GetByID(userID)
 	if err != nil {
 		s.log.Error("查找用户失败", "error", err)
 		return errors.New("用户不存在")
 	}
 
-	// 生成新密码哈希
+	if err := s.applyNewPassword(user, newPassword, userID, false); err != nil {
+		return err
+	}
+
+	// 找回密码后强制已登录的会话重新登录
+	if err := s.Logout(fmt.Sprintf("%d", userID)); err != nil {
+		s.log.Warn("找回密码后注销旧会话失败", "userID", userID, "error", err)
+	}
+
+	s.log.Info("用户自助找回密码成功", "userID", userID)
+	return nil
+}
+
+// CheckNewPassword 按密码策略校验新密码的复杂度与历史密码，不保存
+// 找回密码在消费验证码前先校验，密码不符合策略时验证码仍然有效
+func (s *UserService) CheckNewPassword(userID int64, newPassword string) error {
+	user, err := s.repo.GetByID(userID)
+	if err != nil {
+		s.log.Error("查找用户失败", "error", err)
+		return errors.New("用户不存在")
+	}
+	if err := s.passwordPolicy.Validate(newPassword, user.Username); err != nil {
+		return err
+	}
+	return s.passwordPolicy.CheckHistory(user.ID, newPassword)
+}
+
+// applyNewPassword 按密码策略校验并保存新密码
+// 校验复杂度与历史密码，更新密码哈希、修改时间和强制修改标记，并记录密码历史
+// 只更新密码相关字段，避免更新 wechat_open_id 字段
+func (s *UserService) applyNewPassword(user *entity.User, newPassword string, operatorID int64, mustChange bool) error {
+	if err := s.passwordPolicy.Validate(newPassword, user.Username); err != nil {
+		return err
+	}
+	if err := s.passwordPolicy.CheckHistory(user.ID, newPassword); err != nil {
+		return err
+	}
+
 	passwordHash, err := encryption.GeneratePasswordHash(newPassword)
 	if err != nil {
 		s.log.Error("生成密码哈希失败", "error", err)
 		return err
 	}
 
-	// 只更新密码和更新时间字段，避免更新 wechat_open_id 字段
+	now := time.Now()
 	updates := map[string]interface{}{
-		"password_hash": passwordHash,
-		"updated_at":    time.Now(),
-		"updated_by":    updatedBy,
+		"password_hash":        passwordHash,
+		"password_changed_at":  now,
+		"must_change_password": mustChange,
+		"updated_at":           now,
+		"updated_by":           operatorID,
 	}
-
-	if err := s.repo.UpdateFields(userID, updates); err != nil {
+	if err := s.repo.UpdateFields(user.ID, updates); err != nil {
 		s.log.Error("更新密码失败", "error", err)
 		return err
 	}
 
-	s.log.Info("管理员重置密码成功", "userID", userID, "updatedBy", updatedBy)
+	// 记录密码历史
+	s.passwordPolicy.RecordPassword(user.ID, passwordHash, operatorID)
 	return nil
 }
 
+// CheckPasswordChangeRequired 判断用户登录后是否需要强制修改密码
+// 返回:
+// - bool: 是否需要修改密码
+// - string: 原因（reset-管理员重置，expired-密码过期）
+func (s *UserService) CheckPasswordChangeRequired(user *entity.User) (bool, string) {
+	return s.passwordPolicy.ChangeRequired(user)
+}
+
 // GetSelfSetting 获取用户设置
 func (s *UserService) GetSelfSetting(userID int64) (*entity.UserSetting, error) {
 
@@ -1032,7 +1464,8 @@ func (s *UserService) GetUserSpecialBtnPermissions(userID int64) ([]*entity.BtnP
 }
 
 // UpdateUserRoles 更新用户角色
-func (s *UserService) UpdateUserRoles(userID int64, roleIDs []int64) error {
+// 开启变更审批的角色只提交申请，其余角色照常生效，最后返回第一个 PendingApprovalError
+func (s *UserService) UpdateUserRoles(userID int64, roleIDs []int64, operatorID int64) error {
 	// 检查用户是否存在
 	user, err := s.repo.GetByID(userID)
 	if err != nil {
@@ -1092,19 +1525,47 @@ func (s *UserService) UpdateUserRoles(userID int64, roleIDs []int64) error {
 
 	s.log.Info("✅ 通过管理员角色保护检查", "userID", userID)
 
-	// 清除用户现有的角色关联
+	// 校验用户最终持有的角色是否违反静态职责分离约束
+	if err := s.sod.CheckUserRoles(userID, roleIDs); err != nil {
+		return err
+	}
+
+	// 只变更有差异的角色，保留的角色不做撤销再分配，避免需要审批的角色产生多余申请
+	keep := make(map[int64]bool, len(roleIDs))
+	for _, roleID := range roleIDs {
+		keep[roleID] = true
+	}
+	held := make(map[int64]bool, len(currentRoles))
+	var pendingErr error
+
+	// 移除不再保留的角色关联
 	for _, role := range currentRoles {
-		err := s.roleService.RevokeUserFromRole(role.ID, userID)
+		held[role.ID] = true
+		if keep[role.ID] {
+			continue
+		}
+		err := s.roleService.RevokeUserFromRole(role.ID, userID, operatorID)
+		var pending *PendingApprovalError
+		if errors.As(err, &pending) {
+			if pendingErr == nil {
+				pendingErr = err
+			}
+			continue
+		}
 		if err != nil {
 			s.log.Error("移除用户角色失败", "error", err, "userID", userID, "roleID", role.ID)
 			return err
 		}
 	}
 
-	s.log.Info("已清除用户现有角色", "userID", userID, "clearedCount", len(currentRoles))
+	s.log.Info("已清除用户不再保留的角色", "userID", userID, "currentCount", len(currentRoles))
 
 	// 为用户分配新的角色
 	for _, roleID := range roleIDs {
+		if held[roleID] {
+			continue
+		}
+
 		// 检查角色是否存在
 		role, err := s.roleService.GetRoleByID(roleID)
 		if err != nil {
@@ -1112,7 +1573,14 @@ func (s *UserService) UpdateUserRoles(userID int64, roleIDs []int64) error {
 			return errors.New("角色不存在")
 		}
 
-		err = s.roleService.AssignUserToRole(roleID, userID)
+		err = s.roleService.AssignUserToRole(roleID, userID, operatorID)
+		var pending *PendingApprovalError
+		if errors.As(err, &pending) {
+			if pendingErr == nil {
+				pendingErr = err
+			}
+			continue
+		}
 		if err != nil {
 			s.log.Error("分配用户角色失败", "error", err, "userID", userID, "roleID", roleID, "roleName", role.Name)
 			return err
@@ -1137,12 +1605,13 @@ func (s *UserService) UpdateUserRoles(userID int64, roleIDs []int64) error {
 	}
 
 	s.log.Info("========== 更新用户角色完成 ==========", "userID", userID, "roleCount", len(roleIDs))
-	return nil
+	return pendingErr
 }
 
 // SwitchRole 切换用户当前角色
 // 该方法仅改变用户的当前活动角色，不修改用户的角色关联关系
-func (s *UserService) SwitchRole(userID int64, roleID int64) error {
+// sessionID 为当前登录会话，同一会话中激活过的角色需满足动态职责分离约束
+func (s *UserService) SwitchRole(userID int64, roleID int64, sessionID string) error {
 	s.log.Info("========== 开始切换用户角色 ==========", "userID", userID, "targetRoleID", roleID)
 
 	// 检查用户是否存在
@@ -1185,10 +1654,19 @@ func (s *UserService) SwitchRole(userID int64, roleID int64) error {
 		return errors.New("角色已禁用")
 	}
 
+	// 校验动态职责分离约束，切换前正在使用的角色视为本会话已激活
+	currentRoleKey := fmt.Sprintf("user:current_role:%s", fmt.Sprintf("%d", userID))
+	var currentRoleID int64
+	if value, err := s.cache.Get(currentRoleKey); err == nil && value != "" {
+		currentRoleID, _ = strconv.ParseInt(value, 10, 64)
+	}
+	if err := s.sod.ActivateRole(userID, sessionID, roleID, currentRoleID); err != nil {
+		return err
+	}
+
 	// 【步骤 1】缓存用户当前角色选择到 Redis
 	// key: "user:current_role:{userID}", value: roleID (字符串格式)
 	// 使用 string 格式，与认证中间件保持一致
-	currentRoleKey := fmt.Sprintf("user:current_role:%s", fmt.Sprintf("%d", userID))
 	currentRoleValue := fmt.Sprintf("%d", roleID)
 	if err := s.cache.Set(currentRoleKey, currentRoleValue, 24*time.Hour); err != nil {
 		s.log.Error("❌ 缓存用户当前角色失败", "error", err, "userID", userID, "roleID", roleID, "cacheKey", currentRoleKey)
@@ -1351,47 +1829,3 @@ func parseUserAgent(userAgent string) (browser string, os string) {
 
 	return browser, os
 }
-
-// getLoginPlaceByIP 根据 IP 地址获取登录地点（简化版本）
-// 实际生产环境可接入 IP 定位 API（如高德、百度、IP2Region 等）
-func getLoginPlaceByIP(ip string) string {
-	// 本地回环地址
-	if ip == "127.0.0.1" || ip == "::1" || ip == "localhost" {
-		return "本地连接"
-	}
-
-	// 内网 IP 范围
-	if isPrivateIP(ip) {
-		return "内网"
-	}
-
-	// TODO: 可以接入 IP 定位服务
-	// 示例：使用 IP2Region、高德地图 API、百度地图 API 等
-	// 这里返回一个默认值
-	return "中国"
-}
-
-// isPrivateIP 判断是否为内网 IP
-func isPrivateIP(ip string) bool {
-	// 简单的内网 IP 判断
-	// 10.0.0.0/8
-	if strings.HasPrefix(ip, "10.") {
-		return true
-	}
-	// 172.16.0.0/12
-	if strings.HasPrefix(ip, "172.") {
-		parts := strings.Split(ip, ".")
-		if len(parts) >= 2 {
-			if second, err := strconv.Atoi(parts[1]); err == nil {
-				if second >= 16 && second <= 31 {
-					return true
-				}
-			}
-		}
-	}
-	// 192.168.0.0/16
-	if strings.HasPrefix(ip, "192.168.") {
-		return true
-	}
-	return false
-}
@@ -1,6 +1,7 @@
 package converter
 
 import (
+	"strconv"
 	"time"
 
 	"github.com/ix-pay/ixpay-pro/internal/domain/base/entity"
@@ -123,6 +124,7 @@ func RoleToDTO(role *entity.Role) response.RoleResponse {
 		Sort:        role.Sort,
 		Status:      role.Status,
 		CreatedAt:   role.CreatedAt.Format(time.RFC3339),
+		DataScope:   role.DataScope,
 	}
 }
 
@@ -154,3 +156,27 @@ func AbnormalLoginInfoToDTO(info *entity.AbnormalLoginInfo) response.AbnormalLog
 		RiskDescription: info.RiskDescription,
 	}
 }
+
+// LoginSecurityEventToDTO 转换为登录安全事件 DTO
+func LoginSecurityEventToDTO(event *entity.LoginSecurityEvent) response.LoginSecurityEventDTO {
+	dto := response.LoginSecurityEventDTO{
+		ID:            strconv.FormatInt(event.ID, 10),
+		UserID:        strconv.FormatInt(event.UserID, 10),
+		Username:      event.Username,
+		Type:          event.Type,
+		RiskLevel:     event.RiskLevel,
+		IP:            event.IP,
+		Place:         event.Place,
+		Device:        event.Device,
+		PreviousIP:    event.PreviousIP,
+		PreviousPlace: event.PreviousPlace,
+		DistanceKm:    event.DistanceKm,
+		Description:   event.Description,
+		StepUpStatus:  event.StepUpStatus,
+		CreatedAt:     event.CreatedAt.Format("2006-01-02 15:04:05"),
+	}
+	if !event.PreviousTime.IsZero() {
+		dto.PreviousTime = event.PreviousTime.Format("2006-01-02 15:04:05")
+	}
+	return dto
+}
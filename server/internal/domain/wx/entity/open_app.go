@@ -0,0 +1,34 @@
+package entity
+
+import "time"
+
+// 开放接口应用状态
+const (
+	OpenAppStatusDisabled = 0 // 禁用
+	OpenAppStatusEnabled  = 1 // 启用
+)
+
+// OpenApp 开放接口应用领域实体
+// 合作方使用 AppKey 标识身份，按签名方式使用 AppSecret 或 RSA 私钥对请求签名
+// 应用代表绑定的平台用户调用支付接口，支付记录归属该用户
+// 纯业务模型，无 GORM 标签
+type OpenApp struct {
+	ID          int64     // 应用 ID
+	Name        string    // 应用名称
+	AppKey      string    // 应用标识，唯一
+	AppSecret   string    // 应用密钥，HMAC 签名方式下用于请求和响应签名
+	SignType    string    // 签名方式：HMAC-SHA256、RSA-SHA256
+	PublicKey   string    // 合作方 RSA 公钥 PEM，RSA 签名方式下用于校验请求签名
+	UserID      int64     // 绑定的平台用户 ID
+	Description string    // 描述
+	Status      int       // 状态：1-启用，0-禁用
+	CreatedBy   int64     // 创建人 ID
+	CreatedAt   time.Time // 创建时间
+	UpdatedBy   int64     // 更新人 ID
+	UpdatedAt   time.Time // 更新时间
+}
+
+// IsActive 检查应用是否启用
+func (a *OpenApp) IsActive() bool {
+	return a.Status == OpenAppStatusEnabled
+}
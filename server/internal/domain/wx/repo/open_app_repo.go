@@ -0,0 +1,14 @@
+package repo
+
+import "github.com/ix-pay/ixpay-pro/internal/domain/wx/entity"
+
+// OpenAppRepository 开放接口应用仓库接口
+// 提供对开放接口应用数据的访问方法
+type OpenAppRepository interface {
+	GetByID(id int64) (*entity.OpenApp, error)
+	GetByAppKey(appKey string) (*entity.OpenApp, error)
+	Create(app *entity.OpenApp) error
+	Update(app *entity.OpenApp) error
+	Delete(id int64) error
+	List(page, pageSize int, filters map[string]interface{}) ([]*entity.OpenApp, int64, error)
+}
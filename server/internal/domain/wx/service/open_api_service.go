@@ -0,0 +1,311 @@
+package service
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ix-pay/ixpay-pro/internal/config"
+	baseRepo "github.com/ix-pay/ixpay-pro/internal/domain/base/repo"
+	"github.com/ix-pay/ixpay-pro/internal/domain/wx/entity"
+	wxRepo "github.com/ix-pay/ixpay-pro/internal/domain/wx/repo"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/observability/logger"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/persistence/cache"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/security/apisign"
+)
+
+// 默认请求时间戳允许的最大偏差
+const defaultOpenAPITimestampTolerance = 300 * time.Second
+
+// 随机串长度限制
+const (
+	openAPINonceMinLength = 16
+	openAPINonceMaxLength = 64
+)
+
+// ErrOpenAPIUnavailable 校验请求时依赖的存储不可用，与签名错误区分，调用方应返回服务端错误
+var ErrOpenAPIUnavailable = errors.New("开放接口鉴权服务暂不可用")
+
+// OpenAPIRequest 待校验的开放接口请求
+type OpenAPIRequest struct {
+	Method    string // 请求方法
+	Path      string // 请求路径
+	RawQuery  string // 原始查询串
+	AppKey    string // 应用标识
+	Timestamp string // Unix 时间戳（秒）
+	Nonce     string // 随机串
+	Signature string // 请求签名，base64 编码
+	Body      []byte // 请求体
+}
+
+// OpenAPIResponseSignature 开放接口响应签名
+type OpenAPIResponseSignature struct {
+	SignType  string // 签名方式
+	Timestamp string // Unix 时间戳（秒）
+	Nonce     string // 随机串
+	Signature string // 响应签名，base64 编码
+}
+
+// OpenAPIService 合作方开放接口服务
+// 管理开放接口应用，校验请求签名、时间戳和随机串，并对响应签名
+type OpenAPIService struct {
+	repo      wxRepo.OpenAppRepository
+	userRepo  baseRepo.UserRepository
+	cache     cache.Cache
+	signer    *apisign.Signer
+	tolerance time.Duration
+	log       logger.Logger
+	now       func() time.Time
+}
+
+// NewOpenAPIService 创建开放接口服务实例
+func NewOpenAPIService(repo wxRepo.OpenAppRepository, userRepo baseRepo.UserRepository, cache cache.Cache, signer *apisign.Signer, cfg *config.Config, log logger.Logger) *OpenAPIService {
+	tolerance := defaultOpenAPITimestampTolerance
+	if cfg.OpenAPI.TimestampTolerance > 0 {
+		tolerance = time.Duration(cfg.OpenAPI.TimestampTolerance) * time.Second
+	}
+	return &OpenAPIService{
+		repo:      repo,
+		userRepo:  userRepo,
+		cache:     cache,
+		signer:    signer,
+		tolerance: tolerance,
+		log:       log,
+		now:       time.Now,
+	}
+}
+
+// CreateApp 创建开放接口应用，生成应用标识和应用密钥
+func (s *OpenAPIService) CreateApp(app *entity.OpenApp) (*entity.OpenApp, error) {
+	if err := s.validateApp(app); err != nil {
+		return nil, err
+	}
+
+	appKey, err := randomHex(16)
+	if err != nil {
+		s.log.Error("生成应用标识失败", "error", err)
+		return nil, errors.New("生成应用标识失败")
+	}
+	appSecret, err := randomHex(32)
+	if err != nil {
+		s.log.Error("生成应用密钥失败", "error", err)
+		return nil, errors.New("生成应用密钥失败")
+	}
+	app.AppKey = appKey
+	app.AppSecret = appSecret
+
+	if err := s.repo.Create(app); err != nil {
+		s.log.Error("创建开放接口应用失败", "name", app.Name, "error", err)
+		return nil, errors.New("创建开放接口应用失败")
+	}
+
+	s.log.Info("开放接口应用创建成功", "appID", app.ID, "appKey", app.AppKey, "signType", app.SignType)
+	return app, nil
+}
+
+// UpdateApp 更新开放接口应用的名称、签名方式、公钥、绑定用户、描述和状态
+func (s *OpenAPIService) UpdateApp(app *entity.OpenApp) error {
+	existing, err := s.repo.GetByID(app.ID)
+	if err != nil {
+		return errors.New("开放接口应用不存在")
+	}
+	if err := s.validateApp(app); err != nil {
+		return err
+	}
+
+	existing.Name = app.Name
+	existing.SignType = app.SignType
+	existing.PublicKey = app.PublicKey
+	existing.UserID = app.UserID
+	existing.Description = app.Description
+	existing.Status = app.Status
+	existing.UpdatedBy = app.UpdatedBy
+	if err := s.repo.Update(existing); err != nil {
+		s.log.Error("更新开放接口应用失败", "appID", app.ID, "error", err)
+		return errors.New("更新开放接口应用失败")
+	}
+
+	s.log.Info("开放接口应用更新成功", "appID", app.ID)
+	return nil
+}
+
+// ResetSecret 重置应用密钥，旧密钥立即失效，返回新密钥
+func (s *OpenAPIService) ResetSecret(id, operatorID int64) (string, error) {
+	app, err := s.repo.GetByID(id)
+	if err != nil {
+		return "", errors.New("开放接口应用不存在")
+	}
+
+	appSecret, err := randomHex(32)
+	if err != nil {
+		s.log.Error("生成应用密钥失败", "error", err)
+		return "", errors.New("生成应用密钥失败")
+	}
+	app.AppSecret = appSecret
+	app.UpdatedBy = operatorID
+	if err := s.repo.Update(app); err != nil {
+		s.log.Error("重置应用密钥失败", "appID", id, "error", err)
+		return "", errors.New("重置应用密钥失败")
+	}
+
+	s.log.Info("应用密钥重置成功", "appID", id, "operatorID", operatorID)
+	return appSecret, nil
+}
+
+// DeleteApp 删除开放接口应用
+func (s *OpenAPIService) DeleteApp(id int64) error {
+	if _, err := s.repo.GetByID(id); err != nil {
+		return errors.New("开放接口应用不存在")
+	}
+	if err := s.repo.Delete(id); err != nil {
+		s.log.Error("删除开放接口应用失败", "appID", id, "error", err)
+		return errors.New("删除开放接口应用失败")
+	}
+	return nil
+}
+
+// GetApp 获取开放接口应用
+func (s *OpenAPIService) GetApp(id int64) (*entity.OpenApp, error) {
+	app, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, errors.New("开放接口应用不存在")
+	}
+	return app, nil
+}
+
+// ListApps 分页查询开放接口应用
+func (s *OpenAPIService) ListApps(page, pageSize int, filters map[string]interface{}) ([]*entity.OpenApp, int64, error) {
+	apps, total, err := s.repo.List(page, pageSize, filters)
+	if err != nil {
+		s.log.Error("查询开放接口应用列表失败", "error", err)
+		return nil, 0, errors.New("查询开放接口应用列表失败")
+	}
+	return apps, total, nil
+}
+
+// VerifyRequest 校验开放接口请求
+// 依次校验时间戳、应用状态和签名，签名通过后再登记随机串，避免未认证的请求占用合作方的随机串
+func (s *OpenAPIService) VerifyRequest(req *OpenAPIRequest) (*entity.OpenApp, error) {
+	if req.AppKey == "" || req.Timestamp == "" || req.Nonce == "" || req.Signature == "" {
+		return nil, errors.New("缺少签名参数")
+	}
+	if len(req.Nonce) < openAPINonceMinLength || len(req.Nonce) > openAPINonceMaxLength {
+		return nil, errors.New("随机串长度必须为 16 到 64 位")
+	}
+
+	timestamp, err := strconv.ParseInt(req.Timestamp, 10, 64)
+	if err != nil {
+		return nil, errors.New("时间戳格式错误")
+	}
+	skew := s.now().Sub(time.Unix(timestamp, 0))
+	if skew > s.tolerance || skew < -s.tolerance {
+		return nil, errors.New("请求时间戳已过期")
+	}
+
+	app, err := s.repo.GetByAppKey(req.AppKey)
+	if err != nil {
+		return nil, errors.New("应用不存在")
+	}
+	if !app.IsActive() {
+		return nil, errors.New("应用已禁用")
+	}
+
+	payload := apisign.RequestSigningString(req.Method, req.Path, req.RawQuery, req.AppKey, req.Timestamp, req.Nonce, req.Body)
+	var valid bool
+	switch app.SignType {
+	case apisign.SignTypeHMAC:
+		valid = apisign.VerifyHMAC(app.AppSecret, payload, req.Signature)
+	case apisign.SignTypeRSA:
+		valid = apisign.VerifyRSA(app.PublicKey, payload, req.Signature)
+	}
+	if !valid {
+		return nil, errors.New("签名校验失败")
+	}
+
+	// 随机串在两倍时间戳偏差内保留，覆盖时间戳可被接受的整个区间
+	ok, err := s.cache.SetNX("open_api:nonce:"+app.AppKey+":"+req.Nonce, req.Timestamp, 2*s.tolerance)
+	if err != nil {
+		s.log.Error("登记开放接口随机串失败", "appKey", app.AppKey, "error", err)
+		return nil, ErrOpenAPIUnavailable
+	}
+	if !ok {
+		return nil, errors.New("随机串已使用，请勿重放请求")
+	}
+
+	return app, nil
+}
+
+// SignResponse 对响应体签名
+// HMAC 签名方式使用应用密钥，RSA 签名方式使用平台私钥，合作方通过平台公钥校验
+func (s *OpenAPIService) SignResponse(app *entity.OpenApp, body []byte) (*OpenAPIResponseSignature, error) {
+	nonce, err := randomHex(16)
+	if err != nil {
+		return nil, err
+	}
+	timestamp := strconv.FormatInt(s.now().Unix(), 10)
+	payload := apisign.ResponseSigningString(app.AppKey, timestamp, nonce, body)
+
+	result := &OpenAPIResponseSignature{
+		SignType:  app.SignType,
+		Timestamp: timestamp,
+		Nonce:     nonce,
+	}
+	if app.SignType == apisign.SignTypeRSA {
+		signature, err := s.signer.Sign(payload)
+		if err != nil {
+			return nil, err
+		}
+		result.Signature = signature
+	} else {
+		result.Signature = apisign.SignHMAC(app.AppSecret, payload)
+	}
+	return result, nil
+}
+
+// PlatformPublicKey 返回平台响应签名公钥 PEM
+func (s *OpenAPIService) PlatformPublicKey() string {
+	return s.signer.PublicKeyPEM()
+}
+
+// validateApp 校验应用名称、签名方式、公钥和绑定用户
+func (s *OpenAPIService) validateApp(app *entity.OpenApp) error {
+	app.Name = strings.TrimSpace(app.Name)
+	if app.Name == "" {
+		return errors.New("应用名称不能为空")
+	}
+
+	switch app.SignType {
+	case apisign.SignTypeHMAC:
+		app.PublicKey = ""
+	case apisign.SignTypeRSA:
+		if _, err := apisign.ParseRSAPublicKey(app.PublicKey); err != nil {
+			return errors.New("RSA 公钥无效：" + err.Error())
+		}
+	default:
+		return errors.New("不支持的签名方式")
+	}
+
+	if app.UserID == 0 {
+		return errors.New("必须绑定平台用户")
+	}
+	if _, err := s.userRepo.GetByID(app.UserID); err != nil {
+		return errors.New("绑定的用户不存在")
+	}
+
+	if app.Status != entity.OpenAppStatusEnabled && app.Status != entity.OpenAppStatusDisabled {
+		return errors.New("应用状态无效")
+	}
+	return nil
+}
+
+// randomHex 生成 n 字节随机数的十六进制字符串
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
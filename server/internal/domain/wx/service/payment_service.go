@@ -77,6 +77,17 @@ func (s *PaymentService) GetPayment(paymentID int64) (*entity.Payment, error) {
 	return payment, nil
 }
 
+// GetPaymentList 分页查询支付列表
+// 过滤条件可以携带数据权限，由仓库按支付用户所属部门过滤
+func (s *PaymentService) GetPaymentList(page, pageSize int, filters map[string]interface{}) ([]*entity.Payment, int, error) {
+	payments, total, err := s.repo.List(page, pageSize, filters)
+	if err != nil {
+		s.log.Error("查询支付列表失败", "error", err)
+		return nil, 0, err
+	}
+	return payments, total, nil
+}
+
 // GetPaymentByOrderID 根据订单 ID 获取支付记录
 func (s *PaymentService) GetPaymentByOrderID(orderID string) (*entity.Payment, error) {
 	payment, err := s.repo.GetByOrderID(orderID)
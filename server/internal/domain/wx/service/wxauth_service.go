@@ -3,12 +3,14 @@ package service
 import (
 	"crypto/rand"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
 	"time"
 
 	baseRepo "github.com/ix-pay/ixpay-pro/internal/domain/base/repo"
+	baseService "github.com/ix-pay/ixpay-pro/internal/domain/base/service"
 	"github.com/ix-pay/ixpay-pro/internal/domain/wx/entity"
 	wxRepo "github.com/ix-pay/ixpay-pro/internal/domain/wx/repo"
 	"github.com/ix-pay/ixpay-pro/internal/dto/wx/response"
@@ -23,21 +25,24 @@ type WXAuthService struct {
 	wxUserRepo           wxRepo.WXUserRepository
 	wxAuthSessionRepo    wxRepo.WXAuthSessionRepository
 	baseConfigRepository baseRepo.ConfigRepository
+	onlineUserService    *baseService.OnlineUserService // 在线用户服务，微信登录与管理端共用会话管理
 }
 
 // NewWXAuthService 创建微信认证服务实例
-func NewWXAuthService(auth *auth.JWTAuth, log logger.Logger, wxUserRepo wxRepo.WXUserRepository, wxAuthSessionRepo wxRepo.WXAuthSessionRepository, baseConfigRepository baseRepo.ConfigRepository) *WXAuthService {
+func NewWXAuthService(auth *auth.JWTAuth, log logger.Logger, wxUserRepo wxRepo.WXUserRepository, wxAuthSessionRepo wxRepo.WXAuthSessionRepository, baseConfigRepository baseRepo.ConfigRepository, onlineUserService *baseService.OnlineUserService) *WXAuthService {
 	return &WXAuthService{
 		log:                  log,
 		auth:                 auth,
 		wxUserRepo:           wxUserRepo,
 		wxAuthSessionRepo:    wxAuthSessionRepo,
 		baseConfigRepository: baseConfigRepository,
+		onlineUserService:    onlineUserService,
 	}
 }
 
 // LoginByCode 通过微信授权码登录
-func (s *WXAuthService) LoginByCode(code string) (*entity.WXUser, string, string, time.Time, time.Time, error) {
+// ip 和 userAgent 用于记录登录会话的设备信息
+func (s *WXAuthService) LoginByCode(code, ip, userAgent string) (*entity.WXUser, string, string, time.Time, time.Time, error) {
 	// 1. 换取 openid 和 session_key
 	tokenResult, err := s.getAccessToken(code)
 	if err != nil {
@@ -65,15 +70,26 @@ func (s *WXAuthService) LoginByCode(code string) (*entity.WXUser, string, string
 		}
 	}
 
-	// 3. 生成会话 token 返回给客户端
+	// 3. 创建登录会话，会话数超过上限时按配置踢出最早的会话或拒绝登录
+	userName := wxUser.Nickname
+	if userName == "" {
+		userName = wxUser.OpenID
+	}
+	onlineSession, err := s.onlineUserService.CreateSession(wxUser.ID, userName, wxUser.Nickname, "wechat", ip, userAgent, s.auth.RefreshTokenExpire())
+	if err != nil {
+		return nil, "", "", time.Time{}, time.Time{}, err
+	}
+
+	// 4. 生成会话 token 返回给客户端
 	// 微信用户没有 nickname 字段，使用空字符串
-	accessToken, refreshToken, accessExpire, refreshExpire, err := s.auth.GenerateToken(fmt.Sprintf("%d", wxUser.ID), "", "", "user", "wechat")
+	accessToken, refreshToken, accessExpire, refreshExpire, err := s.auth.GenerateToken(fmt.Sprintf("%d", wxUser.ID), "", "", "user", "wechat", onlineSession.SessionID)
 	if err != nil {
 		s.log.Error("生成令牌失败", "error", err)
+		s.onlineUserService.RemoveOnlineUserBySessionID(onlineSession.SessionID)
 		return nil, "", "", time.Time{}, time.Time{}, err
 	}
 
-	// 4. 创建授权会话记录
+	// 5. 创建授权会话记录
 	session := &entity.WXAuthSession{
 		WXUserID:     wxUser.ID,
 		AccessToken:  accessToken,
@@ -93,6 +109,23 @@ func (s *WXAuthService) LoginByCode(code string) (*entity.WXUser, string, string
 
 // RefreshToken 刷新访问令牌
 func (s *WXAuthService) RefreshToken(refreshToken string) (string, string, time.Time, time.Time, error) {
+	refreshClaims, err := s.auth.ParseToken(refreshToken)
+	if err != nil {
+		s.log.Error("解析刷新令牌失败", "error", err)
+		return "", "", time.Time{}, time.Time{}, fmt.Errorf("刷新令牌失败: %w", err)
+	}
+
+	// 会话已被下线或已过期时不再签发新令牌
+	if refreshClaims.SessionID != "" {
+		active, err := s.onlineUserService.TouchSession(refreshClaims.SessionID)
+		if err != nil {
+			return "", "", time.Time{}, time.Time{}, err
+		}
+		if !active {
+			return "", "", time.Time{}, time.Time{}, errors.New("会话已失效，请重新登录")
+		}
+	}
+
 	// 使用JWTAuth提供的RefreshToken方法直接刷新令牌
 	newAccessToken, newRefreshToken, accessExpire, refreshExpire, err := s.auth.RefreshToken(refreshToken)
 	if err != nil {
@@ -100,6 +133,13 @@ func (s *WXAuthService) RefreshToken(refreshToken string) (string, string, time.
 		return "", "", time.Time{}, time.Time{}, fmt.Errorf("刷新令牌失败: %w", err)
 	}
 
+	// 签发了新的刷新令牌时同步延长登录会话有效期
+	if refreshClaims.SessionID != "" && newRefreshToken != refreshToken {
+		if err := s.onlineUserService.RenewSession(refreshClaims.SessionID, refreshExpire); err != nil {
+			s.log.Warn("延长会话有效期失败", "error", err, "session_id", refreshClaims.SessionID)
+		}
+	}
+
 	// 解析新的访问令牌以获取用户 ID
 	claims, err := s.auth.ParseToken(newAccessToken)
 	if err != nil {
@@ -353,6 +393,13 @@ func (s *WXAuthService) Logout(accessToken string) error {
 		return err
 	}
 
+	// 移除登录会话，令牌随之失效
+	if claims.SessionID != "" {
+		if err := s.onlineUserService.RemoveOnlineUserBySessionID(claims.SessionID); err != nil {
+			return err
+		}
+	}
+
 	// 查询用户会话
 	session, err := s.wxAuthSessionRepo.GetActiveSessionByWXUserID(wxUserID)
 	if err != nil {
@@ -6,6 +6,7 @@ type GetAPIPageRequest struct {
 	PageSize int    `json:"pageSize" form:"pageSize" binding:"omitempty,min=1,max=100"`
 	Keyword  string `json:"keyword" form:"keyword"`
 	Group    string `json:"group" form:"group"`
+	Orphaned *bool  `json:"orphaned" form:"orphaned"` // 是否只看已失效的路由
 }
 
 // CreateAPIRequest 创建 API 路由请求
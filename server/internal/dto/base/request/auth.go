@@ -4,8 +4,14 @@ package request
 type LoginRequest struct {
 	Username  string `json:"userName" binding:"required"`
 	Password  string `json:"password" binding:"required"`
-	CaptchaId string `json:"captchaId" binding:"required_if=OpenCaptcha true"`
-	Captcha   string `json:"captcha" binding:"required_if=OpenCaptcha true"`
+	CaptchaId string `json:"captchaId"` // 验证码 ID，按登录场景策略需要时必填
+	Captcha   string `json:"captcha"`   // 验证码，滑块验证码为拼图块横坐标
+}
+
+// LoginStepUpRequest 登录二次验证请求参数
+type LoginStepUpRequest struct {
+	ChallengeID string `json:"challengeId" binding:"required"` // 登录时返回的二次验证挑战 ID
+	Code        string `json:"code" binding:"required"`        // 验证码
 }
 
 // RefreshTokenRequest 刷新令牌请求参数
@@ -17,3 +23,24 @@ type RefreshTokenRequest struct {
 type JsonInBlacklistRequest struct {
 	Token string `json:"token" binding:"required"` // JWT token
 }
+
+// ForgotPasswordRequest 找回密码发送验证码请求参数
+type ForgotPasswordRequest struct {
+	Account   string `json:"account" binding:"required,max=100"`          // 用户名、邮箱或手机号
+	Channel   string `json:"channel" binding:"omitempty,oneof=email sms"` // 发送渠道，为空时自动选择
+	CaptchaId string `json:"captchaId"`                                   // 图形验证码 ID
+	Captcha   string `json:"captcha"`                                     // 图形验证码，滑块验证码为拼图块横坐标
+}
+
+// CaptchaRequest 获取验证码请求参数
+type CaptchaRequest struct {
+	Scenario string `json:"scenario" form:"scenario" binding:"omitempty,oneof=login register password_reset"` // 验证码场景，默认 login
+	Account  string `json:"account" form:"account" binding:"max=100"`                                         // 账号，登录场景用于判断是否已达到失败阈值
+}
+
+// ForgotPasswordResetRequest 找回密码设置新密码请求参数
+type ForgotPasswordResetRequest struct {
+	Account     string `json:"account" binding:"required,max=100"`
+	Code        string `json:"code" binding:"required,max=10"`
+	NewPassword string `json:"newPassword" binding:"required,min=6,max=64"`
+}
@@ -0,0 +1,39 @@
+// request 包定义 IP 访问策略管理相关的请求模型
+// 用于接收和验证 HTTP 请求参数
+package request
+
+// CreateIPPolicyRequest 创建 IP 访问策略请求
+type CreateIPPolicyRequest struct {
+	Name        string   `json:"name" binding:"required,max=100"` // 策略名称
+	Scope       string   `json:"scope" binding:"required"`        // 作用范围：global、role、user
+	TargetID    string   `json:"targetId"`                        // 作用对象 ID：角色 ID 或用户 ID，全局策略不填
+	Action      string   `json:"action" binding:"required"`       // 动作：allow、deny
+	CIDRs       []string `json:"cidrs" binding:"required"`        // CIDR 规则列表，也可以填写单个 IP
+	Description string   `json:"description"`                     // 描述
+	Status      *int     `json:"status"`                          // 状态：1-启用，0-禁用，默认为 1
+}
+
+// UpdateIPPolicyRequest 更新 IP 访问策略请求
+type UpdateIPPolicyRequest struct {
+	Name        string   `json:"name" binding:"required,max=100"` // 策略名称
+	Scope       string   `json:"scope" binding:"required"`        // 作用范围：global、role、user
+	TargetID    string   `json:"targetId"`                        // 作用对象 ID：角色 ID 或用户 ID，全局策略不填
+	Action      string   `json:"action" binding:"required"`       // 动作：allow、deny
+	CIDRs       []string `json:"cidrs" binding:"required"`        // CIDR 规则列表，也可以填写单个 IP
+	Description string   `json:"description"`                     // 描述
+	Status      int      `json:"status"`                          // 状态：1-启用，0-禁用
+}
+
+// GetIPPolicyListRequest 获取 IP 访问策略列表请求
+type GetIPPolicyListRequest struct {
+	Page     int    `form:"page" binding:"required"`     // 页码
+	PageSize int    `form:"pageSize" binding:"required"` // 每页数量
+	Scope    string `form:"scope"`                       // 作用范围（可选筛选条件）
+	Action   string `form:"action"`                      // 动作（可选筛选条件）
+	Status   *int   `form:"status"`                      // 状态（可选筛选条件）
+}
+
+// BlockAbnormalIPsRequest 封禁异常登录 IP 请求
+type BlockAbnormalIPsRequest struct {
+	MinFailedCount int64 `json:"minFailedCount" binding:"min=0"` // 最近 1 小时失败登录次数阈值，默认为 5
+}
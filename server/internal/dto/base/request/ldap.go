@@ -0,0 +1,28 @@
+// request 包定义 LDAP 组映射相关的请求模型
+// 用于接收和验证 HTTP 请求参数
+package request
+
+// CreateLDAPGroupMappingRequest 创建 LDAP 组映射请求
+type CreateLDAPGroupMappingRequest struct {
+	GroupDN      string `json:"groupDn" binding:"required,max=255"` // LDAP 组 DN 或组名（CN）
+	RoleID       int64  `json:"roleId,string"`                      // 映射的角色 ID
+	DepartmentID int64  `json:"departmentId,string"`                // 映射的部门 ID
+	Priority     int    `json:"priority"`                           // 优先级，值越大越优先
+	Description  string `json:"description"`                        // 描述
+}
+
+// UpdateLDAPGroupMappingRequest 更新 LDAP 组映射请求
+type UpdateLDAPGroupMappingRequest struct {
+	GroupDN      string `json:"groupDn" binding:"required,max=255"` // LDAP 组 DN 或组名（CN）
+	RoleID       int64  `json:"roleId,string"`                      // 映射的角色 ID
+	DepartmentID int64  `json:"departmentId,string"`                // 映射的部门 ID
+	Priority     int    `json:"priority"`                           // 优先级，值越大越优先
+	Description  string `json:"description"`                        // 描述
+}
+
+// GetLDAPGroupMappingListRequest 获取 LDAP 组映射列表请求
+type GetLDAPGroupMappingListRequest struct {
+	Page     int    `form:"page" binding:"required"`     // 页码
+	PageSize int    `form:"pageSize" binding:"required"` // 每页数量
+	GroupDN  string `form:"groupDn"`                     // LDAP 组（模糊匹配）
+}
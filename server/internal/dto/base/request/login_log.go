@@ -26,6 +26,18 @@ type GetAbnormalLoginsRequest struct {
 	PageSize int `form:"pageSize" binding:"required"` // 每页数量
 }
 
+// GetLoginSecurityEventListRequest 获取登录安全事件请求
+type GetLoginSecurityEventListRequest struct {
+	Page      int    `form:"page" binding:"required"`     // 页码
+	PageSize  int    `form:"pageSize" binding:"required"` // 每页数量
+	UserID    *int64 `form:"userId"`                      // 用户 ID（可选筛选）
+	Username  string `form:"userName"`                    // 用户名（可选筛选）
+	Type      string `form:"type"`                        // 事件类型（可选筛选）
+	RiskLevel string `form:"riskLevel"`                   // 风险等级（可选筛选）
+	StartDate string `form:"startDate"`                   // 开始日期（可选筛选）
+	EndDate   string `form:"endDate"`                     // 结束日期（可选筛选）
+}
+
 // GetOnlineUserListRequest 获取在线用户列表请求
 type GetOnlineUserListRequest struct {
 	Page     int `form:"page" binding:"required"`     // 页码（预留分页支持）
@@ -49,6 +61,20 @@ type BatchForceOfflineRequest struct {
 	Reason  string  `json:"reason"`                     // 下线原因
 }
 
+// RevokeSessionRequest 下线登录设备请求
+type RevokeSessionRequest struct {
+	SessionID string `json:"sessionId" binding:"required"` // 会话 ID
+}
+
+// GetSessionAuditLogListRequest 获取会话终止审计记录请求
+type GetSessionAuditLogListRequest struct {
+	Page     int    `form:"page" binding:"required"`     // 页码
+	PageSize int    `form:"pageSize" binding:"required"` // 每页数量
+	UserID   *int64 `form:"userId"`                      // 用户 ID（可选筛选）
+	Username string `form:"userName"`                    // 用户名（可选筛选）
+	Action   string `form:"action"`                      // 终止方式（可选筛选）
+}
+
 // RecordLoginRequest 记录登录日志请求（内部调用）
 type RecordLoginRequest struct {
 	UserID    int64  `json:"userId" binding:"required"`   // 用户 ID
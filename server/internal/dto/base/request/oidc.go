@@ -0,0 +1,134 @@
+// request 包定义 OIDC 单点登录相关的请求模型
+// 用于接收和验证 HTTP 请求参数
+package request
+
+// OIDCRoleMappingRequest 声明到角色的映射规则
+type OIDCRoleMappingRequest struct {
+	Claim  string `json:"claim" binding:"required"`         // 声明路径，例如 groups、realm_access.roles
+	Value  string `json:"value" binding:"required"`         // 期望的声明值
+	RoleID int64  `json:"roleId,string" binding:"required"` // 授予的角色 ID
+}
+
+// CreateOIDCProviderRequest 创建身份提供方请求
+type CreateOIDCProviderRequest struct {
+	Code                  string                   `json:"code" binding:"required"`               // 编码，用于登录地址
+	Name                  string                   `json:"name" binding:"required,max=100"`       // 显示名称
+	Issuer                string                   `json:"issuer" binding:"required"`             // 签发方
+	ClientID              string                   `json:"clientId" binding:"required"`           // 客户端 ID
+	ClientSecret          string                   `json:"clientSecret" binding:"required"`       // 客户端密钥
+	TokenAuthMethod       string                   `json:"tokenAuthMethod"`                       // 客户端认证方式：client_secret_basic / client_secret_post
+	Scopes                []string                 `json:"scopes"`                                // 授权范围，默认 openid profile email
+	RedirectURI           string                   `json:"redirectUri" binding:"required"`        // 回调地址
+	AuthorizationEndpoint string                   `json:"authorizationEndpoint"`                 // 授权端点，为空时使用发现文档
+	TokenEndpoint         string                   `json:"tokenEndpoint"`                         // 令牌端点，为空时使用发现文档
+	UserinfoEndpoint      string                   `json:"userinfoEndpoint"`                      // 用户信息端点，为空时使用发现文档
+	JWKSURI               string                   `json:"jwksUri"`                               // JWKS 地址，为空时使用发现文档
+	LinkBy                string                   `json:"linkBy"`                                // 账号关联方式：subject / email
+	TrustEmail            bool                     `json:"trustEmail"`                            // 是否信任未声明 email_verified 的邮箱
+	AutoCreate            bool                     `json:"autoCreate"`                            // 无关联账号时是否自动开通本地用户
+	UsernameClaim         string                   `json:"usernameClaim"`                         // 自动开通时作为用户名的声明
+	RoleMappings          []OIDCRoleMappingRequest `json:"roleMappings" binding:"omitempty,dive"` // 声明到角色的映射规则
+	Status                int                      `json:"status" binding:"oneof=0 1"`            // 状态：1-启用，0-禁用
+	Sort                  int                      `json:"sort"`                                  // 排序
+}
+
+// UpdateOIDCProviderRequest 更新身份提供方请求
+type UpdateOIDCProviderRequest struct {
+	Name                  string                   `json:"name" binding:"required,max=100"`       // 显示名称
+	Issuer                string                   `json:"issuer" binding:"required"`             // 签发方
+	ClientID              string                   `json:"clientId" binding:"required"`           // 客户端 ID
+	ClientSecret          string                   `json:"clientSecret"`                          // 客户端密钥，为空时保留原值
+	TokenAuthMethod       string                   `json:"tokenAuthMethod"`                       // 客户端认证方式
+	Scopes                []string                 `json:"scopes"`                                // 授权范围
+	RedirectURI           string                   `json:"redirectUri" binding:"required"`        // 回调地址
+	AuthorizationEndpoint string                   `json:"authorizationEndpoint"`                 // 授权端点
+	TokenEndpoint         string                   `json:"tokenEndpoint"`                         // 令牌端点
+	UserinfoEndpoint      string                   `json:"userinfoEndpoint"`                      // 用户信息端点
+	JWKSURI               string                   `json:"jwksUri"`                               // JWKS 地址
+	LinkBy                string                   `json:"linkBy"`                                // 账号关联方式
+	TrustEmail            bool                     `json:"trustEmail"`                            // 是否信任未声明 email_verified 的邮箱
+	AutoCreate            bool                     `json:"autoCreate"`                            // 是否自动开通本地用户
+	UsernameClaim         string                   `json:"usernameClaim"`                         // 自动开通时作为用户名的声明
+	RoleMappings          []OIDCRoleMappingRequest `json:"roleMappings" binding:"omitempty,dive"` // 声明到角色的映射规则
+	Status                int                      `json:"status" binding:"oneof=0 1"`            // 状态：1-启用，0-禁用
+	Sort                  int                      `json:"sort"`                                  // 排序
+}
+
+// GetOIDCProviderListRequest 获取身份提供方列表请求
+type GetOIDCProviderListRequest struct {
+	Page     int    `form:"page" binding:"required"`     // 页码
+	PageSize int    `form:"pageSize" binding:"required"` // 每页数量
+	Name     string `form:"name"`                        // 名称（模糊匹配）
+	Status   *int   `form:"status"`                      // 状态
+}
+
+// OIDCCallbackRequest 单点登录回调请求
+// 前端从身份提供方重定向回来后，将地址中的 code 和 state 原样提交
+type OIDCCallbackRequest struct {
+	Code  string `json:"code" binding:"required"`  // 授权码
+	State string `json:"state" binding:"required"` // 发起登录时返回的 state
+}
+
+// CreateOIDCClientRequest 登记 OIDC 客户端请求
+type CreateOIDCClientRequest struct {
+	ClientID     string   `json:"clientId" binding:"required"`     // 客户端标识，例如 web、h5app
+	Name         string   `json:"name" binding:"required,max=100"` // 应用名称
+	Description  string   `json:"description" binding:"max=500"`   // 描述
+	Public       bool     `json:"public"`                          // 是否为公开客户端（SPA、移动端），无密钥，必须使用 PKCE
+	RedirectURIs []string `json:"redirectUris"`                    // 允许的回调地址，精确匹配
+	GrantTypes   []string `json:"grantTypes"`                      // 授权类型，默认 authorization_code、refresh_token
+	Scopes       []string `json:"scopes"`                          // 允许申请的授权范围，openid 始终包含
+	SkipConsent  bool     `json:"skipConsent"`                     // 是否跳过授权确认
+	Status       int      `json:"status" binding:"oneof=0 1"`      // 状态：1-启用，0-禁用
+}
+
+// UpdateOIDCClientRequest 更新 OIDC 客户端请求（客户端标识和类型不可修改）
+type UpdateOIDCClientRequest struct {
+	Name         string   `json:"name" binding:"required,max=100"` // 应用名称
+	Description  string   `json:"description" binding:"max=500"`   // 描述
+	RedirectURIs []string `json:"redirectUris"`                    // 允许的回调地址
+	GrantTypes   []string `json:"grantTypes"`                      // 授权类型
+	Scopes       []string `json:"scopes"`                          // 允许申请的授权范围
+	SkipConsent  bool     `json:"skipConsent"`                     // 是否跳过授权确认
+	Status       int      `json:"status" binding:"oneof=0 1"`      // 状态：1-启用，0-禁用
+}
+
+// GetOIDCClientListRequest 获取 OIDC 客户端列表请求
+type GetOIDCClientListRequest struct {
+	Page     int    `form:"page" binding:"required"`     // 页码
+	PageSize int    `form:"pageSize" binding:"required"` // 每页数量
+	Name     string `form:"name"`                        // 名称（模糊匹配）
+	Status   *int   `form:"status"`                      // 状态
+}
+
+// OIDCAuthorizeRequest 授权请求参数
+// 授权端点按协议使用查询串参数；授权确认页将同样的参数以 JSON 提交
+type OIDCAuthorizeRequest struct {
+	ResponseType        string `form:"response_type" json:"responseType"`                // 响应类型，仅支持 code
+	ClientID            string `form:"client_id" json:"clientId"`                        // 客户端标识
+	RedirectURI         string `form:"redirect_uri" json:"redirectUri"`                  // 回调地址
+	Scope               string `form:"scope" json:"scope"`                               // 授权范围，空格分隔
+	State               string `form:"state" json:"state"`                               // 客户端状态
+	Nonce               string `form:"nonce" json:"nonce"`                               // ID Token nonce
+	CodeChallenge       string `form:"code_challenge" json:"codeChallenge"`              // PKCE 校验值
+	CodeChallengeMethod string `form:"code_challenge_method" json:"codeChallengeMethod"` // PKCE 方法
+	Prompt              string `form:"prompt" json:"prompt"`                             // consent 表示强制重新确认
+}
+
+// OIDCConsentRequest 授权确认请求
+type OIDCConsentRequest struct {
+	OIDCAuthorizeRequest
+	Approved bool `json:"approved"` // 是否同意授权
+}
+
+// OIDCTokenRequest 令牌端点请求（application/x-www-form-urlencoded）
+type OIDCTokenRequest struct {
+	GrantType    string `form:"grant_type"`    // 授权类型
+	Code         string `form:"code"`          // 授权码
+	RedirectURI  string `form:"redirect_uri"`  // 回调地址
+	CodeVerifier string `form:"code_verifier"` // PKCE 原始值
+	RefreshToken string `form:"refresh_token"` // 刷新令牌
+	Scope        string `form:"scope"`         // 授权范围
+	ClientID     string `form:"client_id"`     // 客户端标识（client_secret_post 或公开客户端）
+	ClientSecret string `form:"client_secret"` // 客户端密钥（client_secret_post）
+}
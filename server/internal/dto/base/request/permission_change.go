@@ -0,0 +1,17 @@
+// request 包定义权限变更审批相关的请求模型
+// 用于接收和验证 HTTP 请求参数
+package request
+
+// ReviewPermissionChangeRequest 审批权限变更申请请求
+type ReviewPermissionChangeRequest struct {
+	Comment string `json:"comment" binding:"max=500"` // 审批意见
+}
+
+// GetPermissionChangeListRequest 获取权限变更申请列表请求
+type GetPermissionChangeListRequest struct {
+	Page       int    `form:"page" binding:"required"`     // 页码
+	PageSize   int    `form:"pageSize" binding:"required"` // 每页数量
+	RoleID     string `form:"roleId"`                      // 角色 ID（可选筛选条件）
+	ChangeType string `form:"changeType"`                  // 变更类型（可选筛选条件）
+	Status     string `form:"status"`                      // 状态（可选筛选条件）
+}
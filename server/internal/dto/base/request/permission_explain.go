@@ -0,0 +1,31 @@
+// request 包定义权限解释相关的请求模型
+// 用于接收和验证 HTTP 请求参数
+package request
+
+// ExplainPermissionRequest 权限解释请求
+// 填写 buttonCode 时解释按钮权限，否则解释 method + path 的 API 访问权限
+type ExplainPermissionRequest struct {
+	UserID     string                 `json:"userId" binding:"required"` // 用户 ID
+	RoleCode   string                 `json:"roleCode"`                  // 当前角色编码，为空时按用户的全部角色解释，不是用户已分配的角色时按无角色解释
+	Method     string                 `json:"method"`                    // 请求方法
+	Path       string                 `json:"path"`                      // 请求路径，如 /api/admin/user/123
+	ButtonCode string                 `json:"buttonCode"`                // 按钮权限编码
+	Attributes map[string]interface{} `json:"attributes"`                // 补充的资源和环境属性，如 {"env.ip": "10.0.0.1"}
+}
+
+// SimulatePermissionRequest 权限模拟请求，按拟议的权限变更解释判定过程，变更不会保存
+type SimulatePermissionRequest struct {
+	ExplainPermissionRequest
+	UserRoleIDs []string            `json:"userRoleIds"` // 用户拟分配的角色 ID 列表，不填写时使用当前分配
+	Roles       []RoleChangeRequest `json:"roles"`       // 角色拟修改的配置
+}
+
+// RoleChangeRequest 角色拟修改的配置，字段不填写时使用当前配置
+type RoleChangeRequest struct {
+	RoleID             string   `json:"roleId" binding:"required"` // 角色 ID
+	ParentID           *string  `json:"parentId"`                  // 父角色 ID，"0" 表示取消继承
+	Status             *int     `json:"status"`                    // 状态：1-启用，0-禁用
+	APIIDs             []string `json:"apiIds"`                    // 直接授予的 API ID 列表
+	BtnPermIDs         []string `json:"btnPermIds"`                // 直接授予的按钮权限 ID 列表
+	PermissionGroupIDs []string `json:"permissionGroupIds"`        // 关联的权限组 ID 列表
+}
@@ -0,0 +1,40 @@
+// request 包定义权限规则（ABAC）管理相关的请求模型
+// 用于接收和验证 HTTP 请求参数
+package request
+
+// CreatePermissionRuleRequest 创建权限规则请求
+type CreatePermissionRuleRequest struct {
+	Name        string `json:"name" binding:"required,max=100"`    // 规则名称
+	Description string `json:"description" binding:"max=500"`      // 规则描述
+	Effect      string `json:"effect" binding:"required"`          // 效果：allow、deny
+	APIPath     string `json:"apiPath" binding:"required,max=255"` // API 路径模式，支持 :param 和 *wildcard
+	Method      string `json:"method"`                             // HTTP 方法，多个用逗号分隔，为空或 * 表示全部
+	Conditions  string `json:"conditions"`                         // 条件表达式，JSON 格式，为空表示无条件
+	Status      *int   `json:"status"`                             // 状态：1-启用，0-禁用，默认为 1
+	Sort        int    `json:"sort"`                               // 排序
+}
+
+// UpdatePermissionRuleRequest 更新权限规则请求
+type UpdatePermissionRuleRequest struct {
+	Name        string `json:"name" binding:"required,max=100"`    // 规则名称
+	Description string `json:"description" binding:"max=500"`      // 规则描述
+	Effect      string `json:"effect" binding:"required"`          // 效果：allow、deny
+	APIPath     string `json:"apiPath" binding:"required,max=255"` // API 路径模式，支持 :param 和 *wildcard
+	Method      string `json:"method"`                             // HTTP 方法，多个用逗号分隔，为空或 * 表示全部
+	Conditions  string `json:"conditions"`                         // 条件表达式，JSON 格式，为空表示无条件
+	Status      int    `json:"status"`                             // 状态：1-启用，0-禁用
+	Sort        int    `json:"sort"`                               // 排序
+}
+
+// GetPermissionRuleListRequest 获取权限规则列表请求
+type GetPermissionRuleListRequest struct {
+	Page     int    `form:"page" binding:"required"`     // 页码
+	PageSize int    `form:"pageSize" binding:"required"` // 每页数量
+	Effect   string `form:"effect"`                      // 效果（可选筛选条件）
+	Status   *int   `form:"status"`                      // 状态（可选筛选条件）
+}
+
+// ValidatePermissionRuleConditionsRequest 校验权限规则条件请求
+type ValidatePermissionRuleConditionsRequest struct {
+	Conditions string `json:"conditions"` // 条件表达式，JSON 格式
+}
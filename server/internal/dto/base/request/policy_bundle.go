@@ -0,0 +1,15 @@
+// request 包定义权限策略包相关的请求模型
+// 用于接收和验证 HTTP 请求参数
+package request
+
+// ExportPolicyBundleRequest 导出权限策略包请求
+type ExportPolicyBundleRequest struct {
+	Format string `form:"format" binding:"omitempty,oneof=yaml json"` // 文件格式：yaml（默认）、json
+}
+
+// ImportPolicyBundleRequest 导入权限策略包请求
+type ImportPolicyBundleRequest struct {
+	Format  string `json:"format" binding:"omitempty,oneof=yaml json"` // 文件格式：yaml（默认）、json
+	Content string `json:"content" binding:"required"`                 // 策略包文件内容
+	DryRun  bool   `json:"dryRun"`                                     // 是否只比较差异不写入
+}
@@ -51,6 +51,17 @@ type AssignToRoleRequest struct {
 	IDs    []string `json:"ids" binding:"required,min=1"`
 }
 
+// SetRoleDataScopeRequest 设置角色数据权限请求模型
+type SetRoleDataScopeRequest struct {
+	DataScope int      `json:"dataScope" binding:"required,min=1,max=5"` // 数据权限范围：1-全部，2-本部门，3-本部门及下级，4-自定义部门，5-仅本人
+	DeptIds   []string `json:"deptIds"`                                  // 自定义部门 ID 列表，数据权限范围为 4 时必填
+}
+
+// SetRoleApprovalRequiredRequest 设置角色权限变更是否需要审批请求模型
+type SetRoleApprovalRequiredRequest struct {
+	ApprovalRequired *bool `json:"approvalRequired" binding:"required"` // 是否需要审批
+}
+
 // SaveRolePermissionsRequest 保存角色权限请求模型
 type SaveRolePermissionsRequest struct {
 	MenuIds     []string `json:"menuIds" binding:"required"`
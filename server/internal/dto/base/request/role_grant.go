@@ -0,0 +1,35 @@
+// request 包定义限时角色授予相关的请求模型
+// 用于接收和验证 HTTP 请求参数
+package request
+
+import "time"
+
+// GrantRoleRequest 直接授予限时角色请求
+type GrantRoleRequest struct {
+	UserID        string     `json:"userId" binding:"required"`       // 被授予用户 ID
+	RoleID        string     `json:"roleId" binding:"required"`       // 授予的角色 ID
+	ValidFrom     *time.Time `json:"validFrom"`                       // 生效时间，为空表示立即生效
+	ValidUntil    time.Time  `json:"validUntil" binding:"required"`   // 到期时间
+	Justification string     `json:"justification" binding:"max=500"` // 授予理由
+}
+
+// RequestElevationRequest 申请临时提权请求
+type RequestElevationRequest struct {
+	RoleID        string `json:"roleId" binding:"required"`                // 申请的角色 ID
+	Hours         int    `json:"hours" binding:"required,min=1"`           // 申请时长（小时）
+	Justification string `json:"justification" binding:"required,max=500"` // 申请理由
+}
+
+// ReviewRoleGrantRequest 审批提权申请或收回限时授予请求
+type ReviewRoleGrantRequest struct {
+	Comment string `json:"comment" binding:"max=500"` // 审批意见或收回原因
+}
+
+// GetRoleGrantListRequest 获取限时授予列表请求
+type GetRoleGrantListRequest struct {
+	Page     int    `form:"page" binding:"required"`     // 页码
+	PageSize int    `form:"pageSize" binding:"required"` // 每页数量
+	UserID   string `form:"userId"`                      // 用户 ID（可选筛选条件）
+	RoleID   string `form:"roleId"`                      // 角色 ID（可选筛选条件）
+	Status   string `form:"status"`                      // 状态（可选筛选条件）
+}
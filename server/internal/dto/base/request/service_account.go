@@ -0,0 +1,36 @@
+// request 包定义服务账号管理相关的请求模型
+// 用于接收和验证 HTTP 请求参数
+package request
+
+import "time"
+
+// CreateServiceAccountRequest 创建服务账号请求
+type CreateServiceAccountRequest struct {
+	Name        string     `json:"name" binding:"required,max=100"` // 服务账号名称
+	Description string     `json:"description"`                     // 描述
+	Status      int        `json:"status"`                          // 状态：1-启用，0-禁用，默认为 1
+	IPAllowlist []string   `json:"ipAllowlist"`                     // IP 白名单（IP 或 CIDR），为空表示不限制
+	RoleIDs     []string   `json:"roleIds" binding:"required"`      // 绑定的角色 ID 列表
+	ExpiresAt   *time.Time `json:"expiresAt"`                       // 首个 API Key 过期时间，为空表示永不过期
+}
+
+// UpdateServiceAccountRequest 更新服务账号请求
+type UpdateServiceAccountRequest struct {
+	Description string   `json:"description"`                // 描述
+	Status      int      `json:"status"`                     // 状态：1-启用，0-禁用
+	IPAllowlist []string `json:"ipAllowlist"`                // IP 白名单（IP 或 CIDR）
+	RoleIDs     []string `json:"roleIds" binding:"required"` // 绑定的角色 ID 列表
+}
+
+// GetServiceAccountListRequest 获取服务账号列表请求
+type GetServiceAccountListRequest struct {
+	Page     int  `form:"page" binding:"required"`     // 页码
+	PageSize int  `form:"pageSize" binding:"required"` // 每页数量
+	Status   *int `form:"status"`                      // 状态（可选筛选条件）
+}
+
+// RotateServiceAccountKeyRequest 轮换 API Key 请求
+type RotateServiceAccountKeyRequest struct {
+	ExpiresAt    *time.Time `json:"expiresAt"`                    // 新 Key 过期时间，为空表示永不过期
+	GraceSeconds int        `json:"graceSeconds" binding:"min=0"` // 旧 Key 宽限期（秒），0 表示立即失效
+}
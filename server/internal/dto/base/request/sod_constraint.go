@@ -0,0 +1,32 @@
+// request 包定义职责分离约束相关的请求模型
+// 用于接收和验证 HTTP 请求参数
+package request
+
+// CreateSoDConstraintRequest 创建职责分离约束请求
+type CreateSoDConstraintRequest struct {
+	Name        string   `json:"name" binding:"required,max=100"`              // 约束名称
+	Description string   `json:"description" binding:"max=500"`                // 描述
+	Type        string   `json:"type" binding:"required,oneof=static dynamic"` // 约束类型：static-静态，dynamic-动态
+	RoleIds     []string `json:"roleIds" binding:"required,min=2"`             // 约束涉及的角色 ID 列表
+	MaxRoles    int      `json:"maxRoles" binding:"omitempty,min=1"`           // 最多可持有或激活的角色数量，默认为 1 即互斥
+	Status      *int     `json:"status"`                                       // 状态：1-启用，0-禁用，默认为 1
+}
+
+// UpdateSoDConstraintRequest 更新职责分离约束请求
+type UpdateSoDConstraintRequest struct {
+	Name        string   `json:"name" binding:"required,max=100"`              // 约束名称
+	Description string   `json:"description" binding:"max=500"`                // 描述
+	Type        string   `json:"type" binding:"required,oneof=static dynamic"` // 约束类型：static-静态，dynamic-动态
+	RoleIds     []string `json:"roleIds" binding:"required,min=2"`             // 约束涉及的角色 ID 列表
+	MaxRoles    int      `json:"maxRoles" binding:"required,min=1"`            // 最多可持有或激活的角色数量
+	Status      int      `json:"status"`                                       // 状态：1-启用，0-禁用
+}
+
+// GetSoDConstraintListRequest 获取职责分离约束列表请求
+type GetSoDConstraintListRequest struct {
+	Page     int    `form:"page" binding:"required"`     // 页码
+	PageSize int    `form:"pageSize" binding:"required"` // 每页数量
+	Name     string `form:"name"`                        // 约束名称（可选筛选条件，模糊匹配）
+	Type     string `form:"type"`                        // 约束类型（可选筛选条件）
+	Status   *int   `form:"status"`                      // 状态（可选筛选条件）
+}
@@ -3,8 +3,11 @@ package request
 // RegisterRequest 注册请求参数
 type RegisterRequest struct {
 	Username string `json:"userName" binding:"required,min=3,max=50"`
-	Password string `json:"password" binding:"required,min=6,max=30"`
+	Password string `json:"password" binding:"required,min=6,max=64"`
 	Email    string `json:"email" binding:"required,email"`
+
+	CaptchaId string `json:"captchaId"` // 验证码 ID
+	Captcha   string `json:"captcha"`   // 验证码，滑块验证码为拼图块横坐标
 }
 
 // UpdateUserRequest 更新用户信息请求参数
@@ -20,19 +23,20 @@ type UpdateUserRequest struct {
 
 // ChangePasswordRequest 修改密码请求参数
 type ChangePasswordRequest struct {
-	OldPassword string `json:"oldPassword" binding:"required,min=6,max=30"`
-	NewPassword string `json:"newPassword" binding:"required,min=6,max=30"`
+	OldPassword string `json:"oldPassword" binding:"required,min=6,max=64"`
+	NewPassword string `json:"newPassword" binding:"required,min=6,max=64"`
 }
 
 // ResetPasswordRequest 重置密码请求参数
 type ResetPasswordRequest struct {
-	UserID int64 `json:"userId" binding:"required"`
+	UserID      int64  `json:"userId" binding:"required"`
+	NewPassword string `json:"newPassword" binding:"omitempty,max=64"` // 新密码，为空时自动生成符合策略的临时密码
 }
 
 // AddUserRequest 增加用户请求参数
 type AddUserRequest struct {
 	Username     string   `json:"userName" binding:"required,min=3,max=50"`
-	Password     string   `json:"password" binding:"required,min=6,max=30"`
+	Password     string   `json:"password" binding:"required,min=6,max=64"`
 	Email        string   `json:"email" binding:"omitempty,email"`
 	Nickname     string   `json:"nickname" binding:"max=50"`
 	Phone        string   `json:"phone" binding:"max=20"`
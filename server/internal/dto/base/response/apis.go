@@ -10,6 +10,7 @@ type APIResponse struct {
 	AuthType     int     `json:"authType"`
 	Description  string  `json:"description"`
 	Status       int     `json:"status"`
+	Orphaned     bool    `json:"orphaned"`
 	RoleIds      []int64 `json:"roleIds"`
 	MenuIds      []int64 `json:"menuIds"`
 	BtnPermIds   []int64 `json:"btnPermIds"`
@@ -18,3 +19,13 @@ type APIResponse struct {
 	UpdatedBy    int64   `json:"updatedBy,string"`
 	UpdatedAt    string  `json:"updatedAt"`
 }
+
+// APISyncReportResponse API 路由同步报告响应
+type APISyncReportResponse struct {
+	SyncedAt   string        `json:"syncedAt"`   // 最近一次同步时间，本实例未同步时为空
+	Total      int           `json:"total"`      // 已注册的路由数量
+	Added      []APIResponse `json:"added"`      // 新登记的路由
+	Restored   []APIResponse `json:"restored"`   // 重新出现的路由
+	Orphaned   []APIResponse `json:"orphaned"`   // 已失效的路由
+	Unassigned []APIResponse `json:"unassigned"` // 需要授权但尚未分配给任何角色的路由
+}
@@ -3,14 +3,38 @@ package response
 // CaptchaResponse 验证码响应
 type CaptchaResponse struct {
 	CaptchaId     string `json:"captchaId"`
-	PicPath       string `json:"picPath"`
+	CaptchaType   string `json:"captchaType"` // 验证码类型：digit | math | string | audio | slider
+	PicPath       string `json:"picPath"`     // base64 编码的图片或语音，滑块验证码为背景图
 	CaptchaLength int    `json:"captchaLength"`
-	OpenCaptcha   bool   `json:"openCaptcha"`
+	OpenCaptcha   bool   `json:"openCaptcha"` // 当前场景是否需要验证码
+
+	// 滑块验证码专用
+	PieceImage string `json:"pieceImage,omitempty"` // base64 编码的拼图块
+	PieceY     int    `json:"pieceY,omitempty"`     // 拼图块纵坐标
+	Width      int    `json:"width,omitempty"`      // 背景图宽度
+	Height     int    `json:"height,omitempty"`     // 背景图高度
 }
 
 // LoginResponse 登录响应
 type LoginResponse struct {
-	User         UserInfoResponse `json:"user"`
-	AccessToken  string           `json:"accessToken"`
-	RefreshToken string           `json:"refreshToken"`
+	User                   UserInfoResponse `json:"user"`
+	AccessToken            string           `json:"accessToken"`
+	RefreshToken           string           `json:"refreshToken"`
+	PasswordChangeRequired bool             `json:"passwordChangeRequired"`         // 是否需要强制修改密码
+	PasswordChangeReason   string           `json:"passwordChangeReason,omitempty"` // 强制修改原因：reset-管理员重置，expired-密码过期
+}
+
+// LoginStepUpResponse 登录需要二次验证时的响应
+type LoginStepUpResponse struct {
+	StepUpRequired bool     `json:"stepUpRequired"` // 固定为 true
+	ChallengeID    string   `json:"challengeId"`    // 二次验证挑战 ID，提交验证码时使用
+	Channel        string   `json:"channel"`        // 验证码发送渠道：email | sms
+	Target         string   `json:"target"`         // 脱敏后的接收方
+	ExpiresIn      int      `json:"expiresIn"`      // 验证码有效期（秒）
+	Reasons        []string `json:"reasons"`        // 需要二次验证的原因
+}
+
+// ForgotPasswordResponse 找回密码发送验证码响应
+type ForgotPasswordResponse struct {
+	Target string `json:"target"` // 脱敏后的接收方
 }
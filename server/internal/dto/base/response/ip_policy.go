@@ -0,0 +1,29 @@
+package response
+
+import "github.com/ix-pay/ixpay-pro/internal/utils/common/baseRes"
+
+// IPPolicyResponse IP 访问策略响应模型
+type IPPolicyResponse struct {
+	ID          int64    `json:"id,string"`       // 策略 ID
+	Name        string   `json:"name"`            // 名称
+	Scope       string   `json:"scope"`           // 作用范围：global、role、user
+	TargetID    int64    `json:"targetId,string"` // 作用对象 ID
+	Action      string   `json:"action"`          // 动作：allow、deny
+	CIDRs       []string `json:"cidrs"`           // CIDR 规则列表
+	Description string   `json:"description"`     // 描述
+	Status      int      `json:"status"`          // 状态：1-启用 0-禁用
+	CreatedAt   string   `json:"createdAt"`       // 创建时间
+	UpdatedAt   string   `json:"updatedAt"`       // 更新时间
+}
+
+// IPPolicyListResponse IP 访问策略列表响应模型
+type IPPolicyListResponse struct {
+	baseRes.PageResult
+	List []IPPolicyResponse `json:"list"` // 策略列表
+}
+
+// BlockAbnormalIPsResponse 封禁异常登录 IP 响应模型
+type BlockAbnormalIPsResponse struct {
+	Added  int               `json:"added"`  // 本次新增封禁的 IP 数量
+	Policy *IPPolicyResponse `json:"policy"` // 异常登录 IP 黑名单策略，没有需要封禁的 IP 时为空
+}
@@ -0,0 +1,21 @@
+package response
+
+import "github.com/ix-pay/ixpay-pro/internal/utils/common/baseRes"
+
+// LDAPGroupMappingResponse LDAP 组映射响应模型
+type LDAPGroupMappingResponse struct {
+	ID           int64  `json:"id,string"`           // 映射 ID
+	GroupDN      string `json:"groupDn"`             // LDAP 组 DN 或组名
+	RoleID       int64  `json:"roleId,string"`       // 角色 ID
+	DepartmentID int64  `json:"departmentId,string"` // 部门 ID
+	Priority     int    `json:"priority"`            // 优先级
+	Description  string `json:"description"`         // 描述
+	CreatedAt    string `json:"createdAt"`           // 创建时间
+	UpdatedAt    string `json:"updatedAt"`           // 更新时间
+}
+
+// LDAPGroupMappingListResponse LDAP 组映射列表响应模型
+type LDAPGroupMappingListResponse struct {
+	baseRes.PageResult
+	List []LDAPGroupMappingResponse `json:"list"` // 映射列表
+}
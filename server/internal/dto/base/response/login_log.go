@@ -48,6 +48,25 @@ type AbnormalLoginInfoDTO struct {
 	RiskDescription string   `json:"riskDescription"`
 }
 
+// LoginSecurityEventDTO 登录安全事件 DTO
+type LoginSecurityEventDTO struct {
+	ID            string  `json:"id"`
+	UserID        string  `json:"userId"`
+	Username      string  `json:"userName"`
+	Type          string  `json:"type"`      // 事件类型：new_device、new_country、new_city、impossible_travel
+	RiskLevel     string  `json:"riskLevel"` // 风险等级：low、medium、high
+	IP            string  `json:"ip"`
+	Place         string  `json:"place"`
+	Device        string  `json:"device"`
+	PreviousIP    string  `json:"previousIp,omitempty"`
+	PreviousPlace string  `json:"previousPlace,omitempty"`
+	PreviousTime  string  `json:"previousTime,omitempty"`
+	DistanceKm    float64 `json:"distanceKm,omitempty"`
+	Description   string  `json:"description"`
+	StepUpStatus  string  `json:"stepUpStatus"` // 二次验证状态：空-未要求，pending、passed、failed
+	CreatedAt     string  `json:"createdAt"`
+}
+
 // LoginLogListResponse 登录日志列表响应
 type LoginLogListResponse struct {
 	PageResult baseRes.PageResult `json:"pageResult"`
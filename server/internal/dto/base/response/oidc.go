@@ -0,0 +1,129 @@
+package response
+
+import "github.com/ix-pay/ixpay-pro/internal/utils/common/baseRes"
+
+// OIDCRoleMappingResponse 声明到角色的映射规则
+type OIDCRoleMappingResponse struct {
+	Claim  string `json:"claim"`         // 声明路径
+	Value  string `json:"value"`         // 期望的声明值
+	RoleID int64  `json:"roleId,string"` // 授予的角色 ID
+}
+
+// OIDCProviderResponse 身份提供方响应模型（不返回客户端密钥）
+type OIDCProviderResponse struct {
+	ID                    int64                     `json:"id,string"`             // 身份提供方 ID
+	Code                  string                    `json:"code"`                  // 编码
+	Name                  string                    `json:"name"`                  // 显示名称
+	Issuer                string                    `json:"issuer"`                // 签发方
+	ClientID              string                    `json:"clientId"`              // 客户端 ID
+	HasClientSecret       bool                      `json:"hasClientSecret"`       // 是否已配置客户端密钥
+	TokenAuthMethod       string                    `json:"tokenAuthMethod"`       // 客户端认证方式
+	Scopes                []string                  `json:"scopes"`                // 授权范围
+	RedirectURI           string                    `json:"redirectUri"`           // 回调地址
+	AuthorizationEndpoint string                    `json:"authorizationEndpoint"` // 授权端点
+	TokenEndpoint         string                    `json:"tokenEndpoint"`         // 令牌端点
+	UserinfoEndpoint      string                    `json:"userinfoEndpoint"`      // 用户信息端点
+	JWKSURI               string                    `json:"jwksUri"`               // JWKS 地址
+	LinkBy                string                    `json:"linkBy"`                // 账号关联方式
+	TrustEmail            bool                      `json:"trustEmail"`            // 是否信任未声明 email_verified 的邮箱
+	AutoCreate            bool                      `json:"autoCreate"`            // 是否自动开通本地用户
+	UsernameClaim         string                    `json:"usernameClaim"`         // 自动开通时作为用户名的声明
+	RoleMappings          []OIDCRoleMappingResponse `json:"roleMappings"`          // 声明到角色的映射规则
+	Status                int                       `json:"status"`                // 状态
+	Sort                  int                       `json:"sort"`                  // 排序
+	CreatedAt             string                    `json:"createdAt"`             // 创建时间
+	UpdatedAt             string                    `json:"updatedAt"`             // 更新时间
+}
+
+// OIDCProviderListResponse 身份提供方列表响应模型
+type OIDCProviderListResponse struct {
+	baseRes.PageResult
+	List []OIDCProviderResponse `json:"list"` // 身份提供方列表
+}
+
+// OIDCLoginProviderResponse 登录页展示的身份提供方
+type OIDCLoginProviderResponse struct {
+	Code string `json:"code"` // 编码
+	Name string `json:"name"` // 显示名称
+}
+
+// OIDCAuthorizeResponse 发起单点登录响应
+type OIDCAuthorizeResponse struct {
+	AuthorizationURL string `json:"authorizationUrl"` // 身份提供方授权地址，前端直接跳转
+}
+
+// OIDCClientResponse OIDC 客户端响应模型（不返回密钥）
+type OIDCClientResponse struct {
+	ID           int64    `json:"id,string"`    // 客户端记录 ID
+	ClientID     string   `json:"clientId"`     // 客户端标识
+	Name         string   `json:"name"`         // 应用名称
+	Description  string   `json:"description"`  // 描述
+	Public       bool     `json:"public"`       // 是否为公开客户端
+	RedirectURIs []string `json:"redirectUris"` // 允许的回调地址
+	GrantTypes   []string `json:"grantTypes"`   // 授权类型
+	Scopes       []string `json:"scopes"`       // 允许申请的授权范围
+	SkipConsent  bool     `json:"skipConsent"`  // 是否跳过授权确认
+	Status       int      `json:"status"`       // 状态
+	CreatedAt    string   `json:"createdAt"`    // 创建时间
+	UpdatedAt    string   `json:"updatedAt"`    // 更新时间
+}
+
+// OIDCClientListResponse OIDC 客户端列表响应模型
+type OIDCClientListResponse struct {
+	baseRes.PageResult
+	List []OIDCClientResponse `json:"list"` // 客户端列表
+}
+
+// OIDCClientSecretResponse 登记客户端或重置密钥的响应，明文密钥仅返回一次
+type OIDCClientSecretResponse struct {
+	Client       *OIDCClientResponse `json:"client,omitempty"` // 客户端信息（重置密钥时为空）
+	ClientSecret string              `json:"clientSecret"`     // 客户端密钥明文，公开客户端为空
+}
+
+// OIDCConsentInfoResponse 授权确认页信息
+type OIDCConsentInfoResponse struct {
+	ClientID        string   `json:"clientId"`        // 客户端标识
+	ClientName      string   `json:"clientName"`      // 应用名称
+	Description     string   `json:"description"`     // 应用描述
+	Scopes          []string `json:"scopes"`          // 申请的授权范围
+	ConsentRequired bool     `json:"consentRequired"` // 是否需要用户确认，为 false 时前端可直接提交同意
+}
+
+// OIDCConsentResponse 授权确认结果
+type OIDCConsentResponse struct {
+	RedirectURL string `json:"redirectUrl"` // 跳转回客户端的地址，携带 code 或 error
+}
+
+// OIDCTokenResponse 令牌端点响应（按 OAuth 2.0 规范直接返回，不使用统一响应包装）
+type OIDCTokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int64  `json:"expires_in"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	IDToken      string `json:"id_token,omitempty"`
+	Scope        string `json:"scope,omitempty"`
+}
+
+// OAuthErrorResponse 协议错误响应
+type OAuthErrorResponse struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description,omitempty"`
+}
+
+// OIDCDiscoveryResponse OIDC 发现文档
+type OIDCDiscoveryResponse struct {
+	Issuer                            string   `json:"issuer"`
+	AuthorizationEndpoint             string   `json:"authorization_endpoint"`
+	TokenEndpoint                     string   `json:"token_endpoint"`
+	UserinfoEndpoint                  string   `json:"userinfo_endpoint"`
+	JWKSURI                           string   `json:"jwks_uri"`
+	ScopesSupported                   []string `json:"scopes_supported"`
+	ResponseTypesSupported            []string `json:"response_types_supported"`
+	GrantTypesSupported               []string `json:"grant_types_supported"`
+	SubjectTypesSupported             []string `json:"subject_types_supported"`
+	IDTokenSigningAlgValuesSupported  []string `json:"id_token_signing_alg_values_supported"`
+	TokenEndpointAuthMethodsSupported []string `json:"token_endpoint_auth_methods_supported"`
+	CodeChallengeMethodsSupported     []string `json:"code_challenge_methods_supported"`
+	ClaimsSupported                   []string `json:"claims_supported"`
+	AuthorizationResponseIssParameter bool     `json:"authorization_response_iss_parameter_supported"`
+}
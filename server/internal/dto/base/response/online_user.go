@@ -8,14 +8,17 @@ type OnlineUserResponse struct {
 	Username     string `json:"userName"`
 	Nickname     string `json:"nickname"`
 	SessionID    string `json:"sessionId"`
+	LoginType    string `json:"loginType"`
 	LoginIP      string `json:"loginIp"`
 	LoginPlace   string `json:"loginPlace"`
 	LoginTime    string `json:"loginTime"`
 	LastActiveAt string `json:"lastActiveAt"`
+	ExpiresAt    string `json:"expiresAt"`
 	Device       string `json:"device"`
 	Browser      string `json:"browser"`
 	OS           string `json:"os"`
 	UserAgent    string `json:"userAgent"`
+	Current      bool   `json:"current"` // 是否为当前请求使用的会话
 }
 
 // OnlineUserListResponse 在线用户列表响应 DTO
@@ -30,3 +33,17 @@ type OnlineUserStatisticsResponse struct {
 	PeakOnline  int64  `json:"peakOnline"`
 	PeakTime    string `json:"peakTime"`
 }
+
+// SessionAuditLogResponse 会话终止审计记录响应 DTO
+type SessionAuditLogResponse struct {
+	ID         string `json:"id"`
+	UserID     string `json:"userId"`
+	Username   string `json:"userName"`
+	SessionID  string `json:"sessionId"`
+	LoginIP    string `json:"loginIp"`
+	Device     string `json:"device"`
+	Action     string `json:"action"`     // 终止方式：kickout、revoke、kick_oldest
+	Reason     string `json:"reason"`     // 原因
+	OperatorID string `json:"operatorId"` // 操作人 ID，系统自动处理时为 0
+	CreatedAt  string `json:"createdAt"`
+}
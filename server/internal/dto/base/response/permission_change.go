@@ -0,0 +1,27 @@
+package response
+
+import "github.com/ix-pay/ixpay-pro/internal/utils/common/baseRes"
+
+// PermissionChangeResponse 权限变更申请响应模型
+type PermissionChangeResponse struct {
+	ID            int64    `json:"id,string"`          // 申请 ID
+	RoleID        int64    `json:"roleId,string"`      // 角色 ID
+	ChangeType    string   `json:"changeType"`         // 变更类型：role_users、role_menus、role_apis、role_btn_perms、approval_required、policy_bundle
+	BeforeIds     []string `json:"beforeIds"`          // 提交时角色已关联的对象 ID
+	AddedIds      []string `json:"addedIds"`           // 新增关联的对象 ID
+	RemovedIds    []string `json:"removedIds"`         // 移除关联的对象 ID
+	AfterIds      []string `json:"afterIds"`           // 生效后角色关联的对象 ID
+	Payload       string   `json:"payload"`            // 策略包导入申请的策略包内容（JSON）
+	Status        string   `json:"status"`             // 状态：pending、approved、rejected
+	RequestedBy   int64    `json:"requestedBy,string"` // 申请人 ID
+	ReviewedBy    int64    `json:"reviewedBy,string"`  // 审批人 ID
+	ReviewedAt    string   `json:"reviewedAt"`         // 审批时间
+	ReviewComment string   `json:"reviewComment"`      // 审批意见
+	CreatedAt     string   `json:"createdAt"`          // 提交时间
+}
+
+// PermissionChangeListResponse 权限变更申请列表响应模型
+type PermissionChangeListResponse struct {
+	baseRes.PageResult
+	List []PermissionChangeResponse `json:"list"` // 申请列表
+}
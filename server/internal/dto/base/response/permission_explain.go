@@ -0,0 +1,76 @@
+package response
+
+// PermissionExplanationResponse 权限判定过程响应
+type PermissionExplanationResponse struct {
+	UserID         int64                       `json:"userId,string"`
+	Username       string                      `json:"username"`
+	Method         string                      `json:"method"`
+	Path           string                      `json:"path"`
+	Route          string                      `json:"route"`          // 匹配到的路由模板
+	Registered     bool                        `json:"registered"`     // 路由是否已在 API 表登记
+	AuthType       int                         `json:"authType"`       // API 授权类型：0-不需要授权，1-需要授权
+	ButtonCode     string                      `json:"buttonCode"`     // 解释的按钮权限编码
+	Simulated      bool                        `json:"simulated"`      // 是否按拟议变更解释
+	Roles          []RoleTraceResponse         `json:"roles"`          // 参与判定的角色，包含继承角色
+	SpecialGranted bool                        `json:"specialGranted"` // 用户特殊权限是否授予该 API
+	Rules          []RuleTraceResponse         `json:"rules"`          // 路径和方法匹配的 ABAC 规则
+	Allowed        bool                        `json:"allowed"`        // 最终结果
+	Reason         string                      `json:"reason"`         // 判定依据：admin、public、rbac、rule_allow、rule_deny、no_permission
+	RuleID         int64                       `json:"ruleId,string"`  // 决定结果的 ABAC 规则 ID
+	Cached         *PermissionDecisionResponse `json:"cached"`         // 当前缓存的判定结果，与 allowed 不一致说明缓存尚未失效
+}
+
+// RoleTraceResponse 角色授权情况响应
+type RoleTraceResponse struct {
+	RoleID        int64                `json:"roleId,string"`
+	Code          string               `json:"code"`
+	Name          string               `json:"name"`
+	Status        int                  `json:"status"`
+	Source        string               `json:"source"`        // 来源：assigned、current、inherited
+	InheritedFrom string               `json:"inheritedFrom"` // 继承该角色的子角色编码
+	Simulated     bool                 `json:"simulated"`     // 角色配置是否来自拟议变更
+	Effective     bool                 `json:"effective"`     // 角色授权是否生效
+	Granted       bool                 `json:"granted"`       // 角色是否直接授予
+	Groups        []GroupTraceResponse `json:"groups"`        // 角色关联的权限组
+}
+
+// GroupTraceResponse 权限组授权情况响应
+type GroupTraceResponse struct {
+	GroupID int64  `json:"groupId,string"`
+	Name    string `json:"name"`
+	Granted bool   `json:"granted"`
+}
+
+// RuleTraceResponse ABAC 规则求值情况响应
+type RuleTraceResponse struct {
+	RuleID    int64                   `json:"ruleId,string"`
+	Name      string                  `json:"name"`
+	Effect    string                  `json:"effect"`
+	APIPath   string                  `json:"apiPath"`
+	Method    string                  `json:"method"`
+	Sort      int                     `json:"sort"`
+	Matched   bool                    `json:"matched"`   // 条件是否满足
+	Error     string                  `json:"error"`     // 条件无法编译时的错误
+	Condition *ConditionTraceResponse `json:"condition"` // 条件求值过程
+}
+
+// ConditionTraceResponse 条件节点求值情况响应
+type ConditionTraceResponse struct {
+	Path     string                   `json:"path"` // 节点位置，如 $.all[0]
+	Kind     string                   `json:"kind"` // 节点类型：all、any、not、compare、empty
+	Attr     string                   `json:"attr,omitempty"`
+	Op       string                   `json:"op,omitempty"`
+	Value    interface{}              `json:"value,omitempty"`  // 条件中的比较值
+	Ref      string                   `json:"ref,omitempty"`    // 引用的属性名
+	Actual   interface{}              `json:"actual,omitempty"` // 请求中的属性值
+	Present  bool                     `json:"present"`          // 请求中是否存在该属性
+	Result   bool                     `json:"result"`
+	Children []ConditionTraceResponse `json:"children,omitempty"`
+}
+
+// PermissionDecisionResponse 权限判定结果响应
+type PermissionDecisionResponse struct {
+	Allowed bool   `json:"allowed"`
+	Reason  string `json:"reason"`
+	Route   string `json:"route"`
+}
@@ -0,0 +1,25 @@
+package response
+
+import "github.com/ix-pay/ixpay-pro/internal/utils/common/baseRes"
+
+// PermissionRuleResponse 权限规则响应模型
+type PermissionRuleResponse struct {
+	ID          int64  `json:"id,string"`   // 规则 ID
+	Name        string `json:"name"`        // 规则名称
+	Description string `json:"description"` // 规则描述
+	Effect      string `json:"effect"`      // 效果：allow、deny
+	APIPath     string `json:"apiPath"`     // API 路径模式
+	Method      string `json:"method"`      // HTTP 方法
+	Conditions  string `json:"conditions"`  // 条件表达式，JSON 格式
+	Status      int    `json:"status"`      // 状态：1-启用 0-禁用
+	Sort        int    `json:"sort"`        // 排序
+	IsSystem    bool   `json:"isSystem"`    // 是否系统规则
+	CreatedAt   string `json:"createdAt"`   // 创建时间
+	UpdatedAt   string `json:"updatedAt"`   // 更新时间
+}
+
+// PermissionRuleListResponse 权限规则列表响应模型
+type PermissionRuleListResponse struct {
+	baseRes.PageResult
+	List []PermissionRuleResponse `json:"list"` // 规则列表
+}
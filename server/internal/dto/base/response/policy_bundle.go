@@ -0,0 +1,25 @@
+package response
+
+// PolicyBundleExportResponse 权限策略包导出响应模型
+type PolicyBundleExportResponse struct {
+	Format  string `json:"format"`  // 文件格式：yaml、json
+	Version int    `json:"version"` // 策略包格式版本
+	Content string `json:"content"` // 策略包文件内容
+}
+
+// PolicyChangeResponse 策略包差异响应模型
+type PolicyChangeResponse struct {
+	Kind   string   `json:"kind"`   // 对象类型：api、menu、btn_perm、role、permission_group、rule
+	Key    string   `json:"key"`    // 对象引用键
+	Action string   `json:"action"` // 变更动作：create、update
+	Fields []string `json:"fields"` // 有差异的字段
+}
+
+// PolicyImportResultResponse 权限策略包导入结果响应模型
+type PolicyImportResultResponse struct {
+	DryRun  bool                   `json:"dryRun"`  // 是否只比较差异
+	Applied bool                   `json:"applied"` // 是否已写入
+	Changes []PolicyChangeResponse `json:"changes"` // 差异列表
+	// 受差异影响且开启了变更审批的角色编码，不为空时导入会提交为权限变更申请
+	ApprovalRoles []string `json:"approvalRoles"`
+}
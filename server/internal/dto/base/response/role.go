@@ -23,6 +23,11 @@ type RoleResponse struct {
 	Sort        int    `json:"sort"`
 	CreatedAt   string `json:"createdAt"`
 	UpdatedAt   string `json:"updatedAt"`
+	DataScope   int    `json:"dataScope"` // 数据权限范围：1-全部，2-本部门，3-本部门及下级，4-自定义部门，5-仅本人
+	// 自定义数据权限的部门 ID 列表
+	DataScopeDeptIds []string `json:"dataScopeDeptIds"`
+	// 权限变更是否需要审批
+	ApprovalRequired bool `json:"approvalRequired"`
 }
 
 // RoleListResponse 角色列表响应模型
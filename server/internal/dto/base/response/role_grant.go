@@ -0,0 +1,29 @@
+package response
+
+import "github.com/ix-pay/ixpay-pro/internal/utils/common/baseRes"
+
+// RoleGrantResponse 限时角色授予响应模型
+type RoleGrantResponse struct {
+	ID             int64  `json:"id,string"`         // 授予 ID
+	UserID         int64  `json:"userId,string"`     // 被授予用户 ID
+	RoleID         int64  `json:"roleId,string"`     // 角色 ID
+	ValidFrom      string `json:"validFrom"`         // 生效时间，待审批的提权申请为空
+	ValidUntil     string `json:"validUntil"`        // 到期时间，待审批的提权申请为空
+	Justification  string `json:"justification"`     // 授予或申请理由
+	Status         string `json:"status"`            // 状态：pending、scheduled、active、rejected、expired、revoked
+	Elevation      bool   `json:"elevation"`         // 是否由用户申请提权产生
+	RequestedHours int    `json:"requestedHours"`    // 提权申请的时长（小时）
+	ApprovedBy     int64  `json:"approvedBy,string"` // 审批人或授予人 ID
+	ApprovedAt     string `json:"approvedAt"`        // 审批时间
+	ReviewComment  string `json:"reviewComment"`     // 审批意见或收回原因
+	RevokedBy      int64  `json:"revokedBy,string"`  // 收回人 ID，到期自动收回时为 0
+	RevokedAt      string `json:"revokedAt"`         // 收回时间
+	CreatedBy      int64  `json:"createdBy,string"`  // 创建人 ID，提权申请时为申请人
+	CreatedAt      string `json:"createdAt"`         // 创建时间
+}
+
+// RoleGrantListResponse 限时授予列表响应模型
+type RoleGrantListResponse struct {
+	baseRes.PageResult
+	List []RoleGrantResponse `json:"list"` // 授予列表
+}
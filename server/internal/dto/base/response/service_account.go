@@ -0,0 +1,47 @@
+package response
+
+import "github.com/ix-pay/ixpay-pro/internal/utils/common/baseRes"
+
+// ServiceAccountKeyResponse API Key 响应模型（不含明文）
+type ServiceAccountKeyResponse struct {
+	ID         int64  `json:"id,string"`  // Key ID
+	Prefix     string `json:"prefix"`     // Key 前缀
+	ExpiresAt  string `json:"expiresAt"`  // 过期时间
+	RevokedAt  string `json:"revokedAt"`  // 吊销时间
+	LastUsedAt string `json:"lastUsedAt"` // 最近使用时间
+	LastUsedIP string `json:"lastUsedIp"` // 最近使用 IP
+	Valid      bool   `json:"valid"`      // 当前是否有效
+	CreatedAt  string `json:"createdAt"`  // 创建时间
+}
+
+// ServiceAccountResponse 服务账号响应模型
+type ServiceAccountResponse struct {
+	ID          int64                       `json:"id,string"`      // 服务账号 ID
+	Name        string                      `json:"name"`           // 名称
+	Description string                      `json:"description"`    // 描述
+	Status      int                         `json:"status"`         // 状态：1-启用 0-禁用
+	IPAllowlist []string                    `json:"ipAllowlist"`    // IP 白名单
+	RoleIDs     []string                    `json:"roleIds"`        // 绑定的角色 ID 列表
+	Roles       []RoleDTO                   `json:"roles"`          // 绑定的角色列表
+	Keys        []ServiceAccountKeyResponse `json:"keys,omitempty"` // API Key 列表
+	LastUsedAt  string                      `json:"lastUsedAt"`     // 最近使用时间
+	LastUsedIP  string                      `json:"lastUsedIp"`     // 最近使用 IP
+	CreatedAt   string                      `json:"createdAt"`      // 创建时间
+	UpdatedAt   string                      `json:"updatedAt"`      // 更新时间
+}
+
+// ServiceAccountListResponse 服务账号列表响应模型
+type ServiceAccountListResponse struct {
+	baseRes.PageResult
+	List []ServiceAccountResponse `json:"list"` // 服务账号列表
+}
+
+// ServiceAccountKeyIssuedResponse 创建或轮换后返回的 API Key
+// APIKey 明文只返回这一次，请调用方妥善保存
+type ServiceAccountKeyIssuedResponse struct {
+	ServiceAccountID int64  `json:"serviceAccountId,string"` // 服务账号 ID
+	KeyID            int64  `json:"keyId,string"`            // Key ID
+	Prefix           string `json:"prefix"`                  // Key 前缀
+	APIKey           string `json:"apiKey"`                  // API Key 明文
+	ExpiresAt        string `json:"expiresAt"`               // 过期时间
+}
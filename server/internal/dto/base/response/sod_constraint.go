@@ -0,0 +1,32 @@
+package response
+
+import "github.com/ix-pay/ixpay-pro/internal/utils/common/baseRes"
+
+// SoDConstraintResponse 职责分离约束响应模型
+type SoDConstraintResponse struct {
+	ID          int64    `json:"id,string"`   // 约束 ID
+	Name        string   `json:"name"`        // 约束名称
+	Description string   `json:"description"` // 描述
+	Type        string   `json:"type"`        // 约束类型：static、dynamic
+	RoleIds     []string `json:"roleIds"`     // 约束涉及的角色 ID 列表
+	MaxRoles    int      `json:"maxRoles"`    // 最多可持有或激活的角色数量
+	Status      int      `json:"status"`      // 状态：1-启用 0-禁用
+	CreatedAt   string   `json:"createdAt"`   // 创建时间
+	UpdatedAt   string   `json:"updatedAt"`   // 更新时间
+}
+
+// SoDConstraintListResponse 职责分离约束列表响应模型
+type SoDConstraintListResponse struct {
+	baseRes.PageResult
+	List []SoDConstraintResponse `json:"list"` // 约束列表
+}
+
+// SoDViolationResponse 职责分离违规响应模型
+type SoDViolationResponse struct {
+	ConstraintID   int64    `json:"constraintId,string"` // 违反的约束 ID
+	ConstraintName string   `json:"constraintName"`      // 违反的约束名称
+	MaxRoles       int      `json:"maxRoles"`            // 约束允许的最多角色数量
+	UserID         int64    `json:"userId,string"`       // 用户 ID
+	Username       string   `json:"username"`            // 用户名
+	RoleIds        []string `json:"roleIds"`             // 用户持有的约束内角色 ID
+}
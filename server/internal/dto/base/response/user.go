@@ -65,3 +65,8 @@ type UserInfoResponse struct {
 	Role          string        `json:"role"`
 	Authority     AuthorityInfo `json:"authority"`
 }
+
+// ResetPasswordResponse 重置密码响应
+type ResetPasswordResponse struct {
+	Password string `json:"password"` // 重置后的密码（未指定时为系统生成的临时密码）
+}
@@ -0,0 +1,28 @@
+package request
+
+// CreateOpenAppRequest 创建开放接口应用请求参数
+type CreateOpenAppRequest struct {
+	Name        string `json:"name" binding:"required,max=100"`         // 应用名称
+	SignType    string `json:"signType" binding:"required"`             // 签名方式：HMAC-SHA256、RSA-SHA256
+	PublicKey   string `json:"publicKey"`                               // 合作方 RSA 公钥 PEM，RSA-SHA256 时必填
+	UserID      string `json:"userId" binding:"required"`               // 绑定的平台用户 ID
+	Description string `json:"description" binding:"omitempty,max=255"` // 描述
+	Status      *int   `json:"status"`                                  // 状态：1-启用，0-禁用，默认为 1
+}
+
+// UpdateOpenAppRequest 更新开放接口应用请求参数
+type UpdateOpenAppRequest struct {
+	Name        string `json:"name" binding:"required,max=100"`         // 应用名称
+	SignType    string `json:"signType" binding:"required"`             // 签名方式：HMAC-SHA256、RSA-SHA256
+	PublicKey   string `json:"publicKey"`                               // 合作方 RSA 公钥 PEM，RSA-SHA256 时必填
+	UserID      string `json:"userId" binding:"required"`               // 绑定的平台用户 ID
+	Description string `json:"description" binding:"omitempty,max=255"` // 描述
+	Status      int    `json:"status"`                                  // 状态：1-启用，0-禁用
+}
+
+// GetOpenAppListRequest 获取开放接口应用列表请求参数
+type GetOpenAppListRequest struct {
+	Page     int  `json:"page" form:"page" binding:"required,min=1"`
+	PageSize int  `json:"pageSize" form:"pageSize" binding:"required,min=1,max=100"`
+	Status   *int `json:"status" form:"status"` // 状态（可选筛选条件）
+}
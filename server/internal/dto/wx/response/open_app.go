@@ -0,0 +1,35 @@
+package response
+
+// OpenAppResponse 开放接口应用响应 DTO，不包含应用密钥
+type OpenAppResponse struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	AppKey      string `json:"appKey"`
+	SignType    string `json:"signType"`
+	PublicKey   string `json:"publicKey"`
+	UserID      string `json:"userId"`
+	Description string `json:"description"`
+	Status      int    `json:"status"`
+	CreatedAt   string `json:"createdAt"`
+	UpdatedAt   string `json:"updatedAt"`
+}
+
+// OpenAppSecretResponse 开放接口应用密钥响应 DTO，密钥只在创建和重置时返回一次
+type OpenAppSecretResponse struct {
+	OpenAppResponse
+	AppSecret string `json:"appSecret"`
+}
+
+// OpenAppListResponse 开放接口应用列表响应 DTO
+type OpenAppListResponse struct {
+	List     []OpenAppResponse `json:"list"`
+	Total    int64             `json:"total"`
+	Page     int64             `json:"page"`
+	PageSize int64             `json:"pageSize"`
+}
+
+// OpenAPIPublicKeyResponse 平台响应签名公钥 DTO
+type OpenAPIPublicKeyResponse struct {
+	SignType  string `json:"signType"`
+	PublicKey string `json:"publicKey"`
+}
@@ -4,6 +4,7 @@ import (
 	"os"
 
 	"github.com/ix-pay/ixpay-pro/internal/config"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/observability/redact"
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
@@ -42,7 +43,8 @@ const (
 // zapLogger 实现 Logger 接口
 type zapLogger struct {
 	logger      *zap.Logger
-	errorLogger *zap.Logger // 错误日志专用记录器
+	errorLogger *zap.Logger      // 错误日志专用记录器
+	redactor    *redact.Redactor // 按字段名脱敏键值对形式的日志字段
 }
 
 // MultiLogger 多类型日志管理器
@@ -88,9 +90,9 @@ func (ml *MultiLogger) GetLogger(loggerType LoggerType) Logger {
 }
 
 // SetupLogger 创建新的日志记录器（默认类型，带错误日志注入）
-func SetupLogger(cfg *config.Config) Logger {
-	defaultLogger := setupLoggerWithType(cfg, DefaultLogger)
-	errorLogger := setupLoggerWithType(cfg, ErrorLogger)
+func SetupLogger(cfg *config.Config, redactor *redact.Redactor) Logger {
+	defaultLogger := setupLoggerWithType(cfg, DefaultLogger, redactor)
+	errorLogger := setupLoggerWithType(cfg, ErrorLogger, redactor)
 
 	// 将 errorLogger 注入到 defaultLogger 中，使得调用 Error 时同时写入两个文件
 	if defaultZapLogger, ok := defaultLogger.(*zapLogger); ok {
@@ -103,18 +105,18 @@ func SetupLogger(cfg *config.Config) Logger {
 }
 
 // SetupMultiLogger 创建多类型日志记录器
-func SetupMultiLogger(cfg *config.Config) *MultiLogger {
+func SetupMultiLogger(cfg *config.Config, redactor *redact.Redactor) *MultiLogger {
 	return &MultiLogger{
-		defaultLogger: setupLoggerWithType(cfg, DefaultLogger),
-		errorLogger:   setupLoggerWithType(cfg, ErrorLogger),
-		taskLogger:    setupLoggerWithType(cfg, TaskLogger),
-		requestLogger: setupLoggerWithType(cfg, RequestLogger),
-		auditLogger:   setupLoggerWithType(cfg, AuditLogger),
+		defaultLogger: setupLoggerWithType(cfg, DefaultLogger, redactor),
+		errorLogger:   setupLoggerWithType(cfg, ErrorLogger, redactor),
+		taskLogger:    setupLoggerWithType(cfg, TaskLogger, redactor),
+		requestLogger: setupLoggerWithType(cfg, RequestLogger, redactor),
+		auditLogger:   setupLoggerWithType(cfg, AuditLogger, redactor),
 	}
 }
 
 // setupLoggerWithType 根据日志类型创建日志记录器
-func setupLoggerWithType(cfg *config.Config, loggerType LoggerType) Logger {
+func setupLoggerWithType(cfg *config.Config, loggerType LoggerType, redactor *redact.Redactor) Logger {
 	// 设置日志级别
 	var level zapcore.Level
 	switch cfg.Logging.Level {
@@ -195,47 +197,49 @@ func setupLoggerWithType(cfg *config.Config, loggerType LoggerType) Logger {
 	logger := zap.New(core, zap.AddCaller(), zap.AddStacktrace(zap.ErrorLevel))
 
 	return &zapLogger{
-		logger: logger,
+		logger:   logger,
+		redactor: redactor,
 	}
 }
 
 // Debug 记录调试信息
 func (l *zapLogger) Debug(msg string, fields ...interface{}) {
-	l.logger.Debug(msg, parseFields(fields)...)
+	l.logger.Debug(msg, l.parseFields(fields)...)
 }
 
 // Info 记录信息
 func (l *zapLogger) Info(msg string, fields ...interface{}) {
-	l.logger.Info(msg, parseFields(fields)...)
+	l.logger.Info(msg, l.parseFields(fields)...)
 }
 
 // Warn 记录警告信息
 func (l *zapLogger) Warn(msg string, fields ...interface{}) {
-	l.logger.Warn(msg, parseFields(fields)...)
+	l.logger.Warn(msg, l.parseFields(fields)...)
 }
 
 // Error 记录错误信息
 func (l *zapLogger) Error(msg string, fields ...interface{}) {
 	if l.errorLogger != nil {
-		l.errorLogger.Error(msg, parseFields(fields)...)
+		l.errorLogger.Error(msg, l.parseFields(fields)...)
 	} else {
-		l.logger.Error(msg, parseFields(fields)...)
+		l.logger.Error(msg, l.parseFields(fields)...)
 	}
 }
 
 // Fatal 记录致命错误信息并退出
 func (l *zapLogger) Fatal(msg string, fields ...interface{}) {
 	if l.errorLogger != nil {
-		l.errorLogger.Fatal(msg, parseFields(fields)...)
+		l.errorLogger.Fatal(msg, l.parseFields(fields)...)
 	} else {
-		l.logger.Fatal(msg, parseFields(fields)...)
+		l.logger.Fatal(msg, l.parseFields(fields)...)
 	}
 }
 
 // With 返回一个带有上下文的新日志记录器
 func (l *zapLogger) With(fields ...interface{}) Logger {
 	return &zapLogger{
-		logger: l.logger.With(parseFields(fields)...),
+		logger:   l.logger.With(l.parseFields(fields)...),
+		redactor: l.redactor,
 	}
 }
 
@@ -244,8 +248,8 @@ func (l *zapLogger) Sync() error {
 	return l.logger.Sync()
 }
 
-// parseFields 解析日志字段
-func parseFields(fields []interface{}) []zap.Field {
+// parseFields 解析日志字段，键值对形式的字段按字段名脱敏
+func (l *zapLogger) parseFields(fields []interface{}) []zap.Field {
 	var zapFields []zap.Field
 
 	for _, field := range fields {
@@ -267,7 +271,7 @@ func parseFields(fields []interface{}) []zap.Field {
 			continue
 		}
 
-		value := fields[i+1]
+		value := l.redactor.RedactField(key, fields[i+1])
 		zapFields = append(zapFields, zap.Any(key, value))
 	}
 
@@ -0,0 +1,514 @@
+package redact
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/ix-pay/ixpay-pro/internal/config"
+)
+
+// Action 脱敏方式
+type Action string
+
+const (
+	// ActionMask 全部遮盖
+	ActionMask Action = "mask"
+	// ActionPartial 保留首尾，遮盖中间部分
+	ActionPartial Action = "partial"
+	// ActionHash 替换为加盐哈希，相同的值哈希结果相同，便于排查问题时关联
+	ActionHash Action = "hash"
+)
+
+const (
+	// maskValue 全部遮盖后的值
+	maskValue = "******"
+	// OmittedBody 跳过记录的请求体和响应体
+	OmittedBody = "[已省略]"
+	// truncatedSuffix 超出长度截断后的后缀
+	truncatedSuffix = "...[已截断]"
+	// defaultMaxBodySize 未配置时请求体和响应体记录的最大字节数
+	defaultMaxBodySize = 4096
+	// maxCaptureSize 响应体捕获的最大字节数，超出部分不再缓存，避免大文件下载占用内存
+	maxCaptureSize = 1 << 20
+)
+
+// DefaultRules 内置脱敏规则，按字段名匹配
+var DefaultRules = []Rule{
+	{Key: "password", Action: ActionMask},
+	{Key: "passwd", Action: ActionMask},
+	{Key: "token", Action: ActionMask},
+	{Key: "secret", Action: ActionMask},
+	{Key: "authorization", Action: ActionMask},
+	{Key: "apikey", Action: ActionMask},
+	{Key: "privatekey", Action: ActionMask},
+	{Key: "credential", Action: ActionMask},
+	{Key: "phone", Action: ActionPartial},
+	{Key: "mobile", Action: ActionPartial},
+	{Key: "idcard", Action: ActionPartial},
+	{Key: "bankcard", Action: ActionPartial},
+	{Key: "email", Action: ActionPartial},
+}
+
+// Rule 脱敏规则，Key 和 Path 二选一
+type Rule struct {
+	Key    string // 字段名，忽略大小写、下划线和中划线，包含即命中
+	Path   string // JSON 路径，如 $.data.list[*].phone
+	Action Action
+}
+
+// pathSegment JSON 路径片段
+type pathSegment struct {
+	key   string // 对象字段名，为空表示数组下标
+	index int    // 数组下标，-1 表示任意下标
+}
+
+// pathRule 编译后的 JSON 路径规则
+type pathRule struct {
+	segments []pathSegment
+	action   Action
+}
+
+// keyRule 编译后的字段名规则
+type keyRule struct {
+	key    string
+	action Action
+}
+
+// skipPath 不记录请求体和响应体的接口
+type skipPath struct {
+	method   string
+	segments []string
+}
+
+// Redactor 日志脱敏引擎
+// 操作日志、请求日志和应用日志共用同一套规则，字段名规则作用于所有层级的字段，JSON 路径规则只作用于请求体和响应体
+type Redactor struct {
+	keyRules    []keyRule
+	pathRules   []pathRule
+	salt        []byte
+	maxBodySize int
+	skipPaths   []skipPath
+	textPattern *regexp.Regexp // 无法解析的请求体按字段名规则做文本替换
+}
+
+// SetupRedactor 根据日志配置创建脱敏引擎
+func SetupRedactor(cfg *config.Config) (*Redactor, error) {
+	redaction := cfg.Logging.Redaction
+	rules := make([]Rule, 0, len(redaction.Rules))
+	for _, r := range redaction.Rules {
+		rules = append(rules, Rule{Key: r.Key, Path: r.Path, Action: Action(r.Action)})
+	}
+	return NewRedactor(rules, redaction.HashSalt, redaction.MaxBodySize, redaction.SkipBodyPaths)
+}
+
+// NewRedactor 创建脱敏引擎，自定义规则追加到内置规则之后
+func NewRedactor(rules []Rule, hashSalt string, maxBodySize int, skipBodyPaths []string) (*Redactor, error) {
+	if maxBodySize <= 0 {
+		maxBodySize = defaultMaxBodySize
+	}
+	r := &Redactor{
+		salt:        []byte(hashSalt),
+		maxBodySize: maxBodySize,
+	}
+
+	for _, rule := range append(append([]Rule{}, DefaultRules...), rules...) {
+		if rule.Action == "" {
+			rule.Action = ActionMask
+		}
+		switch rule.Action {
+		case ActionMask, ActionPartial, ActionHash:
+		default:
+			return nil, fmt.Errorf("不支持的脱敏方式：%s", rule.Action)
+		}
+
+		switch {
+		case rule.Path != "":
+			segments, err := parsePath(rule.Path)
+			if err != nil {
+				return nil, err
+			}
+			r.pathRules = append(r.pathRules, pathRule{segments: segments, action: rule.Action})
+		case rule.Key != "":
+			key := normalizeKey(rule.Key)
+			if key == "" {
+				return nil, fmt.Errorf("脱敏规则字段名无效：%s", rule.Key)
+			}
+			r.keyRules = append(r.keyRules, keyRule{key: key, action: rule.Action})
+		default:
+			return nil, fmt.Errorf("脱敏规则必须配置 key 或 path")
+		}
+	}
+
+	// 文本替换只用于无法解析的请求体，匹配 "key": "value" 和 key=value 两种形式，字段名中包含规则关键字即命中
+	names := make([]string, 0, len(r.keyRules))
+	for _, rule := range r.keyRules {
+		chars := make([]string, 0, len(rule.key))
+		for _, c := range rule.key {
+			chars = append(chars, regexp.QuoteMeta(string(c)))
+		}
+		names = append(names, strings.Join(chars, `[_-]?`))
+	}
+	keyPattern := `[\w-]*(?:` + strings.Join(names, "|") + `)[\w-]*`
+	r.textPattern = regexp.MustCompile(`(?i)("` + keyPattern + `"\s*:\s*)"(?:[^"\\]|\\.)*"|(` + keyPattern + `=)[^&\s"]*`)
+
+	for _, p := range skipBodyPaths {
+		r.skipPaths = append(r.skipPaths, parseSkipPath(p))
+	}
+	return r, nil
+}
+
+// parsePath 解析 JSON 路径，支持 $.a.b、$.a[0].b、$.a[*].b 和 $.a.*
+func parsePath(path string) ([]pathSegment, error) {
+	if !strings.HasPrefix(path, "$") {
+		return nil, fmt.Errorf("JSON 路径必须以 $ 开头：%s", path)
+	}
+	rest := path[1:]
+	var segments []pathSegment
+	for rest != "" {
+		switch rest[0] {
+		case '.':
+			rest = rest[1:]
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+			name := rest[:end]
+			if name == "" {
+				return nil, fmt.Errorf("JSON 路径格式错误：%s", path)
+			}
+			segments = append(segments, pathSegment{key: name, index: -1})
+			rest = rest[end:]
+		case '[':
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("JSON 路径格式错误：%s", path)
+			}
+			index := -1
+			if inner := rest[1:end]; inner != "*" {
+				n, err := strconv.Atoi(inner)
+				if err != nil || n < 0 {
+					return nil, fmt.Errorf("JSON 路径数组下标无效：%s", path)
+				}
+				index = n
+			}
+			segments = append(segments, pathSegment{index: index})
+			rest = rest[end+1:]
+		default:
+			return nil, fmt.Errorf("JSON 路径格式错误：%s", path)
+		}
+	}
+	if len(segments) == 0 {
+		return nil, fmt.Errorf("JSON 路径不能只包含 $：%s", path)
+	}
+	return segments, nil
+}
+
+// parseSkipPath 解析 "[方法] 路径" 格式的接口
+func parseSkipPath(pattern string) skipPath {
+	pattern = strings.TrimSpace(pattern)
+	var method string
+	if i := strings.IndexByte(pattern, ' '); i > 0 {
+		method = strings.ToUpper(pattern[:i])
+		pattern = strings.TrimSpace(pattern[i+1:])
+	}
+	return skipPath{method: method, segments: strings.Split(strings.Trim(pattern, "/"), "/")}
+}
+
+// normalizeKey 字段名统一转为小写并去掉下划线和中划线，使 id_card、idCard、ID-Card 命中同一规则
+func normalizeKey(key string) string {
+	key = strings.ToLower(key)
+	return strings.NewReplacer("_", "", "-", "").Replace(key)
+}
+
+// CaptureLimit 响应体捕获的最大字节数
+func (r *Redactor) CaptureLimit() int {
+	if r == nil {
+		return maxCaptureSize
+	}
+	if r.maxBodySize > maxCaptureSize {
+		return r.maxBodySize
+	}
+	return maxCaptureSize
+}
+
+// SkipBody 判断接口是否配置为不记录请求体和响应体，路径中的路由参数和 * 匹配任意一段，末尾的 * 匹配剩余所有段
+func (r *Redactor) SkipBody(method, path string) bool {
+	if r == nil {
+		return false
+	}
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for _, p := range r.skipPaths {
+		if p.method != "" && p.method != method {
+			continue
+		}
+		if matchSegments(p.segments, segments) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchSegments 匹配路径片段
+func matchSegments(pattern, segments []string) bool {
+	for i, p := range pattern {
+		if p == "*" && i == len(pattern)-1 {
+			return len(segments) >= i+1
+		}
+		if i >= len(segments) {
+			return false
+		}
+		if p == "*" || strings.HasPrefix(p, ":") {
+			continue
+		}
+		if p != segments[i] {
+			return false
+		}
+	}
+	return len(pattern) == len(segments)
+}
+
+// matchKey 返回字段名命中的第一条规则
+func (r *Redactor) matchKey(key string) (Action, bool) {
+	normalized := normalizeKey(key)
+	if normalized == "" {
+		return "", false
+	}
+	for _, rule := range r.keyRules {
+		if strings.Contains(normalized, rule.key) {
+			return rule.action, true
+		}
+	}
+	return "", false
+}
+
+// RedactField 按字段名脱敏单个日志字段，未命中规则时原样返回
+func (r *Redactor) RedactField(key string, value interface{}) interface{} {
+	if r == nil || value == nil {
+		return value
+	}
+	action, ok := r.matchKey(key)
+	if !ok {
+		return value
+	}
+	return r.apply(action, value)
+}
+
+// RedactBody 脱敏请求体或响应体并截断到最大长度
+// JSON 按字段名和路径规则逐层处理，表单按字段名规则处理，其他内容按字段名规则做文本替换
+func (r *Redactor) RedactBody(body []byte) string {
+	if r == nil {
+		return string(body)
+	}
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) == 0 {
+		return ""
+	}
+
+	var result string
+	if trimmed[0] == '{' || trimmed[0] == '[' {
+		var doc interface{}
+		decoder := json.NewDecoder(bytes.NewReader(trimmed))
+		decoder.UseNumber()
+		if err := decoder.Decode(&doc); err == nil {
+			doc = r.redactNode(doc)
+			for _, rule := range r.pathRules {
+				doc = r.applyPath(doc, rule.segments, rule.action)
+			}
+			if data, err := json.Marshal(doc); err == nil {
+				result = string(data)
+			}
+		}
+	} else if values, err := url.ParseQuery(string(trimmed)); err == nil && strings.Contains(string(trimmed), "=") {
+		result = r.redactValues(values)
+	}
+	if result == "" {
+		result = r.redactText(string(trimmed))
+	}
+	return r.truncate(result)
+}
+
+// RedactQuery 脱敏 URL 查询参数
+func (r *Redactor) RedactQuery(rawQuery string) string {
+	if r == nil || rawQuery == "" {
+		return rawQuery
+	}
+	values, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return r.truncate(r.redactText(rawQuery))
+	}
+	return r.truncate(r.redactValues(values))
+}
+
+// RedactURI 脱敏请求 URI 中的查询参数
+func (r *Redactor) RedactURI(uri string) string {
+	if r == nil {
+		return uri
+	}
+	path, query, found := strings.Cut(uri, "?")
+	if !found {
+		return uri
+	}
+	return path + "?" + r.RedactQuery(query)
+}
+
+// redactValues 按字段名规则脱敏表单或查询参数，输出保持参数顺序稳定
+func (r *Redactor) redactValues(values url.Values) string {
+	for key, vals := range values {
+		action, ok := r.matchKey(key)
+		if !ok {
+			continue
+		}
+		for i, v := range vals {
+			vals[i] = r.apply(action, v).(string)
+		}
+	}
+	encoded := values.Encode()
+	if unescaped, err := url.QueryUnescape(encoded); err == nil {
+		return unescaped
+	}
+	return encoded
+}
+
+// redactText 对无法解析的内容按字段名规则做文本替换
+func (r *Redactor) redactText(text string) string {
+	return r.textPattern.ReplaceAllStringFunc(text, func(match string) string {
+		sub := r.textPattern.FindStringSubmatch(match)
+		if sub[1] != "" {
+			return sub[1] + `"` + maskValue + `"`
+		}
+		return sub[2] + maskValue
+	})
+}
+
+// redactNode 递归脱敏 JSON 节点中命中字段名规则的值
+func (r *Redactor) redactNode(node interface{}) interface{} {
+	switch v := node.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			if action, ok := r.matchKey(key); ok {
+				v[key] = r.applyJSON(action, child)
+				continue
+			}
+			v[key] = r.redactNode(child)
+		}
+		return v
+	case []interface{}:
+		for i, child := range v {
+			v[i] = r.redactNode(child)
+		}
+		return v
+	default:
+		return node
+	}
+}
+
+// applyPath 按 JSON 路径脱敏，路径不存在时不做处理
+func (r *Redactor) applyPath(node interface{}, segments []pathSegment, action Action) interface{} {
+	if len(segments) == 0 {
+		return r.applyJSON(action, node)
+	}
+	seg := segments[0]
+	switch v := node.(type) {
+	case map[string]interface{}:
+		if seg.key == "" {
+			return node
+		}
+		if seg.key == "*" {
+			for key, child := range v {
+				v[key] = r.applyPath(child, segments[1:], action)
+			}
+			return v
+		}
+		if child, ok := v[seg.key]; ok {
+			v[seg.key] = r.applyPath(child, segments[1:], action)
+		}
+		return v
+	case []interface{}:
+		if seg.key != "" {
+			return node
+		}
+		for i, child := range v {
+			if seg.index < 0 || seg.index == i {
+				v[i] = r.applyPath(child, segments[1:], action)
+			}
+		}
+		return v
+	default:
+		return node
+	}
+}
+
+// applyJSON 脱敏 JSON 值，对象和数组整体遮盖，空值保持不变
+func (r *Redactor) applyJSON(action Action, value interface{}) interface{} {
+	switch value.(type) {
+	case nil:
+		return nil
+	case map[string]interface{}, []interface{}:
+		return maskValue
+	default:
+		return r.apply(action, value)
+	}
+}
+
+// apply 按脱敏方式处理单个值，结果统一为字符串
+func (r *Redactor) apply(action Action, value interface{}) interface{} {
+	s, ok := value.(string)
+	if !ok {
+		s = fmt.Sprint(value)
+	}
+	if s == "" {
+		return s
+	}
+	switch action {
+	case ActionPartial:
+		return partial(s)
+	case ActionHash:
+		mac := hmac.New(sha256.New, r.salt)
+		mac.Write([]byte(s))
+		return "sha256:" + hex.EncodeToString(mac.Sum(nil))[:16]
+	default:
+		return maskValue
+	}
+}
+
+// partial 保留首尾各约四分之一（最多 4 个字符），其余以 * 遮盖，邮箱只处理 @ 之前的部分
+func partial(s string) string {
+	if local, domain, found := strings.Cut(s, "@"); found && local != "" {
+		return partial(local) + "@" + domain
+	}
+	runes := []rune(s)
+	n := len(runes)
+	keep := n / 4
+	if keep > 4 {
+		keep = 4
+	}
+	if keep == 0 {
+		return strings.Repeat("*", n)
+	}
+	return string(runes[:keep]) + strings.Repeat("*", n-2*keep) + string(runes[n-keep:])
+}
+
+// truncate 截断到最大长度，避免截断在多字节字符中间
+func (r *Redactor) truncate(s string) string {
+	if len(s) <= r.maxBodySize {
+		return s
+	}
+	cut := r.maxBodySize
+	for cut > 0 && !isRuneStart(s[cut]) {
+		cut--
+	}
+	return s[:cut] + truncatedSuffix
+}
+
+// isRuneStart 判断字节是否为 UTF-8 字符的起始字节
+func isRuneStart(b byte) bool {
+	return b&0xC0 != 0x80
+}
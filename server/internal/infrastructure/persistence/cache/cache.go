@@ -17,6 +17,12 @@ type Cache interface {
 	Delete(key string) error
 	// Exists 检查缓存键是否存在
 	Exists(key string) (bool, error)
+	// Incr 计数器自增，键首次创建时设置过期时间，返回自增后的值
+	Incr(key string, expiration time.Duration) (int64, error)
+	// SetNX 仅在键不存在时设置缓存值，返回是否设置成功
+	SetNX(key string, value interface{}, expiration time.Duration) (bool, error)
+	// CompareAndDelete 仅在缓存值等于 expected 时删除键，返回是否删除，用于原子地消费一次性凭据
+	CompareAndDelete(key string, expected string) (bool, error)
 	// Close 关闭缓存连接
 	Close() error
 }
@@ -0,0 +1,98 @@
+package cache
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/observability/logger"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/persistence/redis"
+)
+
+// invalidationChannel Redis 发布订阅频道名（会加上 Redis 键前缀）
+const invalidationChannel = "cache:invalidation"
+
+// InvalidationMessage 缓存失效消息
+type InvalidationMessage struct {
+	Topic   string `json:"topic"`   // 失效主题，如 permission
+	Version int64  `json:"version"` // 失效后的缓存版本号
+	Reason  string `json:"reason"`  // 失效原因，便于排查
+}
+
+// InvalidationBus 缓存失效广播
+// 进程内缓存无法被其他实例清除，多实例部署时通过 Redis 发布订阅把失效消息广播到所有实例
+type InvalidationBus struct {
+	mu       sync.RWMutex
+	handlers map[string][]func(InvalidationMessage)
+	redis    *redis.RedisClient
+	log      logger.Logger
+}
+
+// NewInvalidationBus 创建仅在当前进程内分发消息的失效广播
+func NewInvalidationBus(log logger.Logger) *InvalidationBus {
+	return &InvalidationBus{
+		handlers: make(map[string][]func(InvalidationMessage)),
+		log:      log,
+	}
+}
+
+// SetupInvalidationBus 创建失效广播并订阅 Redis 频道，接收其他实例发布的失效消息
+func SetupInvalidationBus(redisClient *redis.RedisClient, log logger.Logger) *InvalidationBus {
+	bus := NewInvalidationBus(log)
+	if redisClient == nil {
+		return bus
+	}
+
+	bus.redis = redisClient
+	pubsub := redisClient.Client.Subscribe(redisClient.GetContext(), bus.channel())
+	go func() {
+		for msg := range pubsub.Channel() {
+			var message InvalidationMessage
+			if err := json.Unmarshal([]byte(msg.Payload), &message); err != nil {
+				log.Warn("解析缓存失效消息失败", "error", err)
+				continue
+			}
+			bus.dispatch(message)
+		}
+	}()
+
+	log.Info("缓存失效广播已启用", "channel", bus.channel())
+	return bus
+}
+
+// Subscribe 订阅主题的失效消息，handler 在接收消息的协程中同步执行，应尽快返回
+func (b *InvalidationBus) Subscribe(topic string, handler func(InvalidationMessage)) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers[topic] = append(b.handlers[topic], handler)
+}
+
+// Publish 发布失效消息
+// 启用 Redis 时广播给所有实例（包括当前实例），否则直接在当前进程内分发
+func (b *InvalidationBus) Publish(message InvalidationMessage) error {
+	if b.redis == nil {
+		b.dispatch(message)
+		return nil
+	}
+
+	payload, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+	return b.redis.Client.Publish(b.redis.GetContext(), b.channel(), payload).Err()
+}
+
+// dispatch 将消息分发给当前实例的订阅者
+func (b *InvalidationBus) dispatch(message InvalidationMessage) {
+	b.mu.RLock()
+	handlers := b.handlers[message.Topic]
+	b.mu.RUnlock()
+
+	for _, handler := range handlers {
+		handler(message)
+	}
+}
+
+// channel Redis 频道名
+func (b *InvalidationBus) channel() string {
+	return b.redis.PreKey + invalidationChannel
+}
@@ -6,8 +6,17 @@ import (
 	"time"
 
 	"github.com/ix-pay/ixpay-pro/internal/infrastructure/persistence/redis"
+	goredis "github.com/redis/go-redis/v9"
 )
 
+// compareAndDeleteScript 比较并删除脚本，读取和删除在 Redis 中原子执行
+var compareAndDeleteScript = goredis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
 // RedisCache Redis缓存实现
 type RedisCache struct {
 	redisClient *redis.RedisClient
@@ -60,41 +69,43 @@ func (rc *RedisCache) Exists(key string) (bool, error) {
 	return rc.redisClient.Exists(rc.prefix + key)
 }
 
-// Close 关闭缓存连接
-func (rc *RedisCache) Close() error {
-	return rc.redisClient.Close()
-}
-
-// CaptchaStoreAdapter 适配 RedisCache 以兼容 base64Captcha.Store 接口
-type CaptchaStoreAdapter struct {
-	cache *RedisCache
-}
-
-// NewCaptchaStoreAdapter 创建验证码存储适配器
-func NewCaptchaStoreAdapter(cache *RedisCache) *CaptchaStoreAdapter {
-	return &CaptchaStoreAdapter{cache: cache}
+// Incr 计数器自增，键首次创建时设置过期时间
+func (rc *RedisCache) Incr(key string, expiration time.Duration) (int64, error) {
+	if expiration == 0 {
+		expiration = rc.expiration
+	}
+	fullKey := rc.prefix + key
+	count, err := rc.redisClient.Client.Incr(rc.ctx, fullKey).Result()
+	if err != nil {
+		return 0, err
+	}
+	if count == 1 {
+		if err := rc.redisClient.Client.Expire(rc.ctx, fullKey, expiration).Err(); err != nil {
+			return count, err
+		}
+	}
+	return count, nil
 }
 
-// Set 实现 base64Captcha.Store 接口的 Set 方法
-func (a *CaptchaStoreAdapter) Set(key string, value string) error {
-	return a.cache.redisClient.Client.Set(a.cache.ctx, a.cache.prefix+key, value, a.cache.expiration).Err()
+// SetNX 仅在键不存在时设置缓存值，返回是否设置成功
+func (rc *RedisCache) SetNX(key string, value interface{}, expiration time.Duration) (bool, error) {
+	if expiration == 0 {
+		expiration = rc.expiration
+	}
+	return rc.redisClient.Client.SetNX(rc.ctx, rc.prefix+key, value, expiration).Result()
 }
 
-// Get 实现 base64Captcha.Store 接口的 Get 方法
-func (a *CaptchaStoreAdapter) Get(key string, clear bool) string {
-	fullKey := a.cache.prefix + key
-	val, err := a.cache.redisClient.Client.Get(a.cache.ctx, fullKey).Result()
+// CompareAndDelete 仅在缓存值等于 expected 时删除键，返回是否删除
+// 并发请求中只有一个能删除成功，其余请求返回 false
+func (rc *RedisCache) CompareAndDelete(key string, expected string) (bool, error) {
+	deleted, err := compareAndDeleteScript.Run(rc.ctx, rc.redisClient.Client, []string{rc.prefix + key}, expected).Int64()
 	if err != nil {
-		return ""
-	}
-	if clear {
-		a.cache.redisClient.Client.Del(a.cache.ctx, fullKey)
+		return false, err
 	}
-	return val
+	return deleted == 1, nil
 }
 
-// Verify 实现 base64Captcha.Store 接口的 Verify 方法
-func (a *CaptchaStoreAdapter) Verify(key, answer string, clear bool) bool {
-	v := a.Get(key, clear)
-	return v == answer
+// Close 关闭缓存连接
+func (rc *RedisCache) Close() error {
+	return rc.redisClient.Close()
 }
@@ -0,0 +1,38 @@
+package database
+
+import (
+	"gorm.io/gorm"
+)
+
+// TableChange 数据表写入事件
+type TableChange struct {
+	Table string // 表名
+	InTx  bool   // 是否在事务中写入，事务提交前其他连接读到的仍是旧数据
+}
+
+// WatchTables 监听指定数据表的写入（创建、更新、删除），写入成功且影响行数大于 0 时回调
+// 通过 GORM 回调实现，覆盖所有经过 GORM 模型的写入；Exec 执行的原生 SQL 不会触发
+// name 用于区分不同的监听者，同一个 name 只能注册一次
+func WatchTables(db *PostgresDB, name string, tables []string, onChange func(TableChange)) error {
+	watched := make(map[string]bool, len(tables))
+	for _, table := range tables {
+		watched[table] = true
+	}
+
+	callback := func(tx *gorm.DB) {
+		if tx.Error != nil || tx.Statement.RowsAffected <= 0 || !watched[tx.Statement.Table] {
+			return
+		}
+		_, inTx := tx.Statement.ConnPool.(gorm.TxCommitter)
+		onChange(TableChange{Table: tx.Statement.Table, InTx: inTx})
+	}
+
+	callbackName := "watch:" + name
+	if err := db.Callback().Create().After("gorm:create").Register(callbackName, callback); err != nil {
+		return err
+	}
+	if err := db.Callback().Update().After("gorm:update").Register(callbackName, callback); err != nil {
+		return err
+	}
+	return db.Callback().Delete().After("gorm:delete").Register(callbackName, callback)
+}
@@ -0,0 +1,207 @@
+package database
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/observability/logger"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/security/fieldcrypt"
+	"gorm.io/gorm"
+)
+
+var fieldCipher *fieldcrypt.Cipher
+
+// SetFieldCipher 设置全局字段加密器
+func SetFieldCipher(cipher *fieldcrypt.Cipher) {
+	fieldCipher = cipher
+}
+
+// EncryptField 加密敏感字段，未设置加密器时原样返回
+func EncryptField(value string) (string, error) {
+	return fieldCipher.Encrypt(value)
+}
+
+// DecryptField 解密敏感字段，历史明文原样返回
+func DecryptField(value string) (string, error) {
+	return fieldCipher.Decrypt(value)
+}
+
+// BlindIndex 计算敏感字段的盲索引，写入时与密文一起保存
+func BlindIndex(value string) string {
+	return fieldCipher.BlindIndex(value)
+}
+
+// WhereEncrypted 按敏感字段等值查询
+// 同时匹配所有主密钥计算的盲索引，以及尚未重新加密的历史明文
+func WhereEncrypted(query *gorm.DB, column, hashColumn, value string) *gorm.DB {
+	indexes := fieldCipher.BlindIndexes(value)
+	if len(indexes) == 0 {
+		return query.Where(column+" = ?", value)
+	}
+	return query.Where("("+hashColumn+" IN ? OR "+column+" = ?)", indexes, value)
+}
+
+// EncryptedString 透明加密的字符串字段
+// 写入数据库时加密，读取时解密，空串不加密
+type EncryptedString string
+
+// Value 实现 driver.Valuer 接口，写入前加密
+func (s EncryptedString) Value() (driver.Value, error) {
+	return EncryptField(string(s))
+}
+
+// Scan 实现 sql.Scanner 接口，读取后解密
+func (s *EncryptedString) Scan(value interface{}) error {
+	var raw string
+	switch v := value.(type) {
+	case nil:
+		*s = ""
+		return nil
+	case string:
+		raw = v
+	case []byte:
+		raw = string(v)
+	default:
+		return fmt.Errorf("无法将 %T 转换为加密字段", value)
+	}
+
+	plaintext, err := DecryptField(raw)
+	if err != nil {
+		return err
+	}
+	*s = EncryptedString(plaintext)
+	return nil
+}
+
+// EncryptedColumn 需要加密的数据库列，用于重新加密任务
+type EncryptedColumn struct {
+	Table      string // 表名
+	Column     string // 加密列
+	HashColumn string // 盲索引列，不需要等值查询时为空
+	Condition  string // 额外筛选条件，如只加密部分配置项
+}
+
+// 重新加密任务每批处理的行数
+const reEncryptBatchSize = 200
+
+// ReEncryptTask 字段重新加密任务
+// 将历史明文和使用旧主密钥加密的字段改用当前主密钥加密，并重新计算盲索引
+// 全部完成后即可从密钥列表中移除旧主密钥
+type ReEncryptTask struct {
+	db      *PostgresDB
+	columns []EncryptedColumn
+	log     logger.Logger
+}
+
+// NewReEncryptTask 创建字段重新加密任务
+func NewReEncryptTask(db *PostgresDB, columns []EncryptedColumn, log logger.Logger) *ReEncryptTask {
+	return &ReEncryptTask{db: db, columns: columns, log: log}
+}
+
+// GetName 返回任务名称
+func (t *ReEncryptTask) GetName() string {
+	return "field_re_encrypt"
+}
+
+// GetGroup 返回任务分组
+func (t *ReEncryptTask) GetGroup() string {
+	return "system"
+}
+
+// Run 执行重新加密
+func (t *ReEncryptTask) Run(ctx context.Context) error {
+	if !fieldCipher.Enabled() {
+		t.log.Info("未配置字段加密主密钥，跳过重新加密")
+		return nil
+	}
+
+	var errs []error
+	for _, column := range t.columns {
+		count, err := t.reEncryptColumn(ctx, column)
+		if err != nil {
+			t.log.Error("字段重新加密失败", "table", column.Table, "column", column.Column, "error", err)
+			errs = append(errs, fmt.Errorf("%s.%s：%w", column.Table, column.Column, err))
+			continue
+		}
+		if count > 0 {
+			t.log.Info("字段重新加密完成", "table", column.Table, "column", column.Column, "count", count)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// encryptedRow 重新加密时读取的行
+type encryptedRow struct {
+	ID    int64
+	Value string
+}
+
+// reEncryptColumn 按主键顺序分批重新加密一列，返回更新的行数
+func (t *ReEncryptTask) reEncryptColumn(ctx context.Context, column EncryptedColumn) (int, error) {
+	var lastID int64
+	updated := 0
+	for {
+		if err := ctx.Err(); err != nil {
+			return updated, err
+		}
+
+		query := t.db.WithContext(ctx).Table(column.Table).
+			Select("id, "+column.Column+" AS value").
+			Where("id > ? AND deleted_at IS NULL AND "+column.Column+" IS NOT NULL AND "+column.Column+" <> ''", lastID)
+		if column.Condition != "" {
+			query = query.Where(column.Condition)
+		}
+		var rows []encryptedRow
+		if err := query.Order("id").Limit(reEncryptBatchSize).Scan(&rows).Error; err != nil {
+			return updated, err
+		}
+		if len(rows) == 0 {
+			return updated, nil
+		}
+
+		for _, row := range rows {
+			lastID = row.ID
+			if !fieldCipher.NeedsReEncryption(row.Value) {
+				continue
+			}
+			plaintext, err := fieldCipher.Decrypt(row.Value)
+			if err != nil {
+				return updated, fmt.Errorf("解密 ID 为 %d 的记录失败：%w", row.ID, err)
+			}
+			ciphertext, err := fieldCipher.Encrypt(plaintext)
+			if err != nil {
+				return updated, err
+			}
+
+			updates := map[string]interface{}{column.Column: ciphertext}
+			if column.HashColumn != "" {
+				updates[column.HashColumn] = fieldCipher.BlindIndex(plaintext)
+			}
+			// 以旧值作为条件，避免覆盖重新加密期间业务写入的新值
+			result := t.db.WithContext(ctx).Table(column.Table).
+				Where("id = ? AND "+column.Column+" = ?", row.ID, row.Value).
+				UpdateColumns(updates)
+			if result.Error != nil {
+				return updated, result.Error
+			}
+			updated += int(result.RowsAffected)
+		}
+	}
+}
+
+// escapeSQLString 转义 SQL 字符串字面量中的单引号
+func escapeSQLString(value string) string {
+	return strings.ReplaceAll(value, "'", "''")
+}
+
+// InCondition 构造列值属于指定集合的筛选条件，用于 EncryptedColumn.Condition
+func InCondition(column string, values ...string) string {
+	quoted := make([]string, len(values))
+	for i, value := range values {
+		quoted[i] = "'" + escapeSQLString(value) + "'"
+	}
+	return column + " IN (" + strings.Join(quoted, ", ") + ")"
+}
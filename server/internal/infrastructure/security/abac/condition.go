@@ -0,0 +1,299 @@
+package abac
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// 属性命名空间，条件中引用的属性必须带命名空间前缀，如 user.department_id
+const (
+	NamespaceUser     = "user"     // 用户属性：id、username、department_id、position_id、roles 等
+	NamespaceResource = "resource" // 资源属性：path、method、type、id、owner_id 等
+	NamespaceEnv      = "env"      // 环境属性：ip、time 等
+)
+
+// maxDepth 条件嵌套的最大层数，避免恶意构造的深层条件拖慢求值
+const maxDepth = 16
+
+// Attributes 求值时使用的属性集合，键为带命名空间的属性名，如 user.id、resource.owner_id、env.ip
+type Attributes map[string]interface{}
+
+// Condition 条件表达式的 JSON 结构
+// all、any、not 为逻辑节点，attr + op 为比较节点，每个节点只能使用其中一种形式：
+//
+//	{"all": [
+//	  {"attr": "user.roles", "op": "contains", "value": "finance"},
+//	  {"not": {"attr": "env.ip", "op": "cidr", "value": ["10.0.0.0/8"]}},
+//	  {"attr": "resource.owner_id", "op": "eq", "ref": "user.id"}
+//	]}
+type Condition struct {
+	All   []*Condition `json:"all,omitempty"`   // 全部满足
+	Any   []*Condition `json:"any,omitempty"`   // 任一满足
+	Not   *Condition   `json:"not,omitempty"`   // 取反
+	Attr  string       `json:"attr,omitempty"`  // 属性名
+	Op    string       `json:"op,omitempty"`    // 操作符
+	Value interface{}  `json:"value,omitempty"` // 比较值
+	Ref   string       `json:"ref,omitempty"`   // 与另一个属性比较，和 value 二选一
+}
+
+// legacyAttribute 旧版本保存的属性列表格式：[{"Key":"department_id","Value":"1","Type":"user"}]
+type legacyAttribute struct {
+	Key   string
+	Value string
+	Type  string
+}
+
+// node 编译后的条件节点
+type node interface {
+	eval(attrs Attributes) bool
+	explain(attrs Attributes) *Trace
+}
+
+// Program 编译后的条件，可被多个请求并发求值
+type Program struct {
+	root node
+}
+
+// Evaluate 使用给定属性对条件求值，空条件恒为真
+func (p *Program) Evaluate(attrs Attributes) bool {
+	if p == nil || p.root == nil {
+		return true
+	}
+	return p.root.eval(attrs)
+}
+
+// Compile 解析并编译 JSON 条件表达式
+// 空字符串、null 和 {} 表示无条件；兼容旧版本保存的属性列表格式，按全部相等处理
+func Compile(conditions string) (*Program, error) {
+	raw := bytes.TrimSpace([]byte(conditions))
+	if len(raw) == 0 || string(raw) == "null" || string(raw) == "{}" || string(raw) == "[]" {
+		return &Program{}, nil
+	}
+
+	var cond Condition
+	if raw[0] == '[' {
+		var legacy []legacyAttribute
+		if err := json.Unmarshal(raw, &legacy); err != nil {
+			return nil, fmt.Errorf("条件格式错误: %w", err)
+		}
+		cond.All = make([]*Condition, 0, len(legacy))
+		for _, attr := range legacy {
+			cond.All = append(cond.All, &Condition{Attr: legacyNamespace(attr.Type) + "." + attr.Key, Op: OpEq, Value: attr.Value})
+		}
+	} else {
+		decoder := json.NewDecoder(bytes.NewReader(raw))
+		decoder.DisallowUnknownFields()
+		decoder.UseNumber()
+		if err := decoder.Decode(&cond); err != nil {
+			return nil, fmt.Errorf("条件格式错误: %w", err)
+		}
+	}
+
+	root, err := compileNode(&cond, "$", 1)
+	if err != nil {
+		return nil, err
+	}
+	return &Program{root: root}, nil
+}
+
+// Validate 校验条件表达式，返回第一个错误及其位置
+func Validate(conditions string) error {
+	_, err := Compile(conditions)
+	return err
+}
+
+// legacyNamespace 将旧版本的属性类型映射为命名空间
+func legacyNamespace(attrType string) string {
+	switch attrType {
+	case "resource":
+		return NamespaceResource
+	case "environment", NamespaceEnv:
+		return NamespaceEnv
+	default:
+		return NamespaceUser
+	}
+}
+
+// compileNode 递归编译条件节点，path 为错误提示中的节点位置
+func compileNode(cond *Condition, path string, depth int) (node, error) {
+	if cond == nil {
+		return nil, fmt.Errorf("%s: 条件不能为空", path)
+	}
+	if depth > maxDepth {
+		return nil, fmt.Errorf("%s: 条件嵌套超过 %d 层", path, maxDepth)
+	}
+
+	forms := 0
+	if cond.All != nil {
+		forms++
+	}
+	if cond.Any != nil {
+		forms++
+	}
+	if cond.Not != nil {
+		forms++
+	}
+	if cond.Attr != "" || cond.Op != "" {
+		forms++
+	}
+	if forms != 1 {
+		return nil, fmt.Errorf("%s: 每个条件节点必须且只能使用 all、any、not、attr/op 中的一种", path)
+	}
+
+	switch {
+	case cond.All != nil:
+		children, err := compileChildren(cond.All, path+".all", depth)
+		if err != nil {
+			return nil, err
+		}
+		return &allNode{path: path, children: children}, nil
+	case cond.Any != nil:
+		children, err := compileChildren(cond.Any, path+".any", depth)
+		if err != nil {
+			return nil, err
+		}
+		return &anyNode{path: path, children: children}, nil
+	case cond.Not != nil:
+		child, err := compileNode(cond.Not, path+".not", depth+1)
+		if err != nil {
+			return nil, err
+		}
+		return &notNode{path: path, child: child}, nil
+	default:
+		return compileComparison(cond, path)
+	}
+}
+
+// compileChildren 编译 all、any 的子节点
+func compileChildren(conds []*Condition, path string, depth int) ([]node, error) {
+	if len(conds) == 0 {
+		return nil, fmt.Errorf("%s: 至少需要一个子条件", path)
+	}
+	children := make([]node, 0, len(conds))
+	for i, child := range conds {
+		compiled, err := compileNode(child, fmt.Sprintf("%s[%d]", path, i), depth+1)
+		if err != nil {
+			return nil, err
+		}
+		children = append(children, compiled)
+	}
+	return children, nil
+}
+
+// compileComparison 编译比较节点
+func compileComparison(cond *Condition, path string) (node, error) {
+	if err := validateAttr(cond.Attr); err != nil {
+		return nil, fmt.Errorf("%s.attr: %w", path, err)
+	}
+	if cond.Ref != "" {
+		if err := validateAttr(cond.Ref); err != nil {
+			return nil, fmt.Errorf("%s.ref: %w", path, err)
+		}
+		if cond.Value != nil {
+			return nil, fmt.Errorf("%s: value 和 ref 只能填写一个", path)
+		}
+	}
+
+	op, ok := operators[cond.Op]
+	if !ok {
+		return nil, fmt.Errorf("%s.op: 不支持的操作符 %q", path, cond.Op)
+	}
+	if cond.Ref != "" && !op.allowRef {
+		return nil, fmt.Errorf("%s: 操作符 %s 不支持 ref", path, cond.Op)
+	}
+
+	cmp := &comparisonNode{path: path, attr: cond.Attr, op: cond.Op, ref: cond.Ref, value: cond.Value, match: op.match}
+	if cond.Ref == "" && op.compile != nil {
+		operand, err := op.compile(cond.Value)
+		if err != nil {
+			return nil, fmt.Errorf("%s.value: %w", path, err)
+		}
+		cmp.operand = operand
+	}
+	return cmp, nil
+}
+
+// validateAttr 校验属性名必须带 user、resource、env 命名空间
+func validateAttr(attr string) error {
+	if attr == "" {
+		return errors.New("属性名不能为空")
+	}
+	namespace, key, found := strings.Cut(attr, ".")
+	if !found || key == "" {
+		return fmt.Errorf("属性 %q 缺少命名空间，应为 user.*、resource.* 或 env.*", attr)
+	}
+	switch namespace {
+	case NamespaceUser, NamespaceResource, NamespaceEnv:
+		return nil
+	default:
+		return fmt.Errorf("属性 %q 的命名空间无效，应为 user、resource 或 env", attr)
+	}
+}
+
+// allNode 全部子条件满足
+type allNode struct {
+	path     string
+	children []node
+}
+
+func (n *allNode) eval(attrs Attributes) bool {
+	for _, child := range n.children {
+		if !child.eval(attrs) {
+			return false
+		}
+	}
+	return true
+}
+
+// anyNode 任一子条件满足
+type anyNode struct {
+	path     string
+	children []node
+}
+
+func (n *anyNode) eval(attrs Attributes) bool {
+	for _, child := range n.children {
+		if child.eval(attrs) {
+			return true
+		}
+	}
+	return false
+}
+
+// notNode 子条件取反
+type notNode struct {
+	path  string
+	child node
+}
+
+func (n *notNode) eval(attrs Attributes) bool {
+	return !n.child.eval(attrs)
+}
+
+// comparisonNode 属性比较
+// 属性缺失时除 exists 外的操作符均不满足，取反时需注意缺失属性会得到 true
+type comparisonNode struct {
+	path    string
+	attr    string
+	op      string
+	ref     string
+	value   interface{} // 条件中填写的原始比较值，用于解释求值过程
+	operand interface{}
+	match   matchFunc
+}
+
+func (n *comparisonNode) eval(attrs Attributes) bool {
+	actual, ok := attrs[n.attr]
+	operand := n.operand
+	if n.ref != "" {
+		refValue, refOK := attrs[n.ref]
+		if !refOK {
+			return false
+		}
+		operand = refValue
+	}
+	return n.match(actual, ok, operand)
+}
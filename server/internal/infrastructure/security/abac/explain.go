@@ -0,0 +1,77 @@
+package abac
+
+// 条件节点类型
+const (
+	TraceKindAll     = "all"     // 全部满足
+	TraceKindAny     = "any"     // 任一满足
+	TraceKindNot     = "not"     // 取反
+	TraceKindCompare = "compare" // 属性比较
+	TraceKindEmpty   = "empty"   // 无条件，恒为真
+)
+
+// Trace 条件求值过程，记录每个节点的求值结果，用于解释规则为何命中或未命中
+// 与 Evaluate 不同，all、any 会对全部子条件求值，便于一次看到所有不满足的条件
+type Trace struct {
+	Path     string      `json:"path"`               // 节点位置，如 $.all[0]
+	Kind     string      `json:"kind"`               // 节点类型
+	Attr     string      `json:"attr,omitempty"`     // 比较的属性名
+	Op       string      `json:"op,omitempty"`       // 操作符
+	Value    interface{} `json:"value,omitempty"`    // 条件中的比较值
+	Ref      string      `json:"ref,omitempty"`      // 引用的属性名
+	Actual   interface{} `json:"actual,omitempty"`   // 请求中的属性值
+	Present  bool        `json:"present"`            // 请求中是否存在该属性
+	Result   bool        `json:"result"`             // 节点求值结果
+	Children []*Trace    `json:"children,omitempty"` // 子节点
+}
+
+// Explain 使用给定属性对条件求值并返回求值过程，结果与 Evaluate 一致
+func (p *Program) Explain(attrs Attributes) *Trace {
+	if p == nil || p.root == nil {
+		return &Trace{Path: "$", Kind: TraceKindEmpty, Result: true}
+	}
+	return p.root.explain(attrs)
+}
+
+func (n *allNode) explain(attrs Attributes) *Trace {
+	trace := &Trace{Path: n.path, Kind: TraceKindAll, Result: true}
+	for _, child := range n.children {
+		childTrace := child.explain(attrs)
+		trace.Children = append(trace.Children, childTrace)
+		trace.Result = trace.Result && childTrace.Result
+	}
+	return trace
+}
+
+func (n *anyNode) explain(attrs Attributes) *Trace {
+	trace := &Trace{Path: n.path, Kind: TraceKindAny}
+	for _, child := range n.children {
+		childTrace := child.explain(attrs)
+		trace.Children = append(trace.Children, childTrace)
+		trace.Result = trace.Result || childTrace.Result
+	}
+	return trace
+}
+
+func (n *notNode) explain(attrs Attributes) *Trace {
+	childTrace := n.child.explain(attrs)
+	return &Trace{Path: n.path, Kind: TraceKindNot, Result: !childTrace.Result, Children: []*Trace{childTrace}}
+}
+
+func (n *comparisonNode) explain(attrs Attributes) *Trace {
+	actual, ok := attrs[n.attr]
+	trace := &Trace{
+		Path:    n.path,
+		Kind:    TraceKindCompare,
+		Attr:    n.attr,
+		Op:      n.op,
+		Value:   n.value,
+		Ref:     n.ref,
+		Actual:  actual,
+		Present: ok,
+		Result:  n.eval(attrs),
+	}
+	if n.ref != "" {
+		trace.Value = attrs[n.ref]
+	}
+	return trace
+}
@@ -0,0 +1,104 @@
+package abac
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// MethodAny 匹配所有 HTTP 方法
+const MethodAny = "*"
+
+// validMethods 规则允许配置的 HTTP 方法
+var validMethods = map[string]bool{
+	"GET": true, "POST": true, "PUT": true, "DELETE": true, "PATCH": true, "HEAD": true, "OPTIONS": true,
+}
+
+// MatchPath 判断请求路径是否匹配规则的路径模式
+// 模式语法与 gin 路由一致：:name 匹配单个路径段，*name 只能位于末尾并匹配剩余的全部路径；
+// 另外单独的 * 位于中间时匹配单个路径段，模式为 * 或 /* 时匹配所有路径
+func MatchPath(pattern, path string) bool {
+	if pattern == "*" || pattern == "/*" {
+		return true
+	}
+
+	patternSegs := splitPath(pattern)
+	pathSegs := splitPath(path)
+	for i, seg := range patternSegs {
+		if strings.HasPrefix(seg, "*") && i == len(patternSegs)-1 {
+			return len(pathSegs) >= i
+		}
+		if i >= len(pathSegs) {
+			return false
+		}
+		if seg == "*" || strings.HasPrefix(seg, ":") {
+			if pathSegs[i] == "" {
+				return false
+			}
+			continue
+		}
+		if seg != pathSegs[i] {
+			return false
+		}
+	}
+	return len(patternSegs) == len(pathSegs)
+}
+
+// MatchMethod 判断请求方法是否匹配规则的方法，规则方法可以是 *、ANY 或逗号分隔的多个方法
+func MatchMethod(pattern, method string) bool {
+	pattern = strings.TrimSpace(pattern)
+	if pattern == "" || pattern == MethodAny || strings.EqualFold(pattern, "ANY") {
+		return true
+	}
+	for _, m := range strings.Split(pattern, ",") {
+		if strings.EqualFold(strings.TrimSpace(m), method) {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidatePathPattern 校验规则的路径模式
+func ValidatePathPattern(pattern string) error {
+	if pattern == "*" || pattern == "/*" {
+		return nil
+	}
+	if !strings.HasPrefix(pattern, "/") {
+		return errors.New("API 路径必须以 / 开头")
+	}
+	segs := splitPath(pattern)
+	for i, seg := range segs {
+		if seg == "" && i < len(segs)-1 {
+			return errors.New("API 路径不能包含空路径段")
+		}
+		if seg == ":" {
+			return errors.New("API 路径参数缺少名称")
+		}
+		if strings.HasPrefix(seg, "*") && seg != "*" && i != len(segs)-1 {
+			return fmt.Errorf("通配符 %s 只能位于路径末尾", seg)
+		}
+	}
+	return nil
+}
+
+// ValidateMethod 校验规则的 HTTP 方法，返回规范化后的大写形式
+func ValidateMethod(method string) (string, error) {
+	method = strings.TrimSpace(method)
+	if method == "" || method == MethodAny || strings.EqualFold(method, "ANY") {
+		return MethodAny, nil
+	}
+	parts := strings.Split(method, ",")
+	for i, m := range parts {
+		m = strings.ToUpper(strings.TrimSpace(m))
+		if !validMethods[m] {
+			return "", fmt.Errorf("不支持的 HTTP 方法 %q", m)
+		}
+		parts[i] = m
+	}
+	return strings.Join(parts, ","), nil
+}
+
+// splitPath 去掉首个 / 后按 / 切分路径
+func splitPath(path string) []string {
+	return strings.Split(strings.TrimPrefix(path, "/"), "/")
+}
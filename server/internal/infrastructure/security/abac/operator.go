@@ -0,0 +1,486 @@
+package abac
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 支持的操作符
+const (
+	OpEq         = "eq"          // 等于
+	OpNe         = "ne"          // 不等于
+	OpIn         = "in"          // 属于集合，属性为列表时有交集即满足
+	OpNotIn      = "not_in"      // 不属于集合
+	OpContains   = "contains"    // 列表属性包含该值，字符串属性包含该子串
+	OpLt         = "lt"          // 小于，支持数字和 RFC3339 时间
+	OpLte        = "lte"         // 小于等于
+	OpGt         = "gt"          // 大于
+	OpGte        = "gte"         // 大于等于
+	OpRegex      = "regex"       // 正则匹配
+	OpCIDR       = "cidr"        // IP 属于网段，value 为 CIDR 或 IP，也可以是列表
+	OpTimeWindow = "time_window" // 时间落在时间窗口内，value 为 {"start":"09:00","end":"18:00","weekdays":[1,2,3,4,5],"timezone":"Asia/Shanghai"}
+	OpExists     = "exists"      // 属性存在且非空，value 为 false 时表示不存在
+)
+
+// matchFunc 比较函数，present 表示属性是否存在
+type matchFunc func(actual interface{}, present bool, operand interface{}) bool
+
+// operator 操作符定义
+type operator struct {
+	compile  func(value interface{}) (interface{}, error) // 编译期校验并预处理 value
+	match    matchFunc
+	allowRef bool // 是否允许与另一个属性比较
+}
+
+// operators 操作符注册表
+var operators = map[string]operator{
+	OpEq: {compile: compileScalar, allowRef: true, match: func(actual interface{}, present bool, operand interface{}) bool {
+		return present && !isList(actual) && equalValues(actual, operand)
+	}},
+	OpNe: {compile: compileScalar, allowRef: true, match: func(actual interface{}, present bool, operand interface{}) bool {
+		return present && !isList(actual) && !equalValues(actual, operand)
+	}},
+	OpIn: {compile: compileScalarList, allowRef: true, match: func(actual interface{}, present bool, operand interface{}) bool {
+		return present && inList(actual, operand)
+	}},
+	OpNotIn: {compile: compileScalarList, allowRef: true, match: func(actual interface{}, present bool, operand interface{}) bool {
+		return present && !inList(actual, operand)
+	}},
+	OpContains: {compile: compileScalar, allowRef: true, match: matchContains},
+	OpLt:       {compile: compileOrdered, allowRef: true, match: orderedMatch(func(c int) bool { return c < 0 })},
+	OpLte:      {compile: compileOrdered, allowRef: true, match: orderedMatch(func(c int) bool { return c <= 0 })},
+	OpGt:       {compile: compileOrdered, allowRef: true, match: orderedMatch(func(c int) bool { return c > 0 })},
+	OpGte:      {compile: compileOrdered, allowRef: true, match: orderedMatch(func(c int) bool { return c >= 0 })},
+	OpRegex:    {compile: compileRegex, match: matchRegex},
+	OpCIDR:     {compile: compileCIDR, match: matchCIDR},
+	OpTimeWindow: {compile: compileTimeWindow, match: func(actual interface{}, present bool, operand interface{}) bool {
+		if !present {
+			return false
+		}
+		t, ok := toTime(actual)
+		return ok && operand.(*timeWindow).contains(t)
+	}},
+	OpExists: {compile: compileExists, match: func(actual interface{}, present bool, operand interface{}) bool {
+		exists := present && !isEmpty(actual)
+		return exists == operand.(bool)
+	}},
+}
+
+// compileScalar 校验 value 为标量
+func compileScalar(value interface{}) (interface{}, error) {
+	if _, ok := scalarString(value); !ok {
+		return nil, errors.New("必须是字符串、数字或布尔值")
+	}
+	return value, nil
+}
+
+// compileScalarList 校验 value 为标量列表
+func compileScalarList(value interface{}) (interface{}, error) {
+	items, ok := toList(value)
+	if !ok {
+		return nil, errors.New("必须是数组")
+	}
+	for i, item := range items {
+		if _, ok := scalarString(item); !ok {
+			return nil, fmt.Errorf("第 %d 项必须是字符串、数字或布尔值", i)
+		}
+	}
+	return items, nil
+}
+
+// compileOrdered 校验 value 为数字或 RFC3339 时间
+func compileOrdered(value interface{}) (interface{}, error) {
+	s, ok := scalarString(value)
+	if !ok {
+		return nil, errors.New("必须是数字或 RFC3339 时间")
+	}
+	if _, err := strconv.ParseFloat(s, 64); err == nil {
+		return value, nil
+	}
+	if _, err := time.Parse(time.RFC3339, s); err == nil {
+		return value, nil
+	}
+	return nil, fmt.Errorf("%q 不是数字或 RFC3339 时间", s)
+}
+
+// compileRegex 预编译正则表达式
+func compileRegex(value interface{}) (interface{}, error) {
+	pattern, ok := value.(string)
+	if !ok || pattern == "" {
+		return nil, errors.New("必须是非空的正则表达式字符串")
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("正则表达式无效: %w", err)
+	}
+	return re, nil
+}
+
+// compileCIDR 解析网段列表，单个 IP 视为 /32 或 /128
+func compileCIDR(value interface{}) (interface{}, error) {
+	items, ok := toList(value)
+	if !ok {
+		items = []interface{}{value}
+	}
+	if len(items) == 0 {
+		return nil, errors.New("至少需要一个网段")
+	}
+
+	networks := make([]*net.IPNet, 0, len(items))
+	for _, item := range items {
+		s, ok := item.(string)
+		if !ok {
+			return nil, errors.New("网段必须是字符串")
+		}
+		s = strings.TrimSpace(s)
+		if !strings.Contains(s, "/") {
+			ip := net.ParseIP(s)
+			if ip == nil {
+				return nil, fmt.Errorf("%q 不是有效的 IP 或 CIDR", s)
+			}
+			if ip.To4() != nil {
+				s += "/32"
+			} else {
+				s += "/128"
+			}
+		}
+		_, network, err := net.ParseCIDR(s)
+		if err != nil {
+			return nil, fmt.Errorf("%q 不是有效的 IP 或 CIDR", s)
+		}
+		networks = append(networks, network)
+	}
+	return networks, nil
+}
+
+// compileExists 解析 exists 的期望值，默认为 true
+func compileExists(value interface{}) (interface{}, error) {
+	if value == nil {
+		return true, nil
+	}
+	want, ok := value.(bool)
+	if !ok {
+		return nil, errors.New("必须是布尔值")
+	}
+	return want, nil
+}
+
+// matchContains 列表属性包含该值，字符串属性包含该子串
+func matchContains(actual interface{}, present bool, operand interface{}) bool {
+	if !present {
+		return false
+	}
+	if items, ok := toList(actual); ok {
+		for _, item := range items {
+			if equalValues(item, operand) {
+				return true
+			}
+		}
+		return false
+	}
+	s, ok := scalarString(actual)
+	sub, subOK := scalarString(operand)
+	return ok && subOK && strings.Contains(s, sub)
+}
+
+// matchRegex 属性值匹配正则表达式
+func matchRegex(actual interface{}, present bool, operand interface{}) bool {
+	if !present {
+		return false
+	}
+	s, ok := scalarString(actual)
+	return ok && operand.(*regexp.Regexp).MatchString(s)
+}
+
+// matchCIDR IP 属性属于任一网段
+func matchCIDR(actual interface{}, present bool, operand interface{}) bool {
+	if !present {
+		return false
+	}
+	var ip net.IP
+	switch v := actual.(type) {
+	case net.IP:
+		ip = v
+	case string:
+		ip = net.ParseIP(strings.TrimSpace(v))
+	}
+	if ip == nil {
+		return false
+	}
+	for _, network := range operand.([]*net.IPNet) {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// orderedMatch 构造大小比较函数
+func orderedMatch(accept func(c int) bool) matchFunc {
+	return func(actual interface{}, present bool, operand interface{}) bool {
+		if !present {
+			return false
+		}
+		c, ok := compareValues(actual, operand)
+		return ok && accept(c)
+	}
+}
+
+// inList 属性值属于集合，属性为列表时有交集即满足
+func inList(actual, operand interface{}) bool {
+	set, ok := toList(operand)
+	if !ok {
+		return false
+	}
+	values, isListValue := toList(actual)
+	if !isListValue {
+		values = []interface{}{actual}
+	}
+	for _, v := range values {
+		for _, item := range set {
+			if equalValues(v, item) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// timeWindow 时间窗口，start 大于 end 时表示跨天，如 22:00-06:00
+type timeWindow struct {
+	start    int // 开始时间，当天的分钟数
+	end      int // 结束时间，当天的分钟数，不包含
+	weekdays map[time.Weekday]bool
+	location *time.Location
+}
+
+// contains 判断时间是否落在窗口内
+func (w *timeWindow) contains(t time.Time) bool {
+	t = t.In(w.location)
+	if len(w.weekdays) > 0 && !w.weekdays[t.Weekday()] {
+		return false
+	}
+	minute := t.Hour()*60 + t.Minute()
+	if w.start <= w.end {
+		return minute >= w.start && minute < w.end
+	}
+	return minute >= w.start || minute < w.end
+}
+
+// compileTimeWindow 解析时间窗口，weekdays 使用 1-7 表示周一至周日
+func compileTimeWindow(value interface{}) (interface{}, error) {
+	obj, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, errors.New(`必须是对象，如 {"start":"09:00","end":"18:00"}`)
+	}
+	for key := range obj {
+		switch key {
+		case "start", "end", "weekdays", "timezone":
+		default:
+			return nil, fmt.Errorf("未知字段 %q", key)
+		}
+	}
+
+	window := &timeWindow{location: time.Local}
+	var err error
+	if window.start, err = parseClock(obj["start"]); err != nil {
+		return nil, fmt.Errorf("start %w", err)
+	}
+	if window.end, err = parseClock(obj["end"]); err != nil {
+		return nil, fmt.Errorf("end %w", err)
+	}
+	if window.start == window.end {
+		return nil, errors.New("start 和 end 不能相同")
+	}
+
+	if tz, ok := obj["timezone"]; ok {
+		name, ok := tz.(string)
+		if !ok {
+			return nil, errors.New("timezone 必须是字符串")
+		}
+		if window.location, err = time.LoadLocation(name); err != nil {
+			return nil, fmt.Errorf("timezone %q 无效", name)
+		}
+	}
+
+	if days, ok := obj["weekdays"]; ok {
+		items, ok := toList(days)
+		if !ok {
+			return nil, errors.New("weekdays 必须是数组")
+		}
+		window.weekdays = make(map[time.Weekday]bool, len(items))
+		for _, item := range items {
+			s, _ := scalarString(item)
+			day, err := strconv.Atoi(s)
+			if err != nil || day < 1 || day > 7 {
+				return nil, fmt.Errorf("weekdays 的取值必须是 1-7，实际为 %v", item)
+			}
+			window.weekdays[time.Weekday(day%7)] = true
+		}
+	}
+	return window, nil
+}
+
+// parseClock 解析 HH:MM 格式的时间为当天的分钟数
+func parseClock(value interface{}) (int, error) {
+	s, ok := value.(string)
+	if !ok {
+		return 0, errors.New("必须是 HH:MM 格式的字符串")
+	}
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("%q 不是 HH:MM 格式", s)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// scalarString 将标量转换为字符串，列表、对象和 nil 返回 false
+func scalarString(v interface{}) (string, bool) {
+	switch val := v.(type) {
+	case string:
+		return val, true
+	case json.Number:
+		return val.String(), true
+	case bool:
+		return strconv.FormatBool(val), true
+	case int:
+		return strconv.Itoa(val), true
+	case int32:
+		return strconv.FormatInt(int64(val), 10), true
+	case int64:
+		return strconv.FormatInt(val, 10), true
+	case uint:
+		return strconv.FormatUint(uint64(val), 10), true
+	case uint64:
+		return strconv.FormatUint(val, 10), true
+	case float32:
+		return strconv.FormatFloat(float64(val), 'f', -1, 32), true
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64), true
+	case time.Time:
+		return val.Format(time.RFC3339), true
+	case net.IP:
+		return val.String(), true
+	default:
+		return "", false
+	}
+}
+
+// equalValues 比较两个标量是否相等，数字按数值比较，如 1 与 "1.0" 相等
+// 雪花 ID 超出 float64 精度，先按字符串比较，因此 ID 建议在条件中写成字符串
+func equalValues(a, b interface{}) bool {
+	sa, okA := scalarString(a)
+	sb, okB := scalarString(b)
+	if !okA || !okB {
+		return false
+	}
+	if sa == sb {
+		return true
+	}
+	fa, errA := strconv.ParseFloat(sa, 64)
+	fb, errB := strconv.ParseFloat(sb, 64)
+	return errA == nil && errB == nil && fa == fb
+}
+
+// compareValues 比较两个数字或时间的大小
+func compareValues(a, b interface{}) (int, bool) {
+	if ta, ok := toTime(a); ok {
+		if tb, ok := toTime(b); ok {
+			return ta.Compare(tb), true
+		}
+		return 0, false
+	}
+
+	sa, okA := scalarString(a)
+	sb, okB := scalarString(b)
+	if !okA || !okB {
+		return 0, false
+	}
+	if ia, err := strconv.ParseInt(sa, 10, 64); err == nil {
+		if ib, err := strconv.ParseInt(sb, 10, 64); err == nil {
+			switch {
+			case ia < ib:
+				return -1, true
+			case ia > ib:
+				return 1, true
+			default:
+				return 0, true
+			}
+		}
+	}
+	fa, errA := strconv.ParseFloat(sa, 64)
+	fb, errB := strconv.ParseFloat(sb, 64)
+	if errA != nil || errB != nil {
+		return 0, false
+	}
+	switch {
+	case fa < fb:
+		return -1, true
+	case fa > fb:
+		return 1, true
+	default:
+		return 0, true
+	}
+}
+
+// toTime 将 time.Time 或 RFC3339 字符串转换为时间
+func toTime(v interface{}) (time.Time, bool) {
+	switch val := v.(type) {
+	case time.Time:
+		return val, true
+	case string:
+		t, err := time.Parse(time.RFC3339, val)
+		return t, err == nil
+	default:
+		return time.Time{}, false
+	}
+}
+
+// toList 将切片或数组转换为 []interface{}
+func toList(v interface{}) ([]interface{}, bool) {
+	if items, ok := v.([]interface{}); ok {
+		return items, true
+	}
+	if v == nil {
+		return nil, false
+	}
+	if _, ok := v.(net.IP); ok {
+		return nil, false
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return nil, false
+	}
+	items := make([]interface{}, rv.Len())
+	for i := range items {
+		items[i] = rv.Index(i).Interface()
+	}
+	return items, true
+}
+
+// isList 判断是否为列表
+func isList(v interface{}) bool {
+	_, ok := toList(v)
+	return ok
+}
+
+// isEmpty 判断属性值是否为空
+func isEmpty(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+	if s, ok := v.(string); ok {
+		return s == ""
+	}
+	if items, ok := toList(v); ok {
+		return len(items) == 0
+	}
+	return false
+}
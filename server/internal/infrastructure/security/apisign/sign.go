@@ -0,0 +1,207 @@
+package apisign
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/ix-pay/ixpay-pro/internal/config"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/observability/logger"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/security/oidc"
+)
+
+// 开放接口签名相关的请求头和响应头
+const (
+	HeaderAppKey    = "X-App-Key"
+	HeaderTimestamp = "X-Timestamp"
+	HeaderNonce     = "X-Nonce"
+	HeaderSignType  = "X-Sign-Type"
+	HeaderSignature = "X-Signature"
+)
+
+// 签名方式
+const (
+	SignTypeHMAC = "HMAC-SHA256" // 使用应用密钥的 HMAC-SHA256
+	SignTypeRSA  = "RSA-SHA256"  // 使用 RSA 私钥的 SHA256withRSA（PKCS#1 v1.5）
+)
+
+// RequestSigningString 构造请求待签名串
+// 各字段以换行分隔：请求方法、路径、规范化查询串、应用标识、时间戳、随机串、请求体 SHA-256 十六进制摘要
+func RequestSigningString(method, path, rawQuery, appKey, timestamp, nonce string, body []byte) string {
+	return strings.Join([]string{
+		strings.ToUpper(method),
+		path,
+		CanonicalQuery(rawQuery),
+		appKey,
+		timestamp,
+		nonce,
+		bodyDigest(body),
+	}, "\n")
+}
+
+// ResponseSigningString 构造响应待签名串
+// 各字段以换行分隔：应用标识、时间戳、随机串、响应体 SHA-256 十六进制摘要
+func ResponseSigningString(appKey, timestamp, nonce string, body []byte) string {
+	return strings.Join([]string{appKey, timestamp, nonce, bodyDigest(body)}, "\n")
+}
+
+// CanonicalQuery 规范化查询串：参数按名称排序，同名参数按值排序，名称和值均按 RFC 3986 编码
+func CanonicalQuery(rawQuery string) string {
+	values, err := url.ParseQuery(rawQuery)
+	if err != nil || len(values) == 0 {
+		return ""
+	}
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(values))
+	for _, key := range keys {
+		vals := append([]string(nil), values[key]...)
+		sort.Strings(vals)
+		for _, val := range vals {
+			pairs = append(pairs, escape(key)+"="+escape(val))
+		}
+	}
+	return strings.Join(pairs, "&")
+}
+
+// escape 按 RFC 3986 编码，空格编码为 %20
+func escape(s string) string {
+	return strings.ReplaceAll(url.QueryEscape(s), "+", "%20")
+}
+
+// bodyDigest 计算请求体或响应体的 SHA-256 十六进制摘要
+func bodyDigest(body []byte) string {
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:])
+}
+
+// SignHMAC 使用密钥计算 HMAC-SHA256 签名，返回 base64 编码
+func SignHMAC(secret, payload string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(payload))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyHMAC 校验 HMAC-SHA256 签名
+func VerifyHMAC(secret, payload, signature string) bool {
+	expected, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(payload))
+	return hmac.Equal(mac.Sum(nil), expected)
+}
+
+// VerifyRSA 使用 PEM 格式公钥校验 SHA256withRSA 签名
+func VerifyRSA(publicKeyPEM, payload, signature string) bool {
+	publicKey, err := ParseRSAPublicKey(publicKeyPEM)
+	if err != nil {
+		return false
+	}
+	sig, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	digest := sha256.Sum256([]byte(payload))
+	return rsa.VerifyPKCS1v15(publicKey, crypto.SHA256, digest[:], sig) == nil
+}
+
+// ParseRSAPublicKey 解析 PEM 格式的 RSA 公钥，支持 PKIX 和 PKCS#1
+func ParseRSAPublicKey(publicKeyPEM string) (*rsa.PublicKey, error) {
+	block, _ := pem.Decode([]byte(publicKeyPEM))
+	if block == nil {
+		return nil, errors.New("公钥不是有效的 PEM 格式")
+	}
+
+	var publicKey *rsa.PublicKey
+	if parsed, err := x509.ParsePKIXPublicKey(block.Bytes); err == nil {
+		rsaKey, ok := parsed.(*rsa.PublicKey)
+		if !ok {
+			return nil, errors.New("公钥必须为 RSA 密钥")
+		}
+		publicKey = rsaKey
+	} else {
+		rsaKey, pkcs1Err := x509.ParsePKCS1PublicKey(block.Bytes)
+		if pkcs1Err != nil {
+			return nil, fmt.Errorf("解析公钥失败：%w", err)
+		}
+		publicKey = rsaKey
+	}
+
+	if publicKey.N.BitLen() < 2048 {
+		return nil, errors.New("公钥长度不能小于 2048 位")
+	}
+	return publicKey, nil
+}
+
+// Signer 开放接口平台响应签名器
+// RSA 签名方式的应用使用平台私钥验证响应，HMAC 方式的应用直接使用应用密钥，不经过本签名器
+type Signer struct {
+	key *rsa.PrivateKey
+}
+
+// SetupSigner 初始化平台响应签名器
+// 未配置私钥文件时临时生成密钥，服务重启后合作方需重新获取平台公钥，多实例部署时必须配置私钥文件
+func SetupSigner(cfg *config.Config, log logger.Logger) (*Signer, error) {
+	keyFile := cfg.OpenAPI.SigningKeyFile
+	if keyFile == "" {
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		if err != nil {
+			return nil, fmt.Errorf("生成开放接口签名密钥失败：%w", err)
+		}
+		log.Warn("未配置开放接口签名私钥，使用临时生成的密钥，重启后平台公钥将变化")
+		return NewSigner(key), nil
+	}
+
+	data, err := os.ReadFile(keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("读取开放接口签名私钥失败：%w", err)
+	}
+	key, err := oidc.ParseRSAPrivateKey(data)
+	if err != nil {
+		return nil, err
+	}
+	return NewSigner(key), nil
+}
+
+// NewSigner 使用指定私钥创建签名器
+func NewSigner(key *rsa.PrivateKey) *Signer {
+	return &Signer{key: key}
+}
+
+// Sign 计算 SHA256withRSA 签名，返回 base64 编码
+func (s *Signer) Sign(payload string) (string, error) {
+	digest := sha256.Sum256([]byte(payload))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, s.key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(sig), nil
+}
+
+// PublicKeyPEM 返回 PKIX 格式的平台公钥 PEM，供合作方校验响应签名
+func (s *Signer) PublicKeyPEM() string {
+	der, err := x509.MarshalPKIXPublicKey(&s.key.PublicKey)
+	if err != nil {
+		return ""
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+}
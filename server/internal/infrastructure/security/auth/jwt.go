@@ -39,6 +39,7 @@ type Claims struct {
 	Nickname  string `json:"nickname"`
 	Role      string `json:"role"`
 	LoginType string `json:"login_type"`
+	SessionID string `json:"sid,omitempty"` // 登录会话 ID，用于多端会话管理和单个设备下线
 	jwt.RegisteredClaims
 }
 
@@ -64,16 +65,22 @@ func SetupJWTAuth(cfg *config.Config, log logger.Logger) (*JWTAuth, error) {
 		nil
 }
 
+// RefreshTokenExpire 刷新令牌有效期，即登录会话的最长存活时间
+func (j *JWTAuth) RefreshTokenExpire() time.Duration {
+	return j.refreshTokenExpire
+}
+
 // GenerateToken 生成访问令牌和刷新令牌
-func (j *JWTAuth) GenerateToken(userID string, userName string, nickname string, role string, loginType string) (string, string, time.Time, time.Time, error) {
+// sessionID 为登录会话 ID，写入令牌的 sid 声明，刷新令牌时保持不变
+func (j *JWTAuth) GenerateToken(userID string, userName string, nickname string, role string, loginType string, sessionID string) (string, string, time.Time, time.Time, error) {
 	// 生成访问令牌
-	accessToken, accessExpire, err := j.generateAccessToken(userID, userName, nickname, role, loginType)
+	accessToken, accessExpire, err := j.generateAccessToken(userID, userName, nickname, role, loginType, sessionID)
 	if err != nil {
 		return "", "", time.Time{}, time.Time{}, err
 	}
 
 	// 生成刷新令牌
-	refreshToken, refreshExpire, err := j.generateRefreshToken(userID, userName, nickname, role, loginType)
+	refreshToken, refreshExpire, err := j.generateRefreshToken(userID, userName, nickname, role, loginType, sessionID)
 	if err != nil {
 		return "", "", time.Time{}, time.Time{}, err
 	}
@@ -82,7 +89,7 @@ func (j *JWTAuth) GenerateToken(userID string, userName string, nickname string,
 }
 
 // generateAccessToken 生成访问令牌
-func (j *JWTAuth) generateAccessToken(userID string, userName string, nickname string, role string, loginType string) (string, time.Time, error) {
+func (j *JWTAuth) generateAccessToken(userID string, userName string, nickname string, role string, loginType string, sessionID string) (string, time.Time, error) {
 	expirationTime := time.Now().Add(j.accessTokenExpire)
 
 	claims := &Claims{
@@ -91,6 +98,7 @@ func (j *JWTAuth) generateAccessToken(userID string, userName string, nickname s
 		Nickname:  nickname,
 		Role:      role,
 		LoginType: loginType,
+		SessionID: sessionID,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(expirationTime),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
@@ -109,7 +117,7 @@ func (j *JWTAuth) generateAccessToken(userID string, userName string, nickname s
 }
 
 // generateRefreshToken 生成刷新令牌
-func (j *JWTAuth) generateRefreshToken(userID string, userName string, nickname string, role string, loginType string) (string, time.Time, error) {
+func (j *JWTAuth) generateRefreshToken(userID string, userName string, nickname string, role string, loginType string, sessionID string) (string, time.Time, error) {
 	expirationTime := time.Now().Add(j.refreshTokenExpire)
 
 	claims := &Claims{
@@ -118,6 +126,7 @@ func (j *JWTAuth) generateRefreshToken(userID string, userName string, nickname
 		Nickname:  nickname,
 		Role:      role,
 		LoginType: loginType,
+		SessionID: sessionID,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(expirationTime),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
@@ -169,7 +178,7 @@ func (j *JWTAuth) RefreshToken(refreshToken string) (string, string, time.Time,
 	// 检查令牌是否接近过期
 	if time.Until(claims.ExpiresAt.Time) > j.accessTokenExpire {
 		// 令牌还有很长时间才会过期，只生成新的访问令牌
-		accessToken, accessExpire, err := j.generateAccessToken(claims.UserID, claims.Username, claims.Nickname, claims.Role, claims.LoginType)
+		accessToken, accessExpire, err := j.generateAccessToken(claims.UserID, claims.Username, claims.Nickname, claims.Role, claims.LoginType, claims.SessionID)
 		if err != nil {
 			return "", "", time.Time{}, time.Time{}, err
 		}
@@ -177,7 +186,7 @@ func (j *JWTAuth) RefreshToken(refreshToken string) (string, string, time.Time,
 	}
 
 	// 令牌即将过期，生成新的访问令牌和刷新令牌
-	return j.GenerateToken(claims.UserID, claims.Username, claims.Nickname, claims.Role, claims.LoginType)
+	return j.GenerateToken(claims.UserID, claims.Username, claims.Nickname, claims.Role, claims.LoginType, claims.SessionID)
 }
 
 // GetContextWithUserID 将用户ID添加到上下文
@@ -4,16 +4,31 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strconv"
+	"sync"
 	"time"
 
 	"github.com/ix-pay/ixpay-pro/internal/infrastructure/observability/logger"
 	"github.com/ix-pay/ixpay-pro/internal/infrastructure/persistence/cache"
 )
 
+const (
+	// permissionsVersionKey 当前生效的权限缓存版本号，缓存键包含版本号，切换版本号使所有实例的旧缓存和路由模板索引失效
+	permissionsVersionKey = "permissions:version"
+	// permissionsSequenceKey 权限缓存版本序号，每次缓存权限时自增分配新版本号，写完新版本的数据后再切换当前版本号
+	permissionsSequenceKey = "permissions:sequence"
+	permissionsVersionTTL  = 30 * 24 * time.Hour // 版本号有效期，需远大于权限缓存有效期
+)
+
 // PermissionManager 权限管理器
+// 权限按 gin 的路由模板（如 /api/admin/user/:id）缓存，请求路径通过路由前缀树解析为模板后再查找
 type PermissionManager struct {
 	cache cache.Cache
 	log   logger.Logger
+
+	mu            sync.RWMutex
+	routes        *RouteTrie // 路由模板索引，为空或当前版本号变化时从缓存的权限列表加载
+	routesVersion int64      // 路由模板索引对应的缓存版本号，本实例和其他实例缓存权限都会切换版本号
 }
 
 // Permission 权限信息
@@ -34,7 +49,14 @@ func SetupPermissionManager(cache cache.Cache, log logger.Logger) *PermissionMan
 }
 
 // CachePermissions 缓存权限数据
+// 权限数据写入新版本的缓存键后再切换当前版本号，其他实例读到新版本号时重建路由模板索引，不会读到未写完的数据
 func (p *PermissionManager) CachePermissions(permissions []Permission) error {
+	version, err := p.cache.Incr(permissionsSequenceKey, permissionsVersionTTL)
+	if err != nil {
+		p.log.Error("分配权限缓存版本号失败", "error", err)
+		return err
+	}
+
 	// 缓存所有权限信息
 	permissionsData, err := json.Marshal(permissions)
 	if err != nil {
@@ -43,14 +65,14 @@ func (p *PermissionManager) CachePermissions(permissions []Permission) error {
 	}
 
 	// 缓存权限列表，设置过期时间为 24 小时
-	if err := p.cache.Set("permissions:list", string(permissionsData), 24*time.Hour); err != nil {
+	if err := p.cache.Set(listKey(version), string(permissionsData), 24*time.Hour); err != nil {
 		p.log.Error("缓存权限列表失败", "error", err)
 		return err
 	}
 
 	// 为每个路径和方法创建索引
 	for _, perm := range permissions {
-		key := fmt.Sprintf("permission:%s:%s", perm.Method, perm.Path)
+		key := permissionKey(version, perm.Method, perm.Path)
 		permData, err := json.Marshal(perm)
 		if err != nil {
 			p.log.Error("权限序列化失败", "error", err)
@@ -63,12 +85,20 @@ func (p *PermissionManager) CachePermissions(permissions []Permission) error {
 		}
 	}
 
+	if err := p.cache.Set(permissionsVersionKey, strconv.FormatInt(version, 10), permissionsVersionTTL); err != nil {
+		p.log.Error("切换权限缓存版本号失败", "error", err)
+		return err
+	}
+	p.buildRoutes(version, permissions)
 	return nil
 }
 
 // GetPermission 获取特定路径和方法的权限信息
+// path 可以是具体请求路径或 c.FullPath() 路由模板，具体路径会先解析为已登记的路由模板
 func (p *PermissionManager) GetPermission(method, path string) (*Permission, error) {
-	key := fmt.Sprintf("permission:%s:%s", method, path)
+	version := p.version()
+	path = p.resolveRoute(version, method, path)
+	key := permissionKey(version, method, path)
 
 	// 从缓存获取权限信息
 	permData, err := p.cache.Get(key)
@@ -93,6 +123,75 @@ func (p *PermissionManager) GetPermission(method, path string) (*Permission, err
 	return &permission, nil
 }
 
+// ResolveRoute 将请求路径解析为已登记的路由模板，未登记时原样返回
+func (p *PermissionManager) ResolveRoute(method, path string) string {
+	return p.resolveRoute(p.version(), method, path)
+}
+
+// resolveRoute 按指定缓存版本的路由模板索引解析请求路径
+func (p *PermissionManager) resolveRoute(version int64, method, path string) string {
+	if pattern, ok := p.routeIndex(version).Match(method, path); ok {
+		return pattern
+	}
+	return path
+}
+
+// routeIndex 返回路由模板索引，进程重启或其他实例刷新权限后首次使用时从缓存的权限列表重建
+// 缓存中还没有权限列表时返回空索引且不保存，等权限列表写入后再重建
+func (p *PermissionManager) routeIndex(version int64) *RouteTrie {
+	p.mu.RLock()
+	routes, routesVersion := p.routes, p.routesVersion
+	p.mu.RUnlock()
+	if routes != nil && routesVersion == version {
+		return routes
+	}
+
+	data, err := p.cache.Get(listKey(version))
+	if err != nil || data == "" {
+		return NewRouteTrie()
+	}
+	var permissions []Permission
+	if err := json.Unmarshal([]byte(data), &permissions); err != nil {
+		p.log.Error("权限列表反序列化失败", "error", err)
+		return NewRouteTrie()
+	}
+	return p.buildRoutes(version, permissions)
+}
+
+// buildRoutes 根据权限列表重建路由模板索引
+func (p *PermissionManager) buildRoutes(version int64, permissions []Permission) *RouteTrie {
+	routes := NewRouteTrie()
+	for _, perm := range permissions {
+		routes.Insert(perm.Method, perm.Path)
+	}
+
+	p.mu.Lock()
+	p.routes = routes
+	p.routesVersion = version
+	p.mu.Unlock()
+	return routes
+}
+
+// version 获取当前权限缓存版本号，未设置时为 0
+func (p *PermissionManager) version() int64 {
+	data, err := p.cache.Get(permissionsVersionKey)
+	if err != nil || data == "" {
+		return 0
+	}
+	version, _ := strconv.ParseInt(data, 10, 64)
+	return version
+}
+
+// listKey 权限列表的缓存键
+func listKey(version int64) string {
+	return fmt.Sprintf("permissions:v%d:list", version)
+}
+
+// permissionKey 单个路由权限的缓存键
+func permissionKey(version int64, method, path string) string {
+	return fmt.Sprintf("permission:v%d:%s:%s", version, method, path)
+}
+
 // CheckPermission 检查用户是否有权限访问指定路径
 func (p *PermissionManager) CheckPermission(ctx context.Context, method, path string) bool {
 	// 从上下文中获取角色信息
@@ -239,25 +338,15 @@ func (p *PermissionManager) CheckAPIPermissionWithButton(ctx context.Context, me
 }
 
 // RefreshPermissions 刷新权限缓存
+// 缓存接口不支持 SCAN 操作，无法逐个删除单个路由的权限键，旧版本的键到期后自动删除，这里只删除旧版本的权限列表
 func (p *PermissionManager) RefreshPermissions(permissions []Permission) error {
-	// 先清除所有权限缓存
-	if err := p.clearPermissionCache(); err != nil {
-		p.log.Error("清除权限缓存失败", "error", err)
+	previous := p.version()
+	if err := p.CachePermissions(permissions); err != nil {
+		return err
 	}
 
-	// 重新缓存权限
-	return p.CachePermissions(permissions)
-}
-
-// clearPermissionCache 清除权限缓存
-func (p *PermissionManager) clearPermissionCache() error {
-	// 删除权限列表
-	if err := p.cache.Delete("permissions:list"); err != nil {
+	if err := p.cache.Delete(listKey(previous)); err != nil {
 		p.log.Error("删除权限列表失败", "error", err)
 	}
-
-	// 由于缓存接口不支持 SCAN 操作，我们只清除已知的权限键
-	// 如果需要清除所有权限键，需要在调用此方法前收集所有需要清除的键
-	// 这里简化处理，只清除权限列表，单个权限键会在下次访问时自动更新
 	return nil
 }
@@ -0,0 +1,141 @@
+package auth
+
+import "strings"
+
+// RouteTrie 路由模板前缀树
+// 按 HTTP 方法分树、按路径段建立索引，支持 gin 风格的静态段、:param 参数段和末尾的 *wildcard 通配段
+// 匹配优先级与 gin 一致：静态段 > 参数段 > 通配段，查找耗时只与路径段数有关，与路由数量无关
+type RouteTrie struct {
+	roots map[string]*routeNode
+	size  int
+}
+
+// routeNode 前缀树节点
+type routeNode struct {
+	static   map[string]*routeNode // 静态子段
+	param    *routeNode            // :param 子段
+	wildcard string                // 末尾 *wildcard 对应的路由模板
+	pattern  string                // 在该节点结束的路由模板
+}
+
+// NewRouteTrie 创建路由模板前缀树
+func NewRouteTrie() *RouteTrie {
+	return &RouteTrie{roots: make(map[string]*routeNode)}
+}
+
+// Insert 添加路由模板，method 为空时按 ANY 处理
+func (t *RouteTrie) Insert(method, pattern string) {
+	method = normalizeRouteMethod(method)
+	node, ok := t.roots[method]
+	if !ok {
+		node = &routeNode{}
+		t.roots[method] = node
+	}
+
+	for _, segment := range splitRoutePath(pattern) {
+		switch {
+		case strings.HasPrefix(segment, "*"):
+			// 通配段只能出现在末尾，其后的段忽略
+			if node.wildcard == "" {
+				t.size++
+			}
+			node.wildcard = pattern
+			return
+		case strings.HasPrefix(segment, ":"):
+			if node.param == nil {
+				node.param = &routeNode{}
+			}
+			node = node.param
+		default:
+			if node.static == nil {
+				node.static = make(map[string]*routeNode)
+			}
+			child, ok := node.static[segment]
+			if !ok {
+				child = &routeNode{}
+				node.static[segment] = child
+			}
+			node = child
+		}
+	}
+
+	if node.pattern == "" {
+		t.size++
+	}
+	node.pattern = pattern
+}
+
+// Match 查找与请求路径匹配的路由模板
+// path 可以是具体路径（/api/admin/user/123），也可以是 gin 的 c.FullPath()（/api/admin/user/:id）
+// 先按请求方法查找，未命中时再查找 ANY 方法的路由
+func (t *RouteTrie) Match(method, path string) (string, bool) {
+	segments := splitRoutePath(path)
+	method = normalizeRouteMethod(method)
+	if root, ok := t.roots[method]; ok {
+		if pattern, ok := root.match(segments); ok {
+			return pattern, true
+		}
+	}
+	if method != routeMethodAny {
+		if root, ok := t.roots[routeMethodAny]; ok {
+			return root.match(segments)
+		}
+	}
+	return "", false
+}
+
+// Len 返回路由模板数量
+func (t *RouteTrie) Len() int {
+	return t.size
+}
+
+// match 按优先级递归匹配剩余路径段，静态段匹配失败时回溯尝试参数段和通配段
+func (n *routeNode) match(segments []string) (string, bool) {
+	if len(segments) == 0 {
+		if n.pattern != "" {
+			return n.pattern, true
+		}
+		// 通配段可以匹配空路径，与 gin 的 /static/*filepath 匹配 /static/ 一致
+		if n.wildcard != "" {
+			return n.wildcard, true
+		}
+		return "", false
+	}
+
+	segment := segments[0]
+	if child, ok := n.static[segment]; ok {
+		if pattern, ok := child.match(segments[1:]); ok {
+			return pattern, true
+		}
+	}
+	if n.param != nil {
+		if pattern, ok := n.param.match(segments[1:]); ok {
+			return pattern, true
+		}
+	}
+	if n.wildcard != "" {
+		return n.wildcard, true
+	}
+	return "", false
+}
+
+// routeMethodAny 匹配任意请求方法的路由
+const routeMethodAny = "ANY"
+
+// normalizeRouteMethod 规范化请求方法，空值和 * 按 ANY 处理
+func normalizeRouteMethod(method string) string {
+	method = strings.ToUpper(strings.TrimSpace(method))
+	if method == "" || method == "*" {
+		return routeMethodAny
+	}
+	return method
+}
+
+// splitRoutePath 将路径拆分为路径段，忽略首尾的 /
+func splitRoutePath(path string) []string {
+	path = strings.Trim(path, "/")
+	if path == "" {
+		return nil
+	}
+	return strings.Split(path, "/")
+}
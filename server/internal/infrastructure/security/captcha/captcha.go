@@ -1,125 +1,334 @@
 package captcha
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/ix-pay/ixpay-pro/internal/config"
 	"github.com/ix-pay/ixpay-pro/internal/infrastructure/persistence/cache"
-	"github.com/ix-pay/ixpay-pro/internal/infrastructure/persistence/redis"
-	"github.com/mojocn/base64Captcha"
 )
 
 // captcha包提供验证码生成和验证功能
-// 使用base64Captcha库生成验证码图片，并通过Redis存储验证码信息
-// 支持配置验证码长度、过期时间和是否开启验证码功能
+// 支持数字、算术、字母数字、语音和滑块拼图多种验证码类型，按场景配置是否需要验证码以及使用的类型
+// 验证码答案保存在Redis中，校验通过后立即作废，校验失败次数超过上限后作废
+
+// 验证码场景
+const (
+	ScenarioLogin         = "login"          // 登录
+	ScenarioRegister      = "register"       // 注册
+	ScenarioPasswordReset = "password_reset" // 找回密码
+)
+
+// 场景验证码策略
+const (
+	ModeAlways        = "always"         // 始终需要
+	ModeAfterFailures = "after_failures" // 失败次数达到阈值后需要
+	ModeOff           = "off"            // 不需要
+)
+
+// 验证码相关缓存键
+const (
+	captchaItemKey    = "captcha:item:%s"       // 验证码记录
+	captchaAttemptKey = "captcha:attempts:%s"   // 验证码校验次数
+	captchaUsedKey    = "captcha:used:%s"       // 已使用标记，保证并发提交时只有一次校验通过
+	captchaFailureKey = "captcha:fail:%s:%s:%s" // 场景失败次数，按账号和 IP 分别统计
+)
+
+// 验证码默认配置（配置项为零值时使用）
+const (
+	defaultCaptchaType      = TypeDigit
+	defaultCaptchaLen       = 4
+	defaultCaptchaExpiry    = 60
+	defaultMaxAttempts      = 3
+	defaultFailureThreshold = 3
+	defaultFailureWindow    = 900
+)
+
+// defaultScenarios 未配置时的场景策略：注册和找回密码始终需要，登录失败后需要
+var defaultScenarios = map[string]config.CaptchaScenarioConfig{
+	ScenarioLogin:         {Mode: ModeAfterFailures},
+	ScenarioRegister:      {Mode: ModeAlways},
+	ScenarioPasswordReset: {Mode: ModeAlways},
+}
+
+// 验证码校验错误
+var (
+	ErrCaptchaRequired = errors.New("请输入验证码")
+	ErrCaptchaInvalid  = errors.New("验证码错误")
+	ErrCaptchaExpired  = errors.New("验证码已过期或无效")
+	ErrCaptchaTooMany  = errors.New("验证码错误次数过多，请重新获取")
+)
+
+// IsCaptchaError 判断是否为验证码校验错误
+func IsCaptchaError(err error) bool {
+	return errors.Is(err, ErrCaptchaRequired) || errors.Is(err, ErrCaptchaInvalid) ||
+		errors.Is(err, ErrCaptchaExpired) || errors.Is(err, ErrCaptchaTooMany)
+}
+
+// record 缓存中的验证码记录
+type record struct {
+	Type     string `json:"type"`
+	Answer   string `json:"answer"`
+	Scenario string `json:"scenario"`
+}
 
 // SetupCaptcha 初始化验证码服务
-// 根据配置创建验证码实例，设置验证码长度、过期时间和开关状态
+// 根据配置创建验证码实例，设置验证码长度、过期时间、开关状态和各场景策略
 // 参数:
 // - cfg: 应用配置对象，包含验证码相关配置
-// - redis: Redis客户端，用于存储验证码
+// - cache: 缓存服务，用于存储验证码和失败次数
 // 返回:
 // - Captcha指针: 验证码服务实例
 // - error: 错误信息
-func SetupCaptcha(cfg *config.Config, redis *redis.RedisClient) (*Captcha, error) {
+func SetupCaptcha(cfg *config.Config, cache cache.Cache) (*Captcha, error) {
+	return NewCaptcha(cfg, cache)
+}
+
+// NewCaptcha 创建验证码实例
+func NewCaptcha(cfg *config.Config, cache cache.Cache) (*Captcha, error) {
 	// 获取验证码长度，默认4位
-	len := cfg.Server.CaptchaLen
-	if len <= 0 {
-		len = 4
+	length := cfg.Server.CaptchaLen
+	if length <= 0 {
+		length = defaultCaptchaLen
 	}
 
 	// 获取验证码过期时间，默认60秒
 	expiry := cfg.Server.CaptchaTimeOut
 	if expiry <= 0 {
-		expiry = 60
+		expiry = defaultCaptchaExpiry
 	}
-	// 获取验证码开关状态
-	open := cfg.Server.OpenCaptcha
 
-	return new(len, expiry, open, redis)
-}
+	captchaCfg := cfg.Captcha
+	if captchaCfg.Driver == "" {
+		captchaCfg.Driver = defaultCaptchaType
+	}
+	if captchaCfg.MaxAttempts <= 0 {
+		captchaCfg.MaxAttempts = defaultMaxAttempts
+	}
 
-// new 创建验证码实例（内部函数）
-// 配置验证码驱动、存储和相关参数
-// 参数:
-// - len: 验证码长度
-// - expiry: 验证码过期时间（秒）
-// - open: 是否开启验证码功能
-// - redis: Redis客户端，用于存储验证码
-// 返回:
-// - Captcha指针: 验证码服务实例
-// - error: 错误信息
-func new(len int, expiry int, open bool, redis *redis.RedisClient) (*Captcha, error) {
-	// 创建数字验证码驱动配置
-	driver := base64Captcha.NewDriverDigit(
-		80,  // 验证码高度
-		240, // 验证码宽度
-		len, 0.7, 80)
-
-	// 创建独立的验证码存储，使用独立的前缀，不影响全局 Redis 配置
-	// 前缀格式："ixpay-pro:captcha:"，确保与其他业务隔离
-	cacheStore := cache.NewRedisCache(
-		redis,
-		redis.GetContext(),
-		"ixpay-pro:captcha:",
-		time.Duration(expiry)*time.Second,
-	)
-	// 使用适配器使其兼容 base64Captcha.Store 接口
-	store := cache.NewCaptchaStoreAdapter(cacheStore)
-
-	// 使用驱动和存储创建验证码实例
-	captcha := base64Captcha.NewCaptcha(driver, store)
-
-	// 创建并返回Captcha结构体实例
-	return &Captcha{
-			captcha:       captcha,
-			captchaLen:    len,
-			captchaExpiry: expiry,
-			openCaptcha:   open,
-		},
-		nil
+	c := &Captcha{
+		cache:         cache,
+		drivers:       make(map[string]Driver),
+		scenarios:     make(map[string]config.CaptchaScenarioConfig),
+		captchaLen:    length,
+		captchaExpiry: expiry,
+		openCaptcha:   cfg.Server.OpenCaptcha,
+		maxAttempts:   captchaCfg.MaxAttempts,
+		defaultDriver: captchaCfg.Driver,
+	}
+
+	// 合并默认场景策略和配置
+	for name, scenario := range defaultScenarios {
+		c.scenarios[name] = scenario
+	}
+	for name, scenario := range captchaCfg.Scenarios {
+		c.scenarios[name] = scenario
+	}
+
+	// 创建默认类型和各场景使用的驱动，配置错误时启动失败
+	driverTypes := []string{captchaCfg.Driver}
+	for name, scenario := range c.scenarios {
+		switch scenario.Mode {
+		case "", ModeAlways, ModeAfterFailures, ModeOff:
+		default:
+			return nil, fmt.Errorf("验证码场景 %s 的策略无效：%s", name, scenario.Mode)
+		}
+		if scenario.FailureThreshold <= 0 {
+			scenario.FailureThreshold = defaultFailureThreshold
+		}
+		if scenario.FailureWindow <= 0 {
+			scenario.FailureWindow = defaultFailureWindow
+		}
+		c.scenarios[name] = scenario
+		if scenario.Driver != "" {
+			driverTypes = append(driverTypes, scenario.Driver)
+		}
+	}
+	for _, driverType := range driverTypes {
+		if _, ok := c.drivers[driverType]; ok {
+			continue
+		}
+		driver, err := newDriver(driverType, length, captchaCfg.SliderTolerance)
+		if err != nil {
+			return nil, err
+		}
+		c.drivers[driverType] = driver
+	}
+
+	return c, nil
 }
 
 // Captcha 验证码服务结构体
-// 封装验证码生成和验证功能，包含验证码相关配置
+// 封装验证码生成、验证和场景策略，包含验证码相关配置
 // 字段:
-// - captcha: base64Captcha库的验证码实例
+// - cache: 缓存服务，保存验证码答案、校验次数和场景失败次数
+// - drivers: 按类型索引的验证码驱动
+// - scenarios: 各场景验证码策略
 // - captchaLen: 验证码长度
 // - captchaExpiry: 验证码过期时间（秒）
-// - openCaptcha: 验证码功能开关状态
+// - openCaptcha: 验证码总开关
+// - maxAttempts: 单个验证码最大校验次数
+// - defaultDriver: 场景未指定类型时使用的验证码类型
 type Captcha struct {
-	captcha       *base64Captcha.Captcha // base64Captcha验证码实例
-	captchaLen    int                    // 验证码长度
-	captchaExpiry int                    // 验证码过期时间（秒）
-	openCaptcha   bool                   // 验证码功能开关状态
+	cache         cache.Cache
+	drivers       map[string]Driver
+	scenarios     map[string]config.CaptchaScenarioConfig
+	captchaLen    int
+	captchaExpiry int
+	openCaptcha   bool
+	maxAttempts   int
+	defaultDriver string
+}
+
+// scenario 获取场景策略，未知场景始终需要验证码
+func (c *Captcha) scenario(name string) config.CaptchaScenarioConfig {
+	if s, ok := c.scenarios[name]; ok {
+		if s.Mode == "" {
+			s.Mode = ModeAlways
+		}
+		return s
+	}
+	return config.CaptchaScenarioConfig{Mode: ModeAlways}
 }
 
-// NewCaptcha 使用第三方库生成验证码
-// 入参: len - 验证码长度
-// 返回: 验证码文本, base64编码的图片, 错误信息
-func (c *Captcha) NewCaptcha() (string, string, error) {
+// driverFor 获取场景使用的验证码驱动
+func (c *Captcha) driverFor(scenario string) Driver {
+	if driverType := c.scenario(scenario).Driver; driverType != "" {
+		return c.drivers[driverType]
+	}
+	return c.drivers[c.defaultDriver]
+}
 
-	// 生成验证码
-	id, b64s, _, err := c.captcha.Generate()
+// Required 判断场景当前是否需要验证码
+// after_failures 模式下账号或 IP 任一失败次数达到阈值即需要
+// 未配置验证码服务或总开关关闭时不需要
+func (c *Captcha) Required(scenario, account, ip string) bool {
+	if c == nil || !c.openCaptcha {
+		return false
+	}
+	s := c.scenario(scenario)
+	switch s.Mode {
+	case ModeOff:
+		return false
+	case ModeAfterFailures:
+		return c.failureCount(scenario, "account", account) >= int64(s.FailureThreshold) ||
+			c.failureCount(scenario, "ip", ip) >= int64(s.FailureThreshold)
+	default:
+		return true
+	}
+}
+
+// Generate 按场景配置的类型生成验证码
+// 入参: scenario - 验证码场景
+// 返回: 验证码题目, 错误信息
+func (c *Captcha) Generate(scenario string) (*Challenge, error) {
+	driver := c.driverFor(scenario)
+	challenge, answer, err := driver.Generate()
 	if err != nil {
-		return "", "", err
+		return nil, err
 	}
 
-	// 库已经返回了完整的base64图片数据，直接返回
-	return id, b64s, nil
+	data, _ := json.Marshal(&record{Type: driver.Type(), Answer: answer, Scenario: scenario})
+	if err := c.cache.Set(fmt.Sprintf(captchaItemKey, challenge.ID), string(data), c.expiry()); err != nil {
+		return nil, err
+	}
+	return challenge, nil
 }
 
-// VerifyCaptcha 校验验证码是否正确
-// 入参: captchaId - 验证码ID, captcha - 用户输入的验证码
-// 返回: 校验结果, 错误信息
-func (c *Captcha) VerifyCaptcha(captchaId string, captcha string) (bool, error) {
-	// 检查参数
-	if captchaId == "" || captcha == "" {
-		return false, errors.New("验证码ID或验证码为空")
+// Verify 校验场景验证码
+// 场景当前不需要验证码时直接通过；验证码只能用于生成时的场景，校验通过后作废，失败次数超过上限后作废
+// 入参: scenario - 验证码场景, account - 账号, ip - 客户端IP, captchaId - 验证码ID, input - 用户输入的验证码
+// 返回: 错误信息，校验通过时为 nil
+func (c *Captcha) Verify(scenario, account, ip, captchaId, input string) error {
+	if !c.Required(scenario, account, ip) {
+		return nil
+	}
+	if captchaId == "" || input == "" {
+		return ErrCaptchaRequired
+	}
+
+	itemKey := fmt.Sprintf(captchaItemKey, captchaId)
+	raw, err := c.cache.Get(itemKey)
+	if err != nil || raw == "" {
+		return ErrCaptchaExpired
+	}
+	var item record
+	if err := json.Unmarshal([]byte(raw), &item); err != nil || item.Scenario != scenario {
+		return ErrCaptchaExpired
 	}
+	driver, ok := c.drivers[item.Type]
+	if !ok {
+		_ = c.cache.Delete(itemKey)
+		return ErrCaptchaExpired
+	}
+
+	attemptKey := fmt.Sprintf(captchaAttemptKey, captchaId)
+	attempts, err := c.cache.Incr(attemptKey, c.expiry())
+	if err != nil {
+		return err
+	}
+	if attempts > int64(c.maxAttempts) {
+		_ = c.cache.Delete(itemKey)
+		return ErrCaptchaTooMany
+	}
+
+	if !driver.Match(item.Answer, input) {
+		if attempts >= int64(c.maxAttempts) {
+			_ = c.cache.Delete(itemKey)
+		}
+		return ErrCaptchaInvalid
+	}
+
+	// 验证码一次性使用，并发提交时只有一次校验通过
+	if ok, err := c.cache.SetNX(fmt.Sprintf(captchaUsedKey, captchaId), "1", c.expiry()); err != nil || !ok {
+		return ErrCaptchaExpired
+	}
+	_ = c.cache.Delete(itemKey)
+	_ = c.cache.Delete(attemptKey)
+	return nil
+}
+
+// RecordFailure 记录场景失败（如登录密码错误），用于 after_failures 策略
+func (c *Captcha) RecordFailure(scenario, account, ip string) {
+	if c == nil {
+		return
+	}
+	window := time.Duration(c.scenario(scenario).FailureWindow) * time.Second
+	if account != "" {
+		_, _ = c.cache.Incr(fmt.Sprintf(captchaFailureKey, scenario, "account", account), window)
+	}
+	if ip != "" {
+		_, _ = c.cache.Incr(fmt.Sprintf(captchaFailureKey, scenario, "ip", ip), window)
+	}
+}
+
+// ResetFailures 场景成功后清除账号的失败次数，IP 的失败次数保留到窗口结束
+func (c *Captcha) ResetFailures(scenario, account string) {
+	if c == nil || account == "" {
+		return
+	}
+	_ = c.cache.Delete(fmt.Sprintf(captchaFailureKey, scenario, "account", account))
+}
+
+// failureCount 获取场景失败次数
+func (c *Captcha) failureCount(scenario, kind, value string) int64 {
+	if value == "" {
+		return 0
+	}
+	raw, err := c.cache.Get(fmt.Sprintf(captchaFailureKey, scenario, kind, value))
+	if err != nil {
+		return 0
+	}
+	var count int64
+	_, _ = fmt.Sscan(raw, &count)
+	return count
+}
 
-	return c.captcha.Verify(captchaId, captcha, true), nil
+// expiry 验证码有效期
+func (c *Captcha) expiry() time.Duration {
+	return time.Duration(c.captchaExpiry) * time.Second
 }
 
 // GetCaptchaLen 获取验证码长度配置
@@ -0,0 +1,112 @@
+package captcha
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mojocn/base64Captcha"
+)
+
+// 验证码类型
+const (
+	TypeDigit  = "digit"  // 数字图片
+	TypeMath   = "math"   // 算术图片
+	TypeString = "string" // 字母数字图片
+	TypeAudio  = "audio"  // 数字语音
+	TypeSlider = "slider" // 滑块拼图
+)
+
+// Challenge 下发给客户端的验证码题目，答案只保存在服务端
+type Challenge struct {
+	ID     string // 验证码 ID
+	Type   string // 验证码类型
+	Data   string // base64 编码的图片或语音，滑块验证码为背景图
+	Length int    // 答案长度，滑块验证码为 0
+
+	// 滑块验证码专用
+	PieceImage string // base64 编码的拼图块
+	PieceY     int    // 拼图块在背景图中的纵坐标
+	Width      int    // 背景图宽度
+	Height     int    // 背景图高度
+}
+
+// Driver 验证码驱动
+type Driver interface {
+	// Type 验证码类型
+	Type() string
+	// Generate 生成题目，返回下发给客户端的题目和服务端保存的答案
+	Generate() (*Challenge, string, error)
+	// Match 校验用户输入是否与答案一致
+	Match(answer, input string) bool
+}
+
+// newDriver 根据类型创建验证码驱动
+func newDriver(driverType string, length int, sliderTolerance int) (Driver, error) {
+	switch driverType {
+	case TypeDigit:
+		return &imageDriver{
+			driverType: TypeDigit,
+			driver:     base64Captcha.NewDriverDigit(80, 240, length, 0.7, 80),
+			length:     length,
+		}, nil
+	case TypeMath:
+		return &imageDriver{
+			driverType: TypeMath,
+			driver:     base64Captcha.NewDriverMath(80, 240, 0, base64Captcha.OptionShowSlimeLine, nil, nil, nil),
+		}, nil
+	case TypeString:
+		return &imageDriver{
+			driverType:      TypeString,
+			driver:          base64Captcha.NewDriverString(80, 240, 0, base64Captcha.OptionShowSlimeLine, length, base64Captcha.TxtNumbers+base64Captcha.TxtAlphabet, nil, nil, nil),
+			length:          length,
+			caseInsensitive: true,
+		}, nil
+	case TypeAudio:
+		return &imageDriver{
+			driverType: TypeAudio,
+			driver:     base64Captcha.NewDriverAudio(length, "zh"),
+			length:     length,
+		}, nil
+	case TypeSlider:
+		return newSliderDriver(sliderTolerance), nil
+	default:
+		return nil, fmt.Errorf("不支持的验证码类型：%s", driverType)
+	}
+}
+
+// imageDriver 基于 base64Captcha 的图片和语音验证码驱动
+type imageDriver struct {
+	driverType      string
+	driver          base64Captcha.Driver
+	length          int
+	caseInsensitive bool // 字母数字验证码不区分大小写
+}
+
+// Type 验证码类型
+func (d *imageDriver) Type() string {
+	return d.driverType
+}
+
+// Generate 生成题目
+func (d *imageDriver) Generate() (*Challenge, string, error) {
+	id, question, answer := d.driver.GenerateIdQuestionAnswer()
+	item, err := d.driver.DrawCaptcha(question)
+	if err != nil {
+		return nil, "", err
+	}
+	return &Challenge{
+		ID:     id,
+		Type:   d.driverType,
+		Data:   item.EncodeB64string(),
+		Length: d.length,
+	}, answer, nil
+}
+
+// Match 校验用户输入
+func (d *imageDriver) Match(answer, input string) bool {
+	input = strings.TrimSpace(input)
+	if d.caseInsensitive {
+		return strings.EqualFold(answer, input)
+	}
+	return answer == input
+}
@@ -0,0 +1,188 @@
+package captcha
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"image"
+	"image/color"
+	"image/png"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/mojocn/base64Captcha"
+)
+
+// 滑块验证码尺寸（像素）
+const (
+	sliderWidth     = 300
+	sliderHeight    = 150
+	sliderPieceSize = 50
+	sliderMargin    = 10
+	// defaultSliderTolerance 未配置时滑块允许的偏移误差
+	defaultSliderTolerance = 5
+)
+
+// sliderDriver 滑块拼图验证码驱动
+// 背景图上随机挖出一块，客户端拖动拼图块到缺口位置后提交横坐标，服务端按允许误差比对
+type sliderDriver struct {
+	tolerance int
+}
+
+// newSliderDriver 创建滑块验证码驱动
+func newSliderDriver(tolerance int) *sliderDriver {
+	if tolerance <= 0 {
+		tolerance = defaultSliderTolerance
+	}
+	return &sliderDriver{tolerance: tolerance}
+}
+
+// Type 验证码类型
+func (d *sliderDriver) Type() string {
+	return TypeSlider
+}
+
+// Generate 生成背景图和拼图块，答案为缺口的横坐标
+func (d *sliderDriver) Generate() (*Challenge, string, error) {
+	// 缺口不与拼图块初始位置（最左侧）重叠
+	x, err := randomInt(sliderPieceSize+sliderMargin, sliderWidth-sliderPieceSize-sliderMargin)
+	if err != nil {
+		return nil, "", err
+	}
+	y, err := randomInt(sliderMargin, sliderHeight-sliderPieceSize-sliderMargin)
+	if err != nil {
+		return nil, "", err
+	}
+
+	background, err := randomBackground()
+	if err != nil {
+		return nil, "", err
+	}
+
+	// 拼图块取自缺口位置的原图，边缘描白
+	piece := image.NewRGBA(image.Rect(0, 0, sliderPieceSize, sliderPieceSize))
+	for py := 0; py < sliderPieceSize; py++ {
+		for px := 0; px < sliderPieceSize; px++ {
+			if isPieceEdge(px, py) {
+				piece.Set(px, py, color.White)
+				continue
+			}
+			piece.Set(px, py, background.At(x+px, y+py))
+		}
+	}
+
+	// 背景图缺口处变暗
+	for py := 0; py < sliderPieceSize; py++ {
+		for px := 0; px < sliderPieceSize; px++ {
+			if isPieceEdge(px, py) {
+				background.Set(x+px, y+py, color.RGBA{R: 255, G: 255, B: 255, A: 255})
+				continue
+			}
+			c := background.RGBAAt(x+px, y+py)
+			background.SetRGBA(x+px, y+py, color.RGBA{R: c.R / 3, G: c.G / 3, B: c.B / 3, A: 255})
+		}
+	}
+
+	backgroundData, err := encodePNG(background)
+	if err != nil {
+		return nil, "", err
+	}
+	pieceData, err := encodePNG(piece)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return &Challenge{
+		ID:         base64Captcha.RandomId(),
+		Type:       TypeSlider,
+		Data:       backgroundData,
+		PieceImage: pieceData,
+		PieceY:     y,
+		Width:      sliderWidth,
+		Height:     sliderHeight,
+	}, strconv.Itoa(x), nil
+}
+
+// Match 校验拼图块横坐标与缺口位置的误差
+func (d *sliderDriver) Match(answer, input string) bool {
+	expected, err := strconv.Atoi(answer)
+	if err != nil {
+		return false
+	}
+	actual, err := strconv.ParseFloat(strings.TrimSpace(input), 64)
+	if err != nil || math.IsNaN(actual) {
+		return false
+	}
+	return math.Abs(actual-float64(expected)) <= float64(d.tolerance)
+}
+
+// isPieceEdge 判断是否为拼图块边缘
+func isPieceEdge(px, py int) bool {
+	return px < 2 || py < 2 || px >= sliderPieceSize-2 || py >= sliderPieceSize-2
+}
+
+// randomBackground 生成随机色块背景图，色块和噪点使缺口位置无法从纯色差直接识别
+func randomBackground() (*image.RGBA, error) {
+	img := image.NewRGBA(image.Rect(0, 0, sliderWidth, sliderHeight))
+	seed := make([]byte, 12)
+	if _, err := rand.Read(seed); err != nil {
+		return nil, err
+	}
+	noise := make([]byte, sliderWidth*sliderHeight)
+	if _, err := rand.Read(noise); err != nil {
+		return nil, err
+	}
+
+	for y := 0; y < sliderHeight; y++ {
+		for x := 0; x < sliderWidth; x++ {
+			// 两组颜色按横纵方向渐变，叠加正弦色带
+			fx := float64(x) / sliderWidth
+			fy := float64(y) / sliderHeight
+			band := (math.Sin(fx*float64(seed[9]%7+3)+fy*float64(seed[10]%5+2)) + 1) / 2
+			n := int(noise[y*sliderWidth+x]%32) - 16
+			img.SetRGBA(x, y, color.RGBA{
+				R: clampColor(mix(seed[0], seed[3], fx)*band + mix(seed[6], seed[0], fy)*(1-band) + float64(n)),
+				G: clampColor(mix(seed[1], seed[4], fy)*band + mix(seed[7], seed[1], fx)*(1-band) + float64(n)),
+				B: clampColor(mix(seed[2], seed[5], fx)*band + mix(seed[8], seed[2], fy)*(1-band) + float64(n)),
+				A: 255,
+			})
+		}
+	}
+	return img, nil
+}
+
+// mix 两个颜色分量线性插值
+func mix(a, b byte, t float64) float64 {
+	return float64(a)*(1-t) + float64(b)*t
+}
+
+// clampColor 将颜色分量限制在 0-255
+func clampColor(v float64) uint8 {
+	if v < 0 {
+		return 0
+	}
+	if v > 255 {
+		return 255
+	}
+	return uint8(v)
+}
+
+// encodePNG 编码为 base64 PNG 数据 URI
+func encodePNG(img image.Image) (string, error) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return "", err
+	}
+	return "data:image/png;base64," + base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+}
+
+// randomInt 生成 [min, max] 范围内的安全随机整数
+func randomInt(min, max int) (int, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(int64(max-min+1)))
+	if err != nil {
+		return 0, err
+	}
+	return min + int(n.Int64()), nil
+}
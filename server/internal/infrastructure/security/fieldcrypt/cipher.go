@@ -0,0 +1,273 @@
+package fieldcrypt
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ix-pay/ixpay-pro/internal/config"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/observability/logger"
+)
+
+// 密文格式：enc:v1:<主密钥 ID>:<被主密钥加密的数据密钥>:<被数据密钥加密的明文>，后两段为 base64 编码的 nonce+密文
+const (
+	ciphertextPrefix = "enc:v1:"
+	dataKeySize      = 32
+)
+
+// DefaultMasterKeyEnv 未配置时读取主密钥的环境变量名
+const DefaultMasterKeyEnv = "IXPAY_MASTER_KEYS"
+
+// masterKey 主密钥
+type masterKey struct {
+	id       string
+	aead     cipher.AEAD
+	indexKey []byte // 由主密钥派生的盲索引密钥
+}
+
+// Cipher 字段信封加密器
+// 每次加密生成随机数据密钥，使用 AES-256-GCM 加密明文，数据密钥再由主密钥加密后与密文一起保存
+// 第一个主密钥用于加密和计算盲索引，其余主密钥只用于解密和查询，待重新加密任务完成后即可移除
+// 未配置主密钥时不加密，明文原样保存
+type Cipher struct {
+	keys []*masterKey
+	byID map[string]*masterKey
+}
+
+// SetupCipher 从配置的密钥文件或环境变量加载主密钥
+// 密钥格式为 "<密钥 ID>:<base64 编码的 32 字节密钥>"，多个密钥以换行或逗号分隔
+func SetupCipher(cfg *config.Config, log logger.Logger) (*Cipher, error) {
+	var raw string
+	if cfg.Encryption.MasterKeyFile != "" {
+		data, err := os.ReadFile(cfg.Encryption.MasterKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("读取主密钥文件失败：%w", err)
+		}
+		raw = string(data)
+	} else {
+		envName := cfg.Encryption.MasterKeyEnv
+		if envName == "" {
+			envName = DefaultMasterKeyEnv
+		}
+		raw = os.Getenv(envName)
+	}
+
+	keys, err := ParseKeyring(raw)
+	if err != nil {
+		return nil, err
+	}
+	if len(keys) == 0 {
+		log.Warn("未配置字段加密主密钥，敏感字段将以明文保存")
+		return NewCipher(nil)
+	}
+	return NewCipher(keys)
+}
+
+// Key 主密钥 ID 和密钥内容
+type Key struct {
+	ID       string
+	Material []byte
+}
+
+// ParseKeyring 解析主密钥列表
+func ParseKeyring(raw string) ([]Key, error) {
+	var keys []Key
+	for _, item := range strings.FieldsFunc(raw, func(r rune) bool { return r == '\n' || r == ',' }) {
+		item = strings.TrimSpace(item)
+		if item == "" || strings.HasPrefix(item, "#") {
+			continue
+		}
+		id, encoded, ok := strings.Cut(item, ":")
+		if !ok || id == "" {
+			return nil, errors.New("主密钥格式错误，应为 <密钥 ID>:<base64 编码的密钥>")
+		}
+		material, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+		if err != nil {
+			return nil, fmt.Errorf("主密钥 %s 不是有效的 base64 编码", id)
+		}
+		keys = append(keys, Key{ID: strings.TrimSpace(id), Material: material})
+	}
+	return keys, nil
+}
+
+// NewCipher 使用主密钥列表创建加密器，第一个为当前主密钥
+func NewCipher(keys []Key) (*Cipher, error) {
+	c := &Cipher{byID: make(map[string]*masterKey)}
+	for _, key := range keys {
+		if len(key.Material) != 32 {
+			return nil, fmt.Errorf("主密钥 %s 长度必须为 32 字节", key.ID)
+		}
+		if strings.Contains(key.ID, ":") {
+			return nil, fmt.Errorf("主密钥 ID %s 不能包含冒号", key.ID)
+		}
+		if _, exists := c.byID[key.ID]; exists {
+			return nil, fmt.Errorf("主密钥 ID %s 重复", key.ID)
+		}
+		aead, err := newAEAD(key.Material)
+		if err != nil {
+			return nil, err
+		}
+		mac := hmac.New(sha256.New, key.Material)
+		mac.Write([]byte("ixpay-blind-index"))
+		mk := &masterKey{id: key.ID, aead: aead, indexKey: mac.Sum(nil)}
+		c.keys = append(c.keys, mk)
+		c.byID[key.ID] = mk
+	}
+	return c, nil
+}
+
+// Enabled 是否配置了主密钥
+func (c *Cipher) Enabled() bool {
+	return c != nil && len(c.keys) > 0
+}
+
+// IsEncrypted 判断值是否为本加密器生成的密文
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(value, ciphertextPrefix)
+}
+
+// Encrypt 加密明文，未配置主密钥或明文为空时原样返回
+func (c *Cipher) Encrypt(plaintext string) (string, error) {
+	if !c.Enabled() || plaintext == "" || IsEncrypted(plaintext) {
+		return plaintext, nil
+	}
+	primary := c.keys[0]
+
+	dataKey := make([]byte, dataKeySize)
+	if _, err := rand.Read(dataKey); err != nil {
+		return "", err
+	}
+	dataAEAD, err := newAEAD(dataKey)
+	if err != nil {
+		return "", err
+	}
+
+	// 数据密钥以主密钥 ID 作为附加数据，防止密文被挪用到其他主密钥下
+	wrappedKey, err := seal(primary.aead, dataKey, []byte(primary.id))
+	if err != nil {
+		return "", err
+	}
+	sealed, err := seal(dataAEAD, []byte(plaintext), nil)
+	if err != nil {
+		return "", err
+	}
+
+	return ciphertextPrefix + primary.id + ":" +
+		base64.StdEncoding.EncodeToString(wrappedKey) + ":" +
+		base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt 解密密文，非密文格式的值视为历史明文原样返回
+func (c *Cipher) Decrypt(value string) (string, error) {
+	if !IsEncrypted(value) {
+		return value, nil
+	}
+
+	parts := strings.Split(strings.TrimPrefix(value, ciphertextPrefix), ":")
+	if len(parts) != 3 {
+		return "", errors.New("密文格式错误")
+	}
+	if c == nil {
+		return "", errors.New("未配置字段加密主密钥，无法解密")
+	}
+	key, ok := c.byID[parts[0]]
+	if !ok {
+		return "", fmt.Errorf("主密钥 %s 不存在，无法解密", parts[0])
+	}
+
+	wrappedKey, err := base64.StdEncoding.DecodeString(parts[1])
+	if err != nil {
+		return "", errors.New("密文格式错误")
+	}
+	sealed, err := base64.StdEncoding.DecodeString(parts[2])
+	if err != nil {
+		return "", errors.New("密文格式错误")
+	}
+
+	dataKey, err := open(key.aead, wrappedKey, []byte(key.id))
+	if err != nil {
+		return "", errors.New("数据密钥解密失败")
+	}
+	dataAEAD, err := newAEAD(dataKey)
+	if err != nil {
+		return "", err
+	}
+	plaintext, err := open(dataAEAD, sealed, nil)
+	if err != nil {
+		return "", errors.New("字段解密失败")
+	}
+	return string(plaintext), nil
+}
+
+// NeedsReEncryption 判断值是否需要重新加密：历史明文，或使用非当前主密钥加密
+func (c *Cipher) NeedsReEncryption(value string) bool {
+	if !c.Enabled() || value == "" {
+		return false
+	}
+	if !IsEncrypted(value) {
+		return true
+	}
+	return !strings.HasPrefix(value, ciphertextPrefix+c.keys[0].id+":")
+}
+
+// BlindIndex 使用当前主密钥计算盲索引，用于等值查询，未配置主密钥或值为空时返回空串
+func (c *Cipher) BlindIndex(value string) string {
+	if !c.Enabled() || value == "" {
+		return ""
+	}
+	return blindIndex(c.keys[0].indexKey, value)
+}
+
+// BlindIndexes 使用全部主密钥计算盲索引，主密钥轮换期间新旧盲索引都能命中
+func (c *Cipher) BlindIndexes(value string) []string {
+	if !c.Enabled() || value == "" {
+		return nil
+	}
+	indexes := make([]string, len(c.keys))
+	for i, key := range c.keys {
+		indexes[i] = blindIndex(key.indexKey, value)
+	}
+	return indexes
+}
+
+// blindIndex 计算 HMAC-SHA256 盲索引的十六进制编码
+func blindIndex(indexKey []byte, value string) string {
+	mac := hmac.New(sha256.New, indexKey)
+	mac.Write([]byte(value))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// newAEAD 创建 AES-256-GCM 实例
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// seal 加密并在密文前附加随机 nonce
+func seal(aead cipher.AEAD, plaintext, additionalData []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, plaintext, additionalData), nil
+}
+
+// open 拆分 nonce 并解密
+func open(aead cipher.AEAD, data, additionalData []byte) ([]byte, error) {
+	if len(data) < aead.NonceSize() {
+		return nil, errors.New("密文长度不足")
+	}
+	nonce, ciphertext := data[:aead.NonceSize()], data[aead.NonceSize():]
+	return aead.Open(nil, nonce, ciphertext, additionalData)
+}
@@ -0,0 +1,259 @@
+package ldap
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ix-pay/ixpay-pro/internal/config"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/observability/logger"
+)
+
+// ldap包提供 LDAP / Active Directory 认证功能
+// 基于标准库实现 LDAPv3 协议子集（简单绑定、搜索、StartTLS），不依赖第三方库
+// 认证流程：服务账号绑定 -> 按登录名搜索用户 DN -> 以用户 DN 和密码绑定 -> 查询用户所属组
+
+var (
+	// ErrUserNotFound LDAP 中不存在该用户
+	ErrUserNotFound = errors.New("LDAP 用户不存在")
+	// ErrInvalidCredentials 用户名或密码错误
+	ErrInvalidCredentials = errors.New("LDAP 用户名或密码错误")
+)
+
+// 默认配置（配置项为空时使用）
+const (
+	defaultUserFilter        = "(&(objectClass=person)(|(sAMAccountName={username})(uid={username})))"
+	defaultUsernameAttribute = "sAMAccountName"
+	defaultNicknameAttribute = "displayName"
+	defaultEmailAttribute    = "mail"
+	defaultPhoneAttribute    = "mobile"
+	memberOfAttribute        = "memberOf"
+)
+
+// Identity LDAP 认证通过后的用户身份信息
+type Identity struct {
+	DN       string   // 用户 DN
+	Username string   // 用户名
+	Nickname string   // 显示名称
+	Email    string   // 邮箱
+	Phone    string   // 手机号
+	Groups   []string // 所属组 DN 列表
+}
+
+// Authenticator LDAP 认证器
+type Authenticator struct {
+	cfg config.LDAPConfig
+	log logger.Logger
+}
+
+// SetupAuthenticator 初始化 LDAP 认证器
+func SetupAuthenticator(cfg *config.Config, log logger.Logger) *Authenticator {
+	return NewAuthenticator(cfg.LDAP, log)
+}
+
+// NewAuthenticator 根据 LDAP 配置创建认证器，并补充默认值
+func NewAuthenticator(cfg config.LDAPConfig, log logger.Logger) *Authenticator {
+	if cfg.UserFilter == "" {
+		cfg.UserFilter = defaultUserFilter
+	}
+	if cfg.UsernameAttribute == "" {
+		cfg.UsernameAttribute = defaultUsernameAttribute
+	}
+	if cfg.NicknameAttribute == "" {
+		cfg.NicknameAttribute = defaultNicknameAttribute
+	}
+	if cfg.EmailAttribute == "" {
+		cfg.EmailAttribute = defaultEmailAttribute
+	}
+	if cfg.PhoneAttribute == "" {
+		cfg.PhoneAttribute = defaultPhoneAttribute
+	}
+	return &Authenticator{cfg: cfg, log: log}
+}
+
+// Enabled 是否开启 LDAP 登录
+func (a *Authenticator) Enabled() bool {
+	return a != nil && a.cfg.Enabled && a.cfg.URL != ""
+}
+
+// FallbackToLocal LDAP 中不存在该用户或服务不可用时是否回退到本地账号
+func (a *Authenticator) FallbackToLocal() bool {
+	return a == nil || a.cfg.FallbackToLocal
+}
+
+// Authenticate 使用 LDAP 校验用户名和密码
+// 返回:
+// - *Identity: 用户身份信息
+// - error: ErrUserNotFound、ErrInvalidCredentials 或连接、协议错误
+func (a *Authenticator) Authenticate(username, password string) (*Identity, error) {
+	username = strings.TrimSpace(username)
+	// 空密码的简单绑定会被服务端视为匿名绑定而成功，必须拒绝
+	if username == "" || password == "" {
+		return nil, ErrInvalidCredentials
+	}
+
+	conn, err := a.connect()
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	if err := a.bindServiceAccount(conn); err != nil {
+		return nil, err
+	}
+
+	entry, err := a.findUser(conn, username)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := conn.Bind(entry.DN, password); err != nil {
+		if IsResultCode(err, ResultInvalidCredentials) {
+			return nil, ErrInvalidCredentials
+		}
+		return nil, fmt.Errorf("LDAP 用户绑定失败：%w", err)
+	}
+
+	identity := &Identity{
+		DN:       entry.DN,
+		Username: entry.GetAttributeValue(a.cfg.UsernameAttribute),
+		Nickname: entry.GetAttributeValue(a.cfg.NicknameAttribute),
+		Email:    entry.GetAttributeValue(a.cfg.EmailAttribute),
+		Phone:    entry.GetAttributeValue(a.cfg.PhoneAttribute),
+		Groups:   entry.GetAttributeValues(memberOfAttribute),
+	}
+	if identity.Username == "" {
+		identity.Username = username
+	}
+
+	groups, err := a.searchGroups(conn, identity)
+	if err != nil {
+		// 组查询失败不影响登录，仅使用 memberOf 属性
+		a.log.Warn("LDAP 组查询失败", "dn", identity.DN, "error", err)
+	}
+	identity.Groups = mergeGroups(identity.Groups, groups)
+
+	return identity, nil
+}
+
+// connect 建立连接，按配置启用 StartTLS
+func (a *Authenticator) connect() (*Conn, error) {
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: a.cfg.InsecureSkipVerify, // #nosec G402 -- 由配置显式开启，仅用于测试环境
+	}
+
+	conn, err := Dial(a.cfg.URL, time.Duration(a.cfg.Timeout)*time.Second, tlsConfig)
+	if err != nil {
+		return nil, err
+	}
+	if a.cfg.StartTLS && strings.HasPrefix(strings.ToLower(a.cfg.URL), "ldap://") {
+		if err := conn.StartTLS(tlsConfig); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+	return conn, nil
+}
+
+// bindServiceAccount 使用服务账号绑定，未配置时保持匿名
+func (a *Authenticator) bindServiceAccount(conn *Conn) error {
+	if a.cfg.BindDN == "" {
+		return nil
+	}
+	if err := conn.Bind(a.cfg.BindDN, a.cfg.BindPassword); err != nil {
+		return fmt.Errorf("LDAP 服务账号绑定失败：%w", err)
+	}
+	return nil
+}
+
+// findUser 按登录名搜索唯一用户条目
+func (a *Authenticator) findUser(conn *Conn, username string) (*Entry, error) {
+	entries, err := conn.Search(&SearchRequest{
+		BaseDN: a.cfg.BaseDN,
+		Scope:  ScopeWholeSubtree,
+		Filter: strings.ReplaceAll(a.cfg.UserFilter, "{username}", EscapeFilter(username)),
+		Attributes: []string{
+			a.cfg.UsernameAttribute,
+			a.cfg.NicknameAttribute,
+			a.cfg.EmailAttribute,
+			a.cfg.PhoneAttribute,
+			memberOfAttribute,
+		},
+		SizeLimit: 2,
+		TimeLimit: a.cfg.Timeout,
+	})
+	if err != nil && !IsResultCode(err, ResultSizeLimitExceeded) {
+		return nil, fmt.Errorf("LDAP 用户搜索失败：%w", err)
+	}
+
+	switch len(entries) {
+	case 0:
+		return nil, ErrUserNotFound
+	case 1:
+		return entries[0], nil
+	default:
+		return nil, fmt.Errorf("LDAP 中匹配到多个用户：%s", username)
+	}
+}
+
+// searchGroups 按组过滤器查询用户所属组
+// 以用户身份绑定后连接的权限可能不足，配置了服务账号时重新绑定服务账号
+func (a *Authenticator) searchGroups(conn *Conn, identity *Identity) ([]string, error) {
+	if a.cfg.GroupBaseDN == "" || a.cfg.GroupFilter == "" {
+		return nil, nil
+	}
+	if err := a.bindServiceAccount(conn); err != nil {
+		return nil, err
+	}
+
+	filter := strings.ReplaceAll(a.cfg.GroupFilter, "{dn}", EscapeFilter(identity.DN))
+	filter = strings.ReplaceAll(filter, "{username}", EscapeFilter(identity.Username))
+	entries, err := conn.Search(&SearchRequest{
+		BaseDN:     a.cfg.GroupBaseDN,
+		Scope:      ScopeWholeSubtree,
+		Filter:     filter,
+		Attributes: []string{"cn"},
+		TimeLimit:  a.cfg.Timeout,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		groups = append(groups, entry.DN)
+	}
+	return groups, nil
+}
+
+// mergeGroups 合并组 DN 列表并去重（不区分大小写）
+func mergeGroups(lists ...[]string) []string {
+	seen := make(map[string]bool)
+	var merged []string
+	for _, list := range lists {
+		for _, group := range list {
+			key := strings.ToLower(strings.TrimSpace(group))
+			if key == "" || seen[key] {
+				continue
+			}
+			seen[key] = true
+			merged = append(merged, group)
+		}
+	}
+	return merged
+}
+
+// GroupCN 返回组 DN 的第一个 RDN 值，例如 cn=ops,ou=groups,dc=example,dc=com 返回 ops
+func GroupCN(dn string) string {
+	first := dn
+	if i := strings.IndexByte(dn, ','); i >= 0 {
+		first = dn[:i]
+	}
+	if i := strings.IndexByte(first, '='); i >= 0 {
+		return strings.TrimSpace(first[i+1:])
+	}
+	return strings.TrimSpace(first)
+}
@@ -0,0 +1,245 @@
+package ldap
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ber.go 实现 LDAP 协议所需的 BER 编解码子集
+// 只支持单字节标签（tag < 31）和定长编码，足以覆盖 LDAPv3 的绑定、搜索和扩展操作
+
+// BER 标签类别
+const (
+	ClassUniversal   byte = 0x00
+	ClassApplication byte = 0x40
+	ClassContext     byte = 0x80
+)
+
+// BER 通用类型标签
+const (
+	TagBoolean     byte = 0x01
+	TagInteger     byte = 0x02
+	TagOctetString byte = 0x04
+	TagNull        byte = 0x05
+	TagEnumerated  byte = 0x0a
+	TagSequence    byte = 0x10
+	TagSet         byte = 0x11
+)
+
+const (
+	berConstructed  byte = 0x20
+	maxPacketLength      = 16 << 20 // 单个报文最大 16MB，防止异常长度耗尽内存
+)
+
+// Packet BER 编码的 TLV 节点
+type Packet struct {
+	Class       byte      // 标签类别
+	Constructed bool      // 是否为构造类型
+	Tag         byte      // 标签号
+	Data        []byte    // 原始类型的值
+	Children    []*Packet // 构造类型的子节点
+}
+
+// NewPacket 创建原始类型节点
+func NewPacket(class, tag byte, data []byte) *Packet {
+	return &Packet{Class: class, Tag: tag, Data: data}
+}
+
+// NewConstructed 创建构造类型节点
+func NewConstructed(class, tag byte, children ...*Packet) *Packet {
+	return &Packet{Class: class, Constructed: true, Tag: tag, Children: children}
+}
+
+// NewSequence 创建 SEQUENCE 节点
+func NewSequence(children ...*Packet) *Packet {
+	return NewConstructed(ClassUniversal, TagSequence, children...)
+}
+
+// NewOctetString 创建 OCTET STRING 节点
+func NewOctetString(s string) *Packet {
+	return NewPacket(ClassUniversal, TagOctetString, []byte(s))
+}
+
+// NewInteger 创建 INTEGER 节点
+func NewInteger(v int64) *Packet {
+	return NewPacket(ClassUniversal, TagInteger, encodeInt(v))
+}
+
+// NewEnumerated 创建 ENUMERATED 节点
+func NewEnumerated(v int64) *Packet {
+	return NewPacket(ClassUniversal, TagEnumerated, encodeInt(v))
+}
+
+// NewBoolean 创建 BOOLEAN 节点
+func NewBoolean(v bool) *Packet {
+	if v {
+		return NewPacket(ClassUniversal, TagBoolean, []byte{0xff})
+	}
+	return NewPacket(ClassUniversal, TagBoolean, []byte{0x00})
+}
+
+// Append 追加子节点
+func (p *Packet) Append(children ...*Packet) *Packet {
+	p.Children = append(p.Children, children...)
+	return p
+}
+
+// Is 判断节点类别和标签
+func (p *Packet) Is(class, tag byte) bool {
+	return p != nil && p.Class == class && p.Tag == tag
+}
+
+// Int 以整数解析节点值
+func (p *Packet) Int() (int64, error) {
+	if p == nil || p.Constructed || len(p.Data) == 0 || len(p.Data) > 8 {
+		return 0, errors.New("无效的 BER 整数")
+	}
+	v := int64(int8(p.Data[0]))
+	for _, b := range p.Data[1:] {
+		v = v<<8 | int64(b)
+	}
+	return v, nil
+}
+
+// String 以字符串返回节点值
+func (p *Packet) String() string {
+	if p == nil {
+		return ""
+	}
+	return string(p.Data)
+}
+
+// Bytes 编码节点
+func (p *Packet) Bytes() []byte {
+	content := p.Data
+	if p.Constructed {
+		content = nil
+		for _, child := range p.Children {
+			content = append(content, child.Bytes()...)
+		}
+	}
+
+	identifier := p.Class | p.Tag
+	if p.Constructed {
+		identifier |= berConstructed
+	}
+
+	out := make([]byte, 0, len(content)+6)
+	out = append(out, identifier)
+	out = append(out, encodeLength(len(content))...)
+	return append(out, content...)
+}
+
+// ReadPacket 从流中读取并解码一个完整的 BER 报文
+func ReadPacket(r io.Reader) (*Packet, error) {
+	header := make([]byte, 2)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, err
+	}
+
+	length := int(header[1])
+	raw := header
+	if header[1]&0x80 != 0 {
+		n := int(header[1] & 0x7f)
+		if n == 0 || n > 4 {
+			return nil, fmt.Errorf("不支持的 BER 长度编码：%d", n)
+		}
+		lengthBytes := make([]byte, n)
+		if _, err := io.ReadFull(r, lengthBytes); err != nil {
+			return nil, err
+		}
+		length = 0
+		for _, b := range lengthBytes {
+			length = length<<8 | int(b)
+		}
+		raw = append(raw, lengthBytes...)
+	}
+	if length > maxPacketLength {
+		return nil, fmt.Errorf("BER 报文过大：%d", length)
+	}
+
+	content := make([]byte, length)
+	if _, err := io.ReadFull(r, content); err != nil {
+		return nil, err
+	}
+
+	packet, _, err := DecodePacket(append(raw, content...))
+	return packet, err
+}
+
+// DecodePacket 解码字节切片开头的一个 BER 报文，返回报文和消耗的字节数
+func DecodePacket(data []byte) (*Packet, int, error) {
+	if len(data) < 2 {
+		return nil, 0, errors.New("BER 报文不完整")
+	}
+
+	identifier := data[0]
+	if identifier&0x1f == 0x1f {
+		return nil, 0, errors.New("不支持多字节 BER 标签")
+	}
+	p := &Packet{
+		Class:       identifier & 0xc0,
+		Constructed: identifier&berConstructed != 0,
+		Tag:         identifier & 0x1f,
+	}
+
+	offset := 2
+	length := int(data[1])
+	if data[1]&0x80 != 0 {
+		n := int(data[1] & 0x7f)
+		if n == 0 || n > 4 || len(data) < 2+n {
+			return nil, 0, errors.New("无效的 BER 长度")
+		}
+		length = 0
+		for _, b := range data[2 : 2+n] {
+			length = length<<8 | int(b)
+		}
+		offset += n
+	}
+	if length < 0 || offset+length > len(data) {
+		return nil, 0, errors.New("BER 报文长度超出范围")
+	}
+
+	content := data[offset : offset+length]
+	if p.Constructed {
+		for len(content) > 0 {
+			child, n, err := DecodePacket(content)
+			if err != nil {
+				return nil, 0, err
+			}
+			p.Children = append(p.Children, child)
+			content = content[n:]
+		}
+	} else {
+		p.Data = append([]byte(nil), content...)
+	}
+
+	return p, offset + length, nil
+}
+
+// encodeLength 编码 BER 长度
+func encodeLength(n int) []byte {
+	if n < 0x80 {
+		return []byte{byte(n)}
+	}
+	var buf []byte
+	for v := n; v > 0; v >>= 8 {
+		buf = append([]byte{byte(v)}, buf...)
+	}
+	return append([]byte{0x80 | byte(len(buf))}, buf...)
+}
+
+// encodeInt 以最短补码形式编码整数
+func encodeInt(v int64) []byte {
+	buf := []byte{byte(v)}
+	for {
+		next := v >> 8
+		// 剩余高位全为符号位且当前最高字节的符号位一致时结束
+		if (next == 0 && buf[0]&0x80 == 0) || (next == -1 && buf[0]&0x80 != 0) {
+			return buf
+		}
+		v = next
+		buf = append([]byte{byte(v)}, buf...)
+	}
+}
@@ -0,0 +1,372 @@
+package ldap
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// LDAP 协议操作标签（APPLICATION 类别）
+const (
+	ApplicationBindRequest       byte = 0
+	ApplicationBindResponse      byte = 1
+	ApplicationUnbindRequest     byte = 2
+	ApplicationSearchRequest     byte = 3
+	ApplicationSearchResultEntry byte = 4
+	ApplicationSearchResultDone  byte = 5
+	ApplicationSearchResultRef   byte = 19
+	ApplicationExtendedRequest   byte = 23
+	ApplicationExtendedResponse  byte = 24
+)
+
+// 常用结果码（RFC 4511 附录 A）
+const (
+	ResultSuccess            = 0
+	ResultSizeLimitExceeded  = 4
+	ResultNoSuchObject       = 32
+	ResultInvalidCredentials = 49
+	ResultInsufficientAccess = 50
+	ResultUnwillingToPerform = 53
+)
+
+const (
+	startTLSOID              = "1.3.6.1.4.1.1466.20037"
+	protocolVersion          = 3
+	defaultLDAPPort          = "389"
+	defaultLDAPSPort         = "636"
+	defaultConnectionTimeout = 5 * time.Second
+	searchDerefAliasesNever  = 0
+)
+
+// 搜索范围
+const (
+	ScopeBaseObject   = 0
+	ScopeSingleLevel  = 1
+	ScopeWholeSubtree = 2
+)
+
+// Error LDAP 服务端返回的错误结果
+type Error struct {
+	ResultCode int    // 结果码
+	MatchedDN  string // 匹配的 DN
+	Message    string // 诊断信息
+}
+
+// Error 实现 error 接口
+func (e *Error) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("LDAP 结果码 %d", e.ResultCode)
+	}
+	return fmt.Sprintf("LDAP 结果码 %d：%s", e.ResultCode, e.Message)
+}
+
+// IsResultCode 判断错误是否为指定结果码的 LDAP 错误
+func IsResultCode(err error, code int) bool {
+	var ldapErr *Error
+	return errors.As(err, &ldapErr) && ldapErr.ResultCode == code
+}
+
+// Entry 搜索结果条目
+type Entry struct {
+	DN         string              // 条目 DN
+	Attributes map[string][]string // 属性，键为服务端返回的属性名
+}
+
+// GetAttributeValues 获取属性的全部值（属性名不区分大小写）
+func (e *Entry) GetAttributeValues(name string) []string {
+	for attr, values := range e.Attributes {
+		if strings.EqualFold(attr, name) {
+			return values
+		}
+	}
+	return nil
+}
+
+// GetAttributeValue 获取属性的第一个值
+func (e *Entry) GetAttributeValue(name string) string {
+	values := e.GetAttributeValues(name)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+// SearchRequest 搜索请求
+type SearchRequest struct {
+	BaseDN     string   // 搜索根 DN
+	Scope      int      // 搜索范围
+	Filter     string   // 过滤器
+	Attributes []string // 需要返回的属性，为空时返回全部用户属性
+	SizeLimit  int      // 最大返回条数，0 表示不限制
+	TimeLimit  int      // 服务端搜索时间限制（秒）
+}
+
+// Conn LDAP 连接
+// 同一连接上的请求串行执行
+type Conn struct {
+	conn    net.Conn
+	host    string
+	timeout time.Duration
+	msgID   int64
+	mu      sync.Mutex
+}
+
+// Dial 建立 LDAP 连接
+// 参数:
+// - rawURL: ldap://host[:port] 或 ldaps://host[:port]
+// - timeout: 连接和单次请求超时，0 使用默认值
+// - tlsConfig: ldaps 和 StartTLS 使用的 TLS 配置，可为空
+func Dial(rawURL string, timeout time.Duration, tlsConfig *tls.Config) (*Conn, error) {
+	if timeout <= 0 {
+		timeout = defaultConnectionTimeout
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("LDAP 地址格式错误：%w", err)
+	}
+
+	host := u.Hostname()
+	port := u.Port()
+	dialer := &net.Dialer{Timeout: timeout}
+
+	var conn net.Conn
+	switch strings.ToLower(u.Scheme) {
+	case "ldap":
+		if port == "" {
+			port = defaultLDAPPort
+		}
+		conn, err = dialer.Dial("tcp", net.JoinHostPort(host, port))
+	case "ldaps":
+		if port == "" {
+			port = defaultLDAPSPort
+		}
+		conn, err = tls.DialWithDialer(dialer, "tcp", net.JoinHostPort(host, port), withServerName(tlsConfig, host))
+	default:
+		return nil, fmt.Errorf("不支持的 LDAP 协议：%s", u.Scheme)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("连接 LDAP 服务失败：%w", err)
+	}
+
+	return &Conn{conn: conn, host: host, timeout: timeout}, nil
+}
+
+// withServerName 复制 TLS 配置并补充 ServerName
+func withServerName(tlsConfig *tls.Config, host string) *tls.Config {
+	cfg := &tls.Config{MinVersion: tls.VersionTLS12}
+	if tlsConfig != nil {
+		cfg = tlsConfig.Clone()
+	}
+	if cfg.ServerName == "" {
+		cfg.ServerName = host
+	}
+	return cfg
+}
+
+// StartTLS 将明文连接升级为 TLS
+func (c *Conn) StartTLS(tlsConfig *tls.Config) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	op := NewConstructed(ClassApplication, ApplicationExtendedRequest,
+		NewPacket(ClassContext, 0, []byte(startTLSOID)))
+	resp, err := c.roundTrip(op, ApplicationExtendedResponse)
+	if err != nil {
+		return err
+	}
+	if err := resultError(resp); err != nil {
+		return err
+	}
+
+	tlsConn := tls.Client(c.conn, withServerName(tlsConfig, c.host))
+	_ = tlsConn.SetDeadline(time.Now().Add(c.timeout))
+	if err := tlsConn.Handshake(); err != nil {
+		return fmt.Errorf("LDAP StartTLS 握手失败：%w", err)
+	}
+	_ = tlsConn.SetDeadline(time.Time{})
+	c.conn = tlsConn
+	return nil
+}
+
+// Bind 简单绑定
+// 空密码的简单绑定在多数服务端被视为匿名绑定，调用方需自行拒绝空密码
+func (c *Conn) Bind(dn, password string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	op := NewConstructed(ClassApplication, ApplicationBindRequest,
+		NewInteger(protocolVersion),
+		NewOctetString(dn),
+		NewPacket(ClassContext, 0, []byte(password)),
+	)
+	resp, err := c.roundTrip(op, ApplicationBindResponse)
+	if err != nil {
+		return err
+	}
+	return resultError(resp)
+}
+
+// Search 执行搜索，返回全部结果条目
+// 根 DN 不存在时返回空结果；超出条数限制时返回已收到的条目和对应错误
+func (c *Conn) Search(req *SearchRequest) ([]*Entry, error) {
+	filter, err := CompileFilter(req.Filter)
+	if err != nil {
+		return nil, err
+	}
+
+	attributes := NewSequence()
+	for _, attr := range req.Attributes {
+		attributes.Append(NewOctetString(attr))
+	}
+	op := NewConstructed(ClassApplication, ApplicationSearchRequest,
+		NewOctetString(req.BaseDN),
+		NewEnumerated(int64(req.Scope)),
+		NewEnumerated(searchDerefAliasesNever),
+		NewInteger(int64(req.SizeLimit)),
+		NewInteger(int64(req.TimeLimit)),
+		NewBoolean(false),
+		filter,
+		attributes,
+	)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	msgID, err := c.send(op)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []*Entry
+	for {
+		resp, err := c.receive(msgID)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case resp.Is(ClassApplication, ApplicationSearchResultEntry):
+			entry, err := parseEntry(resp)
+			if err != nil {
+				return nil, err
+			}
+			entries = append(entries, entry)
+		case resp.Is(ClassApplication, ApplicationSearchResultRef):
+			// 不跟随引用
+		case resp.Is(ClassApplication, ApplicationSearchResultDone):
+			err := resultError(resp)
+			if IsResultCode(err, ResultNoSuchObject) {
+				return nil, nil
+			}
+			return entries, err
+		default:
+			return nil, fmt.Errorf("未预期的 LDAP 响应标签：%d", resp.Tag)
+		}
+	}
+}
+
+// Close 发送解绑请求并关闭连接
+func (c *Conn) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	_, _ = c.send(NewPacket(ClassApplication, ApplicationUnbindRequest, nil))
+	return c.conn.Close()
+}
+
+// roundTrip 发送请求并读取期望的单个响应
+func (c *Conn) roundTrip(op *Packet, expectTag byte) (*Packet, error) {
+	msgID, err := c.send(op)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.receive(msgID)
+	if err != nil {
+		return nil, err
+	}
+	if !resp.Is(ClassApplication, expectTag) {
+		return nil, fmt.Errorf("未预期的 LDAP 响应标签：%d", resp.Tag)
+	}
+	return resp, nil
+}
+
+// send 封装 LDAPMessage 并发送，返回消息 ID
+func (c *Conn) send(op *Packet) (int64, error) {
+	c.msgID++
+	msg := NewSequence(NewInteger(c.msgID), op)
+	_ = c.conn.SetWriteDeadline(time.Now().Add(c.timeout))
+	if _, err := c.conn.Write(msg.Bytes()); err != nil {
+		return 0, fmt.Errorf("发送 LDAP 请求失败：%w", err)
+	}
+	return c.msgID, nil
+}
+
+// receive 读取指定消息 ID 的响应，返回其中的协议操作节点
+func (c *Conn) receive(msgID int64) (*Packet, error) {
+	for {
+		_ = c.conn.SetReadDeadline(time.Now().Add(c.timeout))
+		msg, err := ReadPacket(c.conn)
+		if err != nil {
+			return nil, fmt.Errorf("读取 LDAP 响应失败：%w", err)
+		}
+		if len(msg.Children) < 2 {
+			return nil, errors.New("LDAP 响应格式错误")
+		}
+		id, err := msg.Children[0].Int()
+		if err != nil {
+			return nil, err
+		}
+		// 消息 ID 0 为服务端主动通知（如断开连接），其他不匹配的响应直接忽略
+		if id == 0 {
+			return nil, fmt.Errorf("LDAP 服务端断开连接：%w", resultError(msg.Children[1]))
+		}
+		if id == msgID {
+			return msg.Children[1], nil
+		}
+	}
+}
+
+// resultError 解析 LDAPResult，成功时返回 nil
+func resultError(resp *Packet) error {
+	if len(resp.Children) < 3 {
+		return errors.New("LDAP 结果格式错误")
+	}
+	code, err := resp.Children[0].Int()
+	if err != nil {
+		return err
+	}
+	if code == ResultSuccess {
+		return nil
+	}
+	return &Error{
+		ResultCode: int(code),
+		MatchedDN:  resp.Children[1].String(),
+		Message:    resp.Children[2].String(),
+	}
+}
+
+// parseEntry 解析 SearchResultEntry
+func parseEntry(resp *Packet) (*Entry, error) {
+	if len(resp.Children) < 2 {
+		return nil, errors.New("LDAP 搜索结果格式错误")
+	}
+	entry := &Entry{
+		DN:         resp.Children[0].String(),
+		Attributes: make(map[string][]string),
+	}
+	for _, attr := range resp.Children[1].Children {
+		if len(attr.Children) < 2 {
+			continue
+		}
+		name := attr.Children[0].String()
+		for _, value := range attr.Children[1].Children {
+			entry.Attributes[name] = append(entry.Attributes[name], value.String())
+		}
+	}
+	return entry, nil
+}
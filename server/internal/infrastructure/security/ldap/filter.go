@@ -0,0 +1,205 @@
+package ldap
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// 搜索过滤器标签（RFC 4511 4.5.1）
+const (
+	filterAnd            byte = 0
+	filterOr             byte = 1
+	filterNot            byte = 2
+	filterEqualityMatch  byte = 3
+	filterSubstrings     byte = 4
+	filterGreaterOrEqual byte = 5
+	filterLessOrEqual    byte = 6
+	filterPresent        byte = 7
+	filterApproxMatch    byte = 8
+)
+
+// 子串过滤器的组成部分
+const (
+	substringInitial byte = 0
+	substringAny     byte = 1
+	substringFinal   byte = 2
+)
+
+// EscapeFilter 转义过滤器中的特殊字符（RFC 4515），用于拼接用户输入
+func EscapeFilter(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch c {
+		case '\\', '*', '(', ')', 0:
+			fmt.Fprintf(&b, "\\%02x", c)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+// CompileFilter 将字符串形式的过滤器编译为 BER 节点
+// 支持 & | ! 组合以及 = >= <= ~= 比较、存在性（attr=*）和子串（attr=a*b*c）匹配
+func CompileFilter(filter string) (*Packet, error) {
+	filter = strings.TrimSpace(filter)
+	if filter == "" {
+		return nil, fmt.Errorf("过滤器不能为空")
+	}
+	if filter[0] != '(' {
+		filter = "(" + filter + ")"
+	}
+
+	packet, pos, err := compileFilter(filter, 0)
+	if err != nil {
+		return nil, err
+	}
+	if pos != len(filter) {
+		return nil, fmt.Errorf("过滤器存在多余内容：%s", filter[pos:])
+	}
+	return packet, nil
+}
+
+// compileFilter 从 pos 处解析一个带括号的过滤器，返回节点和解析结束位置
+func compileFilter(filter string, pos int) (*Packet, int, error) {
+	if pos >= len(filter) || filter[pos] != '(' {
+		return nil, pos, fmt.Errorf("过滤器格式错误：位置 %d 缺少 (", pos)
+	}
+	pos++
+	if pos >= len(filter) {
+		return nil, pos, fmt.Errorf("过滤器不完整")
+	}
+
+	switch filter[pos] {
+	case '&', '|':
+		tag := filterAnd
+		if filter[pos] == '|' {
+			tag = filterOr
+		}
+		packet := NewConstructed(ClassContext, tag)
+		pos++
+		for pos < len(filter) && filter[pos] == '(' {
+			child, next, err := compileFilter(filter, pos)
+			if err != nil {
+				return nil, next, err
+			}
+			packet.Append(child)
+			pos = next
+		}
+		if len(packet.Children) == 0 {
+			return nil, pos, fmt.Errorf("组合过滤器至少需要一个条件")
+		}
+		return closeFilter(filter, pos, packet)
+	case '!':
+		child, next, err := compileFilter(filter, pos+1)
+		if err != nil {
+			return nil, next, err
+		}
+		return closeFilter(filter, next, NewConstructed(ClassContext, filterNot, child))
+	default:
+		end := strings.IndexByte(filter[pos:], ')')
+		if end < 0 {
+			return nil, pos, fmt.Errorf("过滤器缺少 )")
+		}
+		packet, err := compileItem(filter[pos : pos+end])
+		if err != nil {
+			return nil, pos, err
+		}
+		return packet, pos + end + 1, nil
+	}
+}
+
+// closeFilter 校验并跳过组合过滤器的右括号
+func closeFilter(filter string, pos int, packet *Packet) (*Packet, int, error) {
+	if pos >= len(filter) || filter[pos] != ')' {
+		return nil, pos, fmt.Errorf("过滤器格式错误：位置 %d 缺少 )", pos)
+	}
+	return packet, pos + 1, nil
+}
+
+// compileItem 编译单个比较条件，例如 uid=alice、cn=ad*、mail=*
+func compileItem(item string) (*Packet, error) {
+	eq := strings.IndexByte(item, '=')
+	if eq <= 0 {
+		return nil, fmt.Errorf("无效的过滤条件：%s", item)
+	}
+
+	attr, value := item[:eq], item[eq+1:]
+	tag := filterEqualityMatch
+	switch attr[len(attr)-1] {
+	case '>':
+		tag, attr = filterGreaterOrEqual, attr[:len(attr)-1]
+	case '<':
+		tag, attr = filterLessOrEqual, attr[:len(attr)-1]
+	case '~':
+		tag, attr = filterApproxMatch, attr[:len(attr)-1]
+	}
+	if attr == "" {
+		return nil, fmt.Errorf("无效的过滤条件：%s", item)
+	}
+
+	if tag == filterEqualityMatch {
+		if value == "*" {
+			return NewPacket(ClassContext, filterPresent, []byte(attr)), nil
+		}
+		if strings.Contains(value, "*") {
+			return compileSubstrings(attr, value)
+		}
+	}
+
+	decoded, err := unescapeFilterValue(value)
+	if err != nil {
+		return nil, err
+	}
+	return NewConstructed(ClassContext, tag, NewOctetString(attr), NewOctetString(decoded)), nil
+}
+
+// compileSubstrings 编译子串匹配条件
+func compileSubstrings(attr, value string) (*Packet, error) {
+	parts := strings.Split(value, "*")
+	substrings := NewSequence()
+	for i, part := range parts {
+		if part == "" {
+			continue
+		}
+		decoded, err := unescapeFilterValue(part)
+		if err != nil {
+			return nil, err
+		}
+		tag := substringAny
+		switch i {
+		case 0:
+			tag = substringInitial
+		case len(parts) - 1:
+			tag = substringFinal
+		}
+		substrings.Append(NewPacket(ClassContext, tag, []byte(decoded)))
+	}
+	return NewConstructed(ClassContext, filterSubstrings, NewOctetString(attr), substrings), nil
+}
+
+// unescapeFilterValue 还原 \XX 形式的转义字符
+func unescapeFilterValue(value string) (string, error) {
+	if !strings.Contains(value, "\\") {
+		return value, nil
+	}
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		if value[i] != '\\' {
+			b.WriteByte(value[i])
+			continue
+		}
+		if i+3 > len(value) {
+			return "", fmt.Errorf("无效的转义序列：%s", value)
+		}
+		decoded, err := hex.DecodeString(value[i+1 : i+3])
+		if err != nil {
+			return "", fmt.Errorf("无效的转义序列：%s", value)
+		}
+		b.Write(decoded)
+		i += 2
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,212 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// oidc包提供 OpenID Connect 授权码登录所需的客户端功能
+// 包括发现文档、授权地址（PKCE）、授权码换取令牌、用户信息和 ID Token 校验
+
+// 客户端认证方式
+const (
+	AuthMethodClientSecretBasic = "client_secret_basic" // HTTP Basic 认证
+	AuthMethodClientSecretPost  = "client_secret_post"  // 表单参数认证
+)
+
+const (
+	discoveryPath   = "/.well-known/openid-configuration"
+	maxResponseSize = 1 << 20 // 响应体最大 1MB
+)
+
+// Metadata OIDC 发现文档中使用到的字段
+type Metadata struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	UserinfoEndpoint      string `json:"userinfo_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// TokenResponse 令牌端点响应
+type TokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int64  `json:"expires_in"`
+	IDToken      string `json:"id_token"`
+	Scope        string `json:"scope"`
+}
+
+// Client OIDC HTTP 客户端
+type Client struct {
+	httpClient *http.Client
+}
+
+// NewClient 创建 OIDC 客户端，httpClient 为空时使用 10 秒超时的默认客户端
+func NewClient(httpClient *http.Client) *Client {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &Client{httpClient: httpClient}
+}
+
+// Discover 获取并校验发现文档
+// 文档中的 issuer 必须与配置的 issuer 一致，防止被替换为其他身份提供方
+func (c *Client) Discover(ctx context.Context, issuer string) (*Metadata, error) {
+	issuer = strings.TrimRight(issuer, "/")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, issuer+discoveryPath, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var metadata Metadata
+	if err := c.doJSON(req, &metadata); err != nil {
+		return nil, fmt.Errorf("获取 OIDC 发现文档失败：%w", err)
+	}
+	if strings.TrimRight(metadata.Issuer, "/") != issuer {
+		return nil, fmt.Errorf("OIDC 发现文档 issuer 不匹配：%s", metadata.Issuer)
+	}
+	if metadata.AuthorizationEndpoint == "" || metadata.TokenEndpoint == "" || metadata.JWKSURI == "" {
+		return nil, errors.New("OIDC 发现文档缺少必要的端点")
+	}
+	return &metadata, nil
+}
+
+// AuthCodeRequest 授权请求参数
+type AuthCodeRequest struct {
+	Endpoint      string   // 授权端点
+	ClientID      string   // 客户端 ID
+	RedirectURI   string   // 回调地址
+	Scopes        []string // 授权范围
+	State         string   // 防 CSRF 的随机状态
+	Nonce         string   // 绑定 ID Token 的随机数
+	CodeChallenge string   // PKCE S256 挑战值
+}
+
+// AuthCodeURL 构造授权码模式的授权地址
+func AuthCodeURL(req AuthCodeRequest) (string, error) {
+	u, err := url.Parse(req.Endpoint)
+	if err != nil {
+		return "", err
+	}
+	query := u.Query()
+	query.Set("response_type", "code")
+	query.Set("client_id", req.ClientID)
+	query.Set("redirect_uri", req.RedirectURI)
+	query.Set("scope", strings.Join(req.Scopes, " "))
+	query.Set("state", req.State)
+	query.Set("nonce", req.Nonce)
+	query.Set("code_challenge", req.CodeChallenge)
+	query.Set("code_challenge_method", "S256")
+	u.RawQuery = query.Encode()
+	return u.String(), nil
+}
+
+// ExchangeRequest 授权码换取令牌参数
+type ExchangeRequest struct {
+	Endpoint     string // 令牌端点
+	ClientID     string // 客户端 ID
+	ClientSecret string // 客户端密钥
+	AuthMethod   string // 客户端认证方式
+	Code         string // 授权码
+	RedirectURI  string // 回调地址，需与授权请求一致
+	CodeVerifier string // PKCE 校验值
+}
+
+// Exchange 使用授权码换取令牌
+func (c *Client) Exchange(ctx context.Context, req ExchangeRequest) (*TokenResponse, error) {
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", req.Code)
+	form.Set("redirect_uri", req.RedirectURI)
+	form.Set("code_verifier", req.CodeVerifier)
+	if req.AuthMethod == AuthMethodClientSecretPost {
+		form.Set("client_id", req.ClientID)
+		form.Set("client_secret", req.ClientSecret)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, req.Endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if req.AuthMethod != AuthMethodClientSecretPost {
+		httpReq.SetBasicAuth(url.QueryEscape(req.ClientID), url.QueryEscape(req.ClientSecret))
+	}
+
+	var token TokenResponse
+	if err := c.doJSON(httpReq, &token); err != nil {
+		return nil, fmt.Errorf("授权码换取令牌失败：%w", err)
+	}
+	if token.IDToken == "" {
+		return nil, errors.New("令牌响应中缺少 id_token")
+	}
+	return &token, nil
+}
+
+// UserInfo 使用访问令牌获取用户信息
+func (c *Client) UserInfo(ctx context.Context, endpoint, accessToken string) (map[string]interface{}, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+
+	claims := make(map[string]interface{})
+	if err := c.doJSON(req, &claims); err != nil {
+		return nil, fmt.Errorf("获取用户信息失败：%w", err)
+	}
+	return claims, nil
+}
+
+// doJSON 发送请求并将 JSON 响应解析到 out，非 2xx 状态码返回错误
+func (c *Client) doJSON(req *http.Request, out interface{}) error {
+	req.Header.Set("Accept", "application/json")
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		var oauthErr struct {
+			Error            string `json:"error"`
+			ErrorDescription string `json:"error_description"`
+		}
+		if json.Unmarshal(body, &oauthErr) == nil && oauthErr.Error != "" {
+			return fmt.Errorf("HTTP %d：%s %s", resp.StatusCode, oauthErr.Error, oauthErr.ErrorDescription)
+		}
+		return fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+	return json.Unmarshal(body, out)
+}
+
+// RandomString 生成 URL 安全的随机字符串，用于 state、nonce 和 PKCE 校验值
+func RandomString(n int) (string, error) {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// CodeChallengeS256 计算 PKCE S256 挑战值
+func CodeChallengeS256(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
@@ -0,0 +1,211 @@
+package oidc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	// minRefreshInterval 两次拉取 JWKS 的最小间隔，防止伪造 kid 触发频繁请求
+	minRefreshInterval = 30 * time.Second
+	// keySetTTL JWKS 缓存有效期，过期后下次校验时重新拉取
+	keySetTTL = time.Hour
+	// clockSkew 校验时间类声明时允许的时钟偏差
+	clockSkew = time.Minute
+)
+
+// ID Token 允许的签名算法
+var idTokenSigningMethods = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512"}
+
+// JWK JSON Web Key 中使用到的字段
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use,omitempty"`
+	Alg string `json:"alg,omitempty"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+}
+
+// JWKSet JSON Web Key Set
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+// PublicKey 将 JWK 解析为公钥，支持 RSA 和 EC
+func (k JWK) PublicKey() (interface{}, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, err
+		}
+		exponent := new(big.Int).SetBytes(e)
+		if !exponent.IsInt64() || exponent.Int64() > 1<<31-1 {
+			return nil, errors.New("无效的 RSA 公钥指数")
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exponent.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("不支持的椭圆曲线：%s", k.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := base64.RawURLEncoding.DecodeString(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		key := &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+		if !curve.IsOnCurve(key.X, key.Y) {
+			return nil, errors.New("无效的 EC 公钥")
+		}
+		return key, nil
+	default:
+		return nil, fmt.Errorf("不支持的密钥类型：%s", k.Kty)
+	}
+}
+
+// KeySet 远程 JWKS 缓存
+// 遇到未知 kid 时重新拉取一次，以支持身份提供方轮换签名密钥
+type KeySet struct {
+	client    *Client
+	uri       string
+	mu        sync.Mutex
+	keys      map[string]interface{}
+	fetchedAt time.Time
+}
+
+// NewKeySet 创建远程 JWKS 缓存
+func NewKeySet(client *Client, uri string) *KeySet {
+	return &KeySet{client: client, uri: uri}
+}
+
+// Key 根据 kid 获取公钥；kid 为空且只有一个密钥时返回该密钥
+func (s *KeySet) Key(ctx context.Context, kid string) (interface{}, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if key, ok := s.lookup(kid); ok && time.Since(s.fetchedAt) < keySetTTL {
+		return key, nil
+	}
+	if !s.fetchedAt.IsZero() && time.Since(s.fetchedAt) < minRefreshInterval {
+		if key, ok := s.lookup(kid); ok {
+			return key, nil
+		}
+		return nil, fmt.Errorf("未找到签名密钥：%s", kid)
+	}
+
+	if err := s.refresh(ctx); err != nil {
+		return nil, err
+	}
+	if key, ok := s.lookup(kid); ok {
+		return key, nil
+	}
+	return nil, fmt.Errorf("未找到签名密钥：%s", kid)
+}
+
+// lookup 在缓存中查找密钥
+func (s *KeySet) lookup(kid string) (interface{}, bool) {
+	if kid == "" && len(s.keys) == 1 {
+		for _, key := range s.keys {
+			return key, true
+		}
+	}
+	key, ok := s.keys[kid]
+	return key, ok
+}
+
+// refresh 拉取 JWKS，忽略无法解析和非签名用途的密钥
+func (s *KeySet) refresh(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.uri, nil)
+	if err != nil {
+		return err
+	}
+	var set JWKSet
+	if err := s.client.doJSON(req, &set); err != nil {
+		return fmt.Errorf("获取 JWKS 失败：%w", err)
+	}
+
+	keys := make(map[string]interface{}, len(set.Keys))
+	for _, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := jwk.PublicKey()
+		if err != nil {
+			continue
+		}
+		keys[jwk.Kid] = key
+	}
+	s.keys = keys
+	s.fetchedAt = time.Now()
+	return nil
+}
+
+// IDTokenExpectation ID Token 校验条件
+type IDTokenExpectation struct {
+	Issuer   string // 期望的签发方
+	ClientID string // 期望的受众
+	Nonce    string // 授权请求中的 nonce
+}
+
+// VerifyIDToken 校验 ID Token 的签名、签发方、受众、有效期和 nonce，返回全部声明
+func VerifyIDToken(ctx context.Context, rawToken string, keySet *KeySet, expect IDTokenExpectation) (map[string]interface{}, error) {
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(rawToken, claims, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		return keySet.Key(ctx, kid)
+	},
+		jwt.WithValidMethods(idTokenSigningMethods),
+		jwt.WithIssuer(expect.Issuer),
+		jwt.WithAudience(expect.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(clockSkew),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("ID Token 校验失败：%w", err)
+	}
+
+	if nonce, _ := claims["nonce"].(string); nonce == "" || nonce != expect.Nonce {
+		return nil, errors.New("ID Token nonce 不匹配")
+	}
+	if sub, _ := claims["sub"].(string); sub == "" {
+		return nil, errors.New("ID Token 缺少 sub")
+	}
+	// 存在多个受众时，azp 必须是当前客户端
+	if aud, err := claims.GetAudience(); err == nil && len(aud) > 1 {
+		if azp, _ := claims["azp"].(string); azp != expect.ClientID {
+			return nil, errors.New("ID Token azp 不匹配")
+		}
+	}
+	return claims, nil
+}
@@ -0,0 +1,150 @@
+package oidc
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/ix-pay/ixpay-pro/internal/config"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/observability/logger"
+)
+
+// 签发令牌使用的 JWT 头 typ
+const (
+	TokenTypeAccess = "at+jwt" // 访问令牌（RFC 9068），防止 ID Token 被当作访问令牌使用
+	TokenTypeID     = "JWT"    // ID Token
+)
+
+// Signer 身份提供方的令牌签名器
+// 使用 RSA 私钥以 RS256 签名，kid 取公钥的 JWK 指纹（RFC 7638）
+type Signer struct {
+	key *rsa.PrivateKey
+	kid string
+}
+
+// SetupSigner 初始化身份提供方签名器
+// 未配置私钥文件时临时生成密钥，服务重启后已签发的令牌全部失效，多实例部署时必须配置私钥文件
+func SetupSigner(cfg *config.Config, log logger.Logger) (*Signer, error) {
+	keyFile := cfg.IdentityProvider.SigningKeyFile
+	if keyFile == "" {
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		if err != nil {
+			return nil, fmt.Errorf("生成身份提供方签名密钥失败：%w", err)
+		}
+		if cfg.IdentityProvider.Enabled {
+			log.Warn("未配置身份提供方签名私钥，使用临时生成的密钥，重启后已签发的令牌将失效")
+		}
+		return NewSigner(key), nil
+	}
+
+	data, err := os.ReadFile(keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("读取身份提供方签名私钥失败：%w", err)
+	}
+	key, err := ParseRSAPrivateKey(data)
+	if err != nil {
+		return nil, err
+	}
+	return NewSigner(key), nil
+}
+
+// NewSigner 使用指定私钥创建签名器
+func NewSigner(key *rsa.PrivateKey) *Signer {
+	return &Signer{key: key, kid: rsaThumbprint(&key.PublicKey)}
+}
+
+// ParseRSAPrivateKey 解析 PEM 格式的 RSA 私钥，支持 PKCS#1 和 PKCS#8
+func ParseRSAPrivateKey(data []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("签名私钥不是有效的 PEM 格式")
+	}
+	key, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+	if err != nil {
+		parsed, pkcs8Err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if pkcs8Err != nil {
+			return nil, fmt.Errorf("解析签名私钥失败：%w", pkcs8Err)
+		}
+		rsaKey, ok := parsed.(*rsa.PrivateKey)
+		if !ok {
+			return nil, errors.New("签名私钥必须为 RSA 密钥")
+		}
+		key = rsaKey
+	}
+	if key.N.BitLen() < 2048 {
+		return nil, errors.New("签名私钥长度不能小于 2048 位")
+	}
+	return key, nil
+}
+
+// KeyID 返回签名密钥的 kid
+func (s *Signer) KeyID() string {
+	return s.kid
+}
+
+// Sign 使用 RS256 签名声明，typ 为 JWT 头中的令牌类型
+func (s *Signer) Sign(claims jwt.Claims, typ string) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = s.kid
+	token.Header["typ"] = typ
+	return token.SignedString(s.key)
+}
+
+// Verify 校验本签名器签发的令牌，并检查签发方和令牌类型
+func (s *Signer) Verify(rawToken, issuer, typ string, claims jwt.Claims) error {
+	token, err := jwt.ParseWithClaims(rawToken, claims, func(token *jwt.Token) (interface{}, error) {
+		if kid, _ := token.Header["kid"].(string); kid != s.kid {
+			return nil, errors.New("未知的签名密钥")
+		}
+		if t, _ := token.Header["typ"].(string); t != typ {
+			return nil, errors.New("令牌类型不匹配")
+		}
+		return &s.key.PublicKey, nil
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg()}),
+		jwt.WithIssuer(issuer),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(clockSkew),
+	)
+	if err != nil {
+		return err
+	}
+	if !token.Valid {
+		return errors.New("令牌无效")
+	}
+	return nil
+}
+
+// JWKS 返回公开的签名公钥集合
+func (s *Signer) JWKS() JWKSet {
+	return JWKSet{Keys: []JWK{{
+		Kty: "RSA",
+		Kid: s.kid,
+		Use: "sig",
+		Alg: jwt.SigningMethodRS256.Alg(),
+		N:   base64.RawURLEncoding.EncodeToString(s.key.N.Bytes()),
+		E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(s.key.E)).Bytes()),
+	}}}
+}
+
+// AccessTokenHash 计算 ID Token 中的 at_hash：访问令牌 SHA-256 摘要左半部分的 base64url 编码
+func AccessTokenHash(accessToken string) string {
+	sum := sha256.Sum256([]byte(accessToken))
+	return base64.RawURLEncoding.EncodeToString(sum[:len(sum)/2])
+}
+
+// rsaThumbprint 计算 RSA 公钥的 JWK 指纹（RFC 7638）
+func rsaThumbprint(key *rsa.PublicKey) string {
+	e := base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes())
+	n := base64.RawURLEncoding.EncodeToString(key.N.Bytes())
+	sum := sha256.Sum256([]byte(`{"e":"` + e + `","kty":"RSA","n":"` + n + `"}`))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
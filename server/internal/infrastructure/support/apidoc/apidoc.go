@@ -0,0 +1,74 @@
+package apidoc
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/swaggo/swag"
+)
+
+// Operation swagger 文档中的接口说明
+type Operation struct {
+	Summary string   // 接口摘要
+	Tags    []string // 接口标签
+}
+
+// Index swagger 接口索引，按请求方法和路由模板查找接口说明
+type Index struct {
+	operations map[string]Operation
+}
+
+// swaggerDoc swagger 文档中用到的部分
+type swaggerDoc struct {
+	Paths map[string]map[string]struct {
+		Summary string   `json:"summary"`
+		Tags    []string `json:"tags"`
+	} `json:"paths"`
+}
+
+// Load 读取已注册的 swagger 文档并建立索引，文档由 docs 包在导入时注册
+func Load() (*Index, error) {
+	doc, err := swag.ReadDoc()
+	if err != nil {
+		return nil, err
+	}
+	return Parse([]byte(doc))
+}
+
+// Parse 解析 swagger 文档并建立索引
+func Parse(doc []byte) (*Index, error) {
+	var parsed swaggerDoc
+	if err := json.Unmarshal(doc, &parsed); err != nil {
+		return nil, err
+	}
+
+	index := &Index{operations: make(map[string]Operation)}
+	for path, methods := range parsed.Paths {
+		for method, op := range methods {
+			index.operations[operationKey(method, path)] = Operation{Summary: op.Summary, Tags: op.Tags}
+		}
+	}
+	return index, nil
+}
+
+// Lookup 查找路由的接口说明
+// path 为 gin 路由模板，swagger 文档中的 {id} 和 gin 的 :id、*path 视为同一个参数段
+func (i *Index) Lookup(method, path string) (Operation, bool) {
+	if i == nil {
+		return Operation{}, false
+	}
+	op, ok := i.operations[operationKey(method, path)]
+	return op, ok
+}
+
+// operationKey 生成索引键，参数段统一替换为 {}，忽略参数名差异
+func operationKey(method, path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for idx, segment := range segments {
+		if strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*") ||
+			(strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}")) {
+			segments[idx] = "{}"
+		}
+	}
+	return strings.ToUpper(method) + " /" + strings.Join(segments, "/")
+}
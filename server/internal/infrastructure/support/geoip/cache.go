@@ -0,0 +1,61 @@
+package geoip
+
+import (
+	"container/list"
+	"sync"
+)
+
+// lruCache 查询结果的 LRU 缓存，地址库中没有的 IP 同样缓存，避免重复查询
+type lruCache struct {
+	mu       sync.Mutex
+	capacity int
+	items    map[string]*list.Element
+	order    *list.List
+}
+
+// cacheEntry 缓存项
+type cacheEntry struct {
+	key   string
+	loc   Location
+	found bool
+}
+
+// newLRUCache 创建 LRU 缓存
+func newLRUCache(capacity int) *lruCache {
+	return &lruCache{
+		capacity: capacity,
+		items:    make(map[string]*list.Element, capacity),
+		order:    list.New(),
+	}
+}
+
+// get 读取缓存，ok 表示是否命中
+func (c *lruCache) get(key string) (loc Location, found bool, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	elem, ok := c.items[key]
+	if !ok {
+		return Location{}, false, false
+	}
+	c.order.MoveToFront(elem)
+	entry := elem.Value.(*cacheEntry)
+	return entry.loc, entry.found, true
+}
+
+// add 写入缓存，超过容量时淘汰最久未使用的项
+func (c *lruCache) add(key string, loc Location, found bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if elem, ok := c.items[key]; ok {
+		c.order.MoveToFront(elem)
+		entry := elem.Value.(*cacheEntry)
+		entry.loc, entry.found = loc, found
+		return
+	}
+	c.items[key] = c.order.PushFront(&cacheEntry{key: key, loc: loc, found: found})
+	if c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(*cacheEntry).key)
+	}
+}
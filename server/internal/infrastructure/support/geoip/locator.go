@@ -0,0 +1,283 @@
+package geoip
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ix-pay/ixpay-pro/internal/config"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/observability/logger"
+)
+
+// 数据库格式
+const (
+	FormatIP2Region = "ip2region" // ip2region xdb 格式，仅支持 IPv4
+	FormatMMDB      = "mmdb"      // MaxMind DB 格式（GeoLite2、GeoIP2 及兼容格式）
+)
+
+// 登录地点的固定取值
+const (
+	PlaceLocal   = "本地连接"
+	PlacePrivate = "内网"
+	PlaceUnknown = "未知"
+)
+
+// 默认配置
+const (
+	defaultCacheSize      = 10000
+	defaultReloadInterval = time.Minute
+	defaultLanguage       = "zh-CN"
+)
+
+// ErrInvalidDatabase 数据库文件格式错误
+var ErrInvalidDatabase = errors.New("IP 地址库格式错误")
+
+// Location IP 归属地
+type Location struct {
+	Country   string  `json:"country"`   // 国家
+	Province  string  `json:"province"`  // 省份
+	City      string  `json:"city"`      // 城市
+	ISP       string  `json:"isp"`       // 运营商
+	Latitude  float64 `json:"latitude"`  // 纬度，ip2region 地址库不提供
+	Longitude float64 `json:"longitude"` // 经度，ip2region 地址库不提供
+}
+
+// HasCoordinates 是否包含经纬度
+func (l Location) HasCoordinates() bool {
+	return l.Latitude != 0 || l.Longitude != 0
+}
+
+// String 拼接为登录地点，如 "中国 广东省 深圳市 电信"，省市同名时只保留一个
+func (l Location) String() string {
+	parts := make([]string, 0, 4)
+	for _, part := range []string{l.Country, l.Province, l.City, l.ISP} {
+		if part == "" || (len(parts) > 0 && parts[len(parts)-1] == part) {
+			continue
+		}
+		parts = append(parts, part)
+	}
+	return strings.Join(parts, " ")
+}
+
+// provider IP 地址库
+type provider interface {
+	// lookup 查询归属地，地址库中没有该 IP 时返回 false
+	lookup(addr netip.Addr) (Location, bool, error)
+}
+
+// Locator 离线 IP 归属地查询
+// 启动时将地址库文件加载到内存，按检查间隔比较文件修改时间和大小，文件变更后自动重新加载并清空查询缓存
+// 未配置地址库文件时只识别本地和内网地址
+type Locator struct {
+	path           string
+	format         string
+	language       string
+	reloadInterval time.Duration
+	log            logger.Logger
+
+	mu        sync.RWMutex
+	db        provider
+	modTime   time.Time
+	size      int64
+	cache     *lruCache
+	nextCheck atomic.Int64 // 下次检查文件变更的时间（UnixNano）
+}
+
+// SetupLocator 根据配置创建 IP 归属地查询
+func SetupLocator(cfg *config.Config, log logger.Logger) (*Locator, error) {
+	geoCfg := cfg.GeoIP
+	locator, err := NewLocator(geoCfg.DBPath, geoCfg.Format, geoCfg.Language, geoCfg.CacheSize,
+		time.Duration(geoCfg.ReloadInterval)*time.Second, log)
+	if err != nil {
+		return nil, err
+	}
+	if geoCfg.DBPath == "" {
+		log.Warn("未配置 IP 地址库，登录地点只能识别本地和内网地址")
+	}
+	return locator, nil
+}
+
+// NewLocator 创建 IP 归属地查询
+// format 为空时按文件扩展名识别，.xdb 为 ip2region，其余为 MaxMind DB
+// language 为 MaxMind DB 地名的语言，默认 zh-CN，缺少该语言时使用英文
+func NewLocator(path, format, language string, cacheSize int, reloadInterval time.Duration, log logger.Logger) (*Locator, error) {
+	if format == "" && path != "" {
+		format = FormatMMDB
+		if strings.EqualFold(filepath.Ext(path), ".xdb") {
+			format = FormatIP2Region
+		}
+	}
+	if format != "" && format != FormatIP2Region && format != FormatMMDB {
+		return nil, fmt.Errorf("不支持的 IP 地址库格式：%s", format)
+	}
+	if language == "" {
+		language = defaultLanguage
+	}
+	if cacheSize <= 0 {
+		cacheSize = defaultCacheSize
+	}
+	if reloadInterval <= 0 {
+		reloadInterval = defaultReloadInterval
+	}
+
+	l := &Locator{
+		path:           path,
+		format:         format,
+		language:       language,
+		reloadInterval: reloadInterval,
+		log:            log,
+		cache:          newLRUCache(cacheSize),
+	}
+	if path != "" {
+		if err := l.load(); err != nil {
+			return nil, err
+		}
+	}
+	return l, nil
+}
+
+// Enabled 是否已加载地址库
+func (l *Locator) Enabled() bool {
+	return l != nil && l.path != ""
+}
+
+// Lookup 查询 IP 归属地
+// 本地和内网地址、未配置地址库或地址库中没有该 IP 时返回 false
+func (l *Locator) Lookup(ip string) (Location, bool) {
+	if !l.Enabled() {
+		return Location{}, false
+	}
+	addr, err := ParseAddr(ip)
+	if err != nil || addr.IsLoopback() || IsPrivate(addr) {
+		return Location{}, false
+	}
+
+	l.checkReload()
+
+	key := addr.String()
+	l.mu.RLock()
+	db, cache := l.db, l.cache
+	l.mu.RUnlock()
+	if loc, found, ok := cache.get(key); ok {
+		return loc, found
+	}
+
+	loc, found, err := db.lookup(addr)
+	if err != nil {
+		l.log.Warn("查询 IP 归属地失败", "ip", key, "error", err)
+		return Location{}, false
+	}
+	cache.add(key, loc, found)
+	return loc, found
+}
+
+// Place 返回登录地点：本地连接、内网、归属地或未知
+func (l *Locator) Place(ip string) string {
+	addr, err := ParseAddr(ip)
+	if err != nil {
+		if ip == "localhost" {
+			return PlaceLocal
+		}
+		return PlaceUnknown
+	}
+	if addr.IsLoopback() {
+		return PlaceLocal
+	}
+	if IsPrivate(addr) {
+		return PlacePrivate
+	}
+	if loc, found := l.Lookup(ip); found {
+		if place := loc.String(); place != "" {
+			return place
+		}
+	}
+	return PlaceUnknown
+}
+
+// checkReload 到达检查间隔时比较文件修改时间和大小，变更后重新加载
+// 重新加载失败时继续使用已加载的地址库
+func (l *Locator) checkReload() {
+	now := time.Now().UnixNano()
+	next := l.nextCheck.Load()
+	if now < next || !l.nextCheck.CompareAndSwap(next, now+int64(l.reloadInterval)) {
+		return
+	}
+
+	info, err := os.Stat(l.path)
+	if err != nil {
+		l.log.Warn("检查 IP 地址库文件失败", "path", l.path, "error", err)
+		return
+	}
+	l.mu.RLock()
+	changed := !info.ModTime().Equal(l.modTime) || info.Size() != l.size
+	l.mu.RUnlock()
+	if !changed {
+		return
+	}
+
+	if err := l.load(); err != nil {
+		l.log.Error("重新加载 IP 地址库失败", "path", l.path, "error", err)
+		return
+	}
+	l.log.Info("IP 地址库已重新加载", "path", l.path)
+}
+
+// load 加载地址库文件，成功后替换当前地址库并清空查询缓存
+func (l *Locator) load() error {
+	info, err := os.Stat(l.path)
+	if err != nil {
+		return fmt.Errorf("读取 IP 地址库失败：%w", err)
+	}
+	data, err := os.ReadFile(l.path)
+	if err != nil {
+		return fmt.Errorf("读取 IP 地址库失败：%w", err)
+	}
+
+	var db provider
+	switch l.format {
+	case FormatIP2Region:
+		db, err = newXDBReader(data)
+	default:
+		db, err = newMMDBReader(data, l.language)
+	}
+	if err != nil {
+		return fmt.Errorf("加载 IP 地址库 %s 失败：%w", l.path, err)
+	}
+
+	l.mu.Lock()
+	l.db = db
+	l.modTime = info.ModTime()
+	l.size = info.Size()
+	l.cache = newLRUCache(l.cache.capacity)
+	l.mu.Unlock()
+	l.nextCheck.Store(time.Now().Add(l.reloadInterval).UnixNano())
+	return nil
+}
+
+// ParseAddr 解析 IP 地址，去掉 IPv6 区域标识，IPv4 映射的 IPv6 地址转换为 IPv4
+func ParseAddr(ip string) (netip.Addr, error) {
+	addr, err := netip.ParseAddr(strings.TrimSpace(ip))
+	if err != nil {
+		return netip.Addr{}, err
+	}
+	return addr.WithZone("").Unmap(), nil
+}
+
+// sharedAddressSpace 运营商级 NAT 地址段（RFC 6598）
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// IsPrivate 判断是否为内网地址
+// 包括 IPv4 私有地址、IPv6 唯一本地地址（fc00::/7）、链路本地地址、未指定地址和运营商级 NAT 地址
+func IsPrivate(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	return addr.IsPrivate() ||
+		addr.IsLinkLocalUnicast() ||
+		addr.IsUnspecified() ||
+		sharedAddressSpace.Contains(addr)
+}
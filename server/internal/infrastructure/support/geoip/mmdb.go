@@ -0,0 +1,358 @@
+package geoip
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"net/netip"
+)
+
+// MaxMind DB 文件结构：二叉搜索树、16 字节分隔符、数据区，文件末尾为元数据标记和元数据
+// 格式说明见 https://maxmind.github.io/MaxMind-DB/
+var mmdbMetadataMarker = []byte("\xab\xcd\xefMaxMind.com")
+
+// mmdbDataSeparatorSize 搜索树与数据区之间的分隔符长度
+const mmdbDataSeparatorSize = 16
+
+// MaxMind DB 数据类型
+const (
+	mmdbPointer = 1
+	mmdbString  = 2
+	mmdbDouble  = 3
+	mmdbBytes   = 4
+	mmdbUint16  = 5
+	mmdbUint32  = 6
+	mmdbMap     = 7
+	mmdbInt32   = 8
+	mmdbUint64  = 9
+	mmdbUint128 = 10
+	mmdbArray   = 11
+	mmdbBoolean = 14
+	mmdbFloat   = 15
+)
+
+// mmdbReader MaxMind DB 地址库，整个文件加载到内存
+type mmdbReader struct {
+	tree       []byte
+	data       []byte
+	nodeCount  uint
+	recordSize uint
+	ipVersion  uint
+	ipv4Start  uint // IPv6 地址库中 IPv4 地址（::/96）对应的节点
+	language   string
+}
+
+// newMMDBReader 解析 MaxMind DB 文件
+func newMMDBReader(data []byte, language string) (*mmdbReader, error) {
+	markerIndex := bytes.LastIndex(data, mmdbMetadataMarker)
+	if markerIndex < 0 {
+		return nil, ErrInvalidDatabase
+	}
+	metadataStart := markerIndex + len(mmdbMetadataMarker)
+	metadata, _, err := (&mmdbDecoder{data: data[metadataStart:]}).decode(0, 0)
+	if err != nil {
+		return nil, fmt.Errorf("解析元数据失败：%w", err)
+	}
+	meta, ok := metadata.(map[string]interface{})
+	if !ok {
+		return nil, ErrInvalidDatabase
+	}
+
+	r := &mmdbReader{
+		nodeCount:  toUint(meta["node_count"]),
+		recordSize: toUint(meta["record_size"]),
+		ipVersion:  toUint(meta["ip_version"]),
+		language:   language,
+	}
+	if r.recordSize != 24 && r.recordSize != 28 && r.recordSize != 32 {
+		return nil, fmt.Errorf("不支持的记录长度：%d", r.recordSize)
+	}
+	if r.ipVersion != 4 && r.ipVersion != 6 {
+		return nil, fmt.Errorf("不支持的 IP 版本：%d", r.ipVersion)
+	}
+
+	treeSize := int(r.nodeCount * r.recordSize / 4)
+	if treeSize+mmdbDataSeparatorSize > markerIndex {
+		return nil, ErrInvalidDatabase
+	}
+	r.tree = data[:treeSize]
+	r.data = data[treeSize+mmdbDataSeparatorSize : markerIndex]
+
+	if r.ipVersion == 6 {
+		node := uint(0)
+		for i := 0; i < 96 && node < r.nodeCount; i++ {
+			node = r.readRecord(node, 0)
+		}
+		r.ipv4Start = node
+	}
+	return r, nil
+}
+
+// lookup 沿搜索树按 IP 地址逐位查找，叶子记录指向数据区
+func (r *mmdbReader) lookup(addr netip.Addr) (Location, bool, error) {
+	var ip []byte
+	node := uint(0)
+	if addr.Is4() {
+		ip4 := addr.As4()
+		ip = ip4[:]
+		node = r.ipv4Start
+	} else {
+		if r.ipVersion == 4 {
+			return Location{}, false, nil
+		}
+		ip16 := addr.As16()
+		ip = ip16[:]
+	}
+
+	for i := 0; i < len(ip)*8 && node < r.nodeCount; i++ {
+		bit := uint(ip[i/8]>>(7-uint(i%8))) & 1
+		node = r.readRecord(node, bit)
+	}
+	if node == r.nodeCount {
+		return Location{}, false, nil
+	}
+	if node < r.nodeCount {
+		return Location{}, false, ErrInvalidDatabase
+	}
+
+	offset := node - r.nodeCount - mmdbDataSeparatorSize
+	if offset >= uint(len(r.data)) {
+		return Location{}, false, ErrInvalidDatabase
+	}
+	value, _, err := (&mmdbDecoder{data: r.data}).decode(offset, 0)
+	if err != nil {
+		return Location{}, false, err
+	}
+	record, ok := value.(map[string]interface{})
+	if !ok {
+		return Location{}, false, ErrInvalidDatabase
+	}
+	return r.location(record), true, nil
+}
+
+// location 从 GeoIP2/GeoLite2 City 或 ISP 记录中提取归属地
+func (r *mmdbReader) location(record map[string]interface{}) Location {
+	loc := Location{
+		Country: r.name(record["country"]),
+		City:    r.name(record["city"]),
+	}
+	if location, ok := record["location"].(map[string]interface{}); ok {
+		loc.Latitude, _ = location["latitude"].(float64)
+		loc.Longitude, _ = location["longitude"].(float64)
+	}
+	if subdivisions, ok := record["subdivisions"].([]interface{}); ok && len(subdivisions) > 0 {
+		loc.Province = r.name(subdivisions[0])
+	}
+	for _, key := range []string{"isp", "organization", "autonomous_system_organization"} {
+		if isp, ok := record[key].(string); ok && isp != "" {
+			loc.ISP = isp
+			break
+		}
+	}
+	return loc
+}
+
+// name 读取地名，优先使用配置的语言，缺少时使用英文
+func (r *mmdbReader) name(value interface{}) string {
+	entry, ok := value.(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	names, ok := entry["names"].(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	if name, ok := names[r.language].(string); ok && name != "" {
+		return name
+	}
+	name, _ := names["en"].(string)
+	return name
+}
+
+// readRecord 读取节点的左（bit=0）或右（bit=1）记录
+func (r *mmdbReader) readRecord(node, bit uint) uint {
+	switch r.recordSize {
+	case 24:
+		p := node*6 + bit*3
+		return uint(r.tree[p])<<16 | uint(r.tree[p+1])<<8 | uint(r.tree[p+2])
+	case 28:
+		p := node * 7
+		if bit == 0 {
+			return uint(r.tree[p+3]&0xf0)<<20 | uint(r.tree[p])<<16 | uint(r.tree[p+1])<<8 | uint(r.tree[p+2])
+		}
+		return uint(r.tree[p+3]&0x0f)<<24 | uint(r.tree[p+4])<<16 | uint(r.tree[p+5])<<8 | uint(r.tree[p+6])
+	default:
+		p := node*8 + bit*4
+		return uint(binary.BigEndian.Uint32(r.tree[p:]))
+	}
+}
+
+// mmdbDecoder 数据区解码器，指针相对于数据区起始位置
+type mmdbDecoder struct {
+	data []byte
+}
+
+// mmdbMaxDepth 嵌套解码的最大深度，防止损坏的文件造成无限递归
+const mmdbMaxDepth = 32
+
+// decode 解码 offset 处的值，返回值和下一个值的位置
+func (d *mmdbDecoder) decode(offset uint, depth int) (interface{}, uint, error) {
+	if depth > mmdbMaxDepth {
+		return nil, 0, ErrInvalidDatabase
+	}
+	typeNum, size, offset, err := d.decodeControl(offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if typeNum == mmdbPointer {
+		pointer, next, err := d.decodePointer(size, offset)
+		if err != nil {
+			return nil, 0, err
+		}
+		value, _, err := d.decode(pointer, depth+1)
+		return value, next, err
+	}
+
+	switch typeNum {
+	case mmdbMap:
+		result := make(map[string]interface{}, size)
+		for i := uint(0); i < size; i++ {
+			key, next, err := d.decode(offset, depth+1)
+			if err != nil {
+				return nil, 0, err
+			}
+			keyString, ok := key.(string)
+			if !ok {
+				return nil, 0, ErrInvalidDatabase
+			}
+			value, next, err := d.decode(next, depth+1)
+			if err != nil {
+				return nil, 0, err
+			}
+			result[keyString] = value
+			offset = next
+		}
+		return result, offset, nil
+	case mmdbArray:
+		result := make([]interface{}, 0, size)
+		for i := uint(0); i < size; i++ {
+			value, next, err := d.decode(offset, depth+1)
+			if err != nil {
+				return nil, 0, err
+			}
+			result = append(result, value)
+			offset = next
+		}
+		return result, offset, nil
+	case mmdbBoolean:
+		return size != 0, offset, nil
+	}
+
+	if offset+size > uint(len(d.data)) {
+		return nil, 0, ErrInvalidDatabase
+	}
+	raw := d.data[offset : offset+size]
+	next := offset + size
+	switch typeNum {
+	case mmdbString:
+		return string(raw), next, nil
+	case mmdbBytes, mmdbUint128:
+		return append([]byte(nil), raw...), next, nil
+	case mmdbDouble:
+		if size != 8 {
+			return nil, 0, ErrInvalidDatabase
+		}
+		return math.Float64frombits(binary.BigEndian.Uint64(raw)), next, nil
+	case mmdbFloat:
+		if size != 4 {
+			return nil, 0, ErrInvalidDatabase
+		}
+		return math.Float32frombits(binary.BigEndian.Uint32(raw)), next, nil
+	case mmdbUint16, mmdbUint32, mmdbUint64:
+		var value uint64
+		for _, b := range raw {
+			value = value<<8 | uint64(b)
+		}
+		return value, next, nil
+	case mmdbInt32:
+		var value uint32
+		for _, b := range raw {
+			value = value<<8 | uint32(b)
+		}
+		return int32(value), next, nil
+	default:
+		return nil, 0, fmt.Errorf("%w：未知的数据类型 %d", ErrInvalidDatabase, typeNum)
+	}
+}
+
+// decodeControl 解析控制字节，返回类型、长度和数据起始位置
+func (d *mmdbDecoder) decodeControl(offset uint) (uint, uint, uint, error) {
+	if offset >= uint(len(d.data)) {
+		return 0, 0, 0, ErrInvalidDatabase
+	}
+	ctrl := d.data[offset]
+	offset++
+	typeNum := uint(ctrl >> 5)
+	if typeNum == 0 {
+		// 扩展类型
+		if offset >= uint(len(d.data)) {
+			return 0, 0, 0, ErrInvalidDatabase
+		}
+		typeNum = 7 + uint(d.data[offset])
+		offset++
+	}
+
+	size := uint(ctrl & 0x1f)
+	if typeNum == mmdbPointer || size < 29 {
+		return typeNum, size, offset, nil
+	}
+	extra := size - 28
+	if offset+extra > uint(len(d.data)) {
+		return 0, 0, 0, ErrInvalidDatabase
+	}
+	var value uint
+	for _, b := range d.data[offset : offset+extra] {
+		value = value<<8 | uint(b)
+	}
+	switch extra {
+	case 1:
+		size = 29 + value
+	case 2:
+		size = 285 + value
+	default:
+		size = 65821 + value
+	}
+	return typeNum, size, offset + extra, nil
+}
+
+// decodePointer 解析指针，size 为控制字节的低 5 位
+func (d *mmdbDecoder) decodePointer(size, offset uint) (uint, uint, error) {
+	length := (size>>3)&0x3 + 1
+	if offset+length > uint(len(d.data)) {
+		return 0, 0, ErrInvalidDatabase
+	}
+	var value uint
+	if length != 4 {
+		value = size & 0x7
+	}
+	for _, b := range d.data[offset : offset+length] {
+		value = value<<8 | uint(b)
+	}
+	switch length {
+	case 2:
+		value += 2048
+	case 3:
+		value += 526336
+	}
+	return value, offset + length, nil
+}
+
+// toUint 将元数据中的整数转换为 uint
+func toUint(value interface{}) uint {
+	if v, ok := value.(uint64); ok {
+		return uint(v)
+	}
+	return 0
+}
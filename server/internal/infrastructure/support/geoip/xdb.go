@@ -0,0 +1,91 @@
+package geoip
+
+import (
+	"encoding/binary"
+	"net/netip"
+	"strings"
+)
+
+// ip2region xdb 文件结构：
+// 256 字节文件头，之后是按 IP 前两个字节划分的 256×256 向量索引，每项为段索引的起止偏移（各 4 字节）；
+// 段索引每项 14 字节：起始 IP、结束 IP（各 4 字节）、地域数据长度（2 字节）、地域数据偏移（4 字节），均为小端序
+const (
+	xdbHeaderSize       = 256
+	xdbVectorIndexCols  = 256
+	xdbVectorIndexSize  = 8
+	xdbSegmentIndexSize = 14
+	xdbVectorIndexEnd   = xdbHeaderSize + xdbVectorIndexCols*xdbVectorIndexCols*xdbVectorIndexSize
+)
+
+// xdbReader ip2region xdb 地址库，整个文件加载到内存
+type xdbReader struct {
+	data []byte
+}
+
+// newXDBReader 解析 ip2region xdb 文件
+func newXDBReader(data []byte) (*xdbReader, error) {
+	if len(data) < xdbVectorIndexEnd {
+		return nil, ErrInvalidDatabase
+	}
+	return &xdbReader{data: data}, nil
+}
+
+// lookup 查询 IPv4 地址的归属地，xdb 格式不包含 IPv6 数据
+func (r *xdbReader) lookup(addr netip.Addr) (Location, bool, error) {
+	if !addr.Is4() {
+		return Location{}, false, nil
+	}
+	ip4 := addr.As4()
+	ip := binary.BigEndian.Uint32(ip4[:])
+
+	offset := xdbHeaderSize + (int(ip4[0])*xdbVectorIndexCols+int(ip4[1]))*xdbVectorIndexSize
+	start := int(binary.LittleEndian.Uint32(r.data[offset:]))
+	end := int(binary.LittleEndian.Uint32(r.data[offset+4:]))
+	if start == 0 && end == 0 {
+		return Location{}, false, nil
+	}
+	if start < xdbVectorIndexEnd || end < start || end+xdbSegmentIndexSize > len(r.data) {
+		return Location{}, false, ErrInvalidDatabase
+	}
+
+	// 向量索引范围内二分查找段索引
+	low, high := 0, (end-start)/xdbSegmentIndexSize
+	for low <= high {
+		mid := (low + high) / 2
+		p := start + mid*xdbSegmentIndexSize
+		startIP := binary.LittleEndian.Uint32(r.data[p:])
+		endIP := binary.LittleEndian.Uint32(r.data[p+4:])
+		switch {
+		case ip < startIP:
+			high = mid - 1
+		case ip > endIP:
+			low = mid + 1
+		default:
+			dataLen := int(binary.LittleEndian.Uint16(r.data[p+8:]))
+			dataPtr := int(binary.LittleEndian.Uint32(r.data[p+10:]))
+			if dataPtr+dataLen > len(r.data) {
+				return Location{}, false, ErrInvalidDatabase
+			}
+			return parseRegion(string(r.data[dataPtr : dataPtr+dataLen])), true, nil
+		}
+	}
+	return Location{}, false, nil
+}
+
+// parseRegion 解析地域数据
+// 旧版数据为 "国家|区域|省份|城市|运营商"，新版数据为 "国家|省份|城市|运营商"，缺失的字段为 "0"
+func parseRegion(region string) Location {
+	fields := strings.Split(region, "|")
+	for i, field := range fields {
+		if field == "0" {
+			fields[i] = ""
+		}
+	}
+	if len(fields) >= 5 {
+		fields = append(fields[:1], fields[2:]...)
+	}
+	for len(fields) < 4 {
+		fields = append(fields, "")
+	}
+	return Location{Country: fields[0], Province: fields[1], City: fields[2], ISP: fields[3]}
+}
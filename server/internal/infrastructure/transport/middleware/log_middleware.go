@@ -5,12 +5,13 @@ import (
 	"time"
 
 	"github.com/ix-pay/ixpay-pro/internal/infrastructure/observability/logger"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/observability/redact"
 
 	"github.com/gin-gonic/gin"
 )
 
 // LogMiddleware 定义日志中间件
-func LogMiddleware(logger logger.Logger) gin.HandlerFunc {
+func LogMiddleware(logger logger.Logger, redactor *redact.Redactor) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// 开始时间
 		startTime := time.Now()
@@ -24,8 +25,8 @@ func LogMiddleware(logger logger.Logger) gin.HandlerFunc {
 		latencyTime := endTime.Sub(startTime)
 		// 请求方式
 		requestMethod := c.Request.Method
-		// 请求路由
-		requestURI := c.Request.RequestURI
+		// 请求路由（查询参数脱敏）
+		requestURI := redactor.RedactURI(c.Request.RequestURI)
 		// 状态码
 		statusCode := c.Writer.Status()
 		// 请求IP
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/ix-pay/ixpay-pro/internal/infrastructure/observability/logger"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/observability/redact"
 
 	"github.com/gin-gonic/gin"
 )
@@ -14,31 +15,40 @@ import (
 
 type bodyLogWriter struct {
 	gin.ResponseWriter
-	body *bytes.Buffer
+	body  *bytes.Buffer
+	limit int // 捕获的最大字节数，超出部分只写出不缓存
 }
 
 // Write 重写Write方法，捕获响应体
 func (w bodyLogWriter) Write(b []byte) (int, error) {
-	w.body.Write(b)
+	if remain := w.limit - w.body.Len(); remain > 0 {
+		if len(b) > remain {
+			w.body.Write(b[:remain])
+		} else {
+			w.body.Write(b)
+		}
+	}
 	return w.ResponseWriter.Write(b)
 }
 
-// LoggerMiddleware 日志中间件
-func LoggerMiddleware(log logger.Logger) gin.HandlerFunc {
+// LoggerMiddleware 日志中间件，请求体和响应体经脱敏引擎处理后记录
+func LoggerMiddleware(log logger.Logger, redactor *redact.Redactor) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// 开始时间
 		tstart := time.Now()
 
-		// 获取请求信息
-		path := c.Request.URL.Path
-		method := c.Request.Method
-		ip := c.ClientIP()
+		// 配置为不记录请求体和响应体的接口直接跳过捕获
+		if redactor.SkipBody(c.Request.Method, c.Request.URL.Path) {
+			c.Next()
+			logRequest(log, c, tstart, redact.OmittedBody, redact.OmittedBody)
+			return
+		}
 
 		// 捕获请求体
 		var requestBody string
 		if c.Request.Body != nil {
 			bodyBytes, _ := c.GetRawData()
-			requestBody = string(bodyBytes)
+			requestBody = redactor.RedactBody(bodyBytes)
 			// 重新设置请求体，以便后续处理
 			c.Request.Body = io.NopCloser(bytes.NewBuffer(bodyBytes))
 		}
@@ -47,63 +57,72 @@ func LoggerMiddleware(log logger.Logger) gin.HandlerFunc {
 		w := &bodyLogWriter{
 			ResponseWriter: c.Writer,
 			body:           bytes.NewBufferString(""),
+			limit:          redactor.CaptureLimit(),
 		}
 		c.Writer = w
 
 		// 处理请求
 		c.Next()
 
-		// 计算请求处理时间
-		duration := time.Since(tstart)
-
-		// 获取状态码和响应体
-		statusCode := c.Writer.Status()
-		responseBody := w.body.String()
-
-		// 获取用户信息（如果已认证）
-		userID, _ := c.Get("userID")
-		userName, _ := c.Get("userName")
-		role, _ := c.Get("role")
-
-		// 根据状态码选择日志级别
-		if statusCode >= 500 {
-			log.Error("API请求错误",
-				"path", path,
-				"method", method,
-				"ip", ip,
-				"status", statusCode,
-				"duration", duration,
-				"userID", userID,
-				"userName", userName,
-				"role", role,
-				"requestBody", requestBody,
-				"responseBody", responseBody,
-			)
-		} else if statusCode >= 400 {
-			log.Warn("API请求警告",
-				"path", path,
-				"method", method,
-				"ip", ip,
-				"status", statusCode,
-				"duration", duration,
-				"userID", userID,
-				"userName", userName,
-				"role", role,
-				"requestBody", requestBody,
-				"responseBody", responseBody,
-			)
-		} else {
-			log.Info("API请求信息",
-				"path", path,
-				"method", method,
-				"ip", ip,
-				"status", statusCode,
-				"duration", duration,
-				"userID", userID,
-				"userName", userName,
-				"role", role,
-				// 对于成功的请求，通常不需要记录请求体和响应体，除非特别需要
-			)
-		}
+		logRequest(log, c, tstart, requestBody, redactor.RedactBody(w.body.Bytes()))
+	}
+}
+
+// logRequest 根据状态码选择日志级别记录请求
+func logRequest(log logger.Logger, c *gin.Context, tstart time.Time, requestBody, responseBody string) {
+	path := c.Request.URL.Path
+	method := c.Request.Method
+	ip := c.ClientIP()
+
+	// 计算请求处理时间
+	duration := time.Since(tstart)
+
+	// 获取状态码
+	statusCode := c.Writer.Status()
+
+	// 获取用户信息（如果已认证）
+	userID, _ := c.Get("userID")
+	userName, _ := c.Get("userName")
+	role, _ := c.Get("role")
+
+	// 根据状态码选择日志级别
+	if statusCode >= 500 {
+		log.Error("API请求错误",
+			"path", path,
+			"method", method,
+			"ip", ip,
+			"status", statusCode,
+			"duration", duration,
+			"userID", userID,
+			"userName", userName,
+			"role", role,
+			"requestBody", requestBody,
+			"responseBody", responseBody,
+		)
+	} else if statusCode >= 400 {
+		log.Warn("API请求警告",
+			"path", path,
+			"method", method,
+			"ip", ip,
+			"status", statusCode,
+			"duration", duration,
+			"userID", userID,
+			"userName", userName,
+			"role", role,
+			"requestBody", requestBody,
+			"responseBody", responseBody,
+		)
+	} else {
+		log.Info("API请求信息",
+			"path", path,
+			"method", method,
+			"ip", ip,
+			"status", statusCode,
+			"duration", duration,
+			"userID", userID,
+			"userName", userName,
+			"role", role,
+			// 对于成功的请求，通常不需要记录请求体和响应体，除非特别需要
+		)
 	}
 }
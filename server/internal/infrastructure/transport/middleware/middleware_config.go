@@ -8,6 +8,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/ix-pay/ixpay-pro/internal/infrastructure/observability/logger"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/observability/redact"
 	"github.com/ix-pay/ixpay-pro/internal/infrastructure/persistence/cache"
 	auth "github.com/ix-pay/ixpay-pro/internal/infrastructure/security/auth"
 	apperror "github.com/ix-pay/ixpay-pro/internal/infrastructure/support/error"
@@ -17,9 +18,10 @@ import (
 // 集中管理所有中间件，提供统一的中间件注册和使用机制
 type MiddlewareConfig struct {
 	// 依赖服务
-	Auth   *auth.JWTAuth
-	Logger logger.Logger
-	Cache  cache.Cache
+	Auth     *auth.JWTAuth
+	Logger   logger.Logger
+	Redactor *redact.Redactor
+	Cache    cache.Cache
 
 	// 中间件
 	AuthMiddleware          gin.HandlerFunc
@@ -39,19 +41,22 @@ type MiddlewareConfig struct {
 // 参数:
 // - jwtAuth: JWT认证服务，用于身份验证
 // - log: 日志记录器
+// - redactor: 日志脱敏引擎
 // - cache: 缓存服务
 // 返回值:
 // - 配置好的中间件配置中心实例
 func SetupMiddlewareConfig(
 	auth *auth.JWTAuth,
 	log logger.Logger,
+	redactor *redact.Redactor,
 	cache cache.Cache,
 ) *MiddlewareConfig {
 	// 创建中间件配置实例
 	mc := &MiddlewareConfig{
-		Auth:   auth,
-		Logger: log,
-		Cache:  cache,
+		Auth:     auth,
+		Logger:   log,
+		Redactor: redactor,
+		Cache:    cache,
 	}
 
 	// 设置中间件
@@ -177,7 +182,7 @@ func (mc *MiddlewareConfig) GetCacheMiddleware() (gin.HandlerFunc, bool) {
 
 // SetupLogMiddleware 设置 HTTP 请求日志中间件
 func (mc *MiddlewareConfig) SetupLogMiddleware() {
-	mc.LogMiddleware = LogMiddleware(mc.Logger)
+	mc.LogMiddleware = LogMiddleware(mc.Logger, mc.Redactor)
 }
 
 // SetupContextLoggerMiddleware 设置请求上下文日志中间件
@@ -187,12 +192,12 @@ func (mc *MiddlewareConfig) SetupContextLoggerMiddleware() {
 
 // SetupRequestLogMiddleware 设置请求日志中间件（记录到独立的 request.log 文件）
 func (mc *MiddlewareConfig) SetupRequestLogMiddleware() {
-	mc.RequestLogMiddleware = RequestLogMiddleware()
+	mc.RequestLogMiddleware = RequestLogMiddleware(mc.Redactor)
 }
 
 // SetupAuditLogMiddleware 设置审计日志中间件（记录敏感操作到 audit.log）
 func (mc *MiddlewareConfig) SetupAuditLogMiddleware() {
-	mc.AuditLogMiddleware = AuditLogMiddleware()
+	mc.AuditLogMiddleware = AuditLogMiddleware(mc.Redactor)
 }
 
 // SetupErrorMiddleware 设置错误处理中间件
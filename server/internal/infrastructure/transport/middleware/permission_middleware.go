@@ -12,8 +12,11 @@ import (
 // PermissionMiddleware 权限管理中间件
 func PermissionMiddleware(permissionManager *auth.PermissionManager) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// 获取请求路径和方法
-		path := c.Request.URL.Path
+		// 获取路由模板和方法，按 /api/admin/user/:id 这样的模板检查权限，未匹配路由时使用请求路径
+		path := c.FullPath()
+		if path == "" {
+			path = c.Request.URL.Path
+		}
 		method := c.Request.Method
 
 		// 检查用户是否有权限访问
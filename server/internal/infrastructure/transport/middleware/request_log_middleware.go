@@ -4,12 +4,13 @@ import (
 	"time"
 
 	"github.com/ix-pay/ixpay-pro/internal/infrastructure/observability/logger"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/observability/redact"
 
 	"github.com/gin-gonic/gin"
 )
 
 // RequestLogMiddleware 请求日志中间件（记录到独立的 request.log 文件）
-func RequestLogMiddleware() gin.HandlerFunc {
+func RequestLogMiddleware(redactor *redact.Redactor) gin.HandlerFunc {
 	// 获取请求日志记录器
 	requestLogger := logger.GetGlobalLogger(logger.RequestLogger)
 
@@ -26,8 +27,8 @@ func RequestLogMiddleware() gin.HandlerFunc {
 		latencyMs := endTime.Sub(startTime).Milliseconds()
 		// 请求方式
 		requestMethod := c.Request.Method
-		// 请求路由
-		requestURI := c.Request.RequestURI
+		// 请求路由（查询参数脱敏）
+		requestURI := redactor.RedactURI(c.Request.RequestURI)
 		// 状态码
 		statusCode := c.Writer.Status()
 		// 请求 IP
@@ -60,7 +61,7 @@ func RequestLogMiddleware() gin.HandlerFunc {
 }
 
 // AuditLogMiddleware 审计日志中间件（记录敏感操作到 audit.log）
-func AuditLogMiddleware() gin.HandlerFunc {
+func AuditLogMiddleware(redactor *redact.Redactor) gin.HandlerFunc {
 	// 获取审计日志记录器
 	auditLogger := logger.GetGlobalLogger(logger.AuditLogger)
 
@@ -85,8 +86,8 @@ func AuditLogMiddleware() gin.HandlerFunc {
 		clientIP := c.ClientIP()
 		// 请求方式
 		requestMethod := c.Request.Method
-		// 请求路由
-		requestURI := c.Request.RequestURI
+		// 请求路由（查询参数脱敏）
+		requestURI := redactor.RedactURI(c.Request.RequestURI)
 
 		// 构建日志字段
 		fields := []interface{}{
@@ -0,0 +1,28 @@
+package notify
+
+import (
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/observability/logger"
+)
+
+// LogSender 仅记录日志的发送器
+// 用于本地开发和测试环境，不会真实发送消息
+type LogSender struct {
+	channel string
+	log     logger.Logger
+}
+
+// NewLogSender 创建日志发送器
+func NewLogSender(channel string, log logger.Logger) *LogSender {
+	return &LogSender{channel: channel, log: log}
+}
+
+// Name 发送器名称
+func (s *LogSender) Name() string {
+	return "log"
+}
+
+// Send 将消息内容写入日志
+func (s *LogSender) Send(msg *Message) error {
+	s.log.Info("模拟发送消息", "channel", s.channel, "to", msg.To, "subject", msg.Subject, "content", msg.Content)
+	return nil
+}
@@ -0,0 +1,86 @@
+package notify
+
+import (
+	"fmt"
+
+	"github.com/ix-pay/ixpay-pro/internal/config"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/observability/logger"
+)
+
+// notify包提供邮件、短信等消息发送能力
+// 通过 Sender 接口屏蔽具体服务商实现，便于替换和测试
+// 本地开发和测试环境可使用 LogSender，仅记录日志不真实发送
+
+// 消息渠道
+const (
+	ChannelEmail = "email" // 邮件
+	ChannelSMS   = "sms"   // 短信
+)
+
+// Message 待发送的消息
+type Message struct {
+	To      string // 接收方（邮箱地址或手机号）
+	Subject string // 标题（短信忽略）
+	Content string // 正文内容
+}
+
+// Sender 消息发送接口
+type Sender interface {
+	// Name 发送器名称，用于日志
+	Name() string
+	// Send 发送消息
+	Send(msg *Message) error
+}
+
+// Senders 按渠道聚合的消息发送器
+type Senders struct {
+	Email Sender // 邮件发送器
+	SMS   Sender // 短信发送器
+}
+
+// Get 根据渠道获取发送器
+func (s *Senders) Get(channel string) (Sender, error) {
+	var sender Sender
+	switch channel {
+	case ChannelEmail:
+		sender = s.Email
+	case ChannelSMS:
+		sender = s.SMS
+	}
+	if sender == nil {
+		return nil, fmt.Errorf("不支持的消息渠道：%s", channel)
+	}
+	return sender, nil
+}
+
+// SetupSenders 根据配置初始化消息发送器
+// 参数:
+// - cfg: 应用配置，读取 notify 配置节
+// - log: 日志记录器
+// 返回:
+// - *Senders: 消息发送器集合
+// - error: 错误信息
+func SetupSenders(cfg *config.Config, log logger.Logger) (*Senders, error) {
+	senders := &Senders{}
+
+	switch cfg.Notify.EmailDriver {
+	case "smtp":
+		senders.Email = NewSMTPSender(cfg.Notify.SMTP)
+	case "", "log":
+		senders.Email = NewLogSender(ChannelEmail, log)
+	default:
+		return nil, fmt.Errorf("未知的邮件发送驱动：%s", cfg.Notify.EmailDriver)
+	}
+
+	switch cfg.Notify.SMSDriver {
+	case "http":
+		senders.SMS = NewHTTPSMSSender(cfg.Notify.SMS)
+	case "", "log":
+		senders.SMS = NewLogSender(ChannelSMS, log)
+	default:
+		return nil, fmt.Errorf("未知的短信发送驱动：%s", cfg.Notify.SMSDriver)
+	}
+
+	log.Info("消息发送器初始化完成", "email", senders.Email.Name(), "sms", senders.SMS.Name())
+	return senders, nil
+}
@@ -0,0 +1,102 @@
+package notify
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ix-pay/ixpay-pro/internal/config"
+)
+
+// HTTPSMSSender 基于 HTTP 网关的短信发送器
+// 以 JSON 形式将短信投递到网关，请求头携带 AppKey、时间戳和 HMAC-SHA256 签名
+// 签名内容为 AppKey + 时间戳 + 请求体，网关负责对接具体短信服务商
+type HTTPSMSSender struct {
+	cfg    config.SMSConfig
+	client *http.Client
+}
+
+// smsGatewayRequest 短信网关请求体
+type smsGatewayRequest struct {
+	Phone    string `json:"phone"`
+	SignName string `json:"signName"`
+	Content  string `json:"content"`
+}
+
+// smsGatewayResponse 短信网关响应体
+type smsGatewayResponse struct {
+	Code int    `json:"code"`
+	Msg  string `json:"msg"`
+}
+
+// NewHTTPSMSSender 创建 HTTP 短信网关发送器
+func NewHTTPSMSSender(cfg config.SMSConfig) *HTTPSMSSender {
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = 5
+	}
+	return &HTTPSMSSender{
+		cfg:    cfg,
+		client: &http.Client{Timeout: time.Duration(timeout) * time.Second},
+	}
+}
+
+// Name 发送器名称
+func (s *HTTPSMSSender) Name() string {
+	return "http-sms"
+}
+
+// Send 发送短信
+func (s *HTTPSMSSender) Send(msg *Message) error {
+	if s.cfg.GatewayURL == "" {
+		return errors.New("短信网关地址未配置")
+	}
+
+	body, err := json.Marshal(&smsGatewayRequest{
+		Phone:    msg.To,
+		SignName: s.cfg.SignName,
+		Content:  msg.Content,
+	})
+	if err != nil {
+		return err
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(s.cfg.AppSecret))
+	mac.Write([]byte(s.cfg.AppKey + timestamp))
+	mac.Write(body)
+
+	req, err := http.NewRequest(http.MethodPost, s.cfg.GatewayURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-App-Key", s.cfg.AppKey)
+	req.Header.Set("X-Timestamp", timestamp)
+	req.Header.Set("X-Signature", hex.EncodeToString(mac.Sum(nil)))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("请求短信网关失败：%w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("短信网关返回异常状态：%d", resp.StatusCode)
+	}
+
+	var result smsGatewayResponse
+	if err := json.Unmarshal(respBody, &result); err == nil && result.Code != 0 {
+		return fmt.Errorf("短信发送失败：%s", result.Msg)
+	}
+	return nil
+}
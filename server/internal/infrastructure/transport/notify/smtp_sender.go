@@ -0,0 +1,120 @@
+package notify
+
+import (
+	"crypto/tls"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ix-pay/ixpay-pro/internal/config"
+)
+
+// SMTPSender 基于 SMTP 协议的邮件发送器
+type SMTPSender struct {
+	cfg config.SMTPConfig
+}
+
+// NewSMTPSender 创建 SMTP 邮件发送器
+func NewSMTPSender(cfg config.SMTPConfig) *SMTPSender {
+	return &SMTPSender{cfg: cfg}
+}
+
+// Name 发送器名称
+func (s *SMTPSender) Name() string {
+	return "smtp"
+}
+
+// Send 发送邮件
+// UseSSL 为 true 时使用隐式 TLS 连接，否则在服务器支持时升级为 STARTTLS
+func (s *SMTPSender) Send(msg *Message) error {
+	if s.cfg.Host == "" || s.cfg.From == "" {
+		return errors.New("SMTP 配置不完整")
+	}
+	port := s.cfg.Port
+	if port == 0 {
+		port = 25
+	}
+	addr := net.JoinHostPort(s.cfg.Host, strconv.Itoa(port))
+	tlsConfig := &tls.Config{ServerName: s.cfg.Host}
+
+	var conn net.Conn
+	var err error
+	dialer := &net.Dialer{Timeout: 10 * time.Second}
+	if s.cfg.UseSSL {
+		conn, err = tls.DialWithDialer(dialer, "tcp", addr, tlsConfig)
+	} else {
+		conn, err = dialer.Dial("tcp", addr)
+	}
+	if err != nil {
+		return fmt.Errorf("连接 SMTP 服务器失败：%w", err)
+	}
+
+	client, err := smtp.NewClient(conn, s.cfg.Host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("创建 SMTP 客户端失败：%w", err)
+	}
+	defer client.Close()
+
+	if !s.cfg.UseSSL {
+		if ok, _ := client.Extension("STARTTLS"); ok {
+			if err := client.StartTLS(tlsConfig); err != nil {
+				return fmt.Errorf("SMTP STARTTLS 失败：%w", err)
+			}
+		}
+	}
+
+	if s.cfg.Username != "" {
+		auth := smtp.PlainAuth("", s.cfg.Username, s.cfg.Password, s.cfg.Host)
+		if err := client.Auth(auth); err != nil {
+			return fmt.Errorf("SMTP 认证失败：%w", err)
+		}
+	}
+
+	if err := client.Mail(s.cfg.From); err != nil {
+		return fmt.Errorf("设置发件人失败：%w", err)
+	}
+	if err := client.Rcpt(msg.To); err != nil {
+		return fmt.Errorf("设置收件人失败：%w", err)
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("写入邮件内容失败：%w", err)
+	}
+	if _, err := w.Write(s.buildMessage(msg)); err != nil {
+		w.Close()
+		return fmt.Errorf("写入邮件内容失败：%w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("写入邮件内容失败：%w", err)
+	}
+
+	return client.Quit()
+}
+
+// buildMessage 构造 MIME 邮件内容，标题和正文使用 UTF-8 编码
+func (s *SMTPSender) buildMessage(msg *Message) []byte {
+	var b strings.Builder
+	b.WriteString("From: " + s.cfg.From + "\r\n")
+	b.WriteString("To: " + msg.To + "\r\n")
+	b.WriteString("Subject: =?UTF-8?B?" + base64.StdEncoding.EncodeToString([]byte(msg.Subject)) + "?=\r\n")
+	b.WriteString("Date: " + time.Now().Format(time.RFC1123Z) + "\r\n")
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	b.WriteString("Content-Transfer-Encoding: base64\r\n")
+	b.WriteString("\r\n")
+
+	encoded := base64.StdEncoding.EncodeToString([]byte(msg.Content))
+	for len(encoded) > 76 {
+		b.WriteString(encoded[:76] + "\r\n")
+		encoded = encoded[76:]
+	}
+	b.WriteString(encoded + "\r\n")
+	return []byte(b.String())
+}
@@ -0,0 +1,139 @@
+package push
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/observability/logger"
+	redisClient "github.com/ix-pay/ixpay-pro/internal/infrastructure/persistence/redis"
+)
+
+// push包提供面向在线客户端的实时事件推送
+// 客户端按登录会话订阅事件，多实例部署时通过 Redis 发布订阅把事件广播到所有实例，
+// 再由持有该会话连接的实例投递给客户端
+
+// 事件类型
+const (
+	EventSessionRevoked = "session_revoked" // 会话被下线，客户端应清除令牌并跳转登录页
+	EventLoginAlert     = "login_alert"     // 账号在新设备或新地点登录，客户端应提醒用户
+)
+
+// sessionEventChannel Redis 发布订阅频道名（会加上 Redis 键前缀）
+const sessionEventChannel = "push:session-events"
+
+// subscriberBuffer 单个订阅者的事件缓冲，客户端消费过慢时丢弃新事件
+const subscriberBuffer = 8
+
+// Event 推送给客户端的事件
+type Event struct {
+	Type      string    `json:"type"`             // 事件类型
+	SessionID string    `json:"sessionId"`        // 目标会话 ID
+	Message   string    `json:"message"`          // 展示给用户的提示
+	Reason    string    `json:"reason,omitempty"` // 原因
+	Time      time.Time `json:"time"`             // 事件时间
+}
+
+// Hub 按会话 ID 管理订阅者并分发事件
+type Hub struct {
+	mu          sync.RWMutex
+	subscribers map[string]map[chan Event]struct{}
+	redis       *redisClient.RedisClient
+	log         logger.Logger
+}
+
+// NewHub 创建仅在当前进程内分发事件的推送中心
+func NewHub(log logger.Logger) *Hub {
+	return &Hub{
+		subscribers: make(map[string]map[chan Event]struct{}),
+		log:         log,
+	}
+}
+
+// SetupHub 创建推送中心并订阅 Redis 频道，接收其他实例发布的事件
+func SetupHub(redis *redisClient.RedisClient, log logger.Logger) *Hub {
+	hub := NewHub(log)
+	if redis == nil {
+		return hub
+	}
+
+	hub.redis = redis
+	pubsub := redis.Client.Subscribe(redis.GetContext(), hub.channel())
+	go func() {
+		for msg := range pubsub.Channel() {
+			var event Event
+			if err := json.Unmarshal([]byte(msg.Payload), &event); err != nil {
+				log.Warn("解析推送事件失败", "error", err)
+				continue
+			}
+			hub.dispatch(event)
+		}
+	}()
+
+	log.Info("实时推送已启用", "channel", hub.channel())
+	return hub
+}
+
+// Subscribe 订阅会话事件
+// 返回事件通道和取消订阅函数，连接断开时必须调用取消订阅
+func (h *Hub) Subscribe(sessionID string) (<-chan Event, func()) {
+	ch := make(chan Event, subscriberBuffer)
+
+	h.mu.Lock()
+	if h.subscribers[sessionID] == nil {
+		h.subscribers[sessionID] = make(map[chan Event]struct{})
+	}
+	h.subscribers[sessionID][ch] = struct{}{}
+	h.mu.Unlock()
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			h.mu.Lock()
+			defer h.mu.Unlock()
+			delete(h.subscribers[sessionID], ch)
+			if len(h.subscribers[sessionID]) == 0 {
+				delete(h.subscribers, sessionID)
+			}
+		})
+	}
+	return ch, unsubscribe
+}
+
+// Publish 发布事件
+// 启用 Redis 时广播给所有实例（包括当前实例），否则直接在当前进程内分发
+func (h *Hub) Publish(event Event) error {
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+
+	if h.redis == nil {
+		h.dispatch(event)
+		return nil
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	return h.redis.Client.Publish(h.redis.GetContext(), h.channel(), payload).Err()
+}
+
+// dispatch 将事件投递给当前实例上订阅了该会话的客户端
+func (h *Hub) dispatch(event Event) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for ch := range h.subscribers[event.SessionID] {
+		select {
+		case ch <- event:
+		default:
+			h.log.Warn("推送事件缓冲已满，丢弃事件", "session_id", event.SessionID, "type", event.Type)
+		}
+	}
+}
+
+// channel Redis 频道名
+func (h *Hub) channel() string {
+	return h.redis.PreKey + sessionEventChannel
+}
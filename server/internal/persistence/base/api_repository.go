@@ -17,6 +17,7 @@ type apiModel struct {
 	AuthType     *int   `gorm:"not null;default:0"`
 	Description  string `gorm:"size:255"`
 	Status       *int   `gorm:"not null;default:1"`
+	Orphaned     bool   `gorm:"not null;default:false"`
 }
 
 // TableName 指定表名
@@ -36,6 +37,7 @@ func (m *apiModel) toDomain() *entity.API {
 		Method:      m.Method,
 		Group:       m.Group,
 		Description: m.Description,
+		Orphaned:    m.Orphaned,
 		CreatedBy:   m.CreatedBy,
 		CreatedAt:   m.CreatedAt,
 		UpdatedBy:   m.UpdatedBy,
@@ -79,6 +81,7 @@ func fromDomainAPI(api *entity.API) (*apiModel, error) {
 		AuthType:     common.IntPtr(api.AuthType),
 		Description:  api.Description,
 		Status:       common.IntPtr(api.Status),
+		Orphaned:     api.Orphaned,
 	}, nil
 }
 
@@ -201,6 +204,25 @@ func (r *apiRepository) List(page, pageSize int, filters map[string]interface{})
 	return apis, total, nil
 }
 
+// GetUnassigned 获取需要授权但尚未分配给任何角色的有效 API 路由
+func (r *apiRepository) GetUnassigned() ([]*entity.API, error) {
+	var dbModels []apiModel
+	err := r.db.Where("auth_type = ? AND orphaned = ?", 1, false).
+		Where("NOT EXISTS (SELECT 1 FROM base_role_api_routes ra WHERE ra.route_id = base_apis.id)").
+		Order("path, method").
+		Find(&dbModels).Error
+	if err != nil {
+		return nil, err
+	}
+
+	apis := make([]*entity.API, len(dbModels))
+	for i, model := range dbModels {
+		apis[i] = model.toDomain()
+	}
+
+	return apis, nil
+}
+
 // GetAPIsByRole 根据角色获取 API 路由
 func (r *apiRepository) GetAPIsByRole(roleID int64) ([]*entity.API, error) {
 	// TODO: 实现角色关联 API 路由查询
@@ -5,6 +5,7 @@ import (
 	"github.com/ix-pay/ixpay-pro/internal/domain/base/repo"
 	"github.com/ix-pay/ixpay-pro/internal/infrastructure/persistence/database"
 	"github.com/ix-pay/ixpay-pro/internal/persistence/common"
+	"gorm.io/gorm"
 )
 
 // configModel 配置数据库模型
@@ -22,6 +23,42 @@ func (configModel) TableName() string {
 	return "base_configs"
 }
 
+// SensitiveConfigKeys 加密保存的配置项
+var SensitiveConfigKeys = []string{"wechat_app_secret", "wechat_api_key"}
+
+// isSensitiveConfigKey 判断配置项是否需要加密保存
+func isSensitiveConfigKey(configKey string) bool {
+	for _, key := range SensitiveConfigKeys {
+		if key == configKey {
+			return true
+		}
+	}
+	return false
+}
+
+// BeforeSave GORM 钩子函数，保存前加密敏感配置项
+func (m *configModel) BeforeSave(tx *gorm.DB) error {
+	if !isSensitiveConfigKey(m.ConfigKey) {
+		return nil
+	}
+	value, err := database.EncryptField(m.ConfigValue)
+	if err != nil {
+		return err
+	}
+	m.ConfigValue = value
+	return nil
+}
+
+// AfterFind GORM 钩子函数，查询后解密敏感配置项，历史明文原样返回
+func (m *configModel) AfterFind(tx *gorm.DB) error {
+	value, err := database.DecryptField(m.ConfigValue)
+	if err != nil {
+		return err
+	}
+	m.ConfigValue = value
+	return nil
+}
+
 // toDomain 将数据库模型转换为领域实体
 func (m *configModel) toDomain() *entity.Config {
 	if m == nil {
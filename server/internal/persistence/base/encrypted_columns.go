@@ -0,0 +1,12 @@
+package persistence
+
+import "github.com/ix-pay/ixpay-pro/internal/infrastructure/persistence/database"
+
+// EncryptedColumns base 应用加密保存的列，由字段重新加密任务处理
+// 机构数据库密码目前只由重新加密任务维护，读取时需使用 database.DecryptField 解密
+var EncryptedColumns = []database.EncryptedColumn{
+	{Table: "base_users", Column: "email", HashColumn: "email_hash"},
+	{Table: "base_users", Column: "phone", HashColumn: "phone_hash"},
+	{Table: "base_configs", Column: "config_value", Condition: database.InCondition("config_key", SensitiveConfigKeys...)},
+	{Table: "base_organizations", Column: "database_password"},
+}
@@ -0,0 +1,206 @@
+package persistence
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/entity"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/repo"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/persistence/database"
+	"github.com/ix-pay/ixpay-pro/internal/persistence/common"
+)
+
+// ipPolicyModel IP 访问策略数据库模型
+type ipPolicyModel struct {
+	database.SnowflakeBaseModel
+	Name        string `gorm:"size:100;not null;unique"`
+	Scope       string `gorm:"size:20;not null;index"`
+	TargetID    int64  `gorm:"not null;default:0"`
+	Action      string `gorm:"size:20;not null"`
+	CIDRs       string `gorm:"column:cidrs;type:text"`
+	Description string `gorm:"size:255"`
+	Status      *int   `gorm:"not null;default:1"`
+}
+
+// TableName 指定表名
+func (ipPolicyModel) TableName() string {
+	return "base_ip_policies"
+}
+
+// toDomain 将数据库模型转换为领域实体
+func (m *ipPolicyModel) toDomain() *entity.IPPolicy {
+	if m == nil {
+		return nil
+	}
+
+	var cidrs []string
+	if m.CIDRs != "" {
+		json.Unmarshal([]byte(m.CIDRs), &cidrs)
+	}
+
+	policy := &entity.IPPolicy{
+		ID:          m.ID,
+		Name:        m.Name,
+		Scope:       m.Scope,
+		TargetID:    m.TargetID,
+		Action:      m.Action,
+		CIDRs:       cidrs,
+		Description: m.Description,
+		CreatedBy:   m.CreatedBy,
+		CreatedAt:   m.CreatedAt,
+		UpdatedBy:   m.UpdatedBy,
+		UpdatedAt:   m.UpdatedAt,
+	}
+
+	// 安全解引用，提供默认值
+	if m.Status != nil {
+		policy.Status = *m.Status
+	} else {
+		policy.Status = entity.IPPolicyStatusEnabled
+	}
+
+	return policy
+}
+
+// fromDomainIPPolicy 将领域实体转换为数据库模型
+func fromDomainIPPolicy(policy *entity.IPPolicy) (*ipPolicyModel, error) {
+	cidrsJSON, err := json.Marshal(policy.CIDRs)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ipPolicyModel{
+		SnowflakeBaseModel: database.SnowflakeBaseModel{
+			ID:        policy.ID,
+			CreatedBy: policy.CreatedBy,
+			UpdatedBy: policy.UpdatedBy,
+		},
+		Name:        policy.Name,
+		Scope:       policy.Scope,
+		TargetID:    policy.TargetID,
+		Action:      policy.Action,
+		CIDRs:       string(cidrsJSON),
+		Description: policy.Description,
+		Status:      common.IntPtr(policy.Status),
+	}, nil
+}
+
+// ipPolicyRepository Repository 实现
+type ipPolicyRepository struct {
+	db *database.PostgresDB
+}
+
+// 确保实现接口
+var _ repo.IPPolicyRepository = (*ipPolicyRepository)(nil)
+
+// NewIPPolicyRepository 创建 IP 访问策略仓库实现
+func NewIPPolicyRepository(db *database.PostgresDB) repo.IPPolicyRepository {
+	return &ipPolicyRepository{db: db}
+}
+
+// GetByID 根据 ID 查询策略
+func (r *ipPolicyRepository) GetByID(id int64) (*entity.IPPolicy, error) {
+	var dbModel ipPolicyModel
+	if err := r.db.Where("id = ?", id).First(&dbModel).Error; err != nil {
+		return nil, err
+	}
+
+	return dbModel.toDomain(), nil
+}
+
+// GetByName 根据名称查询策略
+func (r *ipPolicyRepository) GetByName(name string) (*entity.IPPolicy, error) {
+	var dbModel ipPolicyModel
+	if err := r.db.Where("name = ?", name).First(&dbModel).Error; err != nil {
+		return nil, err
+	}
+
+	return dbModel.toDomain(), nil
+}
+
+// Create 创建策略
+func (r *ipPolicyRepository) Create(policy *entity.IPPolicy) error {
+	dbModel, err := fromDomainIPPolicy(policy)
+	if err != nil {
+		return err
+	}
+
+	if err := r.db.Create(dbModel).Error; err != nil {
+		return err
+	}
+
+	// 将生成的 ID 回写到领域实体
+	policy.ID = dbModel.ID
+	policy.CreatedAt = dbModel.CreatedAt
+	return nil
+}
+
+// Update 更新策略
+func (r *ipPolicyRepository) Update(policy *entity.IPPolicy) error {
+	dbModel, err := fromDomainIPPolicy(policy)
+	if err != nil {
+		return err
+	}
+
+	return r.db.Model(&ipPolicyModel{}).Where("id = ?", policy.ID).Updates(map[string]interface{}{
+		"name":        dbModel.Name,
+		"scope":       dbModel.Scope,
+		"target_id":   dbModel.TargetID,
+		"action":      dbModel.Action,
+		"cidrs":       dbModel.CIDRs,
+		"description": dbModel.Description,
+		"status":      dbModel.Status,
+		"updated_by":  dbModel.UpdatedBy,
+		"updated_at":  time.Now(),
+	}).Error
+}
+
+// Delete 删除策略
+func (r *ipPolicyRepository) Delete(id int64) error {
+	return r.db.Delete(&ipPolicyModel{}, id).Error
+}
+
+// List 分页查询策略列表
+func (r *ipPolicyRepository) List(page, pageSize int, filters map[string]interface{}) ([]*entity.IPPolicy, int64, error) {
+	var total int64
+	var dbModels []ipPolicyModel
+
+	query := r.db.Model(&ipPolicyModel{})
+
+	// 应用过滤条件
+	for key, value := range filters {
+		query = query.Where(key+" = ?", value)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * pageSize
+	if err := query.Order("created_at DESC").Offset(offset).Limit(pageSize).Find(&dbModels).Error; err != nil {
+		return nil, 0, err
+	}
+
+	policies := make([]*entity.IPPolicy, len(dbModels))
+	for i := range dbModels {
+		policies[i] = dbModels[i].toDomain()
+	}
+
+	return policies, total, nil
+}
+
+// ListEnabled 获取所有启用的策略
+func (r *ipPolicyRepository) ListEnabled() ([]*entity.IPPolicy, error) {
+	var dbModels []ipPolicyModel
+	if err := r.db.Where("status = ?", entity.IPPolicyStatusEnabled).
+		Order("created_at ASC").
+		Find(&dbModels).Error; err != nil {
+		return nil, err
+	}
+
+	policies := make([]*entity.IPPolicy, len(dbModels))
+	for i := range dbModels {
+		policies[i] = dbModels[i].toDomain()
+	}
+	return policies, nil
+}
@@ -0,0 +1,169 @@
+package persistence
+
+import (
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/entity"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/repo"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/persistence/database"
+	"github.com/ix-pay/ixpay-pro/internal/persistence/common"
+)
+
+// ldapGroupMappingModel LDAP 组映射数据库模型
+type ldapGroupMappingModel struct {
+	database.SnowflakeBaseModel
+	GroupDN      string `gorm:"column:group_dn;size:255;not null"`
+	RoleID       *int64 `gorm:"not null;default:0"`
+	DepartmentID *int64 `gorm:"not null;default:0"`
+	Priority     *int   `gorm:"not null;default:0"`
+	Description  string `gorm:"size:255"`
+}
+
+// TableName 指定表名
+func (ldapGroupMappingModel) TableName() string {
+	return "base_ldap_group_mappings"
+}
+
+// toDomain 将数据库模型转换为领域实体
+func (m *ldapGroupMappingModel) toDomain() *entity.LDAPGroupMapping {
+	if m == nil {
+		return nil
+	}
+
+	mapping := &entity.LDAPGroupMapping{
+		ID:          m.ID,
+		GroupDN:     m.GroupDN,
+		Description: m.Description,
+		CreatedBy:   m.CreatedBy,
+		CreatedAt:   m.CreatedAt,
+		UpdatedBy:   m.UpdatedBy,
+		UpdatedAt:   m.UpdatedAt,
+	}
+
+	// 安全解引用，提供默认值
+	if m.RoleID != nil {
+		mapping.RoleID = *m.RoleID
+	}
+	if m.DepartmentID != nil {
+		mapping.DepartmentID = *m.DepartmentID
+	}
+	if m.Priority != nil {
+		mapping.Priority = *m.Priority
+	}
+
+	return mapping
+}
+
+// fromDomainLDAPGroupMapping 将领域实体转换为数据库模型
+func fromDomainLDAPGroupMapping(mapping *entity.LDAPGroupMapping) *ldapGroupMappingModel {
+	return &ldapGroupMappingModel{
+		SnowflakeBaseModel: database.SnowflakeBaseModel{
+			ID:        mapping.ID,
+			CreatedBy: mapping.CreatedBy,
+			UpdatedBy: mapping.UpdatedBy,
+		},
+		GroupDN:      mapping.GroupDN,
+		RoleID:       common.Int64Ptr(mapping.RoleID),
+		DepartmentID: common.Int64Ptr(mapping.DepartmentID),
+		Priority:     common.IntPtr(mapping.Priority),
+		Description:  mapping.Description,
+	}
+}
+
+// ldapGroupMappingRepository Repository 实现
+type ldapGroupMappingRepository struct {
+	db *database.PostgresDB
+}
+
+// 确保实现接口
+var _ repo.LDAPGroupMappingRepository = (*ldapGroupMappingRepository)(nil)
+
+// NewLDAPGroupMappingRepository 创建 LDAP 组映射仓库实现
+func NewLDAPGroupMappingRepository(db *database.PostgresDB) repo.LDAPGroupMappingRepository {
+	return &ldapGroupMappingRepository{db: db}
+}
+
+// GetByID 根据 ID 查询映射
+func (r *ldapGroupMappingRepository) GetByID(id int64) (*entity.LDAPGroupMapping, error) {
+	var dbModel ldapGroupMappingModel
+	if err := r.db.Where("id = ?", id).First(&dbModel).Error; err != nil {
+		return nil, err
+	}
+
+	return dbModel.toDomain(), nil
+}
+
+// Create 创建映射
+func (r *ldapGroupMappingRepository) Create(mapping *entity.LDAPGroupMapping) error {
+	dbModel := fromDomainLDAPGroupMapping(mapping)
+	if err := r.db.Create(dbModel).Error; err != nil {
+		return err
+	}
+
+	// 将生成的 ID 回写到领域实体
+	mapping.ID = dbModel.ID
+	return nil
+}
+
+// Update 更新映射
+func (r *ldapGroupMappingRepository) Update(mapping *entity.LDAPGroupMapping) error {
+	dbModel := fromDomainLDAPGroupMapping(mapping)
+	return r.db.Model(&ldapGroupMappingModel{}).Where("id = ?", mapping.ID).Updates(map[string]interface{}{
+		"group_dn":      dbModel.GroupDN,
+		"role_id":       dbModel.RoleID,
+		"department_id": dbModel.DepartmentID,
+		"priority":      dbModel.Priority,
+		"description":   dbModel.Description,
+		"updated_by":    dbModel.UpdatedBy,
+	}).Error
+}
+
+// Delete 删除映射
+func (r *ldapGroupMappingRepository) Delete(id int64) error {
+	return r.db.Delete(&ldapGroupMappingModel{}, id).Error
+}
+
+// List 分页查询映射列表
+func (r *ldapGroupMappingRepository) List(page, pageSize int, filters map[string]interface{}) ([]*entity.LDAPGroupMapping, int64, error) {
+	var total int64
+	var dbModels []ldapGroupMappingModel
+
+	query := r.db.Model(&ldapGroupMappingModel{})
+
+	// 应用过滤条件
+	for key, value := range filters {
+		if key == "group_dn" {
+			query = query.Where("group_dn ILIKE ?", "%"+value.(string)+"%")
+			continue
+		}
+		query = query.Where(key+" = ?", value)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * pageSize
+	if err := query.Order("priority DESC, id ASC").Offset(offset).Limit(pageSize).Find(&dbModels).Error; err != nil {
+		return nil, 0, err
+	}
+
+	mappings := make([]*entity.LDAPGroupMapping, len(dbModels))
+	for i := range dbModels {
+		mappings[i] = dbModels[i].toDomain()
+	}
+
+	return mappings, total, nil
+}
+
+// GetAll 获取全部映射，按优先级从高到低排序
+func (r *ldapGroupMappingRepository) GetAll() ([]*entity.LDAPGroupMapping, error) {
+	var dbModels []ldapGroupMappingModel
+	if err := r.db.Order("priority DESC, id ASC").Find(&dbModels).Error; err != nil {
+		return nil, err
+	}
+
+	mappings := make([]*entity.LDAPGroupMapping, len(dbModels))
+	for i := range dbModels {
+		mappings[i] = dbModels[i].toDomain()
+	}
+	return mappings, nil
+}
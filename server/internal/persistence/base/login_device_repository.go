@@ -0,0 +1,132 @@
+package persistence
+
+import (
+	"time"
+
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/entity"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/repo"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/persistence/database"
+)
+
+// loginDeviceModel 用户常用登录设备数据库模型
+type loginDeviceModel struct {
+	database.SnowflakeBaseModel
+	UserID      int64     `gorm:"not null;uniqueIndex:idx_login_device_user_fingerprint"`
+	Fingerprint string    `gorm:"size:64;not null;uniqueIndex:idx_login_device_user_fingerprint"`
+	Browser     string    `gorm:"size:50"`
+	OS          string    `gorm:"size:50"`
+	LastIP      string    `gorm:"size:50"`
+	LastPlace   string    `gorm:"size:100"`
+	LoginCount  int       `gorm:"not null;default:0"`
+	FirstSeenAt time.Time `gorm:"not null"`
+	LastSeenAt  time.Time `gorm:"not null"`
+}
+
+// TableName 指定表名
+func (loginDeviceModel) TableName() string {
+	return "base_login_devices"
+}
+
+// toDomain 将数据库模型转换为领域实体
+func (m *loginDeviceModel) toDomain() *entity.LoginDevice {
+	if m == nil {
+		return nil
+	}
+	return &entity.LoginDevice{
+		ID:          m.ID,
+		UserID:      m.UserID,
+		Fingerprint: m.Fingerprint,
+		Browser:     m.Browser,
+		OS:          m.OS,
+		LastIP:      m.LastIP,
+		LastPlace:   m.LastPlace,
+		LoginCount:  m.LoginCount,
+		FirstSeenAt: m.FirstSeenAt,
+		LastSeenAt:  m.LastSeenAt,
+	}
+}
+
+// fromDomainLoginDevice 将领域实体转换为数据库模型
+func fromDomainLoginDevice(device *entity.LoginDevice) *loginDeviceModel {
+	return &loginDeviceModel{
+		SnowflakeBaseModel: database.SnowflakeBaseModel{
+			ID:        device.ID,
+			CreatedBy: device.UserID,
+			UpdatedBy: device.UserID,
+		},
+		UserID:      device.UserID,
+		Fingerprint: device.Fingerprint,
+		Browser:     device.Browser,
+		OS:          device.OS,
+		LastIP:      device.LastIP,
+		LastPlace:   device.LastPlace,
+		LoginCount:  device.LoginCount,
+		FirstSeenAt: device.FirstSeenAt,
+		LastSeenAt:  device.LastSeenAt,
+	}
+}
+
+// loginDeviceRepository Repository 实现
+type loginDeviceRepository struct {
+	db *database.PostgresDB
+}
+
+// 确保实现接口
+var _ repo.LoginDeviceRepository = (*loginDeviceRepository)(nil)
+
+// NewLoginDeviceRepository 创建用户常用登录设备仓库实现
+func NewLoginDeviceRepository(db *database.PostgresDB) repo.LoginDeviceRepository {
+	return &loginDeviceRepository{db: db}
+}
+
+// GetByFingerprint 查询用户的指定设备
+func (r *loginDeviceRepository) GetByFingerprint(userID int64, fingerprint string) (*entity.LoginDevice, error) {
+	var dbModel loginDeviceModel
+	if err := r.db.Where("user_id = ? AND fingerprint = ?", userID, fingerprint).First(&dbModel).Error; err != nil {
+		return nil, err
+	}
+	return dbModel.toDomain(), nil
+}
+
+// CountByUser 统计用户的设备数
+func (r *loginDeviceRepository) CountByUser(userID int64) (int64, error) {
+	var count int64
+	err := r.db.Model(&loginDeviceModel{}).Where("user_id = ?", userID).Count(&count).Error
+	return count, err
+}
+
+// Save 保存设备，ID 为 0 时新建，否则更新最近登录信息
+func (r *loginDeviceRepository) Save(device *entity.LoginDevice) error {
+	if device.ID == 0 {
+		dbModel := fromDomainLoginDevice(device)
+		if err := r.db.Create(dbModel).Error; err != nil {
+			return err
+		}
+		device.ID = dbModel.ID
+		return nil
+	}
+
+	return r.db.Model(&loginDeviceModel{}).Where("id = ?", device.ID).Updates(map[string]interface{}{
+		"browser":      device.Browser,
+		"os":           device.OS,
+		"last_ip":      device.LastIP,
+		"last_place":   device.LastPlace,
+		"login_count":  device.LoginCount,
+		"last_seen_at": device.LastSeenAt,
+		"updated_by":   device.UserID,
+	}).Error
+}
+
+// ListByUser 查询用户的设备，按最近登录时间倒序
+func (r *loginDeviceRepository) ListByUser(userID int64) ([]*entity.LoginDevice, error) {
+	var dbModels []loginDeviceModel
+	if err := r.db.Where("user_id = ?", userID).Order("last_seen_at DESC").Find(&dbModels).Error; err != nil {
+		return nil, err
+	}
+
+	devices := make([]*entity.LoginDevice, len(dbModels))
+	for i := range dbModels {
+		devices[i] = dbModels[i].toDomain()
+	}
+	return devices, nil
+}
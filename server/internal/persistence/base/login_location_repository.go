@@ -0,0 +1,123 @@
+package persistence
+
+import (
+	"time"
+
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/entity"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/repo"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/persistence/database"
+)
+
+// loginLocationModel 用户常用登录地点数据库模型
+type loginLocationModel struct {
+	database.SnowflakeBaseModel
+	UserID     int64     `gorm:"not null;uniqueIndex:idx_login_location_user_place"`
+	Country    string    `gorm:"size:50;not null;uniqueIndex:idx_login_location_user_place"`
+	Province   string    `gorm:"size:50"`
+	City       string    `gorm:"size:50;not null;uniqueIndex:idx_login_location_user_place"`
+	Latitude   float64   `gorm:"not null;default:0"`
+	Longitude  float64   `gorm:"not null;default:0"`
+	LastIP     string    `gorm:"size:50"`
+	LastSeenAt time.Time `gorm:"not null;index"`
+}
+
+// TableName 指定表名
+func (loginLocationModel) TableName() string {
+	return "base_login_locations"
+}
+
+// toDomain 将数据库模型转换为领域实体
+func (m *loginLocationModel) toDomain() *entity.LoginLocation {
+	if m == nil {
+		return nil
+	}
+	return &entity.LoginLocation{
+		ID:         m.ID,
+		UserID:     m.UserID,
+		Country:    m.Country,
+		Province:   m.Province,
+		City:       m.City,
+		Latitude:   m.Latitude,
+		Longitude:  m.Longitude,
+		LastIP:     m.LastIP,
+		LastSeenAt: m.LastSeenAt,
+	}
+}
+
+// fromDomainLoginLocation 将领域实体转换为数据库模型
+func fromDomainLoginLocation(location *entity.LoginLocation) *loginLocationModel {
+	return &loginLocationModel{
+		SnowflakeBaseModel: database.SnowflakeBaseModel{
+			ID:        location.ID,
+			CreatedBy: location.UserID,
+			UpdatedBy: location.UserID,
+		},
+		UserID:     location.UserID,
+		Country:    location.Country,
+		Province:   location.Province,
+		City:       location.City,
+		Latitude:   location.Latitude,
+		Longitude:  location.Longitude,
+		LastIP:     location.LastIP,
+		LastSeenAt: location.LastSeenAt,
+	}
+}
+
+// loginLocationRepository Repository 实现
+type loginLocationRepository struct {
+	db *database.PostgresDB
+}
+
+// 确保实现接口
+var _ repo.LoginLocationRepository = (*loginLocationRepository)(nil)
+
+// NewLoginLocationRepository 创建用户常用登录地点仓库实现
+func NewLoginLocationRepository(db *database.PostgresDB) repo.LoginLocationRepository {
+	return &loginLocationRepository{db: db}
+}
+
+// Get 查询用户的指定地点
+func (r *loginLocationRepository) Get(userID int64, country, city string) (*entity.LoginLocation, error) {
+	var dbModel loginLocationModel
+	if err := r.db.Where("user_id = ? AND country = ? AND city = ?", userID, country, city).First(&dbModel).Error; err != nil {
+		return nil, err
+	}
+	return dbModel.toDomain(), nil
+}
+
+// HasCountry 用户是否在指定国家登录过
+func (r *loginLocationRepository) HasCountry(userID int64, country string) (bool, error) {
+	var count int64
+	err := r.db.Model(&loginLocationModel{}).Where("user_id = ? AND country = ?", userID, country).Count(&count).Error
+	return count > 0, err
+}
+
+// GetLatest 查询用户最近一次登录的地点
+func (r *loginLocationRepository) GetLatest(userID int64) (*entity.LoginLocation, error) {
+	var dbModel loginLocationModel
+	if err := r.db.Where("user_id = ?", userID).Order("last_seen_at DESC").First(&dbModel).Error; err != nil {
+		return nil, err
+	}
+	return dbModel.toDomain(), nil
+}
+
+// Save 保存地点，ID 为 0 时新建，否则更新最近登录信息
+func (r *loginLocationRepository) Save(location *entity.LoginLocation) error {
+	if location.ID == 0 {
+		dbModel := fromDomainLoginLocation(location)
+		if err := r.db.Create(dbModel).Error; err != nil {
+			return err
+		}
+		location.ID = dbModel.ID
+		return nil
+	}
+
+	return r.db.Model(&loginLocationModel{}).Where("id = ?", location.ID).Updates(map[string]interface{}{
+		"province":     location.Province,
+		"latitude":     location.Latitude,
+		"longitude":    location.Longitude,
+		"last_ip":      location.LastIP,
+		"last_seen_at": location.LastSeenAt,
+		"updated_by":   location.UserID,
+	}).Error
+}
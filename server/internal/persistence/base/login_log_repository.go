@@ -136,6 +136,7 @@ func (r *loginLogRepository) List(page, pageSize int, filters map[string]interfa
 	var dbModels []loginLogModel
 
 	query := r.db.Model(&loginLogModel{})
+	query, filters = common.ApplyDataScope(query, filters, "", "user_id")
 
 	// 应用过滤条件
 	for key, value := range filters {
@@ -308,3 +309,33 @@ func (r *loginLogRepository) ClearByTimeRange(startTime, endTime time.Time) erro
 	result := r.db.Where("login_time >= ? AND login_time <= ?", startTime, endTime).Delete(&loginLogModel{})
 	return result.Error
 }
+
+// ListByPlaces 按主键顺序查询登录地点为指定值的登录日志，用于分批回填登录地点
+func (r *loginLogRepository) ListByPlaces(places []string, afterID int64, limit int) ([]*entity.LoginLog, error) {
+	var dbModels []loginLogModel
+	result := r.db.Where("id > ? AND login_place IN (?)", afterID, places).
+		Order("id").
+		Limit(limit).
+		Find(&dbModels)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	logs := make([]*entity.LoginLog, len(dbModels))
+	for i, model := range dbModels {
+		logs[i] = model.toDomain()
+	}
+
+	return logs, nil
+}
+
+// UpdatePlace 更新登录地点，以原登录地点作为条件，返回是否更新
+func (r *loginLogRepository) UpdatePlace(id int64, oldPlace, place string) (bool, error) {
+	result := r.db.Model(&loginLogModel{}).
+		Where("id = ? AND login_place = ?", id, oldPlace).
+		UpdateColumn("login_place", place)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
@@ -0,0 +1,170 @@
+package persistence
+
+import (
+	"time"
+
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/entity"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/repo"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/persistence/database"
+	"gorm.io/gorm"
+)
+
+// loginSecurityEventModel 登录安全事件数据库模型
+type loginSecurityEventModel struct {
+	database.SnowflakeBaseModel
+	UserID        int64  `gorm:"not null;index"`
+	Username      string `gorm:"size:50"`
+	Type          string `gorm:"size:30;not null;index"`
+	RiskLevel     string `gorm:"size:10;not null"`
+	IP            string `gorm:"size:50"`
+	Place         string `gorm:"size:100"`
+	Device        string `gorm:"size:100"`
+	Fingerprint   string `gorm:"size:64"`
+	PreviousIP    string `gorm:"size:50"`
+	PreviousPlace string `gorm:"size:100"`
+	PreviousTime  *time.Time
+	DistanceKm    float64 `gorm:"not null;default:0"`
+	Description   string  `gorm:"size:500"`
+	StepUpStatus  string  `gorm:"size:20"`
+}
+
+// TableName 指定表名
+func (loginSecurityEventModel) TableName() string {
+	return "base_login_security_events"
+}
+
+// toDomain 将数据库模型转换为领域实体
+func (m *loginSecurityEventModel) toDomain() *entity.LoginSecurityEvent {
+	if m == nil {
+		return nil
+	}
+	event := &entity.LoginSecurityEvent{
+		ID:            m.ID,
+		UserID:        m.UserID,
+		Username:      m.Username,
+		Type:          m.Type,
+		RiskLevel:     m.RiskLevel,
+		IP:            m.IP,
+		Place:         m.Place,
+		Device:        m.Device,
+		Fingerprint:   m.Fingerprint,
+		PreviousIP:    m.PreviousIP,
+		PreviousPlace: m.PreviousPlace,
+		DistanceKm:    m.DistanceKm,
+		Description:   m.Description,
+		StepUpStatus:  m.StepUpStatus,
+		CreatedAt:     m.CreatedAt,
+	}
+	if m.PreviousTime != nil {
+		event.PreviousTime = *m.PreviousTime
+	}
+	return event
+}
+
+// fromDomainLoginSecurityEvent 将领域实体转换为数据库模型
+func fromDomainLoginSecurityEvent(event *entity.LoginSecurityEvent) *loginSecurityEventModel {
+	model := &loginSecurityEventModel{
+		SnowflakeBaseModel: database.SnowflakeBaseModel{
+			ID:        event.ID,
+			CreatedBy: event.UserID,
+			UpdatedBy: event.UserID,
+		},
+		UserID:        event.UserID,
+		Username:      event.Username,
+		Type:          event.Type,
+		RiskLevel:     event.RiskLevel,
+		IP:            event.IP,
+		Place:         event.Place,
+		Device:        event.Device,
+		Fingerprint:   event.Fingerprint,
+		PreviousIP:    event.PreviousIP,
+		PreviousPlace: event.PreviousPlace,
+		DistanceKm:    event.DistanceKm,
+		Description:   event.Description,
+		StepUpStatus:  event.StepUpStatus,
+	}
+	if !event.PreviousTime.IsZero() {
+		previousTime := event.PreviousTime
+		model.PreviousTime = &previousTime
+	}
+	return model
+}
+
+// loginSecurityEventRepository Repository 实现
+type loginSecurityEventRepository struct {
+	db *database.PostgresDB
+}
+
+// 确保实现接口
+var _ repo.LoginSecurityEventRepository = (*loginSecurityEventRepository)(nil)
+
+// NewLoginSecurityEventRepository 创建登录安全事件仓库实现
+func NewLoginSecurityEventRepository(db *database.PostgresDB) repo.LoginSecurityEventRepository {
+	return &loginSecurityEventRepository{db: db}
+}
+
+// CreateBatch 在同一事务中写入多条事件
+func (r *loginSecurityEventRepository) CreateBatch(events []*entity.LoginSecurityEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for _, event := range events {
+			dbModel := fromDomainLoginSecurityEvent(event)
+			if err := tx.Create(dbModel).Error; err != nil {
+				return err
+			}
+			// 将生成的 ID 回写到领域实体
+			event.ID = dbModel.ID
+			event.CreatedAt = dbModel.CreatedAt
+		}
+		return nil
+	})
+}
+
+// UpdateStepUpStatus 更新事件的二次验证状态
+func (r *loginSecurityEventRepository) UpdateStepUpStatus(ids []int64, status string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return r.db.Model(&loginSecurityEventModel{}).Where("id IN ?", ids).Update("step_up_status", status).Error
+}
+
+// List 分页查询登录安全事件
+func (r *loginSecurityEventRepository) List(page, pageSize int, filters map[string]interface{}) ([]*entity.LoginSecurityEvent, int64, error) {
+	var total int64
+	var dbModels []loginSecurityEventModel
+
+	query := r.db.Model(&loginSecurityEventModel{})
+
+	// 应用过滤条件
+	for key, value := range filters {
+		switch key {
+		case "userName":
+			query = query.Where("username ILIKE ?", "%"+value.(string)+"%")
+		case "start_time":
+			query = query.Where("created_at >= ?", value)
+		case "end_time":
+			query = query.Where("created_at < ?", value)
+		default:
+			query = query.Where(key+" = ?", value)
+		}
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * pageSize
+	if err := query.Order("created_at DESC").Offset(offset).Limit(pageSize).Find(&dbModels).Error; err != nil {
+		return nil, 0, err
+	}
+
+	events := make([]*entity.LoginSecurityEvent, len(dbModels))
+	for i := range dbModels {
+		events[i] = dbModels[i].toDomain()
+	}
+
+	return events, total, nil
+}
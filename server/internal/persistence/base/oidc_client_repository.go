@@ -0,0 +1,301 @@
+package persistence
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/entity"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/repo"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/persistence/database"
+	"github.com/ix-pay/ixpay-pro/internal/persistence/common"
+	"gorm.io/gorm"
+)
+
+// oidcClientModel OIDC 客户端数据库模型
+type oidcClientModel struct {
+	database.SnowflakeBaseModel
+	ClientID     string `gorm:"size:64;not null;unique"`
+	Name         string `gorm:"size:100;not null"`
+	Description  string `gorm:"size:500"`
+	SecretHash   string `gorm:"size:64"`
+	Public       *bool  `gorm:"not null;default:false"`
+	RedirectURIs string `gorm:"column:redirect_uris;type:text"`
+	GrantTypes   string `gorm:"size:255"`
+	Scopes       string `gorm:"size:255"`
+	SkipConsent  *bool  `gorm:"not null;default:false"`
+	Status       *int   `gorm:"not null;default:1"`
+}
+
+// TableName 指定表名
+func (oidcClientModel) TableName() string {
+	return "base_oidc_clients"
+}
+
+// oidcConsentModel OIDC 用户授权记录数据库模型
+type oidcConsentModel struct {
+	database.SnowflakeBaseModel
+	UserID   int64  `gorm:"not null;index"`
+	ClientID int64  `gorm:"not null;index"`
+	Scopes   string `gorm:"size:255"`
+}
+
+// TableName 指定表名
+func (oidcConsentModel) TableName() string {
+	return "base_oidc_consents"
+}
+
+// toDomain 将数据库模型转换为领域实体
+func (m *oidcClientModel) toDomain() *entity.OIDCClient {
+	if m == nil {
+		return nil
+	}
+
+	var redirectURIs []string
+	if m.RedirectURIs != "" {
+		json.Unmarshal([]byte(m.RedirectURIs), &redirectURIs)
+	}
+
+	client := &entity.OIDCClient{
+		ID:           m.ID,
+		ClientID:     m.ClientID,
+		Name:         m.Name,
+		Description:  m.Description,
+		SecretHash:   m.SecretHash,
+		RedirectURIs: redirectURIs,
+		GrantTypes:   strings.Fields(m.GrantTypes),
+		Scopes:       strings.Fields(m.Scopes),
+		CreatedBy:    m.CreatedBy,
+		CreatedAt:    m.CreatedAt,
+		UpdatedBy:    m.UpdatedBy,
+		UpdatedAt:    m.UpdatedAt,
+	}
+
+	// 安全解引用，提供默认值
+	if m.Public != nil {
+		client.Public = *m.Public
+	}
+	if m.SkipConsent != nil {
+		client.SkipConsent = *m.SkipConsent
+	}
+	if m.Status != nil {
+		client.Status = *m.Status
+	} else {
+		client.Status = entity.OIDCClientStatusEnabled
+	}
+
+	return client
+}
+
+// fromDomainOIDCClient 将领域实体转换为数据库模型
+func fromDomainOIDCClient(client *entity.OIDCClient) (*oidcClientModel, error) {
+	redirectURIsJSON := ""
+	if len(client.RedirectURIs) > 0 {
+		jsonData, err := json.Marshal(client.RedirectURIs)
+		if err != nil {
+			return nil, err
+		}
+		redirectURIsJSON = string(jsonData)
+	}
+
+	return &oidcClientModel{
+		SnowflakeBaseModel: database.SnowflakeBaseModel{
+			ID:        client.ID,
+			CreatedBy: client.CreatedBy,
+			UpdatedBy: client.UpdatedBy,
+		},
+		ClientID:     client.ClientID,
+		Name:         client.Name,
+		Description:  client.Description,
+		SecretHash:   client.SecretHash,
+		Public:       common.BoolPtr(client.Public),
+		RedirectURIs: redirectURIsJSON,
+		GrantTypes:   strings.Join(client.GrantTypes, " "),
+		Scopes:       strings.Join(client.Scopes, " "),
+		SkipConsent:  common.BoolPtr(client.SkipConsent),
+		Status:       common.IntPtr(client.Status),
+	}, nil
+}
+
+// toDomain 将数据库模型转换为领域实体
+func (m *oidcConsentModel) toDomain() *entity.OIDCConsent {
+	if m == nil {
+		return nil
+	}
+	return &entity.OIDCConsent{
+		ID:        m.ID,
+		UserID:    m.UserID,
+		ClientID:  m.ClientID,
+		Scopes:    strings.Fields(m.Scopes),
+		CreatedAt: m.CreatedAt,
+		UpdatedAt: m.UpdatedAt,
+	}
+}
+
+// oidcClientRepository Repository 实现
+type oidcClientRepository struct {
+	db *database.PostgresDB
+}
+
+// 确保实现接口
+var _ repo.OIDCClientRepository = (*oidcClientRepository)(nil)
+
+// NewOIDCClientRepository 创建 OIDC 客户端仓库实现
+func NewOIDCClientRepository(db *database.PostgresDB) repo.OIDCClientRepository {
+	return &oidcClientRepository{db: db}
+}
+
+// GetByID 根据 ID 查询客户端
+func (r *oidcClientRepository) GetByID(id int64) (*entity.OIDCClient, error) {
+	var dbModel oidcClientModel
+	if err := r.db.Where("id = ?", id).First(&dbModel).Error; err != nil {
+		return nil, err
+	}
+
+	return dbModel.toDomain(), nil
+}
+
+// GetByClientID 根据客户端标识查询客户端
+func (r *oidcClientRepository) GetByClientID(clientID string) (*entity.OIDCClient, error) {
+	var dbModel oidcClientModel
+	if err := r.db.Where("client_id = ?", clientID).First(&dbModel).Error; err != nil {
+		return nil, err
+	}
+
+	return dbModel.toDomain(), nil
+}
+
+// Create 创建客户端
+func (r *oidcClientRepository) Create(client *entity.OIDCClient) error {
+	dbModel, err := fromDomainOIDCClient(client)
+	if err != nil {
+		return err
+	}
+
+	if err := r.db.Create(dbModel).Error; err != nil {
+		return err
+	}
+
+	// 将生成的 ID 回写到领域实体
+	client.ID = dbModel.ID
+	client.CreatedAt = dbModel.CreatedAt
+	client.UpdatedAt = dbModel.UpdatedAt
+	return nil
+}
+
+// Update 更新客户端（客户端标识、类型和密钥不在此处修改）
+func (r *oidcClientRepository) Update(client *entity.OIDCClient) error {
+	dbModel, err := fromDomainOIDCClient(client)
+	if err != nil {
+		return err
+	}
+
+	return r.db.Model(&oidcClientModel{}).Where("id = ?", client.ID).Updates(map[string]interface{}{
+		"name":          dbModel.Name,
+		"description":   dbModel.Description,
+		"redirect_uris": dbModel.RedirectURIs,
+		"grant_types":   dbModel.GrantTypes,
+		"scopes":        dbModel.Scopes,
+		"skip_consent":  dbModel.SkipConsent,
+		"status":        dbModel.Status,
+		"updated_by":    dbModel.UpdatedBy,
+		"updated_at":    time.Now(),
+	}).Error
+}
+
+// UpdateSecret 更新客户端密钥哈希
+func (r *oidcClientRepository) UpdateSecret(id int64, secretHash string, operatorID int64) error {
+	return r.db.Model(&oidcClientModel{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"secret_hash": secretHash,
+		"updated_by":  operatorID,
+		"updated_at":  time.Now(),
+	}).Error
+}
+
+// Delete 删除客户端及用户授权记录
+func (r *oidcClientRepository) Delete(id int64) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Where("client_id = ?", id).Delete(&oidcConsentModel{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&oidcClientModel{}, id).Error
+	})
+}
+
+// List 分页查询客户端列表
+func (r *oidcClientRepository) List(page, pageSize int, filters map[string]interface{}) ([]*entity.OIDCClient, int64, error) {
+	var total int64
+	var dbModels []oidcClientModel
+
+	query := r.db.Model(&oidcClientModel{})
+
+	// 应用过滤条件
+	for key, value := range filters {
+		if key == "name" {
+			query = query.Where("name ILIKE ?", "%"+value.(string)+"%")
+		} else {
+			query = query.Where(key+" = ?", value)
+		}
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * pageSize
+	if err := query.Order("created_at DESC").Offset(offset).Limit(pageSize).Find(&dbModels).Error; err != nil {
+		return nil, 0, err
+	}
+
+	clients := make([]*entity.OIDCClient, len(dbModels))
+	for i := range dbModels {
+		clients[i] = dbModels[i].toDomain()
+	}
+
+	return clients, total, nil
+}
+
+// GetConsent 查询用户对客户端的授权记录
+func (r *oidcClientRepository) GetConsent(userID, clientID int64) (*entity.OIDCConsent, error) {
+	var dbModel oidcConsentModel
+	if err := r.db.Where("user_id = ? AND client_id = ?", userID, clientID).First(&dbModel).Error; err != nil {
+		return nil, err
+	}
+
+	return dbModel.toDomain(), nil
+}
+
+// SaveConsent 保存授权记录，已存在时更新授权范围
+func (r *oidcClientRepository) SaveConsent(consent *entity.OIDCConsent) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var dbModel oidcConsentModel
+		err := tx.Where("user_id = ? AND client_id = ?", consent.UserID, consent.ClientID).First(&dbModel).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			dbModel = oidcConsentModel{
+				SnowflakeBaseModel: database.SnowflakeBaseModel{CreatedBy: consent.UserID, UpdatedBy: consent.UserID},
+				UserID:             consent.UserID,
+				ClientID:           consent.ClientID,
+				Scopes:             strings.Join(consent.Scopes, " "),
+			}
+			if err := tx.Create(&dbModel).Error; err != nil {
+				return err
+			}
+			consent.ID = dbModel.ID
+			consent.CreatedAt = dbModel.CreatedAt
+			consent.UpdatedAt = dbModel.UpdatedAt
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		consent.ID = dbModel.ID
+		consent.UpdatedAt = time.Now()
+		return tx.Model(&oidcConsentModel{}).Where("id = ?", dbModel.ID).Updates(map[string]interface{}{
+			"scopes":     strings.Join(consent.Scopes, " "),
+			"updated_by": consent.UserID,
+			"updated_at": consent.UpdatedAt,
+		}).Error
+	})
+}
@@ -0,0 +1,341 @@
+package persistence
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/entity"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/repo"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/persistence/database"
+	"github.com/ix-pay/ixpay-pro/internal/persistence/common"
+	"gorm.io/gorm"
+)
+
+// oidcProviderModel OIDC 身份提供方数据库模型
+type oidcProviderModel struct {
+	database.SnowflakeBaseModel
+	Code                  string `gorm:"size:50;not null;unique"`
+	Name                  string `gorm:"size:100;not null"`
+	Issuer                string `gorm:"size:255;not null"`
+	ClientID              string `gorm:"size:255;not null"`
+	ClientSecret          string `gorm:"size:500"`
+	TokenAuthMethod       string `gorm:"size:50"`
+	Scopes                string `gorm:"size:500"`
+	RedirectURI           string `gorm:"size:500;not null"`
+	AuthorizationEndpoint string `gorm:"size:500"`
+	TokenEndpoint         string `gorm:"size:500"`
+	UserinfoEndpoint      string `gorm:"size:500"`
+	JWKSURI               string `gorm:"column:jwks_uri;size:500"`
+	LinkBy                string `gorm:"size:20"`
+	TrustEmail            *bool  `gorm:"not null;default:false"`
+	AutoCreate            *bool  `gorm:"not null;default:false"`
+	UsernameClaim         string `gorm:"size:100"`
+	RoleMappings          string `gorm:"type:text"`
+	Status                *int   `gorm:"not null;default:1"`
+	Sort                  *int   `gorm:"not null;default:0"`
+}
+
+// TableName 指定表名
+func (oidcProviderModel) TableName() string {
+	return "base_oidc_providers"
+}
+
+// userOIDCIdentityModel 用户 OIDC 身份绑定数据库模型
+type userOIDCIdentityModel struct {
+	database.SnowflakeBaseModel
+	ProviderID  int64      `gorm:"not null;index"`
+	Subject     string     `gorm:"size:255;not null"`
+	UserID      int64      `gorm:"not null;index"`
+	Email       string     `gorm:"size:255"`
+	LastLoginAt *time.Time `gorm:"column:last_login_at"`
+}
+
+// TableName 指定表名
+func (userOIDCIdentityModel) TableName() string {
+	return "base_user_oidc_identities"
+}
+
+// toDomain 将数据库模型转换为领域实体
+func (m *oidcProviderModel) toDomain() *entity.OIDCProvider {
+	if m == nil {
+		return nil
+	}
+
+	var roleMappings []*entity.OIDCRoleMapping
+	if m.RoleMappings != "" {
+		json.Unmarshal([]byte(m.RoleMappings), &roleMappings)
+	}
+
+	provider := &entity.OIDCProvider{
+		ID:                    m.ID,
+		Code:                  m.Code,
+		Name:                  m.Name,
+		Issuer:                m.Issuer,
+		ClientID:              m.ClientID,
+		ClientSecret:          m.ClientSecret,
+		TokenAuthMethod:       m.TokenAuthMethod,
+		Scopes:                strings.Fields(m.Scopes),
+		RedirectURI:           m.RedirectURI,
+		AuthorizationEndpoint: m.AuthorizationEndpoint,
+		TokenEndpoint:         m.TokenEndpoint,
+		UserinfoEndpoint:      m.UserinfoEndpoint,
+		JWKSURI:               m.JWKSURI,
+		LinkBy:                m.LinkBy,
+		UsernameClaim:         m.UsernameClaim,
+		RoleMappings:          roleMappings,
+		CreatedBy:             m.CreatedBy,
+		CreatedAt:             m.CreatedAt,
+		UpdatedBy:             m.UpdatedBy,
+		UpdatedAt:             m.UpdatedAt,
+	}
+
+	// 安全解引用，提供默认值
+	if m.TrustEmail != nil {
+		provider.TrustEmail = *m.TrustEmail
+	}
+	if m.AutoCreate != nil {
+		provider.AutoCreate = *m.AutoCreate
+	}
+	if m.Status != nil {
+		provider.Status = *m.Status
+	} else {
+		provider.Status = entity.OIDCProviderStatusEnabled
+	}
+	if m.Sort != nil {
+		provider.Sort = *m.Sort
+	}
+
+	return provider
+}
+
+// fromDomainOIDCProvider 将领域实体转换为数据库模型
+func fromDomainOIDCProvider(provider *entity.OIDCProvider) (*oidcProviderModel, error) {
+	roleMappingsJSON := ""
+	if len(provider.RoleMappings) > 0 {
+		jsonData, err := json.Marshal(provider.RoleMappings)
+		if err != nil {
+			return nil, err
+		}
+		roleMappingsJSON = string(jsonData)
+	}
+
+	return &oidcProviderModel{
+		SnowflakeBaseModel: database.SnowflakeBaseModel{
+			ID:        provider.ID,
+			CreatedBy: provider.CreatedBy,
+			UpdatedBy: provider.UpdatedBy,
+		},
+		Code:                  provider.Code,
+		Name:                  provider.Name,
+		Issuer:                provider.Issuer,
+		ClientID:              provider.ClientID,
+		ClientSecret:          provider.ClientSecret,
+		TokenAuthMethod:       provider.TokenAuthMethod,
+		Scopes:                strings.Join(provider.Scopes, " "),
+		RedirectURI:           provider.RedirectURI,
+		AuthorizationEndpoint: provider.AuthorizationEndpoint,
+		TokenEndpoint:         provider.TokenEndpoint,
+		UserinfoEndpoint:      provider.UserinfoEndpoint,
+		JWKSURI:               provider.JWKSURI,
+		LinkBy:                provider.LinkBy,
+		TrustEmail:            common.BoolPtr(provider.TrustEmail),
+		AutoCreate:            common.BoolPtr(provider.AutoCreate),
+		UsernameClaim:         provider.UsernameClaim,
+		RoleMappings:          roleMappingsJSON,
+		Status:                common.IntPtr(provider.Status),
+		Sort:                  common.IntPtr(provider.Sort),
+	}, nil
+}
+
+// toDomain 将数据库模型转换为领域实体
+func (m *userOIDCIdentityModel) toDomain() *entity.UserOIDCIdentity {
+	if m == nil {
+		return nil
+	}
+	return &entity.UserOIDCIdentity{
+		ID:          m.ID,
+		ProviderID:  m.ProviderID,
+		Subject:     m.Subject,
+		UserID:      m.UserID,
+		Email:       m.Email,
+		LastLoginAt: m.LastLoginAt,
+		CreatedAt:   m.CreatedAt,
+	}
+}
+
+// oidcProviderRepository Repository 实现
+type oidcProviderRepository struct {
+	db *database.PostgresDB
+}
+
+// 确保实现接口
+var _ repo.OIDCProviderRepository = (*oidcProviderRepository)(nil)
+
+// NewOIDCProviderRepository 创建 OIDC 身份提供方仓库实现
+func NewOIDCProviderRepository(db *database.PostgresDB) repo.OIDCProviderRepository {
+	return &oidcProviderRepository{db: db}
+}
+
+// GetByID 根据 ID 查询身份提供方
+func (r *oidcProviderRepository) GetByID(id int64) (*entity.OIDCProvider, error) {
+	var dbModel oidcProviderModel
+	if err := r.db.Where("id = ?", id).First(&dbModel).Error; err != nil {
+		return nil, err
+	}
+
+	return dbModel.toDomain(), nil
+}
+
+// GetByCode 根据编码查询身份提供方
+func (r *oidcProviderRepository) GetByCode(code string) (*entity.OIDCProvider, error) {
+	var dbModel oidcProviderModel
+	if err := r.db.Where("code = ?", code).First(&dbModel).Error; err != nil {
+		return nil, err
+	}
+
+	return dbModel.toDomain(), nil
+}
+
+// Create 创建身份提供方
+func (r *oidcProviderRepository) Create(provider *entity.OIDCProvider) error {
+	dbModel, err := fromDomainOIDCProvider(provider)
+	if err != nil {
+		return err
+	}
+
+	if err := r.db.Create(dbModel).Error; err != nil {
+		return err
+	}
+
+	// 将生成的 ID 回写到领域实体
+	provider.ID = dbModel.ID
+	provider.CreatedAt = dbModel.CreatedAt
+	provider.UpdatedAt = dbModel.UpdatedAt
+	return nil
+}
+
+// Update 更新身份提供方（编码不可修改）
+func (r *oidcProviderRepository) Update(provider *entity.OIDCProvider) error {
+	dbModel, err := fromDomainOIDCProvider(provider)
+	if err != nil {
+		return err
+	}
+
+	return r.db.Model(&oidcProviderModel{}).Where("id = ?", provider.ID).Updates(map[string]interface{}{
+		"name":                   dbModel.Name,
+		"issuer":                 dbModel.Issuer,
+		"client_id":              dbModel.ClientID,
+		"client_secret":          dbModel.ClientSecret,
+		"token_auth_method":      dbModel.TokenAuthMethod,
+		"scopes":                 dbModel.Scopes,
+		"redirect_uri":           dbModel.RedirectURI,
+		"authorization_endpoint": dbModel.AuthorizationEndpoint,
+		"token_endpoint":         dbModel.TokenEndpoint,
+		"userinfo_endpoint":      dbModel.UserinfoEndpoint,
+		"jwks_uri":               dbModel.JWKSURI,
+		"link_by":                dbModel.LinkBy,
+		"trust_email":            dbModel.TrustEmail,
+		"auto_create":            dbModel.AutoCreate,
+		"username_claim":         dbModel.UsernameClaim,
+		"role_mappings":          dbModel.RoleMappings,
+		"status":                 dbModel.Status,
+		"sort":                   dbModel.Sort,
+		"updated_by":             dbModel.UpdatedBy,
+		"updated_at":             time.Now(),
+	}).Error
+}
+
+// Delete 删除身份提供方及其用户绑定
+func (r *oidcProviderRepository) Delete(id int64) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Where("provider_id = ?", id).Delete(&userOIDCIdentityModel{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&oidcProviderModel{}, id).Error
+	})
+}
+
+// List 分页查询身份提供方列表
+func (r *oidcProviderRepository) List(page, pageSize int, filters map[string]interface{}) ([]*entity.OIDCProvider, int64, error) {
+	var total int64
+	var dbModels []oidcProviderModel
+
+	query := r.db.Model(&oidcProviderModel{})
+
+	// 应用过滤条件
+	for key, value := range filters {
+		if key == "name" {
+			query = query.Where("name ILIKE ?", "%"+value.(string)+"%")
+		} else {
+			query = query.Where(key+" = ?", value)
+		}
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * pageSize
+	if err := query.Order("sort ASC, created_at DESC").Offset(offset).Limit(pageSize).Find(&dbModels).Error; err != nil {
+		return nil, 0, err
+	}
+
+	providers := make([]*entity.OIDCProvider, len(dbModels))
+	for i := range dbModels {
+		providers[i] = dbModels[i].toDomain()
+	}
+
+	return providers, total, nil
+}
+
+// GetEnabled 获取启用的身份提供方，按排序返回
+func (r *oidcProviderRepository) GetEnabled() ([]*entity.OIDCProvider, error) {
+	var dbModels []oidcProviderModel
+	if err := r.db.Where("status = ?", entity.OIDCProviderStatusEnabled).
+		Order("sort ASC, id ASC").
+		Find(&dbModels).Error; err != nil {
+		return nil, err
+	}
+
+	providers := make([]*entity.OIDCProvider, len(dbModels))
+	for i := range dbModels {
+		providers[i] = dbModels[i].toDomain()
+	}
+	return providers, nil
+}
+
+// GetIdentity 根据身份提供方和 sub 查询用户绑定
+func (r *oidcProviderRepository) GetIdentity(providerID int64, subject string) (*entity.UserOIDCIdentity, error) {
+	var dbModel userOIDCIdentityModel
+	if err := r.db.Where("provider_id = ? AND subject = ?", providerID, subject).First(&dbModel).Error; err != nil {
+		return nil, err
+	}
+
+	return dbModel.toDomain(), nil
+}
+
+// CreateIdentity 创建用户绑定
+func (r *oidcProviderRepository) CreateIdentity(identity *entity.UserOIDCIdentity) error {
+	dbModel := &userOIDCIdentityModel{
+		SnowflakeBaseModel: database.SnowflakeBaseModel{ID: identity.ID},
+		ProviderID:         identity.ProviderID,
+		Subject:            identity.Subject,
+		UserID:             identity.UserID,
+		Email:              identity.Email,
+		LastLoginAt:        identity.LastLoginAt,
+	}
+	if err := r.db.Create(dbModel).Error; err != nil {
+		return err
+	}
+
+	identity.ID = dbModel.ID
+	identity.CreatedAt = dbModel.CreatedAt
+	return nil
+}
+
+// TouchIdentity 更新用户绑定的最近登录时间
+func (r *oidcProviderRepository) TouchIdentity(id int64, at time.Time) error {
+	return r.db.Model(&userOIDCIdentityModel{}).Where("id = ?", id).
+		UpdateColumn("last_login_at", at).Error
+}
@@ -12,12 +12,15 @@ import (
 )
 
 const (
-	onlineUserKeyPrefix = "online:user:"
-	onlineUserSetKey    = "online:users:set"
-	onlineUserExpire    = 30 * time.Minute // 在线用户过期时间
+	onlineSessionKeyPrefix = "online:session:"       // 会话记录，按会话 ID 存储
+	onlineUserKeyPrefix    = "online:user:"          // 用户的会话 ID 列表
+	onlineUserListKey      = "online:users:set:list" // 有会话的用户 ID 列表
+	onlineUserExpire       = 30 * time.Minute        // 会话未设置过期时间时的默认有效期
+	onlineUserListExpire   = 30 * 24 * time.Hour     // 在线用户列表最短有效期，残留的用户 ID 由 ClearExpired 清理
 )
 
 // onlineUserRepository Repository 实现（基于缓存）
+// 由于缓存接口不支持集合操作，用户的会话列表和在线用户列表使用 JSON 数组模拟
 type onlineUserRepository struct {
 	cache cache.Cache
 }
@@ -30,256 +33,314 @@ func NewOnlineUserRepository(cache cache.Cache) repo.OnlineUserRepository {
 	return &onlineUserRepository{cache: cache}
 }
 
-// Add 添加在线用户
+// Add 保存会话记录，并加入用户的会话列表和在线用户列表
 func (r *onlineUserRepository) Add(user *entity.OnlineUser) error {
-	// 序列化用户数据
-	data, err := json.Marshal(user)
-	if err != nil {
+	ttl := sessionTTL(user)
+	if err := r.saveSession(user, ttl); err != nil {
 		return err
 	}
 
-	// 存储用户数据 - 使用 fmt.Sprintf 将 int64 转换为 string 作为缓存 key
-	userKey := onlineUserKeyPrefix + fmt.Sprintf("%d", user.UserID)
-	if err := r.cache.Set(userKey, string(data), onlineUserExpire); err != nil {
-		return err
+	// 更新用户的会话列表，顺带清理已过期的会话；列表有效期取最晚过期的会话
+	userKey := generateUserKey(user.UserID)
+	sessionIDs := []string{user.SessionID}
+	for _, sessionID := range r.getIDList(userKey) {
+		if sessionID == user.SessionID {
+			continue
+		}
+		session, err := r.GetBySessionID(sessionID)
+		if err != nil || session == nil {
+			continue
+		}
+		sessionIDs = append(sessionIDs, sessionID)
+		if other := sessionTTL(session); other > ttl {
+			ttl = other
+		}
 	}
-
-	// 添加到在线用户集合（使用缓存的 Set 方法模拟）
-	// 由于缓存接口不支持集合操作，我们使用一个列表来模拟
-	onlineUsersListKey := onlineUserSetKey + ":list"
-	existingUsers, _ := r.cache.Get(onlineUsersListKey)
-	var userIDs []string
-	if existingUsers != "" {
-		json.Unmarshal([]byte(existingUsers), &userIDs)
+	if err := r.setIDList(userKey, sessionIDs, ttl); err != nil {
+		return err
 	}
 
-	// 检查是否已存在，不存在则添加
-	exists := false
-	userIDStr := fmt.Sprintf("%d", user.UserID)
+	// 加入在线用户列表
+	userIDs := r.getIDList(onlineUserListKey)
+	userIDStr := strconv.FormatInt(user.UserID, 10)
 	for _, id := range userIDs {
 		if id == userIDStr {
-			exists = true
-			break
+			return r.setIDList(onlineUserListKey, userIDs, listTTL(ttl))
 		}
 	}
-	if !exists {
-		userIDs = append(userIDs, userIDStr)
-		listData, _ := json.Marshal(userIDs)
-		r.cache.Set(onlineUsersListKey, string(listData), onlineUserExpire)
-	}
-
-	return nil
+	return r.setIDList(onlineUserListKey, append(userIDs, userIDStr), listTTL(ttl))
 }
 
-// GetByUserID 根据用户 ID 获取在线用户
-func (r *onlineUserRepository) GetByUserID(userID int64) (*entity.OnlineUser, error) {
-	userKey := onlineUserKeyPrefix + fmt.Sprintf("%d", userID)
-	data, err := r.cache.Get(userKey)
-	if err != nil {
-		return nil, err
-	}
+// GetByUserID 获取用户的全部会话
+func (r *onlineUserRepository) GetByUserID(userID int64) ([]*entity.OnlineUser, error) {
+	sessionIDs := r.getIDList(generateUserKey(userID))
 
-	var user entity.OnlineUser
-	if err := json.Unmarshal([]byte(data), &user); err != nil {
-		return nil, err
+	sessions := make([]*entity.OnlineUser, 0, len(sessionIDs))
+	for _, sessionID := range sessionIDs {
+		session, err := r.GetBySessionID(sessionID)
+		if err != nil {
+			return nil, err
+		}
+		if session != nil {
+			sessions = append(sessions, session)
+		}
 	}
 
-	return &user, nil
+	return sessions, nil
 }
 
-// GetBySessionID 根据会话 ID 获取在线用户
+// GetBySessionID 根据会话 ID 获取会话，会话不存在时返回 nil
 func (r *onlineUserRepository) GetBySessionID(sessionID string) (*entity.OnlineUser, error) {
-	// 需要通过扫描所有在线用户来查找匹配 sessionID 的用户
-	// 这种方式效率较低，建议只在必要时使用
-	onlineUsersListKey := onlineUserSetKey + ":list"
-	existingUsers, err := r.cache.Get(onlineUsersListKey)
+	key := generateSessionKey(sessionID)
+	data, err := r.cache.Get(key)
 	if err != nil {
-		return nil, err
-	}
-
-	var userIDs []string
-	if existingUsers != "" {
-		json.Unmarshal([]byte(existingUsers), &userIDs)
-	}
-
-	for _, userID := range userIDs {
-		userIDInt, err := strconv.ParseInt(userID, 10, 64)
-		if err != nil {
-			continue
-		}
-		user, err := r.GetByUserID(userIDInt)
-		if err != nil {
-			continue
+		// 区分键不存在和缓存访问失败
+		exists, existsErr := r.cache.Exists(key)
+		if existsErr != nil {
+			return nil, existsErr
 		}
-		if user != nil && user.SessionID == sessionID {
-			return user, nil
+		if !exists {
+			return nil, nil
 		}
+		return nil, err
 	}
 
-	return nil, nil
+	return deserialize(data)
 }
 
-// UpdateActiveTime 更新用户活跃时间
-func (r *onlineUserRepository) UpdateActiveTime(userID int64) error {
-	// 刷新过期时间（通过重新设置缓存实现）
-	user, err := r.GetByUserID(userID)
+// UpdateActiveTime 更新会话最后活跃时间，不改变会话过期时间
+func (r *onlineUserRepository) UpdateActiveTime(sessionID string) error {
+	session, err := r.GetBySessionID(sessionID)
 	if err != nil {
 		return err
 	}
-	if user == nil {
+	if session == nil {
 		return nil
 	}
 
-	user.LastActiveAt = time.Now()
-	return r.Add(user)
+	session.UpdateActiveTime()
+	return r.saveSession(session, sessionTTL(session))
 }
 
-// Remove 移除在线用户
+// Remove 移除用户的全部会话
 func (r *onlineUserRepository) Remove(userID int64) error {
-	userKey := onlineUserKeyPrefix + fmt.Sprintf("%d", userID)
-
-	// 删除用户数据
+	userKey := generateUserKey(userID)
+	for _, sessionID := range r.getIDList(userKey) {
+		if err := r.cache.Delete(generateSessionKey(sessionID)); err != nil {
+			return err
+		}
+	}
 	if err := r.cache.Delete(userKey); err != nil {
 		return err
 	}
 
-	// 从在线用户列表中移除
-	onlineUsersListKey := onlineUserSetKey + ":list"
-	existingUsers, err := r.cache.Get(onlineUsersListKey)
+	return r.removeFromUserList(userID)
+}
+
+// RemoveBySessionID 移除单个会话，用户没有其他会话时从在线用户列表中移除
+func (r *onlineUserRepository) RemoveBySessionID(sessionID string) error {
+	session, err := r.GetBySessionID(sessionID)
 	if err != nil {
 		return err
 	}
-
-	var userIDs []string
-	if existingUsers != "" {
-		json.Unmarshal([]byte(existingUsers), &userIDs)
+	if err := r.cache.Delete(generateSessionKey(sessionID)); err != nil {
+		return err
+	}
+	if session == nil {
+		return nil
 	}
 
-	// 移除该用户
-	newUserIDs := make([]string, 0)
-	userIDStr := fmt.Sprintf("%d", userID)
-	for _, id := range userIDs {
-		if id != userIDStr {
-			newUserIDs = append(newUserIDs, id)
+	// 从用户的会话列表中移除
+	userKey := generateUserKey(session.UserID)
+	remaining := make([]string, 0)
+	var ttl time.Duration
+	for _, id := range r.getIDList(userKey) {
+		if id == sessionID {
+			continue
+		}
+		other, err := r.GetBySessionID(id)
+		if err != nil || other == nil {
+			continue
+		}
+		remaining = append(remaining, id)
+		if otherTTL := sessionTTL(other); otherTTL > ttl {
+			ttl = otherTTL
 		}
 	}
 
-	if len(newUserIDs) > 0 {
-		listData, _ := json.Marshal(newUserIDs)
-		r.cache.Set(onlineUsersListKey, string(listData), onlineUserExpire)
-	} else {
-		r.cache.Delete(onlineUsersListKey)
+	if len(remaining) > 0 {
+		return r.setIDList(userKey, remaining, ttl)
 	}
-
-	return nil
+	if err := r.cache.Delete(userKey); err != nil {
+		return err
+	}
+	return r.removeFromUserList(session.UserID)
 }
 
-// RemoveBySessionID 根据会话 ID 移除在线用户
-func (r *onlineUserRepository) RemoveBySessionID(sessionID string) error {
-	user, err := r.GetBySessionID(sessionID)
-	if err != nil {
-		return err
+// GetAll 获取所有会话
+func (r *onlineUserRepository) GetAll() ([]*entity.OnlineUser, error) {
+	userIDs := r.getIDList(onlineUserListKey)
+
+	sessions := make([]*entity.OnlineUser, 0, len(userIDs))
+	for _, userIDStr := range userIDs {
+		userID, err := strconv.ParseInt(userIDStr, 10, 64)
+		if err != nil {
+			continue
+		}
+		userSessions, err := r.GetByUserID(userID)
+		if err != nil {
+			continue
+		}
+		sessions = append(sessions, userSessions...)
 	}
-	if user != nil {
-		return r.Remove(user.UserID)
+
+	return sessions, nil
+}
+
+// GetCount 获取在线用户数量（按用户去重）
+// 用户会话列表的有效期与最晚过期的会话一致，列表存在即视为在线
+func (r *onlineUserRepository) GetCount() (int, error) {
+	count := 0
+	for _, userIDStr := range r.getIDList(onlineUserListKey) {
+		exists, err := r.cache.Exists(onlineUserKeyPrefix + userIDStr)
+		if err != nil {
+			return 0, err
+		}
+		if exists {
+			count++
+		}
 	}
-	return nil
+	return count, nil
 }
 
-// GetAll 获取所有在线用户
-func (r *onlineUserRepository) GetAll() ([]*entity.OnlineUser, error) {
-	onlineUsersListKey := onlineUserSetKey + ":list"
-	existingUsers, err := r.cache.Get(onlineUsersListKey)
+// Exists 检查用户是否有未过期的会话
+func (r *onlineUserRepository) Exists(userID int64) (bool, error) {
+	sessions, err := r.GetByUserID(userID)
 	if err != nil {
-		return nil, err
+		return false, err
 	}
+	return len(sessions) > 0, nil
+}
 
-	var userIDs []string
-	if existingUsers != "" {
-		json.Unmarshal([]byte(existingUsers), &userIDs)
+// ClearExpired 清理过期的会话索引
+// 缓存会自动删除过期的会话记录，此方法清理列表中残留的会话 ID 和用户 ID
+func (r *onlineUserRepository) ClearExpired() error {
+	userIDs := r.getIDList(onlineUserListKey)
+	if len(userIDs) == 0 {
+		return nil
 	}
 
-	users := make([]*entity.OnlineUser, 0, len(userIDs))
+	validUserIDs := make([]string, 0, len(userIDs))
+	var maxTTL time.Duration
 	for _, userIDStr := range userIDs {
-		// 将 string 转换为 int64
 		userID, err := strconv.ParseInt(userIDStr, 10, 64)
 		if err != nil {
 			continue
 		}
-		user, err := r.GetByUserID(userID)
+		userKey := generateUserKey(userID)
+		sessions, err := r.GetByUserID(userID)
 		if err != nil {
+			return err
+		}
+		if len(sessions) == 0 {
+			r.cache.Delete(userKey)
 			continue
 		}
-		if user != nil {
-			users = append(users, user)
+
+		sessionIDs := make([]string, len(sessions))
+		var ttl time.Duration
+		for i, session := range sessions {
+			sessionIDs[i] = session.SessionID
+			if remaining := sessionTTL(session); remaining > ttl {
+				ttl = remaining
+			}
+		}
+		if err := r.setIDList(userKey, sessionIDs, ttl); err != nil {
+			return err
 		}
+		if ttl > maxTTL {
+			maxTTL = ttl
+		}
+		validUserIDs = append(validUserIDs, userIDStr)
 	}
 
-	return users, nil
+	if len(validUserIDs) > 0 {
+		return r.setIDList(onlineUserListKey, validUserIDs, listTTL(maxTTL))
+	}
+	return r.cache.Delete(onlineUserListKey)
 }
 
-// GetCount 获取在线用户数量
-func (r *onlineUserRepository) GetCount() (int, error) {
-	onlineUsersListKey := onlineUserSetKey + ":list"
-	existingUsers, err := r.cache.Get(onlineUsersListKey)
+// saveSession 写入会话记录
+func (r *onlineUserRepository) saveSession(user *entity.OnlineUser, ttl time.Duration) error {
+	data, err := serialize(user)
 	if err != nil {
-		return 0, err
+		return err
 	}
+	return r.cache.Set(generateSessionKey(user.SessionID), data, ttl)
+}
+
+// removeFromUserList 从在线用户列表中移除用户
+func (r *onlineUserRepository) removeFromUserList(userID int64) error {
+	userIDs := r.getIDList(onlineUserListKey)
+	userIDStr := strconv.FormatInt(userID, 10)
 
-	if existingUsers == "" {
-		return 0, nil
+	remaining := make([]string, 0, len(userIDs))
+	for _, id := range userIDs {
+		if id != userIDStr {
+			remaining = append(remaining, id)
+		}
 	}
 
-	var userIDs []string
-	json.Unmarshal([]byte(existingUsers), &userIDs)
-	return len(userIDs), nil
+	if len(remaining) == len(userIDs) {
+		return nil
+	}
+	if len(remaining) == 0 {
+		return r.cache.Delete(onlineUserListKey)
+	}
+	return r.setIDList(onlineUserListKey, remaining, onlineUserListExpire)
 }
 
-// Exists 检查用户是否在线
-func (r *onlineUserRepository) Exists(userID int64) (bool, error) {
-	userKey := onlineUserKeyPrefix + fmt.Sprintf("%d", userID)
-	_, err := r.cache.Get(userKey)
-	if err != nil {
-		return false, err
+// getIDList 读取 JSON 数组形式的 ID 列表，不存在时返回空列表
+func (r *onlineUserRepository) getIDList(key string) []string {
+	data, err := r.cache.Get(key)
+	if err != nil || data == "" {
+		return nil
 	}
-	return true, nil
+
+	var ids []string
+	json.Unmarshal([]byte(data), &ids)
+	return ids
 }
 
-// ClearExpired 清理过期的在线用户
-func (r *onlineUserRepository) ClearExpired() error {
-	// 缓存会自动处理过期键，此方法可以作为手动清理的补充
-	// 清理在线用户列表中已经不存在的用户
-	onlineUsersListKey := onlineUserSetKey + ":list"
-	existingUsers, err := r.cache.Get(onlineUsersListKey)
+// setIDList 以 JSON 数组形式写入 ID 列表
+func (r *onlineUserRepository) setIDList(key string, ids []string, ttl time.Duration) error {
+	data, err := json.Marshal(ids)
 	if err != nil {
 		return err
 	}
+	return r.cache.Set(key, string(data), ttl)
+}
 
-	if existingUsers == "" {
-		return nil
+// sessionTTL 计算会话剩余有效期
+func sessionTTL(user *entity.OnlineUser) time.Duration {
+	if user.ExpiresAt.IsZero() {
+		return onlineUserExpire
 	}
-
-	var userIDs []string
-	json.Unmarshal([]byte(existingUsers), &userIDs)
-
-	validUserIDs := make([]string, 0)
-	for _, userID := range userIDs {
-		userKey := onlineUserKeyPrefix + userID
-		_, err := r.cache.Get(userKey)
-		if err == nil {
-			// 用户数据还存在
-			validUserIDs = append(validUserIDs, userID)
-		}
+	ttl := time.Until(user.ExpiresAt)
+	if ttl < time.Second {
+		// 缓存有效期为 0 时会使用默认有效期，已过期的会话只保留极短时间
+		return time.Second
 	}
+	return ttl
+}
 
-	if len(validUserIDs) > 0 {
-		listData, _ := json.Marshal(validUserIDs)
-		r.cache.Set(onlineUsersListKey, string(listData), onlineUserExpire)
-	} else {
-		r.cache.Delete(onlineUsersListKey)
+// listTTL 计算在线用户列表有效期，不短于 onlineUserListExpire
+// 缓存接口无法读取键的剩余有效期，列表有效期只延长不缩短，避免其他用户的会话从列表中丢失
+func listTTL(sessionTTL time.Duration) time.Duration {
+	if sessionTTL > onlineUserListExpire {
+		return sessionTTL
 	}
-
-	return nil
+	return onlineUserListExpire
 }
 
 // serialize 序列化在线用户为 JSON
@@ -300,12 +361,12 @@ func deserialize(data string) (*entity.OnlineUser, error) {
 	return &user, nil
 }
 
-// generateUserKey 生成用户缓存键
+// generateUserKey 生成用户会话列表缓存键
 func generateUserKey(userID int64) string {
 	return onlineUserKeyPrefix + fmt.Sprintf("%d", userID)
 }
 
-// generateSessionKey 生成会话缓存键（可选，用于 sessionID 到 userID 的映射）
+// generateSessionKey 生成会话缓存键
 func generateSessionKey(sessionID string) string {
-	return onlineUserKeyPrefix + "session:" + sessionID
+	return onlineSessionKeyPrefix + sessionID
 }
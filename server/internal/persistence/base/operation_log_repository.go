@@ -186,6 +186,7 @@ func (r *operationLogRepository) List(page, pageSize int, filters map[string]int
 	var dbModels []operationLogModel
 
 	query := r.db.Model(&operationLogModel{})
+	query, filters = common.ApplyDataScope(query, filters, "", "user_id")
 
 	// 应用过滤条件
 	for key, value := range filters {
@@ -0,0 +1,101 @@
+package persistence
+
+import (
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/entity"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/repo"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/persistence/database"
+)
+
+// passwordHistoryModel 密码历史数据库模型
+type passwordHistoryModel struct {
+	database.SnowflakeBaseModel
+	UserID       int64  `gorm:"not null;index"`
+	PasswordHash string `gorm:"size:100;not null"`
+}
+
+// TableName 指定表名
+func (passwordHistoryModel) TableName() string {
+	return "base_password_histories"
+}
+
+// toDomain 将数据库模型转换为领域实体
+func (m *passwordHistoryModel) toDomain() *entity.PasswordHistory {
+	if m == nil {
+		return nil
+	}
+	return &entity.PasswordHistory{
+		ID:           m.ID,
+		UserID:       m.UserID,
+		PasswordHash: m.PasswordHash,
+		CreatedBy:    m.CreatedBy,
+		CreatedAt:    m.CreatedAt,
+	}
+}
+
+// fromDomainPasswordHistory 将领域实体转换为数据库模型
+func fromDomainPasswordHistory(history *entity.PasswordHistory) *passwordHistoryModel {
+	return &passwordHistoryModel{
+		SnowflakeBaseModel: database.SnowflakeBaseModel{
+			ID:        history.ID,
+			CreatedBy: history.CreatedBy,
+			UpdatedBy: history.CreatedBy,
+		},
+		UserID:       history.UserID,
+		PasswordHash: history.PasswordHash,
+	}
+}
+
+// passwordHistoryRepository Repository 实现
+type passwordHistoryRepository struct {
+	db *database.PostgresDB
+}
+
+// 确保实现接口
+var _ repo.PasswordHistoryRepository = (*passwordHistoryRepository)(nil)
+
+// NewPasswordHistoryRepository 创建密码历史仓库实现
+func NewPasswordHistoryRepository(db *database.PostgresDB) repo.PasswordHistoryRepository {
+	return &passwordHistoryRepository{db: db}
+}
+
+// Create 创建密码历史记录
+func (r *passwordHistoryRepository) Create(history *entity.PasswordHistory) error {
+	dbModel := fromDomainPasswordHistory(history)
+	if err := r.db.Create(dbModel).Error; err != nil {
+		return err
+	}
+
+	history.ID = dbModel.ID
+	history.CreatedAt = dbModel.CreatedAt
+	return nil
+}
+
+// GetRecentByUserID 按时间倒序获取用户最近 limit 条密码历史
+func (r *passwordHistoryRepository) GetRecentByUserID(userID int64, limit int) ([]*entity.PasswordHistory, error) {
+	var dbModels []passwordHistoryModel
+	if err := r.db.Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Limit(limit).
+		Find(&dbModels).Error; err != nil {
+		return nil, err
+	}
+
+	histories := make([]*entity.PasswordHistory, len(dbModels))
+	for i := range dbModels {
+		histories[i] = dbModels[i].toDomain()
+	}
+	return histories, nil
+}
+
+// PruneByUserID 仅保留用户最近 keep 条密码历史，删除更早的记录
+func (r *passwordHistoryRepository) PruneByUserID(userID int64, keep int) error {
+	keepIDs := r.db.Model(&passwordHistoryModel{}).
+		Select("id").
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Limit(keep)
+
+	return r.db.Unscoped().
+		Where("user_id = ? AND id NOT IN (?)", userID, keepIDs).
+		Delete(&passwordHistoryModel{}).Error
+}
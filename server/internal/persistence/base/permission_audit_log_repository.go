@@ -0,0 +1,82 @@
+package persistence
+
+import (
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/entity"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/repo"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/persistence/database"
+)
+
+// permissionAuditLogModel 权限审计日志数据库模型
+type permissionAuditLogModel struct {
+	database.SnowflakeBaseModelWithoutDeleted
+	OperatorID   int64  `gorm:"not null;index"`
+	OperatorName string `gorm:"size:100"`
+	ActionType   string `gorm:"size:50;not null"`
+	TargetType   string `gorm:"size:50"`
+	TargetID     int64
+	// 变更前后数据，JSON
+	BeforeData *string `gorm:"type:jsonb"`
+	AfterData  *string `gorm:"type:jsonb"`
+	IPAddress  string  `gorm:"size:50"`
+	UserAgent  string  `gorm:"size:500"`
+}
+
+// TableName 指定表名
+func (permissionAuditLogModel) TableName() string {
+	return "sys_permission_logs"
+}
+
+// fromDomainPermissionAuditLog 将领域实体转换为数据库模型
+func fromDomainPermissionAuditLog(log *entity.PermissionAuditLog) *permissionAuditLogModel {
+	model := &permissionAuditLogModel{
+		SnowflakeBaseModelWithoutDeleted: database.SnowflakeBaseModelWithoutDeleted{
+			ID:        log.ID,
+			CreatedBy: log.OperatorID,
+			UpdatedBy: log.OperatorID,
+		},
+		OperatorID:   log.OperatorID,
+		OperatorName: log.OperatorName,
+		ActionType:   log.ActionType,
+		TargetType:   log.TargetType,
+		TargetID:     log.TargetID,
+		IPAddress:    log.IPAddress,
+		UserAgent:    log.UserAgent,
+	}
+
+	// JSONB 列不接受空字符串
+	if log.BeforeData != "" {
+		beforeData := log.BeforeData
+		model.BeforeData = &beforeData
+	}
+	if log.AfterData != "" {
+		afterData := log.AfterData
+		model.AfterData = &afterData
+	}
+
+	return model
+}
+
+// permissionAuditLogRepository Repository 实现
+type permissionAuditLogRepository struct {
+	db *database.PostgresDB
+}
+
+// 确保实现接口
+var _ repo.PermissionAuditLogRepository = (*permissionAuditLogRepository)(nil)
+
+// NewPermissionAuditLogRepository 创建权限审计日志仓库实现
+func NewPermissionAuditLogRepository(db *database.PostgresDB) repo.PermissionAuditLogRepository {
+	return &permissionAuditLogRepository{db: db}
+}
+
+// Create 写入审计日志
+func (r *permissionAuditLogRepository) Create(log *entity.PermissionAuditLog) error {
+	dbModel := fromDomainPermissionAuditLog(log)
+	if err := r.db.Create(dbModel).Error; err != nil {
+		return err
+	}
+
+	log.ID = dbModel.ID
+	log.CreatedAt = dbModel.CreatedAt
+	return nil
+}
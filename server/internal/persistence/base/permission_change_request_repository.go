@@ -0,0 +1,265 @@
+package persistence
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/entity"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/repo"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/persistence/database"
+	"github.com/ix-pay/ixpay-pro/internal/persistence/common"
+	"gorm.io/gorm"
+)
+
+// permissionChangeRequestModel 权限变更申请数据库模型
+type permissionChangeRequestModel struct {
+	database.SnowflakeBaseModel
+	RoleID     int64  `gorm:"not null;index"`
+	ChangeType string `gorm:"size:30;not null"`
+	// 提交时的关联 ID 及增删的关联 ID，JSON 数组
+	BeforeIDs  string `gorm:"column:before_ids;type:text"`
+	AddedIDs   string `gorm:"column:added_ids;type:text"`
+	RemovedIDs string `gorm:"column:removed_ids;type:text"`
+	// 策略包导入申请的策略包内容，JSON
+	Payload       string `gorm:"type:text"`
+	Status        string `gorm:"size:20;not null;index"`
+	RequestedBy   int64  `gorm:"not null;default:0"`
+	ReviewedBy    int64  `gorm:"not null;default:0"`
+	ReviewedAt    *time.Time
+	ReviewComment string `gorm:"size:500"`
+}
+
+// TableName 指定表名
+func (permissionChangeRequestModel) TableName() string {
+	return "base_permission_change_requests"
+}
+
+// toDomain 将数据库模型转换为领域实体
+func (m *permissionChangeRequestModel) toDomain() *entity.PermissionChangeRequest {
+	if m == nil {
+		return nil
+	}
+
+	request := &entity.PermissionChangeRequest{
+		ID:            m.ID,
+		RoleID:        m.RoleID,
+		ChangeType:    m.ChangeType,
+		Payload:       m.Payload,
+		Status:        m.Status,
+		RequestedBy:   m.RequestedBy,
+		ReviewedBy:    m.ReviewedBy,
+		ReviewedAt:    m.ReviewedAt,
+		ReviewComment: m.ReviewComment,
+		CreatedBy:     m.CreatedBy,
+		CreatedAt:     m.CreatedAt,
+		UpdatedBy:     m.UpdatedBy,
+		UpdatedAt:     m.UpdatedAt,
+	}
+
+	if m.BeforeIDs != "" {
+		json.Unmarshal([]byte(m.BeforeIDs), &request.BeforeIDs)
+	}
+	if m.AddedIDs != "" {
+		json.Unmarshal([]byte(m.AddedIDs), &request.AddedIDs)
+	}
+	if m.RemovedIDs != "" {
+		json.Unmarshal([]byte(m.RemovedIDs), &request.RemovedIDs)
+	}
+
+	return request
+}
+
+// fromDomainPermissionChangeRequest 将领域实体转换为数据库模型
+func fromDomainPermissionChangeRequest(request *entity.PermissionChangeRequest) (*permissionChangeRequestModel, error) {
+	beforeIDs, err := marshalIDs(request.BeforeIDs)
+	if err != nil {
+		return nil, err
+	}
+	addedIDs, err := marshalIDs(request.AddedIDs)
+	if err != nil {
+		return nil, err
+	}
+	removedIDs, err := marshalIDs(request.RemovedIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return &permissionChangeRequestModel{
+		SnowflakeBaseModel: database.SnowflakeBaseModel{
+			ID:        request.ID,
+			CreatedBy: request.CreatedBy,
+			UpdatedBy: request.UpdatedBy,
+		},
+		RoleID:        request.RoleID,
+		ChangeType:    request.ChangeType,
+		BeforeIDs:     beforeIDs,
+		AddedIDs:      addedIDs,
+		RemovedIDs:    removedIDs,
+		Payload:       request.Payload,
+		Status:        request.Status,
+		RequestedBy:   request.RequestedBy,
+		ReviewedBy:    request.ReviewedBy,
+		ReviewedAt:    request.ReviewedAt,
+		ReviewComment: request.ReviewComment,
+	}, nil
+}
+
+// marshalIDs 将 ID 列表序列化为 JSON 数组，空列表保存为 []
+func marshalIDs(ids []int64) (string, error) {
+	if ids == nil {
+		ids = []int64{}
+	}
+	data, err := json.Marshal(ids)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// permissionChangeRequestRepository Repository 实现
+type permissionChangeRequestRepository struct {
+	db *database.PostgresDB
+}
+
+// 确保实现接口
+var _ repo.PermissionChangeRequestRepository = (*permissionChangeRequestRepository)(nil)
+
+// NewPermissionChangeRequestRepository 创建权限变更申请仓库实现
+func NewPermissionChangeRequestRepository(db *database.PostgresDB) repo.PermissionChangeRequestRepository {
+	return &permissionChangeRequestRepository{db: db}
+}
+
+// GetByID 根据 ID 查询申请
+func (r *permissionChangeRequestRepository) GetByID(id int64) (*entity.PermissionChangeRequest, error) {
+	var dbModel permissionChangeRequestModel
+	if err := r.db.Where("id = ?", id).First(&dbModel).Error; err != nil {
+		return nil, err
+	}
+
+	return dbModel.toDomain(), nil
+}
+
+// Create 创建申请
+func (r *permissionChangeRequestRepository) Create(request *entity.PermissionChangeRequest) error {
+	dbModel, err := fromDomainPermissionChangeRequest(request)
+	if err != nil {
+		return err
+	}
+	if err := r.db.Create(dbModel).Error; err != nil {
+		return err
+	}
+
+	// 将生成的 ID 回写到领域实体
+	request.ID = dbModel.ID
+	request.CreatedAt = dbModel.CreatedAt
+	return nil
+}
+
+// Update 更新申请的状态和审批信息
+func (r *permissionChangeRequestRepository) Update(request *entity.PermissionChangeRequest) error {
+	return updatePermissionChangeRequest(r.db.DB, request)
+}
+
+// Apply 在单个事务中执行审批通过的变更并更新申请的状态和审批信息
+// 已存在的新增和已不存在的移除直接跳过，任一步失败时整体回滚，申请保持待审批
+func (r *permissionChangeRequestRepository) Apply(request *entity.PermissionChangeRequest) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := applyPermissionChange(tx, request); err != nil {
+			return err
+		}
+		return updatePermissionChangeRequest(tx, request)
+	})
+}
+
+// updatePermissionChangeRequest 更新申请的状态和审批信息
+func updatePermissionChangeRequest(tx *gorm.DB, request *entity.PermissionChangeRequest) error {
+	return tx.Model(&permissionChangeRequestModel{}).Where("id = ?", request.ID).Updates(map[string]interface{}{
+		"status":         request.Status,
+		"reviewed_by":    request.ReviewedBy,
+		"reviewed_at":    request.ReviewedAt,
+		"review_comment": request.ReviewComment,
+		"updated_by":     request.UpdatedBy,
+		"updated_at":     time.Now(),
+	}).Error
+}
+
+// applyPermissionChange 按申请中的差异写入角色关联，或取消角色的审批要求
+func applyPermissionChange(tx *gorm.DB, request *entity.PermissionChangeRequest) error {
+	if request.ChangeType == entity.PermissionChangeApprovalRequired {
+		return tx.Model(&roleModel{}).Where("id = ?", request.RoleID).Updates(map[string]interface{}{
+			"approval_required": false,
+			"updated_by":        request.ReviewedBy,
+			"updated_at":        time.Now(),
+		}).Error
+	}
+
+	var column string
+	var model interface{}
+	var newRelation func(id int64) interface{}
+	switch request.ChangeType {
+	case entity.PermissionChangeRoleUsers:
+		column, model = "user_id", &roleUserModel{}
+		newRelation = func(id int64) interface{} { return &roleUserModel{RoleID: request.RoleID, UserID: id} }
+	case entity.PermissionChangeRoleMenus:
+		column, model = "menu_id", &roleMenuModel{}
+		newRelation = func(id int64) interface{} { return &roleMenuModel{RoleID: request.RoleID, MenuID: id} }
+	case entity.PermissionChangeRoleAPIs:
+		column, model = "route_id", &roleAPIRouteModel{}
+		newRelation = func(id int64) interface{} {
+			return &roleAPIRouteModel{RoleID: request.RoleID, RouteID: id, Source: common.IntPtr(1)} // 1-直接授权
+		}
+	case entity.PermissionChangeRoleBtnPerms:
+		column, model = "btn_perm_id", &roleBtnPermModel{}
+		newRelation = func(id int64) interface{} { return &roleBtnPermModel{RoleID: request.RoleID, BtnPermID: id} }
+	default:
+		return fmt.Errorf("未知的权限变更类型：%s", request.ChangeType)
+	}
+
+	for _, id := range request.AddedIDs {
+		var count int64
+		if err := tx.Model(model).Where("role_id = ? AND "+column+" = ?", request.RoleID, id).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			continue
+		}
+		if err := tx.Create(newRelation(id)).Error; err != nil {
+			return err
+		}
+	}
+	if len(request.RemovedIDs) > 0 {
+		if err := tx.Where("role_id = ? AND "+column+" IN ?", request.RoleID, request.RemovedIDs).Delete(model).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// List 分页查询申请列表
+func (r *permissionChangeRequestRepository) List(page, pageSize int, filters map[string]interface{}) ([]*entity.PermissionChangeRequest, int64, error) {
+	var total int64
+	var dbModels []permissionChangeRequestModel
+
+	query := r.db.Model(&permissionChangeRequestModel{})
+
+	// 应用过滤条件
+	for key, value := range filters {
+		query = query.Where(key+" = ?", value)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * pageSize
+	if err := query.Order("created_at DESC").Offset(offset).Limit(pageSize).Find(&dbModels).Error; err != nil {
+		return nil, 0, err
+	}
+
+	requests := make([]*entity.PermissionChangeRequest, len(dbModels))
+	for i := range dbModels {
+		requests[i] = dbModels[i].toDomain()
+	}
+	return requests, total, nil
+}
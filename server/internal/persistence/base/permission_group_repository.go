@@ -5,6 +5,7 @@ import (
 	"github.com/ix-pay/ixpay-pro/internal/domain/base/repo"
 	"github.com/ix-pay/ixpay-pro/internal/infrastructure/persistence/database"
 	"github.com/ix-pay/ixpay-pro/internal/persistence/common"
+	"gorm.io/gorm/clause"
 )
 
 // permissionGroupModel 权限组数据库模型
@@ -21,6 +22,39 @@ func (permissionGroupModel) TableName() string {
 	return "base_permission_groups"
 }
 
+// permissionGroupAPIModel 权限组-API 路由关联模型
+type permissionGroupAPIModel struct {
+	GroupID int64 `gorm:"primaryKey"`
+	RouteID int64 `gorm:"primaryKey"`
+}
+
+// TableName 指定表名
+func (permissionGroupAPIModel) TableName() string {
+	return "base_permission_group_apis"
+}
+
+// permissionGroupBtnPermModel 权限组-按钮权限关联模型
+type permissionGroupBtnPermModel struct {
+	GroupID   int64 `gorm:"primaryKey"`
+	BtnPermID int64 `gorm:"primaryKey"`
+}
+
+// TableName 指定表名
+func (permissionGroupBtnPermModel) TableName() string {
+	return "base_permission_group_btn_perms"
+}
+
+// rolePermissionGroupModel 角色-权限组关联模型
+type rolePermissionGroupModel struct {
+	RoleID  int64 `gorm:"primaryKey"`
+	GroupID int64 `gorm:"primaryKey"`
+}
+
+// TableName 指定表名
+func (rolePermissionGroupModel) TableName() string {
+	return "base_role_permission_groups"
+}
+
 // toDomain 将数据库模型转换为领域实体
 func (m *permissionGroupModel) toDomain() *entity.PermissionGroup {
 	if m == nil {
@@ -180,60 +214,100 @@ func (r *permissionGroupRepository) GetAllGroups() ([]*entity.PermissionGroup, e
 
 // AddAPIToGroup 添加 API 路由到权限组
 func (r *permissionGroupRepository) AddAPIToGroup(groupID, apiID int64) error {
-	// TODO: 实现权限组关联 API 路由表操作
-	return nil
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&permissionGroupAPIModel{GroupID: groupID, RouteID: apiID}).Error
 }
 
 // RemoveAPIFromGroup 从权限组移除 API 路由
 func (r *permissionGroupRepository) RemoveAPIFromGroup(groupID, apiID int64) error {
-	// TODO: 实现权限组关联 API 路由表操作
-	return nil
+	return r.db.Where("group_id = ? AND route_id = ?", groupID, apiID).Delete(&permissionGroupAPIModel{}).Error
 }
 
 // GetAPIsByGroup 获取权限组下的所有 API 路由
 func (r *permissionGroupRepository) GetAPIsByGroup(groupID int64) ([]*entity.API, error) {
-	// TODO: 实现权限组关联 API 路由表操作
-	return nil, nil
+	var dbModels []apiModel
+	err := r.db.Joins("JOIN base_permission_group_apis ON base_permission_group_apis.route_id = base_apis.id").
+		Where("base_permission_group_apis.group_id = ?", groupID).Find(&dbModels).Error
+	if err != nil {
+		return nil, err
+	}
+
+	apis := make([]*entity.API, len(dbModels))
+	for i := range dbModels {
+		apis[i] = dbModels[i].toDomain()
+	}
+	return apis, nil
 }
 
 // GetGroupsByAPI 获取 API 路由的所有权限组
 func (r *permissionGroupRepository) GetGroupsByAPI(apiID int64) ([]*entity.PermissionGroup, error) {
-	// TODO: 实现权限组关联 API 路由表操作
-	return nil, nil
+	return r.findGroups("JOIN base_permission_group_apis ON base_permission_group_apis.group_id = base_permission_groups.id",
+		"base_permission_group_apis.route_id = ?", apiID)
 }
 
 // AddBtnPermToGroup 添加按钮权限到权限组
 func (r *permissionGroupRepository) AddBtnPermToGroup(groupID, btnPermID int64) error {
-	// TODO: 实现权限组关联按钮权限表操作
-	return nil
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&permissionGroupBtnPermModel{GroupID: groupID, BtnPermID: btnPermID}).Error
 }
 
 // RemoveBtnPermFromGroup 从权限组移除按钮权限
 func (r *permissionGroupRepository) RemoveBtnPermFromGroup(groupID, btnPermID int64) error {
-	// TODO: 实现权限组关联按钮权限表操作
-	return nil
+	return r.db.Where("group_id = ? AND btn_perm_id = ?", groupID, btnPermID).Delete(&permissionGroupBtnPermModel{}).Error
 }
 
 // GetBtnPermsByGroup 获取权限组下的所有按钮权限
 func (r *permissionGroupRepository) GetBtnPermsByGroup(groupID int64) ([]*entity.BtnPerm, error) {
-	// TODO: 实现权限组关联按钮权限表操作
-	return nil, nil
+	var dbModels []btnPermModel
+	err := r.db.Joins("JOIN base_permission_group_btn_perms ON base_permission_group_btn_perms.btn_perm_id = base_btn_perms.id").
+		Where("base_permission_group_btn_perms.group_id = ?", groupID).Find(&dbModels).Error
+	if err != nil {
+		return nil, err
+	}
+
+	btnPerms := make([]*entity.BtnPerm, len(dbModels))
+	for i := range dbModels {
+		btnPerms[i] = dbModels[i].toDomain()
+	}
+	return btnPerms, nil
 }
 
 // GetGroupsByBtnPerm 获取按钮权限的所有权限组
 func (r *permissionGroupRepository) GetGroupsByBtnPerm(btnPermID int64) ([]*entity.PermissionGroup, error) {
-	// TODO: 实现权限组关联按钮权限表操作
-	return nil, nil
+	return r.findGroups("JOIN base_permission_group_btn_perms ON base_permission_group_btn_perms.group_id = base_permission_groups.id",
+		"base_permission_group_btn_perms.btn_perm_id = ?", btnPermID)
 }
 
 // GetRolesByGroup 获取权限组的所有角色
 func (r *permissionGroupRepository) GetRolesByGroup(groupID int64) ([]*entity.Role, error) {
-	// TODO: 实现权限组关联角色表操作
-	return nil, nil
+	var dbModels []roleModel
+	err := r.db.Joins("JOIN base_role_permission_groups ON base_role_permission_groups.role_id = base_roles.id").
+		Where("base_role_permission_groups.group_id = ?", groupID).Find(&dbModels).Error
+	if err != nil {
+		return nil, err
+	}
+
+	roles := make([]*entity.Role, len(dbModels))
+	for i := range dbModels {
+		roles[i] = dbModels[i].toDomain()
+	}
+	return roles, nil
 }
 
 // GetGroupsByRole 获取角色的所有权限组
 func (r *permissionGroupRepository) GetGroupsByRole(roleID int64) ([]*entity.PermissionGroup, error) {
-	// TODO: 实现权限组关联角色表操作
-	return nil, nil
+	return r.findGroups("JOIN base_role_permission_groups ON base_role_permission_groups.group_id = base_permission_groups.id",
+		"base_role_permission_groups.role_id = ?", roleID)
+}
+
+// findGroups 按关联表查询权限组
+func (r *permissionGroupRepository) findGroups(join, condition string, id int64) ([]*entity.PermissionGroup, error) {
+	var dbModels []permissionGroupModel
+	if err := r.db.Joins(join).Where(condition, id).Order("sort ASC").Find(&dbModels).Error; err != nil {
+		return nil, err
+	}
+
+	groups := make([]*entity.PermissionGroup, len(dbModels))
+	for i := range dbModels {
+		groups[i] = dbModels[i].toDomain()
+	}
+	return groups, nil
 }
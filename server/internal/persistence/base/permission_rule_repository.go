@@ -2,10 +2,12 @@ package persistence
 
 import (
 	"encoding/json"
+	"strings"
 
 	"github.com/ix-pay/ixpay-pro/internal/domain/base/entity"
 	"github.com/ix-pay/ixpay-pro/internal/domain/base/repo"
 	"github.com/ix-pay/ixpay-pro/internal/infrastructure/persistence/database"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/security/abac"
 	"github.com/ix-pay/ixpay-pro/internal/persistence/common"
 )
 
@@ -34,8 +36,9 @@ func (m *permissionRuleModel) toDomain() *entity.PermissionRule {
 		return nil
 	}
 
+	// 旧版本以属性列表保存条件，新版本保存条件表达式对象，这里只解析旧格式
 	var attributes []entity.PermissionAttribute
-	if m.Conditions != "" {
+	if strings.HasPrefix(strings.TrimSpace(m.Conditions), "[") {
 		json.Unmarshal([]byte(m.Conditions), &attributes)
 	}
 
@@ -78,8 +81,9 @@ func (m *permissionRuleModel) toDomain() *entity.PermissionRule {
 
 // fromDomain 将领域实体转换为数据库模型
 func fromDomainPermissionRule(rule *entity.PermissionRule) (*permissionRuleModel, error) {
-	conditionsJSON := ""
-	if len(rule.Attributes) > 0 {
+	// 优先保存条件表达式，未填写时兼容旧版本的属性列表
+	conditionsJSON := rule.Conditions
+	if conditionsJSON == "" && len(rule.Attributes) > 0 {
 		jsonData, err := json.Marshal(rule.Attributes)
 		if err != nil {
 			return nil, err
@@ -280,8 +284,19 @@ func (r *permissionRuleRepository) GetRulesByUser(userID int64) ([]*entity.Permi
 	return nil, nil
 }
 
-// FindMatchingRules 查找匹配的权限规则
-func (r *permissionRuleRepository) FindMatchingRules(apiPath, method string, attributes []entity.PermissionAttribute) ([]*entity.PermissionRule, error) {
-	// TODO: 实现权限规则匹配逻辑
-	return nil, nil
+// FindMatchingRules 查找路径和方法匹配的启用规则，按排序返回
+// 规则的 api_path 支持 :param 和 *wildcard，无法在 SQL 中匹配，因此加载启用规则后在内存中过滤
+func (r *permissionRuleRepository) FindMatchingRules(apiPath, method string) ([]*entity.PermissionRule, error) {
+	rules, err := r.GetRulesByStatus(1)
+	if err != nil {
+		return nil, err
+	}
+
+	matched := make([]*entity.PermissionRule, 0, len(rules))
+	for _, rule := range rules {
+		if abac.MatchMethod(rule.Method, method) && abac.MatchPath(rule.APIPath, apiPath) {
+			matched = append(matched, rule)
+		}
+	}
+	return matched, nil
 }
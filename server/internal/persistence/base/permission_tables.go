@@ -0,0 +1,30 @@
+package persistence
+
+// PermissionTables 影响访问判定的表，写入后权限判定缓存失效
+// 包括角色及其继承关系、用户和服务账号的角色分配、菜单、API、按钮、权限组以及 ABAC 规则
+var PermissionTables = []string{
+	"base_roles",
+	"base_role_users",
+	"base_role_menus",
+	"base_role_api_routes",
+	"base_role_btn_perms",
+	"base_menus",
+	"base_menu_api_routes",
+	"base_apis",
+	"base_btn_perms",
+	"base_btn_perm_api_routes",
+	"base_permission_groups",
+	"base_permission_group_apis",
+	"base_permission_group_btn_perms",
+	"base_role_permission_groups",
+	"base_permission_rules",
+	"base_service_account_roles",
+}
+
+// DataScopeTables 影响数据权限解析的表，写入后数据权限缓存失效
+// 包括角色的数据权限配置、用户的角色分配以及限时角色授予
+var DataScopeTables = []string{
+	"base_roles",
+	"base_role_users",
+	"base_role_grants",
+}
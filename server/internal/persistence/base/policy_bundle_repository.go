@@ -0,0 +1,700 @@
+package persistence
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/entity"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/repo"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/persistence/database"
+	"github.com/ix-pay/ixpay-pro/internal/persistence/common"
+	"gorm.io/gorm"
+)
+
+// menuAPIRouteModel 菜单-API 路由关联模型
+type menuAPIRouteModel struct {
+	database.SnowflakeBaseModel
+	MenuID  int64 `gorm:"not null;index"`
+	RouteID int64 `gorm:"not null;index"`
+}
+
+// TableName 指定表名
+func (menuAPIRouteModel) TableName() string {
+	return "base_menu_api_routes"
+}
+
+// btnPermAPIRouteModel 按钮权限-API 路由关联模型
+type btnPermAPIRouteModel struct {
+	BtnPermID int64 `gorm:"primaryKey"`
+	RouteID   int64 `gorm:"primaryKey"`
+}
+
+// TableName 指定表名
+func (btnPermAPIRouteModel) TableName() string {
+	return "base_btn_perm_api_routes"
+}
+
+// policyBundleRepository Repository 实现
+type policyBundleRepository struct {
+	db *database.PostgresDB
+}
+
+var _ repo.PolicyBundleRepository = (*policyBundleRepository)(nil)
+
+// NewPolicyBundleRepository 创建权限策略包仓库实现
+func NewPolicyBundleRepository(db *database.PostgresDB) repo.PolicyBundleRepository {
+	return &policyBundleRepository{db: db}
+}
+
+// Export 导出当前环境的权限配置
+func (r *policyBundleRepository) Export() (*entity.PolicyBundle, error) {
+	var apiModels []apiModel
+	if err := r.db.Find(&apiModels).Error; err != nil {
+		return nil, err
+	}
+	var menuModels []menuModel
+	if err := r.db.Preload("APIRoutes").Find(&menuModels).Error; err != nil {
+		return nil, err
+	}
+	var btnPermModels []btnPermModel
+	if err := r.db.Preload("APIRoutes").Find(&btnPermModels).Error; err != nil {
+		return nil, err
+	}
+	var roleModels []roleModel
+	if err := r.db.Find(&roleModels).Error; err != nil {
+		return nil, err
+	}
+	var roleMenus []roleMenuModel
+	if err := r.db.Find(&roleMenus).Error; err != nil {
+		return nil, err
+	}
+	var roleAPIs []roleAPIRouteModel
+	if err := r.db.Find(&roleAPIs).Error; err != nil {
+		return nil, err
+	}
+	var roleBtnPerms []roleBtnPermModel
+	if err := r.db.Find(&roleBtnPerms).Error; err != nil {
+		return nil, err
+	}
+	var groupModels []permissionGroupModel
+	if err := r.db.Find(&groupModels).Error; err != nil {
+		return nil, err
+	}
+	var groupAPIs []permissionGroupAPIModel
+	if err := r.db.Find(&groupAPIs).Error; err != nil {
+		return nil, err
+	}
+	var groupBtnPerms []permissionGroupBtnPermModel
+	if err := r.db.Find(&groupBtnPerms).Error; err != nil {
+		return nil, err
+	}
+	var roleGroups []rolePermissionGroupModel
+	if err := r.db.Find(&roleGroups).Error; err != nil {
+		return nil, err
+	}
+	var ruleModels []permissionRuleModel
+	if err := r.db.Find(&ruleModels).Error; err != nil {
+		return nil, err
+	}
+
+	bundle := &entity.PolicyBundle{
+		Version:    entity.PolicyBundleVersion,
+		ExportedAt: time.Now(),
+	}
+
+	apiKeys := make(map[int64]string, len(apiModels))
+	for i := range apiModels {
+		api := apiModels[i].toDomain()
+		item := entity.PolicyAPI{
+			Method:       api.Method,
+			Path:         api.Path,
+			Group:        api.Group,
+			AuthRequired: api.AuthRequired,
+			AuthType:     api.AuthType,
+			Description:  api.Description,
+			Status:       api.Status,
+		}
+		apiKeys[api.ID] = item.Key()
+		bundle.APIs = append(bundle.APIs, item)
+	}
+	apiRefs := func(routes []apiModel) []string {
+		keys := make([]string, 0, len(routes))
+		for _, route := range routes {
+			if key, ok := apiKeys[route.ID]; ok {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+		return keys
+	}
+
+	menuNames := make(map[int64]string, len(menuModels))
+	for _, m := range menuModels {
+		menuNames[m.ID] = m.Name
+	}
+	for i := range menuModels {
+		menu := menuModels[i].toDomain()
+		bundle.Menus = append(bundle.Menus, entity.PolicyMenu{
+			Name:       menu.Name,
+			Parent:     menuNames[menu.ParentID],
+			Path:       menu.Path,
+			Component:  menu.Component,
+			Title:      menu.Title,
+			Icon:       menu.Icon,
+			Hidden:     menu.Hidden,
+			Sort:       menu.Sort,
+			Status:     menu.Status,
+			IsExt:      menu.IsExt,
+			Redirect:   menu.Redirect,
+			Permission: menu.Permission,
+			Type:       int(menu.Type),
+			FrameSrc:   menu.FrameSrc,
+			APIs:       apiRefs(menuModels[i].APIRoutes),
+		})
+	}
+
+	btnPermCodes := make(map[int64]string, len(btnPermModels))
+	for i := range btnPermModels {
+		btnPerm := btnPermModels[i].toDomain()
+		btnPermCodes[btnPerm.ID] = btnPerm.Code
+		bundle.BtnPerms = append(bundle.BtnPerms, entity.PolicyBtnPerm{
+			Code:        btnPerm.Code,
+			Name:        btnPerm.Name,
+			Menu:        menuNames[btnPerm.MenuID],
+			Description: btnPerm.Description,
+			Status:      btnPerm.Status,
+			APIs:        apiRefs(btnPermModels[i].APIRoutes),
+		})
+	}
+
+	roleCodes := make(map[int64]string, len(roleModels))
+	for _, m := range roleModels {
+		roleCodes[m.ID] = m.Code
+	}
+	menusByRole := make(map[int64][]string)
+	for _, rm := range roleMenus {
+		if name, ok := menuNames[rm.MenuID]; ok {
+			menusByRole[rm.RoleID] = append(menusByRole[rm.RoleID], name)
+		}
+	}
+	apisByRole := make(map[int64][]string)
+	for _, ra := range roleAPIs {
+		if key, ok := apiKeys[ra.RouteID]; ok {
+			apisByRole[ra.RoleID] = append(apisByRole[ra.RoleID], key)
+		}
+	}
+	btnPermsByRole := make(map[int64][]string)
+	for _, rb := range roleBtnPerms {
+		if code, ok := btnPermCodes[rb.BtnPermID]; ok {
+			btnPermsByRole[rb.RoleID] = append(btnPermsByRole[rb.RoleID], code)
+		}
+	}
+	groupNames := make(map[int64]string, len(groupModels))
+	for _, m := range groupModels {
+		groupNames[m.ID] = m.Name
+	}
+	groupsByRole := make(map[int64][]string)
+	for _, rg := range roleGroups {
+		if name, ok := groupNames[rg.GroupID]; ok {
+			groupsByRole[rg.RoleID] = append(groupsByRole[rg.RoleID], name)
+		}
+	}
+	for i := range roleModels {
+		role := roleModels[i].toDomain()
+		item := entity.PolicyRole{
+			Code:             role.Code,
+			Name:             role.Name,
+			Parent:           roleCodes[role.ParentID],
+			Description:      role.Description,
+			Type:             role.Type,
+			Status:           role.Status,
+			IsSystem:         role.IsSystem,
+			Sort:             role.Sort,
+			DataScope:        role.DataScope,
+			ApprovalRequired: role.ApprovalRequired,
+			Menus:            sortedStrings(menusByRole[role.ID]),
+			APIs:             sortedStrings(apisByRole[role.ID]),
+			BtnPerms:         sortedStrings(btnPermsByRole[role.ID]),
+			PermissionGroups: sortedStrings(groupsByRole[role.ID]),
+		}
+		bundle.Roles = append(bundle.Roles, item)
+	}
+
+	apisByGroup := make(map[int64][]string)
+	for _, ga := range groupAPIs {
+		if key, ok := apiKeys[ga.RouteID]; ok {
+			apisByGroup[ga.GroupID] = append(apisByGroup[ga.GroupID], key)
+		}
+	}
+	btnPermsByGroup := make(map[int64][]string)
+	for _, gb := range groupBtnPerms {
+		if code, ok := btnPermCodes[gb.BtnPermID]; ok {
+			btnPermsByGroup[gb.GroupID] = append(btnPermsByGroup[gb.GroupID], code)
+		}
+	}
+	for i := range groupModels {
+		group := groupModels[i].toDomain()
+		bundle.PermissionGroups = append(bundle.PermissionGroups, entity.PolicyPermissionGroup{
+			Name:        group.Name,
+			Description: group.Description,
+			Status:      group.Status,
+			Sort:        group.Sort,
+			APIs:        sortedStrings(apisByGroup[group.ID]),
+			BtnPerms:    sortedStrings(btnPermsByGroup[group.ID]),
+		})
+	}
+
+	for i := range ruleModels {
+		rule := ruleModels[i].toDomain()
+		bundle.Rules = append(bundle.Rules, entity.PolicyRule{
+			Name:        rule.Name,
+			Description: rule.Description,
+			Effect:      rule.Effect,
+			Method:      rule.Method,
+			APIPath:     rule.APIPath,
+			Conditions:  rule.Conditions,
+			Status:      rule.Status,
+			Sort:        rule.Sort,
+			IsSystem:    rule.IsSystem,
+		})
+	}
+
+	sortPolicyBundle(bundle)
+	return bundle, nil
+}
+
+// Apply 在单个事务中写入策略包，任一对象失败时整体回滚
+// 写入顺序为 API、菜单、按钮权限、权限组、规则、角色，保证引用的对象已存在；菜单和角色的父级在全部写入后再关联
+func (r *policyBundleRepository) Apply(bundle *entity.PolicyBundle, operatorID int64) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return applyPolicyBundle(tx, bundle, operatorID)
+	})
+}
+
+// ApplyApproved 在同一事务中写入审批通过的策略包并更新导入申请，写入失败时申请保持待审批
+func (r *policyBundleRepository) ApplyApproved(bundle *entity.PolicyBundle, request *entity.PermissionChangeRequest) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := applyPolicyBundle(tx, bundle, request.ReviewedBy); err != nil {
+			return err
+		}
+		return updatePermissionChangeRequest(tx, request)
+	})
+}
+
+// applyPolicyBundle 按依赖顺序写入策略包中的全部对象
+func applyPolicyBundle(tx *gorm.DB, bundle *entity.PolicyBundle, operatorID int64) error {
+	apiIDs, err := applyPolicyAPIs(tx, bundle.APIs, operatorID)
+	if err != nil {
+		return err
+	}
+	menuIDs, err := applyPolicyMenus(tx, bundle.Menus, apiIDs, operatorID)
+	if err != nil {
+		return err
+	}
+	btnPermIDs, err := applyPolicyBtnPerms(tx, bundle.BtnPerms, menuIDs, apiIDs, operatorID)
+	if err != nil {
+		return err
+	}
+	groupIDs, err := applyPolicyPermissionGroups(tx, bundle.PermissionGroups, apiIDs, btnPermIDs, operatorID)
+	if err != nil {
+		return err
+	}
+	if err := applyPolicyRules(tx, bundle.Rules, operatorID); err != nil {
+		return err
+	}
+	return applyPolicyRoles(tx, bundle.Roles, menuIDs, apiIDs, btnPermIDs, groupIDs, operatorID)
+}
+
+// applyPolicyAPIs 按方法和路径写入 API，返回全部 API 的引用键到 ID 映射
+func applyPolicyAPIs(tx *gorm.DB, items []entity.PolicyAPI, operatorID int64) (map[string]int64, error) {
+	var existing []apiModel
+	if err := tx.Find(&existing).Error; err != nil {
+		return nil, err
+	}
+	ids := make(map[string]int64, len(existing)+len(items))
+	for _, m := range existing {
+		ids[entity.PolicyAPIKey(m.Method, m.Path)] = m.ID
+	}
+
+	for _, item := range items {
+		fields := map[string]interface{}{
+			"group":         item.Group,
+			"auth_required": item.AuthRequired,
+			"auth_type":     item.AuthType,
+			"description":   item.Description,
+			"status":        item.Status,
+			"updated_by":    operatorID,
+		}
+		if id, ok := ids[item.Key()]; ok {
+			if err := tx.Model(&apiModel{}).Where("id = ?", id).Updates(fields).Error; err != nil {
+				return nil, fmt.Errorf("更新 API %s 失败: %w", item.Key(), err)
+			}
+			continue
+		}
+		model := &apiModel{
+			SnowflakeBaseModel: database.SnowflakeBaseModel{CreatedBy: operatorID, UpdatedBy: operatorID},
+			Method:             item.Method,
+			Path:               item.Path,
+			Group:              item.Group,
+			AuthRequired:       common.BoolPtr(item.AuthRequired),
+			AuthType:           common.IntPtr(item.AuthType),
+			Description:        item.Description,
+			Status:             common.IntPtr(item.Status),
+		}
+		if err := tx.Create(model).Error; err != nil {
+			return nil, fmt.Errorf("创建 API %s 失败: %w", item.Key(), err)
+		}
+		ids[item.Key()] = model.ID
+	}
+	return ids, nil
+}
+
+// applyPolicyMenus 按名称写入菜单并替换其 API 关联，返回全部菜单的名称到 ID 映射
+func applyPolicyMenus(tx *gorm.DB, items []entity.PolicyMenu, apiIDs map[string]int64, operatorID int64) (map[string]int64, error) {
+	var existing []menuModel
+	if err := tx.Find(&existing).Error; err != nil {
+		return nil, err
+	}
+	ids := make(map[string]int64, len(existing)+len(items))
+	for _, m := range existing {
+		ids[m.Name] = m.ID
+	}
+
+	for _, item := range items {
+		fields := map[string]interface{}{
+			"path":       item.Path,
+			"component":  item.Component,
+			"title":      item.Title,
+			"icon":       item.Icon,
+			"hidden":     item.Hidden,
+			"sort":       item.Sort,
+			"status":     item.Status,
+			"is_ext":     item.IsExt,
+			"redirect":   item.Redirect,
+			"permission": item.Permission,
+			"type":       item.Type,
+			"frame_src":  item.FrameSrc,
+			"updated_by": operatorID,
+		}
+		if id, ok := ids[item.Name]; ok {
+			if err := tx.Model(&menuModel{}).Where("id = ?", id).Updates(fields).Error; err != nil {
+				return nil, fmt.Errorf("更新菜单 %s 失败: %w", item.Name, err)
+			}
+			continue
+		}
+		model := &menuModel{
+			SnowflakeBaseModel: database.SnowflakeBaseModel{CreatedBy: operatorID, UpdatedBy: operatorID},
+			ParentID:           common.Int64Ptr(0),
+			Name:               item.Name,
+			Path:               item.Path,
+			Component:          item.Component,
+			Title:              item.Title,
+			Icon:               item.Icon,
+			Hidden:             common.BoolPtr(item.Hidden),
+			Sort:               common.IntPtr(item.Sort),
+			Status:             common.IntPtr(item.Status),
+			IsExt:              common.BoolPtr(item.IsExt),
+			Redirect:           item.Redirect,
+			Permission:         item.Permission,
+			Type:               common.IntPtr(item.Type),
+			FrameSrc:           item.FrameSrc,
+		}
+		if err := tx.Omit("Children", "Parent", "APIRoutes", "BtnPerms").Create(model).Error; err != nil {
+			return nil, fmt.Errorf("创建菜单 %s 失败: %w", item.Name, err)
+		}
+		ids[item.Name] = model.ID
+	}
+
+	for _, item := range items {
+		menuID := ids[item.Name]
+		if err := tx.Model(&menuModel{}).Where("id = ?", menuID).Update("parent_id", ids[item.Parent]).Error; err != nil {
+			return nil, fmt.Errorf("设置菜单 %s 的父菜单失败: %w", item.Name, err)
+		}
+		if err := tx.Unscoped().Where("menu_id = ?", menuID).Delete(&menuAPIRouteModel{}).Error; err != nil {
+			return nil, err
+		}
+		for _, key := range item.APIs {
+			link := &menuAPIRouteModel{
+				SnowflakeBaseModel: database.SnowflakeBaseModel{CreatedBy: operatorID, UpdatedBy: operatorID},
+				MenuID:             menuID,
+				RouteID:            apiIDs[key],
+			}
+			if err := tx.Create(link).Error; err != nil {
+				return nil, fmt.Errorf("关联菜单 %s 与 API %s 失败: %w", item.Name, key, err)
+			}
+		}
+	}
+	return ids, nil
+}
+
+// applyPolicyBtnPerms 按编码写入按钮权限并替换其 API 关联，返回全部按钮权限的编码到 ID 映射
+func applyPolicyBtnPerms(tx *gorm.DB, items []entity.PolicyBtnPerm, menuIDs, apiIDs map[string]int64, operatorID int64) (map[string]int64, error) {
+	var existing []btnPermModel
+	if err := tx.Find(&existing).Error; err != nil {
+		return nil, err
+	}
+	ids := make(map[string]int64, len(existing)+len(items))
+	for _, m := range existing {
+		ids[m.Code] = m.ID
+	}
+
+	for _, item := range items {
+		btnPermID, ok := ids[item.Code]
+		if ok {
+			fields := map[string]interface{}{
+				"menu_id":     menuIDs[item.Menu],
+				"name":        item.Name,
+				"description": item.Description,
+				"status":      item.Status,
+				"updated_by":  operatorID,
+			}
+			if err := tx.Model(&btnPermModel{}).Where("id = ?", btnPermID).Updates(fields).Error; err != nil {
+				return nil, fmt.Errorf("更新按钮权限 %s 失败: %w", item.Code, err)
+			}
+		} else {
+			model := &btnPermModel{
+				SnowflakeBaseModel: database.SnowflakeBaseModel{CreatedBy: operatorID, UpdatedBy: operatorID},
+				MenuID:             common.Int64Ptr(menuIDs[item.Menu]),
+				Code:               item.Code,
+				Name:               item.Name,
+				Description:        item.Description,
+				Status:             common.IntPtr(item.Status),
+			}
+			if err := tx.Omit("APIRoutes", "Menu").Create(model).Error; err != nil {
+				return nil, fmt.Errorf("创建按钮权限 %s 失败: %w", item.Code, err)
+			}
+			btnPermID = model.ID
+			ids[item.Code] = btnPermID
+		}
+
+		if err := tx.Where("btn_perm_id = ?", btnPermID).Delete(&btnPermAPIRouteModel{}).Error; err != nil {
+			return nil, err
+		}
+		for _, key := range item.APIs {
+			link := &btnPermAPIRouteModel{BtnPermID: btnPermID, RouteID: apiIDs[key]}
+			if err := tx.Create(link).Error; err != nil {
+				return nil, fmt.Errorf("关联按钮权限 %s 与 API %s 失败: %w", item.Code, key, err)
+			}
+		}
+	}
+	return ids, nil
+}
+
+// applyPolicyPermissionGroups 按名称写入权限组并替换其 API 和按钮权限，返回全部权限组的名称到 ID 映射
+func applyPolicyPermissionGroups(tx *gorm.DB, items []entity.PolicyPermissionGroup, apiIDs, btnPermIDs map[string]int64, operatorID int64) (map[string]int64, error) {
+	var existing []permissionGroupModel
+	if err := tx.Find(&existing).Error; err != nil {
+		return nil, err
+	}
+	ids := make(map[string]int64, len(existing)+len(items))
+	for _, m := range existing {
+		ids[m.Name] = m.ID
+	}
+
+	for _, item := range items {
+		groupID, ok := ids[item.Name]
+		if ok {
+			fields := map[string]interface{}{
+				"description": item.Description,
+				"status":      item.Status,
+				"sort":        item.Sort,
+				"updated_by":  operatorID,
+			}
+			if err := tx.Model(&permissionGroupModel{}).Where("id = ?", groupID).Updates(fields).Error; err != nil {
+				return nil, fmt.Errorf("更新权限组 %s 失败: %w", item.Name, err)
+			}
+		} else {
+			model := &permissionGroupModel{
+				SnowflakeBaseModel: database.SnowflakeBaseModel{CreatedBy: operatorID, UpdatedBy: operatorID},
+				Name:               item.Name,
+				Description:        item.Description,
+				Status:             common.IntPtr(item.Status),
+				Sort:               common.IntPtr(item.Sort),
+			}
+			if err := tx.Create(model).Error; err != nil {
+				return nil, fmt.Errorf("创建权限组 %s 失败: %w", item.Name, err)
+			}
+			groupID = model.ID
+			ids[item.Name] = groupID
+		}
+
+		if err := tx.Where("group_id = ?", groupID).Delete(&permissionGroupAPIModel{}).Error; err != nil {
+			return nil, err
+		}
+		for _, key := range item.APIs {
+			if err := tx.Create(&permissionGroupAPIModel{GroupID: groupID, RouteID: apiIDs[key]}).Error; err != nil {
+				return nil, fmt.Errorf("关联权限组 %s 与 API %s 失败: %w", item.Name, key, err)
+			}
+		}
+
+		if err := tx.Where("group_id = ?", groupID).Delete(&permissionGroupBtnPermModel{}).Error; err != nil {
+			return nil, err
+		}
+		for _, code := range item.BtnPerms {
+			if err := tx.Create(&permissionGroupBtnPermModel{GroupID: groupID, BtnPermID: btnPermIDs[code]}).Error; err != nil {
+				return nil, fmt.Errorf("关联权限组 %s 与按钮权限 %s 失败: %w", item.Name, code, err)
+			}
+		}
+	}
+	return ids, nil
+}
+
+// applyPolicyRules 按名称写入 ABAC 规则
+func applyPolicyRules(tx *gorm.DB, items []entity.PolicyRule, operatorID int64) error {
+	for _, item := range items {
+		var model permissionRuleModel
+		err := tx.Where("name = ?", item.Name).Take(&model).Error
+		if err == gorm.ErrRecordNotFound {
+			model = permissionRuleModel{
+				SnowflakeBaseModel: database.SnowflakeBaseModel{CreatedBy: operatorID, UpdatedBy: operatorID},
+				Name:               item.Name,
+				Description:        item.Description,
+				Effect:             item.Effect,
+				APIPath:            item.APIPath,
+				Method:             item.Method,
+				Conditions:         item.Conditions,
+				Status:             common.IntPtr(item.Status),
+				Sort:               common.IntPtr(item.Sort),
+				IsSystem:           common.BoolPtr(item.IsSystem),
+			}
+			if err := tx.Create(&model).Error; err != nil {
+				return fmt.Errorf("创建规则 %s 失败: %w", item.Name, err)
+			}
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		fields := map[string]interface{}{
+			"description": item.Description,
+			"effect":      item.Effect,
+			"api_path":    item.APIPath,
+			"method":      item.Method,
+			"conditions":  item.Conditions,
+			"status":      item.Status,
+			"sort":        item.Sort,
+			"is_system":   item.IsSystem,
+			"updated_by":  operatorID,
+		}
+		if err := tx.Model(&permissionRuleModel{}).Where("id = ?", model.ID).Updates(fields).Error; err != nil {
+			return fmt.Errorf("更新规则 %s 失败: %w", item.Name, err)
+		}
+	}
+	return nil
+}
+
+// applyPolicyRoles 按编码写入角色，设置父角色并替换其菜单、API 和按钮权限关联
+func applyPolicyRoles(tx *gorm.DB, items []entity.PolicyRole, menuIDs, apiIDs, btnPermIDs, groupIDs map[string]int64, operatorID int64) error {
+	var existing []roleModel
+	if err := tx.Find(&existing).Error; err != nil {
+		return err
+	}
+	ids := make(map[string]int64, len(existing)+len(items))
+	for _, m := range existing {
+		ids[m.Code] = m.ID
+	}
+
+	for _, item := range items {
+		if id, ok := ids[item.Code]; ok {
+			fields := map[string]interface{}{
+				"name":              item.Name,
+				"description":       item.Description,
+				"type":              item.Type,
+				"status":            item.Status,
+				"is_system":         item.IsSystem,
+				"sort":              item.Sort,
+				"data_scope":        item.DataScope,
+				"approval_required": item.ApprovalRequired,
+				"updated_by":        operatorID,
+			}
+			if err := tx.Model(&roleModel{}).Where("id = ?", id).Updates(fields).Error; err != nil {
+				return fmt.Errorf("更新角色 %s 失败: %w", item.Code, err)
+			}
+			continue
+		}
+		model := &roleModel{
+			SnowflakeBaseModel: database.SnowflakeBaseModel{CreatedBy: operatorID, UpdatedBy: operatorID},
+			Name:               item.Name,
+			Code:               item.Code,
+			Description:        item.Description,
+			Type:               common.IntPtr(item.Type),
+			ParentID:           common.Int64Ptr(0),
+			Status:             common.IntPtr(item.Status),
+			IsSystem:           common.BoolPtr(item.IsSystem),
+			Sort:               common.IntPtr(item.Sort),
+			DataScope:          common.IntPtr(item.DataScope),
+			ApprovalRequired:   common.BoolPtr(item.ApprovalRequired),
+		}
+		if err := tx.Omit("Users", "Menus", "APIRoutes", "BtnPerms", "Children", "Parent").Create(model).Error; err != nil {
+			return fmt.Errorf("创建角色 %s 失败: %w", item.Code, err)
+		}
+		ids[item.Code] = model.ID
+	}
+
+	for _, item := range items {
+		roleID := ids[item.Code]
+		if err := tx.Model(&roleModel{}).Where("id = ?", roleID).Update("parent_id", ids[item.Parent]).Error; err != nil {
+			return fmt.Errorf("设置角色 %s 的父角色失败: %w", item.Code, err)
+		}
+
+		if err := tx.Where("role_id = ?", roleID).Delete(&roleMenuModel{}).Error; err != nil {
+			return err
+		}
+		for _, name := range item.Menus {
+			if err := tx.Create(&roleMenuModel{RoleID: roleID, MenuID: menuIDs[name]}).Error; err != nil {
+				return fmt.Errorf("关联角色 %s 与菜单 %s 失败: %w", item.Code, name, err)
+			}
+		}
+
+		if err := tx.Where("role_id = ?", roleID).Delete(&roleAPIRouteModel{}).Error; err != nil {
+			return err
+		}
+		for _, key := range item.APIs {
+			link := &roleAPIRouteModel{RoleID: roleID, RouteID: apiIDs[key], Source: common.IntPtr(1)}
+			if err := tx.Create(link).Error; err != nil {
+				return fmt.Errorf("关联角色 %s 与 API %s 失败: %w", item.Code, key, err)
+			}
+		}
+
+		if err := tx.Where("role_id = ?", roleID).Delete(&roleBtnPermModel{}).Error; err != nil {
+			return err
+		}
+		for _, code := range item.BtnPerms {
+			if err := tx.Create(&roleBtnPermModel{RoleID: roleID, BtnPermID: btnPermIDs[code]}).Error; err != nil {
+				return fmt.Errorf("关联角色 %s 与按钮权限 %s 失败: %w", item.Code, code, err)
+			}
+		}
+
+		if err := tx.Where("role_id = ?", roleID).Delete(&rolePermissionGroupModel{}).Error; err != nil {
+			return err
+		}
+		for _, name := range item.PermissionGroups {
+			if err := tx.Create(&rolePermissionGroupModel{RoleID: roleID, GroupID: groupIDs[name]}).Error; err != nil {
+				return fmt.Errorf("关联角色 %s 与权限组 %s 失败: %w", item.Code, name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// sortPolicyBundle 按引用键排序策略包中的对象，保证多次导出的结果稳定，便于在 git 中比较
+func sortPolicyBundle(bundle *entity.PolicyBundle) {
+	sort.Slice(bundle.APIs, func(i, j int) bool { return bundle.APIs[i].Key() < bundle.APIs[j].Key() })
+	sort.Slice(bundle.Menus, func(i, j int) bool { return bundle.Menus[i].Name < bundle.Menus[j].Name })
+	sort.Slice(bundle.BtnPerms, func(i, j int) bool { return bundle.BtnPerms[i].Code < bundle.BtnPerms[j].Code })
+	sort.Slice(bundle.Roles, func(i, j int) bool { return bundle.Roles[i].Code < bundle.Roles[j].Code })
+	sort.Slice(bundle.PermissionGroups, func(i, j int) bool {
+		return bundle.PermissionGroups[i].Name < bundle.PermissionGroups[j].Name
+	})
+	sort.Slice(bundle.Rules, func(i, j int) bool { return bundle.Rules[i].Name < bundle.Rules[j].Name })
+}
+
+// sortedStrings 返回排序后的字符串列表，空列表返回非 nil 的空切片以保持导出格式一致
+func sortedStrings(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	sort.Strings(values)
+	return values
+}
@@ -0,0 +1,226 @@
+package persistence
+
+import (
+	"time"
+
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/entity"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/repo"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/persistence/database"
+)
+
+// roleGrantModel 限时角色授予数据库模型
+type roleGrantModel struct {
+	database.SnowflakeBaseModel
+	UserID         int64 `gorm:"not null;index"`
+	RoleID         int64 `gorm:"not null;index"`
+	ValidFrom      *time.Time
+	ValidUntil     *time.Time
+	Justification  string `gorm:"size:500"`
+	Status         string `gorm:"size:20;not null;index"`
+	Elevation      bool   `gorm:"not null;default:false"`
+	RequestedHours int    `gorm:"not null;default:0"`
+	ApprovedBy     int64  `gorm:"not null;default:0"`
+	ApprovedAt     *time.Time
+	ReviewComment  string `gorm:"size:500"`
+	RevokedBy      int64  `gorm:"not null;default:0"`
+	RevokedAt      *time.Time
+}
+
+// TableName 指定表名
+func (roleGrantModel) TableName() string {
+	return "base_role_grants"
+}
+
+// toDomain 将数据库模型转换为领域实体
+func (m *roleGrantModel) toDomain() *entity.RoleGrant {
+	if m == nil {
+		return nil
+	}
+
+	grant := &entity.RoleGrant{
+		ID:             m.ID,
+		UserID:         m.UserID,
+		RoleID:         m.RoleID,
+		Justification:  m.Justification,
+		Status:         m.Status,
+		Elevation:      m.Elevation,
+		RequestedHours: m.RequestedHours,
+		ApprovedBy:     m.ApprovedBy,
+		ApprovedAt:     m.ApprovedAt,
+		ReviewComment:  m.ReviewComment,
+		RevokedBy:      m.RevokedBy,
+		RevokedAt:      m.RevokedAt,
+		CreatedBy:      m.CreatedBy,
+		CreatedAt:      m.CreatedAt,
+		UpdatedBy:      m.UpdatedBy,
+		UpdatedAt:      m.UpdatedAt,
+	}
+
+	// 待审批的提权申请还没有有效期
+	if m.ValidFrom != nil {
+		grant.ValidFrom = *m.ValidFrom
+	}
+	if m.ValidUntil != nil {
+		grant.ValidUntil = *m.ValidUntil
+	}
+
+	return grant
+}
+
+// fromDomainRoleGrant 将领域实体转换为数据库模型
+func fromDomainRoleGrant(grant *entity.RoleGrant) *roleGrantModel {
+	model := &roleGrantModel{
+		SnowflakeBaseModel: database.SnowflakeBaseModel{
+			ID:        grant.ID,
+			CreatedBy: grant.CreatedBy,
+			UpdatedBy: grant.UpdatedBy,
+		},
+		UserID:         grant.UserID,
+		RoleID:         grant.RoleID,
+		Justification:  grant.Justification,
+		Status:         grant.Status,
+		Elevation:      grant.Elevation,
+		RequestedHours: grant.RequestedHours,
+		ApprovedBy:     grant.ApprovedBy,
+		ApprovedAt:     grant.ApprovedAt,
+		ReviewComment:  grant.ReviewComment,
+		RevokedBy:      grant.RevokedBy,
+		RevokedAt:      grant.RevokedAt,
+	}
+
+	if !grant.ValidFrom.IsZero() {
+		validFrom := grant.ValidFrom
+		model.ValidFrom = &validFrom
+	}
+	if !grant.ValidUntil.IsZero() {
+		validUntil := grant.ValidUntil
+		model.ValidUntil = &validUntil
+	}
+
+	return model
+}
+
+// roleGrantRepository Repository 实现
+type roleGrantRepository struct {
+	db *database.PostgresDB
+}
+
+// 确保实现接口
+var _ repo.RoleGrantRepository = (*roleGrantRepository)(nil)
+
+// NewRoleGrantRepository 创建限时角色授予仓库实现
+func NewRoleGrantRepository(db *database.PostgresDB) repo.RoleGrantRepository {
+	return &roleGrantRepository{db: db}
+}
+
+// GetByID 根据 ID 查询授予
+func (r *roleGrantRepository) GetByID(id int64) (*entity.RoleGrant, error) {
+	var dbModel roleGrantModel
+	if err := r.db.Where("id = ?", id).First(&dbModel).Error; err != nil {
+		return nil, err
+	}
+
+	return dbModel.toDomain(), nil
+}
+
+// Create 创建授予
+func (r *roleGrantRepository) Create(grant *entity.RoleGrant) error {
+	dbModel := fromDomainRoleGrant(grant)
+	if err := r.db.Create(dbModel).Error; err != nil {
+		return err
+	}
+
+	// 将生成的 ID 回写到领域实体
+	grant.ID = dbModel.ID
+	grant.CreatedAt = dbModel.CreatedAt
+	return nil
+}
+
+// Update 更新授予的有效期、状态和审批信息
+func (r *roleGrantRepository) Update(grant *entity.RoleGrant) error {
+	dbModel := fromDomainRoleGrant(grant)
+	return r.db.Model(&roleGrantModel{}).Where("id = ?", grant.ID).Updates(map[string]interface{}{
+		"valid_from":     dbModel.ValidFrom,
+		"valid_until":    dbModel.ValidUntil,
+		"status":         dbModel.Status,
+		"approved_by":    dbModel.ApprovedBy,
+		"approved_at":    dbModel.ApprovedAt,
+		"review_comment": dbModel.ReviewComment,
+		"revoked_by":     dbModel.RevokedBy,
+		"revoked_at":     dbModel.RevokedAt,
+		"updated_by":     dbModel.UpdatedBy,
+		"updated_at":     time.Now(),
+	}).Error
+}
+
+// List 分页查询授予列表
+func (r *roleGrantRepository) List(page, pageSize int, filters map[string]interface{}) ([]*entity.RoleGrant, int64, error) {
+	var total int64
+	var dbModels []roleGrantModel
+
+	query := r.db.Model(&roleGrantModel{})
+
+	// 应用过滤条件
+	for key, value := range filters {
+		query = query.Where(key+" = ?", value)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * pageSize
+	if err := query.Order("created_at DESC").Offset(offset).Limit(pageSize).Find(&dbModels).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return roleGrantsToDomain(dbModels), total, nil
+}
+
+// ListByUserRole 获取用户在指定角色上处于指定状态的授予
+func (r *roleGrantRepository) ListByUserRole(userID, roleID int64, statuses ...string) ([]*entity.RoleGrant, error) {
+	var dbModels []roleGrantModel
+	query := r.db.Where("user_id = ? AND role_id = ?", userID, roleID)
+	if len(statuses) > 0 {
+		query = query.Where("status IN ?", statuses)
+	}
+	if err := query.Order("created_at ASC").Find(&dbModels).Error; err != nil {
+		return nil, err
+	}
+
+	return roleGrantsToDomain(dbModels), nil
+}
+
+// ListDueForActivation 获取生效时间已到、尚未生效的授予
+func (r *roleGrantRepository) ListDueForActivation(now time.Time) ([]*entity.RoleGrant, error) {
+	var dbModels []roleGrantModel
+	if err := r.db.Where("status = ? AND valid_from <= ?", entity.RoleGrantStatusScheduled, now).
+		Order("valid_from ASC").
+		Find(&dbModels).Error; err != nil {
+		return nil, err
+	}
+
+	return roleGrantsToDomain(dbModels), nil
+}
+
+// ListDueForExpiry 获取到期时间已到、仍在生效中的授予
+func (r *roleGrantRepository) ListDueForExpiry(now time.Time) ([]*entity.RoleGrant, error) {
+	var dbModels []roleGrantModel
+	if err := r.db.Where("status IN ? AND valid_until <= ?",
+		[]string{entity.RoleGrantStatusScheduled, entity.RoleGrantStatusActive}, now).
+		Order("valid_until ASC").
+		Find(&dbModels).Error; err != nil {
+		return nil, err
+	}
+
+	return roleGrantsToDomain(dbModels), nil
+}
+
+// roleGrantsToDomain 批量转换为领域实体
+func roleGrantsToDomain(dbModels []roleGrantModel) []*entity.RoleGrant {
+	grants := make([]*entity.RoleGrant, len(dbModels))
+	for i := range dbModels {
+		grants[i] = dbModels[i].toDomain()
+	}
+	return grants
+}
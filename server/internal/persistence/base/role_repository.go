@@ -1,6 +1,8 @@
 package persistence
 
 import (
+	"encoding/json"
+
 	"github.com/ix-pay/ixpay-pro/internal/domain/base/entity"
 	"github.com/ix-pay/ixpay-pro/internal/domain/base/repo"
 	"github.com/ix-pay/ixpay-pro/internal/infrastructure/persistence/database"
@@ -18,6 +20,11 @@ type roleModel struct {
 	Status      *int   `gorm:"not null;default:1"`
 	IsSystem    *bool  `gorm:"not null;default:false"`
 	Sort        *int   `gorm:"not null;default:0"`
+	DataScope   *int   `gorm:"not null;default:1"`
+	// 自定义数据权限的部门 ID 列表，JSON 数组
+	DataScopeDeptIDs string `gorm:"column:data_scope_dept_ids;type:text"`
+	// 权限变更是否需要审批
+	ApprovalRequired *bool `gorm:"not null;default:false"`
 
 	// GORM 关联关系 - 多对多（通过中间表）
 	Users []*userModel `gorm:"many2many:base_role_users;joinForeignKey:role_id;joinReferences:user_id;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
@@ -131,6 +138,20 @@ func (m *roleModel) toDomain() *entity.Role {
 		role.Sort = 0
 	}
 
+	if m.DataScope != nil {
+		role.DataScope = *m.DataScope
+	} else {
+		role.DataScope = entity.DataScopeAll
+	}
+
+	if m.ApprovalRequired != nil {
+		role.ApprovalRequired = *m.ApprovalRequired
+	}
+
+	if m.DataScopeDeptIDs != "" {
+		json.Unmarshal([]byte(m.DataScopeDeptIDs), &role.DataScopeDeptIds)
+	}
+
 	// ⭐ 处理关联数据 - 用户（同时填充 UserIds 和 Users）
 	if len(m.Users) > 0 {
 		users := make([]*entity.User, len(m.Users))
@@ -198,6 +219,21 @@ func (m *roleModel) toDomain() *entity.Role {
 
 // fromDomain 将领域实体转换为数据库模型
 func fromDomainRole(role *entity.Role) (*roleModel, error) {
+	// 未设置数据权限时默认为全部数据，与新增字段前的行为一致
+	dataScope := role.DataScope
+	if dataScope == 0 {
+		dataScope = entity.DataScopeAll
+	}
+
+	deptIDs := ""
+	if len(role.DataScopeDeptIds) > 0 {
+		data, err := json.Marshal(role.DataScopeDeptIds)
+		if err != nil {
+			return nil, err
+		}
+		deptIDs = string(data)
+	}
+
 	return &roleModel{
 		SnowflakeBaseModel: database.SnowflakeBaseModel{
 			ID:        role.ID,
@@ -212,6 +248,10 @@ func fromDomainRole(role *entity.Role) (*roleModel, error) {
 		Status:      common.IntPtr(role.Status),
 		IsSystem:    common.BoolPtr(role.IsSystem),
 		Sort:        common.IntPtr(role.Sort),
+		DataScope:   common.IntPtr(dataScope),
+		// 自定义数据权限的部门 ID 列表
+		DataScopeDeptIDs: deptIDs,
+		ApprovalRequired: common.BoolPtr(role.ApprovalRequired),
 	}, nil
 }
 
@@ -0,0 +1,384 @@
+package persistence
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/entity"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/repo"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/persistence/database"
+	"github.com/ix-pay/ixpay-pro/internal/persistence/common"
+	"gorm.io/gorm"
+)
+
+// serviceAccountModel 服务账号数据库模型
+type serviceAccountModel struct {
+	database.SnowflakeBaseModel
+	Name        string     `gorm:"size:100;not null;unique"`
+	Description string     `gorm:"size:255"`
+	Status      *int       `gorm:"not null;default:1"`
+	IPAllowlist string     `gorm:"type:text"`
+	LastUsedAt  *time.Time `gorm:"column:last_used_at"`
+	LastUsedIP  string     `gorm:"size:50"`
+}
+
+// TableName 指定表名
+func (serviceAccountModel) TableName() string {
+	return "base_service_accounts"
+}
+
+// serviceAccountRoleModel 服务账号角色关联模型
+type serviceAccountRoleModel struct {
+	ServiceAccountID int64 `gorm:"not null;index"`
+	RoleID           int64 `gorm:"not null;index"`
+}
+
+// TableName 指定表名
+func (serviceAccountRoleModel) TableName() string {
+	return "base_service_account_roles"
+}
+
+// serviceAccountKeyModel 服务账号 API Key 数据库模型
+type serviceAccountKeyModel struct {
+	database.SnowflakeBaseModel
+	ServiceAccountID int64      `gorm:"not null;index"`
+	Prefix           string     `gorm:"size:32;not null;unique"`
+	KeyHash          string     `gorm:"size:128;not null"`
+	ExpiresAt        *time.Time `gorm:"column:expires_at"`
+	RevokedAt        *time.Time `gorm:"column:revoked_at"`
+	LastUsedAt       *time.Time `gorm:"column:last_used_at"`
+	LastUsedIP       string     `gorm:"size:50"`
+}
+
+// TableName 指定表名
+func (serviceAccountKeyModel) TableName() string {
+	return "base_service_account_keys"
+}
+
+// toDomain 将数据库模型转换为领域实体
+func (m *serviceAccountModel) toDomain() *entity.ServiceAccount {
+	if m == nil {
+		return nil
+	}
+
+	var allowlist []string
+	if m.IPAllowlist != "" {
+		json.Unmarshal([]byte(m.IPAllowlist), &allowlist)
+	}
+
+	account := &entity.ServiceAccount{
+		ID:          m.ID,
+		Name:        m.Name,
+		Description: m.Description,
+		IPAllowlist: allowlist,
+		LastUsedAt:  m.LastUsedAt,
+		LastUsedIP:  m.LastUsedIP,
+		CreatedBy:   m.CreatedBy,
+		CreatedAt:   m.CreatedAt,
+		UpdatedBy:   m.UpdatedBy,
+		UpdatedAt:   m.UpdatedAt,
+	}
+
+	// 安全解引用，提供默认值
+	if m.Status != nil {
+		account.Status = *m.Status
+	} else {
+		account.Status = entity.ServiceAccountStatusEnabled
+	}
+
+	return account
+}
+
+// fromDomainServiceAccount 将领域实体转换为数据库模型
+func fromDomainServiceAccount(account *entity.ServiceAccount) (*serviceAccountModel, error) {
+	allowlistJSON := ""
+	if len(account.IPAllowlist) > 0 {
+		jsonData, err := json.Marshal(account.IPAllowlist)
+		if err != nil {
+			return nil, err
+		}
+		allowlistJSON = string(jsonData)
+	}
+
+	return &serviceAccountModel{
+		SnowflakeBaseModel: database.SnowflakeBaseModel{
+			ID:        account.ID,
+			CreatedBy: account.CreatedBy,
+			UpdatedBy: account.UpdatedBy,
+		},
+		Name:        account.Name,
+		Description: account.Description,
+		Status:      common.IntPtr(account.Status),
+		IPAllowlist: allowlistJSON,
+		LastUsedAt:  account.LastUsedAt,
+		LastUsedIP:  account.LastUsedIP,
+	}, nil
+}
+
+// toDomain 将数据库模型转换为领域实体
+func (m *serviceAccountKeyModel) toDomain() *entity.ServiceAccountKey {
+	if m == nil {
+		return nil
+	}
+	return &entity.ServiceAccountKey{
+		ID:               m.ID,
+		ServiceAccountID: m.ServiceAccountID,
+		Prefix:           m.Prefix,
+		KeyHash:          m.KeyHash,
+		ExpiresAt:        m.ExpiresAt,
+		RevokedAt:        m.RevokedAt,
+		LastUsedAt:       m.LastUsedAt,
+		LastUsedIP:       m.LastUsedIP,
+		CreatedBy:        m.CreatedBy,
+		CreatedAt:        m.CreatedAt,
+	}
+}
+
+// serviceAccountRepository Repository 实现
+type serviceAccountRepository struct {
+	db *database.PostgresDB
+}
+
+// 确保实现接口
+var _ repo.ServiceAccountRepository = (*serviceAccountRepository)(nil)
+
+// NewServiceAccountRepository 创建服务账号仓库实现
+func NewServiceAccountRepository(db *database.PostgresDB) repo.ServiceAccountRepository {
+	return &serviceAccountRepository{db: db}
+}
+
+// GetByID 根据 ID 查询服务账号（含角色 ID 列表）
+func (r *serviceAccountRepository) GetByID(id int64) (*entity.ServiceAccount, error) {
+	var dbModel serviceAccountModel
+	if err := r.db.Where("id = ?", id).First(&dbModel).Error; err != nil {
+		return nil, err
+	}
+
+	account := dbModel.toDomain()
+	roleIDs, err := r.getRoleIDs(account.ID)
+	if err != nil {
+		return nil, err
+	}
+	account.RoleIds = roleIDs
+	return account, nil
+}
+
+// GetByName 根据名称查询服务账号
+func (r *serviceAccountRepository) GetByName(name string) (*entity.ServiceAccount, error) {
+	var dbModel serviceAccountModel
+	if err := r.db.Where("name = ?", name).First(&dbModel).Error; err != nil {
+		return nil, err
+	}
+
+	return dbModel.toDomain(), nil
+}
+
+// Create 创建服务账号
+func (r *serviceAccountRepository) Create(account *entity.ServiceAccount) error {
+	dbModel, err := fromDomainServiceAccount(account)
+	if err != nil {
+		return err
+	}
+
+	if err := r.db.Create(dbModel).Error; err != nil {
+		return err
+	}
+
+	// 将生成的 ID 回写到领域实体
+	account.ID = dbModel.ID
+	account.CreatedAt = dbModel.CreatedAt
+	return nil
+}
+
+// Update 更新服务账号基本信息
+func (r *serviceAccountRepository) Update(account *entity.ServiceAccount) error {
+	dbModel, err := fromDomainServiceAccount(account)
+	if err != nil {
+		return err
+	}
+
+	return r.db.Model(&serviceAccountModel{}).Where("id = ?", account.ID).Updates(map[string]interface{}{
+		"description":  dbModel.Description,
+		"status":       dbModel.Status,
+		"ip_allowlist": dbModel.IPAllowlist,
+		"updated_by":   dbModel.UpdatedBy,
+		"updated_at":   time.Now(),
+	}).Error
+}
+
+// Delete 删除服务账号，同时删除角色关联并吊销所有 Key
+func (r *serviceAccountRepository) Delete(id int64) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("service_account_id = ?", id).Delete(&serviceAccountRoleModel{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&serviceAccountKeyModel{}).
+			Where("service_account_id = ? AND revoked_at IS NULL", id).
+			Update("revoked_at", time.Now()).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&serviceAccountModel{}, id).Error
+	})
+}
+
+// List 分页查询服务账号列表
+func (r *serviceAccountRepository) List(page, pageSize int, filters map[string]interface{}) ([]*entity.ServiceAccount, int64, error) {
+	var total int64
+	var dbModels []serviceAccountModel
+
+	query := r.db.Model(&serviceAccountModel{})
+
+	// 应用过滤条件
+	for key, value := range filters {
+		query = query.Where(key+" = ?", value)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * pageSize
+	if err := query.Order("created_at DESC").Offset(offset).Limit(pageSize).Find(&dbModels).Error; err != nil {
+		return nil, 0, err
+	}
+
+	accounts := make([]*entity.ServiceAccount, len(dbModels))
+	for i, model := range dbModels {
+		accounts[i] = model.toDomain()
+		roleIDs, err := r.getRoleIDs(model.ID)
+		if err != nil {
+			return nil, 0, err
+		}
+		accounts[i].RoleIds = roleIDs
+	}
+
+	return accounts, total, nil
+}
+
+// getRoleIDs 获取服务账号绑定的角色 ID 列表
+func (r *serviceAccountRepository) getRoleIDs(accountID int64) ([]int64, error) {
+	var roleIDs []int64
+	err := r.db.Model(&serviceAccountRoleModel{}).
+		Where("service_account_id = ?", accountID).
+		Pluck("role_id", &roleIDs).Error
+	return roleIDs, err
+}
+
+// SetRoles 覆盖设置服务账号绑定的角色
+func (r *serviceAccountRepository) SetRoles(accountID int64, roleIDs []int64) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("service_account_id = ?", accountID).Delete(&serviceAccountRoleModel{}).Error; err != nil {
+			return err
+		}
+		if len(roleIDs) == 0 {
+			return nil
+		}
+		models := make([]serviceAccountRoleModel, len(roleIDs))
+		for i, roleID := range roleIDs {
+			models[i] = serviceAccountRoleModel{ServiceAccountID: accountID, RoleID: roleID}
+		}
+		return tx.Create(&models).Error
+	})
+}
+
+// GetRoles 获取服务账号绑定的角色，按角色排序返回
+func (r *serviceAccountRepository) GetRoles(accountID int64) ([]*entity.Role, error) {
+	var roleModels []roleModel
+	err := r.db.Table("base_roles").
+		Joins("JOIN base_service_account_roles ON base_service_account_roles.role_id = base_roles.id").
+		Where("base_service_account_roles.service_account_id = ?", accountID).
+		Order("base_roles.sort ASC, base_roles.id ASC").
+		Find(&roleModels).Error
+	if err != nil {
+		return nil, err
+	}
+
+	roles := make([]*entity.Role, len(roleModels))
+	for i, model := range roleModels {
+		roles[i] = model.toDomain()
+	}
+
+	return roles, nil
+}
+
+// TouchAccount 更新服务账号最近使用时间和 IP
+func (r *serviceAccountRepository) TouchAccount(accountID int64, ip string, at time.Time) error {
+	return r.db.Model(&serviceAccountModel{}).Where("id = ?", accountID).
+		UpdateColumns(map[string]interface{}{"last_used_at": at, "last_used_ip": ip}).Error
+}
+
+// CreateKey 创建 API Key
+func (r *serviceAccountRepository) CreateKey(key *entity.ServiceAccountKey) error {
+	dbModel := &serviceAccountKeyModel{
+		SnowflakeBaseModel: database.SnowflakeBaseModel{
+			ID:        key.ID,
+			CreatedBy: key.CreatedBy,
+			UpdatedBy: key.CreatedBy,
+		},
+		ServiceAccountID: key.ServiceAccountID,
+		Prefix:           key.Prefix,
+		KeyHash:          key.KeyHash,
+		ExpiresAt:        key.ExpiresAt,
+	}
+	if err := r.db.Create(dbModel).Error; err != nil {
+		return err
+	}
+
+	key.ID = dbModel.ID
+	key.CreatedAt = dbModel.CreatedAt
+	return nil
+}
+
+// GetKeyByPrefix 根据前缀查询 API Key
+func (r *serviceAccountRepository) GetKeyByPrefix(prefix string) (*entity.ServiceAccountKey, error) {
+	var dbModel serviceAccountKeyModel
+	if err := r.db.Where("prefix = ?", prefix).First(&dbModel).Error; err != nil {
+		return nil, err
+	}
+
+	return dbModel.toDomain(), nil
+}
+
+// ListKeys 获取服务账号下的所有 API Key
+func (r *serviceAccountRepository) ListKeys(accountID int64) ([]*entity.ServiceAccountKey, error) {
+	var dbModels []serviceAccountKeyModel
+	if err := r.db.Where("service_account_id = ?", accountID).
+		Order("created_at DESC").
+		Find(&dbModels).Error; err != nil {
+		return nil, err
+	}
+
+	keys := make([]*entity.ServiceAccountKey, len(dbModels))
+	for i := range dbModels {
+		keys[i] = dbModels[i].toDomain()
+	}
+	return keys, nil
+}
+
+// RevokeKey 吊销指定 Key
+func (r *serviceAccountRepository) RevokeKey(accountID, keyID int64, at time.Time) error {
+	result := r.db.Model(&serviceAccountKeyModel{}).
+		Where("id = ? AND service_account_id = ? AND revoked_at IS NULL", keyID, accountID).
+		Updates(map[string]interface{}{"revoked_at": at, "updated_at": at})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// ExpireActiveKeys 将服务账号下除 exceptKeyID 外仍有效的 Key 的过期时间提前到 expiresAt
+func (r *serviceAccountRepository) ExpireActiveKeys(accountID, exceptKeyID int64, expiresAt time.Time) error {
+	return r.db.Model(&serviceAccountKeyModel{}).
+		Where("service_account_id = ? AND id <> ? AND revoked_at IS NULL", accountID, exceptKeyID).
+		Where("expires_at IS NULL OR expires_at > ?", expiresAt).
+		Updates(map[string]interface{}{"expires_at": expiresAt, "updated_at": time.Now()}).Error
+}
+
+// TouchKey 更新 Key 最近使用时间和 IP
+func (r *serviceAccountRepository) TouchKey(keyID int64, ip string, at time.Time) error {
+	return r.db.Model(&serviceAccountKeyModel{}).Where("id = ?", keyID).
+		UpdateColumns(map[string]interface{}{"last_used_at": at, "last_used_ip": ip}).Error
+}
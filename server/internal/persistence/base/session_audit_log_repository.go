@@ -0,0 +1,130 @@
+package persistence
+
+import (
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/entity"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/repo"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/persistence/database"
+	"gorm.io/gorm"
+)
+
+// sessionAuditLogModel 会话终止审计记录数据库模型
+type sessionAuditLogModel struct {
+	database.SnowflakeBaseModel
+	UserID     int64  `gorm:"not null;index"`
+	Username   string `gorm:"size:50"`
+	SessionID  string `gorm:"size:64;not null;index"`
+	LoginIP    string `gorm:"size:50"`
+	Device     string `gorm:"size:100"`
+	Action     string `gorm:"size:20;not null"`
+	Reason     string `gorm:"size:500"`
+	OperatorID int64  `gorm:"not null;default:0;index"`
+}
+
+// TableName 指定表名
+func (sessionAuditLogModel) TableName() string {
+	return "base_session_audit_logs"
+}
+
+// toDomain 将数据库模型转换为领域实体
+func (m *sessionAuditLogModel) toDomain() *entity.SessionAuditLog {
+	if m == nil {
+		return nil
+	}
+	return &entity.SessionAuditLog{
+		ID:         m.ID,
+		UserID:     m.UserID,
+		Username:   m.Username,
+		SessionID:  m.SessionID,
+		LoginIP:    m.LoginIP,
+		Device:     m.Device,
+		Action:     m.Action,
+		Reason:     m.Reason,
+		OperatorID: m.OperatorID,
+		CreatedAt:  m.CreatedAt,
+	}
+}
+
+// fromDomainSessionAuditLog 将领域实体转换为数据库模型
+func fromDomainSessionAuditLog(log *entity.SessionAuditLog) *sessionAuditLogModel {
+	return &sessionAuditLogModel{
+		SnowflakeBaseModel: database.SnowflakeBaseModel{
+			ID:        log.ID,
+			CreatedBy: log.OperatorID,
+			UpdatedBy: log.OperatorID,
+		},
+		UserID:     log.UserID,
+		Username:   log.Username,
+		SessionID:  log.SessionID,
+		LoginIP:    log.LoginIP,
+		Device:     log.Device,
+		Action:     log.Action,
+		Reason:     log.Reason,
+		OperatorID: log.OperatorID,
+	}
+}
+
+// sessionAuditLogRepository Repository 实现
+type sessionAuditLogRepository struct {
+	db *database.PostgresDB
+}
+
+// 确保实现接口
+var _ repo.SessionAuditLogRepository = (*sessionAuditLogRepository)(nil)
+
+// NewSessionAuditLogRepository 创建会话终止审计记录仓库实现
+func NewSessionAuditLogRepository(db *database.PostgresDB) repo.SessionAuditLogRepository {
+	return &sessionAuditLogRepository{db: db}
+}
+
+// CreateBatch 在同一事务中写入多条记录
+func (r *sessionAuditLogRepository) CreateBatch(logs []*entity.SessionAuditLog) error {
+	if len(logs) == 0 {
+		return nil
+	}
+
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for _, log := range logs {
+			dbModel := fromDomainSessionAuditLog(log)
+			if err := tx.Create(dbModel).Error; err != nil {
+				return err
+			}
+			// 将生成的 ID 回写到领域实体
+			log.ID = dbModel.ID
+			log.CreatedAt = dbModel.CreatedAt
+		}
+		return nil
+	})
+}
+
+// List 分页查询审计记录
+func (r *sessionAuditLogRepository) List(page, pageSize int, filters map[string]interface{}) ([]*entity.SessionAuditLog, int64, error) {
+	var total int64
+	var dbModels []sessionAuditLogModel
+
+	query := r.db.Model(&sessionAuditLogModel{})
+
+	// 应用过滤条件
+	for key, value := range filters {
+		if key == "userName" {
+			query = query.Where("username ILIKE ?", "%"+value.(string)+"%")
+		} else {
+			query = query.Where(key+" = ?", value)
+		}
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * pageSize
+	if err := query.Order("created_at DESC").Offset(offset).Limit(pageSize).Find(&dbModels).Error; err != nil {
+		return nil, 0, err
+	}
+
+	logs := make([]*entity.SessionAuditLog, len(dbModels))
+	for i := range dbModels {
+		logs[i] = dbModels[i].toDomain()
+	}
+
+	return logs, total, nil
+}
@@ -0,0 +1,202 @@
+package persistence
+
+import (
+	"encoding/json"
+
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/entity"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/repo"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/persistence/database"
+	"github.com/ix-pay/ixpay-pro/internal/persistence/common"
+)
+
+// sodConstraintModel 职责分离约束数据库模型
+type sodConstraintModel struct {
+	database.SnowflakeBaseModel
+	Name        string `gorm:"size:100;not null;unique"`
+	Description string `gorm:"size:500"`
+	Type        string `gorm:"size:20;not null;index"`
+	// 约束涉及的角色 ID，JSON 数组
+	RoleIDs  string `gorm:"column:role_ids;type:text"`
+	MaxRoles *int   `gorm:"not null;default:1"`
+	Status   *int   `gorm:"not null;default:1"`
+}
+
+// TableName 指定表名
+func (sodConstraintModel) TableName() string {
+	return "base_sod_constraints"
+}
+
+// toDomain 将数据库模型转换为领域实体
+func (m *sodConstraintModel) toDomain() *entity.SoDConstraint {
+	if m == nil {
+		return nil
+	}
+	constraint := &entity.SoDConstraint{
+		ID:          m.ID,
+		Name:        m.Name,
+		Description: m.Description,
+		Type:        m.Type,
+		CreatedBy:   m.CreatedBy,
+		CreatedAt:   m.CreatedAt,
+		UpdatedBy:   m.UpdatedBy,
+		UpdatedAt:   m.UpdatedAt,
+	}
+
+	// 安全解引用，提供默认值
+	if m.MaxRoles != nil {
+		constraint.MaxRoles = *m.MaxRoles
+	} else {
+		constraint.MaxRoles = 1
+	}
+
+	if m.Status != nil {
+		constraint.Status = *m.Status
+	} else {
+		constraint.Status = 1
+	}
+
+	if m.RoleIDs != "" {
+		json.Unmarshal([]byte(m.RoleIDs), &constraint.RoleIDs)
+	}
+
+	return constraint
+}
+
+// fromDomainSoDConstraint 将领域实体转换为数据库模型
+func fromDomainSoDConstraint(constraint *entity.SoDConstraint) (*sodConstraintModel, error) {
+	roleIDs, err := marshalIDs(constraint.RoleIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sodConstraintModel{
+		SnowflakeBaseModel: database.SnowflakeBaseModel{
+			ID:        constraint.ID,
+			CreatedBy: constraint.CreatedBy,
+			UpdatedBy: constraint.UpdatedBy,
+		},
+		Name:        constraint.Name,
+		Description: constraint.Description,
+		Type:        constraint.Type,
+		RoleIDs:     roleIDs,
+		MaxRoles:    common.IntPtr(constraint.MaxRoles),
+		Status:      common.IntPtr(constraint.Status),
+	}, nil
+}
+
+// sodConstraintRepository Repository 实现
+type sodConstraintRepository struct {
+	db *database.PostgresDB
+}
+
+// 确保实现接口
+var _ repo.SoDConstraintRepository = (*sodConstraintRepository)(nil)
+
+// NewSoDConstraintRepository 创建职责分离约束仓库实现
+func NewSoDConstraintRepository(db *database.PostgresDB) repo.SoDConstraintRepository {
+	return &sodConstraintRepository{db: db}
+}
+
+// GetByID 根据 ID 查询约束
+func (r *sodConstraintRepository) GetByID(id int64) (*entity.SoDConstraint, error) {
+	var dbModel sodConstraintModel
+	if err := r.db.Where("id = ?", id).First(&dbModel).Error; err != nil {
+		return nil, err
+	}
+	return dbModel.toDomain(), nil
+}
+
+// GetByName 根据名称查询约束
+func (r *sodConstraintRepository) GetByName(name string) (*entity.SoDConstraint, error) {
+	var dbModel sodConstraintModel
+	if err := r.db.Where("name = ?", name).First(&dbModel).Error; err != nil {
+		return nil, err
+	}
+	return dbModel.toDomain(), nil
+}
+
+// GetActiveByType 获取指定类型的启用约束
+func (r *sodConstraintRepository) GetActiveByType(constraintType string) ([]*entity.SoDConstraint, error) {
+	var dbModels []sodConstraintModel
+	if err := r.db.Where("type = ? AND status = ?", constraintType, 1).Order("created_at").Find(&dbModels).Error; err != nil {
+		return nil, err
+	}
+
+	constraints := make([]*entity.SoDConstraint, len(dbModels))
+	for i := range dbModels {
+		constraints[i] = dbModels[i].toDomain()
+	}
+	return constraints, nil
+}
+
+// Create 创建约束
+func (r *sodConstraintRepository) Create(constraint *entity.SoDConstraint) error {
+	dbModel, err := fromDomainSoDConstraint(constraint)
+	if err != nil {
+		return err
+	}
+	if err := r.db.Create(dbModel).Error; err != nil {
+		return err
+	}
+
+	// 将生成的 ID 回写到领域实体
+	constraint.ID = dbModel.ID
+	constraint.CreatedAt = dbModel.CreatedAt
+	constraint.UpdatedAt = dbModel.UpdatedAt
+	return nil
+}
+
+// Update 更新约束
+func (r *sodConstraintRepository) Update(constraint *entity.SoDConstraint) error {
+	dbModel, err := fromDomainSoDConstraint(constraint)
+	if err != nil {
+		return err
+	}
+	return r.db.Model(&sodConstraintModel{}).Where("id = ?", constraint.ID).Updates(map[string]interface{}{
+		"name":        dbModel.Name,
+		"description": dbModel.Description,
+		"type":        dbModel.Type,
+		"role_ids":    dbModel.RoleIDs,
+		"max_roles":   dbModel.MaxRoles,
+		"status":      dbModel.Status,
+		"updated_by":  dbModel.UpdatedBy,
+	}).Error
+}
+
+// Delete 删除约束
+func (r *sodConstraintRepository) Delete(id int64) error {
+	return r.db.Delete(&sodConstraintModel{}, id).Error
+}
+
+// List 分页查询约束列表
+func (r *sodConstraintRepository) List(page, pageSize int, filters map[string]interface{}) ([]*entity.SoDConstraint, int64, error) {
+	var total int64
+	var dbModels []sodConstraintModel
+
+	query := r.db.Model(&sodConstraintModel{})
+
+	// 应用过滤条件
+	for key, value := range filters {
+		switch key {
+		case "name":
+			query = query.Where("name LIKE ?", "%"+value.(string)+"%")
+		default:
+			query = query.Where(key+" = ?", value)
+		}
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * pageSize
+	if err := query.Order("created_at DESC").Offset(offset).Limit(pageSize).Find(&dbModels).Error; err != nil {
+		return nil, 0, err
+	}
+
+	constraints := make([]*entity.SoDConstraint, len(dbModels))
+	for i := range dbModels {
+		constraints[i] = dbModels[i].toDomain()
+	}
+	return constraints, total, nil
+}
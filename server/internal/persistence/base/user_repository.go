@@ -1,31 +1,40 @@
 package persistence
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/ix-pay/ixpay-pro/internal/domain/base/entity"
 	"github.com/ix-pay/ixpay-pro/internal/domain/base/repo"
 	"github.com/ix-pay/ixpay-pro/internal/infrastructure/persistence/database"
 	"github.com/ix-pay/ixpay-pro/internal/persistence/common"
+	"gorm.io/gorm"
 )
 
 // userModel 数据库模型（带 GORM 标签）
 type userModel struct {
 	database.SnowflakeBaseModel
-	Username      string `gorm:"size:50;not null;unique"`
-	PasswordHash  string `gorm:"size:100;not null"`
-	Nickname      string `gorm:"size:50"`
-	Email         string `gorm:"size:100"`
-	Phone         string `gorm:"size:20"`
-	Avatar        string `gorm:"size:255"`
-	Status        *int   `gorm:"not null;default:1"`
-	Gender        *int   `gorm:"not null;default:0"`
-	Birthday      string `gorm:"size:20"`
-	Address       string `gorm:"size:255"`
-	PositionID    *int64 `gorm:"not null;default:0;index"`
-	DepartmentID  *int64 `gorm:"not null;default:0;index"`
-	EntryDate     string `gorm:"size:20"`
-	LastLoginIP   string `gorm:"size:50"`
-	LastLoginTime string `gorm:"size:50"`
-	WechatOpenID  string `gorm:"size:100;uniqueIndex;default:null"`
+	Username      string                   `gorm:"size:50;not null;unique"`
+	PasswordHash  string                   `gorm:"size:100;not null"`
+	Nickname      string                   `gorm:"size:50"`
+	Email         database.EncryptedString `gorm:"type:text"`
+	EmailHash     string                   `gorm:"size:64;index"`
+	Phone         database.EncryptedString `gorm:"type:text"`
+	PhoneHash     string                   `gorm:"size:64;index"`
+	Avatar        string                   `gorm:"size:255"`
+	Status        *int                     `gorm:"not null;default:1"`
+	Gender        *int                     `gorm:"not null;default:0"`
+	Birthday      string                   `gorm:"size:20"`
+	Address       string                   `gorm:"size:255"`
+	PositionID    *int64                   `gorm:"not null;default:0;index"`
+	DepartmentID  *int64                   `gorm:"not null;default:0;index"`
+	EntryDate     string                   `gorm:"size:20"`
+	LastLoginIP   string                   `gorm:"size:50"`
+	LastLoginTime string                   `gorm:"size:50"`
+	WechatOpenID  string                   `gorm:"size:100;uniqueIndex;default:null"`
+
+	PasswordChangedAt  *time.Time `gorm:"column:password_changed_at"`
+	MustChangePassword *bool      `gorm:"column:must_change_password;not null;default:false"`
 
 	// GORM 关联标签 - 多对一
 	Department *departmentModel `gorm:"foreignKey:department_id;references:id"`
@@ -51,8 +60,8 @@ func (m *userModel) toDomain() *entity.User {
 		Username:      m.Username,
 		PasswordHash:  m.PasswordHash,
 		Nickname:      m.Nickname,
-		Email:         m.Email,
-		Phone:         m.Phone,
+		Email:         string(m.Email),
+		Phone:         string(m.Phone),
 		Avatar:        m.Avatar,
 		Birthday:      m.Birthday,
 		Address:       m.Address,
@@ -91,6 +100,14 @@ func (m *userModel) toDomain() *entity.User {
 		user.DepartmentID = 0
 	}
 
+	if m.PasswordChangedAt != nil {
+		user.PasswordChangedAt = *m.PasswordChangedAt
+	}
+
+	if m.MustChangePassword != nil {
+		user.MustChangePassword = *m.MustChangePassword
+	}
+
 	// 处理关联数据 - 部门
 	if m.Department != nil {
 		user.Department = m.Department.toDomain()
@@ -117,8 +134,21 @@ func (m *userModel) toDomain() *entity.User {
 	return user
 }
 
+// BeforeSave GORM 钩子函数，保存前计算邮箱和手机号的盲索引
+func (m *userModel) BeforeSave(tx *gorm.DB) error {
+	m.EmailHash = database.BlindIndex(string(m.Email))
+	m.PhoneHash = database.BlindIndex(string(m.Phone))
+	return nil
+}
+
 // fromDomain 将领域实体转换为数据库模型
 func fromDomain(user *entity.User) (*userModel, error) {
+	var passwordChangedAt *time.Time
+	if !user.PasswordChangedAt.IsZero() {
+		t := user.PasswordChangedAt
+		passwordChangedAt = &t
+	}
+
 	return &userModel{
 		SnowflakeBaseModel: database.SnowflakeBaseModel{
 			ID:        user.ID,
@@ -128,8 +158,8 @@ func fromDomain(user *entity.User) (*userModel, error) {
 		Username:      user.Username,
 		PasswordHash:  user.PasswordHash,
 		Nickname:      user.Nickname,
-		Email:         user.Email,
-		Phone:         user.Phone,
+		Email:         database.EncryptedString(user.Email),
+		Phone:         database.EncryptedString(user.Phone),
 		Avatar:        user.Avatar,
 		Status:        common.IntPtr(user.Status),
 		Gender:        common.IntPtr(user.Gender),
@@ -141,6 +171,9 @@ func fromDomain(user *entity.User) (*userModel, error) {
 		LastLoginIP:   user.LastLoginIP,
 		LastLoginTime: user.LastLoginTime,
 		WechatOpenID:  user.WechatOpenID,
+
+		PasswordChangedAt:  passwordChangedAt,
+		MustChangePassword: common.BoolPtr(user.MustChangePassword),
 	}, nil
 }
 
@@ -194,7 +227,7 @@ func (r *userRepository) GetByUsername(userName string) (*entity.User, error) {
 // GetByEmail 根据邮箱查询用户
 func (r *userRepository) GetByEmail(email string) (*entity.User, error) {
 	var dbModel userModel
-	result := r.db.Where("email = ?", email).First(&dbModel)
+	result := database.WhereEncrypted(r.db.DB, "email", "email_hash", email).First(&dbModel)
 	if result.Error != nil {
 		return nil, result.Error
 	}
@@ -205,7 +238,7 @@ func (r *userRepository) GetByEmail(email string) (*entity.User, error) {
 // GetByPhone 根据手机号查询用户
 func (r *userRepository) GetByPhone(phone string) (*entity.User, error) {
 	var dbModel userModel
-	result := r.db.Where("phone = ?", phone).First(&dbModel)
+	result := database.WhereEncrypted(r.db.DB, "phone", "phone_hash", phone).First(&dbModel)
 	if result.Error != nil {
 		return nil, result.Error
 	}
@@ -261,10 +294,16 @@ func (r *userRepository) List(page, pageSize int, filters map[string]interface{}
 	var dbModels []userModel
 
 	query := r.db.Model(&userModel{})
+	query, filters = common.ApplyDataScope(query, filters, "department_id", "id")
 
-	// 应用过滤条件
+	// 应用过滤条件，邮箱和手机号已加密，按盲索引查询
 	for key, value := range filters {
-		query = query.Where(key+" = ?", value)
+		switch key {
+		case "email", "phone":
+			query = database.WhereEncrypted(query, key, key+"_hash", fmt.Sprint(value))
+		default:
+			query = query.Where(key+" = ?", value)
+		}
 	}
 
 	if err := query.Count(&total).Error; err != nil {
@@ -286,7 +325,15 @@ func (r *userRepository) List(page, pageSize int, filters map[string]interface{}
 }
 
 // UpdateFields 更新用户指定字段
+// 邮箱和手机号加密保存，并同步更新盲索引
 func (r *userRepository) UpdateFields(id int64, updates map[string]interface{}) error {
+	for _, column := range []string{"email", "phone"} {
+		if value, ok := updates[column]; ok {
+			plaintext := fmt.Sprint(value)
+			updates[column] = database.EncryptedString(plaintext)
+			updates[column+"_hash"] = database.BlindIndex(plaintext)
+		}
+	}
 	return r.db.Model(&userModel{}).Where("id = ?", id).Updates(updates).Error
 }
 
@@ -0,0 +1,41 @@
+package common
+
+import (
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/entity"
+	"gorm.io/gorm"
+)
+
+// ApplyDataScope 取出过滤条件中的数据权限并追加到查询，返回去掉数据权限后的过滤条件
+// deptColumn 为记录所属部门的列，表中没有部门列时传空字符串，按 userColumn 关联 base_users 的部门过滤
+// userColumn 为记录所属用户的列，部门范围下本人的数据始终可见
+// 数据权限为空或类型不正确时按仅本人处理，宁可少返回数据也不越权
+func ApplyDataScope(query *gorm.DB, filters map[string]interface{}, deptColumn, userColumn string) (*gorm.DB, map[string]interface{}) {
+	value, ok := filters[entity.DataScopeFilterKey]
+	if !ok {
+		return query, filters
+	}
+
+	rest := make(map[string]interface{}, len(filters)-1)
+	for key, v := range filters {
+		if key != entity.DataScopeFilterKey {
+			rest[key] = v
+		}
+	}
+
+	scope, ok := value.(*entity.DataScope)
+	if !ok || scope == nil {
+		scope = &entity.DataScope{Scope: entity.DataScopeSelf}
+	}
+	if scope.IsAll() {
+		return query, rest
+	}
+	if scope.IsSelf() || len(scope.DepartmentIDs) == 0 {
+		return query.Where(userColumn+" = ?", scope.UserID), rest
+	}
+
+	if deptColumn != "" {
+		return query.Where("("+deptColumn+" IN ? OR "+userColumn+" = ?)", scope.DepartmentIDs, scope.UserID), rest
+	}
+	return query.Where("("+userColumn+" IN (SELECT id FROM base_users WHERE department_id IN ? AND deleted_at IS NULL) OR "+userColumn+" = ?)",
+		scope.DepartmentIDs, scope.UserID), rest
+}
@@ -0,0 +1,9 @@
+package persistence
+
+import "github.com/ix-pay/ixpay-pro/internal/infrastructure/persistence/database"
+
+// EncryptedColumns 微信应用加密保存的列，由字段重新加密任务处理
+var EncryptedColumns = []database.EncryptedColumn{
+	{Table: "wx_users", Column: "open_id", HashColumn: "open_id_hash"},
+	{Table: "wx_open_apps", Column: "app_secret"},
+}
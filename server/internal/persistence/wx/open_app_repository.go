@@ -0,0 +1,176 @@
+package persistence
+
+import (
+	"time"
+
+	"github.com/ix-pay/ixpay-pro/internal/domain/wx/entity"
+	"github.com/ix-pay/ixpay-pro/internal/domain/wx/repo"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/persistence/database"
+	"github.com/ix-pay/ixpay-pro/internal/persistence/common"
+)
+
+// openAppModel 开放接口应用数据库模型
+type openAppModel struct {
+	database.SnowflakeBaseModel
+	Name        string                   `gorm:"size:100;not null"`
+	AppKey      string                   `gorm:"size:64;not null;unique"`
+	AppSecret   database.EncryptedString `gorm:"type:text;not null"`
+	SignType    string                   `gorm:"size:20;not null"`
+	PublicKey   string                   `gorm:"type:text"`
+	UserID      *int64                   `gorm:"not null;default:0;index"`
+	Description string                   `gorm:"size:255"`
+	Status      *int                     `gorm:"not null;default:1"`
+}
+
+// TableName 指定表名
+func (openAppModel) TableName() string {
+	return "wx_open_apps"
+}
+
+// toDomain 将数据库模型转换为领域实体
+func (m *openAppModel) toDomain() *entity.OpenApp {
+	if m == nil {
+		return nil
+	}
+
+	app := &entity.OpenApp{
+		ID:          m.ID,
+		Name:        m.Name,
+		AppKey:      m.AppKey,
+		AppSecret:   string(m.AppSecret),
+		SignType:    m.SignType,
+		PublicKey:   m.PublicKey,
+		Description: m.Description,
+		CreatedBy:   m.CreatedBy,
+		CreatedAt:   m.CreatedAt,
+		UpdatedBy:   m.UpdatedBy,
+		UpdatedAt:   m.UpdatedAt,
+	}
+
+	// 安全解引用，提供默认值
+	if m.UserID != nil {
+		app.UserID = *m.UserID
+	}
+	if m.Status != nil {
+		app.Status = *m.Status
+	} else {
+		app.Status = entity.OpenAppStatusEnabled
+	}
+
+	return app
+}
+
+// fromDomainOpenApp 将领域实体转换为数据库模型
+func fromDomainOpenApp(app *entity.OpenApp) *openAppModel {
+	return &openAppModel{
+		SnowflakeBaseModel: database.SnowflakeBaseModel{
+			ID:        app.ID,
+			CreatedBy: app.CreatedBy,
+			UpdatedBy: app.UpdatedBy,
+		},
+		Name:        app.Name,
+		AppKey:      app.AppKey,
+		AppSecret:   database.EncryptedString(app.AppSecret),
+		SignType:    app.SignType,
+		PublicKey:   app.PublicKey,
+		UserID:      common.Int64Ptr(app.UserID),
+		Description: app.Description,
+		Status:      common.IntPtr(app.Status),
+	}
+}
+
+// openAppRepository Repository 实现
+type openAppRepository struct {
+	db *database.PostgresDB
+}
+
+// 确保实现接口
+var _ repo.OpenAppRepository = (*openAppRepository)(nil)
+
+// NewOpenAppRepository 创建开放接口应用仓库实现
+func NewOpenAppRepository(db *database.PostgresDB) repo.OpenAppRepository {
+	return &openAppRepository{db: db}
+}
+
+// GetByID 根据 ID 查询应用
+func (r *openAppRepository) GetByID(id int64) (*entity.OpenApp, error) {
+	var dbModel openAppModel
+	if err := r.db.Where("id = ?", id).First(&dbModel).Error; err != nil {
+		return nil, err
+	}
+
+	return dbModel.toDomain(), nil
+}
+
+// GetByAppKey 根据应用标识查询应用
+func (r *openAppRepository) GetByAppKey(appKey string) (*entity.OpenApp, error) {
+	var dbModel openAppModel
+	if err := r.db.Where("app_key = ?", appKey).First(&dbModel).Error; err != nil {
+		return nil, err
+	}
+
+	return dbModel.toDomain(), nil
+}
+
+// Create 创建应用
+func (r *openAppRepository) Create(app *entity.OpenApp) error {
+	dbModel := fromDomainOpenApp(app)
+	if err := r.db.Create(dbModel).Error; err != nil {
+		return err
+	}
+
+	// 将生成的 ID 回写到领域实体
+	app.ID = dbModel.ID
+	app.CreatedAt = dbModel.CreatedAt
+	return nil
+}
+
+// Update 更新应用
+func (r *openAppRepository) Update(app *entity.OpenApp) error {
+	dbModel := fromDomainOpenApp(app)
+	return r.db.Model(&openAppModel{}).Where("id = ?", app.ID).Updates(map[string]interface{}{
+		"name":        dbModel.Name,
+		"app_secret":  dbModel.AppSecret,
+		"sign_type":   dbModel.SignType,
+		"public_key":  dbModel.PublicKey,
+		"user_id":     dbModel.UserID,
+		"description": dbModel.Description,
+		"status":      dbModel.Status,
+		"updated_by":  dbModel.UpdatedBy,
+		"updated_at":  time.Now(),
+	}).Error
+}
+
+// Delete 删除应用
+func (r *openAppRepository) Delete(id int64) error {
+	return r.db.Delete(&openAppModel{}, id).Error
+}
+
+// List 分页查询应用列表
+func (r *openAppRepository) List(page, pageSize int, filters map[string]interface{}) ([]*entity.OpenApp, int64, error) {
+	var total int64
+	var dbModels []openAppModel
+
+	query := r.db.Model(&openAppModel{})
+
+	// 应用过滤条件
+	for key, value := range filters {
+		query = query.Where(key+" = ?", value)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * pageSize
+	if err := query.Order("created_at DESC").Offset(offset).Limit(pageSize).Find(&dbModels).Error; err != nil {
+		return nil, 0, err
+	}
+
+	apps := make([]*entity.OpenApp, len(dbModels))
+	for i := range dbModels {
+		apps[i] = dbModels[i].toDomain()
+	}
+
+	return apps, total, nil
+}
@@ -233,11 +233,13 @@ func (r *paymentRepository) ListByStatus(status entity.PaymentStatus, page, page
 }
 
 // List 查询支付列表（支持过滤）
+// 过滤条件携带数据权限时，按支付用户所属部门过滤
 func (r *paymentRepository) List(page, pageSize int, filters map[string]interface{}) ([]*entity.Payment, int, error) {
 	var total64 int64
 	var dbModels []paymentModel
 
 	query := r.db.Model(&paymentModel{})
+	query, filters = common.ApplyDataScope(query, filters, "", "user_id")
 
 	// 应用过滤器
 	for key, value := range filters {
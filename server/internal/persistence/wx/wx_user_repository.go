@@ -7,25 +7,27 @@ import (
 	"github.com/ix-pay/ixpay-pro/internal/domain/wx/repo"
 	"github.com/ix-pay/ixpay-pro/internal/infrastructure/persistence/database"
 	"github.com/ix-pay/ixpay-pro/internal/persistence/common"
+	"gorm.io/gorm"
 )
 
 // wxUserModel 微信用户数据库模型
 type wxUserModel struct {
 	database.SnowflakeBaseModel
-	OpenID        string     `gorm:"size:100;not null;uniqueIndex"`
-	UnionID       string     `gorm:"size:100;uniqueIndex"`
-	Nickname      string     `gorm:"size:100"`
-	Avatar        string     `gorm:"size:255"`
-	Gender        *int       `gorm:"not null;default:0"`
-	Country       string     `gorm:"size:50"`
-	Province      string     `gorm:"size:50"`
-	City          string     `gorm:"size:50"`
-	Language      string     `gorm:"size:20"`
-	Subscribe     *bool      `gorm:"not null;default:false"`
-	SubscribeTime *time.Time `gorm:"index"`
-	Remark        string     `gorm:"size:255"`
-	GroupID       *int64     `gorm:"not null;default:0"`
-	UserID        *int64     `gorm:"not null;default:0;index"`
+	OpenID        database.EncryptedString `gorm:"type:text;not null"`
+	OpenIDHash    string                   `gorm:"size:64;uniqueIndex"`
+	UnionID       string                   `gorm:"size:100;uniqueIndex"`
+	Nickname      string                   `gorm:"size:100"`
+	Avatar        string                   `gorm:"size:255"`
+	Gender        *int                     `gorm:"not null;default:0"`
+	Country       string                   `gorm:"size:50"`
+	Province      string                   `gorm:"size:50"`
+	City          string                   `gorm:"size:50"`
+	Language      string                   `gorm:"size:20"`
+	Subscribe     *bool                    `gorm:"not null;default:false"`
+	SubscribeTime *time.Time               `gorm:"index"`
+	Remark        string                   `gorm:"size:255"`
+	GroupID       *int64                   `gorm:"not null;default:0"`
+	UserID        *int64                   `gorm:"not null;default:0;index"`
 }
 
 // TableName 指定表名
@@ -40,7 +42,7 @@ func (m *wxUserModel) toDomain() *entity.WXUser {
 	}
 	return &entity.WXUser{
 		ID:            m.ID,
-		OpenID:        m.OpenID,
+		OpenID:        string(m.OpenID),
 		UnionID:       m.UnionID,
 		Nickname:      m.Nickname,
 		Avatar:        m.Avatar,
@@ -59,6 +61,12 @@ func (m *wxUserModel) toDomain() *entity.WXUser {
 	}
 }
 
+// BeforeSave GORM 钩子函数，保存前计算 OpenID 的盲索引
+func (m *wxUserModel) BeforeSave(tx *gorm.DB) error {
+	m.OpenIDHash = database.BlindIndex(string(m.OpenID))
+	return nil
+}
+
 // fromDomain 将领域实体转换为数据库模型
 func fromDomainWXUser(user *entity.WXUser) (*wxUserModel, error) {
 	return &wxUserModel{
@@ -67,7 +75,7 @@ func fromDomainWXUser(user *entity.WXUser) (*wxUserModel, error) {
 			CreatedBy: 0,
 			UpdatedBy: 0,
 		},
-		OpenID:        user.OpenID,
+		OpenID:        database.EncryptedString(user.OpenID),
 		UnionID:       user.UnionID,
 		Nickname:      user.Nickname,
 		Avatar:        user.Avatar,
@@ -111,7 +119,7 @@ func (r *wxUserRepository) GetByID(id int64) (*entity.WXUser, error) {
 // GetByOpenID 根据 OpenID 查询微信用户
 func (r *wxUserRepository) GetByOpenID(openID string) (*entity.WXUser, error) {
 	var dbModel wxUserModel
-	result := r.db.Where("open_id = ?", openID).First(&dbModel)
+	result := database.WhereEncrypted(r.db.DB, "open_id", "open_id_hash", openID).First(&dbModel)
 	if result.Error != nil {
 		return nil, result.Error
 	}
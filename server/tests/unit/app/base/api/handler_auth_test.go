@@ -0,0 +1,117 @@
+package baseapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	baseapi "github.com/ix-pay/ixpay-pro/internal/app/base/api"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/entity"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/repo"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/service"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/observability/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// MockLogger 日志 Mock 实现，丢弃所有日志
+type MockLogger struct{}
+
+func (m *MockLogger) Debug(msg string, fields ...interface{})  {}
+func (m *MockLogger) Info(msg string, fields ...interface{})   {}
+func (m *MockLogger) Warn(msg string, fields ...interface{})   {}
+func (m *MockLogger) Error(msg string, fields ...interface{})  {}
+func (m *MockLogger) Fatal(msg string, fields ...interface{})  {}
+func (m *MockLogger) With(fields ...interface{}) logger.Logger { return &MockLogger{} }
+func (m *MockLogger) Sync() error                              { return nil }
+
+// memoryPositionRepo 内存岗位仓库
+type memoryPositionRepo struct {
+	positions []*entity.Position
+}
+
+func (r *memoryPositionRepo) GetByID(id int64) (*entity.Position, error) {
+	for _, position := range r.positions {
+		if position.ID == id {
+			return position, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *memoryPositionRepo) Create(position *entity.Position) error {
+	r.positions = append(r.positions, position)
+	return nil
+}
+
+func (r *memoryPositionRepo) Update(position *entity.Position) error { return nil }
+
+func (r *memoryPositionRepo) Delete(id int64) error { return nil }
+
+func (r *memoryPositionRepo) List(page, pageSize int, filters map[string]interface{}) ([]*entity.Position, int64, error) {
+	return r.positions, int64(len(r.positions)), nil
+}
+
+func (r *memoryPositionRepo) GetAll() ([]*entity.Position, error) {
+	return r.positions, nil
+}
+
+func (r *memoryPositionRepo) GetByName(name string) (*entity.Position, error) {
+	return nil, gorm.ErrRecordNotFound
+}
+
+var _ repo.PositionRepository = (*memoryPositionRepo)(nil)
+
+// newPositionRouter 创建岗位列表路由，setup 模拟认证中间件写入的上下文
+func newPositionRouter(setup func(c *gin.Context)) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	positionRepo := &memoryPositionRepo{positions: []*entity.Position{{ID: 1, Name: "出纳", Status: 1}}}
+	controller := baseapi.NewPositionController(service.NewPositionService(positionRepo, &MockLogger{}), &MockLogger{})
+
+	router := gin.New()
+	router.GET("/api/admin/position", func(c *gin.Context) {
+		setup(c)
+		c.Next()
+	}, controller.GetPositionList)
+	return router
+}
+
+// TestHandler_IsAuthenticated 测试需要登录的处理函数同时接受 JWT 用户和服务账号，未认证时返回 401
+func TestHandler_IsAuthenticated(t *testing.T) {
+	tests := []struct {
+		name   string
+		setup  func(c *gin.Context)
+		status int
+	}{
+		{"JWT 用户", func(c *gin.Context) {
+			c.Set("userID", "100")
+			c.Set("loginType", "password")
+		}, http.StatusOK},
+		{"服务账号", func(c *gin.Context) {
+			c.Set("serviceAccountID", "500")
+			c.Set("loginType", service.LoginTypeAPIKey)
+		}, http.StatusOK},
+		{"未认证", func(c *gin.Context) {}, http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/api/admin/position?page=1&pageSize=10", nil)
+			newPositionRouter(tt.setup).ServeHTTP(w, req)
+
+			require.Equal(t, tt.status, w.Code)
+			if tt.status != http.StatusOK {
+				return
+			}
+			var body struct {
+				Code int `json:"code"`
+			}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+			assert.Equal(t, 0, body.Code)
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package baseapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	baseapi "github.com/ix-pay/ixpay-pro/internal/app/base/api"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/entity"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/repo"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// memoryLogUserRepo 内存用户仓库，只实现按 ID 查询，用于判断日志所属用户的部门
+type memoryLogUserRepo struct {
+	repo.UserRepository
+	users map[int64]*entity.User
+}
+
+func (r *memoryLogUserRepo) GetByID(id int64, relations ...repo.UserRelation) (*entity.User, error) {
+	if user, ok := r.users[id]; ok {
+		return user, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+// memoryOperationLogRepo 内存操作日志仓库
+type memoryOperationLogRepo struct {
+	logs map[int64]*entity.OperationLog
+}
+
+func (r *memoryOperationLogRepo) Create(log *entity.OperationLog) error { return nil }
+
+func (r *memoryOperationLogRepo) BatchCreate(logs []*entity.OperationLog) error { return nil }
+
+func (r *memoryOperationLogRepo) GetByID(id int64) (*entity.OperationLog, error) {
+	if log, ok := r.logs[id]; ok {
+		return log, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *memoryOperationLogRepo) List(page, pageSize int, filters map[string]interface{}) ([]*entity.OperationLog, int64, error) {
+	return nil, 0, nil
+}
+
+func (r *memoryOperationLogRepo) Delete(id int64) error { return nil }
+
+func (r *memoryOperationLogRepo) BatchDelete(ids []int64) error { return nil }
+
+func (r *memoryOperationLogRepo) DeleteByTimeRange(startTime, endTime time.Time) error { return nil }
+
+func (r *memoryOperationLogRepo) CountByDate(date time.Time) (int64, error) { return 0, nil }
+
+func (r *memoryOperationLogRepo) CountByModule(module string) (int64, error) { return 0, nil }
+
+func (r *memoryOperationLogRepo) CountByUser(userID int64) (int64, error) { return 0, nil }
+
+func (r *memoryOperationLogRepo) CountByOperationType(operationType entity.OperationType) (int64, error) {
+	return 0, nil
+}
+
+func (r *memoryOperationLogRepo) CountByResult(isSuccess bool) (int64, error) { return 0, nil }
+
+var _ repo.OperationLogRepository = (*memoryOperationLogRepo)(nil)
+
+// newOperationLogRouter 创建操作日志详情路由，scope 模拟数据权限中间件保存的解析结果
+func newOperationLogRouter(scope *entity.DataScope) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	logRepo := &memoryOperationLogRepo{logs: map[int64]*entity.OperationLog{
+		1: {ID: 1, UserID: 100, Module: "用户管理"},
+		2: {ID: 2, UserID: 101, Module: "用户管理"},
+		3: {ID: 3, UserID: 200, Module: "用户管理"},
+		4: {ID: 4, UserID: 999, Module: "用户管理"},
+	}}
+	userRepo := &memoryLogUserRepo{users: map[int64]*entity.User{
+		100: {ID: 100, DepartmentID: 2},
+		101: {ID: 101, DepartmentID: 2},
+		200: {ID: 200, DepartmentID: 4},
+	}}
+	userService := service.NewUserService(userRepo, nil, nil, nil, nil, nil, &MockLogger{}, nil, nil, nil, nil, nil, nil, nil, nil)
+	controller := baseapi.NewOperationLogController(service.NewOperationLogService(logRepo, &MockLogger{}), userService)
+
+	router := gin.New()
+	router.GET("/api/admin/logs/:id", func(c *gin.Context) {
+		c.Set("userID", "100")
+		c.Set("dataScopeResolver", func() *entity.DataScope { return scope })
+		c.Next()
+	}, controller.GetLogByID)
+	return router
+}
+
+// TestOperationLogController_GetLogByID_DataScope 测试按 ID 查询操作日志时应用数据权限，范围外的日志按不存在处理
+func TestOperationLogController_GetLogByID_DataScope(t *testing.T) {
+	all := &entity.DataScope{Scope: entity.DataScopeAll, UserID: 100}
+	dept := &entity.DataScope{Scope: entity.DataScopeDept, UserID: 100, DepartmentIDs: []int64{2}}
+	self := &entity.DataScope{Scope: entity.DataScopeSelf, UserID: 100}
+
+	tests := []struct {
+		name  string
+		scope *entity.DataScope
+		id    string
+		ok    bool
+	}{
+		{"全部数据", all, "3", true},
+		{"全部数据可查看已删除用户的日志", all, "4", true},
+		{"本部门数据", dept, "2", true},
+		{"其他部门", dept, "3", false},
+		{"所属用户已删除", dept, "4", false},
+		{"仅本人数据", self, "1", true},
+		{"仅本人数据查看他人", self, "2", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/api/admin/logs/"+tt.id, nil)
+			newOperationLogRouter(tt.scope).ServeHTTP(w, req)
+
+			require.Equal(t, http.StatusOK, w.Code)
+			var body struct {
+				Code int    `json:"code"`
+				Msg  string `json:"msg"`
+			}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+			if tt.ok {
+				assert.Equal(t, 0, body.Code)
+			} else {
+				assert.NotEqual(t, 0, body.Code)
+				assert.Equal(t, "日志不存在", body.Msg)
+			}
+		})
+	}
+}
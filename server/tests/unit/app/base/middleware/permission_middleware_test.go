@@ -0,0 +1,215 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ix-pay/ixpay-pro/internal/app/base/middleware"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/entity"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/repo"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/service"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/observability/logger"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/persistence/cache"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// MockLogger 日志 Mock 实现，丢弃所有日志
+type MockLogger struct{}
+
+func (m *MockLogger) Debug(msg string, fields ...interface{})  {}
+func (m *MockLogger) Info(msg string, fields ...interface{})   {}
+func (m *MockLogger) Warn(msg string, fields ...interface{})   {}
+func (m *MockLogger) Error(msg string, fields ...interface{})  {}
+func (m *MockLogger) Fatal(msg string, fields ...interface{})  {}
+func (m *MockLogger) With(fields ...interface{}) logger.Logger { return &MockLogger{} }
+func (m *MockLogger) Sync() error                              { return nil }
+
+// memoryCache 内存缓存，键不存在时 Get 返回错误
+type memoryCache struct {
+	data map[string]string
+}
+
+func (c *memoryCache) Get(key string) (string, error) {
+	if value, ok := c.data[key]; ok {
+		return value, nil
+	}
+	return "", errors.New("key not found")
+}
+
+func (c *memoryCache) Set(key string, value interface{}, expiration time.Duration) error {
+	if s, ok := value.(string); ok {
+		c.data[key] = s
+	}
+	return nil
+}
+
+func (c *memoryCache) Delete(key string) error {
+	delete(c.data, key)
+	return nil
+}
+
+func (c *memoryCache) Exists(key string) (bool, error) {
+	_, ok := c.data[key]
+	return ok, nil
+}
+
+func (c *memoryCache) Incr(key string, expiration time.Duration) (int64, error) { return 1, nil }
+
+func (c *memoryCache) SetNX(key string, value interface{}, expiration time.Duration) (bool, error) {
+	return true, nil
+}
+
+func (c *memoryCache) CompareAndDelete(key string, expected string) (bool, error) {
+	if v, ok := c.data[key]; !ok || v != expected {
+		return false, nil
+	}
+	delete(c.data, key)
+	return true, nil
+}
+
+func (c *memoryCache) Close() error { return nil }
+
+// memoryRoleRepo 内存角色仓库，只实现权限判定使用的方法
+type memoryRoleRepo struct {
+	repo.RoleRepository
+	roles     map[int64]*entity.Role
+	apis      map[int64][]*entity.API
+	userRoles map[int64][]int64
+}
+
+func (r *memoryRoleRepo) GetByID(id int64, relations ...repo.RoleRelation) (*entity.Role, error) {
+	if role, ok := r.roles[id]; ok {
+		return role, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *memoryRoleRepo) GetByCode(code string) (*entity.Role, error) {
+	for _, role := range r.roles {
+		if role.Code == code {
+			return role, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *memoryRoleRepo) GetRolesByUser(userID int64) ([]*entity.Role, error) {
+	roles := make([]*entity.Role, 0)
+	for _, id := range r.userRoles[userID] {
+		roles = append(roles, r.roles[id])
+	}
+	return roles, nil
+}
+
+func (r *memoryRoleRepo) GetsByRole(roleID int64) ([]*entity.API, error) {
+	return r.apis[roleID], nil
+}
+
+func (r *memoryRoleRepo) GetBtnPermsByRole(roleID int64) ([]*entity.BtnPerm, error) {
+	return nil, nil
+}
+
+// memoryUserRepo 内存用户仓库，用户不存在时与 gorm First 一样返回 ErrRecordNotFound
+type memoryUserRepo struct {
+	repo.UserRepository
+	users map[int64]*entity.User
+}
+
+func (r *memoryUserRepo) GetByID(id int64, relations ...repo.UserRelation) (*entity.User, error) {
+	if user, ok := r.users[id]; ok {
+		return user, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *memoryUserRepo) GetUserSpecialPermissions(userID int64) ([]*entity.API, error) {
+	return nil, nil
+}
+
+type memoryAPIRepo struct {
+	repo.APIRepository
+	apis []*entity.API
+}
+
+func (r *memoryAPIRepo) GetAllRoutes() ([]*entity.API, error) {
+	return r.apis, nil
+}
+
+type memoryGroupRepo struct {
+	repo.PermissionGroupRepository
+}
+
+func (r *memoryGroupRepo) GetGroupsByRole(roleID int64) ([]*entity.PermissionGroup, error) {
+	return nil, nil
+}
+
+type memoryRuleRepo struct {
+	repo.PermissionRuleRepository
+}
+
+func (r *memoryRuleRepo) GetRulesByStatus(status int) ([]*entity.PermissionRule, error) {
+	return nil, nil
+}
+
+// newTestRouter 创建挂载权限中间件的路由，principal 模拟认证中间件写入的上下文
+// 用户 100 持有 viewer（可查看用户），服务账号 500 不在用户表中
+func newTestRouter(principal gin.HandlerFunc) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	roleRepo := &memoryRoleRepo{
+		roles: map[int64]*entity.Role{
+			1: {ID: 1, Code: "viewer", Status: 1},
+			2: {ID: 2, Code: "admin", Status: 1},
+		},
+		apis:      map[int64][]*entity.API{1: {{Method: "GET", Path: "/api/admin/user/:id"}}},
+		userRoles: map[int64][]int64{100: {1}},
+	}
+	userRepo := &memoryUserRepo{users: map[int64]*entity.User{100: {ID: 100, Username: "alice", Status: 1}}}
+	apiRepo := &memoryAPIRepo{apis: []*entity.API{
+		{Method: "GET", Path: "/api/admin/user/:id", AuthType: 1},
+		{Method: "DELETE", Path: "/api/admin/user/:id", AuthType: 1},
+	}}
+	decisions := service.NewPermissionDecisionService(roleRepo, userRepo, apiRepo, &memoryGroupRepo{}, &memoryRuleRepo{},
+		&memoryCache{data: make(map[string]string)}, cache.NewInvalidationBus(&MockLogger{}), &MockLogger{})
+
+	router := gin.New()
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	group := router.Group("/api/admin", principal, middleware.PermissionMiddleware(decisions, &MockLogger{}))
+	group.GET("/user/:id", ok)
+	group.DELETE("/user/:id", ok)
+	return router
+}
+
+func serve(router *gin.Engine, method, path string) int {
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(method, path, nil))
+	return recorder.Code
+}
+
+// TestPermissionMiddleware_APIKeyPrincipal 测试服务账号不在用户表中时按其绑定的角色判定，而不是返回 500
+func TestPermissionMiddleware_APIKeyPrincipal(t *testing.T) {
+	router := newTestRouter(func(c *gin.Context) {
+		c.Set("serviceAccountID", "500")
+		c.Set("loginType", service.LoginTypeAPIKey)
+		c.Set("role", "viewer")
+	})
+
+	assert.Equal(t, http.StatusOK, serve(router, "GET", "/api/admin/user/7"))
+	assert.Equal(t, http.StatusForbidden, serve(router, "DELETE", "/api/admin/user/7"))
+}
+
+// TestPermissionMiddleware_CurrentRoleMembership 测试缓存中的当前角色不是用户的角色时不能按管理员放行，也不退回用户的其他角色
+func TestPermissionMiddleware_CurrentRoleMembership(t *testing.T) {
+	router := newTestRouter(func(c *gin.Context) {
+		c.Set("userID", "100")
+		c.Set("loginType", service.LoginTypePassword)
+		c.Set("role", "admin")
+	})
+
+	assert.Equal(t, http.StatusForbidden, serve(router, "GET", "/api/admin/user/7"), "按无角色判定")
+	assert.Equal(t, http.StatusForbidden, serve(router, "DELETE", "/api/admin/user/7"))
+}
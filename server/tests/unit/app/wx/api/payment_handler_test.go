@@ -0,0 +1,172 @@
+package wxapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	wxapi "github.com/ix-pay/ixpay-pro/internal/app/wx/api"
+	baseEntity "github.com/ix-pay/ixpay-pro/internal/domain/base/entity"
+	"github.com/ix-pay/ixpay-pro/internal/domain/wx/entity"
+	"github.com/ix-pay/ixpay-pro/internal/domain/wx/repo"
+	wxService "github.com/ix-pay/ixpay-pro/internal/domain/wx/service"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/observability/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// MockLogger 日志 Mock 实现，丢弃所有日志
+type MockLogger struct{}
+
+func (m *MockLogger) Debug(msg string, fields ...interface{})  {}
+func (m *MockLogger) Info(msg string, fields ...interface{})   {}
+func (m *MockLogger) Warn(msg string, fields ...interface{})   {}
+func (m *MockLogger) Error(msg string, fields ...interface{})  {}
+func (m *MockLogger) Fatal(msg string, fields ...interface{})  {}
+func (m *MockLogger) With(fields ...interface{}) logger.Logger { return &MockLogger{} }
+func (m *MockLogger) Sync() error                              { return nil }
+
+// memoryPaymentRepo 内存支付仓库，列表查询按数据权限和用户所属部门过滤
+type memoryPaymentRepo struct {
+	payments    []*entity.Payment
+	departments map[int64]int64 // 用户 ID -> 部门 ID
+}
+
+func (r *memoryPaymentRepo) GetByID(id int64) (*entity.Payment, error) {
+	for _, payment := range r.payments {
+		if payment.ID == id {
+			return payment, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *memoryPaymentRepo) GetByOrderID(orderID string) (*entity.Payment, error) {
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *memoryPaymentRepo) GetByTransactionID(transactionID string) (*entity.Payment, error) {
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *memoryPaymentRepo) Create(payment *entity.Payment) error { return nil }
+
+func (r *memoryPaymentRepo) Update(payment *entity.Payment) error { return nil }
+
+func (r *memoryPaymentRepo) Delete(id int64) error { return nil }
+
+func (r *memoryPaymentRepo) List(page, pageSize int, filters map[string]interface{}) ([]*entity.Payment, int, error) {
+	scope, _ := filters[baseEntity.DataScopeFilterKey].(*baseEntity.DataScope)
+	list := make([]*entity.Payment, 0, len(r.payments))
+	for _, payment := range r.payments {
+		if status, ok := filters["status"]; ok && string(payment.Status) != status {
+			continue
+		}
+		if !scope.CanAccess(payment.UserID, r.departments[payment.UserID]) {
+			continue
+		}
+		list = append(list, payment)
+	}
+	return list, len(list), nil
+}
+
+func (r *memoryPaymentRepo) ListByUser(userID int64, page, pageSize int) ([]*entity.Payment, int, error) {
+	return nil, 0, nil
+}
+
+func (r *memoryPaymentRepo) ListByStatus(status entity.PaymentStatus, page, pageSize int) ([]*entity.Payment, int, error) {
+	return nil, 0, nil
+}
+
+var _ repo.PaymentRepository = (*memoryPaymentRepo)(nil)
+
+// newPaymentListRouter 创建支付列表路由，setup 模拟认证和数据权限中间件写入的上下文
+func newPaymentListRouter(setup func(c *gin.Context)) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	paymentRepo := &memoryPaymentRepo{
+		payments: []*entity.Payment{
+			{ID: 1, OrderID: "A1", UserID: 100, Amount: 1000, Status: entity.PaymentStatusSuccess},
+			{ID: 2, OrderID: "A2", UserID: 101, Amount: 2000, Status: entity.PaymentStatusPending},
+			{ID: 3, OrderID: "A3", UserID: 200, Amount: 3000, Status: entity.PaymentStatusSuccess},
+		},
+		departments: map[int64]int64{100: 2, 101: 2, 200: 4},
+	}
+	controller := wxapi.NewPaymentController(wxService.NewPaymentService(paymentRepo, nil, nil, &MockLogger{}), &MockLogger{})
+
+	router := gin.New()
+	router.GET("/api/wx/payment", func(c *gin.Context) {
+		setup(c)
+		c.Next()
+	}, controller.GetPaymentList)
+	return router
+}
+
+// withScope 模拟数据权限中间件保存的解析函数
+func withScope(userID string, scope *baseEntity.DataScope) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		c.Set("userID", userID)
+		c.Set("dataScopeResolver", func() *baseEntity.DataScope { return scope })
+	}
+}
+
+// TestPaymentController_GetPaymentList 测试支付列表按当前用户的数据权限过滤
+func TestPaymentController_GetPaymentList(t *testing.T) {
+	tests := []struct {
+		name   string
+		query  string
+		setup  func(c *gin.Context)
+		status int
+		orders []string
+	}{
+		{"全部数据", "page=1&pageSize=10", withScope("1", &baseEntity.DataScope{Scope: baseEntity.DataScopeAll, UserID: 1}),
+			http.StatusOK, []string{"A1", "A2", "A3"}},
+		{"本部门数据", "page=1&pageSize=10", withScope("100", &baseEntity.DataScope{Scope: baseEntity.DataScopeDept, UserID: 100, DepartmentIDs: []int64{2}}),
+			http.StatusOK, []string{"A1", "A2"}},
+		{"仅本人数据", "page=1&pageSize=10", withScope("100", &baseEntity.DataScope{Scope: baseEntity.DataScopeSelf, UserID: 100}),
+			http.StatusOK, []string{"A1"}},
+		{"按状态过滤", "page=1&pageSize=10&status=success", withScope("1", &baseEntity.DataScope{Scope: baseEntity.DataScopeAll, UserID: 1}),
+			http.StatusOK, []string{"A1", "A3"}},
+		{"没有数据权限时按仅本人处理", "page=1&pageSize=10", func(c *gin.Context) { c.Set("userID", "200") },
+			http.StatusOK, []string{"A3"}},
+		{"缺少分页参数", "", withScope("1", &baseEntity.DataScope{Scope: baseEntity.DataScopeAll, UserID: 1}),
+			http.StatusOK, nil},
+		{"未认证", "page=1&pageSize=10", func(c *gin.Context) {}, http.StatusUnauthorized, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/api/wx/payment?"+tt.query, nil)
+			newPaymentListRouter(tt.setup).ServeHTTP(w, req)
+
+			require.Equal(t, tt.status, w.Code)
+			if tt.status != http.StatusOK {
+				return
+			}
+			var body struct {
+				Code int `json:"code"`
+				Data struct {
+					List []struct {
+						OrderID string `json:"order_id"`
+					} `json:"list"`
+					Total int64 `json:"total"`
+				} `json:"data"`
+			}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+			if tt.orders == nil {
+				assert.NotEqual(t, 0, body.Code, "参数错误应返回失败")
+				return
+			}
+			require.Equal(t, 0, body.Code)
+			orders := make([]string, 0, len(body.Data.List))
+			for _, item := range body.Data.List {
+				orders = append(orders, item.OrderID)
+			}
+			assert.Equal(t, tt.orders, orders)
+			assert.Equal(t, int64(len(tt.orders)), body.Data.Total)
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/entity"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/repo"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/service"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/support/apidoc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryAPIRepo 内存 API 路由仓库
+type memoryAPIRepo struct {
+	repo.APIRepository
+	apis     map[int64]*entity.API
+	assigned map[int64]bool // 已分配给角色的路由
+	nextID   int64
+	loads    int // GetAllRoutes 调用次数
+}
+
+func newMemoryAPIRepo(apis ...*entity.API) *memoryAPIRepo {
+	r := &memoryAPIRepo{apis: make(map[int64]*entity.API), assigned: make(map[int64]bool)}
+	for _, api := range apis {
+		_ = r.Create(api)
+	}
+	return r
+}
+
+func (r *memoryAPIRepo) GetAllRoutes() ([]*entity.API, error) {
+	r.loads++
+	apis := make([]*entity.API, 0, len(r.apis))
+	for _, api := range r.apis {
+		copied := *api
+		apis = append(apis, &copied)
+	}
+	return apis, nil
+}
+
+func (r *memoryAPIRepo) Create(api *entity.API) error {
+	r.nextID++
+	api.ID = r.nextID
+	copied := *api
+	r.apis[api.ID] = &copied
+	return nil
+}
+
+func (r *memoryAPIRepo) Update(api *entity.API) error {
+	copied := *api
+	r.apis[api.ID] = &copied
+	return nil
+}
+
+func (r *memoryAPIRepo) GetUnassigned() ([]*entity.API, error) {
+	apis := make([]*entity.API, 0)
+	for _, api := range r.apis {
+		if api.AuthType == 1 && !api.Orphaned && !r.assigned[api.ID] {
+			apis = append(apis, api)
+		}
+	}
+	return apis, nil
+}
+
+func (r *memoryAPIRepo) find(method, path string) *entity.API {
+	for _, api := range r.apis {
+		if api.Method == method && api.Path == path {
+			return api
+		}
+	}
+	return nil
+}
+
+// TestAPIService_SyncRoutes 测试路由同步登记新路由、标记失效路由和恢复重新出现的路由
+func TestAPIService_SyncRoutes(t *testing.T) {
+	apiRepo := newMemoryAPIRepo(
+		&entity.API{Path: "/api/admin/user", Method: "GET", Group: "用户管理", Description: "获取用户列表", AuthType: 1, Status: 1},
+		&entity.API{Path: "/api/admin/roles", Method: "GET", Group: "角色管理", Description: "获取角色列表", AuthType: 1, Status: 1},
+		&entity.API{Path: "/api/admin/menu", Method: "GET", Group: "菜单管理", AuthType: 1, Status: 1, Orphaned: true},
+		&entity.API{Path: "/api/wx/payment", Method: "GET", Group: "支付管理", AuthType: 1, Status: 1},
+	)
+	apiRepo.assigned[1] = true
+	apiRepo.assigned[4] = true
+	svc := service.NewAPIService(apiRepo, &MockLogger{})
+
+	report, err := svc.SyncRoutes("/api/admin/", []*entity.API{
+		{Path: "/api/admin/user", Method: "GET", Group: "用户", Description: "用户列表"},
+		{Path: "/api/admin/menu", Method: "GET", Group: "菜单", Description: "菜单列表"},
+		{Path: "/api/admin/role/:id", Method: "GET", Group: "角色管理", Description: "获取角色详情"},
+		{Path: "/api/admin/role/:id", Method: "GET", Group: "角色管理", Description: "获取角色详情"},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, report.Total, "重复路由只计一次")
+	require.Len(t, report.Added, 1)
+	assert.Equal(t, "/api/admin/role/:id", report.Added[0].Path)
+	assert.Equal(t, 1, report.Added[0].AuthType, "新路由需要授权")
+	require.Len(t, report.Restored, 1)
+	assert.Equal(t, "/api/admin/menu", report.Restored[0].Path)
+	require.Len(t, report.Orphaned, 1)
+	assert.Equal(t, "/api/admin/roles", report.Orphaned[0].Path)
+	assert.False(t, apiRepo.find("GET", "/api/wx/payment").Orphaned, "同步前缀外的路由不标记为失效")
+	assert.Len(t, report.Unassigned, 2, "未分配的新路由和恢复的路由需要分配")
+
+	user := apiRepo.find("GET", "/api/admin/user")
+	assert.Equal(t, "用户管理", user.Group, "已登记路由的分组不被覆盖")
+	assert.Equal(t, "获取用户列表", user.Description)
+	menu := apiRepo.find("GET", "/api/admin/menu")
+	assert.False(t, menu.Orphaned)
+	assert.Equal(t, "菜单列表", menu.Description, "为空的描述用文档补齐")
+	roles := apiRepo.find("GET", "/api/admin/roles")
+	assert.True(t, roles.Orphaned, "失效路由只标记不删除")
+
+	report, err = svc.GetSyncReport()
+	require.NoError(t, err)
+	assert.Len(t, report.Added, 1)
+	require.Len(t, report.Orphaned, 1)
+	assert.Equal(t, roles.ID, report.Orphaned[0].ID)
+}
+
+// TestAPIDoc_Lookup 测试按 gin 路由模板查找 swagger 文档的摘要和标签
+func TestAPIDoc_Lookup(t *testing.T) {
+	index, err := apidoc.Parse([]byte(`{"paths":{
+		"/api/admin/user/{id}":{"get":{"summary":"获取用户","tags":["用户管理"]}},
+		"/api/admin/apis/:id":{"delete":{"summary":"删除 API 路由","tags":["API 路由管理"]}}
+	}}`))
+	require.NoError(t, err)
+
+	op, ok := index.Lookup("GET", "/api/admin/user/:userId")
+	require.True(t, ok)
+	assert.Equal(t, "获取用户", op.Summary)
+	assert.Equal(t, []string{"用户管理"}, op.Tags)
+
+	op, ok = index.Lookup("DELETE", "/api/admin/apis/:id")
+	require.True(t, ok)
+	assert.Equal(t, "删除 API 路由", op.Summary)
+
+	_, ok = index.Lookup("POST", "/api/admin/user/:id")
+	assert.False(t, ok)
+}
@@ -3,6 +3,7 @@ package service
 import (
 	"testing"
 
+	"github.com/ix-pay/ixpay-pro/internal/config"
 	"github.com/ix-pay/ixpay-pro/internal/domain/base/entity"
 	"github.com/ix-pay/ixpay-pro/internal/domain/base/service"
 )
@@ -11,7 +12,7 @@ import (
 func BenchmarkOnlineUserService_AddOnlineUser(b *testing.B) {
 	repo := NewMockOnlineUserRepositoryForTest()
 	log := &MockLogger{}
-	service := service.NewOnlineUserService(repo, log)
+	service := newTestOnlineUserService(repo, &config.Config{}, log)
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
@@ -38,7 +39,7 @@ func BenchmarkOnlineUserService_AddOnlineUser(b *testing.B) {
 func BenchmarkOnlineUserService_GetOnlineUser(b *testing.B) {
 	repo := NewMockOnlineUserRepositoryForTest()
 	log := &MockLogger{}
-	service := service.NewOnlineUserService(repo, log)
+	service := newTestOnlineUserService(repo, &config.Config{}, log)
 
 	// 准备测试数据
 	for i := 0; i < 100; i++ {
@@ -68,7 +69,7 @@ func BenchmarkOnlineUserService_GetOnlineUser(b *testing.B) {
 func BenchmarkOnlineUserService_GetOnlineUserList(b *testing.B) {
 	repo := NewMockOnlineUserRepositoryForTest()
 	log := &MockLogger{}
-	service := service.NewOnlineUserService(repo, log)
+	service := newTestOnlineUserService(repo, &config.Config{}, log)
 
 	// 准备大量测试数据
 	for i := 0; i < 1000; i++ {
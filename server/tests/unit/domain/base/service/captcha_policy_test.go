@@ -0,0 +1,134 @@
+package service
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/ix-pay/ixpay-pro/internal/config"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/security/captcha"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestCaptcha 创建使用内存缓存的验证码服务
+func newTestCaptcha(t *testing.T, captchaCfg config.CaptchaConfig) (*captcha.Captcha, *MockCache) {
+	cache := NewMockCache()
+	cfg := &config.Config{
+		Server:  config.ServerConfig{OpenCaptcha: true, CaptchaLen: 4, CaptchaTimeOut: 60},
+		Captcha: captchaCfg,
+	}
+	c, err := captcha.NewCaptcha(cfg, cache)
+	require.NoError(t, err)
+	return c, cache
+}
+
+// answerOf 从缓存中读取验证码答案
+func answerOf(t *testing.T, cache *MockCache, id string) string {
+	raw, err := cache.Get("captcha:item:" + id)
+	require.NoError(t, err)
+	start := strings.Index(raw, `"answer":"`) + len(`"answer":"`)
+	return raw[start : start+strings.Index(raw[start:], `"`)]
+}
+
+// TestCaptcha_ScenarioPolicy 测试场景策略：注册始终需要，登录失败达到阈值后需要
+func TestCaptcha_ScenarioPolicy(t *testing.T) {
+	c, _ := newTestCaptcha(t, config.CaptchaConfig{})
+
+	assert.True(t, c.Required(captcha.ScenarioRegister, "alice", "10.0.0.1"))
+	assert.True(t, c.Required(captcha.ScenarioPasswordReset, "alice", "10.0.0.1"))
+	assert.False(t, c.Required(captcha.ScenarioLogin, "alice", "10.0.0.1"))
+	assert.NoError(t, c.Verify(captcha.ScenarioLogin, "alice", "10.0.0.1", "", ""))
+
+	// 默认阈值 3 次
+	for i := 0; i < 3; i++ {
+		c.RecordFailure(captcha.ScenarioLogin, "alice", "10.0.0.1")
+	}
+	assert.True(t, c.Required(captcha.ScenarioLogin, "alice", "10.0.0.2"))
+	// 同一 IP 换账号仍需要验证码
+	assert.True(t, c.Required(captcha.ScenarioLogin, "bob", "10.0.0.1"))
+	assert.False(t, c.Required(captcha.ScenarioLogin, "bob", "10.0.0.2"))
+	assert.ErrorIs(t, c.Verify(captcha.ScenarioLogin, "alice", "10.0.0.2", "", ""), captcha.ErrCaptchaRequired)
+
+	// 登录成功后清除账号失败次数
+	c.ResetFailures(captcha.ScenarioLogin, "alice")
+	assert.False(t, c.Required(captcha.ScenarioLogin, "alice", "10.0.0.2"))
+
+	// 总开关关闭后都不需要
+	disabled, err := captcha.NewCaptcha(&config.Config{}, NewMockCache())
+	require.NoError(t, err)
+	assert.False(t, disabled.Required(captcha.ScenarioRegister, "alice", ""))
+
+	// 无效配置
+	_, err = captcha.NewCaptcha(&config.Config{Captcha: config.CaptchaConfig{Driver: "qrcode"}}, NewMockCache())
+	assert.Error(t, err)
+	_, err = captcha.NewCaptcha(&config.Config{Captcha: config.CaptchaConfig{
+		Scenarios: map[string]config.CaptchaScenarioConfig{captcha.ScenarioLogin: {Mode: "sometimes"}},
+	}}, NewMockCache())
+	assert.Error(t, err)
+}
+
+// TestCaptcha_SingleUseAndAttempts 测试验证码一次性使用和校验次数上限
+func TestCaptcha_SingleUseAndAttempts(t *testing.T) {
+	c, cache := newTestCaptcha(t, config.CaptchaConfig{
+		Driver:      captcha.TypeMath,
+		MaxAttempts: 2,
+	})
+
+	challenge, err := c.Generate(captcha.ScenarioRegister)
+	require.NoError(t, err)
+	assert.Equal(t, captcha.TypeMath, challenge.Type)
+	assert.True(t, strings.HasPrefix(challenge.Data, "data:image/png;base64,"))
+	answer := answerOf(t, cache, challenge.ID)
+
+	// 验证码只能用于生成时的场景
+	assert.ErrorIs(t, c.Verify(captcha.ScenarioPasswordReset, "alice", "", challenge.ID, answer), captcha.ErrCaptchaExpired)
+
+	require.NoError(t, c.Verify(captcha.ScenarioRegister, "alice", "", challenge.ID, answer))
+	assert.ErrorIs(t, c.Verify(captcha.ScenarioRegister, "alice", "", challenge.ID, answer), captcha.ErrCaptchaExpired)
+
+	// 错误次数达到上限后作废
+	challenge, err = c.Generate(captcha.ScenarioRegister)
+	require.NoError(t, err)
+	answer = answerOf(t, cache, challenge.ID)
+	assert.ErrorIs(t, c.Verify(captcha.ScenarioRegister, "alice", "", challenge.ID, "wrong"), captcha.ErrCaptchaInvalid)
+	assert.ErrorIs(t, c.Verify(captcha.ScenarioRegister, "alice", "", challenge.ID, "wrong"), captcha.ErrCaptchaInvalid)
+	assert.ErrorIs(t, c.Verify(captcha.ScenarioRegister, "alice", "", challenge.ID, answer), captcha.ErrCaptchaExpired)
+}
+
+// TestCaptcha_Drivers 测试各类型验证码生成和校验
+func TestCaptcha_Drivers(t *testing.T) {
+	c, cache := newTestCaptcha(t, config.CaptchaConfig{
+		SliderTolerance: 3,
+		Scenarios: map[string]config.CaptchaScenarioConfig{
+			"digit":  {Driver: captcha.TypeDigit},
+			"string": {Driver: captcha.TypeString},
+			"audio":  {Driver: captcha.TypeAudio},
+			"slider": {Driver: captcha.TypeSlider},
+		},
+	})
+
+	for _, scenario := range []string{"digit", "string", "audio"} {
+		challenge, err := c.Generate(scenario)
+		require.NoError(t, err, scenario)
+		assert.Equal(t, scenario, challenge.Type)
+		assert.Equal(t, 4, challenge.Length)
+		answer := answerOf(t, cache, challenge.ID)
+		if scenario == captcha.TypeString {
+			// 字母数字验证码不区分大小写
+			answer = strings.ToUpper(answer)
+		}
+		assert.NoError(t, c.Verify(scenario, "", "", challenge.ID, answer), scenario)
+	}
+
+	// 滑块验证码按允许误差比对横坐标
+	challenge, err := c.Generate("slider")
+	require.NoError(t, err)
+	assert.Equal(t, captcha.TypeSlider, challenge.Type)
+	assert.NotEmpty(t, challenge.PieceImage)
+	assert.Equal(t, 300, challenge.Width)
+	offset, err := strconv.Atoi(answerOf(t, cache, challenge.ID))
+	require.NoError(t, err)
+	assert.ErrorIs(t, c.Verify("slider", "", "", challenge.ID, strconv.Itoa(offset+10)), captcha.ErrCaptchaInvalid)
+	assert.NoError(t, c.Verify("slider", "", "", challenge.ID, strconv.FormatFloat(float64(offset)+2.5, 'f', 1, 64)))
+}
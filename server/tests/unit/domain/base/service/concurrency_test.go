@@ -5,82 +5,93 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ix-pay/ixpay-pro/internal/config"
 	"github.com/ix-pay/ixpay-pro/internal/domain/base/entity"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/repo"
 	"github.com/ix-pay/ixpay-pro/internal/domain/base/service"
 	"github.com/ix-pay/ixpay-pro/internal/infrastructure/observability/logger"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/transport/push"
 	"github.com/stretchr/testify/assert"
 )
 
-// MockOnlineUserRepository 用于并发测试的 Mock 实现
+// MockOnlineUserRepository 用于并发测试的 Mock 实现，按会话 ID 存储
 type MockOnlineUserRepository struct {
-	users map[int64]*entity.OnlineUser
-	mu    sync.RWMutex
+	sessions map[string]*entity.OnlineUser
+	mu       sync.RWMutex
 }
 
 func NewMockOnlineUserRepositoryForTest() *MockOnlineUserRepository {
 	return &MockOnlineUserRepository{
-		users: make(map[int64]*entity.OnlineUser),
+		sessions: make(map[string]*entity.OnlineUser),
 	}
 }
 
 func (m *MockOnlineUserRepository) Add(user *entity.OnlineUser) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
-	m.users[user.UserID] = user
+	copied := *user
+	m.sessions[user.SessionID] = &copied
 	return nil
 }
 
-func (m *MockOnlineUserRepository) GetByUserID(userID int64) (*entity.OnlineUser, error) {
+func (m *MockOnlineUserRepository) GetByUserID(userID int64) ([]*entity.OnlineUser, error) {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
-	return m.users[userID], nil
+	sessions := make([]*entity.OnlineUser, 0)
+	for _, session := range m.sessions {
+		if session.UserID == userID {
+			copied := *session
+			sessions = append(sessions, &copied)
+		}
+	}
+	return sessions, nil
 }
 
 func (m *MockOnlineUserRepository) GetBySessionID(sessionID string) (*entity.OnlineUser, error) {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
-	for _, user := range m.users {
-		if user.SessionID == sessionID {
-			return user, nil
-		}
+	session, ok := m.sessions[sessionID]
+	if !ok {
+		return nil, nil
 	}
-	return nil, nil
+	copied := *session
+	return &copied, nil
 }
 
 func (m *MockOnlineUserRepository) GetAll() ([]*entity.OnlineUser, error) {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
-	users := make([]*entity.OnlineUser, 0, len(m.users))
-	for _, user := range m.users {
-		users = append(users, user)
+	sessions := make([]*entity.OnlineUser, 0, len(m.sessions))
+	for _, session := range m.sessions {
+		copied := *session
+		sessions = append(sessions, &copied)
 	}
-	return users, nil
+	return sessions, nil
 }
 
 func (m *MockOnlineUserRepository) Remove(userID int64) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
-	delete(m.users, userID)
+	for sessionID, session := range m.sessions {
+		if session.UserID == userID {
+			delete(m.sessions, sessionID)
+		}
+	}
 	return nil
 }
 
 func (m *MockOnlineUserRepository) RemoveBySessionID(sessionID string) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
-	for userID, user := range m.users {
-		if user.SessionID == sessionID {
-			delete(m.users, userID)
-			break
-		}
-	}
+	delete(m.sessions, sessionID)
 	return nil
 }
 
-func (m *MockOnlineUserRepository) UpdateActiveTime(userID int64) error {
+func (m *MockOnlineUserRepository) UpdateActiveTime(sessionID string) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
-	if user, exists := m.users[userID]; exists {
-		user.LastActiveAt = time.Now()
+	if session, exists := m.sessions[sessionID]; exists {
+		session.LastActiveAt = time.Now()
 	}
 	return nil
 }
@@ -88,14 +99,22 @@ func (m *MockOnlineUserRepository) UpdateActiveTime(userID int64) error {
 func (m *MockOnlineUserRepository) Exists(userID int64) (bool, error) {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
-	_, exists := m.users[userID]
-	return exists, nil
+	for _, session := range m.sessions {
+		if session.UserID == userID {
+			return true, nil
+		}
+	}
+	return false, nil
 }
 
 func (m *MockOnlineUserRepository) GetCount() (int, error) {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
-	return len(m.users), nil
+	users := make(map[int64]struct{})
+	for _, session := range m.sessions {
+		users[session.UserID] = struct{}{}
+	}
+	return len(users), nil
 }
 
 func (m *MockOnlineUserRepository) ClearExpired() error {
@@ -103,11 +122,43 @@ func (m *MockOnlineUserRepository) ClearExpired() error {
 	return nil
 }
 
+// MockSessionAuditLogRepository 会话审计记录的 Mock 实现
+type MockSessionAuditLogRepository struct {
+	logs    []*entity.SessionAuditLog
+	failErr error // 不为 nil 时写入失败
+	mu      sync.Mutex
+}
+
+func NewMockSessionAuditLogRepository() *MockSessionAuditLogRepository {
+	return &MockSessionAuditLogRepository{}
+}
+
+func (m *MockSessionAuditLogRepository) CreateBatch(logs []*entity.SessionAuditLog) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.failErr != nil {
+		return m.failErr
+	}
+	m.logs = append(m.logs, logs...)
+	return nil
+}
+
+func (m *MockSessionAuditLogRepository) List(page, pageSize int, filters map[string]interface{}) ([]*entity.SessionAuditLog, int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.logs, int64(len(m.logs)), nil
+}
+
+// newTestOnlineUserService 创建使用 Mock 审计仓库和进程内推送中心的在线用户服务
+func newTestOnlineUserService(onlineUserRepo repo.OnlineUserRepository, cfg *config.Config, log logger.Logger) *service.OnlineUserService {
+	return service.NewOnlineUserService(onlineUserRepo, NewMockSessionAuditLogRepository(), push.NewHub(log), nil, cfg, log)
+}
+
 // TestOnlineUserService_ConcurrentAddUsers 测试并发添加在线用户
 func TestOnlineUserService_ConcurrentAddUsers(t *testing.T) {
 	repo := NewMockOnlineUserRepositoryForTest()
 	log := &MockLogger{}
-	svc := service.NewOnlineUserService(repo, log)
+	svc := newTestOnlineUserService(repo, &config.Config{}, log)
 
 	concurrency := 100
 	var wg sync.WaitGroup
@@ -154,7 +205,7 @@ func TestOnlineUserService_ConcurrentAddUsers(t *testing.T) {
 func TestOnlineUserService_ConcurrentReadWrite(t *testing.T) {
 	repo := NewMockOnlineUserRepositoryForTest()
 	log := &MockLogger{}
-	svc := service.NewOnlineUserService(repo, log)
+	svc := newTestOnlineUserService(repo, &config.Config{}, log)
 
 	// 先添加一些用户
 	for i := 0; i < 10; i++ {
@@ -238,7 +289,7 @@ func TestOnlineUserService_ConcurrentReadWrite(t *testing.T) {
 func TestOnlineUserService_ConcurrentForceOffline(t *testing.T) {
 	repo := NewMockOnlineUserRepositoryForTest()
 	log := &MockLogger{}
-	svc := service.NewOnlineUserService(repo, log)
+	svc := newTestOnlineUserService(repo, &config.Config{}, log)
 
 	// 添加一个测试用户
 	err := svc.AddOnlineUser(
@@ -265,7 +316,7 @@ func TestOnlineUserService_ConcurrentForceOffline(t *testing.T) {
 		wg.Add(1)
 		go func(index int) {
 			defer wg.Done()
-			err := svc.ForceOffline(int64(1), string(rune(index)))
+			err := svc.ForceOffline(int64(1), int64(index))
 			mu.Lock()
 			defer mu.Unlock()
 			if err == nil {
@@ -289,7 +340,7 @@ func TestOnlineUserService_ConcurrentForceOffline(t *testing.T) {
 func TestOnlineUserService_ConcurrentGetOnlineCount(t *testing.T) {
 	repo := NewMockOnlineUserRepositoryForTest()
 	log := &MockLogger{}
-	svc := service.NewOnlineUserService(repo, log)
+	svc := newTestOnlineUserService(repo, &config.Config{}, log)
 
 	// 添加一些用户
 	for i := 0; i < 10; i++ {
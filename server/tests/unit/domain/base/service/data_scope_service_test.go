@@ -0,0 +1,202 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/entity"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/repo"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/service"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/persistence/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryDataScopeRoleRepo 支持更新和查询用户角色的内存角色仓库
+type memoryDataScopeRoleRepo struct {
+	*MockRoleRepositoryForTest
+	userRoles map[int64][]int64
+}
+
+func (r *memoryDataScopeRoleRepo) GetRolesByUser(userID int64) ([]*entity.Role, error) {
+	roles := make([]*entity.Role, 0, len(r.userRoles[userID]))
+	for _, roleID := range r.userRoles[userID] {
+		if role, ok := r.roles[roleID]; ok {
+			roles = append(roles, role)
+		}
+	}
+	return roles, nil
+}
+
+func (r *memoryDataScopeRoleRepo) Update(role *entity.Role) error {
+	r.roles[role.ID] = role
+	return nil
+}
+
+// memoryDataScopeDepartmentRepo 支持查询全部部门的内存部门仓库
+type memoryDataScopeDepartmentRepo struct {
+	*MockDepartmentRepositoryForTest
+}
+
+func (r *memoryDataScopeDepartmentRepo) GetAll() ([]*entity.Department, error) {
+	departments := make([]*entity.Department, 0, len(r.departments))
+	for _, d := range r.departments {
+		departments = append(departments, d)
+	}
+	return departments, nil
+}
+
+var _ repo.RoleRepository = (*memoryDataScopeRoleRepo)(nil)
+
+// TestDataScopeService_Resolve 测试各数据权限范围的解析结果
+func TestDataScopeService_Resolve(t *testing.T) {
+	// 部门树：1 总部 -> 2 财务部 -> 3 结算组；4 研发部
+	departments := &memoryDataScopeDepartmentRepo{&MockDepartmentRepositoryForTest{departments: map[int64]*entity.Department{
+		1: {ID: 1, Name: "总部"},
+		2: {ID: 2, Name: "财务部", ParentID: 1},
+		3: {ID: 3, Name: "结算组", ParentID: 2},
+		4: {ID: 4, Name: "研发部", ParentID: 1},
+	}}}
+	roles := &memoryDataScopeRoleRepo{NewMockRoleRepositoryForTest(
+		&entity.Role{ID: 10, Code: "admin", DataScope: entity.DataScopeAll},
+		&entity.Role{ID: 11, Code: "all", DataScope: entity.DataScopeAll},
+		&entity.Role{ID: 12, Code: "dept", DataScope: entity.DataScopeDept},
+		&entity.Role{ID: 13, Code: "dept_children", DataScope: entity.DataScopeDeptAndChildren},
+		&entity.Role{ID: 14, Code: "custom", DataScope: entity.DataScopeCustom, DataScopeDeptIds: []int64{3, 4}},
+		&entity.Role{ID: 15, Code: "self", DataScope: entity.DataScopeSelf},
+	), map[int64][]int64{
+		100: {11, 12, 13, 14, 15},
+		101: {12},
+		102: {10},
+	}}
+	users := NewMockUserRepositoryForTest(
+		&entity.User{ID: 100, Username: "finance", DepartmentID: 2},
+		&entity.User{ID: 101, Username: "nodept"},
+		&entity.User{ID: 102, Username: "root"},
+	)
+	svc := service.NewDataScopeService(roles, users, departments, NewMockCache(), &MockLogger{})
+
+	testCases := []struct {
+		name    string
+		userID  int64
+		role    string
+		scope   int
+		deptIDs []int64
+	}{
+		{"管理员", 102, "admin", entity.DataScopeAll, nil},
+		{"未分配的管理员角色按仅本人", 100, "admin", entity.DataScopeSelf, nil},
+		{"未分配的角色按仅本人", 101, "all", entity.DataScopeSelf, nil},
+		{"全部数据", 100, "all", entity.DataScopeAll, nil},
+		{"本部门", 100, "dept", entity.DataScopeDept, []int64{2}},
+		{"本部门及以下", 100, "dept_children", entity.DataScopeDeptAndChildren, []int64{2, 3}},
+		{"自定义部门", 100, "custom", entity.DataScopeCustom, []int64{3, 4}},
+		{"仅本人", 100, "self", entity.DataScopeSelf, nil},
+		{"角色不存在按仅本人", 100, "unknown", entity.DataScopeSelf, nil},
+		{"无部门用户按仅本人", 101, "dept", entity.DataScopeSelf, nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			scope, err := svc.Resolve(tc.userID, tc.role)
+			require.NoError(t, err)
+			assert.Equal(t, tc.scope, scope.Scope)
+			assert.Equal(t, tc.userID, scope.UserID)
+			assert.ElementsMatch(t, tc.deptIDs, scope.DepartmentIDs)
+		})
+	}
+
+	t.Run("服务账号", func(t *testing.T) {
+		scope, err := svc.ResolveServiceAccount("custom")
+		require.NoError(t, err)
+		assert.Equal(t, []int64{3, 4}, scope.DepartmentIDs)
+
+		scope, err = svc.ResolveServiceAccount("dept")
+		require.NoError(t, err)
+		assert.True(t, scope.IsSelf(), "服务账号没有部门")
+		assert.False(t, scope.CanAccess(100, 2))
+	})
+}
+
+// TestDataScope_CanAccess 测试按 ID 操作单条记录时的数据权限判断
+func TestDataScope_CanAccess(t *testing.T) {
+	var missing *entity.DataScope
+	assert.False(t, missing.IsAll(), "未解析出数据权限时不视为全部")
+	assert.False(t, missing.CanAccess(100, 2))
+
+	all := &entity.DataScope{Scope: entity.DataScopeAll, UserID: 100}
+	assert.True(t, all.CanAccess(200, 4))
+
+	dept := &entity.DataScope{Scope: entity.DataScopeDeptAndChildren, UserID: 100, DepartmentIDs: []int64{2, 3}}
+	assert.True(t, dept.CanAccess(200, 3))
+	assert.False(t, dept.CanAccess(200, 4))
+	assert.True(t, dept.CanAccess(100, 0), "本人始终可见")
+
+	self := &entity.DataScope{Scope: entity.DataScopeSelf, UserID: 100}
+	assert.True(t, self.CanAccess(100, 2))
+	assert.False(t, self.CanAccess(200, 2))
+}
+
+// TestDataScopeService_SetRoleDataScope 测试设置角色数据权限的校验和缓存失效
+func TestDataScopeService_SetRoleDataScope(t *testing.T) {
+	departments := &memoryDataScopeDepartmentRepo{&MockDepartmentRepositoryForTest{departments: map[int64]*entity.Department{
+		2: {ID: 2, Name: "财务部"},
+	}}}
+	roles := &memoryDataScopeRoleRepo{NewMockRoleRepositoryForTest(
+		&entity.Role{ID: 12, Code: "finance", DataScope: entity.DataScopeSelf},
+	), map[int64][]int64{100: {12}}}
+	users := NewMockUserRepositoryForTest(&entity.User{ID: 100, Username: "finance", DepartmentID: 2})
+	svc := service.NewDataScopeService(roles, users, departments, NewMockCache(), &MockLogger{})
+
+	scope, err := svc.Resolve(100, "finance")
+	require.NoError(t, err)
+	assert.True(t, scope.IsSelf())
+
+	assert.EqualError(t, svc.SetRoleDataScope(12, 9, nil, 1), "数据权限范围无效")
+	assert.EqualError(t, svc.SetRoleDataScope(12, entity.DataScopeCustom, nil, 1), "自定义数据权限至少需要选择一个部门")
+	assert.EqualError(t, svc.SetRoleDataScope(12, entity.DataScopeCustom, []int64{99}, 1), "部门 99 不存在")
+	assert.EqualError(t, svc.SetRoleDataScope(99, entity.DataScopeAll, nil, 1), "角色不存在")
+
+	require.NoError(t, svc.SetRoleDataScope(12, entity.DataScopeCustom, []int64{2}, 1))
+	scope, err = svc.Resolve(100, "finance")
+	require.NoError(t, err)
+	assert.Equal(t, entity.DataScopeCustom, scope.Scope, "设置后应立即使缓存失效")
+	assert.Equal(t, []int64{2}, scope.DepartmentIDs)
+
+	require.NoError(t, svc.SetRoleDataScope(12, entity.DataScopeAll, []int64{2}, 1))
+	assert.Empty(t, roles.roles[12].DataScopeDeptIds, "非自定义范围不保存部门")
+}
+
+// TestDataScopeService_OnTableChange 测试角色分配和角色数据权限在仓库中直接变更后，表写入回调使缓存失效
+func TestDataScopeService_OnTableChange(t *testing.T) {
+	roles := &memoryDataScopeRoleRepo{NewMockRoleRepositoryForTest(
+		&entity.Role{ID: 11, Code: "all", DataScope: entity.DataScopeAll},
+		&entity.Role{ID: 12, Code: "finance", DataScope: entity.DataScopeAll},
+	), map[int64][]int64{100: {11}}}
+	users := NewMockUserRepositoryForTest(&entity.User{ID: 100, Username: "finance", DepartmentID: 2})
+	departments := &memoryDataScopeDepartmentRepo{&MockDepartmentRepositoryForTest{departments: map[int64]*entity.Department{}}}
+	svc := service.NewDataScopeService(roles, users, departments, NewMockCache(), &MockLogger{})
+
+	scope, err := svc.Resolve(100, "all")
+	require.NoError(t, err)
+	assert.True(t, scope.IsAll())
+
+	// 收回角色后未失效前仍使用缓存
+	roles.userRoles[100] = []int64{12}
+	scope, err = svc.Resolve(100, "all")
+	require.NoError(t, err)
+	assert.True(t, scope.IsAll())
+
+	svc.OnTableChange(database.TableChange{Table: "base_role_users"})
+	scope, err = svc.Resolve(100, "all")
+	require.NoError(t, err)
+	assert.True(t, scope.IsSelf(), "角色分配变更后已收回的角色不再生效")
+
+	scope, err = svc.Resolve(100, "finance")
+	require.NoError(t, err)
+	assert.True(t, scope.IsAll())
+
+	roles.roles[12].DataScope = entity.DataScopeSelf
+	svc.OnTableChange(database.TableChange{Table: "base_roles"})
+	scope, err = svc.Resolve(100, "finance")
+	require.NoError(t, err)
+	assert.True(t, scope.IsSelf(), "角色数据权限变更后立即生效")
+}
@@ -0,0 +1,177 @@
+package service
+
+import (
+	"bytes"
+	"encoding/base64"
+	"testing"
+
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/persistence/database"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/security/fieldcrypt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestKey 生成测试用主密钥
+func newTestKey(id string, fill byte) fieldcrypt.Key {
+	return fieldcrypt.Key{ID: id, Material: bytes.Repeat([]byte{fill}, 32)}
+}
+
+// TestFieldCipher_RoundTrip 测试加密解密往返
+func TestFieldCipher_RoundTrip(t *testing.T) {
+	cipher, err := fieldcrypt.NewCipher([]fieldcrypt.Key{newTestKey("k1", 1)})
+	require.NoError(t, err)
+
+	ciphertext, err := cipher.Encrypt("13800138000")
+	require.NoError(t, err)
+	assert.True(t, fieldcrypt.IsEncrypted(ciphertext))
+	assert.NotContains(t, ciphertext, "13800138000")
+
+	plaintext, err := cipher.Decrypt(ciphertext)
+	require.NoError(t, err)
+	assert.Equal(t, "13800138000", plaintext)
+
+	// 每次加密使用随机数据密钥，密文不同
+	another, err := cipher.Encrypt("13800138000")
+	require.NoError(t, err)
+	assert.NotEqual(t, ciphertext, another)
+
+	// 重复加密密文不会再次加密
+	again, err := cipher.Encrypt(ciphertext)
+	require.NoError(t, err)
+	assert.Equal(t, ciphertext, again)
+
+	// 空串不加密
+	empty, err := cipher.Encrypt("")
+	require.NoError(t, err)
+	assert.Equal(t, "", empty)
+}
+
+// TestFieldCipher_LegacyPlaintext 测试历史明文兼容和未配置主密钥
+func TestFieldCipher_LegacyPlaintext(t *testing.T) {
+	cipher, err := fieldcrypt.NewCipher([]fieldcrypt.Key{newTestKey("k1", 1)})
+	require.NoError(t, err)
+
+	plaintext, err := cipher.Decrypt("legacy@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "legacy@example.com", plaintext)
+	assert.True(t, cipher.NeedsReEncryption("legacy@example.com"))
+
+	disabled, err := fieldcrypt.NewCipher(nil)
+	require.NoError(t, err)
+	assert.False(t, disabled.Enabled())
+	value, err := disabled.Encrypt("secret")
+	require.NoError(t, err)
+	assert.Equal(t, "secret", value)
+	assert.Empty(t, disabled.BlindIndex("secret"))
+	assert.False(t, disabled.NeedsReEncryption("secret"))
+
+	// 未配置主密钥时无法解密已有密文
+	ciphertext, err := cipher.Encrypt("secret")
+	require.NoError(t, err)
+	_, err = disabled.Decrypt(ciphertext)
+	assert.Error(t, err)
+}
+
+// TestFieldCipher_KeyRotation 测试主密钥轮换
+func TestFieldCipher_KeyRotation(t *testing.T) {
+	oldCipher, err := fieldcrypt.NewCipher([]fieldcrypt.Key{newTestKey("k1", 1)})
+	require.NoError(t, err)
+	oldCiphertext, err := oldCipher.Encrypt("wx-secret")
+	require.NoError(t, err)
+	oldIndex := oldCipher.BlindIndex("wx-secret")
+
+	rotated, err := fieldcrypt.NewCipher([]fieldcrypt.Key{newTestKey("k2", 2), newTestKey("k1", 1)})
+	require.NoError(t, err)
+
+	// 旧主密钥加密的值仍可解密，但需要重新加密
+	plaintext, err := rotated.Decrypt(oldCiphertext)
+	require.NoError(t, err)
+	assert.Equal(t, "wx-secret", plaintext)
+	assert.True(t, rotated.NeedsReEncryption(oldCiphertext))
+
+	newCiphertext, err := rotated.Encrypt(plaintext)
+	require.NoError(t, err)
+	assert.False(t, rotated.NeedsReEncryption(newCiphertext))
+
+	// 轮换期间新旧盲索引都能命中
+	indexes := rotated.BlindIndexes("wx-secret")
+	assert.Len(t, indexes, 2)
+	assert.Contains(t, indexes, oldIndex)
+	assert.Equal(t, rotated.BlindIndex("wx-secret"), indexes[0])
+	assert.NotEqual(t, oldIndex, indexes[0])
+
+	// 移除旧主密钥后无法解密未重新加密的值
+	newOnly, err := fieldcrypt.NewCipher([]fieldcrypt.Key{newTestKey("k2", 2)})
+	require.NoError(t, err)
+	_, err = newOnly.Decrypt(oldCiphertext)
+	assert.Error(t, err)
+}
+
+// TestFieldCipher_Tampered 测试密文被篡改或使用错误主密钥
+func TestFieldCipher_Tampered(t *testing.T) {
+	cipher, err := fieldcrypt.NewCipher([]fieldcrypt.Key{newTestKey("k1", 1)})
+	require.NoError(t, err)
+	ciphertext, err := cipher.Encrypt("password")
+	require.NoError(t, err)
+
+	wrongKey, err := fieldcrypt.NewCipher([]fieldcrypt.Key{newTestKey("k1", 9)})
+	require.NoError(t, err)
+	_, err = wrongKey.Decrypt(ciphertext)
+	assert.Error(t, err)
+
+	_, err = cipher.Decrypt("enc:v1:k1:bad")
+	assert.Error(t, err)
+
+	tampered := []byte(ciphertext)
+	tampered[len(tampered)-2] ^= 'A' ^ 'B'
+	_, err = cipher.Decrypt(string(tampered))
+	assert.Error(t, err)
+}
+
+// TestFieldCipher_ParseKeyring 测试主密钥列表解析
+func TestFieldCipher_ParseKeyring(t *testing.T) {
+	material := base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{1}, 32))
+
+	keys, err := fieldcrypt.ParseKeyring("# 当前主密钥\nk2:" + material + "\n, k1:" + material)
+	require.NoError(t, err)
+	require.Len(t, keys, 2)
+	assert.Equal(t, "k2", keys[0].ID)
+	assert.Equal(t, "k1", keys[1].ID)
+
+	_, err = fieldcrypt.ParseKeyring("no-separator")
+	assert.Error(t, err)
+	_, err = fieldcrypt.ParseKeyring("k1:not-base64!")
+	assert.Error(t, err)
+
+	_, err = fieldcrypt.NewCipher([]fieldcrypt.Key{{ID: "short", Material: []byte("short")}})
+	assert.Error(t, err)
+	_, err = fieldcrypt.NewCipher([]fieldcrypt.Key{newTestKey("k1", 1), newTestKey("k1", 2)})
+	assert.Error(t, err)
+}
+
+// TestEncryptedString_ValueScan 测试透明加密字段读写
+func TestEncryptedString_ValueScan(t *testing.T) {
+	cipher, err := fieldcrypt.NewCipher([]fieldcrypt.Key{newTestKey("k1", 1)})
+	require.NoError(t, err)
+	database.SetFieldCipher(cipher)
+	defer database.SetFieldCipher(nil)
+
+	value, err := database.EncryptedString("user@example.com").Value()
+	require.NoError(t, err)
+	stored, ok := value.(string)
+	require.True(t, ok)
+	assert.True(t, fieldcrypt.IsEncrypted(stored))
+
+	var scanned database.EncryptedString
+	require.NoError(t, scanned.Scan([]byte(stored)))
+	assert.Equal(t, database.EncryptedString("user@example.com"), scanned)
+
+	// 历史明文直接读取
+	require.NoError(t, scanned.Scan("legacy@example.com"))
+	assert.Equal(t, database.EncryptedString("legacy@example.com"), scanned)
+
+	require.NoError(t, scanned.Scan(nil))
+	assert.Equal(t, database.EncryptedString(""), scanned)
+
+	assert.Equal(t, cipher.BlindIndex("user@example.com"), database.BlindIndex("user@example.com"))
+}
@@ -0,0 +1,571 @@
+package service
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/ix-pay/ixpay-pro/internal/config"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/entity"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/repo"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/service"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/security/oidc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// MockOIDCClientRepository OIDC 客户端仓库 Mock 实现
+type MockOIDCClientRepository struct {
+	clients  map[int64]*entity.OIDCClient
+	consents map[[2]int64]*entity.OIDCConsent
+	nextID   int64
+}
+
+func NewMockOIDCClientRepository() *MockOIDCClientRepository {
+	return &MockOIDCClientRepository{
+		clients:  make(map[int64]*entity.OIDCClient),
+		consents: make(map[[2]int64]*entity.OIDCConsent),
+	}
+}
+
+func (m *MockOIDCClientRepository) GetByID(id int64) (*entity.OIDCClient, error) {
+	if c, ok := m.clients[id]; ok {
+		return c, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (m *MockOIDCClientRepository) GetByClientID(clientID string) (*entity.OIDCClient, error) {
+	for _, c := range m.clients {
+		if c.ClientID == clientID {
+			return c, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (m *MockOIDCClientRepository) Create(client *entity.OIDCClient) error {
+	m.nextID++
+	client.ID = m.nextID
+	m.clients[client.ID] = client
+	return nil
+}
+
+func (m *MockOIDCClientRepository) Update(client *entity.OIDCClient) error {
+	existing := m.clients[client.ID]
+	client.SecretHash = existing.SecretHash
+	m.clients[client.ID] = client
+	return nil
+}
+
+func (m *MockOIDCClientRepository) UpdateSecret(id int64, secretHash string, operatorID int64) error {
+	m.clients[id].SecretHash = secretHash
+	return nil
+}
+
+func (m *MockOIDCClientRepository) Delete(id int64) error {
+	delete(m.clients, id)
+	return nil
+}
+
+func (m *MockOIDCClientRepository) List(page, pageSize int, filters map[string]interface{}) ([]*entity.OIDCClient, int64, error) {
+	var list []*entity.OIDCClient
+	for _, c := range m.clients {
+		list = append(list, c)
+	}
+	return list, int64(len(list)), nil
+}
+
+func (m *MockOIDCClientRepository) GetConsent(userID, clientID int64) (*entity.OIDCConsent, error) {
+	if c, ok := m.consents[[2]int64{userID, clientID}]; ok {
+		return c, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (m *MockOIDCClientRepository) SaveConsent(consent *entity.OIDCConsent) error {
+	m.consents[[2]int64{consent.UserID, consent.ClientID}] = consent
+	return nil
+}
+
+var _ repo.OIDCClientRepository = (*MockOIDCClientRepository)(nil)
+
+// MockDepartmentRepositoryForTest 部门仓库 Mock 实现，只支持按 ID 查询
+type MockDepartmentRepositoryForTest struct {
+	repo.DepartmentRepository
+	departments map[int64]*entity.Department
+}
+
+func (m *MockDepartmentRepositoryForTest) GetByID(id int64, relations ...repo.DepartmentRelation) (*entity.Department, error) {
+	if d, ok := m.departments[id]; ok {
+		return d, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+// idpTestEnv 身份提供方测试环境
+type idpTestEnv struct {
+	clients *MockOIDCClientRepository
+	signer  *oidc.Signer
+	service *service.IdentityProviderService
+
+	webSecret string // 机密客户端 web 的密钥
+}
+
+const (
+	idpTestIssuer      = "https://admin.example.com/api/oidc"
+	idpTestConsentURL  = "https://admin.example.com/oauth/consent"
+	idpTestWebCallback = "https://web.example.com/callback"
+	idpTestH5Callback  = "https://h5.example.com/callback"
+)
+
+func newIDPTestEnv(t *testing.T) *idpTestEnv {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	users := NewMockUserRepositoryForTest(
+		&entity.User{ID: 1, Username: "alice", Nickname: "Alice", Email: "alice@example.com", DepartmentID: 100, Status: 1},
+		&entity.User{ID: 2, Username: "bob", Status: 0},
+	)
+	roles := NewMockUserRoleRepository(
+		&entity.Role{ID: 10, Code: "ops", Name: "运维", Status: 1},
+		&entity.Role{ID: 11, Code: "legacy", Name: "已停用", Status: 0},
+	)
+	roles.userRoles[1] = []int64{10, 11}
+	departments := &MockDepartmentRepositoryForTest{departments: map[int64]*entity.Department{
+		100: {ID: 100, Name: "支付研发部", Status: 1},
+	}}
+
+	env := &idpTestEnv{
+		clients: NewMockOIDCClientRepository(),
+		signer:  oidc.NewSigner(key),
+	}
+	cfg := &config.Config{IdentityProvider: config.IdentityProviderConfig{
+		Enabled:    true,
+		Issuer:     idpTestIssuer + "/",
+		ConsentURL: idpTestConsentURL,
+	}}
+	env.service = service.NewIdentityProviderService(env.clients, users, roles, departments, env.signer, cfg, NewMockCache(), &MockLogger{})
+
+	env.webSecret, err = env.service.CreateClient(&entity.OIDCClient{
+		ClientID:     "web",
+		Name:         "运营后台",
+		RedirectURIs: []string{idpTestWebCallback},
+		GrantTypes:   []string{entity.OIDCGrantAuthorizationCode, entity.OIDCGrantRefreshToken, entity.OIDCGrantClientCredentials},
+		Scopes:       []string{entity.OIDCScopeProfile, entity.OIDCScopeEmail, entity.OIDCScopeRoles, entity.OIDCScopeDepartment},
+		Status:       entity.OIDCClientStatusEnabled,
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, env.webSecret)
+
+	secret, err := env.service.CreateClient(&entity.OIDCClient{
+		ClientID:     "h5app",
+		Name:         "H5 应用",
+		Public:       true,
+		SkipConsent:  true,
+		RedirectURIs: []string{idpTestH5Callback},
+		GrantTypes:   []string{entity.OIDCGrantAuthorizationCode},
+		Status:       entity.OIDCClientStatusEnabled,
+	})
+	require.NoError(t, err)
+	assert.Empty(t, secret)
+	return env
+}
+
+// webAuthorizeRequest 构造 web 客户端的授权请求
+func webAuthorizeRequest(verifier string) *service.AuthorizeRequest {
+	return &service.AuthorizeRequest{
+		ResponseType:        "code",
+		ClientID:            "web",
+		RedirectURI:         idpTestWebCallback,
+		Scope:               "openid profile roles department",
+		State:               "xyz",
+		Nonce:               "n-0S6",
+		CodeChallenge:       oidc.CodeChallengeS256(verifier),
+		CodeChallengeMethod: "S256",
+	}
+}
+
+// authorizeCode 用户同意授权并返回授权码
+func (env *idpTestEnv) authorizeCode(t *testing.T, userID int64, req *service.AuthorizeRequest) string {
+	redirect, err := env.service.Consent(userID, req, true)
+	require.NoError(t, err)
+	u, err := url.Parse(redirect)
+	require.NoError(t, err)
+	require.Empty(t, u.Query().Get("error"), u.Query().Get("error_description"))
+	return u.Query().Get("code")
+}
+
+// parseIDToken 使用 JWKS 中的公钥校验并解析 ID Token
+func (env *idpTestEnv) parseIDToken(t *testing.T, raw string) jwt.MapClaims {
+	publicKey, err := env.signer.JWKS().Keys[0].PublicKey()
+	require.NoError(t, err)
+	claims := jwt.MapClaims{}
+	_, err = jwt.ParseWithClaims(raw, claims, func(token *jwt.Token) (interface{}, error) {
+		assert.Equal(t, env.signer.KeyID(), token.Header["kid"])
+		return publicKey, nil
+	}, jwt.WithValidMethods([]string{"RS256"}), jwt.WithIssuer(idpTestIssuer), jwt.WithAudience("web"))
+	require.NoError(t, err)
+	return claims
+}
+
+// assertOAuthError 断言返回指定协议错误码
+func assertOAuthError(t *testing.T, err error, code string) {
+	var oauthErr *service.OAuthError
+	require.True(t, errors.As(err, &oauthErr), "期望协议错误，实际为：%v", err)
+	assert.Equal(t, code, oauthErr.Code)
+}
+
+// TestIdentityProviderService_AuthorizationCodeFlow 测试授权码完整流程
+func TestIdentityProviderService_AuthorizationCodeFlow(t *testing.T) {
+	env := newIDPTestEnv(t)
+	verifier, err := oidc.RandomString(32)
+	require.NoError(t, err)
+	req := webAuthorizeRequest(verifier)
+
+	// 授权端点跳转授权确认页，原样携带授权参数
+	target, err := env.service.Authorize(req)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(target, idpTestConsentURL+"?"))
+	consentURL, _ := url.Parse(target)
+	assert.Equal(t, "web", consentURL.Query().Get("client_id"))
+	assert.Equal(t, req.CodeChallenge, consentURL.Query().Get("code_challenge"))
+
+	info, err := env.service.GetConsentInfo(1, req)
+	require.NoError(t, err)
+	assert.True(t, info.ConsentRequired)
+	assert.Equal(t, []string{"openid", "profile", "roles", "department"}, info.Scopes)
+
+	redirect, err := env.service.Consent(1, req, true)
+	require.NoError(t, err)
+	callback, _ := url.Parse(redirect)
+	assert.Equal(t, "xyz", callback.Query().Get("state"))
+	assert.Equal(t, idpTestIssuer, callback.Query().Get("iss"))
+	code := callback.Query().Get("code")
+	require.NotEmpty(t, code)
+
+	// 已授权的范围再次申请不需要确认
+	info, err = env.service.GetConsentInfo(1, req)
+	require.NoError(t, err)
+	assert.False(t, info.ConsentRequired)
+
+	token, err := env.service.Token(&service.TokenRequest{
+		GrantType:    entity.OIDCGrantAuthorizationCode,
+		Code:         code,
+		RedirectURI:  idpTestWebCallback,
+		CodeVerifier: verifier,
+		ClientID:     "web",
+		ClientSecret: env.webSecret,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer", token.TokenType)
+	assert.NotEmpty(t, token.RefreshToken)
+	assert.Equal(t, "openid profile roles department", token.Scope)
+
+	claims := env.parseIDToken(t, token.IDToken)
+	assert.Equal(t, "1", claims["sub"])
+	assert.Equal(t, "n-0S6", claims["nonce"])
+	assert.Equal(t, "web", claims["azp"])
+	assert.Equal(t, oidc.AccessTokenHash(token.AccessToken), claims["at_hash"])
+	assert.Equal(t, "alice", claims["preferred_username"])
+	assert.Equal(t, []interface{}{"ops"}, claims["roles"])
+	assert.Equal(t, "100", claims["department_id"])
+	assert.Equal(t, "支付研发部", claims["department_name"])
+	assert.NotContains(t, claims, "email")
+
+	userInfo, err := env.service.UserInfo(token.AccessToken)
+	require.NoError(t, err)
+	assert.Equal(t, "1", userInfo["sub"])
+	assert.Equal(t, []string{"ops"}, userInfo["roles"])
+
+	// 授权码只能使用一次
+	_, err = env.service.Token(&service.TokenRequest{
+		GrantType:    entity.OIDCGrantAuthorizationCode,
+		Code:         code,
+		RedirectURI:  idpTestWebCallback,
+		CodeVerifier: verifier,
+		ClientID:     "web",
+		ClientSecret: env.webSecret,
+	})
+	assertOAuthError(t, err, "invalid_grant")
+
+	// ID Token 不能当作访问令牌使用
+	_, err = env.service.UserInfo(token.IDToken)
+	assertOAuthError(t, err, "invalid_token")
+}
+
+// TestIdentityProviderService_AuthorizeErrors 测试授权请求校验
+func TestIdentityProviderService_AuthorizeErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		modify    func(req *service.AuthorizeRequest)
+		wantErr   string // 不能跳转回客户端的错误
+		wantOAuth string // 跳转回客户端的错误码
+	}{
+		{name: "客户端不存在", modify: func(req *service.AuthorizeRequest) { req.ClientID = "unknown" }, wantErr: "客户端不存在或已被禁用"},
+		{name: "回调地址未登记", modify: func(req *service.AuthorizeRequest) { req.RedirectURI = "https://evil.example.com/cb" }, wantErr: "回调地址未登记"},
+		{name: "不支持的响应类型", modify: func(req *service.AuthorizeRequest) { req.ResponseType = "token" }, wantOAuth: "unsupported_response_type"},
+		{name: "未开通的授权范围", modify: func(req *service.AuthorizeRequest) { req.Scope = "openid phone" }, wantOAuth: "invalid_scope"},
+		{name: "缺少 openid", modify: func(req *service.AuthorizeRequest) { req.Scope = "profile" }, wantOAuth: "invalid_scope"},
+		{name: "不支持 plain PKCE", modify: func(req *service.AuthorizeRequest) { req.CodeChallengeMethod = "plain" }, wantOAuth: "invalid_request"},
+		{name: "公开客户端必须使用 PKCE", modify: func(req *service.AuthorizeRequest) {
+			req.ClientID = "h5app"
+			req.RedirectURI = idpTestH5Callback
+			req.Scope = "openid"
+			req.CodeChallenge = ""
+		}, wantOAuth: "invalid_request"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := newIDPTestEnv(t)
+			req := webAuthorizeRequest("verifier")
+			tt.modify(req)
+
+			target, err := env.service.Authorize(req)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			u, _ := url.Parse(target)
+			assert.Equal(t, req.RedirectURI, u.Scheme+"://"+u.Host+u.Path)
+			assert.Equal(t, tt.wantOAuth, u.Query().Get("error"))
+			assert.Equal(t, "xyz", u.Query().Get("state"))
+		})
+	}
+
+	t.Run("用户拒绝授权", func(t *testing.T) {
+		env := newIDPTestEnv(t)
+		redirect, err := env.service.Consent(1, webAuthorizeRequest("verifier"), false)
+		require.NoError(t, err)
+		u, _ := url.Parse(redirect)
+		assert.Equal(t, "access_denied", u.Query().Get("error"))
+		assert.Empty(t, u.Query().Get("code"))
+	})
+
+	t.Run("禁用用户不能授权", func(t *testing.T) {
+		env := newIDPTestEnv(t)
+		_, err := env.service.Consent(2, webAuthorizeRequest("verifier"), true)
+		assert.EqualError(t, err, "用户不存在或已被禁用")
+	})
+}
+
+// TestIdentityProviderService_TokenErrors 测试令牌端点的客户端认证和授权码校验
+func TestIdentityProviderService_TokenErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		modify   func(env *idpTestEnv, req *service.TokenRequest)
+		wantCode string
+	}{
+		{name: "密钥错误", modify: func(env *idpTestEnv, req *service.TokenRequest) { req.ClientSecret = "wrong" }, wantCode: "invalid_client"},
+		{name: "缺少密钥", modify: func(env *idpTestEnv, req *service.TokenRequest) { req.ClientSecret = "" }, wantCode: "invalid_client"},
+		{name: "PKCE 校验失败", modify: func(env *idpTestEnv, req *service.TokenRequest) { req.CodeVerifier = "other" }, wantCode: "invalid_grant"},
+		{name: "回调地址不匹配", modify: func(env *idpTestEnv, req *service.TokenRequest) { req.RedirectURI = idpTestH5Callback }, wantCode: "invalid_grant"},
+		{name: "不支持的授权类型", modify: func(env *idpTestEnv, req *service.TokenRequest) { req.GrantType = "password" }, wantCode: "unsupported_grant_type"},
+		{name: "授权码属于其他客户端", modify: func(env *idpTestEnv, req *service.TokenRequest) {
+			req.ClientID = "h5app"
+			req.ClientSecret = ""
+		}, wantCode: "invalid_grant"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := newIDPTestEnv(t)
+			code := env.authorizeCode(t, 1, webAuthorizeRequest("verifier"))
+			req := &service.TokenRequest{
+				GrantType:    entity.OIDCGrantAuthorizationCode,
+				Code:         code,
+				RedirectURI:  idpTestWebCallback,
+				CodeVerifier: "verifier",
+				ClientID:     "web",
+				ClientSecret: env.webSecret,
+			}
+			tt.modify(env, req)
+
+			_, err := env.service.Token(req)
+			assertOAuthError(t, err, tt.wantCode)
+		})
+	}
+}
+
+// TestIdentityProviderService_PublicClient 测试公开客户端使用 PKCE 且跳过授权确认
+func TestIdentityProviderService_PublicClient(t *testing.T) {
+	env := newIDPTestEnv(t)
+	req := &service.AuthorizeRequest{
+		ResponseType:        "code",
+		ClientID:            "h5app",
+		RedirectURI:         idpTestH5Callback,
+		Scope:               "openid",
+		CodeChallenge:       oidc.CodeChallengeS256("h5-verifier"),
+		CodeChallengeMethod: "S256",
+	}
+
+	info, err := env.service.GetConsentInfo(1, req)
+	require.NoError(t, err)
+	assert.False(t, info.ConsentRequired)
+
+	code := env.authorizeCode(t, 1, req)
+	assert.Empty(t, env.clients.consents, "跳过授权确认的客户端不记录授权")
+
+	token, err := env.service.Token(&service.TokenRequest{
+		GrantType:    entity.OIDCGrantAuthorizationCode,
+		Code:         code,
+		RedirectURI:  idpTestH5Callback,
+		CodeVerifier: "h5-verifier",
+		ClientID:     "h5app",
+	})
+	require.NoError(t, err)
+	assert.Empty(t, token.RefreshToken, "未开通刷新令牌的客户端不签发刷新令牌")
+	assert.NotEmpty(t, token.IDToken)
+}
+
+// TestIdentityProviderService_RefreshToken 测试刷新令牌轮换
+func TestIdentityProviderService_RefreshToken(t *testing.T) {
+	env := newIDPTestEnv(t)
+	code := env.authorizeCode(t, 1, webAuthorizeRequest("verifier"))
+	token, err := env.service.Token(&service.TokenRequest{
+		GrantType:    entity.OIDCGrantAuthorizationCode,
+		Code:         code,
+		RedirectURI:  idpTestWebCallback,
+		CodeVerifier: "verifier",
+		ClientID:     "web",
+		ClientSecret: env.webSecret,
+	})
+	require.NoError(t, err)
+
+	refreshReq := func(refreshToken, scope string) *service.TokenRequest {
+		return &service.TokenRequest{
+			GrantType:    entity.OIDCGrantRefreshToken,
+			RefreshToken: refreshToken,
+			Scope:        scope,
+			ClientID:     "web",
+			ClientSecret: env.webSecret,
+		}
+	}
+
+	// 不能扩大授权范围，失败后刷新令牌同样失效
+	_, err = env.service.Token(refreshReq(token.RefreshToken, "openid email"))
+	assertOAuthError(t, err, "invalid_scope")
+	_, err = env.service.Token(refreshReq(token.RefreshToken, ""))
+	assertOAuthError(t, err, "invalid_grant")
+
+	code = env.authorizeCode(t, 1, webAuthorizeRequest("verifier"))
+	token, err = env.service.Token(&service.TokenRequest{
+		GrantType:    entity.OIDCGrantAuthorizationCode,
+		Code:         code,
+		RedirectURI:  idpTestWebCallback,
+		CodeVerifier: "verifier",
+		ClientID:     "web",
+		ClientSecret: env.webSecret,
+	})
+	require.NoError(t, err)
+
+	refreshed, err := env.service.Token(refreshReq(token.RefreshToken, "openid roles"))
+	require.NoError(t, err)
+	assert.NotEqual(t, token.RefreshToken, refreshed.RefreshToken)
+	assert.Equal(t, "openid roles", refreshed.Scope)
+	claims := env.parseIDToken(t, refreshed.IDToken)
+	assert.NotContains(t, claims, "nonce")
+	assert.NotContains(t, claims, "department_name")
+
+	// 旧刷新令牌已轮换失效
+	_, err = env.service.Token(refreshReq(token.RefreshToken, ""))
+	assertOAuthError(t, err, "invalid_grant")
+}
+
+// TestIdentityProviderService_ClientCredentials 测试客户端凭证模式
+func TestIdentityProviderService_ClientCredentials(t *testing.T) {
+	env := newIDPTestEnv(t)
+
+	token, err := env.service.Token(&service.TokenRequest{
+		GrantType:    entity.OIDCGrantClientCredentials,
+		Scope:        "roles",
+		ClientID:     "web",
+		ClientSecret: env.webSecret,
+	})
+	require.NoError(t, err)
+	assert.Empty(t, token.IDToken)
+	assert.Empty(t, token.RefreshToken)
+
+	// 客户端令牌不代表用户，不能访问用户信息端点
+	_, err = env.service.UserInfo(token.AccessToken)
+	assertOAuthError(t, err, "insufficient_scope")
+
+	_, err = env.service.Token(&service.TokenRequest{
+		GrantType:    entity.OIDCGrantClientCredentials,
+		Scope:        "openid",
+		ClientID:     "web",
+		ClientSecret: env.webSecret,
+	})
+	assertOAuthError(t, err, "invalid_scope")
+
+	_, err = env.service.Token(&service.TokenRequest{
+		GrantType: entity.OIDCGrantClientCredentials,
+		ClientID:  "h5app",
+	})
+	assertOAuthError(t, err, "unauthorized_client")
+}
+
+// TestIdentityProviderService_ClientManagement 测试客户端登记校验和密钥重置
+func TestIdentityProviderService_ClientManagement(t *testing.T) {
+	tests := []struct {
+		name    string
+		client  *entity.OIDCClient
+		wantErr string
+	}{
+		{name: "客户端标识格式错误", client: &entity.OIDCClient{ClientID: "a", Name: "x", RedirectURIs: []string{idpTestWebCallback}}, wantErr: "客户端标识只能包含字母、数字、下划线、点和中划线，长度 2-64"},
+		{name: "客户端标识重复", client: &entity.OIDCClient{ClientID: "web", Name: "x", RedirectURIs: []string{idpTestWebCallback}}, wantErr: "客户端标识已存在"},
+		{name: "缺少回调地址", client: &entity.OIDCClient{ClientID: "ops", Name: "x"}, wantErr: "授权码模式至少需要登记一个回调地址"},
+		{name: "回调地址必须 https", client: &entity.OIDCClient{ClientID: "ops", Name: "x", RedirectURIs: []string{"http://ops.example.com/cb"}}, wantErr: "回调地址必须使用 https：http://ops.example.com/cb"},
+		{name: "回调地址不能包含片段", client: &entity.OIDCClient{ClientID: "ops", Name: "x", RedirectURIs: []string{"https://ops.example.com/cb#x"}}, wantErr: "回调地址不能包含片段：https://ops.example.com/cb#x"},
+		{name: "公开客户端不能使用客户端凭证", client: &entity.OIDCClient{ClientID: "ops", Name: "x", Public: true, GrantTypes: []string{entity.OIDCGrantClientCredentials}}, wantErr: "公开客户端不能使用客户端凭证授权"},
+		{name: "不支持的授权范围", client: &entity.OIDCClient{ClientID: "ops", Name: "x", RedirectURIs: []string{idpTestWebCallback}, Scopes: []string{"admin"}}, wantErr: "不支持的授权范围：admin"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := newIDPTestEnv(t)
+			_, err := env.service.CreateClient(tt.client)
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+
+	t.Run("重置密钥后旧密钥失效", func(t *testing.T) {
+		env := newIDPTestEnv(t)
+		web, err := env.clients.GetByClientID("web")
+		require.NoError(t, err)
+
+		secret, err := env.service.ResetClientSecret(web.ID, 1)
+		require.NoError(t, err)
+		assert.NotEqual(t, env.webSecret, secret)
+
+		_, err = env.service.Token(&service.TokenRequest{GrantType: entity.OIDCGrantClientCredentials, ClientID: "web", ClientSecret: env.webSecret})
+		assertOAuthError(t, err, "invalid_client")
+		_, err = env.service.Token(&service.TokenRequest{GrantType: entity.OIDCGrantClientCredentials, ClientID: "web", ClientSecret: secret})
+		require.NoError(t, err)
+
+		h5, err := env.clients.GetByClientID("h5app")
+		require.NoError(t, err)
+		_, err = env.service.ResetClientSecret(h5.ID, 1)
+		assert.EqualError(t, err, "公开客户端没有密钥")
+	})
+}
+
+// TestIdentityProviderService_Disabled 测试未开启身份提供方
+func TestIdentityProviderService_Disabled(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	svc := service.NewIdentityProviderService(NewMockOIDCClientRepository(), NewMockUserRepositoryForTest(), NewMockUserRoleRepository(), &MockDepartmentRepositoryForTest{}, oidc.NewSigner(key), &config.Config{}, NewMockCache(), &MockLogger{})
+
+	assert.False(t, svc.Enabled())
+	_, err = svc.Authorize(webAuthorizeRequest("verifier"))
+	assert.EqualError(t, err, "身份提供方未开启")
+	_, err = svc.Token(&service.TokenRequest{GrantType: entity.OIDCGrantClientCredentials, ClientID: "web"})
+	assertOAuthError(t, err, "invalid_request")
+}
@@ -0,0 +1,251 @@
+package service
+
+import (
+	"context"
+	"encoding/binary"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/entity"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/service"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/support/geoip"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// xdbSegment ip2region 测试数据段，起止 IP 须在同一个 /16 内
+type xdbSegment struct {
+	start, end string
+	region     string
+}
+
+// buildXDB 生成 ip2region xdb 测试文件内容
+func buildXDB(segments []xdbSegment) []byte {
+	const vectorEnd = 256 + 256*256*8
+	data := make([]byte, vectorEnd)
+
+	regionPtrs := make([]int, len(segments))
+	for i, segment := range segments {
+		regionPtrs[i] = len(data)
+		data = append(data, segment.region...)
+	}
+	for i, segment := range segments {
+		start, end := netip.MustParseAddr(segment.start).As4(), netip.MustParseAddr(segment.end).As4()
+		ptr := len(data)
+		index := make([]byte, 14)
+		binary.LittleEndian.PutUint32(index[0:], binary.BigEndian.Uint32(start[:]))
+		binary.LittleEndian.PutUint32(index[4:], binary.BigEndian.Uint32(end[:]))
+		binary.LittleEndian.PutUint16(index[8:], uint16(len(segment.region)))
+		binary.LittleEndian.PutUint32(index[10:], uint32(regionPtrs[i]))
+		data = append(data, index...)
+
+		vector := 256 + (int(start[0])*256+int(start[1]))*8
+		if binary.LittleEndian.Uint32(data[vector:]) == 0 {
+			binary.LittleEndian.PutUint32(data[vector:], uint32(ptr))
+		}
+		binary.LittleEndian.PutUint32(data[vector+4:], uint32(ptr))
+	}
+	return data
+}
+
+// mmdb 测试数据编码
+func mmdbString(s string) []byte { return append([]byte{2<<5 | byte(len(s))}, s...) }
+func mmdbMap(size int) []byte    { return []byte{7<<5 | byte(size)} }
+func mmdbArray(size int) []byte  { return []byte{byte(size), 11 - 7} }
+func mmdbUint16(v uint16) []byte { return []byte{5<<5 | 2, byte(v >> 8), byte(v)} }
+func mmdbUint32(v uint32) []byte {
+	return []byte{6<<5 | 4, byte(v >> 24), byte(v >> 16), byte(v >> 8), byte(v)}
+}
+
+// mmdbNames 编码 {"names": {...}}
+func mmdbNames(names map[string]string) []byte {
+	out := append(mmdbMap(1), mmdbString("names")...)
+	out = append(out, mmdbMap(len(names))...)
+	for lang, name := range names {
+		out = append(append(out, mmdbString(lang)...), mmdbString(name)...)
+	}
+	return out
+}
+
+// buildMMDB 生成只包含 1.0.0.0/8 一条记录的 IPv4 MaxMind DB 测试文件内容
+func buildMMDB() []byte {
+	const nodeCount = 8
+	tree := make([]byte, 0, nodeCount*6)
+	record := func(v uint32) []byte { return []byte{byte(v >> 16), byte(v >> 8), byte(v)} }
+	for i := 0; i < nodeCount; i++ {
+		// 1.0.0.0/8 前 7 位为 0、第 8 位为 1
+		if i < nodeCount-1 {
+			tree = append(append(tree, record(uint32(i+1))...), record(nodeCount)...)
+		} else {
+			tree = append(append(tree, record(nodeCount)...), record(nodeCount+16)...)
+		}
+	}
+
+	data := mmdbMap(4)
+	data = append(append(data, mmdbString("country")...), mmdbNames(map[string]string{"en": "China", "zh-CN": "中国"})...)
+	data = append(append(data, mmdbString("subdivisions")...), mmdbArray(1)...)
+	data = append(data, mmdbNames(map[string]string{"en": "Guangdong"})...)
+	data = append(append(data, mmdbString("city")...), mmdbNames(map[string]string{"zh-CN": "深圳"})...)
+	data = append(append(data, mmdbString("isp")...), mmdbString("电信")...)
+
+	metadata := mmdbMap(4)
+	metadata = append(append(metadata, mmdbString("node_count")...), mmdbUint32(nodeCount)...)
+	metadata = append(append(metadata, mmdbString("record_size")...), mmdbUint16(24)...)
+	metadata = append(append(metadata, mmdbString("ip_version")...), mmdbUint16(4)...)
+	metadata = append(append(metadata, mmdbString("database_type")...), mmdbString("GeoIP2-City")...)
+
+	file := append(tree, make([]byte, 16)...)
+	file = append(file, data...)
+	file = append(file, "\xab\xcd\xefMaxMind.com"...)
+	return append(file, metadata...)
+}
+
+// writeGeoDB 写入地址库文件
+func writeGeoDB(t *testing.T, path string, data []byte) {
+	require.NoError(t, os.WriteFile(path, data, 0o644))
+}
+
+// TestLocator_PrivateAddresses 测试本地和内网地址识别，未配置地址库时公网地址为未知
+func TestLocator_PrivateAddresses(t *testing.T) {
+	var locator *geoip.Locator
+	cases := map[string]string{
+		"127.0.0.1":          geoip.PlaceLocal,
+		"::1":                geoip.PlaceLocal,
+		"localhost":          geoip.PlaceLocal,
+		"10.1.2.3":           geoip.PlacePrivate,
+		"172.20.0.1":         geoip.PlacePrivate,
+		"172.32.0.1":         geoip.PlaceUnknown,
+		"192.168.1.1":        geoip.PlacePrivate,
+		"100.64.0.1":         geoip.PlacePrivate,
+		"fd00::1":            geoip.PlacePrivate,
+		"fe80::1%eth0":       geoip.PlacePrivate,
+		"::ffff:192.168.1.1": geoip.PlacePrivate,
+		"2001:4860::8888":    geoip.PlaceUnknown,
+		"not-an-ip":          geoip.PlaceUnknown,
+	}
+	for ip, want := range cases {
+		assert.Equal(t, want, locator.Place(ip), ip)
+	}
+}
+
+// TestLocator_IP2RegionHotReload 测试 ip2region 地址库查询和文件变更后重新加载
+func TestLocator_IP2RegionHotReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ip2region.xdb")
+	writeGeoDB(t, path, buildXDB([]xdbSegment{
+		{"1.0.0.0", "1.0.0.255", "中国|0|广东省|深圳市|电信"},
+		{"1.0.1.0", "1.0.1.255", "中国|0|福建省|0|0"},
+	}))
+
+	locator, err := geoip.NewLocator(path, "", "", 0, 10*time.Millisecond, &MockLogger{})
+	require.NoError(t, err)
+	loc, found := locator.Lookup("1.0.0.8")
+	require.True(t, found)
+	assert.Equal(t, geoip.Location{Country: "中国", Province: "广东省", City: "深圳市", ISP: "电信"}, loc)
+	assert.Equal(t, "中国 福建省", locator.Place("1.0.1.1"))
+	assert.Equal(t, geoip.PlaceUnknown, locator.Place("1.0.2.1"))
+	assert.Equal(t, geoip.PlaceUnknown, locator.Place("2001:4860::8888"))
+
+	// 替换文件后按新地址库查询，新版数据为四个字段
+	writeGeoDB(t, path, buildXDB([]xdbSegment{{"1.0.0.0", "1.0.0.255", "中国|上海|上海|联通"}}))
+	future := time.Now().Add(time.Hour)
+	require.NoError(t, os.Chtimes(path, future, future))
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, "中国 上海 联通", locator.Place("1.0.0.8"))
+
+	// 文件损坏时继续使用已加载的地址库
+	writeGeoDB(t, path, []byte("broken"))
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, "中国 上海 联通", locator.Place("1.0.0.8"))
+
+	_, err = geoip.NewLocator(path, "", "", 0, 0, &MockLogger{})
+	assert.ErrorIs(t, err, geoip.ErrInvalidDatabase)
+	_, err = geoip.NewLocator(path, "qqwry", "", 0, 0, &MockLogger{})
+	assert.Error(t, err)
+}
+
+// TestLocator_MaxMind 测试 MaxMind DB 地址库查询和地名语言回退
+func TestLocator_MaxMind(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "GeoLite2-City.mmdb")
+	writeGeoDB(t, path, buildMMDB())
+
+	locator, err := geoip.NewLocator(path, "", "", 0, 0, &MockLogger{})
+	require.NoError(t, err)
+	assert.Equal(t, "中国 Guangdong 深圳 电信", locator.Place("1.2.3.4"))
+	assert.Equal(t, geoip.PlaceUnknown, locator.Place("2.2.3.4"))
+	assert.Equal(t, geoip.PlaceUnknown, locator.Place("2001:4860::8888"))
+
+	english, err := geoip.NewLocator(path, geoip.FormatMMDB, "en", 0, 0, &MockLogger{})
+	require.NoError(t, err)
+	loc, found := english.Lookup("::ffff:1.2.3.4")
+	require.True(t, found)
+	assert.Equal(t, "China", loc.Country)
+	assert.Equal(t, "Guangdong", loc.Province)
+	// 缺少该语言和英文地名时为空
+	assert.Empty(t, loc.City)
+}
+
+// MockPlaceBackfillRepository 登录日志仓库 Mock 实现，支持登录地点回填
+type MockPlaceBackfillRepository struct {
+	MockLoginLogRepositoryForTest
+	rows []*entity.LoginLog
+}
+
+func (m *MockPlaceBackfillRepository) ListByPlaces(places []string, afterID int64, limit int) ([]*entity.LoginLog, error) {
+	var result []*entity.LoginLog
+	for _, row := range m.rows {
+		for _, place := range places {
+			if row.ID > afterID && row.LoginPlace == place && len(result) < limit {
+				copied := *row
+				result = append(result, &copied)
+				break
+			}
+		}
+	}
+	return result, nil
+}
+
+func (m *MockPlaceBackfillRepository) UpdatePlace(id int64, oldPlace, place string) (bool, error) {
+	for _, row := range m.rows {
+		if row.ID == id && row.LoginPlace == oldPlace {
+			row.LoginPlace = place
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// TestLoginLogService_BackfillPlaces 测试历史登录日志回填登录地点
+func TestLoginLogService_BackfillPlaces(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ip2region.xdb")
+	writeGeoDB(t, path, buildXDB([]xdbSegment{{"1.0.0.0", "1.0.0.255", "中国|0|广东省|深圳市|电信"}}))
+	locator, err := geoip.NewLocator(path, "", "", 0, 0, &MockLogger{})
+	require.NoError(t, err)
+
+	repo := &MockPlaceBackfillRepository{rows: []*entity.LoginLog{
+		{ID: 1, LoginIP: "1.0.0.1", LoginPlace: "中国"},
+		{ID: 2, LoginIP: "1.0.0.2", LoginPlace: geoip.PlaceUnknown},
+		{ID: 3, LoginIP: "10.0.0.1", LoginPlace: "中国"},
+		{ID: 4, LoginIP: "8.8.8.8", LoginPlace: "中国"},
+		{ID: 5, LoginIP: "1.0.0.3", LoginPlace: "美国"},
+	}}
+	svc := service.NewLoginLogService(repo, locator, &MockLogger{})
+	assert.Equal(t, "中国 广东省 深圳市 电信", svc.LoginPlace("1.0.0.9"))
+
+	count, err := svc.BackfillPlaces(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+	assert.Equal(t, "中国 广东省 深圳市 电信", repo.rows[0].LoginPlace)
+	assert.Equal(t, "中国 广东省 深圳市 电信", repo.rows[1].LoginPlace)
+	assert.Equal(t, geoip.PlacePrivate, repo.rows[2].LoginPlace)
+	// 地址库中没有的 IP 和已有真实地点的记录保持不变
+	assert.Equal(t, "中国", repo.rows[3].LoginPlace)
+	assert.Equal(t, "美国", repo.rows[4].LoginPlace)
+
+	// 未配置地址库时不回填
+	count, err = service.NewLoginLogService(repo, nil, &MockLogger{}).BackfillPlaces(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, count)
+}
@@ -0,0 +1,262 @@
+package service
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/entity"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// MockIPPolicyRepository IP 访问策略仓库 Mock 实现
+type MockIPPolicyRepository struct {
+	mu       sync.Mutex
+	policies map[int64]*entity.IPPolicy
+	nextID   int64
+	listHits int // ListEnabled 调用次数，用于验证缓存
+}
+
+func NewMockIPPolicyRepository() *MockIPPolicyRepository {
+	return &MockIPPolicyRepository{policies: make(map[int64]*entity.IPPolicy)}
+}
+
+func (m *MockIPPolicyRepository) GetByID(id int64) (*entity.IPPolicy, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if p, ok := m.policies[id]; ok {
+		copied := *p
+		return &copied, nil
+	}
+	return nil, errors.New("record not found")
+}
+
+func (m *MockIPPolicyRepository) GetByName(name string) (*entity.IPPolicy, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, p := range m.policies {
+		if p.Name == name {
+			copied := *p
+			return &copied, nil
+		}
+	}
+	return nil, errors.New("record not found")
+}
+
+func (m *MockIPPolicyRepository) Create(policy *entity.IPPolicy) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.nextID++
+	policy.ID = m.nextID
+	policy.CreatedAt = time.Now()
+	copied := *policy
+	m.policies[policy.ID] = &copied
+	return nil
+}
+
+func (m *MockIPPolicyRepository) Update(policy *entity.IPPolicy) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	copied := *policy
+	m.policies[policy.ID] = &copied
+	return nil
+}
+
+func (m *MockIPPolicyRepository) Delete(id int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.policies, id)
+	return nil
+}
+
+func (m *MockIPPolicyRepository) List(page, pageSize int, filters map[string]interface{}) ([]*entity.IPPolicy, int64, error) {
+	policies, _ := m.ListEnabled()
+	return policies, int64(len(policies)), nil
+}
+
+func (m *MockIPPolicyRepository) ListEnabled() ([]*entity.IPPolicy, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.listHits++
+	var policies []*entity.IPPolicy
+	for _, p := range m.policies {
+		if p.IsActive() {
+			copied := *p
+			policies = append(policies, &copied)
+		}
+	}
+	return policies, nil
+}
+
+// MockFailedLoginRepository 返回固定失败登录记录的登录日志仓库
+type MockFailedLoginRepository struct {
+	MockLoginLogRepositoryForTest
+	failed []*entity.LoginLog
+}
+
+func (m *MockFailedLoginRepository) List(page, pageSize int, filters map[string]interface{}) ([]*entity.LoginLog, int64, error) {
+	return m.failed, int64(len(m.failed)), nil
+}
+
+// newIPPolicyTestService 创建 IP 访问策略服务，角色 1 为 finance，用户 1 存在
+func newIPPolicyTestService(failed ...*entity.LoginLog) (*service.IPPolicyService, *MockIPPolicyRepository, *MockFailedLoginRepository) {
+	policyRepo := NewMockIPPolicyRepository()
+	roleRepo := NewMockRoleRepositoryForTest(&entity.Role{ID: 1, Code: "finance", Name: "财务"})
+	userRepo := NewMockUserRepositoryForTest(&entity.User{ID: 1, Username: "alice"})
+	loginLogRepo := &MockFailedLoginRepository{failed: failed}
+	log := &MockLogger{}
+	loginLogService := service.NewLoginLogService(loginLogRepo, nil, log)
+	svc := service.NewIPPolicyService(policyRepo, roleRepo, userRepo, loginLogService, NewMockCache(), log)
+	return svc, policyRepo, loginLogRepo
+}
+
+// TestIPPolicyService_Validate 测试策略校验和 CIDR 规范化
+func TestIPPolicyService_Validate(t *testing.T) {
+	testCases := []struct {
+		name   string
+		policy entity.IPPolicy
+		errMsg string
+	}{
+		{"名称为空", entity.IPPolicy{Scope: entity.IPPolicyScopeGlobal, Action: entity.IPPolicyActionAllow, CIDRs: []string{"10.0.0.0/8"}}, "策略名称不能为空"},
+		{"动作无效", entity.IPPolicy{Name: "a", Scope: entity.IPPolicyScopeGlobal, Action: "block", CIDRs: []string{"10.0.0.0/8"}}, "策略动作无效，仅支持 allow 或 deny"},
+		{"范围无效", entity.IPPolicy{Name: "a", Scope: "dept", Action: entity.IPPolicyActionAllow, CIDRs: []string{"10.0.0.0/8"}}, "策略作用范围无效，仅支持 global、role 或 user"},
+		{"角色不存在", entity.IPPolicy{Name: "a", Scope: entity.IPPolicyScopeRole, TargetID: 99, Action: entity.IPPolicyActionAllow, CIDRs: []string{"10.0.0.0/8"}}, "策略关联的角色不存在"},
+		{"用户不存在", entity.IPPolicy{Name: "a", Scope: entity.IPPolicyScopeUser, TargetID: 99, Action: entity.IPPolicyActionAllow, CIDRs: []string{"10.0.0.0/8"}}, "策略关联的用户不存在"},
+		{"规则为空", entity.IPPolicy{Name: "a", Scope: entity.IPPolicyScopeGlobal, Action: entity.IPPolicyActionAllow}, "IP 规则不能为空"},
+		{"规则格式无效", entity.IPPolicy{Name: "a", Scope: entity.IPPolicyScopeGlobal, Action: entity.IPPolicyActionAllow, CIDRs: []string{"10.0.0.300"}}, "IP 规则格式无效：10.0.0.300"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc, _, _ := newIPPolicyTestService()
+			policy := tc.policy
+			assert.EqualError(t, svc.CreateIPPolicy(&policy), tc.errMsg)
+		})
+	}
+
+	t.Run("单个 IP 转换为 CIDR 并去重", func(t *testing.T) {
+		svc, _, _ := newIPPolicyTestService()
+		policy := &entity.IPPolicy{
+			Name:     "办公网",
+			Scope:    entity.IPPolicyScopeGlobal,
+			TargetID: 5,
+			Action:   entity.IPPolicyActionAllow,
+			CIDRs:    []string{"10.1.2.3", "10.1.2.3/32", "192.168.1.77/24", "2001:db8::1"},
+			Status:   entity.IPPolicyStatusEnabled,
+		}
+		require.NoError(t, svc.CreateIPPolicy(policy))
+		assert.Equal(t, []string{"10.1.2.3/32", "192.168.1.0/24", "2001:db8::1/128"}, policy.CIDRs)
+		assert.Equal(t, int64(0), policy.TargetID, "全局策略不关联对象")
+
+		duplicate := &entity.IPPolicy{Name: "办公网", Scope: entity.IPPolicyScopeGlobal, Action: entity.IPPolicyActionAllow, CIDRs: []string{"10.0.0.0/8"}}
+		assert.EqualError(t, svc.CreateIPPolicy(duplicate), "策略名称已存在")
+	})
+}
+
+// TestIPPolicyService_Check 测试全局、角色、用户范围的白名单和黑名单判定
+func TestIPPolicyService_Check(t *testing.T) {
+	svc, _, _ := newIPPolicyTestService()
+	policies := []*entity.IPPolicy{
+		{Name: "办公网和 VPN", Scope: entity.IPPolicyScopeGlobal, Action: entity.IPPolicyActionAllow, CIDRs: []string{"10.0.0.0/8", "172.16.0.0/12"}},
+		{Name: "财务专网", Scope: entity.IPPolicyScopeRole, TargetID: 1, Action: entity.IPPolicyActionAllow, CIDRs: []string{"10.20.0.0/16"}},
+		{Name: "可疑主机", Scope: entity.IPPolicyScopeGlobal, Action: entity.IPPolicyActionDeny, CIDRs: []string{"10.0.0.66"}},
+		{Name: "alice 限制", Scope: entity.IPPolicyScopeUser, TargetID: 1, Action: entity.IPPolicyActionDeny, CIDRs: []string{"172.16.0.0/16"}},
+	}
+	for _, policy := range policies {
+		policy.Status = entity.IPPolicyStatusEnabled
+		require.NoError(t, svc.CreateIPPolicy(policy))
+	}
+
+	testCases := []struct {
+		name     string
+		ip       string
+		userID   int64
+		role     string
+		allowed  bool
+		policyBy string
+	}{
+		{"未认证请求在办公网内", "10.1.1.1", 0, "", true, ""},
+		{"未认证请求不在办公网内", "8.8.8.8", 0, "", false, "办公网和 VPN"},
+		{"全局黑名单优先", "10.0.0.66", 0, "", false, "可疑主机"},
+		{"财务角色在财务专网内", "10.20.3.4", 2, "finance", true, ""},
+		{"财务角色不在财务专网内", "10.1.1.1", 2, "finance", false, "财务专网"},
+		{"其他角色不受财务策略限制", "10.1.1.1", 2, "user", true, ""},
+		{"用户黑名单", "172.16.5.5", 1, "user", false, "alice 限制"},
+		{"其他用户不受用户策略限制", "172.16.5.5", 2, "user", true, ""},
+		{"无法解析的 IP 不满足白名单", "unknown", 0, "", false, "办公网和 VPN"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			decision, err := svc.Check(tc.ip, tc.userID, tc.role)
+			require.NoError(t, err)
+			assert.Equal(t, tc.allowed, decision.Allowed)
+			if !tc.allowed {
+				require.NotNil(t, decision.Policy)
+				assert.Equal(t, tc.policyBy, decision.Policy.Name)
+				assert.NotEmpty(t, decision.Reason)
+			}
+		})
+	}
+}
+
+// TestIPPolicyService_CacheInvalidation 测试策略缓存及变更后失效
+func TestIPPolicyService_CacheInvalidation(t *testing.T) {
+	svc, policyRepo, _ := newIPPolicyTestService()
+	policy := &entity.IPPolicy{Name: "黑名单", Scope: entity.IPPolicyScopeGlobal, Action: entity.IPPolicyActionDeny, CIDRs: []string{"1.2.3.4"}, Status: entity.IPPolicyStatusEnabled}
+	require.NoError(t, svc.CreateIPPolicy(policy))
+
+	for i := 0; i < 3; i++ {
+		decision, err := svc.Check("1.2.3.4", 0, "")
+		require.NoError(t, err)
+		assert.False(t, decision.Allowed)
+	}
+	assert.Equal(t, 1, policyRepo.listHits, "策略应从缓存读取")
+
+	policy.Status = entity.IPPolicyStatusDisabled
+	require.NoError(t, svc.UpdateIPPolicy(policy))
+	decision, err := svc.Check("1.2.3.4", 0, "")
+	require.NoError(t, err)
+	assert.True(t, decision.Allowed, "禁用策略后应立即生效")
+}
+
+// TestIPPolicyService_BlockAbnormalIPs 测试根据异常登录生成黑名单
+func TestIPPolicyService_BlockAbnormalIPs(t *testing.T) {
+	now := time.Now()
+	var failed []*entity.LoginLog
+	for i := 0; i < 5; i++ {
+		failed = append(failed, &entity.LoginLog{LoginIP: "203.0.113.9", Username: "admin", LoginTime: now, Result: entity.LoginResultFailed})
+	}
+	failed = append(failed, &entity.LoginLog{LoginIP: "198.51.100.1", Username: "bob", LoginTime: now, Result: entity.LoginResultFailed})
+
+	svc, _, loginLogRepo := newIPPolicyTestService(failed...)
+
+	policy, added, err := svc.BlockAbnormalIPs(5, 1)
+	require.NoError(t, err)
+	require.NotNil(t, policy)
+	assert.Equal(t, 1, added)
+	assert.Equal(t, service.AbnormalLoginPolicyName, policy.Name)
+	assert.Equal(t, entity.IPPolicyActionDeny, policy.Action)
+	assert.Equal(t, []string{"203.0.113.9/32"}, policy.CIDRs)
+
+	// 重复执行不会重复添加
+	_, added, err = svc.BlockAbnormalIPs(5, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 0, added)
+
+	decision, err := svc.Check("203.0.113.9", 0, "")
+	require.NoError(t, err)
+	assert.False(t, decision.Allowed)
+
+	// 拒绝记录写入登录日志
+	svc.RecordDenial("203.0.113.9", 0, "", "curl/8.0", decision)
+	require.Len(t, loginLogRepo.logs, 1)
+	assert.Equal(t, entity.LoginResultFailed, loginLogRepo.logs[0].Result)
+	assert.Contains(t, loginLogRepo.logs[0].ErrorMsg, "IP 访问策略拒绝")
+
+	_, _, err = svc.BlockAbnormalIPs(0, 1)
+	assert.Error(t, err)
+}
@@ -0,0 +1,333 @@
+package service
+
+import (
+	"errors"
+	"net"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/ix-pay/ixpay-pro/internal/config"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/entity"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/repo"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/service"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/security/ldap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// MockLDAPGroupMappingRepository LDAP 组映射仓库 Mock 实现
+type MockLDAPGroupMappingRepository struct {
+	mappings map[int64]*entity.LDAPGroupMapping
+	nextID   int64
+}
+
+func NewMockLDAPGroupMappingRepository(mappings ...*entity.LDAPGroupMapping) *MockLDAPGroupMappingRepository {
+	m := &MockLDAPGroupMappingRepository{mappings: make(map[int64]*entity.LDAPGroupMapping)}
+	for _, mapping := range mappings {
+		_ = m.Create(mapping)
+	}
+	return m
+}
+
+func (m *MockLDAPGroupMappingRepository) GetByID(id int64) (*entity.LDAPGroupMapping, error) {
+	if mapping, ok := m.mappings[id]; ok {
+		return mapping, nil
+	}
+	return nil, errors.New("record not found")
+}
+
+func (m *MockLDAPGroupMappingRepository) Create(mapping *entity.LDAPGroupMapping) error {
+	m.nextID++
+	mapping.ID = m.nextID
+	m.mappings[mapping.ID] = mapping
+	return nil
+}
+
+func (m *MockLDAPGroupMappingRepository) Update(mapping *entity.LDAPGroupMapping) error {
+	m.mappings[mapping.ID] = mapping
+	return nil
+}
+
+func (m *MockLDAPGroupMappingRepository) Delete(id int64) error {
+	delete(m.mappings, id)
+	return nil
+}
+
+func (m *MockLDAPGroupMappingRepository) List(page, pageSize int, filters map[string]interface{}) ([]*entity.LDAPGroupMapping, int64, error) {
+	all, _ := m.GetAll()
+	return all, int64(len(all)), nil
+}
+
+func (m *MockLDAPGroupMappingRepository) GetAll() ([]*entity.LDAPGroupMapping, error) {
+	list := make([]*entity.LDAPGroupMapping, 0, len(m.mappings))
+	for _, mapping := range m.mappings {
+		list = append(list, mapping)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Priority > list[j].Priority })
+	return list, nil
+}
+
+var _ repo.LDAPGroupMappingRepository = (*MockLDAPGroupMappingRepository)(nil)
+
+// fakeLDAPEntry 测试用 LDAP 条目
+type fakeLDAPEntry struct {
+	dn       string
+	password string
+	attrs    map[string][]string
+}
+
+// fakeLDAPServer 基于 ldap 包 BER 编解码实现的最小 LDAP 服务端
+// 支持简单绑定、搜索（与、或、等值、存在性过滤器）和解绑
+type fakeLDAPServer struct {
+	listener net.Listener
+	entries  []*fakeLDAPEntry
+}
+
+func newFakeLDAPServer(t *testing.T, entries ...*fakeLDAPEntry) *fakeLDAPServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	s := &fakeLDAPServer{listener: listener, entries: entries}
+	go s.serve()
+	t.Cleanup(func() { _ = listener.Close() })
+	return s
+}
+
+func (s *fakeLDAPServer) URL() string {
+	return "ldap://" + s.listener.Addr().String()
+}
+
+func (s *fakeLDAPServer) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+func (s *fakeLDAPServer) handle(conn net.Conn) {
+	defer conn.Close()
+	for {
+		msg, err := ldap.ReadPacket(conn)
+		if err != nil || len(msg.Children) < 2 {
+			return
+		}
+		msgID, _ := msg.Children[0].Int()
+		op := msg.Children[1]
+		switch {
+		case op.Is(ldap.ClassApplication, ldap.ApplicationBindRequest):
+			code := ldap.ResultInvalidCredentials
+			dn, password := op.Children[1].String(), op.Children[2].String()
+			for _, entry := range s.entries {
+				if strings.EqualFold(entry.dn, dn) && entry.password == password {
+					code = ldap.ResultSuccess
+				}
+			}
+			s.reply(conn, msgID, ldap.ApplicationBindResponse, code)
+		case op.Is(ldap.ClassApplication, ldap.ApplicationSearchRequest):
+			baseDN := strings.ToLower(op.Children[0].String())
+			for _, entry := range s.entries {
+				if !strings.HasSuffix(strings.ToLower(entry.dn), baseDN) || !matchFakeFilter(op.Children[6], entry) {
+					continue
+				}
+				attrs := ldap.NewSequence()
+				for name, values := range entry.attrs {
+					set := ldap.NewConstructed(ldap.ClassUniversal, ldap.TagSet)
+					for _, value := range values {
+						set.Append(ldap.NewOctetString(value))
+					}
+					attrs.Append(ldap.NewSequence(ldap.NewOctetString(name), set))
+				}
+				resp := ldap.NewConstructed(ldap.ClassApplication, ldap.ApplicationSearchResultEntry, ldap.NewOctetString(entry.dn), attrs)
+				_, _ = conn.Write(ldap.NewSequence(ldap.NewInteger(msgID), resp).Bytes())
+			}
+			s.reply(conn, msgID, ldap.ApplicationSearchResultDone, ldap.ResultSuccess)
+		default:
+			return
+		}
+	}
+}
+
+func (s *fakeLDAPServer) reply(conn net.Conn, msgID int64, tag byte, code int) {
+	resp := ldap.NewConstructed(ldap.ClassApplication, tag,
+		ldap.NewEnumerated(int64(code)),
+		ldap.NewOctetString(""),
+		ldap.NewOctetString(""),
+	)
+	_, _ = conn.Write(ldap.NewSequence(ldap.NewInteger(msgID), resp).Bytes())
+}
+
+// matchFakeFilter 按过滤器节点匹配条目（context 0 与、1 或、3 等值、7 存在性）
+func matchFakeFilter(filter *ldap.Packet, entry *fakeLDAPEntry) bool {
+	switch filter.Tag {
+	case 0:
+		for _, child := range filter.Children {
+			if !matchFakeFilter(child, entry) {
+				return false
+			}
+		}
+		return true
+	case 1:
+		for _, child := range filter.Children {
+			if matchFakeFilter(child, entry) {
+				return true
+			}
+		}
+		return false
+	case 3:
+		name, value := filter.Children[0].String(), filter.Children[1].String()
+		if strings.EqualFold(name, "member") {
+			for _, member := range entry.attrs["member"] {
+				if strings.EqualFold(member, value) {
+					return true
+				}
+			}
+			return false
+		}
+		for _, v := range entry.attrs[name] {
+			if strings.EqualFold(v, value) {
+				return true
+			}
+		}
+		return false
+	case 7:
+		return len(entry.attrs[filter.String()]) > 0
+	}
+	return false
+}
+
+// newTestLDAPDirectory 构造包含用户和组的测试目录
+func newTestLDAPDirectory(t *testing.T) *fakeLDAPServer {
+	return newFakeLDAPServer(t,
+		&fakeLDAPEntry{dn: "cn=reader,dc=example,dc=com", password: "reader-pass"},
+		&fakeLDAPEntry{
+			dn:       "uid=alice,ou=people,dc=example,dc=com",
+			password: "alice-pass",
+			attrs: map[string][]string{
+				"objectClass": {"person"},
+				"uid":         {"alice"},
+				"displayName": {"Alice"},
+				"mail":        {"alice@example.com"},
+				"memberOf":    {"cn=ops,ou=groups,dc=example,dc=com"},
+			},
+		},
+		&fakeLDAPEntry{
+			dn: "cn=finance,ou=groups,dc=example,dc=com",
+			attrs: map[string][]string{
+				"objectClass": {"groupOfNames"},
+				"cn":          {"finance"},
+				"member":      {"uid=alice,ou=people,dc=example,dc=com"},
+			},
+		},
+	)
+}
+
+func newTestLDAPConfig(url string) config.LDAPConfig {
+	return config.LDAPConfig{
+		Enabled:           true,
+		URL:               url,
+		Timeout:           2,
+		BindDN:            "cn=reader,dc=example,dc=com",
+		BindPassword:      "reader-pass",
+		BaseDN:            "ou=people,dc=example,dc=com",
+		UserFilter:        "(&(objectClass=person)(uid={username}))",
+		GroupBaseDN:       "ou=groups,dc=example,dc=com",
+		GroupFilter:       "(&(objectClass=groupOfNames)(member={dn}))",
+		UsernameAttribute: "uid",
+		FallbackToLocal:   true,
+	}
+}
+
+// TestLDAPService_Authenticate 测试 LDAP 认证和组映射
+func TestLDAPService_Authenticate(t *testing.T) {
+	server := newTestLDAPDirectory(t)
+	mappings := NewMockLDAPGroupMappingRepository(
+		&entity.LDAPGroupMapping{GroupDN: "cn=ops,ou=groups,dc=example,dc=com", RoleID: 11, DepartmentID: 101, Priority: 1},
+		&entity.LDAPGroupMapping{GroupDN: "FINANCE", RoleID: 12, DepartmentID: 102, Priority: 5},
+		&entity.LDAPGroupMapping{GroupDN: "cn=ops, ou=groups, dc=example, dc=com", RoleID: 11, Priority: 0},
+		&entity.LDAPGroupMapping{GroupDN: "hr", RoleID: 13, Priority: 9},
+	)
+	ldapService := service.NewLDAPService(ldap.NewAuthenticator(newTestLDAPConfig(server.URL()), &MockLogger{}), mappings, nil, nil, &MockLogger{})
+
+	tests := []struct {
+		name     string
+		username string
+		password string
+		wantErr  error
+	}{
+		{name: "认证成功", username: "alice", password: "alice-pass"},
+		{name: "密码错误", username: "alice", password: "wrong", wantErr: ldap.ErrInvalidCredentials},
+		{name: "空密码", username: "alice", password: "", wantErr: ldap.ErrInvalidCredentials},
+		{name: "用户不存在", username: "bob", password: "bob-pass", wantErr: ldap.ErrUserNotFound},
+		{name: "过滤器注入", username: "*)(uid=alice", password: "alice-pass", wantErr: ldap.ErrUserNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ldapService.Authenticate(tt.username, tt.password)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "alice", result.Identity.Username)
+			assert.Equal(t, "Alice", result.Identity.Nickname)
+			assert.Equal(t, "alice@example.com", result.Identity.Email)
+			assert.ElementsMatch(t, []string{
+				"cn=ops,ou=groups,dc=example,dc=com",
+				"cn=finance,ou=groups,dc=example,dc=com",
+			}, result.Identity.Groups)
+			// 角色取全部匹配映射的并集，部门取优先级最高的匹配映射
+			assert.ElementsMatch(t, []int64{11, 12}, result.RoleIDs)
+			assert.Equal(t, int64(102), result.DepartmentID)
+		})
+	}
+}
+
+// TestLDAPService_Unavailable 测试 LDAP 服务不可用
+func TestLDAPService_Unavailable(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	url := "ldap://" + listener.Addr().String()
+	require.NoError(t, listener.Close())
+
+	ldapService := service.NewLDAPService(ldap.NewAuthenticator(newTestLDAPConfig(url), &MockLogger{}), NewMockLDAPGroupMappingRepository(), nil, nil, &MockLogger{})
+	_, err = ldapService.Authenticate("alice", "alice-pass")
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, ldap.ErrInvalidCredentials)
+	assert.NotErrorIs(t, err, ldap.ErrUserNotFound)
+	assert.True(t, ldapService.FallbackToLocal())
+}
+
+// TestLDAPService_CreateMapping 测试创建组映射的校验
+func TestLDAPService_CreateMapping(t *testing.T) {
+	roles := NewMockRoleRepositoryForTest(&entity.Role{ID: 11, Code: "ops"})
+	ldapService := service.NewLDAPService(ldap.NewAuthenticator(config.LDAPConfig{}, &MockLogger{}), NewMockLDAPGroupMappingRepository(), roles, nil, &MockLogger{})
+	assert.False(t, ldapService.Enabled())
+
+	tests := []struct {
+		name    string
+		mapping *entity.LDAPGroupMapping
+		wantErr string
+	}{
+		{name: "创建成功", mapping: &entity.LDAPGroupMapping{GroupDN: " ops ", RoleID: 11}},
+		{name: "组为空", mapping: &entity.LDAPGroupMapping{GroupDN: " ", RoleID: 11}, wantErr: "LDAP 组不能为空"},
+		{name: "未映射角色和部门", mapping: &entity.LDAPGroupMapping{GroupDN: "ops"}, wantErr: "角色和部门至少需要映射一项"},
+		{name: "角色不存在", mapping: &entity.LDAPGroupMapping{GroupDN: "ops", RoleID: 99}, wantErr: "角色不存在"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ldapService.CreateMapping(tt.mapping)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "ops", tt.mapping.GroupDN)
+			assert.NotZero(t, tt.mapping.ID)
+		})
+	}
+}
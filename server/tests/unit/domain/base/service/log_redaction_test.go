@@ -0,0 +1,122 @@
+package service
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/observability/redact"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRedactor_JSONBody 测试 JSON 请求体按字段名规则脱敏
+func TestRedactor_JSONBody(t *testing.T) {
+	redactor, err := redact.NewRedactor(nil, "salt", 0, nil)
+	require.NoError(t, err)
+
+	body := `{"username":"admin","old_password":"Old@123","newPassword":"New@123","data":{"access_token":"eyJhbGci","refresh_token":"r-1","list":[{"phone":"13800138000","id_card":"110101199001011234"}]}}`
+	result := redactor.RedactBody([]byte(body))
+
+	var doc map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(result), &doc))
+	assert.Equal(t, "admin", doc["username"])
+	assert.Equal(t, "******", doc["old_password"])
+	assert.Equal(t, "******", doc["newPassword"])
+
+	data := doc["data"].(map[string]interface{})
+	assert.Equal(t, "******", data["access_token"])
+	assert.Equal(t, "******", data["refresh_token"])
+
+	item := data["list"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "13*******00", item["phone"])
+	assert.Equal(t, "1101**********1234", item["id_card"])
+	assert.NotContains(t, result, "13800138000")
+}
+
+// TestRedactor_PathRules 测试自定义 JSON 路径规则和哈希脱敏
+func TestRedactor_PathRules(t *testing.T) {
+	redactor, err := redact.NewRedactor([]redact.Rule{
+		{Path: "$.data.list[*].open_id", Action: redact.ActionHash},
+		{Path: "$.data.list[0].nickname"},
+	}, "salt", 0, nil)
+	require.NoError(t, err)
+
+	body := `{"data":{"list":[{"open_id":"o-1","nickname":"a"},{"open_id":"o-1","nickname":"b"}]}}`
+	var doc struct {
+		Data struct {
+			List []map[string]string `json:"list"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(redactor.RedactBody([]byte(body))), &doc))
+
+	first, second := doc.Data.List[0], doc.Data.List[1]
+	assert.True(t, strings.HasPrefix(first["open_id"], "sha256:"))
+	// 相同的值哈希结果相同
+	assert.Equal(t, first["open_id"], second["open_id"])
+	assert.Equal(t, "******", first["nickname"])
+	assert.Equal(t, "b", second["nickname"])
+
+	// 盐值不同哈希结果不同
+	other, err := redact.NewRedactor([]redact.Rule{{Path: "$.open_id", Action: redact.ActionHash}}, "other", 0, nil)
+	require.NoError(t, err)
+	assert.NotContains(t, other.RedactBody([]byte(`{"open_id":"o-1"}`)), first["open_id"])
+
+	// 非法规则
+	_, err = redact.NewRedactor([]redact.Rule{{Path: "data.list"}}, "", 0, nil)
+	assert.Error(t, err)
+	_, err = redact.NewRedactor([]redact.Rule{{Key: "phone", Action: "encrypt"}}, "", 0, nil)
+	assert.Error(t, err)
+}
+
+// TestRedactor_FormQueryAndText 测试表单、查询参数和无法解析内容的脱敏
+func TestRedactor_FormQueryAndText(t *testing.T) {
+	redactor, err := redact.NewRedactor(nil, "", 0, nil)
+	require.NoError(t, err)
+
+	form := redactor.RedactBody([]byte("grant_type=authorization_code&client_secret=s3cr3t&code=abc"))
+	assert.Contains(t, form, "grant_type=authorization_code")
+	assert.Contains(t, form, "client_secret=******")
+	assert.NotContains(t, form, "s3cr3t")
+
+	assert.Equal(t, "/api/oidc/userinfo?access_token=******&page=1", redactor.RedactURI("/api/oidc/userinfo?access_token=abc&page=1"))
+	assert.Equal(t, "/api/admin/user", redactor.RedactURI("/api/admin/user"))
+
+	// 截断后无法解析的 JSON 按文本替换
+	text := redactor.RedactBody([]byte(`{"username":"admin","password":"p@ss\"word","token":"t-1`))
+	assert.NotContains(t, text, "p@ss")
+	assert.Contains(t, text, `"password":"******"`)
+}
+
+// TestRedactor_SizeCapAndSkip 测试长度截断和跳过记录的接口
+func TestRedactor_SizeCapAndSkip(t *testing.T) {
+	redactor, err := redact.NewRedactor(nil, "", 32, []string{
+		"POST /api/admin/auth/password/reset",
+		"PUT /api/admin/user/:id/password",
+		"/api/oidc/*",
+	})
+	require.NoError(t, err)
+
+	result := redactor.RedactBody([]byte(strings.Repeat("中", 40)))
+	assert.True(t, strings.HasSuffix(result, "...[已截断]"))
+	assert.LessOrEqual(t, len(strings.TrimSuffix(result, "...[已截断]")), 32)
+
+	assert.True(t, redactor.SkipBody("POST", "/api/admin/auth/password/reset"))
+	assert.False(t, redactor.SkipBody("GET", "/api/admin/auth/password/reset"))
+	assert.True(t, redactor.SkipBody("PUT", "/api/admin/user/100/password"))
+	assert.True(t, redactor.SkipBody("POST", "/api/oidc/token"))
+	assert.False(t, redactor.SkipBody("POST", "/api/admin/user"))
+}
+
+// TestRedactor_LogField 测试日志字段按字段名脱敏
+func TestRedactor_LogField(t *testing.T) {
+	redactor, err := redact.NewRedactor([]redact.Rule{{Key: "open_id", Action: redact.ActionHash}}, "", 0, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, "******", redactor.RedactField("refreshToken", "r-1"))
+	assert.Equal(t, "******", redactor.RedactField("clientSecret", 12345))
+	assert.Equal(t, "a***n@example.com", redactor.RedactField("email", "admin@example.com"))
+	assert.True(t, strings.HasPrefix(redactor.RedactField("openID", "o-1").(string), "sha256:"))
+	assert.Equal(t, "admin", redactor.RedactField("username", "admin"))
+	assert.Equal(t, 42, redactor.RedactField("userID", 42))
+}
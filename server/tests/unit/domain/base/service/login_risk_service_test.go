@@ -0,0 +1,299 @@
+package service
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ix-pay/ixpay-pro/internal/config"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/entity"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/service"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/support/geoip"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/transport/notify"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+const (
+	chromeWindowsUA  = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36"
+	chromeWindowsUA2 = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/121.0.0.0 Safari/537.36"
+	firefoxLinuxUA   = "Mozilla/5.0 (X11; Linux x86_64; rv:121.0) Gecko/20100101 Firefox/121.0"
+)
+
+// MockLoginDeviceRepository 内存实现的登录设备仓库
+type MockLoginDeviceRepository struct {
+	devices []*entity.LoginDevice
+	nextID  int64
+}
+
+func (m *MockLoginDeviceRepository) GetByFingerprint(userID int64, fingerprint string) (*entity.LoginDevice, error) {
+	for _, device := range m.devices {
+		if device.UserID == userID && device.Fingerprint == fingerprint {
+			copied := *device
+			return &copied, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (m *MockLoginDeviceRepository) CountByUser(userID int64) (int64, error) {
+	var count int64
+	for _, device := range m.devices {
+		if device.UserID == userID {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (m *MockLoginDeviceRepository) Save(device *entity.LoginDevice) error {
+	copied := *device
+	if device.ID == 0 {
+		m.nextID++
+		device.ID, copied.ID = m.nextID, m.nextID
+		m.devices = append(m.devices, &copied)
+		return nil
+	}
+	for i, existing := range m.devices {
+		if existing.ID == device.ID {
+			m.devices[i] = &copied
+		}
+	}
+	return nil
+}
+
+func (m *MockLoginDeviceRepository) ListByUser(userID int64) ([]*entity.LoginDevice, error) {
+	var devices []*entity.LoginDevice
+	for _, device := range m.devices {
+		if device.UserID == userID {
+			devices = append(devices, device)
+		}
+	}
+	return devices, nil
+}
+
+// MockLoginLocationRepository 内存实现的登录地点仓库
+type MockLoginLocationRepository struct {
+	locations []*entity.LoginLocation
+	nextID    int64
+}
+
+func (m *MockLoginLocationRepository) Get(userID int64, country, city string) (*entity.LoginLocation, error) {
+	for _, location := range m.locations {
+		if location.UserID == userID && location.Country == country && location.City == city {
+			copied := *location
+			return &copied, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (m *MockLoginLocationRepository) HasCountry(userID int64, country string) (bool, error) {
+	for _, location := range m.locations {
+		if location.UserID == userID && location.Country == country {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (m *MockLoginLocationRepository) GetLatest(userID int64) (*entity.LoginLocation, error) {
+	var latest *entity.LoginLocation
+	for _, location := range m.locations {
+		if location.UserID == userID && (latest == nil || location.LastSeenAt.After(latest.LastSeenAt)) {
+			latest = location
+		}
+	}
+	if latest == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+	copied := *latest
+	return &copied, nil
+}
+
+func (m *MockLoginLocationRepository) Save(location *entity.LoginLocation) error {
+	copied := *location
+	if location.ID == 0 {
+		m.nextID++
+		location.ID, copied.ID = m.nextID, m.nextID
+		m.locations = append(m.locations, &copied)
+		return nil
+	}
+	for i, existing := range m.locations {
+		if existing.ID == location.ID {
+			m.locations[i] = &copied
+		}
+	}
+	return nil
+}
+
+// MockLoginSecurityEventRepository 内存实现的登录安全事件仓库
+type MockLoginSecurityEventRepository struct {
+	events []*entity.LoginSecurityEvent
+}
+
+func (m *MockLoginSecurityEventRepository) CreateBatch(events []*entity.LoginSecurityEvent) error {
+	for _, event := range events {
+		event.ID = int64(len(m.events) + 1)
+		m.events = append(m.events, event)
+	}
+	return nil
+}
+
+func (m *MockLoginSecurityEventRepository) UpdateStepUpStatus(ids []int64, status string) error {
+	for _, event := range m.events {
+		for _, id := range ids {
+			if event.ID == id {
+				event.StepUpStatus = status
+			}
+		}
+	}
+	return nil
+}
+
+func (m *MockLoginSecurityEventRepository) List(page, pageSize int, filters map[string]interface{}) ([]*entity.LoginSecurityEvent, int64, error) {
+	return m.events, int64(len(m.events)), nil
+}
+
+type loginRiskTestEnv struct {
+	svc       *service.LoginRiskService
+	events    *MockLoginSecurityEventRepository
+	locations *MockLoginLocationRepository
+	sender    *recordingSender
+}
+
+func newTestLoginRiskService(t *testing.T, riskCfg config.LoginRiskConfig) *loginRiskTestEnv {
+	path := filepath.Join(t.TempDir(), "ip2region.xdb")
+	writeGeoDB(t, path, buildXDB([]xdbSegment{
+		{"1.0.0.0", "1.0.0.255", "中国|0|广东省|深圳市|电信"},
+		{"1.0.1.0", "1.0.1.255", "中国|0|北京|北京市|联通"},
+		{"2.0.0.0", "2.0.0.255", "美国|0|加利福尼亚|洛杉矶|0"},
+	}))
+	log := &MockLogger{}
+	locator, err := geoip.NewLocator(path, "", "", 0, time.Hour, log)
+	require.NoError(t, err)
+
+	riskCfg.Enabled = true
+	env := &loginRiskTestEnv{
+		events:    &MockLoginSecurityEventRepository{},
+		locations: &MockLoginLocationRepository{},
+		sender:    &recordingSender{},
+	}
+	cfg := &config.Config{JWT: config.JWTConfig{SecretKey: "test-secret"}, LoginRisk: riskCfg}
+	env.svc = service.NewLoginRiskService(&MockLoginDeviceRepository{}, env.locations, env.events, locator, nil,
+		&notify.Senders{Email: env.sender, SMS: env.sender}, NewMockCache(), cfg, log)
+	return env
+}
+
+// login 模拟一次不需要二次验证的登录
+func (env *loginRiskTestEnv) login(user *entity.User, ip, userAgent string) *service.LoginAssessment {
+	a := env.svc.Assess(user, ip, userAgent)
+	env.svc.Record(a, entity.StepUpStatusNone)
+	env.svc.Remember(a)
+	return a
+}
+
+func eventTypes(a *service.LoginAssessment) []string {
+	types := make([]string, 0, len(a.Events))
+	for _, event := range a.Events {
+		types = append(types, event.Type)
+	}
+	return types
+}
+
+// TestLoginRiskService_Assess 测试新设备、新城市、新国家和异地登录的识别
+func TestLoginRiskService_Assess(t *testing.T) {
+	env := newTestLoginRiskService(t, config.LoginRiskConfig{NotifyChannels: []string{service.LoginAlertChannelEmail}})
+	user := &entity.User{ID: 1, Username: "alice", Email: "alice@example.com", Status: 1}
+
+	// 首次登录只建立基线
+	assert.Empty(t, env.login(user, "1.0.0.8", chromeWindowsUA).Events)
+	// 浏览器升级不视为新设备
+	assert.Empty(t, env.login(user, "1.0.0.9", chromeWindowsUA2).Events)
+	assert.Empty(t, env.sender.messages)
+
+	a := env.login(user, "1.0.0.9", firefoxLinuxUA)
+	assert.Equal(t, []string{entity.LoginEventNewDevice}, eventTypes(a))
+	assert.Equal(t, entity.LoginRiskMedium, a.Events[0].RiskLevel)
+	require.Len(t, env.sender.messages, 1)
+	assert.Equal(t, "alice@example.com", env.sender.messages[0].To)
+	assert.Contains(t, env.sender.messages[0].Content, "首次使用 Firefox 121.0 / Linux 登录")
+
+	// 同一国家的新城市，没有经纬度时不按异地登录处理
+	a = env.login(user, "1.0.1.1", chromeWindowsUA)
+	assert.Equal(t, []string{entity.LoginEventNewCity}, eventTypes(a))
+
+	// 短时间内更换国家
+	a = env.login(user, "2.0.0.1", chromeWindowsUA)
+	assert.Equal(t, []string{entity.LoginEventNewCountry, entity.LoginEventImpossibleTravel}, eventTypes(a))
+	travel := a.Events[1]
+	assert.Equal(t, entity.LoginRiskHigh, travel.RiskLevel)
+	assert.Equal(t, "中国 北京 北京市", travel.PreviousPlace)
+	assert.Equal(t, "1.0.1.1", travel.PreviousIP)
+	assert.Len(t, env.events.events, 4)
+
+	// 超过时间窗口后更换国家不视为异地登录
+	for _, location := range env.locations.locations {
+		location.LastSeenAt = location.LastSeenAt.Add(-2 * time.Hour)
+	}
+	a = env.login(user, "1.0.0.8", chromeWindowsUA)
+	assert.Empty(t, a.Events)
+
+	// 未开启检测时不评估
+	disabled := service.NewLoginRiskService(nil, nil, nil, nil, nil, nil, nil, &config.Config{}, &MockLogger{})
+	assert.Nil(t, disabled.Assess(user, "2.0.0.1", firefoxLinuxUA))
+}
+
+// TestLoginRiskService_StepUp 测试二次验证：验证码须在发起登录的设备上提交，通过后事件标记为已验证
+func TestLoginRiskService_StepUp(t *testing.T) {
+	env := newTestLoginRiskService(t, config.LoginRiskConfig{
+		StepUpEvents:      []string{entity.LoginEventNewDevice},
+		StepUpMaxAttempts: 3,
+	})
+	user := &entity.User{ID: 1, Username: "alice", Email: "alice@example.com", Status: 1}
+	env.login(user, "1.0.0.8", chromeWindowsUA)
+
+	// 新城市不要求二次验证
+	assert.False(t, env.svc.RequireStepUp(env.svc.Assess(user, "1.0.1.1", chromeWindowsUA)))
+
+	a := env.svc.Assess(user, "1.0.0.8", firefoxLinuxUA)
+	require.True(t, env.svc.RequireStepUp(a))
+	err := env.svc.StartStepUp(user, "alice", service.LoginTypePassword, a)
+	var stepUpErr *service.StepUpRequiredError
+	require.True(t, errors.As(err, &stepUpErr))
+	assert.Equal(t, notify.ChannelEmail, stepUpErr.Channel)
+	assert.Equal(t, "a***@example.com", stepUpErr.Target)
+	require.Len(t, env.events.events, 1)
+	assert.Equal(t, entity.StepUpStatusPending, env.events.events[0].StepUpStatus)
+	code := env.sender.lastCode(t)
+
+	_, err = env.svc.VerifyStepUp(stepUpErr.ChallengeID, "000000x", "1.0.0.8", firefoxLinuxUA)
+	assert.Error(t, err)
+	_, err = env.svc.VerifyStepUp(stepUpErr.ChallengeID, code, "1.0.0.9", firefoxLinuxUA)
+	assert.Error(t, err, "IP 不一致时不能完成验证")
+
+	challenge, err := env.svc.VerifyStepUp(stepUpErr.ChallengeID, code, "1.0.0.8", firefoxLinuxUA)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), challenge.UserID)
+	assert.Equal(t, service.LoginTypePassword, challenge.LoginType)
+	assert.Equal(t, entity.StepUpStatusPassed, env.events.events[0].StepUpStatus)
+
+	// 验证码一次性使用
+	_, err = env.svc.VerifyStepUp(stepUpErr.ChallengeID, code, "1.0.0.8", firefoxLinuxUA)
+	assert.Error(t, err)
+
+	// 失败次数超过上限后作废
+	a = env.svc.Assess(user, "1.0.0.8", firefoxLinuxUA)
+	err = env.svc.StartStepUp(user, "alice", service.LoginTypePassword, a)
+	require.True(t, errors.As(err, &stepUpErr))
+	code = env.sender.lastCode(t)
+	for i := 0; i < 3; i++ {
+		_, err = env.svc.VerifyStepUp(stepUpErr.ChallengeID, "wrong", "1.0.0.8", firefoxLinuxUA)
+		assert.Error(t, err)
+	}
+	_, err = env.svc.VerifyStepUp(stepUpErr.ChallengeID, code, "1.0.0.8", firefoxLinuxUA)
+	assert.Error(t, err)
+	assert.Equal(t, entity.StepUpStatusFailed, env.events.events[1].StepUpStatus)
+}
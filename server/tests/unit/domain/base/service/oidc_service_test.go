@@ -0,0 +1,548 @@
+package service
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/ix-pay/ixpay-pro/internal/config"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/entity"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/repo"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/service"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/security/auth"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/security/oidc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// MockOIDCProviderRepository OIDC 身份提供方仓库 Mock 实现
+type MockOIDCProviderRepository struct {
+	providers  map[int64]*entity.OIDCProvider
+	identities map[int64]*entity.UserOIDCIdentity
+	nextID     int64
+}
+
+func NewMockOIDCProviderRepository() *MockOIDCProviderRepository {
+	return &MockOIDCProviderRepository{
+		providers:  make(map[int64]*entity.OIDCProvider),
+		identities: make(map[int64]*entity.UserOIDCIdentity),
+	}
+}
+
+func (m *MockOIDCProviderRepository) GetByID(id int64) (*entity.OIDCProvider, error) {
+	if p, ok := m.providers[id]; ok {
+		return p, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (m *MockOIDCProviderRepository) GetByCode(code string) (*entity.OIDCProvider, error) {
+	for _, p := range m.providers {
+		if p.Code == code {
+			return p, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (m *MockOIDCProviderRepository) Create(provider *entity.OIDCProvider) error {
+	m.nextID++
+	provider.ID = m.nextID
+	provider.UpdatedAt = time.Now()
+	m.providers[provider.ID] = provider
+	return nil
+}
+
+func (m *MockOIDCProviderRepository) Update(provider *entity.OIDCProvider) error {
+	provider.UpdatedAt = time.Now()
+	m.providers[provider.ID] = provider
+	return nil
+}
+
+func (m *MockOIDCProviderRepository) Delete(id int64) error {
+	delete(m.providers, id)
+	return nil
+}
+
+func (m *MockOIDCProviderRepository) List(page, pageSize int, filters map[string]interface{}) ([]*entity.OIDCProvider, int64, error) {
+	list, _ := m.GetEnabled()
+	return list, int64(len(list)), nil
+}
+
+func (m *MockOIDCProviderRepository) GetEnabled() ([]*entity.OIDCProvider, error) {
+	var list []*entity.OIDCProvider
+	for _, p := range m.providers {
+		if p.IsActive() {
+			list = append(list, p)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Sort < list[j].Sort })
+	return list, nil
+}
+
+func (m *MockOIDCProviderRepository) GetIdentity(providerID int64, subject string) (*entity.UserOIDCIdentity, error) {
+	for _, identity := range m.identities {
+		if identity.ProviderID == providerID && identity.Subject == subject {
+			return identity, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (m *MockOIDCProviderRepository) CreateIdentity(identity *entity.UserOIDCIdentity) error {
+	m.nextID++
+	identity.ID = m.nextID
+	m.identities[identity.ID] = identity
+	return nil
+}
+
+func (m *MockOIDCProviderRepository) TouchIdentity(id int64, at time.Time) error {
+	if identity, ok := m.identities[id]; ok {
+		identity.LastLoginAt = &at
+	}
+	return nil
+}
+
+var _ repo.OIDCProviderRepository = (*MockOIDCProviderRepository)(nil)
+
+// MockUserRoleRepository 支持用户角色关联的角色仓库 Mock 实现
+type MockUserRoleRepository struct {
+	*MockRoleRepositoryForTest
+	userRoles map[int64][]int64
+}
+
+func NewMockUserRoleRepository(roles ...*entity.Role) *MockUserRoleRepository {
+	return &MockUserRoleRepository{
+		MockRoleRepositoryForTest: NewMockRoleRepositoryForTest(roles...),
+		userRoles:                 make(map[int64][]int64),
+	}
+}
+
+func (m *MockUserRoleRepository) GetAllRoles() ([]*entity.Role, error) {
+	var roles []*entity.Role
+	for _, r := range m.roles {
+		roles = append(roles, r)
+	}
+	return roles, nil
+}
+
+func (m *MockUserRoleRepository) GetRolesByUser(userID int64) ([]*entity.Role, error) {
+	var roles []*entity.Role
+	for _, roleID := range m.userRoles[userID] {
+		roles = append(roles, m.roles[roleID])
+	}
+	return roles, nil
+}
+
+func (m *MockUserRoleRepository) GetUsersByRole(roleID int64) ([]*entity.User, error) {
+	var users []*entity.User
+	for userID, roleIDs := range m.userRoles {
+		for _, id := range roleIDs {
+			if id == roleID {
+				users = append(users, &entity.User{ID: userID})
+			}
+		}
+	}
+	return users, nil
+}
+
+func (m *MockUserRoleRepository) GetMenusByRole(roleID int64) ([]*entity.Menu, error) {
+	return nil, nil
+}
+
+func (m *MockUserRoleRepository) GetsByRole(roleID int64) ([]*entity.API, error) {
+	return nil, nil
+}
+
+func (m *MockUserRoleRepository) AddUserToRole(roleID, userID int64) error {
+	m.userRoles[userID] = append(m.userRoles[userID], roleID)
+	return nil
+}
+
+func (m *MockUserRoleRepository) RemoveUserFromRole(roleID, userID int64) error {
+	kept := m.userRoles[userID][:0]
+	for _, id := range m.userRoles[userID] {
+		if id != roleID {
+			kept = append(kept, id)
+		}
+	}
+	m.userRoles[userID] = kept
+	return nil
+}
+
+// MockLoginLogRepositoryForTest 登录日志仓库 Mock 实现，只记录创建的日志
+type MockLoginLogRepositoryForTest struct {
+	repo.LoginLogRepository
+	mu   sync.Mutex
+	logs []*entity.LoginLog
+}
+
+func (m *MockLoginLogRepositoryForTest) Create(log *entity.LoginLog) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.logs = append(m.logs, log)
+	return nil
+}
+
+// fakeIdentityProvider 测试用 OIDC 身份提供方
+// 提供发现文档、JWKS、令牌端点和用户信息端点；授权步骤由测试直接调用 authorize 模拟
+type fakeIdentityProvider struct {
+	server   *httptest.Server
+	key      *rsa.PrivateKey
+	clientID string
+	secret   string
+
+	mu    sync.Mutex
+	codes map[string]fakeAuthorization
+}
+
+// fakeAuthorization 一次授权的上下文
+type fakeAuthorization struct {
+	challenge string
+	claims    jwt.MapClaims
+	userInfo  map[string]interface{}
+}
+
+func newFakeIdentityProvider(t *testing.T) *fakeIdentityProvider {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	idp := &fakeIdentityProvider{key: key, clientID: "ixpay-admin", secret: "s3cret", codes: make(map[string]fakeAuthorization)}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(oidc.Metadata{
+			Issuer:                idp.server.URL,
+			AuthorizationEndpoint: idp.server.URL + "/authorize",
+			TokenEndpoint:         idp.server.URL + "/token",
+			UserinfoEndpoint:      idp.server.URL + "/userinfo",
+			JWKSURI:               idp.server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(oidc.JWKSet{Keys: []oidc.JWK{{
+			Kty: "RSA",
+			Kid: "test-key",
+			Use: "sig",
+			N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}}})
+	})
+	mux.HandleFunc("/token", idp.handleToken)
+	mux.HandleFunc("/userinfo", func(w http.ResponseWriter, r *http.Request) {
+		token := r.Header.Get("Authorization")
+		idp.mu.Lock()
+		authz, ok := idp.codes["access:"+token]
+		idp.mu.Unlock()
+		if !ok {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		json.NewEncoder(w).Encode(authz.userInfo)
+	})
+	idp.server = httptest.NewServer(mux)
+	t.Cleanup(idp.server.Close)
+	return idp
+}
+
+// authorize 模拟用户在身份提供方完成登录，返回授权码
+// claims 中未设置 nonce 时使用授权请求中的 nonce
+func (idp *fakeIdentityProvider) authorize(t *testing.T, authorizationURL string, claims jwt.MapClaims, userInfo map[string]interface{}) (string, string) {
+	u, err := url.Parse(authorizationURL)
+	require.NoError(t, err)
+	query := u.Query()
+	require.Equal(t, idp.server.URL+"/authorize", u.Scheme+"://"+u.Host+u.Path)
+	require.Equal(t, "code", query.Get("response_type"))
+	require.Equal(t, "S256", query.Get("code_challenge_method"))
+	require.Equal(t, idp.clientID, query.Get("client_id"))
+
+	full := jwt.MapClaims{
+		"iss": idp.server.URL,
+		"aud": idp.clientID,
+		"iat": time.Now().Unix(),
+		"exp": time.Now().Add(5 * time.Minute).Unix(),
+	}
+	if _, ok := claims["nonce"]; !ok {
+		full["nonce"] = query.Get("nonce")
+	}
+	for k, v := range claims {
+		full[k] = v
+	}
+
+	code, err := oidc.RandomString(16)
+	require.NoError(t, err)
+	idp.mu.Lock()
+	idp.codes[code] = fakeAuthorization{challenge: query.Get("code_challenge"), claims: full, userInfo: userInfo}
+	idp.mu.Unlock()
+	return code, query.Get("state")
+}
+
+func (idp *fakeIdentityProvider) handleToken(w http.ResponseWriter, r *http.Request) {
+	clientID, secret, ok := r.BasicAuth()
+	if !ok || clientID != idp.clientID || secret != idp.secret {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(map[string]string{"error": "invalid_client"})
+		return
+	}
+
+	idp.mu.Lock()
+	authz, ok := idp.codes[r.FormValue("code")]
+	delete(idp.codes, r.FormValue("code"))
+	idp.mu.Unlock()
+	if !ok || oidc.CodeChallengeS256(r.FormValue("code_verifier")) != authz.challenge {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, authz.claims)
+	token.Header["kid"] = "test-key"
+	idToken, err := token.SignedString(idp.key)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	accessToken, _ := oidc.RandomString(16)
+	if authz.userInfo != nil {
+		authz.userInfo["sub"] = authz.claims["sub"]
+		idp.mu.Lock()
+		idp.codes["access:Bearer "+accessToken] = authz
+		idp.mu.Unlock()
+	}
+	json.NewEncoder(w).Encode(oidc.TokenResponse{AccessToken: accessToken, TokenType: "Bearer", IDToken: idToken})
+}
+
+// oidcTestEnv OIDC 登录测试环境
+type oidcTestEnv struct {
+	idp       *fakeIdentityProvider
+	providers *MockOIDCProviderRepository
+	users     *MockUserRepositoryForTest
+	roles     *MockUserRoleRepository
+	loginLogs *MockLoginLogRepositoryForTest
+	service   *service.OIDCService
+}
+
+func newOIDCTestEnv(t *testing.T, users ...*entity.User) *oidcTestEnv {
+	log := &MockLogger{}
+	cache := NewMockCache()
+	cfg := &config.Config{JWT: config.JWTConfig{SecretKey: "test-secret", AccessTokenExpire: "1h", RefreshTokenExpire: "24h"}}
+	jwtAuth, err := auth.SetupJWTAuth(cfg, log)
+	require.NoError(t, err)
+
+	env := &oidcTestEnv{
+		idp:       newFakeIdentityProvider(t),
+		providers: NewMockOIDCProviderRepository(),
+		users:     NewMockUserRepositoryForTest(users...),
+		roles: NewMockUserRoleRepository(
+			&entity.Role{ID: 10, Code: "user", Name: "普通用户"},
+			&entity.Role{ID: 11, Code: "ops", Name: "运维"},
+		),
+		loginLogs: &MockLoginLogRepositoryForTest{},
+	}
+	sod, _ := newTestSoDService(env.roles)
+	roleService := service.NewRoleService(env.roles, env.users, nil, nil, nil, nil, nil, sod, log)
+	rolePermissionService := service.NewRolePermissionService(nil, env.roles, nil, nil, nil, cache, nil, log)
+	loginLogService := service.NewLoginLogService(env.loginLogs, nil, log)
+	onlineUserService := newTestOnlineUserService(NewMockOnlineUserRepositoryForTest(), cfg, log)
+	userService := service.NewUserService(env.users, nil, roleService, rolePermissionService, jwtAuth, cfg, log, cache, nil, loginLogService, nil, nil, onlineUserService, nil, sod)
+	env.service = service.NewOIDCService(env.providers, env.users, env.roles, userService, cache, log)
+	return env
+}
+
+// createProvider 创建指向测试身份提供方的配置
+func (env *oidcTestEnv) createProvider(t *testing.T, customize func(p *entity.OIDCProvider)) *entity.OIDCProvider {
+	provider := &entity.OIDCProvider{
+		Code:         "corp",
+		Name:         "企业 IdP",
+		Issuer:       env.idp.server.URL,
+		ClientID:     env.idp.clientID,
+		ClientSecret: env.idp.secret,
+		RedirectURI:  "http://localhost:3000/sso/callback",
+		Status:       entity.OIDCProviderStatusEnabled,
+	}
+	if customize != nil {
+		customize(provider)
+	}
+	require.NoError(t, env.service.CreateProvider(provider))
+	return provider
+}
+
+// TestOIDCService_Login 测试 OIDC 授权码登录流程
+func TestOIDCService_Login(t *testing.T) {
+	tests := []struct {
+		name      string
+		customize func(p *entity.OIDCProvider)
+		claims    jwt.MapClaims
+		userInfo  map[string]interface{}
+		wantErr   string
+		check     func(t *testing.T, env *oidcTestEnv, user *entity.User)
+	}{
+		{
+			name:      "按已验证邮箱关联已有用户",
+			customize: func(p *entity.OIDCProvider) { p.LinkBy = entity.OIDCLinkByEmail },
+			claims:    jwt.MapClaims{"sub": "u-1", "email": "alice@example.com", "email_verified": true},
+			check: func(t *testing.T, env *oidcTestEnv, user *entity.User) {
+				assert.Equal(t, int64(1), user.ID)
+				identity, err := env.providers.GetIdentity(1, "u-1")
+				require.NoError(t, err)
+				assert.Equal(t, int64(1), identity.UserID)
+				assert.NotNil(t, identity.LastLoginAt)
+			},
+		},
+		{
+			name: "自动开通并按嵌套声明映射角色",
+			customize: func(p *entity.OIDCProvider) {
+				p.AutoCreate = true
+				p.RoleMappings = []*entity.OIDCRoleMapping{
+					{Claim: "realm_access.roles", Value: "ops", RoleID: 11},
+					{Claim: "groups", Value: "finance", RoleID: 10},
+				}
+			},
+			claims:   jwt.MapClaims{"sub": "u-2", "preferred_username": "bob", "name": "Bob"},
+			userInfo: map[string]interface{}{"realm_access": map[string]interface{}{"roles": []interface{}{"ops", "viewer"}}},
+			check: func(t *testing.T, env *oidcTestEnv, user *entity.User) {
+				assert.Equal(t, "bob", user.Username)
+				assert.Equal(t, "Bob", user.Nickname)
+				assert.Equal(t, []int64{11}, env.roles.userRoles[user.ID])
+			},
+		},
+		{
+			name:      "邮箱未验证时不关联",
+			customize: func(p *entity.OIDCProvider) { p.LinkBy = entity.OIDCLinkByEmail; p.TrustEmail = true },
+			claims:    jwt.MapClaims{"sub": "u-3", "email": "alice@example.com", "email_verified": false},
+			wantErr:   "未找到关联的本地账号，请联系管理员",
+		},
+		{
+			name:    "按 sub 关联且未绑定",
+			claims:  jwt.MapClaims{"sub": "u-4", "email": "alice@example.com", "email_verified": true},
+			wantErr: "未找到关联的本地账号，请联系管理员",
+		},
+		{
+			name:    "nonce 不匹配",
+			claims:  jwt.MapClaims{"sub": "u-5", "nonce": "forged"},
+			wantErr: "单点登录失败，请重新登录",
+		},
+		{
+			name:    "签发方不匹配",
+			claims:  jwt.MapClaims{"sub": "u-6", "iss": "https://evil.example.com"},
+			wantErr: "单点登录失败，请重新登录",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := newOIDCTestEnv(t, &entity.User{ID: 1, Username: "alice", Email: "alice@example.com", Status: 1})
+			env.roles.userRoles[1] = []int64{10}
+			env.createProvider(t, tt.customize)
+
+			authorizationURL, err := env.service.BeginLogin("corp")
+			require.NoError(t, err)
+			code, state := env.idp.authorize(t, authorizationURL, tt.claims, tt.userInfo)
+
+			user, accessToken, refreshToken, _, _, err := env.service.HandleCallback("corp", code, state, "127.0.0.1", "Mozilla/5.0")
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				require.NotEmpty(t, env.loginLogs.logs)
+				assert.Equal(t, entity.LoginResultFailed, env.loginLogs.logs[len(env.loginLogs.logs)-1].Result)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, user)
+			assert.NotEmpty(t, accessToken)
+			assert.NotEmpty(t, refreshToken)
+			assert.Equal(t, entity.LoginResultSuccess, env.loginLogs.logs[len(env.loginLogs.logs)-1].Result)
+			tt.check(t, env, user)
+		})
+	}
+}
+
+// TestOIDCService_StateReplay 测试 state 只能使用一次，且不能跨身份提供方使用
+func TestOIDCService_StateReplay(t *testing.T) {
+	env := newOIDCTestEnv(t, &entity.User{ID: 1, Username: "alice", Status: 1})
+	env.roles.userRoles[1] = []int64{10}
+	provider := env.createProvider(t, func(p *entity.OIDCProvider) { p.AutoCreate = true })
+	env.createProvider(t, func(p *entity.OIDCProvider) { p.Code = "other" })
+	require.NoError(t, env.providers.CreateIdentity(&entity.UserOIDCIdentity{ProviderID: provider.ID, Subject: "u-1", UserID: 1}))
+
+	authorizationURL, err := env.service.BeginLogin("corp")
+	require.NoError(t, err)
+
+	code, state := env.idp.authorize(t, authorizationURL, jwt.MapClaims{"sub": "u-1"}, nil)
+	_, _, _, _, _, err = env.service.HandleCallback("other", code, state, "127.0.0.1", "")
+	assert.EqualError(t, err, "登录状态已失效，请重新登录")
+
+	// state 已被消费，原身份提供方也不能再使用
+	_, _, _, _, _, err = env.service.HandleCallback("corp", code, state, "127.0.0.1", "")
+	assert.EqualError(t, err, "登录状态已失效，请重新登录")
+
+	authorizationURL, err = env.service.BeginLogin("corp")
+	require.NoError(t, err)
+	code, state = env.idp.authorize(t, authorizationURL, jwt.MapClaims{"sub": "u-1"}, nil)
+	user, _, _, _, _, err := env.service.HandleCallback("corp", code, state, "127.0.0.1", "")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), user.ID)
+
+	_, _, _, _, _, err = env.service.HandleCallback("corp", code, state, "127.0.0.1", "")
+	assert.EqualError(t, err, "登录状态已失效，请重新登录")
+}
+
+// TestOIDCService_CreateProvider 测试身份提供方配置校验
+func TestOIDCService_CreateProvider(t *testing.T) {
+	valid := func() *entity.OIDCProvider {
+		return &entity.OIDCProvider{
+			Code:         "keycloak",
+			Name:         "Keycloak",
+			Issuer:       "https://sso.example.com/realms/ixpay",
+			ClientID:     "ixpay",
+			ClientSecret: "secret",
+			RedirectURI:  "https://admin.example.com/sso/callback",
+			Scopes:       []string{"profile"},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(p *entity.OIDCProvider)
+		wantErr string
+	}{
+		{name: "创建成功", modify: func(p *entity.OIDCProvider) {}},
+		{name: "编码格式错误", modify: func(p *entity.OIDCProvider) { p.Code = "Key Cloak" }, wantErr: "编码只能包含小写字母、数字、下划线和中划线，长度 2-50"},
+		{name: "签发方必须为 https", modify: func(p *entity.OIDCProvider) { p.Issuer = "http://sso.example.com" }, wantErr: "签发方必须使用 https"},
+		{name: "缺少客户端密钥", modify: func(p *entity.OIDCProvider) { p.ClientSecret = "" }, wantErr: "客户端密钥不能为空"},
+		{name: "不支持的认证方式", modify: func(p *entity.OIDCProvider) { p.TokenAuthMethod = "private_key_jwt" }, wantErr: "不支持的客户端认证方式"},
+		{name: "映射角色不存在", modify: func(p *entity.OIDCProvider) {
+			p.RoleMappings = []*entity.OIDCRoleMapping{{Claim: "groups", Value: "ops", RoleID: 99}}
+		}, wantErr: "角色映射中的角色不存在：99"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := newOIDCTestEnv(t)
+			provider := valid()
+			tt.modify(provider)
+			err := env.service.CreateProvider(provider)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, []string{"openid", "profile"}, provider.Scopes)
+			assert.Equal(t, oidc.AuthMethodClientSecretBasic, provider.TokenAuthMethod)
+			assert.Equal(t, entity.OIDCLinkBySubject, provider.LinkBy)
+
+			err = env.service.CreateProvider(valid())
+			assert.EqualError(t, err, "身份提供方编码已存在")
+		})
+	}
+}
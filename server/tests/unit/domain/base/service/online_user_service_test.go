@@ -1,9 +1,17 @@
 package service
 
 import (
+	"errors"
 	"testing"
+	"time"
 
+	"github.com/ix-pay/ixpay-pro/internal/config"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/entity"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/service"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/transport/push"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/security/auth"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // TestOnlineUserService_TokenValidation 测试 Token 验证
@@ -122,3 +130,291 @@ func TestOnlineUserService_LoginTimeValidation(t *testing.T) {
 		})
 	}
 }
+
+// newSessionTestService 创建使用内存仓库的在线用户服务
+func newSessionTestService(maxConcurrent int, policy string) (*service.OnlineUserService, *MockOnlineUserRepository) {
+	repo := NewMockOnlineUserRepositoryForTest()
+	cfg := &config.Config{Session: config.SessionConfig{MaxConcurrent: maxConcurrent, Policy: policy}}
+	return newTestOnlineUserService(repo, cfg, &MockLogger{}), repo
+}
+
+// TestOnlineUserService_MultiDeviceSessions 测试同一用户多设备同时在线
+func TestOnlineUserService_MultiDeviceSessions(t *testing.T) {
+	svc, _ := newSessionTestService(0, "")
+
+	pc, err := svc.CreateSession(1, "admin", "管理员", service.LoginTypePassword, "10.0.0.1",
+		"Mozilla/5.0 (Windows NT 10.0; Win64; x64) Chrome/120.0", time.Hour)
+	require.NoError(t, err)
+	phone, err := svc.CreateSession(1, "admin", "管理员", service.LoginTypePassword, "10.0.0.2",
+		"Mozilla/5.0 (iPhone; CPU iPhone OS 17_0 like Mac OS X) Safari/604.1", time.Hour)
+	require.NoError(t, err)
+
+	assert.NotEmpty(t, pc.SessionID)
+	assert.NotEqual(t, pc.SessionID, phone.SessionID)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), pc.ExpiresAt, time.Minute)
+
+	sessions, err := svc.GetUserSessions(1)
+	require.NoError(t, err)
+	assert.Len(t, sessions, 2)
+	assert.Equal(t, phone.SessionID, sessions[0].SessionID, "最近登录的会话排在前面")
+
+	count, err := svc.GetOnlineCount()
+	require.NoError(t, err)
+	assert.Equal(t, 1, count, "在线人数按用户去重")
+
+	// 下线一个设备不影响另一个设备
+	require.NoError(t, svc.RevokeUserSession(1, pc.SessionID))
+	active, err := svc.TouchSession(pc.SessionID)
+	require.NoError(t, err)
+	assert.False(t, active)
+	active, err = svc.TouchSession(phone.SessionID)
+	require.NoError(t, err)
+	assert.True(t, active)
+}
+
+// TestOnlineUserService_SessionLimit 测试同时在线会话数上限策略
+func TestOnlineUserService_SessionLimit(t *testing.T) {
+	testCases := []struct {
+		name        string
+		policy      string
+		expectError bool
+	}{
+		{"踢出最早登录的会话", config.SessionPolicyKickOldest, false},
+		{"未配置策略时踢出最早登录的会话", "", false},
+		{"拒绝本次登录", config.SessionPolicyReject, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc, repo := newSessionTestService(2, tc.policy)
+
+			first, err := svc.CreateSession(1, "admin", "", service.LoginTypePassword, "10.0.0.1", "", time.Hour)
+			require.NoError(t, err)
+			// 保证登录时间有先后
+			first.LoginTime = time.Now().Add(-time.Hour)
+			require.NoError(t, repo.Add(first))
+			second, err := svc.CreateSession(1, "admin", "", service.LoginTypePassword, "10.0.0.2", "", time.Hour)
+			require.NoError(t, err)
+
+			third, err := svc.CreateSession(1, "admin", "", service.LoginTypePassword, "10.0.0.3", "", time.Hour)
+			sessions, _ := svc.GetUserSessions(1)
+			assert.Len(t, sessions, 2)
+
+			if tc.expectError {
+				assert.Error(t, err)
+				assert.Nil(t, third)
+				active, _ := svc.TouchSession(first.SessionID)
+				assert.True(t, active, "拒绝策略不影响已有会话")
+				return
+			}
+
+			require.NoError(t, err)
+			active, _ := svc.TouchSession(first.SessionID)
+			assert.False(t, active, "最早登录的会话应被踢出")
+			active, _ = svc.TouchSession(second.SessionID)
+			assert.True(t, active)
+			active, _ = svc.TouchSession(third.SessionID)
+			assert.True(t, active)
+		})
+	}
+
+	t.Run("其他用户的会话不计入上限", func(t *testing.T) {
+		svc, _ := newSessionTestService(1, config.SessionPolicyReject)
+		_, err := svc.CreateSession(1, "admin", "", service.LoginTypePassword, "10.0.0.1", "", time.Hour)
+		require.NoError(t, err)
+		_, err = svc.CreateSession(2, "user", "", service.LoginTypePassword, "10.0.0.2", "", time.Hour)
+		assert.NoError(t, err)
+	})
+}
+
+// TestOnlineUserService_TouchSession 测试会话校验和活跃时间刷新
+func TestOnlineUserService_TouchSession(t *testing.T) {
+	svc, repo := newSessionTestService(0, "")
+
+	active, err := svc.TouchSession("not-exist")
+	require.NoError(t, err)
+	assert.False(t, active)
+
+	session, err := svc.CreateSession(1, "admin", "", service.LoginTypePassword, "10.0.0.1", "", time.Hour)
+	require.NoError(t, err)
+
+	// 超过刷新间隔后更新最后活跃时间
+	session.LastActiveAt = time.Now().Add(-10 * time.Minute)
+	require.NoError(t, repo.Add(session))
+	active, err = svc.TouchSession(session.SessionID)
+	require.NoError(t, err)
+	assert.True(t, active)
+	stored, _ := repo.GetBySessionID(session.SessionID)
+	assert.WithinDuration(t, time.Now(), stored.LastActiveAt, time.Second)
+
+	// 延长会话有效期
+	expiresAt := time.Now().Add(48 * time.Hour)
+	require.NoError(t, svc.RenewSession(session.SessionID, expiresAt))
+	stored, _ = repo.GetBySessionID(session.SessionID)
+	assert.WithinDuration(t, expiresAt, stored.ExpiresAt, time.Second)
+	assert.Error(t, svc.RenewSession("not-exist", expiresAt))
+}
+
+// TestOnlineUserService_RevokeUserSession 测试用户下线自己的登录设备
+func TestOnlineUserService_RevokeUserSession(t *testing.T) {
+	svc, _ := newSessionTestService(0, "")
+
+	session, err := svc.CreateSession(1, "admin", "", service.LoginTypePassword, "10.0.0.1", "", time.Hour)
+	require.NoError(t, err)
+
+	assert.Error(t, svc.RevokeUserSession(2, session.SessionID), "不能下线其他用户的会话")
+	assert.Error(t, svc.RevokeUserSession(1, "not-exist"))
+
+	active, _ := svc.TouchSession(session.SessionID)
+	assert.True(t, active)
+
+	require.NoError(t, svc.RevokeUserSession(1, session.SessionID))
+	active, _ = svc.TouchSession(session.SessionID)
+	assert.False(t, active)
+}
+
+// newKickoutTestService 创建可以观察审计记录和推送事件的在线用户服务
+func newKickoutTestService() (*service.OnlineUserService, *MockSessionAuditLogRepository, *push.Hub) {
+	log := &MockLogger{}
+	auditRepo := NewMockSessionAuditLogRepository()
+	hub := push.NewHub(log)
+	svc := service.NewOnlineUserService(NewMockOnlineUserRepositoryForTest(), auditRepo, hub, nil, &config.Config{}, log)
+	return svc, auditRepo, hub
+}
+
+// TestOnlineUserService_KickoutUser 测试强制下线终止会话、记录审计并推送事件
+func TestOnlineUserService_KickoutUser(t *testing.T) {
+	svc, auditRepo, hub := newKickoutTestService()
+
+	pc, err := svc.CreateSession(1, "admin", "", service.LoginTypePassword, "10.0.0.1", "", time.Hour)
+	require.NoError(t, err)
+	phone, err := svc.CreateSession(1, "admin", "", service.LoginTypePassword, "10.0.0.2", "", time.Hour)
+	require.NoError(t, err)
+	events, unsubscribe := hub.Subscribe(phone.SessionID)
+	defer unsubscribe()
+
+	require.NoError(t, svc.KickoutUser(1, "违规操作", 99))
+
+	for _, sessionID := range []string{pc.SessionID, phone.SessionID} {
+		active, err := svc.TouchSession(sessionID)
+		require.NoError(t, err)
+		assert.False(t, active, "被踢出用户的全部会话应失效")
+	}
+
+	require.Len(t, auditRepo.logs, 2)
+	for _, log := range auditRepo.logs {
+		assert.Equal(t, entity.SessionAuditActionKickout, log.Action)
+		assert.Equal(t, "违规操作", log.Reason)
+		assert.Equal(t, int64(99), log.OperatorID)
+		assert.False(t, log.CreatedAt.IsZero())
+	}
+
+	select {
+	case event := <-events:
+		assert.Equal(t, push.EventSessionRevoked, event.Type)
+		assert.Equal(t, phone.SessionID, event.SessionID)
+		assert.Equal(t, "违规操作", event.Reason)
+	default:
+		assert.Fail(t, "应推送下线事件")
+	}
+
+	assert.EqualError(t, svc.KickoutUser(1, "", 99), "用户不在线")
+}
+
+// TestOnlineUserService_BatchKickoutAtomic 测试批量强制下线全部成功或全部失败
+func TestOnlineUserService_BatchKickoutAtomic(t *testing.T) {
+	t.Run("存在不在线的用户时不下线任何人", func(t *testing.T) {
+		svc, auditRepo, _ := newKickoutTestService()
+		session, err := svc.CreateSession(1, "admin", "", service.LoginTypePassword, "10.0.0.1", "", time.Hour)
+		require.NoError(t, err)
+
+		assert.Error(t, svc.BatchKickoutUsers([]int64{1, 2}, "", 99))
+		active, _ := svc.TouchSession(session.SessionID)
+		assert.True(t, active)
+		assert.Empty(t, auditRepo.logs)
+	})
+
+	t.Run("审计写入失败时恢复会话", func(t *testing.T) {
+		svc, auditRepo, hub := newKickoutTestService()
+		first, err := svc.CreateSession(1, "admin", "", service.LoginTypePassword, "10.0.0.1", "", time.Hour)
+		require.NoError(t, err)
+		second, err := svc.CreateSession(2, "user", "", service.LoginTypePassword, "10.0.0.2", "", time.Hour)
+		require.NoError(t, err)
+		events, unsubscribe := hub.Subscribe(first.SessionID)
+		defer unsubscribe()
+
+		auditRepo.failErr = errors.New("数据库不可用")
+		assert.Error(t, svc.BatchKickoutUsers([]int64{1, 2}, "", 99))
+
+		for _, sessionID := range []string{first.SessionID, second.SessionID} {
+			active, _ := svc.TouchSession(sessionID)
+			assert.True(t, active, "失败后会话应恢复")
+		}
+		assert.Empty(t, events, "失败时不推送下线事件")
+	})
+
+	t.Run("全部成功", func(t *testing.T) {
+		svc, auditRepo, _ := newKickoutTestService()
+		_, err := svc.CreateSession(1, "admin", "", service.LoginTypePassword, "10.0.0.1", "", time.Hour)
+		require.NoError(t, err)
+		_, err = svc.CreateSession(2, "user", "", service.LoginTypePassword, "10.0.0.2", "", time.Hour)
+		require.NoError(t, err)
+
+		require.NoError(t, svc.BatchKickoutUsers([]int64{1, 2, 2}, "批量清理", 99))
+		count, _ := svc.GetOnlineCount()
+		assert.Equal(t, 0, count)
+		assert.Len(t, auditRepo.logs, 2, "重复的用户 ID 只处理一次")
+	})
+}
+
+// TestOnlineUserService_SessionAudit 测试用户下线设备和超出会话上限时的审计记录
+func TestOnlineUserService_SessionAudit(t *testing.T) {
+	log := &MockLogger{}
+	auditRepo := NewMockSessionAuditLogRepository()
+	cfg := &config.Config{Session: config.SessionConfig{MaxConcurrent: 1, Policy: config.SessionPolicyKickOldest}}
+	svc := service.NewOnlineUserService(NewMockOnlineUserRepositoryForTest(), auditRepo, push.NewHub(log), nil, cfg, log)
+
+	first, err := svc.CreateSession(1, "admin", "", service.LoginTypePassword, "10.0.0.1", "", time.Hour)
+	require.NoError(t, err)
+	second, err := svc.CreateSession(1, "admin", "", service.LoginTypePassword, "10.0.0.2", "", time.Hour)
+	require.NoError(t, err)
+	require.NoError(t, svc.RevokeUserSession(1, second.SessionID))
+
+	require.Len(t, auditRepo.logs, 2)
+	assert.Equal(t, entity.SessionAuditActionKickOldest, auditRepo.logs[0].Action)
+	assert.Equal(t, first.SessionID, auditRepo.logs[0].SessionID)
+	assert.Equal(t, int64(0), auditRepo.logs[0].OperatorID, "系统自动处理时操作人为 0")
+	assert.Equal(t, entity.SessionAuditActionRevoke, auditRepo.logs[1].Action)
+	assert.Equal(t, int64(1), auditRepo.logs[1].OperatorID)
+}
+
+// TestUserService_SessionTokens 测试令牌携带会话 ID，会话下线后无法刷新令牌
+func TestUserService_SessionTokens(t *testing.T) {
+	cfg := &config.Config{JWT: config.JWTConfig{SecretKey: "test-secret", AccessTokenExpire: "30m", RefreshTokenExpire: "720h"}}
+	log := &MockLogger{}
+	jwtAuth, err := auth.SetupJWTAuth(cfg, log)
+	require.NoError(t, err)
+	onlineUserService := newTestOnlineUserService(NewMockOnlineUserRepositoryForTest(), cfg, log)
+	userService := service.NewUserService(nil, nil, nil, nil, jwtAuth, cfg, log, NewMockCache(), nil, nil, nil, nil, onlineUserService, nil, nil)
+
+	session, err := onlineUserService.CreateSession(1, "admin", "", service.LoginTypePassword, "10.0.0.1", "", jwtAuth.RefreshTokenExpire())
+	require.NoError(t, err)
+	accessToken, refreshToken, _, _, err := userService.GenerateToken("1", "admin", "管理员", "", service.LoginTypePassword, session.SessionID)
+	require.NoError(t, err)
+
+	claims, err := jwtAuth.ParseToken(accessToken)
+	require.NoError(t, err)
+	assert.Equal(t, session.SessionID, claims.SessionID)
+
+	// 刷新后的令牌保持同一会话
+	newAccessToken, _, _, _, err := userService.RefreshToken(refreshToken)
+	require.NoError(t, err)
+	claims, err = jwtAuth.ParseToken(newAccessToken)
+	require.NoError(t, err)
+	assert.Equal(t, session.SessionID, claims.SessionID)
+
+	// 退出当前设备后刷新令牌失败
+	require.NoError(t, userService.LogoutSession("1", session.SessionID))
+	_, _, _, _, err = userService.RefreshToken(refreshToken)
+	assert.Error(t, err)
+}
@@ -0,0 +1,171 @@
+package service
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/ix-pay/ixpay-pro/internal/config"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/entity"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/service"
+	"github.com/ix-pay/ixpay-pro/internal/utils/encryption"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// MockPasswordHistoryRepository 密码历史仓库 Mock 实现
+type MockPasswordHistoryRepository struct {
+	histories []*entity.PasswordHistory
+}
+
+func (m *MockPasswordHistoryRepository) Create(history *entity.PasswordHistory) error {
+	history.ID = int64(len(m.histories) + 1)
+	history.CreatedAt = time.Now().Add(time.Duration(len(m.histories)) * time.Second)
+	m.histories = append(m.histories, history)
+	return nil
+}
+
+func (m *MockPasswordHistoryRepository) GetRecentByUserID(userID int64, limit int) ([]*entity.PasswordHistory, error) {
+	result := make([]*entity.PasswordHistory, 0)
+	for _, h := range m.histories {
+		if h.UserID == userID {
+			result = append(result, h)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].CreatedAt.After(result[j].CreatedAt) })
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+func (m *MockPasswordHistoryRepository) PruneByUserID(userID int64, keep int) error {
+	recent, _ := m.GetRecentByUserID(userID, keep)
+	kept := make([]*entity.PasswordHistory, 0, len(m.histories))
+	for _, h := range m.histories {
+		if h.UserID != userID {
+			kept = append(kept, h)
+		}
+	}
+	m.histories = append(kept, recent...)
+	return nil
+}
+
+func newTestPasswordPolicyService(policy config.PasswordPolicyConfig, repo *MockPasswordHistoryRepository) *service.PasswordPolicyService {
+	cfg := &config.Config{PasswordPolicy: policy}
+	return service.NewPasswordPolicyService(cfg, repo, &MockLogger{})
+}
+
+// TestPasswordPolicyService_Validate 测试密码复杂度校验
+func TestPasswordPolicyService_Validate(t *testing.T) {
+	svc := newTestPasswordPolicyService(config.PasswordPolicyConfig{
+		MinLength:        8,
+		MaxLength:        20,
+		RequireUpper:     true,
+		RequireLower:     true,
+		RequireDigit:     true,
+		RequireSpecial:   true,
+		DisallowUsername: true,
+	}, nil)
+
+	testCases := []struct {
+		name        string
+		password    string
+		username    string
+		expectError bool
+		expectMsg   string
+	}{
+		{"有效密码", "Str0ng!Pass", "alice", false, ""},
+		{"长度不足", "Ab1!", "alice", true, "密码长度不能少于8位"},
+		{"长度超限", "Abcdefghij1!Abcdefghij1!", "alice", true, "密码长度不能超过20位"},
+		{"缺少大写字母", "str0ng!pass", "alice", true, "密码必须包含大写字母"},
+		{"缺少小写字母", "STR0NG!PASS", "alice", true, "密码必须包含小写字母"},
+		{"缺少数字", "Strong!Pass", "alice", true, "密码必须包含数字"},
+		{"缺少特殊字符", "Str0ngPass", "alice", true, "密码必须包含特殊字符"},
+		{"包含空白字符", "Str0ng! Pass", "alice", true, "密码不能包含空白字符"},
+		{"包含用户名", "Alice@2024x", "alice", true, "密码不能包含用户名"},
+		{"常用弱密码", "P@ssw0rd", "alice", true, "密码过于简单，请勿使用常用密码"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := svc.Validate(tc.password, tc.username)
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Equal(t, tc.expectMsg, err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+// TestPasswordPolicyService_History 测试禁止复用最近 N 次密码
+func TestPasswordPolicyService_History(t *testing.T) {
+	repo := &MockPasswordHistoryRepository{}
+	svc := newTestPasswordPolicyService(config.PasswordPolicyConfig{HistoryCount: 2}, repo)
+
+	userID := int64(1001)
+	for _, p := range []string{"OldPass1", "OldPass2", "OldPass3"} {
+		hash, err := encryption.GeneratePasswordHash(p)
+		require.NoError(t, err)
+		svc.RecordPassword(userID, hash, userID)
+	}
+
+	// 只保留最近 2 条历史
+	assert.Len(t, repo.histories, 2)
+
+	assert.Error(t, svc.CheckHistory(userID, "OldPass3"), "最近使用过的密码应被拒绝")
+	assert.Error(t, svc.CheckHistory(userID, "OldPass2"), "最近使用过的密码应被拒绝")
+	assert.NoError(t, svc.CheckHistory(userID, "OldPass1"), "超出历史范围的密码允许使用")
+	assert.NoError(t, svc.CheckHistory(2002, "OldPass3"), "其他用户的历史不受影响")
+}
+
+// TestPasswordPolicyService_ChangeRequired 测试强制修改密码判断
+func TestPasswordPolicyService_ChangeRequired(t *testing.T) {
+	svc := newTestPasswordPolicyService(config.PasswordPolicyConfig{ExpireDays: 90}, nil)
+
+	testCases := []struct {
+		name           string
+		user           *entity.User
+		expectRequired bool
+		expectReason   string
+	}{
+		{"管理员重置后首次登录", &entity.User{MustChangePassword: true, PasswordChangedAt: time.Now()}, true, service.PasswordChangeReasonReset},
+		{"密码已过期", &entity.User{PasswordChangedAt: time.Now().AddDate(0, 0, -91)}, true, service.PasswordChangeReasonExpired},
+		{"密码未过期", &entity.User{PasswordChangedAt: time.Now().AddDate(0, 0, -10)}, false, ""},
+		{"无修改时间时以创建时间计算", &entity.User{CreatedAt: time.Now().AddDate(-1, 0, 0)}, true, service.PasswordChangeReasonExpired},
+		{"空用户", nil, false, ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			required, reason := svc.ChangeRequired(tc.user)
+			assert.Equal(t, tc.expectRequired, required)
+			assert.Equal(t, tc.expectReason, reason)
+		})
+	}
+
+	// 未配置过期天数时不判断过期
+	noExpire := newTestPasswordPolicyService(config.PasswordPolicyConfig{}, nil)
+	required, _ := noExpire.ChangeRequired(&entity.User{PasswordChangedAt: time.Now().AddDate(-2, 0, 0)})
+	assert.False(t, required)
+}
+
+// TestPasswordPolicyService_GenerateCompliantPassword 测试生成的临时密码满足策略
+func TestPasswordPolicyService_GenerateCompliantPassword(t *testing.T) {
+	svc := newTestPasswordPolicyService(config.PasswordPolicyConfig{
+		MinLength:      10,
+		RequireUpper:   true,
+		RequireLower:   true,
+		RequireDigit:   true,
+		RequireSpecial: true,
+	}, nil)
+
+	for i := 0; i < 20; i++ {
+		password, err := svc.GenerateCompliantPassword()
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, len(password), 12)
+		assert.NoError(t, svc.Validate(password, ""))
+	}
+}
@@ -0,0 +1,366 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ix-pay/ixpay-pro/internal/config"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/entity"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/repo"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/service"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/transport/notify"
+	"github.com/ix-pay/ixpay-pro/internal/utils/encryption"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// MockCache 内存缓存 Mock 实现
+type MockCache struct {
+	data map[string]string
+	mu   sync.Mutex
+}
+
+func NewMockCache() *MockCache {
+	return &MockCache{data: make(map[string]string)}
+}
+
+func (m *MockCache) Get(key string) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	v, ok := m.data[key]
+	if !ok {
+		return "", errors.New("key not found")
+	}
+	return v, nil
+}
+
+func (m *MockCache) Set(key string, value interface{}, expiration time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	switch v := value.(type) {
+	case string:
+		m.data[key] = v
+	case []byte:
+		m.data[key] = string(v)
+	default:
+		m.data[key] = fmt.Sprintf("%v", v)
+	}
+	return nil
+}
+
+func (m *MockCache) Delete(key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.data, key)
+	return nil
+}
+
+func (m *MockCache) Exists(key string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	_, ok := m.data[key]
+	return ok, nil
+}
+
+func (m *MockCache) Incr(key string, expiration time.Duration) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	n, _ := strconv.ParseInt(m.data[key], 10, 64)
+	n++
+	m.data[key] = strconv.FormatInt(n, 10)
+	return n, nil
+}
+
+func (m *MockCache) SetNX(key string, value interface{}, expiration time.Duration) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.data[key]; ok {
+		return false, nil
+	}
+	m.data[key] = fmt.Sprintf("%v", value)
+	return true, nil
+}
+
+func (m *MockCache) CompareAndDelete(key string, expected string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if v, ok := m.data[key]; !ok || v != expected {
+		return false, nil
+	}
+	delete(m.data, key)
+	return true, nil
+}
+
+func (m *MockCache) Close() error { return nil }
+
+// MockUserRepositoryForTest 用户仓库 Mock 实现
+type MockUserRepositoryForTest struct {
+	users map[int64]*entity.User
+}
+
+func NewMockUserRepositoryForTest(users ...*entity.User) *MockUserRepositoryForTest {
+	m := &MockUserRepositoryForTest{users: make(map[int64]*entity.User)}
+	for _, u := range users {
+		m.users[u.ID] = u
+	}
+	return m
+}
+
+func (m *MockUserRepositoryForTest) find(match func(u *entity.User) bool) (*entity.User, error) {
+	for _, u := range m.users {
+		if match(u) {
+			return u, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (m *MockUserRepositoryForTest) GetByID(id int64, relations ...repo.UserRelation) (*entity.User, error) {
+	return m.find(func(u *entity.User) bool { return u.ID == id })
+}
+
+func (m *MockUserRepositoryForTest) GetByUsername(userName string) (*entity.User, error) {
+	return m.find(func(u *entity.User) bool { return u.Username == userName })
+}
+
+func (m *MockUserRepositoryForTest) GetByEmail(email string) (*entity.User, error) {
+	return m.find(func(u *entity.User) bool { return u.Email != "" && u.Email == email })
+}
+
+func (m *MockUserRepositoryForTest) GetByPhone(phone string) (*entity.User, error) {
+	return m.find(func(u *entity.User) bool { return u.Phone != "" && u.Phone == phone })
+}
+
+func (m *MockUserRepositoryForTest) GetByWechatOpenID(openID string) (*entity.User, error) {
+	return m.find(func(u *entity.User) bool { return u.WechatOpenID != "" && u.WechatOpenID == openID })
+}
+
+func (m *MockUserRepositoryForTest) Create(user *entity.User) error {
+	if user.ID == 0 {
+		user.ID = int64(len(m.users) + 1)
+	}
+	m.users[user.ID] = user
+	return nil
+}
+
+func (m *MockUserRepositoryForTest) Update(user *entity.User) error {
+	m.users[user.ID] = user
+	return nil
+}
+
+func (m *MockUserRepositoryForTest) Delete(id int64) error {
+	delete(m.users, id)
+	return nil
+}
+
+func (m *MockUserRepositoryForTest) List(page, pageSize int, filters map[string]interface{}) ([]*entity.User, int64, error) {
+	list := make([]*entity.User, 0, len(m.users))
+	for _, u := range m.users {
+		list = append(list, u)
+	}
+	return list, int64(len(list)), nil
+}
+
+func (m *MockUserRepositoryForTest) UpdateFields(id int64, updates map[string]interface{}) error {
+	u, ok := m.users[id]
+	if !ok {
+		return errors.New("record not found")
+	}
+	if v, ok := updates["password_hash"].(string); ok {
+		u.PasswordHash = v
+	}
+	if v, ok := updates["must_change_password"].(bool); ok {
+		u.MustChangePassword = v
+	}
+	if v, ok := updates["password_changed_at"].(time.Time); ok {
+		u.PasswordChangedAt = v
+	}
+	return nil
+}
+
+func (m *MockUserRepositoryForTest) SetUserSpecialPermissions(userID int64, apiIDs []int64) error {
+	return nil
+}
+
+func (m *MockUserRepositoryForTest) SetUserSpecialBtnPermissions(userID int64, btnPermIDs []int64) error {
+	return nil
+}
+
+func (m *MockUserRepositoryForTest) GetUserSpecialPermissions(userID int64) ([]*entity.API, error) {
+	return nil, nil
+}
+
+func (m *MockUserRepositoryForTest) GetUserSpecialBtnPermissions(userID int64) ([]*entity.BtnPerm, error) {
+	return nil, nil
+}
+
+// recordingSender 记录已发送消息的发送器
+type recordingSender struct {
+	messages []*notify.Message
+}
+
+func (s *recordingSender) Name() string { return "recording" }
+
+func (s *recordingSender) Send(msg *notify.Message) error {
+	s.messages = append(s.messages, msg)
+	return nil
+}
+
+var resetCodePattern = regexp.MustCompile(`验证码为 (\d+)`)
+
+func (s *recordingSender) lastCode(t *testing.T) string {
+	require.NotEmpty(t, s.messages, "未发送任何消息")
+	m := resetCodePattern.FindStringSubmatch(s.messages[len(s.messages)-1].Content)
+	require.Len(t, m, 2, "消息中未找到验证码")
+	return m[1]
+}
+
+func newTestPasswordResetService(t *testing.T, resetCfg config.PasswordResetConfig) (*service.PasswordResetService, *MockUserRepositoryForTest, *MockCache, *recordingSender) {
+	hash, err := encryption.GeneratePasswordHash("OldPassw0rd")
+	require.NoError(t, err)
+
+	users := NewMockUserRepositoryForTest(&entity.User{
+		ID:           1,
+		Username:     "alice",
+		PasswordHash: hash,
+		Email:        "alice@example.com",
+		Phone:        "13812345678",
+		Status:       1,
+	})
+	cache := NewMockCache()
+	sender := &recordingSender{}
+	cfg := &config.Config{
+		JWT:            config.JWTConfig{SecretKey: "test-secret"},
+		PasswordPolicy: config.PasswordPolicyConfig{MinLength: 8, RequireDigit: true},
+		PasswordReset:  resetCfg,
+	}
+	log := &MockLogger{}
+	policy := service.NewPasswordPolicyService(cfg, nil, log)
+	userService := service.NewUserService(users, nil, nil, nil, nil, cfg, log, cache, nil, nil, policy, nil, nil, nil, nil)
+	svc := service.NewPasswordResetService(users, userService, cache, nil, &notify.Senders{Email: sender, SMS: sender}, cfg, log)
+	return svc, users, cache, sender
+}
+
+// TestPasswordResetService_Flow 测试发送验证码并重置密码的完整流程
+func TestPasswordResetService_Flow(t *testing.T) {
+	svc, users, _, sender := newTestPasswordResetService(t, config.PasswordResetConfig{Enabled: true})
+
+	target, err := svc.SendCode("alice", "", "", "", "10.0.0.1")
+	require.NoError(t, err)
+	assert.Equal(t, "a***@example.com", target)
+	assert.Equal(t, "alice@example.com", sender.messages[0].To)
+
+	code := sender.lastCode(t)
+	assert.Len(t, code, 6)
+
+	// 密码不符合策略时验证码保留
+	assert.Error(t, svc.ResetPassword("alice", code, "short"))
+
+	require.NoError(t, svc.ResetPassword("alice", code, "NewPassw0rd"))
+	assert.NoError(t, encryption.VerifyPassword(users.users[1].PasswordHash, "NewPassw0rd"))
+
+	// 验证码一次性使用
+	assert.Error(t, svc.ResetPassword("alice", code, "OtherPassw0rd"))
+}
+
+// TestPasswordResetService_ConcurrentReset 测试并发提交同一验证码时只有一个请求能重置密码
+func TestPasswordResetService_ConcurrentReset(t *testing.T) {
+	svc, _, _, sender := newTestPasswordResetService(t, config.PasswordResetConfig{Enabled: true})
+
+	_, err := svc.SendCode("alice", "", "", "", "")
+	require.NoError(t, err)
+	code := sender.lastCode(t)
+
+	var wg sync.WaitGroup
+	var succeeded atomic.Int32
+	// 并发数不超过默认的校验次数上限，避免因错误次数过多而失败
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if svc.ResetPassword("alice", code, "NewPassw0rd") == nil {
+				succeeded.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(1), succeeded.Load())
+}
+
+// TestPasswordResetService_InactiveAccount 测试未激活账号与不存在的账号返回相同的错误，不暴露账号是否存在
+func TestPasswordResetService_InactiveAccount(t *testing.T) {
+	svc, users, _, sender := newTestPasswordResetService(t, config.PasswordResetConfig{Enabled: true})
+	users.users[1].Status = 0
+
+	_, inactiveErr := svc.SendCode("alice", "", "", "", "10.0.0.1")
+	require.Error(t, inactiveErr)
+	_, missingErr := svc.SendCode("bob", "", "", "", "10.0.0.1")
+	require.Error(t, missingErr)
+
+	assert.Equal(t, missingErr.Error(), inactiveErr.Error())
+	assert.Empty(t, sender.messages, "未激活账号不发送验证码")
+}
+
+// TestPasswordResetService_AccountLookup 测试按邮箱、手机号查找账号并选择渠道
+func TestPasswordResetService_AccountLookup(t *testing.T) {
+	testCases := []struct {
+		name         string
+		account      string
+		channel      string
+		expectTarget string
+		expectError  bool
+	}{
+		{"邮箱找回", "alice@example.com", "", "a***@example.com", false},
+		{"手机号找回默认短信", "13812345678", "", "138****5678", false},
+		{"用户名指定短信", "alice", notify.ChannelSMS, "138****5678", false},
+		{"账号不存在", "bob", "", "", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc, _, _, _ := newTestPasswordResetService(t, config.PasswordResetConfig{Enabled: true})
+			target, err := svc.SendCode(tc.account, tc.channel, "", "", "")
+			if tc.expectError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectTarget, target)
+		})
+	}
+}
+
+// TestPasswordResetService_Limits 测试发送冷却和错误次数上限
+func TestPasswordResetService_Limits(t *testing.T) {
+	svc, _, _, sender := newTestPasswordResetService(t, config.PasswordResetConfig{Enabled: true, MaxAttempts: 2})
+
+	_, err := svc.SendCode("alice", "", "", "", "")
+	require.NoError(t, err)
+
+	// 冷却期内不能重复发送
+	_, err = svc.SendCode("alice", "", "", "", "")
+	assert.Error(t, err)
+
+	code := sender.lastCode(t)
+	assert.Error(t, svc.ResetPassword("alice", "000000x", "NewPassw0rd"))
+	assert.Error(t, svc.ResetPassword("alice", "000000x", "NewPassw0rd"))
+
+	// 超过错误次数后正确验证码也失效
+	err = svc.ResetPassword("alice", code, "NewPassw0rd")
+	require.Error(t, err)
+	assert.Equal(t, "验证码错误次数过多，请重新获取", err.Error())
+}
+
+// TestPasswordResetService_Disabled 测试关闭自助找回密码
+func TestPasswordResetService_Disabled(t *testing.T) {
+	svc, _, _, _ := newTestPasswordResetService(t, config.PasswordResetConfig{Enabled: false})
+	_, err := svc.SendCode("alice", "", "", "", "")
+	assert.Error(t, err)
+}
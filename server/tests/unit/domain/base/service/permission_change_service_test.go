@@ -0,0 +1,328 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/entity"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/repo"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// memoryChangeRoleRepo 在内存角色仓库基础上支持接口分配和角色更新
+type memoryChangeRoleRepo struct {
+	*memoryDecisionRoleRepo
+	apiRepo *memoryAPIRepo
+}
+
+func (r *memoryChangeRoleRepo) AddToRole(roleID, routeID int64) error {
+	api, err := r.apiRepo.GetByID(routeID)
+	if err != nil {
+		return err
+	}
+	r.apis[roleID] = append(r.apis[roleID], api)
+	return nil
+}
+
+func (r *memoryChangeRoleRepo) RemoveFromRole(roleID, routeID int64) error {
+	apis := make([]*entity.API, 0, len(r.apis[roleID]))
+	for _, api := range r.apis[roleID] {
+		if api.ID != routeID {
+			apis = append(apis, api)
+		}
+	}
+	r.apis[roleID] = apis
+	return nil
+}
+
+func (r *memoryChangeRoleRepo) GetUsersByRole(roleID int64) ([]*entity.User, error) {
+	users := make([]*entity.User, 0)
+	for userID := range r.userRoles {
+		for _, id := range r.userRoles[userID] {
+			if id == roleID {
+				users = append(users, &entity.User{ID: userID})
+			}
+		}
+	}
+	return users, nil
+}
+
+func (r *memoryChangeRoleRepo) GetMenusByRole(roleID int64) ([]*entity.Menu, error) {
+	return nil, nil
+}
+
+func (r *memoryChangeRoleRepo) Update(role *entity.Role) error {
+	r.roles[role.ID] = role
+	return nil
+}
+
+// memoryPermissionChangeRepo 内存权限变更申请仓库，Apply 在 roleRepo 上执行变更，applyErr 模拟事务失败
+type memoryPermissionChangeRepo struct {
+	requests map[int64]*entity.PermissionChangeRequest
+	nextID   int64
+	roleRepo *memoryChangeRoleRepo
+	applyErr error
+}
+
+func (r *memoryPermissionChangeRepo) GetByID(id int64) (*entity.PermissionChangeRequest, error) {
+	if request, ok := r.requests[id]; ok {
+		copied := *request
+		return &copied, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *memoryPermissionChangeRepo) Create(request *entity.PermissionChangeRequest) error {
+	r.nextID++
+	request.ID = r.nextID
+	copied := *request
+	r.requests[request.ID] = &copied
+	return nil
+}
+
+func (r *memoryPermissionChangeRepo) Update(request *entity.PermissionChangeRequest) error {
+	copied := *request
+	r.requests[request.ID] = &copied
+	return nil
+}
+
+func (r *memoryPermissionChangeRepo) Apply(request *entity.PermissionChangeRequest) error {
+	if r.applyErr != nil {
+		return r.applyErr
+	}
+
+	var add, remove func(roleID, id int64) error
+	switch request.ChangeType {
+	case entity.PermissionChangeApprovalRequired:
+		r.roleRepo.roles[request.RoleID].ApprovalRequired = false
+	case entity.PermissionChangeRoleUsers:
+		add, remove = r.roleRepo.AddUserToRole, r.roleRepo.RemoveUserFromRole
+	case entity.PermissionChangeRoleAPIs:
+		add, remove = r.roleRepo.AddToRole, r.roleRepo.RemoveFromRole
+	default:
+		return errors.New("不支持的变更类型")
+	}
+	for _, id := range request.AddedIDs {
+		if err := add(request.RoleID, id); err != nil {
+			return err
+		}
+	}
+	for _, id := range request.RemovedIDs {
+		if err := remove(request.RoleID, id); err != nil {
+			return err
+		}
+	}
+	return r.Update(request)
+}
+
+func (r *memoryPermissionChangeRepo) List(page, pageSize int, filters map[string]interface{}) ([]*entity.PermissionChangeRequest, int64, error) {
+	requests := make([]*entity.PermissionChangeRequest, 0, len(r.requests))
+	for _, request := range r.requests {
+		requests = append(requests, request)
+	}
+	return requests, int64(len(requests)), nil
+}
+
+// memoryPermissionAuditLogRepo 内存权限审计日志仓库
+type memoryPermissionAuditLogRepo struct {
+	logs []*entity.PermissionAuditLog
+}
+
+func (r *memoryPermissionAuditLogRepo) Create(log *entity.PermissionAuditLog) error {
+	r.logs = append(r.logs, log)
+	return nil
+}
+
+var (
+	_ repo.PermissionChangeRequestRepository = (*memoryPermissionChangeRepo)(nil)
+	_ repo.PermissionAuditLogRepository      = (*memoryPermissionAuditLogRepo)(nil)
+)
+
+// allowChangeReview 为 viewer（ID 1）授予审批和拒绝接口，alice 通过 editor 继承获得审批权限
+func allowChangeReview(f *decisionFixture) {
+	for _, route := range []string{"/api/admin/permission-changes/:id/approve", "/api/admin/permission-changes/:id/reject"} {
+		api := &entity.API{Path: route, Method: "POST", AuthType: 1}
+		_ = f.apiRepo.Create(api)
+		f.roleRepo.apis[1] = append(f.roleRepo.apis[1], api)
+	}
+}
+
+// permissionChangeFixture 权限变更审批测试数据
+// editor（ID 2）开启变更审批，bob 提交变更，有审批权限的 alice 审批
+type permissionChangeFixture struct {
+	*roleGrantFixture
+	changeRoleRepo *memoryChangeRoleRepo
+	changeRepo     *memoryPermissionChangeRepo
+	auditRepo      *memoryPermissionAuditLogRepo
+	sod            *service.SoDService
+	roles          *service.RoleService
+	changes        *service.PermissionChangeService
+}
+
+func newPermissionChangeFixture(t *testing.T) *permissionChangeFixture {
+	f := newRoleGrantFixture()
+	roleRepo := &memoryChangeRoleRepo{memoryDecisionRoleRepo: f.roleRepo, apiRepo: f.apiRepo}
+	changeRepo := &memoryPermissionChangeRepo{requests: make(map[int64]*entity.PermissionChangeRequest), roleRepo: roleRepo}
+	auditRepo := &memoryPermissionAuditLogRepo{}
+	allowChangeReview(f.decisionFixture)
+	sod, _ := newTestSoDService(roleRepo)
+	roles := service.NewRoleService(roleRepo, f.userRepo, nil, f.apiRepo, nil, nil, changeRepo, sod, &MockLogger{})
+
+	require.NoError(t, roles.SetRoleApprovalRequired(2, true, 200))
+	require.True(t, f.roleRepo.roles[2].ApprovalRequired, "开启审批立即生效")
+
+	return &permissionChangeFixture{
+		roleGrantFixture: f,
+		changeRoleRepo:   roleRepo,
+		changeRepo:       changeRepo,
+		auditRepo:        auditRepo,
+		sod:              sod,
+		roles:            roles,
+		changes:          service.NewPermissionChangeService(changeRepo, roles, nil, f.userRepo, roleRepo, f.svc, auditRepo, &MockLogger{}),
+	}
+}
+
+// pendingRequest 断言变更已提交审批并返回申请
+func pendingRequest(t *testing.T, err error) *entity.PermissionChangeRequest {
+	var pending *service.PendingApprovalError
+	require.True(t, errors.As(err, &pending), "变更应提交审批，实际错误：%v", err)
+	return pending.Request
+}
+
+// TestPermissionChangeService_Approve 测试需要审批的角色变更：提交时不生效，申请人不能自审，他人通过后生效并写入审计日志
+func TestPermissionChangeService_Approve(t *testing.T) {
+	f := newPermissionChangeFixture(t)
+	deleteAPI := f.apiRepo.find("DELETE", "/api/admin/user/:id")
+
+	request := pendingRequest(t, f.roles.AssignAPIToRole(2, deleteAPI.ID, 200))
+	assert.Equal(t, entity.PermissionChangeRoleAPIs, request.ChangeType)
+	assert.Equal(t, []int64{deleteAPI.ID}, request.AddedIDs)
+	assert.Empty(t, request.RemovedIDs)
+	assert.Len(t, f.roleRepo.apis[2], 1, "提交后尚未生效")
+
+	_, err := f.changes.ApproveChange(request.ID, 200, "")
+	assert.Error(t, err, "申请人不能审批自己的申请")
+
+	approved, err := f.changes.ApproveChange(request.ID, 100, "同意")
+	require.NoError(t, err)
+	assert.Equal(t, entity.PermissionChangeStatusApproved, approved.Status)
+	assert.Equal(t, int64(100), approved.ReviewedBy)
+	assert.Len(t, f.roleRepo.apis[2], 2, "审批通过后生效")
+
+	_, err = f.changes.ApproveChange(request.ID, 100, "")
+	assert.Error(t, err, "已处理的申请不能再次审批")
+
+	require.Len(t, f.auditRepo.logs, 1)
+	log := f.auditRepo.logs[0]
+	assert.Equal(t, int64(100), log.OperatorID)
+	assert.Equal(t, "alice", log.OperatorName)
+	assert.Equal(t, "role", log.TargetType)
+	assert.Equal(t, int64(2), log.TargetID)
+	assert.Contains(t, log.AfterData, `"requestedBy":200`)
+
+	// 未开启审批的角色立即生效
+	require.NoError(t, f.roles.AssignUserToRole(4, 200, 100))
+	assert.Contains(t, f.roleRepo.userRoles[200], int64(4))
+	assert.Len(t, f.changeRepo.requests, 1)
+}
+
+// TestPermissionChangeService_Reject 测试拒绝的变更不生效、不写审计日志，关闭审批本身需要审批
+func TestPermissionChangeService_Reject(t *testing.T) {
+	f := newPermissionChangeFixture(t)
+
+	request := pendingRequest(t, f.roles.RevokeUserFromRole(2, 100, 200))
+	assert.Equal(t, entity.PermissionChangeRoleUsers, request.ChangeType)
+	assert.Equal(t, []int64{100}, request.BeforeIDs)
+	assert.Empty(t, request.AfterIDs())
+
+	rejected, err := f.changes.RejectChange(request.ID, 100, "不同意")
+	require.NoError(t, err)
+	assert.Equal(t, entity.PermissionChangeStatusRejected, rejected.Status)
+	assert.Equal(t, []int64{2}, f.roleRepo.userRoles[100], "拒绝后用户仍在角色中")
+	assert.Empty(t, f.auditRepo.logs)
+
+	// 不会改变关联的变更不生成申请
+	assert.NoError(t, f.roles.AssignUserToRole(2, 100, 200))
+	assert.Len(t, f.changeRepo.requests, 1)
+
+	request = pendingRequest(t, f.roles.SetRoleApprovalRequired(2, false, 200))
+	assert.Equal(t, entity.PermissionChangeApprovalRequired, request.ChangeType)
+	assert.True(t, f.roleRepo.roles[2].ApprovalRequired, "关闭审批在通过前不生效")
+
+	_, err = f.changes.ApproveChange(request.ID, 100, "")
+	require.NoError(t, err)
+	assert.False(t, f.roleRepo.roles[2].ApprovalRequired)
+	assert.Len(t, f.auditRepo.logs, 1)
+}
+
+// TestPermissionChangeService_SaveRolePermissions 测试保存需要审批的角色的权限时只提交差异申请，不直接生效
+func TestPermissionChangeService_SaveRolePermissions(t *testing.T) {
+	f := newPermissionChangeFixture(t)
+	rolePermissions := service.NewRolePermissionService(nil, f.changeRoleRepo, nil, nil, f.apiRepo, NewMockCache(), f.roles, &MockLogger{})
+	deleteAPI := f.apiRepo.find("DELETE", "/api/admin/user/:id")
+	listAPI := f.roleRepo.apis[2][0]
+	var btnPermIDs []int64
+	for _, btnPerm := range f.roleRepo.buttons[2] {
+		btnPermIDs = append(btnPermIDs, btnPerm.ID)
+	}
+
+	request := pendingRequest(t, rolePermissions.SaveRolePermissions(2, nil, btnPermIDs, []int64{deleteAPI.ID}, "200"))
+	assert.Equal(t, entity.PermissionChangeRoleAPIs, request.ChangeType)
+	assert.Equal(t, []int64{deleteAPI.ID}, request.AddedIDs)
+	assert.Equal(t, []int64{listAPI.ID}, request.RemovedIDs)
+	assert.Equal(t, []*entity.API{listAPI}, f.roleRepo.apis[2], "提交后尚未生效")
+	assert.Len(t, f.changeRepo.requests, 1, "没有差异的菜单和按钮权限不生成申请")
+}
+
+// TestPermissionChangeService_SetUserAuthorities 测试为用户设置需要审批的角色时提交申请，其余角色直接分配
+func TestPermissionChangeService_SetUserAuthorities(t *testing.T) {
+	f := newPermissionChangeFixture(t)
+	users := service.NewUserService(f.userRepo, nil, f.roles, nil, nil, nil, &MockLogger{}, NewMockCache(), nil, nil, nil, nil, nil, nil, f.sod)
+
+	request := pendingRequest(t, users.SetUserAuthorities(200, []int64{4, 2}, 100))
+	assert.Equal(t, entity.PermissionChangeRoleUsers, request.ChangeType)
+	assert.Equal(t, int64(2), request.RoleID)
+	assert.Equal(t, []int64{4}, f.roleRepo.userRoles[200], "不需要审批的角色直接分配")
+
+	request = pendingRequest(t, users.SetUserAuthority(200, 2, 100))
+	assert.Equal(t, []int64{200}, request.AddedIDs)
+	assert.Equal(t, []int64{4}, f.roleRepo.userRoles[200])
+}
+
+// TestPermissionChangeService_ReviewPermission 测试没有审批接口权限的用户不能审批或拒绝
+func TestPermissionChangeService_ReviewPermission(t *testing.T) {
+	f := newPermissionChangeFixture(t)
+	f.userRepo.users[300] = &entity.User{ID: 300, Username: "carol", Status: 1}
+	f.roleRepo.userRoles[300] = []int64{4}
+
+	request := pendingRequest(t, f.roles.RevokeUserFromRole(2, 100, 200))
+	_, err := f.changes.ApproveChange(request.ID, 300, "")
+	assert.Error(t, err, "auditor 没有审批接口的权限")
+	_, err = f.changes.RejectChange(request.ID, 300, "")
+	assert.Error(t, err, "auditor 没有拒绝接口的权限")
+	assert.True(t, f.changeRepo.requests[request.ID].IsPending())
+	assert.Equal(t, []int64{2}, f.roleRepo.userRoles[100])
+}
+
+// TestPermissionChangeService_ApplyFailed 测试变更写入失败时申请保持待审批，不写审计日志，之后可以再次审批
+func TestPermissionChangeService_ApplyFailed(t *testing.T) {
+	f := newPermissionChangeFixture(t)
+	deleteAPI := f.apiRepo.find("DELETE", "/api/admin/user/:id")
+	request := pendingRequest(t, f.roles.AssignAPIToRole(2, deleteAPI.ID, 200))
+
+	f.changeRepo.applyErr = errors.New("事务回滚")
+	_, err := f.changes.ApproveChange(request.ID, 100, "")
+	assert.Error(t, err)
+	assert.True(t, f.changeRepo.requests[request.ID].IsPending(), "失败时申请保持待审批")
+	assert.Len(t, f.roleRepo.apis[2], 1)
+	assert.Empty(t, f.auditRepo.logs)
+
+	f.changeRepo.applyErr = nil
+	approved, err := f.changes.ApproveChange(request.ID, 100, "")
+	require.NoError(t, err)
+	assert.Equal(t, entity.PermissionChangeStatusApproved, f.changeRepo.requests[approved.ID].Status)
+	assert.Len(t, f.roleRepo.apis[2], 2)
+}
@@ -0,0 +1,306 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/entity"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/repo"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/service"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/persistence/cache"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/persistence/database"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/security/abac"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// memoryDecisionRoleRepo 内存角色仓库，记录角色授予的 API、按钮和用户角色分配
+type memoryDecisionRoleRepo struct {
+	repo.RoleRepository
+	roles     map[int64]*entity.Role
+	apis      map[int64][]*entity.API
+	buttons   map[int64][]*entity.BtnPerm
+	userRoles map[int64][]int64
+	loads     int // GetsByRole 调用次数，用于验证缓存命中
+}
+
+func newMemoryDecisionRoleRepo(roles ...*entity.Role) *memoryDecisionRoleRepo {
+	r := &memoryDecisionRoleRepo{
+		roles:     make(map[int64]*entity.Role),
+		apis:      make(map[int64][]*entity.API),
+		buttons:   make(map[int64][]*entity.BtnPerm),
+		userRoles: make(map[int64][]int64),
+	}
+	for _, role := range roles {
+		r.roles[role.ID] = role
+	}
+	return r
+}
+
+func (r *memoryDecisionRoleRepo) GetByID(id int64, relations ...repo.RoleRelation) (*entity.Role, error) {
+	if role, ok := r.roles[id]; ok {
+		return role, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *memoryDecisionRoleRepo) GetByCode(code string) (*entity.Role, error) {
+	for _, role := range r.roles {
+		if role.Code == code {
+			return role, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *memoryDecisionRoleRepo) GetAllRoles() ([]*entity.Role, error) {
+	roles := make([]*entity.Role, 0, len(r.roles))
+	for _, role := range r.roles {
+		roles = append(roles, role)
+	}
+	return roles, nil
+}
+
+func (r *memoryDecisionRoleRepo) GetRolesByUser(userID int64) ([]*entity.Role, error) {
+	roles := make([]*entity.Role, 0)
+	for _, id := range r.userRoles[userID] {
+		roles = append(roles, r.roles[id])
+	}
+	return roles, nil
+}
+
+func (r *memoryDecisionRoleRepo) GetsByRole(roleID int64) ([]*entity.API, error) {
+	r.loads++
+	return r.apis[roleID], nil
+}
+
+func (r *memoryDecisionRoleRepo) GetBtnPermsByRole(roleID int64) ([]*entity.BtnPerm, error) {
+	return r.buttons[roleID], nil
+}
+
+// memoryDecisionGroupRepo 内存权限组仓库
+type memoryDecisionGroupRepo struct {
+	repo.PermissionGroupRepository
+	groups map[int64][]*entity.PermissionGroup
+	apis   map[int64][]*entity.API
+}
+
+func (r *memoryDecisionGroupRepo) GetGroupsByRole(roleID int64) ([]*entity.PermissionGroup, error) {
+	return r.groups[roleID], nil
+}
+
+func (r *memoryDecisionGroupRepo) GetAPIsByGroup(groupID int64) ([]*entity.API, error) {
+	return r.apis[groupID], nil
+}
+
+func (r *memoryDecisionGroupRepo) GetBtnPermsByGroup(groupID int64) ([]*entity.BtnPerm, error) {
+	return nil, nil
+}
+
+// memoryDecisionRuleRepo 内存权限规则仓库
+type memoryDecisionRuleRepo struct {
+	repo.PermissionRuleRepository
+	rules []*entity.PermissionRule
+}
+
+func (r *memoryDecisionRuleRepo) GetRulesByStatus(status int) ([]*entity.PermissionRule, error) {
+	rules := make([]*entity.PermissionRule, 0)
+	for _, rule := range r.rules {
+		if rule.Status == status {
+			rules = append(rules, rule)
+		}
+	}
+	return rules, nil
+}
+
+// decisionFixture 权限判定测试数据
+// editor 继承 viewer，viewer 通过权限组获得 /api/admin/notice 的访问权限
+type decisionFixture struct {
+	roleRepo  *memoryDecisionRoleRepo
+	groupRepo *memoryDecisionGroupRepo
+	apiRepo   *memoryAPIRepo
+	userRepo  *MockUserRepositoryForTest
+	ruleRepo  *memoryDecisionRuleRepo
+	cache     *MockCache
+	bus       *cache.InvalidationBus
+	svc       *service.PermissionDecisionService
+}
+
+func newDecisionFixture() *decisionFixture {
+	viewer := &entity.Role{ID: 1, Code: "viewer", Status: 1}
+	editor := &entity.Role{ID: 2, Code: "editor", Status: 1, ParentID: 1}
+	disabled := &entity.Role{ID: 3, Code: "disabled", Status: 0}
+	roleRepo := newMemoryDecisionRoleRepo(viewer, editor, disabled)
+	roleRepo.apis[1] = []*entity.API{{Path: "/api/admin/user/:id", Method: "GET"}}
+	roleRepo.apis[2] = []*entity.API{{Path: "/api/admin/user/:id", Method: "PUT"}}
+	roleRepo.apis[3] = []*entity.API{{Path: "/api/admin/user/:id", Method: "DELETE"}}
+	roleRepo.buttons[1] = []*entity.BtnPerm{{Code: "user:view", Status: 1}}
+	roleRepo.buttons[2] = []*entity.BtnPerm{{Code: "user:edit", Status: 1}, {Code: "user:export", Status: 0}}
+	roleRepo.userRoles[100] = []int64{2}
+
+	groupRepo := &memoryDecisionGroupRepo{
+		groups: map[int64][]*entity.PermissionGroup{1: {{ID: 10}}},
+		apis:   map[int64][]*entity.API{10: {{Path: "/api/admin/notice", Method: "GET"}}},
+	}
+	apiRepo := newMemoryAPIRepo(
+		&entity.API{Path: "/api/admin/user/:id", Method: "GET", AuthType: 1},
+		&entity.API{Path: "/api/admin/user/:id", Method: "PUT", AuthType: 1},
+		&entity.API{Path: "/api/admin/user/:id", Method: "DELETE", AuthType: 1},
+		&entity.API{Path: "/api/admin/notice", Method: "GET", AuthType: 1},
+		&entity.API{Path: "/api/admin/profile", Method: "GET", AuthType: 0},
+	)
+	userRepo := NewMockUserRepositoryForTest(&entity.User{ID: 100, Username: "alice", Status: 1})
+	ruleRepo := &memoryDecisionRuleRepo{}
+	cacheClient := NewMockCache()
+	bus := cache.NewInvalidationBus(&MockLogger{})
+
+	return &decisionFixture{
+		roleRepo:  roleRepo,
+		groupRepo: groupRepo,
+		apiRepo:   apiRepo,
+		userRepo:  userRepo,
+		ruleRepo:  ruleRepo,
+		cache:     cacheClient,
+		bus:       bus,
+		svc:       service.NewPermissionDecisionService(roleRepo, userRepo, apiRepo, groupRepo, ruleRepo, cacheClient, bus, &MockLogger{}),
+	}
+}
+
+// TestPermissionDecisionService_Decide 测试 RBAC 判定：继承角色、权限组、路由模板和无需授权的 API
+func TestPermissionDecisionService_Decide(t *testing.T) {
+	f := newDecisionFixture()
+	// alice 同时持有 viewer 和已禁用的角色，按当前角色分别判定；root 是管理员
+	f.roleRepo.userRoles[100] = []int64{2, 1, 3}
+	f.roleRepo.roles[5] = &entity.Role{ID: 5, Code: "admin", Status: 1}
+	f.userRepo.users[300] = &entity.User{ID: 300, Username: "root", Status: 1}
+	f.roleRepo.userRoles[300] = []int64{5}
+
+	testCases := []struct {
+		name     string
+		req      service.DecisionRequest
+		allowed  bool
+		reason   string
+		route    string
+		buttons  []string
+		register bool
+	}{
+		{"角色直接授予", service.DecisionRequest{UserID: 100, RoleCode: "editor", Method: "PUT", Path: "/api/admin/user/7"}, true, entity.DecisionReasonRBAC, "/api/admin/user/:id", []string{"user:edit", "user:view"}, true},
+		{"继承父角色", service.DecisionRequest{UserID: 100, RoleCode: "editor", Method: "GET", Path: "/api/admin/user/7"}, true, entity.DecisionReasonRBAC, "/api/admin/user/:id", []string{"user:edit", "user:view"}, true},
+		{"父角色的权限组", service.DecisionRequest{UserID: 100, RoleCode: "editor", Method: "GET", Path: "/api/admin/notice"}, true, entity.DecisionReasonRBAC, "/api/admin/notice", []string{"user:edit", "user:view"}, true},
+		{"调用方传入路由模板", service.DecisionRequest{UserID: 100, RoleCode: "editor", Method: "PUT", Path: "/api/admin/user/7", Route: "/api/admin/user/:id"}, true, entity.DecisionReasonRBAC, "/api/admin/user/:id", []string{"user:edit", "user:view"}, true},
+		{"子角色权限不向上继承", service.DecisionRequest{UserID: 100, RoleCode: "viewer", Method: "PUT", Path: "/api/admin/user/7"}, false, entity.DecisionReasonNoPermission, "/api/admin/user/:id", []string{"user:view"}, true},
+		{"禁用角色没有权限", service.DecisionRequest{UserID: 100, RoleCode: "disabled", Method: "DELETE", Path: "/api/admin/user/7"}, false, entity.DecisionReasonNoPermission, "/api/admin/user/:id", []string{}, true},
+		{"当前角色不是用户的角色时按无角色判定", service.DecisionRequest{UserID: 100, RoleCode: "ghost", Method: "GET", Path: "/api/admin/user/7"}, false, entity.DecisionReasonNoPermission, "/api/admin/user/:id", []string{}, true},
+		{"伪造的管理员当前角色", service.DecisionRequest{UserID: 100, RoleCode: "admin", Method: "DELETE", Path: "/api/admin/user/7"}, false, entity.DecisionReasonNoPermission, "/api/admin/user/:id", []string{}, true},
+		{"未指定当前角色时按无角色判定", service.DecisionRequest{UserID: 100, Method: "GET", Path: "/api/admin/user/7"}, false, entity.DecisionReasonNoPermission, "/api/admin/user/:id", []string{}, true},
+		{"用户不存在没有权限", service.DecisionRequest{UserID: 999, RoleCode: "editor", Method: "GET", Path: "/api/admin/user/7"}, false, entity.DecisionReasonNoPermission, "/api/admin/user/:id", []string{}, true},
+		{"用户不存在可访问无需授权的 API", service.DecisionRequest{UserID: 999, Method: "GET", Path: "/api/admin/profile"}, true, entity.DecisionReasonPublic, "/api/admin/profile", []string{}, true},
+		{"服务账号只按绑定角色判定", service.DecisionRequest{UserID: 100, RoleCode: "viewer", ServiceAccount: true, Method: "PUT", Path: "/api/admin/user/7"}, false, entity.DecisionReasonNoPermission, "/api/admin/user/:id", []string{"user:view"}, true},
+		{"服务账号的角色授予", service.DecisionRequest{RoleCode: "viewer", ServiceAccount: true, Method: "GET", Path: "/api/admin/user/7"}, true, entity.DecisionReasonRBAC, "/api/admin/user/:id", []string{"user:view"}, true},
+		{"无需授权的 API", service.DecisionRequest{UserID: 100, RoleCode: "viewer", Method: "GET", Path: "/api/admin/profile"}, true, entity.DecisionReasonPublic, "/api/admin/profile", []string{"user:view"}, true},
+		{"未登记的 API 需要授权", service.DecisionRequest{UserID: 100, RoleCode: "editor", Method: "GET", Path: "/api/admin/unknown"}, false, entity.DecisionReasonNoPermission, "/api/admin/unknown", []string{"user:edit", "user:view"}, false},
+		{"管理员", service.DecisionRequest{UserID: 300, RoleCode: "admin", Method: "DELETE", Path: "/api/admin/user/7"}, true, entity.DecisionReasonAdmin, "/api/admin/user/:id", nil, true},
+		{"按用户全部角色判定", service.DecisionRequest{UserID: 100, AllRoles: true, Method: "GET", Path: "/api/admin/user/7"}, true, entity.DecisionReasonRBAC, "/api/admin/user/:id", []string{"user:edit", "user:view"}, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			decision, err := f.svc.Decide(tc.req)
+			require.NoError(t, err)
+			assert.Equal(t, tc.allowed, decision.Allowed)
+			assert.Equal(t, tc.reason, decision.Reason)
+			assert.Equal(t, tc.route, decision.Route)
+			assert.Equal(t, tc.register, decision.Registered)
+			if tc.buttons != nil {
+				assert.Equal(t, tc.buttons, decision.Buttons)
+			}
+		})
+	}
+}
+
+// TestPermissionDecisionService_Rules 测试 ABAC 规则：拒绝规则优先于管理员、RBAC 和无需授权的 API，允许规则补充 RBAC
+func TestPermissionDecisionService_Rules(t *testing.T) {
+	f := newDecisionFixture()
+	f.ruleRepo.rules = []*entity.PermissionRule{
+		{ID: 1, Name: "禁止外网访问", Effect: entity.PermissionEffectDeny, APIPath: "/api/admin/*", Method: "*", Status: 1,
+			Conditions: `{"not":{"attr":"env.ip","op":"cidr","value":["10.0.0.0/8"]}}`},
+		{ID: 2, Name: "允许删除用户", Effect: entity.PermissionEffectAllow, APIPath: "/api/admin/user/*", Method: "DELETE", Status: 1,
+			Conditions: `{"attr":"resource.route","op":"eq","value":"/api/admin/user/:id"}`},
+	}
+
+	internal := abac.Attributes{"env.ip": "10.1.2.3"}
+	external := abac.Attributes{"env.ip": "8.8.8.8"}
+
+	decision, err := f.svc.Decide(service.DecisionRequest{UserID: 100, RoleCode: "editor", Method: "PUT", Path: "/api/admin/user/7", Attributes: external})
+	require.NoError(t, err)
+	assert.False(t, decision.Allowed, "拒绝规则优先于 RBAC 授权")
+	assert.Equal(t, entity.DecisionReasonRuleDeny, decision.Reason)
+	require.NotNil(t, decision.Rule)
+	assert.Equal(t, int64(1), decision.Rule.ID)
+
+	decision, err = f.svc.Decide(service.DecisionRequest{UserID: 100, RoleCode: "editor", Method: "GET", Path: "/api/admin/profile", Attributes: external})
+	require.NoError(t, err)
+	assert.False(t, decision.Allowed, "拒绝规则优先于无需授权的 API")
+
+	decision, err = f.svc.Decide(service.DecisionRequest{UserID: 100, RoleCode: "editor", Method: "PUT", Path: "/api/admin/user/7", Attributes: internal})
+	require.NoError(t, err)
+	assert.True(t, decision.Allowed)
+	assert.Equal(t, entity.DecisionReasonRBAC, decision.Reason)
+
+	decision, err = f.svc.Decide(service.DecisionRequest{UserID: 100, RoleCode: "editor", Method: "DELETE", Path: "/api/admin/user/7", Attributes: internal})
+	require.NoError(t, err)
+	assert.True(t, decision.Allowed, "允许规则补充 RBAC 未授予的权限")
+	assert.Equal(t, entity.DecisionReasonRuleAllow, decision.Reason)
+	require.NotNil(t, decision.Rule)
+	assert.Equal(t, int64(2), decision.Rule.ID)
+
+	// 拒绝规则对管理员同样生效
+	f.roleRepo.roles[5] = &entity.Role{ID: 5, Code: "admin", Status: 1}
+	f.userRepo.users[300] = &entity.User{ID: 300, Username: "root", Status: 1}
+	f.roleRepo.userRoles[300] = []int64{5}
+	decision, err = f.svc.Decide(service.DecisionRequest{UserID: 300, RoleCode: "admin", Method: "DELETE", Path: "/api/admin/user/7", Attributes: external})
+	require.NoError(t, err)
+	assert.False(t, decision.Allowed, "拒绝规则优先于管理员")
+	assert.Equal(t, entity.DecisionReasonRuleDeny, decision.Reason)
+
+	decision, err = f.svc.Decide(service.DecisionRequest{UserID: 300, RoleCode: "admin", Method: "DELETE", Path: "/api/admin/user/7", Attributes: internal})
+	require.NoError(t, err)
+	assert.True(t, decision.Allowed)
+	assert.Equal(t, entity.DecisionReasonAdmin, decision.Reason)
+}
+
+// TestPermissionDecisionService_Invalidate 测试权限缓存命中以及表变更和失效广播后重新计算
+func TestPermissionDecisionService_Invalidate(t *testing.T) {
+	f := newDecisionFixture()
+	f.roleRepo.userRoles[100] = []int64{2, 1}
+	req := service.DecisionRequest{UserID: 100, RoleCode: "viewer", Method: "PUT", Path: "/api/admin/user/7"}
+
+	decision, err := f.svc.Decide(req)
+	require.NoError(t, err)
+	assert.False(t, decision.Allowed)
+	loads := f.roleRepo.loads
+
+	// 权限变更前命中缓存，不再查询数据库
+	f.roleRepo.apis[1] = append(f.roleRepo.apis[1], &entity.API{Path: "/api/admin/user/:id", Method: "PUT"})
+	decision, err = f.svc.Decide(req)
+	require.NoError(t, err)
+	assert.False(t, decision.Allowed, "失效前使用缓存的权限集合")
+	assert.Equal(t, loads, f.roleRepo.loads)
+
+	// 表变更后重新计算
+	f.svc.OnTableChange(database.TableChange{Table: "base_role_api_routes"})
+	decision, err = f.svc.Decide(req)
+	require.NoError(t, err)
+	assert.True(t, decision.Allowed, "表变更后重新计算权限集合")
+	assert.Greater(t, f.roleRepo.loads, loads)
+
+	// 其他实例更新版本号后发布的失效广播同样清除进程内缓存
+	f.roleRepo.apis[1] = f.roleRepo.apis[1][:1]
+	version, err := f.cache.Incr("perm:version", time.Hour)
+	require.NoError(t, err)
+	require.NoError(t, f.bus.Publish(cache.InvalidationMessage{Topic: "permission", Version: version, Reason: "其他实例"}))
+	decision, err = f.svc.Decide(req)
+	require.NoError(t, err)
+	assert.False(t, decision.Allowed, "失效广播后重新计算权限集合")
+}
@@ -0,0 +1,177 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/entity"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/service"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/security/abac"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func (r *memoryAPIRepo) GetByID(id int64) (*entity.API, error) {
+	if api, ok := r.apis[id]; ok {
+		return api, nil
+	}
+	return nil, errors.New("record not found")
+}
+
+func newExplainService(f *decisionFixture) *service.PermissionExplainService {
+	return service.NewPermissionExplainService(f.roleRepo, f.userRepo, f.apiRepo, nil, f.groupRepo, f.ruleRepo, f.svc, &MockLogger{})
+}
+
+// TestPermissionExplainService_Explain 测试解释 API 访问的判定过程：继承角色、权限组、ABAC 条件求值和缓存结果
+func TestPermissionExplainService_Explain(t *testing.T) {
+	f := newDecisionFixture()
+	f.ruleRepo.rules = []*entity.PermissionRule{
+		{ID: 1, Name: "禁止外网访问", Effect: entity.PermissionEffectDeny, APIPath: "/api/admin/*", Method: "*", Status: 1,
+			Conditions: `{"not":{"attr":"env.ip","op":"cidr","value":["10.0.0.0/8"]}}`},
+		{ID: 2, Name: "其他路径", Effect: entity.PermissionEffectAllow, APIPath: "/api/admin/notice", Method: "GET", Status: 1},
+	}
+	explainService := newExplainService(f)
+
+	explanation, err := explainService.Explain(service.PermissionExplainRequest{
+		UserID: 100, RoleCode: "editor", Method: "get", Path: "/api/admin/user/7",
+		Attributes: abac.Attributes{"env.ip": "10.1.2.3"},
+	})
+	require.NoError(t, err)
+	assert.True(t, explanation.Allowed)
+	assert.Equal(t, entity.DecisionReasonRBAC, explanation.Reason)
+	assert.Equal(t, "GET", explanation.Method)
+	assert.Equal(t, "/api/admin/user/:id", explanation.Route)
+	assert.True(t, explanation.Registered)
+	assert.False(t, explanation.Simulated)
+
+	require.Len(t, explanation.Roles, 2)
+	assert.Equal(t, "editor", explanation.Roles[0].Code)
+	assert.Equal(t, service.RoleSourceCurrent, explanation.Roles[0].Source)
+	assert.False(t, explanation.Roles[0].Granted, "editor 未直接授予 GET")
+	assert.Equal(t, "viewer", explanation.Roles[1].Code)
+	assert.Equal(t, service.RoleSourceInherited, explanation.Roles[1].Source)
+	assert.Equal(t, "editor", explanation.Roles[1].InheritedFrom)
+	assert.True(t, explanation.Roles[1].Granted, "viewer 直接授予 GET")
+	require.Len(t, explanation.Roles[1].Groups, 1)
+	assert.False(t, explanation.Roles[1].Groups[0].Granted)
+
+	require.Len(t, explanation.Rules, 1, "只列出路径和方法匹配的规则")
+	rule := explanation.Rules[0]
+	assert.False(t, rule.Matched)
+	require.NotNil(t, rule.Condition)
+	assert.Equal(t, abac.TraceKindNot, rule.Condition.Kind)
+	require.Len(t, rule.Condition.Children, 1)
+	assert.True(t, rule.Condition.Children[0].Result)
+	assert.Equal(t, "10.1.2.3", rule.Condition.Children[0].Actual)
+
+	require.NotNil(t, explanation.Cached)
+	assert.Equal(t, explanation.Allowed, explanation.Cached.Allowed)
+	assert.Equal(t, explanation.Reason, explanation.Cached.Reason)
+
+	// 外网访问被拒绝规则拦截
+	explanation, err = explainService.Explain(service.PermissionExplainRequest{
+		UserID: 100, RoleCode: "editor", Method: "GET", Path: "/api/admin/user/7",
+		Attributes: abac.Attributes{"env.ip": "8.8.8.8"},
+	})
+	require.NoError(t, err)
+	assert.False(t, explanation.Allowed)
+	assert.Equal(t, entity.DecisionReasonRuleDeny, explanation.Reason)
+	assert.Equal(t, int64(1), explanation.RuleID)
+	assert.True(t, explanation.Rules[0].Matched)
+	assert.Equal(t, explanation.Allowed, explanation.Cached.Allowed)
+
+	_, err = explainService.Explain(service.PermissionExplainRequest{UserID: 100, Method: "GET"})
+	assert.Error(t, err, "缺少路径和按钮权限编码")
+}
+
+// TestPermissionExplainService_Button 测试解释按钮权限：继承角色授予、禁用的按钮不授予
+func TestPermissionExplainService_Button(t *testing.T) {
+	f := newDecisionFixture()
+	explainService := newExplainService(f)
+
+	explanation, err := explainService.Explain(service.PermissionExplainRequest{UserID: 100, RoleCode: "editor", ButtonCode: "user:view"})
+	require.NoError(t, err)
+	assert.True(t, explanation.Allowed)
+	assert.Equal(t, entity.DecisionReasonRBAC, explanation.Reason)
+	require.Len(t, explanation.Roles, 2)
+	assert.True(t, explanation.Roles[1].Granted)
+
+	explanation, err = explainService.Explain(service.PermissionExplainRequest{UserID: 100, RoleCode: "editor", ButtonCode: "user:export"})
+	require.NoError(t, err)
+	assert.False(t, explanation.Allowed, "禁用的按钮权限不授予")
+	assert.Equal(t, entity.DecisionReasonNoPermission, explanation.Reason)
+}
+
+// TestPermissionExplainService_Simulate 测试模拟拟议的角色变更，模拟不影响已保存的配置
+func TestPermissionExplainService_Simulate(t *testing.T) {
+	f := newDecisionFixture()
+	f.roleRepo.userRoles[100] = []int64{2, 1}
+	explainService := newExplainService(f)
+	req := service.PermissionExplainRequest{UserID: 100, RoleCode: "viewer", Method: "PUT", Path: "/api/admin/user/7"}
+
+	explanation, err := explainService.Explain(req)
+	require.NoError(t, err)
+	assert.False(t, explanation.Allowed)
+
+	// viewer 拟授予 GET 和 PUT（API ID 1、2）
+	req.Simulation = &service.PermissionSimulation{Roles: []*service.RoleChange{{RoleID: 1, APIIDs: []int64{1, 2}}}}
+	explanation, err = explainService.Explain(req)
+	require.NoError(t, err)
+	assert.True(t, explanation.Allowed)
+	assert.True(t, explanation.Simulated)
+	assert.True(t, explanation.Roles[0].Simulated)
+	assert.Nil(t, explanation.Cached, "模拟时不返回缓存结果")
+
+	decision, err := f.svc.Decide(service.DecisionRequest{UserID: 100, RoleCode: "viewer", Method: "PUT", Path: "/api/admin/user/7"})
+	require.NoError(t, err)
+	assert.False(t, decision.Allowed, "模拟不会保存变更")
+
+	// 拟禁用 editor 后继承链不再生效
+	disabled := 0
+	explanation, err = explainService.Explain(service.PermissionExplainRequest{
+		UserID: 100, RoleCode: "editor", Method: "GET", Path: "/api/admin/user/7",
+		Simulation: &service.PermissionSimulation{Roles: []*service.RoleChange{{RoleID: 2, Status: &disabled}}},
+	})
+	require.NoError(t, err)
+	assert.False(t, explanation.Allowed)
+	for _, role := range explanation.Roles {
+		assert.False(t, role.Effective)
+	}
+
+	// 拟将用户的角色改为 viewer，按用户全部角色判定
+	explanation, err = explainService.Explain(service.PermissionExplainRequest{
+		UserID: 100, Method: "PUT", Path: "/api/admin/user/7",
+		Simulation: &service.PermissionSimulation{UserRoleIDs: []int64{1}},
+	})
+	require.NoError(t, err)
+	assert.False(t, explanation.Allowed)
+	require.Len(t, explanation.Roles, 1)
+	assert.Equal(t, service.RoleSourceAssigned, explanation.Roles[0].Source)
+
+	_, err = explainService.Explain(service.PermissionExplainRequest{
+		UserID: 100, Method: "PUT", Path: "/api/admin/user/7",
+		Simulation: &service.PermissionSimulation{Roles: []*service.RoleChange{{RoleID: 99}}},
+	})
+	assert.Error(t, err, "不存在的角色")
+}
+
+// TestPermissionExplainService_RouteTrieCached 测试解释与判定共用按权限版本缓存的路由前缀树，版本变更后才重新构建
+func TestPermissionExplainService_RouteTrieCached(t *testing.T) {
+	f := newDecisionFixture()
+	explainService := newExplainService(f)
+	req := service.PermissionExplainRequest{UserID: 100, Method: "GET", Path: "/api/admin/user/7"}
+
+	for i := 0; i < 3; i++ {
+		explanation, err := explainService.Explain(req)
+		require.NoError(t, err)
+		assert.Equal(t, "/api/admin/user/:id", explanation.Route)
+		_, err = f.svc.Decide(service.DecisionRequest{UserID: 100, Method: "GET", Path: "/api/admin/user/7"})
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 1, f.apiRepo.loads, "同一版本只构建一次")
+
+	f.svc.Invalidate("API 变更")
+	_, err := explainService.Explain(req)
+	require.NoError(t, err)
+	assert.Equal(t, 2, f.apiRepo.loads, "版本变更后重新构建")
+}
@@ -0,0 +1,207 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/entity"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/repo"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/service"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/security/abac"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryPermissionRuleRepo 内存权限规则仓库
+type memoryPermissionRuleRepo struct {
+	repo.PermissionRuleRepository
+	rules  map[int64]*entity.PermissionRule
+	nextID int64
+}
+
+func newMemoryPermissionRuleRepo() *memoryPermissionRuleRepo {
+	return &memoryPermissionRuleRepo{rules: make(map[int64]*entity.PermissionRule)}
+}
+
+func (r *memoryPermissionRuleRepo) GetByID(id int64) (*entity.PermissionRule, error) {
+	if rule, ok := r.rules[id]; ok {
+		return rule, nil
+	}
+	return nil, errors.New("record not found")
+}
+
+func (r *memoryPermissionRuleRepo) GetByName(name string) (*entity.PermissionRule, error) {
+	for _, rule := range r.rules {
+		if rule.Name == name {
+			return rule, nil
+		}
+	}
+	return nil, errors.New("record not found")
+}
+
+func (r *memoryPermissionRuleRepo) Create(rule *entity.PermissionRule) error {
+	r.nextID++
+	rule.ID = r.nextID
+	r.rules[rule.ID] = rule
+	return nil
+}
+
+func (r *memoryPermissionRuleRepo) Update(rule *entity.PermissionRule) error {
+	r.rules[rule.ID] = rule
+	return nil
+}
+
+func (r *memoryPermissionRuleRepo) Delete(id int64) error {
+	delete(r.rules, id)
+	return nil
+}
+
+// TestABACCondition_Evaluate 测试条件表达式的逻辑组合和各操作符
+func TestABACCondition_Evaluate(t *testing.T) {
+	// 2026-10-19 是周一
+	workday := time.Date(2026, 10, 19, 10, 30, 0, 0, time.UTC)
+	attrs := abac.Attributes{
+		"user.id":            "1800000000000000001",
+		"user.department_id": "12",
+		"user.roles":         []string{"finance", "auditor"},
+		"user.level":         3,
+		"resource.owner_id":  "1800000000000000001",
+		"resource.path":      "/api/admin/payment/refund",
+		"env.ip":             "10.1.2.3",
+		"env.time":           workday,
+	}
+
+	testCases := []struct {
+		name       string
+		conditions string
+		expect     bool
+	}{
+		{"空条件", "", true},
+		{"相等", `{"attr":"user.department_id","op":"eq","value":12}`, true},
+		{"不相等", `{"attr":"user.department_id","op":"ne","value":"12"}`, false},
+		{"集合", `{"attr":"user.department_id","op":"in","value":["11","12"]}`, true},
+		{"列表属性与集合有交集", `{"attr":"user.roles","op":"in","value":["admin","auditor"]}`, true},
+		{"不在集合", `{"attr":"user.roles","op":"not_in","value":["admin"]}`, true},
+		{"列表包含", `{"attr":"user.roles","op":"contains","value":"finance"}`, true},
+		{"小于", `{"attr":"user.level","op":"lt","value":3}`, false},
+		{"大于等于", `{"attr":"user.level","op":"gte","value":3}`, true},
+		{"正则", `{"attr":"resource.path","op":"regex","value":"^/api/admin/payment/"}`, true},
+		{"网段", `{"attr":"env.ip","op":"cidr","value":["192.168.0.0/16","10.0.0.0/8"]}`, true},
+		{"单个 IP", `{"attr":"env.ip","op":"cidr","value":"10.1.2.4"}`, false},
+		{"工作时间", `{"attr":"env.time","op":"time_window","value":{"start":"09:00","end":"18:00","weekdays":[1,2,3,4,5],"timezone":"UTC"}}`, true},
+		{"跨天时间窗口", `{"attr":"env.time","op":"time_window","value":{"start":"22:00","end":"06:00","timezone":"UTC"}}`, false},
+		{"周末", `{"attr":"env.time","op":"time_window","value":{"start":"00:00","end":"23:59","weekdays":[6,7],"timezone":"UTC"}}`, false},
+		{"引用属性", `{"attr":"resource.owner_id","op":"eq","ref":"user.id"}`, true},
+		{"属性存在", `{"attr":"user.roles","op":"exists"}`, true},
+		{"属性不存在", `{"attr":"resource.tenant_id","op":"exists","value":false}`, true},
+		{"缺失属性不满足比较", `{"attr":"resource.tenant_id","op":"eq","value":"1"}`, false},
+		{"组合条件", `{"all":[{"attr":"user.roles","op":"contains","value":"finance"},{"not":{"attr":"env.ip","op":"cidr","value":"10.0.0.0/8"}}]}`, false},
+		{"任一条件", `{"any":[{"attr":"user.roles","op":"contains","value":"admin"},{"attr":"env.ip","op":"cidr","value":"10.0.0.0/8"}]}`, true},
+		{"旧版属性列表", `[{"Key":"department_id","Value":"12","Type":"user"}]`, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			program, err := abac.Compile(tc.conditions)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expect, program.Evaluate(attrs))
+		})
+	}
+}
+
+// TestABACCondition_Validate 测试无效条件在编译时报错并指出位置
+func TestABACCondition_Validate(t *testing.T) {
+	testCases := []struct {
+		name       string
+		conditions string
+		errContain string
+	}{
+		{"非法 JSON", `{"attr":`, "条件格式错误"},
+		{"未知字段", `{"attr":"user.id","op":"eq","value":"1","foo":1}`, "条件格式错误"},
+		{"混用节点形式", `{"all":[{"attr":"user.id","op":"eq","value":"1"}],"attr":"user.id","op":"eq"}`, "只能使用"},
+		{"空的 all", `{"all":[]}`, "$.all: 至少需要一个子条件"},
+		{"缺少命名空间", `{"attr":"department_id","op":"eq","value":"1"}`, "缺少命名空间"},
+		{"无效命名空间", `{"attr":"role.code","op":"eq","value":"1"}`, "命名空间无效"},
+		{"未知操作符", `{"any":[{"attr":"user.id","op":"like","value":"1"}]}`, "$.any[0].op: 不支持的操作符"},
+		{"集合不是数组", `{"attr":"user.id","op":"in","value":"1"}`, "必须是数组"},
+		{"正则无效", `{"attr":"user.username","op":"regex","value":"("}`, "正则表达式无效"},
+		{"网段无效", `{"attr":"env.ip","op":"cidr","value":"10.0.0.0/33"}`, "不是有效的 IP 或 CIDR"},
+		{"时间格式无效", `{"attr":"env.time","op":"time_window","value":{"start":"9点","end":"18:00"}}`, "HH:MM"},
+		{"星期无效", `{"attr":"env.time","op":"time_window","value":{"start":"09:00","end":"18:00","weekdays":[0]}}`, "1-7"},
+		{"大小比较值无效", `{"attr":"user.level","op":"gt","value":"high"}`, "不是数字或 RFC3339 时间"},
+		{"正则不支持引用", `{"attr":"user.username","op":"regex","ref":"user.id"}`, "不支持 ref"},
+		{"value 和 ref 同时填写", `{"attr":"user.id","op":"eq","value":"1","ref":"resource.owner_id"}`, "只能填写一个"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := abac.Validate(tc.conditions)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.errContain)
+		})
+	}
+}
+
+// TestABACMatchPath 测试规则路径模式和方法匹配
+func TestABACMatchPath(t *testing.T) {
+	testCases := []struct {
+		pattern string
+		path    string
+		expect  bool
+	}{
+		{"/api/admin/user", "/api/admin/user", true},
+		{"/api/admin/user", "/api/admin/user/1", false},
+		{"/api/admin/user/:id", "/api/admin/user/1", true},
+		{"/api/admin/user/:id", "/api/admin/user", false},
+		{"/api/admin/*/list", "/api/admin/role/list", true},
+		{"/api/admin/payment/*path", "/api/admin/payment/refund/1", true},
+		{"/api/admin/payment/*path", "/api/admin/user/1", false},
+		{"*", "/api/admin/anything", true},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expect, abac.MatchPath(tc.pattern, tc.path), "%s -> %s", tc.pattern, tc.path)
+	}
+
+	assert.True(t, abac.MatchMethod("*", "DELETE"))
+	assert.True(t, abac.MatchMethod("get, post", "POST"))
+	assert.False(t, abac.MatchMethod("GET", "DELETE"))
+}
+
+// TestPermissionRuleService_ValidateOnWrite 测试创建和更新规则时校验条件
+func TestPermissionRuleService_ValidateOnWrite(t *testing.T) {
+	ruleRepo := newMemoryPermissionRuleRepo()
+	svc := service.NewPermissionRuleService(ruleRepo, &MockLogger{})
+
+	rule := &entity.PermissionRule{
+		Name:       "禁止非办公网退款",
+		Effect:     entity.PermissionEffectDeny,
+		APIPath:    "/api/admin/payment/:id/refund",
+		Method:     "post",
+		Conditions: `{"not":{"attr":"env.ip","op":"cidr","value":["10.0.0.0/8"]}}`,
+		Status:     1,
+	}
+	require.NoError(t, svc.CreateRule(rule))
+	assert.Equal(t, "POST", rule.Method, "方法应规范化为大写")
+
+	invalid := []*entity.PermissionRule{
+		{Name: "无效条件", Effect: entity.PermissionEffectAllow, APIPath: "/api/admin/user", Conditions: `{"attr":"env.ip","op":"cidr","value":"bad"}`},
+		{Name: "无效效果", Effect: "grant", APIPath: "/api/admin/user"},
+		{Name: "无效路径", Effect: entity.PermissionEffectAllow, APIPath: "api/admin/user"},
+		{Name: "无效方法", Effect: entity.PermissionEffectAllow, APIPath: "/api/admin/user", Method: "FETCH"},
+		{Name: rule.Name, Effect: entity.PermissionEffectAllow, APIPath: "/api/admin/user"},
+	}
+	for _, r := range invalid {
+		assert.Error(t, svc.CreateRule(r), r.Name)
+	}
+
+	update := *rule
+	update.Conditions = `{"attr":"env.time","op":"time_window","value":{"start":"25:00","end":"06:00"}}`
+	err := svc.UpdateRule(&update)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "条件无效")
+	assert.Equal(t, rule.Conditions, ruleRepo.rules[rule.ID].Conditions, "校验失败时不应保存")
+
+	ruleRepo.rules[rule.ID].IsSystem = true
+	assert.EqualError(t, svc.DeleteRule(rule.ID), "系统规则不能删除")
+}
@@ -0,0 +1,316 @@
+package service
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/entity"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/repo"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryPolicyBundleRepo 内存权限策略包仓库，记录每次写入的策略包，审批通过时同时更新 changeRepo 中的申请
+type memoryPolicyBundleRepo struct {
+	current    *entity.PolicyBundle
+	applied    []*entity.PolicyBundle
+	changeRepo *memoryPermissionChangeRepo
+}
+
+func (r *memoryPolicyBundleRepo) Export() (*entity.PolicyBundle, error) {
+	data, _ := json.Marshal(r.current)
+	bundle := &entity.PolicyBundle{}
+	return bundle, json.Unmarshal(data, bundle)
+}
+
+func (r *memoryPolicyBundleRepo) Apply(bundle *entity.PolicyBundle, operatorID int64) error {
+	r.applied = append(r.applied, bundle)
+	return nil
+}
+
+func (r *memoryPolicyBundleRepo) ApplyApproved(bundle *entity.PolicyBundle, request *entity.PermissionChangeRequest) error {
+	r.applied = append(r.applied, bundle)
+	return r.changeRepo.Update(request)
+}
+
+var _ repo.PolicyBundleRepository = (*memoryPolicyBundleRepo)(nil)
+
+// newTestPolicyBundle 当前环境的权限配置：admin 继承自 base，auditor 开启了变更审批
+func newTestPolicyBundle() *entity.PolicyBundle {
+	return &entity.PolicyBundle{
+		Version: entity.PolicyBundleVersion,
+		APIs: []entity.PolicyAPI{
+			{Method: "DELETE", Path: "/api/admin/user/:id", Group: "用户管理", AuthRequired: true, AuthType: 1, Description: "删除用户", Status: 1},
+			{Method: "GET", Path: "/api/admin/user", Group: "用户管理", AuthRequired: true, AuthType: 1, Description: "获取用户列表", Status: 1},
+		},
+		Menus: []entity.PolicyMenu{
+			{Name: "System", Path: "/system", Title: "系统管理", Status: 1, Type: 1, APIs: []string{}},
+			{Name: "User", Parent: "System", Path: "/system/user", Title: "用户管理", Status: 1, Type: 2, APIs: []string{"GET /api/admin/user"}},
+		},
+		BtnPerms: []entity.PolicyBtnPerm{
+			{Code: "user:delete", Name: "删除用户", Menu: "User", Status: 1, APIs: []string{"DELETE /api/admin/user/:id"}},
+		},
+		Roles: []entity.PolicyRole{
+			{Code: "admin", Name: "管理员", Parent: "base", Type: 1, Status: 1, DataScope: 1, Menus: []string{"System", "User"}, APIs: []string{"DELETE /api/admin/user/:id", "GET /api/admin/user"}, BtnPerms: []string{"user:delete"}, PermissionGroups: []string{"用户只读"}},
+			{Code: "auditor", Name: "审计员", Type: 2, Status: 1, DataScope: 1, ApprovalRequired: true, Menus: []string{"User"}, APIs: []string{"GET /api/admin/user"}, BtnPerms: []string{}},
+			{Code: "base", Name: "基础角色", Type: 2, Status: 1, DataScope: 1, Menus: []string{}, APIs: []string{}, BtnPerms: []string{}},
+		},
+		PermissionGroups: []entity.PolicyPermissionGroup{
+			{Name: "用户只读", Status: 1, APIs: []string{"GET /api/admin/user"}},
+		},
+		Rules: []entity.PolicyRule{
+			{Name: "deny-delete-at-night", Effect: "deny", Method: "DELETE", APIPath: "/api/admin/user/:id", Status: 1},
+		},
+	}
+}
+
+func newTestPolicyBundleService() (*service.PolicyBundleService, *memoryPolicyBundleRepo, *memoryPermissionAuditLogRepo) {
+	s, bundleRepo, auditRepo, _ := newTestPolicyBundleServiceWithChanges()
+	return s, bundleRepo, auditRepo
+}
+
+// newTestPolicyBundleServiceWithChanges 同时返回权限变更申请仓库，用于检查提交审批的导入
+func newTestPolicyBundleServiceWithChanges() (*service.PolicyBundleService, *memoryPolicyBundleRepo, *memoryPermissionAuditLogRepo, *memoryPermissionChangeRepo) {
+	changeRepo := &memoryPermissionChangeRepo{requests: make(map[int64]*entity.PermissionChangeRequest)}
+	bundleRepo := &memoryPolicyBundleRepo{current: newTestPolicyBundle(), changeRepo: changeRepo}
+	auditRepo := &memoryPermissionAuditLogRepo{}
+	s := service.NewPolicyBundleService(bundleRepo, newDecisionFixture().userRepo, auditRepo, changeRepo, &MockLogger{})
+	return s, bundleRepo, auditRepo, changeRepo
+}
+
+// encodeTestBundle 将测试修改后的策略包序列化为 JSON
+func encodeTestBundle(t *testing.T, bundle *entity.PolicyBundle) []byte {
+	data, err := json.Marshal(bundle)
+	require.NoError(t, err)
+	return data
+}
+
+// TestPolicyBundleService_RoundTrip 测试导出的 YAML 和 JSON 策略包原样导入时没有差异
+func TestPolicyBundleService_RoundTrip(t *testing.T) {
+	s, bundleRepo, _ := newTestPolicyBundleService()
+
+	for _, format := range []string{service.PolicyFormatYAML, service.PolicyFormatJSON} {
+		data, err := s.Export(format)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), "deny-delete-at-night")
+		assert.NotContains(t, string(data), `"id"`, "策略包以编码引用，不包含 ID")
+
+		result, err := s.Import(data, format, false, 100)
+		require.NoError(t, err, format)
+		assert.Empty(t, result.Changes, format)
+		assert.False(t, result.Applied, "没有差异时不写入")
+	}
+	assert.Empty(t, bundleRepo.applied)
+
+	_, err := s.Export("xml")
+	assert.Error(t, err)
+}
+
+// TestPolicyBundleService_DryRunAndApply 测试预览只返回差异不写入，引用列表顺序不同不算差异，确认后写入并记录审计日志
+func TestPolicyBundleService_DryRunAndApply(t *testing.T) {
+	s, bundleRepo, auditRepo := newTestPolicyBundleService()
+
+	bundle := newTestPolicyBundle()
+	bundle.APIs[0].Description = "删除指定用户"
+	bundle.Roles[0].APIs = []string{"GET /api/admin/user", "DELETE /api/admin/user/:id", "GET /api/admin/user"}
+	bundle.Roles = append(bundle.Roles, entity.PolicyRole{Code: "viewer", Name: "只读", Parent: "base", Status: 1, Menus: []string{"User"}})
+	bundle.Menus = bundle.Menus[1:] // 策略包外的对象保持不变，不算删除
+	data := encodeTestBundle(t, bundle)
+
+	result, err := s.Import(data, service.PolicyFormatJSON, true, 100)
+	require.NoError(t, err)
+	assert.True(t, result.DryRun)
+	assert.False(t, result.Applied)
+	assert.Equal(t, []entity.PolicyChange{
+		{Kind: entity.PolicyKindAPI, Key: "DELETE /api/admin/user/:id", Action: entity.PolicyActionUpdate, Fields: []string{"description"}},
+		{Kind: entity.PolicyKindRole, Key: "viewer", Action: entity.PolicyActionCreate},
+	}, result.Changes)
+	assert.Empty(t, bundleRepo.applied)
+
+	result, err = s.Import(data, service.PolicyFormatJSON, false, 100)
+	require.NoError(t, err)
+	assert.True(t, result.Applied)
+	require.Len(t, bundleRepo.applied, 1)
+	assert.Equal(t, []string{"DELETE /api/admin/user/:id", "GET /api/admin/user"}, bundleRepo.applied[0].Roles[0].APIs, "引用列表去重排序")
+
+	require.Len(t, auditRepo.logs, 1)
+	assert.Equal(t, "policy_bundle_import", auditRepo.logs[0].ActionType)
+	assert.Equal(t, "alice", auditRepo.logs[0].OperatorName)
+	assert.Contains(t, auditRepo.logs[0].AfterData, "viewer")
+}
+
+// TestPolicyBundleService_PermissionGroups 测试权限组的 API、按钮权限和角色绑定参与差异比较并随策略包写入
+func TestPolicyBundleService_PermissionGroups(t *testing.T) {
+	s, bundleRepo, _ := newTestPolicyBundleService()
+
+	bundle := newTestPolicyBundle()
+	bundle.PermissionGroups[0].BtnPerms = []string{"user:delete"}
+	bundle.PermissionGroups = append(bundle.PermissionGroups, entity.PolicyPermissionGroup{Name: "用户管理", Status: 1, APIs: []string{"DELETE /api/admin/user/:id"}})
+	bundle.Roles[2].PermissionGroups = []string{"用户管理", "用户只读", "用户管理"}
+
+	result, err := s.Import(encodeTestBundle(t, bundle), service.PolicyFormatJSON, false, 100)
+	require.NoError(t, err)
+	assert.Equal(t, []entity.PolicyChange{
+		{Kind: entity.PolicyKindPermissionGroup, Key: "用户只读", Action: entity.PolicyActionUpdate, Fields: []string{"btnPerms"}},
+		{Kind: entity.PolicyKindPermissionGroup, Key: "用户管理", Action: entity.PolicyActionCreate},
+		{Kind: entity.PolicyKindRole, Key: "base", Action: entity.PolicyActionUpdate, Fields: []string{"permissionGroups"}},
+	}, result.Changes)
+	require.Len(t, bundleRepo.applied, 1)
+	assert.Equal(t, []string{"用户只读", "用户管理"}, bundleRepo.applied[0].Roles[2].PermissionGroups, "引用列表去重排序")
+	assert.Equal(t, []string{"user:delete"}, bundleRepo.applied[0].PermissionGroups[0].BtnPerms)
+}
+
+// TestPolicyBundleService_Validate 测试版本、引用、继承环、规则和自定义数据权限的校验
+func TestPolicyBundleService_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(bundle *entity.PolicyBundle)
+	}{
+		{"版本不支持", func(b *entity.PolicyBundle) { b.Version = 99 }},
+		{"引用不存在的 API", func(b *entity.PolicyBundle) { b.Roles[0].APIs = []string{"POST /api/admin/missing"} }},
+		{"引用不存在的菜单", func(b *entity.PolicyBundle) { b.BtnPerms[0].Menu = "Missing" }},
+		{"权限组引用不存在的 API", func(b *entity.PolicyBundle) { b.PermissionGroups[0].APIs = []string{"POST /api/admin/missing"} }},
+		{"权限组引用不存在的按钮权限", func(b *entity.PolicyBundle) { b.PermissionGroups[0].BtnPerms = []string{"user:missing"} }},
+		{"角色引用不存在的权限组", func(b *entity.PolicyBundle) { b.Roles[2].PermissionGroups = []string{"missing"} }},
+		{"引用不存在的父角色", func(b *entity.PolicyBundle) { b.Roles[2].Parent = "missing" }},
+		{"角色继承存在环", func(b *entity.PolicyBundle) { b.Roles[2].Parent = "admin" }},
+		{"角色编码重复", func(b *entity.PolicyBundle) { b.Roles = append(b.Roles, b.Roles[0]) }},
+		{"规则效果无效", func(b *entity.PolicyBundle) { b.Rules[0].Effect = "maybe" }},
+		{"规则条件无效", func(b *entity.PolicyBundle) { b.Rules[0].Conditions = "{" }},
+		{"设置自定义数据权限", func(b *entity.PolicyBundle) { b.Roles[2].DataScope = entity.DataScopeCustom }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, bundleRepo, _ := newTestPolicyBundleService()
+			bundle := newTestPolicyBundle()
+			tt.modify(bundle)
+
+			_, err := s.Import(encodeTestBundle(t, bundle), service.PolicyFormatJSON, false, 100)
+			assert.Error(t, err)
+			assert.Empty(t, bundleRepo.applied)
+		})
+	}
+}
+
+// TestPolicyBundleService_CustomDataScope 测试目标环境已有的自定义数据权限可以保留
+func TestPolicyBundleService_CustomDataScope(t *testing.T) {
+	s, bundleRepo, _ := newTestPolicyBundleService()
+	bundleRepo.current.Roles[2].DataScope = entity.DataScopeCustom
+
+	bundle := newTestPolicyBundle()
+	bundle.Roles[2].DataScope = entity.DataScopeCustom
+	bundle.Roles[2].Description = "所有角色的父角色"
+	result, err := s.Import(encodeTestBundle(t, bundle), service.PolicyFormatJSON, false, 100)
+	require.NoError(t, err)
+	assert.True(t, result.Applied)
+}
+
+// TestPolicyBundleService_ApprovalRequired 测试差异通过角色自身、引用的菜单、按钮权限、API、权限组、父角色或 ABAC 规则影响开启审批的角色时，
+// 导入提交为权限变更申请，审批人通过后写入
+func TestPolicyBundleService_ApprovalRequired(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(bundle *entity.PolicyBundle)
+		roles  []string
+	}{
+		{"修改角色自身", func(b *entity.PolicyBundle) { b.Roles[1].BtnPerms = []string{"user:delete"} }, []string{"auditor"}},
+		{"修改引用的菜单", func(b *entity.PolicyBundle) { b.Menus[1].Title = "用户" }, []string{"auditor"}},
+		{"修改菜单关联的 API", func(b *entity.PolicyBundle) { b.Menus[1].APIs = []string{"DELETE /api/admin/user/:id"} }, []string{"auditor"}},
+		{"修改引用的 API", func(b *entity.PolicyBundle) { b.APIs[1].Status = 0 }, []string{"auditor"}},
+		{"修改未引用的按钮权限", func(b *entity.PolicyBundle) { b.BtnPerms[0].Description = "删除" }, nil},
+		{"绑定权限组", func(b *entity.PolicyBundle) { b.Roles[1].PermissionGroups = []string{"用户只读"} }, []string{"auditor"}},
+		{"修改未绑定的权限组", func(b *entity.PolicyBundle) { b.PermissionGroups[0].Description = "只读" }, nil},
+		{"停用 ABAC 规则", func(b *entity.PolicyBundle) { b.Rules[0].Status = 0 }, []string{"auditor"}},
+		{"新增 ABAC 规则", func(b *entity.PolicyBundle) {
+			b.Rules = append(b.Rules, entity.PolicyRule{Name: "allow-list", Effect: "allow", Method: "GET", APIPath: "/api/admin/user", Status: 1})
+		}, []string{"auditor"}},
+		{"修改未引用的菜单", func(b *entity.PolicyBundle) { b.Menus[0].Title = "系统" }, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, bundleRepo, _, changeRepo := newTestPolicyBundleServiceWithChanges()
+			bundle := newTestPolicyBundle()
+			tt.modify(bundle)
+			data := encodeTestBundle(t, bundle)
+
+			result, err := s.Import(data, service.PolicyFormatJSON, true, 200)
+			require.NoError(t, err)
+			assert.Equal(t, tt.roles, result.ApprovalRoles, "预览时列出需要审批的角色")
+
+			result, err = s.Import(data, service.PolicyFormatJSON, false, 200)
+			if tt.roles == nil {
+				require.NoError(t, err)
+				assert.True(t, result.Applied)
+				assert.Empty(t, changeRepo.requests)
+				return
+			}
+			request := pendingRequest(t, err)
+			assert.Equal(t, entity.PermissionChangePolicyBundle, request.ChangeType)
+			assert.Equal(t, int64(200), request.RequestedBy)
+			assert.Empty(t, bundleRepo.applied, "提交后尚未写入")
+		})
+	}
+
+	t.Run("父角色受影响", func(t *testing.T) {
+		s, bundleRepo, _, _ := newTestPolicyBundleServiceWithChanges()
+		bundleRepo.current.Roles[1].Parent = "base"
+
+		bundle := newTestPolicyBundle()
+		bundle.Roles[1].Parent = "base"
+		bundle.Roles[2].APIs = []string{"DELETE /api/admin/user/:id"}
+		result, err := s.Import(encodeTestBundle(t, bundle), service.PolicyFormatJSON, true, 200)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"auditor"}, result.ApprovalRoles, "admin 未开启审批")
+	})
+
+	t.Run("绑定的权限组受影响", func(t *testing.T) {
+		s, bundleRepo, _, _ := newTestPolicyBundleServiceWithChanges()
+		bundleRepo.current.Roles[1].PermissionGroups = []string{"用户只读"}
+
+		bundle := newTestPolicyBundle()
+		bundle.Roles[1].PermissionGroups = []string{"用户只读"}
+		bundle.PermissionGroups[0].APIs = []string{"DELETE /api/admin/user/:id", "GET /api/admin/user"}
+		result, err := s.Import(encodeTestBundle(t, bundle), service.PolicyFormatJSON, true, 200)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"auditor"}, result.ApprovalRoles)
+
+		bundle = newTestPolicyBundle()
+		bundle.Roles[1].PermissionGroups = []string{"用户只读"}
+		bundle.APIs[1].Description = "用户列表"
+		result, err = s.Import(encodeTestBundle(t, bundle), service.PolicyFormatJSON, true, 200)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"auditor"}, result.ApprovalRoles, "权限组关联的 API 变更")
+	})
+}
+
+// TestPolicyBundleService_ApproveImport 测试审批通过策略包导入申请后按审批时的环境写入，申请人不能自审
+func TestPolicyBundleService_ApproveImport(t *testing.T) {
+	s, bundleRepo, auditRepo, changeRepo := newTestPolicyBundleServiceWithChanges()
+	f := newDecisionFixture()
+	allowChangeReview(f)
+	changes := service.NewPermissionChangeService(changeRepo, nil, s, f.userRepo, f.roleRepo, f.svc, auditRepo, &MockLogger{})
+
+	bundle := newTestPolicyBundle()
+	bundle.Menus[1].Title = "用户"
+	_, err := s.Import(encodeTestBundle(t, bundle), service.PolicyFormatJSON, false, 200)
+	request := pendingRequest(t, err)
+	assert.Contains(t, request.Payload, "用户")
+
+	_, err = changes.ApproveChange(request.ID, 200, "")
+	assert.Error(t, err, "申请人不能审批自己的申请")
+
+	approved, err := changes.ApproveChange(request.ID, 100, "同意")
+	require.NoError(t, err)
+	assert.Equal(t, entity.PermissionChangeStatusApproved, approved.Status)
+	assert.Equal(t, entity.PermissionChangeStatusApproved, changeRepo.requests[request.ID].Status, "策略包和申请状态一起写入")
+	require.Len(t, bundleRepo.applied, 1)
+	assert.Equal(t, "用户", bundleRepo.applied[0].Menus[1].Title)
+
+	require.Len(t, auditRepo.logs, 2)
+	assert.Equal(t, "policy_bundle_import", auditRepo.logs[0].ActionType)
+	assert.Equal(t, int64(100), auditRepo.logs[0].OperatorID, "由审批人写入")
+	assert.Equal(t, "permission_change_approve", auditRepo.logs[1].ActionType)
+}
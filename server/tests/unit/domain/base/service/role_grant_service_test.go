@@ -0,0 +1,366 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ix-pay/ixpay-pro/internal/config"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/entity"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/repo"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func (r *memoryDecisionRoleRepo) AddUserToRole(roleID, userID int64) error {
+	for _, id := range r.userRoles[userID] {
+		if id == roleID {
+			return nil
+		}
+	}
+	r.userRoles[userID] = append(r.userRoles[userID], roleID)
+	return nil
+}
+
+func (r *memoryDecisionRoleRepo) RemoveUserFromRole(roleID, userID int64) error {
+	roleIDs := make([]int64, 0, len(r.userRoles[userID]))
+	for _, id := range r.userRoles[userID] {
+		if id != roleID {
+			roleIDs = append(roleIDs, id)
+		}
+	}
+	r.userRoles[userID] = roleIDs
+	return nil
+}
+
+func (r *memoryDecisionRoleRepo) ExistsUserInRole(roleID, userID int64) (bool, error) {
+	for _, id := range r.userRoles[userID] {
+		if id == roleID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// memoryRoleGrantRepo 内存限时授予仓库
+type memoryRoleGrantRepo struct {
+	grants map[int64]*entity.RoleGrant
+	nextID int64
+}
+
+func newMemoryRoleGrantRepo() *memoryRoleGrantRepo {
+	return &memoryRoleGrantRepo{grants: make(map[int64]*entity.RoleGrant)}
+}
+
+func (r *memoryRoleGrantRepo) GetByID(id int64) (*entity.RoleGrant, error) {
+	if grant, ok := r.grants[id]; ok {
+		copied := *grant
+		return &copied, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *memoryRoleGrantRepo) Create(grant *entity.RoleGrant) error {
+	r.nextID++
+	grant.ID = r.nextID
+	grant.CreatedAt = time.Now()
+	copied := *grant
+	r.grants[grant.ID] = &copied
+	return nil
+}
+
+func (r *memoryRoleGrantRepo) Update(grant *entity.RoleGrant) error {
+	copied := *grant
+	r.grants[grant.ID] = &copied
+	return nil
+}
+
+func (r *memoryRoleGrantRepo) List(page, pageSize int, filters map[string]interface{}) ([]*entity.RoleGrant, int64, error) {
+	grants := r.filter(func(g *entity.RoleGrant) bool { return true })
+	return grants, int64(len(grants)), nil
+}
+
+func (r *memoryRoleGrantRepo) ListByUserRole(userID, roleID int64, statuses ...string) ([]*entity.RoleGrant, error) {
+	return r.filter(func(g *entity.RoleGrant) bool {
+		return g.UserID == userID && g.RoleID == roleID && (len(statuses) == 0 || containsStatus(statuses, g.Status))
+	}), nil
+}
+
+func (r *memoryRoleGrantRepo) ListDueForActivation(now time.Time) ([]*entity.RoleGrant, error) {
+	return r.filter(func(g *entity.RoleGrant) bool {
+		return g.Status == entity.RoleGrantStatusScheduled && !g.ValidFrom.After(now)
+	}), nil
+}
+
+func (r *memoryRoleGrantRepo) ListDueForExpiry(now time.Time) ([]*entity.RoleGrant, error) {
+	return r.filter(func(g *entity.RoleGrant) bool {
+		return g.IsOpen() && !g.ValidUntil.After(now)
+	}), nil
+}
+
+func (r *memoryRoleGrantRepo) filter(match func(g *entity.RoleGrant) bool) []*entity.RoleGrant {
+	grants := make([]*entity.RoleGrant, 0)
+	for id := int64(1); id <= r.nextID; id++ {
+		if grant, ok := r.grants[id]; ok && match(grant) {
+			copied := *grant
+			grants = append(grants, &copied)
+		}
+	}
+	return grants
+}
+
+func containsStatus(statuses []string, status string) bool {
+	for _, s := range statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// memoryPermissionLogRepo 内存权限日志仓库
+type memoryPermissionLogRepo struct {
+	repo.PermissionLogRepository
+	logs []*entity.PermissionLog
+}
+
+func (r *memoryPermissionLogRepo) Create(log *entity.PermissionLog) error {
+	r.logs = append(r.logs, log)
+	return nil
+}
+
+func (r *memoryPermissionLogRepo) operations() []string {
+	operations := make([]string, 0, len(r.logs))
+	for _, log := range r.logs {
+		operations = append(operations, log.Operation)
+	}
+	return operations
+}
+
+// roleGrantFixture 限时授予测试数据
+// 在权限判定测试数据的基础上增加 auditor 角色（授予 DELETE）和审批人 bob
+type roleGrantFixture struct {
+	*decisionFixture
+	grantRepo *memoryRoleGrantRepo
+	logRepo   *memoryPermissionLogRepo
+	sodRepo   *memorySoDConstraintRepo
+	grants    *service.RoleGrantService
+}
+
+func newRoleGrantFixture() *roleGrantFixture {
+	f := newDecisionFixture()
+	f.roleRepo.roles[4] = &entity.Role{ID: 4, Code: "auditor", Status: 1}
+	f.roleRepo.apis[4] = []*entity.API{{Path: "/api/admin/user/:id", Method: "DELETE"}}
+	f.userRepo.users[200] = &entity.User{ID: 200, Username: "bob", Status: 1}
+
+	grantRepo := newMemoryRoleGrantRepo()
+	logRepo := &memoryPermissionLogRepo{}
+	cfg := &config.Config{RoleGrant: config.RoleGrantConfig{MaxElevationHours: 8}}
+	sod, sodRepo := newTestSoDService(f.roleRepo)
+	return &roleGrantFixture{
+		decisionFixture: f,
+		grantRepo:       grantRepo,
+		logRepo:         logRepo,
+		sodRepo:         sodRepo,
+		grants:          service.NewRoleGrantService(grantRepo, f.roleRepo, f.userRepo, logRepo, f.svc, sod, f.cache, cfg, &MockLogger{}),
+	}
+}
+
+// canDelete 用户 100 按全部角色判定能否删除用户
+func (f *roleGrantFixture) canDelete(t *testing.T) bool {
+	decision, err := f.svc.Decide(service.DecisionRequest{UserID: 100, AllRoles: true, Method: "DELETE", Path: "/api/admin/user/7"})
+	require.NoError(t, err)
+	return decision.Allowed
+}
+
+// TestRoleGrantService_GrantRole 测试直接授予限时角色：立即生效、到期收回并失效判定缓存
+func TestRoleGrantService_GrantRole(t *testing.T) {
+	f := newRoleGrantFixture()
+	now := time.Now()
+	assert.False(t, f.canDelete(t))
+
+	grant, err := f.grants.GrantRole(100, 4, time.Time{}, now.Add(time.Hour), "值班", 200)
+	require.NoError(t, err)
+	assert.Equal(t, entity.RoleGrantStatusActive, grant.Status)
+	assert.True(t, f.canDelete(t), "授予后立即生效")
+
+	_, err = f.grants.GrantRole(100, 4, time.Time{}, now.Add(time.Hour), "重复授予", 200)
+	assert.Error(t, err, "已有未结束的限时授予")
+	_, err = f.grants.GrantRole(100, 2, time.Time{}, now.Add(time.Hour), "", 200)
+	assert.Error(t, err, "已长期拥有的角色")
+	_, err = f.grants.GrantRole(100, 3, time.Time{}, now.Add(time.Hour), "", 200)
+	assert.Error(t, err, "禁用的角色")
+	_, err = f.grants.GrantRole(200, 4, time.Time{}, now.Add(-time.Minute), "", 100)
+	assert.Error(t, err, "到期时间已过")
+
+	activated, expired, err := f.grants.ProcessDueGrants(now.Add(30 * time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, 0, activated+expired, "未到期不处理")
+
+	activated, expired, err = f.grants.ProcessDueGrants(now.Add(2 * time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 0, activated)
+	assert.Equal(t, 1, expired)
+	assert.False(t, f.canDelete(t), "到期后收回")
+
+	stored, err := f.grantRepo.GetByID(grant.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entity.RoleGrantStatusExpired, stored.Status)
+	assert.NotNil(t, stored.RevokedAt)
+
+	assert.Equal(t, []string{service.RoleGrantOpGrant, service.RoleGrantOpActivate, service.RoleGrantOpExpire}, f.logRepo.operations())
+	expireLog := f.logRepo.logs[2]
+	assert.Equal(t, int64(0), expireLog.UserID)
+	assert.Equal(t, "system", expireLog.Username)
+	assert.Equal(t, int64(4), expireLog.TargetID)
+	assert.Contains(t, expireLog.OldValue, `"status":"active"`)
+	assert.Contains(t, expireLog.NewValue, `"status":"expired"`)
+	assert.Equal(t, "bob", f.logRepo.logs[0].Username)
+}
+
+// TestRoleGrantService_ExpireCurrentRole 测试到期收回的角色是用户当前角色时清除当前角色，其他用户的当前角色不受影响
+func TestRoleGrantService_ExpireCurrentRole(t *testing.T) {
+	f := newRoleGrantFixture()
+	now := time.Now()
+	req := service.DecisionRequest{UserID: 100, RoleCode: "auditor", Method: "DELETE", Path: "/api/admin/user/7"}
+
+	_, err := f.grants.GrantRole(100, 4, time.Time{}, now.Add(time.Hour), "值班", 200)
+	require.NoError(t, err)
+	require.NoError(t, f.cache.Set("user:current_role:100", "4", time.Hour))
+	require.NoError(t, f.cache.Set("user:current_role:200", "4", time.Hour))
+	decision, err := f.svc.Decide(req)
+	require.NoError(t, err)
+	assert.True(t, decision.Allowed, "切换到限时角色后可以访问")
+
+	_, expired, err := f.grants.ProcessDueGrants(now.Add(2 * time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 1, expired)
+
+	current, _ := f.cache.Get("user:current_role:100")
+	assert.Empty(t, current, "到期的当前角色被清除")
+	current, _ = f.cache.Get("user:current_role:200")
+	assert.Equal(t, "4", current, "其他用户的当前角色不受影响")
+
+	decision, err = f.svc.Decide(req)
+	require.NoError(t, err)
+	assert.False(t, decision.Allowed, "到期后不能再以该角色访问")
+}
+
+// TestRoleGrantService_Scheduled 测试未到生效时间的授予由定时任务生效，已过期的授予不再生效
+func TestRoleGrantService_Scheduled(t *testing.T) {
+	f := newRoleGrantFixture()
+	now := time.Now()
+
+	grant, err := f.grants.GrantRole(100, 4, now.Add(time.Hour), now.Add(3*time.Hour), "计划维护", 200)
+	require.NoError(t, err)
+	assert.Equal(t, entity.RoleGrantStatusScheduled, grant.Status)
+	assert.False(t, f.canDelete(t), "未到生效时间")
+
+	activated, expired, err := f.grants.ProcessDueGrants(now.Add(90 * time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, 1, activated)
+	assert.Equal(t, 0, expired)
+	assert.True(t, f.canDelete(t))
+
+	_, expired, err = f.grants.ProcessDueGrants(now.Add(4 * time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 1, expired)
+	assert.False(t, f.canDelete(t))
+
+	// 生效时间和到期时间都已过的授予直接标记为到期
+	grant, err = f.grants.GrantRole(100, 4, now.Add(time.Hour), now.Add(2*time.Hour), "", 200)
+	require.NoError(t, err)
+	activated, expired, err = f.grants.ProcessDueGrants(now.Add(5 * time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 0, activated)
+	assert.Equal(t, 1, expired)
+	assert.False(t, f.canDelete(t))
+}
+
+// TestRoleGrantService_Elevation 测试提权申请：校验时长和理由、不能自我审批、审批后生效、收回和拒绝
+func TestRoleGrantService_Elevation(t *testing.T) {
+	f := newRoleGrantFixture()
+
+	_, err := f.grants.RequestElevation(100, 4, 9, "排查故障")
+	assert.Error(t, err, "超过最长时长")
+	_, err = f.grants.RequestElevation(100, 4, 2, " ")
+	assert.Error(t, err, "缺少理由")
+
+	request, err := f.grants.RequestElevation(100, 4, 2, "排查故障")
+	require.NoError(t, err)
+	assert.Equal(t, entity.RoleGrantStatusPending, request.Status)
+	assert.True(t, request.Elevation)
+	assert.False(t, f.canDelete(t), "审批前不生效")
+
+	_, err = f.grants.RequestElevation(100, 4, 2, "重复申请")
+	assert.Error(t, err, "已有待审批的申请")
+	_, err = f.grants.ApproveElevation(request.ID, 100, "")
+	assert.Error(t, err, "不能审批自己的申请")
+
+	before := time.Now()
+	grant, err := f.grants.ApproveElevation(request.ID, 200, "同意")
+	require.NoError(t, err)
+	assert.Equal(t, entity.RoleGrantStatusActive, grant.Status)
+	assert.Equal(t, int64(200), grant.ApprovedBy)
+	assert.WithinDuration(t, before.Add(2*time.Hour), grant.ValidUntil, time.Minute)
+	assert.True(t, f.canDelete(t))
+
+	_, err = f.grants.ApproveElevation(request.ID, 200, "")
+	assert.Error(t, err, "已审批的申请")
+
+	grant, err = f.grants.RevokeGrant(request.ID, 200, "故障已恢复")
+	require.NoError(t, err)
+	assert.Equal(t, entity.RoleGrantStatusRevoked, grant.Status)
+	assert.Equal(t, int64(200), grant.RevokedBy)
+	assert.False(t, f.canDelete(t), "收回后立即失效")
+
+	request, err = f.grants.RequestElevation(100, 4, 1, "再次申请")
+	require.NoError(t, err)
+	grant, err = f.grants.RejectElevation(request.ID, 200, "无需提权")
+	require.NoError(t, err)
+	assert.Equal(t, entity.RoleGrantStatusRejected, grant.Status)
+	assert.False(t, f.canDelete(t))
+
+	assert.Equal(t, []string{
+		service.RoleGrantOpRequest, service.RoleGrantOpApprove, service.RoleGrantOpActivate, service.RoleGrantOpRevoke,
+		service.RoleGrantOpRequest, service.RoleGrantOpReject,
+	}, f.logRepo.operations())
+}
+
+// TestRoleGrantService_SoD 测试限时授予在授予、申请、审批和生效时都校验静态职责分离约束
+func TestRoleGrantService_SoD(t *testing.T) {
+	f := newRoleGrantFixture()
+	now := time.Now()
+	require.NoError(t, f.sodRepo.Create(&entity.SoDConstraint{Name: "编辑与审计分离", Type: entity.SoDTypeStatic, RoleIDs: []int64{2, 4}, MaxRoles: 1, Status: 1}))
+
+	_, err := f.grants.GrantRole(100, 4, time.Time{}, now.Add(time.Hour), "值班", 200)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "编辑与审计分离")
+	_, err = f.grants.RequestElevation(100, 4, 1, "紧急审计")
+	assert.Error(t, err, "申请时校验")
+	assert.NotContains(t, f.roleRepo.userRoles[100], int64(4))
+
+	// 申请提交后用户获得了冲突的角色，审批时拒绝
+	request, err := f.grants.RequestElevation(200, 4, 1, "紧急审计")
+	require.NoError(t, err)
+	f.roleRepo.userRoles[200] = []int64{2}
+	_, err = f.grants.ApproveElevation(request.ID, 100, "")
+	assert.Error(t, err, "审批时校验")
+	assert.Equal(t, []int64{2}, f.roleRepo.userRoles[200])
+
+	// 计划授予创建后用户获得了冲突的角色，生效时收回，不加入角色
+	f.roleRepo.userRoles[200] = nil
+	grant, err := f.grants.GrantRole(200, 4, now.Add(time.Hour), now.Add(2*time.Hour), "计划审计", 100)
+	require.NoError(t, err)
+	f.roleRepo.userRoles[200] = []int64{2}
+	activated, _, err := f.grants.ProcessDueGrants(now.Add(90 * time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, 0, activated)
+	assert.Equal(t, []int64{2}, f.roleRepo.userRoles[200])
+
+	stored, err := f.grantRepo.GetByID(grant.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entity.RoleGrantStatusRevoked, stored.Status)
+	assert.Contains(t, stored.ReviewComment, "编辑与审计分离")
+}
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/security/auth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRouteTrie_Match 测试路由模板前缀树的参数段、通配段和匹配优先级
+func TestRouteTrie_Match(t *testing.T) {
+	routes := auth.NewRouteTrie()
+	routes.Insert("GET", "/api/admin/user")
+	routes.Insert("GET", "/api/admin/user/:id")
+	routes.Insert("GET", "/api/admin/user/export")
+	routes.Insert("PUT", "/api/admin/user/:id")
+	routes.Insert("PUT", "/api/admin/role/:id/data-scope")
+	routes.Insert("GET", "/api/admin/files/*filepath")
+	routes.Insert("GET", "/api/admin/files/:name/meta")
+	routes.Insert("", "/api/admin/health")
+	assert.Equal(t, 8, routes.Len())
+
+	testCases := []struct {
+		name    string
+		method  string
+		path    string
+		pattern string
+		ok      bool
+	}{
+		{"静态路由", "GET", "/api/admin/user", "/api/admin/user", true},
+		{"参数路由", "GET", "/api/admin/user/123", "/api/admin/user/:id", true},
+		{"路由模板", "GET", "/api/admin/user/:id", "/api/admin/user/:id", true},
+		{"静态段优先于参数段", "GET", "/api/admin/user/export", "/api/admin/user/export", true},
+		{"按方法区分", "PUT", "/api/admin/user/export", "/api/admin/user/:id", true},
+		{"方法未登记", "DELETE", "/api/admin/user/123", "", false},
+		{"中间参数段", "PUT", "/api/admin/role/9/data-scope", "/api/admin/role/:id/data-scope", true},
+		{"参数段不匹配多段", "GET", "/api/admin/user/1/2", "", false},
+		{"通配段", "GET", "/api/admin/files/a/b.png", "/api/admin/files/*filepath", true},
+		{"参数段失败后回溯到通配段", "GET", "/api/admin/files/a/b", "/api/admin/files/*filepath", true},
+		{"参数段优先于通配段", "GET", "/api/admin/files/a/meta", "/api/admin/files/:name/meta", true},
+		{"通配段匹配空路径", "GET", "/api/admin/files/", "/api/admin/files/*filepath", true},
+		{"通配段模板", "GET", "/api/admin/files/*filepath", "/api/admin/files/*filepath", true},
+		{"任意方法", "POST", "/api/admin/health", "/api/admin/health", true},
+		{"未登记路径", "GET", "/api/admin/menu/1", "", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pattern, ok := routes.Match(tc.method, tc.path)
+			assert.Equal(t, tc.ok, ok)
+			assert.Equal(t, tc.pattern, pattern)
+		})
+	}
+}
+
+// TestPermissionManager_GetPermission 测试具体请求路径按路由模板查找权限
+func TestPermissionManager_GetPermission(t *testing.T) {
+	cacheClient := NewMockCache()
+	manager := auth.SetupPermissionManager(cacheClient, &MockLogger{})
+	require.NoError(t, manager.CachePermissions([]auth.Permission{
+		{Path: "/api/admin/user/:id", Method: "GET", Roles: []string{"auditor"}},
+		{Path: "/api/admin/user/export", Method: "GET", Roles: []string{"finance"}},
+	}))
+
+	perm, err := manager.GetPermission("GET", "/api/admin/user/123")
+	require.NoError(t, err)
+	assert.Equal(t, "/api/admin/user/:id", perm.Path)
+	assert.Equal(t, []string{"auditor"}, perm.Roles)
+
+	assert.True(t, manager.CheckRolePermission("finance", "GET", "/api/admin/user/export"))
+	assert.False(t, manager.CheckRolePermission("finance", "GET", "/api/admin/user/123"))
+
+	// 进程重启后从缓存的权限列表重建索引
+	restarted := auth.SetupPermissionManager(cacheClient, &MockLogger{})
+	assert.Equal(t, "/api/admin/user/:id", restarted.ResolveRoute("GET", "/api/admin/user/456"))
+	assert.Equal(t, "/api/admin/menu/1", restarted.ResolveRoute("GET", "/api/admin/menu/1"))
+}
+
+// TestPermissionManager_RefreshAcrossInstances 测试其他实例刷新权限后，本实例的路由模板索引随版本号切换重建
+func TestPermissionManager_RefreshAcrossInstances(t *testing.T) {
+	cacheClient := NewMockCache()
+	local := auth.SetupPermissionManager(cacheClient, &MockLogger{})
+	remote := auth.SetupPermissionManager(cacheClient, &MockLogger{})
+
+	require.NoError(t, local.CachePermissions([]auth.Permission{
+		{Path: "/api/admin/user/:id", Method: "GET", Roles: []string{"auditor"}},
+	}))
+	assert.Equal(t, "/api/admin/user/:id", local.ResolveRoute("GET", "/api/admin/user/export"))
+	assert.Equal(t, "/api/admin/user/:id", remote.ResolveRoute("GET", "/api/admin/user/export"))
+
+	// 另一实例重新缓存权限，即使不经过刷新也会切换版本号
+	require.NoError(t, remote.CachePermissions([]auth.Permission{
+		{Path: "/api/admin/user/:id", Method: "GET", Roles: []string{"auditor"}},
+		{Path: "/api/admin/user/export", Method: "GET", Roles: []string{"finance"}},
+	}))
+	assert.Equal(t, "/api/admin/user/export", local.ResolveRoute("GET", "/api/admin/user/export"))
+	assert.True(t, local.CheckRolePermission("finance", "GET", "/api/admin/user/export"))
+
+	require.NoError(t, remote.RefreshPermissions([]auth.Permission{
+		{Path: "/api/admin/menu/:id", Method: "GET", Roles: []string{"auditor"}},
+	}))
+	assert.Equal(t, "/api/admin/menu/:id", local.ResolveRoute("GET", "/api/admin/menu/1"))
+	assert.Equal(t, "/api/admin/user/1", local.ResolveRoute("GET", "/api/admin/user/1"), "刷新后旧路由不再匹配")
+}
@@ -0,0 +1,334 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/entity"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/repo"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// MockRoleRepositoryForTest 角色仓库 Mock 实现
+// 未覆盖的方法由嵌入的接口提供，调用时会 panic
+type MockRoleRepositoryForTest struct {
+	repo.RoleRepository
+	roles map[int64]*entity.Role
+}
+
+func NewMockRoleRepositoryForTest(roles ...*entity.Role) *MockRoleRepositoryForTest {
+	m := &MockRoleRepositoryForTest{roles: make(map[int64]*entity.Role)}
+	for _, r := range roles {
+		m.roles[r.ID] = r
+	}
+	return m
+}
+
+func (m *MockRoleRepositoryForTest) GetByID(id int64, relations ...repo.RoleRelation) (*entity.Role, error) {
+	if r, ok := m.roles[id]; ok {
+		return r, nil
+	}
+	return nil, errors.New("record not found")
+}
+
+func (m *MockRoleRepositoryForTest) GetByCode(code string) (*entity.Role, error) {
+	for _, r := range m.roles {
+		if r.Code == code {
+			return r, nil
+		}
+	}
+	return nil, errors.New("record not found")
+}
+
+// MockServiceAccountRepository 服务账号仓库 Mock 实现
+type MockServiceAccountRepository struct {
+	roleRepo *MockRoleRepositoryForTest
+	accounts map[int64]*entity.ServiceAccount
+	keys     map[int64]*entity.ServiceAccountKey
+	nextID   int64
+	touches  int
+}
+
+func NewMockServiceAccountRepository(roleRepo *MockRoleRepositoryForTest) *MockServiceAccountRepository {
+	return &MockServiceAccountRepository{
+		roleRepo: roleRepo,
+		accounts: make(map[int64]*entity.ServiceAccount),
+		keys:     make(map[int64]*entity.ServiceAccountKey),
+	}
+}
+
+func (m *MockServiceAccountRepository) GetByID(id int64) (*entity.ServiceAccount, error) {
+	if a, ok := m.accounts[id]; ok {
+		copied := *a
+		return &copied, nil
+	}
+	return nil, errors.New("record not found")
+}
+
+func (m *MockServiceAccountRepository) GetByName(name string) (*entity.ServiceAccount, error) {
+	for _, a := range m.accounts {
+		if a.Name == name {
+			return a, nil
+		}
+	}
+	return nil, errors.New("record not found")
+}
+
+func (m *MockServiceAccountRepository) Create(account *entity.ServiceAccount) error {
+	m.nextID++
+	account.ID = m.nextID
+	copied := *account
+	m.accounts[account.ID] = &copied
+	return nil
+}
+
+func (m *MockServiceAccountRepository) Update(account *entity.ServiceAccount) error {
+	copied := *account
+	m.accounts[account.ID] = &copied
+	return nil
+}
+
+func (m *MockServiceAccountRepository) Delete(id int64) error {
+	delete(m.accounts, id)
+	return nil
+}
+
+func (m *MockServiceAccountRepository) List(page, pageSize int, filters map[string]interface{}) ([]*entity.ServiceAccount, int64, error) {
+	list := make([]*entity.ServiceAccount, 0, len(m.accounts))
+	for _, a := range m.accounts {
+		list = append(list, a)
+	}
+	return list, int64(len(list)), nil
+}
+
+func (m *MockServiceAccountRepository) SetRoles(accountID int64, roleIDs []int64) error {
+	m.accounts[accountID].RoleIds = roleIDs
+	return nil
+}
+
+func (m *MockServiceAccountRepository) GetRoles(accountID int64) ([]*entity.Role, error) {
+	roles := make([]*entity.Role, 0)
+	for _, id := range m.accounts[accountID].RoleIds {
+		if r, err := m.roleRepo.GetByID(id); err == nil {
+			roles = append(roles, r)
+		}
+	}
+	return roles, nil
+}
+
+func (m *MockServiceAccountRepository) TouchAccount(accountID int64, ip string, at time.Time) error {
+	m.accounts[accountID].LastUsedAt = &at
+	m.accounts[accountID].LastUsedIP = ip
+	return nil
+}
+
+func (m *MockServiceAccountRepository) CreateKey(key *entity.ServiceAccountKey) error {
+	m.nextID++
+	key.ID = m.nextID
+	m.keys[key.ID] = key
+	return nil
+}
+
+func (m *MockServiceAccountRepository) GetKeyByPrefix(prefix string) (*entity.ServiceAccountKey, error) {
+	for _, k := range m.keys {
+		if k.Prefix == prefix {
+			return k, nil
+		}
+	}
+	return nil, errors.New("record not found")
+}
+
+func (m *MockServiceAccountRepository) ListKeys(accountID int64) ([]*entity.ServiceAccountKey, error) {
+	keys := make([]*entity.ServiceAccountKey, 0)
+	for _, k := range m.keys {
+		if k.ServiceAccountID == accountID {
+			keys = append(keys, k)
+		}
+	}
+	return keys, nil
+}
+
+func (m *MockServiceAccountRepository) RevokeKey(accountID, keyID int64, at time.Time) error {
+	k, ok := m.keys[keyID]
+	if !ok || k.ServiceAccountID != accountID || k.RevokedAt != nil {
+		return errors.New("record not found")
+	}
+	k.RevokedAt = &at
+	return nil
+}
+
+func (m *MockServiceAccountRepository) ExpireActiveKeys(accountID, exceptKeyID int64, expiresAt time.Time) error {
+	for _, k := range m.keys {
+		if k.ServiceAccountID == accountID && k.ID != exceptKeyID && k.RevokedAt == nil {
+			if k.ExpiresAt == nil || k.ExpiresAt.After(expiresAt) {
+				at := expiresAt
+				k.ExpiresAt = &at
+			}
+		}
+	}
+	return nil
+}
+
+func (m *MockServiceAccountRepository) TouchKey(keyID int64, ip string, at time.Time) error {
+	m.touches++
+	m.keys[keyID].LastUsedAt = &at
+	m.keys[keyID].LastUsedIP = ip
+	return nil
+}
+
+func newTestServiceAccountService() (*service.ServiceAccountService, *MockServiceAccountRepository) {
+	roleRepo := NewMockRoleRepositoryForTest(
+		&entity.Role{ID: 10, Code: "ops", Name: "运维", Status: 1},
+		&entity.Role{ID: 20, Code: "report", Name: "报表", Status: 1},
+		&entity.Role{ID: 30, Code: "disabled", Name: "禁用角色", Status: 0},
+	)
+	saRepo := NewMockServiceAccountRepository(roleRepo)
+	return service.NewServiceAccountService(saRepo, roleRepo, &MockLogger{}), saRepo
+}
+
+// TestServiceAccountService_CreateAndAuthenticate 测试创建服务账号并使用 API Key 认证
+func TestServiceAccountService_CreateAndAuthenticate(t *testing.T) {
+	svc, saRepo := newTestServiceAccountService()
+
+	account, rawKey, err := svc.CreateServiceAccount(&entity.ServiceAccount{
+		Name:        "billing-job",
+		Status:      entity.ServiceAccountStatusEnabled,
+		IPAllowlist: []string{"10.0.0.0/24", "192.168.1.5"},
+		RoleIds:     []int64{10, 20},
+	}, nil)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(rawKey, "ixp_"))
+
+	// 只保存哈希，不保存明文
+	stored := saRepo.keys[account.Keys[0].ID]
+	assert.NotContains(t, rawKey, stored.KeyHash)
+	assert.NotEmpty(t, stored.KeyHash)
+
+	testCases := []struct {
+		name       string
+		key        string
+		ip         string
+		roleID     int64
+		expectRole string
+		expectErr  bool
+	}{
+		{"默认使用第一个角色", rawKey, "10.0.0.8", 0, "ops", false},
+		{"指定绑定的角色", rawKey, "192.168.1.5", 20, "report", false},
+		{"指定未绑定的角色", rawKey, "10.0.0.8", 30, "", true},
+		{"IP 不在白名单", rawKey, "172.16.0.1", 0, "", true},
+		{"Key 格式无效", "invalid", "10.0.0.8", 0, "", true},
+		{"Key 密文错误", rawKey + "0", "10.0.0.8", 0, "", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			principal, err := svc.Authenticate(tc.key, tc.ip, tc.roleID)
+			if tc.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, account.ID, principal.Account.ID)
+			assert.Equal(t, tc.expectRole, principal.Role.Code)
+		})
+	}
+
+	// 禁用账号后认证失败
+	saRepo.accounts[account.ID].Status = entity.ServiceAccountStatusDisabled
+	_, err = svc.Authenticate(rawKey, "10.0.0.8", 0)
+	assert.Error(t, err)
+}
+
+// TestServiceAccountService_Validation 测试创建参数校验
+func TestServiceAccountService_Validation(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+	testCases := []struct {
+		name      string
+		account   *entity.ServiceAccount
+		expiresAt *time.Time
+	}{
+		{"名称为空", &entity.ServiceAccount{RoleIds: []int64{10}}, nil},
+		{"未绑定角色", &entity.ServiceAccount{Name: "a"}, nil},
+		{"角色不存在", &entity.ServiceAccount{Name: "a", RoleIds: []int64{99}}, nil},
+		{"IP 白名单格式错误", &entity.ServiceAccount{Name: "a", RoleIds: []int64{10}, IPAllowlist: []string{"10.0.0.0/33"}}, nil},
+		{"过期时间早于当前", &entity.ServiceAccount{Name: "a", RoleIds: []int64{10}}, &past},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc, _ := newTestServiceAccountService()
+			_, _, err := svc.CreateServiceAccount(tc.account, tc.expiresAt)
+			assert.Error(t, err)
+		})
+	}
+}
+
+// TestServiceAccountService_RotateAndRevoke 测试 Key 轮换宽限期和吊销
+func TestServiceAccountService_RotateAndRevoke(t *testing.T) {
+	svc, _ := newTestServiceAccountService()
+
+	account, oldKey, err := svc.CreateServiceAccount(&entity.ServiceAccount{
+		Name:    "sync-job",
+		Status:  entity.ServiceAccountStatusEnabled,
+		RoleIds: []int64{10},
+	}, nil)
+	require.NoError(t, err)
+
+	// 带宽限期轮换：新旧 Key 同时可用
+	newKeyEntity, newKey, err := svc.RotateKey(account.ID, nil, 300, 1)
+	require.NoError(t, err)
+	assert.NotEqual(t, oldKey, newKey)
+	_, err = svc.Authenticate(oldKey, "", 0)
+	assert.NoError(t, err, "宽限期内旧 Key 仍可用")
+	_, err = svc.Authenticate(newKey, "", 0)
+	assert.NoError(t, err)
+
+	// 立即轮换：旧 Key 失效
+	_, latestKey, err := svc.RotateKey(account.ID, nil, 0, 1)
+	require.NoError(t, err)
+	_, err = svc.Authenticate(newKey, "", 0)
+	assert.Error(t, err, "立即轮换后旧 Key 失效")
+
+	// 吊销
+	_, err = svc.Authenticate(latestKey, "", 0)
+	require.NoError(t, err)
+	latest, err := svc.GetServiceAccountByID(account.ID)
+	require.NoError(t, err)
+	var latestID int64
+	for _, k := range latest.Keys {
+		if k.ID > latestID {
+			latestID = k.ID
+		}
+	}
+	require.NoError(t, svc.RevokeKey(account.ID, latestID))
+	_, err = svc.Authenticate(latestKey, "", 0)
+	assert.Error(t, err)
+	assert.Error(t, svc.RevokeKey(account.ID, latestID), "重复吊销返回错误")
+	assert.NotZero(t, newKeyEntity.ID)
+}
+
+// TestServiceAccountService_TouchThrottle 测试最近使用信息按间隔节流更新
+func TestServiceAccountService_TouchThrottle(t *testing.T) {
+	svc, saRepo := newTestServiceAccountService()
+
+	_, rawKey, err := svc.CreateServiceAccount(&entity.ServiceAccount{
+		Name:    "touch-job",
+		Status:  entity.ServiceAccountStatusEnabled,
+		RoleIds: []int64{10},
+	}, nil)
+	require.NoError(t, err)
+
+	for i := 0; i < 5; i++ {
+		_, err := svc.Authenticate(rawKey, "10.1.1.1", 0)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 1, saRepo.touches, "同一 IP 短时间内只更新一次")
+
+	_, err = svc.Authenticate(rawKey, "10.1.1.2", 0)
+	require.NoError(t, err)
+	assert.Equal(t, 2, saRepo.touches, "IP 变化时立即更新")
+}
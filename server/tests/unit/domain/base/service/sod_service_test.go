@@ -0,0 +1,186 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/entity"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/repo"
+	"github.com/ix-pay/ixpay-pro/internal/domain/base/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// memorySoDConstraintRepo 内存职责分离约束仓库
+type memorySoDConstraintRepo struct {
+	constraints map[int64]*entity.SoDConstraint
+	nextID      int64
+}
+
+func (r *memorySoDConstraintRepo) GetByID(id int64) (*entity.SoDConstraint, error) {
+	if constraint, ok := r.constraints[id]; ok {
+		return constraint, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *memorySoDConstraintRepo) GetByName(name string) (*entity.SoDConstraint, error) {
+	for _, constraint := range r.constraints {
+		if constraint.Name == name {
+			return constraint, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *memorySoDConstraintRepo) GetActiveByType(constraintType string) ([]*entity.SoDConstraint, error) {
+	constraints := make([]*entity.SoDConstraint, 0)
+	for id := int64(1); id <= r.nextID; id++ {
+		if constraint, ok := r.constraints[id]; ok && constraint.Type == constraintType && constraint.IsActive() {
+			constraints = append(constraints, constraint)
+		}
+	}
+	return constraints, nil
+}
+
+func (r *memorySoDConstraintRepo) Create(constraint *entity.SoDConstraint) error {
+	r.nextID++
+	constraint.ID = r.nextID
+	r.constraints[constraint.ID] = constraint
+	return nil
+}
+
+func (r *memorySoDConstraintRepo) Update(constraint *entity.SoDConstraint) error {
+	r.constraints[constraint.ID] = constraint
+	return nil
+}
+
+func (r *memorySoDConstraintRepo) Delete(id int64) error {
+	delete(r.constraints, id)
+	return nil
+}
+
+func (r *memorySoDConstraintRepo) List(page, pageSize int, filters map[string]interface{}) ([]*entity.SoDConstraint, int64, error) {
+	constraints, _ := r.GetActiveByType(entity.SoDTypeStatic)
+	return constraints, int64(len(constraints)), nil
+}
+
+var _ repo.SoDConstraintRepository = (*memorySoDConstraintRepo)(nil)
+
+// newTestSoDService 创建没有任何约束的职责分离服务
+func newTestSoDService(roleRepo repo.RoleRepository) (*service.SoDService, *memorySoDConstraintRepo) {
+	sodRepo := &memorySoDConstraintRepo{constraints: make(map[int64]*entity.SoDConstraint)}
+	return service.NewSoDService(sodRepo, roleRepo, NewMockCache(), &MockLogger{}), sodRepo
+}
+
+// sodFixture 职责分离测试数据
+// alice（ID 100）持有 editor（ID 2），bob（ID 200）没有角色
+type sodFixture struct {
+	*roleGrantFixture
+	roleRepo *memoryChangeRoleRepo
+	sod      *service.SoDService
+	roles    *service.RoleService
+}
+
+func newSoDFixture() *sodFixture {
+	f := newRoleGrantFixture()
+	roleRepo := &memoryChangeRoleRepo{memoryDecisionRoleRepo: f.roleRepo, apiRepo: f.apiRepo}
+	changeRepo := &memoryPermissionChangeRepo{requests: make(map[int64]*entity.PermissionChangeRequest)}
+	sod, _ := newTestSoDService(roleRepo)
+	return &sodFixture{
+		roleGrantFixture: f,
+		roleRepo:         roleRepo,
+		sod:              sod,
+		roles:            service.NewRoleService(roleRepo, f.userRepo, nil, f.apiRepo, nil, nil, changeRepo, sod, &MockLogger{}),
+	}
+}
+
+// createConstraint 创建 editor 与 auditor 互斥的约束
+func (f *sodFixture) createConstraint(t *testing.T, constraintType string) *entity.SoDConstraint {
+	constraint := &entity.SoDConstraint{Name: "编辑与审计分离-" + constraintType, Type: constraintType, RoleIDs: []int64{2, 4}, MaxRoles: 1, Status: 1}
+	require.NoError(t, f.sod.CreateConstraint(constraint))
+	return constraint
+}
+
+// TestSoDService_StaticConstraint 测试静态约束拒绝分配冲突角色，批量分配任一用户冲突时整批不分配
+func TestSoDService_StaticConstraint(t *testing.T) {
+	f := newSoDFixture()
+	f.createConstraint(t, entity.SoDTypeStatic)
+
+	err := f.roles.AssignUserToRole(4, 100, 200)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "编辑与审计分离")
+	assert.Equal(t, []int64{2}, f.roleRepo.userRoles[100], "冲突的角色未分配")
+
+	assert.Error(t, f.roles.BatchAssignUsersToRole(4, []int64{200, 100}, 200))
+	assert.Empty(t, f.roleRepo.userRoles[200], "整批不分配")
+
+	require.NoError(t, f.roles.AssignUserToRole(4, 200, 100))
+	assert.Equal(t, []int64{4}, f.roleRepo.userRoles[200])
+	require.NoError(t, f.roles.AssignUserToRole(1, 100, 200), "约束外的角色不受限制")
+
+	assert.Error(t, f.sod.CheckUserRoles(100, []int64{1, 2, 4}))
+	assert.NoError(t, f.sod.CheckUserRoles(100, []int64{1, 4}))
+}
+
+// TestSoDService_DynamicConstraint 测试动态约束允许同时持有，但同一会话中不能先后激活冲突角色
+func TestSoDService_DynamicConstraint(t *testing.T) {
+	f := newSoDFixture()
+	f.createConstraint(t, entity.SoDTypeDynamic)
+
+	require.NoError(t, f.roles.AssignUserToRole(4, 100, 200), "动态约束不限制持有")
+
+	require.NoError(t, f.sod.ActivateRole(100, "session-1", 2, 0))
+	require.NoError(t, f.sod.ActivateRole(100, "session-1", 1, 2))
+	require.NoError(t, f.sod.ActivateRole(100, "session-1", 2, 1), "重复激活已激活的角色")
+	assert.Error(t, f.sod.ActivateRole(100, "session-1", 4, 2))
+
+	require.NoError(t, f.sod.ActivateRole(100, "session-2", 4, 0), "新会话重新计算")
+	assert.Error(t, f.sod.ActivateRole(100, "session-3", 4, 2), "切换前正在使用的角色视为已激活")
+}
+
+// TestSoDService_Violations 测试约束创建前已存在的冲突分配出现在违规报告中，禁用约束后不再报告
+func TestSoDService_Violations(t *testing.T) {
+	f := newSoDFixture()
+	f.roleRepo.userRoles[100] = []int64{2, 4}
+	f.roleRepo.userRoles[200] = []int64{4}
+	constraint := f.createConstraint(t, entity.SoDTypeStatic)
+	f.createConstraint(t, entity.SoDTypeDynamic)
+
+	violations, err := f.sod.GetViolations()
+	require.NoError(t, err)
+	require.Len(t, violations, 1, "动态约束不产生违规")
+	assert.Equal(t, int64(100), violations[0].UserID)
+	assert.Equal(t, []int64{2, 4}, violations[0].RoleIDs)
+
+	constraint.Status = 0
+	require.NoError(t, f.sod.UpdateConstraint(constraint))
+	violations, err = f.sod.GetViolations()
+	require.NoError(t, err)
+	assert.Empty(t, violations)
+}
+
+// TestSoDService_Validate 测试约束类型、角色集合和上限的校验
+func TestSoDService_Validate(t *testing.T) {
+	tests := []struct {
+		name       string
+		constraint entity.SoDConstraint
+	}{
+		{"名称为空", entity.SoDConstraint{Type: entity.SoDTypeStatic, RoleIDs: []int64{2, 4}, MaxRoles: 1}},
+		{"类型无效", entity.SoDConstraint{Name: "c", Type: "other", RoleIDs: []int64{2, 4}, MaxRoles: 1}},
+		{"去重后不足两个角色", entity.SoDConstraint{Name: "c", Type: entity.SoDTypeStatic, RoleIDs: []int64{2, 2}, MaxRoles: 1}},
+		{"角色不存在", entity.SoDConstraint{Name: "c", Type: entity.SoDTypeStatic, RoleIDs: []int64{2, 99}, MaxRoles: 1}},
+		{"上限不小于角色数", entity.SoDConstraint{Name: "c", Type: entity.SoDTypeStatic, RoleIDs: []int64{2, 4}, MaxRoles: 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newSoDFixture()
+			assert.Error(t, f.sod.CreateConstraint(&tt.constraint))
+		})
+	}
+
+	f := newSoDFixture()
+	f.createConstraint(t, entity.SoDTypeStatic)
+	assert.Error(t, f.sod.CreateConstraint(&entity.SoDConstraint{Name: "编辑与审计分离-static", Type: entity.SoDTypeStatic, RoleIDs: []int64{1, 4}, MaxRoles: 1}), "名称重复")
+}
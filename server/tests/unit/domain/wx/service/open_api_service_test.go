@@ -0,0 +1,508 @@
+package service
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ix-pay/ixpay-pro/internal/config"
+	baseEntity "github.com/ix-pay/ixpay-pro/internal/domain/base/entity"
+	baseRepo "github.com/ix-pay/ixpay-pro/internal/domain/base/repo"
+	"github.com/ix-pay/ixpay-pro/internal/domain/wx/entity"
+	"github.com/ix-pay/ixpay-pro/internal/domain/wx/service"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/observability/logger"
+	"github.com/ix-pay/ixpay-pro/internal/infrastructure/security/apisign"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// MockLogger 日志 Mock 实现
+type MockLogger struct{}
+
+func (m *MockLogger) Debug(msg string, fields ...interface{})  {}
+func (m *MockLogger) Info(msg string, fields ...interface{})   {}
+func (m *MockLogger) Warn(msg string, fields ...interface{})   {}
+func (m *MockLogger) Error(msg string, fields ...interface{})  {}
+func (m *MockLogger) Fatal(msg string, fields ...interface{})  {}
+func (m *MockLogger) With(fields ...interface{}) logger.Logger { return &MockLogger{} }
+func (m *MockLogger) Sync() error                              { return nil }
+
+// MockCache 内存缓存 Mock 实现
+type MockCache struct {
+	data   map[string]string
+	mu     sync.Mutex
+	setErr error
+}
+
+func NewMockCache() *MockCache {
+	return &MockCache{data: make(map[string]string)}
+}
+
+func (m *MockCache) Get(key string) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	v, ok := m.data[key]
+	if !ok {
+		return "", errors.New("key not found")
+	}
+	return v, nil
+}
+
+func (m *MockCache) Set(key string, value interface{}, expiration time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.data[key] = fmt.Sprintf("%v", value)
+	return nil
+}
+
+func (m *MockCache) Delete(key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.data, key)
+	return nil
+}
+
+func (m *MockCache) Exists(key string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	_, ok := m.data[key]
+	return ok, nil
+}
+
+func (m *MockCache) Incr(key string, expiration time.Duration) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	n, _ := strconv.ParseInt(m.data[key], 10, 64)
+	n++
+	m.data[key] = strconv.FormatInt(n, 10)
+	return n, nil
+}
+
+func (m *MockCache) SetNX(key string, value interface{}, expiration time.Duration) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.setErr != nil {
+		return false, m.setErr
+	}
+	if _, ok := m.data[key]; ok {
+		return false, nil
+	}
+	m.data[key] = fmt.Sprintf("%v", value)
+	return true, nil
+}
+
+func (m *MockCache) CompareAndDelete(key string, expected string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if v, ok := m.data[key]; !ok || v != expected {
+		return false, nil
+	}
+	delete(m.data, key)
+	return true, nil
+}
+
+func (m *MockCache) Close() error { return nil }
+
+// MockOpenAppRepository 开放接口应用仓库 Mock 实现
+type MockOpenAppRepository struct {
+	apps   map[int64]*entity.OpenApp
+	nextID int64
+}
+
+func NewMockOpenAppRepository(apps ...*entity.OpenApp) *MockOpenAppRepository {
+	m := &MockOpenAppRepository{apps: make(map[int64]*entity.OpenApp), nextID: 100}
+	for _, app := range apps {
+		m.apps[app.ID] = app
+	}
+	return m
+}
+
+func (m *MockOpenAppRepository) GetByID(id int64) (*entity.OpenApp, error) {
+	app, ok := m.apps[id]
+	if !ok {
+		return nil, errors.New("record not found")
+	}
+	copied := *app
+	return &copied, nil
+}
+
+func (m *MockOpenAppRepository) GetByAppKey(appKey string) (*entity.OpenApp, error) {
+	for _, app := range m.apps {
+		if app.AppKey == appKey {
+			copied := *app
+			return &copied, nil
+		}
+	}
+	return nil, errors.New("record not found")
+}
+
+func (m *MockOpenAppRepository) Create(app *entity.OpenApp) error {
+	m.nextID++
+	app.ID = m.nextID
+	copied := *app
+	m.apps[app.ID] = &copied
+	return nil
+}
+
+func (m *MockOpenAppRepository) Update(app *entity.OpenApp) error {
+	copied := *app
+	m.apps[app.ID] = &copied
+	return nil
+}
+
+func (m *MockOpenAppRepository) Delete(id int64) error {
+	delete(m.apps, id)
+	return nil
+}
+
+func (m *MockOpenAppRepository) List(page, pageSize int, filters map[string]interface{}) ([]*entity.OpenApp, int64, error) {
+	apps := make([]*entity.OpenApp, 0, len(m.apps))
+	for _, app := range m.apps {
+		apps = append(apps, app)
+	}
+	return apps, int64(len(apps)), nil
+}
+
+// MockUserRepository 用户仓库 Mock 实现，只实现 GetByID
+type MockUserRepository struct {
+	baseRepo.UserRepository
+	users map[int64]*baseEntity.User
+}
+
+func (m *MockUserRepository) GetByID(id int64, relations ...baseRepo.UserRelation) (*baseEntity.User, error) {
+	user, ok := m.users[id]
+	if !ok {
+		return nil, errors.New("record not found")
+	}
+	return user, nil
+}
+
+const (
+	testAppKey    = "test-app-key"
+	testAppSecret = "test-app-secret"
+	testUserID    = int64(1001)
+)
+
+// generateRSAKey 生成测试使用的 RSA 密钥和 PEM 公钥
+func generateRSAKey(t *testing.T) (*rsa.PrivateKey, string) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+	return key, string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+}
+
+// signRSA 使用私钥计算 SHA256withRSA 签名
+func signRSA(t *testing.T, key *rsa.PrivateKey, payload string) string {
+	digest := sha256.Sum256([]byte(payload))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	require.NoError(t, err)
+	return base64.StdEncoding.EncodeToString(sig)
+}
+
+// newTestOpenAPIService 创建测试使用的开放接口服务
+func newTestOpenAPIService(t *testing.T, cache *MockCache, apps ...*entity.OpenApp) (*service.OpenAPIService, *MockOpenAppRepository) {
+	platformKey, _ := generateRSAKey(t)
+	repo := NewMockOpenAppRepository(apps...)
+	userRepo := &MockUserRepository{users: map[int64]*baseEntity.User{testUserID: {ID: testUserID}}}
+	cfg := &config.Config{OpenAPI: config.OpenAPIConfig{TimestampTolerance: 300}}
+	svc := service.NewOpenAPIService(repo, userRepo, cache, apisign.NewSigner(platformKey), cfg, &MockLogger{})
+	return svc, repo
+}
+
+// newSignedRequest 构造使用 HMAC 签名的请求
+func newSignedRequest(timestamp time.Time, nonce string, body []byte) *service.OpenAPIRequest {
+	req := &service.OpenAPIRequest{
+		Method:    "POST",
+		Path:      "/api/open/v1/payment",
+		RawQuery:  "b=2&a=1",
+		AppKey:    testAppKey,
+		Timestamp: strconv.FormatInt(timestamp.Unix(), 10),
+		Nonce:     nonce,
+		Body:      body,
+	}
+	payload := apisign.RequestSigningString(req.Method, req.Path, req.RawQuery, req.AppKey, req.Timestamp, req.Nonce, req.Body)
+	req.Signature = apisign.SignHMAC(testAppSecret, payload)
+	return req
+}
+
+func TestOpenAPIService_VerifyRequest(t *testing.T) {
+	body := []byte(`{"order_id":"o-1","amount":1}`)
+
+	tests := []struct {
+		name    string
+		status  int
+		build   func() *service.OpenAPIRequest
+		wantErr string
+	}{
+		{
+			name:   "签名正确",
+			status: entity.OpenAppStatusEnabled,
+			build: func() *service.OpenAPIRequest {
+				return newSignedRequest(time.Now(), "nonce-0000000001", body)
+			},
+		},
+		{
+			name:   "查询参数顺序不影响签名",
+			status: entity.OpenAppStatusEnabled,
+			build: func() *service.OpenAPIRequest {
+				req := newSignedRequest(time.Now(), "nonce-0000000002", body)
+				req.RawQuery = "a=1&b=2"
+				return req
+			},
+		},
+		{
+			name:   "请求体被篡改",
+			status: entity.OpenAppStatusEnabled,
+			build: func() *service.OpenAPIRequest {
+				req := newSignedRequest(time.Now(), "nonce-0000000003", body)
+				req.Body = []byte(`{"order_id":"o-1","amount":100}`)
+				return req
+			},
+			wantErr: "签名校验失败",
+		},
+		{
+			name:   "路径被篡改",
+			status: entity.OpenAppStatusEnabled,
+			build: func() *service.OpenAPIRequest {
+				req := newSignedRequest(time.Now(), "nonce-0000000004", body)
+				req.Path = "/api/open/v1/payment/1"
+				return req
+			},
+			wantErr: "签名校验失败",
+		},
+		{
+			name:   "时间戳过期",
+			status: entity.OpenAppStatusEnabled,
+			build: func() *service.OpenAPIRequest {
+				return newSignedRequest(time.Now().Add(-10*time.Minute), "nonce-0000000005", body)
+			},
+			wantErr: "请求时间戳已过期",
+		},
+		{
+			name:   "时间戳超前",
+			status: entity.OpenAppStatusEnabled,
+			build: func() *service.OpenAPIRequest {
+				return newSignedRequest(time.Now().Add(10*time.Minute), "nonce-0000000006", body)
+			},
+			wantErr: "请求时间戳已过期",
+		},
+		{
+			name:   "随机串过短",
+			status: entity.OpenAppStatusEnabled,
+			build: func() *service.OpenAPIRequest {
+				return newSignedRequest(time.Now(), "short", body)
+			},
+			wantErr: "随机串长度必须为 16 到 64 位",
+		},
+		{
+			name:   "应用不存在",
+			status: entity.OpenAppStatusEnabled,
+			build: func() *service.OpenAPIRequest {
+				req := newSignedRequest(time.Now(), "nonce-0000000007", body)
+				req.AppKey = "unknown"
+				return req
+			},
+			wantErr: "应用不存在",
+		},
+		{
+			name:   "应用已禁用",
+			status: entity.OpenAppStatusDisabled,
+			build: func() *service.OpenAPIRequest {
+				return newSignedRequest(time.Now(), "nonce-0000000008", body)
+			},
+			wantErr: "应用已禁用",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := &entity.OpenApp{ID: 1, AppKey: testAppKey, AppSecret: testAppSecret, SignType: apisign.SignTypeHMAC, UserID: testUserID, Status: tt.status}
+			svc, _ := newTestOpenAPIService(t, NewMockCache(), app)
+
+			got, err := svc.VerifyRequest(tt.build())
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Equal(t, tt.wantErr, err.Error())
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testUserID, got.UserID)
+		})
+	}
+}
+
+func TestOpenAPIService_NonceReplay(t *testing.T) {
+	app := &entity.OpenApp{ID: 1, AppKey: testAppKey, AppSecret: testAppSecret, SignType: apisign.SignTypeHMAC, UserID: testUserID, Status: entity.OpenAppStatusEnabled}
+	cache := NewMockCache()
+	svc, _ := newTestOpenAPIService(t, cache, app)
+
+	req := newSignedRequest(time.Now(), "nonce-replay-0001", nil)
+	_, err := svc.VerifyRequest(req)
+	require.NoError(t, err)
+
+	// 同一请求重放被拒绝
+	_, err = svc.VerifyRequest(req)
+	require.Error(t, err)
+	assert.Equal(t, "随机串已使用，请勿重放请求", err.Error())
+
+	// 签名错误的请求不占用随机串
+	forged := newSignedRequest(time.Now(), "nonce-forged-0001", nil)
+	forged.Signature = apisign.SignHMAC("wrong-secret", "payload")
+	_, err = svc.VerifyRequest(forged)
+	require.Error(t, err)
+	_, err = svc.VerifyRequest(newSignedRequest(time.Now(), "nonce-forged-0001", nil))
+	require.NoError(t, err)
+
+	// 随机串存储不可用时返回服务端错误
+	cache.setErr = errors.New("redis down")
+	_, err = svc.VerifyRequest(newSignedRequest(time.Now(), "nonce-redis-00001", nil))
+	assert.ErrorIs(t, err, service.ErrOpenAPIUnavailable)
+}
+
+func TestOpenAPIService_RSASignature(t *testing.T) {
+	partnerKey, partnerPublicPEM := generateRSAKey(t)
+	app := &entity.OpenApp{ID: 1, AppKey: testAppKey, AppSecret: testAppSecret, SignType: apisign.SignTypeRSA, PublicKey: partnerPublicPEM, UserID: testUserID, Status: entity.OpenAppStatusEnabled}
+	svc, _ := newTestOpenAPIService(t, NewMockCache(), app)
+
+	req := &service.OpenAPIRequest{
+		Method:    "GET",
+		Path:      "/api/open/v1/payment/1",
+		AppKey:    testAppKey,
+		Timestamp: strconv.FormatInt(time.Now().Unix(), 10),
+		Nonce:     "nonce-rsa-000001",
+	}
+	payload := apisign.RequestSigningString(req.Method, req.Path, req.RawQuery, req.AppKey, req.Timestamp, req.Nonce, req.Body)
+
+	// HMAC 签名对 RSA 应用无效
+	req.Signature = apisign.SignHMAC(testAppSecret, payload)
+	_, err := svc.VerifyRequest(req)
+	require.Error(t, err)
+
+	req.Signature = signRSA(t, partnerKey, payload)
+	_, err = svc.VerifyRequest(req)
+	require.NoError(t, err)
+
+	// 响应使用平台私钥签名，合作方使用平台公钥校验
+	responseBody := []byte(`{"code":0}`)
+	sig, err := svc.SignResponse(app, responseBody)
+	require.NoError(t, err)
+	assert.Equal(t, apisign.SignTypeRSA, sig.SignType)
+	responsePayload := apisign.ResponseSigningString(app.AppKey, sig.Timestamp, sig.Nonce, responseBody)
+	assert.True(t, apisign.VerifyRSA(svc.PlatformPublicKey(), responsePayload, sig.Signature))
+	assert.False(t, apisign.VerifyRSA(svc.PlatformPublicKey(), responsePayload, signRSA(t, partnerKey, responsePayload)))
+}
+
+func TestOpenAPIService_SignResponseHMAC(t *testing.T) {
+	app := &entity.OpenApp{ID: 1, AppKey: testAppKey, AppSecret: testAppSecret, SignType: apisign.SignTypeHMAC, UserID: testUserID, Status: entity.OpenAppStatusEnabled}
+	svc, _ := newTestOpenAPIService(t, NewMockCache(), app)
+
+	body := []byte(`{"code":0,"data":{"id":"1"}}`)
+	sig, err := svc.SignResponse(app, body)
+	require.NoError(t, err)
+	assert.NotEmpty(t, sig.Nonce)
+
+	payload := apisign.ResponseSigningString(app.AppKey, sig.Timestamp, sig.Nonce, body)
+	assert.True(t, apisign.VerifyHMAC(testAppSecret, payload, sig.Signature))
+	assert.False(t, apisign.VerifyHMAC(testAppSecret, apisign.ResponseSigningString(app.AppKey, sig.Timestamp, sig.Nonce, []byte(`{}`)), sig.Signature))
+}
+
+func TestOpenAPIService_CreateApp(t *testing.T) {
+	_, partnerPublicPEM := generateRSAKey(t)
+
+	tests := []struct {
+		name    string
+		app     *entity.OpenApp
+		wantErr string
+	}{
+		{
+			name: "HMAC 应用",
+			app:  &entity.OpenApp{Name: "合作方", SignType: apisign.SignTypeHMAC, PublicKey: "ignored", UserID: testUserID, Status: entity.OpenAppStatusEnabled},
+		},
+		{
+			name: "RSA 应用",
+			app:  &entity.OpenApp{Name: "合作方", SignType: apisign.SignTypeRSA, PublicKey: partnerPublicPEM, UserID: testUserID, Status: entity.OpenAppStatusEnabled},
+		},
+		{
+			name:    "名称为空",
+			app:     &entity.OpenApp{Name: " ", SignType: apisign.SignTypeHMAC, UserID: testUserID},
+			wantErr: "应用名称不能为空",
+		},
+		{
+			name:    "签名方式无效",
+			app:     &entity.OpenApp{Name: "合作方", SignType: "MD5", UserID: testUserID},
+			wantErr: "不支持的签名方式",
+		},
+		{
+			name:    "RSA 公钥无效",
+			app:     &entity.OpenApp{Name: "合作方", SignType: apisign.SignTypeRSA, PublicKey: "invalid", UserID: testUserID},
+			wantErr: "RSA 公钥无效：公钥不是有效的 PEM 格式",
+		},
+		{
+			name:    "绑定用户不存在",
+			app:     &entity.OpenApp{Name: "合作方", SignType: apisign.SignTypeHMAC, UserID: 9999},
+			wantErr: "绑定的用户不存在",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, repo := newTestOpenAPIService(t, NewMockCache())
+
+			app, err := svc.CreateApp(tt.app)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Equal(t, tt.wantErr, err.Error())
+				return
+			}
+			require.NoError(t, err)
+			assert.Len(t, app.AppKey, 32)
+			assert.Len(t, app.AppSecret, 64)
+			if app.SignType == apisign.SignTypeHMAC {
+				assert.Empty(t, app.PublicKey)
+			}
+
+			stored, err := repo.GetByAppKey(app.AppKey)
+			require.NoError(t, err)
+			assert.Equal(t, app.AppSecret, stored.AppSecret)
+
+			// 重置密钥后旧密钥失效
+			secret, err := svc.ResetSecret(app.ID, testUserID)
+			require.NoError(t, err)
+			assert.NotEqual(t, app.AppSecret, secret)
+			stored, err = repo.GetByID(app.ID)
+			require.NoError(t, err)
+			assert.Equal(t, secret, stored.AppSecret)
+		})
+	}
+}
+
+func TestCanonicalQuery(t *testing.T) {
+	tests := []struct {
+		name     string
+		rawQuery string
+		want     string
+	}{
+		{name: "空查询串", rawQuery: "", want: ""},
+		{name: "按名称排序", rawQuery: "b=2&a=1", want: "a=1&b=2"},
+		{name: "同名参数按值排序", rawQuery: "a=2&a=1", want: "a=1&a=2"},
+		{name: "空格编码为 %20", rawQuery: "q=a+b", want: "q=a%20b"},
+		{name: "保留字符编码", rawQuery: "q=%E4%B8%AD%2F", want: "q=%E4%B8%AD%2F"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, apisign.CanonicalQuery(tt.rawQuery))
+		})
+	}
+}